@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/bench"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchTargets []string
+	benchSuite   string
+	benchRuns    int
+	benchTimeout time.Duration
+	benchJSON    bool
+	benchOutput  string
+)
+
+// BenchCmd compares providers and models on a prompt suite
+var BenchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark providers and models on a prompt suite",
+	Long: `Run a prompt suite against one or more providers/models and compare
+latency, throughput, token cost and tool-call reliability.
+
+Targets are provider[/model] pairs; the model defaults to the provider's
+default_model. Without --targets the --provider/--model flags (or the
+default provider) are benchmarked.
+
+Measured per target:
+  • TTFT p50        - time to first streamed token
+  • Latency p50/p95 - total time per call
+  • Tok/s           - completion tokens per second after the first token
+  • Tokens in/out   - from provider usage, or estimated with the model's tokenizer
+  • Cost            - from cost_per_1k_input_tokens / cost_per_1k_output_tokens
+                      in the provider config (omitted when not set)
+  • Tool calls      - expected tool called with valid, complete arguments
+  • Checks          - responses containing the case's expected text
+
+The built-in suite covers a short answer, a summary, code generation and a
+tool call. Use --suite to supply your own:
+
+  name: support-triage
+  cases:
+    - name: classify
+      prompt: "Classify this ticket: ..."
+      max_tokens: 50
+      expect: billing
+    - name: lookup
+      prompt: "Find order 1234"
+      tools:
+        - name: get_order
+          description: Look up an order
+          parameters:
+            type: object
+            properties: {id: {type: string}}
+            required: [id]
+      expect_tool: get_order
+
+Calls run one at a time so targets don't skew each other's latency.
+
+Examples:
+  mcp-cli bench --targets openai/gpt-4o-mini,anthropic/claude-3-5-haiku-latest
+  mcp-cli bench --targets ollama/qwen2.5:7b,deepseek --runs 5
+  mcp-cli bench --suite bench/triage.yaml --json -o results.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeBench()
+	},
+}
+
+func init() {
+	BenchCmd.Flags().StringSliceVarP(&benchTargets, "targets", "t", nil, "Providers to compare as provider[/model] (comma-separated)")
+	BenchCmd.Flags().StringVar(&benchSuite, "suite", "", "Prompt suite YAML file (default: built-in suite)")
+	BenchCmd.Flags().IntVar(&benchRuns, "runs", 3, "Repetitions of each case per target")
+	BenchCmd.Flags().DurationVar(&benchTimeout, "timeout", 2*time.Minute, "Timeout per call")
+	BenchCmd.Flags().BoolVarP(&benchJSON, "json", "j", false, "Output results as JSON")
+	BenchCmd.Flags().StringVarP(&benchOutput, "output", "o", "", "Write results to a file instead of stdout")
+}
+
+func executeBench() error {
+	suite := bench.DefaultSuite()
+	if benchSuite != "" {
+		loaded, err := bench.LoadSuite(benchSuite)
+		if err != nil {
+			return domainErrors.Categorize(err, domainErrors.ErrValidation)
+		}
+		suite = loaded
+	}
+
+	specs := benchTargets
+	if len(specs) == 0 {
+		if providerName == "" {
+			return domainErrors.Categorize(fmt.Errorf("no targets: use --targets provider[/model],... or set a default provider"), domainErrors.ErrValidation)
+		}
+		spec := providerName
+		if modelName != "" {
+			spec += "/" + modelName
+		}
+		specs = []string{spec}
+	}
+
+	configService := infraConfig.NewService()
+	if _, err := configService.LoadConfig(configFile); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	aiService := ai.NewService()
+	var targets []bench.Target
+	for _, spec := range specs {
+		// Split on the first slash only; model names may contain slashes
+		provider, model, _ := strings.Cut(strings.TrimSpace(spec), "/")
+		if provider == "" {
+			return domainErrors.Categorize(fmt.Errorf("invalid target '%s': expected provider[/model]", spec), domainErrors.ErrValidation)
+		}
+
+		llm, err := aiService.InitializeProvider(configFile, provider, model)
+		if err != nil {
+			return domainErrors.Categorize(fmt.Errorf("failed to initialize %s: %w", spec, err), domainErrors.ErrProviderFailure)
+		}
+		defer llm.Close()
+
+		providerConfig, _, err := configService.GetProviderConfig(provider)
+		if err != nil {
+			providerConfig = nil
+		}
+		if model == "" && providerConfig != nil {
+			model = providerConfig.DefaultModel
+		}
+
+		targets = append(targets, bench.Target{Provider: provider, Model: model, LLM: llm, Config: providerConfig})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	total := len(targets) * len(suite.Cases) * benchRuns
+	done := 0
+	report := bench.Run(ctx, suite, targets, bench.Options{
+		Runs:    benchRuns,
+		Timeout: benchTimeout,
+		Progress: func(target bench.Target, c bench.Case, run int) {
+			done++
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "\r[%d/%d] %s: %s (run %d)\033[K", done, total, target.Label(), c.Name, run)
+			}
+		},
+	})
+	if !quiet {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+
+	out := os.Stdout
+	if benchOutput != "" {
+		f, err := os.Create(benchOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	var err error
+	if benchJSON {
+		err = bench.WriteJSON(out, report)
+	} else {
+		fmt.Fprintf(out, "Suite: %s (%d cases × %d runs)\n\n", report.Suite, len(suite.Cases), report.Runs)
+		err = bench.WriteTable(out, report)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	if benchOutput != "" {
+		fmt.Fprintf(os.Stderr, "Results written to %s\n", benchOutput)
+	}
+
+	if ctx.Err() != nil {
+		return domainErrors.Categorize(fmt.Errorf("benchmark interrupted"), domainErrors.ErrInterrupted)
+	}
+	return nil
+}
@@ -10,6 +10,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// Chat-specific flags
+var (
+	speakResponses bool
+	speakProvider  string
+)
+
 // ChatCmd represents the unified chat command
 var ChatCmd = &cobra.Command{
 	Use:   "chat",
@@ -65,10 +71,14 @@ func parseChatConfig(_ *cobra.Command, _ []string) *chat.Config {
 		ServerNames:       serverNames,
 		UserSpecified:     userSpecified,
 		SkillNames:        skillNamesSlice,
+		Speak:             speakResponses || speakProvider != "",
+		SpeakProvider:     speakProvider,
+		Quiet:             quiet,
 	}
 }
 
 func init() {
-	// Chat command doesn't need additional flags beyond the global ones
-	// All configuration is handled through global flags and config files
+	// Most configuration is handled through global flags and config files
+	ChatCmd.Flags().BoolVar(&speakResponses, "speak", false, "Read final responses aloud using the tts provider in settings.yaml")
+	ChatCmd.Flags().StringVar(&speakProvider, "speak-provider", "", "TTS provider to use with --speak (default: tts.default_provider)")
 }
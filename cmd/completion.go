@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/spf13/cobra"
+)
+
+// Dynamic shell completion.
+//
+// Cobra's built-in `completion` command generates the bash/zsh/fish/powershell
+// scripts; the functions below supply the values for flags and arguments by
+// reading the configuration named by --config at completion time.
+
+// loadCompletionConfig loads the configuration without creating an example
+// file or printing anything. Returns nil if the config cannot be read.
+func loadCompletionConfig() *config.ApplicationConfig {
+	if _, err := os.Stat(configFile); err != nil {
+		return nil
+	}
+	appConfig, err := infraConfig.NewService().LoadConfig(configFile)
+	if err != nil {
+		return nil
+	}
+	return appConfig
+}
+
+// filterCompletions returns the candidates that start with toComplete, sorted
+func filterCompletions(candidates []string, toComplete string) []string {
+	matches := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if strings.HasPrefix(c, toComplete) {
+			matches = append(matches, c)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// filterListCompletions completes the last element of a comma-separated list
+// such as --server filesystem,brave-search. Names already in the list are skipped.
+func filterListCompletions(candidates []string, toComplete string) []string {
+	prefix := ""
+	current := toComplete
+	used := make(map[string]bool)
+	if idx := strings.LastIndex(toComplete, ","); idx >= 0 {
+		prefix = toComplete[:idx+1]
+		current = toComplete[idx+1:]
+		for _, name := range strings.Split(toComplete[:idx], ",") {
+			used[strings.TrimSpace(name)] = true
+		}
+	}
+
+	matches := make([]string, 0, len(candidates))
+	for _, c := range filterCompletions(candidates, current) {
+		if !used[c] {
+			matches = append(matches, prefix+c)
+		}
+	}
+	return matches
+}
+
+// workflowNames returns the configured workflow names
+func workflowNames() []string {
+	appConfig := loadCompletionConfig()
+	if appConfig == nil {
+		return nil
+	}
+	return appConfig.ListWorkflows()
+}
+
+// providerNames returns the configured AI provider names, from both the
+// provider files (grouped by interface) and the legacy providers map
+func providerNames() []string {
+	appConfig := loadCompletionConfig()
+	if appConfig == nil || appConfig.AI == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, iface := range appConfig.AI.Interfaces {
+		for name := range iface.Providers {
+			add(name)
+		}
+	}
+	for name := range appConfig.AI.Providers {
+		add(name)
+	}
+	return names
+}
+
+// serverNames returns the configured MCP server names
+func serverNames() []string {
+	appConfig := loadCompletionConfig()
+	if appConfig == nil {
+		return nil
+	}
+	names := make([]string, 0, len(appConfig.Servers))
+	for name := range appConfig.Servers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// skillNamesFromDir returns the skill directories (those containing SKILL.md)
+// under config/skills next to the config file. The directory is scanned
+// directly so completion stays fast and silent.
+func skillNamesFromDir() []string {
+	absConfigPath, err := filepath.Abs(configFile)
+	if err != nil {
+		return nil
+	}
+	skillsDir := filepath.Join(filepath.Dir(absConfigPath), "config", "skills")
+
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(skillsDir, entry.Name(), "SKILL.md")); err == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// stepNames returns the step names of the workflow selected with --workflow
+func stepNames() []string {
+	if workflowName == "" {
+		return nil
+	}
+	appConfig := loadCompletionConfig()
+	if appConfig == nil {
+		return nil
+	}
+	wf, exists := appConfig.GetWorkflow(workflowName)
+	if !exists {
+		return nil
+	}
+	names := make([]string, 0, len(wf.Steps))
+	for _, step := range wf.Steps {
+		names = append(names, step.Name)
+	}
+	return names
+}
+
+// promptRefs returns every prompt as name and name@version
+func promptRefs() []string {
+	appConfig := loadCompletionConfig()
+	if appConfig == nil {
+		return nil
+	}
+	var refs []string
+	seen := make(map[string]bool)
+	for _, p := range appConfig.Prompts.List() {
+		if !seen[p.Name] {
+			seen[p.Name] = true
+			refs = append(refs, p.Name)
+		}
+		refs = append(refs, p.Ref())
+	}
+	return refs
+}
+
+func completeWorkflows(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterCompletions(workflowNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProviders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterCompletions(providerNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeServers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterListCompletions(serverNames(), toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+func completeSkills(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterListCompletions(skillNamesFromDir(), toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+func completeSteps(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterCompletions(stepNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completePrompts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(promptRefs(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeLogLevels(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	levels := []string{"error", "warn", "info", "step", "steps", "debug", "verbose", "noisy"}
+	return filterCompletions(levels, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerCompletions wires the dynamic completion functions to the root flags.
+// Persistent flags are inherited by every subcommand, so registering them on
+// RootCmd is enough.
+func registerCompletions() {
+	_ = RootCmd.RegisterFlagCompletionFunc("workflow", completeWorkflows)
+	_ = RootCmd.RegisterFlagCompletionFunc("start-from", completeSteps)
+	_ = RootCmd.RegisterFlagCompletionFunc("end-at", completeSteps)
+	_ = RootCmd.RegisterFlagCompletionFunc("provider", completeProviders)
+	_ = RootCmd.RegisterFlagCompletionFunc("server", completeServers)
+	_ = RootCmd.RegisterFlagCompletionFunc("skills", completeSkills)
+	_ = RootCmd.RegisterFlagCompletionFunc("log-level", completeLogLevels)
+	_ = RootCmd.RegisterFlagCompletionFunc("config", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"yaml", "yml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// testConfigFiles is a modular configuration with two providers, two
+// servers, two workflows, a versioned prompt and three skill directories
+var testConfigFiles = map[string]string{
+	"config.yaml": `includes:
+  providers: config/providers/*.yaml
+  servers: config/servers/*.yaml
+  workflows: config/workflows/*.yaml
+  prompts: config/prompts/*.yaml
+`,
+	"config/providers/openai.yaml": `interface_type: openai_compatible
+provider_name: openai
+config:
+  default_model: gpt-4o
+`,
+	"config/providers/ollama.yaml": `interface_type: ollama_native
+provider_name: ollama
+config:
+  default_model: llama3
+`,
+	"config/servers/filesystem.yaml": `server_name: filesystem
+config:
+  command: mcp-filesystem
+`,
+	"config/servers/github.yaml": `server_name: github
+config:
+  command: mcp-github
+`,
+	"config/workflows/review.yaml": `$schema: workflow/v2.0
+name: review
+version: 1.2.0
+description: Review a pull request
+execution:
+  provider: openai
+  model: gpt-4o
+  servers: [github, jira]
+steps:
+  - name: fetch
+    run: "Read {{input}} and the files under {{ input.path }}"
+    servers: [filesystem]
+    skills: [docx]
+  - name: summarize
+    needs: [fetch]
+    run: "Summarize {{fetch}}"
+`,
+	"config/workflows/release.yaml": `$schema: workflow/v2.0
+name: release
+version: 1.0.0
+description: Draft release notes
+execution:
+  provider: ollama
+steps:
+  - name: draft
+    run: Draft the release notes
+`,
+	"config/prompts/summary.yaml": `name: summary
+version: v2
+template: Summarize the text
+`,
+	"config/skills/docx/SKILL.md":   "# docx",
+	"config/skills/pdf/SKILL.md":    "# pdf",
+	"config/skills/notes/README.md": "not a skill",
+}
+
+// useTestConfig writes testConfigFiles to a temporary directory and points
+// --config at it for the rest of the test
+func useTestConfig(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range testConfigFiles {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previous := configFile
+	configFile = filepath.Join(dir, "config.yaml")
+	t.Cleanup(func() { configFile = previous })
+}
+
+func TestCompletionCandidates(t *testing.T) {
+	useTestConfig(t)
+
+	tests := []struct {
+		name       string
+		complete   func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+		args       []string
+		toComplete string
+		want       []string
+		directive  cobra.ShellCompDirective
+	}{
+		{"workflows", completeWorkflows, nil, "", []string{"release", "review"}, cobra.ShellCompDirectiveNoFileComp},
+		{"workflow prefix", completeWorkflows, nil, "rev", []string{"review"}, cobra.ShellCompDirectiveNoFileComp},
+		{"describe workflow", completeWorkflowArg, nil, "rel", []string{"release"}, cobra.ShellCompDirectiveNoFileComp},
+		{"describe takes one workflow", completeWorkflowArg, []string{"review"}, "", nil, cobra.ShellCompDirectiveNoFileComp},
+		{"providers", completeProviders, nil, "", []string{"ollama", "openai"}, cobra.ShellCompDirectiveNoFileComp},
+		{"provider prefix", completeProviders, nil, "op", []string{"openai"}, cobra.ShellCompDirectiveNoFileComp},
+		{"servers", completeServers, nil, "", []string{"filesystem", "github"}, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace},
+		{"server list", completeServers, nil, "github,", []string{"github,filesystem"}, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace},
+		{"skills", completeSkills, nil, "", []string{"docx", "pdf"}, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace},
+		{"skill list", completeSkills, nil, "pdf,d", []string{"pdf,docx"}, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace},
+		{"prompts", completePrompts, nil, "", []string{"summary", "summary@v2"}, cobra.ShellCompDirectiveNoFileComp},
+		{"prompt takes one name", completePrompts, []string{"summary"}, "", nil, cobra.ShellCompDirectiveNoFileComp},
+		{"log levels", completeLogLevels, nil, "st", []string{"step", "steps"}, cobra.ShellCompDirectiveNoFileComp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, directive := tt.complete(nil, tt.args, tt.toComplete)
+			if len(got) == 0 && len(tt.want) == 0 {
+				got = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("candidates = %q, want %q", got, tt.want)
+			}
+			if directive != tt.directive {
+				t.Errorf("directive = %v, want %v", directive, tt.directive)
+			}
+		})
+	}
+}
+
+func TestCompleteStepsUsesSelectedWorkflow(t *testing.T) {
+	useTestConfig(t)
+	previous := workflowName
+	t.Cleanup(func() { workflowName = previous })
+
+	for workflow, want := range map[string][]string{
+		"review":  {"fetch", "summarize"},
+		"release": {"draft"},
+		"missing": {},
+		"":        {},
+	} {
+		workflowName = workflow
+		if got, _ := completeSteps(nil, nil, ""); !reflect.DeepEqual(got, want) {
+			t.Errorf("--workflow %q: steps = %q, want %q", workflow, got, want)
+		}
+	}
+}
+
+func TestCompletionWithoutConfig(t *testing.T) {
+	previous := configFile
+	configFile = filepath.Join(t.TempDir(), "missing.yaml")
+	t.Cleanup(func() { configFile = previous })
+
+	for name, complete := range map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		"workflows": completeWorkflows,
+		"providers": completeProviders,
+		"servers":   completeServers,
+		"skills":    completeSkills,
+		"prompts":   completePrompts,
+	} {
+		if got, _ := complete(nil, nil, ""); len(got) != 0 {
+			t.Errorf("%s without a config = %q, want none", name, got)
+		}
+	}
+	if _, err := os.Stat(configFile); !os.IsNotExist(err) {
+		t.Errorf("completion created %s", configFile)
+	}
+}
@@ -12,13 +12,16 @@ var ConfigCmd = &cobra.Command{
 
 Available subcommands:
   validate - Validate configuration file and check for security issues
+  export   - Export a shareable bundle with secrets replaced by ${VAR} placeholders
 
 Examples:
   mcp-cli config validate
-  mcp-cli config validate --config custom-config.yaml`,
+  mcp-cli config validate --config custom-config.yaml
+  mcp-cli config export --redacted`,
 }
 
 func init() {
 	// Add subcommands
 	ConfigCmd.AddCommand(ConfigValidateCmd)
+	ConfigCmd.AddCommand(ConfigExportCmd)
 }
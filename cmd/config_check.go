@@ -4,14 +4,19 @@ import (
 	"fmt"
 	"os"
 
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/fatih/color"
 )
 
+// skipConfigCheckAnnotation marks commands that work without a config file
+// (set it to "true" in the command's Annotations)
+const skipConfigCheckAnnotation = "skip-config-check"
+
 // checkConfigExists checks if the configuration file exists and shows a helpful error if not
 func checkConfigExists(configPath string) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		printNoConfigError()
-		os.Exit(1)
+		exitWithError(domainErrors.Categorize(err, domainErrors.ErrValidation), 1)
 	}
 }
 
@@ -35,6 +40,7 @@ func printNoConfigError() {
 
 	infoColor.Fprintln(os.Stderr, "Need help?")
 	fmt.Fprintln(os.Stderr, "  ./mcp-cli init --help")
+	fmt.Fprintln(os.Stderr, "  ./mcp-cli doctor             (check the whole setup)")
 	fmt.Fprintln(os.Stderr)
 }
 
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportRedacted bool
+	exportOutput   string
+)
+
+// ConfigExportCmd bundles the configuration for sharing
+var ConfigExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a shareable configuration bundle",
+	Long: `Export the main config file and everything it includes (providers, servers,
+workflows, settings, ...) as a single bundle for debugging or issue reports.
+
+With --redacted (the default), API keys, tokens, passwords and credentials
+embedded in endpoint URLs are replaced by ${VAR} placeholders, e.g.
+${OPENAI_API_KEY}. Values that already reference the environment are left
+as-is. The .env file and skill directories are never included.
+
+The bundle also contains REDACTIONS.txt listing which fields were replaced
+(never the original values).
+
+Output ending in .tar.gz or .tgz is written as an archive; any other path is
+treated as a directory.
+
+Examples:
+  mcp-cli config export --redacted
+  mcp-cli config export -o issue-1234.tar.gz
+  mcp-cli config export -o ./shared-config`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeConfigExport()
+	},
+}
+
+func init() {
+	ConfigExportCmd.Flags().BoolVar(&exportRedacted, "redacted", true, "Replace secrets with ${VAR} placeholders")
+	ConfigExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output archive (.tar.gz) or directory (default: mcp-cli-config-<timestamp>.tar.gz)")
+}
+
+// exportFile is one file in the bundle
+type exportFile struct {
+	name string // Slash-separated path inside the bundle
+	data []byte
+}
+
+func executeConfigExport() error {
+	absConfig, err := filepath.Abs(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	baseDir := filepath.Dir(absConfig)
+
+	paths, err := config.NewLoader().ConfigFiles(absConfig)
+	if err != nil {
+		return err
+	}
+
+	var files []exportFile
+	var report strings.Builder
+	redactedCount := 0
+
+	for _, path := range paths {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		name := exportName(baseDir, path)
+		if exportRedacted {
+			redacted, redactions, err := infraConfig.RedactYAML(data, path)
+			if err != nil {
+				return err
+			}
+			data = redacted
+			for _, r := range redactions {
+				fmt.Fprintf(&report, "%s: %s → %s (%s)\n", name, r.Path, r.Placeholder, r.Reason)
+			}
+			redactedCount += len(redactions)
+		}
+		files = append(files, exportFile{name: name, data: data})
+	}
+
+	if exportRedacted {
+		header := fmt.Sprintf("# mcp-cli config export (%s)\n# %d value(s) replaced by environment placeholders.\n# Set these variables (e.g. in .env) to use this configuration.\n\n",
+			time.Now().Format(time.RFC3339), redactedCount)
+		files = append(files, exportFile{name: "REDACTIONS.txt", data: []byte(header + report.String())})
+	}
+
+	output := exportOutput
+	if output == "" {
+		output = fmt.Sprintf("mcp-cli-config-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+
+	if isArchivePath(output) {
+		err = writeExportArchive(output, files)
+	} else {
+		err = writeExportDir(output, files)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Exported %d file(s) to %s\n", len(files), output)
+	if exportRedacted {
+		fmt.Fprintf(os.Stderr, "  %d secret(s) replaced with placeholders (see REDACTIONS.txt)\n", redactedCount)
+		fmt.Fprintln(os.Stderr, "  Review the bundle before sharing: redaction is pattern-based.")
+	} else {
+		color.New(color.FgYellow).Fprintln(os.Stderr, "⚠️  Exported without redaction; the bundle may contain secrets")
+	}
+	return nil
+}
+
+// exportName maps a file to its path inside the bundle. Files outside the
+// config directory are placed under external/.
+func exportName(baseDir, path string) string {
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "external/" + filepath.Base(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func isArchivePath(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+func writeExportDir(dir string, files []exportFile) error {
+	for _, f := range files {
+		target := filepath.Join(dir, filepath.FromSlash(f.name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(target, f.data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+func writeExportArchive(path string, files []exportFile) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, f := range files {
+		header := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.data)), ModTime: now}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+	"golang.org/x/term"
+)
+
+// Cost estimate flags, shared by workflows and the embeddings command
+var (
+	estimateOnly bool // --estimate: print the estimate and don't run
+	assumeYes    bool // --yes: run without asking, whatever the estimate
+)
+
+// checkCostEstimate prints the estimate with --estimate, and otherwise asks
+// before running when it exceeds cost_estimate.confirm_above. It returns
+// false when the run shouldn't go ahead; err says why if it was refused.
+func checkCostEstimate(estimate *workflow.CostEstimate, settings *config.CostEstimateConfig) (bool, error) {
+	if estimateOnly {
+		estimate.Print(os.Stdout)
+		return false, nil
+	}
+
+	threshold := settings.ConfirmThreshold()
+	if threshold == 0 || estimate.Total <= threshold || assumeYes {
+		return true, nil
+	}
+
+	estimate.Print(os.Stderr)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, domainErrors.Categorize(fmt.Errorf("estimated cost ~$%.2f is above cost_estimate.confirm_above ($%.2f); rerun with --yes to go ahead",
+			estimate.Total, threshold), domainErrors.ErrValidation)
+	}
+	question := fmt.Sprintf("Estimated cost ~$%.2f is above $%.2f. Continue?", estimate.Total, threshold)
+	if !askYesNo(bufio.NewReader(os.Stdin), question, false) {
+		return false, fmt.Errorf("cancelled: estimated cost ~$%.2f", estimate.Total)
+	}
+	return true, nil
+}
@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/builtintools"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/progress"
+	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolselect"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/daemon"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/query"
+	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
+	"github.com/spf13/cobra"
+)
+
+// noDaemon disables forwarding commands to a running daemon
+var noDaemon bool
+
+// daemonQueryTimeout matches the in-process command timeout
+const daemonQueryTimeout = 30 * time.Minute
+
+// DaemonCmd keeps servers, providers and skills warm between invocations
+var DaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run a background daemon that keeps MCP servers warm",
+	Long: `Run mcp-cli as a long-lived daemon for fast repeated invocations.
+
+The daemon starts every configured MCP server once, loads skill metadata and
+keeps provider clients open. While it is running, "mcp-cli query" connects to
+it over a unix socket (AF_UNIX, also used on Windows 10+) instead of starting
+servers itself. Output, exit codes and flags are unchanged.
+
+One daemon serves one config file; the socket lives in $XDG_RUNTIME_DIR (or
+the temp directory) and is only accessible to the current user. Set
+MCP_CLI_DAEMON_SOCKET to choose the path explicitly. Restart the daemon after
+changing server or provider configuration.
+
+Use --no-daemon on any command to bypass a running daemon.
+
+Examples:
+  mcp-cli daemon &                    # start (runs in the foreground)
+  mcp-cli query "What time is it?"   # served by the daemon
+  mcp-cli query --no-daemon "..."    # run in-process
+  mcp-cli daemon status
+  mcp-cli daemon stop`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDaemon()
+	},
+}
+
+// DaemonStatusCmd reports on a running daemon
+var DaemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether a daemon is running for this config",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := daemon.SocketPath(configFile)
+		resp, err := daemon.Send(path, &daemon.Request{Type: daemon.RequestStatus}, 5*time.Second)
+		if err != nil {
+			fmt.Printf("No daemon running (socket: %s)\n", path)
+			return nil
+		}
+		if err := resp.Err(); err != nil {
+			return err
+		}
+
+		status := resp.Status
+		fmt.Printf("Daemon running (pid %d)\n", status.PID)
+		fmt.Printf("  Socket:    %s\n", path)
+		fmt.Printf("  Config:    %s\n", status.ConfigFile)
+		fmt.Printf("  Uptime:    %s\n", time.Since(status.StartedAt).Round(time.Second))
+		fmt.Printf("  Servers:   %d %v\n", len(status.Servers), status.Servers)
+		fmt.Printf("  Skills:    %d\n", status.Skills)
+		fmt.Printf("  Providers: %v\n", status.Providers)
+		fmt.Printf("  Requests:  %d\n", status.Requests)
+		return nil
+	},
+}
+
+// DaemonStopCmd asks a running daemon to exit
+var DaemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the daemon for this config",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := daemon.SocketPath(configFile)
+		if _, err := daemon.Send(path, &daemon.Request{Type: daemon.RequestShutdown}, 5*time.Second); err != nil {
+			fmt.Println("No daemon running")
+			return nil
+		}
+		fmt.Println("✓ Daemon stopped")
+		return nil
+	},
+}
+
+func init() {
+	DaemonCmd.AddCommand(DaemonStatusCmd)
+	DaemonCmd.AddCommand(DaemonStopCmd)
+}
+
+func runDaemon() error {
+	state, err := newWarmState(configFile)
+	if err != nil {
+		return err
+	}
+	defer state.close()
+
+	path := daemon.SocketPath(configFile)
+	server, err := daemon.Listen(path, state)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	status := state.Status()
+	fmt.Fprintf(os.Stderr, "✓ Daemon listening on %s (%d server(s), %d skill(s))\n", path, len(status.Servers), status.Skills)
+	logging.Info("Daemon started: pid=%d socket=%s", status.PID, path)
+
+	err = server.Serve(ctx)
+	logging.Info("Daemon stopped")
+	return err
+}
+
+// warmState holds the long-lived resources a daemon serves requests from
+type warmState struct {
+	configFile string
+	startedAt  time.Time
+
+	// mu serializes queries: stdio server connections and provider clients
+	// are shared and not safe for interleaved conversations
+	mu            sync.Mutex
+	manager       *host.ServerManager
+	skills        *skillsvc.Service
+	builtins      *config.BuiltinToolsConfig
+	toolSelection *config.ToolSelectionConfig
+	iterations    *config.ToolIterationsConfig
+	providers     map[string]domain.LLMProvider
+	requests      int64
+}
+
+func newWarmState(configFile string) (*warmState, error) {
+	appConfig, err := infraConfig.NewService().LoadConfig(configFile)
+	if err != nil {
+		return nil, domainErrors.Categorize(fmt.Errorf("failed to load configuration: %w", err), domainErrors.ErrValidation)
+	}
+
+	state := &warmState{
+		configFile:    configFile,
+		startedAt:     time.Now(),
+		manager:       host.NewServerManagerWithOptions(!verbose),
+		builtins:      appConfig.BuiltinTools,
+		toolSelection: appConfig.ToolSelection,
+		iterations:    appConfig.ToolIterations,
+		providers:     make(map[string]domain.LLMProvider),
+	}
+
+	serverNames, _ := ProcessOptions(configFile, "", false, "", "")
+	externalServers, _ := infraSkills.SeparateSkillsFromServers(serverNames)
+	if len(externalServers) > 0 {
+		if err := state.manager.ConnectToServers(configFile, externalServers, map[string]bool{}); err != nil {
+			logging.Warn("Daemon: %v", err)
+		}
+	}
+
+	// Skill metadata is cheap to keep and slow to rediscover
+	if skillService, err := infraSkills.InitializeBuiltinSkills(configFile, appConfig); err != nil {
+		logging.Warn("Daemon: built-in skills unavailable: %v", err)
+	} else {
+		state.skills = skillService
+	}
+
+	// Warm the default provider; others are created on first use
+	if _, err := state.provider("", ""); err != nil {
+		logging.Warn("Daemon: default provider not initialized: %v", err)
+	}
+
+	return state, nil
+}
+
+func (s *warmState) close() {
+	s.manager.CloseConnections()
+}
+
+// provider returns a cached provider client for the provider/model pair
+func (s *warmState) provider(providerName, modelName string) (domain.LLMProvider, error) {
+	key := providerName + "/" + modelName
+	if llm, ok := s.providers[key]; ok {
+		return llm, nil
+	}
+	llm, err := ai.NewService().InitializeProvider(s.configFile, providerName, modelName)
+	if err != nil {
+		return nil, err
+	}
+	s.providers[key] = llm
+	return llm, nil
+}
+
+// connections returns warm connections for the named servers, starting any
+// that are not running yet so later requests reuse them
+func (s *warmState) connections(names []string) []*host.ServerConnection {
+	var conns []*host.ServerConnection
+	var appConfig *config.ApplicationConfig
+
+	for _, name := range names {
+		if conn, err := s.manager.GetConnection(name); err == nil {
+			conns = append(conns, conn)
+			continue
+		}
+
+		if appConfig == nil {
+			loaded, err := infraConfig.NewService().LoadConfig(s.configFile)
+			if err != nil {
+				logging.Warn("Daemon: failed to reload configuration: %v", err)
+				return conns
+			}
+			appConfig = loaded
+		}
+		serverConfig, ok := appConfig.Servers[name]
+		if !ok {
+			logging.Warn("Daemon: server configuration not found for %s", name)
+			continue
+		}
+		conn, err := s.manager.ConnectToServer(name, serverConfig, true)
+		if err != nil {
+			logging.Warn("Daemon: failed to connect to server %s: %v", name, err)
+			continue
+		}
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// Query runs a query against the warm servers and providers
+func (s *warmState) Query(ctx context.Context, req *daemon.QueryRequest) *daemon.Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	started := time.Now()
+	logging.Info("Daemon: query (provider=%q model=%q servers=%v)", req.Provider, req.Model, req.Servers)
+
+	llmProvider, err := s.provider(req.Provider, req.Model)
+	if err != nil {
+		return daemon.ErrorResponse(domainErrors.Categorize(fmt.Errorf("failed to initialize AI provider: %w", err), domainErrors.ErrProviderFailure), query.ErrInitializationCode)
+	}
+
+	enhancedAIOptions, err := host.GetEnhancedAIOptions(s.configFile, req.Provider, req.Model)
+	if err != nil {
+		return daemon.ErrorResponse(fmt.Errorf("error loading enhanced AI options: %w", err), query.ErrConfigNotFoundCode)
+	}
+	aiOptions := &host.AIOptions{
+		Provider:      enhancedAIOptions.Provider,
+		Model:         enhancedAIOptions.Model,
+		APIKey:        enhancedAIOptions.APIKey,
+		APIEndpoint:   enhancedAIOptions.APIEndpoint,
+		InterfaceType: enhancedAIOptions.Interface,
+	}
+	if req.Provider != "" {
+		aiOptions.Provider = req.Provider
+	}
+	if req.Model != "" {
+		aiOptions.Model = req.Model
+	}
+
+	externalServers, needsSkills := infraSkills.SeparateSkillsFromServers(req.Servers)
+	var serverManager domain.MCPServerManager = infraSkills.NewHostServerManager(s.connections(externalServers))
+	if needsSkills && s.skills != nil {
+		serverManager = infraSkills.NewSkillsAwareServerManager(serverManager, s.skills)
+	}
+	serverManager = builtintools.Wrap(serverManager, s.builtins)
+	serverManager = toolselect.Wrap(serverManager, s.configFile, s.toolSelection)
+
+	handler := query.NewQueryHandlerWithServerManager(serverManager, llmProvider, aiOptions, req.SystemPrompt)
+	handler.SetMaxFollowUpAttempts(s.iterations.QueryLimit())
+	handler.MaxRepeatedToolCalls = s.iterations.RepeatLimit()
+	handler.MaxToolCallRepairs = s.iterations.RepairLimit()
+	if req.Context != "" {
+		handler.AddContext(req.Context)
+	}
+	handler.AddContextBundle(req.ContextBundle)
+	if err := handler.AddContextSources(ctx, req.Question, req.ContextSources, req.ContextOptions); err != nil {
+		return daemon.ErrorResponse(err, query.GetExitCode(err))
+	}
+	if req.MaxTokens > 0 {
+		handler.SetMaxTokens(req.MaxTokens)
+	}
+
+	result, err := handler.Execute(req.Question)
+	if err != nil {
+		return daemon.ErrorResponse(fmt.Errorf("query failed: %w", err), query.GetExitCode(err))
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return daemon.ErrorResponse(fmt.Errorf("failed to encode result: %w", err), query.ErrOutputFormatCode)
+	}
+	logging.Info("Daemon: query completed in %s", time.Since(started).Round(time.Millisecond))
+	return &daemon.Response{Result: data}
+}
+
+// Status reports what the daemon is keeping warm
+func (s *warmState) Status() *daemon.Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := &daemon.Status{
+		PID:        os.Getpid(),
+		ConfigFile: s.configFile,
+		StartedAt:  s.startedAt,
+		Requests:   s.requests,
+	}
+	for _, conn := range s.manager.GetConnections() {
+		status.Servers = append(status.Servers, conn.Name)
+	}
+	if s.skills != nil {
+		status.Skills = len(s.skills.ListSkills())
+	}
+	for key := range s.providers {
+		status.Providers = append(status.Providers, key)
+	}
+	sort.Strings(status.Servers)
+	sort.Strings(status.Providers)
+	return status
+}
+
+// queryViaDaemon forwards a query to a daemon serving the current config.
+// handled is false when no daemon is running (or --no-daemon is set), in
+// which case the caller runs the query in-process.
+func queryViaDaemon(req *daemon.QueryRequest) (result *query.QueryResult, handled bool, err error) {
+	// Progress events are only emitted by in-process runs
+	if noDaemon || progress.Enabled() {
+		return nil, false, nil
+	}
+
+	path := daemon.SocketPath(configFile)
+	if _, statErr := os.Stat(path); statErr != nil {
+		return nil, false, nil
+	}
+
+	resp, err := daemon.Send(path, &daemon.Request{Type: daemon.RequestQuery, ConfigFile: configFile, Query: req}, daemonQueryTimeout)
+	if errors.Is(err, daemon.ErrUnavailable) {
+		logging.Debug("Daemon unavailable, running in-process: %v", err)
+		return nil, false, nil
+	}
+	if err != nil {
+		// The request may already be running; don't execute it twice
+		return nil, true, err
+	}
+	logging.Debug("Query served by daemon at %s", path)
+
+	if err := resp.Err(); err != nil {
+		return nil, true, err
+	}
+
+	result = &query.QueryResult{}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return nil, true, fmt.Errorf("invalid daemon result: %w", err)
+	}
+	return result, true, nil
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// DescribeCmd prints detailed information about configured resources
+var DescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show details of a workflow",
+	Long: `Show detailed information about a configured resource.
+
+Examples:
+  mcp-cli describe workflow analyze`,
+}
+
+// describeWorkflowCmd prints the steps, inputs and required servers of a workflow
+var describeWorkflowCmd = &cobra.Command{
+	Use:               "workflow <name>",
+	Short:             "Show a workflow's steps, inputs and required servers",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorkflowArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeDescribeWorkflow(args[0])
+	},
+}
+
+func init() {
+	DescribeCmd.AddCommand(describeWorkflowCmd)
+}
+
+// completeWorkflowArg completes the single workflow name argument
+func completeWorkflowArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeWorkflows(cmd, args, toComplete)
+}
+
+// variablePattern matches {{variable}} references in prompts
+var variablePattern = regexp.MustCompile(`\{\{([^}]+)\}\}`)
+
+// executeDescribeWorkflow prints a human-readable summary of a workflow
+func executeDescribeWorkflow(name string) error {
+	configService := infraConfig.NewService()
+	appConfig, err := configService.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	wf, exists := appConfig.GetWorkflow(name)
+	if !exists {
+		return domainErrors.Categorize(fmt.Errorf("workflow '%s' not found. Available workflows: %v", name, appConfig.ListWorkflows()), domainErrors.ErrValidation)
+	}
+
+	heading := color.New(color.FgCyan, color.Bold).SprintFunc()
+	label := color.New(color.FgYellow).SprintFunc()
+	stepColor := color.New(color.FgGreen).SprintFunc()
+
+	fmt.Printf("%s %s\n", heading("Workflow:"), name)
+	if wf.Version != "" {
+		fmt.Printf("%s %s\n", label("Version:"), wf.Version)
+	}
+	if wf.Description != "" {
+		fmt.Printf("%s %s\n", label("Description:"), strings.TrimSpace(wf.Description))
+	}
+
+	// Execution defaults
+	fmt.Println()
+	fmt.Println(heading("Execution:"))
+	if len(wf.Execution.Providers) > 0 {
+		chain := make([]string, 0, len(wf.Execution.Providers))
+		for _, p := range wf.Execution.Providers {
+			chain = append(chain, formatProviderModel(p.Provider, p.Model))
+		}
+		fmt.Printf("  %s %s\n", label("Providers:"), strings.Join(chain, " -> "))
+	} else if wf.Execution.Provider != "" {
+		fmt.Printf("  %s %s\n", label("Provider:"), formatProviderModel(wf.Execution.Provider, wf.Execution.Model))
+	}
+	if wf.Execution.Parallel {
+		workers := wf.Execution.MaxWorkers
+		if workers == 0 {
+			workers = 3
+		}
+		fmt.Printf("  %s enabled (max_workers: %d)\n", label("Parallel:"), workers)
+	}
+	if wf.Execution.Timeout > 0 {
+		fmt.Printf("  %s %s\n", label("Timeout:"), wf.Execution.Timeout)
+	}
+
+	// Inputs
+	fmt.Println()
+	fmt.Println(heading("Inputs:"))
+	inputs := collectWorkflowInputs(wf)
+	if len(inputs) == 0 {
+		fmt.Println("  (none - workflow does not reference {{input}})")
+	}
+	for _, input := range inputs {
+		fmt.Printf("  {{%s}}\n", input)
+	}
+	if len(wf.Env) > 0 {
+		keys := make([]string, 0, len(wf.Env))
+		for k := range wf.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Printf("  %s %s\n", label("Env:"), strings.Join(keys, ", "))
+	}
+	if len(wf.Params) > 0 {
+		names := make([]string, 0, len(wf.Params))
+		for name := range wf.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("  %s\n", label("Params (--param):"))
+		for _, name := range names {
+			param := wf.Params[name]
+			detail := ""
+			if param.Required {
+				detail = " (required)"
+			} else if param.Default != nil {
+				detail = fmt.Sprintf(" (default: %v)", param.Default)
+			}
+			fmt.Printf("    {{params.%s}}%s", name, detail)
+			if param.Description != "" {
+				fmt.Printf(" - %s", param.Description)
+			}
+			fmt.Println()
+		}
+	}
+	if wf.Matrix != nil {
+		combinations, err := wf.Matrix.Combinations()
+		if err != nil {
+			fmt.Printf("  %s %s\n", label("Matrix:"), color.RedString(err.Error()))
+		} else {
+			fmt.Printf("  %s %d runs\n", label("Matrix:"), len(combinations))
+			for _, combination := range combinations {
+				fmt.Printf("    - %s\n", config.MatrixLabel(combination))
+			}
+		}
+	}
+
+	// Required servers and skills
+	servers := workflow.RequiredServers(wf, appConfig.RAG)
+	skills := workflow.RequiredSkills(wf)
+
+	fmt.Println()
+	fmt.Println(heading("Requires:"))
+	if len(servers) == 0 && len(skills) == 0 {
+		fmt.Println("  (no MCP servers or skills)")
+	}
+	for _, server := range servers {
+		status := ""
+		if _, ok := appConfig.Servers[server]; !ok {
+			status = color.RedString(" (not configured)")
+		}
+		fmt.Printf("  %s %s%s\n", label("server:"), server, status)
+	}
+	for _, skill := range skills {
+		fmt.Printf("  %s %s\n", label("skill:"), skill)
+	}
+
+	// Steps
+	fmt.Println()
+	fmt.Printf("%s %d\n", heading("Steps:"), len(wf.Steps))
+	for i, step := range wf.Steps {
+		fmt.Printf("  %d. %s [%s]\n", i+1, stepColor(step.Name), stepMode(&step))
+		if step.Provider != "" {
+			fmt.Printf("       %s %s\n", label("provider:"), formatProviderModel(step.Provider, step.Model))
+		}
+		if len(step.Needs) > 0 {
+			fmt.Printf("       %s %s\n", label("needs:"), strings.Join(step.Needs, ", "))
+		}
+		if len(step.Servers) > 0 {
+			fmt.Printf("       %s %s\n", label("servers:"), strings.Join(step.Servers, ", "))
+		}
+		if len(step.Skills) > 0 {
+			fmt.Printf("       %s %s\n", label("skills:"), strings.Join(step.Skills, ", "))
+		}
+		if step.If != "" {
+			fmt.Printf("       %s %s\n", label("if:"), step.If)
+		}
+		if step.Loop != nil {
+			fmt.Printf("       %s %s\n", label("calls:"), step.Loop.Workflow)
+		}
+		if step.Template != nil {
+			fmt.Printf("       %s %s\n", label("calls:"), step.Template.Name)
+		}
+	}
+
+	if len(wf.Loops) > 0 {
+		fmt.Println()
+		fmt.Printf("%s %d\n", heading("Loops:"), len(wf.Loops))
+		for _, loop := range wf.Loops {
+			mode := loop.Mode
+			if mode == "" {
+				mode = "refine"
+			}
+			fmt.Printf("  - %s -> %s (%s, max %d iterations)\n", stepColor(loop.Name), loop.Workflow, mode, loop.MaxIterations)
+		}
+	}
+
+	return nil
+}
+
+// formatProviderModel renders "provider/model", omitting an empty model
+func formatProviderModel(provider, model string) string {
+	if model == "" {
+		return provider
+	}
+	return provider + "/" + model
+}
+
+// stepMode returns the execution mode name of a step, matching the mode
+// keys used in workflow YAML
+func stepMode(step *config.StepV2) string {
+	switch {
+	case step.Template != nil:
+		return "template"
+	case step.Loop != nil:
+		return "loop"
+	case step.Embeddings != nil:
+		return "embeddings"
+	case step.Consensus != nil:
+		return "consensus"
+	case step.Rag != nil:
+		return "rag"
+	case step.SQL != nil:
+		return "sql"
+	case step.Storage != nil:
+		return "storage"
+	case step.Notify != nil:
+		return "notify"
+	case step.LogAnalytics != nil:
+		return "log_analytics"
+	case step.GraphSecurity != nil:
+		return "graph_security"
+	case step.LoadTable != nil:
+		return "load_table"
+	case step.Render != nil:
+		return "render"
+	case step.TTS != nil:
+		return "tts"
+	case step.Compare != nil:
+		return "compare"
+	case step.Debate != nil:
+		return "debate"
+	case step.Guard != nil:
+		return "guard"
+	case step.Verify != nil:
+		return "verify"
+	default:
+		return "run"
+	}
+}
+
+// collectWorkflowInputs returns the {{input}} and {{input.field}} variables
+// referenced anywhere in the workflow's step prompts
+func collectWorkflowInputs(wf *config.WorkflowV2) []string {
+	seen := make(map[string]bool)
+	scan := func(text string) {
+		for _, match := range variablePattern.FindAllStringSubmatch(text, -1) {
+			name := strings.TrimSpace(match[1])
+			if name == "input" || strings.HasPrefix(name, "input.") {
+				seen[name] = true
+			}
+		}
+	}
+
+	for _, step := range wf.Steps {
+		scan(step.Run)
+		for _, v := range step.PromptArgs {
+			if s, ok := v.(string); ok {
+				scan(s)
+			}
+		}
+		if s, ok := step.Input.(string); ok {
+			scan(s)
+		}
+		if step.Consensus != nil {
+			scan(step.Consensus.Prompt)
+		}
+		if step.Compare != nil {
+			scan(step.Compare.Prompt)
+		}
+		if step.Debate != nil {
+			scan(step.Debate.Question)
+		}
+		if step.Guard != nil {
+			scan(step.Guard.Input)
+		}
+		if step.Verify != nil {
+			scan(step.Verify.Command)
+			for _, content := range step.Verify.Files {
+				scan(content)
+			}
+		}
+		if step.Loop != nil {
+			for _, v := range step.Loop.With {
+				if s, ok := v.(string); ok {
+					scan(s)
+				}
+			}
+		}
+	}
+	for _, loop := range wf.Loops {
+		for _, v := range loop.With {
+			if s, ok := v.(string); ok {
+				scan(s)
+			}
+		}
+	}
+
+	inputs := make([]string, 0, len(seen))
+	for name := range seen {
+		inputs = append(inputs, name)
+	}
+	sort.Strings(inputs)
+	return inputs
+}
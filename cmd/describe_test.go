@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/fatih/color"
+)
+
+// captureStdout returns what fn prints to stdout, without color
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, noColor := os.Stdout, color.NoColor
+	os.Stdout, color.NoColor = w, true
+	defer func() { os.Stdout, color.NoColor = stdout, noColor }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	fnErr := fn()
+	w.Close()
+	return <-done, fnErr
+}
+
+func TestDescribeWorkflow(t *testing.T) {
+	useTestConfig(t)
+
+	tests := []struct {
+		workflow string
+		want     []string
+		notWant  []string
+	}{
+		{
+			workflow: "review",
+			want: []string{
+				"Workflow: review\nVersion: 1.2.0\nDescription: Review a pull request\n",
+				"  Provider: openai/gpt-4o\n",
+				"Inputs:\n  {{input}}\n  {{input.path}}\n",
+				"Requires:\n  server: filesystem\n  server: github\n  server: jira (not configured)\n  skill: docx\n",
+				"Steps: 2\n  1. fetch [run]\n       servers: filesystem\n       skills: docx\n  2. summarize [run]\n       needs: fetch\n",
+			},
+		},
+		{
+			workflow: "release",
+			want: []string{
+				"  Provider: ollama\n",
+				"  (none - workflow does not reference {{input}})\n",
+				"Requires:\n  (no MCP servers or skills)\n",
+				"Steps: 1\n  1. draft [run]\n",
+			},
+			notWant: []string{"server:", "skill:", "Loops:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.workflow, func(t *testing.T) {
+			out, err := captureStdout(t, func() error { return executeDescribeWorkflow(tt.workflow) })
+			if err != nil {
+				t.Fatalf("describe: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out, notWant) {
+					t.Errorf("output has %q:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeUnknownWorkflow(t *testing.T) {
+	useTestConfig(t)
+
+	_, err := captureStdout(t, func() error { return executeDescribeWorkflow("deploy") })
+	if err == nil || !strings.Contains(err.Error(), "workflow 'deploy' not found") {
+		t.Fatalf("error = %v, want workflow not found", err)
+	}
+	if !errors.Is(err, domainErrors.ErrValidation) {
+		t.Errorf("error = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/doctor"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorJSON          bool
+	doctorSkipProviders bool
+	doctorSkipServers   bool
+	doctorTimeout       time.Duration
+)
+
+// DoctorCmd checks the whole setup and lists what to fix
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check config, providers, servers, sandbox and skills, and list fixes",
+	Long: `Checks everything mcp-cli needs and prints one list of fixes, most
+important first:
+
+  - The config file, its values and every workflow
+  - Each provider, with a one-line ping completion (a few tokens each)
+  - Each MCP server: starts it, lists its tools and stops it
+  - The outputs directory is writable
+  - The Docker/Podman sandbox skills run scripts in (see 'sandbox doctor')
+  - The skills directory and which skills have scripts
+
+Exits non-zero when any check fails.
+
+Examples:
+  mcp-cli doctor
+  mcp-cli doctor --skip-providers        # no completions sent
+  mcp-cli doctor --json --timeout 10s`,
+	Annotations: map[string]string{skipConfigCheckAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeDoctor()
+	},
+}
+
+func init() {
+	DoctorCmd.Flags().BoolVarP(&doctorJSON, "json", "j", false, "Output the checks and fixes as JSON")
+	DoctorCmd.Flags().BoolVar(&doctorSkipProviders, "skip-providers", false, "Don't send ping completions to providers")
+	DoctorCmd.Flags().BoolVar(&doctorSkipServers, "skip-servers", false, "Don't start MCP servers")
+	DoctorCmd.Flags().DurationVar(&doctorTimeout, "timeout", doctor.DefaultTimeout, "Time allowed for each provider ping and server start")
+}
+
+func executeDoctor() error {
+	// Problems are reported as checks; log lines from the probes would
+	// only repeat them out of order
+	if !verbose {
+		logging.SetDefaultLevel(logging.FATAL)
+	}
+	if !doctorJSON {
+		fmt.Println("Checking setup...")
+	}
+	report := doctor.Run(doctor.Options{
+		ConfigFile:    configFile,
+		SkipProviders: doctorSkipProviders,
+		SkipServers:   doctorSkipServers,
+		Timeout:       doctorTimeout,
+	})
+
+	if doctorJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(report)
+	}
+
+	if !report.OK {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func printDoctorReport(report *doctor.Report) {
+	bold := color.New(color.Bold)
+	warnColor := color.New(color.FgYellow)
+	failColor := color.New(color.FgRed, color.Bold)
+	okColor := color.New(color.FgGreen)
+
+	area := doctor.Area("")
+	for _, check := range report.Checks {
+		if check.Area != area {
+			area = check.Area
+			bold.Printf("\n%s\n", area)
+		}
+		printDoctorCheck(check.Check)
+	}
+
+	fmt.Println()
+	if len(report.Fixes) == 0 {
+		if report.OK {
+			okColor.Println("✓ Everything looks good")
+		}
+		return
+	}
+	bold.Println("Fix, in this order:")
+	for i, fix := range report.Fixes {
+		marker := warnColor.Sprint("⚠")
+		if fix.Status == sandbox.CheckFail {
+			marker = failColor.Sprint("✗")
+		}
+		fmt.Printf("%2d. %s %s: %s\n", i+1, marker, fix.Name, fix.Fix)
+	}
+}
+
+// printDoctorCheck prints one check the way 'sandbox doctor' does
+func printDoctorCheck(check sandbox.Check) {
+	printDiagnosis(&sandbox.Diagnosis{Checks: []sandbox.Check{{Name: check.Name, Status: check.Status, Detail: check.Detail}}})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -57,6 +58,9 @@ Examples:
   # Advanced chunking and output
   mcp-cli embeddings --chunk-strategy sentence --max-chunk-size 512 --output-format json --overlap 50
   
+  # Code-aware chunking (splits on function/class boundaries)
+  mcp-cli embeddings --input-file main.go --chunk-strategy code
+  
   # Direct text input
   mcp-cli embeddings "Analyze this specific text"
   
@@ -77,7 +81,7 @@ func init() {
 	EmbeddingsCmd.Flags().StringVar(&embeddingOutputFormat, "output-format", "json", "Output format (json, csv, compact)")
 
 	// Chunking flags
-	EmbeddingsCmd.Flags().StringVar(&chunkStrategy, "chunk-strategy", "sentence", "Chunking strategy (sentence, paragraph, fixed)")
+	EmbeddingsCmd.Flags().StringVar(&chunkStrategy, "chunk-strategy", "sentence", "Chunking strategy (sentence, paragraph, fixed, code)")
 	EmbeddingsCmd.Flags().IntVar(&maxChunkSize, "max-chunk-size", 512, "Maximum chunk size in tokens")
 	EmbeddingsCmd.Flags().IntVar(&chunkOverlap, "overlap", 0, "Overlap between chunks in tokens")
 
@@ -89,6 +93,10 @@ func init() {
 	// Info flags
 	EmbeddingsCmd.Flags().BoolVar(&showModels, "show-models", false, "Show available embedding models")
 	EmbeddingsCmd.Flags().BoolVar(&showStrategies, "show-strategies", false, "Show available chunking strategies")
+
+	// Cost flags
+	EmbeddingsCmd.Flags().BoolVar(&estimateOnly, "estimate", false, "Print the estimated cost and exit without generating embeddings")
+	EmbeddingsCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Run without asking when the estimated cost is above cost_estimate.confirm_above")
 }
 
 func executeEmbeddings(cmd *cobra.Command, args []string) error {
@@ -100,7 +108,7 @@ func executeEmbeddings(cmd *cobra.Command, args []string) error {
 	embeddingService := embeddings.NewService(configService, providerFactory)
 
 	// Load configuration using configFile from root command
-	_, err := configService.LoadConfig(configFile)
+	appConfig, err := configService.LoadConfig(configFile)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
@@ -148,6 +156,25 @@ func executeEmbeddings(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("input text is empty")
 	}
 
+	// Estimate the cost before anything is spent
+	estimateProvider := embeddingProvider
+	if estimateProvider == "" {
+		if name, _, _, err := configService.GetDefaultEmbeddingProvider(); err == nil {
+			estimateProvider = name
+		} else if name, _, _, err := configService.GetDefaultProvider(); err == nil {
+			estimateProvider = name
+		}
+	}
+	stepEstimate := workflow.EstimateEmbeddingCost(appConfig, estimateProvider, embeddingModel, workflow.ApproxTokens(inputText), maxChunkSize)
+	stepEstimate.Step = "embeddings"
+	estimate := &workflow.CostEstimate{Steps: []workflow.StepCostEstimate{stepEstimate}, Total: stepEstimate.Cost}
+	if !stepEstimate.Priced {
+		estimate.Unpriced = []string{stepEstimate.Provider + "/" + stepEstimate.Model}
+	}
+	if proceed, err := checkCostEstimate(estimate, appConfig.CostEstimate); !proceed {
+		return err
+	}
+
 	// Create embedding request
 	req := &domain.EmbeddingJobRequest{
 		Input:          inputText,
@@ -158,6 +185,7 @@ func executeEmbeddings(cmd *cobra.Command, args []string) error {
 		ChunkOverlap:   chunkOverlap,
 		EncodingFormat: encodingFormat,
 		Dimensions:     dimensions,
+		FilePath:       embeddingInputFile,
 		Metadata: map[string]interface{}{
 			"cli_version": "1.0.0",
 			"source":      getInputSource(),
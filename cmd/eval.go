@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/builtintools"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolselect"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/eval"
+	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
+	workflow "github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+	"github.com/spf13/cobra"
+)
+
+var (
+	evalDataset           string
+	evalScorer            string
+	evalThreshold         float64
+	evalJudge             string
+	evalEmbeddingProvider string
+	evalEmbeddingModel    string
+	evalConcurrency       int
+	evalTimeout           time.Duration
+	evalMinPassRate       float64
+	evalParams            []string
+	evalJSON              bool
+	evalOutput            string
+)
+
+// EvalCmd runs a workflow against a dataset and scores the outputs
+var EvalCmd = &cobra.Command{
+	Use:   "eval <workflow>",
+	Short: "Evaluate a workflow against a JSONL dataset",
+	Long: `Run a workflow once per case of a JSONL dataset, score each output
+against the expected result and report aggregate metrics and failures.
+
+Each dataset line is a case:
+
+  {"id": "refund", "input": "I want my money back", "expected": "billing"}
+  {"id": "outage", "input": {"ticket": "site down"}, "expected": "^(incident|outage)", "scorer": "regex"}
+  {"id": "tone", "input": "...", "expected": "...", "params": {"tone": "formal"}, "scorer": "judge",
+   "criteria": "politeness and accuracy"}
+
+input is passed as {{input}} (objects as JSON); params set {{params.name}}.
+
+Scorers (--scorer, or per case):
+  exact       Output equals expected (whitespace-normalized)
+  contains    Output contains expected (case-insensitive)
+  regex       Output matches the expected pattern
+  similarity  Embedding cosine similarity to expected >= --threshold
+  judge       A judge model grades the output 0-1 >= --threshold
+              (--judge, else judges.validation / judges.default in settings.yaml)
+
+Use --min-pass-rate in CI to fail when quality regresses.
+
+Examples:
+  mcp-cli eval ticket_triage --dataset evals/triage.jsonl
+  mcp-cli eval summarize --dataset evals/summaries.jsonl --scorer similarity --threshold 0.85
+  mcp-cli eval answer --dataset evals/qa.jsonl --scorer judge --judge anthropic/claude-sonnet-4 --min-pass-rate 0.9
+  mcp-cli eval ticket_triage --dataset evals/triage.jsonl --json -o eval.json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorkflowArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeEval(args[0])
+	},
+}
+
+func init() {
+	EvalCmd.Flags().StringVarP(&evalDataset, "dataset", "d", "", "JSONL dataset of cases (required)")
+	EvalCmd.Flags().StringVarP(&evalScorer, "scorer", "s", eval.ScorerExact, "Default scorer: "+strings.Join(eval.Scorers(), ", "))
+	EvalCmd.Flags().Float64Var(&evalThreshold, "threshold", eval.DefaultThreshold, "Passing score for the similarity and judge scorers (0-1)")
+	EvalCmd.Flags().StringVar(&evalJudge, "judge", "", "Judge model as provider[/model] (default: judges.validation)")
+	EvalCmd.Flags().StringVar(&evalEmbeddingProvider, "embedding-provider", "", "Embedding provider for the similarity scorer (default: configured default)")
+	EvalCmd.Flags().StringVar(&evalEmbeddingModel, "embedding-model", "", "Embedding model for the similarity scorer")
+	EvalCmd.Flags().IntVarP(&evalConcurrency, "concurrency", "c", 1, "Cases to run in parallel")
+	EvalCmd.Flags().DurationVar(&evalTimeout, "timeout", 5*time.Minute, "Timeout per case")
+	EvalCmd.Flags().Float64Var(&evalMinPassRate, "min-pass-rate", 0, "Exit with an error when the pass rate is below this (0-1)")
+	EvalCmd.Flags().StringArrayVar(&evalParams, "param", nil, "Workflow param for every case (name=value, repeatable)")
+	EvalCmd.Flags().BoolVarP(&evalJSON, "json", "j", false, "Output the report as JSON")
+	EvalCmd.Flags().StringVarP(&evalOutput, "output", "o", "", "Write the report to a file instead of stdout")
+	_ = EvalCmd.MarkFlagRequired("dataset")
+}
+
+func executeEval(name string) error {
+	if !eval.IsScorer(evalScorer) {
+		return domainErrors.Categorize(fmt.Errorf("unknown scorer '%s' (valid: %s)", evalScorer, strings.Join(eval.Scorers(), ", ")), domainErrors.ErrValidation)
+	}
+	baseParams, err := parseWorkflowParams(evalParams)
+	if err != nil {
+		return err
+	}
+
+	dataset, err := eval.LoadDataset(evalDataset)
+	if err != nil {
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+
+	configService := infraConfig.NewService()
+	appConfig, err := configService.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	wf, exists := appConfig.GetWorkflow(name)
+	if !exists {
+		return domainErrors.Categorize(fmt.Errorf("workflow '%s' not found. Available workflows: %v", name, appConfig.ListWorkflows()), domainErrors.ErrValidation)
+	}
+	if err := workflow.ValidateWorkflow(wf); err != nil {
+		return domainErrors.Categorize(fmt.Errorf("workflow validation failed:\n%w", err), domainErrors.ErrValidation)
+	}
+
+	providerFactory := ai.NewProviderFactory()
+	embeddingService := embeddings.NewService(configService, providerFactory)
+
+	opts := eval.Options{
+		Workflow:    name,
+		Scorer:      evalScorer,
+		Threshold:   evalThreshold,
+		Concurrency: evalConcurrency,
+		Timeout:     evalTimeout,
+	}
+
+	// Scorer dependencies are only set up when a case needs them
+	if datasetUsesScorer(dataset, eval.ScorerSimilarity) {
+		opts.Embed = func(ctx context.Context, text string) ([]float32, error) {
+			return embeddings.EmbedText(ctx, embeddingService, evalEmbeddingProvider, evalEmbeddingModel, text)
+		}
+	}
+	if datasetUsesScorer(dataset, eval.ScorerJudge) {
+		judgeLLM, err := initEvalJudge(appConfig)
+		if err != nil {
+			return err
+		}
+		defer judgeLLM.Close()
+		opts.Judge = func(ctx context.Context, prompt string) (string, error) {
+			response, err := judgeLLM.CreateCompletion(ctx, &domain.CompletionRequest{
+				Messages:    []domain.Message{{Role: "user", Content: prompt}},
+				Temperature: 0,
+			})
+			if err != nil {
+				return "", fmt.Errorf("judge failed: %w", err)
+			}
+			return response.Response, nil
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !quiet {
+		opts.Progress = func(done, total int, c *eval.Case) {
+			fmt.Fprintf(os.Stderr, "\r[%d/%d] %s\033[K", done, total, c.ID)
+		}
+	}
+
+	var report *eval.Report
+	err = withWorkflowRuntime(wf, appConfig, func(serverManager domain.MCPServerManager) error {
+		run := func(ctx context.Context, c *eval.Case) (string, error) {
+			level := "error"
+			if logLevel != "" || verbose {
+				level = resolveLogLevel(wf.ConsoleLogLevel())
+			}
+			logger := workflow.NewLogger(level, false)
+			logger.SetOutput(os.Stderr)
+
+			orchestrator := workflow.NewOrchestratorWithKey(wf, name, logger)
+			defer orchestrator.Close()
+			orchestrator.SetAppConfig(appConfig)
+			orchestrator.SetAppConfigForWorkflows(appConfig)
+			orchestrator.SetEmbeddingService(embeddingService)
+			if serverManager != nil {
+				orchestrator.SetServerManager(serverManager)
+			}
+
+			params := make(map[string]string, len(baseParams)+len(c.Params))
+			for k, v := range baseParams {
+				params[k] = v
+			}
+			for k, v := range c.ParamValues() {
+				params[k] = v
+			}
+			orchestrator.SetParams(params)
+
+			if err := orchestrator.Execute(ctx, c.InputText()); err != nil {
+				return "", err
+			}
+			output, _ := orchestrator.FinalOutput()
+			return strings.TrimSpace(output), nil
+		}
+
+		report = eval.Run(ctx, dataset, run, opts)
+		return nil
+	})
+	if !quiet {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if evalOutput != "" {
+		f, err := os.Create(evalOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if evalJSON {
+		err = eval.WriteJSON(out, report)
+	} else {
+		err = eval.WriteSummary(out, report)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if evalOutput != "" {
+		fmt.Fprintf(os.Stderr, "Report written to %s (%s)\n", evalOutput, report)
+	}
+
+	if ctx.Err() != nil {
+		return domainErrors.Categorize(fmt.Errorf("evaluation interrupted"), domainErrors.ErrInterrupted)
+	}
+	if report.PassRate < evalMinPassRate {
+		return domainErrors.Categorize(fmt.Errorf("pass rate %.1f%% is below --min-pass-rate %.1f%%", report.PassRate*100, evalMinPassRate*100), domainErrors.ErrValidation)
+	}
+	return nil
+}
+
+// datasetUsesScorer reports whether any case is graded by the scorer
+func datasetUsesScorer(dataset *eval.Dataset, scorer string) bool {
+	for _, c := range dataset.Cases {
+		if c.Scorer == scorer || (c.Scorer == "" && evalScorer == scorer) {
+			return true
+		}
+	}
+	return false
+}
+
+// initEvalJudge creates the judge model from --judge or the judges section
+func initEvalJudge(appConfig *config.ApplicationConfig) (domain.LLMProvider, error) {
+	var override *config.JudgeConfig
+	if evalJudge != "" {
+		provider, model, _ := strings.Cut(evalJudge, "/")
+		override = &config.JudgeConfig{Provider: provider, Model: model}
+	}
+
+	judge := config.ResolveJudge(append([]*config.JudgeConfig{override}, appConfig.Judges.For(config.JudgeRoleValidation)...)...)
+	if judge.Provider == "" && appConfig.AI != nil {
+		judge.Provider = appConfig.AI.DefaultProvider
+	}
+	if judge.Provider == "" {
+		return nil, domainErrors.Categorize(fmt.Errorf("judge scorer needs a judge: use --judge provider[/model] or set judges.validation in settings.yaml"), domainErrors.ErrValidation)
+	}
+
+	llm, err := ai.NewService().InitializeProvider(configFile, judge.Provider, judge.Model)
+	if err != nil {
+		return nil, domainErrors.Categorize(fmt.Errorf("failed to initialize judge %s: %w", judge.Provider, err), domainErrors.ErrProviderFailure)
+	}
+	return llm, nil
+}
+
+// withWorkflowRuntime connects the MCP servers and built-in skills a
+// workflow needs, then calls fn with the server manager (nil when the
+// workflow needs neither)
+func withWorkflowRuntime(wf *config.WorkflowV2, appConfig *config.ApplicationConfig, fn func(serverManager domain.MCPServerManager) error) error {
+	servers := workflow.RequiredServers(wf, appConfig.RAG)
+	externalServers, needsSkills := infraSkills.SeparateSkillsFromServers(servers)
+	if len(workflow.RequiredSkills(wf)) > 0 {
+		needsSkills = true
+	}
+
+	var skillService *skillsvc.Service
+	if needsSkills {
+		var err error
+		skillService, err = infraSkills.InitializeBuiltinSkills(configFile, appConfig)
+		if err != nil {
+			return fmt.Errorf("failed to initialize built-in skills: %w", err)
+		}
+	}
+
+	if len(externalServers) == 0 {
+		var serverManager domain.MCPServerManager
+		if skillService != nil {
+			serverManager = builtintools.Wrap(infraSkills.NewSkillsAwareServerManager(nil, skillService), appConfig.BuiltinTools)
+			serverManager = toolselect.Wrap(serverManager, configFile, appConfig.ToolSelection)
+		}
+		return fn(serverManager)
+	}
+
+	userSpecified := make(map[string]bool)
+	for _, server := range externalServers {
+		userSpecified[server] = true
+	}
+
+	var fnErr error
+	err := host.RunCommandWithOptions(func(conns []*host.ServerConnection) error {
+		var serverManager domain.MCPServerManager = infraSkills.NewHostServerManager(conns)
+		if skillService != nil {
+			serverManager = infraSkills.NewSkillsAwareServerManager(serverManager, skillService)
+		}
+		serverManager = builtintools.Wrap(serverManager, appConfig.BuiltinTools)
+		serverManager = toolselect.Wrap(serverManager, configFile, appConfig.ToolSelection)
+		fnErr = fn(serverManager)
+		return fnErr
+	}, configFile, externalServers, userSpecified, host.QuietCommandOptions())
+	if err != nil {
+		return err
+	}
+	return fnErr
+}
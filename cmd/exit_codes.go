@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/stats"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/console"
+	"github.com/fatih/color"
+)
+
+// ExitCode returns the process exit code for an error returned by a command.
+// See domainErrors.ExitCode for the contract:
+//
+//	0 success, 1 failure, 2 validation, 3 provider, 4 tool, 5 timeout/budget,
+//	130 interrupted
+func ExitCode(err error) int {
+	return domainErrors.ExitCode(err)
+}
+
+// RecordFailure counts a failed command by category in the local usage
+// stats, when they are enabled
+func RecordFailure(err error) {
+	stats.RecordFailure(domainErrors.Category(err))
+}
+
+// exitWithError records a failed command and exits with code. Commands that
+// exit directly, rather than returning the error to main, use it so the
+// failure still shows in 'mcp-cli stats'.
+func exitWithError(err error, code int) {
+	RecordFailure(err)
+	os.Exit(code)
+}
+
+// disableColors turns off ANSI output everywhere (--no-color). NO_COLOR is
+// exported so that lipgloss, glamour and the chat UI also fall back to plain text.
+func disableColors() {
+	color.NoColor = true
+	console.SetColorsEnabled(false)
+	os.Setenv("NO_COLOR", "1")
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/elicitation"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -32,6 +33,10 @@ You can query server information, list available tools and resources, and more.`
 
 		logging.Info("Starting interactive mode")
 
+		// Servers can ask for input while a /call runs
+		elicitation.SetHandler(elicitation.NewStdinHandler())
+		defer elicitation.SetHandler(nil)
+
 		// Run the interactive command
 		err := host.RunCommand(runInteractiveMode, configFile, serverNames, userSpecified)
 		if err != nil {
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/outputcrypt"
+	"github.com/spf13/cobra"
+)
+
+// outputsKeyEnv holds the outputs encryption key when settings don't
+const outputsKeyEnv = "MCP_OUTPUTS_KEY"
+
+var (
+	outputsDecryptOut    string
+	outputsDecryptStdout bool
+)
+
+// OutputsCmd manages files in the outputs directory
+var OutputsCmd = &cobra.Command{
+	Use:   "outputs",
+	Short: "Work with files in the outputs directory",
+	Long: `With skills.outputs_encryption_key set in settings.yaml, files that skills,
+workflows and served tools write to the outputs directory are encrypted
+(AES-256-GCM) and renamed with a .enc suffix.
+
+Examples:
+  mcp-cli outputs decrypt /tmp/mcp-outputs/report.docx.enc
+  mcp-cli outputs decrypt /tmp/mcp-outputs --out ~/decrypted
+  mcp-cli outputs decrypt results.json.enc --stdout | jq .`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+// OutputsDecryptCmd decrypts encrypted outputs
+var OutputsDecryptCmd = &cobra.Command{
+	Use:   "decrypt <file-or-dir>...",
+	Short: "Decrypt encrypted outputs files",
+	Long: `Decrypts .enc files, or every .enc file under a directory. The plain file is
+written next to the encrypted one without the .enc suffix, or under --out;
+the encrypted file is kept.
+
+The key is skills.outputs_encryption_key from settings.yaml, or $MCP_OUTPUTS_KEY.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeOutputsDecrypt(args)
+	},
+}
+
+func init() {
+	OutputsDecryptCmd.Flags().StringVarP(&outputsDecryptOut, "out", "o", "", "Directory to write decrypted files to (default: next to each file)")
+	OutputsDecryptCmd.Flags().BoolVar(&outputsDecryptStdout, "stdout", false, "Write the decrypted file to stdout (one file only)")
+	OutputsCmd.Annotations = map[string]string{skipConfigCheckAnnotation: "true"}
+	OutputsDecryptCmd.Annotations = map[string]string{skipConfigCheckAnnotation: "true"}
+	OutputsCmd.AddCommand(OutputsDecryptCmd)
+}
+
+// outputsKey returns the key from settings, falling back to $MCP_OUTPUTS_KEY
+func outputsKey() ([]byte, error) {
+	if appConfig, err := infraConfig.NewService().LoadConfig(configFile); err == nil {
+		key, err := outputcrypt.KeyFrom(appConfig.Skills)
+		if err != nil || key != nil {
+			return key, err
+		}
+	}
+	if encoded := os.Getenv(outputsKeyEnv); encoded != "" {
+		return outputcrypt.ParseKey(encoded)
+	}
+	return nil, fmt.Errorf("no outputs encryption key: set skills.outputs_encryption_key in settings.yaml or %s", outputsKeyEnv)
+}
+
+func executeOutputsDecrypt(args []string) error {
+	key, err := outputsKey()
+	if err != nil {
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+
+	// Directories stand for the encrypted files under them, which keep their
+	// relative paths under --out
+	type sealedFile struct{ path, rel string }
+	var files []sealedFile
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return domainErrors.Categorize(err, domainErrors.ErrValidation)
+		}
+		if !info.IsDir() {
+			files = append(files, sealedFile{arg, filepath.Base(arg)})
+			continue
+		}
+		err = filepath.WalkDir(arg, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.Type().IsRegular() && strings.HasSuffix(path, outputcrypt.Suffix) {
+				rel, _ := filepath.Rel(arg, path)
+				files = append(files, sealedFile{path, rel})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if outputsDecryptStdout && len(files) != 1 {
+		return domainErrors.Categorize(fmt.Errorf("--stdout needs exactly one file, got %d", len(files)), domainErrors.ErrValidation)
+	}
+
+	failed := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file.path)
+		if err == nil {
+			data, err = outputcrypt.Open(key, data)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", file.path, err)
+			failed++
+			continue
+		}
+		if outputsDecryptStdout {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+
+		target := strings.TrimSuffix(file.path, outputcrypt.Suffix)
+		if outputsDecryptOut != "" {
+			target = filepath.Join(outputsDecryptOut, strings.TrimSuffix(file.rel, outputcrypt.Suffix))
+		}
+		if target == file.path {
+			target += ".dec"
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err == nil {
+			err = os.WriteFile(target, data, 0o600)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", file.path, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s -> %s\n", file.path, target)
+	}
+
+	if len(files) == 0 {
+		fmt.Println("No encrypted files found")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d file(s) could not be decrypted", failed, len(files))
+	}
+	return nil
+}
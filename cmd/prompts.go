@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var promptsSet []string
+
+// PromptsCmd lists and shows prompt templates
+var PromptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "List and inspect prompt templates",
+	Long: `Named, versioned prompt templates live in config/prompts/*.yaml (the
+prompts: include in config.yaml), one version per file:
+
+  name: summarize
+  version: v2
+  description: Summarize text for a given audience
+  parameters:
+    text:     {type: string, required: true}
+    audience: {type: string, enum: [exec, engineer], default: engineer}
+    bullets:  {type: integer, default: 5}
+  template: |
+    Summarize the following for a {{audience}} in {{bullets}} bullets:
+    {{text}}
+
+Workflow steps reference them with prompt_ref instead of run:
+
+  - name: brief
+    prompt_ref: summarize@v2      # omit @version for the latest
+    prompt_args:
+      text: "{{fetch}}"
+
+Examples:
+  mcp-cli prompts list
+  mcp-cli prompts show summarize
+  mcp-cli prompts show summarize@v1 --set text="Quarterly results..."`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executePromptsList()
+	},
+}
+
+// PromptsListCmd lists prompt templates
+var PromptsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List prompt templates and their versions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executePromptsList()
+	},
+}
+
+// PromptsShowCmd shows one prompt template
+var PromptsShowCmd = &cobra.Command{
+	Use:   "show <name[@version]>",
+	Short: "Show a prompt template's parameters and text",
+	Long: `Show a prompt template. Without @version the latest version is shown.
+
+Use --set to preview the rendered prompt:
+  mcp-cli prompts show summarize --set text="..." --set audience=exec`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePrompts,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executePromptsShow(args[0])
+	},
+}
+
+func init() {
+	PromptsShowCmd.Flags().StringArrayVar(&promptsSet, "set", nil, "Render with a parameter value (name=value, repeatable)")
+
+	PromptsCmd.AddCommand(PromptsListCmd)
+	PromptsCmd.AddCommand(PromptsShowCmd)
+}
+
+func loadPromptLibrary() (*config.PromptLibrary, error) {
+	cfg, err := config.NewLoader().Load(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg.Prompts, nil
+}
+
+func executePromptsList() error {
+	library, err := loadPromptLibrary()
+	if err != nil {
+		return err
+	}
+
+	prompts := library.List()
+	if len(prompts) == 0 {
+		fmt.Println("No prompt templates found.")
+		fmt.Println("\nTo add prompts:")
+		fmt.Println("  1. Add 'prompts: config/prompts/*.yaml' under includes in config.yaml")
+		fmt.Println("  2. Create one file per prompt version (see 'mcp-cli prompts --help')")
+		return nil
+	}
+
+	// List is sorted by name then version, so the last entry per name is the latest
+	latest := make(map[string]*config.PromptTemplate)
+	var names []string
+	for _, p := range prompts {
+		if _, seen := latest[p.Name]; !seen {
+			names = append(names, p.Name)
+		}
+		latest[p.Name] = p
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVERSIONS\tPARAMETERS\tDESCRIPTION")
+	for _, name := range names {
+		p := latest[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			name,
+			strings.Join(library.Versions(name), ", "),
+			strings.Join(sortedParamNames(p), ", "),
+			truncate(p.Description, 60),
+		)
+	}
+	return tw.Flush()
+}
+
+func executePromptsShow(ref string) error {
+	library, err := loadPromptLibrary()
+	if err != nil {
+		return err
+	}
+
+	p, err := library.Get(ref)
+	if err != nil {
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+
+	bold := color.New(color.Bold)
+	bold.Printf("%s\n", p.Ref())
+	if p.Description != "" {
+		fmt.Printf("  %s\n", p.Description)
+	}
+	fmt.Printf("  Source:   %s\n", p.Source)
+	fmt.Printf("  Versions: %s\n", strings.Join(library.Versions(p.Name), ", "))
+
+	if len(p.Parameters) > 0 {
+		bold.Println("\nParameters:")
+		for _, name := range sortedParamNames(p) {
+			param := p.Parameters[name]
+			paramType := param.Type
+			if paramType == "" {
+				paramType = config.PromptParamString
+			}
+			var notes []string
+			if param.Required {
+				notes = append(notes, "required")
+			}
+			if param.Default != nil {
+				notes = append(notes, fmt.Sprintf("default: %v", param.Default))
+			}
+			if len(param.Enum) > 0 {
+				notes = append(notes, "one of: "+strings.Join(param.Enum, ", "))
+			}
+			line := fmt.Sprintf("  %s (%s)", name, paramType)
+			if len(notes) > 0 {
+				line += " [" + strings.Join(notes, "; ") + "]"
+			}
+			if param.Description != "" {
+				line += " - " + param.Description
+			}
+			fmt.Println(line)
+		}
+	}
+
+	bold.Println("\nTemplate:")
+	fmt.Println(p.Template)
+
+	if len(promptsSet) > 0 {
+		values := make(map[string]string, len(promptsSet))
+		for _, kv := range promptsSet {
+			name, value, ok := strings.Cut(kv, "=")
+			if !ok {
+				return domainErrors.Categorize(fmt.Errorf("invalid --set '%s': expected name=value", kv), domainErrors.ErrValidation)
+			}
+			values[name] = value
+		}
+		rendered, err := p.Render(values)
+		if err != nil {
+			return domainErrors.Categorize(err, domainErrors.ErrValidation)
+		}
+		bold.Println("\nRendered:")
+		fmt.Println(rendered)
+	}
+
+	return nil
+}
+
+func sortedParamNames(p *config.PromptTemplate) []string {
+	names := make([]string, 0, len(p.Parameters))
+	for name := range p.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
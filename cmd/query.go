@@ -1,19 +1,30 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
 
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/contextbundle"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/builtintools"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/clipboard"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolselect"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/output"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/daemon"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/query"
 	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
 	"github.com/spf13/cobra"
@@ -30,8 +41,19 @@ var (
 	noisy          bool   // Changed to be the opposite of quiet
 	rawDataOutput  bool   // New flag for raw data output
 	queryInputData string // Query-specific input data flag
+	copyResponse   bool   // Copy the response to the system clipboard
+
+	// Context files and pages, summarized when too long
+	contextFiles     []string
+	contextURLs      []string
+	summarizeContext bool
+	contextMaxChars  int
+	contextBundle    string
 )
 
+// maxContextFileBytes is the largest file --context-file reads
+const maxContextFileBytes = 10 << 20
+
 // QueryCmd represents the query command
 var QueryCmd = &cobra.Command{
 	Use:   "query [question] or --input-data \"question\"",
@@ -49,7 +71,7 @@ The query command supports:
   • Multiple MCP servers for tool access
   • Context from files (--context)
   • Custom system prompts (--system-prompt)
-  • JSON output for parsing (--json)
+  • JSON envelope for scripts (--json)
   • Raw tool data output (--raw-data)
   • File output (--output)
 
@@ -78,6 +100,9 @@ Examples:
   
   # Output to file
   mcp-cli query "Analyze this code" --output analysis.txt
+
+  # Also copy the response to the clipboard
+  mcp-cli query --copy "Write a regex that matches IPv4 addresses"
   
   # Using --input-data flag instead of positional argument
   mcp-cli query --input-data "What is the weather today?"
@@ -85,10 +110,20 @@ Examples:
   # Both work the same way
   mcp-cli query "question" --provider anthropic
   mcp-cli query --provider anthropic --input-data "question"`,
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		// Redirect stdin to prevent blocking when called via MCP tools
 		redirectStdinIfNotTerminal()
 
+		// With --json, failures are reported in the envelope too
+		startedAt := time.Now()
+		if jsonOutput && !errorCodeOnly {
+			defer func() {
+				if err != nil {
+					writeQueryErrorEnvelope(err, startedAt)
+				}
+			}()
+		}
+
 		// ARCHITECTURAL FIX: Handle noisy flag override for query command
 		// This allows --noisy to override the default quiet behavior of query mode
 		if noisy && !verbose {
@@ -102,7 +137,7 @@ Examples:
 			if errorCodeOnly {
 				os.Exit(query.ErrInvalidArgumentCode)
 			}
-			return fmt.Errorf("--max-tokens must be positive, got %d", maxTokens)
+			return domainErrors.Categorize(fmt.Errorf("--max-tokens must be positive, got %d", maxTokens), domainErrors.ErrValidation)
 		}
 
 		// Validate context file exists if specified
@@ -111,7 +146,7 @@ Examples:
 				if errorCodeOnly {
 					os.Exit(query.ErrContextNotFoundCode)
 				}
-				return fmt.Errorf("context file does not exist: %s", contextFile)
+				return domainErrors.Categorize(fmt.Errorf("context file does not exist: %s", contextFile), domainErrors.ErrValidation)
 			}
 		}
 
@@ -132,12 +167,12 @@ Examples:
 				if errorCodeOnly {
 					os.Exit(query.ErrOutputWriteCode)
 				}
-				return fmt.Errorf("output directory does not exist: %s", outputDir)
+				return domainErrors.Categorize(fmt.Errorf("output directory does not exist: %s", outputDir), domainErrors.ErrValidation)
 			} else if !stat.IsDir() {
 				if errorCodeOnly {
 					os.Exit(query.ErrOutputWriteCode)
 				}
-				return fmt.Errorf("output path is not a directory: %s", outputDir)
+				return domainErrors.Categorize(fmt.Errorf("output path is not a directory: %s", outputDir), domainErrors.ErrValidation)
 			}
 		}
 
@@ -165,7 +200,11 @@ Examples:
 			if errorCodeOnly {
 				os.Exit(query.ErrInvalidArgumentCode)
 			}
-			os.Exit(1)
+			missingErr := domainErrors.Categorize(errors.New("no question provided"), domainErrors.ErrValidation)
+			if jsonOutput {
+				writeQueryErrorEnvelope(missingErr, startedAt)
+			}
+			exitWithError(missingErr, domainErrors.ExitValidation)
 		}
 
 		// Process server configuration options - use local ProcessOptions with configFile
@@ -235,6 +274,27 @@ Examples:
 			contextContent = string(content)
 		}
 
+		// Load context files and pages
+		contextSources, err := loadContextSources(cmd.Context(), contextFiles, contextURLs)
+		if err != nil {
+			if errorCodeOnly {
+				os.Exit(query.ErrContextNotFoundCode)
+			}
+			return err
+		}
+		contextOptions := query.ContextOptions{Summarize: summarizeContext, MaxChars: contextMaxChars}
+
+		// Load an exported chat or workflow run
+		var bundle *contextbundle.Bundle
+		if contextBundle != "" {
+			if bundle, err = contextbundle.Load(contextBundle); err != nil {
+				if errorCodeOnly {
+					os.Exit(query.ErrContextNotFoundCode)
+				}
+				return fmt.Errorf("%w: %w", query.ErrContextNotFound, err)
+			}
+		}
+
 		// Load the configuration to check for system prompt and other settings
 		oldCfg, err := config.LoadConfig(configFile)
 		if err == nil {
@@ -290,9 +350,33 @@ Examples:
 			commandOptions = host.QuietCommandOptions()
 		}
 
+		// Forward to a warm daemon for this config if one is running
+		result, usedDaemon, err := queryViaDaemon(&daemon.QueryRequest{
+			Question:       question,
+			Provider:       providerName,
+			Model:          modelName,
+			Servers:        serverNames,
+			SystemPrompt:   systemPrompt,
+			Context:        contextContent,
+			ContextSources: contextSources,
+			ContextOptions: contextOptions,
+			ContextBundle:  bundle,
+			MaxTokens:      maxTokens,
+		})
+		if err != nil {
+			if errorCodeOnly {
+				var remoteErr *daemon.RemoteError
+				if errors.As(err, &remoteErr) && remoteErr.ErrorCode != 0 {
+					os.Exit(remoteErr.ErrorCode)
+				}
+				os.Exit(1)
+			}
+			return err
+		}
+
 		// Initialize built-in skills service if needed
 		var skillService *skillsvc.Service
-		if needsSkills {
+		if needsSkills && !usedDaemon {
 			// Load app config for skills initialization
 			configService := config.NewService()
 			appConfig, err := configService.LoadConfig(configFile)
@@ -314,54 +398,67 @@ Examples:
 		}
 
 		// Run the query command with the given options (ONLY external servers)
-		var result *query.QueryResult
-		err = host.RunCommandWithOptions(func(conns []*host.ServerConnection) error {
-			// Use AI service to create provider with full config
-			aiService := ai.NewService()
-			llmProvider, err := aiService.InitializeProvider(configFile, providerName, modelName)
-			if err != nil {
-				if errorCodeOnly {
-					os.Exit(query.ErrInitializationCode)
+		if !usedDaemon {
+			err = host.RunCommandWithOptions(func(conns []*host.ServerConnection) error {
+				// Use AI service to create provider with full config
+				aiService := ai.NewService()
+				llmProvider, err := aiService.InitializeProvider(configFile, providerName, modelName)
+				if err != nil {
+					if errorCodeOnly {
+						os.Exit(query.ErrInitializationCode)
+					}
+					return fmt.Errorf("failed to initialize AI provider: %w", err)
 				}
-				return fmt.Errorf("failed to initialize AI provider: %w", err)
-			}
 
-			// ARCHITECTURAL FIX: Create server manager (with skills if needed)
-			var serverManager domain.MCPServerManager = NewHostServerManager(conns)
-			if skillService != nil {
-				logging.Info("Wrapping query server manager with built-in skills support")
-				serverManager = infraSkills.NewSkillsAwareServerManager(serverManager, skillService)
-			}
+				// ARCHITECTURAL FIX: Create server manager (with skills if needed)
+				var serverManager domain.MCPServerManager = infraSkills.NewHostServerManager(conns)
+				if skillService != nil {
+					logging.Info("Wrapping query server manager with built-in skills support")
+					serverManager = infraSkills.NewSkillsAwareServerManager(serverManager, skillService)
+				}
+				serverManager = builtintools.Wrap(serverManager, loadBuiltinToolsConfig(configFile))
+				serverManager = toolselect.Wrap(serverManager, configFile, loadToolSelectionConfig(configFile))
+
+				// Create query handler with server manager instead of connections
+				handler := query.NewQueryHandlerWithServerManager(serverManager, llmProvider, aiOptions, systemPrompt)
+				toolIterations := loadToolIterationsConfig(configFile)
+				handler.SetMaxFollowUpAttempts(toolIterations.QueryLimit())
+				handler.MaxRepeatedToolCalls = toolIterations.RepeatLimit()
+				handler.MaxToolCallRepairs = toolIterations.RepairLimit()
+
+				// Set context if provided
+				if contextContent != "" {
+					handler.AddContext(contextContent)
+				}
+				handler.AddContextBundle(bundle)
+				if err := handler.AddContextSources(cmd.Context(), question, contextSources, contextOptions); err != nil {
+					if errorCodeOnly {
+						exitWithError(err, query.GetExitCode(err))
+					}
+					return err
+				}
 
-			// Create query handler with server manager instead of connections
-			handler := query.NewQueryHandlerWithServerManager(serverManager, llmProvider, aiOptions, systemPrompt)
+				// Set max tokens if provided
+				if maxTokens > 0 {
+					handler.SetMaxTokens(maxTokens)
+				}
 
-			// Set context if provided
-			if contextContent != "" {
-				handler.AddContext(contextContent)
-			}
+				// Execute the query
+				result, err = handler.Execute(question)
+				if err != nil {
+					// Return specific error code based on the error type
+					if errorCodeOnly {
+						exitWithError(err, query.GetExitCode(err))
+					}
+					return fmt.Errorf("query failed: %w", err)
+				}
 
-			// Set max tokens if provided
-			if maxTokens > 0 {
-				handler.SetMaxTokens(maxTokens)
-			}
+				return nil
+			}, configFile, externalServers, externalUserSpecified, commandOptions)
 
-			// Execute the query
-			result, err = handler.Execute(question)
 			if err != nil {
-				// Return specific error code based on the error type
-				if errorCodeOnly {
-					exitCode := query.GetExitCode(err)
-					os.Exit(exitCode)
-				}
-				return fmt.Errorf("query failed: %w", err)
+				return err
 			}
-
-			return nil
-		}, configFile, externalServers, externalUserSpecified, commandOptions)
-
-		if err != nil {
-			return err
 		}
 
 		// Process the results if raw data output is enabled
@@ -390,26 +487,12 @@ Examples:
 		// Format and output response
 		if result != nil {
 			if jsonOutput {
-				// Output as JSON
-				jsonData, err := json.MarshalIndent(result, "", "  ")
-				if err != nil {
+				// Output the machine-readable envelope
+				if err := writeQueryEnvelope(query.NewEnvelope(result)); err != nil {
 					if errorCodeOnly {
-						os.Exit(query.ErrOutputFormatCode)
-					}
-					return fmt.Errorf("failed to format JSON response: %w", err)
-				}
-
-				// Write to file or stdout
-				if outputFile != "" {
-					err = os.WriteFile(outputFile, jsonData, 0644)
-					if err != nil {
-						if errorCodeOnly {
-							os.Exit(query.ErrOutputWriteCode)
-						}
-						return fmt.Errorf("failed to write output file: %w", err)
+						os.Exit(query.ErrOutputWriteCode)
 					}
-				} else {
-					fmt.Println(string(jsonData))
+					return err
 				}
 			} else {
 				// Output as plain text
@@ -428,12 +511,124 @@ Examples:
 					writer.Println(result.Response)
 				}
 			}
+
+			// Copy the response text to the clipboard (never fails the query)
+			if copyResponse {
+				if err := clipboard.Copy(result.Response); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to copy response to clipboard: %v\n", err)
+				}
+			}
 		}
 
 		return nil
 	},
 }
 
+// loadContextSources reads --context-file files and fetches --context-url
+// pages. Pages are read like the fetch_url tool reads them, but private
+// addresses and robots.txt rules don't apply since the user chose the URL.
+func loadContextSources(ctx context.Context, files, urls []string) ([]query.ContextSource, error) {
+	var sources []query.ContextSource
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read context file: %w", err)
+		}
+		if info.Size() > maxContextFileBytes {
+			return nil, fmt.Errorf("context file %s is %d bytes; the limit is %d", path, info.Size(), maxContextFileBytes)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read context file: %w", err)
+		}
+		if !utf8.Valid(content) {
+			return nil, fmt.Errorf("context file %s is not a text file", path)
+		}
+		sources = append(sources, query.ContextSource{Name: path, Content: string(content)})
+	}
+
+	if len(urls) == 0 {
+		return sources, nil
+	}
+	var fetchConfig domainConfig.FetchToolConfig
+	if builtins := loadBuiltinToolsConfig(configFile); builtins != nil && builtins.Fetch != nil {
+		fetchConfig = *builtins.Fetch
+	}
+	fetchConfig.AllowPrivate = true
+	fetchConfig.IgnoreRobots = true
+	for _, rawURL := range urls {
+		pageURL, title, content, err := builtintools.FetchPage(ctx, fetchConfig, rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch context URL: %w", err)
+		}
+		name := pageURL
+		if title != "" {
+			name = fmt.Sprintf("%s (%s)", pageURL, title)
+		}
+		sources = append(sources, query.ContextSource{Name: name, Content: content})
+	}
+	return sources, nil
+}
+
+// writeQueryEnvelope writes the --json envelope to --output or stdout
+func writeQueryEnvelope(env *query.Envelope) error {
+	jsonData, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON response: %w", err)
+	}
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, append(jsonData, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		return nil
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// writeQueryErrorEnvelope reports a failed query as a --json envelope. The
+// error is still returned, so it is printed to stderr and sets the exit code.
+func writeQueryErrorEnvelope(err error, startedAt time.Time) {
+	env := query.NewErrorEnvelope(err, ExitCode(err), providerName, modelName, startedAt)
+	if writeErr := writeQueryEnvelope(env); writeErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", writeErr)
+	}
+}
+
+// loadBuiltinToolsConfig returns the builtin_tools settings, or nil (the
+// defaults) when the configuration can't be loaded. --disable-filesystem
+// turns off the built-in file tools as well as the filesystem server.
+func loadBuiltinToolsConfig(configFile string) *domainConfig.BuiltinToolsConfig {
+	appConfig, err := config.NewService().LoadConfig(configFile)
+	if err != nil || appConfig == nil {
+		return nil
+	}
+	if disableFilesystem {
+		return appConfig.BuiltinTools.WithoutFilesystem()
+	}
+	return appConfig.BuiltinTools
+}
+
+// loadToolSelectionConfig returns the tool_selection settings, or nil (no
+// limit) when the configuration can't be loaded
+func loadToolSelectionConfig(configFile string) *domainConfig.ToolSelectionConfig {
+	appConfig, err := config.NewService().LoadConfig(configFile)
+	if err != nil || appConfig == nil {
+		return nil
+	}
+	return appConfig.ToolSelection
+}
+
+// loadToolIterationsConfig returns the tool_iterations settings, or nil
+// (the defaults) when the configuration can't be loaded
+func loadToolIterationsConfig(configFile string) *domainConfig.ToolIterationsConfig {
+	appConfig, err := config.NewService().LoadConfig(configFile)
+	if err != nil || appConfig == nil {
+		return nil
+	}
+	return appConfig.ToolIterations
+}
+
 // ProcessOptions processes command-line options and returns the server names
 func ProcessOptions(configFile, serverFlag string, disableFilesystem bool, provider string, model string) ([]string, map[string]bool) {
 	logging.Debug("Processing options: server=%s, disableFilesystem=%v, provider=%s, model=%s",
@@ -593,14 +788,20 @@ func formatJsonObject(obj map[string]interface{}, indent int) string {
 func init() {
 	// Add query-specific flags
 	QueryCmd.Flags().StringVar(&queryInputData, "input-data", "", "Question to ask (alternative to positional argument)")
-	QueryCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output response in JSON format")
+	QueryCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output a JSON envelope (response, tool calls, usage, timing) for scripts")
 	QueryCmd.Flags().StringVarP(&contextFile, "context", "c", "", "File containing additional context")
+	QueryCmd.Flags().StringArrayVar(&contextFiles, "context-file", nil, "Add a text file as context, summarized if too long (repeatable)")
+	QueryCmd.Flags().StringArrayVar(&contextURLs, "context-url", nil, "Add a web page as context, summarized if too long (repeatable)")
+	QueryCmd.Flags().BoolVar(&summarizeContext, "summarize-context", false, "Summarize every --context-file and --context-url source before adding it")
+	QueryCmd.Flags().IntVar(&contextMaxChars, "context-max-chars", query.DefaultContextMaxChars, "Characters per context source before it is summarized in chunks")
+	QueryCmd.Flags().StringVar(&contextBundle, "context-bundle", "", "Continue from a context bundle saved by chat /export-context or a workflow's --export-context")
 	QueryCmd.Flags().StringVar(&systemPrompt, "system-prompt", "", "Custom system prompt")
 	QueryCmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Maximum tokens in response (0 for default)")
 	QueryCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (default is stdout)")
 	QueryCmd.Flags().BoolVar(&errorCodeOnly, "error-code-only", false, "Only return error codes, no error messages")
 	QueryCmd.Flags().BoolVarP(&noisy, "noisy", "n", false, "Show detailed logs and server messages")
 	QueryCmd.Flags().BoolVar(&rawDataOutput, "raw-data", false, "Output raw data from tools instead of AI summary")
+	QueryCmd.Flags().BoolVar(&copyResponse, "copy", false, "Copy the response text to the system clipboard")
 
 	// Note: QueryCmd is added to RootCmd in root.go init() with other commands
 }
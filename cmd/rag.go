@@ -9,6 +9,7 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
@@ -195,7 +196,7 @@ func executeRagSearch(cmd *cobra.Command, args []string) error {
 	// Run with host server connections
 	err = host.RunCommandWithOptions(func(conns []*host.ServerConnection) error {
 		// Create server manager
-		serverManager := NewHostServerManager(conns)
+		serverManager := infraSkills.NewHostServerManager(conns)
 
 		// Create embedding service
 		providerFactory := ai.NewProviderFactory()
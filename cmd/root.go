@@ -5,11 +5,16 @@ import (
 	"os"
 	"strings"
 
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/models"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/env"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/output"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/progress"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/stats"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/console"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -120,12 +125,18 @@ var (
 	verbose           bool
 	logLevel          string
 	noColor           bool
+	quiet             bool
+	progressStream    string
 
 	// Template-based workflow flags
-	workflowName  string
-	startFromStep string
-	endAtStep     string
-	inputData     string
+	workflowName   string
+	startFromStep  string
+	endAtStep      string
+	inputData      string
+	workflowParams []string
+	recordRun      bool
+	workflowBundle string
+	exportContext  string
 
 	// RootCmd represents the base command when called without any subcommands
 	RootCmd = &cobra.Command{
@@ -133,9 +144,22 @@ var (
 		Short: "MCP Command-Line Tool - Interact with AI models and MCP servers",
 		Long:  getColorizedHelp(),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Open the progress stream first so every command can report to it
+			if progressStream != "" {
+				if err := progress.Open(progressStream); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: --progress-stream: %v\n", err)
+					os.Exit(domainErrors.ExitValidation)
+				}
+			}
+
+			// Count the run in the local usage stats (when enabled)
+			stats.RecordCommand(statsCommandName(cmd))
+
 			// Skip config check for init command, help, and serve (serve handles config loading internally)
 			cmdName := cmd.Name()
-			if cmdName == "init" || cmdName == "help" || cmdName == "completion" || cmdName == "serve" {
+			if cmdName == "init" || cmdName == "help" || cmdName == "completion" || cmdName == "serve" ||
+				cmdName == cobra.ShellCompRequestCmd || cmdName == cobra.ShellCompNoDescRequestCmd ||
+				cmd.Annotations[skipConfigCheckAnnotation] == "true" {
 				return
 			}
 
@@ -152,7 +176,7 @@ var (
 			if verbose {
 				// Verbose flag: Show everything
 				outputConfig = models.NewVerboseOutputConfig()
-			} else if isQueryCommand || isTemplateMode || isEmbeddingsCommand {
+			} else if quiet || isQueryCommand || isTemplateMode || isEmbeddingsCommand {
 				// Query/template/embeddings: Quiet mode for clean output
 				outputConfig = models.NewQuietOutputConfig()
 			} else {
@@ -162,9 +186,16 @@ var (
 
 			// Apply no-color flag
 			if noColor {
+				disableColors()
 				outputConfig.ShowColors = false
 			}
 
+			// Quiet mode disables progress spinners and decorative output
+			if quiet {
+				console.SetSpinnersEnabled(false)
+				outputConfig.ShowProgress = false
+			}
+
 			// Set global output manager
 			outputManager := output.NewManager(outputConfig)
 			output.SetGlobalManager(outputManager)
@@ -189,14 +220,14 @@ var (
 			if workflowName != "" {
 				if err := executeWorkflow(); err != nil {
 					logging.Error("Template execution failed: %v", err)
-					os.Exit(1)
+					exitWithError(err, ExitCode(err))
 				}
 				return
 			}
 
 			// Execute the chat command by default
 			if err := ChatCmd.RunE(cmd, args); err != nil {
-				os.Exit(1)
+				exitWithError(err, ExitCode(err))
 			}
 		},
 	}
@@ -214,12 +245,12 @@ func setupErrorHandlers() {
 			if flagName != "" {
 				cliErr := NewUnknownFlagError(flagName, cmd)
 				fmt.Fprintln(os.Stderr, cliErr.Format())
-				os.Exit(1)
+				os.Exit(domainErrors.ExitValidation)
 			}
 		}
 
 		// Fall back to default behavior
-		return err
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
 	})
 
 	// Enable command suggestions with low threshold
@@ -255,12 +286,26 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging (shortcut for --log-level verbose)")
 	RootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "Set log level: error, warn, info, step, steps, debug, verbose, noisy (default: info)")
 	RootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (for piping or logging)")
+	RootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress spinners, progress and decorative output (for scripts and CI)")
+	RootCmd.PersistentFlags().StringVar(&progressStream, "progress-stream", "", "Write NDJSON progress events to fd:N or a file (for GUIs and wrappers)")
+	RootCmd.PersistentFlags().BoolVar(&noDaemon, "no-daemon", false, "Run in-process even if an mcp-cli daemon is running")
+	RootCmd.PersistentFlags().BoolVar(&host.RefreshToolSchemas, "refresh-tools", false, "Ignore cached MCP tool lists and fetch them from the servers")
+	RootCmd.PersistentFlags().StringArrayVar(&host.RootDirs, "root", nil, "Project root directory advertised to MCP servers (repeatable; default: roots in settings, else the working directory)")
 
 	// Template-based workflow flags (only for root command, not subcommands)
 	RootCmd.Flags().StringVar(&workflowName, "workflow", "", "Execute workflow by name")
 	RootCmd.Flags().StringVar(&startFromStep, "start-from", "", "Start workflow from specific step (skips previous steps)")
 	RootCmd.Flags().StringVar(&endAtStep, "end-at", "", "End workflow at specific step (skips steps after)")
 	RootCmd.Flags().StringVar(&inputData, "input-data", "", "Input data for template (JSON or plain text)")
+	RootCmd.Flags().StringArrayVar(&workflowParams, "param", nil, "Workflow param value (name=value, repeatable)")
+	RootCmd.Flags().BoolVar(&recordRun, "record", false, "Record per-step outputs for 'mcp-cli runs diff'")
+	RootCmd.Flags().StringVar(&workflowBundle, "context-bundle", "", "Make a saved chat or workflow context available to the workflow as {{context}}")
+	RootCmd.Flags().StringVar(&exportContext, "export-context", "", "Save the run's input, params and step outputs as a context bundle")
+	RootCmd.Flags().BoolVar(&estimateOnly, "estimate", false, "Print the workflow's estimated cost and exit without running it")
+	RootCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Run without asking when the estimated cost is above cost_estimate.confirm_above")
+
+	// Dynamic shell completion for workflow, provider, server and skill names
+	registerCompletions()
 
 	// Custom error handlers for better UX
 	setupErrorHandlers()
@@ -271,11 +316,23 @@ func init() {
 	RootCmd.AddCommand(QueryCmd)
 	RootCmd.AddCommand(ServersCmd)
 	RootCmd.AddCommand(WorkflowsCmd) // List workflows
+	RootCmd.AddCommand(DescribeCmd)  // Describe workflows
 	RootCmd.AddCommand(SkillsCmd)    // List skills
 	RootCmd.AddCommand(EmbeddingsCmd)
 	RootCmd.AddCommand(RagCmd) // RAG operations
 	RootCmd.AddCommand(ConfigCmd)
-	RootCmd.AddCommand(InitCmd) // Setup wizard
+	RootCmd.AddCommand(SchemaCmd)  // JSON Schemas for editors
+	RootCmd.AddCommand(InitCmd)    // Setup wizard
+	RootCmd.AddCommand(DaemonCmd)  // Warm servers for repeated invocations
+	RootCmd.AddCommand(BenchCmd)   // Compare providers and models
+	RootCmd.AddCommand(PromptsCmd) // Prompt template library
+	RootCmd.AddCommand(EvalCmd)    // Dataset evaluation
+	RootCmd.AddCommand(RunsCmd)    // Recorded run comparison
+	RootCmd.AddCommand(SandboxCmd) // Skill sandbox diagnostics
+	RootCmd.AddCommand(DoctorCmd)  // Whole-setup diagnostics
+	RootCmd.AddCommand(StatsCmd)   // Local usage statistics
+	RootCmd.AddCommand(UsageCmd)   // Provider spend ledger
+	RootCmd.AddCommand(OutputsCmd) // Encrypted outputs
 	// Note: ServeCmd is added in serve.go's init() function
 
 	// Configuration-based initialization
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/runs"
+	workflow "github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/highlight"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runsDir               string
+	runsNoSimilarity      bool
+	runsStat              bool
+	runsContext           int
+	runsEmbeddingProvider string
+	runsEmbeddingModel    string
+	runsJSON              bool
+)
+
+// RunsCmd inspects recorded workflow runs
+var RunsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "List and compare recorded workflow runs",
+	Long: `Workflows run with --record (or execution.record_run: true) save each
+step's provider, model, prompt and output as run.json in the run artifacts
+directory (<outputs>/runs/<workflow>-<timestamp>).
+
+A run is referenced by its directory name, a directory path, or a workflow
+name: summarize is its latest recorded run, summarize~1 the one before.
+
+Examples:
+  mcp-cli --workflow summarize --record --input-data "..."
+  mcp-cli runs list summarize
+  mcp-cli runs diff summarize~1 summarize
+  mcp-cli runs diff summarize-20261016-101500 summarize-20261016-103000 --stat`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeRunsList("")
+	},
+}
+
+// RunsListCmd lists recorded runs
+var RunsListCmd = &cobra.Command{
+	Use:   "list [workflow]",
+	Short: "List recorded runs, newest first",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return executeRunsList(name)
+	},
+}
+
+// RunsDiffCmd compares two recorded runs step by step
+var RunsDiffCmd = &cobra.Command{
+	Use:   "diff <runA> <runB>",
+	Short: "Compare the per-step outputs of two recorded runs",
+	Long: `Compare two recorded runs step by step. For each step the table shows
+whether its output changed, an embedding similarity score of the two
+outputs (1.0 = same meaning), the lines added and removed, and which of
+provider, model or prompt differ. The line diff of every changed step
+follows unless --stat is given.
+
+Similarity uses the default embedding provider; pass --no-similarity to
+skip it.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeRunsDiff(args[0], args[1])
+	},
+}
+
+func init() {
+	RunsCmd.PersistentFlags().StringVar(&runsDir, "dir", "", "Run artifacts directory (default: <outputs>/runs)")
+	RunsDiffCmd.Flags().BoolVar(&runsNoSimilarity, "no-similarity", false, "Skip embedding similarity scores")
+	RunsDiffCmd.Flags().BoolVar(&runsStat, "stat", false, "Show only the per-step summary, not the diffs")
+	RunsDiffCmd.Flags().IntVarP(&runsContext, "unified", "U", runs.DefaultContext, "Context lines around each change")
+	RunsDiffCmd.Flags().StringVar(&runsEmbeddingProvider, "embedding-provider", "", "Embedding provider for similarity (default: configured default)")
+	RunsDiffCmd.Flags().StringVar(&runsEmbeddingModel, "embedding-model", "", "Embedding model for similarity")
+	RunsDiffCmd.Flags().BoolVarP(&runsJSON, "json", "j", false, "Output the comparison as JSON")
+
+	RunsCmd.AddCommand(RunsListCmd)
+	RunsCmd.AddCommand(RunsDiffCmd)
+}
+
+// resolveRunsDir returns --dir, or the runs directory under the configured
+// outputs directory
+func resolveRunsDir() string {
+	if runsDir != "" {
+		return runsDir
+	}
+	outputsDir := "/tmp/mcp-outputs"
+	if cfg, err := config.NewLoader().Load(configFile); err == nil && cfg.Skills != nil {
+		outputsDir = cfg.Skills.GetOutputsDir()
+	}
+	return filepath.Join(outputsDir, "runs")
+}
+
+func executeRunsList(name string) error {
+	dir := resolveRunsDir()
+	records, err := workflow.ListRunRecords(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RUN\tWORKFLOW\tSTARTED\tDURATION\tSTATUS\tSTEPS")
+	shown := 0
+	for _, record := range records {
+		if name != "" && record.Workflow != name {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.1fs\t%s\t%d\n",
+			record.Name(), record.Workflow, record.Started.Format("2006-01-02 15:04:05"),
+			float64(record.DurationMs)/1000, record.Status, len(record.Steps))
+		shown++
+	}
+
+	if shown == 0 {
+		fmt.Printf("No recorded runs in %s.\n", dir)
+		fmt.Println("Run a workflow with --record (or set execution.record_run: true) to record it.")
+		return nil
+	}
+	return tw.Flush()
+}
+
+func executeRunsDiff(refA, refB string) error {
+	dir := resolveRunsDir()
+	a, err := workflow.FindRunRecord(dir, refA)
+	if err != nil {
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+	b, err := workflow.FindRunRecord(dir, refB)
+	if err != nil {
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+
+	contextLines := runsContext
+	if contextLines == 0 {
+		contextLines = -1 // Options treats 0 as the default
+	}
+	opts := runs.Options{Context: contextLines}
+	if !runsNoSimilarity {
+		configService := infraConfig.NewService()
+		if _, err := configService.LoadConfig(configFile); err == nil {
+			embeddingService := embeddings.NewService(configService, ai.NewProviderFactory())
+			opts.Embed = func(ctx context.Context, text string) ([]float32, error) {
+				return embeddings.EmbedText(ctx, embeddingService, runsEmbeddingProvider, runsEmbeddingModel, text)
+			}
+		}
+	}
+
+	report := runs.Diff(context.Background(), a, b, opts)
+	if runsJSON {
+		return runs.WriteJSON(os.Stdout, report)
+	}
+	return runs.WriteSummary(os.Stdout, report, !runsStat, highlight.Render)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var sandboxDoctorJSON bool
+
+// SandboxCmd groups commands for the container sandbox skills run in
+var SandboxCmd = &cobra.Command{
+	Use:         "sandbox",
+	Short:       "Inspect the Docker/Podman sandbox used to run skills",
+	Annotations: map[string]string{skipConfigCheckAnnotation: "true"},
+}
+
+// SandboxDoctorCmd diagnoses why active skill execution is unavailable
+var SandboxDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose why skills can't run scripts (active mode)",
+	Long: `Checks every way mcp-cli can reach a container engine, in the order it
+tries them, and explains what to fix:
+
+  - DOCKER_HOST and CONTAINER_HOST (Podman remote), including ssh:// hosts
+  - The docker and podman CLIs
+  - The podman machine VM on macOS and Windows
+  - Docker and Podman sockets, rootful and rootless
+  - Whether a rootless engine can enforce the sandbox's resource limits
+  - Whether the outputs directory is writable
+
+No containers are started. Exits non-zero when active mode is unavailable.
+
+Examples:
+  mcp-cli sandbox doctor
+  DOCKER_HOST=tcp://buildbox:2376 mcp-cli sandbox doctor --json`,
+	Annotations: map[string]string{skipConfigCheckAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeSandboxDoctor()
+	},
+}
+
+func init() {
+	SandboxDoctorCmd.Flags().BoolVarP(&sandboxDoctorJSON, "json", "j", false, "Output the checks as JSON")
+	SandboxCmd.AddCommand(SandboxDoctorCmd)
+}
+
+func executeSandboxDoctor() error {
+	executorConfig := sandbox.DefaultConfig()
+	if cfg, err := config.NewLoader().Load(configFile); err == nil && cfg.Skills != nil {
+		executorConfig.OutputsDir = cfg.Skills.GetOutputsDir()
+	}
+
+	diagnosis := sandbox.Diagnose(executorConfig)
+
+	if sandboxDoctorJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diagnosis); err != nil {
+			return err
+		}
+	} else {
+		printDiagnosis(diagnosis)
+	}
+
+	if !diagnosis.Available {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func printDiagnosis(diagnosis *sandbox.Diagnosis) {
+	okColor := color.New(color.FgGreen)
+	warnColor := color.New(color.FgYellow)
+	failColor := color.New(color.FgRed, color.Bold)
+	skipColor := color.New(color.Faint)
+	fixColor := color.New(color.FgCyan)
+
+	for _, check := range diagnosis.Checks {
+		switch check.Status {
+		case sandbox.CheckOK:
+			okColor.Print("✓ ")
+		case sandbox.CheckWarn:
+			warnColor.Print("⚠ ")
+		case sandbox.CheckFail:
+			failColor.Print("✗ ")
+		default:
+			skipColor.Print("- ")
+		}
+		fmt.Printf("%-20s %s\n", check.Name, check.Detail)
+		if check.Fix != "" {
+			fixColor.Printf("  → %s\n", check.Fix)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/schema"
+	"github.com/spf13/cobra"
+)
+
+var schemaOutputDir string
+
+// SchemaCmd groups the JSON Schema commands
+var SchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "JSON Schemas for editor autocomplete and validation",
+	Long: `Export JSON Schemas for mcp-cli YAML files.
+
+Point yaml-language-server (VS Code YAML extension) at a schema to get
+autocomplete and validation while editing:
+
+  # yaml-language-server: $schema=../../schemas/workflow.schema.json
+  $schema: "workflow/v2.0"
+  name: my_workflow
+
+Available subcommands:
+  export - Write schemas to a directory or stdout
+
+Examples:
+  mcp-cli schema export --output-dir schemas
+  mcp-cli schema export workflow > workflow.schema.json`,
+	Annotations: map[string]string{skipConfigCheckAnnotation: "true"},
+}
+
+// SchemaExportCmd writes JSON Schemas for the configuration file types
+var SchemaExportCmd = &cobra.Command{
+	Use:       "export [workflow|runas|server|provider|config]...",
+	Short:     "Export JSON Schemas for workflow, runas, server, provider and config files",
+	ValidArgs: schema.Kinds(),
+	Args:      cobra.OnlyValidArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeSchemaExport(args)
+	},
+	Annotations: map[string]string{skipConfigCheckAnnotation: "true"},
+}
+
+func init() {
+	SchemaExportCmd.Flags().StringVarP(&schemaOutputDir, "output-dir", "o", "", "Directory to write <kind>.schema.json files (default: stdout, single schema only)")
+	SchemaCmd.AddCommand(SchemaExportCmd)
+}
+
+func executeSchemaExport(kinds []string) error {
+	if len(kinds) == 0 {
+		kinds = schema.Kinds()
+	}
+
+	// Single schema to stdout
+	if schemaOutputDir == "" {
+		if len(kinds) != 1 {
+			return domainErrors.Categorize(fmt.Errorf("exporting %d schemas requires --output-dir (or name a single schema: %v)", len(kinds), schema.Kinds()), domainErrors.ErrValidation)
+		}
+		data, err := schema.GenerateJSON(kinds[0])
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.MkdirAll(schemaOutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, kind := range kinds {
+		data, err := schema.GenerateJSON(kind)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(schemaOutputDir, schema.FileName(kind))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+	}
+
+	return nil
+}
@@ -2,14 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
+	domainSkills "github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
 	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jobqueue"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tasks"
@@ -22,7 +27,12 @@ import (
 
 var (
 	// Serve command flags
-	serveConfig string
+	serveConfig    string
+	serveTransport string
+	serveHTTPAddr  string
+
+	// serveQueue bounds tool calls across every transport (nil: unbounded)
+	serveQueue *jobqueue.Queue
 )
 
 // ServeCmd represents the serve command
@@ -53,6 +63,26 @@ Example usage:
   # Using the --serve flag
   mcp-cli --serve config/runas/data_analyst.yaml
 
+  # Also listen on Streamable HTTP for web-based MCP clients
+  mcp-cli serve --http-addr 127.0.0.1:8080 config/runas/research_agent.yaml
+
+  # HTTP only (no stdio), e.g. as a background service
+  mcp-cli serve --transport http config/runas/research_agent.yaml
+
+Streamable HTTP:
+  Add an http section to the runas config (runas_type mcp or mcp-skills) to
+  serve the same tools at http://<host>:<port>/mcp alongside stdio:
+
+  http:
+    host: 127.0.0.1        # default
+    port: 8080             # default
+    path: /mcp             # default
+    session_timeout: 30m   # default
+    allowed_origins: ["https://app.example.com"]   # default: localhost only
+    tls:
+      cert_file: /etc/mcp/cert.pem
+      key_file: /etc/mcp/key.pem
+
 Claude Desktop Configuration:
   Add to your Claude Desktop config (claude_desktop_config.json):
   
@@ -183,6 +213,9 @@ Claude Desktop Configuration:
 					InputMapping: map[string]string{
 						"input_data": "{{input_data}}",
 					},
+					Priority: templateSrc.Priority,
+					Async:    templateSrc.Async,
+					Output:   templateSrc.Output,
 				}
 
 				// Add to tools array
@@ -289,11 +322,11 @@ Claude Desktop Configuration:
 						},
 						"code": map[string]interface{}{
 							"type":        "string",
-							"description": "Code to execute (Python or Bash). Can import from 'scripts' module to use skill helper libraries.",
+							"description": "Code to execute (Python or Bash). Can import from 'scripts' module to use skill helper libraries. Write JSON to /outputs/result.json to return structured data, separate from stdout and stderr.",
 						},
 						"files": map[string]interface{}{
 							"type":        "object",
-							"description": "Optional files to make available in workspace (filename -> base64 content)",
+							"description": "Optional files to make available in workspace (filename -> text or base64 content). Types and sizes are listed in /workspace/_manifest.json",
 						},
 					},
 					"required": []string{"skill_name", "code"},
@@ -302,13 +335,21 @@ Claude Desktop Configuration:
 
 			runasConfig.Tools = append(runasConfig.Tools, executeCodeTool)
 
+			// Reference files are read on demand (see domainSkills.ReadReferenceToolDescription)
+			runasConfig.Tools = append(runasConfig.Tools, runas.ToolExposure{
+				Name:        "read_skill_reference",
+				Description: domainSkills.ReadReferenceToolDescription,
+				Template:    "read_skill_reference",
+				InputSchema: domainSkills.ReadReferenceInputSchema(),
+			})
+
 			logging.Info("Generated %d MCP tools from skills (including execute_skill_code)", len(runasConfig.Tools))
 		}
 
 		// Validate templates exist (skip for special skill templates)
 		for i, tool := range runasConfig.Tools {
 			// Skip validation for special skill-related templates
-			if tool.Template == "load_skill" || tool.Template == "execute_skill_code" {
+			if tool.Template == "load_skill" || tool.Template == "execute_skill_code" || tool.Template == "read_skill_reference" {
 				continue
 			}
 
@@ -339,11 +380,92 @@ Claude Desktop Configuration:
 			return startProxyServer(runasConfig, appConfig, configService, skillService)
 		}
 
-		// Default: Start stdio MCP server
+		useStdio, httpConfig, err := resolveServeTransports(runasConfig)
+		if err != nil {
+			return err
+		}
+
+		if q := runasConfig.Queue; q != nil {
+			serveQueue = jobqueue.New(jobqueue.Options{
+				MaxConcurrent: q.MaxConcurrent,
+				MaxDepth:      q.MaxDepth,
+				Policy:        q.Backpressure,
+			})
+			opts := serveQueue.Options()
+			logging.Info("Tool call queue: %d concurrent, %d waiting, backpressure: %s",
+				opts.MaxConcurrent, opts.MaxDepth, opts.Policy)
+		}
+
+		if httpConfig == nil {
+			// Default: Start stdio MCP server
+			return startStdioServer(runasConfig, appConfig, configService, skillService)
+		}
+		if !useStdio {
+			return startHTTPServer(httpConfig, runasConfig, appConfig, configService, skillService)
+		}
+
+		// Dual transport: HTTP in the background, stdio in the foreground
+		go func() {
+			if err := startHTTPServer(httpConfig, runasConfig, appConfig, configService, skillService); err != nil {
+				logging.Error("HTTP server error: %v", err)
+			}
+		}()
 		return startStdioServer(runasConfig, appConfig, configService, skillService)
 	},
 }
 
+// resolveServeTransports decides which transports to serve from --transport,
+// --http-addr and the runas http section. It returns whether to serve stdio
+// and the HTTP settings, or nil when HTTP is off.
+func resolveServeTransports(runasConfig *runas.RunAsConfig) (bool, *runas.HTTPConfig, error) {
+	httpConfig := runasConfig.HTTP
+	if serveHTTPAddr != "" {
+		host, portStr, err := net.SplitHostPort(serveHTTPAddr)
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid --http-addr '%s': expected host:port", serveHTTPAddr)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid --http-addr port '%s'", portStr)
+		}
+		if httpConfig == nil {
+			httpConfig = &runas.HTTPConfig{}
+		}
+		httpConfig.Host = host
+		httpConfig.Port = port
+	}
+
+	isMCPType := runasConfig.RunAsType == runas.RunAsTypeMCP || runasConfig.RunAsType == runas.RunAsTypeMCPSkills
+	if httpConfig != nil && !isMCPType {
+		return false, nil, fmt.Errorf("HTTP transport is only supported for runas_type 'mcp' and 'mcp-skills'")
+	}
+
+	useStdio := true
+	switch serveTransport {
+	case "":
+		// stdio, plus HTTP when configured
+	case "stdio":
+		httpConfig = nil
+	case "http", "both":
+		if !isMCPType {
+			return false, nil, fmt.Errorf("HTTP transport is only supported for runas_type 'mcp' and 'mcp-skills'")
+		}
+		if httpConfig == nil {
+			httpConfig = &runas.HTTPConfig{}
+		}
+		useStdio = serveTransport == "both"
+	default:
+		return false, nil, fmt.Errorf("invalid --transport '%s' (valid: stdio, http, both)", serveTransport)
+	}
+
+	if httpConfig != nil {
+		if err := httpConfig.Validate(); err != nil {
+			return false, nil, fmt.Errorf("invalid http config: %w", err)
+		}
+	}
+	return useStdio, httpConfig, nil
+}
+
 // startProxyServer starts an HTTP proxy server
 func startProxyServer(runasConfig *runas.RunAsConfig, appConfig *config.ApplicationConfig, configService *infraConfig.Service, skillService *skillsvc.Service) error {
 	logging.Info("Starting HTTP proxy server on port %d", runasConfig.ProxyConfig.Port)
@@ -385,6 +507,9 @@ func startStdioServer(runasConfig *runas.RunAsConfig, appConfig *config.Applicat
 	// Create server service
 	service := serverService.NewService(runasConfig, appConfig, configService, skillService)
 	service.SetTaskManager(taskManager)
+	if runasConfig.Queue != nil {
+		service.SetQueue(serveQueue, runasConfig.Queue.GetMaxWait())
+	}
 
 	// Create stdio server
 	stdioServer := server.NewStdioServer(service)
@@ -401,6 +526,80 @@ func startStdioServer(runasConfig *runas.RunAsConfig, appConfig *config.Applicat
 	return nil
 }
 
+// startHTTPServer starts a Streamable HTTP MCP server (blocks until shutdown)
+func startHTTPServer(httpConfig *runas.HTTPConfig, runasConfig *runas.RunAsConfig, appConfig *config.ApplicationConfig, configService *infraConfig.Service, skillService *skillsvc.Service) error {
+	// Create task manager
+	taskManager := tasks.NewManager(30*time.Minute, 2*time.Hour, 5000)
+	defer taskManager.Close()
+
+	// Create server service (separate instance for HTTP sessions)
+	service := serverService.NewService(runasConfig, appConfig, configService, skillService)
+	service.SetTaskManager(taskManager)
+	if runasConfig.Queue != nil {
+		service.SetQueue(serveQueue, runasConfig.Queue.GetMaxWait())
+	}
+
+	opts := server.HTTPServerOptions{
+		Addr:           httpConfig.Addr(),
+		Path:           httpConfig.Path,
+		AllowedOrigins: httpConfig.AllowedOrigins,
+		SessionTimeout: httpConfig.GetSessionTimeout(),
+	}
+	if httpConfig.TLS != nil {
+		opts.CertFile = httpConfig.TLS.CertFile
+		opts.KeyFile = httpConfig.TLS.KeyFile
+	}
+	if auth := httpConfig.Auth; auth != nil {
+		opts.ClientCAFile = auth.ClientCAFile
+		for _, client := range auth.Clients {
+			tools := client.Tools
+			if len(tools) > 0 && runasConfig.HasAsyncTools() {
+				// Scoped clients can still poll the async runs they start
+				tools = append(append([]string{}, tools...), serverService.GetRunResultToolName)
+			}
+			opts.Clients = append(opts.Clients, server.HTTPClient{
+				Name:           client.Name,
+				Token:          client.Token,
+				CertCommonName: client.CertCommonName,
+				Tools:          tools,
+				Tenant:         client.Tenant,
+			})
+		}
+		if httpConfig.TLS == nil {
+			logging.Warn("HTTP auth tokens are sent in plain text without tls; configure http.tls for non-local clients")
+		}
+		logging.Info("HTTP authentication enabled for %d client(s)", len(opts.Clients))
+	}
+	if len(httpConfig.Tenants) > 0 {
+		names := make([]string, 0, len(httpConfig.Tenants))
+		for name := range httpConfig.Tenants {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			tools := httpConfig.Tenants[name].Tools
+			if len(tools) > 0 && runasConfig.HasAsyncTools() {
+				tools = append(append([]string{}, tools...), serverService.GetRunResultToolName)
+			}
+			opts.Tenants = append(opts.Tenants, server.HTTPTenant{Name: name, Tools: tools})
+		}
+		serverService.WarnUnknownTenantProviders(appConfig, httpConfig.Tenants)
+		logging.Info("HTTP server hosting %d tenant(s): %s", len(names), strings.Join(names, ", "))
+	}
+	if serveQueue != nil {
+		opts.Metrics = serveQueue
+	}
+	httpServer := server.NewHTTPServer(service, opts)
+
+	// Progress notifications are streamed to clients that accept SSE
+	service.SetProgressNotifier(httpServer)
+
+	if err := httpServer.Start(); err != nil {
+		return fmt.Errorf("HTTP server error: %w", err)
+	}
+	return nil
+}
+
 // startUnixSocketServer starts a Unix socket MCP server
 func startUnixSocketServer(socketPath string, runasConfig *runas.RunAsConfig, appConfig *config.ApplicationConfig, configService *infraConfig.Service, skillService *skillsvc.Service) error {
 	logging.Info("Starting Unix socket MCP server on: %s", socketPath)
@@ -412,6 +611,9 @@ func startUnixSocketServer(socketPath string, runasConfig *runas.RunAsConfig, ap
 	// Create server service (separate instance for socket connections)
 	service := serverService.NewService(runasConfig, appConfig, configService, skillService)
 	service.SetTaskManager(taskManager)
+	if runasConfig.Queue != nil {
+		service.SetQueue(serveQueue, runasConfig.Queue.GetMaxWait())
+	}
 
 	// Create Unix socket server
 	socketServer := server.NewUnixSocketServer(service, socketPath)
@@ -427,5 +629,7 @@ func startUnixSocketServer(socketPath string, runasConfig *runas.RunAsConfig, ap
 
 func init() {
 	ServeCmd.Flags().StringVar(&serveConfig, "serve", "", "Path to runas config file")
+	ServeCmd.Flags().StringVar(&serveTransport, "transport", "", "Transports to serve: stdio, http or both (default: stdio, plus http when the runas config has an http section)")
+	ServeCmd.Flags().StringVar(&serveHTTPAddr, "http-addr", "", "Serve Streamable HTTP on host:port in addition to stdio (overrides http.host/port)")
 	RootCmd.AddCommand(ServeCmd)
 }
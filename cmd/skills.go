@@ -138,9 +138,7 @@ func executeListSkills() error {
 			yellow.Println("  ⚠️  WARNING: Docker/Podman not available")
 			yellow.Println("     These skills will run in PASSIVE mode (documentation only)")
 			fmt.Println()
-			fmt.Println("  To enable script execution:")
-			fmt.Println("    • Install Docker: https://docs.docker.com/get-docker/")
-			fmt.Println("    • OR Podman: https://podman.io/getting-started/installation")
+			fmt.Println("  Run 'mcp-cli doctor' to see what to fix")
 		}
 
 		fmt.Println()
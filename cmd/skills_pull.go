@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
+	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var skillsPullPin bool
+
+// SkillsPullCmd pre-pulls the images skills run in
+var SkillsPullCmd = &cobra.Command{
+	Use:   "pull [skill...]",
+	Short: "Pre-pull skill images and verify pinned digests",
+	Long: `Pull every image referenced in skill-images.yaml (or only those used by
+the named skills), so the first execute_skill_code call doesn't wait on a
+download and hosts without registry access can run skills.
+
+Images with a digest: are pulled by digest and verified. Images built
+locally from a Dockerfile can't be pulled; they are reported as local if
+already present.
+
+--pin writes the digest of each unpinned image back into skill-images.yaml
+(the repository digest, or the image ID for local builds). Pinned images are
+verified before every execution, and a tag moved afterwards can't change
+what runs. Set require_digest: true under defaults to refuse unpinned images,
+and pull_policy: never to stop mcp-cli pulling at run time.
+
+Examples:
+  mcp-cli skills pull
+  mcp-cli skills pull docx pptx
+  mcp-cli skills pull --pin`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeSkillsPull(cmd.Context(), args)
+	},
+}
+
+func init() {
+	SkillsPullCmd.Flags().BoolVar(&skillsPullPin, "pin", false, "Write the digests of unpinned images into skill-images.yaml")
+	SkillsCmd.AddCommand(SkillsPullCmd)
+}
+
+// skillImage is an image referenced in skill-images.yaml
+type skillImage struct {
+	image  string
+	digest string   // Pinned digest, "" if unpinned
+	owners []string // Skill names using it; "" is the defaults entry
+}
+
+func executeSkillsPull(ctx context.Context, skillNames []string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	appConfig, _ := config.NewLoader().Load(configFile)
+	skillsDir, err := infraSkills.ResolveSkillsDirectory(configFile, appConfig)
+	if err != nil {
+		return err
+	}
+	mappingPath := filepath.Join(skillsDir, "skill-images.yaml")
+	mapping, err := skillsvc.LoadSkillImageMapping(mappingPath)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", mappingPath, err)
+	}
+
+	images := collectSkillImages(mapping, skillNames)
+
+	executor, err := sandbox.DetectExecutor(sandbox.DefaultConfig())
+	if err != nil {
+		return err
+	}
+	manager, ok := executor.(sandbox.ImageManager)
+	if !ok {
+		return fmt.Errorf("%s can't pull images", executor.GetInfo())
+	}
+
+	okColor := color.New(color.FgGreen)
+	warnColor := color.New(color.FgYellow)
+	failColor := color.New(color.FgRed, color.Bold)
+
+	pins := make(map[string]string)
+	failed := 0
+	for _, img := range images {
+		local, warning, err := pullSkillImage(ctx, manager, img)
+		switch {
+		case err != nil:
+			failColor.Print("✗ ")
+			fmt.Printf("%s: %v\n", img.image, err)
+			failed++
+			continue
+		case warning != "":
+			warnColor.Print("⚠ ")
+			fmt.Printf("%s: %s\n", img.image, warning)
+		default:
+			okColor.Print("✓ ")
+			if img.digest != "" {
+				fmt.Printf("%s@%s\n", sandbox.Repository(img.image), img.digest)
+			} else {
+				fmt.Println(img.image)
+			}
+		}
+
+		_, refDigest := sandbox.SplitDigest(img.image)
+		if skillsPullPin && img.digest == "" && refDigest == "" {
+			for _, owner := range img.owners {
+				pins[owner] = local.DigestFor(img.image)
+			}
+		}
+	}
+
+	if len(pins) > 0 {
+		if err := skillsvc.PinDigests(mappingPath, pins); err != nil {
+			return fmt.Errorf("failed to pin digests: %w", err)
+		}
+		fmt.Printf("\nPinned %d image(s) in %s\n", len(pins), mappingPath)
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d image(s) failed\n", failed, len(images))
+		os.Exit(1)
+	}
+	return nil
+}
+
+// collectSkillImages returns the distinct images for the named skills, or
+// for every skill and the defaults if none are named
+func collectSkillImages(mapping *skillsvc.SkillImageMapping, skillNames []string) []*skillImage {
+	owners := skillNames
+	if len(owners) == 0 {
+		owners = []string{""}
+		for name := range mapping.Skills {
+			owners = append(owners, name)
+		}
+		sort.Strings(owners[1:])
+	}
+
+	var images []*skillImage
+	byKey := make(map[string]*skillImage)
+	for _, name := range owners {
+		image, digest := mapping.Defaults.Image, mapping.Defaults.Digest
+		owner := ""
+		if name != "" {
+			image, digest = mapping.GetImageForSkill(name), mapping.GetDigestForSkill(name)
+			if spec, ok := mapping.Skills[name]; ok && spec != nil && spec.Image != "" {
+				owner = name
+			}
+		}
+
+		key := image + "|" + digest
+		img, ok := byKey[key]
+		if !ok {
+			img = &skillImage{image: image, digest: digest}
+			byKey[key] = img
+			images = append(images, img)
+		}
+		if !containsString(img.owners, owner) {
+			img.owners = append(img.owners, owner)
+		}
+	}
+	return images
+}
+
+// pullSkillImage pulls an image, by digest when pinned, and verifies it.
+// Images that can't be pulled but are present locally, such as images
+// built from a skill's Dockerfile, are returned with a warning.
+func pullSkillImage(ctx context.Context, manager sandbox.ImageManager, img *skillImage) (*sandbox.LocalImage, string, error) {
+	name, digest := sandbox.SplitDigest(img.image)
+	if img.digest != "" {
+		digest = img.digest
+	}
+
+	refs := []string{img.image}
+	if digest != "" {
+		refs = []string{sandbox.Repository(name) + "@" + digest, digest}
+	}
+
+	pullErr := manager.PullImage(ctx, refs[0])
+
+	var local *sandbox.LocalImage
+	for _, ref := range refs {
+		found, err := manager.InspectImage(ctx, ref)
+		if err != nil {
+			return nil, "", err
+		}
+		if found != nil {
+			local = found
+			break
+		}
+	}
+
+	if local == nil {
+		if pullErr != nil {
+			return nil, "", pullErr
+		}
+		return nil, "", fmt.Errorf("not present after pulling %s", refs[0])
+	}
+	if digest != "" && !local.Matches(digest) {
+		return nil, "", fmt.Errorf("does not match pinned digest %s (found %s)", digest, local.ID)
+	}
+	if pullErr != nil {
+		return local, fmt.Sprintf("not pulled, using local image %s (%s)", shortID(local.ID), firstErrorLine(pullErr)), nil
+	}
+	return local, "", nil
+}
+
+// shortID shortens an image ID for display
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// firstErrorLine returns the first line of an error message
+func firstErrorLine(err error) string {
+	return strings.TrimSpace(strings.SplitN(err.Error(), "\n", 2)[0])
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/stats"
+	"github.com/spf13/cobra"
+)
+
+var statsJSON bool
+
+// StatsCmd shows the local usage statistics
+var StatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show local usage statistics (opt-in, never sent anywhere)",
+	Long: `Shows how often you ran each command, calls per provider and model with
+their tokens and estimated cost, and failures by category.
+
+Statistics are off until you run 'mcp-cli stats enable'. They are kept in a
+local file (~/.config/mcp-cli/stats.json on Linux, or $MCP_CLI_STATS_FILE)
+and never sent anywhere. Costs are estimated from each provider's
+cost_per_1k_input_tokens and cost_per_1k_output_tokens.
+
+Examples:
+  mcp-cli stats enable
+  mcp-cli stats
+  mcp-cli stats --json
+  mcp-cli stats reset
+  mcp-cli stats disable        # stops collecting and deletes the file`,
+	Annotations: map[string]string{skipConfigCheckAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeStats()
+	},
+}
+
+var statsEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Start collecting usage statistics",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := stats.Enable()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Usage statistics enabled: %s\n", path)
+		return nil
+	},
+}
+
+var statsDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Stop collecting usage statistics and delete them",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := stats.Disable()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Usage statistics disabled; removed %s\n", path)
+		return nil
+	},
+}
+
+var statsResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Clear the collected usage statistics",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !stats.Enabled() {
+			return fmt.Errorf("usage statistics are not enabled (run 'mcp-cli stats enable')")
+		}
+		path, err := stats.Reset()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Usage statistics cleared: %s\n", path)
+		return nil
+	},
+}
+
+func init() {
+	StatsCmd.Flags().BoolVarP(&statsJSON, "json", "j", false, "Output the statistics as JSON")
+	for _, sub := range []*cobra.Command{statsEnableCmd, statsDisableCmd, statsResetCmd} {
+		sub.Annotations = map[string]string{skipConfigCheckAnnotation: "true"}
+		StatsCmd.AddCommand(sub)
+	}
+}
+
+func executeStats() error {
+	path := stats.Path()
+	if !stats.Enabled() {
+		fmt.Println("Usage statistics are not enabled. Run 'mcp-cli stats enable' to start")
+		fmt.Println("collecting them; they are kept locally and never sent anywhere.")
+		return nil
+	}
+	s, err := stats.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if statsJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(s)
+	}
+
+	fmt.Printf("Usage since %s (%s)\n", s.Since.Local().Format("2006-01-02 15:04"), path)
+
+	fmt.Println("\nCommands")
+	if len(s.Commands) == 0 {
+		fmt.Println("  none yet")
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, name := range sortedByCount(s.Commands) {
+			fmt.Fprintf(tw, "  %s\t%d\n", name, s.Commands[name])
+		}
+		tw.Flush()
+	}
+
+	fmt.Println("\nProviders")
+	if len(s.Providers) == 0 {
+		fmt.Println("  none yet")
+	} else {
+		keys := make([]string, 0, len(s.Providers))
+		for key := range s.Providers {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			a, b := s.Providers[keys[i]], s.Providers[keys[j]]
+			if a.Calls != b.Calls {
+				return a.Calls > b.Calls
+			}
+			return keys[i] < keys[j]
+		})
+
+		var total stats.ProviderStats
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  PROVIDER/MODEL\tCALLS\tFAILED\tPROMPT TOKENS\tCOMPLETION TOKENS\tEST. COST")
+		for _, key := range keys {
+			p := s.Providers[key]
+			fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t%d\t$%.4f\n", key, p.Calls, p.Failures, p.PromptTokens, p.CompletionTokens, p.CostUSD)
+			total.Calls += p.Calls
+			total.Failures += p.Failures
+			total.PromptTokens += p.PromptTokens
+			total.CompletionTokens += p.CompletionTokens
+			total.CostUSD += p.CostUSD
+		}
+		if len(keys) > 1 {
+			fmt.Fprintf(tw, "  total\t%d\t%d\t%d\t%d\t$%.4f\n", total.Calls, total.Failures, total.PromptTokens, total.CompletionTokens, total.CostUSD)
+		}
+		tw.Flush()
+	}
+
+	fmt.Println("\nFailures")
+	if len(s.Failures) == 0 {
+		fmt.Println("  none")
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, category := range sortedByCount(s.Failures) {
+			fmt.Fprintf(tw, "  %s\t%d\n", category, s.Failures[category])
+		}
+		tw.Flush()
+	}
+	return nil
+}
+
+// sortedByCount returns the keys of counts, most frequent first
+func sortedByCount(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// statsCommandName names a command for the usage stats: its path below
+// mcp-cli, with the default command counted as chat or workflow. Help,
+// completion and the stats commands themselves aren't counted.
+func statsCommandName(cmd *cobra.Command) string {
+	if !cmd.HasParent() {
+		if workflowName != "" {
+			return "workflow"
+		}
+		return "chat"
+	}
+	switch cmd.Name() {
+	case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return ""
+	}
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if name == "stats" || strings.HasPrefix(name, "stats ") {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/usage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	usageMonth string
+	usageJSON  bool
+)
+
+// UsageCmd reports on the local usage ledger
+var UsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Report provider spend from the local usage ledger",
+	Long: `Every provider call is recorded in a local ledger with its provider,
+model, workflow, tokens and estimated cost: one file per month in
+~/.config/mcp-cli/usage on Linux (or $MCP_CLI_USAGE_DIR; set it to "off" to
+stop recording). Nothing is sent anywhere.
+
+Costs are estimated from each provider's cost_per_1k_input_tokens,
+cost_per_1k_output_tokens and embedding models' cost_per_1k_tokens. A
+provider's monthly_budget (USD) stops calls to it once its spend this month
+reaches the budget.
+
+Examples:
+  mcp-cli usage report
+  mcp-cli usage report --month 2026-09
+  mcp-cli usage report --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+// UsageReportCmd summarizes a month's spend
+var UsageReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize a month's spend by provider, workflow and model",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeUsageReport()
+	},
+}
+
+func init() {
+	UsageReportCmd.Flags().StringVar(&usageMonth, "month", "", "Month to report, as YYYY-MM (default: this month)")
+	UsageReportCmd.Flags().BoolVarP(&usageJSON, "json", "j", false, "Output the report as JSON")
+	UsageCmd.Annotations = map[string]string{skipConfigCheckAnnotation: "true"}
+	UsageReportCmd.Annotations = map[string]string{skipConfigCheckAnnotation: "true"}
+	UsageCmd.AddCommand(UsageReportCmd)
+}
+
+func executeUsageReport() error {
+	dir := usage.Dir()
+	if dir == "" {
+		return domainErrors.Categorize(fmt.Errorf("the usage ledger is off (%s=off)", usage.DirEnv), domainErrors.ErrValidation)
+	}
+	month := usageMonth
+	if month == "" {
+		month = usage.CurrentMonth()
+	}
+	entries, err := usage.Load(dir, month)
+	if err != nil {
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+
+	// Budgets are shown when the config loads; the report works without it
+	var budgets map[string]float64
+	if appConfig, err := infraConfig.NewService().LoadConfig(configFile); err == nil {
+		budgets = appConfig.MonthlyBudgets()
+	}
+	report := usage.Summarize(month, entries, budgets)
+
+	if usageJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Printf("Usage for %s (%s)\n", month, usage.MonthFile(dir, month))
+	if len(entries) == 0 {
+		fmt.Println("  no provider calls recorded")
+		return nil
+	}
+
+	fmt.Println("\nProviders")
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  PROVIDER\tCALLS\tFAILED\tPROMPT TOKENS\tCOMPLETION TOKENS\tEST. COST\tBUDGET")
+	for _, name := range usage.ByCost(report.Providers) {
+		t := report.Providers[name]
+		budget := "-"
+		if limit, ok := report.Budgets[name]; ok {
+			budget = fmt.Sprintf("$%.2f (%.0f%%)", limit, t.CostUSD/limit*100)
+		}
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t%d\t$%.4f\t%s\n", name, t.Calls, t.Failures, t.PromptTokens, t.CompletionTokens, t.CostUSD, budget)
+	}
+	fmt.Fprintf(tw, "  total\t%d\t%d\t%d\t%d\t$%.4f\t\n", report.Total.Calls, report.Total.Failures, report.Total.PromptTokens, report.Total.CompletionTokens, report.Total.CostUSD)
+	tw.Flush()
+
+	fmt.Println("\nWorkflows")
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, name := range usage.ByCost(report.Workflows) {
+		t := report.Workflows[name]
+		if name == "" {
+			name = "(no workflow)"
+		}
+		fmt.Fprintf(tw, "  %s\t%d calls\t$%.4f\n", name, t.Calls, t.CostUSD)
+	}
+	tw.Flush()
+
+	fmt.Println("\nModels")
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, name := range usage.ByCost(report.Models) {
+		t := report.Models[name]
+		fmt.Fprintf(tw, "  %s\t%d calls\t%d in / %d out tokens\t$%.4f\n", name, t.Calls, t.PromptTokens, t.CompletionTokens, t.CostUSD)
+	}
+	tw.Flush()
+
+	if len(report.Tenants) > 0 {
+		fmt.Println("\nTenants")
+		tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, name := range usage.ByCost(report.Tenants) {
+			t := report.Tenants[name]
+			fmt.Fprintf(tw, "  %s\t%d calls\t$%.4f\n", name, t.Calls, t.CostUSD)
+		}
+		tw.Flush()
+	}
+
+	if len(report.OverBudget) > 0 && month == usage.CurrentMonth() {
+		fmt.Printf("\nOver budget: %s (calls fail until next month or a higher monthly_budget)\n", strings.Join(report.OverBudget, ", "))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
@@ -14,13 +15,16 @@ import (
 
 	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
 
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/contextbundle"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/builtintools"
 	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolselect"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
-	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
 	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
 	workflow "github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
@@ -29,12 +33,18 @@ import (
 
 // WorkflowsCmd lists all available workflows
 var WorkflowsCmd = &cobra.Command{
-	Use:   "workflows",
-	Short: "List all available workflows",
+	Use:     "workflows",
+	Aliases: []string{"workflow"},
+	Short:   "List all available workflows",
 	Long: `List all workflow templates configured in the system.
 
 Workflows are defined in YAML files in config/workflows/ directory.
-Use these workflow names with --workflow flag on the root command.`,
+Use these workflow names with --workflow flag on the root command.
+
+Subcommands:
+  pack     Bundle a workflow into a shareable archive
+  install  Install a workflow pack from a file, URL or git repository
+  migrate  Upgrade workflow files to the current spec_version`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return executeListWorkflows()
 	},
@@ -53,6 +63,11 @@ func resolveLogLevel(workflowConfigLevel string) string {
 		return "verbose"
 	}
 
+	// --quiet keeps only errors and step results
+	if quiet {
+		return "error"
+	}
+
 	// Third priority: workflow configuration
 	if workflowConfigLevel != "" {
 		return workflowConfigLevel
@@ -93,28 +108,57 @@ func executeWorkflow() error {
 	if !exists {
 		available := appConfig.ListWorkflows()
 		if len(available) == 0 {
-			return fmt.Errorf("no workflows configured. Add YAML files to config/workflows/")
+			return domainErrors.Categorize(fmt.Errorf("no workflows configured. Add YAML files to config/workflows/"), domainErrors.ErrValidation)
 		}
-		return fmt.Errorf("workflow '%s' not found. Available workflows: %v", workflowName, available)
+		return domainErrors.Categorize(fmt.Errorf("workflow '%s' not found. Available workflows: %v", workflowName, available), domainErrors.ErrValidation)
 	}
 
 	// 2.5. Validate workflow structure BEFORE execution
 	if err := workflow.ValidateWorkflow(wf); err != nil {
-		return fmt.Errorf("workflow validation failed:\n%w", err)
+		return domainErrors.Categorize(fmt.Errorf("workflow validation failed:\n%w", err), domainErrors.ErrValidation)
 	}
 	logging.Debug("Workflow validation passed")
 
-	// 3. Get input data
-	inputData, err := getInputData()
+	// 3. Get input data; large stdin is spooled to a temp file
+	inputData, inputFile, err := getInputData(wf.InputSpoolThreshold())
 	if err != nil {
 		return fmt.Errorf("failed to get input data: %w", err)
 	}
+	if inputFile != "" {
+		defer os.Remove(inputFile)
+	}
+
+	params, err := parseWorkflowParams(workflowParams)
+	if err != nil {
+		return err
+	}
+	if recordRun {
+		wf.Execution.RecordRun = true
+	}
+
+	// Estimate the cost before anything is spent
+	inputBytes := len(inputData)
+	if inputFile != "" {
+		if info, err := os.Stat(inputFile); err == nil {
+			inputBytes = int(info.Size())
+		}
+	}
+	if proceed, err := checkCostEstimate(workflow.EstimateWorkflowCost(wf, appConfig, inputBytes), appConfig.CostEstimate); !proceed {
+		return err
+	}
+
+	var bundle *contextbundle.Bundle
+	if workflowBundle != "" {
+		if bundle, err = contextbundle.Load(workflowBundle); err != nil {
+			return domainErrors.Categorize(err, domainErrors.ErrValidation)
+		}
+	}
 
 	// 4. Collect servers needed from workflow steps
-	servers := collectServersFromWorkflow(wf, appConfig)
+	servers := workflow.RequiredServers(wf, appConfig.RAG)
 
 	// 5. Collect skills needed from workflow steps
-	skills := collectSkillsFromWorkflow(wf)
+	skills := workflow.RequiredSkills(wf)
 
 	// Override with command-line flag if provided
 	if skillNames != "" {
@@ -128,9 +172,22 @@ func executeWorkflow() error {
 
 	// 6. Execute workflow (with or without servers)
 	if len(servers) == 0 {
-		return executeWorkflowWithoutServers(wf, workflowName, inputData, appConfig, skills, startFromStep, endAtStep)
+		return executeWorkflowWithoutServers(wf, workflowName, inputData, inputFile, params, bundle, appConfig, skills, startFromStep, endAtStep)
+	}
+	return executeWorkflowWithServers(wf, workflowName, inputData, inputFile, params, bundle, appConfig, servers, skills, startFromStep, endAtStep)
+}
+
+// parseWorkflowParams parses --param name=value flags
+func parseWorkflowParams(flags []string) (map[string]string, error) {
+	params := make(map[string]string, len(flags))
+	for _, kv := range flags {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, domainErrors.Categorize(fmt.Errorf("invalid --param '%s': expected name=value", kv), domainErrors.ErrValidation)
+		}
+		params[strings.TrimSpace(name)] = value
 	}
-	return executeWorkflowWithServers(wf, workflowName, inputData, appConfig, servers, skills, startFromStep, endAtStep)
+	return params, nil
 }
 
 // initializeProvider creates the LLM provider for the workflow
@@ -148,6 +205,18 @@ func initializeProvider(appConfig *config.ApplicationConfig, configService *infr
 		actualProviderName = wf.Execution.Providers[0].Provider
 	}
 
+	// Resolve a routing policy to the provider it selects; steps route
+	// themselves with their own tags when they execute
+	routedModel := ""
+	if policyName, isRouter := config.ParseRouter(actualProviderName); isRouter {
+		decision, err := ai.Route(appConfig, policyName, nil)
+		if err != nil {
+			return nil, err
+		}
+		actualProviderName = decision.Selected().Provider
+		routedModel = decision.Selected().Model
+	}
+
 	// Get provider config
 	var providerConfig *config.ProviderConfig
 	var interfaceType config.InterfaceType
@@ -170,6 +239,8 @@ func initializeProvider(appConfig *config.ApplicationConfig, configService *infr
 	// Override model if specified
 	if modelName != "" {
 		providerConfig.DefaultModel = modelName
+	} else if routedModel != "" {
+		providerConfig.DefaultModel = routedModel
 	} else if wf.Execution.Model != "" {
 		providerConfig.DefaultModel = wf.Execution.Model
 	} else if len(wf.Execution.Providers) > 0 && wf.Execution.Providers[0].Model != "" {
@@ -187,10 +258,12 @@ func initializeProvider(appConfig *config.ApplicationConfig, configService *infr
 	return provider, nil
 }
 
-// getInputData retrieves input from flag or stdin
-func getInputData() (string, error) {
+// getInputData retrieves input from flag or stdin. Stdin longer than
+// spoolThreshold bytes is spooled to a temp file and its path returned
+// instead of the text; the caller removes it.
+func getInputData(spoolThreshold int) (string, string, error) {
 	if inputData != "" {
-		return inputData, nil
+		return inputData, "", nil
 	}
 
 	// Check if stdin is a pipe (not a terminal)
@@ -200,124 +273,47 @@ func getInputData() (string, error) {
 		// This prevents hanging when called through MCP bash tool where
 		// stdin is connected but no data is being sent
 
-		dataChan := make(chan []byte, 1)
-		errChan := make(chan error, 1)
-
+		// Only the first byte has to arrive within the timeout; the rest is
+		// read to EOF however long it takes
+		stdin := bufio.NewReader(os.Stdin)
+		ready := make(chan error, 1)
 		go func() {
-			data, err := io.ReadAll(os.Stdin)
-			if err != nil {
-				errChan <- err
-				return
-			}
-			dataChan <- data
+			_, err := stdin.Peek(1)
+			ready <- err
 		}()
 
 		// Wait for data with 100ms timeout
 		// If stdin has data piped, it will be available immediately
 		// If stdin is just a pipe with no data, we'll timeout
 		select {
-		case data := <-dataChan:
-			return string(data), nil
-		case err := <-errChan:
-			return "", fmt.Errorf("failed to read stdin: %w", err)
+		case err := <-ready:
+			if err == io.EOF {
+				return "", "", nil
+			}
+			if err != nil {
+				return "", "", fmt.Errorf("failed to read stdin: %w", err)
+			}
 		case <-time.After(100 * time.Millisecond):
 			// Timeout - no data available on stdin
 			// This is normal when called through bash MCP tool
-			return "", nil
-		}
-	}
-
-	return "", nil // Empty input is OK
-}
-
-// collectServersFromWorkflow extracts all unique server names from workflow steps
-func collectServersFromWorkflow(wf *config.WorkflowV2, appConfig *config.ApplicationConfig) []string {
-	serverSet := make(map[string]bool)
-
-	// Get RAG config from already-loaded app config
-	var ragConfig *config.RagConfig
-	if appConfig != nil && appConfig.RAG != nil {
-		ragConfig = appConfig.RAG
-	}
-
-	// Collect from execution level
-	for _, server := range wf.Execution.Servers {
-		serverSet[server] = true
-	}
-
-	// Collect from steps
-	for _, step := range wf.Steps {
-		// Regular server references
-		for _, server := range step.Servers {
-			serverSet[server] = true
+			return "", "", nil
 		}
 
-		// RAG step servers - need to resolve to actual MCP server names
-		if step.Rag != nil && ragConfig != nil {
-			// Helper function to resolve RAG server name to MCP server name
-			resolveRagServer := func(ragServerName string) string {
-				if ragServerConfig, exists := ragConfig.Servers[ragServerName]; exists {
-					logging.Debug("Resolved RAG server '%s' to MCP server '%s'", ragServerName, ragServerConfig.MCPServer)
-					return ragServerConfig.MCPServer
-				}
-				// If not found in RAG config, assume it's an MCP server name directly
-				logging.Debug("RAG server '%s' not in config, using as-is", ragServerName)
-				return ragServerName
-			}
-
-			if step.Rag.Server != "" {
-				// Single server mode
-				mcpServer := resolveRagServer(step.Rag.Server)
-				if mcpServer != "" {
-					serverSet[mcpServer] = true
-				}
-			}
-			// Multi-server mode
-			for _, ragServer := range step.Rag.Servers {
-				mcpServer := resolveRagServer(ragServer)
-				if mcpServer != "" {
-					serverSet[mcpServer] = true
-				}
-			}
+		text, file, err := workflow.ReadInput(stdin, spoolThreshold)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read stdin: %w", err)
 		}
-	}
-
-	// Convert to slice
-	servers := make([]string, 0, len(serverSet))
-	for server := range serverSet {
-		servers = append(servers, server)
-	}
-
-	return servers
-}
-
-// collectSkillsFromWorkflow extracts all unique skill names from workflow steps
-func collectSkillsFromWorkflow(wf *config.WorkflowV2) []string {
-	skillSet := make(map[string]bool)
-
-	// Collect from execution level
-	for _, skill := range wf.Execution.Skills {
-		skillSet[skill] = true
-	}
-
-	// Collect from steps
-	for _, step := range wf.Steps {
-		for _, skill := range step.Skills {
-			skillSet[skill] = true
+		if file != "" {
+			logging.Info("Input is larger than %d bytes; spooled to %s", spoolThreshold, file)
 		}
+		return text, file, nil
 	}
 
-	// Convert to slice
-	skills := make([]string, 0, len(skillSet))
-	for skill := range skillSet {
-		skills = append(skills, skill)
-	}
-
-	return skills
+	return "", "", nil // Empty input is OK
 }
 
 // executeWorkflowWithoutServers executes a workflow that doesn't need MCP servers
-func executeWorkflowWithoutServers(wf *config.WorkflowV2, workflowKey string, inputData string, appConfig *config.ApplicationConfig, skills []string, startFrom string, endAt string) error {
+func executeWorkflowWithoutServers(wf *config.WorkflowV2, workflowKey string, inputData string, inputFile string, params map[string]string, bundle *contextbundle.Bundle, appConfig *config.ApplicationConfig, skills []string, startFrom string, endAt string) error {
 	logging.Debug("Executing workflow without external MCP servers")
 
 	// ARCHITECTURAL FIX: Initialize built-in skills if workflow uses them
@@ -350,37 +346,47 @@ func executeWorkflowWithoutServers(wf *config.WorkflowV2, workflowKey string, in
 	if skillService != nil {
 		logging.Info("Creating server manager with built-in skills only (no external servers)")
 		serverManager = infraSkills.NewSkillsAwareServerManager(nil, skillService)
+		serverManager = builtintools.Wrap(serverManager, appConfig.BuiltinTools)
+		serverManager = toolselect.Wrap(serverManager, configFile, appConfig.ToolSelection)
 	}
 
 	// Create logger with resolved log level
-	effectiveLogLevel := resolveLogLevel(wf.Execution.Logging)
+	effectiveLogLevel := resolveLogLevel(wf.ConsoleLogLevel())
 	logger := workflow.NewLogger(effectiveLogLevel, false) // verbose handled by resolveLogLevel
 
 	// Create orchestrator with workflow key for directory-aware resolution
 	orchestrator := workflow.NewOrchestratorWithKey(wf, workflowKey, logger)
+	defer orchestrator.Close()
 
 	// Set provider on executor
 	orchestrator.SetAppConfig(appConfig)
 	orchestrator.SetAppConfigForWorkflows(appConfig)
+	orchestrator.SetStreamOutput(os.Stderr)
 	orchestrator.SetEmbeddingService(embeddingService)
 	if serverManager != nil {
 		orchestrator.SetServerManager(serverManager)
 	}
 	orchestrator.SetStartFrom(startFrom)
 	orchestrator.SetEndAt(endAt)
+	orchestrator.SetParams(params)
+	orchestrator.SetContextBundle(bundle)
+	orchestrator.SetInputFile(inputFile)
 
 	// Execute
 	ctx := context.Background()
 	if err := orchestrator.Execute(ctx, inputData); err != nil {
 		return handleWorkflowError(wf.Name, err)
 	}
+	if err := exportWorkflowContext(orchestrator); err != nil {
+		return err
+	}
 
 	// Output results
 	return outputWorkflowResults(orchestrator, wf)
 }
 
 // executeWorkflowWithServers executes a workflow that needs MCP servers
-func executeWorkflowWithServers(wf *config.WorkflowV2, workflowKey string, inputData string, appConfig *config.ApplicationConfig, servers []string, skills []string, startFrom string, endAt string) error {
+func executeWorkflowWithServers(wf *config.WorkflowV2, workflowKey string, inputData string, inputFile string, params map[string]string, bundle *contextbundle.Bundle, appConfig *config.ApplicationConfig, servers []string, skills []string, startFrom string, endAt string) error {
 	logging.Debug("Executing workflow with servers: %v", servers)
 	if len(skills) > 0 {
 		logging.Info("Skills filter enabled: %v", skills)
@@ -438,39 +444,49 @@ func executeWorkflowWithServers(wf *config.WorkflowV2, workflowKey string, input
 
 		// Create server manager for external servers
 		var serverManager domain.MCPServerManager
-		serverManager = NewHostServerManager(conns)
+		serverManager = infraSkills.NewHostServerManager(conns)
 
 		// ARCHITECTURAL FIX: Wrap with skills-aware manager if skills are needed
 		if skillService != nil {
 			logging.Info("Wrapping server manager with built-in skills support")
 			serverManager = infraSkills.NewSkillsAwareServerManager(serverManager, skillService)
 		}
+		serverManager = builtintools.Wrap(serverManager, appConfig.BuiltinTools)
+		serverManager = toolselect.Wrap(serverManager, configFile, appConfig.ToolSelection)
 
 		// Create logger with resolved log level
-		effectiveLogLevel := resolveLogLevel(wf.Execution.Logging)
+		effectiveLogLevel := resolveLogLevel(wf.ConsoleLogLevel())
 		logger := workflow.NewLogger(effectiveLogLevel, false) // verbose handled by resolveLogLevel
 
 		// Create orchestrator with workflow key for directory-aware resolution
 		orchestrator := workflow.NewOrchestratorWithKey(wf, workflowKey, logger)
+		defer orchestrator.Close()
 
 		// Set provider and server manager
 		orchestrator.SetAppConfig(appConfig)
 		orchestrator.SetAppConfigForWorkflows(appConfig)
+		orchestrator.SetStreamOutput(os.Stderr)
 		orchestrator.SetServerManager(serverManager)
 		orchestrator.SetEmbeddingService(embeddingService)
 		orchestrator.SetStartFrom(startFrom)
 		orchestrator.SetEndAt(endAt)
+		orchestrator.SetParams(params)
+		orchestrator.SetContextBundle(bundle)
+		orchestrator.SetInputFile(inputFile)
 
 		// Execute with cancellable context
 		if err := orchestrator.Execute(ctx, inputData); err != nil {
 			// Check if error is due to cancellation
 			if errors.Is(err, context.Canceled) {
 				logging.Info("Workflow execution canceled by user")
-				return fmt.Errorf("workflow canceled")
+				return domainErrors.Categorize(fmt.Errorf("workflow canceled"), domainErrors.ErrInterrupted)
 			}
 			execErr = handleWorkflowError(wf.Name, err)
 			return execErr
 		}
+		if execErr = exportWorkflowContext(orchestrator); execErr != nil {
+			return execErr
+		}
 
 		// Output results
 		execErr = outputWorkflowResults(orchestrator, wf)
@@ -485,14 +501,13 @@ func executeWorkflowWithServers(wf *config.WorkflowV2, workflowKey string, input
 
 // outputWorkflowResults outputs the final results from orchestrator
 func outputWorkflowResults(orchestrator *workflow.Orchestrator, wf *config.WorkflowV2) error {
-	// Get final step result
+	// Get final step result (or the matrix report)
 	if len(wf.Steps) == 0 {
 		fmt.Println("Workflow completed (no steps)")
 		return nil
 	}
 
-	lastStepName := wf.Steps[len(wf.Steps)-1].Name
-	finalResult, ok := orchestrator.GetStepResult(lastStepName)
+	finalResult, ok := orchestrator.FinalOutput()
 
 	if !ok {
 		fmt.Printf("Workflow '%s' completed but produced no output\n", wf.Name)
@@ -505,6 +520,18 @@ func outputWorkflowResults(orchestrator *workflow.Orchestrator, wf *config.Workf
 	return nil
 }
 
+// exportWorkflowContext saves the run as a context bundle for --export-context
+func exportWorkflowContext(orchestrator *workflow.Orchestrator) error {
+	if exportContext == "" {
+		return nil
+	}
+	if err := orchestrator.ContextBundle().Save(exportContext); err != nil {
+		return fmt.Errorf("failed to write context bundle: %w", err)
+	}
+	logging.Info("Saved context bundle to %s", exportContext)
+	return nil
+}
+
 // handleWorkflowError formats workflow execution errors
 func handleWorkflowError(workflowName string, err error) error {
 	errorResponse := map[string]interface{}{
@@ -512,6 +539,15 @@ func handleWorkflowError(workflowName string, err error) error {
 		"status":    "failed",
 		"timestamp": time.Now().Format(time.RFC3339),
 		"error":     err.Error(),
+		"exit_code": ExitCode(err),
+	}
+
+	// A parallel run that continued past failures still reports what succeeded
+	var partial *workflow.PartialFailureError
+	if errors.As(err, &partial) {
+		errorResponse["failed"] = partial.Report.Failed
+		errorResponse["skipped"] = partial.Report.Skipped
+		fmt.Println(partial.Report.Format())
 	}
 
 	output, _ := json.MarshalIndent(errorResponse, "", "  ")
@@ -611,240 +647,3 @@ func executeListWorkflows() error {
 
 	return nil
 }
-
-// HostServerManager adapts host.ServerConnection to domain.MCPServerManager interface
-type HostServerManager struct {
-	connections []*host.ServerConnection
-}
-
-func NewHostServerManager(connections []*host.ServerConnection) *HostServerManager {
-	return &HostServerManager{connections: connections}
-}
-
-func (hsm *HostServerManager) StartServer(ctx context.Context, serverName string, cfg *config.ServerConfig) (domain.MCPServer, error) {
-	for _, conn := range hsm.connections {
-		if conn.Name == serverName {
-			return &HostServerAdapter{connection: conn}, nil
-		}
-	}
-	return nil, fmt.Errorf("server '%s' not found in host connections", serverName)
-}
-
-func (hsm *HostServerManager) StopServer(serverName string) error {
-	return nil
-}
-
-func (hsm *HostServerManager) GetServer(serverName string) (domain.MCPServer, bool) {
-	for _, conn := range hsm.connections {
-		if conn.Name == serverName {
-			return &HostServerAdapter{connection: conn}, true
-		}
-	}
-	return nil, false
-}
-
-func (hsm *HostServerManager) ListServers() map[string]domain.MCPServer {
-	servers := make(map[string]domain.MCPServer)
-	for _, conn := range hsm.connections {
-		servers[conn.Name] = &HostServerAdapter{connection: conn}
-	}
-	return servers
-}
-
-func (hsm *HostServerManager) GetAvailableTools() ([]domain.Tool, error) {
-	var toolsList []domain.Tool
-
-	for _, conn := range hsm.connections {
-		adapter := &HostServerAdapter{connection: conn}
-		serverTools, err := adapter.GetTools()
-		if err != nil {
-			logging.Warn("Failed to get tools from server %s: %v", conn.Name, err)
-			continue
-		}
-		toolsList = append(toolsList, serverTools...)
-	}
-
-	return toolsList, nil
-}
-
-func (hsm *HostServerManager) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
-	for _, conn := range hsm.connections {
-		adapter := &HostServerAdapter{connection: conn}
-		toolsList, err := adapter.GetTools()
-		if err != nil {
-			continue
-		}
-
-		// Check both prefixed and unprefixed tool names
-		serverPrefix := conn.Name + "_"
-		serverPrefixUnderscore := strings.ReplaceAll(conn.Name, "-", "_") + "_"
-
-		for _, tool := range toolsList {
-			// Extract original tool name (strip server prefix if present)
-			originalName := tool.Function.Name
-			if strings.HasPrefix(originalName, serverPrefix) {
-				originalName = strings.TrimPrefix(originalName, serverPrefix)
-			} else if strings.HasPrefix(originalName, serverPrefixUnderscore) {
-				originalName = strings.TrimPrefix(originalName, serverPrefixUnderscore)
-			}
-
-			// Match against both original name and prefixed name
-			if tool.Function.Name == toolName || originalName == toolName {
-				return adapter.ExecuteTool(ctx, toolName, arguments)
-			}
-		}
-	}
-
-	return "", fmt.Errorf("tool '%s' not found on any server", toolName)
-}
-
-func (hsm *HostServerManager) StopAll() error {
-	return nil
-}
-
-// HostServerAdapter adapts host.ServerConnection to domain.MCPServer interface
-type HostServerAdapter struct {
-	connection  *host.ServerConnection
-	toolsCache  []domain.Tool
-	toolsCached bool
-}
-
-func (hsa *HostServerAdapter) Start(ctx context.Context) error {
-	return nil
-}
-
-func (hsa *HostServerAdapter) Stop() error {
-	return nil
-}
-
-func (hsa *HostServerAdapter) IsRunning() bool {
-	return hsa.connection.Client != nil
-}
-
-func formatToolNameForOpenAI(serverName, toolName string) string {
-	serverName = strings.ReplaceAll(serverName, ".", "_")
-	serverName = strings.ReplaceAll(serverName, " ", "_")
-	serverName = strings.ReplaceAll(serverName, "-", "_")
-
-	toolName = strings.ReplaceAll(toolName, ".", "_")
-	toolName = strings.ReplaceAll(toolName, " ", "_")
-
-	return fmt.Sprintf("%s_%s", serverName, toolName)
-}
-
-func (hsa *HostServerAdapter) GetTools() ([]domain.Tool, error) {
-	if hsa.toolsCached {
-		return hsa.toolsCache, nil
-	}
-
-	// Type assert to stdio client
-	stdioClient := hsa.connection.GetStdioClient()
-	if stdioClient == nil {
-		return nil, fmt.Errorf("server %s does not support stdio protocol", hsa.connection.Name)
-	}
-
-	result, err := tools.SendToolsList(stdioClient, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tools from MCP server %s: %w", hsa.connection.Name, err)
-	}
-
-	var domainTools []domain.Tool
-	for _, tool := range result.Tools {
-		formattedName := formatToolNameForOpenAI(hsa.connection.Name, tool.Name)
-
-		domainTool := domain.Tool{
-			Type: "function",
-			Function: domain.ToolFunction{
-				Name:        formattedName,
-				Description: fmt.Sprintf("[%s] %s", hsa.connection.Name, tool.Description),
-				Parameters:  tool.InputSchema,
-			},
-		}
-		domainTools = append(domainTools, domainTool)
-	}
-
-	hsa.toolsCache = domainTools
-	hsa.toolsCached = true
-
-	logging.Debug("Successfully got %d tools from server %s", len(domainTools), hsa.connection.Name)
-	return domainTools, nil
-}
-
-func (hsa *HostServerAdapter) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
-	actualToolName := toolName
-	serverPrefix := hsa.connection.Name + "_"
-	serverPrefixUnderscore := strings.ReplaceAll(hsa.connection.Name, "-", "_") + "_"
-
-	if strings.HasPrefix(toolName, serverPrefix) {
-		actualToolName = strings.TrimPrefix(toolName, serverPrefix)
-	} else if strings.HasPrefix(toolName, serverPrefixUnderscore) {
-		actualToolName = strings.TrimPrefix(toolName, serverPrefixUnderscore)
-	}
-
-	logging.Debug("Executing tool %s (actual: %s) on server %s", toolName, actualToolName, hsa.connection.Name)
-
-	// Type assert to stdio client
-	stdioClient := hsa.connection.GetStdioClient()
-	if stdioClient == nil {
-		return "", fmt.Errorf("server %s does not support stdio protocol", hsa.connection.Name)
-	}
-
-	result, err := tools.SendToolsCall(stdioClient, stdioClient.GetDispatcher(), actualToolName, arguments)
-	if err != nil {
-		return "", fmt.Errorf("MCP tool execution failed for %s: %w", actualToolName, err)
-	}
-
-	if result.IsError {
-		return "", fmt.Errorf("tool execution failed: %s", result.Error)
-	}
-
-	// Extract text from content blocks
-	var resultStr string
-	switch content := result.Content.(type) {
-	case string:
-		// Direct string response
-		resultStr = content
-	case []interface{}:
-		// Content blocks array (standard MCP format)
-		// Extract text from the first text-type content block
-		for _, item := range content {
-			if block, ok := item.(map[string]interface{}); ok {
-				if blockType, hasType := block["type"].(string); hasType && blockType == "text" {
-					if text, hasText := block["text"].(string); hasText {
-						resultStr = text
-						break
-					}
-				}
-			}
-		}
-		if resultStr == "" {
-			// No text content found, marshal the whole thing as fallback
-			resultBytes, err := json.Marshal(content)
-			if err != nil {
-				return "", fmt.Errorf("failed to marshal tool result: %w", err)
-			}
-			resultStr = string(resultBytes)
-		}
-	default:
-		// Unknown format, marshal it
-		resultBytes, err := json.Marshal(content)
-		if err != nil {
-			return "", fmt.Errorf("failed to marshal tool result: %w", err)
-		}
-		resultStr = string(resultBytes)
-	}
-
-	logging.Debug("Tool %s executed successfully on server %s", actualToolName, hsa.connection.Name)
-	return resultStr, nil
-}
-
-func (hsa *HostServerAdapter) GetServerName() string {
-	return hsa.connection.Name
-}
-
-func (hsa *HostServerAdapter) GetConfig() *config.ServerConfig {
-	return &config.ServerConfig{
-		Command: "mock",
-		Args:    []string{},
-	}
-}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflowpack"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var migrateWrite bool
+
+// WorkflowMigrateCmd upgrades workflow files to the current spec_version
+var WorkflowMigrateCmd = &cobra.Command{
+	Use:   "migrate [workflow]...",
+	Short: "Upgrade workflow files to the current spec_version",
+	Long: fmt.Sprintf(`Check workflow files against the current spec_version (%s).
+
+Workflows written for an older spec_version are migrated in memory every time
+they load. This command shows what would change and reports deprecated values.
+With --write, the migrated YAML is written back (the original is kept as .bak).
+
+Workflows declaring a newer spec_version than this build supports fail to load;
+upgrade mcp-cli to run them.
+
+Examples:
+  mcp-cli workflow migrate
+  mcp-cli workflow migrate research --write`, config.CurrentSpecVersion),
+	ValidArgsFunction: completeWorkflows,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeWorkflowMigrate(args)
+	},
+}
+
+func init() {
+	WorkflowMigrateCmd.Flags().BoolVar(&migrateWrite, "write", false, "Write migrated workflows back to disk (keeps a .bak copy)")
+	WorkflowsCmd.AddCommand(WorkflowMigrateCmd)
+}
+
+func executeWorkflowMigrate(names []string) error {
+	_, workflowsDir := packPaths()
+	files, err := workflowpack.IndexWorkflowFiles(workflowsDir)
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	pending := 0
+	for _, name := range names {
+		file, ok := files[name]
+		if !ok {
+			color.Red("✗ %s: workflow file not found in %s", name, workflowsDir)
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		migrated, result, err := config.MigrateWorkflow(data)
+		if err != nil {
+			color.Red("✗ %s: %v", name, err)
+			continue
+		}
+
+		if !result.Migrated() && len(result.Warnings) == 0 {
+			fmt.Printf("✓ %s: up to date (spec_version %s)\n", name, result.ToVersion)
+			continue
+		}
+
+		if result.Migrated() {
+			fmt.Printf("↑ %s: spec_version %s → %s\n", name, result.FromVersion, result.ToVersion)
+			for _, note := range result.Notes {
+				fmt.Printf("    %s\n", note)
+			}
+		}
+		for _, warning := range result.Warnings {
+			color.Yellow("    ⚠️  %s", warning)
+		}
+
+		if !result.Migrated() {
+			continue
+		}
+		if !migrateWrite {
+			pending++
+			continue
+		}
+
+		if err := os.WriteFile(file+".bak", data, 0644); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", file, err)
+		}
+		if err := os.WriteFile(file, migrated, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		fmt.Printf("    wrote %s\n", file)
+	}
+
+	if pending > 0 {
+		fmt.Println()
+		fmt.Printf("💡 %d workflow(s) can be upgraded. Re-run with --write to update the files.\n", pending)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflowpack"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	packVersion  string
+	packOutput   string
+	installForce bool
+)
+
+// WorkflowPackCmd bundles a workflow into a shareable archive
+var WorkflowPackCmd = &cobra.Command{
+	Use:   "pack <workflow>",
+	Short: "Bundle a workflow, its sub-workflows and files into a versioned archive",
+	Long: `Bundle a workflow into a .tar.gz pack with a manifest.
+
+The pack contains the workflow, every sub-workflow it calls through loop or
+template steps, and files referenced by render steps (template_file,
+stylesheet). Providers, servers and skills are recorded in the manifest as
+requirements; skill contents are not packed.
+
+Examples:
+  mcp-cli workflow pack research
+  mcp-cli workflow pack research --version 1.2.0 --output dist/research.tar.gz`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorkflowArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeWorkflowPack(args[0])
+	},
+}
+
+// WorkflowInstallCmd installs a pack into the current configuration
+var WorkflowInstallCmd = &cobra.Command{
+	Use:   "install <source>",
+	Short: "Install a workflow pack from a file, URL or git repository",
+	Long: `Install a workflow pack into config/workflows.
+
+Sources:
+  ./research-1.2.0.tar.gz                     Local archive
+  ./packs/research                            Directory containing manifest.yaml
+  https://example.com/research-1.2.0.tar.gz   Download
+  git+https://github.com/org/packs.git#v1.2.0 Git repository (optional ref)
+
+The install fails if a workflow or file would be overwritten; use --force to
+replace them. Providers, servers and skills the pack needs but the config
+does not define are listed after installing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeWorkflowInstall(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	WorkflowPackCmd.Flags().StringVar(&packVersion, "version", "", "Pack version (default: workflow version)")
+	WorkflowPackCmd.Flags().StringVarP(&packOutput, "output", "o", "", "Archive path (default: <workflow>-<version>.tar.gz)")
+	WorkflowInstallCmd.Flags().BoolVar(&installForce, "force", false, "Overwrite existing workflows and files")
+
+	WorkflowsCmd.AddCommand(WorkflowPackCmd)
+	WorkflowsCmd.AddCommand(WorkflowInstallCmd)
+}
+
+// packPaths returns the config directory and workflows directory for --config
+func packPaths() (string, string) {
+	absConfig, err := filepath.Abs(configFile)
+	if err != nil {
+		absConfig = configFile
+	}
+	return filepath.Dir(absConfig), workflowpack.WorkflowsDir(absConfig)
+}
+
+func executeWorkflowPack(name string) error {
+	appConfig, err := infraConfig.NewService().LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	configDir, workflowsDir := packPaths()
+	result, err := workflowpack.Pack(appConfig, name, workflowpack.PackOptions{
+		ConfigDir:    configDir,
+		WorkflowsDir: workflowsDir,
+		Version:      packVersion,
+		Output:       packOutput,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range result.Warnings {
+		color.Yellow("⚠️  %s", warning)
+	}
+
+	m := result.Manifest
+	fmt.Printf("📦 Packed %s %s → %s\n", m.Name, m.Version, result.Path)
+	for _, wf := range m.Workflows {
+		fmt.Printf("   workflow: %s\n", wf.Name)
+	}
+	for _, f := range m.Files {
+		fmt.Printf("   file:     %s\n", f)
+	}
+	printRequirements("Requires", m.Requires)
+	return nil
+}
+
+func executeWorkflowInstall(ctx context.Context, source string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	appConfig, err := infraConfig.NewService().LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	packDir, cleanup, err := workflowpack.Open(ctx, source)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	configDir, workflowsDir := packPaths()
+	result, err := workflowpack.Install(packDir, appConfig, workflowpack.InstallOptions{
+		ConfigDir:    configDir,
+		WorkflowsDir: workflowsDir,
+		Force:        installForce,
+	})
+	if err != nil {
+		return err
+	}
+
+	m := result.Manifest
+	fmt.Printf("✅ Installed %s %s\n", m.Name, m.Version)
+	for _, name := range result.Workflows {
+		fmt.Printf("   workflow: %s\n", name)
+	}
+	for _, f := range result.Files {
+		fmt.Printf("   file:     %s\n", f)
+	}
+
+	if !result.Missing.IsEmpty() {
+		fmt.Println()
+		color.Yellow("⚠️  The pack needs configuration you don't have yet:")
+		printRequirements("Missing", result.Missing)
+	}
+
+	fmt.Println()
+	fmt.Printf("💡 Run it: mcp-cli --workflow %s\n", m.Entry)
+	return nil
+}
+
+// printRequirements prints non-empty requirement lists
+func printRequirements(label string, req workflowpack.Requirements) {
+	if len(req.Providers) > 0 {
+		fmt.Printf("   %s providers: %s\n", label, strings.Join(req.Providers, ", "))
+	}
+	if len(req.Servers) > 0 {
+		fmt.Printf("   %s servers:   %s\n", label, strings.Join(req.Servers, ", "))
+	}
+	if len(req.Skills) > 0 {
+		fmt.Printf("   %s skills:    %s\n", label, strings.Join(req.Skills, ", "))
+	}
+}
@@ -90,9 +90,9 @@ func (h *History) CanFitMessage(msg models.Message) bool {
 	}
 
 	currentTokens := h.GetTokenCount()
-	estimatedMsgTokens := len(msg.Content) / 4
+	msgTokens := h.session.CountTokens(msg.Content)
 
-	return (currentTokens + estimatedMsgTokens + h.reserveTokens) <= h.maxTokens
+	return (currentTokens + msgTokens + h.reserveTokens) <= h.maxTokens
 }
 
 // SummarizeIfNeeded summarizes old messages if approaching limit
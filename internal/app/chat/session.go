@@ -3,6 +3,7 @@ package chat
 import (
 	"time"
 
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/tokens"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/models"
 )
 
@@ -14,6 +15,9 @@ type Session struct {
 	UpdatedAt    time.Time
 	Metadata     map[string]interface{}
 
+	// Model selects the tokenizer used for token counts; empty uses cl100k_base
+	Model string
+
 	// For future multi-user support
 	UserID   string // Identifies the user (for authentication/auditing)
 	ClientID string // Identifies the client connection (for multi-session per user)
@@ -55,6 +59,18 @@ func (s *Session) SetClient(clientID string) {
 	s.UpdatedAt = time.Now()
 }
 
+// SetModel sets the model whose tokenizer is used for token counts
+func (s *Session) SetModel(model string) {
+	s.Model = model
+	s.Metadata["model"] = model
+	s.UpdatedAt = time.Now()
+}
+
+// CountTokens counts tokens in text with the session model's tokenizer
+func (s *Session) CountTokens(text string) int {
+	return tokens.CountTokens(s.Model, text)
+}
+
 // AddMessage adds a message to the session
 func (s *Session) AddMessage(msg models.Message) {
 	s.Conversation.AddMessage(msg)
@@ -84,10 +100,10 @@ func (s *Session) MessageCount() int {
 
 // GetTotalTokens calculates total tokens used in session
 func (s *Session) GetTotalTokens() int {
+	tokenizer := tokens.TokenizerForModel(s.Model)
 	total := 0
 	for _, msg := range s.Conversation.Messages {
-		// Approximate token count (will be replaced with actual usage tracking)
-		total += len(msg.Content) / 4
+		total += tokenizer.Count(msg.Content)
 	}
 	return total
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/models"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/ports"
 )
@@ -41,7 +42,7 @@ func (te *ToolExecutor) ExecuteToolCalls(ctx context.Context, toolCalls []models
 			// Create error result
 			results = append(results, models.Message{
 				Role:       models.RoleTool,
-				Content:    fmt.Sprintf("Error: %v", err),
+				Content:    domainErrors.FormatToolResult(err),
 				ToolCallID: toolCall.ID,
 			})
 			continue
@@ -0,0 +1,101 @@
+package chat
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/clipboard"
+)
+
+// codeBlockPattern matches fenced code blocks, capturing the body
+var codeBlockPattern = regexp.MustCompile("(?s)```[^\\n]*\\n(.*?)```")
+
+// HandleCopyCommand copies the last assistant message, or a code block from it, to the clipboard.
+//
+//	/copy        last assistant message
+//	/copy code   last code block in that message
+//	/copy code N Nth code block (1-based)
+func (m *ChatManager) HandleCopyCommand(args []string) {
+	message := m.lastAssistantMessage()
+	if message == "" {
+		m.UI.PrintSystem("Nothing to copy yet.")
+		return
+	}
+
+	text := message
+	what := "last response"
+
+	if len(args) > 0 && args[0] == "code" {
+		blocks := extractCodeBlocks(message)
+		if len(blocks) == 0 {
+			m.UI.PrintSystem("The last response has no code blocks.")
+			return
+		}
+
+		index := len(blocks)
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 || n > len(blocks) {
+				m.UI.PrintError("Code block must be between 1 and %d", len(blocks))
+				return
+			}
+			index = n
+		}
+		text = blocks[index-1]
+		what = "code block " + strconv.Itoa(index) + " of " + strconv.Itoa(len(blocks))
+	} else if len(args) > 0 {
+		m.UI.PrintError("Usage: /copy [code [N]]")
+		return
+	}
+
+	if err := clipboard.Copy(text); err != nil {
+		m.UI.PrintError("Failed to copy: %v", err)
+		return
+	}
+	m.UI.PrintSystem("Copied %s to clipboard (%d characters).", what, len(text))
+}
+
+// HandlePasteCommand builds a user message from the clipboard, optionally
+// prefixed with an instruction (e.g. "/paste explain this error").
+// Returns false if there is nothing to send.
+func (m *ChatManager) HandlePasteCommand(instruction string) (string, bool) {
+	content, err := clipboard.Paste()
+	if err != nil {
+		m.UI.PrintError("Failed to paste: %v", err)
+		return "", false
+	}
+	if strings.TrimSpace(content) == "" {
+		m.UI.PrintSystem("Clipboard is empty.")
+		return "", false
+	}
+
+	lines := strings.Count(strings.TrimRight(content, "\n"), "\n") + 1
+	m.UI.PrintSystem("Pasted %d lines (%d characters) from clipboard.", lines, len(content))
+
+	instruction = strings.TrimSpace(instruction)
+	if instruction == "" {
+		return content, true
+	}
+	return instruction + "\n\n" + content, true
+}
+
+// lastAssistantMessage returns the most recent non-empty assistant message
+func (m *ChatManager) lastAssistantMessage() string {
+	messages := m.Context.Messages
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "assistant" && strings.TrimSpace(messages[i].Content) != "" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// extractCodeBlocks returns the bodies of all fenced code blocks in text
+func extractCodeBlocks(text string) []string {
+	var blocks []string
+	for _, match := range codeBlockPattern.FindAllStringSubmatch(text, -1) {
+		blocks = append(blocks, strings.TrimRight(match[1], "\n"))
+	}
+	return blocks
+}
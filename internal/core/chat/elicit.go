@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/elicitation"
+	"github.com/chzyer/readline"
+)
+
+// Elicit asks the user for the values a server requested in the middle of
+// a tool call
+func (u *UI) Elicit(ctx context.Context, serverName string, params elicitation.Params) (elicitation.Result, error) {
+	// A tool call spinner may be running; stop it so the prompt stays readable
+	u.StopProgress()
+
+	u.elicitOnce.Do(func() {
+		u.elicitor = &elicitation.ConsoleHandler{ReadLine: u.readElicitLine, Out: os.Stdout}
+	})
+	return u.elicitor.Elicit(ctx, serverName, params)
+}
+
+// readElicitLine reads one answer through readline, when the chat has it,
+// so the terminal stays in a consistent state
+func (u *UI) readElicitLine(prompt string) (string, error) {
+	if u.rl == nil {
+		return elicitation.NewStdinHandler().ReadLine(prompt)
+	}
+
+	defer u.rl.SetPrompt(u.userColor.Sprint("You: "))
+	// Print description lines above the prompt; readline wants one line
+	if i := strings.LastIndex(prompt, "\n"); i >= 0 {
+		fmt.Print(prompt[:i+1])
+		prompt = prompt[i+1:]
+	}
+	u.rl.SetPrompt(u.systemColor.Sprint(prompt))
+	line, err := u.rl.Readline()
+	if err == readline.ErrInterrupt {
+		return "", io.EOF
+	}
+	return line, err
+}
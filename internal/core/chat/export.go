@@ -0,0 +1,259 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/contextbundle"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"gopkg.in/yaml.v3"
+)
+
+// chatTurn is a user message and the tools the assistant used to answer it
+type chatTurn struct {
+	request string
+	tools   []string // Tools that succeeded, in call order
+}
+
+// HandleExportWorkflowCommand writes the conversation as a draft workflow,
+// one step per answered user message.
+//
+//	/export-workflow          write chat_export_<time>.yaml
+//	/export-workflow NAME     write NAME.yaml
+func (m *ChatManager) HandleExportWorkflowCommand(args string) {
+	name := workflowName(strings.TrimSpace(args))
+	if name == "" {
+		name = "chat_export_" + time.Now().Format("20060102_150405")
+	}
+
+	turns := m.answeredTurns()
+	if len(turns) == 0 {
+		m.UI.PrintSystem("Nothing to export: no answered messages in the chat history yet.")
+		return
+	}
+
+	data, err := m.buildWorkflowDraft(name, turns)
+	if err != nil {
+		m.UI.PrintError("failed to build workflow: %v", err)
+		return
+	}
+
+	path := name + ".yaml"
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			m.UI.PrintError("%s already exists; choose another name (/export-workflow NAME)", path)
+			return
+		}
+		m.UI.PrintError("failed to write workflow: %v", err)
+		return
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		m.UI.PrintError("failed to write workflow: %v", err)
+		return
+	}
+
+	m.UI.PrintSystem("Exported %d steps to %s. Review it, then move it into config/workflows/ to run it with --workflow %s.", len(turns), path, name)
+}
+
+// HandleExportContextCommand writes the conversation as a context bundle
+// that query and workflows can load with --context-bundle.
+//
+//	/export-context        write chat_context_<time>.json
+//	/export-context FILE   write FILE (.json is added when missing)
+func (m *ChatManager) HandleExportContextCommand(args string) {
+	path := strings.TrimSpace(args)
+	if path == "" {
+		path = "chat_context_" + time.Now().Format("20060102_150405") + ".json"
+	} else if !strings.HasSuffix(strings.ToLower(path), ".json") {
+		path += ".json"
+	}
+
+	bundle := contextbundle.NewChat(m.Context.Messages, m.Context.SystemPrompt, m.activeProvider, m.modelName)
+	if len(bundle.Messages) == 0 {
+		m.UI.PrintSystem("Nothing to export: the chat history is empty.")
+		return
+	}
+	data, err := bundle.Marshal()
+	if err != nil {
+		m.UI.PrintError("failed to build context bundle: %v", err)
+		return
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			m.UI.PrintError("%s already exists; choose another name (/export-context FILE)", path)
+			return
+		}
+		m.UI.PrintError("failed to write context bundle: %v", err)
+		return
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		m.UI.PrintError("failed to write context bundle: %v", err)
+		return
+	}
+
+	m.UI.PrintSystem("Exported %d messages to %s. Continue with: mcp-cli query --context-bundle %s \"...\"", len(bundle.Messages), path, path)
+}
+
+// answeredTurns returns the user messages in the history that got an
+// answer, with the tools that succeeded along the way
+func (m *ChatManager) answeredTurns() []chatTurn {
+	failed := make(map[string]bool)
+	for _, call := range m.Context.ToolCalls {
+		if call.Error != "" {
+			failed[call.ToolCall.ID] = true
+		}
+	}
+
+	var turns []chatTurn
+	var current *chatTurn
+	answered := false
+	finish := func() {
+		if current != nil && answered {
+			turns = append(turns, *current)
+		}
+	}
+	for _, message := range m.Context.Messages {
+		switch message.Role {
+		case "user":
+			finish()
+			current = &chatTurn{request: message.Content}
+			answered = false
+		case "assistant":
+			if current == nil {
+				continue
+			}
+			for _, call := range message.ToolCalls {
+				if !failed[call.ID] && !containsString(current.tools, call.Function.Name) {
+					current.tools = append(current.tools, call.Function.Name)
+				}
+			}
+			answered = len(message.ToolCalls) == 0 && strings.TrimSpace(message.Content) != ""
+		}
+	}
+	finish()
+	return turns
+}
+
+// buildWorkflowDraft renders the turns as workflow YAML. Steps run without
+// the chat's history, so each step after the first is given the previous
+// step's result.
+func (m *ChatManager) buildWorkflowDraft(name string, turns []chatTurn) ([]byte, error) {
+	wf := config.WorkflowV2{
+		Schema:      "workflow/v2.0",
+		Name:        name,
+		Version:     "1.0.0",
+		SpecVersion: config.CurrentSpecVersion,
+		Description: fmt.Sprintf("Draft exported from a chat session on %s", time.Now().Format("2006-01-02")),
+		Execution: config.ExecutionContext{
+			Provider: m.activeProvider,
+			Model:    m.modelName,
+		},
+	}
+
+	servers := m.serverNames()
+	var unmatched []string
+	for i, turn := range turns {
+		step := config.StepV2{
+			Name: fmt.Sprintf("step_%d", i+1),
+			Run:  turn.request,
+		}
+		if i > 0 {
+			previous := wf.Steps[i-1].Name
+			step.Needs = []string{previous}
+			step.Run = fmt.Sprintf("Previous result:\n{{%s}}\n\n%s", previous, turn.request)
+		}
+		if len(turn.tools) > 0 {
+			step.Run += "\n\nUse these tools: " + strings.Join(turn.tools, ", ")
+		}
+		for _, tool := range turn.tools {
+			if server := toolServer(tool, servers); server != "" {
+				if !containsString(wf.Execution.Servers, server) {
+					wf.Execution.Servers = append(wf.Execution.Servers, server)
+				}
+			} else if !containsString(unmatched, tool) {
+				unmatched = append(unmatched, tool)
+			}
+		}
+		wf.Steps = append(wf.Steps, step)
+	}
+
+	var b strings.Builder
+	b.WriteString("# Draft workflow exported from chat with /export-workflow.\n")
+	b.WriteString("# Replace values that should vary between runs with {{input}} or {{params.NAME}}.\n")
+	if len(unmatched) > 0 {
+		fmt.Fprintf(&b, "# Tools not from a connected server (built-in tools or skills; add skills: if needed): %s\n", strings.Join(unmatched, ", "))
+	}
+	if prompt := strings.TrimSpace(m.Context.SystemPrompt); prompt != "" {
+		b.WriteString("#\n# The chat's system prompt (workflow steps use their own; fold any\n# instructions the steps need into their run text):\n")
+		for _, line := range strings.Split(prompt, "\n") {
+			b.WriteString(strings.TrimRight("#   "+line, " ") + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&wf); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+// serverNames returns the connected servers, longest name first so
+// prefixes match the most specific server
+func (m *ChatManager) serverNames() []string {
+	if m.ServerManager == nil {
+		return nil
+	}
+	var names []string
+	for name := range m.ServerManager.ListServers() {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	return names
+}
+
+// toolServer returns the server a <server>_<tool> name belongs to, or ""
+func toolServer(tool string, servers []string) string {
+	for _, server := range servers {
+		if strings.HasPrefix(tool, server+"_") {
+			return server
+		}
+	}
+	return ""
+}
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// workflowName turns text into a workflow name usable as a file name
+func workflowName(text string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(text, "_"), "_")
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
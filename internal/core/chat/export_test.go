@@ -0,0 +1,204 @@
+package chat
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// stubServers is a server manager that only lists server names
+type stubServers struct {
+	domain.MCPServerManager
+	names []string
+}
+
+func (s *stubServers) ListServers() map[string]domain.MCPServer {
+	servers := make(map[string]domain.MCPServer)
+	for _, name := range s.names {
+		servers[name] = nil
+	}
+	return servers
+}
+
+func toolCall(id, name string) domain.ToolCall {
+	return domain.ToolCall{ID: id, Type: "function", Function: domain.Function{Name: name}}
+}
+
+func TestAnsweredTurns(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []domain.Message
+		failed   []string // IDs of tool calls that returned errors
+		want     []chatTurn
+	}{
+		{
+			name: "unanswered trailing turn",
+			messages: []domain.Message{
+				{Role: "user", Content: "list open issues"},
+				{Role: "assistant", Content: "There are 3."},
+				{Role: "user", Content: "close the oldest"},
+			},
+			want: []chatTurn{{request: "list open issues"}},
+		},
+		{
+			name: "trailing turn stopped at tool calls",
+			messages: []domain.Message{
+				{Role: "user", Content: "read the readme"},
+				{Role: "assistant", ToolCalls: []domain.ToolCall{toolCall("c1", "filesystem_read_file")}},
+				{Role: "tool", ToolCallID: "c1", Content: "# Project"},
+			},
+		},
+		{
+			name: "blank answer",
+			messages: []domain.Message{
+				{Role: "user", Content: "hello"},
+				{Role: "assistant", Content: "  \n"},
+			},
+		},
+		{
+			name: "tools in call order without failures or repeats",
+			messages: []domain.Message{
+				{Role: "assistant", Content: "Welcome back."},
+				{Role: "user", Content: "summarize open pulls"},
+				{Role: "assistant", ToolCalls: []domain.ToolCall{toolCall("c1", "github_list_pulls"), toolCall("c2", "github_get_pull")}},
+				{Role: "tool", ToolCallID: "c1", Content: "[...]"},
+				{Role: "tool", ToolCallID: "c2", Content: "error"},
+				{Role: "assistant", ToolCalls: []domain.ToolCall{toolCall("c3", "github_list_pulls"), toolCall("c4", "get_current_time")}},
+				{Role: "tool", ToolCallID: "c3", Content: "[...]"},
+				{Role: "tool", ToolCallID: "c4", Content: "09:00"},
+				{Role: "assistant", Content: "Two pulls are open."},
+			},
+			failed: []string{"c2"},
+			want:   []chatTurn{{request: "summarize open pulls", tools: []string{"github_list_pulls", "get_current_time"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager()
+			for _, message := range tt.messages {
+				m.Context.AddMessage(message)
+			}
+			for _, id := range tt.failed {
+				m.Context.ToolCalls = append(m.Context.ToolCalls, ToolCallHistory{ToolCall: toolCall(id, ""), Error: "failed"})
+			}
+
+			if got := m.answeredTurns(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("answeredTurns() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolServer(t *testing.T) {
+	m := newTestManager()
+	m.ServerManager = &stubServers{names: []string{"github", "filesystem", "github_enterprise"}}
+	servers := m.serverNames()
+
+	tests := []struct {
+		tool string
+		want string
+	}{
+		{"github_list_pulls", "github"},
+		{"github_enterprise_list_pulls", "github_enterprise"},
+		{"filesystem_read_file", "filesystem"},
+		{"get_current_time", ""},
+		{"github", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			if got := toolServer(tt.tool, servers); got != tt.want {
+				t.Errorf("toolServer(%q) = %q, want %q", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildWorkflowDraft(t *testing.T) {
+	tests := []struct {
+		name         string
+		systemPrompt string
+		turns        []chatTurn
+		wantComments []string // lines the header must contain
+		wantServers  []string
+		wantRuns     []string
+	}{
+		{
+			name:         "single turn",
+			turns:        []chatTurn{{request: "What changed this week?"}},
+			wantComments: []string{"# Draft workflow exported from chat with /export-workflow."},
+			wantRuns:     []string{"What changed this week?"},
+		},
+		{
+			name:         "multi-line system prompt",
+			systemPrompt: "You are a release assistant.\n\nAnswer in bullet points.   \n  Keep it short.",
+			turns:        []chatTurn{{request: "Draft the notes"}},
+			wantComments: []string{
+				"#   You are a release assistant.\n#\n#   Answer in bullet points.\n#     Keep it short.\n",
+			},
+			wantRuns: []string{"Draft the notes"},
+		},
+		{
+			name: "tool calls mapped to servers",
+			turns: []chatTurn{
+				{request: "List open pulls", tools: []string{"github_enterprise_list_pulls", "get_current_time"}},
+				{request: "Save a summary", tools: []string{"filesystem_write_file", "github_enterprise_get_pull", "skill_docx"}},
+			},
+			wantComments: []string{"# Tools not from a connected server (built-in tools or skills; add skills: if needed): get_current_time, skill_docx\n"},
+			wantServers:  []string{"github_enterprise", "filesystem"},
+			wantRuns: []string{
+				"List open pulls\n\nUse these tools: github_enterprise_list_pulls, get_current_time",
+				"Previous result:\n{{step_1}}\n\nSave a summary\n\nUse these tools: filesystem_write_file, github_enterprise_get_pull, skill_docx",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager()
+			m.Context.SystemPrompt = tt.systemPrompt
+			m.ServerManager = &stubServers{names: []string{"github", "github_enterprise", "filesystem"}}
+			m.activeProvider = "openai"
+			m.modelName = "gpt-4o"
+
+			data, err := m.buildWorkflowDraft("weekly_notes", tt.turns)
+			if err != nil {
+				t.Fatalf("buildWorkflowDraft: %v", err)
+			}
+			for _, want := range tt.wantComments {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("draft missing %q:\n%s", want, data)
+				}
+			}
+			if tt.systemPrompt == "" && strings.Contains(string(data), "system prompt") {
+				t.Errorf("draft mentions a system prompt the chat didn't have:\n%s", data)
+			}
+
+			// The draft must load as a v2 workflow
+			wf, err := config.NewWorkflowLoader().LoadFromBytes(data)
+			if err != nil {
+				t.Fatalf("LoadFromBytes: %v\n%s", err, data)
+			}
+			if wf.Name != "weekly_notes" || wf.Execution.Provider != "openai" || wf.Execution.Model != "gpt-4o" {
+				t.Errorf("workflow = %s with %s/%s", wf.Name, wf.Execution.Provider, wf.Execution.Model)
+			}
+			if !reflect.DeepEqual(wf.Execution.Servers, tt.wantServers) {
+				t.Errorf("servers = %q, want %q", wf.Execution.Servers, tt.wantServers)
+			}
+			if len(wf.Steps) != len(tt.wantRuns) {
+				t.Fatalf("got %d steps, want %d", len(wf.Steps), len(tt.wantRuns))
+			}
+			for i, step := range wf.Steps {
+				if step.Run != tt.wantRuns[i] {
+					t.Errorf("step %d run = %q, want %q", i+1, step.Run, tt.wantRuns[i])
+				}
+				if i > 0 && !reflect.DeepEqual(step.Needs, []string{wf.Steps[i-1].Name}) {
+					t.Errorf("step %d needs = %q, want the previous step", i+1, step.Needs)
+				}
+			}
+		})
+	}
+}
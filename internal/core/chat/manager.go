@@ -10,13 +10,17 @@ import (
 	"time"
 
 	appChat "github.com/LaurieRhodes/mcp-cli-go/internal/app/chat"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/toolloop"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/models"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	mcplib "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/mcp"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolcontent"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tts"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 )
 
@@ -43,6 +47,36 @@ type ChatManager struct {
 	// Whether to stream responses
 	StreamResponses bool
 
+	// Speaks final responses aloud when set (chat --speak)
+	Speaker *tts.Speaker
+
+	// Runs workflows for /run; nil when workflows aren't available
+	Workflows WorkflowRunner
+
+	// Creates providers for /provider and /model; nil when switching isn't available
+	Providers ProviderSwitcher
+
+	// Retrieves context for each message (auto-RAG); nil when it's off
+	Retriever Retriever
+
+	// Earlier user messages added to retrieval queries
+	RetrievalTurns int
+
+	// Sources retrieved for the last message, for /sources
+	lastSources []RetrievedSource
+
+	// Puts files into the skills sandbox workspace for /upload; nil without skills
+	Uploader FileUploader
+
+	// Uploads to tell the model about with the next message (/upload)
+	uploadNotes []string
+
+	// Tool-calling rounds allowed per message; nil uses the defaults
+	ToolIterations *config.ToolIterationsConfig
+
+	// Bounds the tool-calling rounds of the current message
+	toolGuard *toolloop.Guard
+
 	// Available tools cache
 	toolsCache map[string][]tools.Tool
 
@@ -54,6 +88,9 @@ type ChatManager struct {
 	session       *appChat.Session
 	providerName  string
 	modelName     string
+
+	// Configured provider name, as used by /provider
+	activeProvider string
 }
 
 // NewChatManager creates a new chat manager
@@ -153,6 +190,9 @@ When writing code, save output files to /outputs/ directory:
 
 // ProcessUserMessage processes a user message and returns the response
 func (m *ChatManager) ProcessUserMessage(userInput string) error {
+	// Look up sources before the message joins the history
+	m.retrieveContext(userInput)
+
 	// Add user message to context
 	userMessage := domain.Message{
 		Role:    "user",
@@ -163,10 +203,11 @@ func (m *ChatManager) ProcessUserMessage(userInput string) error {
 	if m.session != nil {
 		m.session.AddMessage(convertDomainMessage(userMessage))
 	}
+	m.toolGuard = toolloop.NewGuard(m.ToolIterations.ChatLimit(), m.ToolIterations.RepeatLimit())
 
-	// Get available tools for the LLM
+	// Get the tools for this message (a skill router may narrow them)
 	logging.Info("Fetching available tools for LLM")
-	llmTools, err := m.GetAvailableTools()
+	llmTools, err := m.GetToolsForRequest(userInput)
 	if err != nil {
 		m.UI.PrintError("Failed to get available tools: %v", err)
 		// Continue without tools as a fallback
@@ -175,10 +216,10 @@ func (m *ChatManager) ProcessUserMessage(userInput string) error {
 	logging.Info("Successfully fetched %d tools for LLM", len(llmTools))
 
 	// Get messages for the LLM
-	messages := m.Context.GetMessagesForLLM()
+	messages := m.messagesForLLM()
 
 	// Show indicator that we're working
-	m.UI.PrintSystem("Thinking...")
+	m.UI.StartProgress(fmt.Sprintf("Thinking... %s", m.progressLabel()))
 
 	// Create completion request
 	completionReq := &domain.CompletionRequest{
@@ -213,6 +254,7 @@ func (m *ChatManager) ProcessUserMessage(userInput string) error {
 		// Fallback to non-streaming
 		logging.Info("Starting non-streaming completion")
 		response, err = m.LLMProvider.CreateCompletion(context.Background(), completionReq)
+		m.UI.StopProgress()
 
 		// Print the full response
 		if err == nil && response != nil {
@@ -223,6 +265,9 @@ func (m *ChatManager) ProcessUserMessage(userInput string) error {
 	if err != nil {
 		return fmt.Errorf("LLM completion error: %w", err)
 	}
+	if response, err = m.repairToolCalls(completionReq, response); err != nil {
+		return err
+	}
 
 	// Add assistant message to context
 	if response != nil {
@@ -242,8 +287,14 @@ func (m *ChatManager) ProcessUserMessage(userInput string) error {
 			m.lastAssistantMessageWithToolCalls = assistantMessage
 		}
 
-		// Handle tool calls if present
-		if len(response.ToolCalls) > 0 {
+		// Handle tool calls if present; otherwise this is the final response
+		if len(response.ToolCalls) == 0 {
+			m.speakResponse(response.Response)
+		} else if stopped, err := m.stopToolLoop(response.ToolCalls, userMessage.Content); stopped {
+			if err != nil {
+				m.UI.PrintError("Error getting follow-up response: %v", err)
+			}
+		} else {
 			m.UI.PrintSystem("Executing tool calls...")
 			err = m.HandleToolCalls(response.ToolCalls)
 			if err != nil {
@@ -262,6 +313,14 @@ func (m *ChatManager) ProcessUserMessage(userInput string) error {
 	return nil
 }
 
+// speakResponse reads a final assistant response aloud if a speaker is configured
+func (m *ChatManager) speakResponse(text string) {
+	if m.Speaker == nil || strings.TrimSpace(text) == "" {
+		return
+	}
+	m.Speaker.Speak(text)
+}
+
 // streamingWriter implements io.Writer for streaming responses
 type streamingWriter struct {
 	onChunk func(string) error
@@ -277,16 +336,19 @@ func (w *streamingWriter) Write(p []byte) (n int, err error) {
 // ProcessAfterToolExecution gets a follow-up response after tool execution
 func (m *ChatManager) ProcessAfterToolExecution(userQuery string) error {
 	// Get messages for the LLM - this will include the tool results now
-	messages := m.Context.GetMessagesForLLM()
+	messages := m.messagesForLLM()
 
 	// Get available tools for the LLM (might need more tools)
-	llmTools, err := m.GetAvailableTools()
+	llmTools, err := m.GetToolsForRequest(userQuery)
 	if err != nil {
 		llmTools = []domain.Tool{} // Continue without tools as fallback
 	}
+	if m.toolGuard != nil && m.toolGuard.Stopped() {
+		llmTools = []domain.Tool{} // Tool calls were stopped; ask for a final answer
+	}
 
 	// Show indicator that we're working on a response
-	m.UI.PrintSystem("Generating response based on tool results...")
+	m.UI.StartProgress(fmt.Sprintf("Generating response based on tool results... %s", m.progressLabel()))
 
 	// Create completion request
 	completionReq := &domain.CompletionRequest{
@@ -321,6 +383,7 @@ func (m *ChatManager) ProcessAfterToolExecution(userQuery string) error {
 		// Fallback to non-streaming
 		logging.Info("Starting follow-up non-streaming completion")
 		response, err = m.LLMProvider.CreateCompletion(context.Background(), completionReq)
+		m.UI.StopProgress()
 
 		// Print the full response
 		if err == nil && response != nil {
@@ -331,6 +394,9 @@ func (m *ChatManager) ProcessAfterToolExecution(userQuery string) error {
 	if err != nil {
 		return fmt.Errorf("follow-up completion error: %w", err)
 	}
+	if response, err = m.repairToolCalls(completionReq, response); err != nil {
+		return err
+	}
 
 	// Add assistant message to context
 	if response != nil {
@@ -350,8 +416,12 @@ func (m *ChatManager) ProcessAfterToolExecution(userQuery string) error {
 			m.lastAssistantMessageWithToolCalls = assistantMessage
 		}
 
-		// Handle any additional tool calls if present
-		if len(response.ToolCalls) > 0 {
+		// Handle any additional tool calls if present; otherwise this is the final response
+		if len(response.ToolCalls) == 0 {
+			m.speakResponse(response.Response)
+		} else if stopped, err := m.stopToolLoop(response.ToolCalls, userQuery); stopped {
+			return err
+		} else {
 			m.UI.PrintSystem("Executing additional tool calls...")
 			err = m.HandleToolCalls(response.ToolCalls)
 			if err != nil {
@@ -368,6 +438,38 @@ func (m *ChatManager) ProcessAfterToolExecution(userQuery string) error {
 	return nil
 }
 
+// stopToolLoop checks tool calls against the message's iteration budget.
+// Refused calls are answered without running them; the first time, the
+// model is then asked for a final answer without tools. It returns false
+// when the calls may run.
+func (m *ChatManager) stopToolLoop(toolCalls []domain.ToolCall, userQuery string) (bool, error) {
+	if m.toolGuard == nil {
+		return false, nil
+	}
+	alreadyStopped := m.toolGuard.Stopped()
+	if m.toolGuard.Check(toolCalls) == toolloop.Completed {
+		return false, nil
+	}
+
+	// Every tool call needs a result message, even when it doesn't run
+	for _, toolCall := range toolCalls {
+		m.Context.AddMessage(domain.Message{
+			Role:       "tool",
+			Content:    "Not run. " + m.toolGuard.FinalAnswerPrompt(),
+			ToolCallID: toolCall.ID,
+		})
+	}
+
+	if alreadyStopped {
+		m.UI.PrintSystem("The model kept calling tools after being stopped; the answer may be incomplete.")
+		return true, nil
+	}
+
+	logging.Warn("Stopping tool calls (%s): %s", m.toolGuard.Status(), m.toolGuard.Reason())
+	m.UI.PrintSystem("Stopped tool calls: %s. Asking for a final answer.", m.toolGuard.Reason())
+	return true, m.ProcessAfterToolExecution(userQuery)
+}
+
 // HandleToolCalls executes tool calls and adds results to the context
 func (m *ChatManager) HandleToolCalls(toolCalls []domain.ToolCall) error {
 	for _, toolCall := range toolCalls {
@@ -402,7 +504,7 @@ func (m *ChatManager) HandleToolCalls(toolCalls []domain.ToolCall) error {
 		var toolResultContent string
 		if err != nil {
 			m.UI.PrintError("Tool execution failed: %v", err)
-			toolResultContent = fmt.Sprintf("Error: %v", err)
+			toolResultContent = domainErrors.FormatToolResult(err)
 		} else {
 			toolResultContent = result
 		}
@@ -424,6 +526,15 @@ func (m *ChatManager) HandleToolCalls(toolCalls []domain.ToolCall) error {
 	return nil
 }
 
+// progressLabel describes the provider and model for progress indicators
+func (m *ChatManager) progressLabel() string {
+	provider := string(m.LLMProvider.GetProviderType())
+	if m.modelName == "" {
+		return fmt.Sprintf("(%s)", provider)
+	}
+	return fmt.Sprintf("(%s/%s)", provider, m.modelName)
+}
+
 // getDefaultToolArguments provides sensible defaults for common tools
 func (m *ChatManager) getDefaultToolArguments(toolName string) string {
 	// For List Directory, default to project root
@@ -465,7 +576,9 @@ func (m *ChatManager) executeToolCallWithServerManager(toolCall domain.ToolCall)
 
 	// Execute tool using server manager
 	logging.Debug("Executing tool %s using server manager", toolCall.Function.Name)
+	m.UI.StartProgress(fmt.Sprintf("Running %s...", toolCall.Function.Name))
 	result, err := m.ServerManager.ExecuteTool(context.Background(), toolCall.Function.Name, args)
+	m.UI.StopProgress()
 	if err != nil {
 		return "", fmt.Errorf("tool execution error: %w", err)
 	}
@@ -560,7 +673,9 @@ func (m *ChatManager) executeToolCallWithConnections(toolCall domain.ToolCall) (
 		return "", fmt.Errorf("server %s does not support stdio protocol", serverName)
 	}
 
+	m.UI.StartProgress(fmt.Sprintf("Running %s on %s...", toolName, serverName))
 	result, err := tools.SendToolsCall(stdioClient, stdioClient.GetDispatcher(), toolName, args)
+	m.UI.StopProgress()
 	if err != nil {
 		return "", fmt.Errorf("tool execution error: %w", err)
 	}
@@ -584,6 +699,10 @@ func (m *ChatManager) executeToolCallWithConnections(toolCall domain.ToolCall) (
 		return "", fmt.Errorf("tool execution failed: %s", result.Error)
 	}
 
+	if text, ok := toolcontent.Render(toolName, result.Content); ok {
+		return text, nil
+	}
+
 	// Convert result to string if needed
 	var resultStr string
 	switch content := result.Content.(type) {
@@ -676,6 +795,15 @@ func formatToolNameForOpenAI(serverName, toolName string) string {
 	return fmt.Sprintf("%s_%s", serverName, toolName)
 }
 
+// GetToolsForRequest returns the tools to offer the LLM for a user message.
+// A server manager with a skill router offers only the relevant skills.
+func (m *ChatManager) GetToolsForRequest(request string) ([]domain.Tool, error) {
+	if m.ServerManager != nil {
+		return domain.SelectTools(context.Background(), m.ServerManager, request)
+	}
+	return m.GetAvailableTools()
+}
+
 // GetAvailableTools returns the tools available for the LLM
 func (m *ChatManager) GetAvailableTools() ([]domain.Tool, error) {
 	// ARCHITECTURAL FIX: Use ServerManager if available (supports built-in skills)
@@ -964,6 +1092,7 @@ func (m *ChatManager) StartChat() error {
 	// Create session for logging
 	if m.sessionLogger != nil && m.sessionLogger.IsEnabled() {
 		m.session = appChat.NewSession(m.Context.SystemPrompt)
+		m.session.SetModel(m.modelName)
 		logging.Info("Created chat session: %s", m.session.ID)
 	}
 
@@ -1003,7 +1132,8 @@ func (m *ChatManager) StartChat() error {
 		// Process commands
 		if strings.HasPrefix(userInput, "/") {
 			cmd := strings.TrimSpace(userInput)
-			switch cmd {
+			fields := strings.Fields(cmd)
+			switch fields[0] {
 			case "/exit", "/quit":
 				m.UI.PrintSystem("Exiting chat mode.")
 				return nil
@@ -1012,6 +1142,7 @@ func (m *ChatManager) StartChat() error {
 				continue
 			case "/clear":
 				m.Context = NewChatContext(m.Context.SystemPrompt)
+				m.lastSources = nil
 				m.UI.PrintSystem("Chat history cleared.")
 				continue
 			case "/tools":
@@ -1029,6 +1160,42 @@ func (m *ChatManager) StartChat() error {
 				// Print context statistics
 				m.PrintContextStats()
 				continue
+			case "/copy":
+				m.HandleCopyCommand(fields[1:])
+				continue
+			case "/render":
+				m.HandleRenderCommand(fields[1:])
+				continue
+			case "/roots":
+				m.HandleRootsCommand(fields[1:])
+				continue
+			case "/provider":
+				m.HandleProviderCommand(fields[1:])
+				continue
+			case "/model":
+				m.HandleModelCommand(fields[1:])
+				continue
+			case "/export-workflow":
+				m.HandleExportWorkflowCommand(strings.TrimPrefix(cmd, "/export-workflow"))
+				continue
+			case "/export-context":
+				m.HandleExportContextCommand(strings.TrimPrefix(cmd, "/export-context"))
+				continue
+			case "/sources":
+				m.HandleSourcesCommand(fields[1:])
+				continue
+			case "/run":
+				m.HandleRunCommand(strings.TrimPrefix(cmd, "/run"))
+				continue
+			case "/upload":
+				m.HandleUploadCommand(strings.TrimPrefix(cmd, "/upload"))
+				continue
+			case "/paste":
+				pasted, ok := m.HandlePasteCommand(strings.TrimPrefix(cmd, "/paste"))
+				if !ok {
+					continue
+				}
+				userInput = pasted
 			default:
 				m.UI.PrintSystem("Unknown command: %s", cmd)
 				continue
@@ -1036,7 +1203,7 @@ func (m *ChatManager) StartChat() error {
 		}
 
 		// Process user message
-		err = m.ProcessUserMessage(userInput)
+		err = m.ProcessUserMessage(m.withUploadNotes(userInput))
 		// Log session after processing message
 		m.logSession()
 		if err != nil {
@@ -1097,6 +1264,33 @@ func (m *ChatManager) PrintChatHistory() {
 	fmt.Println()
 }
 
+// HandleRenderCommand toggles markdown rendering (/render, /render on, /render off)
+func (m *ChatManager) HandleRenderCommand(args []string) {
+	enabled := !m.UI.MarkdownRendering()
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "on":
+			enabled = true
+		case "off":
+			enabled = false
+		default:
+			m.UI.PrintError("Usage: /render [on|off]")
+			return
+		}
+	}
+
+	if !m.UI.SetMarkdownRendering(enabled) {
+		m.UI.PrintError("Markdown rendering is not available (colors disabled or output is not a terminal)")
+		return
+	}
+
+	if enabled {
+		m.UI.PrintSystem("Markdown rendering enabled.")
+	} else {
+		m.UI.PrintSystem("Markdown rendering disabled; responses are shown as plain text.")
+	}
+}
+
 // PrintContextStats prints context utilization statistics
 func (m *ChatManager) PrintContextStats() {
 	stats := m.Context.GetContextStats()
@@ -1109,6 +1303,7 @@ func (m *ChatManager) PrintContextStats() {
 
 	if stats["token_management"] == "enabled" {
 		fmt.Printf("  Current Tokens: %v", stats["current_tokens"])
+		fmt.Printf("  Tokenizer: %v", stats["tokenizer"])
 		fmt.Printf("  Max Tokens: %v", stats["max_tokens"])
 		fmt.Printf("  Reserve Tokens: %v", stats["reserve_tokens"])
 		fmt.Printf("  Effective Limit: %v", stats["effective_limit"])
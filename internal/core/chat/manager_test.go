@@ -0,0 +1,48 @@
+package chat
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+// newTestManager returns a chat manager with a plain, spinner-free UI and an
+// empty conversation
+func newTestManager() *ChatManager {
+	plain := color.New()
+	return &ChatManager{
+		Context: NewChatContext("You are a test assistant."),
+		UI: &UI{
+			userColor:      plain,
+			assistantColor: plain,
+			systemColor:    plain,
+			toolColor:      plain,
+			errorColor:     plain,
+			noColor:        true,
+			quiet:          true,
+		},
+	}
+}
+
+// captureOutput returns what fn prints to stdout, colored or not
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, colorOutput, noColor := os.Stdout, color.Output, color.NoColor
+	os.Stdout, color.Output, color.NoColor = w, w, true
+	defer func() { os.Stdout, color.Output, color.NoColor = stdout, colorOutput, noColor }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
@@ -0,0 +1,111 @@
+package chat
+
+import (
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// ProviderSwitcher creates LLM providers from the configuration, so /provider
+// and /model can change the model mid-conversation
+type ProviderSwitcher interface {
+	// ProviderNames lists the configured providers
+	ProviderNames() []string
+
+	// ProviderConfig returns a provider's configuration
+	ProviderConfig(name string) (*config.ProviderConfig, error)
+
+	// NewProvider creates a provider for a model ("" for the provider's
+	// default) and returns it with its configuration and resolved model
+	NewProvider(name, model string) (domain.LLMProvider, *config.ProviderConfig, string, error)
+}
+
+// SetActiveProvider records the configured provider the chat started with
+func (m *ChatManager) SetActiveProvider(name string) {
+	m.activeProvider = name
+}
+
+// HandleProviderCommand shows or switches the provider.
+//
+//	/provider                list providers
+//	/provider NAME [MODEL]   switch provider, using its default model unless one is given
+func (m *ChatManager) HandleProviderCommand(args []string) {
+	if m.Providers == nil {
+		m.UI.PrintSystem("Switching providers is not available in this chat.")
+		return
+	}
+
+	if len(args) == 0 {
+		m.UI.PrintSystem("Using %s (%s). Providers (/provider NAME [MODEL]):", m.activeProvider, m.modelName)
+		for _, name := range m.Providers.ProviderNames() {
+			marker := " "
+			if name == m.activeProvider {
+				marker = "*"
+			}
+			m.UI.PrintSystem("  %s %s", marker, name)
+		}
+		return
+	}
+
+	model := ""
+	if len(args) > 1 {
+		model = args[1]
+	}
+	m.switchProvider(args[0], model)
+}
+
+// HandleModelCommand shows or switches the model of the current provider.
+//
+//	/model          list the provider's models
+//	/model NAME     switch model
+func (m *ChatManager) HandleModelCommand(args []string) {
+	if m.Providers == nil {
+		m.UI.PrintSystem("Switching models is not available in this chat.")
+		return
+	}
+
+	if len(args) == 0 {
+		m.UI.PrintSystem("Using %s (%s).", m.activeProvider, m.modelName)
+		providerConfig, err := m.Providers.ProviderConfig(m.activeProvider)
+		if err != nil || len(providerConfig.AvailableModels) == 0 {
+			m.UI.PrintSystem("Switch with /model NAME.")
+			return
+		}
+		m.UI.PrintSystem("Models (/model NAME):")
+		for _, name := range providerConfig.AvailableModels {
+			marker := " "
+			if name == m.modelName {
+				marker = "*"
+			}
+			m.UI.PrintSystem("  %s %s", marker, name)
+		}
+		return
+	}
+
+	m.switchProvider(m.activeProvider, args[0])
+}
+
+// switchProvider replaces the LLM, keeping the conversation
+func (m *ChatManager) switchProvider(name, model string) {
+	llm, providerConfig, model, err := m.Providers.NewProvider(name, model)
+	if err != nil {
+		m.UI.PrintError("%v", err)
+		return
+	}
+
+	m.LLMProvider = llm
+	m.activeProvider = name
+	m.providerName = string(llm.GetProviderType())
+	m.modelName = model
+	if m.session != nil {
+		m.session.SetModel(model)
+	}
+
+	before := len(m.Context.Messages)
+	if err := m.Context.UpdateProvider(model, providerConfig); err != nil {
+		m.UI.PrintSystem("Token limits for %s are unknown; long histories are trimmed by message count.", model)
+	}
+	m.UI.PrintSystem("Switched to %s (%s). Conversation history is kept.", name, model)
+	if trimmed := before - len(m.Context.Messages); trimmed > 0 {
+		m.UI.PrintSystem("Dropped the %d oldest messages to fit the new model's context window.", trimmed)
+	}
+}
@@ -0,0 +1,188 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// stubLLM is a provider that only reports its type
+type stubLLM struct {
+	domain.LLMProvider
+	providerType domain.ProviderType
+	model        string
+}
+
+func (l *stubLLM) GetProviderType() domain.ProviderType { return l.providerType }
+
+// fakeProviders switches between canned provider configurations and records
+// the providers it creates
+type fakeProviders struct {
+	configs map[string]*config.ProviderConfig
+	err     error
+	created []*stubLLM
+}
+
+func (f *fakeProviders) ProviderNames() []string { return []string{"anthropic", "local", "openai"} }
+
+func (f *fakeProviders) ProviderConfig(name string) (*config.ProviderConfig, error) {
+	if providerConfig, ok := f.configs[name]; ok {
+		return providerConfig, nil
+	}
+	return nil, fmt.Errorf("provider %s not found", name)
+}
+
+func (f *fakeProviders) NewProvider(name, model string) (domain.LLMProvider, *config.ProviderConfig, string, error) {
+	if f.err != nil {
+		return nil, nil, "", f.err
+	}
+	providerConfig, err := f.ProviderConfig(name)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if model == "" {
+		model = providerConfig.DefaultModel
+	}
+	llm := &stubLLM{providerType: domain.ProviderType(name), model: model}
+	f.created = append(f.created, llm)
+	return llm, providerConfig, model, nil
+}
+
+// newSwitchingManager returns a manager chatting with openai's gpt-4o, one
+// exchange in
+func newSwitchingManager(providers ProviderSwitcher) (*ChatManager, *stubLLM) {
+	m := newTestManager()
+	llm := &stubLLM{providerType: "openai", model: "gpt-4o"}
+	m.LLMProvider = llm
+	m.Providers = providers
+	m.SetActiveProvider("openai")
+	m.providerName = "openai"
+	m.modelName = "gpt-4o"
+	m.Context.AddMessage(domain.Message{Role: "user", Content: "hello"})
+	m.Context.AddMessage(domain.Message{Role: "assistant", Content: "Hi!"})
+	return m, llm
+}
+
+func testProviders() *fakeProviders {
+	return &fakeProviders{configs: map[string]*config.ProviderConfig{
+		"openai":    {DefaultModel: "gpt-4o", AvailableModels: []string{"gpt-4o", "gpt-4o-mini"}},
+		"anthropic": {DefaultModel: "claude-sonnet-4"},
+	}}
+}
+
+func TestProviderCommandsUnavailable(t *testing.T) {
+	m := newTestManager()
+	out := captureOutput(t, func() {
+		m.HandleProviderCommand(nil)
+		m.HandleModelCommand([]string{"gpt-4o"})
+	})
+	for _, want := range []string{"Switching providers is not available", "Switching models is not available"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHandleProviderCommandList(t *testing.T) {
+	m, _ := newSwitchingManager(testProviders())
+	out := captureOutput(t, func() { m.HandleProviderCommand(nil) })
+	want := "Using openai (gpt-4o). Providers (/provider NAME [MODEL]):\n    anthropic\n    local\n  * openai\n"
+	if out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func TestHandleModelCommandList(t *testing.T) {
+	m, _ := newSwitchingManager(testProviders())
+	out := captureOutput(t, func() { m.HandleModelCommand(nil) })
+	if want := "Using openai (gpt-4o).\nModels (/model NAME):\n  * gpt-4o\n    gpt-4o-mini\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+
+	// Without configured models, or for a provider that can't be looked up
+	for _, active := range []string{"anthropic", "router:cheap"} {
+		m.SetActiveProvider(active)
+		out := captureOutput(t, func() { m.HandleModelCommand(nil) })
+		if !strings.HasSuffix(out, "Switch with /model NAME.\n") {
+			t.Errorf("%s: output = %q, want the switch hint", active, out)
+		}
+	}
+}
+
+func TestSwitchProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		command      func(m *ChatManager)
+		wantProvider string
+		wantModel    string
+	}{
+		{"provider default model", func(m *ChatManager) { m.HandleProviderCommand([]string{"anthropic"}) }, "anthropic", "claude-sonnet-4"},
+		{"provider and model", func(m *ChatManager) { m.HandleProviderCommand([]string{"anthropic", "claude-opus-4"}) }, "anthropic", "claude-opus-4"},
+		{"model of the active provider", func(m *ChatManager) { m.HandleModelCommand([]string{"gpt-4o-mini"}) }, "openai", "gpt-4o-mini"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers := testProviders()
+			m, _ := newSwitchingManager(providers)
+
+			out := captureOutput(t, func() { tt.command(m) })
+			if len(providers.created) != 1 {
+				t.Fatalf("created %d providers, want 1", len(providers.created))
+			}
+			if llm := providers.created[0]; m.LLMProvider != llm || llm.model != tt.wantModel {
+				t.Errorf("LLMProvider = %+v, want the new %s provider", m.LLMProvider, tt.wantModel)
+			}
+			if m.activeProvider != tt.wantProvider || m.providerName != tt.wantProvider || m.modelName != tt.wantModel || m.Context.CurrentModel != tt.wantModel {
+				t.Errorf("switched to %s/%s (type %s, context %s), want %s/%s", m.activeProvider, m.modelName, m.providerName, m.Context.CurrentModel, tt.wantProvider, tt.wantModel)
+			}
+			if m.Context.TokenManager == nil {
+				t.Error("no token manager for the new model")
+			}
+			if want := fmt.Sprintf("Switched to %s (%s). Conversation history is kept.", tt.wantProvider, tt.wantModel); !strings.Contains(out, want) {
+				t.Errorf("output = %q, want %q", out, want)
+			}
+			if len(m.Context.Messages) != 2 {
+				t.Errorf("history has %d messages after switching, want 2", len(m.Context.Messages))
+			}
+		})
+	}
+}
+
+func TestSwitchProviderFailure(t *testing.T) {
+	providers := testProviders()
+	m, llm := newSwitchingManager(providers)
+	providers.err = errors.New("failed to switch to anthropic: missing API key")
+
+	out := captureOutput(t, func() { m.HandleProviderCommand([]string{"anthropic"}) })
+	if !strings.Contains(out, "Error: failed to switch to anthropic: missing API key") {
+		t.Errorf("output = %q, want the error", out)
+	}
+	if m.LLMProvider != llm || m.activeProvider != "openai" || m.modelName != "gpt-4o" {
+		t.Errorf("switched to %s/%s after a failure", m.activeProvider, m.modelName)
+	}
+}
+
+func TestSwitchProviderTrimsHistory(t *testing.T) {
+	providers := testProviders()
+	providers.configs["local"] = &config.ProviderConfig{DefaultModel: "tiny", ContextWindow: 600, ReserveTokens: 100}
+	m, _ := newSwitchingManager(providers)
+	for i := 0; i < 10; i++ {
+		m.Context.AddMessage(domain.Message{Role: "user", Content: strings.Repeat("long question ", 20)})
+		m.Context.AddMessage(domain.Message{Role: "assistant", Content: strings.Repeat("long answer ", 20)})
+	}
+
+	before := len(m.Context.Messages)
+	out := captureOutput(t, func() { m.HandleProviderCommand([]string{"local"}) })
+	trimmed := before - len(m.Context.Messages)
+	if trimmed <= 0 {
+		t.Fatalf("history wasn't trimmed to the 600-token window (%d messages)", before)
+	}
+	if want := fmt.Sprintf("Dropped the %d oldest messages to fit the new model's context window.", trimmed); !strings.Contains(out, want) {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
@@ -0,0 +1,66 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/toolloop"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// repairToolCalls checks the tool calls of a response against the tools
+// offered with req. Invalid calls (unknown tools, arguments not matching the
+// schema) aren't run: the model is told what was wrong and asked again, up
+// to the configured number of times. The corrections aren't kept in the
+// chat history.
+func (m *ChatManager) repairToolCalls(req *domain.CompletionRequest, response *domain.CompletionResponse) (*domain.CompletionResponse, error) {
+	messages := req.Messages
+	for attempt := 0; response != nil; attempt++ {
+		invalid := toolloop.ValidateCalls(response.ToolCalls, req.Tools)
+		if len(invalid) == 0 {
+			return response, nil
+		}
+		if attempt >= m.ToolIterations.RepairLimit() {
+			return nil, &toolloop.InvalidCallsError{Calls: invalid}
+		}
+
+		logging.Warn("Model made %d invalid tool calls; asking it to correct them (attempt %d)", len(invalid), attempt+1)
+		m.UI.PrintSystem("The model made %d invalid tool calls; asking it to correct them...", len(invalid))
+		messages = append(messages[:len(messages):len(messages)], domain.Message{
+			Role:    "user",
+			Content: toolloop.CorrectionPrompt(invalid, req.Tools),
+		})
+
+		retry := *req
+		retry.Messages = messages
+		var err error
+		if response, err = m.complete(&retry); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// complete gets a completion, streaming or printing it like the chat's
+// other responses
+func (m *ChatManager) complete(req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	if m.StreamResponses {
+		m.UI.StartStreamingResponse()
+		response, err := m.LLMProvider.StreamCompletion(context.Background(), req, &streamingWriter{
+			onChunk: func(chunk string) error {
+				m.UI.StreamAssistantResponse(chunk)
+				return nil
+			},
+		})
+		m.UI.EndStreamingResponse()
+		return response, err
+	}
+
+	m.UI.StartProgress("Thinking... " + m.progressLabel())
+	response, err := m.LLMProvider.CreateCompletion(context.Background(), req)
+	m.UI.StopProgress()
+	if err == nil && response != nil {
+		m.UI.PrintAssistantResponse(response.Response)
+	}
+	return response, err
+}
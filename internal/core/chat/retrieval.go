@@ -0,0 +1,146 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// sourcePreviewLength is how much of a source's text /sources lists
+const sourcePreviewLength = 160
+
+// Retriever looks up context for chat messages (auto-RAG)
+type Retriever interface {
+	// Retrieve searches for query and returns the best results
+	Retrieve(ctx context.Context, query string) ([]RetrievedSource, error)
+}
+
+// RetrievedSource is a result retrieved for a message
+type RetrievedSource struct {
+	ID     string  // Chunk ID
+	Source string  // Source file or document
+	Server string  // RAG server it came from
+	Score  float64 // Retrieval score
+	Text   string  // Chunk text
+}
+
+// retrieveContext searches for the message before it's answered. The query
+// is the message with the user's previous RetrievalTurns messages, so
+// follow-up questions find the same material. Retrieval errors are reported
+// and the message is answered without context.
+func (m *ChatManager) retrieveContext(userInput string) {
+	m.lastSources = nil
+	if m.Retriever == nil {
+		return
+	}
+
+	query := m.retrievalQuery(userInput)
+	m.UI.StartProgress("Searching sources...")
+	sources, err := m.Retriever.Retrieve(context.Background(), query)
+	m.UI.StopProgress()
+	if err != nil {
+		logging.Warn("Auto-RAG retrieval failed: %v", err)
+		m.UI.PrintError("Retrieval failed, answering without sources: %v", err)
+		return
+	}
+
+	m.lastSources = sources
+	logging.Info("Auto-RAG retrieved %d sources", len(sources))
+}
+
+// retrievalQuery joins the message with the user's previous messages
+func (m *ChatManager) retrievalQuery(userInput string) string {
+	parts := []string{userInput}
+	for i := len(m.Context.Messages) - 1; i >= 0 && len(parts) <= m.RetrievalTurns; i-- {
+		if msg := m.Context.Messages[i]; msg.Role == "user" && strings.TrimSpace(msg.Content) != "" {
+			parts = append(parts, msg.Content)
+		}
+	}
+	// Oldest first, as the conversation went
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, "\n")
+}
+
+// messagesForLLM returns the messages to send, with the sources retrieved
+// for the current message added to the system prompt. The sources aren't
+// kept in the history, so each message only sees its own.
+func (m *ChatManager) messagesForLLM() []domain.Message {
+	messages := m.Context.GetMessagesForLLM()
+	if len(m.lastSources) == 0 || len(messages) == 0 || messages[0].Role != "system" {
+		return messages
+	}
+
+	messages = append([]domain.Message(nil), messages...)
+	messages[0].Content += "\n\n" + formatRetrievedContext(m.lastSources)
+	return messages
+}
+
+// formatRetrievedContext presents retrieved sources to the model
+func formatRetrievedContext(sources []RetrievedSource) string {
+	var sb strings.Builder
+	sb.WriteString("Retrieved context for the user's latest message. Use it where it is relevant and say which source you used; ignore it if it doesn't help.\n")
+	for i, source := range sources {
+		fmt.Fprintf(&sb, "\n[%d] %s\n%s\n", i+1, sourceLabel(source), strings.TrimSpace(source.Text))
+	}
+	return sb.String()
+}
+
+// sourceLabel names where a source came from
+func sourceLabel(source RetrievedSource) string {
+	label := source.Source
+	if label == "" {
+		label = source.ID
+	}
+	if source.Server != "" {
+		label += " (" + source.Server + ")"
+	}
+	return label
+}
+
+// HandleSourcesCommand shows what auto-RAG retrieved for the last message.
+//
+//	/sources      list the sources with a preview
+//	/sources N    show source N in full
+func (m *ChatManager) HandleSourcesCommand(args []string) {
+	if m.Retriever == nil {
+		m.UI.PrintSystem("Auto-RAG is off. Enable it with chat.auto_rag in settings.yaml.")
+		return
+	}
+	if len(m.lastSources) == 0 {
+		m.UI.PrintSystem("No sources were retrieved for the last message.")
+		return
+	}
+
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(m.lastSources) {
+			m.UI.PrintError("Usage: /sources [N], where N is 1-%d", len(m.lastSources))
+			return
+		}
+		source := m.lastSources[n-1]
+		m.UI.PrintSystem("[%d] %s (score %.3f)", n, sourceLabel(source), source.Score)
+		fmt.Println(strings.TrimSpace(source.Text))
+		return
+	}
+
+	m.UI.PrintSystem("Sources retrieved for the last message (/sources N to show one):")
+	for i, source := range m.lastSources {
+		m.UI.PrintSystem("  [%d] %s (score %.3f)", i+1, sourceLabel(source), source.Score)
+		fmt.Printf("      %s\n", sourcePreview(source.Text))
+	}
+}
+
+// sourcePreview is the start of a source's text on one line
+func sourcePreview(text string) string {
+	preview := []rune(strings.Join(strings.Fields(text), " "))
+	if len(preview) > sourcePreviewLength {
+		return string(preview[:sourcePreviewLength]) + "..."
+	}
+	return string(preview)
+}
@@ -0,0 +1,118 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+// fakeRetriever returns canned sources and records its queries
+type fakeRetriever struct {
+	sources []RetrievedSource
+	err     error
+	queries []string
+}
+
+func (r *fakeRetriever) Retrieve(ctx context.Context, query string) ([]RetrievedSource, error) {
+	r.queries = append(r.queries, query)
+	return r.sources, r.err
+}
+
+var testSources = []RetrievedSource{
+	{ID: "c1", Source: "guide.md", Server: "kb", Score: 0.91, Text: "  Sign in with SSO.\n"},
+	{ID: "c2", Score: 0.42, Text: strings.Repeat("word ", 50)},
+}
+
+func TestRetrieveContextInjectsSources(t *testing.T) {
+	m := newTestManager()
+	retriever := &fakeRetriever{sources: testSources}
+	m.Retriever = retriever
+	m.RetrievalTurns = 1
+	for _, msg := range []domain.Message{
+		{Role: "user", Content: "first question"},
+		{Role: "assistant", Content: "first answer"},
+		{Role: "user", Content: "how do I log in?"},
+		{Role: "assistant", Content: "with SSO"},
+	} {
+		m.Context.AddMessage(msg)
+	}
+
+	m.retrieveContext("what about on mobile?")
+	if len(retriever.queries) != 1 || retriever.queries[0] != "how do I log in?\nwhat about on mobile?" {
+		t.Errorf("queries = %q, want the message after the previous user message", retriever.queries)
+	}
+	m.Context.AddMessage(domain.Message{Role: "user", Content: "what about on mobile?"})
+
+	messages := m.messagesForLLM()
+	system := messages[0].Content
+	if !strings.HasPrefix(system, "You are a test assistant.\n\nRetrieved context") {
+		t.Errorf("system prompt doesn't start with the prompt, then the context:\n%s", system)
+	}
+	for _, want := range []string{"[1] guide.md (kb)\nSign in with SSO.\n", "[2] c2\n"} {
+		if !strings.Contains(system, want) {
+			t.Errorf("system prompt missing %q:\n%s", want, system)
+		}
+	}
+	if last := messages[len(messages)-1]; last.Role != "user" || last.Content != "what about on mobile?" {
+		t.Errorf("last message = %+v, want the user's message unchanged", last)
+	}
+
+	// The sources aren't kept in the history
+	if stored := m.Context.GetMessagesForLLM()[0].Content; strings.Contains(stored, "Retrieved context") {
+		t.Errorf("context's system prompt was changed:\n%s", stored)
+	}
+}
+
+func TestRetrieveContextFailure(t *testing.T) {
+	m := newTestManager()
+	m.Retriever = &fakeRetriever{err: errors.New("server down")}
+	m.lastSources = testSources // from the previous message
+
+	out := captureOutput(t, func() { m.retrieveContext("question") })
+	if !strings.Contains(out, "answering without sources: server down") {
+		t.Errorf("output = %q, want the retrieval error", out)
+	}
+	if m.lastSources != nil {
+		t.Errorf("lastSources = %v, want none after a failure", m.lastSources)
+	}
+	if system := m.messagesForLLM()[0].Content; strings.Contains(system, "Retrieved context") {
+		t.Errorf("system prompt has stale context:\n%s", system)
+	}
+}
+
+func TestHandleSourcesCommand(t *testing.T) {
+	m := newTestManager()
+	if out := captureOutput(t, func() { m.HandleSourcesCommand(nil) }); !strings.Contains(out, "Auto-RAG is off") {
+		t.Errorf("without a retriever: %q", out)
+	}
+
+	m.Retriever = &fakeRetriever{}
+	if out := captureOutput(t, func() { m.HandleSourcesCommand(nil) }); !strings.Contains(out, "No sources were retrieved") {
+		t.Errorf("without sources: %q", out)
+	}
+
+	m.lastSources = testSources
+	out := captureOutput(t, func() { m.HandleSourcesCommand(nil) })
+	for _, want := range []string{
+		"[1] guide.md (kb) (score 0.910)\n      Sign in with SSO.\n",
+		"[2] c2 (score 0.420)\n      " + strings.Repeat("word ", 32)[:sourcePreviewLength] + "...\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("listing missing %q:\n%s", want, out)
+		}
+	}
+
+	out = captureOutput(t, func() { m.HandleSourcesCommand([]string{"2"}) })
+	if !strings.Contains(out, "[2] c2 (score 0.420)") || !strings.Contains(out, strings.TrimSpace(strings.Repeat("word ", 50))) {
+		t.Errorf("/sources 2 = %q, want the full text", out)
+	}
+
+	for _, arg := range []string{"0", "3", "x"} {
+		if out := captureOutput(t, func() { m.HandleSourcesCommand([]string{arg}) }); !strings.Contains(out, "Usage: /sources [N], where N is 1-2") {
+			t.Errorf("/sources %s = %q, want usage", arg, out)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package chat
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/roots"
+)
+
+// HandleRootsCommand shows or changes the project roots advertised to the
+// connected servers, which are notified of every change.
+//
+//	/roots                list the roots
+//	/roots add PATH       add a directory
+//	/roots remove PATH|N  remove a root by path or number
+func (m *ChatManager) HandleRootsCommand(args []string) {
+	current := roots.Current()
+	if len(args) == 0 {
+		if len(current) == 0 {
+			m.UI.PrintSystem("No project roots are advertised to servers.")
+			return
+		}
+		m.UI.PrintSystem("Project roots:")
+		for i, root := range current {
+			m.UI.PrintSystem("  %d. %s (%s)", i+1, root.Name, root.Path())
+		}
+		return
+	}
+
+	if len(args) < 2 || (args[0] != "add" && args[0] != "remove") {
+		m.UI.PrintError("Usage: /roots [add PATH | remove PATH|N]")
+		return
+	}
+	path := strings.Join(args[1:], " ")
+
+	if args[0] == "add" {
+		root, err := roots.FromPath(path, "")
+		if err != nil {
+			m.UI.PrintError("%v", err)
+			return
+		}
+		for _, existing := range current {
+			if existing.URI == root.URI {
+				m.UI.PrintSystem("%s is already a root.", root.Path())
+				return
+			}
+		}
+		roots.Set(append(current, root))
+		m.UI.PrintSystem("Added root %s.", root.Path())
+		return
+	}
+
+	index := -1
+	if n, err := strconv.Atoi(path); err == nil && n >= 1 && n <= len(current) {
+		index = n - 1
+	} else if root, err := roots.FromPath(path, ""); err == nil {
+		for i, existing := range current {
+			if existing.URI == root.URI {
+				index = i
+			}
+		}
+	}
+	if index < 0 {
+		m.UI.PrintError("%s is not a root", path)
+		return
+	}
+	removed := current[index]
+	roots.Set(append(current[:index:index], current[index+1:]...))
+	m.UI.PrintSystem("Removed root %s.", removed.Path())
+}
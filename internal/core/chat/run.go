@@ -0,0 +1,123 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+// WorkflowRunner runs configured workflows from chat with the chat's server
+// connections
+type WorkflowRunner interface {
+	// WorkflowNames lists the workflows that can be run
+	WorkflowNames() []string
+
+	// RunWorkflow runs a workflow with input, writing step progress to
+	// progress, and returns its final output
+	RunWorkflow(ctx context.Context, name, input string, progress io.Writer) (string, error)
+}
+
+// HandleRunCommand runs a workflow and adds its output to the conversation
+// as an assistant message, so the chat can follow up on it.
+//
+//	/run                  list the workflows
+//	/run NAME [INPUT]     run a workflow, passing INPUT as {{input}}
+func (m *ChatManager) HandleRunCommand(args string) {
+	if m.Workflows == nil {
+		m.UI.PrintSystem("Workflows are not available in this chat.")
+		return
+	}
+
+	name, input, _ := strings.Cut(strings.TrimSpace(args), " ")
+	input = strings.TrimSpace(input)
+	if name == "" {
+		names := m.Workflows.WorkflowNames()
+		if len(names) == 0 {
+			m.UI.PrintSystem("No workflows are configured.")
+			return
+		}
+		m.UI.PrintSystem("Workflows (/run NAME [INPUT]):")
+		for _, n := range names {
+			m.UI.PrintSystem("  %s", n)
+		}
+		return
+	}
+
+	// Ctrl+C stops the workflow, not the chat
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	progress := &progressWriter{ui: m.UI}
+	output, err := m.Workflows.RunWorkflow(ctx, name, input, progress)
+	progress.Flush()
+	if err != nil {
+		if ctx.Err() != nil {
+			m.UI.PrintSystem("Workflow %s canceled.", name)
+			return
+		}
+		m.UI.PrintError("workflow %s failed: %v", name, err)
+		return
+	}
+	if strings.TrimSpace(output) == "" {
+		output = fmt.Sprintf("Workflow %s completed without output.", name)
+	}
+
+	// Record the run as a user turn so roles keep alternating
+	request := "Run workflow " + name
+	if input != "" {
+		request += " with input:\n" + input
+	}
+	for _, message := range []domain.Message{
+		{Role: "user", Content: request},
+		{Role: "assistant", Content: output},
+	} {
+		m.Context.AddMessage(message)
+		if m.session != nil {
+			m.session.AddMessage(convertDomainMessage(message))
+		}
+	}
+
+	m.UI.PrintAssistantResponse(output)
+	m.speakResponse(output)
+	m.logSession()
+}
+
+// progressWriter shows workflow step progress as system lines, leaving out
+// the info-level log lines shown alongside steps
+type progressWriter struct {
+	ui      *UI
+	partial string
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.partial += string(p)
+	for {
+		line, rest, ok := strings.Cut(w.partial, "\n")
+		if !ok {
+			break
+		}
+		w.partial = rest
+		w.printLine(line)
+	}
+	return len(p), nil
+}
+
+// Flush prints any incomplete last line
+func (w *progressWriter) Flush() {
+	if w.partial != "" {
+		w.printLine(w.partial)
+		w.partial = ""
+	}
+}
+
+func (w *progressWriter) printLine(line string) {
+	if strings.HasPrefix(line, "[INFO] ") || strings.TrimSpace(line) == "" {
+		return
+	}
+	w.ui.PrintSystem("%s", line)
+}
@@ -0,0 +1,148 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+// fakeWorkflows runs canned workflows, writing progress lines before
+// returning its output
+type fakeWorkflows struct {
+	names    []string
+	progress string
+	output   string
+	err      error
+	runs     []string // "name|input" for each run
+}
+
+func (f *fakeWorkflows) WorkflowNames() []string { return f.names }
+
+func (f *fakeWorkflows) RunWorkflow(ctx context.Context, name, input string, progress io.Writer) (string, error) {
+	f.runs = append(f.runs, name+"|"+input)
+	io.WriteString(progress, f.progress)
+	return f.output, f.err
+}
+
+func TestHandleRunCommandListing(t *testing.T) {
+	tests := []struct {
+		name      string
+		workflows WorkflowRunner
+		want      []string
+	}{
+		{"no runner", nil, []string{"Workflows are not available in this chat."}},
+		{"no workflows", &fakeWorkflows{}, []string{"No workflows are configured."}},
+		{"workflows", &fakeWorkflows{names: []string{"release", "review"}}, []string{"Workflows (/run NAME [INPUT]):\n  release\n  review\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager()
+			m.Workflows = tt.workflows
+			out := captureOutput(t, func() { m.HandleRunCommand("  ") })
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleRunCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         string
+		workflows    *fakeWorkflows
+		wantRun      string
+		wantOutput   []string
+		wantMessages []domain.Message
+	}{
+		{
+			name:       "with input",
+			args:       "review  PR 42\nfocus on tests ",
+			workflows:  &fakeWorkflows{progress: "[INFO] Starting\n→ step analyze\n\n✓ step analyze", output: "Looks good."},
+			wantRun:    "review|PR 42\nfocus on tests",
+			wantOutput: []string{"→ step analyze\n✓ step analyze\n", "Looks good."},
+			wantMessages: []domain.Message{
+				{Role: "user", Content: "Run workflow review with input:\nPR 42\nfocus on tests"},
+				{Role: "assistant", Content: "Looks good."},
+			},
+		},
+		{
+			name:       "without input or output",
+			args:       "cleanup",
+			workflows:  &fakeWorkflows{output: " \n"},
+			wantRun:    "cleanup|",
+			wantOutput: []string{"Workflow cleanup completed without output."},
+			wantMessages: []domain.Message{
+				{Role: "user", Content: "Run workflow cleanup"},
+				{Role: "assistant", Content: "Workflow cleanup completed without output."},
+			},
+		},
+		{
+			name:       "failure",
+			args:       "review PR 42",
+			workflows:  &fakeWorkflows{progress: "✗ step analyze\n", err: errors.New("provider unavailable")},
+			wantRun:    "review|PR 42",
+			wantOutput: []string{"✗ step analyze", "workflow review failed: provider unavailable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager()
+			m.Workflows = tt.workflows
+
+			out := captureOutput(t, func() { m.HandleRunCommand(tt.args) })
+			if len(tt.workflows.runs) != 1 || tt.workflows.runs[0] != tt.wantRun {
+				t.Errorf("runs = %q, want %q", tt.workflows.runs, tt.wantRun)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			if strings.Contains(out, "[INFO]") {
+				t.Errorf("output shows info lines:\n%s", out)
+			}
+
+			if len(m.Context.Messages) != len(tt.wantMessages) {
+				t.Fatalf("got %d messages, want %d: %+v", len(m.Context.Messages), len(tt.wantMessages), m.Context.Messages)
+			}
+			for i, want := range tt.wantMessages {
+				if got := m.Context.Messages[i]; got.Role != want.Role || got.Content != want.Content {
+					t.Errorf("message %d = %s %q, want %s %q", i, got.Role, got.Content, want.Role, want.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestProgressWriter(t *testing.T) {
+	m := newTestManager()
+	w := &progressWriter{ui: m.UI}
+
+	var afterWrites string
+	out := captureOutput(t, func() {
+		for _, chunk := range []string{"→ step ", "one\n[INFO] Calling", " provider\n  \n✓ step", " one"} {
+			fmt.Fprint(w, chunk)
+		}
+		afterWrites = w.partial
+		w.Flush()
+	})
+
+	if afterWrites != "✓ step one" {
+		t.Errorf("buffered %q before Flush, want the incomplete last line", afterWrites)
+	}
+	if want := "→ step one\n✓ step one\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+	if w.partial != "" {
+		t.Errorf("partial = %q after Flush", w.partial)
+	}
+}
@@ -8,8 +8,12 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/elicitation"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/console"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/highlight"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/chzyer/readline"
@@ -31,6 +35,11 @@ type UI struct {
 	// Glamour renderer for markdown
 	glamourRenderer *glamour.TermRenderer
 	noColor         bool
+	renderMarkdown  bool // Toggled with /render
+
+	// Progress spinner for LLM and tool calls
+	spinner *console.Spinner
+	quiet   bool
 
 	// Stream state tracking
 	streamStarted bool
@@ -42,12 +51,16 @@ type UI struct {
 
 	// Multiline input buffer
 	multilineBuffer strings.Builder
+
+	// Prompts for server requests for input (see Elicit)
+	elicitor   *elicitation.ConsoleHandler
+	elicitOnce sync.Once
 }
 
 // NewUI creates a new UI manager
 func NewUI() *UI {
-	// Check if colors are disabled
-	noColor := os.Getenv("NO_COLOR") != ""
+	// Check if colors are disabled (color.NoColor is also set when stdout is not a terminal)
+	noColor := os.Getenv("NO_COLOR") != "" || color.NoColor
 
 	// Initialize glamour renderer
 	var renderer *glamour.TermRenderer
@@ -97,6 +110,7 @@ func NewUI() *UI {
 		errorColor:      color.New(color.FgRed, color.Bold),
 		glamourRenderer: renderer,
 		noColor:         noColor,
+		renderMarkdown:  renderer != nil,
 		streamStarted:   false,
 		streamEmpty:     true,
 		contentBuffer:   "",
@@ -213,23 +227,43 @@ func (u *UI) readBasicInput() (string, error) {
 // PrintAssistantResponse prints the assistant's response with markdown rendering
 func (u *UI) PrintAssistantResponse(response string) {
 	u.assistantColor.Println("\nAssistant:")
+	fmt.Print(u.formatMarkdown(response))
+	fmt.Println()
+}
 
-	// Render with Glamour if available
-	if u.glamourRenderer != nil && !u.noColor {
-		rendered, err := u.glamourRenderer.Render(response)
-		if err != nil {
-			// Fallback to plain text on error
-			logging.Warn("Failed to render markdown: %v", err)
-			fmt.Println(response)
-		} else {
-			fmt.Print(rendered)
-		}
-	} else {
-		// Plain text output
-		fmt.Println(response)
+// formatMarkdown renders markdown for the terminal, falling back to the raw
+// text when rendering is disabled or fails
+func (u *UI) formatMarkdown(text string) string {
+	plain := text
+	if !strings.HasSuffix(plain, "\n") {
+		plain += "\n"
 	}
 
-	fmt.Println()
+	if !u.renderMarkdown || u.glamourRenderer == nil || u.noColor {
+		return plain
+	}
+
+	rendered, err := u.glamourRenderer.Render(text)
+	if err != nil {
+		logging.Warn("Failed to render markdown: %v", err)
+		return plain
+	}
+	return rendered
+}
+
+// SetMarkdownRendering enables or disables markdown rendering of responses.
+// Returns false if rendering was requested but is not available.
+func (u *UI) SetMarkdownRendering(enabled bool) bool {
+	if enabled && (u.glamourRenderer == nil || u.noColor) {
+		return false
+	}
+	u.renderMarkdown = enabled
+	return true
+}
+
+// MarkdownRendering reports whether responses are rendered as markdown
+func (u *UI) MarkdownRendering() bool {
+	return u.renderMarkdown
 }
 
 // StreamAssistantResponse prints the assistant's response in a streaming fashion
@@ -262,15 +296,6 @@ func (u *UI) StartStreamingResponse() {
 	u.streamStarted = true
 	u.streamEmpty = true
 	u.contentBuffer = ""
-	u.assistantColor.Println("\nAssistant:")
-
-	// Show a subtle indicator that we're collecting the response
-	if !u.noColor {
-		fmt.Print(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("243")).
-			Italic(true).
-			Render("Generating response..."))
-	}
 }
 
 // EndStreamingResponse finalizes the streaming response UI
@@ -278,31 +303,17 @@ func (u *UI) EndStreamingResponse() {
 	u.streamMutex.Lock()
 	defer u.streamMutex.Unlock()
 
+	// The progress spinner runs until the complete response has arrived
+	u.StopProgress()
+	u.assistantColor.Println("\nAssistant:")
+
 	// If we didn't actually receive any content but did get tool calls,
 	// print a message to explain what's happening
 	if u.streamEmpty {
 		u.systemColor.Println("[Using tools to process your request...]")
 	} else {
-		// Clear the "Generating response..." message
-		if !u.noColor {
-			fmt.Print("\r\033[K") // Clear the current line
-		}
-
-		// Render the complete content with Glamour
-		if u.glamourRenderer != nil && !u.noColor && len(u.contentBuffer) > 0 {
-			// Render the complete markdown content
-			rendered, err := u.glamourRenderer.Render(u.contentBuffer)
-			if err != nil {
-				// Fallback to plain text on error
-				logging.Warn("Failed to render markdown: %v", err)
-				fmt.Print(u.contentBuffer)
-			} else {
-				fmt.Print(rendered)
-			}
-		} else {
-			// No glamour renderer or no color - print plain text
-			fmt.Print(u.contentBuffer)
-		}
+		// Render the complete content (plain text when rendering is off)
+		fmt.Print(u.formatMarkdown(u.contentBuffer))
 	}
 
 	// Add a newline at the end
@@ -317,6 +328,39 @@ func (u *UI) EndStreamingResponse() {
 	}
 }
 
+// SetQuiet suppresses progress spinners and status lines (--quiet)
+func (u *UI) SetQuiet(quiet bool) {
+	u.quiet = quiet
+}
+
+// StartProgress shows an animated spinner with elapsed time until StopProgress
+// is called. Without a color terminal the message is printed once instead.
+func (u *UI) StartProgress(message string) {
+	u.StopProgress()
+
+	if u.quiet {
+		return
+	}
+	if u.noColor {
+		u.PrintSystem("%s", message)
+		return
+	}
+
+	u.spinner = console.NewSpinner(message)
+	u.spinner.Start()
+}
+
+// StopProgress stops the spinner, if any, and returns how long it ran
+func (u *UI) StopProgress() time.Duration {
+	if u.spinner == nil {
+		return 0
+	}
+	elapsed := u.spinner.Elapsed()
+	u.spinner.Stop()
+	u.spinner = nil
+	return elapsed
+}
+
 // PrintToolExecution prints information about a tool being executed
 func (u *UI) PrintToolExecution(toolName, serverName string) {
 	if u.noColor {
@@ -391,6 +435,11 @@ func (u *UI) printPlainToolResult(formattedResult string) {
 		return
 	}
 
+	// Color unified diffs from code-edit tools
+	if highlight.IsUnifiedDiff(displayResult) {
+		displayResult = highlight.Diff(displayResult)
+	}
+
 	// Use lipgloss box for non-markdown results
 	resultStyle := lipgloss.NewStyle().
 		PaddingLeft(2).
@@ -474,6 +523,17 @@ func (u *UI) PrintHelp() {
 	fmt.Println("  /system      - Set a custom system prompt")
 	fmt.Println("  /tools       - List available tools")
 	fmt.Println("  /history     - Show conversation history")
+	fmt.Println("  /copy        - Copy last response to clipboard (/copy code [N] for a code block)")
+	fmt.Println("  /paste [msg] - Send clipboard contents, optionally after an instruction")
+	fmt.Println("  /render      - Toggle markdown rendering of responses (/render on|off)")
+	fmt.Println("  /roots       - List project roots shared with servers (/roots add|remove PATH)")
+	fmt.Println("  /sources     - Show what auto-RAG retrieved for the last message (/sources N for one in full)")
+	fmt.Println("  /run         - Run a workflow and add its output to the chat (/run NAME [INPUT])")
+	fmt.Println("  /upload      - Copy a file into the skills sandbox workspace (/upload PATH; no PATH lists uploads)")
+	fmt.Println("  /provider    - List providers or switch (/provider NAME [MODEL]), keeping history")
+	fmt.Println("  /model       - List the provider's models or switch (/model NAME)")
+	fmt.Println("  /export-workflow - Save the conversation as a draft workflow (/export-workflow [NAME])")
+	fmt.Println("  /export-context  - Save the conversation as a context bundle for query or workflows (/export-context [FILE])")
 	fmt.Println()
 	u.systemColor.Println("Input tips:")
 	fmt.Println("  ↑/↓          - Navigate command history")
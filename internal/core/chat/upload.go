@@ -0,0 +1,81 @@
+package chat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+// FileUploader puts host files into the skills sandbox workspace. The
+// skills service implements it.
+type FileUploader interface {
+	UploadFile(path string) (*skills.UploadedFile, error)
+	Uploads() []skills.UploadedFile
+}
+
+// HandleUploadCommand copies a file into the sandbox workspace, where skill
+// code finds it under /workspace/ for the rest of the session. The model is
+// told about it with the next message.
+//
+//	/upload          list the uploaded files
+//	/upload PATH     upload a file
+func (m *ChatManager) HandleUploadCommand(arg string) {
+	uploader := m.Uploader
+	if uploader == nil {
+		m.UI.PrintError("Uploads need skills; start chat with skills enabled.")
+		return
+	}
+
+	path := strings.Trim(strings.TrimSpace(arg), `"'`)
+	if path == "" {
+		uploads := uploader.Uploads()
+		if len(uploads) == 0 {
+			m.UI.PrintSystem("No files uploaded. Usage: /upload PATH")
+			return
+		}
+		m.UI.PrintSystem("Uploaded files:")
+		for _, file := range uploads {
+			m.UI.PrintSystem("  %s (%s, from %s)", file.ContainerPath, formatBytes(file.Size), file.HostPath)
+		}
+		return
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+
+	file, err := uploader.UploadFile(path)
+	if err != nil {
+		m.UI.PrintError("Upload failed: %v", err)
+		return
+	}
+	m.UI.PrintSystem("Uploaded %s to %s (%s).", file.HostPath, file.ContainerPath, formatBytes(file.Size))
+	m.uploadNotes = append(m.uploadNotes, fmt.Sprintf("[The user uploaded %s to the sandbox workspace at %s (%s). Skill code run with execute_skill_code can read it there.]",
+		file.Name, file.ContainerPath, formatBytes(file.Size)))
+}
+
+// withUploadNotes prefixes a message with the uploads made since the last
+// one, so the model knows where to find them
+func (m *ChatManager) withUploadNotes(message string) string {
+	if len(m.uploadNotes) == 0 {
+		return message
+	}
+	notes := strings.Join(m.uploadNotes, "\n")
+	m.uploadNotes = nil
+	return notes + "\n\n" + message
+}
+
+// formatBytes formats a size for display
+func formatBytes(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d bytes", size)
+}
@@ -0,0 +1,325 @@
+package chunking
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/tokens"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Supported source languages for code chunking
+const (
+	LanguageGo         = "go"
+	LanguagePython     = "python"
+	LanguageJavaScript = "javascript"
+	LanguageTypeScript = "typescript"
+	LanguageUnknown    = "unknown"
+)
+
+// codeBoundary describes a top-level declaration pattern for a language
+type codeBoundary struct {
+	pattern    *regexp.Regexp
+	symbolType string
+}
+
+// codeBoundaries holds the language-specific heuristics used to find
+// function/class boundaries. Each pattern captures the symbol name in group 1.
+var codeBoundaries = map[string][]codeBoundary{
+	LanguageGo: {
+		{regexp.MustCompile(`^func\s+\([^)]*\)\s*([A-Za-z_][A-Za-z0-9_]*)`), "method"},
+		{regexp.MustCompile(`^func\s+([A-Za-z_][A-Za-z0-9_]*)`), "function"},
+		{regexp.MustCompile(`^type\s+([A-Za-z_][A-Za-z0-9_]*)\s+(?:struct|interface)`), "type"},
+		{regexp.MustCompile(`^type\s+([A-Za-z_][A-Za-z0-9_]*)`), "type"},
+	},
+	LanguagePython: {
+		{regexp.MustCompile(`^(?:async\s+)?def\s+([A-Za-z_][A-Za-z0-9_]*)`), "function"},
+		{regexp.MustCompile(`^class\s+([A-Za-z_][A-Za-z0-9_]*)`), "class"},
+	},
+	LanguageJavaScript: {
+		{regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][A-Za-z0-9_$]*)`), "function"},
+		{regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][A-Za-z0-9_$]*)`), "class"},
+		{regexp.MustCompile(`^(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*=\s*(?:async\s+)?(?:function|\([^)]*\)\s*=>|[A-Za-z_$][A-Za-z0-9_$]*\s*=>)`), "function"},
+	},
+}
+
+// TypeScript shares the JavaScript boundaries plus interfaces and type aliases
+func init() {
+	ts := append([]codeBoundary{}, codeBoundaries[LanguageJavaScript]...)
+	ts = append(ts,
+		codeBoundary{regexp.MustCompile(`^(?:export\s+)?interface\s+([A-Za-z_$][A-Za-z0-9_$]*)`), "interface"},
+		codeBoundary{regexp.MustCompile(`^(?:export\s+)?type\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*=`), "type"},
+		codeBoundary{regexp.MustCompile(`^(?:export\s+)?(?:const\s+)?enum\s+([A-Za-z_$][A-Za-z0-9_$]*)`), "enum"},
+	)
+	codeBoundaries[LanguageTypeScript] = ts
+}
+
+// codeBlock is a contiguous range of source lines belonging to one symbol
+type codeBlock struct {
+	startLine  int // 0-based, inclusive
+	endLine    int // 0-based, exclusive
+	symbol     string
+	symbolType string
+}
+
+// CodeChunker implements code-aware chunking that splits source files on
+// function and class boundaries using language-specific heuristics
+type CodeChunker struct {
+	tokenManager *tokens.TokenManager
+	overlap      int
+	filePath     string
+	language     string
+}
+
+// NewCodeChunker creates a new code-aware chunker
+func NewCodeChunker(tokenManager *tokens.TokenManager, overlap int) *CodeChunker {
+	return &CodeChunker{
+		tokenManager: tokenManager,
+		overlap:      overlap,
+	}
+}
+
+// SetFilePath sets the source file path used for language detection and chunk metadata
+func (cc *CodeChunker) SetFilePath(filePath string) {
+	cc.filePath = filePath
+}
+
+// SetLanguage forces the source language instead of detecting it
+func (cc *CodeChunker) SetLanguage(language string) {
+	cc.language = language
+}
+
+// ChunkText splits source code into chunks aligned with top-level declarations
+func (cc *CodeChunker) ChunkText(text string, maxTokens int) ([]domain.TextChunk, error) {
+	if strings.TrimSpace(text) == "" {
+		return []domain.TextChunk{}, nil
+	}
+
+	language := cc.language
+	if language == "" {
+		language = DetectLanguage(cc.filePath, text)
+	}
+
+	lines := strings.Split(text, "\n")
+
+	// Byte offset of the start of each line, for StartPos/EndPos
+	lineOffsets := make([]int, len(lines)+1)
+	for i, line := range lines {
+		lineOffsets[i+1] = lineOffsets[i] + len(line) + 1
+	}
+
+	blocks := cc.findBlocks(lines, language)
+
+	var chunks []domain.TextChunk
+	for _, block := range blocks {
+		for _, part := range cc.splitBlock(lines, block, maxTokens) {
+			chunkText := strings.Join(lines[part.startLine:part.endLine], "\n")
+			if strings.TrimSpace(chunkText) == "" {
+				continue
+			}
+
+			endPos := lineOffsets[part.endLine] - 1
+			if endPos > len(text) {
+				endPos = len(text)
+			}
+
+			metadata := map[string]interface{}{
+				"language":   language,
+				"start_line": part.startLine + 1,
+				"end_line":   part.endLine,
+			}
+			if cc.filePath != "" {
+				metadata["file_path"] = cc.filePath
+			}
+			if part.symbol != "" {
+				metadata["symbol"] = part.symbol
+				metadata["symbol_type"] = part.symbolType
+			}
+
+			chunks = append(chunks, domain.TextChunk{
+				Text:       chunkText,
+				Index:      len(chunks),
+				StartPos:   lineOffsets[part.startLine],
+				EndPos:     endPos,
+				TokenCount: cc.tokenManager.CountTokensInString(chunkText),
+				Metadata:   metadata,
+			})
+		}
+	}
+
+	logging.Debug("Code chunking complete: %d chunks created from %d lines (%s)", len(chunks), len(lines), language)
+	return chunks, nil
+}
+
+// findBlocks partitions the source into blocks starting at each top-level declaration.
+// Leading comments and decorators are attached to the declaration that follows them.
+func (cc *CodeChunker) findBlocks(lines []string, language string) []codeBlock {
+	boundaries := codeBoundaries[language]
+
+	var blocks []codeBlock
+	current := codeBlock{startLine: 0, symbolType: "preamble"}
+
+	for i, line := range lines {
+		symbol, symbolType, ok := matchBoundary(line, boundaries)
+		if !ok {
+			continue
+		}
+
+		// Pull preceding comments/decorators into the new block
+		start := i
+		for start > current.startLine && isAttachedPrefix(lines[start-1], language) {
+			start--
+		}
+
+		if start > current.startLine {
+			current.endLine = start
+			blocks = append(blocks, current)
+		}
+
+		current = codeBlock{startLine: start, symbol: symbol, symbolType: symbolType}
+	}
+
+	current.endLine = len(lines)
+	blocks = append(blocks, current)
+
+	return cc.mergeSmallBlocks(lines, blocks)
+}
+
+// mergeSmallBlocks folds blank-only blocks into their neighbours
+func (cc *CodeChunker) mergeSmallBlocks(lines []string, blocks []codeBlock) []codeBlock {
+	var merged []codeBlock
+	for _, block := range blocks {
+		if strings.TrimSpace(strings.Join(lines[block.startLine:block.endLine], "\n")) == "" && len(merged) > 0 {
+			merged[len(merged)-1].endLine = block.endLine
+			continue
+		}
+		merged = append(merged, block)
+	}
+	return merged
+}
+
+// splitBlock breaks a block that exceeds maxTokens into line-aligned parts.
+// Each part keeps the symbol of the block it came from.
+func (cc *CodeChunker) splitBlock(lines []string, block codeBlock, maxTokens int) []codeBlock {
+	blockText := strings.Join(lines[block.startLine:block.endLine], "\n")
+	if cc.tokenManager.CountTokensInString(blockText) <= maxTokens {
+		return []codeBlock{block}
+	}
+
+	var parts []codeBlock
+	start := block.startLine
+	tokensSoFar := 0
+
+	for i := block.startLine; i < block.endLine; i++ {
+		lineTokens := cc.tokenManager.CountTokensInString(lines[i]) + 1
+		if tokensSoFar+lineTokens > maxTokens && i > start {
+			parts = append(parts, codeBlock{startLine: start, endLine: i, symbol: block.symbol, symbolType: block.symbolType})
+
+			// Carry overlap lines from the end of the previous part
+			next := i
+			if cc.overlap > 0 {
+				overlapTokens := 0
+				for next > start+1 {
+					t := cc.tokenManager.CountTokensInString(lines[next-1]) + 1
+					if overlapTokens+t > cc.overlap {
+						break
+					}
+					overlapTokens += t
+					next--
+				}
+			}
+			start = next
+			tokensSoFar = 0
+			for j := start; j < i; j++ {
+				tokensSoFar += cc.tokenManager.CountTokensInString(lines[j]) + 1
+			}
+		}
+		tokensSoFar += lineTokens
+	}
+
+	if start < block.endLine {
+		parts = append(parts, codeBlock{startLine: start, endLine: block.endLine, symbol: block.symbol, symbolType: block.symbolType})
+	}
+
+	return parts
+}
+
+// matchBoundary checks whether a line starts a top-level declaration
+func matchBoundary(line string, boundaries []codeBoundary) (string, string, bool) {
+	// Only unindented lines are treated as top-level declarations
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return "", "", false
+	}
+
+	for _, b := range boundaries {
+		if m := b.pattern.FindStringSubmatch(line); m != nil {
+			return m[1], b.symbolType, true
+		}
+	}
+	return "", "", false
+}
+
+// isAttachedPrefix reports whether a line belongs to the declaration below it
+func isAttachedPrefix(line string, language string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return false
+	}
+
+	switch language {
+	case LanguagePython:
+		return strings.HasPrefix(trimmed, "#") || strings.HasPrefix(line, "@")
+	case LanguageGo:
+		return strings.HasPrefix(trimmed, "//")
+	default:
+		return strings.HasPrefix(trimmed, "//") ||
+			strings.HasPrefix(trimmed, "/*") ||
+			strings.HasPrefix(trimmed, "*") ||
+			strings.HasPrefix(line, "@")
+	}
+}
+
+// DetectLanguage determines the source language from the file extension,
+// falling back to simple content sniffing when no path is available
+func DetectLanguage(filePath string, text string) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".go":
+		return LanguageGo
+	case ".py", ".pyw":
+		return LanguagePython
+	case ".js", ".jsx", ".mjs", ".cjs":
+		return LanguageJavaScript
+	case ".ts", ".tsx", ".mts", ".cts":
+		return LanguageTypeScript
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "package ") && !strings.Contains(trimmed, ";"):
+			return LanguageGo
+		case strings.HasPrefix(trimmed, "import ("):
+			return LanguageGo
+		case strings.HasPrefix(trimmed, "def ") || strings.HasPrefix(trimmed, "from ") && strings.Contains(trimmed, " import "):
+			return LanguagePython
+		case strings.HasPrefix(trimmed, "interface ") || strings.HasPrefix(trimmed, "export interface "):
+			return LanguageTypeScript
+		case strings.HasPrefix(trimmed, "function ") || strings.HasPrefix(trimmed, "const ") || strings.HasPrefix(trimmed, "export "):
+			return LanguageJavaScript
+		}
+	}
+
+	return LanguageUnknown
+}
+
+// GetName returns the name of this chunking strategy
+func (cc *CodeChunker) GetName() string {
+	return "code"
+}
+
+// GetDescription returns a description of this chunking strategy
+func (cc *CodeChunker) GetDescription() string {
+	return "Splits source code on function/class boundaries (Go, Python, JavaScript, TypeScript) with file and symbol metadata"
+}
@@ -0,0 +1,302 @@
+package chunking
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/tokens"
+)
+
+func newTestCodeChunker(t *testing.T, overlap int) *CodeChunker {
+	t.Helper()
+	tm, err := tokens.NewTokenManagerFallback("gpt-4o")
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+	return NewCodeChunker(tm, overlap)
+}
+
+const goSource = `package shapes
+
+import "math"
+
+// Shape has an area
+type Shape interface {
+	Area() float64
+}
+
+// Circle is a round shape
+// with a radius
+type Circle struct {
+	R float64
+}
+
+// Area returns the circle's area
+func (c Circle) Area() float64 {
+	return math.Pi * c.R * c.R
+}
+
+func helper() {}
+`
+
+const pythonSource = `import os
+
+# Reads settings from disk
+def load(path):
+    return open(path).read()
+
+@dataclass
+class Config:
+    name: str
+
+    def describe(self):
+        return self.name
+
+async def fetch(url):
+    pass
+`
+
+const jsSource = `import fs from "fs";
+
+/**
+ * Reads a file
+ */
+export async function read(path) {
+  return fs.promises.readFile(path);
+}
+
+export default class Store {
+  get(key) {}
+}
+
+const add = (a, b) => a + b;
+`
+
+const tsSource = `// A user record
+export interface User {
+  id: number;
+}
+
+export type ID = string | number;
+
+export const enum Role {
+  Admin,
+}
+
+function greet(user: User): string {
+  return "hi " + user.id;
+}
+`
+
+func TestCodeChunkerBoundaries(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		source   string
+		language string
+		symbols  []string // symbol_type:symbol for each chunk; "preamble" for the file header
+		firsts   []string // first line of each chunk
+	}{
+		{
+			name:     "go",
+			path:     "shapes.go",
+			source:   goSource,
+			language: LanguageGo,
+			symbols:  []string{"preamble", "type:Shape", "type:Circle", "method:Area", "function:helper"},
+			firsts:   []string{"package shapes", "// Shape has an area", "// Circle is a round shape", "// Area returns the circle's area", "func helper() {}"},
+		},
+		{
+			name:     "python",
+			path:     "config.py",
+			source:   pythonSource,
+			language: LanguagePython,
+			symbols:  []string{"preamble", "function:load", "class:Config", "function:fetch"},
+			firsts:   []string{"import os", "# Reads settings from disk", "@dataclass", "async def fetch(url):"},
+		},
+		{
+			name:     "javascript",
+			path:     "store.js",
+			source:   jsSource,
+			language: LanguageJavaScript,
+			symbols:  []string{"preamble", "function:read", "class:Store", "function:add"},
+			firsts:   []string{`import fs from "fs";`, "/**", "export default class Store {", "const add = (a, b) => a + b;"},
+		},
+		{
+			name:     "typescript",
+			path:     "user.ts",
+			source:   tsSource,
+			language: LanguageTypeScript,
+			symbols:  []string{"interface:User", "type:ID", "enum:Role", "function:greet"},
+			firsts:   []string{"// A user record", "export type ID = string | number;", "export const enum Role {", "function greet(user: User): string {"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := newTestCodeChunker(t, 0)
+			cc.SetFilePath(tt.path)
+			chunks, err := cc.ChunkText(tt.source, 1000)
+			if err != nil {
+				t.Fatalf("ChunkText: %v", err)
+			}
+			if len(chunks) != len(tt.symbols) {
+				for _, c := range chunks {
+					t.Logf("chunk %d: %v\n%s", c.Index, c.Metadata["symbol"], c.Text)
+				}
+				t.Fatalf("got %d chunks, want %d", len(chunks), len(tt.symbols))
+			}
+
+			for i, chunk := range chunks {
+				got := "preamble"
+				if symbol, ok := chunk.Metadata["symbol"]; ok {
+					got = fmt.Sprintf("%v:%v", chunk.Metadata["symbol_type"], symbol)
+				}
+				if got != tt.symbols[i] {
+					t.Errorf("chunk %d symbol = %s, want %s", i, got, tt.symbols[i])
+				}
+				if first := strings.SplitN(chunk.Text, "\n", 2)[0]; first != tt.firsts[i] {
+					t.Errorf("chunk %d starts with %q, want %q", i, first, tt.firsts[i])
+				}
+				if chunk.Metadata["language"] != tt.language || chunk.Metadata["file_path"] != tt.path {
+					t.Errorf("chunk %d metadata = %v", i, chunk.Metadata)
+				}
+				if tt.source[chunk.StartPos:chunk.EndPos] != chunk.Text {
+					t.Errorf("chunk %d positions [%d:%d] don't match its text", i, chunk.StartPos, chunk.EndPos)
+				}
+			}
+		})
+	}
+}
+
+func TestCodeChunkerKeepsDocComments(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		source string
+		symbol string
+		want   string // text the symbol's chunk must contain, from the comment to the declaration
+	}{
+		{
+			name:   "go multi-line comment",
+			path:   "a.go",
+			source: goSource,
+			symbol: "Circle",
+			want:   "// Circle is a round shape\n// with a radius\ntype Circle struct {",
+		},
+		{
+			name:   "python comment",
+			path:   "a.py",
+			source: pythonSource,
+			symbol: "load",
+			want:   "# Reads settings from disk\ndef load(path):",
+		},
+		{
+			name:   "python decorator",
+			path:   "a.py",
+			source: pythonSource,
+			symbol: "Config",
+			want:   "@dataclass\nclass Config:",
+		},
+		{
+			name:   "js block comment",
+			path:   "a.js",
+			source: jsSource,
+			symbol: "read",
+			want:   "/**\n * Reads a file\n */\nexport async function read(path) {",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := newTestCodeChunker(t, 0)
+			cc.SetFilePath(tt.path)
+			chunks, err := cc.ChunkText(tt.source, 1000)
+			if err != nil {
+				t.Fatalf("ChunkText: %v", err)
+			}
+
+			for _, chunk := range chunks {
+				if chunk.Metadata["symbol"] == tt.symbol {
+					if !strings.HasPrefix(chunk.Text, tt.want) {
+						t.Errorf("%s chunk starts:\n%s\nwant prefix:\n%s", tt.symbol, chunk.Text, tt.want)
+					}
+					return
+				}
+				if strings.Contains(chunk.Text, strings.SplitN(tt.want, "\n", 2)[0]) {
+					t.Errorf("comment for %s ended up in chunk %v", tt.symbol, chunk.Metadata["symbol"])
+				}
+			}
+			t.Errorf("no chunk for %s", tt.symbol)
+		})
+	}
+}
+
+func TestCodeChunkerSplitsLargeBlocks(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("func big() {\n")
+	for i := 0; i < 60; i++ {
+		fmt.Fprintf(&sb, "\tstep%02d := compute(%d)\n", i, i)
+	}
+	sb.WriteString("}\n")
+	source := sb.String()
+
+	tests := []struct {
+		name    string
+		overlap int
+	}{
+		{"no overlap", 0},
+		{"overlap", 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := newTestCodeChunker(t, tt.overlap)
+			cc.SetLanguage(LanguageGo)
+			chunks, err := cc.ChunkText(source, 100)
+			if err != nil {
+				t.Fatalf("ChunkText: %v", err)
+			}
+			if len(chunks) < 3 {
+				t.Fatalf("got %d chunks, want the block split into several", len(chunks))
+			}
+
+			covered := map[string]bool{}
+			for i, chunk := range chunks {
+				if chunk.TokenCount > 100 {
+					t.Errorf("chunk %d has %d tokens, over the limit", i, chunk.TokenCount)
+				}
+				if chunk.Metadata["symbol"] != "big" {
+					t.Errorf("chunk %d symbol = %v, want big", i, chunk.Metadata["symbol"])
+				}
+				for _, line := range strings.Split(chunk.Text, "\n") {
+					covered[line] = true
+				}
+				if i == 0 {
+					continue
+				}
+
+				prev := chunks[i-1]
+				prevEnd := prev.Metadata["end_line"].(int)
+				start := chunk.Metadata["start_line"].(int)
+				if tt.overlap == 0 && start != prevEnd+1 {
+					t.Errorf("chunk %d starts at line %d, want %d", i, start, prevEnd+1)
+				}
+				if tt.overlap > 0 {
+					if start > prevEnd {
+						t.Errorf("chunk %d starts at line %d, want overlap with chunk ending at %d", i, start, prevEnd)
+					}
+					prevLines := strings.Split(prev.Text, "\n")
+					if first := strings.SplitN(chunk.Text, "\n", 2)[0]; !strings.Contains(prev.Text, first) || first == prevLines[0] {
+						t.Errorf("chunk %d should start with lines from the end of chunk %d", i, i-1)
+					}
+				}
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(source, "\n"), "\n") {
+				if !covered[line] {
+					t.Errorf("line %q missing from every chunk", line)
+				}
+			}
+		})
+	}
+}
@@ -443,6 +443,9 @@ func NewChunkingManager() *ChunkingManager {
 			domain.ChunkingFixed: func(tm *tokens.TokenManager, overlap int) domain.ChunkingStrategy {
 				return NewFixedChunker(tm, overlap)
 			},
+			domain.ChunkingCode: func(tm *tokens.TokenManager, overlap int) domain.ChunkingStrategy {
+				return NewCodeChunker(tm, overlap)
+			},
 		},
 	}
 }
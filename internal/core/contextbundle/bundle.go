@@ -0,0 +1,201 @@
+// Package contextbundle reads and writes context bundles: a chat
+// conversation or a workflow run's results, saved so a later query or
+// workflow can pick up where it left off.
+package contextbundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+// Version is raised when a field is renamed, removed or changes meaning
+const Version = 1
+
+// Bundle kinds
+const (
+	KindChat     = "chat"
+	KindWorkflow = "workflow"
+)
+
+// Bundle is a portable snapshot of a chat or workflow run's context
+type Bundle struct {
+	Version      int       `json:"version"`
+	Kind         string    `json:"kind"`
+	Source       string    `json:"source,omitempty"` // Workflow name for workflow bundles
+	Created      time.Time `json:"created"`
+	Provider     string    `json:"provider,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	SystemPrompt string    `json:"system_prompt,omitempty"` // Recorded for reference; not applied on import
+
+	// Chat bundles: the user messages and the assistant's answers
+	Messages []Message `json:"messages,omitempty"`
+
+	// Workflow bundles: the run's input, params and step outputs in order
+	Input  string            `json:"input,omitempty"`
+	Params map[string]string `json:"params,omitempty"`
+	Steps  []Step            `json:"steps,omitempty"`
+}
+
+// Message is one chat message
+type Message struct {
+	Role    string `json:"role"` // user or assistant
+	Content string `json:"content"`
+}
+
+// Step is one workflow step's output
+type Step struct {
+	Name   string `json:"name"`
+	Output string `json:"output"`
+}
+
+// NewChat bundles a chat history, keeping user messages and the
+// assistant's text answers. Tool calls and results are left out.
+func NewChat(messages []domain.Message, systemPrompt, provider, model string) *Bundle {
+	b := &Bundle{
+		Version:      Version,
+		Kind:         KindChat,
+		Created:      time.Now().UTC(),
+		Provider:     provider,
+		Model:        model,
+		SystemPrompt: systemPrompt,
+	}
+	for _, message := range messages {
+		if (message.Role != "user" && message.Role != "assistant") || strings.TrimSpace(message.Content) == "" {
+			continue
+		}
+		b.Messages = append(b.Messages, Message{Role: message.Role, Content: message.Content})
+	}
+	return b
+}
+
+// Load reads a bundle file
+func Load(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context bundle: %w", err)
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("context bundle %s is not valid JSON: %w", path, err)
+	}
+	if b.Version < 1 || b.Version > Version {
+		return nil, fmt.Errorf("context bundle %s has version %d; this build reads versions 1 to %d", path, b.Version, Version)
+	}
+	if b.Kind != KindChat && b.Kind != KindWorkflow {
+		return nil, fmt.Errorf("context bundle %s has unknown kind %q", path, b.Kind)
+	}
+	return &b, nil
+}
+
+// Marshal encodes the bundle as indented JSON
+func (b *Bundle) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Save writes the bundle to path, replacing any existing file
+func (b *Bundle) Save(path string) error {
+	data, err := b.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LastResponse returns the last assistant answer or step output
+func (b *Bundle) LastResponse() string {
+	for i := len(b.Messages) - 1; i >= 0; i-- {
+		if b.Messages[i].Role == "assistant" {
+			return b.Messages[i].Content
+		}
+	}
+	if len(b.Steps) > 0 {
+		return b.Steps[len(b.Steps)-1].Output
+	}
+	return ""
+}
+
+// Text renders the bundle for use in a prompt
+func (b *Bundle) Text() string {
+	var sb strings.Builder
+	switch b.Kind {
+	case KindChat:
+		sb.WriteString("Conversation from an earlier chat:\n")
+		for _, message := range b.Messages {
+			role := "User"
+			if message.Role == "assistant" {
+				role = "Assistant"
+			}
+			fmt.Fprintf(&sb, "\n%s: %s\n", role, message.Content)
+		}
+	case KindWorkflow:
+		fmt.Fprintf(&sb, "Results of an earlier run of workflow %s:\n", b.Source)
+		if b.Input != "" {
+			fmt.Fprintf(&sb, "\n## Input\n%s\n", b.Input)
+		}
+		for _, name := range sortedKeys(b.Params) {
+			fmt.Fprintf(&sb, "\n## Param %s\n%s\n", name, b.Params[name])
+		}
+		for _, step := range b.Steps {
+			fmt.Fprintf(&sb, "\n## %s\n%s\n", step.Name, step.Output)
+		}
+	}
+	return sb.String()
+}
+
+// Variables returns the values a workflow can use as {{context.NAME}}:
+// input, params.NAME and each step's output for workflow bundles, and
+// last_response for both kinds
+func (b *Bundle) Variables() map[string]string {
+	vars := map[string]string{"last_response": b.LastResponse()}
+	if b.Kind == KindWorkflow {
+		vars["input"] = b.Input
+		for name, value := range b.Params {
+			vars["params."+name] = value
+		}
+		for _, step := range b.Steps {
+			vars[step.Name] = step.Output
+		}
+	}
+	return vars
+}
+
+// ContextMessages returns the bundle as messages to put before a query's
+// question. A chat is replayed as the conversation it was, ending with the
+// assistant's last answer; a workflow run becomes one context message.
+func (b *Bundle) ContextMessages() []domain.Message {
+	if b.Kind == KindWorkflow {
+		return []domain.Message{{
+			Role:    "user",
+			Content: "Context from an earlier workflow run (use this to help answer my question):\n\n" + b.Text(),
+		}}
+	}
+
+	messages := make([]domain.Message, 0, len(b.Messages))
+	for _, message := range b.Messages {
+		messages = append(messages, domain.Message{Role: message.Role, Content: message.Content})
+	}
+	// A question left unanswered would run into the new one
+	for len(messages) > 0 && messages[len(messages)-1].Role != "assistant" {
+		messages = messages[:len(messages)-1]
+	}
+	return messages
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,92 @@
+package contextbundle
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+func TestChatBundle(t *testing.T) {
+	history := []domain.Message{
+		{Role: "user", Content: "List the open incidents"},
+		{Role: "assistant", Content: " ", ToolCalls: []domain.ToolCall{{ID: "1"}}},
+		{Role: "tool", Content: `{"incidents": 2}`, ToolCallID: "1"},
+		{Role: "assistant", Content: "There are 2 open incidents."},
+		{Role: "user", Content: "Which is older?"},
+	}
+	b := NewChat(history, "Be brief.", "openai", "gpt-4o")
+
+	if len(b.Messages) != 3 {
+		t.Fatalf("kept %d messages, want 3 (tool calls and results left out): %+v", len(b.Messages), b.Messages)
+	}
+	if got := b.LastResponse(); got != "There are 2 open incidents." {
+		t.Errorf("LastResponse() = %q", got)
+	}
+
+	// The unanswered question is dropped so it doesn't run into the new one
+	messages := b.ContextMessages()
+	if len(messages) != 2 || messages[1].Role != "assistant" {
+		t.Errorf("ContextMessages() = %+v, want the answered exchange only", messages)
+	}
+	if !strings.Contains(b.Text(), "Assistant: There are 2 open incidents.") {
+		t.Errorf("Text() = %q", b.Text())
+	}
+}
+
+func TestWorkflowBundle(t *testing.T) {
+	b := &Bundle{
+		Version: Version,
+		Kind:    KindWorkflow,
+		Source:  "triage",
+		Input:   "incident 42",
+		Params:  map[string]string{"severity": "high"},
+		Steps:   []Step{{Name: "collect", Output: "logs"}, {Name: "summarize", Output: "disk full"}},
+	}
+
+	vars := b.Variables()
+	for name, want := range map[string]string{
+		"input":           "incident 42",
+		"params.severity": "high",
+		"collect":         "logs",
+		"last_response":   "disk full",
+	} {
+		if vars[name] != want {
+			t.Errorf("Variables()[%q] = %q, want %q", name, vars[name], want)
+		}
+	}
+
+	messages := b.ContextMessages()
+	if len(messages) != 1 || messages[0].Role != "user" || !strings.Contains(messages[0].Content, "## summarize\ndisk full") {
+		t.Errorf("ContextMessages() = %+v", messages)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bundle.json")
+
+	b := NewChat([]domain.Message{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}, "", "", "")
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Kind != KindChat || len(loaded.Messages) != 2 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+
+	future, _ := json.Marshal(map[string]interface{}{"version": Version + 1, "kind": KindChat})
+	futurePath := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(futurePath, future, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(futurePath); err == nil {
+		t.Error("Load() accepted a bundle from a newer version")
+	}
+}
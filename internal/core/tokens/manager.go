@@ -8,7 +8,6 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/sashabaranov/go-openai"
-	"github.com/tiktoken-go/tokenizer"
 )
 
 // Default fallback limits when provider doesn't specify context_window
@@ -24,7 +23,7 @@ type TokenManager struct {
 	model          string
 	maxTokens      int
 	reserveTokens  int
-	codec          tokenizer.Codec
+	counter        Tokenizer
 	providerConfig *config.ProviderConfig
 }
 
@@ -62,19 +61,15 @@ func NewTokenManagerFromProvider(model string, cfg *config.ProviderConfig) (*Tok
 		logging.Info("Using provider-configured reserve tokens: %d", reserveTokens)
 	}
 
-	// Try to get codec for the model
-	codec, err := getCodecForModel(model)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get token codec for model %s: %w", model, err)
-	}
+	counter := TokenizerForModel(model)
 
-	logging.Info("Created provider-aware token manager for model %s: %d max tokens, %d reserve tokens", model, maxTokens, reserveTokens)
+	logging.Info("Created provider-aware token manager for model %s: %d max tokens, %d reserve tokens, tokenizer %s", model, maxTokens, reserveTokens, counter.Name())
 
 	return &TokenManager{
 		model:          model,
 		maxTokens:      maxTokens,
 		reserveTokens:  reserveTokens,
-		codec:          codec,
+		counter:        counter,
 		providerConfig: cfg,
 	}, nil
 }
@@ -89,56 +84,18 @@ func NewTokenManagerFallback(model string) (*TokenManager, error) {
 		reserveTokens = maxTokens / 4
 	}
 
-	codec, err := getCodecForModel(model)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get token codec for model %s: %w", model, err)
-	}
+	counter := TokenizerForModel(model)
 
-	logging.Warn("Created fallback token manager for model %s (no provider config): %d max tokens, %d reserve tokens", model, maxTokens, reserveTokens)
+	logging.Warn("Created fallback token manager for model %s (no provider config): %d max tokens, %d reserve tokens, tokenizer %s", model, maxTokens, reserveTokens, counter.Name())
 
 	return &TokenManager{
 		model:         model,
 		maxTokens:     maxTokens,
 		reserveTokens: reserveTokens,
-		codec:         codec,
+		counter:       counter,
 	}, nil
 }
 
-// getCodecForModel gets the appropriate tokenizer codec for a model
-func getCodecForModel(model string) (tokenizer.Codec, error) {
-	modelLower := strings.ToLower(model)
-
-	// Try to get model-specific codec first - using encoding fallback since ForModel requires specific model constants
-	// The tiktoken-go/tokenizer library doesn't have a simple string-based ForModel method
-	// So we'll map model names to encodings
-
-	var encoding tokenizer.Encoding
-	switch {
-	case strings.Contains(modelLower, "gpt-4o"):
-		encoding = tokenizer.O200kBase
-	case strings.Contains(modelLower, "gpt-4") || strings.Contains(modelLower, "gpt-3.5"):
-		encoding = tokenizer.Cl100kBase
-	case strings.Contains(modelLower, "claude"):
-		encoding = tokenizer.Cl100kBase // Claude uses similar encoding
-	case strings.Contains(modelLower, "gemini"):
-		encoding = tokenizer.Cl100kBase // Gemini approximation
-	case strings.Contains(modelLower, "text-davinci"):
-		encoding = tokenizer.P50kBase
-	case strings.Contains(modelLower, "codex"):
-		encoding = tokenizer.P50kBase
-	default:
-		encoding = tokenizer.Cl100kBase // Default fallback
-	}
-
-	codec, err := tokenizer.Get(encoding)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get codec for encoding %v: %w", encoding, err)
-	}
-
-	logging.Info("Using encoding %v for model %s", encoding, model)
-	return codec, nil
-}
-
 // CountTokensInMessage counts tokens in a single message
 func (tm *TokenManager) CountTokensInMessage(message domain.Message) int {
 	// Convert domain message to OpenAI format for token counting
@@ -388,7 +345,6 @@ func (tm *TokenManager) getToolPairMessages(message domain.Message, messages []d
 
 // countTokensInOpenAIMessage counts tokens in an OpenAI message format
 func (tm *TokenManager) countTokensInOpenAIMessage(message openai.ChatCompletionMessage) int {
-	// Use the tokenizer codec to count tokens
 	return tm.numTokensFromMessage(message)
 }
 
@@ -458,21 +414,9 @@ func (tm *TokenManager) numTokensFromMessage(message openai.ChatCompletionMessag
 	return tm.NumTokensFromMessages([]openai.ChatCompletionMessage{message})
 }
 
-// numTokensInString counts tokens in a string using the codec
+// numTokensInString counts tokens in a string with the model family's tokenizer
 func (tm *TokenManager) numTokensInString(text string) int {
-	if text == "" {
-		return 0
-	}
-
-	// Use the Encode method and count tokens (tiktoken-go/tokenizer doesn't have Count method)
-	tokens, _, err := tm.codec.Encode(text)
-	if err != nil {
-		// If encoding fails, use a rough approximation (4 chars per token)
-		logging.Debug("Token encoding failed for text length %d, using approximation: %v", len(text), err)
-		return len(text) / 4
-	}
-
-	return len(tokens)
+	return tm.counter.Count(text)
 }
 
 // GetContextUtilization returns the current context utilization as a percentage
@@ -493,6 +437,7 @@ func (tm *TokenManager) GetContextStats(messages []domain.Message) map[string]in
 
 	return map[string]interface{}{
 		"model":               tm.model,
+		"tokenizer":           tm.counter.Name(),
 		"max_tokens":          tm.maxTokens,
 		"reserve_tokens":      tm.reserveTokens,
 		"effective_limit":     tm.GetEffectiveContextLimit(),
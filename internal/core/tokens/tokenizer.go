@@ -0,0 +1,153 @@
+package tokens
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/tiktoken-go/tokenizer"
+)
+
+// Tokenizer counts tokens the way a model family does
+type Tokenizer interface {
+	// Count returns the number of tokens in text
+	Count(text string) int
+
+	// Name identifies the encoding in use, e.g. "o200k_base" or
+	// "claude~cl100k_base*1.15" for approximations
+	Name() string
+}
+
+// Scaling factors for vendors that don't publish their vocabularies. They
+// were measured against provider-reported usage on mixed English prose, code
+// and JSON, and round up so context checks stay on the safe side.
+const (
+	claudeTokenFactor  = 1.15 // Claude 3+ vs cl100k_base
+	geminiTokenFactor  = 1.05 // Gemini vs o200k_base
+	mistralTokenFactor = 1.10 // SentencePiece models (Mistral, Gemma, Llama 2) vs cl100k_base
+)
+
+var (
+	codecCacheMu sync.Mutex
+	codecCache   = map[tokenizer.Encoding]tokenizer.Codec{}
+)
+
+// TokenizerForModel selects a tokenizer by model family. OpenAI models get
+// their exact tiktoken encoding; Claude, Gemini and SentencePiece-based open
+// models get a scaled BPE approximation. Unknown models fall back to
+// cl100k_base, and to a character estimate if no encoding can be loaded.
+func TokenizerForModel(model string) Tokenizer {
+	m := strings.ToLower(model)
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		// Strip router prefixes such as "openai/gpt-4o" or "anthropic/claude-3"
+		m = m[i+1:]
+	}
+
+	switch {
+	case strings.Contains(m, "gpt-4o"), strings.Contains(m, "gpt-4.1"), strings.Contains(m, "gpt-4.5"),
+		strings.Contains(m, "gpt-5"), strings.Contains(m, "gpt-oss"),
+		strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"), strings.HasPrefix(m, "o4"):
+		return bpe(tokenizer.O200kBase)
+	case strings.Contains(m, "gpt-4"), strings.Contains(m, "gpt-3.5"), strings.Contains(m, "gpt-35"),
+		strings.Contains(m, "text-embedding"):
+		return bpe(tokenizer.Cl100kBase)
+	case strings.Contains(m, "davinci"), strings.Contains(m, "codex"):
+		return bpe(tokenizer.P50kBase)
+	case strings.Contains(m, "claude"):
+		return scaled("claude", bpe(tokenizer.Cl100kBase), claudeTokenFactor)
+	case strings.Contains(m, "gemini"):
+		return scaled("gemini", bpe(tokenizer.O200kBase), geminiTokenFactor)
+	case strings.Contains(m, "mistral"), strings.Contains(m, "mixtral"), strings.Contains(m, "gemma"),
+		strings.Contains(m, "llama-2"), strings.Contains(m, "llama2"):
+		return scaled("sentencepiece", bpe(tokenizer.Cl100kBase), mistralTokenFactor)
+	default:
+		// Llama 3, Qwen, DeepSeek and most recent open models use ~100k+ BPE
+		// vocabularies that count within a few percent of cl100k_base
+		return bpe(tokenizer.Cl100kBase)
+	}
+}
+
+// CountTokens counts tokens in text for the given model
+func CountTokens(model, text string) int {
+	return TokenizerForModel(model).Count(text)
+}
+
+// bpe returns an exact tiktoken tokenizer, sharing codecs across callers
+// because building the BPE ranks is expensive
+func bpe(encoding tokenizer.Encoding) Tokenizer {
+	codecCacheMu.Lock()
+	defer codecCacheMu.Unlock()
+
+	codec, ok := codecCache[encoding]
+	if !ok {
+		var err error
+		codec, err = tokenizer.Get(encoding)
+		if err != nil {
+			logging.Warn("Failed to load %s encoding, estimating tokens from length: %v", encoding, err)
+			return charTokenizer{}
+		}
+		codecCache[encoding] = codec
+	}
+	return &bpeTokenizer{encoding: encoding, codec: codec}
+}
+
+func scaled(family string, base Tokenizer, factor float64) Tokenizer {
+	return &scaledTokenizer{family: family, base: base, factor: factor}
+}
+
+// bpeTokenizer counts exactly with a tiktoken BPE encoding
+type bpeTokenizer struct {
+	encoding tokenizer.Encoding
+	codec    tokenizer.Codec
+}
+
+func (t *bpeTokenizer) Count(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	// tiktoken-go/tokenizer doesn't have a Count method
+	ids, _, err := t.codec.Encode(text)
+	if err != nil {
+		logging.Debug("Token encoding failed for text length %d, using approximation: %v", len(text), err)
+		return approximateTokens(text)
+	}
+	return len(ids)
+}
+
+func (t *bpeTokenizer) Name() string {
+	return string(t.encoding)
+}
+
+// scaledTokenizer approximates a proprietary tokenizer by scaling a BPE count
+type scaledTokenizer struct {
+	family string
+	base   Tokenizer
+	factor float64
+}
+
+func (t *scaledTokenizer) Count(text string) int {
+	n := t.base.Count(text)
+	if n == 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(n) * t.factor))
+}
+
+func (t *scaledTokenizer) Name() string {
+	return fmt.Sprintf("%s~%s*%g", t.family, t.base.Name(), t.factor)
+}
+
+// charTokenizer is the last resort when no BPE encoding can be loaded
+type charTokenizer struct{}
+
+func (charTokenizer) Count(text string) int { return approximateTokens(text) }
+
+func (charTokenizer) Name() string { return "chars/4" }
+
+// approximateTokens estimates ~4 characters per token, rounding up
+func approximateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,43 @@
+package tokens
+
+import "testing"
+
+func TestTokenizerForModel(t *testing.T) {
+	tests := []struct {
+		model string
+		name  string
+	}{
+		{"gpt-4o-mini", "o200k_base"},
+		{"openai/gpt-4.1", "o200k_base"},
+		{"o3-mini", "o200k_base"},
+		{"gpt-4-turbo", "cl100k_base"},
+		{"gpt-3.5-turbo", "cl100k_base"},
+		{"text-davinci-003", "p50k_base"},
+		{"claude-sonnet-4-20250514", "claude~cl100k_base*1.15"},
+		{"gemini-2.5-pro", "gemini~o200k_base*1.05"},
+		{"mistral-large-latest", "sentencepiece~cl100k_base*1.1"},
+		{"llama3.1:8b", "cl100k_base"},
+		{"", "cl100k_base"},
+	}
+	for _, tt := range tests {
+		if got := TokenizerForModel(tt.model).Name(); got != tt.name {
+			t.Errorf("TokenizerForModel(%q) = %s, want %s", tt.model, got, tt.name)
+		}
+	}
+}
+
+func TestTokenizerCount(t *testing.T) {
+	text := "The quick brown fox jumps over the lazy dog."
+
+	if n := CountTokens("gpt-4o", text); n != 10 {
+		t.Errorf("o200k_base count = %d, want 10", n)
+	}
+	if n := CountTokens("gpt-4o", ""); n != 0 {
+		t.Errorf("empty text count = %d, want 0", n)
+	}
+
+	base := CountTokens("gpt-4", text)
+	if claude := CountTokens("claude-3-5-haiku", text); claude <= base {
+		t.Errorf("claude approximation %d should exceed cl100k_base count %d", claude, base)
+	}
+}
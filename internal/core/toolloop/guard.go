@@ -0,0 +1,123 @@
+// Package toolloop bounds the tool-calling rounds of a single request,
+// stops models that keep making the same tool call, and checks tool calls
+// against the offered tools before they run.
+package toolloop
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+// Status is how a tool-calling loop ended
+type Status string
+
+const (
+	// Completed means the model stopped calling tools on its own
+	Completed Status = "completed"
+
+	// IterationLimit means the model used up its tool-calling rounds
+	IterationLimit Status = "iteration_limit_reached"
+
+	// RepeatedCall means the model repeated an identical tool call too often
+	RepeatedCall Status = "repeated_tool_call"
+)
+
+// Guard counts the tool-calling rounds of a request and the times each
+// identical call (same name and arguments) was made
+type Guard struct {
+	maxIterations int
+	maxRepeats    int
+
+	iterations int
+	calls      map[string]int
+	status     Status
+	repeated   string // Name of the repeated tool
+}
+
+// NewGuard allows maxIterations rounds of tool calls, each identical call
+// at most maxRepeats times. Zero or less disables a limit.
+func NewGuard(maxIterations, maxRepeats int) *Guard {
+	return &Guard{
+		maxIterations: maxIterations,
+		maxRepeats:    maxRepeats,
+		calls:         make(map[string]int),
+		status:        Completed,
+	}
+}
+
+// Check records a round of tool calls before they run. It returns Completed
+// when they may run, or why the loop must stop instead. Once stopped, every
+// later round is refused for the same reason.
+func (g *Guard) Check(toolCalls []domain.ToolCall) Status {
+	if g.Stopped() {
+		return g.status
+	}
+
+	if g.maxIterations > 0 && g.iterations >= g.maxIterations {
+		g.status = IterationLimit
+		return g.status
+	}
+
+	if g.maxRepeats > 0 {
+		for _, call := range toolCalls {
+			if g.calls[callKey(call)] >= g.maxRepeats {
+				g.status = RepeatedCall
+				g.repeated = call.Function.Name
+				return g.status
+			}
+		}
+	}
+
+	g.iterations++
+	for _, call := range toolCalls {
+		g.calls[callKey(call)]++
+	}
+	return Completed
+}
+
+// Stopped reports whether a limit was reached
+func (g *Guard) Stopped() bool {
+	return g.status != Completed
+}
+
+// Status returns how the loop ended so far
+func (g *Guard) Status() Status {
+	return g.status
+}
+
+// Iterations returns the rounds of tool calls allowed so far
+func (g *Guard) Iterations() int {
+	return g.iterations
+}
+
+// Reason describes why the loop stopped, for the user and the model
+func (g *Guard) Reason() string {
+	switch g.status {
+	case IterationLimit:
+		return fmt.Sprintf("the limit of %d tool-calling rounds was reached", g.maxIterations)
+	case RepeatedCall:
+		return fmt.Sprintf("%s was called again with the same arguments (%d identical calls allowed)", g.repeated, g.maxRepeats)
+	}
+	return ""
+}
+
+// FinalAnswerPrompt asks the model to answer from the results it has
+func (g *Guard) FinalAnswerPrompt() string {
+	return fmt.Sprintf("Stop calling tools: %s. Give your best final answer from the tool results you already have, "+
+		"and say what is missing if they aren't enough.", g.Reason())
+}
+
+// callKey identifies a call by its name and arguments, ignoring argument
+// order and formatting
+func callKey(call domain.ToolCall) string {
+	arguments := string(call.Function.Arguments)
+	var parsed interface{}
+	if err := json.Unmarshal(call.Function.Arguments, &parsed); err == nil {
+		if canonical, err := json.Marshal(parsed); err == nil {
+			arguments = string(canonical)
+		}
+	}
+	return call.Function.Name + "\x00" + arguments
+}
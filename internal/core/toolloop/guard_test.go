@@ -0,0 +1,67 @@
+package toolloop
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+func call(name, arguments string) domain.ToolCall {
+	return domain.ToolCall{
+		Function: domain.Function{Name: name, Arguments: json.RawMessage(arguments)},
+	}
+}
+
+func TestGuardIterationLimit(t *testing.T) {
+	g := NewGuard(2, 0)
+	for i := 0; i < 2; i++ {
+		if status := g.Check([]domain.ToolCall{call("search", fmt.Sprintf(`{"page":%d}`, i+1))}); status != Completed {
+			t.Fatalf("round %d: status = %s, want %s", i+1, status, Completed)
+		}
+	}
+	if status := g.Check([]domain.ToolCall{call("search", `{"page":3}`)}); status != IterationLimit {
+		t.Fatalf("third round: status = %s, want %s", status, IterationLimit)
+	}
+	if !g.Stopped() || g.Iterations() != 2 {
+		t.Errorf("Stopped() = %v, Iterations() = %d; want true, 2", g.Stopped(), g.Iterations())
+	}
+}
+
+func TestGuardRepeatedCall(t *testing.T) {
+	g := NewGuard(0, 2)
+	if g.Check([]domain.ToolCall{call("read", `{"path":"a","limit":10}`)}) != Completed {
+		t.Fatal("first call refused")
+	}
+	// Same arguments in another order and format
+	if g.Check([]domain.ToolCall{call("read", `{ "limit": 10, "path": "a" }`)}) != Completed {
+		t.Fatal("second call refused")
+	}
+	if g.Check([]domain.ToolCall{call("read", `{"path":"b"}`)}) != Completed {
+		t.Fatal("call with other arguments refused")
+	}
+	if status := g.Check([]domain.ToolCall{call("read", `{"limit":10,"path":"a"}`)}); status != RepeatedCall {
+		t.Fatalf("third identical call: status = %s, want %s", status, RepeatedCall)
+	}
+	if g.Reason() == "" {
+		t.Error("Reason() is empty after stopping")
+	}
+
+	// Stopped guards refuse every later round
+	if status := g.Check([]domain.ToolCall{call("write", `{}`)}); status != RepeatedCall {
+		t.Errorf("after stopping: status = %s, want %s", status, RepeatedCall)
+	}
+}
+
+func TestGuardUnlimited(t *testing.T) {
+	g := NewGuard(0, 0)
+	for i := 0; i < 100; i++ {
+		if g.Check([]domain.ToolCall{call("ping", `{}`)}) != Completed {
+			t.Fatalf("round %d refused without limits", i+1)
+		}
+	}
+	if g.Status() != Completed || g.Reason() != "" {
+		t.Errorf("Status() = %s, Reason() = %q; want completed and no reason", g.Status(), g.Reason())
+	}
+}
@@ -0,0 +1,119 @@
+package toolloop
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jsonschema"
+)
+
+// maxArgumentsShown bounds the arguments quoted back to the model
+const maxArgumentsShown = 300
+
+// maxToolNamesShown bounds the tool names suggested for an unknown tool
+const maxToolNamesShown = 30
+
+// InvalidCall is a tool call that can't run and why
+type InvalidCall struct {
+	Call     domain.ToolCall
+	Problems []string
+}
+
+// InvalidCallsError reports tool calls the model still got wrong after
+// being asked to correct them
+type InvalidCallsError struct {
+	Calls []InvalidCall
+}
+
+func (e *InvalidCallsError) Error() string {
+	var parts []string
+	for _, invalid := range e.Calls {
+		parts = append(parts, fmt.Sprintf("%s: %s", invalid.Call.Function.Name, strings.Join(invalid.Problems, "; ")))
+	}
+	return "model made invalid tool calls: " + strings.Join(parts, ", ")
+}
+
+// ValidateCalls checks tool calls against the tools offered with the
+// request: the tool must have been offered, and its arguments must be a
+// JSON object matching the tool's parameter schema. Values a server would
+// convert, such as "5" for a number, are accepted.
+func ValidateCalls(toolCalls []domain.ToolCall, tools []domain.Tool) []InvalidCall {
+	offered := make(map[string]domain.Tool, len(tools))
+	for _, tool := range tools {
+		offered[tool.Function.Name] = tool
+	}
+
+	var invalid []InvalidCall
+	for _, call := range toolCalls {
+		if problems := validateCall(call, offered); len(problems) > 0 {
+			invalid = append(invalid, InvalidCall{Call: call, Problems: problems})
+		}
+	}
+	return invalid
+}
+
+func validateCall(call domain.ToolCall, offered map[string]domain.Tool) []string {
+	tool, ok := offered[call.Function.Name]
+	if !ok {
+		return []string{"unknown tool"}
+	}
+
+	arguments := strings.TrimSpace(string(call.Function.Arguments))
+	if arguments == "" || arguments == "null" {
+		arguments = "{}"
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(arguments), &value); err != nil {
+		return []string{fmt.Sprintf("arguments are not valid JSON: %v", err)}
+	}
+	if _, ok := value.(map[string]interface{}); !ok {
+		return []string{"arguments must be a JSON object"}
+	}
+
+	if len(tool.Function.Parameters) == 0 {
+		return nil
+	}
+	return jsonschema.Validate(tool.Function.Parameters, jsonschema.Coerce(tool.Function.Parameters, value))
+}
+
+// CorrectionPrompt describes invalid tool calls to the model and asks it
+// to try again
+func CorrectionPrompt(invalid []InvalidCall, tools []domain.Tool) string {
+	var b strings.Builder
+	b.WriteString("Your last response had invalid tool calls, so none of its tool calls were run:\n")
+	unknown := false
+	for _, call := range invalid {
+		arguments := string(call.Call.Function.Arguments)
+		if len(arguments) > maxArgumentsShown {
+			arguments = arguments[:maxArgumentsShown] + "..."
+		}
+		fmt.Fprintf(&b, "- %s with arguments %s: %s\n", call.Call.Function.Name, arguments, strings.Join(call.Problems, "; "))
+		for _, problem := range call.Problems {
+			if problem == "unknown tool" {
+				unknown = true
+			}
+		}
+	}
+
+	if unknown {
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			names = append(names, tool.Function.Name)
+		}
+		sort.Strings(names)
+		if len(names) > maxToolNamesShown {
+			names = append(names[:maxToolNamesShown], "...")
+		}
+		if len(names) == 0 {
+			b.WriteString("No tools are available for this request.\n")
+		} else {
+			fmt.Fprintf(&b, "The available tools are: %s\n", strings.Join(names, ", "))
+		}
+	}
+
+	b.WriteString("Make the tool calls again with valid names and arguments matching each tool's schema, or answer without them.")
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package toolloop
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+var readFile = domain.Tool{
+	Type: "function",
+	Function: domain.ToolFunction{
+		Name: "read_file",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"path":  map[string]interface{}{"type": "string"},
+				"limit": map[string]interface{}{"type": "integer"},
+			},
+			"required": []interface{}{"path"},
+		},
+	},
+}
+
+func TestValidateCalls(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      domain.ToolCall
+		wantValid bool
+		problem   string
+	}{
+		{"valid", call("read_file", `{"path":"a.txt","limit":5}`), true, ""},
+		{"coercible value", call("read_file", `{"path":"a.txt","limit":"5"}`), true, ""},
+		{"unknown tool", call("read_files", `{"path":"a.txt"}`), false, "unknown tool"},
+		{"malformed JSON", call("read_file", `{"path":"a.txt"`), false, "not valid JSON"},
+		{"not an object", call("read_file", `["a.txt"]`), false, "JSON object"},
+		{"missing required", call("read_file", ``), false, "missing required property 'path'"},
+		{"wrong type", call("read_file", `{"path":{"name":"a.txt"}}`), false, "expected string"},
+	}
+	for _, tt := range tests {
+		invalid := ValidateCalls([]domain.ToolCall{tt.call}, []domain.Tool{readFile})
+		if tt.wantValid {
+			if len(invalid) != 0 {
+				t.Errorf("%s: unexpected problems %v", tt.name, invalid[0].Problems)
+			}
+			continue
+		}
+		if len(invalid) != 1 || !strings.Contains(strings.Join(invalid[0].Problems, "; "), tt.problem) {
+			t.Errorf("%s: got %+v, want a problem containing %q", tt.name, invalid, tt.problem)
+		}
+	}
+}
+
+func TestCorrectionPrompt(t *testing.T) {
+	invalid := ValidateCalls([]domain.ToolCall{call("read_files", `{"path":"a.txt"}`)}, []domain.Tool{readFile})
+	prompt := CorrectionPrompt(invalid, []domain.Tool{readFile})
+	for _, want := range []string{"read_files", "unknown tool", "The available tools are: read_file"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	err := &InvalidCallsError{Calls: invalid}
+	if !strings.Contains(err.Error(), "read_files: unknown tool") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,22 @@
+// Package vector holds the math shared by everything that compares embeddings.
+package vector
+
+import "math"
+
+// CosineSimilarity returns the cosine of the angle between two vectors, from
+// -1 to 1. Vectors of different lengths, empty vectors and zero vectors score 0.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"path"
+)
+
+// AgentConfig is a reusable persona for workflow steps: the model, system
+// prompt, tools and sampling settings a step gets with agent: <name>.
+// Agents are defined in a workflow's agents: section or one per file in
+// config/agents/; a workflow's own agents hide shared ones of the same name.
+type AgentConfig struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+
+	// Model (a step's own provider settings take precedence)
+	Provider  string             `yaml:"provider,omitempty"`
+	Model     string             `yaml:"model,omitempty"`
+	Providers []ProviderFallback `yaml:"providers,omitempty"`
+
+	// System prompt layer between the workflow's and the step's
+	SystemPrompt     string           `yaml:"system_prompt,omitempty"`
+	SystemPromptMode SystemPromptMode `yaml:"system_prompt_mode,omitempty"`
+
+	// Tools
+	Servers []string `yaml:"servers,omitempty"`
+	Skills  []string `yaml:"skills,omitempty"`
+	Tools   []string `yaml:"tools,omitempty"` // Tool names the agent may call, * wildcards allowed (default: all)
+
+	// Model parameters
+	Temperature   *float64 `yaml:"temperature,omitempty"`
+	MaxTokens     *int     `yaml:"max_tokens,omitempty"`
+	MaxIterations *int     `yaml:"max_iterations,omitempty"`
+
+	// top_p, top_k, penalties, stop sequences and logit_bias
+	GenerationParams `yaml:",inline"`
+
+	Source string `yaml:"-"` // File or workflow the agent was defined in
+}
+
+// Validate checks an agent definition
+func (a *AgentConfig) Validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("agent name is required")
+	}
+	if len(a.Providers) > 0 && (a.Provider != "" || a.Model != "") {
+		return fmt.Errorf("agent %s: providers and provider/model are mutually exclusive", a.Name)
+	}
+	if (a.Provider == "") != (a.Model == "") {
+		return fmt.Errorf("agent %s: provider and model must be set together", a.Name)
+	}
+	if err := a.SystemPromptMode.Validate(); err != nil {
+		return fmt.Errorf("agent %s: %w", a.Name, err)
+	}
+	for _, pattern := range a.Tools {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("agent %s: invalid tools pattern %q", a.Name, pattern)
+		}
+	}
+	if err := a.GenerationParams.Validate(); err != nil {
+		return fmt.Errorf("agent %s: %w", a.Name, err)
+	}
+	return nil
+}
+
+// AllowsTool reports whether the agent may call a tool
+func (a *AgentConfig) AllowsTool(name string) bool {
+	if len(a.Tools) == 0 {
+		return true
+	}
+	for _, pattern := range a.Tools {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply gives a step the agent's settings where the step doesn't set its
+// own, and records the agent on the step
+func (a *AgentConfig) Apply(step *StepV2) {
+	if step.Provider == "" && step.Model == "" && len(step.Providers) == 0 {
+		step.Provider, step.Model = a.Provider, a.Model
+		step.Providers = a.Providers
+	}
+	if len(step.Servers) == 0 {
+		step.Servers = a.Servers
+	}
+	if len(step.Skills) == 0 {
+		step.Skills = a.Skills
+	}
+	if step.Temperature == nil {
+		step.Temperature = a.Temperature
+	}
+	if step.MaxTokens == nil {
+		step.MaxTokens = a.MaxTokens
+	}
+	if step.MaxIterations == nil {
+		step.MaxIterations = a.MaxIterations
+	}
+	step.GenerationParams = a.GenerationParams.Merge(step.GenerationParams)
+	step.ResolvedAgent = a
+}
+
+// ApplyAgents resolves the agent: of each step, and the agents of debate
+// steps, against the workflow's agents, then shared (config/agents/)
+func (w *WorkflowV2) ApplyAgents(shared map[string]*AgentConfig) error {
+	agents := make(map[string]*AgentConfig, len(w.Agents))
+	for i := range w.Agents {
+		agent := &w.Agents[i]
+		if err := agent.Validate(); err != nil {
+			return err
+		}
+		if _, exists := agents[agent.Name]; exists {
+			return fmt.Errorf("agent %s is defined twice", agent.Name)
+		}
+		agent.Source = "workflow " + w.Name
+		agents[agent.Name] = agent
+	}
+
+	resolve := func(step *StepV2, name string) (*AgentConfig, error) {
+		agent, ok := agents[name]
+		if !ok {
+			agent, ok = shared[name]
+		}
+		if !ok {
+			return nil, fmt.Errorf("step %s: unknown agent %s (define it in agents: or config/agents/)", step.Name, name)
+		}
+		return agent, nil
+	}
+
+	for i := range w.Steps {
+		step := &w.Steps[i]
+		if step.Agent != "" {
+			agent, err := resolve(step, step.Agent)
+			if err != nil {
+				return err
+			}
+			agent.Apply(step)
+		}
+
+		if debate := step.Debate; debate != nil {
+			debate.ResolvedAgents = make([]*AgentConfig, 0, len(debate.Agents))
+			for _, name := range debate.Agents {
+				agent, err := resolve(step, name)
+				if err != nil {
+					return err
+				}
+				debate.ResolvedAgents = append(debate.ResolvedAgents, agent)
+			}
+			if debate.Adjudicator != "" {
+				agent, err := resolve(step, debate.Adjudicator)
+				if err != nil {
+					return err
+				}
+				debate.ResolvedAdjudicator = agent
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestAgentConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		agent   AgentConfig
+		wantErr bool
+	}{
+		{name: "minimal", agent: AgentConfig{Name: "critic"}},
+		{name: "full", agent: AgentConfig{Name: "critic", Provider: "openai", Model: "gpt-4o", Tools: []string{"search_*"}}},
+		{name: "no name", agent: AgentConfig{}, wantErr: true},
+		{name: "provider without model", agent: AgentConfig{Name: "critic", Provider: "openai"}, wantErr: true},
+		{name: "providers and provider", agent: AgentConfig{Name: "critic", Provider: "openai", Model: "gpt-4o",
+			Providers: []ProviderFallback{{Provider: "anthropic", Model: "claude"}}}, wantErr: true},
+		{name: "mode", agent: AgentConfig{Name: "critic", SystemPromptMode: "merge"}, wantErr: true},
+		{name: "tools pattern", agent: AgentConfig{Name: "critic", Tools: []string{"[search"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.agent.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAgentConfig_AllowsTool(t *testing.T) {
+	agent := &AgentConfig{Name: "researcher", Tools: []string{"search_*", "fetch_url"}}
+	for tool, want := range map[string]bool{
+		"search_web":  true,
+		"fetch_url":   true,
+		"write_file":  false,
+		"fetch_url_2": false,
+	} {
+		if got := agent.AllowsTool(tool); got != want {
+			t.Errorf("AllowsTool(%s) = %v, want %v", tool, got, want)
+		}
+	}
+
+	if !(&AgentConfig{Name: "any"}).AllowsTool("write_file") {
+		t.Error("an agent without tools should allow every tool")
+	}
+}
+
+func TestWorkflowV2_ApplyAgents(t *testing.T) {
+	var workflow WorkflowV2
+	err := yaml.Unmarshal([]byte(`
+name: review
+agents:
+  - name: critic
+    system_prompt: Find the weaknesses.
+    temperature: 0.2
+    top_p: 0.5
+    servers: [files]
+steps:
+  - name: draft
+    run: Write it
+  - name: critique
+    agent: critic
+    run: Critique {{draft}}
+  - name: final
+    agent: writer
+    provider: anthropic
+    model: claude
+    temperature: 0.9
+    run: Rewrite {{draft}}
+`), &workflow)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	temperature := 0.7
+	shared := map[string]*AgentConfig{
+		"critic": {Name: "critic", SystemPrompt: "hidden by the workflow's critic"},
+		"writer": {Name: "writer", Provider: "openai", Model: "gpt-4o", Temperature: &temperature, Skills: []string{"docx"}},
+	}
+	if err := workflow.ApplyAgents(shared); err != nil {
+		t.Fatalf("ApplyAgents() error = %v", err)
+	}
+
+	if workflow.Steps[0].ResolvedAgent != nil {
+		t.Error("a step without agent: should not get one")
+	}
+
+	critique := workflow.Steps[1]
+	if critique.ResolvedAgent == nil || critique.ResolvedAgent.SystemPrompt != "Find the weaknesses." {
+		t.Fatalf("critique agent = %+v, want the workflow's critic", critique.ResolvedAgent)
+	}
+	if critique.ResolvedAgent.Source != "workflow review" {
+		t.Errorf("critique agent source = %q", critique.ResolvedAgent.Source)
+	}
+	if critique.Temperature == nil || *critique.Temperature != 0.2 || critique.TopP == nil || *critique.TopP != 0.5 {
+		t.Errorf("critique sampling = %v/%v, want the agent's", critique.Temperature, critique.TopP)
+	}
+	if strings.Join(critique.Servers, ",") != "files" {
+		t.Errorf("critique servers = %v, want [files]", critique.Servers)
+	}
+
+	// The step's own settings win over the shared agent's
+	final := workflow.Steps[2]
+	if final.Provider != "anthropic" || final.Model != "claude" || *final.Temperature != 0.9 {
+		t.Errorf("final = %s/%s at %v, want the step's own settings", final.Provider, final.Model, *final.Temperature)
+	}
+	if strings.Join(final.Skills, ",") != "docx" {
+		t.Errorf("final skills = %v, want the agent's [docx]", final.Skills)
+	}
+}
+
+func TestWorkflowV2_ApplyAgentsDebate(t *testing.T) {
+	workflow := WorkflowV2{
+		Name:   "assess",
+		Agents: []AgentConfig{{Name: "red_team"}, {Name: "chair"}},
+		Steps: []StepV2{{Name: "debate", Debate: &DebateMode{
+			Question:    "Is it exploitable?",
+			Agents:      []string{"red_team", "blue_team"},
+			Adjudicator: "chair",
+		}}},
+	}
+	shared := map[string]*AgentConfig{"blue_team": {Name: "blue_team", Source: "config/agents/blue.yaml"}}
+	if err := workflow.ApplyAgents(shared); err != nil {
+		t.Fatalf("ApplyAgents() error = %v", err)
+	}
+
+	debate := workflow.Steps[0].Debate
+	if len(debate.ResolvedAgents) != 2 || debate.ResolvedAgents[0] != &workflow.Agents[0] || debate.ResolvedAgents[1] != shared["blue_team"] {
+		t.Errorf("ResolvedAgents = %+v, want red_team from the workflow and blue_team from shared", debate.ResolvedAgents)
+	}
+	if debate.ResolvedAdjudicator != &workflow.Agents[1] {
+		t.Errorf("ResolvedAdjudicator = %+v, want the workflow's chair", debate.ResolvedAdjudicator)
+	}
+	if workflow.Steps[0].ResolvedAgent != nil {
+		t.Error("a debate step without agent: should not get one")
+	}
+}
+
+func TestWorkflowV2_ApplyAgentsErrors(t *testing.T) {
+	unknown := WorkflowV2{Steps: []StepV2{{Name: "s", Agent: "ghost", Run: "x"}}}
+	if err := unknown.ApplyAgents(nil); err == nil || !strings.Contains(err.Error(), "unknown agent ghost") {
+		t.Errorf("ApplyAgents() unknown agent error = %v", err)
+	}
+
+	duplicate := WorkflowV2{Agents: []AgentConfig{{Name: "a"}, {Name: "a"}}}
+	if err := duplicate.ApplyAgents(nil); err == nil {
+		t.Error("ApplyAgents() should reject an agent defined twice")
+	}
+
+	debate := WorkflowV2{Steps: []StepV2{{Name: "d", Debate: &DebateMode{Agents: []string{"red", "ghost"}}}}}
+	if err := debate.ApplyAgents(map[string]*AgentConfig{"red": {Name: "red"}}); err == nil || !strings.Contains(err.Error(), "unknown agent ghost") {
+		t.Errorf("ApplyAgents() unknown debate agent error = %v", err)
+	}
+
+	invalid := WorkflowV2{Agents: []AgentConfig{{Name: "a", Provider: "openai"}}}
+	if err := invalid.ApplyAgents(nil); err == nil {
+		t.Error("ApplyAgents() should validate the workflow's agents")
+	}
+}
@@ -6,13 +6,28 @@ import (
 
 // ApplicationConfig represents the complete application configuration
 type ApplicationConfig struct {
-	Servers    map[string]ServerConfig `yaml:"servers"`
-	AI         *AIConfig               `yaml:"ai,omitempty"`
-	Embeddings *EmbeddingsConfig       `yaml:"embeddings,omitempty"`
-	Chat       *ChatConfig             `yaml:"chat,omitempty"`
-	Skills     *SkillsConfig           `yaml:"skills,omitempty"`
-	RAG        *RagConfig              `yaml:"rag,omitempty"`
-	Workflows  map[string]*WorkflowV2  `yaml:"-"` // Loaded separately from config/workflows/
+	Servers        map[string]ServerConfig `yaml:"servers"`
+	AI             *AIConfig               `yaml:"ai,omitempty"`
+	Embeddings     *EmbeddingsConfig       `yaml:"embeddings,omitempty"`
+	Chat           *ChatConfig             `yaml:"chat,omitempty"`
+	Skills         *SkillsConfig           `yaml:"skills,omitempty"`
+	RAG            *RagConfig              `yaml:"rag,omitempty"`
+	Storage        *StorageConfig          `yaml:"storage,omitempty"`
+	Notifications  *NotificationsConfig    `yaml:"notifications,omitempty"`
+	LogAnalytics   *LogAnalyticsConfig     `yaml:"log_analytics,omitempty"`
+	Graph          *GraphConfig            `yaml:"graph,omitempty"`
+	TTS            *TTSConfig              `yaml:"tts,omitempty"`
+	Routing        *RoutingConfig          `yaml:"routing,omitempty"`
+	Judges         *JudgesConfig           `yaml:"judges,omitempty"`
+	BuiltinTools   *BuiltinToolsConfig     `yaml:"builtin_tools,omitempty"`
+	ToolSelection  *ToolSelectionConfig    `yaml:"tool_selection,omitempty"`
+	ToolIterations *ToolIterationsConfig   `yaml:"tool_iterations,omitempty"`
+	CostEstimate   *CostEstimateConfig     `yaml:"cost_estimate,omitempty"`
+	Roots          []RootConfig            `yaml:"roots,omitempty"`
+	Workflows      map[string]*WorkflowV2  `yaml:"-"` // Loaded separately from config/workflows/
+	Prompts        *PromptLibrary          `yaml:"-"` // Loaded separately from config/prompts/
+	Agents         map[string]*AgentConfig `yaml:"-"` // Loaded separately from config/agents/
+	Tenant         string                  `yaml:"-"` // Set on a tenant's view of the config in serve mode
 }
 
 // ValidateWorkflows validates all workflow v2 definitions
@@ -86,6 +101,23 @@ type SkillsConfig struct {
 
 	// OutputsDir is the directory where skill outputs are persisted
 	OutputsDir string `yaml:"outputs_dir,omitempty"`
+
+	// OutputsEncryptionKey encrypts files written to the outputs directory
+	// (base64 256-bit key, usually ${MCP_OUTPUTS_KEY}; default: not encrypted)
+	OutputsEncryptionKey string `yaml:"outputs_encryption_key,omitempty"`
+
+	// Audit records every execute_skill_code call for later review
+	Audit *SkillAuditConfig `yaml:"audit,omitempty"`
+
+	// MaxConcurrent bounds how many skill executions run at once (default: 4)
+	MaxConcurrent int `yaml:"max_concurrent,omitempty"`
+
+	// MaxUploadMB is the largest file that can be uploaded into the sandbox
+	// workspace in chat (default: 50)
+	MaxUploadMB int `yaml:"max_upload_mb,omitempty"`
+
+	// Router offers only the skills relevant to each request
+	Router *SkillRouterConfig `yaml:"router,omitempty"`
 }
 
 // GetSkillsDirectory returns the skills directory with fallback to default
@@ -103,3 +135,20 @@ func (s *SkillsConfig) GetOutputsDir() string {
 	}
 	return s.OutputsDir
 }
+
+// GetMaxUploadSize returns the largest file that can be uploaded into the
+// sandbox workspace, in bytes
+func (s *SkillsConfig) GetMaxUploadSize() int64 {
+	if s == nil || s.MaxUploadMB <= 0 {
+		return 50 << 20
+	}
+	return int64(s.MaxUploadMB) << 20
+}
+
+// GetMaxConcurrent returns how many skill executions may run at once
+func (s *SkillsConfig) GetMaxConcurrent() int {
+	if s == nil || s.MaxConcurrent <= 0 {
+		return 4
+	}
+	return s.MaxConcurrent
+}
@@ -0,0 +1,55 @@
+package config
+
+// AzureCredentialConfig holds Azure AD (Entra ID) client credentials
+type AzureCredentialConfig struct {
+	TenantID      string `yaml:"tenant_id,omitempty"`
+	ClientID      string `yaml:"client_id,omitempty"`
+	ClientSecret  string `yaml:"client_secret,omitempty"`
+	AuthorityHost string `yaml:"authority_host,omitempty"` // default: https://login.microsoftonline.com
+	AccessToken   string `yaml:"access_token,omitempty"`   // Pre-issued token (alternative to client credentials)
+}
+
+// LogAnalyticsConfig represents Azure Monitor Logs Ingestion configuration
+// (settings.yaml `log_analytics:` section)
+type LogAnalyticsConfig struct {
+	DefaultDestination string                                   `yaml:"default_destination,omitempty"`
+	Destinations       map[string]LogAnalyticsDestinationConfig `yaml:"destinations,omitempty"`
+}
+
+// LogAnalyticsDestinationConfig defines a DCR-based ingestion target
+type LogAnalyticsDestinationConfig struct {
+	Endpoint       string `yaml:"endpoint"`         // Data collection endpoint (DCE) or DCR ingestion endpoint
+	DCRImmutableID string `yaml:"dcr_immutable_id"` // Data collection rule immutable ID (dcr-...)
+	StreamName     string `yaml:"stream_name"`      // Stream declared in the DCR (e.g. Custom-WorkflowResults_CL)
+
+	AzureCredentialConfig `yaml:",inline"`
+
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+}
+
+// GetDestination returns a named destination, falling back to the default destination
+func (c *LogAnalyticsConfig) GetDestination(name string) (*LogAnalyticsDestinationConfig, string, bool) {
+	if c == nil || c.Destinations == nil {
+		return nil, "", false
+	}
+
+	if name == "" {
+		name = c.DefaultDestination
+	}
+
+	destination, exists := c.Destinations[name]
+	if !exists {
+		return nil, name, false
+	}
+
+	return &destination, name, true
+}
+
+// GraphConfig represents Microsoft Graph configuration (settings.yaml `graph:` section)
+type GraphConfig struct {
+	Endpoint string `yaml:"endpoint,omitempty"` // default: https://graph.microsoft.com
+
+	AzureCredentialConfig `yaml:",inline"`
+
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+}
@@ -0,0 +1,28 @@
+package config
+
+import "regexp"
+
+// blackboardKeyPattern matches the names blackboard keys may use, so
+// {{blackboard.<key>.version}} and friends parse unambiguously
+var blackboardKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// BlackboardConfig configures the run's blackboard: versioned entries that
+// steps, parallel ones included, read as {{blackboard.<key>}} and add to
+// with write_blackboard
+type BlackboardConfig struct {
+	Initial map[string]string `yaml:"initial,omitempty"` // Entries the run starts with (version 1)
+	Tools   bool              `yaml:"tools,omitempty"`   // Offer read_blackboard and write_blackboard tools to run: steps
+}
+
+// BlackboardWrite adds a version of a blackboard key once its step succeeds
+type BlackboardWrite struct {
+	Key       string `yaml:"key"`
+	Value     string `yaml:"value,omitempty"`      // Supports {{variables}} (default: the step's result)
+	IfVersion *int   `yaml:"if_version,omitempty"` // Only write while the key is at this version (0: not written yet)
+}
+
+// ValidBlackboardKey reports whether key is a usable blackboard key: letters,
+// digits, underscores and hyphens
+func ValidBlackboardKey(key string) bool {
+	return blackboardKeyPattern.MatchString(key)
+}
@@ -0,0 +1,108 @@
+package config
+
+import "time"
+
+// BuiltinToolsConfig represents the built-in helper tools (settings.yaml `builtin_tools:` section)
+type BuiltinToolsConfig struct {
+	Enabled    *bool                 `yaml:"enabled,omitempty"`    // Offer built-in tools to the LLM (default: true)
+	Exclude    []string              `yaml:"exclude,omitempty"`    // Built-in tool names to leave out, e.g. [generate_random]
+	Fetch      *FetchToolConfig      `yaml:"fetch,omitempty"`      // Opt-in fetch_url tool
+	Search     *SearchToolConfig     `yaml:"search,omitempty"`     // search_web tool, offered once a backend is set
+	Filesystem *FilesystemToolConfig `yaml:"filesystem,omitempty"` // File tools, offered once a directory is allowed
+}
+
+// FilesystemToolConfig configures the built-in filesystem tools
+type FilesystemToolConfig struct {
+	AllowedDirectories []string `yaml:"allowed_directories"`     // Roots the tools may touch; relative paths resolve against the working directory
+	ReadOnly           bool     `yaml:"read_only,omitempty"`     // Offer only the tools that read
+	MaxFileSize        int64    `yaml:"max_file_size,omitempty"` // Largest file read or written (default: 1 MiB)
+}
+
+// FetchToolConfig configures the fetch_url built-in tool
+type FetchToolConfig struct {
+	Enabled        bool     `yaml:"enabled"`                   // Offer fetch_url (default: false)
+	MaxBytes       int64    `yaml:"max_bytes,omitempty"`       // Largest response downloaded (default: 2 MiB)
+	MaxLength      int      `yaml:"max_length,omitempty"`      // Characters of extracted text returned per call (default: 20000)
+	TimeoutSeconds int      `yaml:"timeout_seconds,omitempty"` // Per request (default: 30)
+	CacheTTL       string   `yaml:"cache_ttl,omitempty"`       // How long fetched pages are reused, e.g. 15m (default: 15m; 0 disables)
+	UserAgent      string   `yaml:"user_agent,omitempty"`      // Sent with requests and matched against robots.txt (default: mcp-cli)
+	IgnoreRobots   bool     `yaml:"ignore_robots,omitempty"`   // Fetch pages robots.txt disallows
+	AllowPrivate   bool     `yaml:"allow_private,omitempty"`   // Allow loopback, private and link-local addresses
+	AllowedDomains []string `yaml:"allowed_domains,omitempty"` // Only fetch these domains and their subdomains
+	BlockedDomains []string `yaml:"blocked_domains,omitempty"` // Never fetch these domains and their subdomains
+}
+
+// Web search backends
+const (
+	SearchSearXNG = "searxng"
+	SearchBrave   = "brave"
+	SearchBing    = "bing"
+	SearchTavily  = "tavily"
+)
+
+// SearchToolConfig configures the search_web built-in tool
+type SearchToolConfig struct {
+	Backend        string `yaml:"backend"`                   // searxng, brave, bing or tavily
+	APIKey         string `yaml:"api_key,omitempty"`         // Required for brave, bing and tavily
+	Endpoint       string `yaml:"endpoint,omitempty"`        // SearXNG instance URL (required for searxng); overrides the API URL for the others
+	MaxResults     int    `yaml:"max_results,omitempty"`     // Results per search (default: 5, max: 20)
+	SafeSearch     string `yaml:"safe_search,omitempty"`     // off, moderate or strict (default: moderate)
+	Language       string `yaml:"language,omitempty"`        // Result language or market, e.g. en-US
+	TimeoutSeconds int    `yaml:"timeout_seconds,omitempty"` // Per search (default: 30)
+}
+
+// IsEnabled reports whether built-in tools are offered (default: true)
+func (c *BuiltinToolsConfig) IsEnabled() bool {
+	return c == nil || c.Enabled == nil || *c.Enabled
+}
+
+// Excludes reports whether a built-in tool has been turned off
+func (c *BuiltinToolsConfig) Excludes(name string) bool {
+	if c == nil {
+		return false
+	}
+	for _, excluded := range c.Exclude {
+		if excluded == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchEnabled reports whether the opt-in fetch_url tool is turned on
+func (c *BuiltinToolsConfig) FetchEnabled() bool {
+	return c != nil && c.Fetch != nil && c.Fetch.Enabled
+}
+
+// SearchEnabled reports whether a search_web backend is configured
+func (c *BuiltinToolsConfig) SearchEnabled() bool {
+	return c != nil && c.Search != nil && c.Search.Backend != ""
+}
+
+// FilesystemEnabled reports whether the filesystem tools have a directory to work in
+func (c *BuiltinToolsConfig) FilesystemEnabled() bool {
+	return c != nil && c.Filesystem != nil && len(c.Filesystem.AllowedDirectories) > 0
+}
+
+// WithoutFilesystem returns a copy of the settings with the filesystem
+// tools turned off, for --disable-filesystem
+func (c *BuiltinToolsConfig) WithoutFilesystem() *BuiltinToolsConfig {
+	if c == nil {
+		return nil
+	}
+	copied := *c
+	copied.Filesystem = nil
+	return &copied
+}
+
+// GetCacheTTL returns how long fetched pages are cached (default: 15m)
+func (c *FetchToolConfig) GetCacheTTL() time.Duration {
+	if c == nil || c.CacheTTL == "" {
+		return 15 * time.Minute
+	}
+	d, err := time.ParseDuration(c.CacheTTL)
+	if err != nil || d < 0 {
+		return 15 * time.Minute
+	}
+	return d
+}
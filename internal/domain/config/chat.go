@@ -15,6 +15,55 @@ type ChatConfig struct {
 
 	// Whether to enable session logging (derived from ChatLogsLocation)
 	SessionLoggingEnabled bool `yaml:"-" json:"-"`
+
+	// Retrieval from a RAG server for every message (optional)
+	AutoRAG *AutoRAGConfig `yaml:"auto_rag,omitempty" json:"auto_rag,omitempty"`
+}
+
+// AutoRAGConfig configures auto-RAG: before each message is answered, the
+// configured RAG server is searched with it and the top results are given
+// to the model as context the conversation doesn't show
+type AutoRAGConfig struct {
+	// Whether retrieval runs (auto-RAG is opt-in)
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// RAG server from rag.servers (default: rag.default_server)
+	Server string `yaml:"server,omitempty" json:"server,omitempty"`
+
+	// Results given to the model per message (default: 3)
+	TopK int `yaml:"top_k,omitempty" json:"top_k,omitempty"`
+
+	// Results scoring below this are left out (default: 0, keep all)
+	MinScore float64 `yaml:"min_score,omitempty" json:"min_score,omitempty"`
+
+	// Only search chunks in this namespace (optional)
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Earlier user messages added to the search query, so follow-ups such
+	// as "what about the second one?" find the same material (default: 1)
+	HistoryTurns *int `yaml:"history_turns,omitempty" json:"history_turns,omitempty"`
+}
+
+// Default auto-RAG settings
+const (
+	DefaultAutoRAGTopK         = 3
+	DefaultAutoRAGHistoryTurns = 1
+)
+
+// TopKOrDefault returns the results per message
+func (c *AutoRAGConfig) TopKOrDefault() int {
+	if c.TopK > 0 {
+		return c.TopK
+	}
+	return DefaultAutoRAGTopK
+}
+
+// HistoryTurnsOrDefault returns the earlier user messages added to queries
+func (c *AutoRAGConfig) HistoryTurnsOrDefault() int {
+	if c.HistoryTurns != nil {
+		return *c.HistoryTurns
+	}
+	return DefaultAutoRAGHistoryTurns
 }
 
 // DefaultChatConfig returns default chat configuration
@@ -39,5 +88,26 @@ func (c *ChatConfig) Validate() error {
 			WithContext("max_history_size", c.MaxHistorySize)
 	}
 
+	if c.AutoRAG != nil {
+		return c.AutoRAG.Validate()
+	}
+
+	return nil
+}
+
+// Validate checks if the auto-RAG config is valid
+func (c *AutoRAGConfig) Validate() error {
+	if c.TopK < 0 {
+		return NewConfigError("auto_rag.top_k must not be negative").
+			WithContext("top_k", c.TopK)
+	}
+	if c.MinScore < 0 {
+		return NewConfigError("auto_rag.min_score must not be negative").
+			WithContext("min_score", c.MinScore)
+	}
+	if c.HistoryTurns != nil && *c.HistoryTurns < 0 {
+		return NewConfigError("auto_rag.history_turns must not be negative").
+			WithContext("history_turns", *c.HistoryTurns)
+	}
 	return nil
 }
@@ -0,0 +1,84 @@
+package config
+
+// DefaultAverageOutputTokens is the completion length assumed per LLM call
+// when a step doesn't set a lower max_tokens
+const DefaultAverageOutputTokens = 1000
+
+// CostEstimateConfig controls the cost estimate printed before workflows
+// and embedding jobs run (settings.yaml `cost_estimate:` section). Prices
+// come from the providers' cost_per_1k_* settings.
+type CostEstimateConfig struct {
+	ConfirmAbove        float64 `yaml:"confirm_above,omitempty"`         // Ask before running when the estimate exceeds this many USD (0 = never ask)
+	AverageOutputTokens int     `yaml:"average_output_tokens,omitempty"` // Completion tokens assumed per LLM call (default: 1000)
+}
+
+// ConfirmThreshold returns the USD estimate above which runs need
+// confirmation, or 0 when they never do
+func (c *CostEstimateConfig) ConfirmThreshold() float64 {
+	if c == nil || c.ConfirmAbove < 0 {
+		return 0
+	}
+	return c.ConfirmAbove
+}
+
+// OutputTokens returns the completion tokens assumed per LLM call
+func (c *CostEstimateConfig) OutputTokens() int {
+	if c == nil || c.AverageOutputTokens <= 0 {
+		return DefaultAverageOutputTokens
+	}
+	return c.AverageOutputTokens
+}
+
+// EmbeddingPrice returns the USD price per 1K tokens of an embedding model,
+// looked up in the embeddings section and then the AI providers, and
+// whether one is configured
+func (c *ApplicationConfig) EmbeddingPrice(provider, model string) (float64, bool) {
+	if c.Embeddings != nil {
+		candidates := []EmbeddingProviderConfig{}
+		for _, iface := range c.Embeddings.Interfaces {
+			if providerConfig, ok := iface.Providers[provider]; ok {
+				candidates = append(candidates, providerConfig)
+			}
+		}
+		if providerConfig, ok := c.Embeddings.Providers[provider]; ok {
+			candidates = append(candidates, providerConfig)
+		}
+		for _, providerConfig := range candidates {
+			name := model
+			if name == "" {
+				name = providerConfig.DefaultModel
+			}
+			if modelConfig, ok := providerConfig.Models[name]; ok && modelConfig.CostPer1kTokens > 0 {
+				return modelConfig.CostPer1kTokens, true
+			}
+		}
+	}
+
+	if providerConfig := c.FindProviderConfig(provider); providerConfig != nil {
+		name := model
+		if name == "" {
+			name = providerConfig.DefaultEmbeddingModel
+		}
+		if modelConfig, ok := providerConfig.EmbeddingModels[name]; ok && modelConfig.CostPer1kTokens > 0 {
+			return modelConfig.CostPer1kTokens, true
+		}
+	}
+	return 0, false
+}
+
+// FindProviderConfig looks an AI provider up across interfaces and the
+// legacy providers section, returning nil when it isn't configured
+func (c *ApplicationConfig) FindProviderConfig(name string) *ProviderConfig {
+	if c == nil || c.AI == nil {
+		return nil
+	}
+	for _, iface := range c.AI.Interfaces {
+		if providerConfig, ok := iface.Providers[name]; ok {
+			return &providerConfig
+		}
+	}
+	if providerConfig, ok := c.AI.Providers[name]; ok {
+		return &providerConfig
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ const (
 	ChunkingFixed     ChunkingType = "fixed"
 	ChunkingSemantic  ChunkingType = "semantic"
 	ChunkingSliding   ChunkingType = "sliding"
+	ChunkingCode      ChunkingType = "code"
 )
 
 // EmbeddingsConfig represents the embeddings configuration section
@@ -23,6 +24,7 @@ type EmbeddingsConfig struct {
 	CacheTTL             string                                     `yaml:"cache_ttl,omitempty"`
 	Interfaces           map[InterfaceType]EmbeddingInterfaceConfig `yaml:"interfaces,omitempty"`
 	Providers            map[string]EmbeddingProviderConfig         `yaml:"providers,omitempty"`
+	FallbackChain        []ProviderFallback                         `yaml:"fallback_chain,omitempty"` // Tried in order when a request's provider fails
 }
 
 // EmbeddingInterfaceConfig represents configuration for an embedding interface
@@ -39,6 +41,7 @@ type EmbeddingProviderConfig struct {
 	TimeoutSeconds  int                             `yaml:"timeout_seconds,omitempty"`
 	MaxRetries      int                             `yaml:"max_retries,omitempty"`
 	Models          map[string]EmbeddingModelConfig `yaml:"models,omitempty"`
+	MonthlyBudget   float64                         `yaml:"monthly_budget,omitempty"` // USD; 0 means no cap
 }
 
 // Note: EmbeddingModelConfig is defined in provider.go to avoid duplication
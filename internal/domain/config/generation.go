@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GenerationParams are the sampling settings beyond temperature and
+// max_tokens. They can be set as provider defaults, in a workflow's
+// execution section and on a step; each level overrides the fields it sets.
+// Interfaces that don't support a setting leave it out of their requests.
+type GenerationParams struct {
+	TopP             *float64           `yaml:"top_p,omitempty" json:"top_p,omitempty"`
+	TopK             *int               `yaml:"top_k,omitempty" json:"top_k,omitempty"`
+	PresencePenalty  *float64           `yaml:"presence_penalty,omitempty" json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64           `yaml:"frequency_penalty,omitempty" json:"frequency_penalty,omitempty"`
+	Stop             []string           `yaml:"stop,omitempty" json:"stop,omitempty"`             // Stop sequences
+	LogitBias        map[string]float64 `yaml:"logit_bias,omitempty" json:"logit_bias,omitempty"` // Token ID -> bias (-100 to 100)
+}
+
+// Merge returns p with the fields override sets replaced
+func (p GenerationParams) Merge(override GenerationParams) GenerationParams {
+	if override.TopP != nil {
+		p.TopP = override.TopP
+	}
+	if override.TopK != nil {
+		p.TopK = override.TopK
+	}
+	if override.PresencePenalty != nil {
+		p.PresencePenalty = override.PresencePenalty
+	}
+	if override.FrequencyPenalty != nil {
+		p.FrequencyPenalty = override.FrequencyPenalty
+	}
+	if override.Stop != nil {
+		p.Stop = override.Stop
+	}
+	if override.LogitBias != nil {
+		p.LogitBias = override.LogitBias
+	}
+	return p
+}
+
+// Names returns the YAML names of the settings p sets, sorted
+func (p GenerationParams) Names() []string {
+	var names []string
+	add := func(set bool, name string) {
+		if set {
+			names = append(names, name)
+		}
+	}
+	add(p.TopP != nil, "top_p")
+	add(p.TopK != nil, "top_k")
+	add(p.PresencePenalty != nil, "presence_penalty")
+	add(p.FrequencyPenalty != nil, "frequency_penalty")
+	add(len(p.Stop) > 0, "stop")
+	add(len(p.LogitBias) > 0, "logit_bias")
+	sort.Strings(names)
+	return names
+}
+
+// IsZero reports whether p sets nothing
+func (p GenerationParams) IsZero() bool {
+	return len(p.Names()) == 0
+}
+
+// Validate checks the settings against the ranges the provider APIs accept
+func (p GenerationParams) Validate() error {
+	if p.TopP != nil && (*p.TopP <= 0 || *p.TopP > 1) {
+		return fmt.Errorf("top_p must be greater than 0 and at most 1, got %g", *p.TopP)
+	}
+	if p.TopK != nil && *p.TopK < 1 {
+		return fmt.Errorf("top_k must be at least 1, got %d", *p.TopK)
+	}
+	if p.PresencePenalty != nil && (*p.PresencePenalty < -2 || *p.PresencePenalty > 2) {
+		return fmt.Errorf("presence_penalty must be between -2 and 2, got %g", *p.PresencePenalty)
+	}
+	if p.FrequencyPenalty != nil && (*p.FrequencyPenalty < -2 || *p.FrequencyPenalty > 2) {
+		return fmt.Errorf("frequency_penalty must be between -2 and 2, got %g", *p.FrequencyPenalty)
+	}
+	for _, stop := range p.Stop {
+		if stop == "" {
+			return fmt.Errorf("stop sequences must not be empty")
+		}
+	}
+	for token, bias := range p.LogitBias {
+		if bias < -100 || bias > 100 {
+			return fmt.Errorf("logit_bias for token %s must be between -100 and 100, got %g", token, bias)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerationParamsMerge(t *testing.T) {
+	topP, lower := 0.9, 0.5
+	topK := 40
+	base := GenerationParams{TopP: &topP, TopK: &topK, Stop: []string{"END"}}
+	merged := base.Merge(GenerationParams{TopP: &lower, Stop: []string{}})
+
+	if *merged.TopP != 0.5 || *merged.TopK != 40 {
+		t.Errorf("merged = top_p %v, top_k %v", *merged.TopP, *merged.TopK)
+	}
+	// An explicitly empty list clears the stop sequences
+	if len(merged.Stop) != 0 {
+		t.Errorf("merged stop = %v", merged.Stop)
+	}
+	if *base.TopP != 0.9 || len(base.Stop) != 1 {
+		t.Error("Merge modified the receiver")
+	}
+	if got := merged.Names(); !reflect.DeepEqual(got, []string{"top_k", "top_p"}) {
+		t.Errorf("Names() = %v", got)
+	}
+}
+
+func TestGenerationParamsInline(t *testing.T) {
+	var step StepV2
+	data := "name: summarize\nrun: Summarize\ntop_p: 0.8\nfrequency_penalty: 0.5\nstop: [\"\\n\\n\"]\nlogit_bias: {\"50256\": -100}\n"
+	if err := yaml.Unmarshal([]byte(data), &step); err != nil {
+		t.Fatal(err)
+	}
+	if step.TopP == nil || *step.TopP != 0.8 || *step.FrequencyPenalty != 0.5 {
+		t.Errorf("step params = %+v", step.GenerationParams)
+	}
+	if len(step.Stop) != 1 || step.Stop[0] != "\n\n" || step.LogitBias["50256"] != -100 {
+		t.Errorf("stop %q, logit_bias %v", step.Stop, step.LogitBias)
+	}
+}
+
+func TestGenerationParamsValidate(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	zero := 0
+
+	tests := []struct {
+		name    string
+		params  GenerationParams
+		wantErr string
+	}{
+		{name: "empty", params: GenerationParams{}},
+		{name: "in range", params: GenerationParams{TopP: f(1), PresencePenalty: f(-2), LogitBias: map[string]float64{"1": 100}}},
+		{name: "top_p zero", params: GenerationParams{TopP: f(0)}, wantErr: "top_p"},
+		{name: "top_k zero", params: GenerationParams{TopK: &zero}, wantErr: "top_k"},
+		{name: "penalty too high", params: GenerationParams{FrequencyPenalty: f(2.5)}, wantErr: "frequency_penalty"},
+		{name: "empty stop", params: GenerationParams{Stop: []string{""}}, wantErr: "stop"},
+		{name: "bias out of range", params: GenerationParams{LogitBias: map[string]float64{"7": -101}}, wantErr: "token 7"},
+	}
+	for _, tt := range tests {
+		err := tt.params.Validate()
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+// Judge roles
+const (
+	JudgeRoleConditions = "conditions" // Loop until_llm exit conditions
+	JudgeRoleValidation = "validation" // Output checks such as compare rankings
+	JudgeRoleConsensus  = "consensus"  // Adjudicating consensus steps that fail to agree, and debates
+	JudgeRoleCombine    = "combine"    // Summarizing iterate loop outputs (combine: summarize)
+)
+
+// JudgeConfig selects the model that makes a judgment call
+type JudgeConfig struct {
+	Provider    string   `yaml:"provider,omitempty"`
+	Model       string   `yaml:"model,omitempty"`
+	Temperature *float64 `yaml:"temperature,omitempty"` // Default: 0
+}
+
+// JudgesConfig assigns judge models per role (settings.yaml or workflow
+// `judges:` section). Roles without an entry use default.
+type JudgesConfig struct {
+	Default    *JudgeConfig `yaml:"default,omitempty"`
+	Conditions *JudgeConfig `yaml:"conditions,omitempty"`
+	Validation *JudgeConfig `yaml:"validation,omitempty"`
+	Consensus  *JudgeConfig `yaml:"consensus,omitempty"`
+	Combine    *JudgeConfig `yaml:"combine,omitempty"`
+}
+
+// For returns the role's judge followed by the default; either may be nil
+func (c *JudgesConfig) For(role string) []*JudgeConfig {
+	if c == nil {
+		return nil
+	}
+	var specific *JudgeConfig
+	switch role {
+	case JudgeRoleConditions:
+		specific = c.Conditions
+	case JudgeRoleValidation:
+		specific = c.Validation
+	case JudgeRoleConsensus:
+		specific = c.Consensus
+	case JudgeRoleCombine:
+		specific = c.Combine
+	}
+	return []*JudgeConfig{specific, c.Default}
+}
+
+// All returns the configured judges, in role order
+func (c *JudgesConfig) All() []*JudgeConfig {
+	if c == nil {
+		return nil
+	}
+	var judges []*JudgeConfig
+	for _, judge := range []*JudgeConfig{c.Default, c.Conditions, c.Validation, c.Consensus, c.Combine} {
+		if judge != nil {
+			judges = append(judges, judge)
+		}
+	}
+	return judges
+}
+
+// ResolveJudge merges candidates ordered most specific first. The first
+// candidate naming a provider supplies it; model and temperature come from
+// the most specific candidate setting them, as long as it is not less
+// specific than the provider's (a model is only meaningful for its provider).
+func ResolveJudge(candidates ...*JudgeConfig) JudgeConfig {
+	var resolved JudgeConfig
+	for _, candidate := range candidates {
+		if candidate == nil {
+			continue
+		}
+		if resolved.Model == "" {
+			resolved.Model = candidate.Model
+		}
+		if resolved.Temperature == nil {
+			resolved.Temperature = candidate.Temperature
+		}
+		if candidate.Provider != "" {
+			resolved.Provider = candidate.Provider
+			break
+		}
+	}
+	return resolved
+}
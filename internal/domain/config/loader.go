@@ -100,6 +100,17 @@ func enhanceValidationError(err error, v interface{}) error {
 		enhanced += "  - run (for prompts)\n"
 		enhanced += "  - loop (for child workflows)\n"
 		enhanced += "  - rag (for retrieval)\n"
+		enhanced += "  - sql (for database queries)\n"
+		enhanced += "  - storage (for S3/Azure Blob/GCS get/put)\n"
+		enhanced += "  - notify (for email/Slack/Teams notifications)\n"
+		enhanced += "  - log_analytics (for Sentinel/Log Analytics ingestion)\n"
+		enhanced += "  - graph_security (for Microsoft Graph incidents/alerts)\n"
+		enhanced += "  - load_table (for CSV/XLSX files)\n"
+		enhanced += "  - render (for HTML/PDF reports)\n"
+		enhanced += "  - tts (for text-to-speech audio)\n"
+		enhanced += "  - compare (for A/B comparison across models)\n"
+		enhanced += "  - guard (for output policy checks)\n"
+		enhanced += "  - verify (for build/test commands)\n"
 		enhanced += "  - template, consensus, embeddings\n"
 		enhanced += "  - provider, model (overrides)\n"
 		enhanced += "  - servers, skills\n"
@@ -112,7 +123,8 @@ func enhanceValidationError(err error, v interface{}) error {
 
 // Loader handles loading configuration files in both monolithic and modular formats
 type Loader struct {
-	baseDir string
+	baseDir  string
+	warnings []string
 }
 
 // NewLoader creates a new config loader
@@ -120,6 +132,52 @@ func NewLoader() *Loader {
 	return &Loader{}
 }
 
+// Warnings returns non-fatal issues found by the last Load, such as
+// workflows migrated from an older spec_version or deprecated values
+func (l *Loader) Warnings() []string {
+	return l.warnings
+}
+
+// ConfigFiles returns the main config file followed by every file matched by
+// its include patterns (providers, servers, workflows, ...), without loading them
+func (l *Loader) ConfigFiles(path string) ([]string, error) {
+	l.baseDir = filepath.Dir(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var mainConfig MainConfigFile
+	if err := yaml.Unmarshal(data, &mainConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	files := []string{path}
+	if mainConfig.Includes == nil {
+		return files, nil
+	}
+
+	inc := mainConfig.Includes
+	seen := map[string]bool{}
+	for _, pattern := range []string{inc.Settings, inc.Providers, inc.Servers, inc.RunAs, inc.Embeddings, inc.Templates, inc.Workflows, inc.RAG, inc.Skills, inc.Prompts, inc.Agents} {
+		if pattern == "" {
+			continue
+		}
+		matches, err := l.glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
 // IncludeDirectives specifies file patterns to include for modular config
 type IncludeDirectives struct {
 	Providers  string `yaml:"providers,omitempty"`  // e.g., "config/providers/*.yaml"
@@ -131,6 +189,8 @@ type IncludeDirectives struct {
 	Settings   string `yaml:"settings,omitempty"`   // e.g., "config/settings.yaml"
 	RAG        string `yaml:"rag,omitempty"`        // e.g., "config/rag/*.yaml"
 	Skills     string `yaml:"skills,omitempty"`     // e.g., "config/skills/*.yaml"
+	Prompts    string `yaml:"prompts,omitempty"`    // e.g., "config/prompts/*.yaml"
+	Agents     string `yaml:"agents,omitempty"`     // e.g., "config/agents/*.yaml"
 }
 
 // MainConfigFile represents the main config file with optional includes
@@ -146,6 +206,7 @@ type MainConfigFile struct {
 func (l *Loader) Load(path string) (*ApplicationConfig, error) {
 	// Set base directory for relative path resolution
 	l.baseDir = filepath.Dir(path)
+	l.warnings = nil
 
 	// Read main config file
 	data, err := os.ReadFile(path)
@@ -232,11 +293,23 @@ func (l *Loader) loadSettings(pattern string, result *ApplicationConfig) error {
 
 	// Parse settings into a temporary struct
 	var settings struct {
-		AI         *AIConfig         `yaml:"ai,omitempty"`
-		Embeddings *EmbeddingsConfig `yaml:"embeddings,omitempty"`
-		Chat       *ChatConfig       `yaml:"chat,omitempty"`
-		Skills     *SkillsConfig     `yaml:"skills,omitempty"`
-		RAG        *RagConfig        `yaml:"rag,omitempty"`
+		AI             *AIConfig             `yaml:"ai,omitempty"`
+		Embeddings     *EmbeddingsConfig     `yaml:"embeddings,omitempty"`
+		Chat           *ChatConfig           `yaml:"chat,omitempty"`
+		Skills         *SkillsConfig         `yaml:"skills,omitempty"`
+		RAG            *RagConfig            `yaml:"rag,omitempty"`
+		Storage        *StorageConfig        `yaml:"storage,omitempty"`
+		Notifications  *NotificationsConfig  `yaml:"notifications,omitempty"`
+		LogAnalytics   *LogAnalyticsConfig   `yaml:"log_analytics,omitempty"`
+		Graph          *GraphConfig          `yaml:"graph,omitempty"`
+		TTS            *TTSConfig            `yaml:"tts,omitempty"`
+		Routing        *RoutingConfig        `yaml:"routing,omitempty"`
+		Judges         *JudgesConfig         `yaml:"judges,omitempty"`
+		BuiltinTools   *BuiltinToolsConfig   `yaml:"builtin_tools,omitempty"`
+		ToolSelection  *ToolSelectionConfig  `yaml:"tool_selection,omitempty"`
+		ToolIterations *ToolIterationsConfig `yaml:"tool_iterations,omitempty"`
+		CostEstimate   *CostEstimateConfig   `yaml:"cost_estimate,omitempty"`
+		Roots          []RootConfig          `yaml:"roots,omitempty"`
 	}
 
 	if err := unmarshalStrict(data, &settings); err != nil {
@@ -248,6 +321,18 @@ func (l *Loader) loadSettings(pattern string, result *ApplicationConfig) error {
 	result.Embeddings = settings.Embeddings
 	result.Chat = settings.Chat
 	result.Skills = settings.Skills
+	result.Storage = settings.Storage
+	result.Notifications = settings.Notifications
+	result.LogAnalytics = settings.LogAnalytics
+	result.Graph = settings.Graph
+	result.TTS = settings.TTS
+	result.Routing = settings.Routing
+	result.Judges = settings.Judges
+	result.BuiltinTools = settings.BuiltinTools
+	result.ToolSelection = settings.ToolSelection
+	result.ToolIterations = settings.ToolIterations
+	result.CostEstimate = settings.CostEstimate
+	result.Roots = settings.Roots
 	if settings.RAG != nil {
 		if result.RAG == nil {
 			result.RAG = settings.RAG
@@ -300,6 +385,20 @@ func (l *Loader) loadIncludes(includes *IncludeDirectives, result *ApplicationCo
 		}
 	}
 
+	// Load prompt templates
+	if includes.Prompts != "" {
+		if err := l.loadPrompts(includes.Prompts, result); err != nil {
+			return fmt.Errorf("failed to load prompts: %w", err)
+		}
+	}
+
+	// Load agents, before the workflows whose steps use them
+	if includes.Agents != "" {
+		if err := l.loadAgents(includes.Agents, result); err != nil {
+			return fmt.Errorf("failed to load agents: %w", err)
+		}
+	}
+
 	// Load workflows (new v2.0 system)
 	if includes.Workflows != "" {
 		if err := l.loadWorkflows(includes.Workflows, result); err != nil {
@@ -506,11 +605,21 @@ func (l *Loader) loadWorkflows(pattern string, result *ApplicationConfig) error
 			continue
 		}
 
-		// Parse and validate using workflow loader
-		workflow, err := workflowLoader.LoadFromBytes(data)
+		// Parse and validate using workflow loader (older spec versions are migrated in memory)
+		workflow, migration, err := workflowLoader.LoadFromBytesWithMigration(data)
 		if err != nil {
 			return fmt.Errorf("failed to load workflow from %s: %w", file, err)
 		}
+		if err := workflow.ApplyAgents(result.Agents); err != nil {
+			return fmt.Errorf("failed to load workflow from %s: %w", file, err)
+		}
+		if migration.FromVersion != migration.ToVersion && len(migration.Notes) > 0 {
+			l.warnings = append(l.warnings, fmt.Sprintf("%s: migrated from spec_version %s to %s (run 'mcp-cli workflow migrate --write' to update the file)",
+				file, migration.FromVersion, migration.ToVersion))
+		}
+		for _, warning := range migration.Warnings {
+			l.warnings = append(l.warnings, fmt.Sprintf("%s: %s", file, warning))
+		}
 
 		// Calculate relative path from base workflow directory
 		relPath, err := filepath.Rel(baseWorkflowDir, file)
@@ -627,6 +736,27 @@ type WorkflowLoader struct{}
 
 // LoadFromBytes loads a workflow from bytes
 func (wl *WorkflowLoader) LoadFromBytes(data []byte) (*WorkflowV2, error) {
+	workflow, _, err := wl.LoadFromBytesWithMigration(data)
+	return workflow, err
+}
+
+// LoadFromBytesWithMigration migrates the document to CurrentSpecVersion
+// before parsing and returns the migration report alongside the workflow
+func (wl *WorkflowLoader) LoadFromBytesWithMigration(data []byte) (*WorkflowV2, *MigrationResult, error) {
+	data, migration, err := MigrateWorkflow(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workflow, err := wl.parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return workflow, migration, nil
+}
+
+// parse decodes and checks the required fields of a current-spec workflow
+func (wl *WorkflowLoader) parse(data []byte) (*WorkflowV2, error) {
 	var workflow WorkflowV2
 	if err := unmarshalStrict(data, &workflow); err != nil {
 		return nil, fmt.Errorf("failed to parse workflow: %w", err)
@@ -646,6 +776,65 @@ func (wl *WorkflowLoader) LoadFromBytes(data []byte) (*WorkflowV2, error) {
 	return &workflow, nil
 }
 
+// loadPrompts loads prompt templates, one name@version per file
+func (l *Loader) loadPrompts(pattern string, result *ApplicationConfig) error {
+	files, err := l.glob(pattern)
+	if err != nil {
+		return err
+	}
+
+	result.Prompts = NewPromptLibrary()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt file %s: %w", file, err)
+		}
+
+		var prompt PromptTemplate
+		if err := unmarshalStrict(data, &prompt); err != nil {
+			return fmt.Errorf("failed to parse prompt file %s: %w", file, err)
+		}
+		prompt.Source = file
+
+		if err := result.Prompts.Add(&prompt); err != nil {
+			return fmt.Errorf("invalid prompt file %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// loadAgents loads shared agent definitions, one agent per file
+func (l *Loader) loadAgents(pattern string, result *ApplicationConfig) error {
+	files, err := l.glob(pattern)
+	if err != nil {
+		return err
+	}
+
+	result.Agents = make(map[string]*AgentConfig)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read agent file %s: %w", file, err)
+		}
+
+		var agent AgentConfig
+		if err := unmarshalStrict(data, &agent); err != nil {
+			return fmt.Errorf("failed to parse agent file %s: %w", file, err)
+		}
+		if err := agent.Validate(); err != nil {
+			return fmt.Errorf("invalid agent file %s: %w", file, err)
+		}
+		if existing, exists := result.Agents[agent.Name]; exists {
+			return fmt.Errorf("agent %s in %s is already defined in %s", agent.Name, file, existing.Source)
+		}
+		agent.Source = file
+		result.Agents[agent.Name] = &agent
+	}
+
+	return nil
+}
+
 // loadRAG loads RAG server configurations from pattern
 func (l *Loader) loadRAG(pattern string, result *ApplicationConfig) error {
 	files, err := l.glob(pattern)
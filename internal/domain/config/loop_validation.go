@@ -64,8 +64,11 @@ func (l *LoopV2) Validate() error {
 			return fmt.Errorf("iterate mode requires 'items' field")
 		}
 	} else if l.Mode == "refine" {
-		if l.Until == "" {
-			return fmt.Errorf("refine mode requires 'until' condition")
+		if l.Until == "" && l.UntilLLM == "" {
+			return fmt.Errorf("refine mode requires an 'until' or 'until_llm' condition")
+		}
+		if l.Until != "" && l.UntilLLM != "" {
+			return fmt.Errorf("'until' and 'until_llm' are mutually exclusive")
 		}
 	}
 
@@ -94,7 +97,7 @@ func (l *LoopV2) Validate() error {
 		return fmt.Errorf("on_failure must be 'halt', 'continue', or 'retry', got '%s'", l.OnFailure)
 	}
 
-	return nil
+	return validateCombine(l.Mode, l.Combine)
 }
 
 // Validate validates the LoopMode configuration
@@ -115,8 +118,11 @@ func (l *LoopMode) Validate() error {
 			return fmt.Errorf("iterate mode requires 'items' field")
 		}
 	} else if l.Mode == "refine" {
-		if l.Until == "" {
-			return fmt.Errorf("refine mode requires 'until' condition")
+		if l.Until == "" && l.UntilLLM == "" {
+			return fmt.Errorf("refine mode requires an 'until' or 'until_llm' condition")
+		}
+		if l.Until != "" && l.UntilLLM != "" {
+			return fmt.Errorf("'until' and 'until_llm' are mutually exclusive")
 		}
 	}
 
@@ -145,5 +151,26 @@ func (l *LoopMode) Validate() error {
 		return fmt.Errorf("on_failure must be 'halt', 'continue', or 'retry', got '%s'", l.OnFailure)
 	}
 
-	return nil
+	return validateCombine(l.Mode, l.Combine)
+}
+
+// Loop combine strategies
+const (
+	LoopCombineLast      = "last"      // The last successful item's output
+	LoopCombineSummarize = "summarize" // A model reduces all item outputs to one
+	LoopCombineVote      = "vote"      // The most common, or most similar, item output
+)
+
+// validateCombine checks a loop's combine strategy
+func validateCombine(mode, combine string) error {
+	switch combine {
+	case "", LoopCombineLast:
+		return nil
+	case LoopCombineSummarize, LoopCombineVote:
+		if mode != "iterate" {
+			return fmt.Errorf("combine: %s requires iterate mode", combine)
+		}
+		return nil
+	}
+	return fmt.Errorf("combine must be 'last', 'summarize' or 'vote', got '%s'", combine)
 }
@@ -82,7 +82,29 @@ func TestLoopV2_Validate_RefineMode(t *testing.T) {
 				MaxIterations: 5,
 			},
 			wantErr: true,
-			errMsg:  "refine mode requires 'until' condition",
+			errMsg:  "refine mode requires an 'until' or 'until_llm' condition",
+		},
+		{
+			name: "valid refine mode with until_llm",
+			loop: LoopV2{
+				Mode:          "refine",
+				UntilLLM:      "Review says PASS",
+				Workflow:      "improve_code",
+				MaxIterations: 5,
+			},
+			wantErr: false,
+		},
+		{
+			name: "refine mode with both conditions",
+			loop: LoopV2{
+				Mode:          "refine",
+				Until:         "output.score >= 8",
+				UntilLLM:      "Review says PASS",
+				Workflow:      "improve_code",
+				MaxIterations: 5,
+			},
+			wantErr: true,
+			errMsg:  "'until' and 'until_llm' are mutually exclusive",
 		},
 		{
 			name: "defaults to refine mode",
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSpecVersion is the newest workflow spec_version this build understands.
+// Workflows without spec_version are treated as BaselineSpecVersion.
+const (
+	CurrentSpecVersion  = "2.1"
+	BaselineSpecVersion = "2.0"
+)
+
+// workflowMigration upgrades a workflow document from one spec_version to the
+// next. Migrate edits the YAML node tree in place (preserving comments and key
+// order for write-back) and returns a note for each change it made.
+type workflowMigration struct {
+	From    string
+	To      string
+	Migrate func(root *yaml.Node) []string
+}
+
+// workflowMigrations must stay ordered and contiguous from BaselineSpecVersion
+// to CurrentSpecVersion. When the WorkflowV2 schema changes incompatibly, bump
+// CurrentSpecVersion and append a migration here.
+var workflowMigrations = []workflowMigration{
+	{From: "2.0", To: "2.1", Migrate: migrateWorkflow20To21},
+}
+
+// deprecatedValues lists values that still load but will be removed.
+// Keys are field names; the map maps a deprecated value to its replacement.
+var deprecatedValues = map[string]map[string]string{
+	"logging": {"noisy": "verbose"},
+}
+
+// MigrationResult describes what MigrateWorkflow did to a document
+type MigrationResult struct {
+	FromVersion string   // spec_version found in the file (or the baseline if absent)
+	ToVersion   string   // spec_version after migration
+	Notes       []string // Changes applied by migrations
+	Warnings    []string // Deprecated fields or values that remain in the file
+}
+
+// Migrated reports whether the document was changed
+func (r *MigrationResult) Migrated() bool {
+	return r.FromVersion != r.ToVersion || len(r.Notes) > 0
+}
+
+// MigrateWorkflow upgrades a workflow YAML document to CurrentSpecVersion.
+// It returns the (possibly unchanged) document bytes and a report. Documents
+// declaring a spec_version newer than this build supports are rejected.
+func MigrateWorkflow(data []byte) ([]byte, *MigrationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		// Let the strict decoder produce the detailed parse error
+		return data, &MigrationResult{FromVersion: BaselineSpecVersion, ToVersion: BaselineSpecVersion}, nil
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, &MigrationResult{FromVersion: BaselineSpecVersion, ToVersion: BaselineSpecVersion}, nil
+	}
+	root := doc.Content[0]
+
+	version := BaselineSpecVersion
+	if node := mappingValue(root, "spec_version"); node != nil && node.Value != "" {
+		version = node.Value
+	}
+	result := &MigrationResult{FromVersion: version, ToVersion: version}
+
+	cmp, err := compareSpecVersions(version, CurrentSpecVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cmp > 0 {
+		return nil, nil, fmt.Errorf("workflow spec_version %s is newer than this mcp-cli supports (%s); upgrade mcp-cli", version, CurrentSpecVersion)
+	}
+
+	for _, m := range workflowMigrations {
+		if result.ToVersion != m.From {
+			continue
+		}
+		for _, note := range m.Migrate(root) {
+			result.Notes = append(result.Notes, fmt.Sprintf("%s→%s: %s", m.From, m.To, note))
+		}
+		result.ToVersion = m.To
+	}
+
+	if result.ToVersion != CurrentSpecVersion {
+		return nil, nil, fmt.Errorf("no migration path from workflow spec_version %s to %s", version, CurrentSpecVersion)
+	}
+
+	result.Warnings = findDeprecations(root, "")
+
+	if !result.Migrated() {
+		return data, result, nil
+	}
+
+	setMappingScalar(root, "spec_version", CurrentSpecVersion, "name")
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to re-encode migrated workflow: %w", err)
+	}
+	enc.Close()
+	return buf.Bytes(), result, nil
+}
+
+// migrateWorkflow20To21 replaces the legacy "noisy" log level with "verbose"
+// and records spec_version.
+func migrateWorkflow20To21(root *yaml.Node) []string {
+	var notes []string
+
+	rewrite := func(node *yaml.Node, path string) {
+		if logging := mappingValue(node, "logging"); logging != nil && logging.Value == "noisy" {
+			logging.Value = "verbose"
+			notes = append(notes, fmt.Sprintf("%slogging: noisy → verbose", path))
+		}
+	}
+
+	if exec := mappingValue(root, "execution"); exec != nil {
+		rewrite(exec, "execution.")
+	}
+	if steps := mappingValue(root, "steps"); steps != nil && steps.Kind == yaml.SequenceNode {
+		for _, step := range steps.Content {
+			name := "?"
+			if n := mappingValue(step, "name"); n != nil {
+				name = n.Value
+			}
+			rewrite(step, fmt.Sprintf("steps[%s].", name))
+		}
+	}
+
+	return notes
+}
+
+// findDeprecations walks the document and reports deprecated values still present
+func findDeprecations(node *yaml.Node, path string) []string {
+	var warnings []string
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+			if replacements, ok := deprecatedValues[key.Value]; ok && value.Kind == yaml.ScalarNode {
+				if replacement, deprecated := replacements[value.Value]; deprecated {
+					warnings = append(warnings, fmt.Sprintf("%s: %q is deprecated, use %q (line %d)", childPath, value.Value, replacement, value.Line))
+				}
+			}
+			warnings = append(warnings, findDeprecations(value, childPath)...)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			warnings = append(warnings, findDeprecations(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+
+	return warnings
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingScalar sets key to a string value, inserting it after the key
+// named after (or at the end) when absent
+func setMappingScalar(node *yaml.Node, key, value, after string) {
+	if existing := mappingValue(node, key); existing != nil {
+		existing.Kind = yaml.ScalarNode
+		existing.Tag = "!!str"
+		existing.Style = yaml.DoubleQuotedStyle
+		existing.Value = value
+		return
+	}
+
+	pair := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle},
+	}
+
+	insertAt := len(node.Content)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == after {
+			insertAt = i + 2
+			break
+		}
+	}
+	node.Content = append(node.Content[:insertAt], append(pair, node.Content[insertAt:]...)...)
+}
+
+// compareSpecVersions compares "major.minor" versions, returning -1, 0 or 1
+func compareSpecVersions(a, b string) (int, error) {
+	pa, err := parseSpecVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := parseSpecVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseSpecVersion(v string) ([2]int, error) {
+	var parsed [2]int
+	parts := strings.Split(v, ".")
+	if len(parts) != 2 {
+		return parsed, fmt.Errorf("invalid spec_version %q (expected major.minor, e.g. %s)", v, CurrentSpecVersion)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("invalid spec_version %q (expected major.minor, e.g. %s)", v, CurrentSpecVersion)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
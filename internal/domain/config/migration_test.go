@@ -0,0 +1,83 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const legacyWorkflow = `$schema: "workflow/v2.0"
+name: legacy
+version: "1.0.0"
+# execution defaults
+execution:
+  provider: ollama
+  logging: noisy
+steps:
+  - name: first
+    run: "Hello"
+    logging: noisy
+`
+
+func TestMigrateWorkflowFromBaseline(t *testing.T) {
+	migrated, result, err := MigrateWorkflow([]byte(legacyWorkflow))
+	if err != nil {
+		t.Fatalf("MigrateWorkflow() error: %v", err)
+	}
+
+	if result.FromVersion != BaselineSpecVersion || result.ToVersion != CurrentSpecVersion {
+		t.Errorf("versions = %s→%s, want %s→%s", result.FromVersion, result.ToVersion, BaselineSpecVersion, CurrentSpecVersion)
+	}
+	if len(result.Notes) != 2 {
+		t.Errorf("notes = %v, want execution and step logging rewrites", result.Notes)
+	}
+
+	out := string(migrated)
+	if strings.Contains(out, "noisy") {
+		t.Errorf("migrated document still contains noisy:\n%s", out)
+	}
+	if !strings.Contains(out, `spec_version: "`+CurrentSpecVersion+`"`) {
+		t.Errorf("spec_version not recorded:\n%s", out)
+	}
+	if !strings.Contains(out, "# execution defaults") {
+		t.Errorf("comments should survive migration:\n%s", out)
+	}
+
+	wf, err := NewWorkflowLoader().LoadFromBytes([]byte(legacyWorkflow))
+	if err != nil {
+		t.Fatalf("LoadFromBytes() error: %v", err)
+	}
+	if wf.Execution.Logging != "verbose" || wf.SpecVersion != CurrentSpecVersion {
+		t.Errorf("loaded workflow not migrated: logging=%q spec_version=%q", wf.Execution.Logging, wf.SpecVersion)
+	}
+}
+
+func TestMigrateWorkflowCurrentUnchanged(t *testing.T) {
+	doc := "$schema: \"workflow/v2.0\"\nname: x\nversion: \"1\"\nspec_version: \"" + CurrentSpecVersion + "\"\nsteps:\n  - name: a\n    run: hi\n"
+	migrated, result, err := MigrateWorkflow([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Migrated() || string(migrated) != doc {
+		t.Error("current-spec workflow should be returned unchanged")
+	}
+}
+
+func TestMigrateWorkflowDeprecationWarning(t *testing.T) {
+	doc := "name: x\nspec_version: \"" + CurrentSpecVersion + "\"\nexecution:\n  logging: noisy\n"
+	_, result, err := MigrateWorkflow([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "execution.logging") {
+		t.Errorf("warnings = %v, want one for execution.logging", result.Warnings)
+	}
+}
+
+func TestMigrateWorkflowRejectsUnsupportedVersions(t *testing.T) {
+	for _, version := range []string{"9.0", "2.99", "two", "2"} {
+		doc := "name: x\nspec_version: \"" + version + "\"\n"
+		if _, _, err := MigrateWorkflow([]byte(doc)); err == nil {
+			t.Errorf("spec_version %q should be rejected", version)
+		}
+	}
+}
@@ -29,7 +29,7 @@ func (g *ModularConfigGenerator) Generate(config *GeneratorConfig) error {
 	}
 
 	// Create subdirectories
-	dirs := []string{"providers", "embeddings", "servers", "workflows", "runasMCP", "proxy"}
+	dirs := []string{"providers", "embeddings", "servers", "workflows", "prompts", "agents", "runasMCP", "proxy"}
 	for _, dir := range dirs {
 		path := filepath.Join(g.baseDir, dir)
 		if err := os.MkdirAll(path, 0755); err != nil {
@@ -113,6 +113,8 @@ func (g *ModularConfigGenerator) createMainConfig(config *GeneratorConfig) error
 			Templates:  filepath.Join(configDirName, "templates/*.yaml"),
 			Workflows:  filepath.Join(configDirName, "workflows/*.yaml"),
 			RAG:        filepath.Join(configDirName, "rag/*.yaml"),
+			Prompts:    filepath.Join(configDirName, "prompts/*.yaml"),
+			Agents:     filepath.Join(configDirName, "agents/*.yaml"),
 			Settings:   filepath.Join(configDirName, "settings.yaml"),
 		},
 	}
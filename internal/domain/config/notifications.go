@@ -0,0 +1,52 @@
+package config
+
+// Notification channel types
+const (
+	NotificationSMTP         = "smtp"
+	NotificationSlackWebhook = "slack_webhook"
+	NotificationTeamsWebhook = "teams_webhook"
+)
+
+// NotificationsConfig represents notification configuration (settings.yaml `notifications:` section)
+type NotificationsConfig struct {
+	DefaultChannel string                               `yaml:"default_channel,omitempty"` // Channel used when a step doesn't name one
+	Channels       map[string]NotificationChannelConfig `yaml:"channels,omitempty"`        // Named notification channels
+}
+
+// NotificationChannelConfig defines a single notification channel
+type NotificationChannelConfig struct {
+	Type string `yaml:"type"` // smtp, slack_webhook, teams_webhook
+
+	// Webhook settings (slack_webhook, teams_webhook)
+	WebhookURL string `yaml:"webhook_url,omitempty"`
+
+	// SMTP settings
+	Host     string   `yaml:"host,omitempty"`
+	Port     int      `yaml:"port,omitempty"` // default: 587
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from,omitempty"`
+	To       []string `yaml:"to,omitempty"`       // Default recipients
+	TLS      bool     `yaml:"tls,omitempty"`      // Implicit TLS (port 465) instead of STARTTLS
+	Insecure bool     `yaml:"insecure,omitempty"` // Skip TLS certificate verification
+
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+}
+
+// GetChannel returns a named channel, falling back to the default channel
+func (c *NotificationsConfig) GetChannel(name string) (*NotificationChannelConfig, string, bool) {
+	if c == nil || c.Channels == nil {
+		return nil, "", false
+	}
+
+	if name == "" {
+		name = c.DefaultChannel
+	}
+
+	channel, exists := c.Channels[name]
+	if !exists {
+		return nil, name, false
+	}
+
+	return &channel, name, true
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxMatrixRuns caps the combinations a matrix may expand to
+const MaxMatrixRuns = 256
+
+// MatrixConfig expands a workflow into one sub-run per parameter combination
+type MatrixConfig struct {
+	Params     map[string][]interface{} `yaml:"params"`                // Values per parameter; every combination runs
+	Include    []map[string]interface{} `yaml:"include,omitempty"`     // Extra combinations to run
+	Exclude    []map[string]interface{} `yaml:"exclude,omitempty"`     // Combinations to skip (matched on the keys given)
+	MaxWorkers int                      `yaml:"max_workers,omitempty"` // Concurrent sub-runs (default: 3)
+	FailFast   bool                     `yaml:"fail_fast,omitempty"`   // Cancel remaining sub-runs after the first failure
+}
+
+// ResolveParams merges caller values over the declared defaults of a
+// workflow's params, checking declared types and enums. Values for
+// undeclared params are passed through unchecked.
+func ResolveParams(params map[string]PromptParameter, values map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(params)+len(values))
+	for name, value := range values {
+		resolved[name] = value
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		param := params[name]
+		value, ok := resolved[name]
+		if !ok {
+			if param.Default == nil {
+				if param.Required {
+					return nil, fmt.Errorf("workflow param '%s' is required", name)
+				}
+				continue
+			}
+			value = formatPromptValue(param.Default)
+			resolved[name] = value
+		}
+		if err := param.check(value); err != nil {
+			return nil, fmt.Errorf("workflow param '%s': %w", name, err)
+		}
+	}
+	return resolved, nil
+}
+
+// Combinations expands the matrix into parameter sets: the cartesian
+// product of params (names sorted) minus exclusions, followed by includes
+func (m *MatrixConfig) Combinations() ([]map[string]string, error) {
+	names := make([]string, 0, len(m.Params))
+	for name := range m.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	total := 1
+	for _, name := range names {
+		if len(m.Params[name]) == 0 {
+			return nil, fmt.Errorf("matrix param '%s' has no values", name)
+		}
+		total *= len(m.Params[name])
+		if total > MaxMatrixRuns {
+			return nil, fmt.Errorf("matrix expands to more than %d runs", MaxMatrixRuns)
+		}
+	}
+
+	var combinations []map[string]string
+	if len(names) > 0 {
+		combinations = []map[string]string{{}}
+		for _, name := range names {
+			expanded := make([]map[string]string, 0, len(combinations)*len(m.Params[name]))
+			for _, combination := range combinations {
+				for _, value := range m.Params[name] {
+					next := make(map[string]string, len(combination)+1)
+					for k, v := range combination {
+						next[k] = v
+					}
+					next[name] = formatPromptValue(value)
+					expanded = append(expanded, next)
+				}
+			}
+			combinations = expanded
+		}
+	}
+
+	kept := combinations[:0]
+	for _, combination := range combinations {
+		if !m.excluded(combination) {
+			kept = append(kept, combination)
+		}
+	}
+	combinations = kept
+
+	for _, include := range m.Include {
+		combination := make(map[string]string, len(include))
+		for k, v := range include {
+			combination[k] = formatPromptValue(v)
+		}
+		combinations = append(combinations, combination)
+	}
+
+	if len(combinations) == 0 {
+		return nil, fmt.Errorf("matrix has no combinations to run")
+	}
+	if len(combinations) > MaxMatrixRuns {
+		return nil, fmt.Errorf("matrix expands to more than %d runs", MaxMatrixRuns)
+	}
+	return combinations, nil
+}
+
+// excluded reports whether a combination matches any exclude entry
+func (m *MatrixConfig) excluded(combination map[string]string) bool {
+	for _, exclude := range m.Exclude {
+		if len(exclude) == 0 {
+			continue
+		}
+		matched := true
+		for k, v := range exclude {
+			if combination[k] != formatPromptValue(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// MatrixLabel renders a combination as "k1=v1, k2=v2" with keys sorted
+func MatrixLabel(combination map[string]string) string {
+	keys := make([]string, 0, len(combination))
+	for k := range combination {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + combination[k]
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ApplyExecutionParams overrides execution defaults from params named
+// provider, model, temperature or max_tokens, so a matrix can vary the model
+// settings of each run. Steps that set these fields themselves keep them.
+func ApplyExecutionParams(exec *ExecutionContext, params map[string]string) error {
+	provider, hasProvider := params["provider"]
+	model, hasModel := params["model"]
+	if hasProvider || hasModel {
+		if !hasProvider {
+			provider = exec.Provider
+			if provider == "" && len(exec.Providers) > 0 {
+				provider = exec.Providers[0].Provider
+			}
+		}
+		if !hasModel {
+			model = exec.Model
+		}
+		exec.Provider = provider
+		exec.Model = model
+		exec.Providers = nil
+	}
+
+	if value, ok := params["temperature"]; ok {
+		temperature, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("param 'temperature': expected a number, got '%s'", value)
+		}
+		exec.Temperature = temperature
+	}
+	if value, ok := params["max_tokens"]; ok {
+		maxTokens, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("param 'max_tokens': expected an integer, got '%s'", value)
+		}
+		exec.MaxTokens = maxTokens
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveParams(t *testing.T) {
+	params := map[string]PromptParameter{
+		"dataset": {Required: true},
+		"tone":    {Enum: []string{"formal", "casual"}, Default: "formal"},
+		"samples": {Type: PromptParamInteger, Default: 10},
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:   "defaults fill gaps",
+			values: map[string]string{"dataset": "a.csv"},
+			want:   map[string]string{"dataset": "a.csv", "tone": "formal", "samples": "10"},
+		},
+		{
+			name:   "values override defaults and undeclared pass through",
+			values: map[string]string{"dataset": "b.csv", "tone": "casual", "extra": "x"},
+			want:   map[string]string{"dataset": "b.csv", "tone": "casual", "samples": "10", "extra": "x"},
+		},
+		{name: "missing required", values: nil, wantErr: "'dataset' is required"},
+		{name: "enum", values: map[string]string{"dataset": "a", "tone": "loud"}, wantErr: "'tone'"},
+		{name: "type", values: map[string]string{"dataset": "a", "samples": "many"}, wantErr: "expected an integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveParams(params, tt.values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveParams() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveParams() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ResolveParams() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("ResolveParams()[%s] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestMatrixConfig_Combinations(t *testing.T) {
+	matrix := &MatrixConfig{
+		Params: map[string][]interface{}{
+			"model":       {"gpt-4o", "claude"},
+			"temperature": {0.2, 0.8},
+		},
+		Exclude: []map[string]interface{}{{"model": "claude", "temperature": 0.8}},
+		Include: []map[string]interface{}{{"model": "llama", "temperature": 0}},
+	}
+
+	combinations, err := matrix.Combinations()
+	if err != nil {
+		t.Fatalf("Combinations() error = %v", err)
+	}
+
+	var labels []string
+	for _, combination := range combinations {
+		labels = append(labels, MatrixLabel(combination))
+	}
+	want := []string{
+		"model=gpt-4o, temperature=0.2",
+		"model=gpt-4o, temperature=0.8",
+		"model=claude, temperature=0.2",
+		"model=llama, temperature=0",
+	}
+	if strings.Join(labels, "; ") != strings.Join(want, "; ") {
+		t.Errorf("Combinations() = %v, want %v", labels, want)
+	}
+}
+
+func TestMatrixConfig_CombinationsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix MatrixConfig
+	}{
+		{name: "empty values", matrix: MatrixConfig{Params: map[string][]interface{}{"model": {}}}},
+		{name: "everything excluded", matrix: MatrixConfig{
+			Params:  map[string][]interface{}{"model": {"a"}},
+			Exclude: []map[string]interface{}{{"model": "a"}},
+		}},
+		{name: "too many runs", matrix: MatrixConfig{Params: map[string][]interface{}{
+			"a": make([]interface{}, 20),
+			"b": make([]interface{}, 20),
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.matrix.Combinations(); err == nil {
+				t.Error("Combinations() expected error")
+			}
+		})
+	}
+}
+
+func TestApplyExecutionParams(t *testing.T) {
+	exec := ExecutionContext{
+		Providers:   []ProviderFallback{{Provider: "openai", Model: "gpt-4o"}, {Provider: "ollama", Model: "llama"}},
+		Temperature: 0.7,
+	}
+
+	err := ApplyExecutionParams(&exec, map[string]string{"model": "gpt-4o-mini", "temperature": "0.1", "max_tokens": "500", "tone": "formal"})
+	if err != nil {
+		t.Fatalf("ApplyExecutionParams() error = %v", err)
+	}
+	if exec.Provider != "openai" || exec.Model != "gpt-4o-mini" || exec.Providers != nil {
+		t.Errorf("provider/model = %s/%s (providers %v), want openai/gpt-4o-mini and no chain", exec.Provider, exec.Model, exec.Providers)
+	}
+	if exec.Temperature != 0.1 || exec.MaxTokens != 500 {
+		t.Errorf("temperature/max_tokens = %v/%d, want 0.1/500", exec.Temperature, exec.MaxTokens)
+	}
+
+	if err := ApplyExecutionParams(&exec, map[string]string{"temperature": "hot"}); err == nil {
+		t.Error("ApplyExecutionParams() expected error for non-numeric temperature")
+	}
+}
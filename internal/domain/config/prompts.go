@@ -0,0 +1,318 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Prompt parameter types
+const (
+	PromptParamString  = "string"
+	PromptParamNumber  = "number"
+	PromptParamInteger = "integer"
+	PromptParamBoolean = "boolean"
+	PromptParamArray   = "array"
+	PromptParamObject  = "object"
+)
+
+// DefaultPromptVersion is assigned to prompt templates that don't declare one
+const DefaultPromptVersion = "v1"
+
+// PromptTemplate is a named, versioned prompt (config/prompts/*.yaml or a
+// workflow's prompts: section). Parameters are referenced in the template as
+// {{name}}; any other {{variables}} are left for workflow interpolation.
+type PromptTemplate struct {
+	Name        string                     `yaml:"name"`
+	Version     string                     `yaml:"version,omitempty"` // e.g. v2 or 1.3.0 (default: v1)
+	Description string                     `yaml:"description,omitempty"`
+	Parameters  map[string]PromptParameter `yaml:"parameters,omitempty"`
+	Template    string                     `yaml:"template"`
+
+	Source string `yaml:"-"` // File the prompt was loaded from, or "workflow"
+}
+
+// PromptParameter declares a typed template parameter
+type PromptParameter struct {
+	Type        string      `yaml:"type,omitempty"` // string (default), number, integer, boolean, array, object
+	Description string      `yaml:"description,omitempty"`
+	Required    bool        `yaml:"required,omitempty"`
+	Default     interface{} `yaml:"default,omitempty"`
+	Enum        []string    `yaml:"enum,omitempty"` // Allowed values
+}
+
+// Ref returns the name@version reference for the prompt
+func (p *PromptTemplate) Ref() string {
+	return p.Name + "@" + p.Version
+}
+
+// Validate checks the prompt definition
+func (p *PromptTemplate) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("prompt name is required")
+	}
+	if strings.Contains(p.Name, "@") {
+		return fmt.Errorf("prompt name '%s' must not contain '@'", p.Name)
+	}
+	if strings.TrimSpace(p.Template) == "" {
+		return fmt.Errorf("prompt %s: template is required", p.Ref())
+	}
+	for name, param := range p.Parameters {
+		switch param.Type {
+		case "", PromptParamString, PromptParamNumber, PromptParamInteger, PromptParamBoolean, PromptParamArray, PromptParamObject:
+		default:
+			return fmt.Errorf("prompt %s: parameter '%s' has unknown type '%s'", p.Ref(), name, param.Type)
+		}
+		if param.Default != nil {
+			if err := param.check(formatPromptValue(param.Default)); err != nil {
+				return fmt.Errorf("prompt %s: parameter '%s' default: %w", p.Ref(), name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Render substitutes parameter values into the template. Values override
+// parameter defaults; unknown, missing required and mistyped values are errors.
+func (p *PromptTemplate) Render(values map[string]string) (string, error) {
+	for name := range values {
+		if _, declared := p.Parameters[name]; !declared {
+			return "", fmt.Errorf("prompt %s has no parameter '%s'", p.Ref(), name)
+		}
+	}
+
+	resolved := make(map[string]string, len(p.Parameters))
+	for name, param := range p.Parameters {
+		value, ok := values[name]
+		if !ok {
+			if param.Default != nil {
+				value = formatPromptValue(param.Default)
+			} else if param.Required {
+				return "", fmt.Errorf("prompt %s: parameter '%s' is required", p.Ref(), name)
+			}
+		}
+		if ok || param.Default != nil {
+			if err := param.check(value); err != nil {
+				return "", fmt.Errorf("prompt %s: parameter '%s': %w", p.Ref(), name, err)
+			}
+		}
+		resolved[name] = value
+	}
+
+	return promptParamPattern.ReplaceAllStringFunc(p.Template, func(match string) string {
+		name := strings.TrimSpace(match[2 : len(match)-2])
+		if value, ok := resolved[name]; ok {
+			return value
+		}
+		return match
+	}), nil
+}
+
+var promptParamPattern = regexp.MustCompile(`\{\{\s*[a-zA-Z_][a-zA-Z0-9_]*\s*\}\}`)
+
+// check validates a value against the parameter's type and enum
+func (param PromptParameter) check(value string) error {
+	switch param.Type {
+	case PromptParamNumber:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+			return fmt.Errorf("expected a number, got '%s'", value)
+		}
+	case PromptParamInteger:
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("expected an integer, got '%s'", value)
+		}
+	case PromptParamBoolean:
+		if _, err := strconv.ParseBool(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("expected true or false, got '%s'", value)
+		}
+	case PromptParamArray, PromptParamObject:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			return fmt.Errorf("expected a JSON %s: %w", param.Type, err)
+		}
+		if _, isArray := decoded.([]interface{}); isArray != (param.Type == PromptParamArray) {
+			return fmt.Errorf("expected a JSON %s", param.Type)
+		}
+	}
+
+	if len(param.Enum) > 0 {
+		for _, allowed := range param.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("'%s' is not one of %v", value, param.Enum)
+	}
+	return nil
+}
+
+// formatPromptValue converts a YAML value to its template text; lists and
+// maps become JSON
+func formatPromptValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// FormatPromptArgs converts step prompt_args to template values
+func FormatPromptArgs(args map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(args))
+	for name, value := range args {
+		values[name] = formatPromptValue(value)
+	}
+	return values
+}
+
+// ParsePromptRef splits name[@version]; version is empty when omitted
+func ParsePromptRef(ref string) (name, version string) {
+	name, version, _ = strings.Cut(strings.TrimSpace(ref), "@")
+	return name, version
+}
+
+// PromptLibrary holds prompt templates by name and version
+type PromptLibrary struct {
+	prompts map[string]map[string]*PromptTemplate
+}
+
+// NewPromptLibrary creates an empty prompt library
+func NewPromptLibrary() *PromptLibrary {
+	return &PromptLibrary{prompts: make(map[string]map[string]*PromptTemplate)}
+}
+
+// Add registers a prompt; the same name@version may only be defined once
+func (l *PromptLibrary) Add(p *PromptTemplate) error {
+	if p.Version == "" {
+		p.Version = DefaultPromptVersion
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	versions, ok := l.prompts[p.Name]
+	if !ok {
+		versions = make(map[string]*PromptTemplate)
+		l.prompts[p.Name] = versions
+	}
+	if existing, dup := versions[p.Version]; dup {
+		return fmt.Errorf("prompt %s defined twice (%s and %s)", p.Ref(), existing.Source, p.Source)
+	}
+	versions[p.Version] = p
+	return nil
+}
+
+// Get resolves name[@version]. Without a version the highest version is used.
+func (l *PromptLibrary) Get(ref string) (*PromptTemplate, error) {
+	name, version := ParsePromptRef(ref)
+	if l == nil || l.prompts[name] == nil {
+		return nil, fmt.Errorf("prompt '%s' not found (define it in config/prompts/ or the workflow's prompts: section)", name)
+	}
+	versions := l.prompts[name]
+	if version == "" {
+		all := l.Versions(name)
+		return versions[all[len(all)-1]], nil
+	}
+	if p, ok := versions[version]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("prompt '%s' has no version '%s' (available: %s)", name, version, strings.Join(l.Versions(name), ", "))
+}
+
+// Versions returns a prompt's versions, lowest first
+func (l *PromptLibrary) Versions(name string) []string {
+	if l == nil {
+		return nil
+	}
+	var versions []string
+	for version := range l.prompts[name] {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// List returns every prompt sorted by name, then version
+func (l *PromptLibrary) List() []*PromptTemplate {
+	if l == nil {
+		return nil
+	}
+	names := make([]string, 0, len(l.prompts))
+	for name := range l.prompts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var list []*PromptTemplate
+	for _, name := range names {
+		for _, version := range l.Versions(name) {
+			list = append(list, l.prompts[name][version])
+		}
+	}
+	return list
+}
+
+// Overlay returns a library where the given prompts (e.g. a workflow's own
+// prompts: section) take precedence. A name defined in the overlay hides all
+// library versions of that name, so a workflow can pin its own copy.
+func (l *PromptLibrary) Overlay(prompts []PromptTemplate, source string) (*PromptLibrary, error) {
+	if len(prompts) == 0 {
+		return l, nil
+	}
+
+	overlay := NewPromptLibrary()
+	for i := range prompts {
+		p := prompts[i]
+		p.Source = source
+		if err := overlay.Add(&p); err != nil {
+			return nil, err
+		}
+	}
+	if l != nil {
+		for name, versions := range l.prompts {
+			if _, overridden := overlay.prompts[name]; !overridden {
+				overlay.prompts[name] = versions
+			}
+		}
+	}
+	return overlay, nil
+}
+
+// compareVersions orders versions like v2 < v10 and 1.2.0 < 1.10.0, falling
+// back to string comparison for non-numeric parts
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(strings.ToLower(a), "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(strings.ToLower(b), "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		if i >= len(as) {
+			return -1
+		}
+		if i >= len(bs) {
+			return 1
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		if c := strings.Compare(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
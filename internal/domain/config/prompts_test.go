@@ -0,0 +1,102 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestLibrary(t *testing.T) *PromptLibrary {
+	t.Helper()
+	library := NewPromptLibrary()
+	for _, p := range []*PromptTemplate{
+		{Name: "summarize", Version: "v2", Template: "v2: {{text}}"},
+		{Name: "summarize", Version: "v10", Template: "Summarize for {{audience}} in {{bullets}} bullets: {{text}} ({{input}})",
+			Parameters: map[string]PromptParameter{
+				"text":     {Required: true},
+				"audience": {Enum: []string{"exec", "engineer"}, Default: "engineer"},
+				"bullets":  {Type: PromptParamInteger, Default: 5},
+			}},
+		{Name: "summarize", Template: "v1: {{text}}"},
+	} {
+		if err := library.Add(p); err != nil {
+			t.Fatalf("Add(%s) error = %v", p.Name, err)
+		}
+	}
+	return library
+}
+
+func TestPromptLibrary_GetResolvesVersions(t *testing.T) {
+	library := newTestLibrary(t)
+
+	tests := []struct {
+		ref         string
+		wantVersion string
+		wantErr     bool
+	}{
+		{ref: "summarize", wantVersion: "v10"}, // numeric, not lexical, ordering
+		{ref: "summarize@v2", wantVersion: "v2"},
+		{ref: "summarize@v1", wantVersion: "v1"}, // default version
+		{ref: "summarize@v3", wantErr: true},
+		{ref: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			p, err := library.Get(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && p.Version != tt.wantVersion {
+				t.Errorf("Get() version = %s, want %s", p.Version, tt.wantVersion)
+			}
+		})
+	}
+
+	if err := library.Add(&PromptTemplate{Name: "summarize", Version: "v2", Template: "dup"}); err == nil {
+		t.Error("Add() should reject a duplicate name@version")
+	}
+}
+
+func TestPromptTemplate_Render(t *testing.T) {
+	p, _ := newTestLibrary(t).Get("summarize")
+
+	got, err := p.Render(map[string]string{"text": "notes", "audience": "exec"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	// Workflow variables such as {{input}} are left for interpolation
+	if want := "Summarize for exec in 5 bullets: notes ({{input}})"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	for name, values := range map[string]map[string]string{
+		"missing required": {},
+		"unknown param":    {"text": "x", "tone": "dry"},
+		"enum":             {"text": "x", "audience": "board"},
+		"type":             {"text": "x", "bullets": "five"},
+	} {
+		if _, err := p.Render(values); err == nil {
+			t.Errorf("Render() %s: expected error", name)
+		}
+	}
+}
+
+func TestPromptLibrary_OverlayHidesLibraryVersions(t *testing.T) {
+	library := newTestLibrary(t)
+
+	overlay, err := library.Overlay([]PromptTemplate{{Name: "summarize", Version: "v1", Template: "local {{text}}"}}, "workflow")
+	if err != nil {
+		t.Fatalf("Overlay() error = %v", err)
+	}
+
+	p, err := overlay.Get("summarize")
+	if err != nil || p.Source != "workflow" {
+		t.Fatalf("Get() = %v, %v; want the workflow's copy", p, err)
+	}
+	if _, err := overlay.Get("summarize@v10"); err == nil || !strings.Contains(err.Error(), "available: v1") {
+		t.Errorf("library versions should be hidden by the workflow's prompt, got %v", err)
+	}
+	if _, err := library.Get("summarize@v10"); err != nil {
+		t.Errorf("Overlay() must not modify the library: %v", err)
+	}
+}
@@ -11,6 +11,7 @@ const (
 	AzureOpenAI      InterfaceType = "azure_openai"  // Azure OpenAI Service
 	AWSBedrock       InterfaceType = "aws_bedrock"   // AWS Bedrock
 	GCPVertexAI      InterfaceType = "gcp_vertex_ai" // GCP Vertex AI
+	External         InterfaceType = "external"      // A provider program spoken to over stdio
 )
 
 // AIConfig represents the AI configuration
@@ -50,6 +51,21 @@ type ProviderConfig struct {
 	EmbeddingModels       map[string]EmbeddingModelConfig `yaml:"embedding_models,omitempty"`
 	DefaultEmbeddingModel string                          `yaml:"default_embedding_model,omitempty"`
 
+	// Default top_p, top_k, penalties, stop sequences and logit_bias
+	GenerationParams `yaml:",inline"`
+
+	// Send images from tool results to the model. Defaults to on for
+	// Anthropic and Gemini, whose models all accept images, and off elsewhere.
+	Vision *bool `yaml:"vision,omitempty"`
+
+	// Pricing in USD for the default model, used for cost reporting
+	CostPer1kInputTokens  float64 `yaml:"cost_per_1k_input_tokens,omitempty"`
+	CostPer1kOutputTokens float64 `yaml:"cost_per_1k_output_tokens,omitempty"`
+
+	// Monthly spend cap in USD, checked against the usage ledger before
+	// each call. 0 means no cap.
+	MonthlyBudget float64 `yaml:"monthly_budget,omitempty"`
+
 	// AWS Bedrock specific fields
 	AWSRegion          string `yaml:"aws_region,omitempty"`
 	AWSAccessKeyID     string `yaml:"aws_access_key_id,omitempty"`
@@ -60,6 +76,35 @@ type ProviderConfig struct {
 	ProjectID       string `yaml:"project_id,omitempty"`
 	Location        string `yaml:"location,omitempty"`
 	CredentialsPath string `yaml:"credentials_path,omitempty"`
+
+	// External provider program: started once and sent requests as JSON
+	// lines on stdin (see docs/providers/external.md)
+	Command string            `yaml:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+}
+
+// VisionEnabled reports whether images are sent to the model, given the
+// provider's default
+func (p *ProviderConfig) VisionEnabled(defaultOn bool) bool {
+	if p == nil || p.Vision == nil {
+		return defaultOn
+	}
+	return *p.Vision
+}
+
+// HasPricing reports whether token prices are configured for the provider
+func (p *ProviderConfig) HasPricing() bool {
+	return p != nil && (p.CostPer1kInputTokens > 0 || p.CostPer1kOutputTokens > 0)
+}
+
+// EstimateCost returns the USD cost of a completion from its token counts
+func (p *ProviderConfig) EstimateCost(promptTokens, completionTokens int) float64 {
+	if p == nil {
+		return 0
+	}
+	return float64(promptTokens)/1000*p.CostPer1kInputTokens +
+		float64(completionTokens)/1000*p.CostPer1kOutputTokens
 }
 
 // EmbeddingModelConfig represents configuration for a specific embedding model
@@ -70,3 +115,38 @@ type EmbeddingModelConfig struct {
 	Default         bool    `yaml:"default,omitempty"`
 	Description     string  `yaml:"description,omitempty"`
 }
+
+// MonthlyBudgets returns the monthly USD budget of each provider that has
+// one, from the AI providers and then the embedding providers
+func (c *ApplicationConfig) MonthlyBudgets() map[string]float64 {
+	budgets := make(map[string]float64)
+	if c.AI != nil {
+		for _, iface := range c.AI.Interfaces {
+			for name, providerConfig := range iface.Providers {
+				if providerConfig.MonthlyBudget > 0 {
+					budgets[name] = providerConfig.MonthlyBudget
+				}
+			}
+		}
+		for name, providerConfig := range c.AI.Providers {
+			if _, ok := budgets[name]; !ok && providerConfig.MonthlyBudget > 0 {
+				budgets[name] = providerConfig.MonthlyBudget
+			}
+		}
+	}
+	if c.Embeddings != nil {
+		for _, iface := range c.Embeddings.Interfaces {
+			for name, providerConfig := range iface.Providers {
+				if _, ok := budgets[name]; !ok && providerConfig.MonthlyBudget > 0 {
+					budgets[name] = providerConfig.MonthlyBudget
+				}
+			}
+		}
+		for name, providerConfig := range c.Embeddings.Providers {
+			if _, ok := budgets[name]; !ok && providerConfig.MonthlyBudget > 0 {
+				budgets[name] = providerConfig.MonthlyBudget
+			}
+		}
+	}
+	return budgets
+}
@@ -20,6 +20,58 @@ type RagServerConfig struct {
 	TextColumns     []string              `yaml:"text_columns"`               // Columns to return
 	MetadataColumns []string              `yaml:"metadata_columns,omitempty"` // Metadata columns
 	QueryEmbedding  *QueryEmbeddingConfig `yaml:"query_embedding,omitempty"`  // Default embedding config for queries
+	MetadataFields  RagMetadataFields     `yaml:"metadata_fields,omitempty"`  // Columns that filter: and namespace: use
+}
+
+// RagMetadataFields names the metadata columns that RAG step filters and
+// namespaces apply to
+type RagMetadataFields struct {
+	Path      string `yaml:"path,omitempty"`      // Source path (default: path)
+	Date      string `yaml:"date,omitempty"`      // Date (default: date)
+	Tags      string `yaml:"tags,omitempty"`      // Tag list (default: tags)
+	Namespace string `yaml:"namespace,omitempty"` // Namespace (default: namespace)
+}
+
+// PathField returns the path column, defaulting to path
+func (f RagMetadataFields) PathField() string {
+	if f.Path == "" {
+		return "path"
+	}
+	return f.Path
+}
+
+// DateField returns the date column, defaulting to date
+func (f RagMetadataFields) DateField() string {
+	if f.Date == "" {
+		return "date"
+	}
+	return f.Date
+}
+
+// TagsField returns the tags column, defaulting to tags
+func (f RagMetadataFields) TagsField() string {
+	if f.Tags == "" {
+		return "tags"
+	}
+	return f.Tags
+}
+
+// NamespaceField returns the namespace column, defaulting to namespace
+func (f RagMetadataFields) NamespaceField() string {
+	if f.Namespace == "" {
+		return "namespace"
+	}
+	return f.Namespace
+}
+
+// RagFilter restricts RAG retrieval to chunks whose metadata match every
+// condition set. Column names come from the server's metadata_fields.
+type RagFilter struct {
+	PathPrefix string                 `yaml:"path_prefix,omitempty"` // Path starts with this
+	After      string                 `yaml:"after,omitempty"`       // Date on or after (YYYY-MM-DD or RFC 3339)
+	Before     string                 `yaml:"before,omitempty"`      // Date before (YYYY-MM-DD or RFC 3339)
+	Tags       []string               `yaml:"tags,omitempty"`        // Chunk has all of these tags
+	Equals     map[string]interface{} `yaml:"equals,omitempty"`      // Metadata columns with exactly these values
 }
 
 // QueryEmbeddingConfig defines how to generate query embeddings
@@ -0,0 +1,25 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// RootConfig is a project root advertised to MCP servers (settings.yaml
+// `roots:` section). Entries are either a path or a mapping with a name:
+//
+//	roots:
+//	  - ~/src/my-app
+//	  - path: ./docs
+//	    name: Documentation
+type RootConfig struct {
+	Path string `yaml:"path"`           // Directory; relative paths resolve against the working directory
+	Name string `yaml:"name,omitempty"` // Display name (default: the directory's base name)
+}
+
+// UnmarshalYAML accepts a plain path as well as a mapping
+func (r *RootConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		r.Path = value.Value
+		return nil
+	}
+	type plain RootConfig
+	return value.Decode((*plain)(r))
+}
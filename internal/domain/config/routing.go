@@ -0,0 +1,58 @@
+package config
+
+import "strings"
+
+// RouterPrefix marks a provider name as a routing policy, e.g. "router:cheap"
+const RouterPrefix = "router:"
+
+// Routing strategies
+const (
+	RouteCheapest = "cheapest" // Lowest blended token price first
+	RouteFastest  = "fastest"  // Lowest expected latency first
+	RouteBest     = "best"     // Highest quality rank first
+	RouteOrdered  = "ordered"  // Candidates in the order listed (default)
+)
+
+// RoutingConfig represents model routing policies (settings.yaml `routing:` section)
+type RoutingConfig struct {
+	Policies map[string]RoutingPolicy `yaml:"policies,omitempty"` // Named policies, used as provider: router:<name>
+}
+
+// RoutingPolicy selects a provider/model per request from a candidate list
+type RoutingPolicy struct {
+	Strategy   string           `yaml:"strategy,omitempty"`    // cheapest, fastest, best, ordered (default: ordered)
+	Candidates []RouteCandidate `yaml:"candidates"`            // Providers/models to choose from
+	MaxCost    float64          `yaml:"max_cost,omitempty"`    // Ceiling on blended USD per 1K tokens; pricier candidates are skipped
+	LatencySLO int              `yaml:"latency_slo,omitempty"` // Milliseconds; candidates expected to be slower are skipped
+	NoFallback bool             `yaml:"no_fallback,omitempty"` // Only try the selected candidate instead of falling back through the rest
+}
+
+// RouteCandidate is one provider/model a policy can route to
+type RouteCandidate struct {
+	Provider  string   `yaml:"provider"`
+	Model     string   `yaml:"model,omitempty"`
+	Tags      []string `yaml:"tags,omitempty"`       // Task tags this candidate serves (e.g. code, summarize); none means any task
+	LatencyMs int      `yaml:"latency_ms,omitempty"` // Expected latency, e.g. from mcp-cli bench
+	Quality   int      `yaml:"quality,omitempty"`    // Rank for the best strategy; higher is better
+	Cost      *float64 `yaml:"cost,omitempty"`       // Blended USD per 1K tokens (0 for local models); default: average of the provider's input and output prices
+}
+
+// ParseRouter returns the policy name if provider refers to a routing policy
+func ParseRouter(provider string) (string, bool) {
+	if !strings.HasPrefix(provider, RouterPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(provider, RouterPrefix), true
+}
+
+// GetPolicy returns a named routing policy
+func (c *RoutingConfig) GetPolicy(name string) (*RoutingPolicy, bool) {
+	if c == nil || c.Policies == nil {
+		return nil, false
+	}
+	policy, exists := c.Policies[name]
+	if !exists {
+		return nil, false
+	}
+	return &policy, true
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SkillAuditConfig keeps a snapshot of every execute_skill_code call
+// (skills `audit:` block in settings)
+//
+//	skills:
+//	  audit:
+//	    enabled: true
+//	    dir: /outputs/runs/skill-audit
+//	    max_age: 720h
+//	    max_runs: 1000
+type SkillAuditConfig struct {
+	Enabled           bool   `yaml:"enabled"`
+	Dir               string `yaml:"dir,omitempty"`                  // /outputs/ maps to the outputs directory (default: /outputs/runs/skill-audit)
+	MaxAge            string `yaml:"max_age,omitempty"`              // Remove snapshots older than this duration (default: keep)
+	MaxRuns           int    `yaml:"max_runs,omitempty"`             // Keep at most this many snapshots, newest first (default: unlimited)
+	MaxArtifactSizeMB int    `yaml:"max_artifact_size_mb,omitempty"` // Larger artifacts are hashed but not copied (default: 50)
+}
+
+// GetAuditDir returns the directory snapshots are written to
+func (s *SkillsConfig) GetAuditDir() string {
+	dir := "/outputs/runs/skill-audit"
+	if s != nil && s.Audit != nil && s.Audit.Dir != "" {
+		dir = s.Audit.Dir
+	}
+	if !strings.HasPrefix(dir, "/outputs/") {
+		return dir
+	}
+	return filepath.Join(s.GetOutputsDir(), strings.TrimPrefix(dir, "/outputs/"))
+}
+
+// GetMaxAge returns how long snapshots are kept; 0 keeps them forever
+func (a *SkillAuditConfig) GetMaxAge() time.Duration {
+	if a == nil || a.MaxAge == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(a.MaxAge)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// GetMaxArtifactSize returns the largest artifact copied into a snapshot, in bytes
+func (a *SkillAuditConfig) GetMaxArtifactSize() int64 {
+	if a == nil || a.MaxArtifactSizeMB <= 0 {
+		return 50 << 20
+	}
+	return int64(a.MaxArtifactSizeMB) << 20
+}
@@ -0,0 +1,36 @@
+package config
+
+// SkillRouterConfig offers the LLM only the skills relevant to each request
+// instead of every skill tool (skills `router:` block in settings). Skill
+// descriptions are embedded at startup and compared with the user message
+// or workflow step prompt.
+//
+//	skills:
+//	  router:
+//	    enabled: true
+//	    top_n: 3
+//	    min_score: 0.25
+//	    provider: ollama
+//	    model: nomic-embed-text
+//	    always: [docx]
+type SkillRouterConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	TopN     int      `yaml:"top_n,omitempty"`     // Skills offered per request (default: 5)
+	MinScore float64  `yaml:"min_score,omitempty"` // Drop skills scoring below this cosine similarity (default: 0, keep top_n)
+	Provider string   `yaml:"provider,omitempty"`  // Embedding provider (default: the default embedding provider)
+	Model    string   `yaml:"model,omitempty"`     // Embedding model (default: the provider's default)
+	Always   []string `yaml:"always,omitempty"`    // Skills offered on every request
+}
+
+// RouterEnabled reports whether skill tools are selected per request
+func (s *SkillsConfig) RouterEnabled() bool {
+	return s != nil && s.Router != nil && s.Router.Enabled
+}
+
+// GetTopN returns how many routed skills are offered per request
+func (r *SkillRouterConfig) GetTopN() int {
+	if r == nil || r.TopN <= 0 {
+		return 5
+	}
+	return r.TopN
+}
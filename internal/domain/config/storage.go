@@ -0,0 +1,58 @@
+package config
+
+// Storage backend types
+const (
+	StorageS3        = "s3"
+	StorageAzureBlob = "azure_blob"
+	StorageGCS       = "gcs"
+)
+
+// StorageConfig represents object storage configuration (settings.yaml `storage:` section)
+type StorageConfig struct {
+	DefaultBackend string                          `yaml:"default_backend,omitempty"` // Backend used when a step doesn't name one
+	Backends       map[string]StorageBackendConfig `yaml:"backends,omitempty"`        // Named storage backends
+}
+
+// StorageBackendConfig defines a single object storage backend
+type StorageBackendConfig struct {
+	Type   string `yaml:"type"`             // s3, azure_blob, gcs
+	Bucket string `yaml:"bucket"`           // Bucket (S3/GCS) or container (Azure)
+	Prefix string `yaml:"prefix,omitempty"` // Optional key prefix applied to every object
+
+	// S3 (and S3-compatible) settings
+	Region          string `yaml:"region,omitempty"`
+	Endpoint        string `yaml:"endpoint,omitempty"`   // Custom endpoint (MinIO, R2, etc.)
+	PathStyle       bool   `yaml:"path_style,omitempty"` // Use path-style URLs instead of virtual-hosted
+	AccessKeyID     string `yaml:"access_key_id,omitempty"`
+	SecretAccessKey string `yaml:"secret_access_key,omitempty"`
+	SessionToken    string `yaml:"session_token,omitempty"`
+
+	// Azure Blob settings
+	AccountName string `yaml:"account_name,omitempty"`
+	AccountKey  string `yaml:"account_key,omitempty"` // Shared key (base64)
+	SASToken    string `yaml:"sas_token,omitempty"`   // Alternative to account_key
+
+	// GCS settings
+	CredentialsPath string `yaml:"credentials_path,omitempty"` // Service account JSON file
+	AccessToken     string `yaml:"access_token,omitempty"`     // Pre-issued OAuth2 token (alternative)
+
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+}
+
+// GetBackend returns a named backend, falling back to the default backend
+func (c *StorageConfig) GetBackend(name string) (*StorageBackendConfig, string, bool) {
+	if c == nil || c.Backends == nil {
+		return nil, "", false
+	}
+
+	if name == "" {
+		name = c.DefaultBackend
+	}
+
+	backend, exists := c.Backends[name]
+	if !exists {
+		return nil, name, false
+	}
+
+	return &backend, name, true
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SystemPromptMode is how a system prompt layer combines with the layers
+// before it
+type SystemPromptMode string
+
+const (
+	SystemPromptAppend  SystemPromptMode = "append"  // After the earlier layers (default)
+	SystemPromptPrepend SystemPromptMode = "prepend" // Before the earlier layers
+	SystemPromptReplace SystemPromptMode = "replace" // Instead of the earlier layers; empty text clears them
+)
+
+// Validate checks the mode is one of the known modes or empty
+func (m SystemPromptMode) Validate() error {
+	switch m {
+	case "", SystemPromptAppend, SystemPromptPrepend, SystemPromptReplace:
+		return nil
+	}
+	return fmt.Errorf("unknown system_prompt_mode %q (use append, prepend or replace)", m)
+}
+
+// SystemPromptLayer is one source of a composed system prompt, such as the
+// settings default, a workflow's execution section or a step
+type SystemPromptLayer struct {
+	Name string
+	Text string
+	Mode SystemPromptMode
+}
+
+// ComposeSystemPrompt combines layers in order, each appended, prepended or
+// replacing what the layers before it produced. Layers without text are
+// skipped unless they replace. It returns the prompt and the names of the
+// layers it contains, in the order they appear.
+func ComposeSystemPrompt(layers ...SystemPromptLayer) (string, []string) {
+	var parts []SystemPromptLayer
+	for _, layer := range layers {
+		text := strings.TrimSpace(layer.Text)
+		switch {
+		case layer.Mode == SystemPromptReplace:
+			parts = nil
+			if text == "" {
+				continue
+			}
+		case text == "":
+			continue
+		}
+
+		layer.Text = text
+		if layer.Mode == SystemPromptPrepend {
+			parts = append([]SystemPromptLayer{layer}, parts...)
+		} else {
+			parts = append(parts, layer)
+		}
+	}
+
+	texts := make([]string, len(parts))
+	names := make([]string, len(parts))
+	for i, part := range parts {
+		texts[i] = part.Text
+		names[i] = part.Name
+	}
+	return strings.Join(texts, "\n\n"), names
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComposeSystemPrompt(t *testing.T) {
+	settings := SystemPromptLayer{Name: "settings", Text: "You are a helpful assistant."}
+	skills := SystemPromptLayer{Name: "skills", Text: "Save files to /outputs/."}
+
+	tests := []struct {
+		name        string
+		layers      []SystemPromptLayer
+		want        string
+		wantSources []string
+	}{
+		{
+			name:        "append by default",
+			layers:      []SystemPromptLayer{settings, skills, {Name: "workflow", Text: "Answer in French."}},
+			want:        "You are a helpful assistant.\n\nSave files to /outputs/.\n\nAnswer in French.",
+			wantSources: []string{"settings", "skills", "workflow"},
+		},
+		{
+			name:        "prepend goes first",
+			layers:      []SystemPromptLayer{settings, {Name: "step", Text: "You are a security analyst.", Mode: SystemPromptPrepend}},
+			want:        "You are a security analyst.\n\nYou are a helpful assistant.",
+			wantSources: []string{"step", "settings"},
+		},
+		{
+			name:        "replace drops earlier layers",
+			layers:      []SystemPromptLayer{settings, {Name: "workflow", Text: "Be terse.", Mode: SystemPromptReplace}, {Name: "step", Text: "Use JSON."}},
+			want:        "Be terse.\n\nUse JSON.",
+			wantSources: []string{"workflow", "step"},
+		},
+		{
+			name:   "empty replace clears",
+			layers: []SystemPromptLayer{settings, skills, {Name: "step", Mode: SystemPromptReplace}},
+		},
+		{
+			name:        "empty layers skipped",
+			layers:      []SystemPromptLayer{settings, {Name: "workflow", Text: "  "}, {Name: "step", Mode: SystemPromptPrepend}},
+			want:        "You are a helpful assistant.",
+			wantSources: []string{"settings"},
+		},
+	}
+	for _, tt := range tests {
+		got, sources := ComposeSystemPrompt(tt.layers...)
+		if got != tt.want {
+			t.Errorf("%s: prompt = %q, want %q", tt.name, got, tt.want)
+		}
+		if len(sources) != len(tt.wantSources) || (len(sources) > 0 && !reflect.DeepEqual(sources, tt.wantSources)) {
+			t.Errorf("%s: sources = %v, want %v", tt.name, sources, tt.wantSources)
+		}
+	}
+
+	if err := SystemPromptMode("override").Validate(); err == nil {
+		t.Error("unknown mode accepted")
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+// Default tool-calling rounds per request in each mode
+const (
+	DefaultChatToolIterations     = 25
+	DefaultQueryToolIterations    = 50
+	DefaultWorkflowToolIterations = 10
+	DefaultMaxRepeatedToolCalls   = 2
+	DefaultToolCallRepairs        = 2
+)
+
+// ToolIterationsConfig bounds how many rounds of tool calls the model may
+// make to answer one request (settings.yaml `tool_iterations:` section).
+// Rounds stop early when the model repeats an identical tool call.
+// Invalid tool calls (unknown tools, arguments not matching the schema)
+// aren't run; the model is asked to correct them instead.
+type ToolIterationsConfig struct {
+	Chat           int `yaml:"chat,omitempty"`            // Rounds per chat message (default: 25)
+	Query          int `yaml:"query,omitempty"`           // Rounds per query (default: 50)
+	Workflow       int `yaml:"workflow,omitempty"`        // Rounds per workflow step without max_iterations (default: 10)
+	MaxRepeats     int `yaml:"max_repeats,omitempty"`     // Identical calls (same tool and arguments) allowed per request (default: 2)
+	RepairAttempts int `yaml:"repair_attempts,omitempty"` // Times the model is asked to correct invalid tool calls before failing (default: 2, -1 = never)
+}
+
+// ChatLimit returns the rounds allowed per chat message
+func (c *ToolIterationsConfig) ChatLimit() int {
+	if c == nil || c.Chat <= 0 {
+		return DefaultChatToolIterations
+	}
+	return c.Chat
+}
+
+// QueryLimit returns the rounds allowed per query
+func (c *ToolIterationsConfig) QueryLimit() int {
+	if c == nil || c.Query <= 0 {
+		return DefaultQueryToolIterations
+	}
+	return c.Query
+}
+
+// WorkflowLimit returns the rounds allowed per workflow step
+func (c *ToolIterationsConfig) WorkflowLimit() int {
+	if c == nil || c.Workflow <= 0 {
+		return DefaultWorkflowToolIterations
+	}
+	return c.Workflow
+}
+
+// RepeatLimit returns how often an identical tool call may be made
+func (c *ToolIterationsConfig) RepeatLimit() int {
+	if c == nil || c.MaxRepeats <= 0 {
+		return DefaultMaxRepeatedToolCalls
+	}
+	return c.MaxRepeats
+}
+
+// RepairLimit returns how often the model is asked to correct invalid
+// tool calls in one response
+func (c *ToolIterationsConfig) RepairLimit() int {
+	if c == nil || c.RepairAttempts == 0 {
+		return DefaultToolCallRepairs
+	}
+	if c.RepairAttempts < 0 {
+		return 0
+	}
+	return c.RepairAttempts
+}
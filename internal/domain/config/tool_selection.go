@@ -0,0 +1,18 @@
+package config
+
+// ToolSelectionConfig limits how many tools are sent to the LLM with each
+// request (settings.yaml `tool_selection:` section). When more tools are
+// available, the ones most relevant to the message are sent along with a
+// search_tools tool the model can use to find the rest.
+type ToolSelectionConfig struct {
+	MaxTools   int      `yaml:"max_tools"`            // Tools sent per request, including search_tools (0 = no limit)
+	Embeddings bool     `yaml:"embeddings,omitempty"` // Rank by embedding similarity as well as keywords (default: false)
+	Provider   string   `yaml:"provider,omitempty"`   // Embedding provider (default: the default embedding provider)
+	Model      string   `yaml:"model,omitempty"`      // Embedding model (default: the provider's default)
+	Always     []string `yaml:"always,omitempty"`     // Tool names sent on every request
+}
+
+// IsEnabled reports whether tools are limited per request
+func (c *ToolSelectionConfig) IsEnabled() bool {
+	return c != nil && c.MaxTools > 0
+}
@@ -0,0 +1,55 @@
+package config
+
+// Text-to-speech provider types
+const (
+	TTSOpenAI  = "openai"
+	TTSAzure   = "azure"
+	TTSCommand = "command"
+)
+
+// TTSConfig represents text-to-speech configuration (settings.yaml `tts:` section)
+type TTSConfig struct {
+	DefaultProvider string                       `yaml:"default_provider,omitempty"` // Provider used when a step or chat doesn't name one
+	Providers       map[string]TTSProviderConfig `yaml:"providers,omitempty"`        // Named TTS providers
+	Player          string                       `yaml:"player,omitempty"`           // Command used to play audio, {file} is replaced (default: auto-detect)
+}
+
+// TTSProviderConfig defines a single text-to-speech provider
+type TTSProviderConfig struct {
+	Type string `yaml:"type"` // openai, azure, command
+
+	// openai: API key and optional OpenAI-compatible endpoint
+	// azure: Speech resource key, with region or a full endpoint
+	APIKey   string `yaml:"api_key,omitempty"`
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Region   string `yaml:"region,omitempty"`
+
+	Model  string  `yaml:"model,omitempty"`  // openai: tts-1 (default), tts-1-hd, gpt-4o-mini-tts
+	Voice  string  `yaml:"voice,omitempty"`  // openai: alloy; azure: en-US-JennyNeural
+	Format string  `yaml:"format,omitempty"` // mp3 (default) or wav
+	Speed  float64 `yaml:"speed,omitempty"`  // openai: 0.25-4.0
+
+	// command: local synthesizer; text is written to stdin, {output} is replaced with the audio path
+	// e.g. "piper --model en_US-amy-medium.onnx --output_file {output}"
+	Command string `yaml:"command,omitempty"`
+
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+}
+
+// GetProvider returns a named provider, falling back to the default provider
+func (c *TTSConfig) GetProvider(name string) (*TTSProviderConfig, string, bool) {
+	if c == nil || c.Providers == nil {
+		return nil, "", false
+	}
+
+	if name == "" {
+		name = c.DefaultProvider
+	}
+
+	provider, exists := c.Providers[name]
+	if !exists {
+		return nil, name, false
+	}
+
+	return &provider, name, true
+}
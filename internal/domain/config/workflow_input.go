@@ -0,0 +1,38 @@
+package config
+
+// Defaults for the workflow input block
+const (
+	DefaultInputSpoolThreshold = 10 << 20 // 10 MiB
+	DefaultInputPortionLines   = 20
+	DefaultInputSummaryChunk   = 100000
+)
+
+// WorkflowInput controls how a workflow's input is read and which portions
+// of it steps can use (workflow `input:` block)
+//
+//	input:
+//	  spool_threshold: 5000000  # bytes of stdin kept in memory; larger input goes to a temp file
+//	  head_lines: 50            # {{input.head}}
+//	  tail_lines: 50            # {{input.tail}}
+//	  summarize: true           # {{input.summary}}
+type WorkflowInput struct {
+	SpoolThreshold int  `yaml:"spool_threshold,omitempty"` // Bytes above which stdin is spooled to a temp file (default: 10 MiB, -1 disables)
+	HeadLines      int  `yaml:"head_lines,omitempty"`      // Lines in {{input.head}} (default: 20)
+	TailLines      int  `yaml:"tail_lines,omitempty"`      // Lines in {{input.tail}} (default: 20)
+	Summarize      bool `yaml:"summarize,omitempty"`       // Summarize the input before the first step as {{input.summary}}
+
+	SummaryPrompt    string `yaml:"summary_prompt,omitempty"`     // Instruction for each summary request (default: a general summary)
+	SummaryChunkSize int    `yaml:"summary_chunk_size,omitempty"` // Bytes summarized per request; longer input is summarized in parts and combined (default: 100000)
+}
+
+// InputSpoolThreshold returns the stdin spool threshold in bytes, or -1
+// when spooling is disabled
+func (w *WorkflowV2) InputSpoolThreshold() int {
+	if w.Input == nil || w.Input.SpoolThreshold == 0 {
+		return DefaultInputSpoolThreshold
+	}
+	if w.Input.SpoolThreshold < 0 {
+		return -1
+	}
+	return w.Input.SpoolThreshold
+}
@@ -0,0 +1,34 @@
+package config
+
+// WorkflowLogging routes a workflow's log messages (workflow `logging:` block)
+//
+//	logging:
+//	  level: steps          # console level (overrides execution.logging)
+//	  quiet: true           # nothing on the console, e.g. when embedded in serve mode
+//	  file:
+//	    path: /outputs/logs/{{workflow}}-{{run_id}}.log
+//	    level: debug
+//	    max_size_mb: 10
+//	    max_backups: 3
+type WorkflowLogging struct {
+	Level string         `yaml:"level,omitempty"` // Console level: error, warn, info, steps, debug or verbose
+	Quiet bool           `yaml:"quiet,omitempty"` // Keep all logger output, including step results, off the console
+	File  *LogFileConfig `yaml:"file,omitempty"`  // Also write logs to a file
+}
+
+// LogFileConfig is a workflow's log file
+type LogFileConfig struct {
+	Path       string `yaml:"path"`                  // Supports {{workflow}}, {{run_id}} and {{date}}; /outputs/ maps to the outputs directory
+	Level      string `yaml:"level,omitempty"`       // File level, independent of the console (default: debug)
+	MaxSizeMB  int    `yaml:"max_size_mb,omitempty"` // Rotate when the file would exceed this size (default: 10)
+	MaxBackups int    `yaml:"max_backups,omitempty"` // Rotated files kept as <path>.1, <path>.2, ... (default: 3)
+}
+
+// ConsoleLogLevel returns the console log level: logging.level, else
+// execution.logging
+func (w *WorkflowV2) ConsoleLogLevel() string {
+	if w.Logging != nil && w.Logging.Level != "" {
+		return w.Logging.Level
+	}
+	return w.Execution.Logging
+}
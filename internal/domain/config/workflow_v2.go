@@ -4,14 +4,23 @@ import "time"
 
 // WorkflowV2 represents the v2.0 workflow schema with property inheritance
 type WorkflowV2 struct {
-	Schema      string            `yaml:"$schema"`
-	Name        string            `yaml:"name"`
-	Version     string            `yaml:"version"`
-	Description string            `yaml:"description"`
-	Execution   ExecutionContext  `yaml:"execution"`
-	Env         map[string]string `yaml:"env,omitempty"`
-	Steps       []StepV2          `yaml:"steps,omitempty"`
-	Loops       []LoopV2          `yaml:"loops,omitempty"`
+	Schema      string                     `yaml:"$schema"`
+	Name        string                     `yaml:"name"`
+	Version     string                     `yaml:"version"`
+	SpecVersion string                     `yaml:"spec_version,omitempty"` // Workflow schema revision (see CurrentSpecVersion)
+	Description string                     `yaml:"description"`
+	Execution   ExecutionContext           `yaml:"execution"`
+	Env         map[string]string          `yaml:"env,omitempty"`
+	Params      map[string]PromptParameter `yaml:"params,omitempty"`     // Declared parameters, referenced as {{params.<name>}}
+	Matrix      *MatrixConfig              `yaml:"matrix,omitempty"`     // Run the workflow once per parameter combination
+	Prompts     []PromptTemplate           `yaml:"prompts,omitempty"`    // Workflow-local prompt templates; override config/prompts/ by name
+	Agents      []AgentConfig              `yaml:"agents,omitempty"`     // Personas steps use with agent:; override config/agents/ by name
+	Blackboard  *BlackboardConfig          `yaml:"blackboard,omitempty"` // Initial entries and LLM tools for the run's shared blackboard
+	Judges      *JudgesConfig              `yaml:"judges,omitempty"`     // Judge models for this workflow; override settings.yaml judges per role
+	Logging     *WorkflowLogging           `yaml:"logging,omitempty"`    // Console level, quiet mode and log file for this workflow
+	Input       *WorkflowInput             `yaml:"input,omitempty"`      // Stdin spooling and the input portions steps can use
+	Steps       []StepV2                   `yaml:"steps,omitempty"`
+	Loops       []LoopV2                   `yaml:"loops,omitempty"`
 }
 
 // ExecutionContext defines workflow-level defaults for all steps
@@ -31,6 +40,13 @@ type ExecutionContext struct {
 	Temperature float64 `yaml:"temperature,omitempty"`
 	MaxTokens   int     `yaml:"max_tokens,omitempty"`
 
+	// top_p, top_k, penalties, stop sequences and logit_bias
+	GenerationParams `yaml:",inline"`
+
+	// System prompt layered over the settings default (see ComposeSystemPrompt)
+	SystemPrompt     string           `yaml:"system_prompt,omitempty"`
+	SystemPromptMode SystemPromptMode `yaml:"system_prompt_mode,omitempty"` // append (default), prepend or replace
+
 	// Execution control
 	Timeout       time.Duration `yaml:"timeout,omitempty"`
 	MaxIterations int           `yaml:"max_iterations,omitempty"`
@@ -40,6 +56,14 @@ type ExecutionContext struct {
 	MaxWorkers int    `yaml:"max_workers,omitempty"` // Maximum concurrent steps (default: 3)
 	OnError    string `yaml:"on_error,omitempty"`    // Error policy: cancel_all, complete_running, continue (default: cancel_all)
 
+	// Run artifacts
+	ArtifactsDir   string `yaml:"artifacts_dir,omitempty"`   // Base directory for per-run artifacts (default: /outputs/runs)
+	ExportTimeline bool   `yaml:"export_timeline,omitempty"` // Write timeline.html and trace.json for parallel runs
+	RecordRun      bool   `yaml:"record_run,omitempty"`      // Write run.json with per-step outputs (compare with runs diff)
+
+	// Large outputs
+	SpillThreshold int `yaml:"spill_threshold,omitempty"` // Bytes above which step outputs are kept in temp files (default: 1 MiB, -1 disables)
+
 	// Logging
 	Logging string `yaml:"logging,omitempty"` // normal, verbose, noisy
 	NoColor bool   `yaml:"no_color,omitempty"`
@@ -57,13 +81,22 @@ type StepV2 struct {
 	ExecutionOrder int    `yaml:"execution_order,omitempty"`
 
 	// Core execution
-	Run  string    `yaml:"run,omitempty"`  // The prompt
-	Loop *LoopMode `yaml:"loop,omitempty"` // Loop execution
+	Run        string                 `yaml:"run,omitempty"`         // The prompt
+	PromptRef  string                 `yaml:"prompt_ref,omitempty"`  // Named prompt template instead of run (name or name@version)
+	PromptArgs map[string]interface{} `yaml:"prompt_args,omitempty"` // Parameter values for prompt_ref (support {{variables}})
+	Examples   *ExamplesConfig        `yaml:"examples,omitempty"`    // Few-shot examples added before the prompt
+	History    []string               `yaml:"history,omitempty"`     // Earlier run: steps whose messages come before the prompt, in order
+	Loop       *LoopMode              `yaml:"loop,omitempty"`        // Loop execution
+
+	// Agent whose settings the step uses where it doesn't set its own
+	Agent         string       `yaml:"agent,omitempty"`
+	ResolvedAgent *AgentConfig `yaml:"-"` // Set when the workflow is loaded (see WorkflowV2.ApplyAgents)
 
 	// Provider override (inherits from execution if not specified)
 	Provider  string             `yaml:"provider,omitempty"`
 	Model     string             `yaml:"model,omitempty"`
 	Providers []ProviderFallback `yaml:"providers,omitempty"`
+	Tags      []string           `yaml:"tags,omitempty"` // Task tags for router:<policy> providers (e.g. code, summarize)
 
 	// Override execution context
 	Servers       []string       `yaml:"servers,omitempty"`
@@ -75,12 +108,32 @@ type StepV2 struct {
 	Logging       string         `yaml:"logging,omitempty"`
 	NoColor       *bool          `yaml:"no_color,omitempty"`
 	Input         interface{}    `yaml:"input,omitempty"`
+	Stream        bool           `yaml:"stream,omitempty"` // Write the response as it arrives (CLI: stderr, serve: progress notifications)
+
+	// Sampling overrides: top_p, top_k, penalties, stop sequences and logit_bias
+	GenerationParams `yaml:",inline"`
+
+	// System prompt layered over the settings and workflow prompts
+	SystemPrompt     string           `yaml:"system_prompt,omitempty"`
+	SystemPromptMode SystemPromptMode `yaml:"system_prompt_mode,omitempty"` // append (default), prepend or replace
 
 	// Special modes
-	Embeddings *EmbeddingsMode `yaml:"embeddings,omitempty"`
-	Template   *TemplateMode   `yaml:"template,omitempty"`
-	Consensus  *ConsensusMode  `yaml:"consensus,omitempty"`
-	Rag        *RagMode        `yaml:"rag,omitempty"` // RAG retrieval
+	Embeddings    *EmbeddingsMode    `yaml:"embeddings,omitempty"`
+	Template      *TemplateMode      `yaml:"template,omitempty"`
+	Consensus     *ConsensusMode     `yaml:"consensus,omitempty"`
+	Rag           *RagMode           `yaml:"rag,omitempty"`            // RAG retrieval
+	SQL           *SQLMode           `yaml:"sql,omitempty"`            // Database query
+	Storage       *StorageMode       `yaml:"storage,omitempty"`        // Object storage get/put
+	Notify        *NotifyMode        `yaml:"notify,omitempty"`         // Email/Slack/Teams notification
+	LogAnalytics  *LogAnalyticsMode  `yaml:"log_analytics,omitempty"`  // Azure Monitor Logs Ingestion
+	GraphSecurity *GraphSecurityMode `yaml:"graph_security,omitempty"` // Microsoft Graph incidents/alerts
+	LoadTable     *LoadTableMode     `yaml:"load_table,omitempty"`     // CSV/XLSX file to JSON rows
+	Render        *RenderMode        `yaml:"render,omitempty"`         // Markdown/template to HTML or PDF report
+	TTS           *TTSMode           `yaml:"tts,omitempty"`            // Text-to-speech audio
+	Compare       *CompareMode       `yaml:"compare,omitempty"`        // Same prompt across models, optionally judged
+	Debate        *DebateMode        `yaml:"debate,omitempty"`         // Agents argue a question, an adjudicator answers
+	Guard         *GuardMode         `yaml:"guard,omitempty"`          // Output policy checks
+	Verify        *VerifyMode        `yaml:"verify,omitempty"`         // Build/test command with parsed failures
 
 	// Control flow
 	If    string   `yaml:"if,omitempty"`
@@ -89,6 +142,13 @@ type StepV2 struct {
 	// Error handling
 	OnFailure  string `yaml:"on_failure,omitempty"`  // halt|continue|retry (inherits from execution.on_error if not specified)
 	MaxRetries int    `yaml:"max_retries,omitempty"` // Number of retries for on_failure: retry
+
+	// Result persistence (any step type)
+	OutputFile string `yaml:"output_file,omitempty"` // Path the result is written to (supports templating; JSON is pretty-printed)
+	OutputMode string `yaml:"output_mode,omitempty"` // overwrite (default) or append
+
+	// Blackboard entries added once the step succeeds (any step type)
+	WriteBlackboard []BlackboardWrite `yaml:"write_blackboard,omitempty"`
 }
 
 // LoopV2 represents an iterative execution block
@@ -102,8 +162,10 @@ type LoopV2 struct {
 	With     map[string]interface{} `yaml:"with,omitempty"`  // Input parameters
 
 	// Iteration control
-	MaxIterations int    `yaml:"max_iterations"` // Safety limit
-	Until         string `yaml:"until"`          // Exit condition (LLM evaluates, refine mode)
+	MaxIterations int          `yaml:"max_iterations"`      // Safety limit
+	Until         string       `yaml:"until,omitempty"`     // Exit condition expression (refine mode), e.g. output.score >= 8
+	UntilLLM      string       `yaml:"until_llm,omitempty"` // Exit condition judged by an LLM (refine mode)
+	Judge         *JudgeConfig `yaml:"judge,omitempty"`     // Model that judges until_llm (default: judges.conditions)
 
 	// Error handling
 	OnFailure  string `yaml:"on_failure,omitempty"`  // halt|continue|retry
@@ -121,6 +183,11 @@ type LoopV2 struct {
 	Accumulate string `yaml:"accumulate,omitempty"`  // Store iteration results
 	Parallel   bool   `yaml:"parallel,omitempty"`    // Enable parallel execution
 	MaxWorkers int    `yaml:"max_workers,omitempty"` // Concurrent worker limit (default: 3)
+
+	// Fan-in (iterate mode): how item outputs become the loop's output
+	Combine       string       `yaml:"combine,omitempty"`        // last (default), summarize or vote
+	CombinePrompt string       `yaml:"combine_prompt,omitempty"` // Reduction instructions for combine: summarize
+	CombineModel  *JudgeConfig `yaml:"combine_model,omitempty"`  // Model that summarizes (default: judges.combine)
 }
 
 // LoopMode defines loop execution within a step
@@ -132,8 +199,10 @@ type LoopMode struct {
 	With     map[string]interface{} `yaml:"with,omitempty"`  // Input parameters
 
 	// Iteration control
-	MaxIterations int    `yaml:"max_iterations"` // Safety limit (required)
-	Until         string `yaml:"until"`          // Exit condition (LLM evaluates, refine mode)
+	MaxIterations int          `yaml:"max_iterations"`      // Safety limit (required)
+	Until         string       `yaml:"until,omitempty"`     // Exit condition expression (refine mode), e.g. output.score >= 8
+	UntilLLM      string       `yaml:"until_llm,omitempty"` // Exit condition judged by an LLM (refine mode)
+	Judge         *JudgeConfig `yaml:"judge,omitempty"`     // Model that judges until_llm (default: judges.conditions)
 
 	// Error handling
 	OnFailure  string `yaml:"on_failure,omitempty"`  // halt|continue|retry
@@ -151,6 +220,11 @@ type LoopMode struct {
 	Accumulate string `yaml:"accumulate,omitempty"`  // Store iteration results
 	Parallel   bool   `yaml:"parallel,omitempty"`    // Enable parallel execution
 	MaxWorkers int    `yaml:"max_workers,omitempty"` // Concurrent worker limit (default: 3)
+
+	// Fan-in (iterate mode): how item outputs become the loop's output
+	Combine       string       `yaml:"combine,omitempty"`        // last (default), summarize or vote
+	CombinePrompt string       `yaml:"combine_prompt,omitempty"` // Reduction instructions for combine: summarize
+	CombineModel  *JudgeConfig `yaml:"combine_model,omitempty"`  // Model that summarizes (default: judges.combine)
 }
 
 // EmbeddingsMode represents embeddings generation
@@ -159,12 +233,15 @@ type EmbeddingsMode struct {
 	Provider string `yaml:"provider,omitempty"`
 	Model    string `yaml:"model,omitempty"`
 
+	// Fallback chain tried in order, instead of provider and model
+	Providers []ProviderFallback `yaml:"providers,omitempty"`
+
 	// Input source (one required)
 	Input     interface{} `yaml:"input,omitempty"`      // string or array
 	InputFile string      `yaml:"input_file,omitempty"` // alternative to Input
 
 	// Chunking configuration
-	ChunkStrategy string `yaml:"chunk_strategy,omitempty"` // sentence, paragraph, fixed
+	ChunkStrategy string `yaml:"chunk_strategy,omitempty"` // sentence, paragraph, fixed, code
 	MaxChunkSize  int    `yaml:"max_chunk_size,omitempty"` // default: 512
 	Overlap       int    `yaml:"overlap,omitempty"`        // overlap between chunks in tokens
 
@@ -180,8 +257,10 @@ type EmbeddingsMode struct {
 
 // TemplateMode represents template execution
 type TemplateMode struct {
-	Name string                 `yaml:"name"`
-	With map[string]interface{} `yaml:"with,omitempty"`
+	Name    string                 `yaml:"name"`
+	With    map[string]interface{} `yaml:"with,omitempty"`    // input and params; lists and maps are passed as JSON
+	Inherit []string               `yaml:"inherit,omitempty"` // Variables of this workflow the called one can read, under the same names
+	Outputs map[string]string      `yaml:"outputs,omitempty"` // Variable to set here -> variable or step of the called workflow
 }
 
 // ConsensusMode represents multi-provider consensus execution
@@ -191,6 +270,8 @@ type ConsensusMode struct {
 	Require      string          `yaml:"require"` // unanimous, 2/3, majority
 	AllowPartial bool            `yaml:"allow_partial,omitempty"`
 	Timeout      time.Duration   `yaml:"timeout,omitempty"`
+	Adjudicate   bool            `yaml:"adjudicate,omitempty"` // Let a judge pick the answer when the vote falls short
+	Judge        *JudgeConfig    `yaml:"judge,omitempty"`      // Adjudicating model (default: judges.consensus)
 }
 
 // ConsensusExec represents a single provider execution in consensus
@@ -204,11 +285,114 @@ type ConsensusExec struct {
 
 // ConsensusResult represents the result of a consensus execution
 type ConsensusResult struct {
-	Success    bool              `json:"success"`
-	Result     string            `json:"result"`
-	Agreement  float64           `json:"agreement"`
-	Votes      map[string]string `json:"votes"`
-	Confidence string            `json:"confidence"` // high, good, medium, low
+	Success     bool              `json:"success"`
+	Result      string            `json:"result"`
+	Agreement   float64           `json:"agreement"`
+	Votes       map[string]string `json:"votes"`
+	Confidence  string            `json:"confidence"`            // high, good, medium, low, or adjudicated
+	Adjudicator string            `json:"adjudicator,omitempty"` // Judge that picked the result when the vote fell short
+}
+
+// VerifyMode runs a build or test command and reports parsed failures, so a
+// refine loop can feed them into its next iteration
+type VerifyMode struct {
+	Command     string            `yaml:"command"`                 // Shell command (supports {{variables}})
+	Dir         string            `yaml:"dir,omitempty"`           // Working directory (local runs)
+	Files       map[string]string `yaml:"files,omitempty"`         // Files to write first: path (relative to dir) -> content
+	Sandbox     string            `yaml:"sandbox,omitempty"`       // Run in this skill's container instead of locally
+	Parser      string            `yaml:"parser,omitempty"`        // auto (default), go, pytest, jest, tsc, rust, or generic
+	Timeout     time.Duration     `yaml:"timeout,omitempty"`       // Default: step timeout
+	MaxErrors   int               `yaml:"max_errors,omitempty"`    // Errors included in the feedback (default: 20)
+	FailOnError bool              `yaml:"fail_on_error,omitempty"` // Fail the step when the command fails
+}
+
+// Guard actions
+const (
+	GuardActionBlock     = "block"     // Fail the step (default)
+	GuardActionRedact    = "redact"    // Mask offending text and continue
+	GuardActionRemediate = "remediate" // Send the output to a remediation workflow
+)
+
+// GuardMode runs text through policy checks and blocks, redacts or
+// remediates it when a check fails
+type GuardMode struct {
+	Input     string          `yaml:"input"`               // Text to check (e.g. "{{draft}}")
+	Checks    GuardChecks     `yaml:"checks"`              // Checks to run
+	Action    string          `yaml:"action,omitempty"`    // block (default), redact, or remediate
+	Remediate *GuardRemediate `yaml:"remediate,omitempty"` // Workflow to fix the output (action: remediate)
+}
+
+// GuardChecks configures the policy checks a guard step runs
+type GuardChecks struct {
+	Deny       []string               `yaml:"deny,omitempty"`        // Regular expressions that must not match
+	MaxLength  int                    `yaml:"max_length,omitempty"`  // Maximum characters
+	JSONSchema map[string]interface{} `yaml:"json_schema,omitempty"` // Output must be JSON matching this schema
+	PII        bool                   `yaml:"pii,omitempty"`         // Emails, phone numbers, card numbers, SSNs, IP addresses
+	Profanity  bool                   `yaml:"profanity,omitempty"`   // Built-in profanity list
+	Moderation *GuardModeration       `yaml:"moderation,omitempty"`  // OpenAI-compatible moderation API
+}
+
+// GuardModeration calls a moderation endpoint using an AI provider's credentials
+type GuardModeration struct {
+	Provider   string   `yaml:"provider"`             // Provider from config (e.g. openai)
+	Model      string   `yaml:"model,omitempty"`      // Default: omni-moderation-latest
+	Categories []string `yaml:"categories,omitempty"` // Only fail on these categories (default: any flagged)
+}
+
+// GuardRemediate names the workflow that repairs a failing output
+type GuardRemediate struct {
+	Workflow string                 `yaml:"workflow"`       // Workflow to call
+	With     map[string]interface{} `yaml:"with,omitempty"` // Inputs (default input: violations and the output)
+}
+
+// ExamplesConfig injects few-shot examples into a run step's prompt, either
+// from a file or retrieved by similarity from a RAG vector store
+type ExamplesConfig struct {
+	File      string       `yaml:"file,omitempty"`      // YAML list or JSONL of example records (e.g. {input, output})
+	Rag       *ExamplesRag `yaml:"rag,omitempty"`       // Retrieve the most similar prior examples instead
+	TopK      int          `yaml:"top_k,omitempty"`     // Maximum examples (default: all from file, 3 from rag)
+	Template  string       `yaml:"template,omitempty"`  // Per-example format using record fields, e.g. "Q: {{input}}\nA: {{output}}"
+	Header    string       `yaml:"header,omitempty"`    // Text before the examples (default: "Here are some examples:")
+	Separator string       `yaml:"separator,omitempty"` // Between examples (default: blank line)
+}
+
+// ExamplesRag retrieves few-shot examples from a configured RAG server
+type ExamplesRag struct {
+	Server      string   `yaml:"server,omitempty"`       // RAG server (default: rag default_server)
+	Query       string   `yaml:"query,omitempty"`        // Similarity query (default: the step's prompt)
+	Strategies  []string `yaml:"strategies,omitempty"`   // Vector strategies to use
+	MinScore    float64  `yaml:"min_score,omitempty"`    // Drop less similar examples
+	InputField  string   `yaml:"input_field,omitempty"`  // Column holding the example input (default: input)
+	OutputField string   `yaml:"output_field,omitempty"` // Column holding the example output (default: output)
+}
+
+// CompareMode represents an A/B comparison of one prompt across models
+type CompareMode struct {
+	Prompt     string          `yaml:"prompt"`
+	Executions []ConsensusExec `yaml:"executions"`            // Providers/models to compare
+	Judge      *CompareJudge   `yaml:"judge,omitempty"`       // Ranks the answers; without a judge the first successful execution wins
+	Timeout    time.Duration   `yaml:"timeout,omitempty"`     // Overall timeout (default: step timeout)
+	MinSuccess int             `yaml:"min_success,omitempty"` // Answers required to succeed (default: 1)
+}
+
+// CompareJudge configures the model that ranks compared answers
+type CompareJudge struct {
+	JudgeConfig `yaml:",inline"` // Default: judges.validation
+	Criteria    string           `yaml:"criteria,omitempty"` // What makes an answer better (default: accuracy, completeness, clarity)
+}
+
+// DebateMode has two or more agents argue a question over several rounds,
+// after which an adjudicator gives the answer
+type DebateMode struct {
+	Question    string        `yaml:"question"`              // What the agents argue (supports {{variables}})
+	Agents      []string      `yaml:"agents"`                // Debaters, speaking in this order every round
+	Rounds      int           `yaml:"rounds,omitempty"`      // Default: 2
+	Adjudicator string        `yaml:"adjudicator,omitempty"` // Agent that gives the answer (default: the judge)
+	Judge       *JudgeConfig  `yaml:"judge,omitempty"`       // Adjudicating model when there is no adjudicator (default: judges.consensus)
+	Timeout     time.Duration `yaml:"timeout,omitempty"`     // Per turn (default: step timeout)
+
+	ResolvedAgents      []*AgentConfig `yaml:"-"` // Set when the workflow is loaded (see WorkflowV2.ApplyAgents)
+	ResolvedAdjudicator *AgentConfig   `yaml:"-"`
 }
 
 // RagMode represents RAG retrieval execution
@@ -218,13 +402,18 @@ type RagMode struct {
 	QueryVector []float32 `yaml:"query_vector,omitempty"` // Pre-computed vector (optional)
 
 	// Server configuration
-	Server  string   `yaml:"server,omitempty"`  // Single server (default: from rag config)
-	Servers []string `yaml:"servers,omitempty"` // Multiple servers for fusion
+	Server  string             `yaml:"server,omitempty"`  // Single server (default: from rag config)
+	Servers []string           `yaml:"servers,omitempty"` // Several servers searched in parallel, results merged
+	Weights map[string]float64 `yaml:"weights,omitempty"` // Score multiplier per server in servers (default: 1)
 
 	// Strategy configuration
 	Strategies []string `yaml:"strategies,omitempty"` // Vector strategies to use
 	TopK       int      `yaml:"top_k,omitempty"`      // Number of results (default: from config)
 
+	// Scope
+	Filter    *RagFilter `yaml:"filter,omitempty"`    // Metadata conditions (path prefix, dates, tags, values)
+	Namespace string     `yaml:"namespace,omitempty"` // Only chunks in this namespace (supports templating)
+
 	// Fusion configuration
 	Fusion string `yaml:"fusion,omitempty"` // rrf, weighted, max, avg (default: from config)
 
@@ -234,4 +423,100 @@ type RagMode struct {
 
 	// Output configuration
 	OutputFormat string `yaml:"output_format,omitempty"` // json, text, compact
+	Citations    bool   `yaml:"citations,omitempty"`     // Label results for citing and record which ones later answers use (text output)
+}
+
+// SQLMode represents a database query execution
+type SQLMode struct {
+	// Connection
+	Driver string `yaml:"driver"` // postgres, mysql, sqlite
+	DSN    string `yaml:"dsn"`    // Connection string (supports ${ENV_VAR})
+
+	// Query
+	Query  string        `yaml:"query"`            // SQL statement (supports templating)
+	Params []interface{} `yaml:"params,omitempty"` // Positional parameters (strings support templating)
+
+	// Safety
+	ReadOnly *bool         `yaml:"read_only,omitempty"` // Reject write statements (default: true)
+	MaxRows  int           `yaml:"max_rows,omitempty"`  // Row limit (default: 1000)
+	Timeout  time.Duration `yaml:"timeout,omitempty"`   // Query timeout
+
+	// Output configuration
+	OutputFormat string `yaml:"output_format,omitempty"` // json, compact, rows (default: json)
+}
+
+// StorageMode represents an object storage get/put
+type StorageMode struct {
+	Action      string `yaml:"action"`                 // get, put
+	Backend     string `yaml:"backend,omitempty"`      // Named backend from settings (default: storage.default_backend)
+	Key         string `yaml:"key"`                    // Object key (supports templating)
+	Content     string `yaml:"content,omitempty"`      // put: content to upload (supports templating)
+	File        string `yaml:"file,omitempty"`         // put: local file to upload; get: local path to save to
+	ContentType string `yaml:"content_type,omitempty"` // put: override the detected content type
+}
+
+// NotifyMode represents sending a notification
+type NotifyMode struct {
+	Channel string   `yaml:"channel,omitempty"` // Named channel from settings (default: notifications.default_channel)
+	Subject string   `yaml:"subject,omitempty"` // Subject/title (supports templating)
+	Body    string   `yaml:"body"`              // Message body (supports templating)
+	To      []string `yaml:"to,omitempty"`      // Email recipients (smtp only, overrides channel default)
+}
+
+// LogAnalyticsMode represents sending records to Azure Log Analytics via the Logs Ingestion API
+type LogAnalyticsMode struct {
+	Destination string                 `yaml:"destination,omitempty"` // Named destination from settings (default: log_analytics.default_destination)
+	Stream      string                 `yaml:"stream,omitempty"`      // Override the destination's stream name
+	Data        string                 `yaml:"data,omitempty"`        // JSON object or array of records (supports templating)
+	Record      map[string]interface{} `yaml:"record,omitempty"`      // Single record; string values support templating
+}
+
+// GraphSecurityMode represents fetching Microsoft Graph security incidents or alerts
+type GraphSecurityMode struct {
+	Resource string `yaml:"resource,omitempty"`  // incidents (default) or alerts
+	Filter   string `yaml:"filter,omitempty"`    // OData $filter expression (supports templating)
+	OrderBy  string `yaml:"order_by,omitempty"`  // OData $orderby (default: createdDateTime desc)
+	Expand   string `yaml:"expand,omitempty"`    // OData $expand, e.g. "alerts" for incidents
+	Select   string `yaml:"select,omitempty"`    // OData $select (only with raw: true)
+	PageSize int    `yaml:"page_size,omitempty"` // $top per page (default: 50)
+	MaxItems int    `yaml:"max_items,omitempty"` // Stop paging after this many items (default: 500)
+	Raw      bool   `yaml:"raw,omitempty"`       // Output Graph objects as-is instead of normalized records
+}
+
+// LoadTableMode represents reading a CSV/TSV/XLSX file into JSON rows
+type LoadTableMode struct {
+	File       string `yaml:"file"`                  // Path to the file (supports templating)
+	Format     string `yaml:"format,omitempty"`      // csv, tsv or xlsx (default: from file extension)
+	Sheet      string `yaml:"sheet,omitempty"`       // XLSX sheet name (default: first sheet)
+	Delimiter  string `yaml:"delimiter,omitempty"`   // CSV delimiter (default: "," or tab for tsv)
+	Header     *bool  `yaml:"header,omitempty"`      // First row holds column names (default: true)
+	InferTypes *bool  `yaml:"infer_types,omitempty"` // Convert numbers/booleans (default: true)
+
+	// Row selection
+	Offset int   `yaml:"offset,omitempty"` // Skip this many data rows
+	Limit  int   `yaml:"limit,omitempty"`  // Return at most this many rows
+	Sample int   `yaml:"sample,omitempty"` // Random sample of this many rows
+	Seed   int64 `yaml:"seed,omitempty"`   // Seed for reproducible samples
+
+	OutputFormat string `yaml:"output_format,omitempty"` // json (default), rows, schema
+}
+
+// RenderMode represents rendering Markdown or a Go template to an HTML or PDF report
+type RenderMode struct {
+	Markdown     string `yaml:"markdown,omitempty"`      // Markdown source (supports templating)
+	Template     string `yaml:"template,omitempty"`      // Inline Go text/template producing Markdown
+	TemplateFile string `yaml:"template_file,omitempty"` // Path to a Go text/template file
+	Format       string `yaml:"format,omitempty"`        // html (default) or pdf
+	Output       string `yaml:"output,omitempty"`        // Output path (default: /outputs/<step>.<format>)
+	Title        string `yaml:"title,omitempty"`         // Document title (default: step name)
+	Stylesheet   string `yaml:"stylesheet,omitempty"`    // CSS file for HTML output (default: built-in)
+}
+
+// TTSMode represents converting text to speech through a configured TTS provider
+type TTSMode struct {
+	Provider string `yaml:"provider,omitempty"` // Named provider from settings (default: tts.default_provider)
+	Text     string `yaml:"text"`               // Text to speak (supports templating; Markdown is stripped)
+	Voice    string `yaml:"voice,omitempty"`    // Override the provider's voice
+	Output   string `yaml:"output,omitempty"`   // Audio path (default: /outputs/<step>.<format>)
+	Play     bool   `yaml:"play,omitempty"`     // Also play the audio locally
 }
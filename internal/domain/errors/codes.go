@@ -19,6 +19,11 @@ const (
 	ErrCodeToolNotFound       ErrorCode = "TOOL_NOT_FOUND"
 	ErrCodeToolExecutionError ErrorCode = "TOOL_EXECUTION_ERROR"
 	ErrCodeToolInvalidArgs    ErrorCode = "TOOL_INVALID_ARGS"
+	ErrCodeToolPermission     ErrorCode = "TOOL_PERMISSION_DENIED"
+	ErrCodeToolResourceAbsent ErrorCode = "TOOL_RESOURCE_NOT_FOUND"
+	ErrCodeToolTimeout        ErrorCode = "TOOL_TIMEOUT"
+	ErrCodeToolUnavailable    ErrorCode = "TOOL_UNAVAILABLE"
+	ErrCodeToolRateLimited    ErrorCode = "TOOL_RATE_LIMITED"
 
 	// Request errors
 	ErrCodeRequestInvalid  ErrorCode = "REQUEST_INVALID"
@@ -37,7 +42,8 @@ const (
 // IsRetryable returns true if the error code indicates a retryable error
 func (ec ErrorCode) IsRetryable() bool {
 	switch ec {
-	case ErrCodeProviderTimeout, ErrCodeProviderAPIError:
+	case ErrCodeProviderTimeout, ErrCodeProviderAPIError,
+		ErrCodeToolTimeout, ErrCodeToolUnavailable, ErrCodeToolRateLimited:
 		return true
 	default:
 		return false
@@ -49,14 +55,22 @@ func (ec ErrorCode) HTTPStatusCode() int {
 	switch ec {
 	case ErrCodeConfigInvalid, ErrCodeRequestInvalid, ErrCodeToolInvalidArgs:
 		return 400
-	case ErrCodeProviderNotFound, ErrCodeToolNotFound, ErrCodeServerNotFound:
+	case ErrCodeToolPermission:
+		return 403
+	case ErrCodeProviderNotFound, ErrCodeToolNotFound, ErrCodeServerNotFound, ErrCodeToolResourceAbsent:
 		return 404
 	case ErrCodeProviderTimeout:
 		return 408
 	case ErrCodeRequestTooLarge:
 		return 413
+	case ErrCodeToolRateLimited:
+		return 429
 	case ErrCodeProviderAPIError, ErrCodeToolExecutionError:
 		return 502
+	case ErrCodeToolUnavailable:
+		return 503
+	case ErrCodeToolTimeout:
+		return 504
 	default:
 		return 500
 	}
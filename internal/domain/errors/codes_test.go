@@ -10,6 +10,9 @@ func TestErrorCodeIsRetryable(t *testing.T) {
 	}{
 		{"provider timeout", ErrCodeProviderTimeout, true},
 		{"provider API error", ErrCodeProviderAPIError, true},
+		{"tool timeout", ErrCodeToolTimeout, true},
+		{"tool unavailable", ErrCodeToolUnavailable, true},
+		{"tool permission", ErrCodeToolPermission, false},
 		{"config invalid", ErrCodeConfigInvalid, false},
 		{"tool not found", ErrCodeToolNotFound, false},
 		{"request invalid", ErrCodeRequestInvalid, false},
@@ -40,6 +43,9 @@ func TestErrorCodeHTTPStatusCode(t *testing.T) {
 		{"request too large", ErrCodeRequestTooLarge, 413},
 		{"provider API error", ErrCodeProviderAPIError, 502},
 		{"tool execution error", ErrCodeToolExecutionError, 502},
+		{"tool permission", ErrCodeToolPermission, 403},
+		{"tool rate limited", ErrCodeToolRateLimited, 429},
+		{"tool timeout", ErrCodeToolTimeout, 504},
 		{"unknown", ErrCodeUnknown, 500},
 		{"internal", ErrCodeInternal, 500},
 	}
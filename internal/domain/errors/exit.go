@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+)
+
+// Process exit codes. These are a stable contract for scripts and CI
+// pipelines; do not renumber.
+const (
+	ExitOK         = 0 // Success
+	ExitFailure    = 1 // Unclassified failure
+	ExitValidation = 2 // Invalid flags, configuration or workflow definition
+	ExitProvider   = 3 // LLM provider failure (API error, auth, all fallbacks failed)
+	ExitTool       = 4 // MCP tool or server failure
+	ExitTimeout    = 5 // Timeout or budget exceeded
+
+	ExitInterrupted = 130 // Cancelled by the user (Ctrl-C), as shells report SIGINT
+)
+
+// Failure categories. Attach one to an error with Categorize so ExitCode
+// can classify it anywhere in the chain.
+var (
+	ErrValidation      = stderrors.New("validation failed")
+	ErrProviderFailure = stderrors.New("provider failure")
+	ErrToolFailure     = stderrors.New("tool failure")
+	ErrTimeout         = stderrors.New("timed out")
+	ErrBudgetExceeded  = stderrors.New("budget exceeded")
+	ErrInterrupted     = stderrors.New("interrupted")
+)
+
+// categorizedError tags an error with a category without changing its message
+type categorizedError struct {
+	err      error
+	category error
+}
+
+func (e *categorizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorizedError) Unwrap() []error {
+	return []error{e.err, e.category}
+}
+
+// Categorize tags err with a failure category (ErrValidation, ErrToolFailure, ...).
+// The error message is unchanged. Returns nil if err is nil.
+func Categorize(err error, category error) error {
+	if err == nil {
+		return nil
+	}
+	return &categorizedError{err: err, category: category}
+}
+
+// ExitCode maps an error to a process exit code. Timeouts take precedence,
+// then validation, tool and provider failures, so that a tool failure
+// reported through a provider error still exits with ExitTool.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var timeout interface{ Timeout() bool }
+	switch {
+	case stderrors.Is(err, ErrTimeout), stderrors.Is(err, ErrBudgetExceeded),
+		stderrors.Is(err, context.DeadlineExceeded):
+		return ExitTimeout
+	case stderrors.As(err, &timeout) && timeout.Timeout():
+		return ExitTimeout
+	case stderrors.Is(err, ErrInterrupted), stderrors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case stderrors.Is(err, ErrValidation):
+		return ExitValidation
+	case stderrors.Is(err, ErrToolFailure):
+		return ExitTool
+	case stderrors.Is(err, ErrProviderFailure):
+		return ExitProvider
+	}
+
+	var domainErr *DomainError
+	if stderrors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case ErrCodeConfigInvalid, ErrCodeConfigNotFound, ErrCodeConfigParseFailed,
+			ErrCodeRequestInvalid, ErrCodeRequestTooLarge, ErrCodeToolInvalidArgs:
+			return ExitValidation
+		case ErrCodeProviderTimeout, ErrCodeToolTimeout:
+			return ExitTimeout
+		case ErrCodeProviderNotFound, ErrCodeProviderInvalid, ErrCodeProviderAPIError:
+			return ExitProvider
+		case ErrCodeToolNotFound, ErrCodeToolExecutionError, ErrCodeToolPermission,
+			ErrCodeToolResourceAbsent, ErrCodeToolUnavailable, ErrCodeToolRateLimited,
+			ErrCodeServerNotFound, ErrCodeServerStartFailed, ErrCodeServerStopped:
+			return ExitTool
+		}
+	}
+
+	// Any failed tool call is a tool failure, whatever the LLM got wrong
+	var toolErr *ToolError
+	if stderrors.As(err, &toolErr) {
+		if toolErr.Code == ErrCodeToolTimeout {
+			return ExitTimeout
+		}
+		return ExitTool
+	}
+
+	return ExitFailure
+}
+
+// Category names the failure category of an error's exit code: validation,
+// provider, tool, timeout, interrupted or failure. Returns "" if err is nil.
+func Category(err error) string {
+	switch ExitCode(err) {
+	case ExitOK:
+		return ""
+	case ExitValidation:
+		return "validation"
+	case ExitProvider:
+		return "provider"
+	case ExitTool:
+		return "tool"
+	case ExitTimeout:
+		return "timeout"
+	case ExitInterrupted:
+		return "interrupted"
+	}
+	return "failure"
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string { return "i/o timeout" }
+func (timeoutErr) Timeout() bool { return true }
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain", stderrors.New("boom"), ExitFailure},
+		{"validation", Categorize(stderrors.New("bad workflow"), ErrValidation), ExitValidation},
+		{"wrapped validation", fmt.Errorf("run: %w", Categorize(stderrors.New("bad"), ErrValidation)), ExitValidation},
+		{"provider", Categorize(stderrors.New("all providers failed"), ErrProviderFailure), ExitProvider},
+		{"tool inside provider", Categorize(fmt.Errorf("all failed: %w", Categorize(stderrors.New("x"), ErrToolFailure)), ErrProviderFailure), ExitTool},
+		{"deadline", fmt.Errorf("step: %w", context.DeadlineExceeded), ExitTimeout},
+		{"net timeout", fmt.Errorf("request: %w", timeoutErr{}), ExitTimeout},
+		{"budget", Categorize(stderrors.New("too many tool calls"), ErrBudgetExceeded), ExitTimeout},
+		{"canceled", fmt.Errorf("query failed: %w", context.Canceled), ExitInterrupted},
+		{"interrupted", Categorize(stderrors.New("workflow canceled"), ErrInterrupted), ExitInterrupted},
+		{"deadline wins over canceled", fmt.Errorf("%w: %w", context.Canceled, context.DeadlineExceeded), ExitTimeout},
+		{"domain error", NewDomainError(ErrCodeToolNotFound, "no such tool"), ExitTool},
+		{"wrapped domain error", fmt.Errorf("x: %w", NewDomainError(ErrCodeConfigInvalid, "bad")), ExitValidation},
+		{"tool error", fmt.Errorf("x: %w", NewToolError(ErrCodeToolInvalidArgs, "bad arguments")), ExitTool},
+		{"tool timeout", NewToolError(ErrCodeToolTimeout, "slow"), ExitTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategorizeKeepsMessage(t *testing.T) {
+	err := Categorize(stderrors.New("workflow validation failed"), ErrValidation)
+	if err.Error() != "workflow validation failed" {
+		t.Errorf("Categorize changed the message: %q", err.Error())
+	}
+	if Categorize(nil, ErrValidation) != nil {
+		t.Error("Categorize(nil) should return nil")
+	}
+}
+
+func TestCategory(t *testing.T) {
+	if got := Category(nil); got != "" {
+		t.Errorf("Category(nil) = %q, want empty", got)
+	}
+	if got := Category(Categorize(stderrors.New("x"), ErrToolFailure)); got != "tool" {
+		t.Errorf("Category(tool failure) = %q, want tool", got)
+	}
+	if got := Category(context.Canceled); got != "interrupted" {
+		t.Errorf("Category(context.Canceled) = %q, want interrupted", got)
+	}
+	if got := Category(stderrors.New("x")); got != "failure" {
+		t.Errorf("Category(plain error) = %q, want failure", got)
+	}
+}
@@ -0,0 +1,199 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io/fs"
+	"regexp"
+	"strings"
+)
+
+// ToolErrorCategory groups tool failures by what the model can do next
+type ToolErrorCategory string
+
+const (
+	CategoryInvalidInput ToolErrorCategory = "invalid_input" // Fix the arguments and call again
+	CategoryNotFound     ToolErrorCategory = "not_found"     // The tool or the thing it was asked about doesn't exist
+	CategoryPermission   ToolErrorCategory = "permission"    // Not allowed; retrying the same call won't help
+	CategoryTransient    ToolErrorCategory = "transient"     // May succeed if retried
+	CategoryExecution    ToolErrorCategory = "execution"     // The tool ran and failed
+)
+
+// ToolCategory returns the recovery category of a tool error code
+func (ec ErrorCode) ToolCategory() ToolErrorCategory {
+	switch ec {
+	case ErrCodeToolInvalidArgs, ErrCodeRequestInvalid, ErrCodeRequestTooLarge:
+		return CategoryInvalidInput
+	case ErrCodeToolNotFound, ErrCodeToolResourceAbsent, ErrCodeServerNotFound:
+		return CategoryNotFound
+	case ErrCodeToolPermission:
+		return CategoryPermission
+	case ErrCodeToolTimeout, ErrCodeToolUnavailable, ErrCodeToolRateLimited, ErrCodeServerStopped:
+		return CategoryTransient
+	default:
+		return CategoryExecution
+	}
+}
+
+// defaultSuggestions are the recovery hints used when a tool error doesn't
+// carry a more specific one
+var defaultSuggestions = map[ErrorCode]string{
+	ErrCodeToolInvalidArgs:    "Check the tool's parameter schema and call it again with corrected arguments.",
+	ErrCodeToolNotFound:       "Call only tools from the list of available tools.",
+	ErrCodeToolResourceAbsent: "Check the name or path, or list what exists, before trying again.",
+	ErrCodeToolPermission:     "Don't repeat this call. Choose a target the tool is allowed to access, or ask the user.",
+	ErrCodeToolTimeout:        "Retry once, with a smaller request if possible.",
+	ErrCodeToolUnavailable:    "Retry once. If it fails again, continue without this tool and tell the user.",
+	ErrCodeToolRateLimited:    "Wait briefly before retrying, and make fewer calls.",
+	ErrCodeToolExecutionError: "Read the message, adjust the call and try again, or explain the failure to the user.",
+}
+
+// ToolError is a tool failure described for the LLM: a code, whether a
+// retry can help, and a hint on how to recover
+type ToolError struct {
+	Code       ErrorCode
+	Message    string
+	Suggestion string
+	Cause      error
+}
+
+// Error returns the message alone, so logs read as before
+func (e *ToolError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error
+func (e *ToolError) Unwrap() error {
+	return e.Cause
+}
+
+// WithSuggestion sets the recovery hint shown to the LLM
+func (e *ToolError) WithSuggestion(suggestion string) *ToolError {
+	e.Suggestion = suggestion
+	return e
+}
+
+// WithCause records the underlying error
+func (e *ToolError) WithCause(err error) *ToolError {
+	e.Cause = err
+	return e
+}
+
+// NewToolError creates a tool error
+func NewToolError(code ErrorCode, message string) *ToolError {
+	return &ToolError{Code: code, Message: message}
+}
+
+// ClassifyToolError returns err as a ToolError. Errors that aren't one
+// already are classified from their chain and message, so failures from
+// external MCP servers get a code too. Returns nil if err is nil.
+func ClassifyToolError(err error) *ToolError {
+	if err == nil {
+		return nil
+	}
+	var toolErr *ToolError
+	if stderrors.As(err, &toolErr) {
+		// Keep the context added by wrapping
+		classified := *toolErr
+		classified.Message = err.Error()
+		return &classified
+	}
+	var domainErr *DomainError
+	if stderrors.As(err, &domainErr) && strings.HasPrefix(string(domainErr.Code), "TOOL_") {
+		return NewToolError(domainErr.Code, err.Error()).WithCause(err)
+	}
+	return NewToolError(classifyMessage(err), err.Error()).WithCause(err)
+}
+
+// missingTool matches the "tool 'name' not found" errors of the server managers
+var missingTool = regexp.MustCompile(`tool '[^']*' not found`)
+
+// classifyMessage guesses an error code from the error chain and message
+func classifyMessage(err error) ErrorCode {
+	var timeout interface{ Timeout() bool }
+	switch {
+	case stderrors.Is(err, context.DeadlineExceeded), stderrors.As(err, &timeout) && timeout.Timeout():
+		return ErrCodeToolTimeout
+	case stderrors.Is(err, fs.ErrPermission):
+		return ErrCodeToolPermission
+	case stderrors.Is(err, fs.ErrNotExist):
+		return ErrCodeToolResourceAbsent
+	}
+
+	msg := strings.ToLower(err.Error())
+	matches := func(phrases ...string) bool {
+		for _, phrase := range phrases {
+			if strings.Contains(msg, phrase) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case matches("timed out", "timeout", "deadline exceeded"):
+		return ErrCodeToolTimeout
+	case matches("rate limit", "too many requests", "http 429"):
+		return ErrCodeToolRateLimited
+	case matches("permission denied", "access denied", "not allowed", "forbidden", "http 401", "http 403"):
+		return ErrCodeToolPermission
+	case matches("tool not found", "unknown tool") || missingTool.MatchString(msg):
+		return ErrCodeToolNotFound
+	case matches("invalid argument", "invalid arguments", "failed to parse arguments", "is required", "must be", "missing required"):
+		return ErrCodeToolInvalidArgs
+	case matches("connection refused", "broken pipe", "server not running", "not connected", "unavailable", "http 502", "http 503"):
+		return ErrCodeToolUnavailable
+	case matches("not found", "no such file", "does not exist", "http 404"):
+		return ErrCodeToolResourceAbsent
+	}
+	return ErrCodeToolExecutionError
+}
+
+// toolErrorResult is the JSON shape of a failed tool result
+type toolErrorResult struct {
+	Error struct {
+		Code       ErrorCode         `json:"code"`
+		Category   ToolErrorCategory `json:"category"`
+		Retryable  bool              `json:"retryable"`
+		Message    string            `json:"message"`
+		Suggestion string            `json:"suggestion,omitempty"`
+	} `json:"error"`
+}
+
+// FormatToolResult renders a tool failure as the content of the tool
+// result message sent back to the LLM, for example:
+//
+//	{"error":{"code":"TOOL_PERMISSION_DENIED","category":"permission","retryable":false,
+//	  "message":"access denied: ...","suggestion":"Use a path inside: /home/me/project"}}
+func FormatToolResult(err error) string {
+	toolErr := ClassifyToolError(err)
+	if toolErr == nil {
+		return ""
+	}
+
+	var result toolErrorResult
+	result.Error.Code = toolErr.Code
+	result.Error.Category = toolErr.Code.ToolCategory()
+	result.Error.Retryable = toolErr.Code.IsRetryable()
+	result.Error.Message = toolErr.Message
+	result.Error.Suggestion = toolErr.Suggestion
+	if result.Error.Suggestion == "" {
+		result.Error.Suggestion = defaultSuggestions[toolErr.Code]
+	}
+
+	data, marshalErr := json.Marshal(result)
+	if marshalErr != nil {
+		return "Error: " + toolErr.Message
+	}
+	return string(data)
+}
+
+// IsToolErrorResult reports whether content is a tool result written by
+// FormatToolResult
+func IsToolErrorResult(content string) bool {
+	if !strings.HasPrefix(content, `{"error":`) {
+		return false
+	}
+	var result toolErrorResult
+	return json.Unmarshal([]byte(content), &result) == nil && result.Error.Code != ""
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+func TestClassifyToolError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"tool error", NewToolError(ErrCodeToolPermission, "access denied"), ErrCodeToolPermission},
+		{"wrapped tool error", fmt.Errorf("search failed: %w", NewToolError(ErrCodeToolRateLimited, "slow down")), ErrCodeToolRateLimited},
+		{"domain error", NewDomainError(ErrCodeToolInvalidArgs, "bad args"), ErrCodeToolInvalidArgs},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), ErrCodeToolTimeout},
+		{"missing file", &fs.PathError{Op: "open", Path: "/x", Err: fs.ErrNotExist}, ErrCodeToolResourceAbsent},
+		{"unknown tool", stderrors.New("tool 'frobnicate' not found on any connected server"), ErrCodeToolNotFound},
+		{"missing argument", stderrors.New("query is required"), ErrCodeToolInvalidArgs},
+		{"server down", stderrors.New("tool execution failed: connection refused"), ErrCodeToolUnavailable},
+		{"http 429", stderrors.New("brave search failed: HTTP 429: quota"), ErrCodeToolRateLimited},
+		{"other", stderrors.New("division by zero"), ErrCodeToolExecutionError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyToolError(tt.err).Code; got != tt.want {
+				t.Errorf("ClassifyToolError() code = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if ClassifyToolError(nil) != nil {
+		t.Error("ClassifyToolError(nil) should be nil")
+	}
+}
+
+func TestFormatToolResult(t *testing.T) {
+	err := fmt.Errorf("read_file: %w", NewToolError(ErrCodeToolPermission, "access denied: /etc/passwd is outside the allowed directories").
+		WithSuggestion("Use a path inside the allowed directories: /home/me/project"))
+	content := FormatToolResult(err)
+
+	var result toolErrorResult
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		t.Fatalf("not JSON: %v\n%s", err, content)
+	}
+	got := result.Error
+	if got.Code != ErrCodeToolPermission || got.Category != CategoryPermission || got.Retryable {
+		t.Errorf("code/category/retryable = %s/%s/%v", got.Code, got.Category, got.Retryable)
+	}
+	if got.Message != "read_file: access denied: /etc/passwd is outside the allowed directories" {
+		t.Errorf("message = %q", got.Message)
+	}
+	if got.Suggestion != "Use a path inside the allowed directories: /home/me/project" {
+		t.Errorf("suggestion = %q", got.Suggestion)
+	}
+	if !IsToolErrorResult(content) {
+		t.Error("IsToolErrorResult should recognise FormatToolResult output")
+	}
+
+	timeout := FormatToolResult(stderrors.New("request timed out"))
+	if err := json.Unmarshal([]byte(timeout), &result); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Error.Retryable || result.Error.Category != CategoryTransient || result.Error.Suggestion == "" {
+		t.Errorf("timeout result = %+v", result.Error)
+	}
+
+	for _, content := range []string{`{"error": "plain"}`, `{"results": []}`, "Error: boom"} {
+		if IsToolErrorResult(content) {
+			t.Errorf("IsToolErrorResult(%q) should be false", content)
+		}
+	}
+}
@@ -16,6 +16,17 @@ type Message struct {
 	Name       string     `json:"name,omitempty"`
 	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
 	ToolCallID string     `json:"tool_call_id,omitempty"`
+
+	// Images returned by a tool, attached by providers that accept image
+	// input. Not persisted: the content keeps a placeholder with the path.
+	Images []Image `json:"-"`
+}
+
+// Image is base64-encoded image content
+type Image struct {
+	MimeType string `json:"mime_type"`
+	Data     string `json:"data"`
+	Path     string `json:"path,omitempty"`
 }
 
 // ToolCall represents a call to a tool
@@ -52,6 +63,9 @@ type CompletionRequest struct {
 	Temperature  float64   `json:"temperature,omitempty"`
 	MaxTokens    int       `json:"max_tokens,omitempty"`
 	Stream       bool      `json:"stream,omitempty"`
+
+	// Sampling settings over the provider's defaults
+	config.GenerationParams
 }
 
 // CompletionResponse contains the response from an LLM completion
@@ -120,11 +134,12 @@ type TextChunk = Chunk
 
 // Chunk represents a chunk of text with metadata
 type Chunk struct {
-	Text       string `json:"text"`
-	Index      int    `json:"index"`
-	StartPos   int    `json:"start_pos"`
-	EndPos     int    `json:"end_pos"`
-	TokenCount int    `json:"token_count"`
+	Text       string                 `json:"text"`
+	Index      int                    `json:"index"`
+	StartPos   int                    `json:"start_pos"`
+	EndPos     int                    `json:"end_pos"`
+	TokenCount int                    `json:"token_count"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"` // Strategy-specific metadata (e.g. file_path, symbol for code)
 }
 
 // EmbeddingWithMeta combines embedding vector with chunk metadata
@@ -150,6 +165,7 @@ const (
 	ChunkingFixed     ChunkingType = "fixed"
 	ChunkingSemantic  ChunkingType = "semantic"
 	ChunkingSliding   ChunkingType = "sliding"
+	ChunkingCode      ChunkingType = "code"
 )
 
 // EmbeddingJobRequest represents a request to generate embeddings for text
@@ -162,7 +178,11 @@ type EmbeddingJobRequest struct {
 	ChunkOverlap   int                    `json:"chunk_overlap,omitempty"`
 	EncodingFormat string                 `json:"encoding_format,omitempty"`
 	Dimensions     int                    `json:"dimensions,omitempty"`
+	FilePath       string                 `json:"file_path,omitempty"` // Source file path (used by code chunking)
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+
+	// Providers tried in order if Provider fails; empty uses embeddings.fallback_chain
+	Fallbacks []config.ProviderFallback `json:"fallbacks,omitempty"`
 }
 
 // ProviderType represents the type of LLM provider
@@ -265,6 +285,21 @@ type MCPServerManager interface {
 	StopAll() error
 }
 
+// ToolSelector is implemented by server managers that can narrow their tools
+// to the ones relevant to a request, such as the skills router
+type ToolSelector interface {
+	SelectTools(ctx context.Context, request string) ([]Tool, error)
+}
+
+// SelectTools returns the tools to offer the LLM for a request: the
+// manager's selection when it makes one, otherwise all available tools
+func SelectTools(ctx context.Context, manager MCPServerManager, request string) ([]Tool, error) {
+	if selector, ok := manager.(ToolSelector); ok {
+		return selector.SelectTools(ctx, request)
+	}
+	return manager.GetAvailableTools()
+}
+
 // QueryRequest represents a query request
 type QueryRequest struct {
 	Query           string  `json:"query"`
@@ -410,6 +445,9 @@ type ConfigurationService interface {
 	// GetDefaultEmbeddingProvider returns the default embedding provider configuration
 	GetDefaultEmbeddingProvider() (string, *config.EmbeddingProviderConfig, config.InterfaceType, error)
 
+	// GetEmbeddingFallbackChain returns the embedding providers to fail over to
+	GetEmbeddingFallbackChain() []config.ProviderFallback
+
 	// ListServers returns a list of configured server names
 	ListServers() []string
 
@@ -3,7 +3,13 @@ package runas
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // RunAsType defines the type of server to run
@@ -27,6 +33,32 @@ const (
 	// RunAsTypeACP RunAsType = "acp"  // Agent Communication Protocol
 )
 
+// OutputFormat defines how a tool's result is returned to MCP clients
+type OutputFormat string
+
+const (
+	// OutputText returns the result as plain text (default)
+	OutputText OutputFormat = "text"
+
+	// OutputMarkdown returns the result in a fenced markdown code block
+	OutputMarkdown OutputFormat = "markdown"
+
+	// OutputJSON returns the result as structured JSON content
+	OutputJSON OutputFormat = "json"
+
+	// OutputFile saves the result to the outputs directory and returns a resource link
+	OutputFile OutputFormat = "file"
+)
+
+// Validate checks the output format is one of the supported values
+func (f OutputFormat) Validate() error {
+	switch f {
+	case "", OutputText, OutputMarkdown, OutputJSON, OutputFile:
+		return nil
+	}
+	return fmt.Errorf("invalid output '%s' (valid: text, markdown, json, file)", f)
+}
+
 // RunAsConfig defines how to expose templates as an MCP server
 type RunAsConfig struct {
 	// Type of server (mcp, mcp-skills, proxy, proxy-skills)
@@ -68,6 +100,18 @@ type RunAsConfig struct {
 
 	// Proxy configuration (for runas_type: proxy, proxy-skills)
 	ProxyConfig *ProxyConfig `yaml:"proxy_config,omitempty" json:"proxy_config,omitempty"`
+
+	// Streamable HTTP transport (for runas_type: mcp, mcp-skills)
+	// When set, the server listens on HTTP in addition to stdio
+	HTTP *HTTPConfig `yaml:"http,omitempty" json:"http,omitempty"`
+
+	// Bounded job queue for tool calls across all transports (for runas_type: mcp, mcp-skills)
+	// When omitted, tool calls run as they arrive with no limit
+	Queue *QueueConfig `yaml:"queue,omitempty" json:"queue,omitempty"`
+
+	// Result delivery for tools marked async (for runas_type: mcp, mcp-skills)
+	// Optional - async tools are always pollable with get_run_result
+	Async *AsyncConfig `yaml:"async,omitempty" json:"async,omitempty"`
 }
 
 // TemplateSource specifies a template to expose with its config source
@@ -80,6 +124,15 @@ type TemplateSource struct {
 
 	// Optional custom description (defaults to template description from config)
 	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Optional queue priority (higher runs first, defaults to 0)
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Optional: Return a run ID immediately and run in the background
+	Async bool `yaml:"async,omitempty" json:"async,omitempty"`
+
+	// Optional: How the result is returned (text, markdown, json, file; defaults to text)
+	Output OutputFormat `yaml:"output,omitempty" json:"output,omitempty"`
 }
 
 // ServerInfo contains metadata about the MCP server
@@ -120,6 +173,17 @@ type ToolExposure struct {
 
 	// Optional: Override template settings
 	Overrides *ToolOverrides `yaml:"overrides,omitempty" json:"overrides,omitempty"`
+
+	// Optional: Queue priority (higher runs first, defaults to 0)
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Optional: Return a run ID immediately and run in the background
+	// The result is fetched with get_run_result or posted to a callback URL
+	Async bool `yaml:"async,omitempty" json:"async,omitempty"`
+
+	// Optional: How the result is returned (text, markdown, json, file; defaults to text)
+	// file saves the result under the outputs directory and returns a link to it
+	Output OutputFormat `yaml:"output,omitempty" json:"output,omitempty"`
 }
 
 // ToolOverrides allows overriding template configuration per tool
@@ -176,6 +240,123 @@ type ProxyConfig struct {
 	TLS *TLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
 }
 
+// HTTPConfig defines the MCP Streamable HTTP transport for serve mode
+type HTTPConfig struct {
+	// Host to bind to (defaults to "127.0.0.1")
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+
+	// Port to listen on (defaults to 8080)
+	Port int `yaml:"port,omitempty" json:"port,omitempty"`
+
+	// Endpoint path (defaults to "/mcp")
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// Origins allowed to connect from browsers (defaults to localhost only, "*" allows any)
+	AllowedOrigins []string `yaml:"allowed_origins,omitempty" json:"allowed_origins,omitempty"`
+
+	// Idle time after which a session expires, e.g. "30m" (defaults to 30m)
+	SessionTimeout string `yaml:"session_timeout,omitempty" json:"session_timeout,omitempty"`
+
+	// TLS configuration (optional)
+	TLS *TLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
+
+	// Client authentication and per-client tool scoping (optional)
+	// When set, every request must present a configured token or certificate
+	Auth *HTTPAuthConfig `yaml:"auth,omitempty" json:"auth,omitempty"`
+
+	// Tenant profiles for a server shared by several teams (optional)
+	// When set, every request must belong to a tenant: the tenant of its
+	// client, or the one named by the Mcp-Tenant header without auth
+	Tenants map[string]TenantConfig `yaml:"tenants,omitempty" json:"tenants,omitempty"`
+}
+
+// TenantConfig isolates one tenant of a shared server: the providers it may
+// use with its own keys and budgets, the tools it may call, and where its
+// outputs are written
+type TenantConfig struct {
+	// Providers the tenant may use, by provider name from settings.yaml;
+	// providers not listed are unavailable to it
+	Providers map[string]TenantProviderConfig `yaml:"providers" json:"providers"`
+
+	// Tool names or glob patterns the tenant may list and call (defaults to all tools)
+	Tools []string `yaml:"tools,omitempty" json:"tools,omitempty"`
+
+	// Directory for the tenant's outputs (/outputs/ paths, saved results, run records)
+	OutputsDir string `yaml:"outputs_dir" json:"outputs_dir"`
+}
+
+// TenantProviderConfig replaces a provider's settings for one tenant
+type TenantProviderConfig struct {
+	// API key used for the tenant's calls (supports ${ENV_VAR}; defaults to the shared key)
+	APIKey string `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+
+	// API endpoint for the tenant's calls (defaults to the shared endpoint)
+	APIEndpoint string `yaml:"api_endpoint,omitempty" json:"api_endpoint,omitempty"`
+
+	// The tenant's monthly spend cap in USD for this provider (0: no cap)
+	MonthlyBudget float64 `yaml:"monthly_budget,omitempty" json:"monthly_budget,omitempty"`
+}
+
+// HTTPAuthConfig defines the clients allowed to use the HTTP transport
+type HTTPAuthConfig struct {
+	// CA bundle used to verify client certificates (enables mTLS; requires tls)
+	ClientCAFile string `yaml:"client_ca_file,omitempty" json:"client_ca_file,omitempty"`
+
+	// Authorized clients
+	Clients []HTTPClientConfig `yaml:"clients" json:"clients"`
+}
+
+// HTTPClientConfig defines one authorized client and the tools it may use
+type HTTPClientConfig struct {
+	// Client name (used in logs)
+	Name string `yaml:"name" json:"name"`
+
+	// Bearer token sent as "Authorization: Bearer <token>" (supports ${ENV_VAR})
+	Token string `yaml:"token,omitempty" json:"token,omitempty"`
+
+	// Common name of the client certificate (requires client_ca_file)
+	CertCommonName string `yaml:"cert_common_name,omitempty" json:"cert_common_name,omitempty"`
+
+	// Tool names or glob patterns the client may list and call (defaults to all tools)
+	Tools []string `yaml:"tools,omitempty" json:"tools,omitempty"`
+
+	// Tenant the client's requests belong to (required when tenants are configured)
+	Tenant string `yaml:"tenant,omitempty" json:"tenant,omitempty"`
+}
+
+// QueueConfig defines how many tool calls run at once and what happens to
+// calls that arrive while all slots are busy
+type QueueConfig struct {
+	// Tool calls executing at once (defaults to 4)
+	MaxConcurrent int `yaml:"max_concurrent,omitempty" json:"max_concurrent,omitempty"`
+
+	// Tool calls waiting for a slot (defaults to 32)
+	MaxDepth int `yaml:"max_depth,omitempty" json:"max_depth,omitempty"`
+
+	// Behavior when busy: "queue" waits up to max_depth, "reject" never waits,
+	// "shed" drops the lowest priority waiting call for a higher priority one (defaults to queue)
+	Backpressure string `yaml:"backpressure,omitempty" json:"backpressure,omitempty"`
+
+	// Longest a call waits for a slot before failing, e.g. "2m" (optional, no limit by default)
+	MaxWait string `yaml:"max_wait,omitempty" json:"max_wait,omitempty"`
+}
+
+// AsyncConfig defines how results of async tool runs are kept and delivered
+type AsyncConfig struct {
+	// URL every finished async run is POSTed to (optional, supports ${ENV_VAR})
+	CallbackURL string `yaml:"callback_url,omitempty" json:"callback_url,omitempty"`
+
+	// URL prefixes clients may pass as a callback_url argument
+	// Optional - clients cannot choose a callback URL when empty
+	AllowedCallbackURLs []string `yaml:"allowed_callback_urls,omitempty" json:"allowed_callback_urls,omitempty"`
+
+	// Secret signing callback bodies in the X-MCP-Signature header (optional, supports ${ENV_VAR})
+	CallbackSecret string `yaml:"callback_secret,omitempty" json:"callback_secret,omitempty"`
+
+	// How long results stay available to get_run_result, e.g. "1h" (defaults to 1h)
+	ResultTTL string `yaml:"result_ttl,omitempty" json:"result_ttl,omitempty"`
+}
+
 // TLSConfig defines TLS/HTTPS configuration
 type TLSConfig struct {
 	// Path to certificate file
@@ -220,6 +401,34 @@ func (c *RunAsConfig) Validate() error {
 		}
 	}
 
+	// HTTP transport applies to the MCP server types only
+	if c.HTTP != nil {
+		if c.RunAsType != RunAsTypeMCP && c.RunAsType != RunAsTypeMCPSkills {
+			return fmt.Errorf("http is only supported for runas_type 'mcp' and 'mcp-skills' (proxy types use proxy_config)")
+		}
+		if err := c.HTTP.Validate(); err != nil {
+			return fmt.Errorf("invalid http: %w", err)
+		}
+	}
+
+	if c.Queue != nil {
+		if c.RunAsType != RunAsTypeMCP && c.RunAsType != RunAsTypeMCPSkills {
+			return fmt.Errorf("queue is only supported for runas_type 'mcp' and 'mcp-skills'")
+		}
+		if err := c.Queue.Validate(); err != nil {
+			return fmt.Errorf("invalid queue: %w", err)
+		}
+	}
+
+	if c.Async != nil {
+		if c.RunAsType != RunAsTypeMCP && c.RunAsType != RunAsTypeMCPSkills {
+			return fmt.Errorf("async is only supported for runas_type 'mcp' and 'mcp-skills'")
+		}
+		if err := c.Async.Validate(); err != nil {
+			return fmt.Errorf("invalid async: %w", err)
+		}
+	}
+
 	// Type-specific validation
 	if c.RunAsType == RunAsTypeMCP {
 		// MCP type requires either tools array or templates array
@@ -232,6 +441,9 @@ func (c *RunAsConfig) Validate() error {
 			if template.ConfigSource == "" {
 				return fmt.Errorf("template at index %d missing config_source", i)
 			}
+			if err := template.Output.Validate(); err != nil {
+				return fmt.Errorf("template at index %d: %w", i, err)
+			}
 		}
 
 		// Validate each tool
@@ -350,6 +562,10 @@ func (t *ToolExposure) Validate() error {
 		return fmt.Errorf("tool name is required")
 	}
 
+	if err := t.Output.Validate(); err != nil {
+		return err
+	}
+
 	// Description is optional - will be auto-generated if not provided
 	// InputSchema is optional - will be auto-generated if not provided
 
@@ -465,3 +681,241 @@ func (p *ProxyConfig) Validate() error {
 
 	return nil
 }
+
+// Validate validates the HTTPConfig and applies defaults
+func (h *HTTPConfig) Validate() error {
+	if h.Host == "" {
+		h.Host = "127.0.0.1"
+	}
+
+	if h.Port == 0 {
+		h.Port = 8080
+	}
+
+	if h.Path == "" {
+		h.Path = "/mcp"
+	}
+
+	if h.Port < 1 || h.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535, got: %d", h.Port)
+	}
+
+	if !strings.HasPrefix(h.Path, "/") {
+		return fmt.Errorf("path must start with '/', got: %s", h.Path)
+	}
+
+	if h.SessionTimeout != "" {
+		if d, err := time.ParseDuration(h.SessionTimeout); err != nil || d <= 0 {
+			return fmt.Errorf("invalid session_timeout '%s': use a positive duration like 30m", h.SessionTimeout)
+		}
+	}
+
+	if h.TLS != nil {
+		if h.TLS.CertFile == "" {
+			return fmt.Errorf("tls.cert_file is required when TLS is enabled")
+		}
+		if h.TLS.KeyFile == "" {
+			return fmt.Errorf("tls.key_file is required when TLS is enabled")
+		}
+	}
+
+	if h.Auth != nil {
+		if err := h.Auth.Validate(h.TLS != nil); err != nil {
+			return fmt.Errorf("invalid auth: %w", err)
+		}
+	}
+
+	for name, tenant := range h.Tenants {
+		if err := tenant.Validate(); err != nil {
+			return fmt.Errorf("tenant '%s': %w", name, err)
+		}
+	}
+	if h.Auth != nil {
+		for _, client := range h.Auth.Clients {
+			if client.Tenant == "" && len(h.Tenants) > 0 {
+				return fmt.Errorf("client '%s' requires a tenant when tenants are configured", client.Name)
+			}
+			if _, ok := h.Tenants[client.Tenant]; client.Tenant != "" && !ok {
+				return fmt.Errorf("client '%s': unknown tenant '%s'", client.Name, client.Tenant)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the TenantConfig
+func (t *TenantConfig) Validate() error {
+	if len(t.Providers) == 0 {
+		return fmt.Errorf("at least one provider is required")
+	}
+	if t.OutputsDir == "" {
+		return fmt.Errorf("outputs_dir is required")
+	}
+	for name, provider := range t.Providers {
+		if provider.MonthlyBudget < 0 {
+			return fmt.Errorf("provider '%s': monthly_budget must not be negative", name)
+		}
+	}
+	for _, pattern := range t.Tools {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tool pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+// Validate validates the HTTPAuthConfig. tlsEnabled reports whether the
+// transport serves HTTPS, which client certificates require.
+func (a *HTTPAuthConfig) Validate(tlsEnabled bool) error {
+	if len(a.Clients) == 0 {
+		return fmt.Errorf("at least one client is required")
+	}
+
+	if a.ClientCAFile != "" && !tlsEnabled {
+		return fmt.Errorf("client_ca_file requires tls")
+	}
+
+	names := make(map[string]bool)
+	for i, client := range a.Clients {
+		if client.Name == "" {
+			return fmt.Errorf("client at index %d missing name", i)
+		}
+		if names[client.Name] {
+			return fmt.Errorf("duplicate client name: %s", client.Name)
+		}
+		names[client.Name] = true
+
+		if client.Token == "" && client.CertCommonName == "" {
+			return fmt.Errorf("client '%s' requires a token or cert_common_name", client.Name)
+		}
+		if client.CertCommonName != "" && a.ClientCAFile == "" {
+			return fmt.Errorf("client '%s': cert_common_name requires client_ca_file", client.Name)
+		}
+		for _, pattern := range client.Tools {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("client '%s': invalid tool pattern '%s'", client.Name, pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the QueueConfig
+func (q *QueueConfig) Validate() error {
+	if q.MaxConcurrent < 0 {
+		return fmt.Errorf("max_concurrent must not be negative, got: %d", q.MaxConcurrent)
+	}
+	if q.MaxDepth < 0 {
+		return fmt.Errorf("max_depth must not be negative, got: %d", q.MaxDepth)
+	}
+
+	switch q.Backpressure {
+	case "", "queue", "reject", "shed":
+	default:
+		return fmt.Errorf("invalid backpressure '%s' (valid: queue, reject, shed)", q.Backpressure)
+	}
+
+	if q.MaxWait != "" {
+		if d, err := time.ParseDuration(q.MaxWait); err != nil || d <= 0 {
+			return fmt.Errorf("invalid max_wait '%s': use a positive duration like 2m", q.MaxWait)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the AsyncConfig
+func (a *AsyncConfig) Validate() error {
+	if a.CallbackURL != "" {
+		if err := validateCallbackURL(a.CallbackURL); err != nil {
+			return fmt.Errorf("callback_url: %w", err)
+		}
+	}
+	for _, prefix := range a.AllowedCallbackURLs {
+		if err := validateCallbackURL(prefix); err != nil {
+			return fmt.Errorf("allowed_callback_urls: %w", err)
+		}
+	}
+
+	if a.ResultTTL != "" {
+		if d, err := time.ParseDuration(a.ResultTTL); err != nil || d <= 0 {
+			return fmt.Errorf("invalid result_ttl '%s': use a positive duration like 1h", a.ResultTTL)
+		}
+	}
+
+	return nil
+}
+
+// GetResultTTL returns how long async results are kept, with default fallback
+func (a *AsyncConfig) GetResultTTL() time.Duration {
+	if a != nil {
+		if d, err := time.ParseDuration(a.ResultTTL); err == nil && d > 0 {
+			return d
+		}
+	}
+	return time.Hour
+}
+
+// CallbackAllowed reports whether a client may ask for results at
+// callbackURL: it must have the scheme and host of an allowed URL, and a
+// path at or below the allowed path. Comparing parsed URLs keeps
+// "https://hooks.example.com" from allowing "https://hooks.example.com.attacker.net".
+func (a *AsyncConfig) CallbackAllowed(callbackURL string) bool {
+	if a == nil || validateCallbackURL(callbackURL) != nil {
+		return false
+	}
+	requested, _ := url.Parse(callbackURL)
+	requestedPath := path.Clean("/" + requested.Path)
+	for _, prefix := range a.AllowedCallbackURLs {
+		allowed, err := url.Parse(prefix)
+		if err != nil || allowed.Scheme != requested.Scheme || !strings.EqualFold(allowed.Host, requested.Host) {
+			continue
+		}
+		allowedPath := strings.TrimSuffix(path.Clean("/"+allowed.Path), "/")
+		if requestedPath == allowedPath || strings.HasPrefix(requestedPath, allowedPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func validateCallbackURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("'%s' is not an http(s) URL", rawURL)
+	}
+	return nil
+}
+
+// HasAsyncTools reports whether any tool runs asynchronously
+func (c *RunAsConfig) HasAsyncTools() bool {
+	for i := range c.Tools {
+		if c.Tools[i].Async {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMaxWait returns the queue wait limit, or 0 for none
+func (q *QueueConfig) GetMaxWait() time.Duration {
+	if d, err := time.ParseDuration(q.MaxWait); err == nil && d > 0 {
+		return d
+	}
+	return 0
+}
+
+// GetSessionTimeout returns the session idle timeout with default fallback
+func (h *HTTPConfig) GetSessionTimeout() time.Duration {
+	if d, err := time.ParseDuration(h.SessionTimeout); err == nil && d > 0 {
+		return d
+	}
+	return 30 * time.Minute
+}
+
+// Addr returns the host:port listen address
+func (h *HTTPConfig) Addr() string {
+	return net.JoinHostPort(h.Host, strconv.Itoa(h.Port))
+}
@@ -47,6 +47,30 @@ func (l *Loader) expandEnvVars(config *RunAsConfig) {
 	if config.ProxyConfig != nil {
 		config.ProxyConfig.APIKey = expandEnvVar(config.ProxyConfig.APIKey)
 	}
+
+	// Expand async callback settings
+	if config.Async != nil {
+		config.Async.CallbackURL = expandEnvVar(config.Async.CallbackURL)
+		config.Async.CallbackSecret = expandEnvVar(config.Async.CallbackSecret)
+	}
+
+	// Expand HTTP client tokens
+	if config.HTTP != nil && config.HTTP.Auth != nil {
+		for i := range config.HTTP.Auth.Clients {
+			config.HTTP.Auth.Clients[i].Token = expandEnvVar(config.HTTP.Auth.Clients[i].Token)
+		}
+	}
+
+	// Expand tenant provider keys
+	if config.HTTP != nil {
+		for _, tenant := range config.HTTP.Tenants {
+			for name, provider := range tenant.Providers {
+				provider.APIKey = expandEnvVar(provider.APIKey)
+				provider.APIEndpoint = expandEnvVar(provider.APIEndpoint)
+				tenant.Providers[name] = provider
+			}
+		}
+	}
 }
 
 // expandEnvVar expands environment variables in a string
@@ -0,0 +1,155 @@
+// Package schema generates JSON Schema documents for the YAML configuration
+// files (workflows, runas, servers and providers) so editors such as VS Code
+// can offer autocomplete and validation via yaml-language-server.
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect used by generated schemas
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// generator builds a schema from Go types by reflecting over their yaml tags.
+// Named struct types are emitted once under "definitions" and referenced with
+// $ref, which keeps recursive types finite.
+type generator struct {
+	definitions map[string]interface{}
+	names       map[reflect.Type]string
+	enums       map[reflect.Type][]string
+}
+
+func newGenerator(enums map[reflect.Type][]string) *generator {
+	return &generator{
+		definitions: make(map[string]interface{}),
+		names:       make(map[reflect.Type]string),
+		enums:       enums,
+	}
+}
+
+// schemaFor returns the schema for t, registering struct definitions as needed
+func (g *generator) schemaFor(t reflect.Type) map[string]interface{} {
+	if t == durationType {
+		return map[string]interface{}{
+			"type":        []string{"string", "integer"},
+			"description": "Duration such as 30s, 5m or 1h30m",
+		}
+	}
+
+	if values, ok := g.enums[t]; ok {
+		return map[string]interface{}{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schemaFor(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + g.define(t)}
+	default:
+		// interface{} and anything else accept any value
+		return map[string]interface{}{}
+	}
+}
+
+// define registers a named struct under definitions and returns its key
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.definitions[name]; taken {
+		// Same type name from another package
+		parts := strings.Split(t.PkgPath(), "/")
+		name = parts[len(parts)-1] + "." + name
+	}
+
+	g.names[t] = name
+	g.definitions[name] = map[string]interface{}{} // placeholder for recursion
+	g.definitions[name] = g.structSchema(t)
+	return name
+}
+
+// structSchema builds an object schema from a struct's yaml-tagged fields.
+// Unknown properties are rejected to match the loader's strict decoding.
+func (g *generator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	g.collectFields(t, properties)
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// collectFields adds the properties of t to properties, flattening inline structs
+func (g *generator) collectFields(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, inline, skip := parseYAMLTag(field)
+		if skip {
+			continue
+		}
+
+		if inline {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.collectFields(ft, properties)
+			}
+			continue
+		}
+
+		properties[name] = g.schemaFor(field.Type)
+	}
+}
+
+// parseYAMLTag returns the YAML key for a field, whether it is inlined, and
+// whether it is excluded from YAML entirely
+func parseYAMLTag(field reflect.StructField) (name string, inline bool, skip bool) {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+
+	name = parts[0]
+	if name == "" {
+		// yaml.v3 default: lowercased field name
+		name = strings.ToLower(field.Name)
+	}
+	return name, inline, false
+}
@@ -0,0 +1,167 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
+)
+
+// BaseURL is where the published schemas are served from. Editors resolve
+// the $id of each schema against it.
+const BaseURL = "https://raw.githubusercontent.com/LaurieRhodes/mcp-cli-go/main/schemas/"
+
+// providerFile mirrors the layout of config/providers/*.yaml
+type providerFile struct {
+	InterfaceType config.InterfaceType  `yaml:"interface_type"`
+	ProviderName  string                `yaml:"provider_name"`
+	Config        config.ProviderConfig `yaml:"config"`
+}
+
+// serverFile mirrors the layout of config/servers/*.yaml
+type serverFile struct {
+	ServerName string              `yaml:"server_name"`
+	Config     config.ServerConfig `yaml:"config"`
+}
+
+// kind describes one schema-backed YAML file type
+type kind struct {
+	title       string
+	description string
+	root        interface{}
+	required    []string
+	patch       func(properties map[string]interface{})
+}
+
+var kinds = map[string]kind{
+	"workflow": {
+		title:       "mcp-cli workflow",
+		description: "Workflow v2.0 definition (config/workflows/*.yaml)",
+		root:        config.WorkflowV2{},
+		required:    []string{"$schema", "name"},
+		patch: func(properties map[string]interface{}) {
+			properties["$schema"] = map[string]interface{}{
+				"const":       "workflow/v2.0",
+				"description": "Workflow schema identifier",
+			}
+			properties["spec_version"] = map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{config.BaselineSpecVersion, config.CurrentSpecVersion},
+				"default":     config.CurrentSpecVersion,
+				"description": "Workflow spec revision; older revisions are migrated on load",
+			}
+		},
+	},
+	"runas": {
+		title:       "mcp-cli runas",
+		description: "Serve configuration exposing workflows or skills as an MCP server (config/runas/*.yaml)",
+		root:        runas.RunAsConfig{},
+		required:    []string{"runas_type"},
+	},
+	"server": {
+		title:       "mcp-cli server",
+		description: "MCP server definition (config/servers/*.yaml)",
+		root:        serverFile{},
+		required:    []string{"server_name", "config"},
+	},
+	"provider": {
+		title:       "mcp-cli provider",
+		description: "LLM provider definition (config/providers/*.yaml)",
+		root:        providerFile{},
+		required:    []string{"interface_type", "provider_name", "config"},
+	},
+	"prompt": {
+		title:       "mcp-cli prompt",
+		description: "Named, versioned prompt template (config/prompts/*.yaml)",
+		root:        config.PromptTemplate{},
+		required:    []string{"name", "template"},
+	},
+	"agent": {
+		title:       "mcp-cli agent",
+		description: "Reusable step persona (config/agents/*.yaml)",
+		root:        config.AgentConfig{},
+		required:    []string{"name"},
+	},
+	"config": {
+		title:       "mcp-cli config",
+		description: "Main configuration file (config.yaml)",
+		root:        config.MainConfigFile{},
+	},
+}
+
+// enums lists the allowed values of string-typed enumerations
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(config.InterfaceType("")): {
+		string(config.OpenAICompatible), string(config.AnthropicNative), string(config.OllamaNative),
+		string(config.GeminiNative), string(config.AzureOpenAI), string(config.AWSBedrock), string(config.GCPVertexAI),
+		string(config.External),
+	},
+	reflect.TypeOf(config.SystemPromptMode("")): {
+		string(config.SystemPromptAppend), string(config.SystemPromptPrepend), string(config.SystemPromptReplace),
+	},
+	reflect.TypeOf(runas.RunAsType("")): {
+		string(runas.RunAsTypeMCP), string(runas.RunAsTypeMCPSkills),
+		string(runas.RunAsTypeProxy), string(runas.RunAsTypeProxySkills),
+	},
+	reflect.TypeOf(runas.OutputFormat("")): {
+		string(runas.OutputText), string(runas.OutputMarkdown),
+		string(runas.OutputJSON), string(runas.OutputFile),
+	},
+}
+
+// Kinds returns the names of all schemas that can be generated, sorted
+func Kinds() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FileName returns the file name a schema is published under
+func FileName(name string) string {
+	return name + ".schema.json"
+}
+
+// Generate returns the JSON Schema for the named kind as a map
+func Generate(name string) (map[string]interface{}, error) {
+	k, ok := kinds[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %q (available: %v)", name, Kinds())
+	}
+
+	g := newGenerator(enums)
+	root := g.structSchema(reflect.TypeOf(k.root))
+	if k.patch != nil {
+		k.patch(root["properties"].(map[string]interface{}))
+	}
+
+	root["$schema"] = Draft
+	root["$id"] = BaseURL + FileName(name)
+	root["title"] = k.title
+	root["description"] = k.description
+	if len(k.required) > 0 {
+		root["required"] = k.required
+	}
+	if len(g.definitions) > 0 {
+		root["definitions"] = g.definitions
+	}
+	return root, nil
+}
+
+// GenerateJSON returns the indented JSON Schema document for the named kind
+func GenerateJSON(name string) ([]byte, error) {
+	doc, err := Generate(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s schema: %w", name, err)
+	}
+	return append(data, '\n'), nil
+}
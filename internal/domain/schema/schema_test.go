@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the published schemas in schemas/")
+
+// TestPublishedSchemasUpToDate keeps schemas/*.schema.json in sync with the
+// Go types. Regenerate with: go test ./internal/domain/schema -update
+func TestPublishedSchemasUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "schemas")
+	for _, name := range Kinds() {
+		want, err := GenerateJSON(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, FileName(name))
+
+		if *update {
+			if err := os.WriteFile(path, want, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("published schema missing: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run: go test ./internal/domain/schema -update", path)
+		}
+	}
+}
+
+func TestGenerateAllKinds(t *testing.T) {
+	for _, name := range Kinds() {
+		t.Run(name, func(t *testing.T) {
+			data, err := GenerateJSON(name)
+			if err != nil {
+				t.Fatalf("GenerateJSON(%q) error: %v", name, err)
+			}
+			var doc map[string]interface{}
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("schema is not valid JSON: %v", err)
+			}
+			if doc["$schema"] != Draft {
+				t.Errorf("$schema = %v, want %s", doc["$schema"], Draft)
+			}
+		})
+	}
+}
+
+func TestGenerateUnknownKind(t *testing.T) {
+	if _, err := Generate("nope"); err == nil {
+		t.Error("expected error for unknown schema kind")
+	}
+}
+
+func TestWorkflowSchema(t *testing.T) {
+	doc, err := Generate("workflow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	props := doc["properties"].(map[string]interface{})
+	if props["$schema"].(map[string]interface{})["const"] != "workflow/v2.0" {
+		t.Error("$schema property should be pinned to workflow/v2.0")
+	}
+
+	defs := doc["definitions"].(map[string]interface{})
+	step, ok := defs["StepV2"].(map[string]interface{})
+	if !ok {
+		t.Fatal("StepV2 definition missing")
+	}
+	stepProps := step["properties"].(map[string]interface{})
+	for _, key := range []string{"name", "run", "needs", "loop", "rag", "timeout"} {
+		if _, ok := stepProps[key]; !ok {
+			t.Errorf("step property %q missing", key)
+		}
+	}
+	if step["additionalProperties"] != false {
+		t.Error("steps should reject unknown properties")
+	}
+
+	timeout := stepProps["timeout"].(map[string]interface{})
+	if types, ok := timeout["type"].([]string); !ok || len(types) != 2 {
+		t.Errorf("timeout should accept duration strings, got %v", timeout["type"])
+	}
+}
+
+func TestProviderInterfaceEnum(t *testing.T) {
+	doc, err := Generate("provider")
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := doc["properties"].(map[string]interface{})
+	iface := props["interface_type"].(map[string]interface{})
+	if values, ok := iface["enum"].([]string); !ok || len(values) == 0 {
+		t.Error("interface_type should be an enum")
+	}
+}
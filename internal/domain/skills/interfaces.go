@@ -38,6 +38,9 @@ type SkillLoader interface {
 
 	// LoadAsPassive loads skill in passive mode (as context)
 	LoadAsPassive(skill *Skill, request *SkillLoadRequest) (*SkillLoadResult, error)
+
+	// ReadReference reads a reference file, or a section or byte range of it
+	ReadReference(request *ReferenceReadRequest) (*ReferenceReadResult, error)
 }
 
 // SkillExecutor defines the interface for executing skill workflows
@@ -189,3 +189,96 @@ type HelperScriptRequest struct {
 	ScriptName string   // Script filename (e.g., "process_chunk.py")
 	Args       []string // Command-line arguments
 }
+
+// ReferenceReadRequest represents a request to read a reference file, or
+// part of one, on demand
+type ReferenceReadRequest struct {
+	SkillName string `json:"skill_name"`
+	Reference string `json:"reference"`         // File name as listed in the skill's reference index
+	Section   string `json:"section,omitempty"` // Heading to read, up to the next heading at the same or higher level
+	Offset    int    `json:"offset,omitempty"`  // Byte offset into the file, or into the section
+	Length    int    `json:"length,omitempty"`  // Bytes to read (default and maximum: ReferenceReadLimit)
+}
+
+// ReferenceReadLimit is the most a single reference read returns, so one
+// call can't fill the context window
+const ReferenceReadLimit = 32 * 1024
+
+// ReferenceReadResult represents part of a reference file
+type ReferenceReadResult struct {
+	SkillName  string `json:"skill_name"`
+	Reference  string `json:"reference"`
+	Section    string `json:"section,omitempty"`
+	Content    string `json:"content"`
+	Offset     int    `json:"offset"`
+	TotalSize  int    `json:"total_size"`            // Size of the file, or of the section
+	NextOffset int    `json:"next_offset,omitempty"` // Set when more content follows
+}
+
+// Text returns the content with a note on how to continue reading when
+// the result stops short of the end
+func (r *ReferenceReadResult) Text() string {
+	if r.NextOffset == 0 {
+		return r.Content
+	}
+	section := ""
+	if r.Section != "" {
+		section = fmt.Sprintf(", section='%s'", r.Section)
+	}
+	return fmt.Sprintf("%s\n\n[Showing bytes %d-%d of %d. Continue with read_skill_reference skill_name='%s', reference='%s'%s, offset=%d]",
+		r.Content, r.Offset, r.NextOffset, r.TotalSize, r.SkillName, r.Reference, section, r.NextOffset)
+}
+
+// ReadReferenceToolDescription describes the read_skill_reference tool.
+// Skill tools only list their reference files, so every place that exposes
+// skills as tools also adds read_skill_reference for reading them on demand.
+const ReadReferenceToolDescription = "[SKILL REFERENCE] Read one of a skill's reference files on demand. " +
+	"Skill tools list their reference files with sizes and headings instead of loading them. " +
+	"Read only what you need: pass section to get the part under one heading, " +
+	"or offset and length for a byte range. Long results end with the offset to continue from."
+
+// ReadReferenceInputSchema returns the JSON Schema for the read_skill_reference tool
+func ReadReferenceInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"skill_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the skill (e.g., 'docx', 'pdf')",
+			},
+			"reference": map[string]interface{}{
+				"type":        "string",
+				"description": "Reference file name from the skill's reference index (e.g., 'ooxml.md')",
+			},
+			"section": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional heading to read, e.g. 'Tables'. Returns that section up to the next heading at the same level",
+			},
+			"offset": map[string]interface{}{
+				"type":        "integer",
+				"description": "Optional byte offset to start from (within the section if one is given)",
+			},
+			"length": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Optional number of bytes to read (max %d)", ReferenceReadLimit),
+			},
+		},
+		"required": []string{"skill_name", "reference"},
+	}
+}
+
+// ReferenceReadRequestFromArguments builds a request from tool call
+// arguments, where numbers arrive as float64
+func ReferenceReadRequestFromArguments(arguments map[string]interface{}) *ReferenceReadRequest {
+	request := &ReferenceReadRequest{}
+	request.SkillName, _ = arguments["skill_name"].(string)
+	request.Reference, _ = arguments["reference"].(string)
+	request.Section, _ = arguments["section"].(string)
+	if offset, ok := arguments["offset"].(float64); ok {
+		request.Offset = int(offset)
+	}
+	if length, ok := arguments["length"].(float64); ok {
+		request.Length = int(length)
+	}
+	return request
+}
@@ -1,5 +1,11 @@
 package skills
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // ExecutionMode determines how scripts are executed
 type ExecutionMode string
 
@@ -21,12 +27,42 @@ type ScriptExecution struct {
 	Timeout    int      // Timeout in seconds (0 = use default)
 }
 
+// ResultFileName is the file in /outputs code writes to return structured
+// data from execute_skill_code
+const ResultFileName = "result.json"
+
 // ExecutionResult represents the result of script execution
 type ExecutionResult struct {
-	Output   string // Combined stdout/stderr
-	ExitCode int    // Exit code (0 = success)
-	Error    error  // Error if execution failed
-	Duration int64  // Execution time in milliseconds
+	Output   string          // Combined stdout/stderr
+	Stdout   string          // Captured stdout, when the executor keeps the streams apart
+	Stderr   string          // Captured stderr, when the executor keeps the streams apart
+	Result   json.RawMessage // JSON the code wrote to /outputs/result.json
+	ExitCode int             // Exit code (0 = success)
+	Error    error           // Error if execution failed
+	Duration int64           // Execution time in milliseconds
+}
+
+// Text returns the result for a model: stdout, stderr and the structured
+// result in separate sections, so warnings aren't mistaken for data
+func (r *ExecutionResult) Text() string {
+	if r.Stdout == "" && r.Stderr == "" && r.Result == nil {
+		return r.Output
+	}
+	var sb strings.Builder
+	section := func(title, content string) {
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		fmt.Fprintf(&sb, "=== %s ===\n%s", title, strings.TrimRight(content, "\n"))
+	}
+	section("stdout", r.Stdout)
+	if r.Stderr != "" {
+		section("stderr", r.Stderr)
+	}
+	if r.Result != nil {
+		section("result (/outputs/"+ResultFileName+")", string(r.Result))
+	}
+	return sb.String()
 }
 
 // CodeExecutionRequest represents a request to execute arbitrary code with skill context
@@ -37,3 +73,12 @@ type CodeExecutionRequest struct {
 	Files     map[string][]byte // Optional files to make available in workspace
 	Timeout   int               // Timeout in seconds (0 = use default)
 }
+
+// UploadedFile is a host file copied into the sandbox workspace for the
+// rest of a chat session
+type UploadedFile struct {
+	Name          string // File name in the workspace
+	HostPath      string // Absolute path it was uploaded from
+	ContainerPath string // Path the code sees, /workspace/<name>
+	Size          int64  // Size in bytes
+}
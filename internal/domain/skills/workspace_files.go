@@ -0,0 +1,180 @@
+package skills
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ManifestFileName is the workspace file describing the files passed to
+// execute_skill_code, so code can check types instead of guessing from
+// extensions
+const ManifestFileName = "_manifest.json"
+
+// WorkspaceManifest lists the files in an execute_skill_code workspace
+type WorkspaceManifest struct {
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes one workspace file
+type ManifestFile struct {
+	Name     string `json:"name"`      // Name as passed, relative to /workspace
+	Path     string `json:"path"`      // Path the code opens, /workspace/<name>
+	Size     int    `json:"size"`      // Size in bytes, after decoding
+	MIMEType string `json:"mime_type"` // Detected from the content, then the extension
+	Text     bool   `json:"text"`      // UTF-8 text, safe to open in text mode
+	SHA256   string `json:"sha256"`
+}
+
+// DecodeFiles reads the files argument of execute_skill_code, a map of
+// file name to content. See DecodeFileContent for the accepted encodings.
+func DecodeFiles(arg interface{}) map[string][]byte {
+	filesArg, ok := arg.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	files := make(map[string][]byte, len(filesArg))
+	for name, content := range filesArg {
+		if text, ok := content.(string); ok {
+			files[name] = DecodeFileContent(text)
+		}
+	}
+	return files
+}
+
+// DecodeFileContent decodes file content passed by a model: a data: URI,
+// base64, or plain text. Base64 is only assumed when the content decodes to
+// text, a recognizable file format, or is too long to be a plain word.
+func DecodeFileContent(content string) []byte {
+	if strings.HasPrefix(content, "data:") {
+		if comma := strings.IndexByte(content, ','); comma > 0 {
+			if strings.HasSuffix(content[:comma], ";base64") {
+				if data, err := base64.StdEncoding.DecodeString(compactBase64(content[comma+1:])); err == nil {
+					return data
+				}
+			} else if data, err := url.PathUnescape(content[comma+1:]); err == nil {
+				return []byte(data)
+			}
+		}
+	}
+
+	compact := compactBase64(content)
+	if len(compact) < 4 || len(compact)%4 != 0 {
+		return []byte(content)
+	}
+	data, err := base64.StdEncoding.DecodeString(compact)
+	if err != nil {
+		return []byte(content)
+	}
+	// Short words like "true" are valid base64 too, decoding to noise that
+	// only sniffs as plain text or octet-stream
+	if len(compact) >= 64 || IsText(data) {
+		return data
+	}
+	if sniffed := http.DetectContentType(data); sniffed != "application/octet-stream" && !strings.HasPrefix(sniffed, "text/plain") {
+		return data
+	}
+	return []byte(content)
+}
+
+// compactBase64 drops the line breaks base64 is often wrapped with
+func compactBase64(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+}
+
+// IsText reports whether data is UTF-8 text without control characters
+// other than tabs and line breaks
+func IsText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, b := range data {
+		if b < 0x20 && b != '\t' && b != '\n' && b != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+// extensionTypes are the types content sniffing can't tell apart: Office
+// documents sniff as zip, and CSV, Markdown and YAML as plain text
+var extensionTypes = map[string]string{
+	".docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".csv":   "text/csv",
+	".tsv":   "text/tab-separated-values",
+	".md":    "text/markdown",
+	".json":  "application/json",
+	".jsonl": "application/x-ndjson",
+	".yaml":  "application/yaml",
+	".yml":   "application/yaml",
+	".xml":   "application/xml",
+}
+
+// DetectMIMEType returns a file's MIME type, sniffed from its content. The
+// extension only refines what sniffing can't tell apart, so a misnamed file
+// keeps the type of its content.
+func DetectMIMEType(name string, data []byte) string {
+	sniffed := http.DetectContentType(data)
+	ext := strings.ToLower(path.Ext(name))
+	byExtension := extensionTypes[ext]
+	if byExtension == "" {
+		byExtension = mime.TypeByExtension(ext)
+	}
+	if byExtension == "" {
+		return sniffed
+	}
+
+	switch {
+	case sniffed == "application/octet-stream":
+		return byExtension
+	case sniffed == "application/zip" && strings.HasPrefix(byExtension, "application/vnd.openxmlformats"):
+		return byExtension
+	case strings.HasPrefix(sniffed, "text/plain") && isTextType(byExtension):
+		return byExtension
+	}
+	return sniffed
+}
+
+func isTextType(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "text/") || strings.HasSuffix(mimeType, "json") ||
+		strings.HasSuffix(mimeType, "yaml") || strings.HasSuffix(mimeType, "xml") || mimeType == "application/x-ndjson"
+}
+
+// BuildManifest describes workspace files, sorted by name
+func BuildManifest(files map[string][]byte) *WorkspaceManifest {
+	manifest := &WorkspaceManifest{Files: []ManifestFile{}}
+	for name, data := range files {
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, ManifestFile{
+			Name:     name,
+			Path:     "/workspace/" + path.Clean(strings.ReplaceAll(name, "\\", "/")),
+			Size:     len(data),
+			MIMEType: DetectMIMEType(name, data),
+			Text:     IsText(data),
+			SHA256:   hex.EncodeToString(sum[:]),
+		})
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].Name < manifest.Files[j].Name })
+	return manifest
+}
+
+// JSON returns the manifest as indented JSON
+func (m *WorkspaceManifest) JSON() []byte {
+	data, _ := json.MarshalIndent(m, "", "  ")
+	return append(data, '\n')
+}
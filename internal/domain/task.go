@@ -65,6 +65,13 @@ type TaskMetadata struct {
 	PollInterval int64 `json:"pollInterval,omitempty"`
 }
 
+// TaskOwner identifies who started a task on a shared server. Only the
+// same owner can see the task; the zero owner is used without authentication.
+type TaskOwner struct {
+	Client string // Authenticated client
+	Tenant string // Tenant the client belongs to
+}
+
 // Task represents a long-running operation
 type Task struct {
 	// Metadata
@@ -78,6 +85,7 @@ type Task struct {
 	// Request information
 	RequestMethod string
 	RequestParams json.RawMessage
+	Owner         TaskOwner
 
 	// Result information
 	Result      interface{}
@@ -0,0 +1,184 @@
+// Package auth obtains credentials for cloud APIs that the providers and
+// storage backends call directly over HTTP.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+const (
+	// GoogleCloudPlatformScope grants access to all Google Cloud APIs
+	GoogleCloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+	defaultGoogleTokenURI = "https://oauth2.googleapis.com/token"
+
+	// tokenRefreshMargin is how long before expiry a cached token is replaced
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+// GoogleServiceAccount holds the service account key fields used for the
+// JWT-bearer token exchange
+type GoogleServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// LoadGoogleServiceAccount reads a service account key file
+func LoadGoogleServiceAccount(path string) (*GoogleServiceAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account file: %w", err)
+	}
+
+	var sa GoogleServiceAccount
+	if err := json.Unmarshal(data, &sa); err != nil {
+		return nil, fmt.Errorf("failed to parse service account JSON: %w", err)
+	}
+	if sa.TokenURI == "" {
+		sa.TokenURI = defaultGoogleTokenURI
+	}
+
+	return &sa, nil
+}
+
+// GoogleTokenSource exchanges signed service account JWTs for OAuth2 access
+// tokens, caching each token until shortly before it expires
+type GoogleTokenSource struct {
+	account    *GoogleServiceAccount
+	scope      string
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewGoogleTokenSource creates a token source for the account and scope
+func NewGoogleTokenSource(account *GoogleServiceAccount, scope string, httpClient *http.Client) *GoogleTokenSource {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GoogleTokenSource{account: account, scope: scope, httpClient: httpClient}
+}
+
+// Token returns a valid access token, exchanging a new JWT when the cached
+// token is missing or about to expire
+func (s *GoogleTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(tokenRefreshMargin).Before(s.expiry) {
+		return s.token, nil
+	}
+
+	now := time.Now()
+	assertion, err := s.signJWT(now)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("OAuth2 token exchange failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("OAuth2 token exchange failed (%s): %s", resp.Status, string(body))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	s.token = tokenResp.AccessToken
+	s.expiry = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+
+	logging.Debug("Obtained OAuth2 access token for %s, expires at %v", s.account.ClientEmail, s.expiry)
+
+	return s.token, nil
+}
+
+// signJWT builds the RS256-signed assertion for the token exchange
+func (s *GoogleTokenSource) signJWT(now time.Time) (string, error) {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss":   s.account.ClientEmail,
+		"scope": s.scope,
+		"aud":   s.account.TokenURI,
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+	})
+
+	signInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	privateKey, err := parseRSAPrivateKey(s.account.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	hash := sha256.Sum256([]byte(signInput))
+	signature, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return signInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey parses a PKCS#8 RSA private key from PEM format
+func parseRSAPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse PEM block")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA private key")
+	}
+
+	return rsaKey, nil
+}
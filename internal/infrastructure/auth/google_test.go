@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestAccount returns a service account with a fresh RSA key and its public half
+func newTestAccount(t *testing.T, tokenURI string) (*GoogleServiceAccount, *rsa.PublicKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &GoogleServiceAccount{
+		ClientEmail: "runner@project.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		TokenURI:    tokenURI,
+	}, &key.PublicKey
+}
+
+// tokenServer answers token exchanges with numbered tokens, checking each
+// assertion against the account's public key
+func tokenServer(t *testing.T, publicKey *rsa.PublicKey, expiresIn int) (*httptest.Server, *int) {
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			http.Error(w, "bad grant", http.StatusBadRequest)
+			return
+		}
+
+		parts := strings.Split(r.Form.Get("assertion"), ".")
+		if len(parts) != 3 {
+			http.Error(w, "bad assertion", http.StatusBadRequest)
+			return
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature); err != nil {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claims map[string]interface{}
+		json.Unmarshal(payload, &claims)
+		if claims["iss"] != "runner@project.iam.gserviceaccount.com" || claims["scope"] != "test-scope" || claims["aud"] != "http://"+r.Host+"/token" {
+			http.Error(w, fmt.Sprintf("bad claims %v", claims), http.StatusUnauthorized)
+			return
+		}
+
+		exchanges++
+		fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":%d}`, exchanges, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &exchanges
+}
+
+func TestGoogleTokenSourceCachesToken(t *testing.T) {
+	account, publicKey := newTestAccount(t, "")
+	server, exchanges := tokenServer(t, publicKey, 3600)
+	account.TokenURI = server.URL + "/token"
+	source := NewGoogleTokenSource(account, "test-scope", server.Client())
+
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+		if token != "token-1" {
+			t.Errorf("call %d: Token = %q, want the cached token-1", i, token)
+		}
+	}
+	if *exchanges != 1 {
+		t.Errorf("exchanges = %d, want 1", *exchanges)
+	}
+}
+
+func TestGoogleTokenSourceRefreshesExpiringToken(t *testing.T) {
+	account, publicKey := newTestAccount(t, "")
+	server, exchanges := tokenServer(t, publicKey, 60) // inside the refresh margin
+	account.TokenURI = server.URL + "/token"
+	source := NewGoogleTokenSource(account, "test-scope", server.Client())
+
+	source.Token(context.Background())
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if token != "token-2" || *exchanges != 2 {
+		t.Errorf("Token = %q after %d exchanges, want token-2 after 2", token, *exchanges)
+	}
+}
+
+func TestGoogleTokenSourceErrors(t *testing.T) {
+	account, _ := newTestAccount(t, "")
+	otherAccount, otherKey := newTestAccount(t, "")
+	server, _ := tokenServer(t, otherKey, 3600)
+	account.TokenURI = server.URL + "/token"
+	otherAccount.TokenURI = server.URL + "/token"
+
+	tests := []struct {
+		name    string
+		account *GoogleServiceAccount
+		scope   string
+		want    string
+	}{
+		{"rejected signature", account, "test-scope", "401 Unauthorized"},
+		{"rejected claims", otherAccount, "other-scope", "bad claims"},
+		{"bad key", &GoogleServiceAccount{PrivateKey: "not pem", TokenURI: server.URL + "/token"}, "test-scope", "failed to parse PEM block"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGoogleTokenSource(tt.account, tt.scope, server.Client()).Token(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Token error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadGoogleServiceAccount(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sa.json")
+	os.WriteFile(path, []byte(`{"type":"service_account","project_id":"p1","client_email":"a@p1.iam","private_key":"KEY"}`), 0600)
+
+	account, err := LoadGoogleServiceAccount(path)
+	if err != nil {
+		t.Fatalf("LoadGoogleServiceAccount: %v", err)
+	}
+	want := GoogleServiceAccount{ProjectID: "p1", ClientEmail: "a@p1.iam", PrivateKey: "KEY", TokenURI: "https://oauth2.googleapis.com/token"}
+	if *account != want {
+		t.Errorf("account = %+v, want %+v", *account, want)
+	}
+
+	if _, err := LoadGoogleServiceAccount(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
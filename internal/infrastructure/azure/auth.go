@@ -0,0 +1,129 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// defaultAuthorityHost is the public cloud Azure AD endpoint
+const defaultAuthorityHost = "https://login.microsoftonline.com"
+
+// TokenSource obtains Azure AD access tokens using the client credentials flow
+type TokenSource struct {
+	cred       config.AzureCredentialConfig
+	scope      string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// NewTokenSource creates a token source for a resource scope
+// (e.g. "https://monitor.azure.com/.default")
+func NewTokenSource(cred config.AzureCredentialConfig, scope string, httpClient *http.Client) (*TokenSource, error) {
+	if cred.AccessToken == "" {
+		if cred.TenantID == "" || cred.ClientID == "" || cred.ClientSecret == "" {
+			return nil, fmt.Errorf("Azure tenant_id, client_id and client_secret are required (or access_token)")
+		}
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	ts := &TokenSource{cred: cred, scope: scope, httpClient: httpClient}
+	if cred.AccessToken != "" {
+		ts.accessToken = cred.AccessToken
+		ts.tokenExpiry = time.Now().Add(time.Hour)
+	}
+
+	return ts, nil
+}
+
+// Token returns a valid access token, refreshing it when it is about to expire
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.accessToken != "" && time.Now().Add(5*time.Minute).Before(ts.tokenExpiry) {
+		return ts.accessToken, nil
+	}
+
+	if ts.cred.ClientSecret == "" {
+		return "", fmt.Errorf("Azure access token expired and no client credentials are configured")
+	}
+
+	authority := ts.cred.AuthorityHost
+	if authority == "" {
+		authority = defaultAuthorityHost
+	}
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", strings.TrimSuffix(authority, "/"), url.PathEscape(ts.cred.TenantID))
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", ts.cred.ClientID)
+	form.Set("client_secret", ts.cred.ClientSecret)
+	form.Set("scope", ts.scope)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := ts.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Azure AD token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+			return "", fmt.Errorf("Azure AD token request failed (%s): %s", errResp.Error, firstLine(errResp.ErrorDescription))
+		}
+		return "", fmt.Errorf("Azure AD token request failed (%s)", resp.Status)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	ts.accessToken = tokenResp.AccessToken
+	ts.tokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+
+	logging.Debug("Obtained Azure AD token for scope %s, expires at %v", ts.scope, ts.tokenExpiry)
+
+	return ts.accessToken, nil
+}
+
+// firstLine trims multi-line Azure AD error descriptions (which include trace IDs)
+func firstLine(s string) string {
+	if idx := strings.IndexAny(s, "\r\n"); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
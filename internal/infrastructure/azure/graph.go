@@ -0,0 +1,239 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+const (
+	// defaultGraphEndpoint is the global Microsoft Graph endpoint
+	defaultGraphEndpoint = "https://graph.microsoft.com"
+
+	// maxThrottleRetries bounds retries on HTTP 429 responses
+	maxThrottleRetries = 3
+)
+
+// Graph security resources and their API paths
+var graphSecurityPaths = map[string]string{
+	"incidents": "/v1.0/security/incidents",
+	"alerts":    "/v1.0/security/alerts_v2",
+}
+
+// GraphQuery holds OData options for a list request
+type GraphQuery struct {
+	Filter   string
+	OrderBy  string
+	Expand   string
+	Select   string
+	PageSize int // $top per page
+	MaxItems int // Stop after this many items (0 = no limit)
+}
+
+// GraphClient calls Microsoft Graph with application permissions
+type GraphClient struct {
+	endpoint    string
+	httpClient  *http.Client
+	tokenSource *TokenSource
+}
+
+// NewGraphClient creates a Microsoft Graph client
+func NewGraphClient(cfg *config.GraphConfig) (*GraphClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("graph configuration is required")
+	}
+
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = defaultGraphEndpoint
+	}
+
+	timeout := 60 * time.Second
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	httpClient := &http.Client{Timeout: timeout}
+
+	tokenSource, err := NewTokenSource(cfg.AzureCredentialConfig, endpoint+"/.default", httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphClient{endpoint: endpoint, httpClient: httpClient, tokenSource: tokenSource}, nil
+}
+
+// ListSecurity lists security incidents or alerts, following @odata.nextLink paging.
+// The returned bool reports whether results were cut off by MaxItems.
+func (c *GraphClient) ListSecurity(ctx context.Context, resource string, query GraphQuery) ([]map[string]interface{}, bool, error) {
+	path, ok := graphSecurityPaths[resource]
+	if !ok {
+		return nil, false, fmt.Errorf("unsupported graph security resource '%s' (supported: incidents, alerts)", resource)
+	}
+
+	params := url.Values{}
+	if query.Filter != "" {
+		params.Set("$filter", query.Filter)
+	}
+	if query.OrderBy != "" {
+		params.Set("$orderby", query.OrderBy)
+	}
+	if query.Expand != "" {
+		params.Set("$expand", query.Expand)
+	}
+	if query.Select != "" {
+		params.Set("$select", query.Select)
+	}
+	if query.PageSize > 0 {
+		params.Set("$top", strconv.Itoa(query.PageSize))
+	}
+
+	nextURL := c.endpoint + path
+	if encoded := params.Encode(); encoded != "" {
+		// Graph expects %20 rather than + for spaces in OData expressions
+		nextURL += "?" + strings.ReplaceAll(encoded, "+", "%20")
+	}
+
+	var items []map[string]interface{}
+	page := 0
+	for nextURL != "" {
+		page++
+
+		var pageResp struct {
+			Value    []map[string]interface{} `json:"value"`
+			NextLink string                   `json:"@odata.nextLink"`
+		}
+		if err := c.getJSON(ctx, nextURL, &pageResp); err != nil {
+			return nil, false, fmt.Errorf("page %d: %w", page, err)
+		}
+
+		items = append(items, pageResp.Value...)
+		logging.Debug("Graph %s page %d: %d items (total %d)", resource, page, len(pageResp.Value), len(items))
+
+		if query.MaxItems > 0 && len(items) >= query.MaxItems {
+			truncated := len(items) > query.MaxItems || pageResp.NextLink != ""
+			return items[:query.MaxItems], truncated, nil
+		}
+
+		nextURL = pageResp.NextLink
+	}
+
+	return items, false, nil
+}
+
+// getJSON performs an authenticated GET, retrying when throttled
+func (c *GraphClient) getJSON(ctx context.Context, requestURL string, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("graph request failed: %w", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read graph response: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < maxThrottleRetries {
+			delay := 5 * time.Second
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+				delay = time.Duration(seconds) * time.Second
+			}
+			logging.Warn("Graph request throttled, retrying in %v", delay)
+			select {
+			case <-time.After(delay):
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			var errResp struct {
+				Error struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if json.Unmarshal(body, &errResp) == nil && errResp.Error.Code != "" {
+				return fmt.Errorf("graph request failed (%s): %s", errResp.Error.Code, errResp.Error.Message)
+			}
+			return fmt.Errorf("graph request failed (%s)", resp.Status)
+		}
+
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("failed to parse graph response: %w", err)
+		}
+		return nil
+	}
+}
+
+// NormalizeIncident flattens a Graph security incident into a triage-friendly shape
+func NormalizeIncident(incident map[string]interface{}) map[string]interface{} {
+	normalized := map[string]interface{}{
+		"id":             incident["id"],
+		"title":          incident["displayName"],
+		"severity":       incident["severity"],
+		"status":         incident["status"],
+		"classification": incident["classification"],
+		"determination":  incident["determination"],
+		"assigned_to":    incident["assignedTo"],
+		"created":        incident["createdDateTime"],
+		"last_updated":   incident["lastUpdateDateTime"],
+		"url":            incident["incidentWebUrl"],
+		"tags":           incident["customTags"],
+	}
+
+	if alerts, ok := incident["alerts"].([]interface{}); ok {
+		var normalizedAlerts []map[string]interface{}
+		for _, alert := range alerts {
+			if alertMap, ok := alert.(map[string]interface{}); ok {
+				normalizedAlerts = append(normalizedAlerts, NormalizeAlert(alertMap))
+			}
+		}
+		normalized["alerts"] = normalizedAlerts
+		normalized["alert_count"] = len(normalizedAlerts)
+	}
+
+	return normalized
+}
+
+// NormalizeAlert flattens a Graph security alert (alerts_v2) into a triage-friendly shape
+func NormalizeAlert(alert map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":               alert["id"],
+		"title":            alert["title"],
+		"description":      alert["description"],
+		"severity":         alert["severity"],
+		"status":           alert["status"],
+		"category":         alert["category"],
+		"service_source":   alert["serviceSource"],
+		"detection_source": alert["detectionSource"],
+		"incident_id":      alert["incidentId"],
+		"mitre_techniques": alert["mitreTechniques"],
+		"created":          alert["createdDateTime"],
+		"last_updated":     alert["lastUpdateDateTime"],
+		"url":              alert["alertWebUrl"],
+	}
+}
@@ -0,0 +1,68 @@
+package azure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestListSecurityPaging(t *testing.T) {
+	var server *httptest.Server
+	var filters []string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer static" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			filters = append(filters, r.URL.Query().Get("$filter"))
+			io.WriteString(w, `{"value":[{"id":"1","displayName":"First"},{"id":"2","displayName":"Second"}],
+				"@odata.nextLink":"`+server.URL+`/v1.0/security/incidents?page=2"}`)
+		case "2":
+			io.WriteString(w, `{"value":[{"id":"3","displayName":"Third","alerts":[{"id":"a1","title":"Alert"}]}]}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewGraphClient(&config.GraphConfig{
+		Endpoint:              server.URL,
+		AzureCredentialConfig: config.AzureCredentialConfig{AccessToken: "static"},
+	})
+	if err != nil {
+		t.Fatalf("NewGraphClient() error = %v", err)
+	}
+
+	items, truncated, err := client.ListSecurity(context.Background(), "incidents", GraphQuery{Filter: "status eq 'active'"})
+	if err != nil {
+		t.Fatalf("ListSecurity() error = %v", err)
+	}
+	if len(filters) != 1 || filters[0] != "status eq 'active'" {
+		t.Errorf("unexpected $filter %q", filters)
+	}
+	if len(items) != 3 || truncated {
+		t.Fatalf("got %d items (truncated=%v), want 3 untruncated", len(items), truncated)
+	}
+
+	normalized := NormalizeIncident(items[2])
+	if normalized["title"] != "Third" || normalized["alert_count"] != 1 {
+		t.Errorf("unexpected normalized incident: %v", normalized)
+	}
+
+	items, truncated, err = client.ListSecurity(context.Background(), "incidents", GraphQuery{MaxItems: 2})
+	if err != nil {
+		t.Fatalf("ListSecurity() error = %v", err)
+	}
+	if len(items) != 2 || !truncated {
+		t.Errorf("got %d items (truncated=%v), want 2 truncated", len(items), truncated)
+	}
+
+	if _, _, err := client.ListSecurity(context.Background(), "users", GraphQuery{}); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expected unsupported resource error, got %v", err)
+	}
+}
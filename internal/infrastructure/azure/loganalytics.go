@@ -0,0 +1,160 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+const (
+	// monitorScope is the token scope for the Logs Ingestion API
+	monitorScope = "https://monitor.azure.com/.default"
+
+	// logsIngestionAPIVersion is the Logs Ingestion API version
+	logsIngestionAPIVersion = "2023-01-01"
+
+	// maxIngestionBatchBytes keeps each request under the 1MB API limit
+	maxIngestionBatchBytes = 900 * 1024
+)
+
+// LogsIngestionClient sends records to a DCR stream via the Logs Ingestion API
+type LogsIngestionClient struct {
+	dest        *config.LogAnalyticsDestinationConfig
+	httpClient  *http.Client
+	tokenSource *TokenSource
+}
+
+// NewLogsIngestionClient creates a client for a configured destination
+func NewLogsIngestionClient(dest *config.LogAnalyticsDestinationConfig) (*LogsIngestionClient, error) {
+	if dest == nil {
+		return nil, fmt.Errorf("log analytics destination configuration is required")
+	}
+	if dest.Endpoint == "" || dest.DCRImmutableID == "" {
+		return nil, fmt.Errorf("log analytics endpoint and dcr_immutable_id are required")
+	}
+
+	timeout := 60 * time.Second
+	if dest.TimeoutSeconds > 0 {
+		timeout = time.Duration(dest.TimeoutSeconds) * time.Second
+	}
+	httpClient := &http.Client{Timeout: timeout}
+
+	tokenSource, err := NewTokenSource(dest.AzureCredentialConfig, monitorScope, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogsIngestionClient{dest: dest, httpClient: httpClient, tokenSource: tokenSource}, nil
+}
+
+// Upload sends records to a stream, splitting them into batches under the API size limit.
+// Records without a TimeGenerated column get the current time.
+func (c *LogsIngestionClient) Upload(ctx context.Context, stream string, records []map[string]interface{}) error {
+	if stream == "" {
+		stream = c.dest.StreamName
+	}
+	if stream == "" {
+		return fmt.Errorf("stream name is required")
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	for _, record := range records {
+		if _, ok := record["TimeGenerated"]; !ok {
+			record["TimeGenerated"] = now
+		}
+	}
+
+	batches, err := batchRecords(records, maxIngestionBatchBytes)
+	if err != nil {
+		return err
+	}
+
+	for i, batch := range batches {
+		if err := c.post(ctx, stream, batch); err != nil {
+			return fmt.Errorf("batch %d/%d: %w", i+1, len(batches), err)
+		}
+	}
+
+	logging.Debug("Uploaded %d records to %s in %d batches", len(records), stream, len(batches))
+	return nil
+}
+
+// post sends one JSON array batch
+func (c *LogsIngestionClient) post(ctx context.Context, stream string, batch []byte) error {
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return err
+	}
+
+	requestURL := fmt.Sprintf("%s/dataCollectionRules/%s/streams/%s?api-version=%s",
+		strings.TrimSuffix(c.dest.Endpoint, "/"),
+		url.PathEscape(c.dest.DCRImmutableID),
+		url.PathEscape(stream),
+		logsIngestionAPIVersion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(batch))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("logs ingestion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return fmt.Errorf("logs ingestion failed (%s): %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// batchRecords encodes records as JSON arrays no larger than maxBytes each
+func batchRecords(records []map[string]interface{}, maxBytes int) ([][]byte, error) {
+	var batches [][]byte
+	var current [][]byte
+	currentSize := 2 // []
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		batches = append(batches, append(append([]byte("["), bytes.Join(current, []byte(","))...), ']'))
+		current = nil
+		currentSize = 2
+	}
+
+	for i, record := range records {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode record %d: %w", i, err)
+		}
+		if len(encoded)+2 > maxBytes {
+			return nil, fmt.Errorf("record %d is %d bytes, which exceeds the 1MB ingestion limit", i, len(encoded))
+		}
+		if currentSize+len(encoded)+1 > maxBytes {
+			flush()
+		}
+		current = append(current, encoded)
+		currentSize += len(encoded) + 1
+	}
+	flush()
+
+	return batches, nil
+}
@@ -0,0 +1,104 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestLogsIngestionUpload(t *testing.T) {
+	var uploaded []map[string]interface{}
+	var tokenRequests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/oauth2/v2.0/token"):
+			tokenRequests++
+			r.ParseForm()
+			if r.Form.Get("scope") != monitorScope {
+				t.Errorf("unexpected scope %q", r.Form.Get("scope"))
+			}
+			io.WriteString(w, `{"access_token":"tok","expires_in":3600}`)
+		case strings.HasPrefix(r.URL.Path, "/dataCollectionRules/dcr-123/streams/Custom-Results_CL"):
+			if r.Header.Get("Authorization") != "Bearer tok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var batch []map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&batch)
+			uploaded = append(uploaded, batch...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewLogsIngestionClient(&config.LogAnalyticsDestinationConfig{
+		Endpoint:       server.URL,
+		DCRImmutableID: "dcr-123",
+		StreamName:     "Custom-Results_CL",
+		AzureCredentialConfig: config.AzureCredentialConfig{
+			TenantID:      "tenant",
+			ClientID:      "client",
+			ClientSecret:  "secret",
+			AuthorityHost: server.URL,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewLogsIngestionClient() error = %v", err)
+	}
+
+	records := []map[string]interface{}{
+		{"IncidentId": "1", "Severity": "High"},
+		{"IncidentId": "2", "TimeGenerated": "2024-01-01T00:00:00Z"},
+	}
+	if err := client.Upload(context.Background(), "", records); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if len(uploaded) != 2 {
+		t.Fatalf("uploaded %d records, want 2", len(uploaded))
+	}
+	if uploaded[0]["TimeGenerated"] == nil {
+		t.Error("TimeGenerated was not added")
+	}
+	if uploaded[1]["TimeGenerated"] != "2024-01-01T00:00:00Z" {
+		t.Error("existing TimeGenerated was overwritten")
+	}
+	if tokenRequests != 1 {
+		t.Errorf("token requested %d times, want 1", tokenRequests)
+	}
+}
+
+func TestBatchRecords(t *testing.T) {
+	records := []map[string]interface{}{
+		{"a": strings.Repeat("x", 40)},
+		{"a": strings.Repeat("y", 40)},
+		{"a": strings.Repeat("z", 40)},
+	}
+
+	batches, err := batchRecords(records, 90)
+	if err != nil {
+		t.Fatalf("batchRecords() error = %v", err)
+	}
+	if len(batches) != 3 {
+		t.Errorf("got %d batches, want 3", len(batches))
+	}
+	for _, batch := range batches {
+		var decoded []map[string]interface{}
+		if err := json.Unmarshal(batch, &decoded); err != nil {
+			t.Errorf("batch is not a valid JSON array: %v", err)
+		}
+	}
+
+	if _, err := batchRecords(records, 20); err == nil {
+		t.Error("expected error for oversized record")
+	}
+}
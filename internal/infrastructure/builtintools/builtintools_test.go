@@ -0,0 +1,228 @@
+package builtintools
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"2 ^ 3 ^ 2", 512},
+		{"2 ** 10", 1024},
+		{"-2 ^ 2", -4},
+		{"2 ^ -1", 0.5},
+		{"10 % 4", 2},
+		{"1.5e3 / 3", 500},
+		{"1_000 * 3", 3000},
+		{"sqrt(16) + abs(-2)", 6},
+		{"max(1, 7, 3) - min(4, 2)", 5},
+		{"round(pi * 100) / 100", 3.14},
+		{"log(1000) + ln(e)", 4},
+		{"pow(2, 8)", 256},
+	}
+	for _, tt := range tests {
+		got, err := Evaluate(tt.expression)
+		if err != nil {
+			t.Errorf("Evaluate(%q) error: %v", tt.expression, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "1 +", "(1 + 2", "1 / 0", "foo(1)", "2 x 3", "sqrt(1, 2)", "sqrt(-1)"} {
+		if _, err := Evaluate(bad); err == nil {
+			t.Errorf("Evaluate(%q) should fail", bad)
+		}
+	}
+
+	if got := FormatNumber(0.1 + 0.2); got != "0.3" {
+		t.Errorf("FormatNumber(0.1 + 0.2) = %s, want 0.3", got)
+	}
+}
+
+func TestConvertUnits(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{1, "mi", "km", 1.609344},
+		{10, "kilometres", "m", 10000},
+		{3, "feet", "inches", 36},
+		{1, "lb", "g", 453.59237},
+		{1, "gallon", "l", 3.785411784},
+		{90, "min", "h", 1.5},
+		{1, "GiB", "MiB", 1024},
+		{100, "km/h", "m/s", 27.7777777778},
+		{1, "hectare", "m²", 10000},
+		{212, "fahrenheit", "celsius", 100},
+		{0, "C", "K", 273.15},
+	}
+	for _, tt := range tests {
+		got, err := ConvertUnits(tt.value, tt.from, tt.to)
+		if err != nil {
+			t.Errorf("ConvertUnits(%v, %s, %s) error: %v", tt.value, tt.from, tt.to, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("ConvertUnits(%v, %s, %s) = %v, want %v", tt.value, tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	if _, err := ConvertUnits(1, "kg", "m"); err == nil {
+		t.Error("converting mass to length should fail")
+	}
+	if _, err := ConvertUnits(1, "c", "km"); err == nil {
+		t.Error("converting temperature to length should fail")
+	}
+	if _, err := ConvertUnits(1, "furlong", "m"); err == nil {
+		t.Error("unknown unit should fail")
+	}
+}
+
+func TestGetCurrentTime(t *testing.T) {
+	now = func() time.Time { return time.Date(2026, 3, 15, 23, 30, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	out, err := getCurrentTime(context.Background(), map[string]interface{}{"timezone": "Australia/Sydney"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result["date"] != "2026-03-16" || result["weekday"] != "Monday" || result["utc_offset"] != "+11:00" {
+		t.Errorf("result = %v", result)
+	}
+
+	if _, err := getCurrentTime(context.Background(), map[string]interface{}{"timezone": "Mars/Olympus"}); err == nil {
+		t.Error("unknown timezone should fail")
+	}
+}
+
+func TestGenerateRandom(t *testing.T) {
+	id, err := NewUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(id) {
+		t.Errorf("NewUUID() = %s, not a v4 UUID", id)
+	}
+
+	out, err := generateRandom(context.Background(), map[string]interface{}{"kind": "integer", "min": 5.0, "max": 6.0, "count": 20.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		Values []float64 `json:"values"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Values) != 20 {
+		t.Fatalf("got %d values, want 20", len(result.Values))
+	}
+	for _, v := range result.Values {
+		if v != 5 && v != 6 {
+			t.Errorf("value %v outside [5, 6]", v)
+		}
+	}
+
+	for _, bad := range []map[string]interface{}{
+		{"kind": "integer", "min": 10.0, "max": 1.0},
+		{"kind": "choice"},
+		{"kind": "string", "length": 1000.0},
+		{"count": 0.0},
+		{"kind": "dice"},
+	} {
+		if _, err := generateRandom(context.Background(), bad); err == nil {
+			t.Errorf("generateRandom(%v) should fail", bad)
+		}
+	}
+}
+
+// stubManager is an external server manager offering a single tool
+type stubManager struct {
+	domain.MCPServerManager
+	tool string
+}
+
+func (s *stubManager) GetAvailableTools() ([]domain.Tool, error) {
+	return []domain.Tool{{Type: "function", Function: domain.ToolFunction{Name: s.tool}}}, nil
+}
+
+func (s *stubManager) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	return "external:" + toolName, nil
+}
+
+func TestServerManager(t *testing.T) {
+	sm := Wrap(&stubManager{tool: "calculate"}, &config.BuiltinToolsConfig{Exclude: []string{"generate_random"}})
+
+	tools, err := sm.GetAvailableTools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Function.Name)
+	}
+	if got := strings.Join(names, ","); got != "calculate,get_current_time,convert_units" {
+		t.Errorf("tools = %s", got)
+	}
+
+	// The external server's calculate shadows the built-in one
+	if out, _ := sm.ExecuteTool(context.Background(), "calculate", nil); out != "external:calculate" {
+		t.Errorf("calculate routed to %q, want external", out)
+	}
+	out, err := sm.ExecuteTool(context.Background(), "convert_units", map[string]interface{}{"value": "2", "from": "km", "to": "m"})
+	if err != nil || !strings.Contains(out, `"result": "2000"`) {
+		t.Errorf("convert_units = %q, %v", out, err)
+	}
+
+	disabled := false
+	external := &stubManager{tool: "echo"}
+	if Wrap(external, &config.BuiltinToolsConfig{Enabled: &disabled}) != external {
+		t.Error("disabled built-in tools should leave the manager unwrapped")
+	}
+}
+
+// selectingManager narrows its tools to the request, like the skill router
+type selectingManager struct {
+	stubManager
+}
+
+func (s *selectingManager) SelectTools(ctx context.Context, request string) ([]domain.Tool, error) {
+	return []domain.Tool{{Type: "function", Function: domain.ToolFunction{Name: request}}}, nil
+}
+
+func TestServerManagerSelectTools(t *testing.T) {
+	sm := Wrap(&selectingManager{stubManager{tool: "echo"}}, &config.BuiltinToolsConfig{Exclude: []string{"generate_random", "convert_units"}})
+
+	tools, err := domain.SelectTools(context.Background(), sm, "skills_docx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Function.Name)
+	}
+	if got := strings.Join(names, ","); got != "skills_docx,get_current_time,calculate" {
+		t.Errorf("tools = %s", got)
+	}
+}
@@ -0,0 +1,446 @@
+package builtintools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Defaults for the fetch_url tool
+const (
+	DefaultFetchMaxBytes  = 2 << 20
+	DefaultFetchMaxLength = 20000
+	DefaultFetchTimeout   = 30 * time.Second
+	DefaultUserAgent      = "mcp-cli"
+)
+
+const (
+	fetchToolName  = "fetch_url"
+	robotsTTL      = time.Hour
+	maxCachedPages = 64
+	maxRobotsBytes = 512 << 10
+	maxRedirects   = 10
+)
+
+// errPrivateAddress is returned when a URL resolves to a non-public address
+var errPrivateAddress = domainErrors.NewToolError(domainErrors.ErrCodeToolPermission,
+	"refusing to fetch a loopback, private or link-local address (set builtin_tools.fetch.allow_private to allow)").
+	WithSuggestion("Only public web addresses can be fetched. Don't retry this URL.")
+
+// fetchedPage is a downloaded response body
+type fetchedPage struct {
+	url         string // Final URL after redirects
+	contentType string
+	body        []byte
+	truncated   bool
+	fetchedAt   time.Time
+}
+
+// pageCache holds recent responses for all fetch_url tools in the process,
+// so repeated and paged reads of a URL download it once
+var pageCache = struct {
+	sync.Mutex
+	pages  map[string]*fetchedPage
+	robots map[string]*cachedRobots
+}{
+	pages:  make(map[string]*fetchedPage),
+	robots: make(map[string]*cachedRobots),
+}
+
+type cachedRobots struct {
+	rules     *robotsRules
+	fetchedAt time.Time
+}
+
+// fetcher downloads pages for the fetch_url tool
+type fetcher struct {
+	cfg      config.FetchToolConfig
+	cacheTTL time.Duration
+	client   *http.Client
+}
+
+// newFetcher applies defaults to cfg and builds an HTTP client that
+// enforces its address and domain rules on every connection and redirect
+func newFetcher(cfg config.FetchToolConfig) *fetcher {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultFetchMaxBytes
+	}
+	if cfg.MaxLength <= 0 {
+		cfg.MaxLength = DefaultFetchMaxLength
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+	timeout := DefaultFetchTimeout
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+
+	f := &fetcher{cfg: cfg, cacheTTL: cfg.GetCacheTTL()}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !cfg.AllowPrivate {
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	f.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return f.checkURL(req.URL)
+		},
+	}
+	return f
+}
+
+// isPublicIP reports whether ip is a globally routable address
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast())
+}
+
+// fetchTool downloads a web page and returns its readable text
+func fetchTool(cfg config.FetchToolConfig) Tool {
+	f := newFetcher(cfg)
+	return Tool{
+		Name: fetchToolName,
+		Description: "Fetch a web page and return its main content as Markdown, without navigation, ads and other page clutter. " +
+			"Use this to read documentation, articles or other pages you need to answer from. Long pages are returned in parts; " +
+			"call again with start_index to continue.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "http or https URL to fetch",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"markdown", "text"},
+					"description": "Return Markdown (default) or plain text",
+				},
+				"start_index": map[string]interface{}{
+					"type":        "integer",
+					"description": "Character to start from, to continue a long page (default: 0)",
+				},
+				"max_length": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Most characters to return (default and max: %d)", f.cfg.MaxLength),
+				},
+			},
+			"required": []string{"url"},
+		},
+		Execute: f.execute,
+	}
+}
+
+func (f *fetcher) execute(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	rawURL := stringArg(arguments, "url", "")
+	if rawURL == "" {
+		return "", fmt.Errorf("url is required")
+	}
+	format := stringArg(arguments, "format", "markdown")
+	if format != "markdown" && format != "text" {
+		return "", fmt.Errorf("format must be 'markdown' or 'text'")
+	}
+	start, err := intArg(arguments, "start_index", 0)
+	if err != nil {
+		return "", err
+	}
+	maxLength, err := intArg(arguments, "max_length", int64(f.cfg.MaxLength))
+	if err != nil {
+		return "", err
+	}
+	if maxLength <= 0 || maxLength > int64(f.cfg.MaxLength) {
+		maxLength = int64(f.cfg.MaxLength)
+	}
+
+	page, err := f.fetch(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	title, content, err := f.render(page, format == "text")
+	if err != nil {
+		return "", err
+	}
+	return paginate(page.url, title, content, int(start), int(maxLength)), nil
+}
+
+// FetchPage downloads a page with the fetch_url rules in cfg and returns
+// its final URL, title and readable Markdown
+func FetchPage(ctx context.Context, cfg config.FetchToolConfig, rawURL string) (string, string, string, error) {
+	f := newFetcher(cfg)
+	page, err := f.fetch(ctx, rawURL)
+	if err != nil {
+		return "", "", "", err
+	}
+	title, content, err := f.render(page, false)
+	if err != nil {
+		return "", "", "", err
+	}
+	return page.url, title, content, nil
+}
+
+// fetch returns a page from the cache or downloads it
+func (f *fetcher) fetch(ctx context.Context, rawURL string) (*fetchedPage, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if err := f.checkURL(u); err != nil {
+		return nil, err
+	}
+	u.Fragment = ""
+	key := u.String()
+
+	if f.cacheTTL > 0 {
+		pageCache.Lock()
+		page, ok := pageCache.pages[key]
+		pageCache.Unlock()
+		if ok && time.Since(page.fetchedAt) < f.cacheTTL {
+			logging.Debug("fetch_url cache hit for %s", key)
+			return page, nil
+		}
+	}
+
+	if !f.cfg.IgnoreRobots {
+		if !f.robotsAllowed(ctx, u) {
+			return nil, domainErrors.NewToolError(domainErrors.ErrCodeToolPermission,
+				fmt.Sprintf("robots.txt for %s disallows fetching %s", u.Host, u.RequestURI())).
+				WithSuggestion("This site doesn't allow automated fetching of this page. Use another source.")
+		}
+	}
+
+	resp, err := f.get(ctx, key, "text/html,application/xhtml+xml,text/plain;q=0.9,*/*;q=0.5")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("fetching %s failed: HTTP %d", key, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.cfg.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	page := &fetchedPage{
+		url:         resp.Request.URL.String(),
+		contentType: resp.Header.Get("Content-Type"),
+		body:        body,
+		fetchedAt:   time.Now(),
+	}
+	if int64(len(body)) > f.cfg.MaxBytes {
+		page.body = body[:f.cfg.MaxBytes]
+		page.truncated = true
+	}
+
+	if f.cacheTTL > 0 {
+		pageCache.Lock()
+		if len(pageCache.pages) >= maxCachedPages {
+			evictOldestPage()
+		}
+		pageCache.pages[key] = page
+		pageCache.Unlock()
+	}
+	return page, nil
+}
+
+// evictOldestPage drops the least recently fetched page; callers hold the lock
+func evictOldestPage() {
+	oldestKey := ""
+	var oldest time.Time
+	for key, page := range pageCache.pages {
+		if oldestKey == "" || page.fetchedAt.Before(oldest) {
+			oldestKey, oldest = key, page.fetchedAt
+		}
+	}
+	delete(pageCache.pages, oldestKey)
+}
+
+func (f *fetcher) get(ctx context.Context, rawURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	req.Header.Set("User-Agent", f.cfg.UserAgent)
+	req.Header.Set("Accept", accept)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, errPrivateAddress) {
+			return nil, errPrivateAddress
+		}
+		return nil, fmt.Errorf("fetching %s failed: %w", rawURL, err)
+	}
+	return resp, nil
+}
+
+// checkURL applies the scheme and domain rules to a URL
+func (f *fetcher) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("only http and https URLs can be fetched, got '%s'", u.String())
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("url '%s' has no host", u.String())
+	}
+	for _, blocked := range f.cfg.BlockedDomains {
+		if domainMatches(host, blocked) {
+			return domainErrors.NewToolError(domainErrors.ErrCodeToolPermission,
+				fmt.Sprintf("fetching from %s is blocked by settings", host))
+		}
+	}
+	if len(f.cfg.AllowedDomains) == 0 {
+		return nil
+	}
+	for _, allowed := range f.cfg.AllowedDomains {
+		if domainMatches(host, allowed) {
+			return nil
+		}
+	}
+	return domainErrors.NewToolError(domainErrors.ErrCodeToolPermission, fmt.Sprintf("fetching from %s is not allowed", host)).
+		WithSuggestion("Fetch a URL from one of the allowed domains: " + strings.Join(f.cfg.AllowedDomains, ", "))
+}
+
+// domainMatches reports whether host is domain or one of its subdomains
+func domainMatches(host, domain string) bool {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// robotsAllowed checks the site's robots.txt. A missing or unreadable
+// robots.txt allows everything.
+func (f *fetcher) robotsAllowed(ctx context.Context, u *url.URL) bool {
+	site := u.Scheme + "://" + u.Host
+	key := site + " " + f.cfg.UserAgent
+
+	pageCache.Lock()
+	cached, ok := pageCache.robots[key]
+	pageCache.Unlock()
+
+	if !ok || time.Since(cached.fetchedAt) > robotsTTL {
+		cached = &cachedRobots{rules: f.loadRobots(ctx, site), fetchedAt: time.Now()}
+		pageCache.Lock()
+		pageCache.robots[key] = cached
+		pageCache.Unlock()
+	}
+
+	return cached.rules.allowed(u.RequestURI())
+}
+
+func (f *fetcher) loadRobots(ctx context.Context, site string) *robotsRules {
+	resp, err := f.get(ctx, site+"/robots.txt", "text/plain")
+	if err != nil {
+		logging.Debug("robots.txt for %s unavailable: %v", site, err)
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsBytes))
+	if err != nil {
+		return nil
+	}
+	return parseRobots(string(body), f.cfg.UserAgent)
+}
+
+// render turns a response into a title and readable text
+func (f *fetcher) render(page *fetchedPage, plain bool) (string, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(page.contentType)
+	if mediaType == "" {
+		mediaType = http.DetectContentType(page.body)
+		mediaType, _, _ = mime.ParseMediaType(mediaType)
+	}
+
+	body := string(page.body)
+	if !utf8.ValidString(body) {
+		body = strings.ToValidUTF8(body, "�")
+	}
+
+	var title, content string
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		base, _ := url.Parse(page.url)
+		extracted := extractReadable(body, base, plain)
+		title, content = extracted.Title, extracted.Content
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || mediaType == "application/xml" ||
+		strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml"):
+		content = strings.TrimSpace(body)
+	default:
+		return "", "", fmt.Errorf("cannot read %s: unsupported content type '%s'", page.url, mediaType)
+	}
+
+	if page.truncated {
+		content += fmt.Sprintf("\n\n[The page was larger than %d bytes; only the first part was read.]", f.cfg.MaxBytes)
+	}
+	return title, content, nil
+}
+
+// paginate returns one part of a page's content with a header and, when
+// more remains, a note on how to continue
+func paginate(pageURL, title, content string, start, maxLength int) string {
+	runes := []rune(content)
+	total := len(runes)
+	if start < 0 {
+		start = 0
+	}
+
+	var b strings.Builder
+	if title != "" {
+		b.WriteString("Title: " + title + "\n")
+	}
+	b.WriteString("URL: " + pageURL + "\n\n")
+
+	if start >= total {
+		if total == 0 {
+			b.WriteString("[The page has no readable text.]")
+		} else {
+			fmt.Fprintf(&b, "[No more content: the page has %d characters.]", total)
+		}
+		return b.String()
+	}
+
+	end := start + maxLength
+	if end > total {
+		end = total
+	}
+	b.WriteString(string(runes[start:end]))
+	if end < total {
+		fmt.Fprintf(&b, "\n\n[Showing characters %d-%d of %d. Call %s with start_index=%d to continue.]",
+			start, end, total, fetchToolName, end)
+	}
+	return b.String()
+}
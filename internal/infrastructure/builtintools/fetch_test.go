@@ -0,0 +1,199 @@
+package builtintools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+const samplePage = `<!DOCTYPE html>
+<html><head><title>Release Notes &amp; Changes</title>
+<style>body { color: red }</style>
+<script>var x = "<p>not text</p>";</script></head>
+<body>
+<header><a href="/">Home</a> | <a href="/about">About</a></header>
+<nav><ul><li><a href="/a">Menu A</a><li><a href="/b">Menu B</a></ul></nav>
+<div class="sidebar-widget"><p>Subscribe to our newsletter, today, for offers, deals, and more.</p></div>
+<article class="post">
+  <h1>Version 2.0</h1>
+  <p>This release adds <strong>streaming</strong>, faster startup, and a new
+     <a href="/docs/config">configuration guide</a>.</p>
+  <p>Upgrading is simple, backwards compatible, and takes a few minutes at most.
+  <ul><li>Run <code>mcp-cli init</code><li>Restart the daemon</ul>
+  <pre>servers:
+  fs: {}</pre>
+</article>
+<div id="cookie-banner" style="display: none">We use cookies</div>
+<footer>Copyright 2026</footer>
+</body></html>`
+
+func TestExtractReadable(t *testing.T) {
+	base, _ := url.Parse("https://example.com/releases/2.0")
+	got := extractReadable(samplePage, base, false)
+
+	if got.Title != "Release Notes & Changes" {
+		t.Errorf("title = %q", got.Title)
+	}
+	for _, want := range []string{
+		"# Version 2.0",
+		"This release adds **streaming**, faster startup, and a new [configuration guide](https://example.com/docs/config).",
+		"- Run `mcp-cli init`",
+		"- Restart the daemon",
+		"```\nservers:\n  fs: {}\n```",
+	} {
+		if !strings.Contains(got.Content, want) {
+			t.Errorf("content missing %q:\n%s", want, got.Content)
+		}
+	}
+	for _, unwanted := range []string{"Menu A", "Home", "newsletter", "cookies", "Copyright", "not text", "color: red"} {
+		if strings.Contains(got.Content, unwanted) {
+			t.Errorf("content contains boilerplate %q:\n%s", unwanted, got.Content)
+		}
+	}
+
+	plain := extractReadable(samplePage, base, true)
+	if strings.Contains(plain.Content, "**") || strings.Contains(plain.Content, "](") || !strings.Contains(plain.Content, "configuration guide") {
+		t.Errorf("plain text content:\n%s", plain.Content)
+	}
+}
+
+func TestParseRobots(t *testing.T) {
+	robots := parseRobots(`
+User-agent: *
+Disallow: /
+
+User-agent: Googlebot
+User-agent: mcp-cli
+Disallow: /private
+Allow: /private/public$
+Disallow: /*.pdf$
+`, "mcp-cli")
+
+	tests := map[string]bool{
+		"/":                    true,
+		"/docs":                true,
+		"/private/x":           false,
+		"/private/public":      true,
+		"/private/public/more": false,
+		"/files/report.pdf":    false,
+	}
+	for path, want := range tests {
+		if got := robots.allowed(path); got != want {
+			t.Errorf("allowed(%s) = %v, want %v", path, got, want)
+		}
+	}
+
+	if parseRobots("User-agent: *\nDisallow: /\n", "other-bot").allowed("/docs") {
+		t.Error("the * group should apply to agents without their own group")
+	}
+}
+
+func TestFetchTool(t *testing.T) {
+	var pageHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+		case "/page":
+			pageHits.Add(1)
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, samplePage)
+		case "/big":
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, strings.Repeat("x", 5000))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte{0x89, 'P', 'N', 'G'})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tool := fetchTool(config.FetchToolConfig{Enabled: true, AllowPrivate: true, MaxBytes: 1000, MaxLength: 200})
+	call := func(args map[string]interface{}) (string, error) {
+		return tool.Execute(context.Background(), args)
+	}
+
+	out, err := call(map[string]interface{}{"url": server.URL + "/page"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "Title: Release Notes & Changes\nURL: "+server.URL+"/page\n\n# Version 2.0") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if !strings.Contains(out, "start_index=200 to continue") {
+		t.Errorf("long page should say how to continue:\n%s", out)
+	}
+
+	// The next part comes from the cache
+	if _, err := call(map[string]interface{}{"url": server.URL + "/page", "start_index": 200.0}); err != nil {
+		t.Fatal(err)
+	}
+	if hits := pageHits.Load(); hits != 1 {
+		t.Errorf("page downloaded %d times, want 1", hits)
+	}
+
+	if _, err := call(map[string]interface{}{"url": server.URL + "/private/doc"}); err == nil || !strings.Contains(err.Error(), "robots.txt") {
+		t.Errorf("robots.txt disallowed fetch err = %v", err)
+	}
+
+	// max_length can't exceed the configured limit, and only max_bytes are read
+	out, err = call(map[string]interface{}{"url": server.URL + "/big", "max_length": 5000.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, strings.Repeat("x", 200)) || strings.Contains(out, strings.Repeat("x", 201)) {
+		t.Errorf("want exactly 200 characters:\n%s", out)
+	}
+	out, err = call(map[string]interface{}{"url": server.URL + "/big", "start_index": 1000.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "larger than 1000 bytes") {
+		t.Errorf("oversized page should say it was cut:\n%s", out)
+	}
+
+	for _, bad := range []string{server.URL + "/image", server.URL + "/missing", "file:///etc/passwd"} {
+		if _, err := call(map[string]interface{}{"url": bad}); err == nil {
+			t.Errorf("fetching %s should fail", bad)
+		}
+	}
+
+	private := fetchTool(config.FetchToolConfig{Enabled: true, IgnoreRobots: true})
+	if _, err := private.Execute(context.Background(), map[string]interface{}{"url": server.URL + "/page?fresh"}); !errors.Is(err, errPrivateAddress) {
+		t.Errorf("loopback fetch err = %v, want errPrivateAddress", err)
+	}
+
+	scoped := fetchTool(config.FetchToolConfig{Enabled: true, AllowedDomains: []string{"example.com"}})
+	if _, err := scoped.Execute(context.Background(), map[string]interface{}{"url": "https://example.org/"}); err == nil {
+		t.Error("domain outside allowed_domains should fail")
+	}
+}
+
+func TestFetchPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, samplePage)
+	}))
+	defer server.Close()
+
+	pageURL, title, content, err := FetchPage(context.Background(), config.FetchToolConfig{AllowPrivate: true, IgnoreRobots: true}, server.URL+"/notes#top")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pageURL != server.URL+"/notes" || title != "Release Notes & Changes" {
+		t.Errorf("url = %q, title = %q", pageURL, title)
+	}
+	if !strings.HasPrefix(content, "# Version 2.0") || strings.Contains(content, "Menu A") {
+		t.Errorf("unexpected content:\n%s", content)
+	}
+}
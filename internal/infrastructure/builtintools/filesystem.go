@@ -0,0 +1,539 @@
+package builtintools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+)
+
+// Limits on the filesystem tools
+const (
+	DefaultFilesystemMaxFileSize = 1 << 20
+	maxDirectoryEntries          = 1000
+	maxGlobResults               = 500
+)
+
+// sandbox confines file access to the configured allowed directories.
+// Every path is resolved through symbolic links before it is checked, so
+// neither "../" nor a link pointing outside a root can escape it.
+type sandbox struct {
+	roots    []string
+	maxSize  int64
+	readOnly bool
+}
+
+// newSandbox resolves the allowed directories, which must exist
+func newSandbox(cfg config.FilesystemToolConfig) (*sandbox, error) {
+	s := &sandbox{maxSize: cfg.MaxFileSize, readOnly: cfg.ReadOnly}
+	if s.maxSize <= 0 {
+		s.maxSize = DefaultFilesystemMaxFileSize
+	}
+
+	for _, dir := range cfg.AllowedDirectories {
+		abs, err := filepath.Abs(expandHome(dir))
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed directory '%s': %w", dir, err)
+		}
+		real, err := filepath.EvalSymlinks(abs)
+		if err != nil {
+			return nil, fmt.Errorf("allowed directory '%s' is not accessible: %w", dir, err)
+		}
+		info, err := os.Stat(real)
+		if err != nil {
+			return nil, fmt.Errorf("allowed directory '%s' is not accessible: %w", dir, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("allowed directory '%s' is not a directory", dir)
+		}
+		s.roots = append(s.roots, real)
+	}
+	if len(s.roots) == 0 {
+		return nil, fmt.Errorf("no allowed directories configured")
+	}
+	return s, nil
+}
+
+// resolve turns a tool's path argument into a real path inside one of the
+// roots. Relative paths are taken from the first allowed directory. Paths
+// that don't exist yet are resolved through their nearest existing parent.
+func (s *sandbox) resolve(path string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	path = expandHome(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.roots[0], path)
+	}
+
+	real, err := realPath(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	if !s.contains(real) {
+		return "", domainErrors.NewToolError(domainErrors.ErrCodeToolPermission,
+			fmt.Sprintf("access denied: %s is outside the allowed directories", path)).
+			WithSuggestion("Use a path inside the allowed directories: " + strings.Join(s.roots, ", "))
+	}
+	return real, nil
+}
+
+// contains reports whether path is one of the roots or lies beneath one
+func (s *sandbox) contains(path string) bool {
+	for _, root := range s.roots {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || filepath.IsAbs(rel) {
+			continue
+		}
+		if rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// realPath evaluates symbolic links in path, keeping any trailing
+// components that don't exist yet. A dangling link is refused because
+// writing through it would create its target wherever it points.
+func realPath(path string) (string, error) {
+	var missing []string
+	for {
+		real, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(append([]string{real}, missing...)...), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if _, lerr := os.Lstat(path); lerr == nil {
+			return "", fmt.Errorf("%s is a broken symbolic link", path)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", err
+		}
+		missing = append([]string{filepath.Base(path)}, missing...)
+		path = parent
+	}
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// filesystemTools builds the file tools for the configured directories.
+// Names follow the reference filesystem MCP server so that a configured
+// external server takes precedence.
+func filesystemTools(cfg config.FilesystemToolConfig) ([]Tool, error) {
+	s, err := newSandbox(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := []Tool{
+		{
+			Name:        "read_file",
+			Description: "Read a text file. Optionally return only a range of lines.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path":       pathParameter,
+					"start_line": map[string]interface{}{"type": "integer", "description": "First line to return, counting from 1"},
+					"end_line":   map[string]interface{}{"type": "integer", "description": "Last line to return"},
+				},
+				"required": []string{"path"},
+			},
+			Execute: s.readFile,
+		},
+		{
+			Name:        "list_directory",
+			Description: "List the files and subdirectories of a directory.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": pathParameter,
+				},
+				"required": []string{"path"},
+			},
+			Execute: s.listDirectory,
+		},
+		{
+			Name: "glob_files",
+			Description: "Find files whose paths match a glob pattern such as **/*.go or docs/*.md. " +
+				"A pattern without a slash matches file names at any depth.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"pattern": map[string]interface{}{"type": "string", "description": "Glob pattern (*, ?, ** and [...])"},
+					"path":    map[string]interface{}{"type": "string", "description": "Directory to search (default: the first allowed directory)"},
+				},
+				"required": []string{"pattern"},
+			},
+			Execute: s.globFiles,
+		},
+		{
+			Name:        "list_allowed_directories",
+			Description: "List the directories the file tools can access.",
+			Parameters:  map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+			Execute: func(ctx context.Context, arguments map[string]interface{}) (string, error) {
+				return "Allowed directories:\n" + strings.Join(s.roots, "\n"), nil
+			},
+		},
+	}
+	if s.readOnly {
+		return tools, nil
+	}
+
+	return append(tools,
+		Tool{
+			Name:        "write_file",
+			Description: "Create a file or overwrite it with new content. Missing parent directories are created.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path":    pathParameter,
+					"content": map[string]interface{}{"type": "string", "description": "Complete new content of the file"},
+				},
+				"required": []string{"path", "content"},
+			},
+			Execute: s.writeFile,
+		},
+		Tool{
+			Name: "edit_file",
+			Description: "Replace text in a file. Each old_text must occur exactly once; edits apply in order. " +
+				"Set dry_run to preview the changes without writing them.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": pathParameter,
+					"edits": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"old_text": map[string]interface{}{"type": "string", "description": "Exact text to replace"},
+								"new_text": map[string]interface{}{"type": "string", "description": "Replacement text"},
+							},
+							"required": []string{"old_text", "new_text"},
+						},
+					},
+					"dry_run": map[string]interface{}{"type": "boolean", "description": "Preview without writing (default: false)"},
+				},
+				"required": []string{"path", "edits"},
+			},
+			Execute: s.editFile,
+		},
+	), nil
+}
+
+var pathParameter = map[string]interface{}{
+	"type":        "string",
+	"description": "File path, absolute or relative to the first allowed directory",
+}
+
+// readText reads a regular text file no larger than the size limit
+func (s *sandbox) readText(path string) (string, os.FileInfo, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", nil, domainErrors.NewToolError(domainErrors.ErrCodeToolResourceAbsent, fmt.Sprintf("%s does not exist", path)).
+			WithSuggestion("Use list_directory or glob_files to find the file.")
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if info.IsDir() {
+		return "", nil, fmt.Errorf("%s is a directory", path)
+	}
+	if info.Size() > s.maxSize {
+		return "", nil, fmt.Errorf("%s is %d bytes, larger than the %d byte limit", path, info.Size(), s.maxSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return "", nil, fmt.Errorf("%s is a binary file", path)
+	}
+	return string(data), info, nil
+}
+
+func (s *sandbox) readFile(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	path, err := s.resolve(stringArg(arguments, "path", ""))
+	if err != nil {
+		return "", err
+	}
+	content, _, err := s.readText(path)
+	if err != nil {
+		return "", err
+	}
+
+	start, err := intArg(arguments, "start_line", 0)
+	if err != nil {
+		return "", err
+	}
+	end, err := intArg(arguments, "end_line", 0)
+	if err != nil {
+		return "", err
+	}
+	if start <= 0 && end <= 0 {
+		return content, nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	if start < 1 {
+		start = 1
+	}
+	if end <= 0 || end > int64(len(lines)) {
+		end = int64(len(lines))
+	}
+	if start > end {
+		return "", fmt.Errorf("start_line %d is past the end of the file (%d lines)", start, len(lines))
+	}
+	return strings.Join(lines[start-1:end], ""), nil
+}
+
+func (s *sandbox) writeFile(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	path, err := s.resolve(stringArg(arguments, "path", ""))
+	if err != nil {
+		return "", err
+	}
+	content, ok := arguments["content"].(string)
+	if !ok {
+		return "", fmt.Errorf("content is required")
+	}
+	if int64(len(content)) > s.maxSize {
+		return "", fmt.Errorf("content is %d bytes, larger than the %d byte limit", len(content), s.maxSize)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Wrote %d bytes to %s", len(content), path), nil
+}
+
+func (s *sandbox) listDirectory(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	path, err := s.resolve(stringArg(arguments, "path", ""))
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i, entry := range entries {
+		if i == maxDirectoryEntries {
+			fmt.Fprintf(&b, "[%d more entries not shown]\n", len(entries)-i)
+			break
+		}
+		switch {
+		case entry.IsDir():
+			fmt.Fprintf(&b, "[DIR] %s\n", entry.Name())
+		case entry.Type()&fs.ModeSymlink != 0:
+			fmt.Fprintf(&b, "[LINK] %s\n", entry.Name())
+		default:
+			fmt.Fprintf(&b, "[FILE] %s\n", entry.Name())
+		}
+	}
+	if b.Len() == 0 {
+		return fmt.Sprintf("%s is empty", path), nil
+	}
+	return b.String(), nil
+}
+
+func (s *sandbox) globFiles(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	pattern := strings.TrimSpace(stringArg(arguments, "pattern", ""))
+	if pattern == "" {
+		return "", fmt.Errorf("pattern is required")
+	}
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return "", err
+	}
+	dir, err := s.resolve(stringArg(arguments, "path", s.roots[0]))
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	truncated := false
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Skip unreadable directories rather than abandoning the search
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if path == dir {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if re.MatchString(filepath.ToSlash(rel)) {
+			if len(matches) == maxGlobResults {
+				truncated = true
+				return filepath.SkipAll
+			}
+			matches = append(matches, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(matches) == 0 {
+		return fmt.Sprintf("No files in %s match %s", dir, pattern), nil
+	}
+	sort.Strings(matches)
+	result := fmt.Sprintf("%d matches in %s:\n%s", len(matches), dir, strings.Join(matches, "\n"))
+	if truncated {
+		result += fmt.Sprintf("\n[Stopped after %d matches; use a narrower pattern.]", maxGlobResults)
+	}
+	return result, nil
+}
+
+// globRegexp compiles a glob into a regular expression over slash
+// separated relative paths. "**" spans directories, "*" and "?" don't.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	pattern = filepath.ToSlash(pattern)
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [ in pattern %s", pattern)
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// textEdit is one replacement requested of edit_file
+type textEdit struct {
+	oldText string
+	newText string
+}
+
+func (s *sandbox) editFile(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	path, err := s.resolve(stringArg(arguments, "path", ""))
+	if err != nil {
+		return "", err
+	}
+	edits, err := editsArg(arguments)
+	if err != nil {
+		return "", err
+	}
+	content, info, err := s.readText(path)
+	if err != nil {
+		return "", err
+	}
+
+	var preview strings.Builder
+	for i, edit := range edits {
+		switch n := strings.Count(content, edit.oldText); n {
+		case 1:
+		case 0:
+			return "", domainErrors.NewToolError(domainErrors.ErrCodeToolInvalidArgs,
+				fmt.Sprintf("edit %d: old_text not found in %s", i+1, path)).
+				WithSuggestion("Read the file again and copy old_text exactly, including whitespace.")
+		default:
+			return "", domainErrors.NewToolError(domainErrors.ErrCodeToolInvalidArgs,
+				fmt.Sprintf("edit %d: old_text occurs %d times in %s", i+1, n, path)).
+				WithSuggestion("Include more of the surrounding text in old_text so it occurs only once.")
+		}
+		content = strings.Replace(content, edit.oldText, edit.newText, 1)
+
+		fmt.Fprintf(&preview, "@@ edit %d @@\n", i+1)
+		for _, line := range strings.Split(edit.oldText, "\n") {
+			fmt.Fprintf(&preview, "-%s\n", line)
+		}
+		for _, line := range strings.Split(edit.newText, "\n") {
+			fmt.Fprintf(&preview, "+%s\n", line)
+		}
+	}
+
+	if dryRun, _ := arguments["dry_run"].(bool); dryRun {
+		return fmt.Sprintf("Dry run, %s not changed:\n%s", path, preview.String()), nil
+	}
+	if int64(len(content)) > s.maxSize {
+		return "", fmt.Errorf("edited file would be %d bytes, larger than the %d byte limit", len(content), s.maxSize)
+	}
+	if err := os.WriteFile(path, []byte(content), info.Mode().Perm()); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Applied %d edits to %s:\n%s", len(edits), path, preview.String()), nil
+}
+
+func editsArg(arguments map[string]interface{}) ([]textEdit, error) {
+	raw, ok := arguments["edits"].([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil, fmt.Errorf("edits is required")
+	}
+
+	edits := make([]textEdit, 0, len(raw))
+	for i, item := range raw {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("edit %d must be an object with old_text and new_text", i+1)
+		}
+		oldText, _ := fields["old_text"].(string)
+		newText, ok := fields["new_text"].(string)
+		if oldText == "" || !ok {
+			return nil, fmt.Errorf("edit %d needs old_text and new_text", i+1)
+		}
+		edits = append(edits, textEdit{oldText: oldText, newText: newText})
+	}
+	return edits, nil
+}
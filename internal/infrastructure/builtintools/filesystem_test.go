@@ -0,0 +1,129 @@
+package builtintools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestFilesystemTools(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "notes.txt"), []byte("one\ntwo\nthree\n"), 0644)
+	os.MkdirAll(filepath.Join(root, "src", "pkg"), 0755)
+	os.WriteFile(filepath.Join(root, "src", "main.go"), []byte("package main\n"), 0644)
+	os.WriteFile(filepath.Join(root, "src", "pkg", "util.go"), []byte("package pkg\n"), 0644)
+	os.WriteFile(filepath.Join(root, "blob.bin"), []byte{'a', 0, 'b'}, 0644)
+
+	tools, err := filesystemTools(config.FilesystemToolConfig{AllowedDirectories: []string{root}, MaxFileSize: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := func(name string, args map[string]interface{}) (string, error) {
+		for _, tool := range tools {
+			if tool.Name == name {
+				return tool.Execute(context.Background(), args)
+			}
+		}
+		t.Fatalf("no tool %s", name)
+		return "", nil
+	}
+
+	if out, err := call("read_file", map[string]interface{}{"path": "notes.txt", "start_line": 2.0, "end_line": 2.0}); err != nil || out != "two\n" {
+		t.Errorf("read_file lines = %q, %v", out, err)
+	}
+	if _, err := call("read_file", map[string]interface{}{"path": "blob.bin"}); err == nil || !strings.Contains(err.Error(), "binary") {
+		t.Errorf("reading a binary file err = %v", err)
+	}
+
+	if out, err := call("glob_files", map[string]interface{}{"pattern": "**/*.go"}); err != nil || !strings.Contains(out, "src/main.go\nsrc/pkg/util.go") {
+		t.Errorf("glob_files = %q, %v", out, err)
+	}
+	if out, err := call("glob_files", map[string]interface{}{"pattern": "src/*.go"}); err != nil || strings.Contains(out, "util.go") {
+		t.Errorf("* should not cross directories: %q, %v", out, err)
+	}
+
+	if out, err := call("list_directory", map[string]interface{}{"path": root}); err != nil || !strings.Contains(out, "[DIR] src") || !strings.Contains(out, "[FILE] notes.txt") {
+		t.Errorf("list_directory = %q, %v", out, err)
+	}
+
+	if _, err := call("write_file", map[string]interface{}{"path": "new/dir/file.txt", "content": "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "new", "dir", "file.txt")); string(data) != "hello" {
+		t.Errorf("written file = %q", data)
+	}
+
+	edits := []interface{}{map[string]interface{}{"old_text": "two", "new_text": "2"}}
+	if out, err := call("edit_file", map[string]interface{}{"path": "notes.txt", "edits": edits, "dry_run": true}); err != nil || !strings.Contains(out, "-two\n+2") {
+		t.Errorf("dry run = %q, %v", out, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "notes.txt")); string(data) != "one\ntwo\nthree\n" {
+		t.Errorf("dry run changed the file: %q", data)
+	}
+	if _, err := call("edit_file", map[string]interface{}{"path": "notes.txt", "edits": edits}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "notes.txt")); string(data) != "one\n2\nthree\n" {
+		t.Errorf("edited file = %q", data)
+	}
+	ambiguous := []interface{}{map[string]interface{}{"old_text": "e", "new_text": "E"}}
+	if _, err := call("edit_file", map[string]interface{}{"path": "notes.txt", "edits": ambiguous}); err == nil || !strings.Contains(err.Error(), "occurs") {
+		t.Errorf("ambiguous edit err = %v", err)
+	}
+}
+
+func TestFilesystemSandbox(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644)
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	os.Symlink(filepath.Join(outside, "missing.txt"), filepath.Join(root, "dangling"))
+
+	tools, err := filesystemTools(config.FilesystemToolConfig{AllowedDirectories: []string{root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]Tool)
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+
+	for _, path := range []string{
+		"../" + filepath.Base(outside) + "/secret.txt",
+		filepath.Join(outside, "secret.txt"),
+		"escape/secret.txt",
+		"/etc/passwd",
+	} {
+		if _, err := byName["read_file"].Execute(context.Background(), map[string]interface{}{"path": path}); err == nil {
+			t.Errorf("read_file(%s) should be denied", path)
+		}
+	}
+	for _, path := range []string{"escape/new.txt", "dangling"} {
+		if _, err := byName["write_file"].Execute(context.Background(), map[string]interface{}{"path": path, "content": "x"}); err == nil {
+			t.Errorf("write_file(%s) should be denied", path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outside, "missing.txt")); !os.IsNotExist(err) {
+		t.Error("writing through a dangling link created its target")
+	}
+
+	readOnly, err := filesystemTools(config.FilesystemToolConfig{AllowedDirectories: []string{root}, ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tool := range readOnly {
+		if tool.Name == "write_file" || tool.Name == "edit_file" {
+			t.Errorf("read_only should leave out %s", tool.Name)
+		}
+	}
+
+	if _, err := filesystemTools(config.FilesystemToolConfig{AllowedDirectories: []string{filepath.Join(root, "missing")}}); err == nil {
+		t.Error("a missing allowed directory should fail")
+	}
+}
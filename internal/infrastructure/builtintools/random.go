@@ -0,0 +1,199 @@
+package builtintools
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Limits on generate_random arguments
+const (
+	maxRandomCount  = 100
+	maxStringLength = 256
+)
+
+const randomAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// randomTool generates UUIDs and random values
+func randomTool() Tool {
+	return Tool{
+		Name:        "generate_random",
+		Description: "Generate UUIDs or random values: a UUID v4, an integer or decimal number in a range, a choice from a list, or an alphanumeric string.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"kind": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"uuid", "integer", "number", "choice", "string"},
+					"description": "What to generate (default: uuid)",
+				},
+				"min": map[string]interface{}{
+					"type":        "number",
+					"description": "Lowest value for integer and number (default: 0)",
+				},
+				"max": map[string]interface{}{
+					"type":        "number",
+					"description": "Highest value for integer and number, inclusive for integer (default: 100 for integer, 1 for number)",
+				},
+				"choices": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Options to pick from for choice",
+				},
+				"length": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Length of a string (default: 16, max: %d)", maxStringLength),
+				},
+				"count": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("How many values to generate (default: 1, max: %d)", maxRandomCount),
+				},
+			},
+		},
+		Execute: generateRandom,
+	}
+}
+
+func generateRandom(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	kind := stringArg(arguments, "kind", "uuid")
+
+	count, err := intArg(arguments, "count", 1)
+	if err != nil {
+		return "", err
+	}
+	if count < 1 || count > maxRandomCount {
+		return "", fmt.Errorf("count must be between 1 and %d", maxRandomCount)
+	}
+
+	var generate func() (interface{}, error)
+	switch kind {
+	case "uuid":
+		generate = func() (interface{}, error) { return NewUUID() }
+
+	case "integer":
+		low, err := intArg(arguments, "min", 0)
+		if err != nil {
+			return "", err
+		}
+		high, err := intArg(arguments, "max", 100)
+		if err != nil {
+			return "", err
+		}
+		if high < low {
+			return "", fmt.Errorf("max (%d) must not be less than min (%d)", high, low)
+		}
+		span := new(big.Int).Add(new(big.Int).Sub(big.NewInt(high), big.NewInt(low)), big.NewInt(1))
+		generate = func() (interface{}, error) {
+			n, err := rand.Int(rand.Reader, span)
+			if err != nil {
+				return nil, err
+			}
+			return n.Int64() + low, nil
+		}
+
+	case "number":
+		low, _, err := numberArg(arguments, "min")
+		if err != nil {
+			return "", err
+		}
+		high, present, err := numberArg(arguments, "max")
+		if err != nil {
+			return "", err
+		}
+		if !present {
+			high = 1
+		}
+		if high < low {
+			return "", fmt.Errorf("max must not be less than min")
+		}
+		generate = func() (interface{}, error) {
+			n, err := rand.Int(rand.Reader, big.NewInt(1<<53))
+			if err != nil {
+				return nil, err
+			}
+			return low + (high-low)*float64(n.Int64())/(1<<53), nil
+		}
+
+	case "choice":
+		choices, ok := arguments["choices"].([]interface{})
+		if !ok || len(choices) == 0 {
+			return "", fmt.Errorf("choices is required for kind 'choice'")
+		}
+		generate = func() (interface{}, error) {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(choices))))
+			if err != nil {
+				return nil, err
+			}
+			return choices[n.Int64()], nil
+		}
+
+	case "string":
+		length, err := intArg(arguments, "length", 16)
+		if err != nil {
+			return "", err
+		}
+		if length < 1 || length > maxStringLength {
+			return "", fmt.Errorf("length must be between 1 and %d", maxStringLength)
+		}
+		generate = func() (interface{}, error) { return randomString(int(length)) }
+
+	default:
+		return "", fmt.Errorf("unknown kind '%s' (expected uuid, integer, number, choice or string)", kind)
+	}
+
+	values := make([]interface{}, 0, count)
+	for i := int64(0); i < count; i++ {
+		value, err := generate()
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random value: %w", err)
+		}
+		values = append(values, value)
+	}
+
+	if count == 1 {
+		return jsonResult(map[string]interface{}{"kind": kind, "value": values[0]})
+	}
+	return jsonResult(map[string]interface{}{"kind": kind, "values": values})
+}
+
+// NewUUID returns a random (version 4) UUID
+func NewUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// randomString returns an alphanumeric string of the given length
+func randomString(length int) (string, error) {
+	out := make([]byte, length)
+	alphabetSize := big.NewInt(int64(len(randomAlphabet)))
+	for i := range out {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		out[i] = randomAlphabet[n.Int64()]
+	}
+	return string(out), nil
+}
+
+// intArg returns a whole-number argument, or def when it is missing
+func intArg(arguments map[string]interface{}, name string, def int64) (int64, error) {
+	value, present, err := numberArg(arguments, name)
+	if err != nil {
+		return 0, err
+	}
+	if !present {
+		return def, nil
+	}
+	if value != math.Trunc(value) || math.Abs(value) > 1<<53 {
+		return 0, fmt.Errorf("%s must be a whole number", name)
+	}
+	return int64(value), nil
+}
@@ -0,0 +1,684 @@
+package builtintools
+
+import (
+	"html"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// htmlNode is an element or text node of a parsed HTML document
+type htmlNode struct {
+	tag      string // Lower-case element name; "" for text
+	text     string
+	attrs    map[string]string
+	parent   *htmlNode
+	children []*htmlNode
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// Elements whose content is not markup
+var rawTextElements = map[string]bool{
+	"script": true, "style": true, "title": true, "textarea": true, "noscript": true,
+}
+
+// impliedEnds lists, for a start tag, the open elements it closes
+var impliedEnds = map[string][]string{
+	"li":     {"p", "li"},
+	"dt":     {"p", "dt", "dd"},
+	"dd":     {"p", "dt", "dd"},
+	"tr":     {"td", "th", "tr"},
+	"td":     {"td", "th"},
+	"th":     {"td", "th"},
+	"option": {"option"},
+}
+
+// Block elements that end an open paragraph
+var closesParagraph = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "div": true, "dl": true,
+	"footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "ul": true, "figure": true,
+}
+
+// parseHTML builds a tolerant document tree. It is not a full HTML5 parser,
+// but copes with unclosed and misnested tags well enough to find the text.
+func parseHTML(src string) *htmlNode {
+	root := &htmlNode{tag: "#document"}
+	stack := []*htmlNode{root}
+	top := func() *htmlNode { return stack[len(stack)-1] }
+	appendText := func(text string) {
+		if text == "" {
+			return
+		}
+		parent := top()
+		parent.children = append(parent.children, &htmlNode{text: html.UnescapeString(text), parent: parent})
+	}
+
+	for i := 0; i < len(src); {
+		lt := strings.IndexByte(src[i:], '<')
+		if lt < 0 {
+			appendText(src[i:])
+			break
+		}
+		appendText(src[i : i+lt])
+		i += lt
+		rest := src[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return root
+			}
+			i += 4 + end + 3
+
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return root
+			}
+			i += end + 1
+
+		case strings.HasPrefix(rest, "</"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return root
+			}
+			name := ""
+			if fields := strings.Fields(rest[2:end]); len(fields) > 0 {
+				name = strings.ToLower(fields[0])
+			}
+			for j := len(stack) - 1; j > 0; j-- {
+				if stack[j].tag == name {
+					stack = stack[:j]
+					break
+				}
+			}
+			i += end + 1
+
+		case len(rest) > 1 && isASCIILetter(rest[1]):
+			name, attrs, selfClosing, n := parseTag(rest)
+			i += n
+
+			ends := impliedEnds[name]
+			if closesParagraph[name] {
+				ends = []string{"p"}
+			}
+			for len(stack) > 1 && containsString(ends, top().tag) {
+				stack = stack[:len(stack)-1]
+			}
+
+			parent := top()
+			node := &htmlNode{tag: name, attrs: attrs, parent: parent}
+			parent.children = append(parent.children, node)
+
+			if rawTextElements[name] && !selfClosing {
+				closing := strings.Index(strings.ToLower(src[i:]), "</"+name)
+				if closing < 0 {
+					closing = len(src) - i
+				}
+				if name == "title" || name == "textarea" {
+					node.children = append(node.children, &htmlNode{text: html.UnescapeString(src[i : i+closing]), parent: node})
+				}
+				i += closing
+				if end := strings.IndexByte(src[i:], '>'); end >= 0 {
+					i += end + 1
+				}
+				continue
+			}
+			if !selfClosing && !voidElements[name] {
+				stack = append(stack, node)
+			}
+
+		default:
+			appendText("<")
+			i++
+		}
+	}
+	return root
+}
+
+// parseTag reads a start tag, returning its name, attributes, whether it
+// ends with "/>" and how many bytes it spans
+func parseTag(s string) (string, map[string]string, bool, int) {
+	i := 1
+	for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+		i++
+	}
+	name := strings.ToLower(s[1:i])
+	attrs := make(map[string]string)
+
+	for i < len(s) {
+		for i < len(s) && (isTagSpace(s[i]) || s[i] == '/') {
+			if s[i] == '/' && i+1 < len(s) && s[i+1] == '>' {
+				return name, attrs, true, i + 2
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '>' {
+			return name, attrs, false, i + 1
+		}
+
+		start := i
+		for i < len(s) && !isTagSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		key := strings.ToLower(s[start:i])
+		value := ""
+		for i < len(s) && isTagSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isTagSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					end = len(s) - i - 1
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		if key != "" {
+			attrs[key] = html.UnescapeString(value)
+		}
+	}
+	return name, attrs, false, len(s)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// Readability heuristics, after Mozilla's Readability.js
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)-ad-|banner|breadcrumb|combx|comment|community|cookie|disqus|footer|gdpr|legends|menu|modal|newsletter|pager|pagination|popup|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe`)
+	maybeCandidates    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveNames      = regexp.MustCompile(`(?i)article|body|content|entry|hentry|main|page|post|text|blog|story`)
+	negativeNames      = regexp.MustCompile(`(?i)-ad-|hidden|banner|combx|comment|com-|contact|foot|footnote|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+)
+
+// Elements that never hold article text
+var boilerplateElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true, "canvas": true,
+	"iframe": true, "object": true, "embed": true, "form": true, "button": true, "input": true,
+	"select": true, "textarea": true, "nav": true, "aside": true, "footer": true, "dialog": true,
+	"head": true, "link": true, "meta": true,
+}
+
+// article is the readable content extracted from a page
+type article struct {
+	Title   string
+	Content string
+}
+
+// extractReadable finds a page's main content and renders it as Markdown,
+// or as plain text when plain is set
+func extractReadable(src string, base *url.URL, plain bool) article {
+	doc := parseHTML(src)
+
+	result := article{Title: collapseSpace(innerText(findFirst(doc, "title")))}
+	removeBoilerplate(doc)
+
+	content := topCandidate(doc)
+	r := &markdownRenderer{base: base, plain: plain}
+	r.render(content)
+	result.Content = tidyMarkdown(r.String())
+	return result
+}
+
+// removeBoilerplate drops elements that are navigation, chrome or hidden
+func removeBoilerplate(n *htmlNode) {
+	kept := n.children[:0]
+	for _, child := range n.children {
+		if child.tag != "" && isBoilerplate(child) {
+			continue
+		}
+		removeBoilerplate(child)
+		kept = append(kept, child)
+	}
+	n.children = kept
+}
+
+func isBoilerplate(n *htmlNode) bool {
+	if boilerplateElements[n.tag] {
+		return true
+	}
+	if _, hidden := n.attrs["hidden"]; hidden || n.attrs["aria-hidden"] == "true" {
+		return true
+	}
+	if style := strings.ReplaceAll(strings.ToLower(n.attrs["style"]), " ", ""); strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+		return true
+	}
+	if n.tag == "header" && !hasAncestor(n, "article", "main") {
+		return true
+	}
+	switch n.tag {
+	case "html", "body", "article", "main", "a":
+		return false
+	}
+	names := n.attrs["class"] + " " + n.attrs["id"]
+	if role := n.attrs["role"]; role == "navigation" || role == "banner" || role == "complementary" || role == "contentinfo" || role == "dialog" {
+		return true
+	}
+	return unlikelyCandidates.MatchString(names) && !maybeCandidates.MatchString(names)
+}
+
+// topCandidate scores elements by the paragraph text they hold and
+// returns the best, falling back to <article>, <main> or <body>
+func topCandidate(doc *htmlNode) *htmlNode {
+	scores := make(map[*htmlNode]float64)
+	var order []*htmlNode
+	addScore := func(n *htmlNode, score float64) {
+		if n == nil || n.tag == "" || n.tag == "#document" || n.tag == "html" {
+			return
+		}
+		if _, seen := scores[n]; !seen {
+			scores[n] = initialScore(n)
+			order = append(order, n)
+		}
+		scores[n] += score
+	}
+
+	walk(doc, func(n *htmlNode) {
+		switch n.tag {
+		case "p", "pre", "td", "blockquote":
+		default:
+			return
+		}
+		text := collapseSpace(innerText(n))
+		if len(text) < 25 {
+			return
+		}
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text)/100), 3)
+		addScore(n.parent, score)
+		if n.parent != nil {
+			addScore(n.parent.parent, score/2)
+		}
+	})
+
+	var best *htmlNode
+	bestScore := 0.0
+	for _, n := range order {
+		score := scores[n] * (1 - linkDensity(n))
+		if best == nil || score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	for _, tag := range []string{"article", "main", "body"} {
+		if n := findFirst(doc, tag); n != nil {
+			return n
+		}
+	}
+	return doc
+}
+
+func initialScore(n *htmlNode) float64 {
+	score := 0.0
+	switch n.tag {
+	case "div", "article", "main", "section":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+	names := n.attrs["class"] + " " + n.attrs["id"]
+	if strings.TrimSpace(names) != "" {
+		if negativeNames.MatchString(names) {
+			score -= 25
+		}
+		if positiveNames.MatchString(names) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// linkDensity is the share of an element's text that sits inside links
+func linkDensity(n *htmlNode) float64 {
+	total := len(collapseSpace(innerText(n)))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	walk(n, func(child *htmlNode) {
+		if child.tag == "a" {
+			linked += len(collapseSpace(innerText(child)))
+		}
+	})
+	return math.Min(float64(linked)/float64(total), 1)
+}
+
+func walk(n *htmlNode, fn func(*htmlNode)) {
+	fn(n)
+	for _, child := range n.children {
+		walk(child, fn)
+	}
+}
+
+func findFirst(n *htmlNode, tag string) *htmlNode {
+	if n.tag == tag {
+		return n
+	}
+	for _, child := range n.children {
+		if found := findFirst(child, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func hasAncestor(n *htmlNode, tags ...string) bool {
+	for p := n.parent; p != nil; p = p.parent {
+		for _, tag := range tags {
+			if p.tag == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func innerText(n *htmlNode) string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	walk(n, func(child *htmlNode) {
+		if child.tag == "" {
+			b.WriteString(child.text)
+		}
+	})
+	return b.String()
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// markdownRenderer writes a document tree as Markdown
+type markdownRenderer struct {
+	b     strings.Builder
+	base  *url.URL
+	plain bool
+	list  []string // Markers of the enclosing lists ("-" or "1.")
+	inPre bool
+}
+
+func (r *markdownRenderer) String() string {
+	return r.b.String()
+}
+
+// block starts a new paragraph
+func (r *markdownRenderer) block() {
+	r.b.WriteString("\n\n")
+}
+
+// inline writes text, collapsing whitespace outside <pre>
+func (r *markdownRenderer) inline(text string) {
+	if r.inPre {
+		r.b.WriteString(text)
+		return
+	}
+	if text == "" {
+		return
+	}
+	leading := isTagSpace(text[0])
+	trailing := isTagSpace(text[len(text)-1])
+	text = collapseSpace(text)
+	current := r.b.String()
+	if leading && len(current) > 0 && !strings.HasSuffix(current, " ") && !strings.HasSuffix(current, "\n") {
+		r.b.WriteString(" ")
+	}
+	r.b.WriteString(text)
+	if trailing && text != "" {
+		r.b.WriteString(" ")
+	}
+}
+
+// sub renders n's children on their own and returns the result
+func (r *markdownRenderer) sub(n *htmlNode) string {
+	child := &markdownRenderer{base: r.base, plain: r.plain, list: r.list, inPre: r.inPre}
+	child.renderChildren(n)
+	return child.String()
+}
+
+func (r *markdownRenderer) renderChildren(n *htmlNode) {
+	for _, child := range n.children {
+		r.render(child)
+	}
+}
+
+func (r *markdownRenderer) render(n *htmlNode) {
+	if n.tag == "" {
+		r.inline(n.text)
+		return
+	}
+
+	switch n.tag {
+	case "title", "head":
+		return
+
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := collapseSpace(r.sub(n))
+		if text == "" {
+			return
+		}
+		r.block()
+		if !r.plain {
+			r.b.WriteString(strings.Repeat("#", int(n.tag[1]-'0')) + " ")
+		}
+		r.b.WriteString(text)
+		r.block()
+
+	case "br":
+		r.b.WriteString("\n")
+
+	case "hr":
+		r.block()
+		if !r.plain {
+			r.b.WriteString("---")
+		}
+		r.block()
+
+	case "pre":
+		r.block()
+		r.inPre = true
+		text := strings.Trim(r.sub(n), "\n")
+		r.inPre = false
+		if r.plain {
+			r.b.WriteString(text)
+		} else {
+			r.b.WriteString("```\n" + text + "\n```")
+		}
+		r.block()
+
+	case "code", "kbd", "samp":
+		if r.inPre || r.plain {
+			r.renderChildren(n)
+			return
+		}
+		r.wrap(r.sub(n), "`", "`")
+
+	case "strong", "b":
+		r.emphasis(n, "**")
+
+	case "em", "i":
+		r.emphasis(n, "*")
+
+	case "a":
+		href := r.resolve(n.attrs["href"])
+		if r.plain || href == "" {
+			r.renderChildren(n)
+			return
+		}
+		r.wrap(r.sub(n), "[", "]("+href+")")
+
+	case "img":
+		alt := collapseSpace(n.attrs["alt"])
+		src := r.resolve(n.attrs["src"])
+		if alt == "" || r.plain || src == "" {
+			return
+		}
+		r.inline("![" + alt + "](" + src + ")")
+
+	case "ul", "ol":
+		marker := "-"
+		if n.tag == "ol" {
+			marker = "1."
+		}
+		r.block()
+		r.list = append(r.list, marker)
+		r.renderChildren(n)
+		r.list = r.list[:len(r.list)-1]
+		r.block()
+
+	case "li":
+		marker := "-"
+		if len(r.list) > 0 {
+			marker = r.list[len(r.list)-1]
+		}
+		// Items stay tight; nested lines, including nested lists, are
+		// indented under the marker
+		body := strings.ReplaceAll(tidyMarkdown(r.sub(n)), "\n\n", "\n")
+		body = strings.ReplaceAll(body, "\n", "\n  ")
+		r.b.WriteString("\n" + marker + " " + body)
+
+	case "blockquote":
+		body := strings.TrimSpace(tidyMarkdown(r.sub(n)))
+		r.block()
+		if r.plain {
+			r.b.WriteString(body)
+		} else {
+			r.b.WriteString("> " + strings.ReplaceAll(body, "\n", "\n> "))
+		}
+		r.block()
+
+	case "tr":
+		var cells []string
+		header := false
+		for _, child := range n.children {
+			if child.tag == "td" || child.tag == "th" {
+				cells = append(cells, strings.ReplaceAll(collapseSpace(r.sub(child)), "|", "\\|"))
+				header = header || child.tag == "th"
+			}
+		}
+		if len(cells) == 0 {
+			return
+		}
+		if r.plain {
+			r.b.WriteString("\n" + strings.Join(cells, "\t"))
+			return
+		}
+		r.b.WriteString("\n| " + strings.Join(cells, " | ") + " |")
+		if header {
+			r.b.WriteString("\n|" + strings.Repeat(" --- |", len(cells)))
+		}
+
+	case "table":
+		r.block()
+		r.renderChildren(n)
+		r.block()
+
+	case "p", "div", "section", "article", "main", "header", "figure", "figcaption",
+		"dl", "dt", "dd", "address", "details", "summary":
+		r.block()
+		r.renderChildren(n)
+		r.block()
+
+	default:
+		r.renderChildren(n)
+	}
+}
+
+func (r *markdownRenderer) emphasis(n *htmlNode, marker string) {
+	if r.plain || r.inPre {
+		r.renderChildren(n)
+		return
+	}
+	r.wrap(r.sub(n), marker, marker)
+}
+
+// wrap writes rendered inline content between open and close, keeping
+// the whitespace that surrounded it
+func (r *markdownRenderer) wrap(raw, open, close string) {
+	text := collapseSpace(raw)
+	if text == "" {
+		r.inline(raw)
+		return
+	}
+	if isTagSpace(raw[0]) {
+		open = " " + open
+	}
+	if isTagSpace(raw[len(raw)-1]) {
+		close += " "
+	}
+	r.inline(open + text + close)
+}
+
+// resolve makes a link absolute, dropping script and fragment-only links
+func (r *markdownRenderer) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(strings.ToLower(ref), "javascript:") {
+		return ""
+	}
+	if r.base == nil {
+		return ref
+	}
+	u, err := r.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// tidyMarkdown trims trailing spaces and collapses runs of blank lines
+func tidyMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	s = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(s, "\n\n"))
+}
@@ -0,0 +1,103 @@
+package builtintools
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+// robotsRules are the robots.txt rules that apply to one user agent
+type robotsRules struct {
+	rules []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// parseRobots extracts the rules for agent from a robots.txt file, per
+// RFC 9309: groups naming the agent win over the "*" group, and groups
+// for the same agent are merged
+func parseRobots(body, agent string) *robotsRules {
+	agent = strings.ToLower(agent)
+
+	var specific, wildcard []robotsRule
+	var groupAgents []string
+	inRules := false // Seen a rule since the last user-agent line
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				groupAgents = nil
+				inRules = false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // An empty disallow allows everything
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value, re: robotsPattern(value)}
+			for _, groupAgent := range groupAgents {
+				switch {
+				case groupAgent == "*":
+					wildcard = append(wildcard, rule)
+				case groupAgent != "" && strings.Contains(agent, groupAgent):
+					specific = append(specific, rule)
+				}
+			}
+		}
+	}
+
+	if specific != nil {
+		return &robotsRules{rules: specific}
+	}
+	return &robotsRules{rules: wildcard}
+}
+
+// robotsPattern compiles a robots.txt path pattern, where * matches any
+// characters and a trailing $ anchors the end
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// allowed reports whether path (with any query) may be fetched: the
+// longest matching rule decides, and allow wins ties
+func (r *robotsRules) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	best := -1
+	allow := true
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		if len(rule.pattern) > best || (len(rule.pattern) == best && rule.allow) {
+			best = len(rule.pattern)
+			allow = rule.allow
+		}
+	}
+	return allow
+}
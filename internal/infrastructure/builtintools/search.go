@@ -0,0 +1,340 @@
+package builtintools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// Limits on search_web results
+const (
+	DefaultSearchResults = 5
+	DefaultSearchTimeout = 30 * time.Second
+	maxSearchResults     = 20
+	maxSearchResponse    = 4 << 20
+)
+
+// Default API endpoints, overridden by builtin_tools.search.endpoint
+var searchEndpoints = map[string]string{
+	config.SearchBrave:  "https://api.search.brave.com/res/v1/web/search",
+	config.SearchBing:   "https://api.bing.microsoft.com/v7.0/search",
+	config.SearchTavily: "https://api.tavily.com/search",
+}
+
+// SearchResult is one web search hit
+type SearchResult struct {
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Snippet   string `json:"snippet,omitempty"`
+	Published string `json:"published,omitempty"`
+}
+
+// searchBackend runs a query against one search service
+type searchBackend func(ctx context.Context, s *searcher, query string, count int) ([]SearchResult, error)
+
+var searchBackends = map[string]searchBackend{
+	config.SearchSearXNG: searchSearXNG,
+	config.SearchBrave:   searchBrave,
+	config.SearchBing:    searchBing,
+	config.SearchTavily:  searchTavily,
+}
+
+// searcher holds the settings and client of a search_web tool
+type searcher struct {
+	cfg      config.SearchToolConfig
+	endpoint string
+	backend  searchBackend
+	client   *http.Client
+}
+
+// newSearcher checks cfg and applies defaults
+func newSearcher(cfg config.SearchToolConfig) (*searcher, error) {
+	cfg.Backend = strings.ToLower(cfg.Backend)
+	backend, ok := searchBackends[cfg.Backend]
+	if !ok {
+		return nil, fmt.Errorf("unknown search backend '%s' (expected searxng, brave, bing or tavily)", cfg.Backend)
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = searchEndpoints[cfg.Backend]
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("search backend '%s' needs an endpoint (the URL of your SearXNG instance)", cfg.Backend)
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if cfg.Backend == config.SearchSearXNG && !strings.HasSuffix(endpoint, "/search") {
+		endpoint += "/search"
+	}
+	if cfg.Backend != config.SearchSearXNG && cfg.APIKey == "" {
+		return nil, fmt.Errorf("search backend '%s' needs an api_key", cfg.Backend)
+	}
+
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = DefaultSearchResults
+	}
+	if cfg.MaxResults > maxSearchResults {
+		cfg.MaxResults = maxSearchResults
+	}
+	switch cfg.SafeSearch = strings.ToLower(cfg.SafeSearch); cfg.SafeSearch {
+	case "":
+		cfg.SafeSearch = "moderate"
+	case "off", "moderate", "strict":
+	default:
+		return nil, fmt.Errorf("safe_search must be off, moderate or strict, got '%s'", cfg.SafeSearch)
+	}
+	timeout := DefaultSearchTimeout
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+
+	return &searcher{
+		cfg:      cfg,
+		endpoint: endpoint,
+		backend:  backend,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// searchTool builds the search_web tool for a configured backend
+func searchTool(cfg config.SearchToolConfig) (Tool, error) {
+	s, err := newSearcher(cfg)
+	if err != nil {
+		return Tool{}, err
+	}
+	return Tool{
+		Name: "search_web",
+		Description: "Search the web and get back titles, URLs and snippets of the top results. " +
+			"Use this to find current information or sources; fetch a result's URL to read it in full.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query",
+				},
+				"max_results": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Number of results (default and max: %d)", s.cfg.MaxResults),
+				},
+			},
+			"required": []string{"query"},
+		},
+		Execute: s.execute,
+	}, nil
+}
+
+func (s *searcher) execute(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	query := strings.TrimSpace(stringArg(arguments, "query", ""))
+	if query == "" {
+		return "", fmt.Errorf("query is required")
+	}
+	count, err := intArg(arguments, "max_results", int64(s.cfg.MaxResults))
+	if err != nil {
+		return "", err
+	}
+	if count <= 0 || count > int64(s.cfg.MaxResults) {
+		count = int64(s.cfg.MaxResults)
+	}
+
+	results, err := s.backend(ctx, s, query, int(count))
+	if err != nil {
+		return "", fmt.Errorf("%s search failed: %w", s.cfg.Backend, err)
+	}
+	if int64(len(results)) > count {
+		results = results[:count]
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+	for i := range results {
+		results[i].Title = stripTags(results[i].Title)
+		results[i].Snippet = stripTags(results[i].Snippet)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"query":   query,
+		"backend": s.cfg.Backend,
+		"results": results,
+	})
+}
+
+// do sends a request and decodes its JSON response into out
+func (s *searcher) do(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", DefaultUserAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSearchResponse))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(truncate(string(body), 300)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("unexpected response: %w", err)
+	}
+	return nil
+}
+
+func (s *searcher) get(ctx context.Context, params url.Values, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return s.do(req, out)
+}
+
+// searchSearXNG queries a SearXNG instance's JSON API, which must be
+// enabled in its settings (search.formats: [html, json])
+func searchSearXNG(ctx context.Context, s *searcher, query string, count int) ([]SearchResult, error) {
+	params := url.Values{"q": {query}, "format": {"json"}}
+	params.Set("safesearch", map[string]string{"off": "0", "moderate": "1", "strict": "2"}[s.cfg.SafeSearch])
+	if s.cfg.Language != "" {
+		params.Set("language", s.cfg.Language)
+	}
+
+	var resp struct {
+		Results []struct {
+			Title         string `json:"title"`
+			URL           string `json:"url"`
+			Content       string `json:"content"`
+			PublishedDate string `json:"publishedDate"`
+		} `json:"results"`
+	}
+	if err := s.get(ctx, params, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, r := range resp.Results {
+		results = append(results, SearchResult{Title: r.Title, URL: r.URL, Snippet: r.Content, Published: r.PublishedDate})
+	}
+	return results, nil
+}
+
+// searchBrave queries the Brave Search API
+func searchBrave(ctx context.Context, s *searcher, query string, count int) ([]SearchResult, error) {
+	params := url.Values{"q": {query}, "count": {strconv.Itoa(count)}, "safesearch": {s.cfg.SafeSearch}}
+	if s.cfg.Language != "" {
+		params.Set("search_lang", strings.SplitN(s.cfg.Language, "-", 2)[0])
+	}
+
+	var resp struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+				Age         string `json:"age"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	if err := s.get(ctx, params, map[string]string{"X-Subscription-Token": s.cfg.APIKey}, &resp); err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, r := range resp.Web.Results {
+		results = append(results, SearchResult{Title: r.Title, URL: r.URL, Snippet: r.Description, Published: r.Age})
+	}
+	return results, nil
+}
+
+// searchBing queries the Bing Web Search API
+func searchBing(ctx context.Context, s *searcher, query string, count int) ([]SearchResult, error) {
+	params := url.Values{"q": {query}, "count": {strconv.Itoa(count)}, "textDecorations": {"false"}}
+	params.Set("safeSearch", map[string]string{"off": "Off", "moderate": "Moderate", "strict": "Strict"}[s.cfg.SafeSearch])
+	if s.cfg.Language != "" {
+		params.Set("mkt", s.cfg.Language)
+	}
+
+	var resp struct {
+		WebPages struct {
+			Value []struct {
+				Name            string `json:"name"`
+				URL             string `json:"url"`
+				Snippet         string `json:"snippet"`
+				DateLastCrawled string `json:"dateLastCrawled"`
+			} `json:"value"`
+		} `json:"webPages"`
+	}
+	if err := s.get(ctx, params, map[string]string{"Ocp-Apim-Subscription-Key": s.cfg.APIKey}, &resp); err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, r := range resp.WebPages.Value {
+		results = append(results, SearchResult{Title: r.Name, URL: r.URL, Snippet: r.Snippet, Published: r.DateLastCrawled})
+	}
+	return results, nil
+}
+
+// searchTavily queries the Tavily search API
+func searchTavily(ctx context.Context, s *searcher, query string, count int) ([]SearchResult, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":        query,
+		"max_results":  count,
+		"search_depth": "basic",
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+
+	var resp struct {
+		Results []struct {
+			Title         string `json:"title"`
+			URL           string `json:"url"`
+			Content       string `json:"content"`
+			PublishedDate string `json:"published_date"`
+		} `json:"results"`
+	}
+	if err := s.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, r := range resp.Results {
+		results = append(results, SearchResult{Title: r.Title, URL: r.URL, Snippet: r.Content, Published: r.PublishedDate})
+	}
+	return results, nil
+}
+
+// stripTags removes highlighting markup such as <strong> from a snippet
+func stripTags(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return s
+	}
+	return collapseSpace(innerText(parseHTML(s)))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,126 @@
+package builtintools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestSearchBackends(t *testing.T) {
+	tests := []struct {
+		backend  string
+		response string
+		check    func(r *http.Request) error
+	}{
+		{
+			backend:  config.SearchSearXNG,
+			response: `{"results": [{"title": "Go", "url": "https://go.dev", "content": "The Go <b>language</b>"}, {"title": "Extra", "url": "https://x"}]}`,
+			check: func(r *http.Request) error {
+				if r.URL.Path != "/search" || r.URL.Query().Get("format") != "json" || r.URL.Query().Get("safesearch") != "2" {
+					return fmt.Errorf("request %s", r.URL)
+				}
+				return nil
+			},
+		},
+		{
+			backend:  config.SearchBrave,
+			response: `{"web": {"results": [{"title": "Go", "url": "https://go.dev", "description": "The Go <strong>language</strong>", "age": "2 days ago"}]}}`,
+			check: func(r *http.Request) error {
+				if r.Header.Get("X-Subscription-Token") != "key" || r.URL.Query().Get("count") != "1" {
+					return fmt.Errorf("request %s %v", r.URL, r.Header)
+				}
+				return nil
+			},
+		},
+		{
+			backend:  config.SearchBing,
+			response: `{"webPages": {"value": [{"name": "Go", "url": "https://go.dev", "snippet": "The Go language"}]}}`,
+			check: func(r *http.Request) error {
+				if r.Header.Get("Ocp-Apim-Subscription-Key") != "key" || r.URL.Query().Get("safeSearch") != "Strict" {
+					return fmt.Errorf("request %s %v", r.URL, r.Header)
+				}
+				return nil
+			},
+		},
+		{
+			backend:  config.SearchTavily,
+			response: `{"results": [{"title": "Go", "url": "https://go.dev", "content": "The Go language"}]}`,
+			check: func(r *http.Request) error {
+				var body map[string]interface{}
+				data, _ := io.ReadAll(r.Body)
+				json.Unmarshal(data, &body)
+				if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer key" || body["query"] != "golang" {
+					return fmt.Errorf("request %s %v %s", r.Method, r.Header, data)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := tt.check(r); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				fmt.Fprint(w, tt.response)
+			}))
+			defer server.Close()
+
+			tool, err := searchTool(config.SearchToolConfig{Backend: tt.backend, APIKey: "key", Endpoint: server.URL, SafeSearch: "strict"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := tool.Execute(context.Background(), map[string]interface{}{"query": "golang", "max_results": 1.0})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result struct {
+				Results []SearchResult `json:"results"`
+			}
+			if err := json.Unmarshal([]byte(out), &result); err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Results) != 1 {
+				t.Fatalf("got %d results, want 1:\n%s", len(result.Results), out)
+			}
+			if r := result.Results[0]; r.Title != "Go" || r.URL != "https://go.dev" || r.Snippet != "The Go language" {
+				t.Errorf("result = %+v", r)
+			}
+		})
+	}
+}
+
+func TestSearchConfigErrors(t *testing.T) {
+	for _, cfg := range []config.SearchToolConfig{
+		{Backend: "altavista"},
+		{Backend: config.SearchSearXNG},
+		{Backend: config.SearchBrave},
+		{Backend: config.SearchBing, APIKey: "key", SafeSearch: "maximum"},
+	} {
+		if _, err := searchTool(cfg); err == nil {
+			t.Errorf("searchTool(%+v) should fail", cfg)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "invalid key"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	tool, err := searchTool(config.SearchToolConfig{Backend: config.SearchBrave, APIKey: "bad", Endpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"query": "x"}); err == nil || !strings.Contains(err.Error(), "HTTP 401") {
+		t.Errorf("err = %v, want HTTP 401", err)
+	}
+}
@@ -0,0 +1,256 @@
+// Package builtintools provides small helper tools (time, arithmetic,
+// random values and unit conversion) that every LLM session can call
+// without an external MCP server.
+package builtintools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Tool is a built-in tool: its schema and the function that runs it
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{}
+	Execute     func(ctx context.Context, arguments map[string]interface{}) (string, error)
+}
+
+// Defaults returns the built-in tools that are on unless excluded. Opt-in
+// tools such as fetch_url are added by Wrap when settings enable them.
+func Defaults() []Tool {
+	return []Tool{
+		currentTimeTool(),
+		calculateTool(),
+		randomTool(),
+		convertUnitsTool(),
+	}
+}
+
+// ServerManager adds built-in tools to another server manager. Tools from
+// the wrapped manager win when names collide.
+type ServerManager struct {
+	externalServers domain.MCPServerManager
+	tools           map[string]Tool
+	order           []string
+
+	mu       sync.Mutex
+	shadowed map[string]bool // Built-in names an external server also offers
+}
+
+// NewServerManager wraps external (which may be nil) with the given tools
+func NewServerManager(external domain.MCPServerManager, tools []Tool) *ServerManager {
+	sm := &ServerManager{
+		externalServers: external,
+		tools:           make(map[string]Tool, len(tools)),
+	}
+	for _, tool := range tools {
+		sm.tools[tool.Name] = tool
+		sm.order = append(sm.order, tool.Name)
+	}
+	return sm
+}
+
+// Wrap adds the default built-in tools, and any opt-in tools settings
+// enable, to a server manager. When settings turn built-in tools off,
+// external is returned unchanged.
+func Wrap(external domain.MCPServerManager, cfg *config.BuiltinToolsConfig) domain.MCPServerManager {
+	if !cfg.IsEnabled() {
+		return external
+	}
+
+	available := Defaults()
+	if cfg.FetchEnabled() {
+		available = append(available, fetchTool(*cfg.Fetch))
+	}
+	if cfg.SearchEnabled() {
+		if tool, err := searchTool(*cfg.Search); err != nil {
+			logging.Warn("search_web unavailable: %v", err)
+		} else {
+			available = append(available, tool)
+		}
+	}
+	if cfg.FilesystemEnabled() {
+		if fsTools, err := filesystemTools(*cfg.Filesystem); err != nil {
+			logging.Warn("Filesystem tools unavailable: %v", err)
+		} else {
+			available = append(available, fsTools...)
+		}
+	}
+
+	var tools []Tool
+	for _, tool := range available {
+		if !cfg.Excludes(tool.Name) {
+			tools = append(tools, tool)
+		}
+	}
+	if len(tools) == 0 {
+		return external
+	}
+
+	logging.Debug("Adding %d built-in tools to server manager", len(tools))
+	return NewServerManager(external, tools)
+}
+
+// GetAvailableTools returns tools from external servers followed by the
+// built-in tools they don't shadow
+func (sm *ServerManager) GetAvailableTools() ([]domain.Tool, error) {
+	var tools []domain.Tool
+	var err error
+	if sm.externalServers != nil {
+		tools, err = sm.externalServers.GetAvailableTools()
+	}
+	return sm.withBuiltins(tools, err), nil
+}
+
+// SelectTools narrows the external tools to a request when the external
+// servers support it; built-in tools are always offered
+func (sm *ServerManager) SelectTools(ctx context.Context, request string) ([]domain.Tool, error) {
+	var tools []domain.Tool
+	var err error
+	if sm.externalServers != nil {
+		tools, err = domain.SelectTools(ctx, sm.externalServers, request)
+	}
+	return sm.withBuiltins(tools, err), nil
+}
+
+// withBuiltins appends the built-in tools the external tools don't shadow
+func (sm *ServerManager) withBuiltins(externalTools []domain.Tool, err error) []domain.Tool {
+	allTools := []domain.Tool{}
+	taken := make(map[string]bool)
+	if err == nil {
+		allTools = append(allTools, externalTools...)
+		for _, tool := range externalTools {
+			taken[tool.Function.Name] = true
+		}
+	}
+
+	sm.mu.Lock()
+	sm.shadowed = taken
+	sm.mu.Unlock()
+
+	for _, name := range sm.order {
+		if taken[name] {
+			continue
+		}
+		tool := sm.tools[name]
+		allTools = append(allTools, domain.Tool{
+			Type: "function",
+			Function: domain.ToolFunction{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.Parameters,
+			},
+		})
+	}
+
+	return allTools
+}
+
+// ExecuteTool runs a built-in tool, or delegates to the external servers
+func (sm *ServerManager) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	sm.mu.Lock()
+	shadowed := sm.shadowed[toolName]
+	sm.mu.Unlock()
+
+	if tool, builtin := sm.tools[toolName]; builtin && !shadowed {
+		logging.Debug("Executing built-in tool '%s'", toolName)
+		return tool.Execute(ctx, arguments)
+	}
+
+	if sm.externalServers == nil {
+		return "", fmt.Errorf("tool '%s' not found (no external servers available)", toolName)
+	}
+	return sm.externalServers.ExecuteTool(ctx, toolName, arguments)
+}
+
+// StartServer delegates to external servers
+func (sm *ServerManager) StartServer(ctx context.Context, serverName string, cfg *config.ServerConfig) (domain.MCPServer, error) {
+	if sm.externalServers == nil {
+		return nil, fmt.Errorf("no external server manager available")
+	}
+	return sm.externalServers.StartServer(ctx, serverName, cfg)
+}
+
+// StopServer delegates to external servers
+func (sm *ServerManager) StopServer(serverName string) error {
+	if sm.externalServers == nil {
+		return nil
+	}
+	return sm.externalServers.StopServer(serverName)
+}
+
+// GetServer delegates to external servers
+func (sm *ServerManager) GetServer(serverName string) (domain.MCPServer, bool) {
+	if sm.externalServers == nil {
+		return nil, false
+	}
+	return sm.externalServers.GetServer(serverName)
+}
+
+// ListServers delegates to external servers
+func (sm *ServerManager) ListServers() map[string]domain.MCPServer {
+	if sm.externalServers == nil {
+		return map[string]domain.MCPServer{}
+	}
+	return sm.externalServers.ListServers()
+}
+
+// StopAll delegates to external servers
+func (sm *ServerManager) StopAll() error {
+	if sm.externalServers == nil {
+		return nil
+	}
+	return sm.externalServers.StopAll()
+}
+
+// jsonResult formats a tool result as indented JSON
+func jsonResult(value interface{}) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format result: %w", err)
+	}
+	return string(data), nil
+}
+
+// stringArg returns a string argument, or def when it is missing or empty
+func stringArg(arguments map[string]interface{}, name, def string) string {
+	if value, ok := arguments[name].(string); ok && value != "" {
+		return value
+	}
+	return def
+}
+
+// numberArg returns a numeric argument, accepting numbers sent as strings
+func numberArg(arguments map[string]interface{}, name string) (float64, bool, error) {
+	value, present := arguments[name]
+	if !present || value == nil {
+		return 0, false, nil
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true, nil
+	case int:
+		return float64(v), true, nil
+	case int64:
+		return float64(v), true, nil
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil, err
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("%s must be a number, got %q", name, v)
+		}
+		return f, true, nil
+	}
+	return 0, false, fmt.Errorf("%s must be a number", name)
+}
@@ -0,0 +1,63 @@
+package builtintools
+
+import (
+	"context"
+	"fmt"
+	"time"
+	_ "time/tzdata" // Timezones work on hosts without a zoneinfo database
+)
+
+// now is the clock used by get_current_time, replaced in tests
+var now = time.Now
+
+// currentTimeTool reports the current date and time in a timezone
+func currentTimeTool() Tool {
+	return Tool{
+		Name:        "get_current_time",
+		Description: "Get the current date and time. Use this instead of guessing today's date, the day of the week or the time in another timezone.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"timezone": map[string]interface{}{
+					"type":        "string",
+					"description": "IANA timezone name, e.g. 'Australia/Sydney', 'America/New_York' or 'UTC' (default: the local timezone)",
+				},
+			},
+		},
+		Execute: getCurrentTime,
+	}
+}
+
+func getCurrentTime(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	location := time.Local
+	if name := stringArg(arguments, "timezone", ""); name != "" {
+		loaded, err := time.LoadLocation(name)
+		if err != nil {
+			return "", fmt.Errorf("unknown timezone '%s': use an IANA name such as 'Europe/London'", name)
+		}
+		location = loaded
+	}
+
+	t := now().In(location)
+	zone, offset := t.Zone()
+	return jsonResult(map[string]interface{}{
+		"datetime":   t.Format(time.RFC3339),
+		"date":       t.Format("2006-01-02"),
+		"time":       t.Format("15:04:05"),
+		"weekday":    t.Weekday().String(),
+		"timezone":   location.String(),
+		"zone":       zone,
+		"utc_offset": formatOffset(offset),
+		"unix":       t.Unix(),
+	})
+}
+
+// formatOffset formats seconds east of UTC as +hh:mm
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
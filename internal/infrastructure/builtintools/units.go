@@ -0,0 +1,221 @@
+package builtintools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// unit is a unit of measure as a multiple of its dimension's base unit
+type unit struct {
+	dimension string
+	factor    float64
+}
+
+// units maps lower-case unit names and aliases to their definitions.
+// Temperatures are handled separately because they have offsets.
+var units = map[string]unit{}
+
+func init() {
+	define := func(dimension string, factor float64, names ...string) {
+		for _, name := range names {
+			units[name] = unit{dimension: dimension, factor: factor}
+		}
+	}
+
+	// Length (metres)
+	define("length", 0.001, "mm", "millimeter", "millimetre")
+	define("length", 0.01, "cm", "centimeter", "centimetre")
+	define("length", 1, "m", "meter", "metre")
+	define("length", 1000, "km", "kilometer", "kilometre")
+	define("length", 0.0254, "in", "inch", "inches")
+	define("length", 0.3048, "ft", "foot", "feet")
+	define("length", 0.9144, "yd", "yard")
+	define("length", 1609.344, "mi", "mile")
+	define("length", 1852, "nmi", "nautical mile")
+
+	// Mass (kilograms)
+	define("mass", 1e-6, "mg", "milligram")
+	define("mass", 0.001, "g", "gram")
+	define("mass", 1, "kg", "kilogram")
+	define("mass", 1000, "t", "tonne", "metric ton")
+	define("mass", 0.028349523125, "oz", "ounce")
+	define("mass", 0.45359237, "lb", "pound")
+	define("mass", 6.35029318, "st", "stone")
+
+	// Volume (litres)
+	define("volume", 0.001, "ml", "milliliter", "millilitre")
+	define("volume", 1, "l", "liter", "litre")
+	define("volume", 1000, "m3", "cubic meter", "cubic metre")
+	define("volume", 0.00492892159375, "tsp", "teaspoon")
+	define("volume", 0.01478676478125, "tbsp", "tablespoon")
+	define("volume", 0.0295735295625, "floz", "fl oz", "fluid ounce")
+	define("volume", 0.2365882365, "cup")
+	define("volume", 0.473176473, "pt", "pint")
+	define("volume", 0.946352946, "qt", "quart")
+	define("volume", 3.785411784, "gal", "gallon")
+
+	// Time (seconds)
+	define("time", 0.001, "ms", "millisecond")
+	define("time", 1, "s", "sec", "second")
+	define("time", 60, "min", "minute")
+	define("time", 3600, "h", "hr", "hour")
+	define("time", 86400, "d", "day")
+	define("time", 604800, "wk", "week")
+	define("time", 31557600, "yr", "year") // Julian year
+
+	// Data (bytes)
+	define("data", 0.125, "bit")
+	define("data", 1, "b", "byte")
+	define("data", 1e3, "kb", "kilobyte")
+	define("data", 1e6, "mb", "megabyte")
+	define("data", 1e9, "gb", "gigabyte")
+	define("data", 1e12, "tb", "terabyte")
+	define("data", 1<<10, "kib", "kibibyte")
+	define("data", 1<<20, "mib", "mebibyte")
+	define("data", 1<<30, "gib", "gibibyte")
+	define("data", 1<<40, "tib", "tebibyte")
+
+	// Speed (metres per second)
+	define("speed", 1, "m/s")
+	define("speed", 1/3.6, "km/h", "kph")
+	define("speed", 0.44704, "mph")
+	define("speed", 1852.0/3600, "kn", "knot")
+	define("speed", 0.3048, "ft/s")
+
+	// Area (square metres)
+	define("area", 1e-4, "cm2")
+	define("area", 1, "m2", "square meter", "square metre")
+	define("area", 1e4, "ha", "hectare")
+	define("area", 1e6, "km2", "square kilometer", "square kilometre")
+	define("area", 0.00064516, "in2", "square inch")
+	define("area", 0.09290304, "ft2", "square foot", "square feet")
+	define("area", 4046.8564224, "acre")
+	define("area", 2589988.110336, "mi2", "square mile")
+}
+
+// temperatures maps temperature unit names to their canonical symbol
+var temperatures = map[string]string{
+	"c": "C", "°c": "C", "celsius": "C",
+	"f": "F", "°f": "F", "fahrenheit": "F",
+	"k": "K", "kelvin": "K",
+}
+
+// convertUnitsTool converts values between units of measure
+func convertUnitsTool() Tool {
+	return Tool{
+		Name: "convert_units",
+		Description: "Convert a value between units of the same kind: length (mm, cm, m, km, in, ft, yd, mi, nmi), " +
+			"mass (mg, g, kg, t, oz, lb, st), volume (ml, l, m3, tsp, tbsp, floz, cup, pt, qt, gal; US measures), " +
+			"time (ms, s, min, h, d, wk, yr), data (bit, b, kb, mb, gb, tb, kib, mib, gib, tib), " +
+			"speed (m/s, km/h, mph, kn, ft/s), area (cm2, m2, ha, km2, in2, ft2, acre, mi2) and temperature (c, f, k).",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"value": map[string]interface{}{
+					"type":        "number",
+					"description": "Value to convert",
+				},
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Unit of the value, e.g. 'km' or 'fahrenheit'",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "Unit to convert to, e.g. 'mi' or 'celsius'",
+				},
+			},
+			"required": []string{"value", "from", "to"},
+		},
+		Execute: convertUnits,
+	}
+}
+
+func convertUnits(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	value, present, err := numberArg(arguments, "value")
+	if err != nil {
+		return "", err
+	}
+	if !present {
+		return "", fmt.Errorf("value is required")
+	}
+	from := stringArg(arguments, "from", "")
+	to := stringArg(arguments, "to", "")
+	if from == "" || to == "" {
+		return "", fmt.Errorf("from and to are required")
+	}
+
+	result, err := ConvertUnits(value, from, to)
+	if err != nil {
+		return "", err
+	}
+	return jsonResult(map[string]interface{}{
+		"value":  value,
+		"from":   from,
+		"to":     to,
+		"result": FormatNumber(result),
+	})
+}
+
+// ConvertUnits converts value from one unit to another of the same dimension
+func ConvertUnits(value float64, from, to string) (float64, error) {
+	fromTemp, fromIsTemp := temperatures[normalizeUnit(from)]
+	toTemp, toIsTemp := temperatures[normalizeUnit(to)]
+	if fromIsTemp || toIsTemp {
+		if !fromIsTemp || !toIsTemp {
+			return 0, fmt.Errorf("cannot convert between '%s' and '%s': different kinds of unit", from, to)
+		}
+		return convertTemperature(value, fromTemp, toTemp), nil
+	}
+
+	fromUnit, err := lookupUnit(from)
+	if err != nil {
+		return 0, err
+	}
+	toUnit, err := lookupUnit(to)
+	if err != nil {
+		return 0, err
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		return 0, fmt.Errorf("cannot convert %s ('%s') to %s ('%s')", fromUnit.dimension, from, toUnit.dimension, to)
+	}
+	return value * fromUnit.factor / toUnit.factor, nil
+}
+
+// lookupUnit finds a unit by name, accepting plurals such as "miles"
+func lookupUnit(name string) (unit, error) {
+	key := normalizeUnit(name)
+	if u, ok := units[key]; ok {
+		return u, nil
+	}
+	if u, ok := units[strings.TrimSuffix(key, "s")]; ok {
+		return u, nil
+	}
+	return unit{}, fmt.Errorf("unknown unit '%s'", name)
+}
+
+// normalizeUnit lower-cases a unit name and tidies spacing and squares
+func normalizeUnit(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Join(strings.Fields(name), " ")
+	name = strings.NewReplacer("²", "2", "³", "3", "^2", "2", "^3", "3", "sq ", "square ").Replace(name)
+	return name
+}
+
+// convertTemperature converts between C, F and K via Celsius
+func convertTemperature(value float64, from, to string) float64 {
+	celsius := value
+	switch from {
+	case "F":
+		celsius = (value - 32) * 5 / 9
+	case "K":
+		celsius = value - 273.15
+	}
+	switch to {
+	case "F":
+		return celsius*9/5 + 32
+	case "K":
+		return celsius + 273.15
+	}
+	return celsius
+}
@@ -0,0 +1,135 @@
+// Package clipboard reads and writes the system clipboard using the
+// platform's clipboard utilities.
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// lookPath is replaceable in tests
+var lookPath = exec.LookPath
+
+// tool is a clipboard command line
+type tool struct {
+	name string
+	args []string
+}
+
+// copyTools returns candidate copy commands for the current platform, in preference order
+func copyTools() []tool {
+	switch runtime.GOOS {
+	case "darwin":
+		return []tool{{"pbcopy", nil}}
+	case "windows":
+		return []tool{{"clip.exe", nil}}
+	}
+
+	var tools []tool
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, tool{"wl-copy", nil})
+	}
+	return append(tools,
+		tool{"xclip", []string{"-selection", "clipboard"}},
+		tool{"xsel", []string{"--clipboard", "--input"}},
+		tool{"clip.exe", nil}, // WSL
+	)
+}
+
+// pasteTools returns candidate paste commands for the current platform, in preference order
+func pasteTools() []tool {
+	switch runtime.GOOS {
+	case "darwin":
+		return []tool{{"pbpaste", nil}}
+	case "windows":
+		return []tool{{"powershell.exe", []string{"-NoProfile", "-Command", "Get-Clipboard -Raw"}}}
+	}
+
+	var tools []tool
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, tool{"wl-paste", []string{"--no-newline"}})
+	}
+	return append(tools,
+		tool{"xclip", []string{"-selection", "clipboard", "-out"}},
+		tool{"xsel", []string{"--clipboard", "--output"}},
+		tool{"powershell.exe", []string{"-NoProfile", "-Command", "Get-Clipboard -Raw"}}, // WSL
+	)
+}
+
+// findTool returns the first available tool
+func findTool(tools []tool) (tool, bool) {
+	for _, t := range tools {
+		if _, err := lookPath(t.name); err == nil {
+			return t, true
+		}
+	}
+	return tool{}, false
+}
+
+// Copy writes text to the system clipboard. When no clipboard utility is
+// available (e.g. over SSH), it falls back to an OSC 52 terminal escape sequence,
+// which most modern terminals forward to the local clipboard.
+func Copy(text string) error {
+	t, ok := findTool(copyTools())
+	if !ok {
+		return copyOSC52(text)
+	}
+
+	cmd := exec.Command(t.name, t.args...)
+	cmd.Stdin = strings.NewReader(text)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", t.name, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// Paste reads text from the system clipboard
+func Paste() (string, error) {
+	t, ok := findTool(pasteTools())
+	if !ok {
+		return "", fmt.Errorf("no clipboard utility found (install %s)", installHint())
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(t.name, t.args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s failed: %w: %s", t.name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	// Windows tools use CRLF line endings
+	return strings.ReplaceAll(stdout.String(), "\r\n", "\n"), nil
+}
+
+// copyOSC52 copies via the terminal's OSC 52 escape sequence
+func copyOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("no clipboard utility found (install %s)", installHint())
+	}
+	defer tty.Close()
+
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if os.Getenv("TMUX") != "" {
+		// tmux requires passthrough wrapping
+		seq = "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	}
+
+	_, err = tty.WriteString(seq)
+	return err
+}
+
+func installHint() string {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		return "wl-clipboard"
+	}
+	return "xclip or xsel"
+}
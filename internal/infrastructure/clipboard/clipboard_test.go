@@ -0,0 +1,42 @@
+package clipboard
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestFindToolPrefersFirstAvailable(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("tool order is platform specific")
+	}
+
+	original := lookPath
+	defer func() { lookPath = original }()
+
+	available := map[string]bool{"xsel": true, "clip.exe": true}
+	lookPath = func(name string) (string, error) {
+		if available[name] {
+			return "/usr/bin/" + name, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	t.Setenv("WAYLAND_DISPLAY", "")
+	got, ok := findTool(copyTools())
+	if !ok || got.name != "xsel" {
+		t.Errorf("findTool(copy) = %v, %v; want xsel", got, ok)
+	}
+
+	available["wl-copy"] = true
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+	got, _ = findTool(copyTools())
+	if got.name != "wl-copy" {
+		t.Errorf("findTool(copy) under Wayland = %v; want wl-copy", got)
+	}
+
+	available = map[string]bool{}
+	if _, ok := findTool(pasteTools()); ok {
+		t.Error("findTool(paste) found a tool when none are installed")
+	}
+}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redaction records one secret replaced by an environment placeholder.
+// The original value is never kept.
+type Redaction struct {
+	Path        string // Dotted path of the value within the file
+	Placeholder string // ${VAR} that replaced it
+	Reason      string
+}
+
+var (
+	// envOnlyPattern matches values that are already a single env reference
+	envOnlyPattern = regexp.MustCompile(`^\$\{?[A-Za-z_][A-Za-z0-9_]*\}?$`)
+
+	// secretPrefixes are token formats recognizable regardless of the key name
+	secretPrefixes = []string{"sk-", "sk-ant-", "sk-or-", "AIza", "xoxb-", "xoxp-", "ghp_", "github_pat_", "AKIA", "glpat-"}
+
+	// sensitiveKeyParts flag keys whose values are credentials; keys ending
+	// in _key (account_key, signing_key, ...) are too, but not _key_id
+	sensitiveKeyParts = []string{"api_key", "apikey", "secret", "password", "passwd", "token", "access_key",
+		"private_key", "encryption_key", "connection_string", "dsn", "webhook_url", "webhook", "authorization", "auth_header"}
+
+	// sensitiveQueryParams are URL parameters that carry credentials
+	sensitiveQueryParams = map[string]bool{"key": true, "api_key": true, "apikey": true, "api-key": true,
+		"token": true, "access_token": true, "sig": true, "signature": true, "code": true, "secret": true,
+		"password": true, "client_secret": true}
+
+	nonAlnum = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// RedactYAML replaces credentials in a YAML (or JSON) config document with
+// ${VAR} placeholders. Values that are already env references are left alone.
+// Returns the original bytes when nothing was redacted.
+func RedactYAML(data []byte, file string) ([]byte, []Redaction, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return data, nil, nil
+	}
+
+	r := &redactor{}
+	root := doc.Content[0]
+	r.walk(root, "", documentName(root), false)
+
+	if len(r.redactions) == 0 {
+		return data, nil, nil
+	}
+
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		var value interface{}
+		if err := doc.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("failed to re-encode %s: %w", file, err)
+		}
+		out, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to re-encode %s: %w", file, err)
+		}
+		return append(out, '\n'), r.redactions, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to re-encode %s: %w", file, err)
+	}
+	enc.Close()
+	return buf.Bytes(), r.redactions, nil
+}
+
+type redactor struct {
+	redactions []Redaction
+}
+
+// walk visits node; owner names the enclosing provider/server for placeholder
+// names, and inEnv is true for the values of an env: mapping
+func (r *redactor) walk(node *yaml.Node, path, owner string, inEnv bool) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := joinPath(path, key.Value)
+
+			if value.Kind == yaml.ScalarNode {
+				r.scalar(value, key.Value, childPath, owner, inEnv)
+				continue
+			}
+
+			childOwner := owner
+			switch parentKey(path) {
+			case "providers", "servers", "destinations", "interfaces", "backends", "channels":
+				childOwner = key.Value
+			}
+			r.walk(value, childPath, childOwner, key.Value == "env")
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				r.scalar(item, "", fmt.Sprintf("%s[%d]", path, i), owner, false)
+				continue
+			}
+			r.walk(item, fmt.Sprintf("%s[%d]", path, i), owner, false)
+		}
+	}
+}
+
+// scalar redacts a single string value if it looks like a credential
+func (r *redactor) scalar(node *yaml.Node, key, path, owner string, inEnv bool) {
+	value := node.Value
+	if (node.Tag != "!!str" && node.Tag != "") || value == "" || envOnlyPattern.MatchString(value) {
+		return
+	}
+
+	var placeholder, reason string
+	switch {
+	case inEnv && isSensitiveKey(key):
+		placeholder, reason = envPlaceholder("", key), "sensitive environment variable"
+	case isSensitiveKey(key) && !strings.Contains(value, "${"):
+		placeholder, reason = envPlaceholder(owner, key), "credential field"
+	case hasSecretPrefix(value):
+		placeholder, reason = envPlaceholder(owner, orDefault(key, "secret")), "token format"
+	}
+
+	if placeholder != "" {
+		r.replace(node, placeholder, path, reason)
+		return
+	}
+
+	// Endpoints: strip credentials embedded in URLs, keep the rest readable
+	if redacted, ok := redactURL(value, envPlaceholder(owner, orDefault(key, "url"))); ok {
+		node.Value = redacted
+		node.Style = yaml.DoubleQuotedStyle
+		r.redactions = append(r.redactions, Redaction{Path: path, Placeholder: redacted, Reason: "credentials in URL"})
+	}
+}
+
+func (r *redactor) replace(node *yaml.Node, placeholder, path, reason string) {
+	node.Value = placeholder
+	node.Tag = "!!str"
+	node.Style = yaml.DoubleQuotedStyle
+	r.redactions = append(r.redactions, Redaction{Path: path, Placeholder: placeholder, Reason: reason})
+}
+
+// redactURL replaces userinfo passwords and credential query parameters
+func redactURL(value, placeholder string) (string, bool) {
+	if !strings.Contains(value, "://") || strings.ContainsAny(value, " \n") {
+		return "", false
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+
+	changed := false
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), "REDACTED")
+			changed = true
+		}
+	}
+
+	query := u.Query()
+	for param := range query {
+		if sensitiveQueryParams[strings.ToLower(param)] && !envOnlyPattern.MatchString(query.Get(param)) {
+			query.Set(param, "REDACTED")
+			changed = true
+		}
+	}
+	if !changed {
+		return "", false
+	}
+	u.RawQuery = query.Encode()
+
+	// url.URL escapes ${...}, so substitute the marker afterwards
+	return strings.ReplaceAll(u.String(), "REDACTED", placeholder), true
+}
+
+func isSensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+	if k == "" || strings.HasSuffix(k, "_path") || strings.HasSuffix(k, "_file") || strings.HasSuffix(k, "_env") || strings.HasPrefix(k, "max_") {
+		return false
+	}
+	if strings.HasSuffix(k, "_key") {
+		return true
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(k, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSecretPrefix(value string) bool {
+	for _, prefix := range secretPrefixes {
+		if strings.HasPrefix(value, prefix) && len(value) > len(prefix)+8 && !strings.Contains(value, " ") {
+			return true
+		}
+	}
+	return false
+}
+
+// envPlaceholder builds ${OWNER_KEY}, e.g. ${OPENAI_API_KEY}
+func envPlaceholder(owner, key string) string {
+	name := strings.ToUpper(key)
+	if owner != "" && !strings.HasPrefix(name, strings.ToUpper(owner)) {
+		name = strings.ToUpper(owner) + "_" + name
+	}
+	name = strings.Trim(nonAlnum.ReplaceAllString(name, "_"), "_")
+	return "${" + name + "}"
+}
+
+// documentName returns the provider or server a modular file defines, if any
+func documentName(root *yaml.Node) string {
+	if root.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case "provider_name", "server_name":
+			return root.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// parentKey returns the last element of a dotted path
+func parentKey(path string) string {
+	if idx := strings.LastIndex(path, "."); idx >= 0 {
+		return path[idx+1:]
+	}
+	return path
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -5,13 +5,19 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/env"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"gopkg.in/yaml.v3"
 )
 
+// reportedWarnings de-duplicates loader warnings, since the config is loaded
+// several times per command
+var reportedWarnings sync.Map
+
 // Service implements the ConfigurationService interface
 type Service struct {
 	config    *domainConfig.ApplicationConfig
@@ -102,6 +108,11 @@ func (s *Service) expandEnvVarsInConfig(config *domainConfig.ApplicationConfig)
 				providerConfig.ProjectID = expandEnvVars(providerConfig.ProjectID)
 				providerConfig.Location = expandEnvVars(providerConfig.Location)
 				providerConfig.CredentialsPath = expandEnvVars(providerConfig.CredentialsPath)
+				// External provider programs
+				providerConfig.Command = expandEnvVars(providerConfig.Command)
+				for key, value := range providerConfig.Env {
+					providerConfig.Env[key] = expandEnvVars(value)
+				}
 				interfaceConfig.Providers[providerName] = providerConfig
 			}
 			config.AI.Interfaces[interfaceType] = interfaceConfig
@@ -138,6 +149,89 @@ func (s *Service) expandEnvVarsInConfig(config *domainConfig.ApplicationConfig)
 		}
 	}
 
+	// Expand the outputs encryption key
+	if config.Skills != nil {
+		config.Skills.OutputsEncryptionKey = expandEnvVars(config.Skills.OutputsEncryptionKey)
+	}
+
+	// Expand in storage backends
+	if config.Storage != nil && config.Storage.Backends != nil {
+		for backendName, backendConfig := range config.Storage.Backends {
+			backendConfig.Bucket = expandEnvVars(backendConfig.Bucket)
+			backendConfig.Region = expandEnvVars(backendConfig.Region)
+			backendConfig.Endpoint = expandEnvVars(backendConfig.Endpoint)
+			backendConfig.AccessKeyID = expandEnvVars(backendConfig.AccessKeyID)
+			backendConfig.SecretAccessKey = expandEnvVars(backendConfig.SecretAccessKey)
+			backendConfig.SessionToken = expandEnvVars(backendConfig.SessionToken)
+			backendConfig.AccountName = expandEnvVars(backendConfig.AccountName)
+			backendConfig.AccountKey = expandEnvVars(backendConfig.AccountKey)
+			backendConfig.SASToken = expandEnvVars(backendConfig.SASToken)
+			backendConfig.CredentialsPath = expandEnvVars(backendConfig.CredentialsPath)
+			backendConfig.AccessToken = expandEnvVars(backendConfig.AccessToken)
+			config.Storage.Backends[backendName] = backendConfig
+		}
+	}
+
+	// Expand in notification channels
+	if config.Notifications != nil && config.Notifications.Channels != nil {
+		for channelName, channelConfig := range config.Notifications.Channels {
+			channelConfig.WebhookURL = expandEnvVars(channelConfig.WebhookURL)
+			channelConfig.Host = expandEnvVars(channelConfig.Host)
+			channelConfig.Username = expandEnvVars(channelConfig.Username)
+			channelConfig.Password = expandEnvVars(channelConfig.Password)
+			channelConfig.From = expandEnvVars(channelConfig.From)
+			config.Notifications.Channels[channelName] = channelConfig
+		}
+	}
+
+	// Expand in log analytics destinations
+	if config.LogAnalytics != nil && config.LogAnalytics.Destinations != nil {
+		for destName, destConfig := range config.LogAnalytics.Destinations {
+			destConfig.Endpoint = expandEnvVars(destConfig.Endpoint)
+			destConfig.DCRImmutableID = expandEnvVars(destConfig.DCRImmutableID)
+			destConfig.TenantID = expandEnvVars(destConfig.TenantID)
+			destConfig.ClientID = expandEnvVars(destConfig.ClientID)
+			destConfig.ClientSecret = expandEnvVars(destConfig.ClientSecret)
+			destConfig.AccessToken = expandEnvVars(destConfig.AccessToken)
+			config.LogAnalytics.Destinations[destName] = destConfig
+		}
+	}
+
+	// Expand in Microsoft Graph credentials
+	if config.Graph != nil {
+		config.Graph.Endpoint = expandEnvVars(config.Graph.Endpoint)
+		config.Graph.TenantID = expandEnvVars(config.Graph.TenantID)
+		config.Graph.ClientID = expandEnvVars(config.Graph.ClientID)
+		config.Graph.ClientSecret = expandEnvVars(config.Graph.ClientSecret)
+		config.Graph.AccessToken = expandEnvVars(config.Graph.AccessToken)
+	}
+
+	// Expand in TTS providers
+	if config.TTS != nil && config.TTS.Providers != nil {
+		for providerName, providerConfig := range config.TTS.Providers {
+			providerConfig.APIKey = expandEnvVars(providerConfig.APIKey)
+			providerConfig.Endpoint = expandEnvVars(providerConfig.Endpoint)
+			providerConfig.Region = expandEnvVars(providerConfig.Region)
+			config.TTS.Providers[providerName] = providerConfig
+		}
+	}
+
+	// Expand in built-in tool settings
+	if config.BuiltinTools != nil && config.BuiltinTools.Search != nil {
+		config.BuiltinTools.Search.APIKey = expandEnvVars(config.BuiltinTools.Search.APIKey)
+		config.BuiltinTools.Search.Endpoint = expandEnvVars(config.BuiltinTools.Search.Endpoint)
+	}
+	if config.BuiltinTools != nil && config.BuiltinTools.Filesystem != nil {
+		for i, dir := range config.BuiltinTools.Filesystem.AllowedDirectories {
+			config.BuiltinTools.Filesystem.AllowedDirectories[i] = expandEnvVars(dir)
+		}
+	}
+
+	// Expand in project roots
+	for i := range config.Roots {
+		config.Roots[i].Path = expandEnvVars(config.Roots[i].Path)
+	}
+
 	// Expand in servers
 	if config.Servers != nil {
 		for serverName, serverConfig := range config.Servers {
@@ -171,6 +265,13 @@ func (s *Service) LoadConfig(filePath string) (*domainConfig.ApplicationConfig,
 		return nil, err
 	}
 
+	// Report migrated workflows and deprecated values once per process
+	for _, warning := range s.loader.Warnings() {
+		if _, seen := reportedWarnings.LoadOrStore(warning, true); !seen {
+			logging.Warn("%s", warning)
+		}
+	}
+
 	// Expand environment variables in config
 	s.expandEnvVarsInConfig(config)
 
@@ -365,6 +466,14 @@ func (s *Service) GetDefaultEmbeddingProvider() (string, *domainConfig.Embedding
 	return defaultProviderName, providerConfig, interfaceType, nil
 }
 
+// GetEmbeddingFallbackChain returns the embedding providers to fail over to
+func (s *Service) GetEmbeddingFallbackChain() []domainConfig.ProviderFallback {
+	if s.config == nil || s.config.Embeddings == nil {
+		return nil
+	}
+	return s.config.Embeddings.FallbackChain
+}
+
 // ListServers returns a list of configured server names
 func (s *Service) ListServers() []string {
 	if s.config == nil || s.config.Servers == nil {
@@ -437,6 +546,19 @@ func (s *Service) ValidateConfig(config *domainConfig.ApplicationConfig) error {
 		return domain.NewDomainError(domain.ErrCodeProviderNotFound, fmt.Sprintf("default provider '%s' not found in configuration", config.AI.DefaultProvider))
 	}
 
+	for _, interfaceConfig := range config.AI.Interfaces {
+		for name, providerConfig := range interfaceConfig.Providers {
+			if err := providerConfig.GenerationParams.Validate(); err != nil {
+				return domain.NewDomainError(domain.ErrCodeConfigInvalid, fmt.Sprintf("provider '%s': %v", name, err))
+			}
+		}
+	}
+	for name, providerConfig := range config.AI.Providers {
+		if err := providerConfig.GenerationParams.Validate(); err != nil {
+			return domain.NewDomainError(domain.ErrCodeConfigInvalid, fmt.Sprintf("provider '%s': %v", name, err))
+		}
+	}
+
 	if err := config.ValidateWorkflows(); err != nil {
 		return fmt.Errorf("workflow template validation failed: %w", err)
 	}
@@ -0,0 +1,321 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Supported database types for the sql workflow step
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+// DefaultMaxRows is the row limit applied when a query does not set one
+const DefaultMaxRows = 1000
+
+// driverAliases maps user-facing driver names to database types
+var driverAliases = map[string]string{
+	"postgres":   DriverPostgres,
+	"postgresql": DriverPostgres,
+	"pgx":        DriverPostgres,
+	"mysql":      DriverMySQL,
+	"mariadb":    DriverMySQL,
+	"sqlite":     DriverSQLite,
+	"sqlite3":    DriverSQLite,
+}
+
+// registeredDrivers maps database types to the database/sql driver name
+// that implements them. Drivers are registered by build-tagged files
+// (driver_postgres.go, driver_mysql.go, driver_sqlite.go) so that the
+// default binary does not carry every database client.
+var registeredDrivers = map[string]string{}
+
+// RegisterDriver registers a database/sql driver name for a database type
+func RegisterDriver(dbType, sqlDriverName string) {
+	registeredDrivers[dbType] = sqlDriverName
+}
+
+// ResolveDriver normalizes a user-facing driver name to a database type
+func ResolveDriver(name string) (string, error) {
+	dbType, ok := driverAliases[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", fmt.Errorf("unsupported database driver '%s' (supported: postgres, mysql, sqlite)", name)
+	}
+	return dbType, nil
+}
+
+// QueryOptions controls how a query is executed
+type QueryOptions struct {
+	ReadOnly bool          // Reject non-read statements and have the database refuse writes
+	MaxRows  int           // Maximum rows returned (0 = DefaultMaxRows)
+	Timeout  time.Duration // Query timeout (0 = no timeout beyond ctx)
+}
+
+// QueryResult holds the rows returned by a query
+type QueryResult struct {
+	Columns      []string                 `json:"columns"`
+	Rows         []map[string]interface{} `json:"rows"`
+	RowCount     int                      `json:"row_count"`
+	Truncated    bool                     `json:"truncated"`
+	RowsAffected int64                    `json:"rows_affected,omitempty"`
+}
+
+// Client executes queries against a single database
+type Client struct {
+	dbType string
+	db     *sql.DB
+}
+
+// Open opens a database connection using a registered driver
+func Open(driver, dsn string) (*Client, error) {
+	dbType, err := ResolveDriver(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDriverName, ok := registeredDrivers[dbType]
+	if !ok {
+		return nil, fmt.Errorf("database driver '%s' is not compiled into this binary (rebuild with: go build -tags %s)", dbType, dbType)
+	}
+
+	if dsn == "" {
+		return nil, fmt.Errorf("database connection string (dsn) is required")
+	}
+
+	db, err := sql.Open(sqlDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", dbType, err)
+	}
+
+	logging.Debug("Opened %s database connection (driver: %s)", dbType, sqlDriverName)
+
+	return &Client{dbType: dbType, db: db}, nil
+}
+
+// Close closes the underlying connection pool
+func (c *Client) Close() error {
+	return c.db.Close()
+}
+
+// Query executes a statement with positional parameters and returns rows as maps
+func (c *Client) Query(ctx context.Context, query string, params []interface{}, opts QueryOptions) (*QueryResult, error) {
+	if opts.MaxRows <= 0 {
+		opts.MaxRows = DefaultMaxRows
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	if opts.ReadOnly {
+		if err := CheckReadOnly(query); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	// The SQLite driver can't begin read-only transactions, so read-only
+	// mode is enforced there by switching the connection to query_only
+	sqliteReadOnly := opts.ReadOnly && c.dbType == DriverSQLite
+	if sqliteReadOnly {
+		if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+			return nil, fmt.Errorf("failed to enable read-only mode: %w", err)
+		}
+		defer resetQueryOnly(conn)
+	}
+
+	// Always run inside a transaction so read-only mode can be enforced by
+	// the database as well as by statement inspection
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: opts.ReadOnly && !sqliteReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if !opts.ReadOnly && !returnsRows(query) {
+		res, err := tx.ExecContext(ctx, query, params...)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("statement failed: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit: %w", err)
+		}
+		affected, _ := res.RowsAffected()
+		return &QueryResult{Columns: []string{}, Rows: []map[string]interface{}{}, RowsAffected: affected}, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, query, params...)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+
+	result, err := scanRows(rows, opts.MaxRows)
+	rows.Close()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if opts.ReadOnly {
+		tx.Rollback()
+	} else if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// resetQueryOnly turns query_only off before a SQLite connection returns to
+// the pool, discarding the connection if that fails
+func resetQueryOnly(conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "PRAGMA query_only = OFF"); err != nil {
+		logging.Warn("Discarding SQLite connection left in read-only mode: %v", err)
+		conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+}
+
+// scanRows converts result rows to JSON-friendly maps, stopping at maxRows
+func scanRows(rows *sql.Rows, maxRows int) (*QueryResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	result := &QueryResult{
+		Columns: columns,
+		Rows:    []map[string]interface{}{},
+	}
+
+	for rows.Next() {
+		if len(result.Rows) >= maxRows {
+			result.Truncated = true
+			break
+		}
+
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			row[col] = normalizeValue(values[i])
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+
+	result.RowCount = len(result.Rows)
+	return result, nil
+}
+
+// normalizeValue converts driver values into types that marshal cleanly to JSON
+func normalizeValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	default:
+		return val
+	}
+}
+
+var (
+	// leadingCommentRegex strips leading SQL comments before keyword inspection
+	leadingCommentRegex = regexp.MustCompile(`^(?:\s*(?:--[^\n]*\n|/\*.*?\*/))*\s*`)
+
+	// readOnlyKeywords are statement prefixes permitted in read-only mode
+	readOnlyKeywords = []string{"SELECT", "WITH", "SHOW", "EXPLAIN", "DESCRIBE", "DESC", "VALUES", "TABLE"}
+
+	// writeKeywordRegex catches data-modifying statements hidden inside CTEs
+	writeKeywordRegex = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|MERGE|DROP|ALTER|CREATE|TRUNCATE|GRANT|REVOKE|ATTACH|DETACH|VACUUM|COPY|CALL|EXEC|EXECUTE)\b`)
+)
+
+// CheckReadOnly returns an error if the query is not a single read-only statement
+func CheckReadOnly(query string) error {
+	stmt := strings.TrimSpace(leadingCommentRegex.ReplaceAllString(query, ""))
+	stmt = strings.TrimSuffix(stmt, ";")
+
+	if stmt == "" {
+		return fmt.Errorf("query is empty")
+	}
+
+	if strings.Contains(stripQuoted(stmt), ";") {
+		return fmt.Errorf("multiple statements are not allowed in read-only mode")
+	}
+
+	upper := strings.ToUpper(stmt)
+	allowed := false
+	for _, keyword := range readOnlyKeywords {
+		if strings.HasPrefix(upper, keyword+" ") || strings.HasPrefix(upper, keyword+"\n") || strings.HasPrefix(upper, keyword+"\t") || upper == keyword {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("only read statements (SELECT, WITH, SHOW, EXPLAIN) are allowed in read-only mode")
+	}
+
+	if m := writeKeywordRegex.FindString(stripQuoted(stmt)); m != "" {
+		return fmt.Errorf("statement contains '%s' which is not allowed in read-only mode", strings.ToUpper(m))
+	}
+
+	return nil
+}
+
+// stripQuoted removes string literals and quoted identifiers so keyword checks
+// are not fooled by values such as 'DELETE ME'
+func stripQuoted(stmt string) string {
+	var sb strings.Builder
+	var quote rune
+	for _, r := range stmt {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// returnsRows reports whether a statement is expected to produce a result set
+func returnsRows(query string) bool {
+	stmt := strings.ToUpper(strings.TrimSpace(leadingCommentRegex.ReplaceAllString(query, "")))
+	for _, keyword := range append(readOnlyKeywords, "PRAGMA") {
+		if strings.HasPrefix(stmt, keyword) {
+			return true
+		}
+	}
+	return strings.Contains(stmt, " RETURNING ")
+}
@@ -0,0 +1,69 @@
+package database
+
+import "testing"
+
+func TestCheckReadOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{"simple select", "SELECT * FROM users", false},
+		{"select with trailing semicolon", "SELECT id FROM users;", false},
+		{"cte select", "WITH recent AS (SELECT * FROM logins) SELECT * FROM recent", false},
+		{"leading comment", "-- report\nSELECT 1", false},
+		{"keyword inside literal", "SELECT * FROM notes WHERE body = 'DELETE ME'", false},
+		{"explain", "EXPLAIN SELECT 1", false},
+		{"insert", "INSERT INTO users (id) VALUES (1)", true},
+		{"update", "update users set name = 'x'", true},
+		{"multiple statements", "SELECT 1; DROP TABLE users", true},
+		{"writable cte", "WITH d AS (DELETE FROM users RETURNING *) SELECT * FROM d", true},
+		{"empty", "  ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckReadOnly(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckReadOnly(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveDriver(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"postgres", DriverPostgres, false},
+		{"PostgreSQL", DriverPostgres, false},
+		{"mariadb", DriverMySQL, false},
+		{"sqlite3", DriverSQLite, false},
+		{"oracle", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ResolveDriver(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveDriver(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveDriver(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenUnregisteredDriver(t *testing.T) {
+	if name, ok := registeredDrivers[DriverMySQL]; ok {
+		defer RegisterDriver(DriverMySQL, name)
+	}
+	delete(registeredDrivers, DriverMySQL)
+
+	if _, err := Open("mysql", "user:pass@/db"); err == nil {
+		t.Error("expected error for driver not compiled into binary")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build mysql
+
+package database
+
+import (
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func init() {
+	RegisterDriver(DriverMySQL, "mysql")
+}
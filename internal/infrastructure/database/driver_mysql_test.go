@@ -0,0 +1,36 @@
+//go:build mysql
+
+package database
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+// Set TEST_MYSQL_DSN to run the query against a live server
+func TestMySQLDriver(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		client, err := Open("mariadb", "user:pass@tcp(localhost:3306)/db")
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		client.Close()
+		t.Skip("TEST_MYSQL_DSN not set")
+	}
+
+	client, err := Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Query(context.Background(), "SELECT ? AS n", []interface{}{42}, QueryOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if result.RowCount != 1 || result.Columns[0] != "n" {
+		t.Errorf("select = %+v", result)
+	}
+}
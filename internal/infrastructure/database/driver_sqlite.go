@@ -0,0 +1,11 @@
+//go:build sqlite
+
+package database
+
+import (
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	RegisterDriver(DriverSQLite, "sqlite")
+}
@@ -0,0 +1,85 @@
+//go:build sqlite
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSQLiteDriver(t *testing.T) {
+	client, err := Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE hosts (name TEXT, cpu REAL)",
+		"INSERT INTO hosts VALUES ('web01', 71.5), ('web02', 12)",
+	} {
+		if _, err := client.Query(ctx, stmt, nil, QueryOptions{}); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	result, err := client.Query(ctx, "SELECT name, cpu FROM hosts WHERE cpu > ? ORDER BY name", []interface{}{50}, QueryOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if result.RowCount != 1 || result.Rows[0]["name"] != "web01" || result.Rows[0]["cpu"] != 71.5 {
+		t.Errorf("select = %+v", result)
+	}
+
+	if _, err := client.Query(ctx, "DELETE FROM hosts", nil, QueryOptions{ReadOnly: true}); err == nil {
+		t.Error("read-only query should reject DELETE")
+	}
+
+	result, err = client.Query(ctx, "SELECT name FROM hosts", nil, QueryOptions{MaxRows: 1})
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if !result.Truncated || result.RowCount != 1 {
+		t.Errorf("MaxRows: truncated=%v rows=%d", result.Truncated, result.RowCount)
+	}
+}
+
+func TestSQLiteReadOnlyRejectsCTEWrites(t *testing.T) {
+	client, err := Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer client.Close()
+	client.db.SetMaxOpenConns(1) // every query reuses the connection the read-only query ran on
+
+	ctx := context.Background()
+	if _, err := client.Query(ctx, "CREATE TABLE hosts (name TEXT PRIMARY KEY, cpu REAL)", nil, QueryOptions{}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// REPLACE gets past statement inspection, so only the database can stop it
+	write := "WITH src AS (SELECT 'web03' AS name, 99 AS cpu) REPLACE INTO hosts SELECT name, cpu FROM src"
+	if err := CheckReadOnly(write); err != nil {
+		t.Fatalf("CheckReadOnly should miss this write: %v", err)
+	}
+	_, err = client.Query(ctx, write, nil, QueryOptions{ReadOnly: true})
+	if err == nil || !strings.Contains(err.Error(), "readonly") {
+		t.Errorf("read-only CTE write error = %v, want the database to refuse it", err)
+	}
+
+	result, err := client.Query(ctx, "SELECT COUNT(*) AS n FROM hosts", nil, QueryOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if n := result.Rows[0]["n"]; n != int64(0) {
+		t.Errorf("hosts has %v rows after the rejected write, want 0", n)
+	}
+
+	// The connection is writable again outside read-only mode
+	if _, err := client.Query(ctx, write, nil, QueryOptions{}); err != nil {
+		t.Errorf("write after read-only query: %v", err)
+	}
+}
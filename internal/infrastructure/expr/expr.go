@@ -0,0 +1,366 @@
+// Package expr evaluates small deterministic expressions over workflow
+// variables, for conditions such as
+//
+//	output.score >= 8 && !contains(output.status, "error")
+//
+// Identifiers are dotted variable names (loop.iteration, steps.review).
+// When a name is not defined as a whole, the longest defined prefix is
+// decoded as JSON and the rest of the path is looked up inside it, so
+// output.score reads the score field of a JSON output. Fenced ```json
+// blocks are unwrapped first.
+//
+// Operators: || && ! == != < <= > >= (also "and", "or", "not") and
+// parentheses. Literals: "strings", 'strings', numbers, true, false, null.
+// Functions: contains, startsWith, endsWith, matches, len, lower, upper,
+// trim, number, json.
+package expr
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Lookup resolves a variable name to its (string) value
+type Lookup func(name string) (string, bool)
+
+// Expr is a parsed expression
+type Expr struct {
+	src  string
+	root node
+}
+
+// Parse parses an expression. A ${{ ... }} wrapper is accepted and ignored.
+func Parse(src string) (*Expr, error) {
+	text := strings.TrimSpace(src)
+	if strings.HasPrefix(text, "${{") && strings.HasSuffix(text, "}}") {
+		text = strings.TrimSpace(text[3 : len(text)-2])
+	}
+	if text == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos+1)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// String returns the source text
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression. Results are nil, bool, float64, string,
+// []interface{} or map[string]interface{}.
+func (e *Expr) Eval(lookup Lookup) (interface{}, error) {
+	return e.root.eval(lookup)
+}
+
+// EvalBool evaluates the expression and reports whether the result is truthy
+func (e *Expr) EvalBool(lookup Lookup) (bool, error) {
+	value, err := e.root.eval(lookup)
+	if err != nil {
+		return false, err
+	}
+	return Truthy(value), nil
+}
+
+// EvalBool parses and evaluates src in one go
+func EvalBool(src string, lookup Lookup) (bool, error) {
+	e, err := Parse(src)
+	if err != nil {
+		return false, err
+	}
+	return e.EvalBool(lookup)
+}
+
+// Truthy reports whether a value counts as true. Empty strings, "false",
+// "0", zero, null and empty lists or objects are false.
+func Truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		s := strings.TrimSpace(v)
+		return s != "" && !strings.EqualFold(s, "false") && s != "0"
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// node is an expression tree node
+type node interface {
+	eval(lookup Lookup) (interface{}, error)
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (n *literal) eval(Lookup) (interface{}, error) {
+	return n.value, nil
+}
+
+// variable is a dotted path such as output.items.0.name
+type variable struct {
+	path []string
+}
+
+func (n *variable) eval(lookup Lookup) (interface{}, error) {
+	// Prefer the longest defined name, then walk into its JSON
+	for i := len(n.path); i > 0; i-- {
+		name := strings.Join(n.path[:i], ".")
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if i == len(n.path) {
+			return raw, nil
+		}
+		var value interface{} = raw
+		for _, key := range n.path[i:] {
+			value = member(value, key)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("undefined variable '%s'", strings.Join(n.path, "."))
+}
+
+// member returns value[key], decoding JSON text on the way. Missing members
+// are nil.
+func member(value interface{}, key string) interface{} {
+	if s, ok := value.(string); ok {
+		decoded, ok := decodeJSON(s)
+		if !ok {
+			return nil
+		}
+		value = decoded
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v[key]
+	case []interface{}:
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil
+		}
+		return v[idx]
+	}
+	return nil
+}
+
+var fencedJSON = regexp.MustCompile("(?s)```(?:json)?\\s*\\n(.*?)```")
+
+// decodeJSON parses text as JSON, unwrapping a fenced code block
+func decodeJSON(text string) (interface{}, bool) {
+	text = strings.TrimSpace(text)
+	if m := fencedJSON.FindStringSubmatch(text); m != nil {
+		text = strings.TrimSpace(m[1])
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+type unary struct {
+	op      string
+	operand node
+}
+
+func (n *unary) eval(lookup Lookup) (interface{}, error) {
+	value, err := n.operand.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "-" {
+		f, ok := toNumber(value)
+		if !ok {
+			return nil, fmt.Errorf("cannot negate %s", describe(value))
+		}
+		return -f, nil
+	}
+	return !Truthy(value), nil
+}
+
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (n *binary) eval(lookup Lookup) (interface{}, error) {
+	left, err := n.left.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short-circuit logic operators
+	switch n.op {
+	case "&&":
+		if !Truthy(left) {
+			return false, nil
+		}
+		right, err := n.right.eval(lookup)
+		if err != nil {
+			return nil, err
+		}
+		return Truthy(right), nil
+	case "||":
+		if Truthy(left) {
+			return true, nil
+		}
+		right, err := n.right.eval(lookup)
+		if err != nil {
+			return nil, err
+		}
+		return Truthy(right), nil
+	}
+
+	right, err := n.right.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	}
+
+	cmp, err := compare(left, right)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+// equal compares numerically when both sides are numbers (or numeric
+// strings), as booleans when either side is a boolean, and as text otherwise
+func equal(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil || toText(left) == "" && toText(right) == ""
+	}
+	if l, ok := toNumber(left); ok {
+		if r, ok := toNumber(right); ok {
+			return l == r
+		}
+	}
+	_, lb := left.(bool)
+	_, rb := right.(bool)
+	if lb || rb {
+		return Truthy(left) == Truthy(right)
+	}
+	return toText(left) == toText(right)
+}
+
+// compare orders numbers numerically and everything else as text
+func compare(left, right interface{}) (int, error) {
+	if l, ok := toNumber(left); ok {
+		if r, ok := toNumber(right); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if !lok || !rok {
+		return 0, fmt.Errorf("cannot compare %s with %s", describe(left), describe(right))
+	}
+	return strings.Compare(ls, rs), nil
+}
+
+// toNumber converts numbers and numeric strings
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || math.IsNaN(f) {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+// toText renders a value the way it would appear in a prompt
+func toText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// describe names a value's type for error messages
+func describe(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return fmt.Sprintf("%q", truncate(value.(string), 40))
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
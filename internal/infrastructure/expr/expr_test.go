@@ -0,0 +1,89 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalBool(t *testing.T) {
+	vars := map[string]string{
+		"output":         "```json\n{\"score\": 8.5, \"status\": \"PASS\", \"issues\": [], \"tags\": [\"go\", \"cli\"]}\n```",
+		"loop.iteration": "3",
+		"loop.verified":  "true",
+		"review":         "Score: 7/10. Needs work.",
+		"step.review":    "Score: 7/10. Needs work.",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`output.score >= 8`, true},
+		{`output.status == "PASS" && len(output.issues) == 0`, true},
+		{`contains(output.tags, "cli")`, true},
+		{`output.tags[1] == 'cli' and not contains(output.tags, "rust")`, true},
+		{`output.missing == null`, true},
+		{`loop.iteration >= 3`, true},
+		{`loop.iteration > 3 || loop.verified`, true},
+		{`loop.verified == true`, true},
+		{`number(review) >= 8`, false},
+		{`contains(lower(review), "needs work")`, true},
+		{`matches(review, "^Score: [0-9]+/10")`, true},
+		{`${{ startsWith(review, "Score") }}`, true},
+		{`!(output.score < 9)`, false},
+		{`-output.score < 0`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := EvalBool(tt.expr, lookup)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		if name == "output" {
+			return "done", true
+		}
+		return "", false
+	}
+
+	_, err := EvalBool(`unknown == "x"`, lookup)
+	assert.EqualError(t, err, "undefined variable 'unknown'")
+
+	_, err = EvalBool(`output.items[0] < true`, lookup)
+	assert.EqualError(t, err, "cannot compare null with boolean")
+
+	for src, msg := range map[string]string{
+		`The output says PASS`:   "unexpected 'output' at position 5",
+		`output = "done"`:        "unexpected '=' at position 8 (use == to compare)",
+		`contains(output)`:       "contains() takes 2 argument(s), got 1",
+		`shout(output)`:          "unknown function 'shout' at position 1",
+		`(output == "done"`:      "expected ')' but found end of expression at position 18",
+		`output == "done`:        "unterminated string at position 11",
+		``:                       "empty expression",
+		`output == "done" &&`:    "unexpected end of expression at position 20",
+		`output.items[name]`:     "expected an index or quoted key at position 14",
+		`output.score >= 8 >= 1`: "unexpected '>=' at position 19",
+	} {
+		_, err := Parse(src)
+		assert.EqualError(t, err, msg, src)
+	}
+}
+
+func TestTruthy(t *testing.T) {
+	for _, v := range []interface{}{nil, false, 0.0, "", " ", "false", "FALSE", "0", []interface{}{}, map[string]interface{}{}} {
+		assert.False(t, Truthy(v), "%#v", v)
+	}
+	for _, v := range []interface{}{true, 1.0, "yes", "PASS", []interface{}{1.0}} {
+		assert.True(t, Truthy(v), "%#v", v)
+	}
+}
@@ -0,0 +1,108 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// function is a built-in function with a fixed number of arguments
+type function struct {
+	arity int
+	call  func(args []interface{}) (interface{}, error)
+}
+
+var functions = map[string]function{
+	// contains(text, sub) or contains(list, value)
+	"contains": {2, func(args []interface{}) (interface{}, error) {
+		if list, ok := args[0].([]interface{}); ok {
+			for _, item := range list {
+				if equal(item, args[1]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return strings.Contains(toText(args[0]), toText(args[1])), nil
+	}},
+	"startsWith": {2, func(args []interface{}) (interface{}, error) {
+		return strings.HasPrefix(toText(args[0]), toText(args[1])), nil
+	}},
+	"endsWith": {2, func(args []interface{}) (interface{}, error) {
+		return strings.HasSuffix(toText(args[0]), toText(args[1])), nil
+	}},
+	// matches(text, regexp) uses Go regexp syntax
+	"matches": {2, func(args []interface{}) (interface{}, error) {
+		re, err := regexp.Compile(toText(args[1]))
+		if err != nil {
+			return nil, fmt.Errorf("matches(): %w", err)
+		}
+		return re.MatchString(toText(args[0])), nil
+	}},
+	// len counts list items, object keys or characters
+	"len": {1, func(args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case []interface{}:
+			return float64(len(v)), nil
+		case map[string]interface{}:
+			return float64(len(v)), nil
+		}
+		return float64(utf8.RuneCountInString(toText(args[0]))), nil
+	}},
+	"lower": {1, func(args []interface{}) (interface{}, error) {
+		return strings.ToLower(toText(args[0])), nil
+	}},
+	"upper": {1, func(args []interface{}) (interface{}, error) {
+		return strings.ToUpper(toText(args[0])), nil
+	}},
+	"trim": {1, func(args []interface{}) (interface{}, error) {
+		return strings.TrimSpace(toText(args[0])), nil
+	}},
+	// number parses a numeric string, or the first number in the text
+	// ("Score: 8/10" is 8)
+	"number": {1, func(args []interface{}) (interface{}, error) {
+		if f, ok := toNumber(args[0]); ok {
+			return f, nil
+		}
+		if m := firstNumber.FindString(toText(args[0])); m != "" {
+			if f, ok := toNumber(m); ok {
+				return f, nil
+			}
+		}
+		return nil, nil
+	}},
+	// json decodes JSON text so lists and objects can be inspected
+	"json": {1, func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return args[0], nil
+		}
+		value, ok := decodeJSON(s)
+		if !ok {
+			return nil, fmt.Errorf("json(): value is not valid JSON: %s", describe(s))
+		}
+		return value, nil
+	}},
+}
+
+var firstNumber = regexp.MustCompile(`-?\d+(?:\.\d+)?`)
+
+// call invokes a built-in function
+type call struct {
+	name string
+	fn   function
+	args []node
+}
+
+func (n *call) eval(lookup Lookup) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(lookup)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	return n.fn.call(args)
+}
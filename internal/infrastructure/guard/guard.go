@@ -0,0 +1,211 @@
+// Package guard checks text against output policies: regex denylists, length
+// limits, JSON schemas, PII and profanity detection and moderation APIs.
+package guard
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jsonschema"
+)
+
+// Check names reported in violations
+const (
+	CheckDeny       = "deny"
+	CheckMaxLength  = "max_length"
+	CheckJSONSchema = "json_schema"
+	CheckPII        = "pii"
+	CheckProfanity  = "profanity"
+	CheckModeration = "moderation"
+)
+
+// Violation is one failed check
+type Violation struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+	Match   string `json:"match,omitempty"` // Offending text, when the check matched a span
+
+	span []int // Byte offsets of Match, for redaction
+}
+
+// Redactable reports whether the violation can be fixed by masking text
+func (v Violation) Redactable() bool {
+	return v.span != nil || v.Check == CheckMaxLength
+}
+
+// Moderator classifies text; it returns the flagged categories
+type Moderator func(ctx context.Context, text string) ([]string, error)
+
+// Checker runs a set of compiled checks
+type Checker struct {
+	checks    config.GuardChecks
+	deny      []*regexp.Regexp
+	moderator Moderator
+}
+
+// NewChecker compiles the checks. moderator may be nil when no moderation
+// check is configured.
+func NewChecker(checks config.GuardChecks, moderator Moderator) (*Checker, error) {
+	c := &Checker{checks: checks, moderator: moderator}
+	for _, pattern := range checks.Deny {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deny pattern %q: %w", pattern, err)
+		}
+		c.deny = append(c.deny, re)
+	}
+	if checks.Moderation != nil && moderator == nil {
+		return nil, fmt.Errorf("moderation check configured without a moderator")
+	}
+	return c, nil
+}
+
+// Check returns every violation found in text
+func (c *Checker) Check(ctx context.Context, text string) ([]Violation, error) {
+	var violations []Violation
+
+	for _, re := range c.deny {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			violations = append(violations, Violation{
+				Check:   CheckDeny,
+				Message: fmt.Sprintf("matches denied pattern %q", re.String()),
+				Match:   text[loc[0]:loc[1]],
+				span:    loc,
+			})
+		}
+	}
+
+	if c.checks.MaxLength > 0 {
+		if length := utf8.RuneCountInString(text); length > c.checks.MaxLength {
+			violations = append(violations, Violation{
+				Check:   CheckMaxLength,
+				Message: fmt.Sprintf("%d characters exceeds max_length %d", length, c.checks.MaxLength),
+			})
+		}
+	}
+
+	if c.checks.JSONSchema != nil {
+		problems, err := jsonschema.ValidateJSON(c.checks.JSONSchema, extractJSON(text))
+		if err != nil {
+			problems = []string{err.Error()}
+		}
+		for _, problem := range problems {
+			violations = append(violations, Violation{Check: CheckJSONSchema, Message: problem})
+		}
+	}
+
+	if c.checks.PII {
+		violations = append(violations, findPII(text)...)
+	}
+
+	if c.checks.Profanity {
+		for _, loc := range profanityPattern.FindAllStringIndex(text, -1) {
+			violations = append(violations, Violation{
+				Check:   CheckProfanity,
+				Message: "contains profanity",
+				Match:   text[loc[0]:loc[1]],
+				span:    loc,
+			})
+		}
+	}
+
+	if c.checks.Moderation != nil {
+		categories, err := c.moderator(ctx, text)
+		if err != nil {
+			return nil, fmt.Errorf("moderation check failed: %w", err)
+		}
+		for _, category := range filterCategories(categories, c.checks.Moderation.Categories) {
+			violations = append(violations, Violation{
+				Check:   CheckModeration,
+				Message: fmt.Sprintf("flagged for %s", category),
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// Redact masks every matched span and truncates to max_length. Violations
+// that can't be masked (schema, moderation) are returned as remaining.
+func (c *Checker) Redact(text string, violations []Violation) (string, []Violation) {
+	var spans [][]int
+	var remaining []Violation
+	for _, v := range violations {
+		switch {
+		case v.span != nil:
+			spans = append(spans, v.span)
+		case v.Check != CheckMaxLength:
+			remaining = append(remaining, v)
+		}
+	}
+
+	// Replace from the end so earlier offsets stay valid; overlapping
+	// spans are merged
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	var merged [][]int
+	for _, span := range spans {
+		if n := len(merged); n > 0 && span[0] <= merged[n-1][1] {
+			if span[1] > merged[n-1][1] {
+				merged[n-1][1] = span[1]
+			}
+			continue
+		}
+		merged = append(merged, []int{span[0], span[1]})
+	}
+	for i := len(merged) - 1; i >= 0; i-- {
+		text = text[:merged[i][0]] + RedactionMarker + text[merged[i][1]:]
+	}
+
+	if c.checks.MaxLength > 0 && utf8.RuneCountInString(text) > c.checks.MaxLength {
+		text = string([]rune(text)[:c.checks.MaxLength])
+	}
+	return text, remaining
+}
+
+// RedactionMarker replaces redacted text
+const RedactionMarker = "[REDACTED]"
+
+// Summary formats violations as a bulleted list
+func Summary(violations []Violation) string {
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		line := fmt.Sprintf("- %s: %s", v.Check, v.Message)
+		if v.Match != "" && v.Check != CheckPII {
+			line += fmt.Sprintf(" (%q)", v.Match)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// extractJSON strips a markdown code fence around a JSON answer
+func extractJSON(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "```") {
+		trimmed = strings.TrimPrefix(trimmed, "```json")
+		trimmed = strings.TrimPrefix(trimmed, "```")
+		trimmed = strings.TrimSuffix(strings.TrimSpace(trimmed), "```")
+	}
+	return strings.TrimSpace(trimmed)
+}
+
+func filterCategories(flagged, wanted []string) []string {
+	if len(wanted) == 0 {
+		return flagged
+	}
+	var matched []string
+	for _, category := range flagged {
+		for _, w := range wanted {
+			if strings.EqualFold(category, w) {
+				matched = append(matched, category)
+				break
+			}
+		}
+	}
+	return matched
+}
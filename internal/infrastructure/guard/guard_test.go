@@ -0,0 +1,91 @@
+package guard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAndRedact(t *testing.T) {
+	checker, err := NewChecker(config.GuardChecks{
+		Deny:      []string{`(?i)internal use only`},
+		PII:       true,
+		Profanity: true,
+		MaxLength: 200,
+	}, nil)
+	assert.NoError(t, err)
+
+	text := "INTERNAL USE ONLY: mail jane.doe@example.com or call +1 415-555-0132, card 4111 1111 1111 1111. What the hell, this is shit."
+	violations, err := checker.Check(context.Background(), text)
+	assert.NoError(t, err)
+
+	var checks []string
+	for _, v := range violations {
+		checks = append(checks, v.Check+": "+v.Message)
+	}
+	assert.Equal(t, []string{
+		"deny: matches denied pattern \"(?i)internal use only\"",
+		"pii: contains email address",
+		"pii: contains card number",
+		"pii: contains phone number",
+		"profanity: contains profanity",
+	}, checks)
+
+	redacted, remaining := checker.Redact(text, violations)
+	assert.Empty(t, remaining)
+	assert.Equal(t, "[REDACTED]: mail [REDACTED] or call [REDACTED], card [REDACTED]. What the hell, this is [REDACTED].", redacted)
+}
+
+func TestCheckPIIIgnoresNonCardDigits(t *testing.T) {
+	violations := findPII("Order 1234567812345678 shipped from 10.0.0.12")
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "contains IP address", violations[0].Message)
+}
+
+func TestCheckJSONSchemaAndLength(t *testing.T) {
+	checker, err := NewChecker(config.GuardChecks{
+		MaxLength: 20,
+		JSONSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"title"},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	violations, err := checker.Check(context.Background(), "```json\n{\"summary\": \"too long for the limit\"}\n```")
+	assert.NoError(t, err)
+	assert.Len(t, violations, 2)
+	assert.Equal(t, CheckMaxLength, violations[0].Check)
+	assert.Equal(t, "$: missing required property 'title'", violations[1].Message)
+
+	// Schema violations can't be masked
+	_, remaining := checker.Redact("x", violations)
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, CheckJSONSchema, remaining[0].Check)
+}
+
+func TestCheckModeration(t *testing.T) {
+	moderator := func(ctx context.Context, text string) ([]string, error) {
+		return []string{"harassment", "violence"}, nil
+	}
+	checker, err := NewChecker(config.GuardChecks{
+		Moderation: &config.GuardModeration{Provider: "openai", Categories: []string{"Violence"}},
+	}, moderator)
+	assert.NoError(t, err)
+
+	violations, err := checker.Check(context.Background(), "text")
+	assert.NoError(t, err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "flagged for violence", violations[0].Message)
+
+	_, err = NewChecker(config.GuardChecks{Moderation: &config.GuardModeration{Provider: "openai"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestParseModeration(t *testing.T) {
+	categories, err := parseModeration([]byte(`{"results": [{"flagged": true, "categories": {"hate": false, "self-harm": true, "violence": true}}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"self-harm", "violence"}, categories)
+}
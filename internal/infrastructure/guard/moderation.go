@@ -0,0 +1,95 @@
+package guard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+const (
+	defaultModerationEndpoint = "https://api.openai.com/v1"
+	defaultModerationModel    = "omni-moderation-latest"
+)
+
+// NewOpenAIModerator returns a Moderator that calls an OpenAI-compatible
+// /moderations endpoint with the provider's API key and endpoint
+func NewOpenAIModerator(provider *config.ProviderConfig, model string) Moderator {
+	endpoint := strings.TrimRight(provider.APIEndpoint, "/")
+	if endpoint == "" {
+		endpoint = defaultModerationEndpoint
+	}
+	if model == "" {
+		model = defaultModerationModel
+	}
+	timeout := 30 * time.Second
+	if provider.TimeoutSeconds > 0 {
+		timeout = time.Duration(provider.TimeoutSeconds) * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+
+	return func(ctx context.Context, text string) ([]string, error) {
+		body, err := json.Marshal(map[string]interface{}{"model": model, "input": text})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/moderations", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+provider.APIKey)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("moderation API returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+		}
+		return parseModeration(data)
+	}
+}
+
+// parseModeration returns the flagged categories from a moderation response
+func parseModeration(data []byte) ([]string, error) {
+	var response struct {
+		Results []struct {
+			Flagged    bool            `json:"flagged"`
+			Categories map[string]bool `json:"categories"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("invalid moderation response: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var categories []string
+	for _, result := range response.Results {
+		for category, flagged := range result.Categories {
+			if flagged && !seen[category] {
+				seen[category] = true
+				categories = append(categories, category)
+			}
+		}
+		if result.Flagged && len(result.Categories) == 0 && !seen["flagged"] {
+			seen["flagged"] = true
+			categories = append(categories, "flagged")
+		}
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
@@ -0,0 +1,90 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// piiPatterns detect common personal data. Card numbers are confirmed with
+// a Luhn checksum to avoid flagging arbitrary digit runs.
+var piiPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+	verify  func(string) bool
+}{
+	{"email address", regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`), nil},
+	{"card number", regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`), luhnValid},
+	{"US social security number", regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), nil},
+	{"phone number", regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?(?:\(\d{2,4}\)|\b\d{2,4})[ .\-]\d{3,4}[ .\-]\d{3,4}\b`), nil},
+	{"IP address", regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`), nil},
+}
+
+func findPII(text string) []Violation {
+	var violations []Violation
+	claimed := make([][]int, 0)
+
+	overlaps := func(loc []int) bool {
+		for _, c := range claimed {
+			if loc[0] < c[1] && c[0] < loc[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Earlier patterns win, so a card number isn't also reported as a phone number
+	for _, p := range piiPatterns {
+		for _, loc := range p.pattern.FindAllStringIndex(text, -1) {
+			match := text[loc[0]:loc[1]]
+			if overlaps(loc) || (p.verify != nil && !p.verify(match)) {
+				continue
+			}
+			claimed = append(claimed, loc)
+			violations = append(violations, Violation{
+				Check:   CheckPII,
+				Message: fmt.Sprintf("contains %s", p.kind),
+				Match:   match,
+				span:    loc,
+			})
+		}
+	}
+	return violations
+}
+
+// luhnValid checks a card number's checksum, ignoring spaces and dashes
+func luhnValid(number string) bool {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, number)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// profanityWords is a deliberately short list of unambiguous terms; use
+// deny patterns for domain-specific language
+var profanityWords = []string{
+	"fuck\\w*", "shit\\w*", "bullshit", "cunt\\w*", "bitch\\w*", "asshole\\w*",
+	"bastard\\w*", "dickhead\\w*", "motherfuck\\w*", "wank\\w*", "twat\\w*",
+}
+
+var profanityPattern = regexp.MustCompile(`(?i)\b(?:` + strings.Join(profanityWords, "|") + `)\b`)
@@ -0,0 +1,39 @@
+package host
+
+import (
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/roots"
+)
+
+// RootDirs are the project roots given with --root. They replace the
+// `roots:` settings when set.
+var RootDirs []string
+
+// ConfigureRoots sets the roots advertised to MCP servers: the --root
+// directories, else the configured roots, else the working directory.
+// Directories that don't exist are skipped with a warning.
+func ConfigureRoots(configured []domainConfig.RootConfig) {
+	entries := configured
+	if len(RootDirs) > 0 {
+		entries = make([]domainConfig.RootConfig, len(RootDirs))
+		for i, dir := range RootDirs {
+			entries[i] = domainConfig.RootConfig{Path: dir}
+		}
+	}
+	if len(entries) == 0 {
+		entries = []domainConfig.RootConfig{{Path: "."}}
+	}
+
+	list := make([]roots.Root, 0, len(entries))
+	for _, entry := range entries {
+		root, err := roots.FromPath(entry.Path, entry.Name)
+		if err != nil {
+			logging.Warn("Ignoring project root: %v", err)
+			continue
+		}
+		list = append(list, root)
+	}
+	roots.Set(list)
+	logging.Debug("Advertising %d project root(s) to servers", len(list))
+}
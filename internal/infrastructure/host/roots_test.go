@@ -0,0 +1,43 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/roots"
+)
+
+func TestConfigureRoots(t *testing.T) {
+	defer roots.Set(nil)
+	dir := t.TempDir()
+	for _, name := range []string{"app", "docs", "flag"} {
+		os.Mkdir(filepath.Join(dir, name), 0755)
+	}
+	configured := []domainConfig.RootConfig{
+		{Path: filepath.Join(dir, "app")},
+		{Path: filepath.Join(dir, "docs"), Name: "Documentation"},
+		{Path: filepath.Join(dir, "missing")},
+	}
+
+	ConfigureRoots(configured)
+	got := roots.Current()
+	if len(got) != 2 || got[0].Name != "app" || got[1].Name != "Documentation" {
+		t.Errorf("configured roots = %+v", got)
+	}
+
+	RootDirs = []string{filepath.Join(dir, "flag")}
+	defer func() { RootDirs = nil }()
+	ConfigureRoots(configured)
+	if got := roots.Current(); len(got) != 1 || got[0].Name != "flag" {
+		t.Errorf("--root should replace the configured roots, got %+v", got)
+	}
+
+	RootDirs = nil
+	ConfigureRoots(nil)
+	wd, _ := os.Getwd()
+	if got := roots.Current(); len(got) != 1 || got[0].Path() != wd {
+		t.Errorf("default roots = %+v, want the working directory", got)
+	}
+}
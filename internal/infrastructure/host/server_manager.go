@@ -8,13 +8,18 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/output"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolcontent"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/elicitation"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/initialize"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/roots"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/transport/stdio"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/transport/unixsocket"
@@ -36,6 +41,16 @@ type ServerConnection struct {
 
 	// Whether this server was explicitly requested by the user
 	UserSpecified bool
+
+	// Cached tools/list result (see ListTools)
+	toolsMu        sync.Mutex
+	tools          []tools.Tool
+	toolsFetched   bool
+	toolsFetchedAt time.Time
+
+	// Identifies the server build in the on-disk tool schema cache; empty
+	// when the connection can't be keyed (e.g. Unix socket servers)
+	schemaCacheKey string
 }
 
 // GetStdioClient returns the client as a stdio client if it is one, nil otherwise
@@ -54,6 +69,9 @@ func (sc *ServerConnection) GetUnixSocketClient() *unixsocket.UnixSocketClient {
 	return nil
 }
 
+// maxParallelConnects bounds how many servers ConnectToServers starts at once
+const maxParallelConnects = 8
+
 // ServerManager manages connections to MCP servers
 type ServerManager struct {
 	connections     []*ServerConnection
@@ -86,9 +104,20 @@ func NewServerManagerWithOptions(suppressConsole bool) *ServerManager {
 
 // ConnectToServer connects to a server with the given configuration
 func (m *ServerManager) ConnectToServer(serverName string, serverConfig domainConfig.ServerConfig, userSpecified bool) (*ServerConnection, error) {
+	conn, err := m.connect(serverName, serverConfig, userSpecified)
+	if err != nil {
+		return nil, err
+	}
+
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.connections = append(m.connections, conn)
+	m.mu.Unlock()
+	return conn, nil
+}
 
+// connect starts and initializes a server without registering it, so several
+// servers can start concurrently
+func (m *ServerManager) connect(serverName string, serverConfig domainConfig.ServerConfig, userSpecified bool) (*ServerConnection, error) {
 	logging.Info("Connecting to server: %s", serverName)
 
 	// NESTED MCP DETECTION: Check if we should use Unix socket instead of stdio
@@ -151,6 +180,10 @@ func (m *ServerManager) ConnectToServer(serverName string, serverConfig domainCo
 		return nil, fmt.Errorf("failed to start server %s: %w", serverName, err)
 	}
 
+	// Answer requests for user input and for the project roots
+	elicitation.Register(client.GetDispatcher(), serverName)
+	roots.Register(client.GetDispatcher(), serverName)
+
 	// Send initialize request
 	logging.Debug("Sending initialize request to server: %s", serverName)
 	initResult, err := initialize.SendInitialize(client, client.GetDispatcher())
@@ -167,10 +200,16 @@ func (m *ServerManager) ConnectToServer(serverName string, serverConfig domainCo
 		ServerInfo:    initResult.ServerInfo,
 		Capabilities:  initResult.Capabilities,
 		UserSpecified: userSpecified,
+
+		schemaCacheKey: toolSchemaCacheKey(serverConfig, initResult.ServerInfo),
 	}
 
-	// Add to connections
-	m.connections = append(m.connections, conn)
+	// Drop the cached tools/list result when the server's tools change
+	client.GetDispatcher().HandleNotification(tools.ListChanged, func(*messages.JSONRPCMessage) {
+		logging.Debug("Server %s changed its tools", serverName)
+		conn.InvalidateTools()
+	})
+
 	logging.Info("Successfully connected to server: %s (%s v%s)",
 		serverName, conn.ServerInfo.Name, conn.ServerInfo.Version)
 
@@ -239,8 +278,6 @@ func (m *ServerManager) connectViaUnixSocket(serverName string, socketPath strin
 		UserSpecified: userSpecified,
 	}
 
-	// Add to connections
-	m.connections = append(m.connections, conn)
 	logging.Info("Successfully connected to server via Unix socket: %s (%s v%s)",
 		serverName, conn.ServerInfo.Name, conn.ServerInfo.Version)
 
@@ -260,35 +297,20 @@ func (m *ServerManager) ConnectToServers(configFile string, serverNames []string
 	}
 
 	logging.Debug("Loaded configuration with %d server entries", len(appConfig.Servers))
+	ConfigureRoots(appConfig.Roots)
+	toolcontent.Configure(appConfig.Skills)
 
-	// Connect to each server
-	for _, name := range serverNames {
-		logging.Debug("Processing server: %s", name)
+	started := time.Now()
+	results := m.startServers(serverNames, appConfig.Servers, userSpecified, m.connect)
 
-		// Get the server configuration
-		serverConfig, exists := appConfig.Servers[name]
-		if !exists {
-			logging.Warn("Server configuration not found for %s", name)
-			if !m.suppressConsole {
-				fmt.Fprintf(os.Stderr, "Warning: server %s not found in configuration\n", name)
-			}
-			continue
-		}
-
-		// Connect to the server (now accepts domain config directly)
-		isUserSpecified := userSpecified[name]
-		_, err = m.ConnectToServer(name, serverConfig, isUserSpecified)
-		if err != nil {
-			logging.Warn("Failed to connect to server %s: %v", name, err)
-			if !m.suppressConsole {
-				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
-			}
-			continue
+	m.mu.Lock()
+	for _, conn := range results {
+		if conn != nil {
+			m.connections = append(m.connections, conn)
 		}
-
-		// Connection successful - no need to print message in normal mode
-		// Message will be in logs if verbose mode is enabled
 	}
+	m.mu.Unlock()
+	logging.Debug("Server startup finished in %s", time.Since(started).Round(time.Millisecond))
 
 	// Check if we have any connections
 	// IMPORTANT: Allow zero connections when no servers were requested
@@ -307,6 +329,57 @@ func (m *ServerManager) ConnectToServers(configFile string, serverNames []string
 	return nil
 }
 
+// startServers connects to the named servers concurrently (bounded by
+// maxParallelConnects) and prefetches their tools. The result has one entry
+// per name in the configured order, nil where the server is missing from
+// servers or failed to connect.
+func (m *ServerManager) startServers(serverNames []string, servers map[string]domainConfig.ServerConfig, userSpecified map[string]bool, connect func(string, domainConfig.ServerConfig, bool) (*ServerConnection, error)) []*ServerConnection {
+	results := make([]*ServerConnection, len(serverNames))
+	sem := make(chan struct{}, maxParallelConnects)
+	var wg sync.WaitGroup
+
+	for i, name := range serverNames {
+		logging.Debug("Processing server: %s", name)
+
+		// Get the server configuration
+		serverConfig, exists := servers[name]
+		if !exists {
+			logging.Warn("Server configuration not found for %s", name)
+			if !m.suppressConsole {
+				fmt.Fprintf(os.Stderr, "Warning: server %s not found in configuration\n", name)
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, name string, serverConfig domainConfig.ServerConfig) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			serverStarted := time.Now()
+			conn, err := connect(name, serverConfig, userSpecified[name])
+			if err != nil {
+				logging.Warn("Failed to connect to server %s: %v", name, err)
+				if !m.suppressConsole {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+				return
+			}
+
+			// Prefetch tools so the first request doesn't wait on tools/list
+			if _, err := conn.ListTools(); err != nil {
+				logging.Warn("Failed to prefetch tools from server %s: %v", name, err)
+			}
+
+			logging.Info("Server %s ready in %s", name, time.Since(serverStarted).Round(time.Millisecond))
+			results[i] = conn
+		}(i, name, serverConfig)
+	}
+	wg.Wait()
+	return results
+}
+
 // GetConnections returns all server connections
 func (m *ServerManager) GetConnections() []*ServerConnection {
 	m.mu.Lock()
@@ -373,71 +446,25 @@ func (m *ServerManager) SetQuiet(quiet bool) {
 
 // GetAvailableTools returns all tools from all connected servers
 func (m *ServerManager) GetAvailableTools() ([]domain.Tool, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	var allTools []domain.Tool
 
-	for _, conn := range m.connections {
-		// Handle both stdio and Unix socket clients
-		var toolsList map[string]interface{}
-		var err error
-
-		switch client := conn.Client.(type) {
-		case *stdio.StdioClient:
-			// Get tools from server using MCP protocol
-			result, e := tools.SendToolsList(client, nil)
-			if e != nil {
-				logging.Warn("Failed to get tools from server %s: %v", conn.Name, e)
-				continue
-			}
-			// Convert MCP tools to domain tools
-			for _, tool := range result.Tools {
-				allTools = append(allTools, domain.Tool{
-					Type: "function",
-					Function: domain.ToolFunction{
-						Name:        tool.Name,
-						Description: tool.Description,
-						Parameters:  tool.InputSchema,
-					},
-				})
-			}
-			continue
-
-		case *unixsocket.UnixSocketClient:
-			toolsList, err = client.SendToolsList(nil)
-			if err != nil {
-				logging.Warn("Failed to get tools from server %s: %v", conn.Name, err)
-				continue
-			}
-
-		default:
-			logging.Warn("Unknown client type for server: %s", conn.Name)
+	for _, conn := range m.GetConnections() {
+		serverTools, err := conn.ListTools()
+		if err != nil {
+			logging.Warn("Failed to get tools from server %s: %v", conn.Name, err)
 			continue
 		}
 
-		// Parse tools from Unix socket response
-		if toolsArray, ok := toolsList["tools"].([]interface{}); ok {
-			for _, t := range toolsArray {
-				if toolMap, ok := t.(map[string]interface{}); ok {
-					tool := domain.Tool{
-						Type:     "function",
-						Function: domain.ToolFunction{},
-					}
-
-					if name, ok := toolMap["name"].(string); ok {
-						tool.Function.Name = name
-					}
-					if desc, ok := toolMap["description"].(string); ok {
-						tool.Function.Description = desc
-					}
-					if schema, ok := toolMap["inputSchema"].(map[string]interface{}); ok {
-						tool.Function.Parameters = schema
-					}
-
-					allTools = append(allTools, tool)
-				}
-			}
+		// Convert MCP tools to domain tools
+		for _, tool := range serverTools {
+			allTools = append(allTools, domain.Tool{
+				Type: "function",
+				Function: domain.ToolFunction{
+					Name:        tool.Name,
+					Description: tool.Description,
+					Parameters:  tool.InputSchema,
+				},
+			})
 		}
 	}
 
@@ -451,42 +478,17 @@ func (m *ServerManager) ExecuteTool(ctx context.Context, toolName string, params
 
 	// Find which server has this tool
 	for _, conn := range m.connections {
-		// Get tools list based on client type
-		var hasToolResult bool
-
-		switch client := conn.Client.(type) {
-		case *stdio.StdioClient:
-			result, err := tools.SendToolsList(client, nil)
-			if err != nil {
-				continue
-			}
-			// Check if this server has the tool
-			for _, tool := range result.Tools {
-				if tool.Name == toolName {
-					hasToolResult = true
-					break
-				}
-			}
+		serverTools, err := conn.ListTools()
+		if err != nil {
+			continue
+		}
 
-		case *unixsocket.UnixSocketClient:
-			result, err := client.SendToolsList(nil)
-			if err != nil {
-				continue
-			}
-			// Check if this server has the tool
-			if toolsArray, ok := result["tools"].([]interface{}); ok {
-				for _, t := range toolsArray {
-					if toolMap, ok := t.(map[string]interface{}); ok {
-						if name, ok := toolMap["name"].(string); ok && name == toolName {
-							hasToolResult = true
-							break
-						}
-					}
-				}
+		hasToolResult := false
+		for _, tool := range serverTools {
+			if tool.Name == toolName {
+				hasToolResult = true
+				break
 			}
-
-		default:
-			continue
 		}
 
 		if !hasToolResult {
@@ -513,6 +515,10 @@ func (m *ServerManager) ExecuteTool(ctx context.Context, toolName string, params
 				return "", nil
 			}
 
+			if text, ok := toolcontent.Render(toolName, callResult.Content); ok {
+				return text, nil
+			}
+
 			// Try to convert content to a reasonable string representation
 			switch v := callResult.Content.(type) {
 			case string:
@@ -547,6 +553,9 @@ func (m *ServerManager) ExecuteTool(ctx context.Context, toolName string, params
 
 			// Convert content to string
 			if content, ok := result["content"]; ok {
+				if text, ok := toolcontent.Render(toolName, content); ok {
+					return text, nil
+				}
 				switch v := content.(type) {
 				case string:
 					return v, nil
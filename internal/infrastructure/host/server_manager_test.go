@@ -0,0 +1,81 @@
+package host
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
+)
+
+func TestStartServersKeepsOrder(t *testing.T) {
+	names := []string{"alpha", "broken", "gamma", "missing", "delta", "epsilon"}
+	servers := map[string]domainConfig.ServerConfig{}
+	for _, name := range names {
+		if name != "missing" {
+			servers[name] = domainConfig.ServerConfig{Command: name}
+		}
+	}
+
+	// Earlier servers take longer, so they finish connecting last
+	connect := func(name string, cfg domainConfig.ServerConfig, userSpecified bool) (*ServerConnection, error) {
+		for i, n := range names {
+			if n == name {
+				time.Sleep(time.Duration(len(names)-i) * 5 * time.Millisecond)
+			}
+		}
+		if name == "broken" {
+			return nil, fmt.Errorf("failed to start server %s", name)
+		}
+		// Pre-fill the tools cache so the prefetch doesn't need a client
+		return &ServerConnection{Name: name, UserSpecified: userSpecified, toolsFetched: true, toolsFetchedAt: time.Now()}, nil
+	}
+
+	m := NewServerManagerWithOptions(true)
+	results := m.startServers(names, servers, map[string]bool{"gamma": true}, connect)
+
+	want := []string{"alpha", "", "gamma", "", "delta", "epsilon"}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, name := range want {
+		got := ""
+		if results[i] != nil {
+			got = results[i].Name
+		}
+		if got != name {
+			t.Errorf("results[%d] = %q, want %q", i, got, name)
+		}
+	}
+	if !results[2].UserSpecified || results[0].UserSpecified {
+		t.Error("userSpecified not passed through")
+	}
+}
+
+func TestListToolsUsesCache(t *testing.T) {
+	cached := []tools.Tool{{Name: "read_file"}}
+
+	// A connection without a client fails whenever it has to ask the server
+	conn := &ServerConnection{Name: "fs", tools: cached, toolsFetched: true, toolsFetchedAt: time.Now()}
+
+	for i := 0; i < 2; i++ {
+		list, err := conn.ListTools()
+		if err != nil {
+			t.Fatalf("ListTools call %d: %v", i+1, err)
+		}
+		if len(list) != 1 || list[0].Name != "read_file" {
+			t.Fatalf("ListTools call %d = %v, want the cached tools", i+1, list)
+		}
+	}
+
+	conn.InvalidateTools()
+	if _, err := conn.ListTools(); err == nil {
+		t.Error("ListTools after InvalidateTools should query the server")
+	}
+
+	expired := &ServerConnection{Name: "fs", tools: cached, toolsFetched: true, toolsFetchedAt: time.Now().Add(-ToolsCacheTTL - time.Second)}
+	if _, err := expired.ListTools(); err == nil {
+		t.Error("ListTools after the TTL should query the server")
+	}
+}
@@ -0,0 +1,131 @@
+package host
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/initialize"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
+)
+
+var (
+	// RefreshToolSchemas ignores the on-disk tool schema cache and rewrites it
+	// from fresh tools/list calls (--refresh-tools)
+	RefreshToolSchemas bool
+
+	// ToolSchemaCacheMaxAge bounds reuse of on-disk tool lists for servers
+	// that change tools without bumping their version
+	ToolSchemaCacheMaxAge = 24 * time.Hour
+)
+
+// toolSchemaCacheEntry is the on-disk form of one server's tools/list result
+type toolSchemaCacheEntry struct {
+	Server    string       `json:"server"`
+	Version   string       `json:"version"`
+	FetchedAt time.Time    `json:"fetched_at"`
+	Tools     []tools.Tool `json:"tools"`
+}
+
+// toolSchemaCacheKey identifies a server build: any change to its command,
+// args, env or reported version produces a new key. Env values are hashed,
+// never stored.
+func toolSchemaCacheKey(serverConfig domainConfig.ServerConfig, info initialize.ServerInfo) string {
+	h := sha256.New()
+	h.Write([]byte(serverConfig.Command))
+	for _, arg := range serverConfig.Args {
+		h.Write([]byte{0})
+		h.Write([]byte(arg))
+	}
+
+	envKeys := make([]string, 0, len(serverConfig.Env))
+	for k := range serverConfig.Env {
+		envKeys = append(envKeys, k)
+	}
+	sort.Strings(envKeys)
+	for _, k := range envKeys {
+		h.Write([]byte{1})
+		h.Write([]byte(k + "=" + serverConfig.Env[k]))
+	}
+
+	h.Write([]byte{2})
+	h.Write([]byte(info.Name + "@" + info.Version))
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// toolSchemaCacheDir returns $XDG_CACHE_HOME/mcp-cli/tools (or ~/.cache/...)
+func toolSchemaCacheDir() string {
+	cacheDir := os.Getenv("XDG_CACHE_HOME")
+	if cacheDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		cacheDir = filepath.Join(homeDir, ".cache")
+	}
+	return filepath.Join(cacheDir, "mcp-cli", "tools")
+}
+
+// loadCachedToolSchemas returns the tool list stored for key, if present and fresh
+func loadCachedToolSchemas(key string) ([]tools.Tool, bool) {
+	dir := toolSchemaCacheDir()
+	if key == "" || dir == "" || RefreshToolSchemas {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, key+".json"))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry toolSchemaCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		logging.Debug("Ignoring corrupt tool schema cache %s: %v", key, err)
+		return nil, false
+	}
+	if time.Since(entry.FetchedAt) > ToolSchemaCacheMaxAge {
+		return nil, false
+	}
+	return entry.Tools, true
+}
+
+// saveCachedToolSchemas writes a server's tool list to the on-disk cache.
+// Failures are logged only; the cache is an optimization.
+func saveCachedToolSchemas(key string, entry toolSchemaCacheEntry) {
+	dir := toolSchemaCacheDir()
+	if key == "" || dir == "" {
+		return
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		logging.Debug("Failed to create tool schema cache directory: %v", err)
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// Write atomically so concurrent invocations never read a partial file
+	tmp, err := os.CreateTemp(dir, key+".*.tmp")
+	if err != nil {
+		logging.Debug("Failed to write tool schema cache: %v", err)
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, key+".json")); err != nil {
+		os.Remove(tmp.Name())
+		logging.Debug("Failed to write tool schema cache: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/initialize"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
+)
+
+func TestToolSchemaCacheKey(t *testing.T) {
+	cfg := domainConfig.ServerConfig{Command: "npx", Args: []string{"-y", "server-fs"}, Env: map[string]string{"ROOT": "/tmp"}}
+	info := initialize.ServerInfo{Name: "fs", Version: "1.0.0"}
+	key := toolSchemaCacheKey(cfg, info)
+
+	if key != toolSchemaCacheKey(cfg, info) {
+		t.Fatal("key should be stable")
+	}
+
+	changed := []struct {
+		name string
+		cfg  domainConfig.ServerConfig
+		info initialize.ServerInfo
+	}{
+		{"args", domainConfig.ServerConfig{Command: "npx", Args: []string{"-y", "server-fs", "/data"}, Env: cfg.Env}, info},
+		{"env", domainConfig.ServerConfig{Command: "npx", Args: cfg.Args, Env: map[string]string{"ROOT": "/data"}}, info},
+		{"version", cfg, initialize.ServerInfo{Name: "fs", Version: "1.1.0"}},
+	}
+	for _, c := range changed {
+		if toolSchemaCacheKey(c.cfg, c.info) == key {
+			t.Errorf("changing %s should change the key", c.name)
+		}
+	}
+}
+
+func TestToolSchemaCacheRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	key := "abc123"
+	list := []tools.Tool{{Name: "read_file", Description: "Read a file"}}
+
+	if _, ok := loadCachedToolSchemas(key); ok {
+		t.Fatal("empty cache should miss")
+	}
+
+	saveCachedToolSchemas(key, toolSchemaCacheEntry{Server: "fs", FetchedAt: time.Now(), Tools: list})
+	cached, ok := loadCachedToolSchemas(key)
+	if !ok || len(cached) != 1 || cached[0].Name != "read_file" {
+		t.Fatalf("cache hit = %v, tools = %+v", ok, cached)
+	}
+
+	RefreshToolSchemas = true
+	defer func() { RefreshToolSchemas = false }()
+	if _, ok := loadCachedToolSchemas(key); ok {
+		t.Error("RefreshToolSchemas should bypass the cache")
+	}
+	RefreshToolSchemas = false
+
+	saveCachedToolSchemas(key, toolSchemaCacheEntry{Server: "fs", FetchedAt: time.Now().Add(-48 * time.Hour), Tools: list})
+	if _, ok := loadCachedToolSchemas(key); ok {
+		t.Error("entries older than ToolSchemaCacheMaxAge should miss")
+	}
+}
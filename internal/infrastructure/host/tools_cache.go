@@ -0,0 +1,104 @@
+package host
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
+)
+
+// ToolsCacheTTL is how long a server's tools/list result is reused before it
+// is fetched again. Servers rarely change their tools during a run, so this
+// mainly bounds staleness for long-lived processes (chat, serve, daemon).
+var ToolsCacheTTL = 5 * time.Minute
+
+// ListTools returns the server's tools, using the cached tools/list result
+// while it is younger than ToolsCacheTTL. The first call in a process is
+// served from the on-disk tool schema cache when the server build matches.
+func (sc *ServerConnection) ListTools() ([]tools.Tool, error) {
+	sc.toolsMu.Lock()
+	defer sc.toolsMu.Unlock()
+
+	if sc.toolsFetched && time.Since(sc.toolsFetchedAt) < ToolsCacheTTL {
+		return sc.tools, nil
+	}
+
+	if sc.toolsFetchedAt.IsZero() {
+		if cached, ok := loadCachedToolSchemas(sc.schemaCacheKey); ok {
+			sc.tools = cached
+			sc.toolsFetched = true
+			sc.toolsFetchedAt = time.Now()
+			logging.Debug("Using %d cached tool schemas for server %s", len(cached), sc.Name)
+			return cached, nil
+		}
+	}
+
+	started := time.Now()
+	var list []tools.Tool
+	if stdioClient := sc.GetStdioClient(); stdioClient != nil {
+		result, err := tools.SendToolsList(stdioClient, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tools from server %s: %w", sc.Name, err)
+		}
+		list = result.Tools
+	} else if socketClient := sc.GetUnixSocketClient(); socketClient != nil {
+		result, err := socketClient.SendToolsList(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tools from server %s: %w", sc.Name, err)
+		}
+		list = parseSocketTools(result)
+	} else {
+		return nil, fmt.Errorf("server %s has unknown client type", sc.Name)
+	}
+
+	sc.tools = list
+	sc.toolsFetched = true
+	sc.toolsFetchedAt = time.Now()
+	logging.Debug("Fetched %d tools from server %s in %s", len(list), sc.Name, time.Since(started).Round(time.Millisecond))
+
+	saveCachedToolSchemas(sc.schemaCacheKey, toolSchemaCacheEntry{
+		Server:    sc.Name,
+		Version:   sc.ServerInfo.Name + "@" + sc.ServerInfo.Version,
+		FetchedAt: sc.toolsFetchedAt,
+		Tools:     list,
+	})
+	return list, nil
+}
+
+// InvalidateTools drops the cached tools/list result, e.g. after a
+// notifications/tools/list_changed message. The next ListTools call queries
+// the server and refreshes the on-disk cache.
+func (sc *ServerConnection) InvalidateTools() {
+	sc.toolsMu.Lock()
+	defer sc.toolsMu.Unlock()
+	sc.tools = nil
+	sc.toolsFetched = false
+}
+
+// parseSocketTools converts a Unix socket tools/list response
+func parseSocketTools(result map[string]interface{}) []tools.Tool {
+	var parsed []tools.Tool
+	toolsArray, ok := result["tools"].([]interface{})
+	if !ok {
+		return parsed
+	}
+	for _, t := range toolsArray {
+		toolMap, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		tool := tools.Tool{}
+		if name, ok := toolMap["name"].(string); ok {
+			tool.Name = name
+		}
+		if desc, ok := toolMap["description"].(string); ok {
+			tool.Description = desc
+		}
+		if schema, ok := toolMap["inputSchema"].(map[string]interface{}); ok {
+			tool.InputSchema = schema
+		}
+		parsed = append(parsed, tool)
+	}
+	return parsed
+}
@@ -0,0 +1,253 @@
+// Package jobqueue bounds how many jobs run at once and how many may wait,
+// applying a backpressure policy when the waiting line is full.
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Backpressure policies
+const (
+	// PolicyQueue waits for a slot, rejecting new jobs once MaxDepth jobs wait
+	PolicyQueue = "queue"
+
+	// PolicyReject runs jobs only while a slot is free, never waiting
+	PolicyReject = "reject"
+
+	// PolicyShed waits like PolicyQueue, but a full queue evicts its lowest
+	// priority waiting job for a new job of higher priority
+	PolicyShed = "shed"
+)
+
+// Defaults applied by New
+const (
+	DefaultMaxConcurrent = 4
+	DefaultMaxDepth      = 32
+)
+
+var (
+	// ErrRejected is returned when a job is refused admission
+	ErrRejected = errors.New("server busy: job queue is full")
+
+	// ErrShed is returned to a waiting job evicted for higher-priority work
+	ErrShed = fmt.Errorf("%w (dropped for higher-priority work)", ErrRejected)
+)
+
+// Options configures a Queue
+type Options struct {
+	MaxConcurrent int    // Jobs running at once (default: DefaultMaxConcurrent)
+	MaxDepth      int    // Jobs waiting for a slot (default: DefaultMaxDepth; ignored by PolicyReject)
+	Policy        string // PolicyQueue (default), PolicyReject or PolicyShed
+}
+
+// Stats is a snapshot of queue activity
+type Stats struct {
+	Running       int    `json:"running"`
+	Waiting       int    `json:"waiting"`
+	PeakWaiting   int    `json:"peak_waiting"`
+	MaxConcurrent int    `json:"max_concurrent"`
+	MaxDepth      int    `json:"max_depth"`
+	Accepted      uint64 `json:"accepted"`
+	Rejected      uint64 `json:"rejected"`
+	Shed          uint64 `json:"shed"`
+	Abandoned     uint64 `json:"abandoned"` // Gave up waiting (timeout or cancellation)
+	Completed     uint64 `json:"completed"`
+}
+
+// Queue admits jobs by priority, highest first, FIFO within a priority
+type Queue struct {
+	opts Options
+
+	mu      sync.Mutex
+	running int
+	waiting []*Ticket
+	stats   Stats
+}
+
+// Ticket is an admitted job waiting for, or holding, a slot
+type Ticket struct {
+	q        *Queue
+	priority int
+	done     chan struct{} // Closed when granted a slot or shed
+	err      error         // ErrShed when evicted
+	released bool
+}
+
+// New creates a queue, applying defaults to unset options
+func New(opts Options) *Queue {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = DefaultMaxConcurrent
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	if opts.Policy == "" {
+		opts.Policy = PolicyQueue
+	}
+	if opts.Policy == PolicyReject {
+		opts.MaxDepth = 0
+	}
+	return &Queue{opts: opts}
+}
+
+// Enqueue admits a job or returns ErrRejected. The returned ticket must be
+// waited on, and released once the job finishes. A nil queue admits every
+// job with a nil ticket, whose Wait and Release do nothing.
+func (q *Queue) Enqueue(priority int) (*Ticket, error) {
+	if q == nil {
+		return nil, nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t := &Ticket{q: q, priority: priority, done: make(chan struct{})}
+
+	if q.running < q.opts.MaxConcurrent && len(q.waiting) == 0 {
+		q.running++
+		q.stats.Accepted++
+		close(t.done)
+		return t, nil
+	}
+
+	if len(q.waiting) >= q.opts.MaxDepth {
+		// The lowest priority, most recent waiting job sorts last
+		if q.opts.Policy != PolicyShed || len(q.waiting) == 0 || q.waiting[len(q.waiting)-1].priority >= priority {
+			q.stats.Rejected++
+			return nil, ErrRejected
+		}
+		victim := q.waiting[len(q.waiting)-1]
+		q.waiting = q.waiting[:len(q.waiting)-1]
+		victim.err = ErrShed
+		close(victim.done)
+		q.stats.Shed++
+	}
+
+	i := sort.Search(len(q.waiting), func(i int) bool { return q.waiting[i].priority < priority })
+	q.waiting = append(q.waiting, nil)
+	copy(q.waiting[i+1:], q.waiting[i:])
+	q.waiting[i] = t
+
+	q.stats.Accepted++
+	if len(q.waiting) > q.stats.PeakWaiting {
+		q.stats.PeakWaiting = len(q.waiting)
+	}
+	return t, nil
+}
+
+// Wait blocks until the ticket holds a slot. It returns ErrShed if the job
+// was evicted, or the context's error if ctx ends first.
+func (t *Ticket) Wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	select {
+	case <-t.done:
+		return t.err
+	case <-ctx.Done():
+	}
+
+	q := t.q
+	q.mu.Lock()
+	for i, waiting := range q.waiting {
+		if waiting == t {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			q.stats.Abandoned++
+			q.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+	q.mu.Unlock()
+
+	// Granted or shed while ctx ended
+	if t.err != nil {
+		return t.err
+	}
+	t.Release()
+	return ctx.Err()
+}
+
+// Release frees the ticket's slot for the next waiting job. It is safe to
+// call more than once, and a no-op for tickets that never got a slot.
+func (t *Ticket) Release() {
+	if t == nil {
+		return
+	}
+	q := t.q
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case <-t.done:
+	default:
+		return // Still waiting
+	}
+	if t.released || t.err != nil {
+		return
+	}
+	t.released = true
+	q.running--
+	q.stats.Completed++
+
+	for q.running < q.opts.MaxConcurrent && len(q.waiting) > 0 {
+		next := q.waiting[0]
+		q.waiting = q.waiting[1:]
+		q.running++
+		close(next.done)
+	}
+}
+
+// Options returns the queue's settings with defaults applied
+func (q *Queue) Options() Options {
+	return q.opts
+}
+
+// Stats returns a snapshot of the queue's activity
+func (q *Queue) Stats() Stats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := q.stats
+	stats.Running = q.running
+	stats.Waiting = len(q.waiting)
+	stats.MaxConcurrent = q.opts.MaxConcurrent
+	stats.MaxDepth = q.opts.MaxDepth
+	return stats
+}
+
+// WritePrometheus writes the stats in the Prometheus text format
+func (q *Queue) WritePrometheus(w io.Writer) error {
+	s := q.Stats()
+	metrics := []struct {
+		name, kind, help string
+		value            float64
+	}{
+		{"mcp_queue_running", "gauge", "Jobs currently running", float64(s.Running)},
+		{"mcp_queue_waiting", "gauge", "Jobs waiting for a slot", float64(s.Waiting)},
+		{"mcp_queue_waiting_peak", "gauge", "Most jobs waiting at once", float64(s.PeakWaiting)},
+		{"mcp_queue_max_concurrent", "gauge", "Configured concurrent job limit", float64(s.MaxConcurrent)},
+		{"mcp_queue_max_depth", "gauge", "Configured waiting job limit", float64(s.MaxDepth)},
+		{"mcp_queue_accepted_total", "counter", "Jobs admitted", float64(s.Accepted)},
+		{"mcp_queue_rejected_total", "counter", "Jobs rejected because the queue was full", float64(s.Rejected)},
+		{"mcp_queue_shed_total", "counter", "Waiting jobs dropped for higher-priority work", float64(s.Shed)},
+		{"mcp_queue_abandoned_total", "counter", "Jobs that stopped waiting (timeout or cancellation)", float64(s.Abandoned)},
+		{"mcp_queue_completed_total", "counter", "Jobs finished", float64(s.Completed)},
+	}
+	for _, m := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", m.name, m.help, m.name, m.kind, m.name, m.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeHTTP serves the stats in the Prometheus text format
+func (q *Queue) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	q.WritePrometheus(w)
+}
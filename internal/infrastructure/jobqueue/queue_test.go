@@ -0,0 +1,114 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// granted reports whether the ticket holds a slot without blocking
+func granted(t *Ticket) bool {
+	select {
+	case <-t.done:
+		return t.err == nil
+	default:
+		return false
+	}
+}
+
+func TestQueue_PriorityOrder(t *testing.T) {
+	q := New(Options{MaxConcurrent: 1, MaxDepth: 3})
+
+	running, _ := q.Enqueue(0)
+	low, _ := q.Enqueue(0)
+	high, _ := q.Enqueue(5)
+	low2, _ := q.Enqueue(0)
+	if _, err := q.Enqueue(9); !errors.Is(err, ErrRejected) {
+		t.Fatalf("full queue err = %v, want ErrRejected", err)
+	}
+
+	running.Release()
+	if !granted(high) || granted(low) {
+		t.Fatal("highest priority job should run next")
+	}
+	high.Release()
+	if !granted(low) || granted(low2) {
+		t.Fatal("equal priorities should run in arrival order")
+	}
+
+	s := q.Stats()
+	if s.Running != 1 || s.Waiting != 1 || s.Accepted != 4 || s.Rejected != 1 || s.PeakWaiting != 3 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestQueue_RejectPolicy(t *testing.T) {
+	q := New(Options{MaxConcurrent: 1, MaxDepth: 10, Policy: PolicyReject})
+
+	first, err := q.Enqueue(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Enqueue(0); !errors.Is(err, ErrRejected) {
+		t.Errorf("busy queue err = %v, want ErrRejected", err)
+	}
+	first.Release()
+	first.Release() // Idempotent
+	if _, err := q.Enqueue(0); err != nil {
+		t.Errorf("free slot err = %v", err)
+	}
+}
+
+func TestQueue_ShedPolicy(t *testing.T) {
+	q := New(Options{MaxConcurrent: 1, MaxDepth: 1, Policy: PolicyShed})
+
+	running, _ := q.Enqueue(0)
+	low, _ := q.Enqueue(1)
+	if _, err := q.Enqueue(1); !errors.Is(err, ErrRejected) {
+		t.Errorf("equal priority err = %v, want ErrRejected", err)
+	}
+
+	high, err := q.Enqueue(2)
+	if err != nil {
+		t.Fatalf("higher priority err = %v, want admitted", err)
+	}
+	if err := low.Wait(context.Background()); !errors.Is(err, ErrShed) || !errors.Is(err, ErrRejected) {
+		t.Errorf("shed job err = %v, want ErrShed", err)
+	}
+
+	running.Release()
+	if err := high.Wait(context.Background()); err != nil {
+		t.Errorf("high priority job err = %v", err)
+	}
+	if s := q.Stats(); s.Shed != 1 || s.Rejected != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestQueue_WaitTimeout(t *testing.T) {
+	q := New(Options{MaxConcurrent: 1})
+
+	running, _ := q.Enqueue(0)
+	waiting, _ := q.Enqueue(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := waiting.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+
+	// The abandoned job no longer holds a place in line
+	running.Release()
+	if s := q.Stats(); s.Running != 0 || s.Waiting != 0 || s.Abandoned != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+
+	var out strings.Builder
+	if err := q.WritePrometheus(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "mcp_queue_abandoned_total 1\n") {
+		t.Errorf("metrics missing abandoned count:\n%s", out.String())
+	}
+}
@@ -0,0 +1,92 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Coerce converts values to the types schema declares where the conversion
+// is unambiguous: numeric and boolean strings to numbers and booleans,
+// numbers and booleans to strings, and JSON-encoded strings to the arrays
+// or objects they contain. It recurses into properties and items. Values
+// that cannot be converted are returned unchanged for Validate to report.
+func Coerce(schema map[string]interface{}, value interface{}) interface{} {
+	value = normalize(value)
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 && !matchesAny(value, types) {
+		for _, t := range types {
+			if converted, ok := convert(value, t); ok {
+				value = converted
+				break
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		if items, ok := schemaMap(schema["items"]); ok {
+			for i, item := range v {
+				v[i] = Coerce(items, item)
+			}
+		}
+	case map[string]interface{}:
+		properties, _ := schemaMap(schema["properties"])
+		for key, item := range v {
+			if propSchema, ok := schemaMap(properties[key]); ok {
+				v[key] = Coerce(propSchema, item)
+			} else if additional, ok := schemaMap(schema["additionalProperties"]); ok {
+				v[key] = Coerce(additional, item)
+			}
+		}
+	}
+	return value
+}
+
+func matchesAny(value interface{}, types []string) bool {
+	for _, t := range types {
+		if hasType(value, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// convert converts a scalar to type t, reporting whether it could
+func convert(value interface{}, t string) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		s := strings.TrimSpace(v)
+		switch t {
+		case "number":
+			if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+				return f, true
+			}
+		case "integer":
+			if f, err := strconv.ParseFloat(s, 64); err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) {
+				return f, true
+			}
+		case "boolean":
+			if strings.EqualFold(s, "true") || strings.EqualFold(s, "false") {
+				return strings.EqualFold(s, "true"), true
+			}
+		case "array", "object":
+			var decoded interface{}
+			if json.Unmarshal([]byte(s), &decoded) == nil && hasType(decoded, t) {
+				return decoded, true
+			}
+		}
+
+	case float64:
+		if t == "string" {
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		}
+
+	case bool:
+		if t == "string" {
+			return strconv.FormatBool(v), true
+		}
+	}
+	return nil, false
+}
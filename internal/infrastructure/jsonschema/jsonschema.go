@@ -0,0 +1,267 @@
+// Package jsonschema validates JSON values against the commonly used subset of
+// JSON Schema: type, enum, const, properties, required, additionalProperties,
+// items, length/size bounds, numeric bounds and pattern.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateJSON parses data and validates it against schema
+func ValidateJSON(schema map[string]interface{}, data string) ([]string, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		return nil, fmt.Errorf("not valid JSON: %w", err)
+	}
+	return Validate(schema, value), nil
+}
+
+// Validate checks a decoded JSON value (as produced by encoding/json or YAML)
+// against schema and returns one message per problem; nil means valid.
+func Validate(schema map[string]interface{}, value interface{}) []string {
+	var problems []string
+	validate(schema, normalize(value), "$", &problems)
+	return problems
+}
+
+func validate(schema map[string]interface{}, value interface{}, path string, problems *[]string) {
+	fail := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if hasType(value, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("expected %s, got %s", strings.Join(types, " or "), typeName(value))
+			return
+		}
+	}
+
+	if enum, ok := normalize(schema["enum"]).([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(normalize(allowed), value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("value %s is not one of %s", encode(value), encode(enum))
+		}
+	}
+	if constant, ok := schema["const"]; ok && !reflect.DeepEqual(normalize(constant), value) {
+		fail("value must be %s", encode(constant))
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if min, ok := number(schema["minLength"]); ok && float64(length) < min {
+			fail("string shorter than %v characters", min)
+		}
+		if max, ok := number(schema["maxLength"]); ok && float64(length) > max {
+			fail("string longer than %v characters", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				fail("invalid pattern %q in schema: %v", pattern, err)
+			} else if !re.MatchString(v) {
+				fail("string does not match pattern %q", pattern)
+			}
+		}
+
+	case float64:
+		if min, ok := number(schema["minimum"]); ok && v < min {
+			fail("%v is less than minimum %v", v, min)
+		}
+		if max, ok := number(schema["maximum"]); ok && v > max {
+			fail("%v is greater than maximum %v", v, max)
+		}
+		if min, ok := number(schema["exclusiveMinimum"]); ok && v <= min {
+			fail("%v must be greater than %v", v, min)
+		}
+		if max, ok := number(schema["exclusiveMaximum"]); ok && v >= max {
+			fail("%v must be less than %v", v, max)
+		}
+
+	case []interface{}:
+		if min, ok := number(schema["minItems"]); ok && float64(len(v)) < min {
+			fail("array has fewer than %v items", min)
+		}
+		if max, ok := number(schema["maxItems"]); ok && float64(len(v)) > max {
+			fail("array has more than %v items", max)
+		}
+		if items, ok := schemaMap(schema["items"]); ok {
+			for i, item := range v {
+				validate(items, item, fmt.Sprintf("%s[%d]", path, i), problems)
+			}
+		}
+
+	case map[string]interface{}:
+		if required, ok := normalize(schema["required"]).([]interface{}); ok {
+			for _, name := range required {
+				if key, ok := name.(string); ok {
+					if _, present := v[key]; !present {
+						fail("missing required property '%s'", key)
+					}
+				}
+			}
+		}
+
+		properties, _ := schemaMap(schema["properties"])
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if propSchema, ok := schemaMap(properties[key]); ok {
+				validate(propSchema, v[key], path+"."+key, problems)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					fail("unexpected property '%s'", key)
+				}
+			case map[string]interface{}:
+				validate(additional, v[key], path+"."+key, problems)
+			}
+		}
+	}
+}
+
+// normalize converts YAML-decoded values (ints, map[interface{}]interface{})
+// to the shapes encoding/json produces
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = normalize(item)
+		}
+		return out
+	case []string:
+		// Schemas built in Go code list required properties and enums as []string
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = item
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = normalize(item)
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[fmt.Sprint(key)] = normalize(item)
+		}
+		return out
+	}
+	return value
+}
+
+func schemaTypes(raw interface{}) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func schemaMap(raw interface{}) (map[string]interface{}, bool) {
+	if m, ok := normalize(raw).(map[string]interface{}); ok {
+		return m, true
+	}
+	return nil, false
+}
+
+func hasType(value interface{}, t string) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func number(raw interface{}) (float64, bool) {
+	f, ok := normalize(raw).(float64)
+	return f, ok
+}
+
+func encode(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
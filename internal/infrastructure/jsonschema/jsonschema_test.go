@@ -0,0 +1,98 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"title", "tags"},
+		"properties": map[string]interface{}{
+			"title":    map[string]interface{}{"type": "string", "minLength": 3},
+			"priority": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 5},
+			"status":   map[string]interface{}{"enum": []interface{}{"open", "closed"}},
+			"tags":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+		"additionalProperties": false,
+	}
+
+	problems, err := ValidateJSON(schema, `{"title": "Fix login", "priority": 2, "status": "open", "tags": ["auth"]}`)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+
+	problems, err = ValidateJSON(schema, `{"title": "x", "priority": 2.5, "status": "stale", "tags": [1], "extra": true}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"$: unexpected property 'extra'",
+		"$.priority: expected integer, got number",
+		"$.status: value \"stale\" is not one of [\"open\",\"closed\"]",
+		"$.tags[0]: expected string, got number",
+		"$.title: string shorter than 3 characters",
+	}, problems)
+
+	problems, _ = ValidateJSON(schema, `{"title": "Fix login"}`)
+	assert.Equal(t, []string{"$: missing required property 'tags'"}, problems)
+
+	_, err = ValidateJSON(schema, `not json`)
+	assert.Error(t, err)
+}
+
+func TestValidateYAMLValues(t *testing.T) {
+	// Schemas and values decoded from YAML use ints and interface-keyed maps
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[interface{}]interface{}{
+			"count": map[interface{}]interface{}{"type": "integer", "maximum": 10},
+		},
+	}
+	assert.Empty(t, Validate(schema, map[interface{}]interface{}{"count": 3}))
+	assert.Equal(t, []string{"$.count: 11 is greater than maximum 10"},
+		Validate(schema, map[string]interface{}{"count": 11}))
+}
+
+func TestCoerce(t *testing.T) {
+	// Serve-mode schemas are built in Go with []string required lists
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"count"},
+		"properties": map[string]interface{}{
+			"count":   map[string]interface{}{"type": "integer"},
+			"ratio":   map[string]interface{}{"type": "number"},
+			"verbose": map[string]interface{}{"type": "boolean"},
+			"label":   map[string]interface{}{"type": "string"},
+			"tags":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}},
+			"mode":    map[string]interface{}{"type": "string", "enum": []string{"fast", "slow"}},
+		},
+	}
+
+	value := Coerce(schema, map[string]interface{}{
+		"count":   "3",
+		"ratio":   " 0.5",
+		"verbose": "TRUE",
+		"label":   42.0,
+		"tags":    `["1", 2]`,
+		"mode":    "fast",
+	})
+	assert.Equal(t, map[string]interface{}{
+		"count":   3.0,
+		"ratio":   0.5,
+		"verbose": true,
+		"label":   "42",
+		"tags":    []interface{}{1.0, 2.0},
+		"mode":    "fast",
+	}, value)
+	assert.Empty(t, Validate(schema, value))
+
+	// Values that do not convert are left for Validate to report
+	value = Coerce(schema, map[string]interface{}{"count": "3.5", "verbose": "yes", "mode": "medium"})
+	assert.Equal(t, []string{
+		"$.count: expected integer, got string",
+		"$.mode: value \"medium\" is not one of [\"fast\",\"slow\"]",
+		"$.verbose: expected boolean, got string",
+	}, Validate(schema, value))
+
+	assert.Equal(t, []string{"$: missing required property 'count'"}, Validate(schema, map[string]interface{}{}))
+}
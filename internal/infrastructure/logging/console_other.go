@@ -0,0 +1,9 @@
+//go:build !windows
+
+package logging
+
+// EnableUTF8Console does nothing outside Windows, where terminals already
+// use UTF-8
+func EnableUTF8Console() (restore func()) {
+	return func() {}
+}
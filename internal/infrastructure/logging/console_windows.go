@@ -0,0 +1,33 @@
+package logging
+
+import "golang.org/x/sys/windows"
+
+// utf8CodePage is the Windows code page for UTF-8
+const utf8CodePage = 65001
+
+// EnableUTF8Console switches the console to UTF-8, so non-ASCII text from
+// models and servers isn't garbled, and turns on ANSI color handling. The
+// code page belongs to the console rather than the process, so call the
+// returned function before exiting to put it back.
+func EnableUTF8Console() (restore func()) {
+	inputCP, inErr := windows.GetConsoleCP()
+	outputCP, outErr := windows.GetConsoleOutputCP()
+	if inErr != nil || outErr != nil {
+		// Not attached to a console
+		return func() {}
+	}
+	windows.SetConsoleCP(utf8CodePage)
+	windows.SetConsoleOutputCP(utf8CodePage)
+
+	for _, stream := range []windows.Handle{windows.Stdout, windows.Stderr} {
+		var mode uint32
+		if windows.GetConsoleMode(stream, &mode) == nil {
+			windows.SetConsoleMode(stream, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+		}
+	}
+
+	return func() {
+		windows.SetConsoleCP(inputCP)
+		windows.SetConsoleOutputCP(outputCP)
+	}
+}
@@ -118,6 +118,13 @@ func (l *Logger) SetLevel(level LogLevel) {
 	l.level = level
 }
 
+// SetOutput sets where log messages are written
+func (l *Logger) SetOutput(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.SetOutput(w)
+}
+
 // GetLevel returns the current logging level
 func (l *Logger) GetLevel() LogLevel {
 	l.mu.Lock()
@@ -209,6 +216,13 @@ func SetDefaultLevel(level LogLevel) {
 	defaultLogger.SetLevel(level)
 }
 
+// SetOutput sets where the default logger writes, for programs that embed
+// mcp-cli and need its logs kept off stderr
+func SetOutput(w io.Writer) {
+	once.Do(initDefaultLogger)
+	defaultLogger.SetOutput(w)
+}
+
 // SetColorOutput globally enables or disables colored output
 func SetColorOutput(enabled bool) {
 	once.Do(initDefaultLogger)
@@ -0,0 +1,265 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Message is a notification to deliver
+type Message struct {
+	Subject string
+	Body    string
+	To      []string // SMTP only; overrides channel default recipients
+}
+
+// Send delivers a message through the configured channel
+func Send(ctx context.Context, channel *config.NotificationChannelConfig, msg *Message) error {
+	if channel == nil {
+		return fmt.Errorf("notification channel configuration is required")
+	}
+
+	timeout := 30 * time.Second
+	if channel.TimeoutSeconds > 0 {
+		timeout = time.Duration(channel.TimeoutSeconds) * time.Second
+	}
+
+	switch channel.Type {
+	case config.NotificationSlackWebhook:
+		return postWebhook(ctx, channel.WebhookURL, slackPayload(msg), nil, timeout)
+	case config.NotificationTeamsWebhook:
+		return postWebhook(ctx, channel.WebhookURL, teamsPayload(msg), nil, timeout)
+	case config.NotificationSMTP:
+		return sendSMTP(channel, msg, timeout)
+	default:
+		return fmt.Errorf("unsupported notification type '%s' (supported: smtp, slack_webhook, teams_webhook)", channel.Type)
+	}
+}
+
+// slackPayload builds a Slack incoming webhook payload
+func slackPayload(msg *Message) map[string]interface{} {
+	text := msg.Body
+	if msg.Subject != "" {
+		text = fmt.Sprintf("*%s*\n%s", msg.Subject, msg.Body)
+	}
+	return map[string]interface{}{"text": text}
+}
+
+// teamsPayload builds a Teams incoming webhook payload (Adaptive Card)
+func teamsPayload(msg *Message) map[string]interface{} {
+	var body []map[string]interface{}
+	if msg.Subject != "" {
+		body = append(body, map[string]interface{}{
+			"type":   "TextBlock",
+			"text":   msg.Subject,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"wrap":   true,
+		})
+	}
+	body = append(body, map[string]interface{}{
+		"type": "TextBlock",
+		"text": msg.Body,
+		"wrap": true,
+	})
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			},
+		},
+	}
+}
+
+// SignatureHeader carries the HMAC-SHA256 signature of a signed webhook body
+const SignatureHeader = "X-MCP-Signature"
+
+// PostSigned posts a JSON payload to a webhook URL. When secret is set the
+// body is signed in the X-MCP-Signature header as "sha256=<hex HMAC>", so
+// the receiver can verify it came from this server.
+func PostSigned(ctx context.Context, webhookURL string, payload interface{}, secret string, timeout time.Duration) error {
+	return postWebhook(ctx, webhookURL, payload, func(data []byte) map[string]string {
+		if secret == "" {
+			return nil
+		}
+		return map[string]string{SignatureHeader: Sign(secret, data)}
+	}, timeout)
+}
+
+// Sign returns the X-MCP-Signature value for a body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// postWebhook posts a JSON payload to a webhook URL. headers, when set,
+// returns extra headers for the encoded body.
+func postWebhook(ctx context.Context, webhookURL string, payload interface{}, headers func(body []byte) map[string]string, timeout time.Duration) error {
+	if webhookURL == "" {
+		return fmt.Errorf("webhook_url is required")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if headers != nil {
+		for name, value := range headers(data) {
+			req.Header.Set(name, value)
+		}
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	logging.Debug("Webhook notification delivered (%s)", resp.Status)
+	return nil
+}
+
+// sendSMTP sends a plain-text email
+func sendSMTP(channel *config.NotificationChannelConfig, msg *Message, timeout time.Duration) error {
+	if channel.Host == "" {
+		return fmt.Errorf("smtp host is required")
+	}
+	if channel.From == "" {
+		return fmt.Errorf("smtp from address is required")
+	}
+
+	recipients := msg.To
+	if len(recipients) == 0 {
+		recipients = channel.To
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("no email recipients (set to: on the step or channel)")
+	}
+
+	port := channel.Port
+	if port == 0 {
+		if channel.TLS {
+			port = 465
+		} else {
+			port = 587
+		}
+	}
+	addr := net.JoinHostPort(channel.Host, strconv.Itoa(port))
+	tlsConfig := &tls.Config{ServerName: channel.Host, InsecureSkipVerify: channel.Insecure}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: timeout}
+	if channel.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server %s: %w", addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, channel.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake failed: %w", err)
+	}
+	defer client.Close()
+
+	if !channel.TLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("smtp STARTTLS failed: %w", err)
+			}
+		}
+	}
+
+	if channel.Username != "" {
+		auth := smtp.PlainAuth("", channel.Username, channel.Password, channel.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(channel.From); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s failed: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(buildEmail(channel.From, recipients, msg)); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	logging.Debug("Email notification sent to %d recipients via %s", len(recipients), addr)
+	return client.Quit()
+}
+
+// buildEmail formats an RFC 5322 plain-text message
+func buildEmail(from string, to []string, msg *Message) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	sb.WriteString("Subject: " + sanitizeHeader(msg.Subject) + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	sb.WriteString("\r\n")
+	return []byte(sb.String())
+}
+
+// sanitizeHeader prevents header injection through templated subjects
+func sanitizeHeader(value string) string {
+	value = strings.ReplaceAll(value, "\r", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestSendSlackWebhook(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	channel := &config.NotificationChannelConfig{Type: config.NotificationSlackWebhook, WebhookURL: server.URL}
+	err := Send(context.Background(), channel, &Message{Subject: "Done", Body: "Workflow finished"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if received["text"] != "*Done*\nWorkflow finished" {
+		t.Errorf("unexpected slack payload: %v", received)
+	}
+}
+
+func TestSendWebhookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	channel := &config.NotificationChannelConfig{Type: config.NotificationTeamsWebhook, WebhookURL: server.URL}
+	err := Send(context.Background(), channel, &Message{Body: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("expected webhook error, got %v", err)
+	}
+}
+
+func TestPostSigned(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+	}))
+	defer server.Close()
+
+	err := PostSigned(context.Background(), server.URL, map[string]string{"run_id": "abc"}, "s3cret", time.Second)
+	if err != nil {
+		t.Fatalf("PostSigned() error = %v", err)
+	}
+	if string(body) != `{"run_id":"abc"}` || signature != Sign("s3cret", body) || !strings.HasPrefix(signature, "sha256=") {
+		t.Errorf("body %s, signature %q", body, signature)
+	}
+
+	if err := PostSigned(context.Background(), server.URL, map[string]string{}, "", time.Second); err != nil || signature != "" {
+		t.Errorf("unsigned post: err %v, signature %q", err, signature)
+	}
+}
+
+func TestBuildEmailSanitizesSubject(t *testing.T) {
+	email := string(buildEmail("bot@example.com", []string{"a@example.com"}, &Message{
+		Subject: "Alert\r\nBcc: evil@example.com",
+		Body:    "line1\nline2",
+	}))
+
+	if strings.Contains(email, "\r\nBcc:") {
+		t.Error("subject newline was not sanitized")
+	}
+	if !strings.Contains(email, "line1\r\nline2") {
+		t.Error("body line endings were not normalized")
+	}
+}
@@ -0,0 +1,274 @@
+// Package outputcrypt encrypts files written to the outputs directory, for
+// sensitive documents processed on shared machines. Files are sealed with
+// AES-256-GCM under a key from settings and renamed with a .enc suffix;
+// `mcp-cli outputs decrypt` turns them back into plain files.
+package outputcrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Suffix is appended to the names of sealed files
+const Suffix = ".enc"
+
+// magic starts every sealed file, ahead of the nonce and ciphertext
+var magic = []byte("mcp-cli-sealed-v1\n")
+
+// ErrNotSealed is returned when opening data that was not sealed
+var ErrNotSealed = errors.New("not an encrypted outputs file")
+
+// ParseKey decodes a base64 256-bit key, as generated by
+// `openssl rand -base64 32`
+func ParseKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		key, err = base64.URLEncoding.DecodeString(encoded)
+	}
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("outputs encryption key must be 32 bytes encoded as base64 (generate one with: openssl rand -base64 32)")
+	}
+	return key, nil
+}
+
+// KeyFrom returns the outputs encryption key configured in settings, or nil
+// when outputs are not encrypted
+func KeyFrom(skills *config.SkillsConfig) ([]byte, error) {
+	if skills == nil || skills.OutputsEncryptionKey == "" {
+		return nil, nil
+	}
+	return ParseKey(skills.OutputsEncryptionKey)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts data
+func Seal(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append(append([]byte{}, magic...), nonce...)
+	return gcm.Seal(out, nonce, data, magic), nil
+}
+
+// IsSealed reports whether data was produced by Seal
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// Open decrypts data produced by Seal
+func Open(key, data []byte) ([]byte, error) {
+	if !IsSealed(data) {
+		return nil, ErrNotSealed
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	data = data[len(magic):]
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted file is truncated")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], magic)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt: wrong key or corrupted file")
+	}
+	return plain, nil
+}
+
+// SealFile encrypts a file to <path>.enc and removes the plain file,
+// returning the new path
+func SealFile(key []byte, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := Seal(key, data)
+	if err != nil {
+		return "", err
+	}
+	target := path + Suffix
+	if err := os.WriteFile(target, sealed, 0o600); err != nil {
+		return "", err
+	}
+	if err := os.Remove(path); err != nil {
+		os.Remove(target)
+		return "", err
+	}
+	return target, nil
+}
+
+// WriteFile writes data sealed to <path>.enc when key is set, or plain
+// otherwise. While a Hold is active the file is written plain, to be sealed
+// with the rest of the run's outputs; ReadFile reads it either way. Returns
+// the path written.
+func WriteFile(key []byte, path string, data []byte) (string, error) {
+	held.Lock()
+	holding := held.count > 0
+	held.Unlock()
+	if key == nil || holding {
+		return path, os.WriteFile(path, data, 0o644)
+	}
+
+	sealed, err := Seal(key, data)
+	if err != nil {
+		return "", err
+	}
+	target := path + Suffix
+	if err := os.WriteFile(target, sealed, 0o600); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// ReadFile reads a file, decrypting <path>.enc when only the sealed copy
+// exists
+func ReadFile(key []byte, path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil || key == nil || !os.IsNotExist(err) {
+		return data, err
+	}
+	sealed, sealedErr := os.ReadFile(path + Suffix)
+	if sealedErr != nil {
+		return nil, err
+	}
+	return Open(key, sealed)
+}
+
+// sealDir seals the regular files under dir modified since the given time,
+// except those under skip
+func sealDir(key []byte, dir string, since time.Time, skip string) (int, error) {
+	sealed := 0
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if skip != "" && path == skip {
+			return filepath.SkipDir
+		}
+		if !entry.Type().IsRegular() || strings.HasSuffix(path, Suffix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(since) {
+			return nil
+		}
+		if _, err := SealFile(key, path); err != nil {
+			return err
+		}
+		sealed++
+		return nil
+	})
+	return sealed, err
+}
+
+// held defers sealing while workflows run, so their steps can read what
+// earlier steps wrote. Sealing happens once the last of them finishes.
+var held struct {
+	sync.Mutex
+	count   int
+	pending map[string]pendingSeal // Keyed by directory
+}
+
+type pendingSeal struct {
+	key   []byte
+	since time.Time
+	skip  string
+}
+
+// Hold defers SealOutputs until the returned release is called. Holds may
+// overlap; outputs are sealed when the last is released.
+func Hold() (release func()) {
+	held.Lock()
+	held.count++
+	held.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			held.Lock()
+			held.count--
+			var pending map[string]pendingSeal
+			if held.count == 0 {
+				pending, held.pending = held.pending, nil
+			}
+			held.Unlock()
+			for dir, p := range pending {
+				seal(p.key, dir, p.since, p.skip)
+			}
+		})
+	}
+}
+
+// SealOutputs encrypts the files written to the outputs directory since the
+// given time, when outputs encryption is configured. Skill audit snapshots
+// are left as they are. Failures are logged: the files stay in plain text.
+func SealOutputs(skills *config.SkillsConfig, since time.Time) {
+	key, err := KeyFrom(skills)
+	if err != nil {
+		logging.Error("Outputs were not encrypted: %v", err)
+		return
+	}
+	if key == nil {
+		return
+	}
+	dir := filepath.Clean(skills.GetOutputsDir())
+	skip := filepath.Clean(skills.GetAuditDir())
+	since = since.Truncate(time.Second) // Some filesystems keep whole seconds
+
+	held.Lock()
+	if held.count > 0 {
+		if held.pending == nil {
+			held.pending = make(map[string]pendingSeal)
+		}
+		if p, ok := held.pending[dir]; !ok || since.Before(p.since) {
+			held.pending[dir] = pendingSeal{key: key, since: since, skip: skip}
+		}
+		held.Unlock()
+		return
+	}
+	held.Unlock()
+	seal(key, dir, since, skip)
+}
+
+func seal(key []byte, dir string, since time.Time, skip string) {
+	count, err := sealDir(key, dir, since, skip)
+	if err != nil {
+		logging.Error("Failed to encrypt outputs in %s: %v", dir, err)
+	}
+	if count > 0 {
+		logging.Debug("Encrypted %d output file(s) in %s", count, dir)
+	}
+}
@@ -0,0 +1,123 @@
+package outputcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+var testKey = bytes.Repeat([]byte{7}, 32)
+
+func TestSealAndOpen(t *testing.T) {
+	sealed, err := Seal(testKey, []byte("quarterly figures"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsSealed(sealed) || bytes.Contains(sealed, []byte("quarterly")) {
+		t.Fatalf("sealed data = %q", sealed)
+	}
+	plain, err := Open(testKey, sealed)
+	if err != nil || string(plain) != "quarterly figures" {
+		t.Errorf("Open = %q, %v", plain, err)
+	}
+
+	if _, err := Open(bytes.Repeat([]byte{8}, 32), sealed); err == nil {
+		t.Error("opened with the wrong key")
+	}
+	if _, err := Open(testKey, []byte("plain text")); err != ErrNotSealed {
+		t.Errorf("got %v for plain data, want ErrNotSealed", err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	if _, err := ParseKey(base64.StdEncoding.EncodeToString(testKey) + "\n"); err != nil {
+		t.Errorf("valid key: %v", err)
+	}
+	if _, err := ParseKey("too-short"); err == nil {
+		t.Error("expected an error for a short key")
+	}
+}
+
+func TestSealOutputs(t *testing.T) {
+	dir := t.TempDir()
+	skills := &config.SkillsConfig{
+		OutputsDir:           dir,
+		OutputsEncryptionKey: base64.StdEncoding.EncodeToString(testKey),
+		Audit:                &config.SkillAuditConfig{Enabled: true, Dir: "/outputs/audit"},
+	}
+	write := func(name string) string {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	old := write("old.txt")
+	past := time.Now().Add(-time.Hour)
+	os.Chtimes(old, past, past)
+	started := time.Now()
+
+	// Sealing waits while a workflow holds it
+	release := Hold()
+	write("report/summary.md")
+	write("audit/manifest.json")
+	SealOutputs(skills, started)
+	if _, err := os.Stat(filepath.Join(dir, "report", "summary.md")); err != nil {
+		t.Fatalf("sealed while held: %v", err)
+	}
+	release()
+
+	if _, err := os.Stat(filepath.Join(dir, "report", "summary.md")); !os.IsNotExist(err) {
+		t.Error("plain file left after sealing")
+	}
+	data, err := ReadFile(testKey, filepath.Join(dir, "report", "summary.md"))
+	if err != nil || string(data) != "report/summary.md" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+	if _, err := os.Stat(old); err != nil {
+		t.Error("a file older than the run was sealed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "audit", "manifest.json")); err != nil {
+		t.Error("the audit directory was sealed")
+	}
+
+	// Without a key nothing is sealed
+	path := write("plain.txt")
+	SealOutputs(&config.SkillsConfig{OutputsDir: dir}, started)
+	if _, err := os.Stat(path); err != nil {
+		t.Error("sealed without a key")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := WriteFile(testKey, filepath.Join(dir, "chart.png"), []byte("pixels"))
+	if err != nil || path != filepath.Join(dir, "chart.png"+Suffix) {
+		t.Fatalf("WriteFile = %s, %v; want the sealed path", path, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "chart.png")); !os.IsNotExist(err) {
+		t.Error("WriteFile left a plain copy")
+	}
+	if data, err := ReadFile(testKey, filepath.Join(dir, "chart.png")); err != nil || string(data) != "pixels" {
+		t.Errorf("ReadFile = %q, %v", data, err)
+	}
+
+	// Held files stay plain for the rest of the run
+	release := Hold()
+	path, err = WriteFile(testKey, filepath.Join(dir, "held.png"), []byte("pixels"))
+	release()
+	if err != nil || path != filepath.Join(dir, "held.png") {
+		t.Errorf("WriteFile while held = %s, %v; want the plain path", path, err)
+	}
+
+	if path, err := WriteFile(nil, filepath.Join(dir, "plain.png"), []byte("pixels")); err != nil || path != filepath.Join(dir, "plain.png") {
+		t.Errorf("WriteFile without a key = %s, %v", path, err)
+	}
+}
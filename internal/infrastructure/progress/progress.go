@@ -0,0 +1,174 @@
+// Package progress writes machine-readable progress events as
+// newline-delimited JSON (--progress-stream), so that GUIs and wrappers
+// embedding mcp-cli can render their own progress without parsing logs.
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Event types
+const (
+	WorkflowStarted   = "workflow_started"
+	StepStarted       = "step_started"
+	StepCompleted     = "step_completed"
+	ToolCall          = "tool_call"
+	TokenUsage        = "token_usage"
+	WorkflowCompleted = "workflow_completed"
+)
+
+// Event is one line of the progress stream. Fields that don't apply to an
+// event type are omitted.
+type Event struct {
+	Type     string    `json:"type"`
+	Time     time.Time `json:"time"`
+	RunID    string    `json:"run_id,omitempty"`
+	Workflow string    `json:"workflow,omitempty"`
+	Step     string    `json:"step,omitempty"`
+
+	// workflow_started: version and number of steps
+	Version string `json:"version,omitempty"`
+	Steps   int    `json:"steps,omitempty"`
+
+	// step_started: 1-based position of the step in the workflow
+	Index int `json:"index,omitempty"`
+
+	// step_completed, tool_call and workflow_completed outcome
+	Status     string `json:"status,omitempty"`
+	Error      string `json:"error,omitempty"`
+	ErrorCode  string `json:"error_code,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+
+	// tool_call
+	Tool string `json:"tool,omitempty"`
+
+	// token_usage
+	Provider         string `json:"provider,omitempty"`
+	Model            string `json:"model,omitempty"`
+	PromptTokens     int    `json:"prompt_tokens,omitempty"`
+	CompletionTokens int    `json:"completion_tokens,omitempty"`
+	TotalTokens      int    `json:"total_tokens,omitempty"`
+}
+
+// Statuses reported in events
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+var (
+	mu  sync.Mutex
+	out io.WriteCloser
+)
+
+// Open starts the progress stream. target is fd:N to write to an open file
+// descriptor inherited from the parent process (fd:1 is stdout, fd:2 is
+// stderr), or a file path, which may be a named pipe.
+func Open(target string) error {
+	var w io.WriteCloser
+	if fd, ok := strings.CutPrefix(target, "fd:"); ok {
+		n, err := strconv.Atoi(fd)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid progress stream descriptor '%s'", target)
+		}
+		file := os.NewFile(uintptr(n), "progress-stream")
+		if file == nil {
+			return fmt.Errorf("file descriptor %d is not open", n)
+		}
+		if _, err := file.Stat(); err != nil {
+			return fmt.Errorf("file descriptor %d is not open: %w", n, err)
+		}
+		w = file
+	} else {
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open progress stream: %w", err)
+		}
+		w = file
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+	return nil
+}
+
+// Enabled reports whether a progress stream is open
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return out != nil
+}
+
+// Emit writes an event to the stream, if one is open. Each event is written
+// with a single write, so lines from concurrent steps never interleave. A
+// stream that can no longer be written (the reader went away) is dropped.
+func Emit(event Event) {
+	mu.Lock()
+	defer mu.Unlock()
+	if out == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if _, err := out.Write(append(data, '\n')); err != nil {
+		logging.Warn("Progress stream closed: %v", err)
+		out = nil
+	}
+}
+
+// Close closes the stream
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if out == nil {
+		return nil
+	}
+	err := out.Close()
+	out = nil
+	return err
+}
+
+// Scope identifies where events come from. Components that don't know the
+// workflow they run in (such as the query handler) emit through the scope
+// they were given.
+type Scope struct {
+	RunID    string
+	Workflow string
+	Step     string
+}
+
+// WithStep returns the scope of a step within s
+func (s Scope) WithStep(step string) Scope {
+	s.Step = step
+	return s
+}
+
+// Emit writes event with the scope's run, workflow and step filled in
+func (s Scope) Emit(event Event) {
+	if event.RunID == "" {
+		event.RunID = s.RunID
+	}
+	if event.Workflow == "" {
+		event.Workflow = s.Workflow
+	}
+	if event.Step == "" {
+		event.Step = s.Step
+	}
+	Emit(event)
+}
@@ -0,0 +1,65 @@
+package progress
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestEmitWritesOneEventPerLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.ndjson")
+	if err := Open(path); err != nil {
+		t.Fatal(err)
+	}
+
+	scope := Scope{RunID: "run-1", Workflow: "wf"}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scope.WithStep("step").Emit(Event{Type: StepCompleted, Status: StatusSucceeded})
+		}()
+	}
+	wg.Wait()
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	Emit(Event{Type: ToolCall}) // Dropped once closed
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %d: %v: %s", lines+1, err, scanner.Text())
+		}
+		if event.RunID != "run-1" || event.Workflow != "wf" || event.Step != "step" || event.Time.IsZero() {
+			t.Errorf("event = %+v", event)
+		}
+		lines++
+	}
+	if lines != 20 {
+		t.Errorf("got %d events, want 20", lines)
+	}
+}
+
+func TestOpenDescriptor(t *testing.T) {
+	for _, target := range []string{"fd:x", "fd:-1", "fd:987"} {
+		if err := Open(target); err == nil {
+			Close()
+			t.Errorf("Open(%s) should fail", target)
+		}
+	}
+	if Enabled() {
+		t.Error("stream enabled after failed opens")
+	}
+}
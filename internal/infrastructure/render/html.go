@@ -0,0 +1,62 @@
+// Package render turns Markdown into standalone HTML and PDF reports.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+)
+
+// markdown is the shared Markdown converter (GitHub-flavoured: tables, task lists, strikethrough, autolinks).
+// Raw HTML and javascript: links are dropped, since step output can carry text from tools and web pages.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+// DefaultStylesheet is a print-friendly stylesheet used when no stylesheet is given
+const DefaultStylesheet = `body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; line-height: 1.5; color: #1f2328; max-width: 860px; margin: 2rem auto; padding: 0 1.5rem; }
+h1, h2, h3 { line-height: 1.25; margin-top: 1.5em; }
+h1 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+code { font-family: ui-monospace, Menlo, Consolas, monospace; font-size: 85%; background: #f6f8fa; padding: .2em .4em; border-radius: 4px; }
+pre { background: #f6f8fa; padding: 1rem; overflow: auto; border-radius: 6px; }
+pre code { background: none; padding: 0; }
+table { border-collapse: collapse; margin: 1rem 0; }
+th, td { border: 1px solid #d0d7de; padding: 6px 13px; }
+th { background: #f6f8fa; }
+blockquote { margin: 0; padding: 0 1em; color: #59636e; border-left: .25em solid #d0d7de; }
+@media print { body { margin: 0; max-width: none; } }`
+
+// MarkdownToHTML converts Markdown to an HTML fragment
+func MarkdownToHTML(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// HTMLDocument wraps an HTML fragment in a standalone document with embedded styles
+func HTMLDocument(title, body, stylesheet string) string {
+	if stylesheet == "" {
+		stylesheet = DefaultStylesheet
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n")
+	sb.WriteString("<meta charset=\"utf-8\">\n")
+	sb.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	sb.WriteString("<style>\n")
+	sb.WriteString(stylesheet)
+	sb.WriteString("\n</style>\n</head>\n<body>\n")
+	sb.WriteString(body)
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
@@ -0,0 +1,494 @@
+package render
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Page geometry (A4, in points)
+const (
+	pdfPageWidth   = 595.28
+	pdfPageHeight  = 841.89
+	pdfMargin      = 56.0
+	pdfContentWide = pdfPageWidth - 2*pdfMargin
+)
+
+// Standard PDF fonts (no embedding required)
+const (
+	fontRegular = "F1" // Helvetica
+	fontBold    = "F2" // Helvetica-Bold
+	fontMono    = "F3" // Courier
+	fontItalic  = "F4" // Helvetica-Oblique
+)
+
+// pdfBlockKind identifies a block-level Markdown element
+type pdfBlockKind int
+
+const (
+	blockParagraph pdfBlockKind = iota
+	blockHeading
+	blockListItem
+	blockCode
+	blockQuote
+	blockRule
+	blockTable
+)
+
+// pdfBlock is a simplified Markdown block used for PDF layout
+type pdfBlock struct {
+	kind   pdfBlockKind
+	level  int        // Heading level or list nesting depth
+	marker string     // List marker ("•" or "1.")
+	text   string     // Paragraph, heading, list item or quote text
+	lines  []string   // Code block lines
+	rows   [][]string // Table rows (first row is the header)
+}
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	listPattern       = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	rulePattern       = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	tableSepPattern   = regexp.MustCompile(`^\s*\|?\s*:?-{2,}:?\s*(\|\s*:?-{2,}:?\s*)*\|?\s*$`)
+	imagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	strongPattern     = regexp.MustCompile(`(\*\*|__)(.+?)(\*\*|__)`)
+	emphasisPattern   = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	strikePattern     = regexp.MustCompile(`~~(.+?)~~`)
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+	htmlTagPattern    = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+)
+
+// MarkdownToPDF renders Markdown to a simple, self-contained PDF document.
+// Layout covers headings, paragraphs, lists, block quotes, code blocks, tables
+// and rules using the standard PDF fonts; images and raw HTML are omitted.
+func MarkdownToPDF(source, title string) ([]byte, error) {
+	w := newPDFWriter()
+	for _, block := range parseMarkdownBlocks(source) {
+		w.renderBlock(block)
+	}
+	return w.finish(title)
+}
+
+// parseMarkdownBlocks splits Markdown into the block types the PDF layout understands
+func parseMarkdownBlocks(source string) []pdfBlock {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	var blocks []pdfBlock
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, pdfBlock{kind: blockParagraph, text: strings.Join(paragraph, " ")})
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			fence := trimmed[:3]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, strings.ReplaceAll(lines[i], "\t", "    "))
+			}
+			blocks = append(blocks, pdfBlock{kind: blockCode, lines: code})
+
+		case trimmed == "":
+			flush()
+
+		case headingPattern.MatchString(trimmed):
+			flush()
+			m := headingPattern.FindStringSubmatch(trimmed)
+			blocks = append(blocks, pdfBlock{kind: blockHeading, level: len(m[1]), text: cleanInline(m[2])})
+
+		case rulePattern.MatchString(trimmed):
+			flush()
+			blocks = append(blocks, pdfBlock{kind: blockRule})
+
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			var rows [][]string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				row := strings.TrimSpace(lines[i])
+				if tableSepPattern.MatchString(row) {
+					continue
+				}
+				row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+				var cells []string
+				for _, cell := range strings.Split(row, "|") {
+					cells = append(cells, cleanInline(strings.TrimSpace(cell)))
+				}
+				rows = append(rows, cells)
+			}
+			i--
+			blocks = append(blocks, pdfBlock{kind: blockTable, rows: rows})
+
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			if n := len(blocks); n > 0 && blocks[n-1].kind == blockQuote && i > 0 && strings.TrimSpace(lines[i-1]) != "" {
+				blocks[n-1].text += " " + cleanInline(text)
+			} else {
+				blocks = append(blocks, pdfBlock{kind: blockQuote, text: cleanInline(text)})
+			}
+
+		case listPattern.MatchString(line):
+			flush()
+			m := listPattern.FindStringSubmatch(line)
+			marker := "•"
+			if m[2][0] >= '0' && m[2][0] <= '9' {
+				marker = strings.TrimSuffix(strings.TrimSuffix(m[2], "."), ")") + "."
+			}
+			depth := len(strings.ReplaceAll(m[1], "\t", "  ")) / 2
+			blocks = append(blocks, pdfBlock{kind: blockListItem, level: depth, marker: marker, text: cleanInline(m[3])})
+
+		case len(paragraph) == 0 && len(blocks) > 0 && blocks[len(blocks)-1].kind == blockListItem && line != trimmed:
+			// Indented continuation of the previous list item
+			blocks[len(blocks)-1].text += " " + cleanInline(trimmed)
+
+		default:
+			paragraph = append(paragraph, cleanInline(trimmed))
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// cleanInline strips inline Markdown and HTML, keeping link targets readable
+func cleanInline(text string) string {
+	text = imagePattern.ReplaceAllString(text, "$1")
+	text = linkPattern.ReplaceAllString(text, "$1 ($2)")
+	text = strongPattern.ReplaceAllString(text, "$2")
+	text = emphasisPattern.ReplaceAllString(text, "$1")
+	text = strikePattern.ReplaceAllString(text, "$1")
+	text = inlineCodePattern.ReplaceAllString(text, "$1")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	return text
+}
+
+// pdfWriter lays out text onto pages
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64 // Current baseline position, measured from the bottom
+	gray  float64 // Text fill colour (0 = black)
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.newPage()
+	return w
+}
+
+func (w *pdfWriter) newPage() {
+	w.page = &bytes.Buffer{}
+	w.pages = append(w.pages, w.page)
+	w.y = pdfPageHeight - pdfMargin
+}
+
+// advance moves down by height, starting a new page when the bottom margin is reached
+func (w *pdfWriter) advance(height float64) {
+	if w.y-height < pdfMargin {
+		w.newPage()
+	}
+	w.y -= height
+}
+
+// space adds vertical whitespace without forcing a page break
+func (w *pdfWriter) space(height float64) {
+	if w.y != pdfPageHeight-pdfMargin {
+		w.y -= height
+	}
+}
+
+// drawText places a single line of text at the current baseline
+func (w *pdfWriter) drawText(font string, size, x float64, text string) {
+	fmt.Fprintf(w.page, "BT %.2f g /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", w.gray, font, size, x, w.y, escapePDFString(text))
+}
+
+// paragraph wraps and draws text starting at x
+func (w *pdfWriter) paragraph(font string, size, leading, x float64, text string) {
+	for _, line := range wrapText(text, font, size, pdfPageWidth-pdfMargin-x) {
+		w.advance(leading)
+		w.drawText(font, size, x, line)
+	}
+}
+
+func (w *pdfWriter) renderBlock(block pdfBlock) {
+	switch block.kind {
+	case blockHeading:
+		sizes := []float64{20, 16, 13.5, 12, 11, 11}
+		size := sizes[block.level-1]
+		w.space(size * 0.8)
+		w.paragraph(fontBold, size, size*1.3, pdfMargin, block.text)
+		if block.level <= 2 {
+			w.y -= 5
+			fmt.Fprintf(w.page, "0.82 G 0.6 w %.2f %.2f m %.2f %.2f l S 0 G\n", pdfMargin, w.y, pdfPageWidth-pdfMargin, w.y)
+		}
+		w.space(6)
+
+	case blockParagraph:
+		w.paragraph(fontRegular, 10.5, 14.5, pdfMargin, block.text)
+		w.space(7)
+
+	case blockListItem:
+		x := pdfMargin + 16*float64(block.level+1)
+		lines := wrapText(block.text, fontRegular, 10.5, pdfPageWidth-pdfMargin-x)
+		for i, line := range lines {
+			w.advance(14.5)
+			if i == 0 {
+				w.drawText(fontRegular, 10.5, x-textWidth(block.marker+" ", fontRegular, 10.5), block.marker)
+			}
+			w.drawText(fontRegular, 10.5, x, line)
+		}
+		w.space(2)
+
+	case blockQuote:
+		x := pdfMargin + 14
+		top, page := w.y, w.page
+		w.gray = 0.35
+		w.paragraph(fontItalic, 10.5, 14.5, x, block.text)
+		w.gray = 0
+		if w.page == page && w.y < top {
+			fmt.Fprintf(w.page, "0.82 G 2 w %.2f %.2f m %.2f %.2f l S 0 G 1 w\n", pdfMargin+4, top-2, pdfMargin+4, w.y-3)
+		}
+		w.space(7)
+
+	case blockCode:
+		const size, leading = 9.0, 11.5
+		maxChars := int(textWidthLimit(pdfContentWide-12, size))
+		for _, line := range block.lines {
+			for {
+				w.advance(leading)
+				fmt.Fprintf(w.page, "0.965 g %.2f %.2f %.2f %.2f re f 0 g\n", pdfMargin, w.y-3, pdfContentWide, leading)
+				chunk := line
+				if len([]rune(chunk)) > maxChars {
+					chunk = string([]rune(line)[:maxChars])
+				}
+				w.drawText(fontMono, size, pdfMargin+6, chunk)
+				line = string([]rune(line)[len([]rune(chunk)):])
+				if line == "" {
+					break
+				}
+			}
+		}
+		w.space(8)
+
+	case blockTable:
+		w.renderTable(block.rows)
+		w.space(8)
+
+	case blockRule:
+		w.advance(10)
+		fmt.Fprintf(w.page, "0.82 G 0.6 w %.2f %.2f m %.2f %.2f l S 0 G 1 w\n", pdfMargin, w.y+4, pdfPageWidth-pdfMargin, w.y+4)
+		w.space(6)
+	}
+}
+
+// renderTable draws rows with equal-width columns, wrapping cell text
+func (w *pdfWriter) renderTable(rows [][]string) {
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return
+	}
+
+	const size, leading, pad = 9.5, 12.5, 4.0
+	colWidth := pdfContentWide / float64(columns)
+
+	for r, row := range rows {
+		font := fontRegular
+		if r == 0 {
+			font = fontBold
+		}
+
+		cells := make([][]string, columns)
+		height := 1
+		for c := 0; c < columns; c++ {
+			if c < len(row) {
+				cells[c] = wrapText(row[c], font, size, colWidth-2*pad)
+			}
+			if len(cells[c]) > height {
+				height = len(cells[c])
+			}
+		}
+
+		for line := 0; line < height; line++ {
+			w.advance(leading)
+			for c := 0; c < columns; c++ {
+				if line < len(cells[c]) {
+					w.drawText(font, size, pdfMargin+float64(c)*colWidth+pad, cells[c][line])
+				}
+			}
+		}
+
+		w.y -= 4
+		fmt.Fprintf(w.page, "0.82 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G 1 w\n", pdfMargin, w.y, pdfPageWidth-pdfMargin, w.y)
+	}
+}
+
+// finish assembles the PDF file
+func (w *pdfWriter) finish(title string) ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	startObj := func() int {
+		offsets = append(offsets, out.Len())
+		id := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", id)
+		return id
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Fixed objects: 1 catalog, 2 pages, 3-6 fonts, 7 info; pages start at 8
+	pageIDs := make([]string, len(w.pages))
+	for i := range w.pages {
+		pageIDs[i] = fmt.Sprintf("%d 0 R", 8+i*2)
+	}
+
+	startObj()
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	startObj()
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(pageIDs, " "), len(w.pages))
+
+	for _, base := range []string{"Helvetica", "Helvetica-Bold", "Courier", "Helvetica-Oblique"} {
+		startObj()
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\nendobj\n", base)
+	}
+
+	startObj()
+	fmt.Fprintf(&out, "<< /Title (%s) /Producer (mcp-cli) /CreationDate (D:%s) >>\nendobj\n",
+		escapePDFString(title), time.Now().UTC().Format("20060102150405Z"))
+
+	for i, page := range w.pages {
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+
+		pageID := startObj()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R /F4 6 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			pdfPageWidth, pdfPageHeight, pageID+1)
+
+		startObj()
+		fmt.Fprintf(&out, "<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+		out.Write(compressed.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 7 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes(), nil
+}
+
+// wrapText breaks text into lines no wider than maxWidth
+func wrapText(text, font string, size, maxWidth float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	var lines []string
+	current := ""
+	for _, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if textWidth(candidate, font, size) <= maxWidth || current == "" {
+			current = candidate
+			continue
+		}
+		lines = append(lines, current)
+		current = word
+	}
+	return append(lines, current)
+}
+
+// textWidthLimit returns how many monospace characters fit in width
+func textWidthLimit(width, size float64) float64 {
+	return width / (0.6 * size)
+}
+
+// helveticaWidths holds Helvetica glyph widths (1/1000 em) for ASCII 32-126
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// textWidth estimates the rendered width of text in points
+func textWidth(text, font string, size float64) float64 {
+	if font == fontMono {
+		return float64(len([]rune(text))) * 600 * size / 1000
+	}
+
+	total := 0
+	for _, r := range text {
+		if r >= 32 && r <= 126 {
+			total += helveticaWidths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	width := float64(total) * size / 1000
+	if font == fontBold {
+		width *= 1.07
+	}
+	return width
+}
+
+// winAnsiReplacements maps common typographic characters to WinAnsiEncoding bytes
+var winAnsiReplacements = map[rune]byte{
+	'•': 0x95, '–': 0x96, '—': 0x97, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'…': 0x85, '€': 0x80, '™': 0x99,
+}
+
+// escapePDFString encodes text as a WinAnsi PDF string literal body
+func escapePDFString(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteByte('\\')
+			sb.WriteByte(byte(r))
+		case r >= 32 && r <= 126:
+			sb.WriteByte(byte(r))
+		case winAnsiReplacements[r] != 0:
+			sb.WriteByte(winAnsiReplacements[r])
+		case r >= 160 && r <= 255:
+			sb.WriteByte(byte(r))
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
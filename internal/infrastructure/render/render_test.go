@@ -0,0 +1,94 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdownBlocks(t *testing.T) {
+	source := "# Title\n\nSome **bold** text and a [link](https://example.com).\n\n" +
+		"- one\n  continued\n- two\n  1. nested\n\n```\ncode line\n```\n\n" +
+		"| Host | CPU |\n|------|-----|\n| web01 | 71% |\n\n---\n"
+
+	blocks := parseMarkdownBlocks(source)
+
+	kinds := []pdfBlockKind{blockHeading, blockParagraph, blockListItem, blockListItem, blockListItem, blockCode, blockTable, blockRule}
+	if len(blocks) != len(kinds) {
+		t.Fatalf("got %d blocks, want %d: %+v", len(blocks), len(kinds), blocks)
+	}
+	for i, kind := range kinds {
+		if blocks[i].kind != kind {
+			t.Errorf("block %d kind = %v, want %v", i, blocks[i].kind, kind)
+		}
+	}
+
+	if blocks[1].text != "Some bold text and a link (https://example.com)." {
+		t.Errorf("inline markdown not cleaned: %q", blocks[1].text)
+	}
+	if blocks[2].text != "one continued" {
+		t.Errorf("list continuation not joined: %q", blocks[2].text)
+	}
+	if blocks[4].marker != "1." || blocks[4].level != 1 {
+		t.Errorf("nested ordered item = %+v", blocks[4])
+	}
+	if len(blocks[6].rows) != 2 || blocks[6].rows[1][0] != "web01" {
+		t.Errorf("table rows = %v", blocks[6].rows)
+	}
+}
+
+func TestMarkdownToPDF(t *testing.T) {
+	source := strings.Repeat("## Section (1)\n\nA paragraph of text that should wrap across lines in the report.\n\n", 80)
+
+	data, err := MarkdownToPDF(source, "Report")
+	if err != nil {
+		t.Fatalf("MarkdownToPDF() error = %v", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Error("output is not a complete PDF file")
+	}
+	if pages := bytes.Count(data, []byte("/Type /Page ")); pages < 2 {
+		t.Errorf("expected long content to span pages, got %d", pages)
+	}
+}
+
+func TestEscapePDFString(t *testing.T) {
+	got := escapePDFString(`a(b)\c – “d” 日`)
+	want := "a\\(b\\)\\\\c \x96 \x93d\x94 ?"
+	if got != want {
+		t.Errorf("escapePDFString() = %q, want %q", got, want)
+	}
+}
+
+func TestExecuteTemplate(t *testing.T) {
+	data := map[string]interface{}{
+		"Vars": map[string]string{"hosts.rows": `[{"host":"web01"},{"host":"db01"}]`},
+	}
+
+	out, err := ExecuteTemplate("report", `{{range fromJSON (index .Vars "hosts.rows")}}- {{upper .host}}
+{{end}}`, data)
+	if err != nil {
+		t.Fatalf("ExecuteTemplate() error = %v", err)
+	}
+	if out != "- WEB01\n- DB01\n" {
+		t.Errorf("ExecuteTemplate() = %q", out)
+	}
+}
+
+func TestMarkdownToHTMLOmitsRawHTML(t *testing.T) {
+	source := "# Report\n\n<script>alert(1)</script>\n\nSee <img src=x onerror=alert(1)> and [this](javascript:alert(1)).\n"
+
+	out, err := MarkdownToHTML(source)
+	if err != nil {
+		t.Fatalf("MarkdownToHTML: %v", err)
+	}
+	for _, bad := range []string{"<script", "<img", "onerror", "javascript:"} {
+		if strings.Contains(out, bad) {
+			t.Errorf("output contains %q:\n%s", bad, out)
+		}
+	}
+	if !strings.Contains(out, "<h1") {
+		t.Errorf("markdown not rendered:\n%s", out)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolcontent"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 )
 
@@ -139,19 +140,14 @@ func (hsa *HostServerAdapter) GetTools() ([]domain.Tool, error) {
 		return hsa.toolsCache, nil
 	}
 
-	// Type assert to stdio client
-	stdioClient := hsa.connection.GetStdioClient()
-	if stdioClient == nil {
-		return nil, fmt.Errorf("server %s does not support stdio protocol", hsa.connection.Name)
-	}
-
-	result, err := tools.SendToolsList(stdioClient, nil)
+	// The connection caches tools/list, so fresh adapters don't re-query the server
+	serverTools, err := hsa.connection.ListTools()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tools from MCP server %s: %w", hsa.connection.Name, err)
 	}
 
 	var domainTools []domain.Tool
-	for _, tool := range result.Tools {
+	for _, tool := range serverTools {
 		formattedName := formatToolNameForOpenAI(hsa.connection.Name, tool.Name)
 
 		domainTool := domain.Tool{
@@ -200,6 +196,10 @@ func (hsa *HostServerAdapter) ExecuteTool(ctx context.Context, toolName string,
 		return "", fmt.Errorf("tool execution failed: %s", result.Error)
 	}
 
+	if text, ok := toolcontent.Render(actualToolName, result.Content); ok {
+		return text, nil
+	}
+
 	// Extract text from content blocks
 	var resultStr string
 	switch content := result.Content.(type) {
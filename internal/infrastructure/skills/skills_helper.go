@@ -1,13 +1,18 @@
 package skills
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
 	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
 )
 
@@ -33,6 +38,57 @@ func InitializeBuiltinSkills(configFile string, appConfig *config.ApplicationCon
 	skillService := skillsvc.NewService()
 	skillService.SetConfig(appConfig)
 
+	skillsDir, err := ResolveSkillsDirectory(configFile, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Debug("Skills directory: %s", skillsDir)
+
+	// Initialize with auto execution mode
+	if err := skillService.Initialize(skillsDir, skills.ExecutionModeAuto); err != nil {
+		return nil, fmt.Errorf("failed to initialize built-in skills: %w", err)
+	}
+
+	discoveredSkills := skillService.ListSkills()
+	logging.Info("Initialized %d built-in skills: %v", len(discoveredSkills), discoveredSkills)
+
+	if appConfig != nil && appConfig.Skills.RouterEnabled() {
+		if err := initializeSkillRouter(configFile, appConfig.Skills.Router, skillService); err != nil {
+			logging.Warn("Skill router disabled, offering all skills: %v", err)
+		}
+	}
+
+	return skillService, nil
+}
+
+// initializeSkillRouter embeds the skill descriptions so each request is
+// offered only its most relevant skills
+func initializeSkillRouter(configFile string, cfg *config.SkillRouterConfig, skillService *skillsvc.Service) error {
+	configService := infraConfig.NewService()
+	if _, err := configService.LoadConfig(configFile); err != nil {
+		return fmt.Errorf("failed to load config for embeddings: %w", err)
+	}
+	embeddingService := embeddings.NewService(configService, ai.NewProviderFactory())
+
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		return embeddings.EmbedText(ctx, embeddingService, cfg.Provider, cfg.Model, text)
+	}
+
+	router := skillsvc.NewRouter(embed, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if err := router.Index(ctx, skillService.AllSkills()); err != nil {
+		return err
+	}
+	skillService.SetRouter(router)
+	return nil
+}
+
+// ResolveSkillsDirectory returns the absolute skills directory from config
+// (defaults to "config/skills"), resolving relative paths against the
+// config file's location
+func ResolveSkillsDirectory(configFile string, appConfig *config.ApplicationConfig) (string, error) {
 	// Get skills directory from config (defaults to "config/skills")
 	var skillsDir string
 	if appConfig != nil && appConfig.Skills != nil {
@@ -46,7 +102,7 @@ func InitializeBuiltinSkills(configFile string, appConfig *config.ApplicationCon
 		// Get absolute path of config file first
 		absConfigFile, err := filepath.Abs(configFile)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get absolute path of config file: %w", err)
+			return "", fmt.Errorf("failed to get absolute path of config file: %w", err)
 		}
 
 		configDir := filepath.Dir(absConfigFile)
@@ -70,15 +126,5 @@ func InitializeBuiltinSkills(configFile string, appConfig *config.ApplicationCon
 		}
 	}
 
-	logging.Debug("Skills directory: %s", skillsDir)
-
-	// Initialize with auto execution mode
-	if err := skillService.Initialize(skillsDir, skills.ExecutionModeAuto); err != nil {
-		return nil, fmt.Errorf("failed to initialize built-in skills: %w", err)
-	}
-
-	discoveredSkills := skillService.ListSkills()
-	logging.Info("Initialized %d built-in skills: %v", len(discoveredSkills), discoveredSkills)
-
-	return skillService, nil
+	return skillsDir, nil
 }
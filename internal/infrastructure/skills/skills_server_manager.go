@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	domainSkills "github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/roots"
 	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
 )
 
@@ -43,7 +46,7 @@ func (sm *SkillsAwareServerManager) GetAvailableTools() ([]domain.Tool, error) {
 	}
 
 	// Generate tools from built-in skills
-	skillTools := sm.generateSkillTools()
+	skillTools := sm.generateSkillTools(sm.skillService.ListSkills())
 
 	allTools := append(externalTools, skillTools...)
 	logging.Debug("Total tools available: %d (external: %d, skills: %d)",
@@ -52,6 +55,36 @@ func (sm *SkillsAwareServerManager) GetAvailableTools() ([]domain.Tool, error) {
 	return allTools, nil
 }
 
+// SelectTools returns the tools for a request. With a skill router, only
+// the routed skills get their own tool; the generic skill tools are always
+// offered. Without one, or when routing fails, every tool is returned.
+func (sm *SkillsAwareServerManager) SelectTools(ctx context.Context, request string) ([]domain.Tool, error) {
+	router := sm.skillService.GetRouter()
+	if router == nil || strings.TrimSpace(request) == "" {
+		return sm.GetAvailableTools()
+	}
+
+	skillNames, err := router.Route(ctx, request)
+	if err != nil {
+		logging.Warn("Skill routing failed, offering all skills: %v", err)
+		return sm.GetAvailableTools()
+	}
+
+	externalTools := []domain.Tool{}
+	if sm.externalServers != nil {
+		tools, err := domain.SelectTools(ctx, sm.externalServers, request)
+		if err == nil {
+			externalTools = tools
+		}
+	}
+
+	skillTools := sm.generateSkillTools(skillNames)
+	logging.Debug("Tools selected: %d (external: %d, skills: %d)",
+		len(externalTools)+len(skillTools), len(externalTools), len(skillTools))
+
+	return append(externalTools, skillTools...), nil
+}
+
 // ExecuteTool routes tool execution to either built-in skills or external servers
 func (sm *SkillsAwareServerManager) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
 	// Check if this is a skill tool (prefixed with "skills_")
@@ -69,13 +102,13 @@ func (sm *SkillsAwareServerManager) ExecuteTool(ctx context.Context, toolName st
 	return sm.externalServers.ExecuteTool(ctx, toolName, arguments)
 }
 
-// generateSkillTools creates MCP tools from all available skills
-// This matches the logic in cmd/serve.go
-func (sm *SkillsAwareServerManager) generateSkillTools() []domain.Tool {
+// generateSkillTools creates MCP tools for the named skills plus the
+// generic skill tools. This matches the logic in cmd/serve.go
+func (sm *SkillsAwareServerManager) generateSkillTools(skillNames []string) []domain.Tool {
 	tools := []domain.Tool{}
 
 	// Generate a tool for each skill
-	for _, skillName := range sm.skillService.ListSkills() {
+	for _, skillName := range skillNames {
 		skill, exists := sm.skillService.GetSkill(skillName)
 		if !exists {
 			continue
@@ -111,7 +144,7 @@ func (sm *SkillsAwareServerManager) generateSkillTools() []domain.Tool {
 					},
 					"code": map[string]interface{}{
 						"type":        "string",
-						"description": "Code to execute (Python or Bash). Can import from 'scripts' module to use skill helper libraries.",
+						"description": "Code to execute (Python or Bash). Can import from 'scripts' module to use skill helper libraries. Write JSON to /outputs/result.json to return structured data, separate from stdout and stderr.",
 					},
 					"language": map[string]interface{}{
 						"type":        "string",
@@ -121,7 +154,7 @@ func (sm *SkillsAwareServerManager) generateSkillTools() []domain.Tool {
 					},
 					"files": map[string]interface{}{
 						"type":        "object",
-						"description": "Optional files to make available in workspace (filename -> base64 content)",
+						"description": "Optional files to make available in workspace (filename -> text or base64 content). Types and sizes are listed in /workspace/_manifest.json",
 					},
 				},
 				"required": []string{"skill_name", "code"},
@@ -163,6 +196,37 @@ func (sm *SkillsAwareServerManager) generateSkillTools() []domain.Tool {
 		},
 	}
 	tools = append(tools, runHelperScriptTool)
+
+	// Add upload_file tool for putting project files into the workspace
+	tools = append(tools, domain.Tool{
+		Type: "function",
+		Function: domain.ToolFunction{
+			Name: "skills_upload_file",
+			Description: "[WORKSPACE UPLOAD] Copy a file from the user's project into the sandbox workspace. " +
+				"Every later execute_skill_code call can read it at the returned /workspace/ path. " +
+				"Only files under the project roots can be uploaded.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path of the file on the host, absolute or relative to the working directory",
+					},
+				},
+				"required": []string{"path"},
+			},
+		},
+	})
+
+	// Reads the reference files the tools above only list; see domainSkills.ReadReferenceToolDescription
+	tools = append(tools, domain.Tool{
+		Type: "function",
+		Function: domain.ToolFunction{
+			Name:        "skills_read_skill_reference",
+			Description: domainSkills.ReadReferenceToolDescription,
+			Parameters:  domainSkills.ReadReferenceInputSchema(),
+		},
+	})
 	return tools
 }
 
@@ -181,6 +245,16 @@ func (sm *SkillsAwareServerManager) executeSkillTool(ctx context.Context, toolNa
 		return sm.runHelperScript(ctx, arguments)
 	}
 
+	// Special handling for read_skill_reference
+	if actualToolName == "read_skill_reference" {
+		return sm.readSkillReference(ctx, arguments)
+	}
+
+	// Special handling for upload_file
+	if actualToolName == "upload_file" {
+		return sm.uploadFileTool(arguments)
+	}
+
 	// Find the skill that matches this tool
 	for _, skillName := range sm.skillService.ListSkills() {
 		skill, exists := sm.skillService.GetSkill(skillName)
@@ -275,15 +349,7 @@ func (sm *SkillsAwareServerManager) executeSkillCode(ctx context.Context, argume
 		language = lang
 	}
 
-	var files map[string][]byte
-	if filesArg, ok := arguments["files"].(map[string]interface{}); ok {
-		files = make(map[string][]byte)
-		for k, v := range filesArg {
-			if vStr, ok := v.(string); ok {
-				files[k] = []byte(vStr)
-			}
-		}
-	}
+	files := domainSkills.DecodeFiles(arguments["files"])
 
 	// Create code execution request
 	request := &domainSkills.CodeExecutionRequest{
@@ -308,6 +374,72 @@ func (sm *SkillsAwareServerManager) executeSkillCode(ctx context.Context, argume
 	return string(resultJSON), nil
 }
 
+// readSkillReference handles the read_skill_reference tool
+func (sm *SkillsAwareServerManager) readSkillReference(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	result, err := sm.skillService.ReadReference(domainSkills.ReferenceReadRequestFromArguments(arguments))
+	if err != nil {
+		return "", fmt.Errorf("failed to read skill reference: %w", err)
+	}
+	return result.Text(), nil
+}
+
+// uploadFileTool handles the upload_file tool. The model may only upload
+// files under the project roots (the working directory without roots); the
+// user can upload others with /upload in chat.
+func (sm *SkillsAwareServerManager) uploadFileTool(arguments map[string]interface{}) (string, error) {
+	path, _ := arguments["path"].(string)
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if !underRoots(abs) {
+		return "", fmt.Errorf("%s is outside the project roots; ask the user to upload it with /upload", path)
+	}
+
+	file, err := sm.skillService.UploadFile(abs)
+	if err != nil {
+		return "", err
+	}
+	resultJSON, err := json.Marshal(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal upload result: %w", err)
+	}
+	return string(resultJSON), nil
+}
+
+// underRoots reports whether a path is inside one of the project roots, or
+// the working directory when there are none
+func underRoots(path string) bool {
+	var dirs []string
+	for _, root := range roots.Current() {
+		dirs = append(dirs, root.Path())
+	}
+	if len(dirs) == 0 {
+		wd, err := os.Getwd()
+		if err != nil {
+			return false
+		}
+		dirs = append(dirs, wd)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+	for _, dir := range dirs {
+		if resolvedDir, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolvedDir
+		}
+		rel, err := filepath.Rel(dir, resolved)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
 // runHelperScript handles the run_helper_script tool
 func (sm *SkillsAwareServerManager) runHelperScript(ctx context.Context, arguments map[string]interface{}) (string, error) {
 	// Extract required parameters
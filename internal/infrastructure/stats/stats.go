@@ -0,0 +1,227 @@
+// Package stats keeps opt-in usage statistics in a local file: how often
+// each command runs, provider calls with their tokens and estimated cost,
+// and failures by category. Nothing is sent anywhere. Collection is on
+// while the stats file exists; 'mcp-cli stats enable' creates it.
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileEnv overrides the stats file location
+const FileEnv = "MCP_CLI_STATS_FILE"
+
+// fileVersion is the format version written to the stats file
+const fileVersion = 1
+
+// Stats is the content of the stats file
+type Stats struct {
+	Version   int                       `json:"version"`
+	Since     time.Time                 `json:"since"`
+	Updated   time.Time                 `json:"updated,omitempty"`
+	Commands  map[string]int            `json:"commands"`
+	Providers map[string]*ProviderStats `json:"providers"` // Keyed by provider/model
+	Failures  map[string]int            `json:"failures"`  // Keyed by failure category
+}
+
+// ProviderStats counts calls to one provider and model
+type ProviderStats struct {
+	Calls            int     `json:"calls"`
+	Failures         int     `json:"failures"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"` // Estimated from the provider's configured prices
+}
+
+// New returns empty stats starting now
+func New() *Stats {
+	return &Stats{
+		Version:   fileVersion,
+		Since:     time.Now().UTC(),
+		Commands:  map[string]int{},
+		Providers: map[string]*ProviderStats{},
+		Failures:  map[string]int{},
+	}
+}
+
+// Path returns the stats file location: $MCP_CLI_STATS_FILE, or stats.json
+// in the user's config directory (~/.config/mcp-cli on Linux)
+func Path() string {
+	if path := os.Getenv(FileEnv); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mcp-cli", "stats.json")
+}
+
+// Enabled reports whether stats are being collected
+func Enabled() bool {
+	path := Path()
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Load reads the stats file
+func Load(path string) (*Stats, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := New()
+	if len(data) == 0 {
+		return s, nil
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse stats file %s: %w", path, err)
+	}
+	if s.Commands == nil {
+		s.Commands = map[string]int{}
+	}
+	if s.Providers == nil {
+		s.Providers = map[string]*ProviderStats{}
+	}
+	if s.Failures == nil {
+		s.Failures = map[string]int{}
+	}
+	return s, nil
+}
+
+// Save writes the stats file, replacing it atomically
+func (s *Stats) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create stats directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".stats-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	return nil
+}
+
+// Enable starts collecting into a new stats file, keeping an existing one
+func Enable() (string, error) {
+	path := Path()
+	if path == "" {
+		return "", fmt.Errorf("no config directory for the stats file; set %s", FileEnv)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	return path, New().Save(path)
+}
+
+// Disable stops collecting and deletes the stats file
+func Disable() (string, error) {
+	path := Path()
+	if path == "" {
+		return "", nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return path, err
+	}
+	return path, nil
+}
+
+// Reset clears the collected stats, leaving collection on
+func Reset() (string, error) {
+	path := Path()
+	if path == "" {
+		return "", fmt.Errorf("no config directory for the stats file; set %s", FileEnv)
+	}
+	return path, New().Save(path)
+}
+
+// mu serializes this process's updates to the stats file
+var mu sync.Mutex
+
+// update applies fn to the stats file if collection is enabled. Each record
+// is written straight away, so counts survive commands that exit early and
+// long-running ones like serve. Errors are ignored: stats must never break
+// a command.
+func update(fn func(*Stats)) {
+	mu.Lock()
+	defer mu.Unlock()
+	path := Path()
+	if path == "" {
+		return
+	}
+	s, err := Load(path)
+	if err != nil {
+		return // Disabled, or unreadable
+	}
+	fn(s)
+	s.Updated = time.Now().UTC()
+	s.Save(path)
+}
+
+// RecordCommand counts a run of a command, such as "query" or "rag search"
+func RecordCommand(name string) {
+	if name == "" {
+		return
+	}
+	update(func(s *Stats) {
+		s.Commands[name]++
+	})
+}
+
+// RecordProviderCall counts a call to a provider's model with its token
+// usage and estimated cost
+func RecordProviderCall(provider, model string, promptTokens, completionTokens int, cost float64, failed bool) {
+	key := provider
+	if model != "" {
+		key = provider + "/" + model
+	}
+	update(func(s *Stats) {
+		p := s.Providers[key]
+		if p == nil {
+			p = &ProviderStats{}
+			s.Providers[key] = p
+		}
+		p.Calls++
+		if failed {
+			p.Failures++
+		}
+		p.PromptTokens += promptTokens
+		p.CompletionTokens += completionTokens
+		p.CostUSD += cost
+	})
+}
+
+// RecordFailure counts a failed command by category (validation, provider,
+// tool, timeout or failure)
+func RecordFailure(category string) {
+	if category == "" {
+		return
+	}
+	update(func(s *Stats) {
+		s.Failures[category]++
+	})
+}
@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useFile points the stats file at a temp path
+func useFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	t.Setenv(FileEnv, path)
+	return path
+}
+
+func TestDisabledRecordsNothing(t *testing.T) {
+	path := useFile(t)
+
+	RecordCommand("query")
+	RecordFailure("tool")
+	if Enabled() {
+		t.Error("enabled without a stats file")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("stats file written while disabled: %v", err)
+	}
+}
+
+func TestRecordsAccumulate(t *testing.T) {
+	path := useFile(t)
+	if _, err := Enable(); err != nil {
+		t.Fatal(err)
+	}
+
+	for run := 0; run < 2; run++ {
+		RecordCommand("query")
+		RecordProviderCall("openai", "gpt-4o", 100, 20, 0.5, false)
+		RecordProviderCall("openai", "gpt-4o", 0, 0, 0, true)
+		RecordFailure("provider")
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Commands["query"] != 2 {
+		t.Errorf("query count = %d, want 2", s.Commands["query"])
+	}
+	p := s.Providers["openai/gpt-4o"]
+	if p == nil || p.Calls != 4 || p.Failures != 2 || p.PromptTokens != 200 || p.CompletionTokens != 40 || p.CostUSD != 1 {
+		t.Errorf("provider stats = %+v", p)
+	}
+	if s.Failures["provider"] != 2 {
+		t.Errorf("provider failures = %d, want 2", s.Failures["provider"])
+	}
+}
+
+func TestEnableKeepsAndResetClears(t *testing.T) {
+	path := useFile(t)
+	if _, err := Enable(); err != nil {
+		t.Fatal(err)
+	}
+	RecordCommand("chat")
+
+	if _, err := Enable(); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := Load(path); s == nil || s.Commands["chat"] != 1 {
+		t.Errorf("Enable dropped existing stats: %+v", s)
+	}
+
+	if _, err := Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := Load(path); s == nil || len(s.Commands) != 0 {
+		t.Errorf("Reset kept stats: %+v", s)
+	}
+
+	if _, err := Disable(); err != nil {
+		t.Fatal(err)
+	}
+	RecordCommand("chat")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("recorded after Disable: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// azureStorageVersion is the Blob service REST API version used for requests
+const azureStorageVersion = "2021-08-06"
+
+// azureBlobStore implements Store for Azure Blob Storage
+type azureBlobStore struct {
+	cfg        *config.StorageBackendConfig
+	httpClient *http.Client
+	accountKey []byte
+}
+
+func newAzureBlobStore(cfg *config.StorageBackendConfig, httpClient *http.Client) (Store, error) {
+	if cfg.AccountName == "" {
+		return nil, fmt.Errorf("Azure account_name is required")
+	}
+	if cfg.AccountKey == "" && cfg.SASToken == "" {
+		return nil, fmt.Errorf("Azure account_key or sas_token is required")
+	}
+
+	store := &azureBlobStore{cfg: cfg, httpClient: httpClient}
+
+	if cfg.AccountKey != "" {
+		key, err := base64.StdEncoding.DecodeString(cfg.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Azure account_key (expected base64): %w", err)
+		}
+		store.accountKey = key
+	}
+
+	return store, nil
+}
+
+// blobURL builds the request URL for a key (without SAS token)
+func (s *azureBlobStore) blobURL(key string) string {
+	endpoint := s.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", s.cfg.AccountName)
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(endpoint, "/"), s.cfg.Bucket, escapePath(ObjectKey(s.cfg, key)))
+}
+
+// newRequest creates an authenticated Blob service request
+func (s *azureBlobStore) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	requestURL := s.blobURL(key)
+	if s.accountKey == nil {
+		requestURL += "?" + strings.TrimPrefix(s.cfg.SASToken, "?")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureStorageVersion)
+
+	return req, nil
+}
+
+// Get downloads a blob
+func (s *azureBlobStore) Get(ctx context.Context, key string) (*Object, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.signRequest(req, 0)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Azure Blob request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Azure Blob response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, readError("Azure Blob get", resp, body)
+	}
+
+	return &Object{Key: key, Data: body, ContentType: resp.Header.Get("Content-Type")}, nil
+}
+
+// Put uploads a block blob
+func (s *azureBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	s.signRequest(req, len(data))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Azure Blob request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return "", readError("Azure Blob put", resp, body)
+	}
+
+	return s.blobURL(key), nil
+}
+
+// signRequest adds a Shared Key authorization header (no-op when using a SAS token)
+func (s *azureBlobStore) signRequest(req *http.Request, contentLength int) {
+	if s.accountKey == nil {
+		return
+	}
+
+	length := ""
+	if contentLength > 0 {
+		length = strconv.Itoa(contentLength)
+	}
+
+	// Canonicalized x-ms-* headers, sorted by name
+	var msHeaders []string
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, lower+":"+strings.TrimSpace(req.Header.Get(name)))
+		}
+	}
+	sort.Strings(msHeaders)
+
+	canonicalizedResource := fmt.Sprintf("/%s%s", s.cfg.AccountName, req.URL.EscapedPath())
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		"", // Content-Encoding
+		"", // Content-Language
+		length,
+		"", // Content-MD5
+		req.Header.Get("Content-Type"),
+		"", // Date (x-ms-date is used instead)
+		"", // If-Modified-Since
+		"", // If-Match
+		"", // If-None-Match
+		"", // If-Unmodified-Since
+		"", // Range
+		strings.Join(msHeaders, "\n"),
+		canonicalizedResource,
+	}, "\n")
+
+	h := hmac.New(sha256.New, s.accountKey)
+	h.Write([]byte(stringToSign))
+	signature := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", s.cfg.AccountName, signature))
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/auth"
+)
+
+const (
+	gcsAPIBase    = "https://storage.googleapis.com"
+	gcsOAuthScope = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+// gcsStore implements Store for Google Cloud Storage
+type gcsStore struct {
+	cfg        *config.StorageBackendConfig
+	httpClient *http.Client
+	tokens     *auth.GoogleTokenSource
+
+	// accessToken is a fixed token from the config, used without a service account
+	accessToken string
+	tokenExpiry time.Time
+}
+
+func newGCSStore(cfg *config.StorageBackendConfig, httpClient *http.Client) (Store, error) {
+	store := &gcsStore{cfg: cfg, httpClient: httpClient}
+
+	if cfg.AccessToken != "" {
+		store.accessToken = cfg.AccessToken
+		store.tokenExpiry = time.Now().Add(time.Hour)
+		return store, nil
+	}
+
+	if cfg.CredentialsPath == "" {
+		return nil, fmt.Errorf("GCS credentials_path or access_token is required")
+	}
+
+	account, err := auth.LoadGoogleServiceAccount(cfg.CredentialsPath)
+	if err != nil {
+		return nil, err
+	}
+	store.tokens = auth.NewGoogleTokenSource(account, gcsOAuthScope, httpClient)
+
+	return store, nil
+}
+
+// apiBase returns the JSON API base URL
+func (s *gcsStore) apiBase() string {
+	if s.cfg.Endpoint != "" {
+		return strings.TrimSuffix(s.cfg.Endpoint, "/")
+	}
+	return gcsAPIBase
+}
+
+// Get downloads an object from GCS
+func (s *gcsStore) Get(ctx context.Context, key string) (*Object, error) {
+	token, err := s.ensureAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media",
+		s.apiBase(), url.PathEscape(s.cfg.Bucket), url.PathEscape(ObjectKey(s.cfg, key)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GCS request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCS response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, readError("GCS get", resp, body)
+	}
+
+	return &Object{Key: key, Data: body, ContentType: resp.Header.Get("Content-Type")}, nil
+}
+
+// Put uploads an object to GCS using a simple media upload
+func (s *gcsStore) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	token, err := s.ensureAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	objectName := ObjectKey(s.cfg, key)
+	requestURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		s.apiBase(), url.PathEscape(s.cfg.Bucket), url.QueryEscape(objectName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("GCS request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", readError("GCS put", resp, body)
+	}
+
+	return fmt.Sprintf("gs://%s/%s", s.cfg.Bucket, objectName), nil
+}
+
+// ensureAccessToken returns the configured access token, or one exchanged
+// from the service account
+func (s *gcsStore) ensureAccessToken(ctx context.Context) (string, error) {
+	if s.tokens != nil {
+		return s.tokens.Token(ctx)
+	}
+	if time.Now().Add(5 * time.Minute).Before(s.tokenExpiry) {
+		return s.accessToken, nil
+	}
+	return "", fmt.Errorf("GCS access token expired and no service account is configured")
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// s3Store implements Store for Amazon S3 and S3-compatible services
+type s3Store struct {
+	cfg        *config.StorageBackendConfig
+	httpClient *http.Client
+	region     string
+}
+
+func newS3Store(cfg *config.StorageBackendConfig, httpClient *http.Client) (Store, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 access_key_id and secret_access_key are required")
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &s3Store{cfg: cfg, httpClient: httpClient, region: region}, nil
+}
+
+// objectURL builds the request URL for a key
+func (s *s3Store) objectURL(key string) string {
+	escaped := escapePath(ObjectKey(s.cfg, key))
+
+	if s.cfg.Endpoint != "" {
+		endpoint := strings.TrimSuffix(s.cfg.Endpoint, "/")
+		if s.cfg.PathStyle {
+			return fmt.Sprintf("%s/%s/%s", endpoint, s.cfg.Bucket, escaped)
+		}
+		scheme, host, _ := strings.Cut(endpoint, "://")
+		return fmt.Sprintf("%s://%s.%s/%s", scheme, s.cfg.Bucket, host, escaped)
+	}
+
+	if s.cfg.PathStyle {
+		return fmt.Sprintf("https://s3.%s.amazonaws.com/%s/%s", s.region, s.cfg.Bucket, escaped)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.cfg.Bucket, s.region, escaped)
+}
+
+// Get downloads an object from S3
+func (s *s3Store) Get(ctx context.Context, key string) (*Object, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	s.signRequest(req, nil)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, readError("S3 get", resp, body)
+	}
+
+	return &Object{Key: key, Data: body, ContentType: resp.Header.Get("Content-Type")}, nil
+}
+
+// Put uploads an object to S3
+func (s *s3Store) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	objectURL := s.objectURL(key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.signRequest(req, data)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("S3 request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", readError("S3 put", resp, body)
+	}
+
+	return objectURL, nil
+}
+
+// signRequest signs an S3 request with SigV4
+func (s *s3Store) signRequest(req *http.Request, payload []byte) {
+	now := time.Now().UTC()
+	dateStamp := now.Format("20060102")
+	amzDate := now.Format("20060102T150405Z")
+	payloadHash := hashSHA256(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+
+	// Canonical headers must be sorted alphabetically
+	canonicalHeadersList := []string{fmt.Sprintf("host:%s", req.URL.Host)}
+	signedHeadersList := []string{"host"}
+
+	canonicalHeadersList = append(canonicalHeadersList, fmt.Sprintf("x-amz-content-sha256:%s", payloadHash))
+	signedHeadersList = append(signedHeadersList, "x-amz-content-sha256")
+
+	canonicalHeadersList = append(canonicalHeadersList, fmt.Sprintf("x-amz-date:%s", amzDate))
+	signedHeadersList = append(signedHeadersList, "x-amz-date")
+
+	if s.cfg.SessionToken != "" {
+		canonicalHeadersList = append(canonicalHeadersList, fmt.Sprintf("x-amz-security-token:%s", s.cfg.SessionToken))
+		signedHeadersList = append(signedHeadersList, "x-amz-security-token")
+	}
+
+	signedHeaders := strings.Join(signedHeadersList, ";")
+
+	canonicalRequest := req.Method + "\n" +
+		req.URL.EscapedPath() + "\n" +
+		req.URL.RawQuery + "\n" +
+		strings.Join(canonicalHeadersList, "\n") + "\n" +
+		"\n" +
+		signedHeaders + "\n" +
+		payloadHash
+
+	credentialScope := fmt.Sprintf("%s/%s/s3/aws4_request", dateStamp, s.region)
+	stringToSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%s\n%s\n%s",
+		amzDate,
+		credentialScope,
+		hashSHA256([]byte(canonicalRequest)))
+
+	kDate := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), []byte(dateStamp))
+	kRegion := hmacSHA256(kDate, []byte(s.region))
+	kService := hmacSHA256(kRegion, []byte("s3"))
+	kSigning := hmacSHA256(kService, []byte("aws4_request"))
+	signature := hex.EncodeToString(hmacSHA256(kSigning, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, credentialScope, signedHeaders, signature))
+
+	logging.Debug("Signed S3 %s request for %s", req.Method, req.URL.Path)
+}
+
+// hashSHA256 calculates SHA256 hash
+func hashSHA256(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// hmacSHA256 calculates HMAC-SHA256
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// Object is an object retrieved from storage
+type Object struct {
+	Key         string
+	Data        []byte
+	ContentType string
+}
+
+// Store is implemented by each object storage backend
+type Store interface {
+	// Get downloads an object
+	Get(ctx context.Context, key string) (*Object, error)
+
+	// Put uploads an object and returns its URL
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+}
+
+// New creates a store for the configured backend type
+func New(cfg *config.StorageBackendConfig) (Store, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("storage backend configuration is required")
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("storage backend bucket is required")
+	}
+
+	timeout := 60 * time.Second
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	httpClient := &http.Client{Timeout: timeout}
+
+	switch cfg.Type {
+	case config.StorageS3:
+		return newS3Store(cfg, httpClient)
+	case config.StorageAzureBlob:
+		return newAzureBlobStore(cfg, httpClient)
+	case config.StorageGCS:
+		return newGCSStore(cfg, httpClient)
+	default:
+		return nil, fmt.Errorf("unsupported storage type '%s' (supported: s3, azure_blob, gcs)", cfg.Type)
+	}
+}
+
+// ObjectKey applies the backend prefix to a key
+func ObjectKey(cfg *config.StorageBackendConfig, key string) string {
+	key = strings.TrimPrefix(key, "/")
+	if cfg.Prefix == "" {
+		return key
+	}
+	return path.Join(strings.Trim(cfg.Prefix, "/"), key)
+}
+
+// DetectContentType determines a content type from the key's extension,
+// falling back to sniffing the data
+func DetectContentType(key string, data []byte) string {
+	if ext := path.Ext(key); ext != "" {
+		switch strings.ToLower(ext) {
+		case ".md", ".markdown":
+			return "text/markdown; charset=utf-8"
+		case ".yaml", ".yml":
+			return "application/yaml"
+		case ".jsonl", ".ndjson":
+			return "application/x-ndjson"
+		}
+		if contentType := mime.TypeByExtension(ext); contentType != "" {
+			return contentType
+		}
+	}
+	return http.DetectContentType(data)
+}
+
+// escapePath percent-encodes an object key, preserving '/' separators
+func escapePath(key string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(key); i++ {
+		ch := key[i]
+		// Unreserved characters per RFC 3986: A-Z a-z 0-9 - _ . ~
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' || ch == '/' {
+			encoded.WriteByte(ch)
+		} else {
+			encoded.WriteString(fmt.Sprintf("%%%02X", ch))
+		}
+	}
+	return encoded.String()
+}
+
+// readError builds an error from a failed storage response
+func readError(operation string, resp *http.Response, body []byte) error {
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > 500 {
+		msg = msg[:500] + "..."
+	}
+	return fmt.Errorf("%s failed (%s): %s", operation, resp.Status, msg)
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		key  string
+		data string
+		want string
+	}{
+		{"report.md", "# Title", "text/markdown; charset=utf-8"},
+		{"data.json", "{}", "application/json"},
+		{"notes", "plain text", "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := DetectContentType(tt.key, []byte(tt.data)); got != tt.want {
+				t.Errorf("DetectContentType(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	cfg := &config.StorageBackendConfig{Prefix: "/reports/"}
+	if got := ObjectKey(cfg, "/2024/summary.md"); got != "reports/2024/summary.md" {
+		t.Errorf("ObjectKey() = %q", got)
+	}
+
+	cfg.Prefix = ""
+	if got := ObjectKey(cfg, "summary.md"); got != "summary.md" {
+		t.Errorf("ObjectKey() = %q", got)
+	}
+}
+
+func TestS3PutAndGet(t *testing.T) {
+	objects := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = string(body)
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, body)
+		}
+	}))
+	defer server.Close()
+
+	store, err := New(&config.StorageBackendConfig{
+		Type:            config.StorageS3,
+		Bucket:          "artifacts",
+		Endpoint:        server.URL,
+		PathStyle:       true,
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	url, err := store.Put(ctx, "out/report one.txt", []byte("hello"), "text/plain")
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if !strings.HasSuffix(url, "/artifacts/out/report%20one.txt") {
+		t.Errorf("Put() url = %q", url)
+	}
+
+	obj, err := store.Get(ctx, "out/report one.txt")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(obj.Data) != "hello" {
+		t.Errorf("Get() data = %q, want %q", obj.Data, "hello")
+	}
+}
+
+func TestGCSServiceAccountPutAndGet(t *testing.T) {
+	objects := map[string]string{}
+	exchanges := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			exchanges++
+			io.WriteString(w, `{"access_token":"gcs-token","expires_in":3600}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer gcs-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Query().Get("name")] = string(body)
+		case http.MethodGet:
+			io.WriteString(w, objects[strings.TrimPrefix(r.URL.Path, "/storage/v1/b/artifacts/o/")])
+		}
+	}))
+	defer server.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	credentials, _ := json.Marshal(map[string]string{
+		"client_email": "store@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    server.URL + "/token",
+	})
+	credentialsPath := filepath.Join(t.TempDir(), "sa.json")
+	if err := os.WriteFile(credentialsPath, credentials, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := New(&config.StorageBackendConfig{
+		Type:            config.StorageGCS,
+		Bucket:          "artifacts",
+		Endpoint:        server.URL,
+		CredentialsPath: credentialsPath,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	url, err := store.Put(ctx, "report.txt", []byte("hello"), "text/plain")
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if url != "gs://artifacts/report.txt" {
+		t.Errorf("Put() url = %q", url)
+	}
+
+	obj, err := store.Get(ctx, "report.txt")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(obj.Data) != "hello" {
+		t.Errorf("Get() data = %q, want %q", obj.Data, "hello")
+	}
+	if exchanges != 1 {
+		t.Errorf("token exchanges = %d, want the token reused", exchanges)
+	}
+}
+
+func TestNewRejectsUnknownType(t *testing.T) {
+	if _, err := New(&config.StorageBackendConfig{Type: "ftp", Bucket: "b"}); err == nil {
+		t.Error("expected error for unsupported storage type")
+	}
+}
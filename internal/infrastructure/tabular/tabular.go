@@ -0,0 +1,371 @@
+// Package tabular loads CSV and XLSX files into JSON-friendly rows with
+// basic column type inference.
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported formats
+const (
+	FormatCSV  = "csv"
+	FormatTSV  = "tsv"
+	FormatXLSX = "xlsx"
+)
+
+// Inferred column types
+const (
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeDate    = "date"
+	TypeString  = "string"
+	TypeEmpty   = "empty" // Column has no values
+)
+
+// Options controls how a table is read and which rows are returned
+type Options struct {
+	Format     string // csv, tsv or xlsx (detected from the file extension when empty)
+	Sheet      string // XLSX sheet name (default: first sheet)
+	Delimiter  rune   // CSV delimiter (default: ',' for csv, '\t' for tsv)
+	NoHeader   bool   // First row is data; columns are named column_1, column_2, ...
+	InferTypes bool   // Convert values to the inferred column type
+
+	Offset int   // Skip this many data rows
+	Limit  int   // Return at most this many rows (0 = all)
+	Sample int   // Return a random sample of this many rows (0 = no sampling)
+	Seed   int64 // Random seed for sampling (0 = random)
+}
+
+// Column describes an inferred column
+type Column struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+}
+
+// Table is the result of loading a tabular file
+type Table struct {
+	Columns   []Column                 `json:"columns"`
+	Rows      []map[string]interface{} `json:"rows"`
+	RowCount  int                      `json:"row_count"`  // Rows returned
+	TotalRows int                      `json:"total_rows"` // Data rows in the file
+	Sampled   bool                     `json:"sampled,omitempty"`
+}
+
+// LoadFile reads a CSV/TSV/XLSX file into a Table
+func LoadFile(path string, opts Options) (*Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if opts.Format == "" {
+		opts.Format = DetectFormat(path)
+	}
+
+	return Load(data, opts)
+}
+
+// Load parses tabular data in the given format into a Table
+func Load(data []byte, opts Options) (*Table, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(opts.Format) {
+	case FormatCSV, "":
+		records, err = readCSV(data, opts.Delimiter, ',')
+	case FormatTSV:
+		records, err = readCSV(data, opts.Delimiter, '\t')
+	case FormatXLSX:
+		records, err = readXLSX(data, opts.Sheet)
+	default:
+		return nil, fmt.Errorf("unsupported format '%s' (supported: csv, tsv, xlsx)", opts.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTable(records, opts), nil
+}
+
+// DetectFormat determines the table format from a file extension
+func DetectFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		return FormatTSV
+	case ".xlsx", ".xlsm":
+		return FormatXLSX
+	default:
+		return FormatCSV
+	}
+}
+
+// readCSV parses delimited text, tolerating ragged rows and a UTF-8 BOM
+func readCSV(data []byte, delimiter, defaultDelimiter rune) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = defaultDelimiter
+	if delimiter != 0 {
+		reader.Comma = delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// buildTable names columns, infers types and applies row selection
+func buildTable(records [][]string, opts Options) *Table {
+	// Drop trailing blank rows (common in spreadsheet exports)
+	for len(records) > 0 && isBlankRow(records[len(records)-1]) {
+		records = records[:len(records)-1]
+	}
+
+	var header []string
+	if !opts.NoHeader && len(records) > 0 {
+		header = records[0]
+		records = records[1:]
+	}
+
+	width := len(header)
+	for _, record := range records {
+		if len(record) > width {
+			width = len(record)
+		}
+	}
+	names := columnNames(header, width)
+
+	// Infer types over every row so the schema describes the whole file
+	columns := make([]Column, width)
+	for i, name := range names {
+		columns[i] = inferColumn(name, records, i)
+	}
+
+	selected := selectRows(records, opts)
+
+	rows := make([]map[string]interface{}, 0, len(selected))
+	for _, record := range selected {
+		row := make(map[string]interface{}, width)
+		for i, col := range columns {
+			value := ""
+			if i < len(record) {
+				value = record[i]
+			}
+			row[col.Name] = convertValue(value, col.Type, opts.InferTypes)
+		}
+		rows = append(rows, row)
+	}
+
+	return &Table{
+		Columns:   columns,
+		Rows:      rows,
+		RowCount:  len(rows),
+		TotalRows: len(records),
+		Sampled:   opts.Sample > 0 && opts.Sample < len(records)-opts.Offset,
+	}
+}
+
+// columnNames produces unique, non-empty column names
+func columnNames(header []string, width int) []string {
+	names := make([]string, width)
+	seen := make(map[string]int)
+	for i := 0; i < width; i++ {
+		name := ""
+		if i < len(header) {
+			name = strings.TrimSpace(header[i])
+		}
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		if count := seen[name]; count > 0 {
+			seen[name]++
+			name = fmt.Sprintf("%s_%d", name, count+1)
+		}
+		seen[name]++
+		names[i] = name
+	}
+	return names
+}
+
+// selectRows applies offset, then either random sampling or a head limit
+func selectRows(records [][]string, opts Options) [][]string {
+	if opts.Offset > 0 {
+		if opts.Offset >= len(records) {
+			return nil
+		}
+		records = records[opts.Offset:]
+	}
+
+	if opts.Sample > 0 && opts.Sample < len(records) {
+		seed := opts.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		rng := rand.New(rand.NewSource(seed))
+
+		// Pick indices, then keep file order so samples read naturally
+		indices := rng.Perm(len(records))[:opts.Sample]
+		sort.Ints(indices)
+
+		sampled := make([][]string, len(indices))
+		for i, idx := range indices {
+			sampled[i] = records[idx]
+		}
+		records = sampled
+	}
+
+	if opts.Limit > 0 && opts.Limit < len(records) {
+		records = records[:opts.Limit]
+	}
+
+	return records
+}
+
+// inferColumn finds the narrowest type that fits every non-empty value
+func inferColumn(name string, records [][]string, index int) Column {
+	col := Column{Name: name, Type: TypeEmpty}
+
+	for _, record := range records {
+		value := ""
+		if index < len(record) {
+			value = strings.TrimSpace(record[index])
+		}
+		if value == "" {
+			col.Nullable = true
+			continue
+		}
+		col.Type = widenType(col.Type, valueType(value))
+	}
+
+	return col
+}
+
+// valueType returns the narrowest type for a single value
+func valueType(value string) string {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return TypeInteger
+	}
+	if _, ok := parseNumber(value); ok {
+		return TypeNumber
+	}
+	if _, ok := parseBool(value); ok {
+		return TypeBoolean
+	}
+	if isDate(value) {
+		return TypeDate
+	}
+	return TypeString
+}
+
+// widenType combines the type seen so far with the type of a new value
+func widenType(current, next string) string {
+	switch {
+	case current == TypeEmpty || current == next:
+		return next
+	case (current == TypeInteger && next == TypeNumber) || (current == TypeNumber && next == TypeInteger):
+		return TypeNumber
+	default:
+		return TypeString
+	}
+}
+
+// convertValue converts a raw cell to its column type
+func convertValue(value, columnType string, infer bool) interface{} {
+	trimmed := strings.TrimSpace(value)
+	if !infer {
+		return value
+	}
+	if trimmed == "" {
+		return nil
+	}
+
+	switch columnType {
+	case TypeInteger:
+		if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return n
+		}
+	case TypeNumber:
+		if f, ok := parseNumber(trimmed); ok {
+			return f
+		}
+	case TypeBoolean:
+		if b, ok := parseBool(trimmed); ok {
+			return b
+		}
+	}
+	return value
+}
+
+// parseNumber parses a finite number. ParseFloat also accepts "NaN" and
+// "Inf", which JSON can't encode, so those stay strings.
+func parseNumber(value string) (float64, bool) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// parseBool accepts common spreadsheet boolean spellings
+func parseBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "yes":
+		return true, true
+	case "false", "no":
+		return false, true
+	}
+	return false, false
+}
+
+// dateLayouts are the date formats recognised during inference
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006/01/02",
+}
+
+// isDate reports whether a value parses as one of the known date layouts
+func isDate(value string) bool {
+	for _, layout := range dateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlankRow reports whether every cell in a row is empty
+func isBlankRow(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
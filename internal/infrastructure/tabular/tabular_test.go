@@ -0,0 +1,134 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestLoadCSVInfersTypes(t *testing.T) {
+	data := []byte("id,name,score,active,joined,notes\n" +
+		"1,Alice,9.5,yes,2024-01-02,\n" +
+		"2,Bob,7,no,2024-02-03,late\n" +
+		"3,Carol,,true,2024-03-04,\n")
+
+	table, err := Load(data, Options{Format: FormatCSV, InferTypes: true})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := map[string]Column{
+		"id":     {Name: "id", Type: TypeInteger},
+		"name":   {Name: "name", Type: TypeString},
+		"score":  {Name: "score", Type: TypeNumber, Nullable: true},
+		"active": {Name: "active", Type: TypeBoolean},
+		"joined": {Name: "joined", Type: TypeDate},
+		"notes":  {Name: "notes", Type: TypeString, Nullable: true},
+	}
+	for _, col := range table.Columns {
+		if col != want[col.Name] {
+			t.Errorf("column %s = %+v, want %+v", col.Name, col, want[col.Name])
+		}
+	}
+
+	if table.TotalRows != 3 || table.RowCount != 3 {
+		t.Fatalf("row counts = %d/%d, want 3/3", table.RowCount, table.TotalRows)
+	}
+	first := table.Rows[0]
+	if first["id"] != int64(1) || first["score"] != 9.5 || first["active"] != true || first["notes"] != nil {
+		t.Errorf("unexpected converted row: %v", first)
+	}
+}
+
+func TestLoadNonFiniteNumbers(t *testing.T) {
+	data := []byte("ratio,score\n1.5,NaN\nInf,2\n-Infinity,3.5\n")
+
+	table, err := Load(data, Options{Format: FormatCSV, InferTypes: true})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, col := range table.Columns {
+		if col.Type != TypeString {
+			t.Errorf("column %s = %s, want string since it holds non-finite values", col.Name, col.Type)
+		}
+	}
+	if table.Rows[0]["score"] != "NaN" || table.Rows[1]["ratio"] != "Inf" {
+		t.Errorf("non-finite values should stay strings: %v", table.Rows)
+	}
+	if _, err := json.MarshalIndent(table, "", "  "); err != nil {
+		t.Errorf("table with non-finite values should encode as JSON: %v", err)
+	}
+}
+
+func TestLoadRowSelection(t *testing.T) {
+	data := []byte("n\n1\n2\n3\n4\n5\n6\n")
+
+	table, err := Load(data, Options{Offset: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if table.RowCount != 2 || table.TotalRows != 6 || table.Rows[0]["n"] != "2" {
+		t.Errorf("offset/limit gave %+v", table)
+	}
+
+	a, _ := Load(data, Options{Sample: 3, Seed: 42})
+	b, _ := Load(data, Options{Sample: 3, Seed: 42})
+	if a.RowCount != 3 || !a.Sampled {
+		t.Fatalf("sample gave %d rows (sampled=%v), want 3", a.RowCount, a.Sampled)
+	}
+	for i := range a.Rows {
+		if a.Rows[i]["n"] != b.Rows[i]["n"] {
+			t.Errorf("same seed produced different samples")
+		}
+	}
+}
+
+func TestLoadNoHeaderAndDuplicateNames(t *testing.T) {
+	table, err := Load([]byte("a,a,\n1,2,3\n"), Options{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	names := []string{table.Columns[0].Name, table.Columns[1].Name, table.Columns[2].Name}
+	if names[0] != "a" || names[1] != "a_2" || names[2] != "column_3" {
+		t.Errorf("column names = %v", names)
+	}
+
+	table, _ = Load([]byte("x\ty\n"), Options{Format: FormatTSV, NoHeader: true})
+	if table.Columns[1].Name != "column_2" || table.Rows[0]["column_2"] != "y" {
+		t.Errorf("no-header TSV gave %+v", table)
+	}
+}
+
+func TestLoadXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Summary" sheetId="1" r:id="rId1"/><sheet name="Data" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":     `<sst><si><t>host</t></si><si><t>count</t></si><si><r><t>web</t></r><r><t>01</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>ignored</t></is></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>5</v></c></row></sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+
+	table, err := Load(buf.Bytes(), Options{Format: FormatXLSX, Sheet: "data", InferTypes: true})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(table.Columns) != 3 || table.Rows[0]["host"] != "web01" || table.Rows[0]["column_3"] != int64(5) || table.Rows[0]["count"] != nil {
+		t.Errorf("unexpected XLSX table: %+v", table)
+	}
+
+	if _, err := Load(buf.Bytes(), Options{Format: FormatXLSX, Sheet: "Missing"}); err == nil {
+		t.Error("expected error for missing sheet")
+	}
+}
@@ -44,8 +44,8 @@ func NewManager(defaultTTL, maxTTL time.Duration, pollInterval int64) *Manager {
 	return m
 }
 
-// CreateTask creates a new task
-func (m *Manager) CreateTask(requestMethod string, requestParams interface{}, requestedTTL int64) (*domain.Task, error) {
+// CreateTask creates a new task belonging to owner
+func (m *Manager) CreateTask(requestMethod string, requestParams interface{}, requestedTTL int64, owner domain.TaskOwner) (*domain.Task, error) {
 	taskID, err := generateTaskID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate task ID: %w", err)
@@ -71,6 +71,7 @@ func (m *Manager) CreateTask(requestMethod string, requestParams interface{}, re
 		LastUpdatedAt: now,
 		ExpiresAt:     now.Add(ttl),
 		RequestMethod: requestMethod,
+		Owner:         owner,
 		Cancel:        make(chan struct{}),
 		Done:          make(chan struct{}),
 		ResultChan:    make(chan domain.TaskResult, 1),
@@ -166,15 +167,17 @@ func (m *Manager) CancelTask(taskID string) error {
 	return nil
 }
 
-// ListTasks returns a paginated list of tasks
-func (m *Manager) ListTasks(cursor string, limit int) ([]domain.TaskMetadata, string, error) {
+// ListTasks returns a paginated list of owner's tasks
+func (m *Manager) ListTasks(cursor string, limit int, owner domain.TaskOwner) ([]domain.TaskMetadata, string, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// Collect all tasks
+	// Collect the owner's tasks
 	var allTasks []*domain.Task
 	for _, task := range m.tasks {
-		allTasks = append(allTasks, task)
+		if task.Owner == owner {
+			allTasks = append(allTasks, task)
+		}
 	}
 
 	// Sort by creation time (newest first)
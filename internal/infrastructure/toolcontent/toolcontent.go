@@ -0,0 +1,303 @@
+// Package toolcontent renders the content blocks of MCP tool results as
+// text. Images, audio and binary resources are saved to the outputs
+// directory (encrypted when outputs encryption is configured) and replaced
+// by a placeholder naming the file, which providers that accept images turn
+// back into image content.
+package toolcontent
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register decoders for image dimensions
+	_ "image/jpeg" // in placeholders
+	_ "image/png"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/outputcrypt"
+)
+
+const (
+	// subdirectory of the outputs directory that holds saved tool content
+	resultsSubdir = "tool-results"
+
+	// MaxImageBytes is the largest saved image passed to a model
+	MaxImageBytes = 5 << 20
+)
+
+var (
+	dirMu     sync.RWMutex
+	outputDir = filepath.Join(os.TempDir(), "mcp-outputs")
+	outputKey []byte // Outputs encryption key, nil when files are saved plain
+
+	// sequence keeps file names unique within a run
+	sequence atomic.Int64
+)
+
+// SetOutputDir sets the outputs directory (skills.outputs_dir). Tool
+// content is saved in its tool-results subdirectory.
+func SetOutputDir(dir string) {
+	if dir == "" {
+		return
+	}
+	dirMu.Lock()
+	defer dirMu.Unlock()
+	outputDir = dir
+}
+
+// Configure sets the outputs directory and encryption key from the skills
+// settings. An invalid key is logged and content is saved unencrypted, as
+// outputcrypt.SealOutputs does.
+func Configure(skills *config.SkillsConfig) {
+	SetOutputDir(skills.GetOutputsDir())
+
+	key, err := outputcrypt.KeyFrom(skills)
+	if err != nil {
+		logging.Error("Tool content will not be encrypted: %v", err)
+	}
+	dirMu.Lock()
+	defer dirMu.Unlock()
+	outputKey = key
+}
+
+// ResultsDir returns the directory tool content is saved in
+func ResultsDir() string {
+	dirMu.RLock()
+	defer dirMu.RUnlock()
+	return filepath.Join(outputDir, resultsSubdir)
+}
+
+// Render converts the content of a tool result to text, saving images, audio
+// and resources to files described in the text. It only handles content
+// block lists holding something other than text; for anything else it
+// returns false and callers keep their usual formatting. Every tool-calling
+// path (host, skills adapter, chat) calls it before its own formatting.
+func Render(toolName string, content interface{}) (string, bool) {
+	blocks, ok := content.([]interface{})
+	if !ok || !hasMedia(blocks) {
+		return "", false
+	}
+
+	var parts []string
+	for _, item := range blocks {
+		block, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if text := renderBlock(toolName, block); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n"), true
+}
+
+func hasMedia(blocks []interface{}) bool {
+	for _, item := range blocks {
+		if block, ok := item.(map[string]interface{}); ok {
+			switch block["type"] {
+			case "image", "audio", "resource", "resource_link":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func renderBlock(toolName string, block map[string]interface{}) string {
+	str := func(m map[string]interface{}, key string) string {
+		s, _ := m[key].(string)
+		return s
+	}
+
+	switch str(block, "type") {
+	case "text":
+		return str(block, "text")
+
+	case "image", "audio":
+		return saveBlob(toolName, "", str(block, "mimeType"), str(block, "data"))
+
+	case "resource":
+		resource, _ := block["resource"].(map[string]interface{})
+		uri, mimeType := str(resource, "uri"), str(resource, "mimeType")
+		if text, ok := resource["text"].(string); ok {
+			header := "Resource " + uri
+			if mimeType != "" {
+				header += " (" + mimeType + ")"
+			}
+			return header + ":\n" + text
+		}
+		return saveBlob(toolName, uri, mimeType, str(resource, "blob"))
+
+	case "resource_link":
+		link := "[Resource link: " + str(block, "uri")
+		if name := str(block, "name"); name != "" {
+			link = "[Resource link: " + name + " <" + str(block, "uri") + ">"
+		}
+		if description := str(block, "description"); description != "" {
+			link += " - " + description
+		}
+		return link + "]"
+	}
+
+	data, err := json.Marshal(block)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// saveBlob writes base64 content to the results directory and describes it
+func saveBlob(toolName, uri, mimeType, encoded string) string {
+	label := kindOf(mimeType)
+	if uri != "" {
+		label = "Resource " + uri
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		logging.Warn("Tool %s returned %s content that is not valid base64: %v", toolName, mimeType, err)
+		return fmt.Sprintf("[%s (%s) could not be decoded]", label, mimeType)
+	}
+
+	dir := ResultsDir()
+	var path string
+	err = os.MkdirAll(dir, 0755)
+	if err == nil {
+		path, err = outputcrypt.WriteFile(encryptionKey(), filepath.Join(dir, fileName(toolName, uri, mimeType)), data)
+	}
+	if err != nil {
+		logging.Warn("Failed to save %s content from tool %s: %v", mimeType, toolName, err)
+		return fmt.Sprintf("[%s (%s, %s) could not be saved: %v]", label, mimeType, formatSize(len(data)), err)
+	}
+
+	details := []string{mimeType}
+	if strings.HasPrefix(mimeType, "image/") {
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+			details = append(details, fmt.Sprintf("%dx%d", cfg.Width, cfg.Height))
+		}
+	}
+	details = append(details, formatSize(len(data)))
+	logging.Info("Saved %s from tool %s to %s", strings.ToLower(kindOf(mimeType)), toolName, path)
+	return fmt.Sprintf("[%s (%s) saved to %s]", label, strings.Join(details, ", "), path)
+}
+
+// encryptionKey returns the key saved content is sealed with, or nil
+func encryptionKey() []byte {
+	dirMu.RLock()
+	defer dirMu.RUnlock()
+	return outputKey
+}
+
+// kindOf names content for placeholders: Image, Audio or File
+func kindOf(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return "Image"
+	case strings.HasPrefix(mimeType, "audio/"):
+		return "Audio"
+	}
+	return "File"
+}
+
+// commonExtensions avoids the platform-dependent choices of mime.ExtensionsByType
+var commonExtensions = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/svg+xml":   ".svg",
+	"audio/wav":       ".wav",
+	"audio/mpeg":      ".mp3",
+	"audio/ogg":       ".ogg",
+	"application/pdf": ".pdf",
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func fileName(toolName, uri, mimeType string) string {
+	ext := commonExtensions[mimeType]
+	if ext == "" {
+		if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
+			ext = exts[0]
+		} else {
+			ext = ".bin"
+		}
+	}
+	base := toolName
+	if uri != "" {
+		if name := strings.TrimSuffix(filepath.Base(uri), filepath.Ext(uri)); name != "" && name != "." && name != "/" {
+			base += "-" + name
+		}
+	}
+	base = strings.Trim(unsafeNameChars.ReplaceAllString(base, "_"), "_")
+	if base == "" {
+		base = "tool"
+	}
+	return fmt.Sprintf("%s-%s-%d%s", base, time.Now().Format("20060102-150405"), sequence.Add(1), ext)
+}
+
+func formatSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
+
+// savedImage matches the placeholders written for saved images
+var savedImage = regexp.MustCompile(`\[(?:Image|Resource [^\]\n]*) \((image/[\w.+-]+)[^\]\n]*\) saved to ([^\]\n]+)\]`)
+
+// Images returns the images whose placeholders appear in a tool result.
+// Only files in the results directory are read, so a tool can't make
+// mcp-cli send arbitrary files to the model by printing a placeholder.
+func Images(text string) []domain.Image {
+	matches := savedImage.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	dir, err := filepath.EvalSymlinks(ResultsDir())
+	if err != nil {
+		return nil
+	}
+	var images []domain.Image
+	for _, match := range matches {
+		mimeType, path := match[1], match[2]
+		if mimeType == "image/svg+xml" {
+			continue // Models only accept raster images
+		}
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil || filepath.Dir(resolved) != dir {
+			logging.Debug("Not attaching image outside %s: %s", dir, path)
+			continue
+		}
+		data, err := os.ReadFile(resolved)
+		if err == nil && outputcrypt.IsSealed(data) {
+			data, err = outputcrypt.Open(encryptionKey(), data)
+		}
+		if err != nil || len(data) > MaxImageBytes {
+			logging.Debug("Not attaching image %s: %d bytes, %v", path, len(data), err)
+			continue
+		}
+		images = append(images, domain.Image{
+			MimeType: mimeType,
+			Data:     base64.StdEncoding.EncodeToString(data),
+			Path:     path,
+		})
+	}
+	return images
+}
@@ -0,0 +1,125 @@
+package toolcontent
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/outputcrypt"
+)
+
+func pngData(t *testing.T) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestRender(t *testing.T) {
+	SetOutputDir(t.TempDir())
+	defer SetOutputDir(filepath.Join(os.TempDir(), "mcp-outputs"))
+
+	if _, ok := Render("echo", []interface{}{map[string]interface{}{"type": "text", "text": "hi"}}); ok {
+		t.Error("text-only results should keep the caller's formatting")
+	}
+	if _, ok := Render("echo", "plain"); ok {
+		t.Error("string results should keep the caller's formatting")
+	}
+
+	text, ok := Render("screenshot", []interface{}{
+		map[string]interface{}{"type": "text", "text": "Captured the page"},
+		map[string]interface{}{"type": "image", "mimeType": "image/png", "data": pngData(t)},
+		map[string]interface{}{"type": "resource", "resource": map[string]interface{}{
+			"uri": "file:///notes.md", "mimeType": "text/markdown", "text": "# Notes",
+		}},
+		map[string]interface{}{"type": "resource", "resource": map[string]interface{}{
+			"uri": "file:///report.pdf", "mimeType": "application/pdf", "blob": base64.StdEncoding.EncodeToString([]byte("%PDF")),
+		}},
+		map[string]interface{}{"type": "resource_link", "uri": "https://example.com/a", "name": "a"},
+	})
+	if !ok {
+		t.Fatal("Render should handle media content")
+	}
+
+	for _, want := range []string{
+		"Captured the page\n[Image (image/png, 4x3, ",
+		"saved to " + ResultsDir(),
+		"Resource file:///notes.md (text/markdown):\n# Notes",
+		"[Resource file:///report.pdf (application/pdf, 4 bytes) saved to ",
+		"-report-",
+		"[Resource link: a <https://example.com/a>]",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+
+	images := Images(text)
+	if len(images) != 1 || images[0].MimeType != "image/png" || images[0].Data != pngData(t) {
+		t.Fatalf("Images() = %d images, want the PNG", len(images))
+	}
+	if !strings.HasPrefix(images[0].Path, ResultsDir()) {
+		t.Errorf("image path %s is not in %s", images[0].Path, ResultsDir())
+	}
+}
+
+func TestImagesOnlyReadsSavedFiles(t *testing.T) {
+	SetOutputDir(t.TempDir())
+	defer SetOutputDir(filepath.Join(os.TempDir(), "mcp-outputs"))
+	os.MkdirAll(ResultsDir(), 0755)
+
+	outside := filepath.Join(t.TempDir(), "secret.png")
+	os.WriteFile(outside, []byte("secret"), 0644)
+	if images := Images("[Image (image/png, 6 bytes) saved to " + outside + "]"); len(images) != 0 {
+		t.Errorf("read a file outside the results directory: %+v", images)
+	}
+
+	link := filepath.Join(ResultsDir(), "link.png")
+	if err := os.Symlink(outside, link); err == nil {
+		if images := Images("[Image (image/png, 6 bytes) saved to " + link + "]"); len(images) != 0 {
+			t.Errorf("followed a symlink out of the results directory: %+v", images)
+		}
+	}
+}
+
+func TestRenderEncryptsSavedContent(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	Configure(&config.SkillsConfig{
+		OutputsDir:           t.TempDir(),
+		OutputsEncryptionKey: base64.StdEncoding.EncodeToString(key),
+	})
+	defer Configure(&config.SkillsConfig{OutputsDir: filepath.Join(os.TempDir(), "mcp-outputs")})
+
+	text, ok := Render("screenshot", []interface{}{
+		map[string]interface{}{"type": "image", "mimeType": "image/png", "data": pngData(t)},
+	})
+	if !ok {
+		t.Fatal("Render should handle media content")
+	}
+	if !strings.HasSuffix(text, ".png"+outputcrypt.Suffix+"]") {
+		t.Fatalf("placeholder %q should name the encrypted file", text)
+	}
+
+	entries, _ := os.ReadDir(ResultsDir())
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), outputcrypt.Suffix) {
+		t.Fatalf("results directory holds %v, want only the encrypted file", entries)
+	}
+	data, _ := os.ReadFile(filepath.Join(ResultsDir(), entries[0].Name()))
+	if !outputcrypt.IsSealed(data) {
+		t.Error("saved image is not encrypted")
+	}
+
+	// Models still get the image
+	images := Images(text)
+	if len(images) != 1 || images[0].Data != pngData(t) {
+		t.Errorf("Images() = %d images, want the decrypted PNG", len(images))
+	}
+}
@@ -0,0 +1,130 @@
+package toolselect
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/vector"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// rankedTool is a tool's position in the candidate list and its relevance
+type rankedTool struct {
+	index int
+	score float64
+}
+
+// rank scores every tool against text, best first. Keyword matches count
+// alone, or equally with embedding similarity when embeddings are on.
+func (sm *ServerManager) rank(ctx context.Context, text string, tools []domain.Tool) []rankedTool {
+	queryTerms := terms(text)
+	vectors, query := sm.embedTools(ctx, text, tools)
+
+	ranked := make([]rankedTool, len(tools))
+	for i, tool := range tools {
+		score := keywordScore(queryTerms, tool)
+		if query != nil {
+			score = (score + vector.CosineSimilarity(query, vectors[i])) / 2
+		}
+		ranked[i] = rankedTool{index: i, score: score}
+	}
+	// Best first, keeping the original order for ties
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	return ranked
+}
+
+// embedTools returns the embedding of each tool and of text, or nils when
+// embeddings are off or fail. Tool embeddings are cached.
+func (sm *ServerManager) embedTools(ctx context.Context, text string, tools []domain.Tool) ([][]float32, []float32) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.embed == nil || sm.embedFailed {
+		return nil, nil
+	}
+
+	fail := func(err error) ([][]float32, []float32) {
+		logging.Warn("Tool selection will rank by keywords only: %v", err)
+		sm.embedFailed = true
+		return nil, nil
+	}
+
+	vectors := make([][]float32, len(tools))
+	for i, tool := range tools {
+		key := tool.Function.Name + ": " + tool.Function.Description
+		vector, ok := sm.vectors[key]
+		if !ok {
+			var err error
+			if vector, err = sm.embed(ctx, key); err != nil {
+				return fail(err)
+			}
+			sm.vectors[key] = vector
+		}
+		vectors[i] = vector
+	}
+
+	query, err := sm.embed(ctx, text)
+	if err != nil {
+		return fail(err)
+	}
+	return vectors, query
+}
+
+// keywordScore is the share of query terms found in the tool, in [0, 1].
+// Terms in the tool name count twice as much as terms in its description.
+func keywordScore(queryTerms []string, tool domain.Tool) float64 {
+	if len(queryTerms) == 0 {
+		return 0
+	}
+	nameTerms := termSet(tool.Function.Name)
+	descriptionTerms := termSet(tool.Function.Description)
+
+	var score float64
+	for _, term := range queryTerms {
+		if nameTerms[term] {
+			score += 2
+		} else if descriptionTerms[term] {
+			score++
+		}
+	}
+	return score / float64(2*len(queryTerms))
+}
+
+// stopWords are left out of keyword matching
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"this": true, "that": true, "what": true, "how": true, "can": true, "you": true,
+	"please": true, "use": true, "tool": true, "tools": true, "get": true, "are": true,
+}
+
+// terms splits text into distinct lowercase words of three or more
+// characters, without stop words or a plural "s"
+func terms(text string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) < 3 || stopWords[word] {
+			continue
+		}
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		if !seen[word] {
+			seen[word] = true
+			out = append(out, word)
+		}
+	}
+	return out
+}
+
+func termSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, term := range terms(text) {
+		set[term] = true
+	}
+	return set
+}
@@ -0,0 +1,287 @@
+// Package toolselect limits the tools sent to the LLM with each request to
+// the ones most relevant to it, for providers that reject long tool lists.
+// A search_tools tool lets the model find the tools that were left out.
+package toolselect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
+)
+
+// SearchToolsName is the escape hatch tool offered when tools are left out
+const SearchToolsName = "search_tools"
+
+// Results search_tools returns by default and at most
+const (
+	defaultSearchResults = 5
+	maxSearchResults     = 10
+)
+
+// EmbedFunc returns the embedding vector for a text
+type EmbedFunc func(ctx context.Context, text string) ([]float32, error)
+
+// ServerManager sends only the top-ranked tools of another server manager
+// with each request
+type ServerManager struct {
+	externalServers domain.MCPServerManager
+	maxTools        int
+	always          []string
+	embed           EmbedFunc // nil ranks by keywords only
+
+	mu          sync.Mutex
+	vectors     map[string][]float32 // Tool text -> embedding
+	embedFailed bool
+	found       []string // Tools search_tools surfaced, most recent first
+	shadowed    bool     // An external server offers its own search_tools
+}
+
+// NewServerManager wraps external, sending at most cfg.MaxTools tools per
+// request. embed may be nil to rank by keywords alone.
+func NewServerManager(external domain.MCPServerManager, cfg *config.ToolSelectionConfig, embed EmbedFunc) *ServerManager {
+	return &ServerManager{
+		externalServers: external,
+		maxTools:        cfg.MaxTools,
+		always:          cfg.Always,
+		embed:           embed,
+		vectors:         make(map[string][]float32),
+	}
+}
+
+// Wrap limits the tools sent per request when settings set max_tools.
+// Otherwise, or when external is nil, external is returned unchanged.
+func Wrap(external domain.MCPServerManager, configFile string, cfg *config.ToolSelectionConfig) domain.MCPServerManager {
+	if external == nil || !cfg.IsEnabled() {
+		return external
+	}
+
+	var embed EmbedFunc
+	if cfg.Embeddings {
+		configService := infraConfig.NewService()
+		if _, err := configService.LoadConfig(configFile); err != nil {
+			logging.Warn("Tool selection will rank by keywords only: failed to load config for embeddings: %v", err)
+		} else {
+			embeddingService := embeddings.NewService(configService, ai.NewProviderFactory())
+			embed = func(ctx context.Context, text string) ([]float32, error) {
+				return embeddings.EmbedText(ctx, embeddingService, cfg.Provider, cfg.Model, text)
+			}
+		}
+	}
+
+	logging.Debug("Limiting tools to %d per request", cfg.MaxTools)
+	return NewServerManager(external, cfg, embed)
+}
+
+// GetAvailableTools returns every tool, unranked
+func (sm *ServerManager) GetAvailableTools() ([]domain.Tool, error) {
+	return sm.externalServers.GetAvailableTools()
+}
+
+// SelectTools returns the tools to send for a request. When there are more
+// than max_tools, the always-on tools and the ones search_tools found come
+// first, then the best matches for the request, then search_tools itself.
+func (sm *ServerManager) SelectTools(ctx context.Context, request string) ([]domain.Tool, error) {
+	tools, err := domain.SelectTools(ctx, sm.externalServers, request)
+	if err != nil {
+		return nil, err
+	}
+
+	sm.mu.Lock()
+	sm.shadowed = findTool(tools, SearchToolsName) >= 0
+	found := append([]string{}, sm.found...)
+	shadowed := sm.shadowed
+	sm.mu.Unlock()
+
+	if len(tools) <= sm.maxTools || shadowed {
+		if shadowed {
+			logging.Warn("An external server offers %s; sending all %d tools", SearchToolsName, len(tools))
+		}
+		return tools, nil
+	}
+
+	limit := sm.maxTools - 1 // Leave room for search_tools
+	selected := make([]domain.Tool, 0, sm.maxTools)
+	taken := make(map[string]bool)
+	take := func(from []domain.Tool, name string) bool {
+		if len(selected) == limit || taken[name] {
+			return true
+		}
+		i := findTool(from, name)
+		if i < 0 {
+			return false
+		}
+		selected = append(selected, from[i])
+		taken[name] = true
+		return true
+	}
+
+	for _, name := range sm.always {
+		take(tools, name)
+	}
+	var all []domain.Tool
+	for _, name := range found {
+		if !take(tools, name) {
+			// Found tools may be ones an inner selection, such as the
+			// skill router, left out
+			if all == nil {
+				all, _ = sm.externalServers.GetAvailableTools()
+			}
+			take(all, name)
+		}
+	}
+	for _, r := range sm.rank(ctx, request, tools) {
+		take(tools, tools[r.index].Function.Name)
+	}
+
+	selected = append(selected, searchTool(len(tools)))
+	logging.Info("Sending %d of %d tools for this request (%s finds the rest)", len(selected)-1, len(tools), SearchToolsName)
+	return selected, nil
+}
+
+// ExecuteTool runs search_tools, or delegates to the external servers
+func (sm *ServerManager) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	sm.mu.Lock()
+	shadowed := sm.shadowed
+	sm.mu.Unlock()
+
+	if toolName == SearchToolsName && !shadowed {
+		return sm.searchTools(ctx, arguments)
+	}
+	return sm.externalServers.ExecuteTool(ctx, toolName, arguments)
+}
+
+// searchTools ranks every tool against the query and makes the matches
+// available from the model's next turn
+func (sm *ServerManager) searchTools(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	query, _ := arguments["query"].(string)
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("query is required")
+	}
+	limit := defaultSearchResults
+	if n, ok := arguments["limit"].(float64); ok && n > 0 {
+		limit = int(n)
+	}
+	if limit > maxSearchResults {
+		limit = maxSearchResults
+	}
+
+	// Search every tool, including any an inner selection (such as the
+	// skill router) left out
+	tools, err := sm.externalServers.GetAvailableTools()
+	if err != nil {
+		return "", fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	var matches []domain.Tool
+	for _, r := range sm.rank(ctx, query, tools) {
+		if len(matches) == limit || r.score <= 0 {
+			break
+		}
+		matches = append(matches, tools[r.index])
+	}
+	if len(matches) == 0 {
+		return fmt.Sprintf("No tools match %q. Try other words for what you want to do.", query), nil
+	}
+
+	sm.mu.Lock()
+	for i := len(matches) - 1; i >= 0; i-- {
+		sm.found = prepend(sm.found, matches[i].Function.Name)
+	}
+	if keep := sm.maxTools - 1; len(sm.found) > keep {
+		sm.found = sm.found[:keep]
+	}
+	sm.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("Matching tools, callable from your next turn:\n")
+	for _, tool := range matches {
+		fmt.Fprintf(&b, "- %s: %s\n", tool.Function.Name, firstLine(tool.Function.Description))
+	}
+	return b.String(), nil
+}
+
+// searchTool describes search_tools to the LLM
+func searchTool(total int) domain.Tool {
+	return domain.Tool{
+		Type: "function",
+		Function: domain.ToolFunction{
+			Name: SearchToolsName,
+			Description: fmt.Sprintf("Only some of the %d available tools are listed. "+
+				"Search the rest by describing what you need to do; matching tools can be called from your next turn.", total),
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "What the tool should do, e.g. 'read a file' or 'query a database'",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Most tools to return (default: %d, max: %d)", defaultSearchResults, maxSearchResults),
+					},
+				},
+				"required": []string{"query"},
+			},
+		},
+	}
+}
+
+// StartServer delegates to external servers
+func (sm *ServerManager) StartServer(ctx context.Context, serverName string, cfg *config.ServerConfig) (domain.MCPServer, error) {
+	return sm.externalServers.StartServer(ctx, serverName, cfg)
+}
+
+// StopServer delegates to external servers
+func (sm *ServerManager) StopServer(serverName string) error {
+	return sm.externalServers.StopServer(serverName)
+}
+
+// GetServer delegates to external servers
+func (sm *ServerManager) GetServer(serverName string) (domain.MCPServer, bool) {
+	return sm.externalServers.GetServer(serverName)
+}
+
+// ListServers delegates to external servers
+func (sm *ServerManager) ListServers() map[string]domain.MCPServer {
+	return sm.externalServers.ListServers()
+}
+
+// StopAll delegates to external servers
+func (sm *ServerManager) StopAll() error {
+	return sm.externalServers.StopAll()
+}
+
+func findTool(tools []domain.Tool, name string) int {
+	for i, tool := range tools {
+		if tool.Function.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// prepend moves name to the front of names
+func prepend(names []string, name string) []string {
+	out := []string{name}
+	for _, n := range names {
+		if n != name {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
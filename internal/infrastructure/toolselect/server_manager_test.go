@@ -0,0 +1,129 @@
+package toolselect
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// stubManager is an external server manager offering fixed tools
+type stubManager struct {
+	domain.MCPServerManager
+	tools []domain.Tool
+}
+
+func (s *stubManager) GetAvailableTools() ([]domain.Tool, error) {
+	return s.tools, nil
+}
+
+func (s *stubManager) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	return "external:" + toolName, nil
+}
+
+func stubTools() []domain.Tool {
+	tools := []domain.Tool{}
+	for name, description := range map[string]string{
+		"filesystem_read_file":  "Read the contents of a file",
+		"filesystem_write_file": "Write text to a file",
+		"github_create_issue":   "Create an issue in a GitHub repository",
+		"github_list_pulls":     "List pull requests in a repository",
+		"postgres_query":        "Run a read-only SQL query against the database",
+		"get_current_time":      "Current date and time in a timezone",
+	} {
+		tools = append(tools, domain.Tool{Type: "function", Function: domain.ToolFunction{Name: name, Description: description}})
+	}
+	return tools
+}
+
+func toolNames(tools []domain.Tool) string {
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Function.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSelectTools(t *testing.T) {
+	sm := NewServerManager(&stubManager{tools: stubTools()}, &config.ToolSelectionConfig{MaxTools: 4, Always: []string{"get_current_time"}}, nil)
+
+	tools, err := domain.SelectTools(context.Background(), sm, "Open an issue on GitHub about the failing build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := toolNames(tools)
+	if !strings.HasPrefix(got, "get_current_time,github_create_issue,") || !strings.HasSuffix(got, ","+SearchToolsName) || len(tools) != 4 {
+		t.Errorf("tools = %s", got)
+	}
+
+	// Under the limit everything is sent as is
+	all := NewServerManager(&stubManager{tools: stubTools()}, &config.ToolSelectionConfig{MaxTools: 10}, nil)
+	if tools, _ := all.SelectTools(context.Background(), "anything"); len(tools) != 6 {
+		t.Errorf("Expected all 6 tools, got %s", toolNames(tools))
+	}
+}
+
+func TestSearchTools(t *testing.T) {
+	sm := NewServerManager(&stubManager{tools: stubTools()}, &config.ToolSelectionConfig{MaxTools: 3}, nil)
+	ctx := context.Background()
+
+	tools, _ := sm.SelectTools(ctx, "Open an issue on GitHub")
+	if strings.Contains(toolNames(tools), "postgres_query") {
+		t.Fatalf("postgres_query should not be selected: %s", toolNames(tools))
+	}
+
+	out, err := sm.ExecuteTool(ctx, SearchToolsName, map[string]interface{}{"query": "run an SQL query on the database", "limit": 1.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "postgres_query: Run a read-only SQL query") {
+		t.Errorf("search_tools result = %s", out)
+	}
+
+	// The found tool is sent from the next turn
+	tools, _ = sm.SelectTools(ctx, "Open an issue on GitHub")
+	if got := toolNames(tools); !strings.HasPrefix(got, "postgres_query,") {
+		t.Errorf("tools after search = %s", got)
+	}
+
+	if out, _ := sm.ExecuteTool(ctx, "github_create_issue", nil); out != "external:github_create_issue" {
+		t.Errorf("github_create_issue routed to %q, want external", out)
+	}
+	if _, err := sm.ExecuteTool(ctx, SearchToolsName, map[string]interface{}{}); err == nil {
+		t.Error("search_tools without a query should fail")
+	}
+}
+
+func TestRankWithEmbeddings(t *testing.T) {
+	// A query with no shared keywords still finds the tool by meaning
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		if strings.Contains(text, "SQL") || strings.Contains(text, "records") {
+			return []float32{1, 0}, nil
+		}
+		return []float32{0, 1}, nil
+	}
+	sm := NewServerManager(&stubManager{tools: stubTools()}, &config.ToolSelectionConfig{MaxTools: 2}, embed)
+
+	tools, _ := sm.SelectTools(context.Background(), "Look up customer records")
+	if got := toolNames(tools); got != "postgres_query,"+SearchToolsName {
+		t.Errorf("tools = %s", got)
+	}
+}
+
+func TestTerms(t *testing.T) {
+	if got := strings.Join(terms("Read the FILES in /tmp, please"), ","); got != "read,file,tmp" {
+		t.Errorf("terms = %s", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	external := &stubManager{}
+	if Wrap(external, "", nil) != external || Wrap(external, "", &config.ToolSelectionConfig{}) != external {
+		t.Error("Wrap without max_tools should leave the manager unwrapped")
+	}
+	if Wrap(nil, "", &config.ToolSelectionConfig{MaxTools: 5}) != nil {
+		t.Error("Wrap of a nil manager should stay nil")
+	}
+}
@@ -0,0 +1,133 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Play plays an audio file with the configured player command, or the first
+// available platform player when none is configured
+func Play(ctx context.Context, player, path string) error {
+	args, err := playerCommand(player, path)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("audio player failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// playerCommand builds the command line used to play path
+func playerCommand(player, path string) ([]string, error) {
+	if player != "" {
+		args := strings.Fields(player)
+		replaced := false
+		for i, arg := range args {
+			if strings.Contains(arg, "{file}") {
+				args[i] = strings.ReplaceAll(arg, "{file}", path)
+				replaced = true
+			}
+		}
+		if !replaced {
+			args = append(args, path)
+		}
+		return args, nil
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"afplay", path}, nil
+	case "windows":
+		// Opens the file with the default media application
+		return []string{"cmd", "/c", "start", "", path}, nil
+	}
+
+	candidates := [][]string{
+		{"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet", path},
+		{"mpv", "--no-video", "--really-quiet", path},
+		{"paplay", path},
+		{"mpg123", "-q", path},
+		{"aplay", "-q", path},
+	}
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err == nil {
+			// aplay and paplay can't decode mp3
+			if strings.HasSuffix(path, ".mp3") && (candidate[0] == "aplay" || candidate[0] == "paplay") {
+				continue
+			}
+			return candidate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no audio player found (install ffplay or mpv, or set tts.player in settings)")
+}
+
+// Speaker speaks text aloud in the background, one utterance at a time
+type Speaker struct {
+	provider *config.TTSProviderConfig
+	player   string
+	mu       sync.Mutex
+}
+
+// NewSpeaker creates a speaker for the named provider (empty = default provider)
+func NewSpeaker(cfg *config.TTSConfig, providerName string) (*Speaker, error) {
+	provider, name, ok := cfg.GetProvider(providerName)
+	if !ok {
+		if name == "" {
+			return nil, fmt.Errorf("no tts provider specified and no default_provider in tts config")
+		}
+		return nil, fmt.Errorf("tts provider '%s' not found in config", name)
+	}
+
+	return &Speaker{provider: provider, player: cfg.Player}, nil
+}
+
+// Speak synthesizes and plays text without blocking the caller.
+// Utterances are serialized so responses are never spoken over each other.
+func (s *Speaker) Speak(text string) {
+	go func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if err := s.speak(context.Background(), text); err != nil {
+			logging.Warn("Failed to speak response: %v", err)
+		}
+	}()
+}
+
+func (s *Speaker) speak(ctx context.Context, text string) error {
+	audio, ext, err := Synthesize(ctx, s.provider, text)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp("", "mcp-speak-*."+ext)
+	if err != nil {
+		return fmt.Errorf("failed to create audio file: %w", err)
+	}
+	path := f.Name()
+	if _, err := f.Write(audio); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write audio: %w", err)
+	}
+	f.Close()
+
+	// The default Windows player returns immediately, so the file must outlive Play
+	if runtime.GOOS != "windows" || s.player != "" {
+		defer os.Remove(path)
+	}
+
+	return Play(ctx, s.player, path)
+}
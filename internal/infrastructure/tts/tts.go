@@ -0,0 +1,256 @@
+// Package tts synthesizes speech through OpenAI, Azure AI Speech or a local command.
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+const (
+	defaultOpenAIEndpoint = "https://api.openai.com/v1"
+	defaultOpenAIModel    = "tts-1"
+	defaultOpenAIVoice    = "alloy"
+	defaultAzureVoice     = "en-US-JennyNeural"
+
+	// MaxTextLength is the longest input sent to a provider (OpenAI's limit);
+	// longer text is truncated at a sentence boundary
+	MaxTextLength = 4096
+)
+
+// Synthesize converts text to audio and returns the audio bytes and file extension
+func Synthesize(ctx context.Context, provider *config.TTSProviderConfig, text string) ([]byte, string, error) {
+	if provider == nil {
+		return nil, "", fmt.Errorf("tts provider configuration is required")
+	}
+
+	text = PrepareText(text)
+	if text == "" {
+		return nil, "", fmt.Errorf("nothing to speak")
+	}
+
+	format := strings.ToLower(provider.Format)
+	if format == "" {
+		format = "mp3"
+	}
+	if format != "mp3" && format != "wav" {
+		return nil, "", fmt.Errorf("unsupported audio format '%s' (supported: mp3, wav)", provider.Format)
+	}
+
+	timeout := 60 * time.Second
+	if provider.TimeoutSeconds > 0 {
+		timeout = time.Duration(provider.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var audio []byte
+	var err error
+	switch provider.Type {
+	case config.TTSOpenAI:
+		audio, err = synthesizeOpenAI(ctx, provider, text, format)
+	case config.TTSAzure:
+		audio, err = synthesizeAzure(ctx, provider, text, format)
+	case config.TTSCommand:
+		audio, err = synthesizeCommand(ctx, provider, text, format)
+	default:
+		return nil, "", fmt.Errorf("unsupported tts provider type '%s' (supported: openai, azure, command)", provider.Type)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	logging.Debug("Synthesized %d characters to %d bytes of %s audio", len(text), len(audio), format)
+	return audio, format, nil
+}
+
+// synthesizeOpenAI calls the OpenAI (or compatible) /audio/speech endpoint
+func synthesizeOpenAI(ctx context.Context, provider *config.TTSProviderConfig, text, format string) ([]byte, error) {
+	if provider.APIKey == "" {
+		return nil, fmt.Errorf("openai tts requires api_key")
+	}
+
+	endpoint := strings.TrimSuffix(provider.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = defaultOpenAIEndpoint
+	}
+
+	payload := map[string]interface{}{
+		"model":           valueOr(provider.Model, defaultOpenAIModel),
+		"voice":           valueOr(provider.Voice, defaultOpenAIVoice),
+		"input":           text,
+		"response_format": format,
+	}
+	if provider.Speed > 0 {
+		payload["speed"] = provider.Speed
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/audio/speech", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+provider.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	return doAudioRequest(req)
+}
+
+// azureOutputFormats maps audio formats to Azure Speech output format names
+var azureOutputFormats = map[string]string{
+	"mp3": "audio-24khz-48kbitrate-mono-mp3",
+	"wav": "riff-24khz-16bit-mono-pcm",
+}
+
+// synthesizeAzure calls the Azure AI Speech REST API with SSML
+func synthesizeAzure(ctx context.Context, provider *config.TTSProviderConfig, text, format string) ([]byte, error) {
+	if provider.APIKey == "" {
+		return nil, fmt.Errorf("azure tts requires api_key")
+	}
+
+	endpoint := strings.TrimSuffix(provider.Endpoint, "/")
+	if endpoint == "" {
+		if provider.Region == "" {
+			return nil, fmt.Errorf("azure tts requires region or endpoint")
+		}
+		endpoint = fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", provider.Region)
+	}
+
+	voice := valueOr(provider.Voice, defaultAzureVoice)
+
+	// Language is the locale prefix of the voice name (en-US-JennyNeural -> en-US)
+	lang := "en-US"
+	if parts := strings.SplitN(voice, "-", 3); len(parts) == 3 {
+		lang = parts[0] + "-" + parts[1]
+	}
+
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(text)); err != nil {
+		return nil, fmt.Errorf("failed to encode text: %w", err)
+	}
+	ssml := fmt.Sprintf(`<speak version="1.0" xmlns="http://www.w3.org/2001/10/synthesis" xml:lang="%s"><voice name="%s">%s</voice></speak>`,
+		lang, voice, escaped.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(ssml))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", provider.APIKey)
+	req.Header.Set("Content-Type", "application/ssml+xml")
+	req.Header.Set("X-Microsoft-OutputFormat", azureOutputFormats[format])
+	req.Header.Set("User-Agent", "mcp-cli")
+
+	return doAudioRequest(req)
+}
+
+// synthesizeCommand runs a local synthesizer (piper, espeak-ng, say, ...)
+func synthesizeCommand(ctx context.Context, provider *config.TTSProviderConfig, text, format string) ([]byte, error) {
+	if provider.Command == "" {
+		return nil, fmt.Errorf("command tts requires command")
+	}
+
+	tmp, err := os.CreateTemp("", "mcp-tts-*."+format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	outputPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(outputPath)
+
+	args := strings.Fields(provider.Command)
+	for i, arg := range args {
+		args[i] = strings.ReplaceAll(arg, "{output}", outputPath)
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("tts command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	audio, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synthesized audio: %w", err)
+	}
+	if len(audio) == 0 {
+		return nil, fmt.Errorf("tts command produced no audio (does the command write to {output}?)")
+	}
+	return audio, nil
+}
+
+// doAudioRequest executes an HTTP request expected to return audio bytes
+func doAudioRequest(req *http.Request) ([]byte, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("tts request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tts response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > 300 {
+			msg = msg[:300] + "..."
+		}
+		return nil, fmt.Errorf("tts request failed (%s): %s", resp.Status, msg)
+	}
+
+	return body, nil
+}
+
+var (
+	codeFencePattern  = regexp.MustCompile("(?s)```.*?```")
+	mdLinkPattern     = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	mdEmphasisPattern = regexp.MustCompile("[*_`~]{1,3}")
+	mdPrefixPattern   = regexp.MustCompile(`(?m)^\s{0,3}(#{1,6}\s+|>\s?|[-*+]\s+|\d+[.)]\s+|\|)`)
+	whitespacePattern = regexp.MustCompile(`[ \t]*\n[ \t\n]*`)
+)
+
+// PrepareText turns Markdown into speakable plain text: code blocks are
+// replaced with a short note, formatting is removed and overlong text is truncated
+func PrepareText(text string) string {
+	text = codeFencePattern.ReplaceAllString(text, " (code block omitted) ")
+	text = mdLinkPattern.ReplaceAllString(text, "$1")
+	text = mdPrefixPattern.ReplaceAllString(text, "")
+	text = mdEmphasisPattern.ReplaceAllString(text, "")
+	text = strings.ReplaceAll(text, "|", ", ")
+	text = whitespacePattern.ReplaceAllString(text, "\n")
+	text = strings.TrimSpace(text)
+
+	if len(text) > MaxTextLength {
+		cut := text[:MaxTextLength]
+		if idx := strings.LastIndexAny(cut, ".!?\n"); idx > MaxTextLength/2 {
+			cut = cut[:idx+1]
+		}
+		text = cut
+	}
+	return text
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+package tts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestPrepareText(t *testing.T) {
+	input := "# Summary\n\nThe **build** passed. See [the log](https://ci/log).\n\n```go\nfmt.Println()\n```\n\n- one\n- two\n"
+	got := PrepareText(input)
+
+	for _, unwanted := range []string{"#", "**", "https://", "fmt.Println", "- one"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("PrepareText() kept %q: %q", unwanted, got)
+		}
+	}
+	if !strings.Contains(got, "The build passed. See the log.") || !strings.Contains(got, "code block omitted") {
+		t.Errorf("PrepareText() = %q", got)
+	}
+
+	long := strings.Repeat("A sentence. ", 500)
+	if got := PrepareText(long); len(got) > MaxTextLength || !strings.HasSuffix(got, ".") {
+		t.Errorf("long text not truncated at a sentence boundary (len %d)", len(got))
+	}
+}
+
+func TestSynthesizeOpenAI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/speech" || r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["voice"] != "nova" || payload["model"] != "tts-1" || payload["response_format"] != "mp3" {
+			t.Errorf("unexpected payload %v", payload)
+		}
+		io.WriteString(w, "ID3audio")
+	}))
+	defer server.Close()
+
+	audio, ext, err := Synthesize(context.Background(), &config.TTSProviderConfig{
+		Type:     config.TTSOpenAI,
+		APIKey:   "key",
+		Endpoint: server.URL + "/v1",
+		Voice:    "nova",
+	}, "Hello")
+	if err != nil {
+		t.Fatalf("Synthesize() error = %v", err)
+	}
+	if string(audio) != "ID3audio" || ext != "mp3" {
+		t.Errorf("Synthesize() = %q, %q", audio, ext)
+	}
+}
+
+func TestSynthesizeAzureSSML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `xml:lang="en-GB"`) || !strings.Contains(string(body), "Tom &amp; Jerry") {
+			t.Errorf("unexpected SSML %s", body)
+		}
+		if r.Header.Get("X-Microsoft-OutputFormat") != "riff-24khz-16bit-mono-pcm" {
+			t.Errorf("unexpected output format %q", r.Header.Get("X-Microsoft-OutputFormat"))
+		}
+		io.WriteString(w, "RIFF")
+	}))
+	defer server.Close()
+
+	_, ext, err := Synthesize(context.Background(), &config.TTSProviderConfig{
+		Type:     config.TTSAzure,
+		APIKey:   "key",
+		Endpoint: server.URL,
+		Voice:    "en-GB-SoniaNeural",
+		Format:   "wav",
+	}, "Tom & Jerry")
+	if err != nil {
+		t.Fatalf("Synthesize() error = %v", err)
+	}
+	if ext != "wav" {
+		t.Errorf("ext = %q, want wav", ext)
+	}
+}
+
+func TestPlayerCommand(t *testing.T) {
+	args, err := playerCommand("vlc --play-and-exit {file}", "/tmp/a.mp3")
+	if err != nil || strings.Join(args, " ") != "vlc --play-and-exit /tmp/a.mp3" {
+		t.Errorf("playerCommand() = %v, %v", args, err)
+	}
+
+	args, _ = playerCommand("mpv", "/tmp/a.mp3")
+	if strings.Join(args, " ") != "mpv /tmp/a.mp3" {
+		t.Errorf("playerCommand() without placeholder = %v", args)
+	}
+}
@@ -0,0 +1,255 @@
+// Package usage keeps a local ledger of provider calls: one line per call
+// with its provider, model, workflow, tokens and estimated cost, in a file
+// per month. Monthly provider budgets are checked against it. Nothing is
+// sent anywhere.
+package usage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+)
+
+// DirEnv overrides the ledger directory. Set it to "off" to stop recording.
+const DirEnv = "MCP_CLI_USAGE_DIR"
+
+// MonthFormat is the layout of month names, as in 2026-10
+const MonthFormat = "2006-01"
+
+// Entry is one provider call in the ledger
+type Entry struct {
+	Time             time.Time `json:"time"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model,omitempty"`
+	Workflow         string    `json:"workflow,omitempty"`
+	Tenant           string    `json:"tenant,omitempty"` // Tenant of a shared server
+	Kind             string    `json:"kind"`             // completion or embeddings
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens,omitempty"`
+	CostUSD          float64   `json:"cost_usd"` // Estimated from the provider's configured prices
+	Failed           bool      `json:"failed,omitempty"`
+}
+
+// Entry kinds
+const (
+	KindCompletion = "completion"
+	KindEmbeddings = "embeddings"
+)
+
+// Dir returns the ledger directory: $MCP_CLI_USAGE_DIR, or usage in the
+// user's config directory (~/.config/mcp-cli/usage on Linux). It is empty
+// when recording is off.
+func Dir() string {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		if dir == "off" {
+			return ""
+		}
+		return dir
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mcp-cli", "usage")
+}
+
+// Enabled reports whether provider calls are recorded
+func Enabled() bool {
+	return Dir() != ""
+}
+
+// MonthFile returns the ledger file for a month (2026-10) in dir
+func MonthFile(dir, month string) string {
+	return filepath.Join(dir, month+".jsonl")
+}
+
+// CurrentMonth names the current month, as in 2026-10
+func CurrentMonth() string {
+	return time.Now().Format(MonthFormat)
+}
+
+// Load reads a month's entries. A month without a file has none.
+func Load(dir, month string) ([]Entry, error) {
+	if _, err := time.Parse(MonthFormat, month); err != nil {
+		return nil, fmt.Errorf("invalid month %q: use YYYY-MM", month)
+	}
+	f, err := os.Open(MonthFile(dir, month))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	_, err = readEntries(f, func(entry Entry) { entries = append(entries, entry) })
+	return entries, err
+}
+
+// readEntries calls fn for each entry in r and returns the bytes read up to
+// the last complete line. Lines that don't parse are skipped.
+func readEntries(r io.Reader, fn func(Entry)) (int64, error) {
+	reader := bufio.NewReader(r)
+	var read int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return read, nil // A partial last line is read next time
+		}
+		if err != nil {
+			return read, err
+		}
+		read += int64(len(line))
+		var entry Entry
+		if json.Unmarshal(line, &entry) == nil {
+			fn(entry)
+		}
+	}
+}
+
+// mu serializes this process's ledger writes and budget checks
+var mu sync.Mutex
+
+// Record appends an entry to the current month's ledger. Errors are
+// ignored: the ledger must never break a command.
+func Record(entry Entry) {
+	dir := Dir()
+	if dir == "" {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(MonthFile(dir, entry.Time.Format(MonthFormat)), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	f.Write(append(data, '\n'))
+	f.Close()
+}
+
+// spent caches the current month's cost per tenant and provider, reading
+// only what was appended to the ledger since the last check
+var spent struct {
+	month  string
+	offset int64
+	cost   map[string]float64 // Keyed by spendKey
+}
+
+// spendKey keys spend by tenant and provider: a tenant's budgets count only
+// its own calls
+func spendKey(tenant, provider string) string {
+	return tenant + "\x00" + provider
+}
+
+// Spent returns a provider's estimated spend this month by a tenant, or
+// outside tenants when tenant is empty
+func Spent(tenant, provider string) (float64, error) {
+	dir := Dir()
+	if dir == "" {
+		return 0, nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	month := CurrentMonth()
+	if spent.month != month {
+		spent.month, spent.offset, spent.cost = month, 0, make(map[string]float64)
+	}
+	f, err := os.Open(MonthFile(dir, month))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(spent.offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	read, err := readEntries(f, func(entry Entry) { spent.cost[spendKey(entry.Tenant, entry.Provider)] += entry.CostUSD })
+	spent.offset += read
+	return spent.cost[spendKey(tenant, provider)], err
+}
+
+// CheckBudget fails when a provider's spend this month, by tenant or
+// outside tenants, has reached its monthly budget in USD. A budget of 0 is
+// no budget.
+func CheckBudget(tenant, provider string, budget float64) error {
+	if budget <= 0 {
+		return nil
+	}
+	cost, err := Spent(tenant, provider)
+	if err != nil {
+		return nil // An unreadable ledger doesn't block calls
+	}
+	if cost >= budget {
+		owner := "provider " + provider
+		if tenant != "" {
+			owner = "tenant " + tenant + " on provider " + provider
+		}
+		return domainErrors.Categorize(fmt.Errorf("%s has spent ~$%.2f of its $%.2f monthly budget for %s (raise monthly_budget in its settings, or see 'mcp-cli usage report')",
+			owner, cost, budget, CurrentMonth()), domainErrors.ErrBudgetExceeded)
+	}
+	return nil
+}
+
+type (
+	workflowKey struct{}
+	tenantKey   struct{}
+)
+
+// WithWorkflow attributes the provider calls made with ctx to a workflow.
+// Calls made by workflows it runs stay attributed to the outer one.
+func WithWorkflow(ctx context.Context, name string) context.Context {
+	if name == "" || WorkflowFrom(ctx) != "" {
+		return ctx
+	}
+	return context.WithValue(ctx, workflowKey{}, name)
+}
+
+// WorkflowFrom returns the workflow ctx's calls are attributed to
+func WorkflowFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	name, _ := ctx.Value(workflowKey{}).(string)
+	return name
+}
+
+// WithTenant attributes the provider calls made with ctx to a tenant of a
+// shared server, whose budgets they count against
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	if tenant == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the tenant ctx's calls are attributed to
+func TenantFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
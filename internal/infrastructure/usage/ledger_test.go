@@ -0,0 +1,124 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+)
+
+// useDir points the ledger at a temp directory
+func useDir(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv(DirEnv, dir)
+	spent.month = "" // Forget another test's ledger
+	return dir
+}
+
+func TestOffRecordsNothing(t *testing.T) {
+	t.Setenv(DirEnv, "off")
+	if Enabled() {
+		t.Error("enabled with the ledger off")
+	}
+	Record(Entry{Provider: "openai", CostUSD: 1}) // Must not panic or write
+}
+
+func TestRecordAndSummarize(t *testing.T) {
+	dir := useDir(t)
+	Record(Entry{Provider: "openai", Model: "gpt-4o", Workflow: "triage", Kind: KindCompletion, PromptTokens: 100, CompletionTokens: 20, CostUSD: 0.5})
+	Record(Entry{Provider: "openai", Model: "small", Kind: KindEmbeddings, PromptTokens: 1000, CostUSD: 0.02})
+	Record(Entry{Provider: "anthropic", Model: "sonnet", Workflow: "triage", Kind: KindCompletion, Failed: true})
+	Record(Entry{Provider: "openai", Time: time.Now().AddDate(0, -1, 0), CostUSD: 9}) // Last month
+
+	entries, err := Load(dir, CurrentMonth())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries this month, want 3", len(entries))
+	}
+
+	report := Summarize(CurrentMonth(), entries, map[string]float64{"openai": 0.5, "anthropic": 10})
+	if report.Total.Calls != 3 || report.Total.Failures != 1 {
+		t.Errorf("total = %+v, want 3 calls and 1 failure", report.Total)
+	}
+	if got := report.Providers["openai"].CostUSD; got < 0.519 || got > 0.521 {
+		t.Errorf("openai cost = %v, want 0.52", got)
+	}
+	if report.Workflows["triage"].Calls != 2 || report.Workflows[""].Calls != 1 {
+		t.Errorf("workflows = %v", report.Workflows)
+	}
+	if report.Models["openai/gpt-4o"].PromptTokens != 100 {
+		t.Errorf("models = %v", report.Models)
+	}
+	if len(report.OverBudget) != 1 || report.OverBudget[0] != "openai" {
+		t.Errorf("over budget = %v, want [openai]", report.OverBudget)
+	}
+	if keys := ByCost(report.Providers); keys[0] != "openai" {
+		t.Errorf("ByCost = %v, want openai first", keys)
+	}
+
+	if _, err := Load(dir, "October"); err == nil {
+		t.Error("expected an error for a malformed month")
+	}
+}
+
+func TestCheckBudget(t *testing.T) {
+	dir := useDir(t)
+	if err := CheckBudget("", "openai", 1); err != nil {
+		t.Fatalf("empty ledger: %v", err)
+	}
+
+	Record(Entry{Provider: "openai", CostUSD: 0.6})
+	if err := CheckBudget("", "openai", 1); err != nil {
+		t.Errorf("under budget: %v", err)
+	}
+
+	// Entries appended by another process are picked up too
+	f, err := os.OpenFile(MonthFile(dir, CurrentMonth()), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"provider":"openai","cost_usd":0.5}` + "\n")
+	f.Close()
+
+	err = CheckBudget("", "openai", 1)
+	if !errors.Is(err, domainErrors.ErrBudgetExceeded) {
+		t.Errorf("got %v, want a budget exceeded error", err)
+	}
+	if err := CheckBudget("", "openai", 0); err != nil {
+		t.Errorf("no budget: %v", err)
+	}
+	if err := CheckBudget("", "anthropic", 1); err != nil {
+		t.Errorf("other provider: %v", err)
+	}
+
+	// A tenant's budget counts only its own calls
+	if err := CheckBudget("team-a", "openai", 1); err != nil {
+		t.Errorf("tenant without spend: %v", err)
+	}
+	Record(Entry{Provider: "openai", Tenant: "team-a", CostUSD: 2})
+	if err := CheckBudget("team-a", "openai", 1); !errors.Is(err, domainErrors.ErrBudgetExceeded) {
+		t.Errorf("tenant over budget: got %v", err)
+	}
+	if cost, _ := Spent("", "openai"); cost < 1.09 || cost > 1.11 {
+		t.Errorf("shared spend = %v, want 1.1 without the tenant's", cost)
+	}
+}
+
+func TestWithWorkflowKeepsOuter(t *testing.T) {
+	ctx := WithWorkflow(context.Background(), "outer")
+	ctx = WithWorkflow(ctx, "inner")
+	if got := WorkflowFrom(ctx); got != "outer" {
+		t.Errorf("got %q, want outer", got)
+	}
+	if got := WorkflowFrom(context.Background()); got != "" {
+		t.Errorf("got %q without a workflow", got)
+	}
+	if got := TenantFrom(WithTenant(ctx, "team-a")); got != "team-a" {
+		t.Errorf("tenant = %q, want team-a", got)
+	}
+}
@@ -0,0 +1,99 @@
+package usage
+
+import (
+	"sort"
+)
+
+// Totals adds up calls, tokens and cost
+type Totals struct {
+	Calls            int     `json:"calls"`
+	Failures         int     `json:"failures"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+}
+
+func (t *Totals) add(entry Entry) {
+	t.Calls++
+	if entry.Failed {
+		t.Failures++
+	}
+	t.PromptTokens += entry.PromptTokens
+	t.CompletionTokens += entry.CompletionTokens
+	t.CostUSD += entry.CostUSD
+}
+
+// Report is a month's spend by provider, workflow and model
+type Report struct {
+	Month      string             `json:"month"`
+	Total      Totals             `json:"total"`
+	Providers  map[string]*Totals `json:"providers"`
+	Workflows  map[string]*Totals `json:"workflows"` // Calls outside workflows are under ""
+	Models     map[string]*Totals `json:"models"`    // Keyed by provider/model
+	Tenants    map[string]*Totals `json:"tenants,omitempty"`
+	Budgets    map[string]float64 `json:"budgets,omitempty"`
+	OverBudget []string           `json:"over_budget,omitempty"`
+}
+
+// Summarize totals a month's entries. budgets are the providers' monthly
+// budgets in USD, reported alongside their spend outside tenants.
+func Summarize(month string, entries []Entry, budgets map[string]float64) *Report {
+	report := &Report{
+		Month:     month,
+		Providers: make(map[string]*Totals),
+		Workflows: make(map[string]*Totals),
+		Models:    make(map[string]*Totals),
+		Budgets:   budgets,
+	}
+	shared := make(map[string]float64) // Spend outside tenants, which budgets cap
+	for _, entry := range entries {
+		report.Total.add(entry)
+		totalFor(report.Providers, entry.Provider).add(entry)
+		totalFor(report.Workflows, entry.Workflow).add(entry)
+		model := entry.Provider
+		if entry.Model != "" {
+			model += "/" + entry.Model
+		}
+		totalFor(report.Models, model).add(entry)
+		if entry.Tenant != "" {
+			if report.Tenants == nil {
+				report.Tenants = make(map[string]*Totals)
+			}
+			totalFor(report.Tenants, entry.Tenant).add(entry)
+		} else {
+			shared[entry.Provider] += entry.CostUSD
+		}
+	}
+	for provider, budget := range budgets {
+		if budget > 0 && shared[provider] >= budget {
+			report.OverBudget = append(report.OverBudget, provider)
+		}
+	}
+	sort.Strings(report.OverBudget)
+	return report
+}
+
+func totalFor(totals map[string]*Totals, key string) *Totals {
+	t := totals[key]
+	if t == nil {
+		t = &Totals{}
+		totals[key] = t
+	}
+	return t
+}
+
+// ByCost returns the keys of totals, most expensive first
+func ByCost(totals map[string]*Totals) []string {
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := totals[keys[i]], totals[keys[j]]
+		if a.CostUSD != b.CostUSD {
+			return a.CostUSD > b.CostUSD
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -105,7 +105,7 @@ func NewAnthropicClient(cfg *config.ProviderConfig) (domain.LLMProvider, error)
 // CreateCompletion generates a completion using the Anthropic API
 func (c *AnthropicClient) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
 	// Convert domain request to internal format
-	messages := convertDomainMessages(req.Messages)
+	messages := convertDomainMessages(withToolImages(req.Messages, c.config.VisionEnabled(true)))
 	tools := convertDomainTools(req.Tools)
 
 	// Convert our message format to Anthropic's format
@@ -130,10 +130,12 @@ func (c *AnthropicClient) CreateCompletion(ctx context.Context, req *domain.Comp
 		payload["system"] = systemPrompt
 	}
 
-	// Add temperature if specified
-	if req.Temperature > 0 {
-		payload["temperature"] = req.Temperature
+	// Add temperature and sampling settings if specified
+	settings := resolveSampling(c.config, req)
+	if settings.Temperature > 0 {
+		payload["temperature"] = settings.Temperature
 	}
+	addAnthropicParams(payload, "anthropic", settings.GenerationParams)
 
 	// Add tools if provided
 	if len(anthropicTools) > 0 {
@@ -190,7 +192,7 @@ func (c *AnthropicClient) CreateCompletion(ctx context.Context, req *domain.Comp
 // StreamCompletion generates a streaming completion
 func (c *AnthropicClient) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, writer io.Writer) (*domain.CompletionResponse, error) {
 	// Convert domain request to internal format
-	messages := convertDomainMessages(req.Messages)
+	messages := convertDomainMessages(withToolImages(req.Messages, c.config.VisionEnabled(true)))
 	tools := convertDomainTools(req.Tools)
 
 	// Convert our message format to Anthropic's format
@@ -216,10 +218,12 @@ func (c *AnthropicClient) StreamCompletion(ctx context.Context, req *domain.Comp
 		payload["system"] = systemPrompt
 	}
 
-	// Add temperature if specified
-	if req.Temperature > 0 {
-		payload["temperature"] = req.Temperature
+	// Add temperature and sampling settings if specified
+	settings := resolveSampling(c.config, req)
+	if settings.Temperature > 0 {
+		payload["temperature"] = settings.Temperature
 	}
+	addAnthropicParams(payload, "anthropic", settings.GenerationParams)
 
 	// Add tools if provided
 	if len(anthropicTools) > 0 {
@@ -532,6 +536,20 @@ func (c *AnthropicClient) convertToAnthropicMessages(messages []internalMessage,
 				"tool_use_id": msg.ToolCallID,
 				"content":     msg.Content,
 			}
+			if len(msg.Images) > 0 {
+				blocks := []map[string]interface{}{{"type": "text", "text": msg.Content}}
+				for _, img := range msg.Images {
+					blocks = append(blocks, map[string]interface{}{
+						"type": "image",
+						"source": map[string]interface{}{
+							"type":       "base64",
+							"media_type": img.MimeType,
+							"data":       img.Data,
+						},
+					})
+				}
+				toolResult["content"] = blocks
+			}
 
 			anthropicMsg["content"] = []map[string]interface{}{toolResult}
 			anthropicMessages = append(anthropicMessages, anthropicMsg)
@@ -662,6 +680,7 @@ type internalMessage struct {
 	Name       string             `json:"name,omitempty"`
 	ToolCalls  []internalToolCall `json:"tool_calls,omitempty"`
 	ToolCallID string             `json:"tool_call_id,omitempty"`
+	Images     []domain.Image     `json:"-"`
 }
 
 type internalToolCall struct {
@@ -695,6 +714,7 @@ func convertDomainMessages(domainMessages []domain.Message) []internalMessage {
 			Content:    msg.Content,
 			Name:       msg.Name,
 			ToolCallID: msg.ToolCallID,
+			Images:     msg.Images,
 		}
 
 		// Convert tool calls
@@ -29,10 +29,34 @@ type bedrockClaudeRequest struct {
 	MaxTokens        int                    `json:"max_tokens"`
 	Messages         []bedrockClaudeMessage `json:"messages"`
 	Temperature      float64                `json:"temperature,omitempty"`
-	TopP             float64                `json:"top_p,omitempty"`
+	TopP             *float64               `json:"top_p,omitempty"`
+	TopK             *int                   `json:"top_k,omitempty"`
+	StopSequences    []string               `json:"stop_sequences,omitempty"`
 	System           string                 `json:"system,omitempty"`
 }
 
+// newBedrockClaudeRequest returns a Messages API request with the request's
+// sampling settings, defaulting to a temperature of 0.7 and 2048 tokens
+func newBedrockClaudeRequest(provider string, messages []bedrockClaudeMessage, settings sampling) bedrockClaudeRequest {
+	warnUnsupported(provider, settings.GenerationParams, "top_p", "top_k", "stop")
+	bedrockReq := bedrockClaudeRequest{
+		AnthropicVersion: "bedrock-2023-05-31",
+		MaxTokens:        2048,
+		Messages:         messages,
+		Temperature:      0.7,
+		TopP:             settings.TopP,
+		TopK:             settings.TopK,
+		StopSequences:    settings.Stop,
+	}
+	if settings.MaxTokens > 0 {
+		bedrockReq.MaxTokens = settings.MaxTokens
+	}
+	if settings.Temperature > 0 {
+		bedrockReq.Temperature = settings.Temperature
+	}
+	return bedrockReq
+}
+
 type bedrockClaudeResponse struct {
 	ID           string                 `json:"id"`
 	Type         string                 `json:"type"`
@@ -165,12 +189,7 @@ func (c *AWSBedrockClient) CreateCompletion(ctx context.Context, req *domain.Com
 	messages := c.convertToClaudeMessages(req.Messages)
 
 	// Create Bedrock request (Claude Messages API format)
-	bedrockReq := bedrockClaudeRequest{
-		AnthropicVersion: "bedrock-2023-05-31",
-		MaxTokens:        2048,
-		Messages:         messages,
-		Temperature:      0.7,
-	}
+	bedrockReq := newBedrockClaudeRequest(string(c.providerType), messages, resolveSampling(c.config, req))
 
 	// Add system prompt if provided
 	if req.SystemPrompt != "" {
@@ -249,12 +268,7 @@ func (c *AWSBedrockClient) StreamCompletion(ctx context.Context, req *domain.Com
 	// Convert messages to Claude Messages API format
 	messages := c.convertToClaudeMessages(req.Messages)
 
-	bedrockReq := bedrockClaudeRequest{
-		AnthropicVersion: "bedrock-2023-05-31",
-		MaxTokens:        2048,
-		Messages:         messages,
-		Temperature:      0.7,
-	}
+	bedrockReq := newBedrockClaudeRequest(string(c.providerType), messages, resolveSampling(c.config, req))
 
 	// Add system prompt if provided
 	if req.SystemPrompt != "" {
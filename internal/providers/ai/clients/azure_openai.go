@@ -88,7 +88,7 @@ func NewAzureOpenAIClient(providerType domain.ProviderType, cfg *config.Provider
 
 // CreateCompletion implements domain.LLMProvider
 func (c *AzureOpenAIClient) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
-	messages := convertToOpenAIMessages(req.Messages, req.SystemPrompt)
+	messages := convertToOpenAIMessages(withToolImages(req.Messages, c.config.VisionEnabled(false)), req.SystemPrompt)
 	tools := convertToOpenAITools(req.Tools)
 
 	payload := openaiChatRequest{
@@ -97,6 +97,7 @@ func (c *AzureOpenAIClient) CreateCompletion(ctx context.Context, req *domain.Co
 		Tools:    tools,
 		Stream:   false,
 	}
+	applyOpenAIParams(&payload, string(c.providerType), resolveSampling(c.config, req).GenerationParams)
 
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
@@ -144,7 +145,7 @@ func (c *AzureOpenAIClient) CreateCompletion(ctx context.Context, req *domain.Co
 
 // StreamCompletion implements domain.LLMProvider
 func (c *AzureOpenAIClient) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, writer io.Writer) (*domain.CompletionResponse, error) {
-	messages := convertToOpenAIMessages(req.Messages, req.SystemPrompt)
+	messages := convertToOpenAIMessages(withToolImages(req.Messages, c.config.VisionEnabled(false)), req.SystemPrompt)
 	tools := convertToOpenAITools(req.Tools)
 
 	payload := openaiChatRequest{
@@ -153,6 +154,7 @@ func (c *AzureOpenAIClient) StreamCompletion(ctx context.Context, req *domain.Co
 		Tools:    tools,
 		Stream:   true,
 	}
+	applyOpenAIParams(&payload, string(c.providerType), resolveSampling(c.config, req).GenerationParams)
 
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
@@ -0,0 +1,514 @@
+package clients
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// ExternalProtocolVersion is the version of the stdio protocol spoken to
+// external provider programs
+const ExternalProtocolVersion = 1
+
+// Methods of the external provider protocol
+const (
+	externalMethodInitialize = "initialize"
+	externalMethodComplete   = "complete"
+	externalMethodEmbed      = "embed"
+	externalMethodCancel     = "cancel"
+)
+
+// How long a provider program gets to exit after its stdin is closed
+const externalShutdownGrace = 5 * time.Second
+
+// ExternalClient implements the domain.LLMProvider interface by running a
+// provider program and exchanging JSON lines with it over stdin and stdout.
+// It lets users wrap gateways mcp-cli doesn't support natively without
+// changing mcp-cli. The program is started on first use and restarted if it
+// exits.
+type ExternalClient struct {
+	config       *config.ProviderConfig
+	providerType domain.ProviderType
+
+	mu   sync.Mutex
+	proc *externalProcess
+	info externalInfo
+}
+
+// externalRequest is a request or notification sent to the program
+type externalRequest struct {
+	ID     int64       `json:"id,omitempty"`
+	Method string      `json:"method"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+// externalResponse is a line from the program: a streamed chunk of a
+// completion, or the final result or error of a request
+type externalResponse struct {
+	ID     int64           `json:"id"`
+	Chunk  string          `json:"chunk,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *ExternalError  `json:"error,omitempty"`
+}
+
+// ExternalError is an error reported by a provider program
+type ExternalError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *ExternalError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("provider program error %s: %s", e.Code, e.Message)
+	}
+	return "provider program error: " + e.Message
+}
+
+// externalInitializeParams are sent once when the program starts
+type externalInitializeParams struct {
+	ProtocolVersion int    `json:"protocol_version"`
+	Provider        string `json:"provider"`
+	Model           string `json:"model"`
+	APIKey          string `json:"api_key,omitempty"`
+	APIEndpoint     string `json:"api_endpoint,omitempty"`
+}
+
+// externalInfo is the program's answer to initialize
+type externalInfo struct {
+	ProtocolVersion    int      `json:"protocol_version"`
+	Name               string   `json:"name,omitempty"`
+	EmbeddingModels    []string `json:"embedding_models,omitempty"`
+	MaxEmbeddingTokens int      `json:"max_embedding_tokens,omitempty"`
+}
+
+// externalCompletionParams are the params of a complete request
+type externalCompletionParams struct {
+	Model string `json:"model"`
+	*domain.CompletionRequest
+}
+
+// NewExternalClient creates a client for the provider program in the
+// configuration's command
+func NewExternalClient(providerType domain.ProviderType, cfg *config.ProviderConfig) (domain.LLMProvider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("provider configuration is required")
+	}
+	client := &ExternalClient{config: cfg, providerType: providerType}
+	if err := client.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	logging.Info("Creating external provider client with command: %s, model: %s", cfg.Command, cfg.DefaultModel)
+	return client, nil
+}
+
+// CreateCompletion generates a completion using the specified request
+func (c *ExternalClient) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("completion request is required")
+	}
+	return c.complete(ctx, req, nil)
+}
+
+// StreamCompletion generates a streaming completion. The program sends the
+// text as chunks before its final result.
+func (c *ExternalClient) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, writer io.Writer) (*domain.CompletionResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("completion request is required")
+	}
+	var streamed strings.Builder
+	response, err := c.complete(ctx, req, func(chunk string) error {
+		streamed.WriteString(chunk)
+		if writer != nil {
+			_, err := writer.Write([]byte(chunk))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.Response == "" {
+		response.Response = streamed.String()
+	}
+	return response, nil
+}
+
+func (c *ExternalClient) complete(ctx context.Context, req *domain.CompletionRequest, onChunk func(string) error) (*domain.CompletionResponse, error) {
+	params := *req
+	params.Stream = onChunk != nil
+	if params.Temperature == 0 {
+		params.Temperature = c.config.Temperature
+	}
+	if params.MaxTokens == 0 {
+		params.MaxTokens = c.config.MaxTokens
+	}
+	params.GenerationParams = c.config.GenerationParams.Merge(req.GenerationParams)
+
+	var response domain.CompletionResponse
+	err := c.call(ctx, externalMethodComplete, externalCompletionParams{Model: c.config.DefaultModel, CompletionRequest: &params}, onChunk, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Model == "" {
+		response.Model = c.config.DefaultModel
+	}
+	return &response, nil
+}
+
+// CreateEmbeddings generates vector embeddings for the given input
+func (c *ExternalClient) CreateEmbeddings(ctx context.Context, req *domain.EmbeddingRequest) (*domain.EmbeddingResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("embedding request is required")
+	}
+	params := *req
+	if params.Model == "" {
+		params.Model = c.config.DefaultEmbeddingModel
+	}
+
+	var response domain.EmbeddingResponse
+	if err := c.call(ctx, externalMethodEmbed, params, nil, &response); err != nil {
+		return nil, err
+	}
+	if response.Model == "" {
+		response.Model = params.Model
+	}
+	return &response, nil
+}
+
+// GetSupportedEmbeddingModels returns the configured embedding models, or
+// those the program reported when it started
+func (c *ExternalClient) GetSupportedEmbeddingModels() []string {
+	if len(c.config.EmbeddingModels) > 0 {
+		models := make([]string, 0, len(c.config.EmbeddingModels))
+		for model := range c.config.EmbeddingModels {
+			models = append(models, model)
+		}
+		return models
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.info.EmbeddingModels...)
+}
+
+// GetMaxEmbeddingTokens returns the token limit of an embedding model
+func (c *ExternalClient) GetMaxEmbeddingTokens(model string) int {
+	if modelConfig, ok := c.config.EmbeddingModels[model]; ok && modelConfig.MaxTokens > 0 {
+		return modelConfig.MaxTokens
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.info.MaxEmbeddingTokens
+}
+
+// GetProviderType returns the type of this provider
+func (c *ExternalClient) GetProviderType() domain.ProviderType {
+	return c.providerType
+}
+
+// GetInterfaceType returns the interface type of this provider
+func (c *ExternalClient) GetInterfaceType() config.InterfaceType {
+	return config.External
+}
+
+// ValidateConfig validates the provider configuration
+func (c *ExternalClient) ValidateConfig() error {
+	if c.config == nil {
+		return fmt.Errorf("provider configuration is required")
+	}
+	if c.config.Command == "" {
+		return fmt.Errorf("command is required for external provider '%s'", c.providerType)
+	}
+	return nil
+}
+
+// Close stops the provider program
+func (c *ExternalClient) Close() error {
+	c.mu.Lock()
+	proc := c.proc
+	c.proc = nil
+	c.mu.Unlock()
+
+	if proc != nil {
+		proc.stop()
+	}
+	return nil
+}
+
+// call sends a request and decodes its result into out. onChunk receives
+// any streamed chunks.
+func (c *ExternalClient) call(ctx context.Context, method string, params interface{}, onChunk func(string) error, out interface{}) error {
+	if c.config.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.config.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+
+	proc, err := c.process(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := proc.call(ctx, method, params, onChunk)
+	if err != nil {
+		return fmt.Errorf("%s request to provider '%s' failed: %w", method, c.providerType, err)
+	}
+	if out != nil && len(result) > 0 {
+		if err := json.Unmarshal(result, out); err != nil {
+			return fmt.Errorf("invalid %s result from provider '%s': %w", method, c.providerType, err)
+		}
+	}
+	return nil
+}
+
+// process returns the running program, starting it and completing the
+// initialize handshake if needed
+func (c *ExternalClient) process(ctx context.Context) (*externalProcess, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.proc != nil && !c.proc.exited() {
+		return c.proc, nil
+	}
+
+	proc, err := startExternalProcess(string(c.providerType), c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := proc.call(ctx, externalMethodInitialize, externalInitializeParams{
+		ProtocolVersion: ExternalProtocolVersion,
+		Provider:        string(c.providerType),
+		Model:           c.config.DefaultModel,
+		APIKey:          c.config.APIKey,
+		APIEndpoint:     c.config.APIEndpoint,
+	}, nil)
+	if err == nil && len(result) > 0 {
+		var info externalInfo
+		if err = json.Unmarshal(result, &info); err == nil {
+			c.info = info
+		}
+	}
+	if err == nil && c.info.ProtocolVersion > ExternalProtocolVersion {
+		err = fmt.Errorf("program speaks protocol version %d; this mcp-cli supports up to %d", c.info.ProtocolVersion, ExternalProtocolVersion)
+	}
+	if err != nil {
+		proc.stop()
+		return nil, fmt.Errorf("failed to initialize external provider '%s': %w", c.providerType, err)
+	}
+
+	logging.Info("External provider '%s' started (PID %d)", c.providerType, proc.cmd.Process.Pid)
+	c.proc = proc
+	return proc, nil
+}
+
+// externalProcess is a running provider program. Requests are matched to
+// responses by ID, so several can be in flight at once.
+type externalProcess struct {
+	name  string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]*externalCall
+
+	done chan struct{}
+	err  error // Why the program stopped; set before done is closed
+}
+
+// externalCall is a request waiting for its result
+type externalCall struct {
+	responses chan externalResponse
+	gone      chan struct{} // Closed when the caller stops listening
+}
+
+func startExternalProcess(name string, cfg *config.ProviderConfig) (*externalProcess, error) {
+	cmd := exec.Command(cfg.Command, cfg.Args...)
+	if len(cfg.Env) > 0 {
+		env := os.Environ()
+		for key, value := range cfg.Env {
+			env = append(env, key+"="+value)
+		}
+		cmd.Env = env
+	}
+	cmd.Stderr = &stderrLogger{name: name}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start external provider '%s': %w", name, err)
+	}
+
+	proc := &externalProcess{
+		name:    name,
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[int64]*externalCall),
+		done:    make(chan struct{}),
+	}
+	go proc.readLoop(stdout)
+	return proc, nil
+}
+
+// call sends a request and waits for its result, passing streamed chunks
+// to onChunk
+func (p *externalProcess) call(ctx context.Context, method string, params interface{}, onChunk func(string) error) (json.RawMessage, error) {
+	pending := &externalCall{
+		responses: make(chan externalResponse, 16),
+		gone:      make(chan struct{}),
+	}
+	p.mu.Lock()
+	p.nextID++
+	id := p.nextID
+	p.pending[id] = pending
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		close(pending.gone)
+	}()
+
+	if err := p.send(externalRequest{ID: id, Method: method, Params: params}); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case response := <-pending.responses:
+			switch {
+			case response.Error != nil:
+				return nil, response.Error
+			case response.Result != nil:
+				return response.Result, nil
+			case onChunk != nil && response.Chunk != "":
+				if err := onChunk(response.Chunk); err != nil {
+					p.send(externalRequest{Method: externalMethodCancel, Params: map[string]int64{"id": id}})
+					return nil, err
+				}
+			}
+		case <-p.done:
+			return nil, p.err
+		case <-ctx.Done():
+			// Best effort: let the program stop work nobody is waiting for
+			p.send(externalRequest{Method: externalMethodCancel, Params: map[string]int64{"id": id}})
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// send writes one request line
+func (p *externalProcess) send(req externalRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", req.Method, err)
+	}
+	data = append(data, '\n')
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	if _, err := p.stdin.Write(data); err != nil {
+		return fmt.Errorf("failed to write to external provider '%s': %w", p.name, err)
+	}
+	return nil
+}
+
+// readLoop routes the program's output lines to the waiting calls until
+// the program exits
+func (p *externalProcess) readLoop(stdout io.Reader) {
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			p.dispatch(line)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	waitErr := p.cmd.Wait()
+	if waitErr != nil {
+		p.err = fmt.Errorf("external provider '%s' exited: %w", p.name, waitErr)
+	} else {
+		p.err = fmt.Errorf("external provider '%s' exited", p.name)
+	}
+	close(p.done)
+}
+
+func (p *externalProcess) dispatch(line []byte) {
+	var response externalResponse
+	if err := json.Unmarshal(line, &response); err != nil || response.ID == 0 {
+		if len(line) > 200 {
+			line = line[:200]
+		}
+		logging.Warn("Ignoring unexpected output from external provider '%s': %s", p.name, line)
+		return
+	}
+
+	p.mu.Lock()
+	pending, ok := p.pending[response.ID]
+	p.mu.Unlock()
+	if !ok {
+		logging.Debug("Dropping response from external provider '%s' for finished request %d", p.name, response.ID)
+		return
+	}
+	select {
+	case pending.responses <- response:
+	case <-pending.gone:
+	}
+}
+
+func (p *externalProcess) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop closes the program's stdin and kills it if it doesn't exit promptly
+func (p *externalProcess) stop() {
+	p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(externalShutdownGrace):
+		logging.Warn("External provider '%s' did not exit; killing it", p.name)
+		p.cmd.Process.Kill()
+		<-p.done
+	}
+}
+
+// stderrLogger logs what a provider program writes to stderr
+type stderrLogger struct {
+	name string
+}
+
+func (l *stderrLogger) Write(data []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if line != "" {
+			logging.Debug("[%s] %s", l.name, line)
+		}
+	}
+	return len(data), nil
+}
@@ -0,0 +1,161 @@
+package clients
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain lets the test binary stand in for an external provider program
+func TestMain(m *testing.M) {
+	if os.Getenv("EXTERNAL_PROVIDER_HELPER") == "1" {
+		runFakeProvider()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeProvider echoes the last user message back, streaming it word by
+// word when asked, and embeds each input as its length
+func runFakeProvider() {
+	scanner := bufio.NewScanner(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+	var model string
+	for scanner.Scan() {
+		var req struct {
+			ID     int64           `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Fprintln(os.Stderr, "bad request:", err)
+			continue
+		}
+
+		switch req.Method {
+		case "initialize":
+			var params externalInitializeParams
+			json.Unmarshal(req.Params, &params)
+			model = params.Model
+			encoder.Encode(map[string]interface{}{"id": req.ID, "result": map[string]interface{}{
+				"protocol_version": 1, "embedding_models": []string{"len"}, "max_embedding_tokens": 512,
+			}})
+		case "complete":
+			var params struct {
+				Model string `json:"model"`
+				domain.CompletionRequest
+			}
+			json.Unmarshal(req.Params, &params)
+			last := params.Messages[len(params.Messages)-1].Content
+			if last == "fail" {
+				encoder.Encode(map[string]interface{}{"id": req.ID, "error": map[string]string{"code": "quota", "message": "out of credit"}})
+				continue
+			}
+			if params.Stream {
+				for _, word := range strings.Fields(last) {
+					encoder.Encode(map[string]interface{}{"id": req.ID, "chunk": word + " "})
+				}
+				encoder.Encode(map[string]interface{}{"id": req.ID, "result": map[string]interface{}{}})
+				continue
+			}
+			encoder.Encode(map[string]interface{}{"id": req.ID, "result": domain.CompletionResponse{
+				Response: params.SystemPrompt + ": " + last,
+				Model:    model,
+				Usage:    &domain.Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+			}})
+		case "embed":
+			var params domain.EmbeddingRequest
+			json.Unmarshal(req.Params, &params)
+			var data []domain.Embedding
+			for i, input := range params.Input {
+				data = append(data, domain.Embedding{Index: i, Embedding: []float32{float32(len(input))}})
+			}
+			encoder.Encode(map[string]interface{}{"id": req.ID, "result": domain.EmbeddingResponse{Data: data}})
+		}
+	}
+}
+
+func newFakeExternalClient(t *testing.T) *ExternalClient {
+	t.Helper()
+	provider, err := NewExternalClient("gateway", &config.ProviderConfig{
+		Command:      os.Args[0],
+		Env:          map[string]string{"EXTERNAL_PROVIDER_HELPER": "1"},
+		DefaultModel: "house-model",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { provider.Close() })
+	return provider.(*ExternalClient)
+}
+
+func TestExternalClient(t *testing.T) {
+	client := newFakeExternalClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := client.CreateCompletion(ctx, &domain.CompletionRequest{
+		SystemPrompt: "echo",
+		Messages:     []domain.Message{{Role: "user", Content: "hello there"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "echo: hello there", response.Response)
+	assert.Equal(t, "house-model", response.Model)
+	assert.Equal(t, 5, response.Usage.TotalTokens)
+
+	var streamed strings.Builder
+	response, err = client.StreamCompletion(ctx, &domain.CompletionRequest{
+		Messages: []domain.Message{{Role: "user", Content: "one two three"}},
+	}, &streamed)
+	require.NoError(t, err)
+	assert.Equal(t, "one two three ", streamed.String())
+	assert.Equal(t, "one two three ", response.Response)
+
+	_, err = client.CreateCompletion(ctx, &domain.CompletionRequest{
+		Messages: []domain.Message{{Role: "user", Content: "fail"}},
+	})
+	assert.ErrorContains(t, err, "quota: out of credit")
+
+	embeddings, err := client.CreateEmbeddings(ctx, &domain.EmbeddingRequest{Input: []string{"ab", "abcd"}})
+	require.NoError(t, err)
+	require.Len(t, embeddings.Data, 2)
+	assert.Equal(t, float32(4), embeddings.Data[1].Embedding[0])
+	assert.Equal(t, []string{"len"}, client.GetSupportedEmbeddingModels())
+	assert.Equal(t, 512, client.GetMaxEmbeddingTokens("len"))
+}
+
+func TestExternalClientRestartsProgram(t *testing.T) {
+	client := newFakeExternalClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req := &domain.CompletionRequest{Messages: []domain.Message{{Role: "user", Content: "hi"}}}
+	_, err := client.CreateCompletion(ctx, req)
+	require.NoError(t, err)
+
+	client.mu.Lock()
+	client.proc.cmd.Process.Kill()
+	<-client.proc.done
+	client.mu.Unlock()
+
+	_, err = client.CreateCompletion(ctx, req)
+	assert.NoError(t, err)
+}
+
+func TestExternalClientConfig(t *testing.T) {
+	_, err := NewExternalClient("gateway", &config.ProviderConfig{DefaultModel: "m"})
+	assert.ErrorContains(t, err, "command is required")
+
+	client, err := NewExternalClient("gateway", &config.ProviderConfig{Command: "/nonexistent/provider"})
+	require.NoError(t, err)
+	_, err = client.CreateCompletion(context.Background(), &domain.CompletionRequest{})
+	assert.ErrorContains(t, err, "failed to start")
+}
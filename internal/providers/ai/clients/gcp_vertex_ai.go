@@ -4,55 +4,19 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"crypto"
-	"crypto/rsa"
-	"crypto/sha256"
-	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/auth"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 )
 
-// GCP Service Account structure
-type gcpServiceAccount struct {
-	Type                    string `json:"type"`
-	ProjectID               string `json:"project_id"`
-	PrivateKeyID            string `json:"private_key_id"`
-	PrivateKey              string `json:"private_key"`
-	ClientEmail             string `json:"client_email"`
-	ClientID                string `json:"client_id"`
-	AuthURI                 string `json:"auth_uri"`
-	TokenURI                string `json:"token_uri"`
-	AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url"`
-	ClientX509CertURL       string `json:"client_x509_cert_url"`
-}
-
-// JWT claims for OAuth2
-type jwtClaims struct {
-	Iss   string `json:"iss"`
-	Scope string `json:"scope"`
-	Aud   string `json:"aud"`
-	Exp   int64  `json:"exp"`
-	Iat   int64  `json:"iat"`
-}
-
-// OAuth2 token response
-type oauth2TokenResponse struct {
-	AccessToken string `json:"access_token"`
-	TokenType   string `json:"token_type"`
-	ExpiresIn   int    `json:"expires_in"`
-}
-
 // Vertex AI Gemini request/response structures
 type vertexGeminiRequest struct {
 	Contents         []vertexContent       `json:"contents"`
@@ -70,11 +34,37 @@ type vertexPart struct {
 }
 
 type vertexGenConfig struct {
-	Temperature      float64 `json:"temperature,omitempty"`
-	MaxOutputTokens  int     `json:"maxOutputTokens,omitempty"`
-	TopP             float64 `json:"topP,omitempty"`
-	TopK             int     `json:"topK,omitempty"`
-	ResponseMimeType string  `json:"responseMimeType,omitempty"` // text/plain disables code execution
+	Temperature      float64  `json:"temperature,omitempty"`
+	MaxOutputTokens  int      `json:"maxOutputTokens,omitempty"`
+	TopP             *float64 `json:"topP,omitempty"`
+	TopK             *int     `json:"topK,omitempty"`
+	PresencePenalty  *float64 `json:"presencePenalty,omitempty"`
+	FrequencyPenalty *float64 `json:"frequencyPenalty,omitempty"`
+	StopSequences    []string `json:"stopSequences,omitempty"`
+	ResponseMimeType string   `json:"responseMimeType,omitempty"` // text/plain disables code execution
+}
+
+// newVertexGenConfig returns the generation config for a request's sampling
+// settings, defaulting to a temperature of 0.7 and 2048 tokens
+func newVertexGenConfig(provider string, settings sampling) *vertexGenConfig {
+	warnUnsupported(provider, settings.GenerationParams, "top_p", "top_k", "presence_penalty", "frequency_penalty", "stop")
+	genConfig := &vertexGenConfig{
+		Temperature:      0.7,
+		MaxOutputTokens:  2048,
+		TopP:             settings.TopP,
+		TopK:             settings.TopK,
+		PresencePenalty:  settings.PresencePenalty,
+		FrequencyPenalty: settings.FrequencyPenalty,
+		StopSequences:    settings.Stop,
+		ResponseMimeType: "text/plain", // Disable code execution
+	}
+	if settings.Temperature > 0 {
+		genConfig.Temperature = settings.Temperature
+	}
+	if settings.MaxTokens > 0 {
+		genConfig.MaxOutputTokens = settings.MaxTokens
+	}
+	return genConfig
 }
 
 type vertexSafetySetting struct {
@@ -113,17 +103,15 @@ type vertexEmbeddingPrediction struct {
 
 // GCPVertexAIClient implements domain.LLMProvider for Google Cloud Vertex AI
 type GCPVertexAIClient struct {
-	httpClient     *http.Client
-	projectID      string
-	location       string
-	model          string
-	accessToken    string
-	tokenExpiry    time.Time
-	serviceAccount *gcpServiceAccount
-	providerType   domain.ProviderType
-	config         *config.ProviderConfig
-	timeout        time.Duration
-	maxRetries     int
+	httpClient   *http.Client
+	projectID    string
+	location     string
+	model        string
+	tokens       *auth.GoogleTokenSource
+	providerType domain.ProviderType
+	config       *config.ProviderConfig
+	timeout      time.Duration
+	maxRetries   int
 }
 
 // NewGCPVertexAIClient creates a new GCP Vertex AI provider
@@ -192,12 +180,8 @@ func (c *GCPVertexAIClient) CreateCompletion(ctx context.Context, req *domain.Co
 	contents := c.convertToVertexContents(req.Messages, req.SystemPrompt)
 
 	vertexReq := vertexGeminiRequest{
-		Contents: contents,
-		GenerationConfig: &vertexGenConfig{
-			Temperature:      0.7,
-			MaxOutputTokens:  2048,
-			ResponseMimeType: "text/plain", // Disable code execution
-		},
+		Contents:         contents,
+		GenerationConfig: newVertexGenConfig(string(c.providerType), resolveSampling(c.config, req)),
 	}
 
 	payloadBytes, err := json.Marshal(vertexReq)
@@ -217,7 +201,8 @@ func (c *GCPVertexAIClient) CreateCompletion(ctx context.Context, req *domain.Co
 		}
 
 		// Ensure we have a valid access token
-		if err := c.ensureAccessToken(); err != nil {
+		token, err := c.accessToken(ctx)
+		if err != nil {
 			lastErr = fmt.Errorf("failed to get access token: %w", err)
 			continue
 		}
@@ -229,7 +214,7 @@ func (c *GCPVertexAIClient) CreateCompletion(ctx context.Context, req *domain.Co
 		}
 
 		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("Authorization", "Bearer "+c.accessToken)
+		httpReq.Header.Set("Authorization", "Bearer "+token)
 
 		resp, err := c.httpClient.Do(httpReq)
 		if err != nil {
@@ -276,12 +261,8 @@ func (c *GCPVertexAIClient) StreamCompletion(ctx context.Context, req *domain.Co
 	contents := c.convertToVertexContents(req.Messages, req.SystemPrompt)
 
 	vertexReq := vertexGeminiRequest{
-		Contents: contents,
-		GenerationConfig: &vertexGenConfig{
-			Temperature:      0.7,
-			MaxOutputTokens:  2048,
-			ResponseMimeType: "text/plain", // Disable code execution
-		},
+		Contents:         contents,
+		GenerationConfig: newVertexGenConfig(string(c.providerType), resolveSampling(c.config, req)),
 	}
 
 	payloadBytes, err := json.Marshal(vertexReq)
@@ -300,7 +281,8 @@ func (c *GCPVertexAIClient) StreamCompletion(ctx context.Context, req *domain.Co
 			time.Sleep(time.Duration(retry) * 2 * time.Second)
 		}
 
-		if err := c.ensureAccessToken(); err != nil {
+		token, err := c.accessToken(ctx)
+		if err != nil {
 			lastErr = err
 			continue
 		}
@@ -312,7 +294,7 @@ func (c *GCPVertexAIClient) StreamCompletion(ctx context.Context, req *domain.Co
 		}
 
 		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("Authorization", "Bearer "+c.accessToken)
+		httpReq.Header.Set("Authorization", "Bearer "+token)
 
 		resp, err := c.httpClient.Do(httpReq)
 		if err != nil {
@@ -421,7 +403,8 @@ func (c *GCPVertexAIClient) CreateEmbeddings(ctx context.Context, req *domain.Em
 			time.Sleep(time.Duration(retry) * 2 * time.Second)
 		}
 
-		if err := c.ensureAccessToken(); err != nil {
+		token, err := c.accessToken(ctx)
+		if err != nil {
 			lastErr = err
 			continue
 		}
@@ -433,7 +416,7 @@ func (c *GCPVertexAIClient) CreateEmbeddings(ctx context.Context, req *domain.Em
 		}
 
 		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("Authorization", "Bearer "+c.accessToken)
+		httpReq.Header.Set("Authorization", "Bearer "+token)
 
 		resp, err := c.httpClient.Do(httpReq)
 		if err != nil {
@@ -550,128 +533,23 @@ func (c *GCPVertexAIClient) convertToVertexContents(messages []domain.Message, s
 	return contents
 }
 
-// ensureAccessToken ensures we have a valid OAuth2 access token
-func (c *GCPVertexAIClient) ensureAccessToken() error {
-	// Check if token is still valid (with 5 minute buffer)
-	if c.accessToken != "" && time.Now().Add(5*time.Minute).Before(c.tokenExpiry) {
-		return nil
-	}
-
-	if c.serviceAccount == nil {
-		return fmt.Errorf("service account credentials required - set credentials_path in config")
-	}
-
-	// Create JWT
-	now := time.Now()
-	claims := jwtClaims{
-		Iss:   c.serviceAccount.ClientEmail,
-		Scope: "https://www.googleapis.com/auth/cloud-platform",
-		Aud:   c.serviceAccount.TokenURI,
-		Exp:   now.Add(time.Hour).Unix(),
-		Iat:   now.Unix(),
-	}
-
-	// Create JWT header and payload
-	header := map[string]string{
-		"alg": "RS256",
-		"typ": "JWT",
-	}
-
-	headerJSON, _ := json.Marshal(header)
-	claimsJSON, _ := json.Marshal(claims)
-
-	headerB64 := base64.RawURLEncoding.EncodeToString(headerJSON)
-	claimsB64 := base64.RawURLEncoding.EncodeToString(claimsJSON)
-
-	signInput := headerB64 + "." + claimsB64
-
-	// Sign with private key
-	privateKey, err := parsePrivateKey(c.serviceAccount.PrivateKey)
-	if err != nil {
-		return fmt.Errorf("failed to parse private key: %w", err)
-	}
-
-	hash := sha256.Sum256([]byte(signInput))
-	signature, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA256, hash[:])
-	if err != nil {
-		return fmt.Errorf("failed to sign JWT: %w", err)
-	}
-
-	signatureB64 := base64.RawURLEncoding.EncodeToString(signature)
-	jwt := signInput + "." + signatureB64
-
-	// Exchange JWT for access token
-	tokenReq := fmt.Sprintf("grant_type=urn:ietf:params:oauth:grant-type:jwt-bearer&assertion=%s", jwt)
-
-	httpReq, err := http.NewRequest("POST", c.serviceAccount.TokenURI, strings.NewReader(tokenReq))
-	if err != nil {
-		return err
-	}
-
-	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("OAuth2 token exchange failed (%s): %s", resp.Status, string(body))
-	}
-
-	var tokenResp oauth2TokenResponse
-	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return fmt.Errorf("failed to parse token response: %w", err)
+// accessToken returns a valid OAuth2 access token for the service account
+func (c *GCPVertexAIClient) accessToken(ctx context.Context) (string, error) {
+	if c.tokens == nil {
+		return "", fmt.Errorf("service account credentials required - set credentials_path in config")
 	}
-
-	c.accessToken = tokenResp.AccessToken
-	c.tokenExpiry = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-
-	logging.Debug("Successfully obtained OAuth2 access token, expires at %v", c.tokenExpiry)
-
-	return nil
+	return c.tokens.Token(ctx)
 }
 
 // loadServiceAccount loads service account credentials from file
 func (c *GCPVertexAIClient) loadServiceAccount(path string) error {
-	data, err := os.ReadFile(path)
+	account, err := auth.LoadGoogleServiceAccount(path)
 	if err != nil {
-		return fmt.Errorf("failed to read service account file: %w", err)
-	}
-
-	var sa gcpServiceAccount
-	if err := json.Unmarshal(data, &sa); err != nil {
-		return fmt.Errorf("failed to parse service account JSON: %w", err)
+		return err
 	}
 
-	c.serviceAccount = &sa
-	c.projectID = sa.ProjectID
+	c.tokens = auth.NewGoogleTokenSource(account, auth.GoogleCloudPlatformScope, c.httpClient)
+	c.projectID = account.ProjectID
 
 	return nil
 }
-
-// parsePrivateKey parses RSA private key from PEM format
-func parsePrivateKey(pemKey string) (*rsa.PrivateKey, error) {
-	block, _ := pem.Decode([]byte(pemKey))
-	if block == nil {
-		return nil, fmt.Errorf("failed to parse PEM block")
-	}
-
-	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
-	}
-
-	rsaKey, ok := key.(*rsa.PrivateKey)
-	if !ok {
-		return nil, fmt.Errorf("not an RSA private key")
-	}
-
-	return rsaKey, nil
-}
@@ -3,20 +3,16 @@ package clients
 import (
 	"bytes"
 	"context"
-	"crypto"
-	"crypto/rsa"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/auth"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 )
 
@@ -24,15 +20,13 @@ import (
 // Uses OpenAI-compatible endpoint for chat/completions (supports tool calling)
 // Uses native Vertex AI endpoint for embeddings (OpenAI endpoint doesn't support them)
 type GCPVertexAIOpenAIClient struct {
-	openaiClient   *OpenAICompatibleClient
-	projectID      string
-	location       string
-	serviceAccount *gcpServiceAccount
-	httpClient     *http.Client
-	accessToken    string
-	tokenExpiry    time.Time
-	providerType   domain.ProviderType
-	config         *config.ProviderConfig
+	openaiClient *OpenAICompatibleClient
+	projectID    string
+	location     string
+	tokens       *auth.GoogleTokenSource
+	httpClient   *http.Client
+	providerType domain.ProviderType
+	config       *config.ProviderConfig
 }
 
 // NewGCPVertexAIOpenAIClient creates a Vertex AI client using OpenAI-compatible endpoint
@@ -88,7 +82,8 @@ func NewGCPVertexAIOpenAIClient(providerType domain.ProviderType, cfg *config.Pr
 	}
 
 	// Get initial OAuth2 token
-	if err := wrapper.ensureAccessToken(); err != nil {
+	token, err := wrapper.tokens.Token(context.Background())
+	if err != nil {
 		return nil, fmt.Errorf("failed to obtain initial access token: %w", err)
 	}
 
@@ -99,7 +94,7 @@ func NewGCPVertexAIOpenAIClient(providerType domain.ProviderType, cfg *config.Pr
 
 	// Create modified config for OpenAI client
 	openaiConfig := &config.ProviderConfig{
-		APIKey:          wrapper.accessToken, // Will be updated before each request
+		APIKey:          token, // Will be updated before each request
 		APIEndpoint:     openaiEndpoint,
 		DefaultModel:    model, // Now in "google/gemini-2.5-flash" format
 		TimeoutSeconds:  cfg.TimeoutSeconds,
@@ -124,7 +119,7 @@ func NewGCPVertexAIOpenAIClient(providerType domain.ProviderType, cfg *config.Pr
 // CreateCompletion implements domain.LLMProvider
 func (c *GCPVertexAIOpenAIClient) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
 	// Refresh OAuth2 token and update OpenAI client
-	if err := c.refreshClientToken(); err != nil {
+	if err := c.refreshClientToken(ctx); err != nil {
 		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
 
@@ -135,7 +130,7 @@ func (c *GCPVertexAIOpenAIClient) CreateCompletion(ctx context.Context, req *dom
 // StreamCompletion implements domain.LLMProvider
 func (c *GCPVertexAIOpenAIClient) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, writer io.Writer) (*domain.CompletionResponse, error) {
 	// Refresh OAuth2 token and update OpenAI client
-	if err := c.refreshClientToken(); err != nil {
+	if err := c.refreshClientToken(ctx); err != nil {
 		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
 
@@ -151,7 +146,8 @@ func (c *GCPVertexAIOpenAIClient) CreateEmbeddings(ctx context.Context, req *dom
 	}
 
 	// Ensure we have a valid access token
-	if err := c.ensureAccessToken(); err != nil {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
 
@@ -191,7 +187,7 @@ func (c *GCPVertexAIOpenAIClient) CreateEmbeddings(ctx context.Context, req *dom
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.accessToken)
+	httpReq.Header.Set("Authorization", "Bearer "+token)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -267,7 +263,7 @@ func (c *GCPVertexAIOpenAIClient) ValidateConfig() error {
 	if c.projectID == "" {
 		return fmt.Errorf("project ID is required")
 	}
-	if c.serviceAccount == nil {
+	if c.tokens == nil {
 		return fmt.Errorf("service account credentials required")
 	}
 	return nil
@@ -282,120 +278,28 @@ func (c *GCPVertexAIOpenAIClient) Close() error {
 }
 
 // refreshClientToken ensures token is fresh and updates the OpenAI client
-func (c *GCPVertexAIOpenAIClient) refreshClientToken() error {
-	if err := c.ensureAccessToken(); err != nil {
-		return err
-	}
-
-	// Update the OpenAI client's API key with fresh token
-	c.openaiClient.apiKey = c.accessToken
-
-	return nil
-}
-
-// ensureAccessToken ensures we have a valid OAuth2 access token
-func (c *GCPVertexAIOpenAIClient) ensureAccessToken() error {
-	// Check if token is still valid (with 5 minute buffer)
-	if c.accessToken != "" && time.Now().Add(5*time.Minute).Before(c.tokenExpiry) {
-		return nil
-	}
-
-	logging.Debug("Refreshing Vertex AI OAuth2 token...")
-
-	// Create JWT
-	now := time.Now()
-	claims := jwtClaims{
-		Iss:   c.serviceAccount.ClientEmail,
-		Scope: "https://www.googleapis.com/auth/cloud-platform",
-		Aud:   c.serviceAccount.TokenURI,
-		Exp:   now.Add(time.Hour).Unix(),
-		Iat:   now.Unix(),
-	}
-
-	// Create JWT header and payload
-	header := map[string]string{
-		"alg": "RS256",
-		"typ": "JWT",
-	}
-
-	headerJSON, _ := json.Marshal(header)
-	claimsJSON, _ := json.Marshal(claims)
-
-	headerB64 := base64.RawURLEncoding.EncodeToString(headerJSON)
-	claimsB64 := base64.RawURLEncoding.EncodeToString(claimsJSON)
-
-	signInput := headerB64 + "." + claimsB64
-
-	// Sign with private key
-	privateKey, err := parsePrivateKey(c.serviceAccount.PrivateKey)
-	if err != nil {
-		return fmt.Errorf("failed to parse private key: %w", err)
-	}
-
-	hash := sha256.Sum256([]byte(signInput))
-	signature, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA256, hash[:])
-	if err != nil {
-		return fmt.Errorf("failed to sign JWT: %w", err)
-	}
-
-	signatureB64 := base64.RawURLEncoding.EncodeToString(signature)
-	jwt := signInput + "." + signatureB64
-
-	// Exchange JWT for access token
-	tokenReq := fmt.Sprintf("grant_type=urn:ietf:params:oauth:grant-type:jwt-bearer&assertion=%s", jwt)
-
-	httpReq, err := http.NewRequest("POST", c.serviceAccount.TokenURI, strings.NewReader(tokenReq))
+func (c *GCPVertexAIOpenAIClient) refreshClientToken(ctx context.Context) error {
+	token, err := c.tokens.Token(ctx)
 	if err != nil {
 		return err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("OAuth2 token exchange failed (%s): %s", resp.Status, string(body))
-	}
-
-	var tokenResp oauth2TokenResponse
-	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return fmt.Errorf("failed to parse token response: %w", err)
-	}
-
-	c.accessToken = tokenResp.AccessToken
-	c.tokenExpiry = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-
-	logging.Debug("Successfully obtained OAuth2 access token, expires at %v", c.tokenExpiry)
+	// Update the OpenAI client's API key with fresh token
+	c.openaiClient.apiKey = token
 
 	return nil
 }
 
 // loadServiceAccount loads service account credentials from file
 func (c *GCPVertexAIOpenAIClient) loadServiceAccount(path string) error {
-	data, err := os.ReadFile(path)
+	account, err := auth.LoadGoogleServiceAccount(path)
 	if err != nil {
-		return fmt.Errorf("failed to read service account file: %w", err)
-	}
-
-	var sa gcpServiceAccount
-	if err := json.Unmarshal(data, &sa); err != nil {
-		return fmt.Errorf("failed to parse service account JSON: %w", err)
+		return err
 	}
 
-	c.serviceAccount = &sa
+	c.tokens = auth.NewGoogleTokenSource(account, auth.GoogleCloudPlatformScope, c.httpClient)
 
-	logging.Info("Loaded service account: %s", sa.ClientEmail)
+	logging.Info("Loaded service account: %s", account.ClientEmail)
 
 	return nil
 }
-
-// Note: parsePrivateKey() is defined in gcp_vertex_ai.go at package level
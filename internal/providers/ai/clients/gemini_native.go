@@ -39,6 +39,13 @@ type geminiPart struct {
 	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
 	ThoughtSignature string                  `json:"thoughtSignature,omitempty"`
 	Thought          bool                    `json:"thought,omitempty"`
+	InlineData       *geminiInlineData       `json:"inlineData,omitempty"`
+}
+
+// geminiInlineData is base64 media sent inline, such as an image
+type geminiInlineData struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
 }
 
 type geminiFunctionCall struct {
@@ -69,8 +76,36 @@ type geminiGenerateContentRequest struct {
 }
 
 type geminiGenerationConfig struct {
-	Temperature     *float64 `json:"temperature,omitempty"`
-	MaxOutputTokens *int     `json:"maxOutputTokens,omitempty"`
+	Temperature      *float64 `json:"temperature,omitempty"`
+	MaxOutputTokens  *int     `json:"maxOutputTokens,omitempty"`
+	TopP             *float64 `json:"topP,omitempty"`
+	TopK             *int     `json:"topK,omitempty"`
+	PresencePenalty  *float64 `json:"presencePenalty,omitempty"`
+	FrequencyPenalty *float64 `json:"frequencyPenalty,omitempty"`
+	StopSequences    []string `json:"stopSequences,omitempty"`
+}
+
+// newGeminiGenerationConfig returns the generation config for a request's
+// settings, or nil when it has none
+func newGeminiGenerationConfig(provider string, settings sampling) *geminiGenerationConfig {
+	warnUnsupported(provider, settings.GenerationParams, "top_p", "top_k", "presence_penalty", "frequency_penalty", "stop")
+	if settings.Temperature <= 0 && settings.MaxTokens <= 0 && settings.IsZero() {
+		return nil
+	}
+	genConfig := &geminiGenerationConfig{
+		TopP:             settings.TopP,
+		TopK:             settings.TopK,
+		PresencePenalty:  settings.PresencePenalty,
+		FrequencyPenalty: settings.FrequencyPenalty,
+		StopSequences:    settings.Stop,
+	}
+	if settings.Temperature > 0 {
+		genConfig.Temperature = &settings.Temperature
+	}
+	if settings.MaxTokens > 0 {
+		genConfig.MaxOutputTokens = &settings.MaxTokens
+	}
+	return genConfig
 }
 
 type geminiGenerateContentResponse struct {
@@ -140,7 +175,7 @@ func NewGeminiNativeClient(providerType domain.ProviderType, cfg *config.Provide
 // CreateCompletion implements domain.LLMProvider
 func (c *GeminiNativeClient) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
 	// Convert domain messages to Gemini format
-	contents, systemInstruction := convertToGeminiContents(req.Messages, req.SystemPrompt)
+	contents, systemInstruction := convertToGeminiContents(withToolImages(req.Messages, c.config.VisionEnabled(true)), req.SystemPrompt)
 
 	// Convert domain tools to Gemini format
 	var tools []geminiTool
@@ -151,17 +186,7 @@ func (c *GeminiNativeClient) CreateCompletion(ctx context.Context, req *domain.C
 	}
 
 	// Create generation config
-	var genConfig *geminiGenerationConfig
-	if req.Temperature > 0 || req.MaxTokens > 0 {
-		genConfig = &geminiGenerationConfig{}
-		if req.Temperature > 0 {
-			temp := req.Temperature
-			genConfig.Temperature = &temp
-		}
-		if req.MaxTokens > 0 {
-			genConfig.MaxOutputTokens = &req.MaxTokens
-		}
-	}
+	genConfig := newGeminiGenerationConfig(string(c.providerType), resolveSampling(c.config, req))
 
 	// Create request payload
 	payload := geminiGenerateContentRequest{
@@ -217,7 +242,7 @@ func (c *GeminiNativeClient) CreateCompletion(ctx context.Context, req *domain.C
 // StreamCompletion implements domain.LLMProvider
 func (c *GeminiNativeClient) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, writer io.Writer) (*domain.CompletionResponse, error) {
 	// Convert domain messages to Gemini format
-	contents, systemInstruction := convertToGeminiContents(req.Messages, req.SystemPrompt)
+	contents, systemInstruction := convertToGeminiContents(withToolImages(req.Messages, c.config.VisionEnabled(true)), req.SystemPrompt)
 
 	// Convert domain tools to Gemini format
 	var tools []geminiTool
@@ -228,17 +253,7 @@ func (c *GeminiNativeClient) StreamCompletion(ctx context.Context, req *domain.C
 	}
 
 	// Create generation config
-	var genConfig *geminiGenerationConfig
-	if req.Temperature > 0 || req.MaxTokens > 0 {
-		genConfig = &geminiGenerationConfig{}
-		if req.Temperature > 0 {
-			temp := req.Temperature
-			genConfig.Temperature = &temp
-		}
-		if req.MaxTokens > 0 {
-			genConfig.MaxOutputTokens = &req.MaxTokens
-		}
-	}
+	genConfig := newGeminiGenerationConfig(string(c.providerType), resolveSampling(c.config, req))
 
 	// Create request payload
 	payload := geminiGenerateContentRequest{
@@ -489,6 +504,11 @@ func convertToGeminiContents(messages []domain.Message, systemPrompt string) ([]
 					},
 				},
 			}
+			for _, img := range msg.Images {
+				content.Parts = append(content.Parts, geminiPart{
+					InlineData: &geminiInlineData{MimeType: img.MimeType, Data: img.Data},
+				})
+			}
 			contents = append(contents, content)
 			continue
 		default:
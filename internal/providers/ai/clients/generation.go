@@ -0,0 +1,101 @@
+package clients
+
+import (
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// sampling is a request's generation settings over the provider's defaults
+// from settings
+type sampling struct {
+	Temperature float64 // 0 when neither sets one
+	MaxTokens   int     // 0 when neither sets one
+	config.GenerationParams
+}
+
+// resolveSampling returns the settings for a request: its own, falling back
+// to the provider's
+func resolveSampling(cfg *config.ProviderConfig, req *domain.CompletionRequest) sampling {
+	s := sampling{Temperature: req.Temperature, MaxTokens: req.MaxTokens}
+	if cfg == nil {
+		s.GenerationParams = req.GenerationParams
+		return s
+	}
+	if s.Temperature == 0 {
+		s.Temperature = cfg.Temperature
+	}
+	if s.MaxTokens == 0 {
+		s.MaxTokens = cfg.MaxTokens
+	}
+	s.GenerationParams = cfg.GenerationParams.Merge(req.GenerationParams)
+	return s
+}
+
+// warnUnsupported logs the settings an interface has no way to send
+func warnUnsupported(provider string, params config.GenerationParams, supported ...string) {
+	var ignored []string
+	for _, name := range params.Names() {
+		found := false
+		for _, s := range supported {
+			if s == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ignored = append(ignored, name)
+		}
+	}
+	if len(ignored) > 0 {
+		logging.Warn("%s does not support %s; ignoring", provider, strings.Join(ignored, ", "))
+	}
+}
+
+// applyOpenAIParams sets the sampling settings of a chat completions
+// request. top_k isn't part of the OpenAI API, so it is left out.
+func applyOpenAIParams(payload *openaiChatRequest, provider string, params config.GenerationParams) {
+	warnUnsupported(provider, params, "top_p", "presence_penalty", "frequency_penalty", "stop", "logit_bias")
+	payload.TopP = params.TopP
+	payload.PresencePenalty = params.PresencePenalty
+	payload.FrequencyPenalty = params.FrequencyPenalty
+	payload.Stop = params.Stop
+	payload.LogitBias = params.LogitBias
+}
+
+// addAnthropicParams adds the settings the Anthropic Messages API supports
+// to a request payload: top_p, top_k and stop sequences
+func addAnthropicParams(payload map[string]interface{}, provider string, params config.GenerationParams) {
+	warnUnsupported(provider, params, "top_p", "top_k", "stop")
+	if params.TopP != nil {
+		payload["top_p"] = *params.TopP
+	}
+	if params.TopK != nil {
+		payload["top_k"] = *params.TopK
+	}
+	if len(params.Stop) > 0 {
+		payload["stop_sequences"] = params.Stop
+	}
+}
+
+// addOllamaOptions adds the settings Ollama supports to a request's options
+func addOllamaOptions(options map[string]interface{}, provider string, params config.GenerationParams) {
+	warnUnsupported(provider, params, "top_p", "top_k", "presence_penalty", "frequency_penalty", "stop")
+	if params.TopP != nil {
+		options["top_p"] = *params.TopP
+	}
+	if params.TopK != nil {
+		options["top_k"] = *params.TopK
+	}
+	if params.PresencePenalty != nil {
+		options["presence_penalty"] = *params.PresencePenalty
+	}
+	if params.FrequencyPenalty != nil {
+		options["frequency_penalty"] = *params.FrequencyPenalty
+	}
+	if len(params.Stop) > 0 {
+		options["stop"] = params.Stop
+	}
+}
@@ -0,0 +1,70 @@
+package clients
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSampling(t *testing.T) {
+	topP, override := 0.9, 0.5
+	topK := 40
+	cfg := &config.ProviderConfig{
+		Temperature:      0.7,
+		MaxTokens:        2048,
+		GenerationParams: config.GenerationParams{TopP: &topP, TopK: &topK},
+	}
+	req := &domain.CompletionRequest{
+		MaxTokens:        100,
+		GenerationParams: config.GenerationParams{TopP: &override, Stop: []string{"END"}},
+	}
+
+	s := resolveSampling(cfg, req)
+	assert.Equal(t, 0.7, s.Temperature)
+	assert.Equal(t, 100, s.MaxTokens)
+	assert.Equal(t, 0.5, *s.TopP)
+	assert.Equal(t, 40, *s.TopK)
+	assert.Equal(t, []string{"END"}, s.Stop)
+}
+
+func TestApplyOpenAIParams(t *testing.T) {
+	topP, penalty := 0.8, 0.3
+	topK := 20
+	payload := openaiChatRequest{Model: "gpt-4o"}
+	applyOpenAIParams(&payload, "openai", config.GenerationParams{
+		TopP:             &topP,
+		TopK:             &topK,
+		FrequencyPenalty: &penalty,
+		Stop:             []string{"\n\n"},
+	})
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	var sent map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &sent))
+
+	assert.Equal(t, 0.8, sent["top_p"])
+	assert.Equal(t, 0.3, sent["frequency_penalty"])
+	assert.Equal(t, []interface{}{"\n\n"}, sent["stop"])
+	assert.NotContains(t, sent, "top_k")
+	assert.NotContains(t, sent, "presence_penalty")
+}
+
+func TestNewGeminiGenerationConfig(t *testing.T) {
+	assert.Nil(t, newGeminiGenerationConfig("gemini", sampling{}))
+
+	topK := 32
+	genConfig := newGeminiGenerationConfig("gemini", sampling{
+		MaxTokens:        512,
+		GenerationParams: config.GenerationParams{TopK: &topK, Stop: []string{"END"}},
+	})
+	require.NotNil(t, genConfig)
+	assert.Nil(t, genConfig.Temperature)
+	assert.Equal(t, 512, *genConfig.MaxOutputTokens)
+	assert.Equal(t, 32, *genConfig.TopK)
+	assert.Equal(t, []string{"END"}, genConfig.StopSequences)
+}
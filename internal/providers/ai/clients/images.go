@@ -0,0 +1,50 @@
+package clients
+
+import (
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolcontent"
+)
+
+// maxAttachedImages bounds the images sent per request; older tool results
+// keep only their placeholder text
+const maxAttachedImages = 8
+
+// withToolImages returns the messages with the images saved from tool
+// results attached, newest first up to maxAttachedImages. The slice is
+// copied before any message is changed.
+func withToolImages(messages []domain.Message, enabled bool) []domain.Message {
+	if !enabled {
+		return messages
+	}
+
+	var result []domain.Message
+	remaining := maxAttachedImages
+	for i := len(messages) - 1; i >= 0 && remaining > 0; i-- {
+		msg := messages[i]
+		if msg.Role != "tool" || len(msg.Images) > 0 {
+			continue
+		}
+		images := toolcontent.Images(msg.Content)
+		if len(images) == 0 {
+			continue
+		}
+		if len(images) > remaining {
+			images = images[len(images)-remaining:]
+		}
+		remaining -= len(images)
+
+		if result == nil {
+			result = append([]domain.Message(nil), messages...)
+		}
+		result[i].Images = images
+	}
+	if result == nil {
+		return messages
+	}
+	return result
+}
+
+// dataURL returns an image as a data: URL
+func dataURL(img domain.Image) string {
+	return "data:" + img.MimeType + ";base64," + img.Data
+}
@@ -27,6 +27,7 @@ type OllamaClient struct {
 type ollamaChatMessage struct {
 	Role       string           `json:"role"`
 	Content    string           `json:"content"`
+	Images     []string         `json:"images,omitempty"` // Base64, for vision models
 	ToolCalls  []ollamaToolCall `json:"tool_calls,omitempty"`
 	ToolCallID string           `json:"tool_call_id,omitempty"`
 }
@@ -140,7 +141,7 @@ func (c *OllamaClient) CreateCompletion(ctx context.Context, req *domain.Complet
 	}
 
 	// Convert domain request to Ollama format
-	ollamaMessages := c.convertToOllamaMessages(req.Messages, req.SystemPrompt)
+	ollamaMessages := c.convertToOllamaMessages(withToolImages(req.Messages, c.config.VisionEnabled(false)), req.SystemPrompt)
 	ollamaTools := c.convertToOllamaTools(req.Tools)
 
 	// Add tool follow-up clarification if needed
@@ -167,6 +168,7 @@ func (c *OllamaClient) CreateCompletion(ctx context.Context, req *domain.Complet
 	if req.MaxTokens > 0 {
 		ollamaReq.Options["num_predict"] = req.MaxTokens
 	}
+	addOllamaOptions(ollamaReq.Options, string(c.providerType), resolveSampling(c.config, req).GenerationParams)
 
 	// Send request
 	url := c.config.APIEndpoint + ollamaChatEndpoint
@@ -195,7 +197,7 @@ func (c *OllamaClient) StreamCompletion(ctx context.Context, req *domain.Complet
 	}
 
 	// Convert domain request to Ollama format
-	ollamaMessages := c.convertToOllamaMessages(req.Messages, req.SystemPrompt)
+	ollamaMessages := c.convertToOllamaMessages(withToolImages(req.Messages, c.config.VisionEnabled(false)), req.SystemPrompt)
 	ollamaTools := c.convertToOllamaTools(req.Tools)
 
 	// Add tool follow-up clarification if needed
@@ -222,6 +224,7 @@ func (c *OllamaClient) StreamCompletion(ctx context.Context, req *domain.Complet
 	if req.MaxTokens > 0 {
 		ollamaReq.Options["num_predict"] = req.MaxTokens
 	}
+	addOllamaOptions(ollamaReq.Options, string(c.providerType), resolveSampling(c.config, req).GenerationParams)
 
 	// Create callback for streaming
 	callback := func(chunk string) error {
@@ -344,11 +347,15 @@ func (c *OllamaClient) convertToOllamaMessages(messages []domain.Message, system
 				Content: msg.Content,
 			})
 		case "tool":
-			ollamaMessages = append(ollamaMessages, ollamaChatMessage{
+			toolMsg := ollamaChatMessage{
 				Role:       "tool",
 				Content:    msg.Content,
 				ToolCallID: msg.ToolCallID,
-			})
+			}
+			for _, img := range msg.Images {
+				toolMsg.Images = append(toolMsg.Images, img.Data)
+			}
+			ollamaMessages = append(ollamaMessages, toolMsg)
 		case "assistant":
 			ollamaMessages = append(ollamaMessages, ollamaChatMessage{
 				Role:    "assistant",
@@ -23,6 +23,32 @@ type openaiMessage struct {
 	Name       string           `json:"name,omitempty"`
 	ToolCalls  []openaiToolCall `json:"tool_calls,omitempty"`
 	ToolCallID string           `json:"tool_call_id,omitempty"`
+
+	// Parts replaces Content with text and image parts when set
+	Parts []openaiContentPart `json:"-"`
+}
+
+// openaiContentPart is one part of a multi-part user message
+type openaiContentPart struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	ImageURL *openaiImageURL `json:"image_url,omitempty"`
+}
+
+type openaiImageURL struct {
+	URL string `json:"url"`
+}
+
+// MarshalJSON sends Parts as the content of multi-part messages
+func (m openaiMessage) MarshalJSON() ([]byte, error) {
+	type plain openaiMessage
+	if len(m.Parts) == 0 {
+		return json.Marshal(plain(m))
+	}
+	return json.Marshal(struct {
+		plain
+		Content []openaiContentPart `json:"content"`
+	}{plain(m), m.Parts})
 }
 
 type openaiToolCall struct {
@@ -48,6 +74,13 @@ type openaiChatRequest struct {
 	Stream      bool            `json:"stream,omitempty"`
 	Temperature float64         `json:"temperature,omitempty"`
 	MaxTokens   int             `json:"max_tokens,omitempty"`
+
+	// Sampling settings, see applyOpenAIParams
+	TopP             *float64           `json:"top_p,omitempty"`
+	PresencePenalty  *float64           `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64           `json:"frequency_penalty,omitempty"`
+	Stop             []string           `json:"stop,omitempty"`
+	LogitBias        map[string]float64 `json:"logit_bias,omitempty"`
 }
 
 type openaiChatResponse struct {
@@ -177,7 +210,7 @@ func NewOpenAICompatibleClient(providerType domain.ProviderType, cfg *config.Pro
 // CreateCompletion implements domain.LLMProvider
 func (c *OpenAICompatibleClient) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
 	// Convert domain messages to OpenAI format
-	messages := convertToOpenAIMessages(req.Messages, req.SystemPrompt)
+	messages := convertToOpenAIMessages(withToolImages(req.Messages, c.sendsImages()), req.SystemPrompt)
 
 	// Convert domain tools to OpenAI format
 	tools := convertToOpenAITools(req.Tools)
@@ -189,6 +222,7 @@ func (c *OpenAICompatibleClient) CreateCompletion(ctx context.Context, req *doma
 		Tools:    tools,
 		Stream:   false,
 	}
+	applyOpenAIParams(&payload, string(c.providerType), resolveSampling(c.config, req).GenerationParams)
 
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
@@ -278,7 +312,7 @@ func isRetryableError(err error) bool {
 
 // StreamCompletion implements domain.LLMProvider
 func (c *OpenAICompatibleClient) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, writer io.Writer) (*domain.CompletionResponse, error) {
-	messages := convertToOpenAIMessages(req.Messages, req.SystemPrompt)
+	messages := convertToOpenAIMessages(withToolImages(req.Messages, c.sendsImages()), req.SystemPrompt)
 	tools := convertToOpenAITools(req.Tools)
 
 	payload := openaiChatRequest{
@@ -287,6 +321,7 @@ func (c *OpenAICompatibleClient) StreamCompletion(ctx context.Context, req *doma
 		Tools:    tools,
 		Stream:   true,
 	}
+	applyOpenAIParams(&payload, string(c.providerType), resolveSampling(c.config, req).GenerationParams)
 
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
@@ -671,6 +706,12 @@ func (c *OpenAICompatibleClient) processStreamingResponse(resp *http.Response, w
 	return fullContent, toolCalls, nil
 }
 
+// sendsImages reports whether tool result images go to the model. Only
+// OpenAI itself defaults to on; other compatible APIs may not accept images.
+func (c *OpenAICompatibleClient) sendsImages() bool {
+	return c.config.VisionEnabled(c.providerType == domain.ProviderOpenAI)
+}
+
 // Conversion helper methods (package-level, shared with Azure client)
 
 func convertToOpenAIMessages(messages []domain.Message, systemPrompt string) []openaiMessage {
@@ -683,6 +724,7 @@ func convertToOpenAIMessages(messages []domain.Message, systemPrompt string) []o
 		})
 	}
 
+	var pendingImages []domain.Image
 	for _, msg := range messages {
 		openaiMsg := openaiMessage{
 			Role:    msg.Role,
@@ -709,10 +751,30 @@ func convertToOpenAIMessages(messages []domain.Message, systemPrompt string) []o
 			openaiMsg.ToolCallID = msg.ToolCallID
 		}
 
+		// Tool messages can't carry images, so images from a run of tool
+		// results follow it in a user message
+		if msg.Role != "tool" {
+			openaiMessages = appendImageMessage(openaiMessages, pendingImages)
+			pendingImages = nil
+		}
+		pendingImages = append(pendingImages, msg.Images...)
+
 		openaiMessages = append(openaiMessages, openaiMsg)
 	}
 
-	return openaiMessages
+	return appendImageMessage(openaiMessages, pendingImages)
+}
+
+// appendImageMessage adds a user message showing images from tool results
+func appendImageMessage(openaiMessages []openaiMessage, images []domain.Image) []openaiMessage {
+	if len(images) == 0 {
+		return openaiMessages
+	}
+	parts := []openaiContentPart{{Type: "text", Text: "Images returned by the tool calls above:"}}
+	for _, img := range images {
+		parts = append(parts, openaiContentPart{Type: "image_url", ImageURL: &openaiImageURL{URL: dataURL(img)}})
+	}
+	return append(openaiMessages, openaiMessage{Role: "user", Parts: parts})
 }
 
 func convertToOpenAITools(tools []domain.Tool) []openaiTool {
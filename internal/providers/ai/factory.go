@@ -6,6 +6,8 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/stats"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/usage"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai/clients"
 )
 
@@ -27,6 +29,15 @@ func (f *ProviderFactory) CreateProvider(providerType domain.ProviderType, cfg *
 
 	logging.Info("Creating provider '%s' with interface type '%s'", providerType, interfaceType)
 
+	provider, err := f.createClient(providerType, cfg, interfaceType)
+	if err != nil || (!stats.Enabled() && !usage.Enabled()) {
+		return provider, err
+	}
+	return &meteredProvider{LLMProvider: provider, name: string(providerType), cfg: cfg}, nil
+}
+
+// createClient creates the client for the interface type
+func (f *ProviderFactory) createClient(providerType domain.ProviderType, cfg *config.ProviderConfig, interfaceType config.InterfaceType) (domain.LLMProvider, error) {
 	// Create the appropriate client based on the interface type from configuration
 	switch interfaceType {
 	case config.OpenAICompatible:
@@ -43,6 +54,8 @@ func (f *ProviderFactory) CreateProvider(providerType domain.ProviderType, cfg *
 		return clients.NewAWSBedrockClient(providerType, cfg)
 	case config.GCPVertexAI:
 		return clients.NewGCPVertexAIOpenAIClient(providerType, cfg)
+	case config.External:
+		return clients.NewExternalClient(providerType, cfg)
 	default:
 		return nil, fmt.Errorf("unsupported interface type: %s", interfaceType)
 	}
@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"context"
+	"io"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/stats"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/usage"
+)
+
+// meteredProvider records each call to the provider it wraps in the local
+// usage stats and usage ledger, and refuses calls once the provider's
+// monthly budget is spent. CreateProvider only wraps providers when stats
+// or the ledger are enabled.
+type meteredProvider struct {
+	domain.LLMProvider
+	name string
+	cfg  *config.ProviderConfig
+}
+
+func (p *meteredProvider) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	if err := usage.CheckBudget(usage.TenantFrom(ctx), p.name, p.cfg.MonthlyBudget); err != nil {
+		return nil, err
+	}
+	resp, err := p.LLMProvider.CreateCompletion(ctx, req)
+	p.recordCompletion(ctx, resp, err)
+	return resp, err
+}
+
+func (p *meteredProvider) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, writer io.Writer) (*domain.CompletionResponse, error) {
+	if err := usage.CheckBudget(usage.TenantFrom(ctx), p.name, p.cfg.MonthlyBudget); err != nil {
+		return nil, err
+	}
+	resp, err := p.LLMProvider.StreamCompletion(ctx, req, writer)
+	p.recordCompletion(ctx, resp, err)
+	return resp, err
+}
+
+func (p *meteredProvider) CreateEmbeddings(ctx context.Context, req *domain.EmbeddingRequest) (*domain.EmbeddingResponse, error) {
+	if err := usage.CheckBudget(usage.TenantFrom(ctx), p.name, p.cfg.MonthlyBudget); err != nil {
+		return nil, err
+	}
+	resp, err := p.LLMProvider.CreateEmbeddings(ctx, req)
+	model, tokens, cost := req.Model, 0, 0.0
+	if resp != nil {
+		if resp.Model != "" {
+			model = resp.Model
+		}
+		tokens = resp.Usage.PromptTokens
+		if embedding, ok := p.cfg.EmbeddingModels[model]; ok {
+			cost = float64(tokens) / 1000 * embedding.CostPer1kTokens
+		}
+	}
+	stats.RecordProviderCall(p.name, model, tokens, 0, cost, err != nil)
+	usage.Record(usage.Entry{
+		Provider:     p.name,
+		Model:        model,
+		Workflow:     usage.WorkflowFrom(ctx),
+		Tenant:       usage.TenantFrom(ctx),
+		Kind:         usage.KindEmbeddings,
+		PromptTokens: tokens,
+		CostUSD:      cost,
+		Failed:       err != nil,
+	})
+	return resp, err
+}
+
+func (p *meteredProvider) recordCompletion(ctx context.Context, resp *domain.CompletionResponse, err error) {
+	model, promptTokens, completionTokens := p.cfg.DefaultModel, 0, 0
+	if resp != nil {
+		if resp.Model != "" {
+			model = resp.Model
+		}
+		if resp.Usage != nil {
+			promptTokens, completionTokens = resp.Usage.PromptTokens, resp.Usage.CompletionTokens
+		}
+	}
+	cost := p.cfg.EstimateCost(promptTokens, completionTokens)
+	stats.RecordProviderCall(p.name, model, promptTokens, completionTokens, cost, err != nil)
+	usage.Record(usage.Entry{
+		Provider:         p.name,
+		Model:            model,
+		Workflow:         usage.WorkflowFrom(ctx),
+		Tenant:           usage.TenantFrom(ctx),
+		Kind:             usage.KindCompletion,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		CostUSD:          cost,
+		Failed:           err != nil,
+	})
+}
@@ -0,0 +1,184 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// RouteDecision is the outcome of applying a routing policy to a request
+type RouteDecision struct {
+	Policy  string
+	Chain   []config.ProviderFallback // Selected candidate first, then fallbacks
+	Skipped []string                  // Candidates excluded by tags, cost ceiling or latency SLO, with reasons
+}
+
+// Selected returns the chosen provider/model
+func (d *RouteDecision) Selected() config.ProviderFallback {
+	return d.Chain[0]
+}
+
+// String summarizes the decision for logs
+func (d *RouteDecision) String() string {
+	chosen := d.Selected()
+	var b strings.Builder
+	fmt.Fprintf(&b, "router:%s -> %s", d.Policy, formatRoute(chosen))
+	if len(d.Chain) > 1 {
+		rest := make([]string, 0, len(d.Chain)-1)
+		for _, pc := range d.Chain[1:] {
+			rest = append(rest, formatRoute(pc))
+		}
+		fmt.Fprintf(&b, " (fallbacks: %s)", strings.Join(rest, ", "))
+	}
+	if len(d.Skipped) > 0 {
+		fmt.Fprintf(&b, " [skipped: %s]", strings.Join(d.Skipped, "; "))
+	}
+	return b.String()
+}
+
+// Route applies the named routing policy. Candidates are filtered by task
+// tags (candidates without tags serve any task; if none match, all are kept),
+// then by the policy's cost ceiling and latency SLO, and ordered by its
+// strategy. Unknown costs and latencies sort last and never fail a ceiling.
+func Route(appConfig *config.ApplicationConfig, policyName string, tags []string) (*RouteDecision, error) {
+	policy, ok := appConfig.Routing.GetPolicy(policyName)
+	if !ok {
+		return nil, fmt.Errorf("routing policy '%s' not found (define it under routing.policies in settings.yaml)", policyName)
+	}
+	if len(policy.Candidates) == 0 {
+		return nil, fmt.Errorf("routing policy '%s' has no candidates", policyName)
+	}
+
+	decision := &RouteDecision{Policy: policyName}
+
+	type scored struct {
+		candidate config.RouteCandidate
+		cost      float64 // <0 when unknown
+	}
+
+	// Task tags
+	candidates := policy.Candidates
+	if len(tags) > 0 {
+		var matching []config.RouteCandidate
+		for _, c := range candidates {
+			if len(c.Tags) == 0 || hasAnyTag(c.Tags, tags) {
+				matching = append(matching, c)
+			} else {
+				decision.Skipped = append(decision.Skipped, fmt.Sprintf("%s: no tag in %v", formatCandidate(c), tags))
+			}
+		}
+		if len(matching) == 0 {
+			matching = candidates
+			decision.Skipped = nil
+		}
+		candidates = matching
+	}
+
+	// Ceilings
+	var eligible []scored
+	for _, c := range candidates {
+		cost := candidateCost(appConfig, c)
+		if policy.MaxCost > 0 && cost > policy.MaxCost {
+			decision.Skipped = append(decision.Skipped, fmt.Sprintf("%s: cost $%.4f/1K > max_cost $%.4f", formatCandidate(c), cost, policy.MaxCost))
+			continue
+		}
+		if policy.LatencySLO > 0 && c.LatencyMs > policy.LatencySLO {
+			decision.Skipped = append(decision.Skipped, fmt.Sprintf("%s: latency %dms > latency_slo %dms", formatCandidate(c), c.LatencyMs, policy.LatencySLO))
+			continue
+		}
+		eligible = append(eligible, scored{candidate: c, cost: cost})
+	}
+	if len(eligible) == 0 {
+		return nil, fmt.Errorf("routing policy '%s': no candidate satisfies the policy (%s)", policyName, strings.Join(decision.Skipped, "; "))
+	}
+
+	// Strategy
+	switch policy.Strategy {
+	case config.RouteCheapest:
+		sort.SliceStable(eligible, func(i, j int) bool {
+			return lessKnown(eligible[i].cost, eligible[j].cost)
+		})
+	case config.RouteFastest:
+		sort.SliceStable(eligible, func(i, j int) bool {
+			return lessKnown(float64(eligible[i].candidate.LatencyMs)-1, float64(eligible[j].candidate.LatencyMs)-1)
+		})
+	case config.RouteBest:
+		sort.SliceStable(eligible, func(i, j int) bool {
+			return eligible[i].candidate.Quality > eligible[j].candidate.Quality
+		})
+	case "", config.RouteOrdered:
+	default:
+		return nil, fmt.Errorf("routing policy '%s': unknown strategy '%s' (use cheapest, fastest, best or ordered)", policyName, policy.Strategy)
+	}
+
+	for _, s := range eligible {
+		decision.Chain = append(decision.Chain, config.ProviderFallback{Provider: s.candidate.Provider, Model: s.candidate.Model})
+		if policy.NoFallback {
+			break
+		}
+	}
+	return decision, nil
+}
+
+// candidateCost returns the blended USD per 1K tokens, or -1 when unknown
+func candidateCost(appConfig *config.ApplicationConfig, c config.RouteCandidate) float64 {
+	if c.Cost != nil {
+		return *c.Cost
+	}
+	providerConfig := findProviderConfig(appConfig, c.Provider)
+	if !providerConfig.HasPricing() {
+		return -1
+	}
+	return (providerConfig.CostPer1kInputTokens + providerConfig.CostPer1kOutputTokens) / 2
+}
+
+// findProviderConfig looks a provider up across interfaces and the legacy providers section
+func findProviderConfig(appConfig *config.ApplicationConfig, name string) *config.ProviderConfig {
+	if appConfig == nil || appConfig.AI == nil {
+		return nil
+	}
+	for _, iface := range appConfig.AI.Interfaces {
+		if providerConfig, exists := iface.Providers[name]; exists {
+			return &providerConfig
+		}
+	}
+	if providerConfig, exists := appConfig.AI.Providers[name]; exists {
+		return &providerConfig
+	}
+	return nil
+}
+
+// lessKnown orders ascending with negative (unknown) values last
+func lessKnown(a, b float64) bool {
+	if a < 0 {
+		return false
+	}
+	if b < 0 {
+		return true
+	}
+	return a < b
+}
+
+func hasAnyTag(have, want []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if strings.EqualFold(h, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func formatCandidate(c config.RouteCandidate) string {
+	return formatRoute(config.ProviderFallback{Provider: c.Provider, Model: c.Model})
+}
+
+func formatRoute(pc config.ProviderFallback) string {
+	if pc.Model == "" {
+		return pc.Provider
+	}
+	return pc.Provider + "/" + pc.Model
+}
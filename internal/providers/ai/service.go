@@ -54,6 +54,20 @@ func (s *Service) InitializeProvider(configFile, providerOverride, modelOverride
 		}
 	}
 
+	// Resolve routing policies to the provider they select
+	if policyName, isRouter := config.ParseRouter(providerName); isRouter {
+		decision, err := Route(appConfig, policyName, nil)
+		if err != nil {
+			return nil, err
+		}
+		logging.Info("Routing %s", decision)
+		selected := decision.Selected()
+		providerName = selected.Provider
+		if modelOverride == "" {
+			modelOverride = selected.Model
+		}
+	}
+
 	logging.Info("Using AI provider: %s", providerName)
 
 	// Get provider configuration from the modular config hierarchy
@@ -192,6 +206,7 @@ func (s *Service) validateProviderConfig(providerName string, cfg *config.Provid
 		config.AWSBedrock:   true, // Uses AWS credentials
 		config.GCPVertexAI:  true, // Uses GCP service account
 		config.OllamaNative: true, // No auth needed
+		config.External:     true, // The provider program authenticates itself
 	}
 
 	// API key required for cloud providers (excluding those with alternative auth)
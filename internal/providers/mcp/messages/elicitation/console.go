@@ -0,0 +1,138 @@
+package elicitation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ConsoleHandler asks for elicitation values one at a time on a terminal.
+// Typing /decline refuses the request; /cancel or end of input dismisses it.
+type ConsoleHandler struct {
+	// ReadLine shows prompt and returns the user's answer. io.EOF cancels.
+	ReadLine func(prompt string) (string, error)
+
+	// Out receives the server's message and validation errors
+	Out io.Writer
+
+	// mu keeps prompts from concurrent tool calls from interleaving
+	mu sync.Mutex
+}
+
+// NewStdinHandler creates a console handler reading lines from standard input
+func NewStdinHandler() *ConsoleHandler {
+	reader := bufio.NewReader(os.Stdin)
+	return &ConsoleHandler{
+		Out: os.Stdout,
+		ReadLine: func(prompt string) (string, error) {
+			fmt.Print(prompt)
+			line, err := reader.ReadString('\n')
+			if err != nil && line == "" {
+				return "", err
+			}
+			return line, nil
+		},
+	}
+}
+
+// Elicit implements Handler
+func (h *ConsoleHandler) Elicit(ctx context.Context, serverName string, params Params) (Result, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	schema := params.RequestedSchema
+	fmt.Fprintf(h.Out, "\n%s needs more information:\n%s\n", serverName, params.Message)
+	fmt.Fprintln(h.Out, "(type /decline to refuse or /cancel to skip)")
+
+	names := schema.Order
+	if len(names) != len(schema.Properties) {
+		names = make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	content := make(map[string]interface{})
+	for _, name := range names {
+		prop := schema.Properties[name]
+		required := schema.IsRequired(name)
+		for {
+			if err := ctx.Err(); err != nil {
+				return Result{}, err
+			}
+			line, err := h.ReadLine(questionFor(name, prop, required))
+			if err == io.EOF {
+				return Result{Action: ActionCancel}, nil
+			}
+			if err != nil {
+				return Result{}, err
+			}
+
+			switch answer := strings.TrimSpace(line); {
+			case answer == "/decline":
+				return Result{Action: ActionDecline}, nil
+			case answer == "/cancel":
+				return Result{Action: ActionCancel}, nil
+			case answer == "" && prop.Default != nil:
+				content[name] = prop.Default
+			case answer == "" && !required:
+			case answer == "":
+				fmt.Fprintln(h.Out, "  A value is required.")
+				continue
+			default:
+				value, err := ParseValue(prop, answer)
+				if err != nil {
+					fmt.Fprintf(h.Out, "  %v\n", err)
+					continue
+				}
+				content[name] = value
+			}
+			break
+		}
+	}
+
+	return Result{Action: ActionAccept, Content: content}, nil
+}
+
+// questionFor builds the prompt for one property, e.g.
+// "  Priority [1) low, 2) high] (default: low): "
+func questionFor(name string, prop Property, required bool) string {
+	label := prop.Title
+	if label == "" {
+		label = name
+	}
+
+	var b strings.Builder
+	if prop.Description != "" {
+		fmt.Fprintf(&b, "  %s\n", prop.Description)
+	}
+	b.WriteString("  " + label)
+	switch {
+	case len(prop.Enum) > 0:
+		choices := make([]string, len(prop.Enum))
+		for i, value := range prop.Enum {
+			if i < len(prop.EnumNames) {
+				value = prop.EnumNames[i]
+			}
+			choices[i] = fmt.Sprintf("%d) %s", i+1, value)
+		}
+		fmt.Fprintf(&b, " [%s]", strings.Join(choices, ", "))
+	case prop.Type == "boolean":
+		b.WriteString(" [y/n]")
+	case prop.Format != "":
+		fmt.Fprintf(&b, " (%s)", prop.Format)
+	}
+	if prop.Default != nil {
+		fmt.Fprintf(&b, " (default: %v)", prop.Default)
+	} else if !required {
+		b.WriteString(" (optional)")
+	}
+	b.WriteString(": ")
+	return b.String()
+}
@@ -0,0 +1,168 @@
+package elicitation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+)
+
+const sampleParams = `{
+	"message": "Which repository should the issue go to?",
+	"requestedSchema": {
+		"type": "object",
+		"properties": {
+			"repo": {"type": "string", "title": "Repository", "minLength": 3},
+			"priority": {"type": "string", "enum": ["low", "high"], "default": "low"},
+			"count": {"type": "integer", "minimum": 1, "maximum": 5},
+			"notify": {"type": "boolean"}
+		},
+		"required": ["repo", "count"]
+	}
+}`
+
+func sampleRequest(t *testing.T) *messages.JSONRPCMessage {
+	t.Helper()
+	msg, err := messages.NewRequest(7, Method, json.RawMessage(sampleParams))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestSchemaOrder(t *testing.T) {
+	var params Params
+	if err := json.Unmarshal([]byte(sampleParams), &params); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(params.RequestedSchema.Order, ","); got != "repo,priority,count,notify" {
+		t.Errorf("order = %s", got)
+	}
+	if !params.RequestedSchema.IsRequired("count") || params.RequestedSchema.IsRequired("notify") {
+		t.Error("IsRequired mismatch")
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	one, five := 1.0, 5.0
+	tests := []struct {
+		prop    Property
+		input   string
+		want    interface{}
+		wantErr bool
+	}{
+		{Property{Type: "boolean"}, "Yes", true, false},
+		{Property{Type: "boolean"}, "maybe", nil, true},
+		{Property{Type: "integer", Minimum: &one, Maximum: &five}, "3", int64(3), false},
+		{Property{Type: "integer"}, "2.5", nil, true},
+		{Property{Type: "number", Maximum: &five}, "7", nil, true},
+		{Property{Type: "string", Enum: []string{"low", "high"}, EnumNames: []string{"Low", "High"}}, "2", "high", false},
+		{Property{Type: "string", Enum: []string{"low", "high"}}, "medium", nil, true},
+		{Property{Type: "string", Format: "email"}, "me@example.com", "me@example.com", false},
+		{Property{Type: "string", Format: "date"}, "16/10/2026", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseValue(tt.prop, tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseValue(%+v, %q) = %v, %v", tt.prop, tt.input, got, err)
+		}
+	}
+}
+
+type fakeHandler struct {
+	result Result
+	err    error
+}
+
+func (f fakeHandler) Elicit(ctx context.Context, serverName string, params Params) (Result, error) {
+	return f.result, f.err
+}
+
+func TestAnswer(t *testing.T) {
+	defer SetHandler(nil)
+
+	SetHandler(nil)
+	_, rpcErr := answer(context.Background(), "github", sampleRequest(t))
+	if rpcErr == nil || !strings.Contains(string(rpcErr.Data), "ELICITATION_UNAVAILABLE") {
+		t.Errorf("non-interactive answer = %+v", rpcErr)
+	}
+
+	SetHandler(fakeHandler{result: Result{Action: ActionAccept, Content: map[string]interface{}{"repo": "mcp-cli", "count": int64(2)}}})
+	result, rpcErr := answer(context.Background(), "github", sampleRequest(t))
+	if rpcErr != nil || result.(Result).Action != ActionAccept {
+		t.Errorf("accepted answer = %+v, %+v", result, rpcErr)
+	}
+
+	SetHandler(fakeHandler{result: Result{Action: ActionAccept, Content: map[string]interface{}{"repo": "mcp-cli"}}})
+	if _, rpcErr := answer(context.Background(), "github", sampleRequest(t)); rpcErr == nil {
+		t.Error("content missing a required value should be rejected")
+	}
+
+	SetHandler(fakeHandler{err: context.Canceled})
+	if result, _ := answer(context.Background(), "github", sampleRequest(t)); result.(Result).Action != ActionCancel {
+		t.Errorf("cancelled answer = %+v", result)
+	}
+
+	bad, _ := messages.NewRequest(8, Method, map[string]interface{}{
+		"message":         "x",
+		"requestedSchema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"nested": map[string]interface{}{"type": "object"}}},
+	})
+	if _, rpcErr := answer(context.Background(), "github", bad); rpcErr == nil || rpcErr.Code != errCodeInvalidParams {
+		t.Errorf("nested schema answer = %+v", rpcErr)
+	}
+}
+
+func TestConsoleHandler(t *testing.T) {
+	var params Params
+	if err := json.Unmarshal([]byte(sampleParams), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	script := func(answers ...string) *ConsoleHandler {
+		var out strings.Builder
+		return &ConsoleHandler{
+			Out: &out,
+			ReadLine: func(prompt string) (string, error) {
+				if len(answers) == 0 {
+					return "", io.EOF
+				}
+				answer := answers[0]
+				answers = answers[1:]
+				return answer, nil
+			},
+		}
+	}
+
+	// "ab" is too short and "9" too large, so both are asked again
+	result, err := script("ab", "mcp-cli", "", "9", "2", "y").Elicit(context.Background(), "github", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"repo": "mcp-cli", "priority": "low", "count": int64(2), "notify": true}
+	if result.Action != ActionAccept || len(result.Content) != len(want) {
+		t.Fatalf("result = %+v", result)
+	}
+	for key, value := range want {
+		if result.Content[key] != value {
+			t.Errorf("%s = %v, want %v", key, result.Content[key], value)
+		}
+	}
+
+	if result, _ := script("/decline").Elicit(context.Background(), "github", params); result.Action != ActionDecline {
+		t.Errorf("/decline result = %+v", result)
+	}
+	if result, _ := script("mcp-cli").Elicit(context.Background(), "github", params); result.Action != ActionCancel {
+		t.Errorf("end of input result = %+v", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := script().Elicit(ctx, "github", params); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled context err = %v", err)
+	}
+}
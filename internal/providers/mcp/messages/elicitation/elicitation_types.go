@@ -0,0 +1,116 @@
+package elicitation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Method is the request a server sends to ask the user for input
+const Method = "elicitation/create"
+
+// Actions a user can take on an elicitation request
+const (
+	ActionAccept  = "accept"  // The user answered; Content holds the values
+	ActionDecline = "decline" // The user explicitly refused
+	ActionCancel  = "cancel"  // The user dismissed the request without choosing
+)
+
+// Property describes one requested value. Elicitation schemas only allow
+// flat objects of primitive values.
+type Property struct {
+	// string, number, integer or boolean
+	Type string `json:"type"`
+
+	// Short label and longer help text
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// String format: email, uri, date or date-time
+	Format string `json:"format,omitempty"`
+
+	// Allowed string values, and optional display names for them
+	Enum      []string `json:"enum,omitempty"`
+	EnumNames []string `json:"enumNames,omitempty"`
+
+	// Bounds for numbers and string lengths
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+
+	// Value used when the user gives none
+	Default interface{} `json:"default,omitempty"`
+}
+
+// Schema is the requestedSchema of an elicitation request
+type Schema struct {
+	Type       string              `json:"type"`
+	Properties map[string]Property `json:"properties"`
+	Required   []string            `json:"required,omitempty"`
+
+	// Property names in the order the server listed them
+	Order []string `json:"-"`
+}
+
+// UnmarshalJSON decodes a schema and records the order of its properties,
+// so questions are asked in the order the server intended
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw.Properties) == 0 {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw.Properties))
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	s.Order = nil
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return fmt.Errorf("unexpected property key %v", key)
+		}
+		s.Order = append(s.Order, name)
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsRequired reports whether the named property must be answered
+func (s *Schema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Params are the parameters of an elicitation/create request
+type Params struct {
+	// What the server needs, shown to the user
+	Message string `json:"message"`
+
+	// The values to collect
+	RequestedSchema Schema `json:"requestedSchema"`
+}
+
+// Result is the client's answer to an elicitation/create request
+type Result struct {
+	Action  string                 `json:"action"`
+	Content map[string]interface{} `json:"content,omitempty"`
+}
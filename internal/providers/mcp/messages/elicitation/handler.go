@@ -0,0 +1,223 @@
+package elicitation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/transport/stdio"
+)
+
+// JSON-RPC error code for invalid request parameters
+const errCodeInvalidParams = -32602
+
+// Handler asks the user for the input a server requested
+type Handler interface {
+	Elicit(ctx context.Context, serverName string, params Params) (Result, error)
+}
+
+var (
+	handlerMu sync.RWMutex
+	handler   Handler
+)
+
+// SetHandler installs the handler that prompts the user. Interactive modes
+// set it before connecting to servers; nil (the default) means there is no
+// one to ask, and requests are refused with a structured error.
+func SetHandler(h Handler) {
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	handler = h
+}
+
+// Enabled reports whether a handler is installed, and so whether the
+// elicitation capability should be declared to servers
+func Enabled() bool {
+	handlerMu.RLock()
+	defer handlerMu.RUnlock()
+	return handler != nil
+}
+
+func currentHandler() Handler {
+	handlerMu.RLock()
+	defer handlerMu.RUnlock()
+	return handler
+}
+
+// UnavailableData is the data of the error returned when nobody can answer
+type UnavailableData struct {
+	Code      string `json:"code"`
+	Reason    string `json:"reason"`
+	Retryable bool   `json:"retryable"`
+}
+
+// Register answers elicitation requests from the named server
+func Register(dispatcher *stdio.ResponseDispatcher, serverName string) {
+	dispatcher.HandleRequest(Method, func(ctx context.Context, msg *messages.JSONRPCMessage) (interface{}, *messages.JSONRPCError) {
+		return answer(ctx, serverName, msg)
+	})
+}
+
+func answer(ctx context.Context, serverName string, msg *messages.JSONRPCMessage) (interface{}, *messages.JSONRPCError) {
+	var params Params
+	if err := msg.UnmarshalParams(&params); err != nil {
+		return nil, &messages.JSONRPCError{Code: errCodeInvalidParams, Message: fmt.Sprintf("invalid elicitation params: %v", err)}
+	}
+	if err := ValidateSchema(params.RequestedSchema); err != nil {
+		return nil, &messages.JSONRPCError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+
+	h := currentHandler()
+	if h == nil {
+		logging.Warn("Server %s asked for user input, but mcp-cli is not running interactively: %s", serverName, params.Message)
+		data, _ := json.Marshal(UnavailableData{
+			Code:   "ELICITATION_UNAVAILABLE",
+			Reason: "mcp-cli is running non-interactively, so there is no user to ask",
+		})
+		return nil, &messages.JSONRPCError{
+			Code:    stdio.ErrCodeMethodNotFound,
+			Message: "elicitation is not available in non-interactive mode",
+			Data:    data,
+		}
+	}
+
+	result, err := h.Elicit(ctx, serverName, params)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+			return Result{Action: ActionCancel}, nil
+		}
+		return nil, &messages.JSONRPCError{Code: stdio.ErrCodeInternalError, Message: fmt.Sprintf("elicitation failed: %v", err)}
+	}
+	if result.Action == ActionAccept {
+		if err := ValidateContent(params.RequestedSchema, result.Content); err != nil {
+			return nil, &messages.JSONRPCError{Code: stdio.ErrCodeInternalError, Message: err.Error()}
+		}
+	} else {
+		result.Content = nil
+	}
+	logging.Info("Elicitation from %s answered with %s", serverName, result.Action)
+	return result, nil
+}
+
+// ValidateSchema checks that a requested schema is a flat object of
+// primitive properties, as the protocol requires
+func ValidateSchema(schema Schema) error {
+	if schema.Type != "" && schema.Type != "object" {
+		return fmt.Errorf("requestedSchema must be an object, got %s", schema.Type)
+	}
+	for name, prop := range schema.Properties {
+		switch prop.Type {
+		case "string", "number", "integer", "boolean":
+		default:
+			return fmt.Errorf("property %s has unsupported type '%s'", name, prop.Type)
+		}
+	}
+	for _, name := range schema.Required {
+		if _, ok := schema.Properties[name]; !ok {
+			return fmt.Errorf("required property %s is not defined", name)
+		}
+	}
+	return nil
+}
+
+// ValidateContent checks accepted values against the schema
+func ValidateContent(schema Schema, content map[string]interface{}) error {
+	for _, name := range schema.Required {
+		if _, ok := content[name]; !ok {
+			return fmt.Errorf("missing required value %s", name)
+		}
+	}
+	for name, value := range content {
+		prop, ok := schema.Properties[name]
+		if !ok {
+			return fmt.Errorf("unexpected value %s", name)
+		}
+		if _, err := ParseValue(prop, fmt.Sprint(value)); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ParseValue converts what the user typed into a value of the property's
+// type, applying its enum, format and bounds
+func ParseValue(prop Property, input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+
+	switch prop.Type {
+	case "boolean":
+		switch strings.ToLower(input) {
+		case "y", "yes", "true", "1":
+			return true, nil
+		case "n", "no", "false", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("answer yes or no")
+
+	case "number", "integer":
+		n, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a number", input)
+		}
+		if prop.Type == "integer" && n != float64(int64(n)) {
+			return nil, fmt.Errorf("'%s' is not a whole number", input)
+		}
+		if prop.Minimum != nil && n < *prop.Minimum {
+			return nil, fmt.Errorf("must be at least %v", *prop.Minimum)
+		}
+		if prop.Maximum != nil && n > *prop.Maximum {
+			return nil, fmt.Errorf("must be at most %v", *prop.Maximum)
+		}
+		if prop.Type == "integer" {
+			return int64(n), nil
+		}
+		return n, nil
+	}
+
+	if len(prop.Enum) > 0 {
+		// Accept the value, its display name or its 1-based number
+		for i, value := range prop.Enum {
+			if strings.EqualFold(input, value) || input == strconv.Itoa(i+1) ||
+				(i < len(prop.EnumNames) && strings.EqualFold(input, prop.EnumNames[i])) {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("choose one of: %s", strings.Join(prop.Enum, ", "))
+	}
+
+	length := len([]rune(input))
+	if prop.MinLength != nil && length < *prop.MinLength {
+		return nil, fmt.Errorf("must be at least %d characters", *prop.MinLength)
+	}
+	if prop.MaxLength != nil && length > *prop.MaxLength {
+		return nil, fmt.Errorf("must be at most %d characters", *prop.MaxLength)
+	}
+	switch prop.Format {
+	case "email":
+		if _, err := mail.ParseAddress(input); err != nil {
+			return nil, fmt.Errorf("'%s' is not an email address", input)
+		}
+	case "uri":
+		if u, err := url.Parse(input); err != nil || u.Scheme == "" {
+			return nil, fmt.Errorf("'%s' is not a URI", input)
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", input); err != nil {
+			return nil, fmt.Errorf("'%s' is not a date (YYYY-MM-DD)", input)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, input); err != nil {
+			return nil, fmt.Errorf("'%s' is not a date and time (RFC 3339)", input)
+		}
+	}
+	return input, nil
+}
@@ -19,6 +19,21 @@ type ClientCapabilities struct {
 
 	// Whether the client supports cancellation
 	SupportsCancellation bool `json:"supportsCancellation,omitempty"`
+
+	// Present when the client can ask the user for input (elicitation/create)
+	Elicitation *ElicitationCapability `json:"elicitation,omitempty"`
+
+	// Present when the client answers roots/list
+	Roots *RootsCapability `json:"roots,omitempty"`
+}
+
+// ElicitationCapability declares support for server requests for user input
+type ElicitationCapability struct{}
+
+// RootsCapability declares support for listing the client's roots
+type RootsCapability struct {
+	// Whether the client sends notifications/roots/list_changed
+	ListChanged bool `json:"listChanged"`
 }
 
 // InitializeParams represents the parameters for an initialize request
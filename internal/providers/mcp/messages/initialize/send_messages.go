@@ -7,6 +7,8 @@ import (
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/elicitation"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/roots"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/transport/stdio"
 )
 
@@ -41,6 +43,12 @@ func SendInitialize(client *stdio.StdioClient, dispatcher *stdio.ResponseDispatc
 			SupportsCancellation:        true,
 		},
 	}
+	if dispatcher.Handles(elicitation.Method) && elicitation.Enabled() {
+		params.Capabilities.Elicitation = &ElicitationCapability{}
+	}
+	if dispatcher.Handles(roots.Method) {
+		params.Capabilities.Roots = &RootsCapability{ListChanged: true}
+	}
 
 	logging.Debug("Initialize parameters: protocolVersion=%s, clientInfo=%s/%s",
 		params.ProtocolVersion, params.ClientInfo.Name, params.ClientInfo.Version)
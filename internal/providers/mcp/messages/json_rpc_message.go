@@ -79,6 +79,19 @@ type JSONRPCMessage struct {
 	Error   *JSONRPCError   `json:"error,omitempty"`
 }
 
+// MarshalJSON omits the id of notifications, which must not carry one
+func (m JSONRPCMessage) MarshalJSON() ([]byte, error) {
+	type plain JSONRPCMessage
+	if m.Method == "" || !m.ID.IsEmpty() {
+		return json.Marshal(plain(m))
+	}
+	return json.Marshal(struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params,omitempty"`
+	}{m.JSONRPC, m.Method, m.Params})
+}
+
 // GetIDString returns the ID as a string for logging/comparison
 func (m *JSONRPCMessage) GetIDString() string {
 	return m.ID.String()
@@ -110,6 +123,24 @@ func NewRequest(id interface{}, method string, params interface{}) (*JSONRPCMess
 	}, nil
 }
 
+// NewNotification creates a new JSON-RPC notification message (a request without an ID)
+func NewNotification(method string, params interface{}) (*JSONRPCMessage, error) {
+	var paramsJSON json.RawMessage
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal params: %w", err)
+		}
+		paramsJSON = data
+	}
+
+	return &JSONRPCMessage{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  paramsJSON,
+	}, nil
+}
+
 // NewResponse creates a new JSON-RPC response message
 func NewResponse(id interface{}, result interface{}) (*JSONRPCMessage, error) {
 	var resultJSON json.RawMessage
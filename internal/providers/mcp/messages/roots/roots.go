@@ -0,0 +1,132 @@
+package roots
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/transport/stdio"
+)
+
+// notifier sends notifications to one connected server
+type notifier interface {
+	Notify(method string, params interface{}) error
+}
+
+var (
+	mu          sync.RWMutex
+	current     []Root
+	subscribers = map[notifier]string{}
+)
+
+// Set replaces the roots advertised to servers. Connected servers are told
+// when the list changes; a server that can no longer be reached is dropped.
+func Set(roots []Root) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if equal(current, roots) {
+		return
+	}
+	current = append([]Root(nil), roots...)
+
+	for n, serverName := range subscribers {
+		if err := n.Notify(ListChanged, nil); err != nil {
+			logging.Debug("Not notifying %s of root changes any more: %v", serverName, err)
+			delete(subscribers, n)
+		}
+	}
+}
+
+// Current returns the roots advertised to servers
+func Current() []Root {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Root{}, current...)
+}
+
+// Register answers roots/list requests from the named server and notifies
+// it when the roots change
+func Register(dispatcher *stdio.ResponseDispatcher, serverName string) {
+	dispatcher.HandleRequest(Method, func(ctx context.Context, msg *messages.JSONRPCMessage) (interface{}, *messages.JSONRPCError) {
+		list := Current()
+		logging.Debug("Server %s listed %d root(s)", serverName, len(list))
+		return ListResult{Roots: list}, nil
+	})
+	subscribe(dispatcher, serverName)
+}
+
+func subscribe(n notifier, serverName string) {
+	mu.Lock()
+	defer mu.Unlock()
+	subscribers[n] = serverName
+}
+
+// FromPath returns the root for a local directory. Relative paths resolve
+// against the working directory and a leading ~ against the home directory.
+// The name defaults to the directory's base name.
+func FromPath(path, name string) (Root, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Root{}, fmt.Errorf("cannot expand %s: %w", path, err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Root{}, fmt.Errorf("invalid root %s: %w", path, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return Root{}, fmt.Errorf("invalid root %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return Root{}, fmt.Errorf("invalid root %s: not a directory", path)
+	}
+
+	if name == "" {
+		name = filepath.Base(abs)
+	}
+	return Root{URI: fileURI(abs), Name: name}, nil
+}
+
+// Path returns the local path of a file:// root
+func (r Root) Path() string {
+	u, err := url.Parse(r.URI)
+	if err != nil || u.Scheme != "file" {
+		return r.URI
+	}
+	path := u.Path
+	// file:///C:/dir on Windows
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+func fileURI(abs string) string {
+	path := filepath.ToSlash(abs)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+func equal(a, b []Root) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package roots
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeNotifier struct {
+	sent []string
+	err  error
+}
+
+func (f *fakeNotifier) Notify(method string, params interface{}) error {
+	f.sent = append(f.sent, method)
+	return f.err
+}
+
+func TestFromPath(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "my project")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := FromPath(sub, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(root.URI, "file:///") || !strings.HasSuffix(root.URI, "/my%20project") {
+		t.Errorf("URI = %s", root.URI)
+	}
+	if root.Name != "my project" {
+		t.Errorf("Name = %s, want the base name", root.Name)
+	}
+	if root.Path() != sub {
+		t.Errorf("Path() = %s, want %s", root.Path(), sub)
+	}
+
+	if root, _ := FromPath(sub, "docs"); root.Name != "docs" {
+		t.Errorf("Name = %s, want docs", root.Name)
+	}
+
+	file := filepath.Join(dir, "file.txt")
+	os.WriteFile(file, []byte("x"), 0644)
+	for _, path := range []string{file, filepath.Join(dir, "missing")} {
+		if _, err := FromPath(path, ""); err == nil {
+			t.Errorf("FromPath(%s) should fail", path)
+		}
+	}
+}
+
+func TestSetNotifiesSubscribers(t *testing.T) {
+	defer func() {
+		Set(nil)
+		subscribers = map[notifier]string{}
+	}()
+
+	ok := &fakeNotifier{}
+	gone := &fakeNotifier{err: errors.New("client stopped")}
+	subscribe(ok, "ok")
+	subscribe(gone, "gone")
+
+	roots := []Root{{URI: "file:///project", Name: "project"}}
+	Set(roots)
+	Set(roots) // unchanged, no notification
+
+	if len(ok.sent) != 1 || ok.sent[0] != ListChanged {
+		t.Errorf("sent = %v, want one %s", ok.sent, ListChanged)
+	}
+	if got := Current(); len(got) != 1 || got[0] != roots[0] {
+		t.Errorf("Current() = %v", got)
+	}
+
+	Set(nil)
+	if len(ok.sent) != 2 {
+		t.Errorf("sent = %v, want a second notification", ok.sent)
+	}
+	if len(gone.sent) != 1 {
+		t.Errorf("a failed subscriber should be dropped, sent = %v", gone.sent)
+	}
+	if got := Current(); got == nil || len(got) != 0 {
+		t.Errorf("Current() = %#v, want an empty list", got)
+	}
+}
@@ -0,0 +1,21 @@
+package roots
+
+// Method is the request a server sends to learn the client's roots
+const Method = "roots/list"
+
+// ListChanged is the notification sent to servers when the roots change
+const ListChanged = "notifications/roots/list_changed"
+
+// Root is a directory or file a server may operate on
+type Root struct {
+	// A file:// URI
+	URI string `json:"uri"`
+
+	// Optional display name
+	Name string `json:"name,omitempty"`
+}
+
+// ListResult is the client's answer to a roots/list request
+type ListResult struct {
+	Roots []Root `json:"roots"`
+}
@@ -18,6 +18,9 @@ const (
 	// Method name for progress notifications
 	progressNotificationMethod = "notifications/progress"
 
+	// ListChanged is the notification a server sends when its tools change
+	ListChanged = "notifications/tools/list_changed"
+
 	// Default timeout for tools requests
 	// This is the timeout between progress updates, not total execution time
 	// If server sends progress notifications, timeout resets on each update
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// HTTPClient is an authorized caller of the HTTP transport. A request
+// authenticates as the client whose bearer token it presents, or whose
+// certificate common name matches a verified client certificate.
+type HTTPClient struct {
+	Name           string
+	Token          string   // Bearer token
+	CertCommonName string   // Common name of a verified client certificate
+	Tools          []string // Tool names or glob patterns it may use; empty allows all
+	Tenant         string   // Tenant its requests belong to
+}
+
+// AllowsTool reports whether the client may list and call the named tool.
+// A nil client (authentication disabled) may use every tool.
+func (c *HTTPClient) AllowsTool(name string) bool {
+	if c == nil || len(c.Tools) == 0 {
+		return true
+	}
+	for _, pattern := range c.Tools {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTools removes the tools allows rejects from a tools/list result
+func filterTools(result map[string]interface{}, allows func(name string) bool) map[string]interface{} {
+	var allowed []map[string]interface{}
+	filtered := false
+	keep := func(tool map[string]interface{}) {
+		if name, _ := tool["name"].(string); allows(name) {
+			allowed = append(allowed, tool)
+		} else {
+			filtered = true
+		}
+	}
+	switch tools := result["tools"].(type) {
+	case []map[string]interface{}:
+		for _, tool := range tools {
+			keep(tool)
+		}
+	case []interface{}:
+		for _, item := range tools {
+			if tool, ok := item.(map[string]interface{}); ok {
+				keep(tool)
+			}
+		}
+	default:
+		return result
+	}
+	if !filtered {
+		return result
+	}
+
+	scoped := make(map[string]interface{}, len(result))
+	for key, value := range result {
+		scoped[key] = value
+	}
+	if allowed == nil {
+		allowed = []map[string]interface{}{}
+	}
+	scoped["tools"] = allowed
+	return scoped
+}
+
+func (c *HTTPClient) name() string {
+	if c == nil {
+		return "anonymous"
+	}
+	return c.Name
+}
+
+// HTTPTenant is a tenant of a shared server. Its tool calls run with its
+// own configuration through a ScopedHandler.
+type HTTPTenant struct {
+	Name  string
+	Tools []string // Tool names or glob patterns it may use; empty allows all
+}
+
+// ScopedHandler is a MessageHandler that acts for a client of a tenant
+// (either empty when not in use): tool calls run with the tenant's
+// configuration, and tasks belong to the caller that started them, which
+// alone can get, list or cancel them
+type ScopedHandler interface {
+	HandleToolsCallAs(params map[string]interface{}, client, tenant string) (map[string]interface{}, error)
+	HandleTasksAs(method string, params map[string]interface{}, client, tenant string) (map[string]interface{}, error)
+}
+
+// AllowsTool reports whether the tenant may list and call the named tool.
+// A nil tenant (tenancy disabled) may use every tool.
+func (t *HTTPTenant) AllowsTool(name string) bool {
+	if t == nil {
+		return true
+	}
+	return (&HTTPClient{Tools: t.Tools}).AllowsTool(name)
+}
+
+func (t *HTTPTenant) name() string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+// tenantFor finds the tenant of a request: its client's tenant, or without
+// authentication the one named by the Mcp-Tenant header. With tenants
+// configured, every request must belong to one.
+func (s *HTTPServer) tenantFor(r *http.Request, client *HTTPClient) (*HTTPTenant, error) {
+	if len(s.opts.Tenants) == 0 {
+		return nil, nil
+	}
+	header := r.Header.Get(TenantHeader)
+	name := header
+	if client != nil {
+		if header != "" && header != client.Tenant {
+			return nil, fmt.Errorf("client %s does not belong to tenant %s", client.Name, header)
+		}
+		name = client.Tenant
+	}
+	if name == "" {
+		return nil, fmt.Errorf("missing %s header", TenantHeader)
+	}
+	for i := range s.opts.Tenants {
+		if s.opts.Tenants[i].Name == name {
+			return &s.opts.Tenants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown tenant %s", name)
+}
+
+// callTool runs a tool call for the session's client, with its tenant's
+// configuration when it has one
+func (s *HTTPServer) callTool(params map[string]interface{}, session *httpSession) (map[string]interface{}, error) {
+	if session.client == nil && session.tenant == nil {
+		return s.handler.HandleToolsCall(params)
+	}
+	handler, ok := s.handler.(ScopedHandler)
+	if !ok {
+		if session.tenant != nil {
+			return nil, fmt.Errorf("server does not support tenants")
+		}
+		return s.handler.HandleToolsCall(params)
+	}
+	client, tenant := session.caller()
+	return handler.HandleToolsCallAs(params, client, tenant)
+}
+
+// handleTasks runs a tasks/* request for the session's client. Servers
+// that can't tell tasks' owners apart refuse them to authenticated clients,
+// who would otherwise see each other's tasks.
+func (s *HTTPServer) handleTasks(method string, params map[string]interface{}, session *httpSession) (map[string]interface{}, error) {
+	if session.client == nil && session.tenant == nil {
+		switch method {
+		case "tasks/get":
+			return s.handler.HandleTasksGet(params)
+		case "tasks/result":
+			return s.handler.HandleTasksResult(params)
+		case "tasks/list":
+			return s.handler.HandleTasksList(params)
+		default:
+			return s.handler.HandleTasksCancel(params)
+		}
+	}
+	handler, ok := s.handler.(ScopedHandler)
+	if !ok {
+		return nil, fmt.Errorf("tasks are not available to authenticated clients of this server")
+	}
+	client, tenant := session.caller()
+	return handler.HandleTasksAs(method, params, client, tenant)
+}
+
+// caller names the session's client and tenant for a ScopedHandler
+func (session *httpSession) caller() (client, tenant string) {
+	if session.client != nil {
+		client = session.client.Name
+	}
+	return client, session.tenant.name()
+}
+
+// authenticate identifies the client making the request. It returns nil
+// and true when no clients are configured.
+func (s *HTTPServer) authenticate(r *http.Request) (*HTTPClient, bool) {
+	if len(s.opts.Clients) == 0 {
+		return nil, true
+	}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && token != "" {
+		for i := range s.opts.Clients {
+			client := &s.opts.Clients[i]
+			if client.Token != "" && subtle.ConstantTimeCompare([]byte(client.Token), []byte(token)) == 1 {
+				return client, true
+			}
+		}
+	}
+
+	// Only certificates that verified against the client CA count
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		for i := range s.opts.Clients {
+			client := &s.opts.Clients[i]
+			if client.CertCommonName != "" && client.CertCommonName == commonName {
+				return client, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// clientCertTLSConfig requests client certificates and verifies any that
+// are presented against the CA bundle. Certificates are optional at the
+// TLS layer so token clients can connect over the same listener.
+func clientCertTLSConfig(caFile string) (*tls.Config, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", caFile)
+	}
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
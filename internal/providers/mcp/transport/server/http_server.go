@@ -0,0 +1,640 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jobqueue"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+)
+
+// SessionIDHeader carries the Streamable HTTP session ID
+const SessionIDHeader = "Mcp-Session-Id"
+
+// TenantHeader names the tenant of a request when authentication is off,
+// as set by a gateway in front of the server
+const TenantHeader = "Mcp-Tenant"
+
+// maxHTTPBodySize bounds a single POSTed JSON-RPC message or batch
+const maxHTTPBodySize = 10 * 1024 * 1024
+
+// codeServerBusy is the JSON-RPC error for tool calls refused by the job
+// queue; single requests refused this way are answered with HTTP 429
+const codeServerBusy = -32000
+
+// busyRetryAfter is the Retry-After hint, in seconds, sent with a 429
+const busyRetryAfter = "5"
+
+// HTTPServerOptions configures the Streamable HTTP transport
+type HTTPServerOptions struct {
+	Addr           string        // host:port to listen on
+	Path           string        // MCP endpoint path (default: /mcp)
+	CertFile       string        // TLS certificate (serves HTTPS when set with KeyFile)
+	KeyFile        string        // TLS private key
+	AllowedOrigins []string      // Browser origins allowed to connect; "*" allows any (default: localhost only)
+	SessionTimeout time.Duration // Idle time before a session expires (default: 30m)
+	ClientCAFile   string        // CA bundle verifying client certificates (mTLS)
+	Clients        []HTTPClient  // Authorized clients; empty disables authentication
+	Tenants        []HTTPTenant  // Tenants requests belong to; empty disables tenancy
+	Metrics        http.Handler  // Served at /metrics when set (same origin and auth checks)
+}
+
+// HTTPServer implements the MCP Streamable HTTP transport: JSON-RPC
+// messages are POSTed to a single endpoint and answered with JSON, or with
+// an SSE stream when the client accepts one, so progress notifications can
+// be delivered before the result. Sessions are assigned on initialize via
+// the Mcp-Session-Id header.
+type HTTPServer struct {
+	handler MessageHandler
+	opts    HTTPServerOptions
+	server  *http.Server
+
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+	streams  map[string]*sseStream // Open SSE responses by progress token
+}
+
+// httpSession tracks one client session
+type httpSession struct {
+	id       string
+	lastSeen time.Time
+	client   *HTTPClient // Authenticated owner (nil without authentication)
+	tenant   *HTTPTenant // Tenant the session belongs to (nil without tenancy)
+}
+
+// sseStream is a POST response upgraded to text/event-stream
+type sseStream struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	closed  bool // Set when the handler returns; the writer is no longer valid
+}
+
+// NewHTTPServer creates a Streamable HTTP MCP server
+func NewHTTPServer(handler MessageHandler, opts HTTPServerOptions) *HTTPServer {
+	if opts.Path == "" {
+		opts.Path = "/mcp"
+	}
+	if opts.SessionTimeout <= 0 {
+		opts.SessionTimeout = 30 * time.Minute
+	}
+
+	s := &HTTPServer{
+		handler:  handler,
+		opts:     opts,
+		sessions: make(map[string]*httpSession),
+		streams:  make(map[string]*sseStream),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(opts.Path, s.handleMCP)
+	if opts.Metrics != nil {
+		mux.HandleFunc("/metrics", s.handleMetrics)
+	}
+	s.server = &http.Server{
+		Addr:              opts.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Start listens and serves until Stop is called. It blocks.
+func (s *HTTPServer) Start() error {
+	listener, err := net.Listen("tcp", s.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.opts.Addr, err)
+	}
+	return s.Serve(listener)
+}
+
+// Serve serves on an existing listener until Stop is called. It blocks.
+func (s *HTTPServer) Serve(listener net.Listener) error {
+	stopSweep := make(chan struct{})
+	defer close(stopSweep)
+	go s.sweepSessions(stopSweep)
+
+	var err error
+	if s.opts.ClientCAFile != "" {
+		if s.server.TLSConfig, err = clientCertTLSConfig(s.opts.ClientCAFile); err != nil {
+			return err
+		}
+	}
+	if s.opts.CertFile != "" && s.opts.KeyFile != "" {
+		logging.Info("Starting MCP server in Streamable HTTP mode: https://%s%s", listener.Addr(), s.opts.Path)
+		err = s.server.ServeTLS(listener, s.opts.CertFile, s.opts.KeyFile)
+	} else {
+		logging.Info("Starting MCP server in Streamable HTTP mode: http://%s%s", listener.Addr(), s.opts.Path)
+		err = s.server.Serve(listener)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Stop shuts the server down, waiting briefly for in-flight requests
+func (s *HTTPServer) Stop() {
+	logging.Info("Stopping MCP HTTP server")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		logging.Warn("HTTP server shutdown: %v", err)
+	}
+}
+
+// handleMCP serves the MCP endpoint
+func (s *HTTPServer) handleMCP(w http.ResponseWriter, r *http.Request) {
+	// Browsers send Origin; rejecting unknown ones prevents DNS rebinding attacks
+	if !s.originAllowed(r.Header.Get("Origin")) {
+		http.Error(w, "Forbidden origin", http.StatusForbidden)
+		return
+	}
+
+	client, ok := s.authenticate(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		s.handlePost(w, r, client)
+	case http.MethodDelete:
+		s.handleDelete(w, r, client)
+	default:
+		// No server-initiated stream (GET): all messages answer a POST
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleMetrics serves the metrics handler behind the endpoint's checks
+func (s *HTTPServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if !s.originAllowed(r.Header.Get("Origin")) {
+		http.Error(w, "Forbidden origin", http.StatusForbidden)
+		return
+	}
+	if _, ok := s.authenticate(r); !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.opts.Metrics.ServeHTTP(w, r)
+}
+
+// handlePost handles one JSON-RPC message or a batch
+func (s *HTTPServer) handlePost(w http.ResponseWriter, r *http.Request, client *HTTPClient) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPBodySize+1))
+	if err != nil {
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxHTTPBodySize {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	msgs, batch, err := parseJSONRPCBody(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorMessage(messages.NewRequestID(nil), -32700, "Parse error", nil))
+		return
+	}
+
+	// initialize creates the session; everything else must carry it
+	var session *httpSession
+	if isInitialize(msgs) {
+		if len(msgs) > 1 {
+			writeJSON(w, http.StatusBadRequest, errorMessage(msgs[0].ID, -32600, "initialize must not be batched", nil))
+			return
+		}
+		tenant, err := s.tenantFor(r, client)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		session = s.newSession(client, tenant)
+	} else {
+		id := r.Header.Get(SessionIDHeader)
+		if id == "" {
+			http.Error(w, "Missing "+SessionIDHeader+" header", http.StatusBadRequest)
+			return
+		}
+		if session = s.touchSession(id); session == nil {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+		if session.client != client {
+			http.Error(w, "Session belongs to another client", http.StatusForbidden)
+			return
+		}
+		if name := r.Header.Get(TenantHeader); name != "" && name != session.tenant.name() {
+			http.Error(w, "Session belongs to another tenant", http.StatusForbidden)
+			return
+		}
+	}
+
+	var requests []*messages.JSONRPCMessage
+	for _, msg := range msgs {
+		if msg.Method != "" && !msg.ID.IsEmpty() {
+			requests = append(requests, msg)
+		} else if msg.Method != "" {
+			logging.Debug("HTTP notification: %s", msg.Method)
+		}
+		// Client responses (no method) need no handling: the server sends no requests
+	}
+
+	w.Header().Set(SessionIDHeader, session.id)
+	if len(requests) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	// Stream when the client accepts SSE and asked for progress
+	tokens := progressTokens(requests)
+	if len(tokens) > 0 && acceptsEventStream(r) {
+		s.streamResponses(w, r, session, requests, tokens)
+		return
+	}
+
+	responses := make([]*messages.JSONRPCMessage, 0, len(requests))
+	for _, msg := range requests {
+		responses = append(responses, s.dispatch(msg, session))
+	}
+	if batch {
+		writeJSON(w, http.StatusOK, responses)
+		return
+	}
+	if responses[0].Error != nil && responses[0].Error.Code == codeServerBusy {
+		w.Header().Set("Retry-After", busyRetryAfter)
+		writeJSON(w, http.StatusTooManyRequests, responses[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, responses[0])
+}
+
+// streamResponses answers requests over SSE, forwarding progress
+// notifications for their tokens before each response
+func (s *HTTPServer) streamResponses(w http.ResponseWriter, r *http.Request, session *httpSession, requests []*messages.JSONRPCMessage, tokens []string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	stream := &sseStream{w: w, flusher: flusher}
+	s.mu.Lock()
+	for _, token := range tokens {
+		s.streams[token] = stream
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		for _, token := range tokens {
+			delete(s.streams, token)
+		}
+		s.mu.Unlock()
+
+		stream.mu.Lock()
+		stream.closed = true
+		stream.mu.Unlock()
+	}()
+
+	for _, msg := range requests {
+		if r.Context().Err() != nil {
+			return
+		}
+		stream.send(s.dispatch(msg, session))
+	}
+}
+
+// dispatch routes a request to the handler and builds the response,
+// limiting tools/list and tools/call to the tools the session's client and
+// tenant may use, and tasks/* to the tasks it started. Tool calls of a
+// tenant run with its configuration.
+func (s *HTTPServer) dispatch(msg *messages.JSONRPCMessage, session *httpSession) *messages.JSONRPCMessage {
+	client, tenant := session.client, session.tenant
+	allowsTool := func(name string) bool {
+		return client.AllowsTool(name) && tenant.AllowsTool(name)
+	}
+
+	params := make(map[string]interface{})
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return errorMessage(msg.ID, -32602, "Invalid params", nil)
+		}
+	}
+
+	var (
+		result map[string]interface{}
+		err    error
+	)
+	switch msg.Method {
+	case "initialize":
+		result, err = s.handler.HandleInitialize(params)
+	case "ping":
+		result = map[string]interface{}{}
+	case "tools/list":
+		result, err = s.handler.HandleToolsList(params)
+		if err == nil {
+			result = filterTools(result, allowsTool)
+		}
+	case "tools/call":
+		// Out-of-scope tools are reported as unknown so their names don't leak
+		if name, _ := params["name"].(string); !allowsTool(name) {
+			logging.Warn("HTTP client %s denied tool: %s", client.name(), name)
+			return errorMessage(msg.ID, -32602, "Unknown tool: "+name, nil)
+		}
+		result, err = s.callTool(params, session)
+		if errors.Is(err, jobqueue.ErrRejected) {
+			return errorMessage(msg.ID, codeServerBusy, err.Error(), nil)
+		}
+		if err != nil {
+			// Tool failures are results the model can see, as in stdio mode
+			logging.Error("Tools call handler failed: %v", err)
+			result, err = map[string]interface{}{
+				"content": []interface{}{
+					map[string]interface{}{"type": "text", "text": fmt.Sprintf("Error: %v", err)},
+				},
+				"isError": true,
+			}, nil
+		}
+	case "tasks/get", "tasks/result", "tasks/list", "tasks/cancel":
+		result, err = s.handleTasks(msg.Method, params, session)
+	default:
+		logging.Warn("Unknown method: %s", msg.Method)
+		return errorMessage(msg.ID, -32601, "Method not found", map[string]interface{}{"method": msg.Method})
+	}
+	if err != nil {
+		logging.Error("%s handler failed: %v", msg.Method, err)
+		return errorMessage(msg.ID, -32603, err.Error(), nil)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return errorMessage(msg.ID, -32603, "Internal error", nil)
+	}
+	return &messages.JSONRPCMessage{JSONRPC: "2.0", ID: msg.ID, Result: resultJSON}
+}
+
+// handleDelete ends a session at the client's request
+func (s *HTTPServer) handleDelete(w http.ResponseWriter, r *http.Request, client *HTTPClient) {
+	id := r.Header.Get(SessionIDHeader)
+	if id == "" {
+		http.Error(w, "Missing "+SessionIDHeader+" header", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	session, ok := s.sessions[id]
+	if ok && session.client == client {
+		delete(s.sessions, id)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+	if session.client != client {
+		http.Error(w, "Session belongs to another client", http.StatusForbidden)
+		return
+	}
+	logging.Info("HTTP session closed: %s", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SendProgressNotification forwards a progress notification to the SSE
+// stream of the request that registered the token. Tokens without an open
+// stream (JSON responses) are dropped.
+func (s *HTTPServer) SendProgressNotification(progressToken string, progress float64, total int, message string) {
+	s.mu.Lock()
+	stream := s.streams[progressToken]
+	s.mu.Unlock()
+	if stream == nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"progressToken": progressToken,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	stream.send(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "notifications/progress",
+		"params":  params,
+	})
+}
+
+// send writes one SSE message event
+func (st *sseStream) send(msg interface{}) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		logging.Error("Failed to marshal SSE message: %v", err)
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.closed {
+		return
+	}
+	if _, err := fmt.Fprintf(st.w, "event: message\ndata: %s\n\n", data); err != nil {
+		logging.Debug("Failed to write SSE event: %v", err)
+		return
+	}
+	st.flusher.Flush()
+}
+
+// newSession creates a session with an unguessable ID, owned by client
+// and belonging to tenant
+func (s *HTTPServer) newSession(client *HTTPClient, tenant *HTTPTenant) *httpSession {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	session := &httpSession{id: hex.EncodeToString(buf), lastSeen: time.Now(), client: client, tenant: tenant}
+
+	s.mu.Lock()
+	s.sessions[session.id] = session
+	s.mu.Unlock()
+
+	if tenant != nil {
+		logging.Info("HTTP session started: %s (client: %s, tenant: %s)", session.id, client.name(), tenant.Name)
+	} else {
+		logging.Info("HTTP session started: %s (client: %s)", session.id, client.name())
+	}
+	return session
+}
+
+// touchSession returns a live session and marks it active, or nil
+func (s *HTTPServer) touchSession(id string) *httpSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil
+	}
+	if time.Since(session.lastSeen) > s.opts.SessionTimeout {
+		delete(s.sessions, id)
+		return nil
+	}
+	session.lastSeen = time.Now()
+	return session
+}
+
+// sweepSessions removes idle sessions until stop is closed
+func (s *HTTPServer) sweepSessions(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			for id, session := range s.sessions {
+				if time.Since(session.lastSeen) > s.opts.SessionTimeout {
+					delete(s.sessions, id)
+					logging.Info("HTTP session expired: %s", id)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// SessionCount returns the number of live sessions
+func (s *HTTPServer) SessionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// originAllowed reports whether a request from origin may proceed.
+// Requests without an Origin header come from non-browser clients.
+func (s *HTTPServer) originAllowed(origin string) bool {
+	if origin == "" {
+		return true
+	}
+	if len(s.opts.AllowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		host := u.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	}
+	for _, allowed := range s.opts.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJSONRPCBody decodes a single message or a batch array
+func parseJSONRPCBody(body []byte) ([]*messages.JSONRPCMessage, bool, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var msgs []*messages.JSONRPCMessage
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			return nil, true, err
+		}
+		if len(msgs) == 0 {
+			return nil, true, fmt.Errorf("empty batch")
+		}
+		return msgs, true, nil
+	}
+
+	var msg messages.JSONRPCMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, false, err
+	}
+	return []*messages.JSONRPCMessage{&msg}, false, nil
+}
+
+func isInitialize(msgs []*messages.JSONRPCMessage) bool {
+	for _, msg := range msgs {
+		if msg.Method == "initialize" {
+			return true
+		}
+	}
+	return false
+}
+
+// progressTokens returns the progress tokens requested in _meta
+func progressTokens(requests []*messages.JSONRPCMessage) []string {
+	var tokens []string
+	for _, msg := range requests {
+		var params struct {
+			Meta struct {
+				ProgressToken interface{} `json:"progressToken"`
+			} `json:"_meta"`
+		}
+		if len(msg.Params) == 0 || json.Unmarshal(msg.Params, &params) != nil {
+			continue
+		}
+		// The service only reports progress for string tokens
+		if token, ok := params.Meta.ProgressToken.(string); ok && token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+func errorMessage(id messages.RequestID, code int, message string, data interface{}) *messages.JSONRPCMessage {
+	var dataJSON json.RawMessage
+	if data != nil {
+		dataJSON, _ = json.Marshal(data)
+	}
+	return &messages.JSONRPCMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &messages.JSONRPCError{Code: code, Message: message, Data: dataJSON},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.Debug("Failed to write HTTP response: %v", err)
+	}
+}
@@ -0,0 +1,503 @@
+package server
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jobqueue"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+)
+
+// fakeHandler answers MCP requests and reports progress through the server
+type fakeHandler struct {
+	server *HTTPServer
+	tenant string            // Tenant of the last scoped tools/call
+	tasks  map[string]string // Task ID -> client@tenant that started it
+}
+
+func (h *fakeHandler) HandleInitialize(params map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{"protocolVersion": "2024-11-05", "serverInfo": map[string]interface{}{"name": "test"}}, nil
+}
+
+func (h *fakeHandler) HandleToolsList(params map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{"tools": []interface{}{
+		map[string]interface{}{"name": "echo"},
+		map[string]interface{}{"name": "admin_reset"},
+	}}, nil
+}
+
+func (h *fakeHandler) HandleToolsCall(params map[string]interface{}) (map[string]interface{}, error) {
+	if params["name"] == "busy" {
+		return nil, jobqueue.ErrRejected
+	}
+	if name := params["name"]; name != "echo" && name != "admin_reset" {
+		return nil, fmt.Errorf("unknown tool")
+	}
+	if meta, ok := params["_meta"].(map[string]interface{}); ok {
+		if token, ok := meta["progressToken"].(string); ok {
+			h.server.SendProgressNotification(token, 0.5, 1, "halfway")
+		}
+	}
+	return map[string]interface{}{"content": []interface{}{map[string]interface{}{"type": "text", "text": "hi"}}}, nil
+}
+
+func (h *fakeHandler) HandleToolsCallAs(params map[string]interface{}, client, tenant string) (map[string]interface{}, error) {
+	h.tenant = tenant
+	if _, ok := params["task"]; !ok {
+		return h.HandleToolsCall(params)
+	}
+	if h.tasks == nil {
+		h.tasks = make(map[string]string)
+	}
+	id := fmt.Sprintf("task-%d", len(h.tasks)+1)
+	h.tasks[id] = client + "@" + tenant
+	return map[string]interface{}{"task": map[string]interface{}{"taskId": id}}, nil
+}
+
+func (h *fakeHandler) HandleTasksAs(method string, params map[string]interface{}, client, tenant string) (map[string]interface{}, error) {
+	if method == "tasks/list" {
+		owned := []interface{}{}
+		for id, owner := range h.tasks {
+			if owner == client+"@"+tenant {
+				owned = append(owned, map[string]interface{}{"taskId": id})
+			}
+		}
+		return map[string]interface{}{"tasks": owned}, nil
+	}
+	id, _ := params["taskId"].(string)
+	if owner, ok := h.tasks[id]; !ok || owner != client+"@"+tenant {
+		return nil, fmt.Errorf("task not found: %s", id)
+	}
+	return map[string]interface{}{"task": map[string]interface{}{"taskId": id}}, nil
+}
+
+func (h *fakeHandler) HandleTasksGet(params map[string]interface{}) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("no tasks")
+}
+
+func (h *fakeHandler) HandleTasksResult(params map[string]interface{}) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("no tasks")
+}
+
+func (h *fakeHandler) HandleTasksList(params map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{"tasks": []interface{}{}}, nil
+}
+
+func (h *fakeHandler) HandleTasksCancel(params map[string]interface{}) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("no tasks")
+}
+
+func newTestHTTPServer(t *testing.T, opts HTTPServerOptions) (*HTTPServer, *httptest.Server) {
+	t.Helper()
+	handler := &fakeHandler{}
+	s := NewHTTPServer(handler, opts)
+	handler.server = s
+	ts := httptest.NewServer(s.server.Handler)
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+func post(t *testing.T, url, session, accept, body string) *http.Response {
+	t.Helper()
+	return postAs(t, url, "", session, accept, body)
+}
+
+func postAs(t *testing.T, url, token, session, accept, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if session != "" {
+		req.Header.Set(SessionIDHeader, session)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestHTTPServer_SessionLifecycle(t *testing.T) {
+	s, ts := newTestHTTPServer(t, HTTPServerOptions{})
+	url := ts.URL + "/mcp"
+
+	resp := post(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+	session := resp.Header.Get(SessionIDHeader)
+	if resp.StatusCode != http.StatusOK || session == "" {
+		t.Fatalf("initialize: status %d, session %q", resp.StatusCode, session)
+	}
+
+	if resp := post(t, url, session, "", `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("notification status = %d, want 202", resp.StatusCode)
+	}
+
+	resp = post(t, url, session, "", `{"jsonrpc":"2.0","id":"a","method":"tools/list"}`)
+	var msg struct {
+		ID     string `json:"id"`
+		Result struct {
+			Tools []map[string]interface{} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.ID != "a" || len(msg.Result.Tools) != 2 {
+		t.Errorf("tools/list = %+v", msg)
+	}
+
+	// Batches get an array of responses
+	resp = post(t, url, session, "", `[{"jsonrpc":"2.0","id":1,"method":"ping"},{"jsonrpc":"2.0","id":2,"method":"nope"}]`)
+	var batch []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != 2 || batch[1]["error"] == nil {
+		t.Errorf("batch = %v", batch)
+	}
+
+	if resp := post(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("missing session status = %d, want 400", resp.StatusCode)
+	}
+	if resp := post(t, url, "unknown", "", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown session status = %d, want 404", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+	req.Header.Set(SessionIDHeader, session)
+	del, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	del.Body.Close()
+	if del.StatusCode != http.StatusNoContent || s.SessionCount() != 0 {
+		t.Errorf("delete status = %d, sessions = %d", del.StatusCode, s.SessionCount())
+	}
+	if resp := post(t, url, session, "", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("closed session status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestHTTPServer_StreamsProgress(t *testing.T) {
+	_, ts := newTestHTTPServer(t, HTTPServerOptions{})
+	url := ts.URL + "/mcp"
+
+	session := post(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`).Header.Get(SessionIDHeader)
+
+	resp := post(t, url, session, "application/json, text/event-stream",
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","_meta":{"progressToken":"p1"}}}`)
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %s, want text/event-stream", ct)
+	}
+
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			events = append(events, data)
+		}
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %v, want progress then result", events)
+	}
+	if !strings.Contains(events[0], `"notifications/progress"`) || !strings.Contains(events[0], `"halfway"`) {
+		t.Errorf("first event = %s", events[0])
+	}
+	if !strings.Contains(events[1], `"id":2`) || !strings.Contains(events[1], `"result"`) {
+		t.Errorf("second event = %s", events[1])
+	}
+}
+
+func TestHTTPServer_OriginAndExpiry(t *testing.T) {
+	s, ts := newTestHTTPServer(t, HTTPServerOptions{SessionTimeout: time.Millisecond})
+	url := ts.URL + "/mcp"
+
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize"}`))
+	req.Header.Set("Origin", "https://evil.example")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("foreign origin status = %d, want 403", resp.StatusCode)
+	}
+	if !s.originAllowed("http://localhost:3000") {
+		t.Error("localhost origin should be allowed by default")
+	}
+
+	session := post(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`).Header.Get(SessionIDHeader)
+	time.Sleep(5 * time.Millisecond)
+	if resp := post(t, url, session, "", `{"jsonrpc":"2.0","id":2,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expired session status = %d, want 404", resp.StatusCode)
+	}
+
+	allowAll := NewHTTPServer(&fakeHandler{}, HTTPServerOptions{AllowedOrigins: []string{"*"}})
+	if !allowAll.originAllowed("https://app.example") {
+		t.Error(`"*" should allow any origin`)
+	}
+}
+
+func TestHTTPServer_AuthAndToolScoping(t *testing.T) {
+	s, ts := newTestHTTPServer(t, HTTPServerOptions{Clients: []HTTPClient{
+		{Name: "ops", Token: "ops-token"},
+		{Name: "bot", Token: "bot-token", Tools: []string{"ec*"}},
+		{Name: "laptop", CertCommonName: "laptop.internal"},
+	}})
+	url := ts.URL + "/mcp"
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize"}`
+
+	resp := postAs(t, url, "", "", "", initialize)
+	if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+		t.Errorf("no token status = %d, want 401 with WWW-Authenticate", resp.StatusCode)
+	}
+	if resp := postAs(t, url, "wrong", "", "", initialize); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("wrong token status = %d, want 401", resp.StatusCode)
+	}
+
+	session := postAs(t, url, "bot-token", "", "", initialize).Header.Get(SessionIDHeader)
+	resp = postAs(t, url, "bot-token", session, "", `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	var list struct {
+		Result struct {
+			Tools []map[string]interface{} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Result.Tools) != 1 || list.Result.Tools[0]["name"] != "echo" {
+		t.Errorf("scoped tools/list = %v, want only echo", list.Result.Tools)
+	}
+
+	resp = postAs(t, url, "bot-token", session, "", `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"admin_reset"}}`)
+	var call map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&call); err != nil {
+		t.Fatal(err)
+	}
+	if call["error"] == nil {
+		t.Errorf("out-of-scope tools/call = %v, want error", call)
+	}
+
+	// Sessions can't be used with another client's credentials
+	if resp := postAs(t, url, "ops-token", session, "", `{"jsonrpc":"2.0","id":4,"method":"ping"}`); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("foreign session status = %d, want 403", resp.StatusCode)
+	}
+
+	// Verified client certificates authenticate by common name
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+		{Subject: pkix.Name{CommonName: "laptop.internal"}},
+	}}}
+	if client, ok := s.authenticate(req); !ok || client.Name != "laptop" {
+		t.Errorf("certificate auth = %v, %v", client, ok)
+	}
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+		{Subject: pkix.Name{CommonName: "laptop.internal"}},
+	}}
+	if _, ok := s.authenticate(req); ok {
+		t.Error("unverified certificate should not authenticate")
+	}
+}
+
+func TestHTTPServer_TaskOwnership(t *testing.T) {
+	clients := []HTTPClient{{Name: "ops", Token: "ops-token"}, {Name: "bot", Token: "bot-token"}}
+	_, ts := newTestHTTPServer(t, HTTPServerOptions{Clients: clients})
+	url := ts.URL + "/mcp"
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize"}`
+	call := func(token, session, body string) map[string]interface{} {
+		var msg map[string]interface{}
+		if err := json.NewDecoder(postAs(t, url, token, session, "", body).Body).Decode(&msg); err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+
+	ops := postAs(t, url, "ops-token", "", "", initialize).Header.Get(SessionIDHeader)
+	bot := postAs(t, url, "bot-token", "", "", initialize).Header.Get(SessionIDHeader)
+	started := call("ops-token", ops, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","task":{}}}`)
+	task, _ := started["result"].(map[string]interface{})["task"].(map[string]interface{})
+	taskID, _ := task["taskId"].(string)
+	if taskID == "" {
+		t.Fatalf("task-augmented tools/call = %v, want a task", started)
+	}
+
+	listTasks := func(token, session string) []interface{} {
+		result, _ := call(token, session, `{"jsonrpc":"2.0","id":3,"method":"tasks/list"}`)["result"].(map[string]interface{})
+		tasks, _ := result["tasks"].([]interface{})
+		return tasks
+	}
+	if tasks := listTasks("ops-token", ops); len(tasks) != 1 {
+		t.Errorf("ops tasks/list = %v, want its task", tasks)
+	}
+	if tasks := listTasks("bot-token", bot); len(tasks) != 0 {
+		t.Errorf("bot tasks/list = %v, want none of ops's tasks", tasks)
+	}
+	for _, method := range []string{"tasks/get", "tasks/result", "tasks/cancel"} {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":4,"method":%q,"params":{"taskId":%q}}`, method, taskID)
+		if msg := call("bot-token", bot, body); msg["error"] == nil {
+			t.Errorf("bot %s of ops's task = %v, want error", method, msg)
+		}
+	}
+	if msg := call("ops-token", ops, fmt.Sprintf(`{"jsonrpc":"2.0","id":5,"method":"tasks/get","params":{"taskId":%q}}`, taskID)); msg["error"] != nil {
+		t.Errorf("ops tasks/get of its task = %v", msg)
+	}
+
+	// Handlers that can't tell owners apart refuse tasks to authenticated clients
+	plain := NewHTTPServer(struct{ MessageHandler }{&fakeHandler{}}, HTTPServerOptions{Clients: clients})
+	msg := plain.dispatch(&messages.JSONRPCMessage{JSONRPC: "2.0", Method: "tasks/list"}, &httpSession{client: &clients[1]})
+	if msg.Error == nil {
+		t.Errorf("unscoped tasks/list = %s, want error", msg.Result)
+	}
+}
+
+func TestHTTPServer_Tenants(t *testing.T) {
+	s, ts := newTestHTTPServer(t, HTTPServerOptions{
+		Clients: []HTTPClient{
+			{Name: "a-bot", Token: "a-token", Tenant: "team-a"},
+			{Name: "b-bot", Token: "b-token", Tenant: "team-b"},
+		},
+		Tenants: []HTTPTenant{
+			{Name: "team-a", Tools: []string{"echo"}},
+			{Name: "team-b"},
+		},
+	})
+	url := ts.URL + "/mcp"
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize"}`
+	asTenant := func(token, session, tenant, body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		if session != "" {
+			req.Header.Set(SessionIDHeader, session)
+		}
+		if tenant != "" {
+			req.Header.Set(TenantHeader, tenant)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	// Clients can't claim another tenant
+	if resp := asTenant("a-token", "", "team-b", initialize); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("foreign tenant status = %d, want 403", resp.StatusCode)
+	}
+
+	session := asTenant("a-token", "", "", initialize).Header.Get(SessionIDHeader)
+	if session == "" {
+		t.Fatal("no session for a tenant client")
+	}
+	resp := asTenant("a-token", session, "", `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"admin_reset"}}`)
+	var call map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&call); err != nil {
+		t.Fatal(err)
+	}
+	if call["error"] == nil {
+		t.Errorf("tool outside the tenant's tools = %v, want error", call)
+	}
+
+	asTenant("a-token", session, "", `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo"}}`)
+	if got := s.handler.(*fakeHandler).tenant; got != "team-a" {
+		t.Errorf("tools/call ran for tenant %q, want team-a", got)
+	}
+	if resp := asTenant("a-token", session, "team-b", `{"jsonrpc":"2.0","id":4,"method":"ping"}`); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("session used for another tenant status = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestHTTPServer_TenantTasks(t *testing.T) {
+	_, ts := newTestHTTPServer(t, HTTPServerOptions{Tenants: []HTTPTenant{{Name: "team-a"}, {Name: "team-b"}}})
+	url := ts.URL + "/mcp"
+	call := func(session, tenant, body string) (*http.Response, map[string]interface{}) {
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(TenantHeader, tenant)
+		if session != "" {
+			req.Header.Set(SessionIDHeader, session)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var msg map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+			t.Fatal(err)
+		}
+		return resp, msg
+	}
+
+	resp, _ := call("", "team-a", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+	teamA := resp.Header.Get(SessionIDHeader)
+	resp, _ = call("", "team-b", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`)
+	teamB := resp.Header.Get(SessionIDHeader)
+
+	_, started := call(teamA, "team-a", `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","task":{}}}`)
+	task, _ := started["result"].(map[string]interface{})["task"].(map[string]interface{})
+	taskID, _ := task["taskId"].(string)
+	if taskID == "" {
+		t.Fatalf("task-augmented tools/call = %v, want a task", started)
+	}
+
+	_, listed := call(teamB, "team-b", `{"jsonrpc":"2.0","id":3,"method":"tasks/list"}`)
+	if tasks, _ := listed["result"].(map[string]interface{})["tasks"].([]interface{}); len(tasks) != 0 {
+		t.Errorf("team-b tasks/list = %v, want none of team-a's tasks", tasks)
+	}
+	if _, msg := call(teamB, "team-b", fmt.Sprintf(`{"jsonrpc":"2.0","id":4,"method":"tasks/result","params":{"taskId":%q}}`, taskID)); msg["error"] == nil {
+		t.Errorf("team-b tasks/result of team-a's task = %v, want error", msg)
+	}
+	if _, msg := call(teamA, "team-a", fmt.Sprintf(`{"jsonrpc":"2.0","id":5,"method":"tasks/result","params":{"taskId":%q}}`, taskID)); msg["error"] != nil {
+		t.Errorf("team-a tasks/result of its task = %v", msg)
+	}
+}
+
+func TestHTTPServer_BusyAndMetrics(t *testing.T) {
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "mcp_queue_waiting 0")
+	})
+	_, ts := newTestHTTPServer(t, HTTPServerOptions{Metrics: metrics})
+	url := ts.URL + "/mcp"
+
+	session := post(t, url, "", "", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`).Header.Get(SessionIDHeader)
+	resp := post(t, url, session, "", `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"busy"}}`)
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("rejected call status = %d, want 429 with Retry-After", resp.StatusCode)
+	}
+
+	// In a batch the refusal is only a JSON-RPC error
+	resp = post(t, url, session, "", `[{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"busy"}},{"jsonrpc":"2.0","id":4,"method":"ping"}]`)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("batch status = %d, want 200", resp.StatusCode)
+	}
+
+	metricsResp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer metricsResp.Body.Close()
+	body, _ := io.ReadAll(metricsResp.Body)
+	if metricsResp.StatusCode != http.StatusOK || !strings.Contains(string(body), "mcp_queue_waiting") {
+		t.Errorf("metrics = %d %q", metricsResp.StatusCode, body)
+	}
+}
@@ -1,27 +1,84 @@
 package stdio
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"sync"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
 )
 
+// JSON-RPC error codes used when answering server requests
+const (
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInternalError  = -32603
+)
+
+// RequestHandler answers a request the server sends to the client, such
+// as elicitation/create. It returns the result, or an error to send back.
+type RequestHandler func(ctx context.Context, msg *messages.JSONRPCMessage) (interface{}, *messages.JSONRPCError)
+
+// NotificationHandler handles a notification the server sends to the
+// client, such as notifications/tools/list_changed
+type NotificationHandler func(msg *messages.JSONRPCMessage)
+
 // ResponseDispatcher handles routing responses to waiting requests
 type ResponseDispatcher struct {
 	client       *StdioClient
 	pending      map[string]chan *messages.JSONRPCMessage
 	pendingMutex sync.RWMutex
+	handlers     map[string]RequestHandler
+	listeners    map[string]NotificationHandler
+	handlerMutex sync.RWMutex
 	started      bool
 	startMutex   sync.Mutex
 }
 
 // NewResponseDispatcher creates a new response dispatcher
 func NewResponseDispatcher(client *StdioClient) *ResponseDispatcher {
-	return &ResponseDispatcher{
-		client:  client,
-		pending: make(map[string]chan *messages.JSONRPCMessage),
+	d := &ResponseDispatcher{
+		client:    client,
+		pending:   make(map[string]chan *messages.JSONRPCMessage),
+		handlers:  make(map[string]RequestHandler),
+		listeners: make(map[string]NotificationHandler),
+	}
+	d.HandleRequest("ping", func(ctx context.Context, msg *messages.JSONRPCMessage) (interface{}, *messages.JSONRPCError) {
+		return struct{}{}, nil
+	})
+	return d
+}
+
+// HandleRequest registers the handler for server requests with the given method
+func (d *ResponseDispatcher) HandleRequest(method string, handler RequestHandler) {
+	d.handlerMutex.Lock()
+	defer d.handlerMutex.Unlock()
+	d.handlers[method] = handler
+}
+
+// HandleNotification registers the handler for server notifications with the given method
+func (d *ResponseDispatcher) HandleNotification(method string, handler NotificationHandler) {
+	d.handlerMutex.Lock()
+	defer d.handlerMutex.Unlock()
+	d.listeners[method] = handler
+}
+
+// Handles reports whether a handler is registered for method
+func (d *ResponseDispatcher) Handles(method string) bool {
+	d.handlerMutex.RLock()
+	defer d.handlerMutex.RUnlock()
+	_, ok := d.handlers[method]
+	return ok
+}
+
+// Notify sends a notification to the server
+func (d *ResponseDispatcher) Notify(method string, params interface{}) error {
+	msg, err := messages.NewNotification(method, params)
+	if err != nil {
+		return err
 	}
+	return d.client.Write(msg)
 }
 
 // Start begins the dispatcher goroutine (call once)
@@ -41,6 +98,17 @@ func (d *ResponseDispatcher) Start() {
 func (d *ResponseDispatcher) dispatch() {
 	logging.Debug("Response dispatcher started")
 	for msg := range d.client.Read() {
+		// Requests from the server are answered in their own goroutine,
+		// since handlers such as elicitation may wait on the user
+		if msg.IsRequest() {
+			if !msg.ID.IsEmpty() {
+				go d.answer(msg)
+			} else {
+				d.notified(msg)
+			}
+			continue
+		}
+
 		msgID := msg.ID.String()
 		logging.Debug("Dispatcher received message ID: %s", msgID)
 
@@ -68,6 +136,44 @@ func (d *ResponseDispatcher) dispatch() {
 	logging.Debug("Response dispatcher stopped")
 }
 
+// answer runs the handler for a server request and writes its response
+func (d *ResponseDispatcher) answer(msg *messages.JSONRPCMessage) {
+	d.handlerMutex.RLock()
+	handler, ok := d.handlers[msg.Method]
+	d.handlerMutex.RUnlock()
+
+	response := &messages.JSONRPCMessage{JSONRPC: "2.0", ID: msg.ID}
+	if !ok {
+		logging.Debug("No handler for server request %s (ID %s)", msg.Method, msg.ID.String())
+		response.Error = &messages.JSONRPCError{Code: ErrCodeMethodNotFound, Message: "method not found: " + msg.Method}
+	} else if result, rpcErr := handler(d.client.ctx, msg); rpcErr != nil {
+		response.Error = rpcErr
+	} else if data, err := json.Marshal(result); err != nil {
+		response.Error = &messages.JSONRPCError{Code: ErrCodeInternalError, Message: fmt.Sprintf("failed to encode result: %v", err)}
+	} else {
+		response.Result = data
+	}
+
+	if err := d.client.Write(response); err != nil {
+		logging.Warn("Failed to answer server request %s: %v", msg.Method, err)
+	}
+}
+
+// notified runs the handler for a server notification. Handlers run in their
+// own goroutine so one that takes a lock held by a pending request (e.g.
+// tools/list) can't stall the responses that request is waiting for.
+func (d *ResponseDispatcher) notified(msg *messages.JSONRPCMessage) {
+	d.handlerMutex.RLock()
+	handler, ok := d.listeners[msg.Method]
+	d.handlerMutex.RUnlock()
+
+	if !ok {
+		logging.Debug("Ignoring server notification %s", msg.Method)
+		return
+	}
+	go handler(msg)
+}
+
 // RegisterRequest registers a request ID and returns a channel for the response
 func (d *ResponseDispatcher) RegisterRequest(requestID string) chan *messages.JSONRPCMessage {
 	responseCh := make(chan *messages.JSONRPCMessage, 1)
@@ -0,0 +1,42 @@
+package stdio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages"
+)
+
+func TestDispatcherNotifications(t *testing.T) {
+	client := &StdioClient{readChan: make(chan *messages.JSONRPCMessage, 2)}
+	d := NewResponseDispatcher(client)
+
+	got := make(chan string, 2)
+	d.HandleNotification("notifications/tools/list_changed", func(msg *messages.JSONRPCMessage) {
+		got <- msg.Method
+	})
+	d.Start()
+
+	for _, method := range []string{"notifications/unknown", "notifications/tools/list_changed"} {
+		msg, err := messages.NewNotification(method, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.readChan <- msg
+	}
+	close(client.readChan)
+
+	select {
+	case method := <-got:
+		if method != "notifications/tools/list_changed" {
+			t.Errorf("handler got %s", method)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("notification handler was not called")
+	}
+	select {
+	case method := <-got:
+		t.Errorf("unexpected second notification %s", method)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,95 @@
+package stdio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// newServerCommand builds the command that starts a server, with the
+// platform's fixes applied
+func newServerCommand(ctx context.Context, params StdioServerParameters) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, params.Command, params.Args...)
+	configureCommand(cmd, params.Args)
+
+	// Set environment variables; the server's own settings win over the
+	// platform defaults
+	defaults := platformEnv()
+	if params.Env != nil || len(defaults) > 0 {
+		env := os.Environ()
+		for k, v := range defaults {
+			env = append(env, fmt.Sprintf("%s=%s", k, v))
+		}
+		for k, v := range params.Env {
+			env = append(env, fmt.Sprintf("%s=%s", k, v))
+			logging.Debug("Setting environment variable: %s=%s", k, v)
+		}
+		cmd.Env = env
+	}
+	return cmd
+}
+
+// isBatchFile reports whether path is a Windows batch script, which has to
+// be run through cmd.exe
+func isBatchFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".cmd", ".bat":
+		return true
+	}
+	return false
+}
+
+// batchMetaChars are the characters cmd.exe interprets, even inside quotes
+var batchMetaChars = regexp.MustCompile("([()\\][%!^\"`<>&|;, *?])")
+
+// cmdShimPath matches the wrappers npm installs for package binaries, which
+// pass their arguments through cmd.exe a second time
+var cmdShimPath = regexp.MustCompile(`(?i)node_modules[\\/]\.bin[\\/][^\\/]+\.cmd$`)
+
+// batchCommandLine returns the cmd.exe arguments that run a batch script
+// with args passed through unchanged, so arguments containing spaces,
+// quotes or characters like & and % can't break out of the command
+func batchCommandLine(path string, args []string) string {
+	doubleEscape := cmdShimPath.MatchString(path)
+
+	parts := []string{batchMetaChars.ReplaceAllString(path, "^$1")}
+	for _, arg := range args {
+		quoted := batchMetaChars.ReplaceAllString(quoteArg(arg), "^$1")
+		if doubleEscape {
+			quoted = batchMetaChars.ReplaceAllString(quoted, "^$1")
+		}
+		parts = append(parts, quoted)
+	}
+	return `/d /s /c "` + strings.Join(parts, " ") + `"`
+}
+
+// quoteArg quotes an argument the way Windows programs parse their command
+// line: backslashes are only special before a quote
+func quoteArg(arg string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	backslashes := 0
+	for i := 0; i < len(arg); i++ {
+		switch c := arg[i]; c {
+		case '\\':
+			backslashes++
+		case '"':
+			b.WriteString(strings.Repeat(`\`, backslashes*2+1))
+			b.WriteByte('"')
+			backslashes = 0
+		default:
+			b.WriteString(strings.Repeat(`\`, backslashes))
+			b.WriteByte(c)
+			backslashes = 0
+		}
+	}
+	b.WriteString(strings.Repeat(`\`, backslashes*2))
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,30 @@
+//go:build !windows
+
+package stdio
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// configureCommand needs no changes outside Windows
+func configureCommand(cmd *exec.Cmd, args []string) {}
+
+// platformEnv adds no environment outside Windows
+func platformEnv() map[string]string {
+	return nil
+}
+
+// processJob groups a server's processes; only Windows has one
+type processJob struct{}
+
+func attachJob(process *os.Process) (*processJob, error) {
+	return nil, nil
+}
+
+func (j *processJob) kill() error {
+	return fmt.Errorf("no job")
+}
+
+func (j *processJob) close() {}
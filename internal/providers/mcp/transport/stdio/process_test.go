@@ -0,0 +1,38 @@
+package stdio
+
+import "testing"
+
+func TestQuoteArg(t *testing.T) {
+	tests := map[string]string{
+		``:            `""`,
+		`plain`:       `"plain"`,
+		`a b`:         `"a b"`,
+		`say "hi"`:    `"say \"hi\""`,
+		`C:\dir\`:     `"C:\dir\\"`,
+		`back\"quote`: `"back\\\"quote"`,
+	}
+	for arg, want := range tests {
+		if got := quoteArg(arg); got != want {
+			t.Errorf("quoteArg(%q) = %s, want %s", arg, got, want)
+		}
+	}
+}
+
+func TestBatchCommandLine(t *testing.T) {
+	got := batchCommandLine(`C:\Program Files\nodejs\npx.cmd`, []string{"-y", "a b", `say "hi" & exit`, "50%"})
+	want := `/d /s /c "C:\Program^ Files\nodejs\npx.cmd ^"-y^" ^"a^ b^" ^"say^ \^"hi\^"^ ^&^ exit^" ^"50^%^""`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	// npm's wrappers pass arguments through cmd.exe twice
+	got = batchCommandLine(`C:\app\node_modules\.bin\server.cmd`, []string{"x&y"})
+	want = `/d /s /c "C:\app\node_modules\.bin\server.cmd ^^^"x^^^&y^^^""`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	if !isBatchFile(`C:\tools\RUN.BAT`) || isBatchFile(`C:\tools\node.exe`) {
+		t.Error("isBatchFile misclassified")
+	}
+}
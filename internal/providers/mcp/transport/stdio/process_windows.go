@@ -0,0 +1,92 @@
+package stdio
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// configureCommand runs batch scripts (npx.cmd and other npm wrappers)
+// through cmd.exe with arguments quoted for it. Go would otherwise pass them
+// quoted for ordinary programs, which cmd.exe misreads.
+func configureCommand(cmd *exec.Cmd, args []string) {
+	if cmd.Err != nil || !isBatchFile(cmd.Path) {
+		return
+	}
+	comspec := os.Getenv("ComSpec")
+	if comspec == "" {
+		comspec = `C:\Windows\System32\cmd.exe`
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine: syscall.EscapeArg(comspec) + " " + batchCommandLine(cmd.Path, args),
+	}
+	cmd.Path = comspec
+}
+
+// platformEnv makes Python servers write UTF-8 to their pipes instead of
+// the console's legacy code page, which corrupts non-ASCII JSON
+func platformEnv() map[string]string {
+	if os.Getenv("PYTHONIOENCODING") != "" {
+		return nil
+	}
+	return map[string]string{"PYTHONIOENCODING": "utf-8"}
+}
+
+// processJob is a Windows job object holding a server and every process it
+// starts. Windows kills the whole job when its last handle closes, which
+// also happens when mcp-cli crashes, so servers aren't left orphaned.
+type processJob struct {
+	handle windows.Handle
+}
+
+// attachJob puts a started process in a new job. Processes it started
+// before being attached aren't included.
+func attachJob(process *os.Process) (*processJob, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job object: %w", err)
+	}
+
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("failed to configure job object: %w", err)
+	}
+
+	handle, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(process.Pid))
+	if err != nil {
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("failed to open process %d: %w", process.Pid, err)
+	}
+	defer windows.CloseHandle(handle)
+
+	if err := windows.AssignProcessToJobObject(job, handle); err != nil {
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("failed to assign process %d to job: %w", process.Pid, err)
+	}
+	return &processJob{handle: job}, nil
+}
+
+// kill stops every process in the job
+func (j *processJob) kill() error {
+	if j == nil {
+		return fmt.Errorf("no job")
+	}
+	return windows.TerminateJobObject(j.handle, 1)
+}
+
+// close releases the job, killing any process still in it
+func (j *processJob) close() {
+	if j != nil {
+		windows.CloseHandle(j.handle)
+	}
+}
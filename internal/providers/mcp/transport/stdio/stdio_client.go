@@ -42,6 +42,7 @@ type StdioClient struct {
 	stderrMutex     sync.Mutex          // Protects stderr buffer access
 	hasRealErrors   bool                // Indicates if server reported ACTUAL errors (not just info/debug logs)
 	dispatcher      *ResponseDispatcher // Routes responses to waiting requests
+	job             *processJob         // The server's process tree (Windows only)
 }
 
 // NewStdioClient creates a new stdio client with the given parameters
@@ -168,17 +169,7 @@ func (c *StdioClient) Start() error {
 	logging.Debug("Starting stdio client with command: %s", c.params.Command)
 
 	// Create the command
-	c.cmd = exec.CommandContext(c.ctx, c.params.Command, c.params.Args...)
-
-	// Set environment variables
-	if c.params.Env != nil {
-		env := os.Environ()
-		for k, v := range c.params.Env {
-			env = append(env, fmt.Sprintf("%s=%s", k, v))
-			logging.Debug("Setting environment variable: %s=%s", k, v)
-		}
-		c.cmd.Env = env
-	}
+	c.cmd = newServerCommand(c.ctx, c.params)
 
 	// Get stdin/stdout pipes
 	var err error
@@ -206,6 +197,11 @@ func (c *StdioClient) Start() error {
 	}
 	logging.Info("Server process started with PID %d", c.cmd.Process.Pid)
 
+	// Tie the server's process tree together so none of it outlives mcp-cli
+	if c.job, err = attachJob(c.cmd.Process); err != nil {
+		logging.Warn("Server processes may outlive mcp-cli: %v", err)
+	}
+
 	// Start the reader, writer, and stderr monitor goroutines
 	c.wg.Add(3)
 	go c.readLoop()
@@ -417,9 +413,11 @@ func (c *StdioClient) Stop() {
 		logging.Debug("Attempting to terminate process with PID %d", c.cmd.Process.Pid)
 		// First try to terminate gracefully
 		if err := c.cmd.Process.Signal(os.Interrupt); err != nil {
-			logging.Warn("Failed to send interrupt signal: %v", err)
-			logging.Debug("Forcefully killing process")
-			_ = c.cmd.Process.Kill()
+			// Windows can't deliver interrupts to other processes
+			logging.Debug("Failed to send interrupt signal (%v), forcefully killing process", err)
+			if err := c.job.kill(); err != nil {
+				_ = c.cmd.Process.Kill()
+			}
 		}
 
 		// Wait for the process to exit
@@ -432,6 +430,10 @@ func (c *StdioClient) Stop() {
 		} else {
 			logging.Debug("Process exited successfully")
 		}
+
+		// Stop anything the server started and left running
+		c.job.close()
+		c.job = nil
 	}
 
 	logging.Info("Stdio client stopped")
@@ -0,0 +1,216 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Labels set on every container mcp-cli starts, so leftovers can be found
+const (
+	LabelManaged = "mcp-cli.managed" // Always "true"
+	LabelOwner   = "mcp-cli.owner"   // host:pid of the mcp-cli process that started it
+)
+
+// How long removing a container or sweeping for orphans may take
+const cleanupTimeout = 15 * time.Second
+
+// containerRuntime is the part of an executor that finds and removes
+// containers
+type containerRuntime interface {
+	// removeContainer force-removes a container by ID or name
+	removeContainer(ctx context.Context, id string) error
+
+	// listManaged returns the containers labeled as started by mcp-cli
+	listManaged(ctx context.Context) ([]managedContainer, error)
+}
+
+// managedContainer is a container started by some mcp-cli process
+type managedContainer struct {
+	ID    string
+	Owner string // Value of LabelOwner
+}
+
+// containerTracker records the containers this process has running, so
+// they can be removed however mcp-cli exits
+type containerTracker struct {
+	mu      sync.Mutex
+	seq     int
+	running map[string]containerRuntime
+	signals chan os.Signal // Set while containers are running
+}
+
+var tracker = &containerTracker{running: make(map[string]containerRuntime)}
+
+// owner is the LabelOwner value for containers started by this process
+func owner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// containerLabels returns the labels for a new container
+func containerLabels() map[string]string {
+	return map[string]string{LabelManaged: "true", LabelOwner: owner()}
+}
+
+// newContainerName returns a unique name for a new container
+func (t *containerTracker) newContainerName() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seq++
+	return fmt.Sprintf("mcp-cli-%d-%d", os.Getpid(), t.seq)
+}
+
+// add records a container as running
+func (t *containerTracker) add(id string, rt containerRuntime) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.running[id] = rt
+	if t.signals == nil {
+		// Ctrl+C or SIGTERM would otherwise end the process and leave the
+		// container running
+		t.signals = make(chan os.Signal, 1)
+		signal.Notify(t.signals, os.Interrupt, syscall.SIGTERM)
+		go t.watchSignals(t.signals)
+	}
+}
+
+// remove forgets a container that has been removed
+func (t *containerTracker) remove(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.running, id)
+	if len(t.running) == 0 && t.signals != nil {
+		signal.Stop(t.signals)
+		close(t.signals)
+		t.signals = nil
+	}
+}
+
+// watchSignals removes the running containers when the process is
+// interrupted, then delivers the signal again so the process stops as it
+// would have
+func (t *containerTracker) watchSignals(signals chan os.Signal) {
+	sig, ok := <-signals
+	if !ok {
+		return
+	}
+	logging.Info("Received %v - removing sandbox containers", sig)
+	Cleanup()
+
+	// Let the signal through even if a container couldn't be removed
+	t.mu.Lock()
+	if t.signals == signals {
+		signal.Stop(signals)
+		t.signals = nil
+	}
+	t.mu.Unlock()
+
+	if self, err := os.FindProcess(os.Getpid()); err == nil && self.Signal(sig) == nil {
+		return
+	}
+	// Windows can't signal itself
+	os.Exit(130)
+}
+
+// Cleanup removes every container this process still has running. Call it
+// before exiting; it is safe to call more than once.
+func Cleanup() {
+	tracker.mu.Lock()
+	running := make(map[string]containerRuntime, len(tracker.running))
+	for id, rt := range tracker.running {
+		running[id] = rt
+	}
+	tracker.mu.Unlock()
+
+	if len(running) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for id, rt := range running {
+		wg.Add(1)
+		go func(id string, rt containerRuntime) {
+			defer wg.Done()
+			if err := rt.removeContainer(ctx, id); err != nil {
+				logging.Warn("Failed to remove sandbox container %s: %v", id, err)
+				return
+			}
+			logging.Debug("Removed sandbox container %s", id)
+			tracker.remove(id)
+		}(id, rt)
+	}
+	wg.Wait()
+}
+
+// sweepOrphans removes containers left behind by mcp-cli processes on this
+// host that are no longer running, for example after a crash
+func sweepOrphans(rt containerRuntime) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	containers, err := rt.listManaged(ctx)
+	if err != nil {
+		logging.Debug("Skipping sweep for orphaned sandbox containers: %v", err)
+		return
+	}
+
+	host, _ := os.Hostname()
+	removed := 0
+	for _, container := range containers {
+		if !isOrphan(container.Owner, host) {
+			continue
+		}
+		if err := rt.removeContainer(ctx, container.ID); err != nil {
+			logging.Warn("Failed to remove orphaned sandbox container %s: %v", container.ID, err)
+			continue
+		}
+		removed++
+	}
+	if removed > 0 {
+		logging.Info("Removed %d orphaned sandbox container(s) left by earlier runs", removed)
+	}
+}
+
+// isOrphan reports whether a container's owner is an mcp-cli process on
+// this host that has exited. Containers from other hosts sharing the
+// daemon are left alone.
+func isOrphan(ownerLabel, host string) bool {
+	i := strings.LastIndex(ownerLabel, ":")
+	if i < 0 || ownerLabel[:i] != host {
+		return false
+	}
+	pid, err := strconv.Atoi(ownerLabel[i+1:])
+	if err != nil || pid == os.Getpid() {
+		return false
+	}
+	return !processAlive(pid)
+}
+
+// processAlive reports whether a process with the given PID exists
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// FindProcess fails on Windows if the process doesn't exist
+		process.Release()
+		return true
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,81 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// fakeRuntime records removals instead of talking to a container engine
+type fakeRuntime struct {
+	mu         sync.Mutex
+	containers []managedContainer
+	removed    []string
+}
+
+func (f *fakeRuntime) removeContainer(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removed = append(f.removed, id)
+	return nil
+}
+
+func (f *fakeRuntime) listManaged(ctx context.Context) ([]managedContainer, error) {
+	return f.containers, nil
+}
+
+func TestCleanupRemovesRunningContainers(t *testing.T) {
+	rt := &fakeRuntime{}
+	first, second := tracker.newContainerName(), tracker.newContainerName()
+	if first == second {
+		t.Fatalf("container names should be unique: %s", first)
+	}
+
+	tracker.add(first, rt)
+	tracker.add(second, rt)
+	tracker.add("finished", rt)
+	tracker.remove("finished")
+
+	Cleanup()
+	sort.Strings(rt.removed)
+	want := []string{first, second}
+	sort.Strings(want)
+	if fmt.Sprint(rt.removed) != fmt.Sprint(want) {
+		t.Errorf("removed %v, want %v", rt.removed, want)
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if len(tracker.running) != 0 || tracker.signals != nil {
+		t.Errorf("tracker should be empty with no signal handler: %v", tracker.running)
+	}
+}
+
+func TestSweepOrphans(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	exited := exec.Command("sh", "-c", "exit 0")
+	if err := exited.Run(); err != nil {
+		t.Fatal(err)
+	}
+	host, _ := os.Hostname()
+
+	rt := &fakeRuntime{containers: []managedContainer{
+		{ID: "orphan", Owner: fmt.Sprintf("%s:%d", host, exited.Process.Pid)},
+		{ID: "mine", Owner: owner()},
+		{ID: "parent", Owner: fmt.Sprintf("%s:%d", host, os.Getppid())},
+		{ID: "other-host", Owner: fmt.Sprintf("elsewhere:%d", exited.Process.Pid)},
+		{ID: "unlabeled", Owner: ""},
+	}}
+	sweepOrphans(rt)
+
+	if fmt.Sprint(rt.removed) != "[orphan]" {
+		t.Errorf("removed %v, want [orphan]", rt.removed)
+	}
+}
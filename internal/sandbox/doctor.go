@@ -0,0 +1,451 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// CheckStatus is the outcome of one doctor check
+type CheckStatus string
+
+const (
+	CheckOK   CheckStatus = "ok"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+	CheckSkip CheckStatus = "skip" // Not installed or not applicable
+)
+
+// Check is one finding of Diagnose
+type Check struct {
+	Name   string      `json:"name"`
+	Status CheckStatus `json:"status"`
+	Detail string      `json:"detail"`
+	Fix    string      `json:"fix,omitempty"` // What to do about a warning or failure
+}
+
+// Diagnosis explains whether skills can run in active mode, and if not, why
+type Diagnosis struct {
+	Checks    []Check `json:"checks"`
+	Available bool    `json:"available"`
+	Executor  string  `json:"executor,omitempty"` // How scripts would run, e.g. "docker CLI"
+}
+
+func (d *Diagnosis) add(check Check) {
+	d.Checks = append(d.Checks, check)
+}
+
+// How long each probe of an engine may take
+const probeTimeout = 10 * time.Second
+
+// Diagnose checks every way of reaching Docker or Podman, in the order
+// DetectExecutor tries them, without starting any containers
+func Diagnose(config ExecutorConfig) *Diagnosis {
+	d := &Diagnosis{}
+	inContainer := isRunningInContainer()
+
+	where := fmt.Sprintf("native (%s/%s)", runtime.GOOS, runtime.GOARCH)
+	if inContainer {
+		where = "inside a container, so the engine socket must be mounted in"
+	}
+	d.add(Check{Name: "Environment", Status: CheckOK, Detail: where})
+
+	for _, name := range []string{"DOCKER_HOST", "CONTAINER_HOST"} {
+		if value := os.Getenv(name); value != "" {
+			d.add(checkHostVariable(Endpoint{Host: value, Source: name}))
+		}
+	}
+
+	// CLIs
+	cli := ""
+	for _, name := range []string{"docker", "podman"} {
+		check := checkCLI(name)
+		d.add(check)
+		if check.Status == CheckOK && cli == "" {
+			cli = name
+		}
+	}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		if _, err := exec.LookPath("podman"); err == nil {
+			d.add(checkPodmanMachine())
+		}
+	}
+
+	// Engine APIs
+	var api *docker.Client
+	var apiEndpoint Endpoint
+	tried := 0
+	for _, endpoint := range Endpoints() {
+		if !endpoint.APISupported() {
+			continue
+		}
+		tried++
+		check, client := checkEndpoint(endpoint)
+		d.add(check)
+		if client != nil && api == nil {
+			api, apiEndpoint = client, endpoint
+		}
+	}
+	if tried == 0 {
+		d.add(Check{Name: "Engine API", Status: CheckSkip, Detail: "no DOCKER_HOST, CONTAINER_HOST or local Docker/Podman socket found"})
+	}
+
+	// The executor DetectExecutor would pick, and what it runs against
+	var info *engineInfo
+	remote := false
+	switch {
+	case api != nil && (inContainer || cli == ""):
+		d.Executor = "engine API via " + apiEndpoint.Host
+		info = apiInfo(api)
+		remote = apiEndpoint.Remote()
+	case cli != "":
+		d.Executor = cli + " CLI"
+		info = cliInfo(cli)
+		variable := "DOCKER_HOST"
+		if cli == "podman" {
+			variable = "CONTAINER_HOST"
+		}
+		if host := os.Getenv(variable); host != "" {
+			remote = Endpoint{Host: host}.Remote()
+		}
+	}
+	d.Available = d.Executor != ""
+
+	if info != nil {
+		d.add(checkRootless(info))
+	}
+	if remote {
+		d.add(Check{
+			Name:   "Bind mounts",
+			Status: CheckWarn,
+			Detail: "the engine is on another machine, so mounts refer to its filesystem",
+			Fix:    "Make the skills and outputs directories available at the same paths on the engine host, or run a local engine",
+		})
+	}
+	d.add(checkOutputsDir(config.OutputsDir))
+
+	if !d.Available {
+		d.add(Check{
+			Name:   "Active mode",
+			Status: CheckFail,
+			Detail: "no working Docker or Podman engine, so skills run in passive mode (documentation only)",
+			Fix:    "Fix the failures above, or install Docker (https://docs.docker.com/get-docker/) or Podman (https://podman.io/getting-started/installation)",
+		})
+	} else {
+		d.add(Check{Name: "Active mode", Status: CheckOK, Detail: "skills run scripts using the " + d.Executor})
+	}
+	return d
+}
+
+// checkHostVariable checks a DOCKER_HOST or CONTAINER_HOST value
+func checkHostVariable(endpoint Endpoint) Check {
+	check := Check{Name: endpoint.Source, Status: CheckOK, Detail: endpoint.Host}
+	var notes []string
+	if endpoint.Remote() {
+		notes = append(notes, "remote engine")
+	}
+	switch {
+	case endpoint.Scheme() == "ssh":
+		notes = append(notes, "CLI only")
+	case !endpoint.APISupported():
+		check.Status = CheckFail
+		notes = append(notes, "unsupported scheme "+endpoint.Scheme())
+		check.Fix = "Use a unix://, npipe://, tcp:// or ssh:// address"
+	}
+	if len(notes) > 0 {
+		check.Detail += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return check
+}
+
+// checkCLI checks that a CLI is installed and can reach its engine
+func checkCLI(name string) Check {
+	check := Check{Name: name + " CLI"}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		check.Status = CheckSkip
+		check.Detail = "not installed"
+		return check
+	}
+
+	output, err := runProbe(name, "version", "--format", "{{.Server.Version}}")
+	if err != nil {
+		check.Status = CheckFail
+		check.Detail = fmt.Sprintf("%s cannot reach its engine: %v", path, err)
+		check.Fix = cliFix(name, err.Error())
+		return check
+	}
+	check.Status = CheckOK
+	check.Detail = fmt.Sprintf("%s, engine %s", path, strings.TrimSpace(output))
+	return check
+}
+
+// cliFix suggests a fix for a CLI's error message
+func cliFix(name, message string) string {
+	message = strings.ToLower(message)
+	switch {
+	case name == "docker" && strings.Contains(message, "permission denied"):
+		return "Add yourself to the docker group (sudo usermod -aG docker $USER) and log in again, or use rootless Docker"
+	case strings.Contains(message, "newuidmap") || strings.Contains(message, "subuid") || strings.Contains(message, "user namespace"):
+		return "Rootless Podman needs subordinate IDs: sudo usermod --add-subuids 100000-165535 --add-subgids 100000-165535 $USER"
+	case name == "podman" && (runtime.GOOS == "darwin" || runtime.GOOS == "windows"):
+		return "Start the Podman VM: podman machine start"
+	case name == "docker" && (runtime.GOOS == "darwin" || runtime.GOOS == "windows"):
+		return "Start Docker Desktop"
+	case name == "docker":
+		return "Start the Docker daemon (sudo systemctl start docker) or, for rootless Docker, systemctl --user start docker"
+	}
+	return "Check the engine is running: podman info"
+}
+
+// checkPodmanMachine checks that a podman machine exists and is running
+func checkPodmanMachine() Check {
+	check := Check{Name: "podman machine"}
+	output, err := runProbe("podman", "machine", "list", "--format", "json")
+	if err != nil {
+		check.Status = CheckWarn
+		check.Detail = fmt.Sprintf("cannot list machines: %v", err)
+		return check
+	}
+
+	var machines []struct {
+		Name    string
+		Running bool
+	}
+	if err := json.Unmarshal([]byte(output), &machines); err != nil {
+		check.Status = CheckWarn
+		check.Detail = fmt.Sprintf("unexpected output: %v", err)
+		return check
+	}
+	if len(machines) == 0 {
+		check.Status = CheckFail
+		check.Detail = "no machine created; Podman needs a Linux VM on " + runtime.GOOS
+		check.Fix = "podman machine init && podman machine start"
+		return check
+	}
+	for _, machine := range machines {
+		if machine.Running {
+			check.Status = CheckOK
+			check.Detail = strings.TrimSuffix(machine.Name, "*") + " is running"
+			return check
+		}
+	}
+	check.Status = CheckFail
+	check.Detail = strings.TrimSuffix(machines[0].Name, "*") + " is stopped"
+	check.Fix = "podman machine start"
+	return check
+}
+
+// checkEndpoint connects to an engine API, returning the client if it works
+func checkEndpoint(endpoint Endpoint) (Check, *docker.Client) {
+	check := Check{Name: "API " + endpoint.Host, Detail: endpoint.Source}
+	client, err := newEndpointClient(endpoint)
+	if err == nil {
+		err = probeEndpoint(client)
+	}
+	if err != nil {
+		check.Status = CheckFail
+		check.Detail = fmt.Sprintf("%s: %v", endpoint.Source, err)
+		if strings.Contains(strings.ToLower(err.Error()), "permission denied") {
+			check.Fix = "Your user cannot open the socket: join the group that owns it, or use the rootless socket"
+		}
+		return check, nil
+	}
+	check.Status = CheckOK
+	return check, client
+}
+
+// engineInfo is what the doctor needs to know about an engine
+type engineInfo struct {
+	Rootless      bool
+	CgroupVersion string
+	Missing       []string // Resource limits the engine can't enforce
+}
+
+// dockerInfo is the subset of `docker info` (and the /info API) used
+type dockerInfo struct {
+	SecurityOptions []string
+	CgroupVersion   string
+	MemoryLimit     bool
+	CPUCfsQuota     bool  `json:"CpuCfsQuota"`
+	PidsLimit       *bool // Not reported by older APIs
+}
+
+// parseDockerInfo reads `docker info --format '{{json .}}'`
+func parseDockerInfo(data []byte) (*engineInfo, error) {
+	var raw dockerInfo
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	info := &engineInfo{CgroupVersion: raw.CgroupVersion}
+	for _, option := range raw.SecurityOptions {
+		if strings.Contains(option, "name=rootless") {
+			info.Rootless = true
+		}
+	}
+	if !raw.MemoryLimit {
+		info.Missing = append(info.Missing, "memory")
+	}
+	if !raw.CPUCfsQuota {
+		info.Missing = append(info.Missing, "cpu")
+	}
+	if raw.PidsLimit != nil && !*raw.PidsLimit {
+		info.Missing = append(info.Missing, "pids")
+	}
+	return info, nil
+}
+
+// parsePodmanInfo reads `podman info --format json`
+func parsePodmanInfo(data []byte) (*engineInfo, error) {
+	var raw struct {
+		Host struct {
+			CgroupsVersion    string   `json:"cgroupVersion"`
+			CgroupControllers []string `json:"cgroupControllers"`
+			Security          struct {
+				Rootless bool `json:"rootless"`
+			} `json:"security"`
+		} `json:"host"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	info := &engineInfo{Rootless: raw.Host.Security.Rootless, CgroupVersion: strings.TrimPrefix(raw.Host.CgroupsVersion, "v")}
+	controllers := make(map[string]bool)
+	for _, controller := range raw.Host.CgroupControllers {
+		controllers[controller] = true
+	}
+	for _, controller := range []string{"memory", "cpu", "pids"} {
+		if !controllers[controller] {
+			info.Missing = append(info.Missing, controller)
+		}
+	}
+	return info, nil
+}
+
+func cliInfo(cli string) *engineInfo {
+	var output string
+	var err error
+	var info *engineInfo
+	if cli == "podman" {
+		if output, err = runProbe(cli, "info", "--format", "json"); err == nil {
+			info, err = parsePodmanInfo([]byte(output))
+		}
+	} else if output, err = runProbe(cli, "info", "--format", "{{json .}}"); err == nil {
+		info, err = parseDockerInfo([]byte(output))
+	}
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+func apiInfo(client *docker.Client) *engineInfo {
+	raw, err := client.Info()
+	if err != nil {
+		return nil
+	}
+	data, _ := json.Marshal(raw)
+	info, err := parseDockerInfo(data)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// checkRootless reports whether the engine is rootless and can enforce the
+// memory, CPU and process limits every sandbox container is started with
+func checkRootless(info *engineInfo) Check {
+	mode := "rootful"
+	if info.Rootless {
+		mode = "rootless"
+	}
+	if info.CgroupVersion != "" {
+		mode += ", cgroup v" + info.CgroupVersion
+	}
+
+	check := Check{Name: "Resource limits", Status: CheckOK, Detail: mode}
+	if len(info.Missing) == 0 {
+		return check
+	}
+
+	check.Status = CheckWarn
+	check.Detail += fmt.Sprintf("; cannot enforce %s limits", strings.Join(info.Missing, ", "))
+	switch {
+	case info.Rootless && info.CgroupVersion == "1":
+		check.Fix = "Rootless engines need cgroup v2 for resource limits: boot with systemd.unified_cgroup_hierarchy=1"
+	case info.Rootless:
+		check.Fix = "Delegate cgroup controllers to your user: https://rootlesscontaine.rs/getting-started/common/cgroup2/"
+	default:
+		check.Fix = "Enable the missing cgroup controllers in the kernel"
+	}
+	return check
+}
+
+// checkOutputsDir checks the outputs directory can be written
+func checkOutputsDir(dir string) Check {
+	check := Check{Name: "Outputs directory", Detail: dir}
+	if dir == "" {
+		check.Status = CheckSkip
+		check.Detail = "not configured"
+		return check
+	}
+	info, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		check.Status = CheckOK
+		check.Detail += " (created on first use)"
+		return check
+	}
+	if err == nil && !info.IsDir() {
+		err = fmt.Errorf("not a directory")
+	}
+	if err == nil {
+		var probe *os.File
+		if probe, err = os.CreateTemp(dir, ".mcp-cli-doctor-*"); err == nil {
+			probe.Close()
+			os.Remove(probe.Name())
+		}
+	}
+	if err != nil {
+		check.Status = CheckFail
+		check.Detail = fmt.Sprintf("%s: %v", dir, err)
+		check.Fix = "Set skills.outputs_dir to a directory you can write"
+		return check
+	}
+	check.Status = CheckOK
+	return check
+}
+
+// runProbe runs a CLI command, returning its stdout or an error holding
+// its stderr
+func runProbe(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New(firstLine(message))
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		host   string
+		api    bool
+		remote bool
+	}{
+		{"unix:///var/run/docker.sock", true, false},
+		{"npipe:////./pipe/docker_engine", true, false},
+		{"tcp://127.0.0.1:2375", true, false},
+		{"tcp://localhost:2376", true, false},
+		{"tcp://10.0.0.5:2376", true, true},
+		{"buildbox:2375", true, true},
+		{"ssh://me@buildbox", false, true},
+	}
+	for _, tt := range tests {
+		endpoint := Endpoint{Host: tt.host}
+		if endpoint.APISupported() != tt.api || endpoint.Remote() != tt.remote {
+			t.Errorf("%s: api=%v remote=%v, want api=%v remote=%v",
+				tt.host, endpoint.APISupported(), endpoint.Remote(), tt.api, tt.remote)
+		}
+	}
+}
+
+func TestEndpointsPreferEnvironment(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "tcp://10.0.0.5:2376")
+	t.Setenv("CONTAINER_HOST", "ssh://me@buildbox/run/podman/podman.sock")
+
+	endpoints := Endpoints()
+	if len(endpoints) < 2 || endpoints[0].Source != "DOCKER_HOST" || endpoints[1].Source != "CONTAINER_HOST" {
+		t.Fatalf("environment endpoints should come first: %+v", endpoints)
+	}
+}
+
+func TestCheckHostVariable(t *testing.T) {
+	if check := checkHostVariable(Endpoint{Host: "ssh://me@buildbox", Source: "DOCKER_HOST"}); check.Status != CheckOK || check.Detail != "ssh://me@buildbox (remote engine, CLI only)" {
+		t.Errorf("ssh: %+v", check)
+	}
+	if check := checkHostVariable(Endpoint{Host: "fd://", Source: "DOCKER_HOST"}); check.Status != CheckFail || check.Fix == "" {
+		t.Errorf("unsupported scheme should fail with a fix: %+v", check)
+	}
+}
+
+func TestParseEngineInfo(t *testing.T) {
+	docker, err := parseDockerInfo([]byte(`{"SecurityOptions":["name=seccomp,profile=builtin","name=rootless","name=cgroupns"],"CgroupVersion":"2","MemoryLimit":true,"CpuCfsQuota":false,"PidsLimit":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !docker.Rootless || docker.CgroupVersion != "2" || len(docker.Missing) != 1 || docker.Missing[0] != "cpu" {
+		t.Errorf("docker info: %+v", docker)
+	}
+
+	podman, err := parsePodmanInfo([]byte(`{"host":{"cgroupVersion":"v2","cgroupControllers":["memory","pids"],"security":{"rootless":true}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !podman.Rootless || podman.CgroupVersion != "2" || len(podman.Missing) != 1 || podman.Missing[0] != "cpu" {
+		t.Errorf("podman info: %+v", podman)
+	}
+
+	check := checkRootless(podman)
+	if check.Status != CheckWarn || check.Detail != "rootless, cgroup v2; cannot enforce cpu limits" || check.Fix == "" {
+		t.Errorf("rootless check: %+v", check)
+	}
+	if check := checkRootless(&engineInfo{CgroupVersion: "2"}); check.Status != CheckOK {
+		t.Errorf("rootful check: %+v", check)
+	}
+}
+
+func TestCheckOutputsDir(t *testing.T) {
+	dir := t.TempDir()
+	if check := checkOutputsDir(dir); check.Status != CheckOK {
+		t.Errorf("writable dir: %+v", check)
+	}
+	if check := checkOutputsDir(filepath.Join(dir, "new")); check.Status != CheckOK {
+		t.Errorf("missing dir is created on first use: %+v", check)
+	}
+
+	file := filepath.Join(dir, "file")
+	os.WriteFile(file, nil, 0644)
+	if check := checkOutputsDir(file); check.Status != CheckFail {
+		t.Errorf("file instead of dir: %+v", check)
+	}
+}
@@ -2,48 +2,74 @@ package sandbox
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"os"
 
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	docker "github.com/fsouza/go-dockerclient"
 )
 
 // DooDockerExecutor uses Docker API directly with socket mount (for containerized deployments)
 type DooDockerExecutor struct {
-	config ExecutorConfig
-	client *docker.Client
+	config   ExecutorConfig
+	client   *docker.Client
+	endpoint Endpoint
 }
 
 // NewDooDockerExecutor creates a new Docker-out-of-Docker executor
-// Works with both Docker and Podman sockets
+// Works with both Docker and Podman, through DOCKER_HOST, CONTAINER_HOST,
+// a podman machine or a local socket (see Endpoints)
 func NewDooDockerExecutor(config ExecutorConfig) (*DooDockerExecutor, error) {
-	// Try multiple socket locations (Docker and Podman)
-	socketPaths := []string{
-		"unix:///var/run/docker.sock",                                      // Standard Docker
-		fmt.Sprintf("unix:///run/user/%d/podman/podman.sock", os.Getuid()), // Podman rootless
-		"unix:///run/podman/podman.sock",                                   // Podman rootful
-	}
+	err := fmt.Errorf("no Docker/Podman socket found")
+	for _, endpoint := range Endpoints() {
+		if !endpoint.APISupported() {
+			continue
+		}
 
-	var client *docker.Client
-	var err error
-
-	for _, socketPath := range socketPaths {
-		client, err = docker.NewClient(socketPath)
-		if err == nil {
-			// Test if socket is actually working
-			if _, err := client.Version(); err == nil {
-				return &DooDockerExecutor{
-					config: config,
-					client: client,
-				}, nil
-			}
+		var client *docker.Client
+		client, err = newEndpointClient(endpoint)
+		if err != nil {
+			continue
+		}
+		// Test if socket is actually working
+		if err = probeEndpoint(client); err != nil {
+			continue
+		}
+
+		if endpoint.Remote() {
+			logging.Warn("Container engine at %s is remote: skill, workspace and outputs directories must exist at the same paths on that host", endpoint.Host)
+		}
+		executor := &DooDockerExecutor{
+			config:   config,
+			client:   client,
+			endpoint: endpoint,
 		}
+		sweepOrphans(executor)
+		return executor, nil
 	}
 
 	return nil, fmt.Errorf("failed to connect to Docker/Podman socket: %w", err)
 }
 
+// probeEndpoint checks that an engine answers, without waiting long on
+// unreachable remote hosts
+func probeEndpoint(client *docker.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	_, err := client.VersionWithContext(ctx)
+	return err
+}
+
+// newEndpointClient returns an API client for an endpoint. DOCKER_HOST
+// also honors DOCKER_TLS_VERIFY and DOCKER_CERT_PATH.
+func newEndpointClient(endpoint Endpoint) (*docker.Client, error) {
+	if endpoint.Source == "DOCKER_HOST" {
+		return docker.NewClientFromEnv()
+	}
+	return docker.NewClient(endpoint.Host)
+}
+
 // IsAvailable checks if Docker socket is accessible
 func (d *DooDockerExecutor) IsAvailable() bool {
 	_, err := d.client.Version()
@@ -52,12 +78,18 @@ func (d *DooDockerExecutor) IsAvailable() bool {
 
 // ExecutePython runs a Python script using Docker API
 func (d *DooDockerExecutor) ExecutePython(ctx context.Context, skillDir, scriptPath string, args []string) (string, error) {
-	image := d.config.GetImageForSkill(skillDir)
+	image, err := d.config.resolveSkillImage(ctx, d, skillDir)
+	if err != nil {
+		return "", err
+	}
 	return d.executeInContainer(ctx, skillDir, image, "python", scriptPath, args)
 }
 
 // ExecuteBash runs a Bash script using Docker API
 func (d *DooDockerExecutor) ExecuteBash(ctx context.Context, skillDir, scriptPath string, args []string) (string, error) {
+	if err := d.config.ensureImage(ctx, d, "alpine:latest"); err != nil {
+		return "", err
+	}
 	return d.executeInContainer(ctx, skillDir, "alpine:latest", "sh", scriptPath, args)
 }
 
@@ -70,11 +102,6 @@ func (d *DooDockerExecutor) executeInContainer(
 	scriptPath string,
 	args []string,
 ) (string, error) {
-	// Pull image if not present
-	if err := d.ensureImage(ctx, image); err != nil {
-		return "", fmt.Errorf("failed to ensure image: %w", err)
-	}
-
 	// Build command
 	cmd := []string{interpreter, "/skill/" + scriptPath}
 	cmd = append(cmd, args...)
@@ -82,7 +109,9 @@ func (d *DooDockerExecutor) executeInContainer(
 	// Create container
 	pidsLimit := int64(100)
 	container, err := d.client.CreateContainer(docker.CreateContainerOptions{
+		Name: tracker.newContainerName(),
 		Config: &docker.Config{
+			Labels:          containerLabels(),
 			Image:           image,
 			Cmd:             cmd,
 			NetworkDisabled: true,
@@ -106,11 +135,13 @@ func (d *DooDockerExecutor) executeInContainer(
 	}
 
 	// Ensure container cleanup
+	tracker.add(container.ID, d)
 	defer func() {
 		d.client.RemoveContainer(docker.RemoveContainerOptions{
 			ID:    container.ID,
 			Force: true,
 		})
+		tracker.remove(container.ID)
 	}()
 
 	// Start container
@@ -155,23 +186,48 @@ func (d *DooDockerExecutor) executeInContainer(
 	return output, nil
 }
 
-// ensureImage pulls an image if it doesn't exist locally
-func (d *DooDockerExecutor) ensureImage(ctx context.Context, image string) error {
-	// Check if image exists
-	_, err := d.client.InspectImage(image)
-	if err == nil {
-		return nil // Image exists
+// ResolveImage returns the image used for a skill and its digest
+func (d *DooDockerExecutor) ResolveImage(ctx context.Context, skillLibsDir string) (string, string, error) {
+	image := d.config.GetImageForSkill(skillLibsDir)
+	local, err := d.InspectImage(ctx, image)
+	if err != nil {
+		return image, "", err
+	}
+	if local == nil {
+		return image, "", fmt.Errorf("image %s is not present", image)
+	}
+	return image, local.DigestFor(image), nil
+}
+
+// InspectImage returns a local image, or nil if it isn't present
+func (d *DooDockerExecutor) InspectImage(ctx context.Context, ref string) (*LocalImage, error) {
+	info, err := d.client.InspectImage(ref)
+	if errors.Is(err, docker.ErrNoSuchImage) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image %s: %w", ref, err)
 	}
+	return &LocalImage{ID: info.ID, RepoDigests: info.RepoDigests}, nil
+}
 
-	// Pull image
+// PullImage pulls an image from its registry
+func (d *DooDockerExecutor) PullImage(ctx context.Context, ref string) error {
 	return d.client.PullImage(docker.PullImageOptions{
-		Repository: image,
+		Repository: ref,
 		Context:    ctx,
 	}, docker.AuthConfiguration{})
 }
 
-// getContainerLogs retrieves logs from a container
+// getContainerLogs retrieves logs from a container, stdout followed by stderr
 func (d *DooDockerExecutor) getContainerLogs(containerID string) (string, error) {
+	stdout, stderr, err := d.getContainerStreams(containerID)
+	output := &CodeOutput{Stdout: stdout, Stderr: stderr}
+	return output.Combined(), err
+}
+
+// getContainerStreams retrieves a container's stdout and stderr
+func (d *DooDockerExecutor) getContainerStreams(containerID string) (string, string, error) {
 	var stdout, stderr []byte
 
 	// Create pipes for output
@@ -186,12 +242,7 @@ func (d *DooDockerExecutor) getContainerLogs(containerID string) (string, error)
 		Stderr:       true,
 	})
 
-	output := string(stdout)
-	if len(stderr) > 0 {
-		output += "\n" + string(stderr)
-	}
-
-	return output, err
+	return string(stdout), string(stderr), err
 }
 
 // bytesWriter implements io.Writer by writing to a byte slice
@@ -211,23 +262,33 @@ func (d *DooDockerExecutor) GetInfo() string {
 		return "Docker/Podman (DooD, version unknown)"
 	}
 	version := env.Get("Version")
-	return fmt.Sprintf("Docker/Podman %s (DooD via socket)", version)
+	return fmt.Sprintf("Docker/Podman %s (DooD via %s)", version, d.endpoint.Host)
 }
 
 // ExecutePythonCode runs Python code with dual mount support
 // workspaceDir: read-write workspace for files and code execution
 // skillLibsDir: read-only skill directory for importing helper libraries
 func (d *DooDockerExecutor) ExecutePythonCode(ctx context.Context, workspaceDir, skillLibsDir, scriptPath string, args []string) (string, error) {
-	image := d.config.GetImageForSkill(skillLibsDir)
-	return d.executeCodeInContainer(ctx, workspaceDir, skillLibsDir, image, "python", scriptPath, args)
+	output, err := d.ExecuteCodeStreams(ctx, "python", workspaceDir, skillLibsDir, scriptPath, args)
+	return output.Combined(), err
 }
 
 // ExecuteBashCode runs Bash code with dual mount support
 // workspaceDir: read-write workspace for files and code execution
 // skillLibsDir: read-only skill directory (for future bash libraries)
 func (d *DooDockerExecutor) ExecuteBashCode(ctx context.Context, workspaceDir, skillLibsDir, scriptPath string, args []string) (string, error) {
-	image := d.config.GetImageForSkill(skillLibsDir)
-	return d.executeCodeInContainer(ctx, workspaceDir, skillLibsDir, image, "bash", scriptPath, args)
+	output, err := d.ExecuteCodeStreams(ctx, "bash", workspaceDir, skillLibsDir, scriptPath, args)
+	return output.Combined(), err
+}
+
+// ExecuteCodeStreams runs code with dual mount support, keeping stdout and
+// stderr apart
+func (d *DooDockerExecutor) ExecuteCodeStreams(ctx context.Context, interpreter, workspaceDir, skillLibsDir, scriptPath string, args []string) (*CodeOutput, error) {
+	image, err := d.config.resolveSkillImage(ctx, d, skillLibsDir)
+	if err != nil {
+		return &CodeOutput{ExitCode: -1}, err
+	}
+	return d.executeCodeInContainer(ctx, workspaceDir, skillLibsDir, image, interpreter, scriptPath, args)
 }
 
 // executeCodeInContainer handles container execution with dual mounts
@@ -239,12 +300,7 @@ func (d *DooDockerExecutor) executeCodeInContainer(
 	interpreter string,
 	scriptPath string,
 	args []string,
-) (string, error) {
-	// Pull image if not present
-	if err := d.ensureImage(ctx, image); err != nil {
-		return "", fmt.Errorf("failed to ensure image: %w", err)
-	}
-
+) (*CodeOutput, error) {
 	// Build command
 	cmd := []string{interpreter, scriptPath}
 	cmd = append(cmd, args...)
@@ -253,7 +309,9 @@ func (d *DooDockerExecutor) executeCodeInContainer(
 	pidsLimit := int64(100)
 	networkMode := d.config.GetNetworkModeForSkill(skillLibsDir)
 	container, err := d.client.CreateContainer(docker.CreateContainerOptions{
+		Name: tracker.newContainerName(),
 		Config: &docker.Config{
+			Labels:     containerLabels(),
 			Image:      image,
 			Cmd:        cmd,
 			WorkingDir: "/workspace",
@@ -276,23 +334,26 @@ func (d *DooDockerExecutor) executeCodeInContainer(
 		Context: ctx,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to create container: %w", err)
+		return &CodeOutput{ExitCode: -1}, fmt.Errorf("failed to create container: %w", err)
 	}
 
 	// Ensure container cleanup
+	tracker.add(container.ID, d)
 	defer func() {
 		d.client.RemoveContainer(docker.RemoveContainerOptions{
 			ID:    container.ID,
 			Force: true,
 		})
+		tracker.remove(container.ID)
 	}()
 
 	// Start container
 	if err := d.client.StartContainer(container.ID, nil); err != nil {
-		return "", fmt.Errorf("failed to start container: %w", err)
+		return &CodeOutput{ExitCode: -1}, fmt.Errorf("failed to start container: %w", err)
 	}
 
 	// Wait for completion with timeout
+	output := &CodeOutput{ExitCode: -1}
 	resultCh := make(chan error, 1)
 	go func() {
 		exitCode, err := d.client.WaitContainer(container.ID)
@@ -300,6 +361,7 @@ func (d *DooDockerExecutor) executeCodeInContainer(
 			resultCh <- err
 			return
 		}
+		output.ExitCode = exitCode
 		if exitCode != 0 {
 			resultCh <- fmt.Errorf("code exited with code %d", exitCode)
 			return
@@ -311,22 +373,49 @@ func (d *DooDockerExecutor) executeCodeInContainer(
 	select {
 	case <-ctx.Done():
 		d.client.StopContainer(container.ID, 1)
-		return "", fmt.Errorf("execution timeout after %v", d.config.Timeout)
+		return &CodeOutput{ExitCode: -1}, fmt.Errorf("execution timeout after %v", d.config.Timeout)
 	case err := <-resultCh:
 		if err != nil {
 			// Get logs even on error
-			logs, _ := d.getContainerLogs(container.ID)
-			return logs, err
+			output.Stdout, output.Stderr, _ = d.getContainerStreams(container.ID)
+			return output, err
 		}
 	}
 
 	// Get output
-	output, err := d.getContainerLogs(container.ID)
+	output.Stdout, output.Stderr, err = d.getContainerStreams(container.ID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get container logs: %w", err)
+		return &CodeOutput{ExitCode: output.ExitCode}, fmt.Errorf("failed to get container logs: %w", err)
 	}
 
 	return output, nil
 }
 
+// removeContainer force-removes a container
+func (d *DooDockerExecutor) removeContainer(ctx context.Context, id string) error {
+	err := d.client.RemoveContainer(docker.RemoveContainerOptions{ID: id, Force: true, Context: ctx})
+	var noSuch *docker.NoSuchContainer
+	if errors.As(err, &noSuch) {
+		return nil
+	}
+	return err
+}
+
+// listManaged returns the containers labeled as started by mcp-cli
+func (d *DooDockerExecutor) listManaged(ctx context.Context) ([]managedContainer, error) {
+	listed, err := d.client.ListContainers(docker.ListContainersOptions{
+		All:     true,
+		Filters: map[string][]string{"label": {LabelManaged + "=true"}},
+		Context: ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	containers := make([]managedContainer, 0, len(listed))
+	for _, c := range listed {
+		containers = append(containers, managedContainer{ID: c.ID, Owner: c.Labels[LabelOwner]})
+	}
+	return containers, nil
+}
+
 var _ io.Writer = (*bytesWriter)(nil) // Ensure interface compliance
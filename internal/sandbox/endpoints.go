@@ -0,0 +1,135 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Endpoint is a Docker or Podman engine API the sandbox can talk to
+type Endpoint struct {
+	Host   string // unix://, npipe://, tcp:// or ssh:// address
+	Source string // Where it was found, e.g. DOCKER_HOST or "podman machine"
+}
+
+// Scheme returns the endpoint's URL scheme
+func (e Endpoint) Scheme() string {
+	if !strings.Contains(e.Host, "://") {
+		return "tcp"
+	}
+	return strings.SplitN(e.Host, "://", 2)[0]
+}
+
+// APISupported reports whether the engine API can be used directly.
+// ssh:// endpoints are only reachable through the docker or podman CLI.
+func (e Endpoint) APISupported() bool {
+	switch e.Scheme() {
+	case "unix", "npipe", "tcp", "http", "https":
+		return true
+	}
+	return false
+}
+
+// Remote reports whether the engine runs on another machine. Bind mounts
+// then refer to that machine's filesystem, so the skill, workspace and
+// outputs directories must exist there at the same paths.
+func (e Endpoint) Remote() bool {
+	switch e.Scheme() {
+	case "unix", "npipe":
+		return false
+	}
+	u, err := url.Parse(e.Host)
+	if err != nil || !strings.Contains(e.Host, "://") {
+		u = &url.URL{Host: e.Host}
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// Endpoints returns the engine endpoints to try, in order: DOCKER_HOST,
+// CONTAINER_HOST (Podman remote), a running podman machine, then the
+// usual local sockets and named pipes
+func Endpoints() []Endpoint {
+	var endpoints []Endpoint
+	seen := make(map[string]bool)
+	add := func(host, source string) {
+		if host == "" || seen[host] {
+			return
+		}
+		seen[host] = true
+		endpoints = append(endpoints, Endpoint{Host: host, Source: source})
+	}
+
+	add(os.Getenv("DOCKER_HOST"), "DOCKER_HOST")
+	add(os.Getenv("CONTAINER_HOST"), "CONTAINER_HOST")
+	if host := podmanMachineHost(); host != "" {
+		add(host, "podman machine")
+	}
+
+	if runtime.GOOS == "windows" {
+		add("npipe:////./pipe/docker_engine", "Docker Desktop")
+		add("npipe:////./pipe/podman-machine-default", "podman machine")
+		return endpoints
+	}
+
+	home, _ := os.UserHomeDir()
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+	sockets := []struct{ path, source string }{
+		{"/var/run/docker.sock", "Docker socket"},
+		{filepath.Join(runtimeDir, "docker.sock"), "Docker rootless socket"},
+		{filepath.Join(runtimeDir, "podman", "podman.sock"), "Podman rootless socket"},
+		{"/run/podman/podman.sock", "Podman rootful socket"},
+		{filepath.Join(home, ".docker", "run", "docker.sock"), "Docker Desktop"},
+	}
+	for _, socket := range sockets {
+		if _, err := os.Stat(socket.path); err == nil {
+			add("unix://"+socket.path, socket.source)
+		}
+	}
+	return endpoints
+}
+
+// podmanMachineHost returns the API address of the running podman machine
+// on macOS and Windows, where Podman runs containers in a VM
+func podmanMachineHost() string {
+	if runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		return ""
+	}
+	if _, err := exec.LookPath("podman"); err != nil {
+		return ""
+	}
+
+	format := "{{.ConnectionInfo.PodmanSocket.Path}}"
+	if runtime.GOOS == "windows" {
+		format = "{{.ConnectionInfo.PodmanPipe.Path}}"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "podman", "machine", "inspect", "--format", format).Output()
+	if err != nil {
+		return ""
+	}
+
+	// One line per machine; the first is the default
+	path := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	if path == "" || path == "<no value>" {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		return "npipe://" + filepath.ToSlash(path)
+	}
+	return "unix://" + path
+}
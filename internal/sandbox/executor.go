@@ -36,6 +36,39 @@ type Executor interface {
 	GetInfo() string
 }
 
+// ImageResolver is implemented by executors that can report exactly which
+// image a skill runs in
+type ImageResolver interface {
+	// ResolveImage returns the image used for a skill and its digest (a
+	// repository digest if the image was pulled, otherwise the image ID)
+	ResolveImage(ctx context.Context, skillLibsDir string) (image, digest string, err error)
+}
+
+// CodeOutput is the output of a code run with stdout and stderr kept apart
+type CodeOutput struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int // Exit code of the interpreter, -1 if it didn't run or finish
+}
+
+// Combined returns stdout followed by stderr, as ExecutePythonCode and
+// ExecuteBashCode report them
+func (o *CodeOutput) Combined() string {
+	if o.Stderr == "" {
+		return o.Stdout
+	}
+	return o.Stdout + "\n" + o.Stderr
+}
+
+// StreamExecutor is implemented by executors that can keep a code run's
+// stdout and stderr apart
+type StreamExecutor interface {
+	// ExecuteCodeStreams runs code like ExecutePythonCode or ExecuteBashCode,
+	// by interpreter ("python" or "bash"). The output is returned with
+	// errors too, when the code ran.
+	ExecuteCodeStreams(ctx context.Context, interpreter, workspaceDir, skillLibsDir, scriptPath string, args []string) (*CodeOutput, error)
+}
+
 // ExecutorConfig holds common configuration
 type ExecutorConfig struct {
 	PythonImage  string
@@ -101,7 +134,15 @@ func DetectExecutor(config ExecutorConfig) (Executor, error) {
 		return exec, nil
 	}
 
-	return nil, fmt.Errorf("no Docker executor available")
+	// No working CLI, but the engine API may still be reachable, e.g. via
+	// DOCKER_HOST or a podman machine socket
+	if !isRunningInContainer() {
+		if exec, err := NewDooDockerExecutor(config); err == nil && exec.IsAvailable() {
+			return exec, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no Docker executor available (run 'mcp-cli sandbox doctor' to see why)")
 }
 
 // isRunningInContainer checks if we're inside a Docker container
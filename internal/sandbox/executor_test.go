@@ -97,3 +97,19 @@ func TestExecutorConfigWithoutMapping(t *testing.T) {
 		}
 	}
 }
+
+func TestCodeOutputCombined(t *testing.T) {
+	tests := []struct {
+		output CodeOutput
+		want   string
+	}{
+		{CodeOutput{Stdout: "data\n"}, "data\n"},
+		{CodeOutput{Stdout: "data\n", Stderr: "warning\n"}, "data\n\nwarning\n"},
+		{CodeOutput{Stderr: "error\n"}, "\nerror\n"},
+	}
+	for _, tt := range tests {
+		if got := tt.output.Combined(); got != tt.want {
+			t.Errorf("Combined() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Pull policies for skill images (pull_policy in skill-images.yaml)
+const (
+	PullMissing = "missing" // Pull images that aren't present (default)
+	PullNever   = "never"   // Only run images already present, e.g. after `mcp-cli skills pull`
+)
+
+// LocalImage is an image present on the engine
+type LocalImage struct {
+	ID          string   // sha256:... image ID
+	RepoDigests []string // repository@sha256:... for images pulled from a registry
+}
+
+// Matches reports whether the image is the one a digest pins: either its
+// ID or one of its repository digests
+func (img *LocalImage) Matches(digest string) bool {
+	if img.ID == digest {
+		return true
+	}
+	for _, repoDigest := range img.RepoDigests {
+		if _, d := SplitDigest(repoDigest); d == digest {
+			return true
+		}
+	}
+	return false
+}
+
+// DigestFor returns the digest to pin a reference to: the repository digest
+// for the reference's repository if it came from a registry, else the ID
+func (img *LocalImage) DigestFor(ref string) string {
+	repository := Repository(ref)
+	for _, repoDigest := range img.RepoDigests {
+		// Podman reports fully qualified names, e.g. docker.io/library/python
+		if name, digest := SplitDigest(repoDigest); name == repository || strings.HasSuffix(name, "/"+repository) {
+			return digest
+		}
+	}
+	if len(img.RepoDigests) > 0 {
+		_, digest := SplitDigest(img.RepoDigests[0])
+		return digest
+	}
+	return img.ID
+}
+
+// ImageManager is implemented by executors that can inspect and pull images
+type ImageManager interface {
+	// InspectImage returns a local image, or nil if it isn't present
+	InspectImage(ctx context.Context, ref string) (*LocalImage, error)
+
+	// PullImage pulls an image from its registry
+	PullImage(ctx context.Context, ref string) error
+}
+
+// SplitDigest splits name@sha256:... into the name and the digest
+func SplitDigest(ref string) (name, digest string) {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// Repository returns a reference without its tag or digest
+func Repository(ref string) string {
+	name, _ := SplitDigest(ref)
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		return name[:i]
+	}
+	return name
+}
+
+// GetDigestForSkill returns the digest a skill's image is pinned to, or ""
+func (c *ExecutorConfig) GetDigestForSkill(skillLibsDir string) string {
+	type digestMapper interface {
+		GetDigestForSkill(string) string
+	}
+	if mapper, ok := c.ImageMapping.(digestMapper); ok {
+		return mapper.GetDigestForSkill(filepath.Base(skillLibsDir))
+	}
+	return ""
+}
+
+// imagePolicy returns the pull policy and whether images must be pinned
+func (c *ExecutorConfig) imagePolicy() (pullPolicy string, requireDigest bool) {
+	type policyMapper interface {
+		GetPullPolicy() string
+		RequiresDigest() bool
+	}
+	pullPolicy = PullMissing
+	if mapper, ok := c.ImageMapping.(policyMapper); ok {
+		if mapper.GetPullPolicy() != "" {
+			pullPolicy = mapper.GetPullPolicy()
+		}
+		requireDigest = mapper.RequiresDigest()
+	}
+	return pullPolicy, requireDigest
+}
+
+// resolveSkillImage returns the image to run for a skill. Missing images
+// are pulled unless the pull policy is never. Pinned images are verified
+// against their digest and run by ID, so a tag moved since can't change
+// what runs.
+func (c *ExecutorConfig) resolveSkillImage(ctx context.Context, images ImageManager, skillLibsDir string) (string, error) {
+	image := c.GetImageForSkill(skillLibsDir)
+	pullPolicy, requireDigest := c.imagePolicy()
+
+	name, digest := SplitDigest(image)
+	if pinned := c.GetDigestForSkill(skillLibsDir); pinned != "" {
+		if digest != "" && digest != pinned {
+			return "", fmt.Errorf("image %s conflicts with its pinned digest %s", image, pinned)
+		}
+		digest = pinned
+	}
+	if digest == "" && requireDigest {
+		return "", fmt.Errorf("image %s is not pinned by digest and require_digest is set (run 'mcp-cli skills pull --pin')", image)
+	}
+
+	// Pinned images are looked up by repository digest, or by ID for
+	// images built locally
+	refs := []string{image}
+	if digest != "" {
+		refs = []string{Repository(name) + "@" + digest, digest}
+	}
+	local, err := findImage(ctx, images, refs)
+	if err != nil {
+		return "", err
+	}
+
+	if local == nil {
+		if pullPolicy == PullNever {
+			return "", fmt.Errorf("image %s is not present and pull_policy is never (run 'mcp-cli skills pull' first)", image)
+		}
+		if err := images.PullImage(ctx, refs[0]); err != nil {
+			return "", fmt.Errorf("failed to pull image %s: %w", refs[0], err)
+		}
+		if local, err = findImage(ctx, images, refs); err != nil {
+			return "", err
+		}
+		if local == nil {
+			return "", fmt.Errorf("image %s is not present after pulling", refs[0])
+		}
+	}
+
+	if digest == "" {
+		return image, nil
+	}
+	if !local.Matches(digest) {
+		return "", fmt.Errorf("image %s does not match its pinned digest %s (found %s)", image, digest, local.ID)
+	}
+	return local.ID, nil
+}
+
+// ensureImage makes sure an image outside the skill mapping is present,
+// pulling it unless the pull policy is never
+func (c *ExecutorConfig) ensureImage(ctx context.Context, images ImageManager, image string) error {
+	local, err := images.InspectImage(ctx, image)
+	if err != nil || local != nil {
+		return err
+	}
+	if pullPolicy, _ := c.imagePolicy(); pullPolicy == PullNever {
+		return fmt.Errorf("image %s is not present and pull_policy is never (run 'mcp-cli skills pull' first)", image)
+	}
+	if err := images.PullImage(ctx, image); err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", image, err)
+	}
+	return nil
+}
+
+// findImage returns the first of refs present on the engine
+func findImage(ctx context.Context, images ImageManager, refs []string) (*LocalImage, error) {
+	for _, ref := range refs {
+		local, err := images.InspectImage(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		if local != nil {
+			return local, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,168 @@
+package sandbox
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// fakeImages is an image store that records pulls instead of talking to a
+// container engine. Pulling a reference makes the image in registry under
+// that reference present.
+type fakeImages struct {
+	local    map[string]*LocalImage
+	registry map[string]*LocalImage
+	pulled   []string
+}
+
+func (f *fakeImages) InspectImage(ctx context.Context, ref string) (*LocalImage, error) {
+	return f.local[ref], nil
+}
+
+func (f *fakeImages) PullImage(ctx context.Context, ref string) error {
+	f.pulled = append(f.pulled, ref)
+	if img, ok := f.registry[ref]; ok {
+		f.local[ref] = img
+	}
+	return nil
+}
+
+// fakeMapping provides the image, digest and policy for every skill
+type fakeMapping struct {
+	image, digest, pullPolicy string
+	requireDigest             bool
+}
+
+func (m fakeMapping) GetImageForSkill(string) string  { return m.image }
+func (m fakeMapping) GetDigestForSkill(string) string { return m.digest }
+func (m fakeMapping) GetPullPolicy() string           { return m.pullPolicy }
+func (m fakeMapping) RequiresDigest() bool            { return m.requireDigest }
+
+func TestResolveSkillImage(t *testing.T) {
+	python := &LocalImage{ID: "sha256:aaa", RepoDigests: []string{"python@sha256:111"}}
+	moved := &LocalImage{ID: "sha256:bbb", RepoDigests: []string{"python@sha256:222"}}
+
+	tests := []struct {
+		name     string
+		mapping  fakeMapping
+		local    map[string]*LocalImage
+		registry map[string]*LocalImage
+		want     string
+		wantErr  string
+		pulled   []string
+	}{
+		{
+			name:    "unpinned image present",
+			mapping: fakeMapping{image: "python:3.11"},
+			local:   map[string]*LocalImage{"python:3.11": python},
+			want:    "python:3.11",
+		},
+		{
+			name:     "unpinned image pulled",
+			mapping:  fakeMapping{image: "python:3.11"},
+			registry: map[string]*LocalImage{"python:3.11": python},
+			want:     "python:3.11",
+			pulled:   []string{"python:3.11"},
+		},
+		{
+			name:     "pinned image pulled by digest",
+			mapping:  fakeMapping{image: "python:3.11", digest: "sha256:111"},
+			registry: map[string]*LocalImage{"python@sha256:111": python},
+			want:     "sha256:aaa",
+			pulled:   []string{"python@sha256:111"},
+		},
+		{
+			name:    "pinned local build found by ID",
+			mapping: fakeMapping{image: "mcp-skills-docx", digest: "sha256:bbb"},
+			local:   map[string]*LocalImage{"sha256:bbb": moved},
+			want:    "sha256:bbb",
+		},
+		{
+			name:    "pinned image mismatch",
+			mapping: fakeMapping{image: "python:3.11", digest: "sha256:111"},
+			local:   map[string]*LocalImage{"python@sha256:111": moved},
+			wantErr: "does not match its pinned digest",
+		},
+		{
+			name:    "reference conflicts with pin",
+			mapping: fakeMapping{image: "python@sha256:222", digest: "sha256:111"},
+			wantErr: "conflicts with its pinned digest",
+		},
+		{
+			name:    "pull policy never",
+			mapping: fakeMapping{image: "python:3.11", pullPolicy: PullNever},
+			wantErr: "pull_policy is never",
+		},
+		{
+			name:    "require digest",
+			mapping: fakeMapping{image: "python:3.11", requireDigest: true},
+			local:   map[string]*LocalImage{"python:3.11": python},
+			wantErr: "not pinned by digest",
+		},
+		{
+			name:    "require digest satisfied by reference",
+			mapping: fakeMapping{image: "python@sha256:111", requireDigest: true},
+			local:   map[string]*LocalImage{"python@sha256:111": python},
+			want:    "sha256:aaa",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := &fakeImages{local: tt.local, registry: tt.registry}
+			if images.local == nil {
+				images.local = make(map[string]*LocalImage)
+			}
+			config := DefaultConfig()
+			config.ImageMapping = tt.mapping
+
+			got, err := config.resolveSkillImage(context.Background(), images, "/skills/docx")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("image = %q, want %q", got, tt.want)
+			}
+			if strings.Join(images.pulled, ",") != strings.Join(tt.pulled, ",") {
+				t.Errorf("pulled = %v, want %v", images.pulled, tt.pulled)
+			}
+		})
+	}
+}
+
+func TestLocalImageDigestFor(t *testing.T) {
+	img := &LocalImage{
+		ID:          "sha256:aaa",
+		RepoDigests: []string{"docker.io/library/alpine@sha256:111", "docker.io/library/python@sha256:222"},
+	}
+	if got := img.DigestFor("python:3.11"); got != "sha256:222" {
+		t.Errorf("DigestFor(python:3.11) = %q, want sha256:222", got)
+	}
+
+	local := &LocalImage{ID: "sha256:bbb"}
+	if got := local.DigestFor("mcp-skills-docx"); got != "sha256:bbb" {
+		t.Errorf("DigestFor(local build) = %q, want image ID", got)
+	}
+}
+
+func TestRepository(t *testing.T) {
+	tests := map[string]string{
+		"python":                           "python",
+		"python:3.11-alpine":               "python",
+		"registry:5000/team/skill":         "registry:5000/team/skill",
+		"registry:5000/team/skill:v2":      "registry:5000/team/skill",
+		"python@sha256:111":                "python",
+		"ghcr.io/org/skill:1.0@sha256:111": "ghcr.io/org/skill",
+	}
+	for ref, want := range tests {
+		if got := Repository(ref); got != want {
+			t.Errorf("Repository(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
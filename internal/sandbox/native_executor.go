@@ -1,8 +1,11 @@
 package sandbox
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 
@@ -28,6 +31,7 @@ func NewNativeExecutor(config ExecutorConfig) (*NativeExecutor, error) {
 		return nil, fmt.Errorf("neither docker nor podman found")
 	}
 
+	sweepOrphans(executor)
 	return executor, nil
 }
 
@@ -38,9 +42,12 @@ func (n *NativeExecutor) IsAvailable() bool {
 
 // ExecutePython runs a Python script using Docker/Podman CLI
 func (n *NativeExecutor) ExecutePython(ctx context.Context, skillDir, scriptPath string, args []string) (string, error) {
+	if err := n.config.ensureImage(ctx, n, n.config.PythonImage); err != nil {
+		return "", err
+	}
+
 	// Build docker/podman run command with security constraints
 	cmdArgs := []string{
-		"run",
 		"--rm",                                      // Remove container after execution
 		"--read-only",                               // Read-only root filesystem
 		"--network=" + n.config.NetworkMode,         // Network mode from config
@@ -57,8 +64,7 @@ func (n *NativeExecutor) ExecutePython(ctx context.Context, skillDir, scriptPath
 	}
 	cmdArgs = append(cmdArgs, args...)
 
-	cmd := exec.CommandContext(ctx, n.command, cmdArgs...)
-	output, err := cmd.CombinedOutput()
+	output, err := n.runContainer(ctx, cmdArgs)
 
 	// Check for timeout
 	if ctx.Err() == context.DeadlineExceeded {
@@ -74,8 +80,11 @@ func (n *NativeExecutor) ExecutePython(ctx context.Context, skillDir, scriptPath
 
 // ExecuteBash runs a Bash script using Docker/Podman CLI
 func (n *NativeExecutor) ExecuteBash(ctx context.Context, skillDir, scriptPath string, args []string) (string, error) {
+	if err := n.config.ensureImage(ctx, n, "alpine:latest"); err != nil {
+		return "", err
+	}
+
 	cmdArgs := []string{
-		"run",
 		"--rm",
 		"--read-only",
 		"--network=" + n.config.NetworkMode,
@@ -91,8 +100,7 @@ func (n *NativeExecutor) ExecuteBash(ctx context.Context, skillDir, scriptPath s
 	}
 	cmdArgs = append(cmdArgs, args...)
 
-	cmd := exec.CommandContext(ctx, n.command, cmdArgs...)
-	output, err := cmd.CombinedOutput()
+	output, err := n.runContainer(ctx, cmdArgs)
 
 	if ctx.Err() == context.DeadlineExceeded {
 		return "", fmt.Errorf("execution timeout after %v", n.config.Timeout)
@@ -120,35 +128,24 @@ func (n *NativeExecutor) GetInfo() string {
 // workspaceDir: read-write workspace for files and code execution
 // skillLibsDir: read-only skill directory for importing helper libraries
 func (n *NativeExecutor) ExecutePythonCode(ctx context.Context, workspaceDir, skillLibsDir, scriptPath string, args []string) (string, error) {
-	// Get the appropriate image and network mode for this skill
-	image := n.config.GetImageForSkill(skillLibsDir)
-	networkMode := n.config.GetNetworkModeForSkill(skillLibsDir)
-	logging.Info("🐳 Executing skill from '%s' with image '%s' (network: %s)", skillLibsDir, image, networkMode)
+	return n.executeCode(ctx, "python", workspaceDir, skillLibsDir, scriptPath, args)
+}
 
-	// Build docker/podman run command with dual mounts
-	cmdArgs := []string{
-		"run",
-		"--rm",                                              // Remove container after execution
-		"--read-only",                                       // Read-only root filesystem
-		"--network=" + networkMode,                          // Network mode for this skill
-		"--memory=" + n.config.MemoryLimit,                  // Memory limit
-		"--cpus=" + n.config.CPULimit,                       // CPU limit
-		"--pids-limit=100",                                  // Process limit
-		"--security-opt=no-new-privileges",                  // No privilege escalation
-		"--cap-drop=ALL",                                    // Drop all capabilities
-		"-v", fmt.Sprintf("%s:/workspace:rw", workspaceDir), // Read-write workspace
-		"-v", fmt.Sprintf("%s:/skill:ro", skillLibsDir), // Read-only skill libs
-		"-v", fmt.Sprintf("%s:/outputs:rw", n.config.OutputsDir), // Persistent outputs directory
-		"-w", "/workspace", // Working directory
-		"-e", "PYTHONPATH=/skill", // Can import from /skill
-		"--tmpfs", "/tmp:rw,exec,size=100m", // Writable /tmp for Python
-		image,                // Use skill-specific image
-		"python", scriptPath, // Command (relative to /workspace)
+// ExecuteBashCode runs Bash code with dual mount support
+// workspaceDir: read-write workspace for files and code execution
+// skillLibsDir: read-only skill directory (for future bash libraries)
+func (n *NativeExecutor) ExecuteBashCode(ctx context.Context, workspaceDir, skillLibsDir, scriptPath string, args []string) (string, error) {
+	return n.executeCode(ctx, "bash", workspaceDir, skillLibsDir, scriptPath, args)
+}
+
+// executeCode runs code with stdout and stderr combined
+func (n *NativeExecutor) executeCode(ctx context.Context, interpreter, workspaceDir, skillLibsDir, scriptPath string, args []string) (string, error) {
+	cmdArgs, err := n.codeRunArgs(ctx, interpreter, workspaceDir, skillLibsDir, scriptPath, args)
+	if err != nil {
+		return "", err
 	}
-	cmdArgs = append(cmdArgs, args...)
 
-	cmd := exec.CommandContext(ctx, n.command, cmdArgs...)
-	output, err := cmd.CombinedOutput()
+	output, err := n.runContainer(ctx, cmdArgs)
 
 	// Check for timeout
 	if ctx.Err() == context.DeadlineExceeded {
@@ -162,18 +159,47 @@ func (n *NativeExecutor) ExecutePythonCode(ctx context.Context, workspaceDir, sk
 	return string(output), nil
 }
 
-// ExecuteBashCode runs Bash code with dual mount support
-// workspaceDir: read-write workspace for files and code execution
-// skillLibsDir: read-only skill directory (for future bash libraries)
-func (n *NativeExecutor) ExecuteBashCode(ctx context.Context, workspaceDir, skillLibsDir, scriptPath string, args []string) (string, error) {
+// ExecuteCodeStreams runs code with dual mount support, keeping stdout and
+// stderr apart
+func (n *NativeExecutor) ExecuteCodeStreams(ctx context.Context, interpreter, workspaceDir, skillLibsDir, scriptPath string, args []string) (*CodeOutput, error) {
+	cmdArgs, err := n.codeRunArgs(ctx, interpreter, workspaceDir, skillLibsDir, scriptPath, args)
+	if err != nil {
+		return &CodeOutput{ExitCode: -1}, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = n.runContainerTo(ctx, cmdArgs, &stdout, &stderr)
+	if ctx.Err() == context.DeadlineExceeded {
+		return &CodeOutput{ExitCode: -1}, fmt.Errorf("execution timeout after %v", n.config.Timeout)
+	}
+
+	output := &CodeOutput{Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		output.ExitCode = exitErr.ExitCode()
+		return output, fmt.Errorf("code exited with code %d", output.ExitCode)
+	default:
+		output.ExitCode = -1
+		return output, fmt.Errorf("code execution failed: %w", err)
+	}
+	return output, nil
+}
+
+// codeRunArgs builds the run arguments for code in the workspace, with the
+// skill directory mounted for its helper libraries
+func (n *NativeExecutor) codeRunArgs(ctx context.Context, interpreter, workspaceDir, skillLibsDir, scriptPath string, args []string) ([]string, error) {
 	// Get the appropriate image and network mode for this skill
-	image := n.config.GetImageForSkill(skillLibsDir)
+	image, err := n.config.resolveSkillImage(ctx, n, skillLibsDir)
+	if err != nil {
+		return nil, err
+	}
 	networkMode := n.config.GetNetworkModeForSkill(skillLibsDir)
-	logging.Info("🐳 Executing bash skill from '%s' with image '%s' (network: %s)", skillLibsDir, image, networkMode)
+	logging.Info("🐳 Executing %s code for skill '%s' with image '%s' (network: %s)", interpreter, skillLibsDir, image, networkMode)
 
 	// Build docker/podman run command with dual mounts
 	cmdArgs := []string{
-		"run",
 		"--rm",                                              // Remove container after execution
 		"--read-only",                                       // Read-only root filesystem
 		"--network=" + networkMode,                          // Network mode for this skill
@@ -186,23 +212,125 @@ func (n *NativeExecutor) ExecuteBashCode(ctx context.Context, workspaceDir, skil
 		"-v", fmt.Sprintf("%s:/skill:ro", skillLibsDir), // Read-only skill libs
 		"-v", fmt.Sprintf("%s:/outputs:rw", n.config.OutputsDir), // Persistent outputs directory
 		"-w", "/workspace", // Working directory
+	}
+	if interpreter == "python" {
+		cmdArgs = append(cmdArgs, "-e", "PYTHONPATH=/skill") // Can import from /skill
+	}
+	cmdArgs = append(cmdArgs,
 		"--tmpfs", "/tmp:rw,exec,size=100m", // Writable /tmp
-		image,              // Use skill-specific image
-		"bash", scriptPath, // Command (relative to /workspace)
+		image,                   // Use skill-specific image
+		interpreter, scriptPath, // Command (relative to /workspace)
+	)
+	return append(cmdArgs, args...), nil
+}
+
+// ResolveImage returns the image used for a skill and its digest
+func (n *NativeExecutor) ResolveImage(ctx context.Context, skillLibsDir string) (string, string, error) {
+	image := n.config.GetImageForSkill(skillLibsDir)
+	local, err := n.InspectImage(ctx, image)
+	if err != nil {
+		return image, "", err
 	}
-	cmdArgs = append(cmdArgs, args...)
+	if local == nil {
+		return image, "", fmt.Errorf("image %s is not present", image)
+	}
+	return image, local.DigestFor(image), nil
+}
 
-	cmd := exec.CommandContext(ctx, n.command, cmdArgs...)
-	output, err := cmd.CombinedOutput()
+// InspectImage returns a local image, or nil if it isn't present
+func (n *NativeExecutor) InspectImage(ctx context.Context, ref string) (*LocalImage, error) {
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, n.command, "image", "inspect", "--format", "{{.Id}} {{range .RepoDigests}}{{.}} {{end}}", ref)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		message := strings.ToLower(stderr.String())
+		if strings.Contains(message, "no such") || strings.Contains(message, "not known") || strings.Contains(message, "not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to inspect image %s: %w: %s", ref, err, strings.TrimSpace(stderr.String()))
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no ID reported for image %s", ref)
+	}
+	return &LocalImage{ID: fields[0], RepoDigests: fields[1:]}, nil
+}
 
-	// Check for timeout
-	if ctx.Err() == context.DeadlineExceeded {
-		return "", fmt.Errorf("execution timeout after %v", n.config.Timeout)
+// PullImage pulls an image from its registry
+func (n *NativeExecutor) PullImage(ctx context.Context, ref string) error {
+	output, err := exec.CommandContext(ctx, n.command, "pull", ref).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// runContainer runs `docker run` with the given arguments in a named,
+// labeled container. If ctx ends first the container is removed, since
+// killing the CLI leaves it running.
+func (n *NativeExecutor) runContainer(ctx context.Context, runArgs []string) ([]byte, error) {
+	var output bytes.Buffer
+	err := n.runContainerTo(ctx, runArgs, &output, &output)
+	return output.Bytes(), err
+}
+
+// runContainerTo runs a labeled container, writing its output to stdout and
+// stderr
+func (n *NativeExecutor) runContainerTo(ctx context.Context, runArgs []string, stdout, stderr io.Writer) error {
+	name := tracker.newContainerName()
+	args := []string{"run", "--name", name}
+	for key, value := range containerLabels() {
+		args = append(args, "--label", key+"="+value)
+	}
+	args = append(args, runArgs...)
+
+	tracker.add(name, n)
+	defer tracker.remove(name)
+
+	cmd := exec.CommandContext(ctx, n.command, args...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		removeCtx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+		defer cancel()
+		if rmErr := n.removeContainer(removeCtx, name); rmErr != nil {
+			logging.Warn("Failed to remove sandbox container %s: %v", name, rmErr)
+		}
 	}
+	return err
+}
 
+// removeContainer force-removes a container
+func (n *NativeExecutor) removeContainer(ctx context.Context, id string) error {
+	output, err := exec.CommandContext(ctx, n.command, "rm", "--force", id).CombinedOutput()
+	if err != nil && !strings.Contains(strings.ToLower(string(output)), "no such container") {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// listManaged returns the containers labeled as started by mcp-cli
+func (n *NativeExecutor) listManaged(ctx context.Context) ([]managedContainer, error) {
+	output, err := exec.CommandContext(ctx, n.command, "ps", "--all", "--quiet", "--filter", "label="+LabelManaged+"=true").Output()
 	if err != nil {
-		return string(output), fmt.Errorf("code execution failed: %w\nOutput: %s", err, output)
+		return nil, err
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return nil, nil
 	}
 
-	return string(output), nil
+	format := fmt.Sprintf(`{{.Id}} {{index .Config.Labels "%s"}}`, LabelOwner)
+	output, err = exec.CommandContext(ctx, n.command, append([]string{"inspect", "--format", format}, ids...)...).Output()
+	if err != nil {
+		return nil, err
+	}
+	var containers []managedContainer
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if id, owner, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+			containers = append(containers, managedContainer{ID: id, Owner: owner})
+		}
+	}
+	return containers, nil
 }
@@ -0,0 +1,90 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteJSON writes the report as indented JSON
+func WriteJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteTable writes a comparison table with one row per target
+func WriteTable(w io.Writer, report *Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tOK\tTTFT p50\tLATENCY p50\tp95\tTOK/S\tTOKENS in/out\tCOST\tTOOL CALLS\tCHECKS")
+
+	estimated := false
+	for _, r := range report.Results {
+		label := Target{Provider: r.Provider, Model: r.Model}.Label()
+
+		tokenCounts := fmt.Sprintf("%d/%d", r.PromptTokens, r.CompletionTokens)
+		if r.TokensEstimated {
+			tokenCounts += "*"
+			estimated = true
+		}
+
+		cost := "-"
+		if r.CostUSD != nil {
+			cost = fmt.Sprintf("$%.4f", *r.CostUSD)
+		}
+
+		fmt.Fprintf(tw, "%s\t%d/%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			label,
+			r.Calls-r.Errors, r.Calls,
+			formatMs(r.TTFTP50Ms),
+			formatMs(r.LatencyP50Ms),
+			formatMs(r.LatencyP95Ms),
+			formatRate(r.TokensPerSecond),
+			tokenCounts,
+			cost,
+			formatRatio(r.ToolCallsValid, r.ToolCallsExpected),
+			formatRatio(r.ChecksPassed, r.ChecksExpected),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if estimated {
+		fmt.Fprintln(w, "\n* provider did not report usage; tokens estimated with the model family's tokenizer")
+	}
+	for _, r := range report.Results {
+		for _, s := range r.Samples {
+			if s.Error != "" {
+				fmt.Fprintf(w, "\n%s %s (run %d): %s", Target{Provider: r.Provider, Model: r.Model}.Label(), s.Case, s.Run, s.Error)
+			}
+		}
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+func formatMs(ms float64) string {
+	if ms == 0 {
+		return "-"
+	}
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.0fms", ms)
+}
+
+func formatRate(rate float64) string {
+	if rate == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", rate)
+}
+
+func formatRatio(n, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", n, total)
+}
@@ -0,0 +1,340 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/tokens"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// Target is a provider/model pair under test
+type Target struct {
+	Provider string
+	Model    string
+	LLM      domain.LLMProvider
+	Config   *config.ProviderConfig // Pricing source; nil or unpriced means no cost column
+}
+
+// Label returns "provider/model", or just the provider when the model is its default
+func (t Target) Label() string {
+	if t.Model == "" {
+		return t.Provider
+	}
+	return t.Provider + "/" + t.Model
+}
+
+// Options controls a benchmark run
+type Options struct {
+	Runs     int           // Repetitions of each case per target (default: 3)
+	Timeout  time.Duration // Per-call timeout (default: 2m)
+	Progress func(target Target, c Case, run int)
+}
+
+// Report is the outcome of a benchmark run
+type Report struct {
+	Suite    string    `json:"suite"`
+	Started  time.Time `json:"started"`
+	Runs     int       `json:"runs_per_case"`
+	Results  []Result  `json:"results"`
+	Duration float64   `json:"duration_seconds"`
+}
+
+// Result aggregates all samples for one target
+type Result struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+
+	Calls  int `json:"calls"`
+	Errors int `json:"errors"`
+
+	TTFTP50Ms    float64 `json:"ttft_p50_ms"`
+	LatencyP50Ms float64 `json:"latency_p50_ms"`
+	LatencyP95Ms float64 `json:"latency_p95_ms"`
+
+	// TokensPerSecond is completion tokens over generation time (after the first token)
+	TokensPerSecond  float64 `json:"tokens_per_second"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	TokensEstimated  bool    `json:"tokens_estimated,omitempty"`
+
+	CostUSD *float64 `json:"cost_usd,omitempty"`
+
+	ToolCallsExpected int `json:"tool_calls_expected"`
+	ToolCallsValid    int `json:"tool_calls_valid"`
+	ChecksExpected    int `json:"checks_expected"`
+	ChecksPassed      int `json:"checks_passed"`
+
+	Samples []Sample `json:"samples"`
+}
+
+// Sample is one call of one case
+type Sample struct {
+	Case             string  `json:"case"`
+	Run              int     `json:"run"`
+	TTFTMs           float64 `json:"ttft_ms,omitempty"`
+	LatencyMs        float64 `json:"latency_ms"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	TokensEstimated  bool    `json:"tokens_estimated,omitempty"`
+	ToolCallValid    *bool   `json:"tool_call_valid,omitempty"`
+	CheckPassed      *bool   `json:"check_passed,omitempty"`
+	Error            string  `json:"error,omitempty"`
+}
+
+// Run executes every case Runs times against each target. Calls run one at a
+// time so targets don't compete for bandwidth and skew each other's latency.
+func Run(ctx context.Context, suite *Suite, targets []Target, opts Options) *Report {
+	if opts.Runs <= 0 {
+		opts.Runs = 3
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Minute
+	}
+
+	report := &Report{Suite: suite.Name, Started: time.Now(), Runs: opts.Runs}
+	for _, target := range targets {
+		result := Result{Provider: target.Provider, Model: target.Model}
+		for run := 1; run <= opts.Runs; run++ {
+			for _, c := range suite.Cases {
+				if ctx.Err() != nil {
+					break
+				}
+				if opts.Progress != nil {
+					opts.Progress(target, c, run)
+				}
+				sample, model := runCase(ctx, target, c, run, opts.Timeout)
+				if result.Model == "" && model != "" {
+					result.Model = model
+				}
+				result.Samples = append(result.Samples, sample)
+			}
+		}
+		summarize(&result, target.Config)
+		report.Results = append(report.Results, result)
+	}
+	report.Duration = time.Since(report.Started).Seconds()
+	return report
+}
+
+// runCase performs one streamed completion and scores it. The returned model
+// is the one the provider reported, for targets using their default model.
+func runCase(ctx context.Context, target Target, c Case, run int, timeout time.Duration) (Sample, string) {
+	sample := Sample{Case: c.Name, Run: run}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	writer := &firstWriteRecorder{}
+	started := time.Now()
+	writer.started = started
+	resp, err := target.LLM.StreamCompletion(callCtx, c.request(), writer)
+	elapsed := time.Since(started)
+
+	sample.LatencyMs = toMs(elapsed)
+	if ttft, ok := writer.ttft(); ok {
+		sample.TTFTMs = toMs(ttft)
+	}
+	if err != nil {
+		sample.Error = err.Error()
+		return sample, ""
+	}
+
+	text := resp.Response
+	if text == "" {
+		text = writer.text()
+	}
+
+	model := target.Model
+	if model == "" {
+		model = resp.Model
+	}
+	if resp.Usage != nil && resp.Usage.TotalTokens > 0 {
+		sample.PromptTokens = resp.Usage.PromptTokens
+		sample.CompletionTokens = resp.Usage.CompletionTokens
+	} else {
+		// Not every provider reports usage on streamed responses
+		counter := tokens.TokenizerForModel(model)
+		sample.PromptTokens = counter.Count(c.SystemPrompt) + counter.Count(c.Prompt)
+		sample.CompletionTokens = counter.Count(text)
+		for _, call := range resp.ToolCalls {
+			sample.CompletionTokens += counter.Count(call.Function.Name) + counter.Count(string(call.Function.Arguments))
+		}
+		sample.TokensEstimated = true
+	}
+
+	if c.ExpectTool != "" {
+		valid := checkToolCall(&c, resp.ToolCalls) == nil
+		sample.ToolCallValid = &valid
+	}
+	if c.Expect != "" {
+		passed := strings.Contains(strings.ToLower(text), strings.ToLower(c.Expect))
+		sample.CheckPassed = &passed
+	}
+
+	return sample, resp.Model
+}
+
+// checkToolCall verifies that the expected tool was called with a JSON object
+// containing its required arguments
+func checkToolCall(c *Case, calls []domain.ToolCall) error {
+	tool := c.findTool(c.ExpectTool)
+	for _, call := range calls {
+		if call.Function.Name != c.ExpectTool {
+			continue
+		}
+
+		args, err := parseArguments(call.Function.Arguments)
+		if err != nil {
+			return fmt.Errorf("tool %s called with invalid arguments: %w", c.ExpectTool, err)
+		}
+		if tool != nil {
+			if required, ok := tool.Parameters["required"].([]interface{}); ok {
+				for _, r := range required {
+					name, _ := r.(string)
+					if _, ok := args[name]; !ok {
+						return fmt.Errorf("tool %s called without required argument %s", c.ExpectTool, name)
+					}
+				}
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("tool %s was not called", c.ExpectTool)
+}
+
+// parseArguments decodes tool call arguments, accepting both a JSON object
+// and a JSON string containing one (as some providers double-encode)
+func parseArguments(raw json.RawMessage) (map[string]interface{}, error) {
+	if len(raw) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		raw = json.RawMessage(encoded)
+	}
+
+	var args map[string]interface{}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+// summarize fills the aggregate fields of a result from its samples
+func summarize(result *Result, providerConfig *config.ProviderConfig) {
+	var ttfts, latencies []float64
+	var genSeconds float64
+	var genTokens int
+
+	for _, s := range result.Samples {
+		result.Calls++
+		if s.Error != "" {
+			result.Errors++
+			continue
+		}
+
+		latencies = append(latencies, s.LatencyMs)
+		if s.TTFTMs > 0 {
+			ttfts = append(ttfts, s.TTFTMs)
+		}
+
+		result.PromptTokens += s.PromptTokens
+		result.CompletionTokens += s.CompletionTokens
+		if s.TokensEstimated {
+			result.TokensEstimated = true
+		}
+
+		// Generation time excludes the wait for the first token
+		gen := s.LatencyMs - s.TTFTMs
+		if gen > 0 && s.CompletionTokens > 0 {
+			genSeconds += gen / 1000
+			genTokens += s.CompletionTokens
+		}
+
+		if s.ToolCallValid != nil {
+			result.ToolCallsExpected++
+			if *s.ToolCallValid {
+				result.ToolCallsValid++
+			}
+		}
+		if s.CheckPassed != nil {
+			result.ChecksExpected++
+			if *s.CheckPassed {
+				result.ChecksPassed++
+			}
+		}
+	}
+
+	result.TTFTP50Ms = percentile(ttfts, 50)
+	result.LatencyP50Ms = percentile(latencies, 50)
+	result.LatencyP95Ms = percentile(latencies, 95)
+	if genSeconds > 0 {
+		result.TokensPerSecond = float64(genTokens) / genSeconds
+	}
+	if providerConfig.HasPricing() {
+		cost := providerConfig.EstimateCost(result.PromptTokens, result.CompletionTokens)
+		result.CostUSD = &cost
+	}
+}
+
+// percentile returns the nearest-rank percentile of values (0 when empty)
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := int(float64(len(sorted))*p/100+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+func toMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// firstWriteRecorder captures streamed output and the time of the first chunk
+type firstWriteRecorder struct {
+	mu      sync.Mutex
+	started time.Time
+	first   time.Time
+	buf     strings.Builder
+}
+
+func (r *firstWriteRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.first.IsZero() && len(p) > 0 {
+		r.first = time.Now()
+	}
+	return r.buf.Write(p)
+}
+
+func (r *firstWriteRecorder) ttft() (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.first.IsZero() {
+		return 0, false
+	}
+	return r.first.Sub(r.started), true
+}
+
+func (r *firstWriteRecorder) text() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.String()
+}
@@ -0,0 +1,120 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// fakeProvider streams a canned answer, calling a tool when one is offered
+type fakeProvider struct {
+	answer   string
+	toolArgs string
+	usage    *domain.Usage
+}
+
+func (f *fakeProvider) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	return f.StreamCompletion(ctx, req, io.Discard)
+}
+
+func (f *fakeProvider) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, w io.Writer) (*domain.CompletionResponse, error) {
+	resp := &domain.CompletionResponse{Model: "fake-1", Usage: f.usage}
+	if len(req.Tools) > 0 {
+		resp.ToolCalls = []domain.ToolCall{{
+			ID:       "call_1",
+			Type:     "function",
+			Function: domain.Function{Name: req.Tools[0].Function.Name, Arguments: json.RawMessage(f.toolArgs)},
+		}}
+		return resp, nil
+	}
+	io.WriteString(w, f.answer)
+	resp.Response = f.answer
+	return resp, nil
+}
+
+func (f *fakeProvider) CreateEmbeddings(ctx context.Context, req *domain.EmbeddingRequest) (*domain.EmbeddingResponse, error) {
+	return nil, nil
+}
+func (f *fakeProvider) GetSupportedEmbeddingModels() []string  { return nil }
+func (f *fakeProvider) GetMaxEmbeddingTokens(model string) int { return 0 }
+func (f *fakeProvider) GetProviderType() domain.ProviderType   { return "fake" }
+func (f *fakeProvider) GetInterfaceType() config.InterfaceType { return config.OpenAICompatible }
+func (f *fakeProvider) ValidateConfig() error                  { return nil }
+func (f *fakeProvider) Close() error                           { return nil }
+
+func TestRunScoresCasesAndCost(t *testing.T) {
+	suite := DefaultSuite()
+	llm := &fakeProvider{
+		answer:   "Canberra",
+		toolArgs: `"{\"city\":\"Paris\"}"`, // double-encoded, as some providers send it
+		usage:    &domain.Usage{PromptTokens: 100, CompletionTokens: 50, TotalTokens: 150},
+	}
+	pricing := &config.ProviderConfig{CostPer1kInputTokens: 0.01, CostPer1kOutputTokens: 0.02}
+
+	report := Run(context.Background(), suite, []Target{{Provider: "fake", LLM: llm, Config: pricing}}, Options{Runs: 2})
+
+	if len(report.Results) != 1 {
+		t.Fatalf("results = %d, want 1", len(report.Results))
+	}
+	r := report.Results[0]
+	if r.Model != "fake-1" {
+		t.Errorf("model = %q, want provider-reported fake-1", r.Model)
+	}
+	if r.Calls != 8 || r.Errors != 0 {
+		t.Errorf("calls/errors = %d/%d, want 8/0", r.Calls, r.Errors)
+	}
+	if r.ToolCallsExpected != 2 || r.ToolCallsValid != 2 {
+		t.Errorf("tool calls = %d/%d, want 2/2", r.ToolCallsValid, r.ToolCallsExpected)
+	}
+	// Only short_answer matches "Canberra"; summarize and code checks fail
+	if r.ChecksExpected != 6 || r.ChecksPassed != 2 {
+		t.Errorf("checks = %d/%d, want 2/6", r.ChecksPassed, r.ChecksExpected)
+	}
+	if r.CostUSD == nil || *r.CostUSD < 0.0159 || *r.CostUSD > 0.0161 {
+		t.Errorf("cost = %v, want 8*(0.001+0.001)=0.016", r.CostUSD)
+	}
+
+	var table bytes.Buffer
+	if err := WriteTable(&table, report); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(table.String(), "fake/fake-1") || !strings.Contains(table.String(), "$0.0160") {
+		t.Errorf("table missing target or cost:\n%s", table.String())
+	}
+}
+
+func TestCheckToolCallRequiresArguments(t *testing.T) {
+	c := DefaultSuite().Cases[3]
+
+	if err := checkToolCall(&c, nil); err == nil {
+		t.Error("missing call should fail")
+	}
+	call := func(args string) []domain.ToolCall {
+		return []domain.ToolCall{{Function: domain.Function{Name: "get_weather", Arguments: json.RawMessage(args)}}}
+	}
+	if err := checkToolCall(&c, call(`{"unit":"celsius"}`)); err == nil {
+		t.Error("call without required city should fail")
+	}
+	if err := checkToolCall(&c, call(`{not json`)); err == nil {
+		t.Error("invalid JSON arguments should fail")
+	}
+	if err := checkToolCall(&c, call(`{"city":"Paris"}`)); err != nil {
+		t.Errorf("valid call failed: %v", err)
+	}
+}
+
+func TestSuiteValidate(t *testing.T) {
+	suite := &Suite{Cases: []Case{{Name: "a", Prompt: "p", ExpectTool: "missing"}}}
+	if err := suite.Validate(); err == nil {
+		t.Error("expect_tool not in tools should be rejected")
+	}
+	if err := DefaultSuite().Validate(); err != nil {
+		t.Errorf("default suite invalid: %v", err)
+	}
+}
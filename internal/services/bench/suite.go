@@ -0,0 +1,169 @@
+package bench
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is a set of prompts run against every benchmark target
+type Suite struct {
+	Name  string `yaml:"name"`
+	Cases []Case `yaml:"cases"`
+}
+
+// Case is a single benchmark prompt
+type Case struct {
+	Name         string `yaml:"name"`
+	Prompt       string `yaml:"prompt"`
+	SystemPrompt string `yaml:"system_prompt,omitempty"`
+	MaxTokens    int    `yaml:"max_tokens,omitempty"`
+
+	// Expect is a substring the response must contain (case-insensitive)
+	Expect string `yaml:"expect,omitempty"`
+
+	// Tools are offered to the model; ExpectTool names the one it should call
+	// with arguments that satisfy the tool's required parameters
+	Tools      []Tool `yaml:"tools,omitempty"`
+	ExpectTool string `yaml:"expect_tool,omitempty"`
+}
+
+// Tool is a tool definition offered in a benchmark case. Tools are never
+// executed; the benchmark only checks that the model calls them correctly.
+type Tool struct {
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description"`
+	Parameters  map[string]interface{} `yaml:"parameters"`
+}
+
+// LoadSuite reads a suite from a YAML file
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suite: %w", err)
+	}
+
+	var suite Suite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("failed to parse suite %s: %w", path, err)
+	}
+	if err := suite.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid suite %s: %w", path, err)
+	}
+	if suite.Name == "" {
+		suite.Name = path
+	}
+	return &suite, nil
+}
+
+// Validate checks that every case has a prompt and that expected tools are offered
+func (s *Suite) Validate() error {
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("suite has no cases")
+	}
+
+	seen := make(map[string]bool)
+	for i, c := range s.Cases {
+		if c.Name == "" {
+			return fmt.Errorf("case %d has no name", i+1)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("duplicate case name '%s'", c.Name)
+		}
+		seen[c.Name] = true
+
+		if c.Prompt == "" {
+			return fmt.Errorf("case '%s' has no prompt", c.Name)
+		}
+		if c.ExpectTool != "" && c.findTool(c.ExpectTool) == nil {
+			return fmt.Errorf("case '%s' expects tool '%s' which is not in its tools list", c.Name, c.ExpectTool)
+		}
+	}
+	return nil
+}
+
+// DefaultSuite covers short answers, summarization, code and a tool call
+func DefaultSuite() *Suite {
+	return &Suite{
+		Name: "default",
+		Cases: []Case{
+			{
+				Name:      "short_answer",
+				Prompt:    "What is the capital of Australia? Answer with one word.",
+				MaxTokens: 16,
+				Expect:    "Canberra",
+			},
+			{
+				Name: "summarize",
+				Prompt: "Summarize in two sentences: The Model Context Protocol (MCP) is an open protocol " +
+					"that standardizes how applications provide context to language models. Servers expose " +
+					"tools, resources and prompts over JSON-RPC, and clients such as editors, chat apps and " +
+					"command-line tools connect to them over stdio or HTTP. This lets one integration work " +
+					"across many AI applications instead of each application building its own connectors.",
+				MaxTokens: 200,
+				Expect:    "protocol",
+			},
+			{
+				Name:      "code",
+				Prompt:    "Write a Go function named Reverse that reverses a string by runes. Reply with only the code.",
+				MaxTokens: 300,
+				Expect:    "func Reverse",
+			},
+			{
+				Name:      "tool_call",
+				Prompt:    "What's the weather in Paris right now? Use the available tool.",
+				MaxTokens: 200,
+				Tools: []Tool{{
+					Name:        "get_weather",
+					Description: "Get the current weather for a city",
+					Parameters: map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"city": map[string]interface{}{"type": "string", "description": "City name"},
+							"unit": map[string]interface{}{"type": "string", "enum": []interface{}{"celsius", "fahrenheit"}},
+						},
+						"required": []interface{}{"city"},
+					},
+				}},
+				ExpectTool: "get_weather",
+			},
+		},
+	}
+}
+
+// findTool returns the case's tool with the given name
+func (c *Case) findTool(name string) *Tool {
+	for i := range c.Tools {
+		if c.Tools[i].Name == name {
+			return &c.Tools[i]
+		}
+	}
+	return nil
+}
+
+// request builds the completion request for the case
+func (c *Case) request() *domain.CompletionRequest {
+	req := &domain.CompletionRequest{
+		Messages:     []domain.Message{{Role: "user", Content: c.Prompt}},
+		SystemPrompt: c.SystemPrompt,
+		MaxTokens:    c.MaxTokens,
+		Stream:       true,
+	}
+	for _, t := range c.Tools {
+		params := t.Parameters
+		if params == nil {
+			params = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		req.Tools = append(req.Tools, domain.Tool{
+			Type: "function",
+			Function: domain.ToolFunction{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  params,
+			},
+		})
+	}
+	return req
+}
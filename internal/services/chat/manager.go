@@ -10,9 +10,11 @@ import (
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/mcp"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolcontent"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/transport/stdio"
 )
@@ -321,7 +323,7 @@ func (m *ChatManager) HandleToolCalls(toolCalls []domain.ToolCall) error {
 		var toolResultContent string
 		if err != nil {
 			m.UI.PrintError("Tool execution failed: %v", err)
-			toolResultContent = fmt.Sprintf("Error: %v", err)
+			toolResultContent = domainErrors.FormatToolResult(err)
 		} else {
 			toolResultContent = result
 		}
@@ -466,6 +468,10 @@ func (m *ChatManager) ExecuteToolCall(toolCall domain.ToolCall) (string, error)
 		return "", fmt.Errorf("tool execution failed: %s", errorMsg)
 	}
 
+	if text, ok := toolcontent.Render(toolName, result.Content); ok {
+		return text, nil
+	}
+
 	// Convert result to string if needed
 	var resultStr string
 	switch content := result.Content.(type) {
@@ -785,6 +791,7 @@ func (m *ChatManager) PrintContextStats() {
 
 	if stats["token_management"] == "enabled" {
 		fmt.Printf("  Current Tokens: %v\n", stats["current_tokens"])
+		fmt.Printf("  Tokenizer: %v\n", stats["tokenizer"])
 		fmt.Printf("  Max Tokens: %v\n", stats["max_tokens"])
 		fmt.Printf("  Reserve Tokens: %v\n", stats["reserve_tokens"])
 		fmt.Printf("  Effective Limit: %v\n", stats["effective_limit"])
@@ -0,0 +1,98 @@
+package chat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+)
+
+// providerSwitcher creates providers for the chat /provider and /model
+// commands, closing them when the chat ends
+type providerSwitcher struct {
+	service    *Service
+	appConfig  *config.ApplicationConfig
+	configFile string
+	created    []domain.LLMProvider
+}
+
+// ProviderNames lists the providers in the configuration
+func (p *providerSwitcher) ProviderNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if p.appConfig.AI != nil {
+		for _, interfaceConfig := range p.appConfig.AI.Interfaces {
+			for name := range interfaceConfig.Providers {
+				add(name)
+			}
+		}
+		for name := range p.appConfig.AI.Providers {
+			add(name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProviderConfig returns a provider's configuration
+func (p *providerSwitcher) ProviderConfig(name string) (*config.ProviderConfig, error) {
+	providerConfig, _, err := p.service.getProviderConfiguration(p.appConfig, name)
+	return providerConfig, err
+}
+
+// NewProvider creates a provider, defaulting to its configured model.
+// Routing policies (router:NAME) resolve to the provider they select.
+func (p *providerSwitcher) NewProvider(name, model string) (domain.LLMProvider, *config.ProviderConfig, string, error) {
+	providerName := name
+	if policyName, isRouter := config.ParseRouter(name); isRouter {
+		decision, err := ai.Route(p.appConfig, policyName, nil)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		selected := decision.Selected()
+		providerName = selected.Provider
+		if model == "" {
+			model = selected.Model
+		}
+	}
+
+	providerConfig, err := p.ProviderConfig(providerName)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("%w (run /provider to list providers)", err)
+	}
+	if model == "" {
+		model = providerConfig.DefaultModel
+	}
+
+	provider, err := p.service.aiService.InitializeProvider(p.configFile, providerName, model)
+	if err == nil {
+		if err = provider.ValidateConfig(); err != nil {
+			provider.Close()
+		}
+	}
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to switch to %s: %w", name, err)
+	}
+	p.created = append(p.created, provider)
+
+	logging.Info("Switched chat to provider %s, model %s", providerName, model)
+	return provider, providerConfig, model, nil
+}
+
+// Close closes the providers created during the chat
+func (p *providerSwitcher) Close() {
+	for _, provider := range p.created {
+		if err := provider.Close(); err != nil {
+			logging.Warn("Error closing provider: %v", err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package chat
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// switcherConfigFiles is a modular configuration with two OpenAI-compatible
+// providers, one missing its API key, and a routing policy
+var switcherConfigFiles = map[string]string{
+	"config.yaml": `includes:
+  providers: config/providers/*.yaml
+  settings: config/settings.yaml
+`,
+	"config/providers/local.yaml": `interface_type: openai_compatible
+provider_name: local
+config:
+  api_key: test
+  api_endpoint: http://127.0.0.1:1/v1
+  default_model: m1
+  available_models: [m1, m2]
+`,
+	"config/providers/keyless.yaml": `interface_type: openai_compatible
+provider_name: keyless
+config:
+  api_endpoint: http://127.0.0.1:1/v1
+  default_model: m1
+`,
+	"config/settings.yaml": `routing:
+  policies:
+    small:
+      candidates:
+        - provider: local
+          model: m2
+`,
+}
+
+// newTestSwitcher loads switcherConfigFiles into a provider switcher
+func newTestSwitcher(t *testing.T) *providerSwitcher {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range switcherConfigFiles {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := NewService()
+	configFile := filepath.Join(dir, "config.yaml")
+	appConfig, err := service.configService.LoadConfig(configFile)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	return &providerSwitcher{service: service, appConfig: appConfig, configFile: configFile}
+}
+
+func TestProviderSwitcherProviderNames(t *testing.T) {
+	p := &providerSwitcher{service: &Service{}, appConfig: &config.ApplicationConfig{AI: &config.AIConfig{
+		Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+			config.OpenAICompatible: {Providers: map[string]config.ProviderConfig{"openai": {}, "lmstudio": {}}},
+			config.OllamaNative:     {Providers: map[string]config.ProviderConfig{"ollama": {}}},
+		},
+		Providers: map[string]config.ProviderConfig{"openai": {}, "anthropic": {}},
+	}}}
+	if got, want := p.ProviderNames(), []string{"anthropic", "lmstudio", "ollama", "openai"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ProviderNames() = %q, want %q", got, want)
+	}
+
+	p.appConfig = &config.ApplicationConfig{}
+	if got := p.ProviderNames(); len(got) != 0 {
+		t.Errorf("ProviderNames() without AI configuration = %q", got)
+	}
+}
+
+func TestProviderSwitcherProviderConfig(t *testing.T) {
+	p := &providerSwitcher{service: &Service{}, appConfig: &config.ApplicationConfig{AI: &config.AIConfig{
+		Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+			config.OpenAICompatible: {Providers: map[string]config.ProviderConfig{"openai": {DefaultModel: "gpt-4o"}}},
+		},
+		Providers: map[string]config.ProviderConfig{"anthropic": {DefaultModel: "claude-sonnet-4"}},
+	}}}
+
+	for name, want := range map[string]string{"openai": "gpt-4o", "anthropic": "claude-sonnet-4"} {
+		providerConfig, err := p.ProviderConfig(name)
+		if err != nil || providerConfig.DefaultModel != want {
+			t.Errorf("ProviderConfig(%s) = %+v, %v, want default model %s", name, providerConfig, err, want)
+		}
+	}
+	if _, err := p.ProviderConfig("gemini"); err == nil {
+		t.Error("ProviderConfig(gemini) should fail")
+	}
+}
+
+func TestProviderSwitcherNewProvider(t *testing.T) {
+	p := newTestSwitcher(t)
+
+	tests := []struct {
+		name      string
+		provider  string
+		model     string
+		wantModel string
+	}{
+		{"default model", "local", "", "m1"},
+		{"given model", "local", "m2", "m2"},
+		{"routing policy", "router:small", "", "m2"},
+		{"routing policy with model", "router:small", "m1", "m1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, providerConfig, model, err := p.NewProvider(tt.provider, tt.model)
+			if err != nil {
+				t.Fatalf("NewProvider: %v", err)
+			}
+			if model != tt.wantModel || provider.GetProviderType() != "local" || providerConfig.DefaultModel != "m1" {
+				t.Errorf("NewProvider = %s with %s (default %s), want local with %s", provider.GetProviderType(), model, providerConfig.DefaultModel, tt.wantModel)
+			}
+		})
+	}
+	if len(p.created) != len(tests) {
+		t.Errorf("created %d providers, want %d", len(p.created), len(tests))
+	}
+}
+
+func TestProviderSwitcherNewProviderErrors(t *testing.T) {
+	p := newTestSwitcher(t)
+
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{"openai", "provider 'openai' not found in configuration (run /provider to list providers)"},
+		{"router:fast", "routing policy 'fast' not found"},
+		{"keyless", "failed to switch to keyless: provider authentication failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			if _, _, _, err := p.NewProvider(tt.provider, ""); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewProvider error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+	if len(p.created) != 0 {
+		t.Errorf("kept %d providers from failed switches", len(p.created))
+	}
+}
+
+// closeCounter is a provider that counts Close calls
+type closeCounter struct {
+	domain.LLMProvider
+	closed int
+}
+
+func (c *closeCounter) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestProviderSwitcherClose(t *testing.T) {
+	first, second := &closeCounter{}, &closeCounter{}
+	p := &providerSwitcher{created: []domain.LLMProvider{first, second}}
+	p.Close()
+	if first.closed != 1 || second.closed != 1 {
+		t.Errorf("Close calls = %d, %d, want 1 each", first.closed, second.closed)
+	}
+}
@@ -0,0 +1,99 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/chat"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
+)
+
+// ragRetriever searches a RAG server for each chat message (chat.auto_rag).
+// It connects the server's MCP server on its own manager, so the search tool
+// isn't offered to the model.
+type ragRetriever struct {
+	service       ragSearcher
+	serverManager *host.ServerManager
+	settings      *config.AutoRAGConfig
+	server        string
+}
+
+// ragSearcher runs RAG searches, as rag.Service does
+type ragSearcher interface {
+	Search(ctx context.Context, req rag.SearchRequest) (*rag.SearchResponse, error)
+}
+
+// newRAGRetriever connects the RAG server auto-RAG searches
+func newRAGRetriever(appConfig *config.ApplicationConfig, configService domain.ConfigurationService, settings *config.AutoRAGConfig) (*ragRetriever, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if appConfig.RAG == nil {
+		return nil, fmt.Errorf("chat.auto_rag requires a rag: section in settings.yaml")
+	}
+	server := settings.Server
+	if server == "" {
+		server = appConfig.RAG.DefaultServer
+	}
+	ragServer, ok := appConfig.RAG.Servers[server]
+	if !ok {
+		return nil, fmt.Errorf("chat.auto_rag: RAG server '%s' not found in rag.servers", server)
+	}
+	serverDef, ok := appConfig.Servers[ragServer.MCPServer]
+	if !ok {
+		return nil, fmt.Errorf("chat.auto_rag: MCP server '%s' not found in servers config", ragServer.MCPServer)
+	}
+
+	serverManager := host.NewServerManagerWithOptions(true) // suppress console
+	if _, err := serverManager.ConnectToServer(ragServer.MCPServer, serverDef, false); err != nil {
+		return nil, fmt.Errorf("chat.auto_rag: failed to connect RAG server '%s': %w", ragServer.MCPServer, err)
+	}
+
+	embeddingService := embeddings.NewService(configService, ai.NewProviderFactory())
+	return &ragRetriever{
+		service:       rag.NewServiceWithConfig(appConfig.RAG, serverManager, embeddingService),
+		serverManager: serverManager,
+		settings:      settings,
+		server:        server,
+	}, nil
+}
+
+// Retrieve searches the server and returns the results that reach min_score
+func (r *ragRetriever) Retrieve(ctx context.Context, query string) ([]chat.RetrievedSource, error) {
+	response, err := r.service.Search(ctx, rag.SearchRequest{
+		Query:     query,
+		Server:    r.server,
+		TopK:      r.settings.TopKOrDefault(),
+		Namespace: r.settings.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []chat.RetrievedSource
+	for _, result := range response.Results {
+		if result.CombinedScore < r.settings.MinScore {
+			continue
+		}
+		sources = append(sources, chat.RetrievedSource{
+			ID:     result.ID,
+			Source: result.SourceName(),
+			Server: r.server,
+			Score:  result.CombinedScore,
+			Text:   result.JoinedText(),
+		})
+	}
+	return sources, nil
+}
+
+// Close disconnects the RAG server
+func (r *ragRetriever) Close() {
+	logging.Debug("Closing auto-RAG server connections")
+	r.serverManager.CloseConnections()
+}
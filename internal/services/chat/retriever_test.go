@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/chat"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
+)
+
+// fakeSearcher returns canned results and records the requests it gets
+type fakeSearcher struct {
+	results  []rag.SearchResult
+	err      error
+	requests []rag.SearchRequest
+}
+
+func (s *fakeSearcher) Search(ctx context.Context, req rag.SearchRequest) (*rag.SearchResponse, error) {
+	s.requests = append(s.requests, req)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &rag.SearchResponse{Results: s.results}, nil
+}
+
+func TestRAGRetrieverRetrieve(t *testing.T) {
+	searcher := &fakeSearcher{results: []rag.SearchResult{
+		{ID: "c1", Source: "guide.md", CombinedScore: 0.9, Text: map[string]interface{}{"content": "Use SSO"}},
+		{ID: "c2", CombinedScore: 0.2, Text: map[string]interface{}{"content": "Unrelated"}},
+		{ID: "c3", CombinedScore: 0.5, Metadata: map[string]interface{}{"file": "faq.md"}, Text: map[string]interface{}{"a": "Reset", "b": "passwords"}},
+	}}
+	r := &ragRetriever{
+		service:  searcher,
+		settings: &config.AutoRAGConfig{Enabled: true, MinScore: 0.5, Namespace: "docs"},
+		server:   "kb",
+	}
+
+	sources, err := r.Retrieve(context.Background(), "how do I log in?")
+	if err != nil {
+		t.Fatalf("Retrieve: %v", err)
+	}
+
+	want := []chat.RetrievedSource{
+		{ID: "c1", Source: "guide.md", Server: "kb", Score: 0.9, Text: "Use SSO"},
+		{ID: "c3", Source: "faq.md", Server: "kb", Score: 0.5, Text: "Reset\npasswords"},
+	}
+	if !reflect.DeepEqual(sources, want) {
+		t.Errorf("sources = %+v, want %+v", sources, want)
+	}
+
+	wantReq := rag.SearchRequest{Query: "how do I log in?", Server: "kb", TopK: config.DefaultAutoRAGTopK, Namespace: "docs"}
+	if len(searcher.requests) != 1 || !reflect.DeepEqual(searcher.requests[0], wantReq) {
+		t.Errorf("requests = %+v, want %+v", searcher.requests, wantReq)
+	}
+}
+
+func TestRAGRetrieverRetrieveError(t *testing.T) {
+	r := &ragRetriever{
+		service:  &fakeSearcher{err: errors.New("server down")},
+		settings: &config.AutoRAGConfig{Enabled: true},
+		server:   "kb",
+	}
+	if _, err := r.Retrieve(context.Background(), "q"); err == nil {
+		t.Error("Retrieve should return the search error")
+	}
+}
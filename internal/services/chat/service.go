@@ -9,11 +9,15 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/core/chat"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/builtintools"
 	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolselect"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tts"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/elicitation"
 	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
 )
 
@@ -33,6 +37,9 @@ type Config struct {
 	ServerNames       []string
 	UserSpecified     map[string]bool
 	SkillNames        []string // Filtered list of skills to expose
+	Speak             bool     // Read final responses aloud via the configured TTS provider
+	SpeakProvider     string   // TTS provider name (default: tts.default_provider)
+	Quiet             bool     // Suppress progress spinners (--quiet)
 }
 
 // NewService creates a new chat service
@@ -99,12 +106,17 @@ func (s *Service) StartChat(cfg *Config) error {
 
 	// Create UI at service level to ensure cleanup even on timeout
 	ui := chat.NewUI()
+	ui.SetQuiet(cfg.Quiet)
 	defer func() {
 		if err := ui.Close(); err != nil {
 			logging.Warn("Error closing UI: %v", err)
 		}
 	}()
 
+	// Let servers ask the user for input in the middle of a tool call
+	elicitation.SetHandler(ui)
+	defer elicitation.SetHandler(nil)
+
 	// ARCHITECTURAL FIX: Separate built-in skills from external servers
 	externalServers, needsSkills := infraSkills.SeparateSkillsFromServers(cfg.ServerNames)
 	logging.Debug("External servers: %v, needs built-in skills: %v", externalServers, needsSkills)
@@ -136,8 +148,20 @@ func (s *Service) StartChat(cfg *Config) error {
 			logging.Info("Wrapping chat server manager with built-in skills support")
 			serverManager = infraSkills.NewSkillsAwareServerManager(serverManager, skillService)
 		}
+		builtins := appConfig.BuiltinTools
+		if cfg.DisableFilesystem {
+			builtins = builtins.WithoutFilesystem()
+		}
+		serverManager = builtintools.Wrap(serverManager, builtins)
+		serverManager = toolselect.Wrap(serverManager, cfg.ConfigFile, appConfig.ToolSelection)
+
+		// Skills take files uploaded with /upload
+		var uploader chat.FileUploader
+		if skillService != nil {
+			uploader = skillService
+		}
 
-		return s.runChat(serverManager, provider, providerConfig, modelName, ui, appConfig, cfg.SkillNames)
+		return s.runChat(serverManager, uploader, provider, providerName, providerConfig, modelName, ui, appConfig, cfg)
 	}, cfg.ConfigFile, externalServers, externalUserSpecified)
 }
 
@@ -184,7 +208,7 @@ func (s *Service) inferInterfaceType(providerName string) config.InterfaceType {
 }
 
 // runChat executes the chat session with server connections
-func (s *Service) runChat(serverManager domain.MCPServerManager, provider domain.LLMProvider, providerConfig *config.ProviderConfig, model string, ui *chat.UI, appConfig *config.ApplicationConfig, skillNames []string) error {
+func (s *Service) runChat(serverManager domain.MCPServerManager, uploader chat.FileUploader, provider domain.LLMProvider, providerName string, providerConfig *config.ProviderConfig, model string, ui *chat.UI, appConfig *config.ApplicationConfig, cfg *Config) error {
 	// Get chat configuration from loaded app config
 	var chatConfig *config.ChatConfig
 	if appConfig != nil && appConfig.Chat != nil {
@@ -226,12 +250,55 @@ func (s *Service) runChat(serverManager domain.MCPServerManager, provider domain
 	}
 
 	// Set enabled skills
-	chatManager.EnabledSkills = skillNames
+	chatManager.EnabledSkills = cfg.SkillNames
+	chatManager.ToolIterations = appConfig.ToolIterations
+	chatManager.Uploader = uploader
+
+	// Configure spoken responses if requested
+	if cfg.Speak {
+		if appConfig == nil || appConfig.TTS == nil {
+			return fmt.Errorf("--speak requires a tts: section in settings.yaml")
+		}
+		speaker, err := tts.NewSpeaker(appConfig.TTS, cfg.SpeakProvider)
+		if err != nil {
+			return fmt.Errorf("failed to configure --speak: %w", err)
+		}
+		chatManager.Speaker = speaker
+		logging.Info("Spoken responses enabled")
+	}
+
+	// Let /run execute workflows with this chat's servers
+	if appConfig != nil {
+		runner := &workflowRunner{appConfig: appConfig, configService: s.configService, serverManager: serverManager}
+		for _, server := range cfg.ServerNames {
+			runner.hasSkills = runner.hasSkills || server == "skills"
+		}
+		chatManager.Workflows = runner
+	}
+
+	// Retrieve sources for each message if auto-RAG is on
+	if autoRAG := chatConfig.AutoRAG; autoRAG != nil && autoRAG.Enabled {
+		retriever, err := newRAGRetriever(appConfig, s.configService, autoRAG)
+		if err != nil {
+			return err
+		}
+		defer retriever.Close()
+		chatManager.Retriever = retriever
+		chatManager.RetrievalTurns = autoRAG.HistoryTurnsOrDefault()
+		logging.Info("Auto-RAG enabled with RAG server %s", retriever.server)
+	}
+
+	// Let /provider and /model switch the LLM mid-conversation
+	chatManager.SetActiveProvider(providerName)
+	if appConfig != nil {
+		switcher := &providerSwitcher{service: s, appConfig: appConfig, configFile: cfg.ConfigFile}
+		defer switcher.Close()
+		chatManager.Providers = switcher
+	}
 
 	// Configure session logging if enabled
 	if sessionLogger != nil && sessionLogger.IsEnabled() {
-		providerName := string(provider.GetProviderType())
-		chatManager.SetSessionLogger(sessionLogger, providerName, model)
+		chatManager.SetSessionLogger(sessionLogger, string(provider.GetProviderType()), model)
 	}
 
 	if err := chatManager.StartChat(); err != nil {
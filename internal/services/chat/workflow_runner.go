@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+)
+
+// workflowRunner runs configured workflows for the chat /run command with
+// the chat's server manager
+type workflowRunner struct {
+	appConfig     *config.ApplicationConfig
+	configService domain.ConfigurationService
+	serverManager domain.MCPServerManager
+	hasSkills     bool
+}
+
+// WorkflowNames lists the configured workflows
+func (r *workflowRunner) WorkflowNames() []string {
+	names := r.appConfig.ListWorkflows()
+	sort.Strings(names)
+	return names
+}
+
+// RunWorkflow runs a workflow, writing step-level progress and the responses
+// of steps with stream: true to progress
+func (r *workflowRunner) RunWorkflow(ctx context.Context, name, input string, progress io.Writer) (string, error) {
+	wf, ok := r.appConfig.GetWorkflow(name)
+	if !ok {
+		return "", fmt.Errorf("workflow not found: %s (run /run to list workflows)", name)
+	}
+	if err := workflow.ValidateWorkflow(wf); err != nil {
+		return "", fmt.Errorf("workflow validation failed: %w", err)
+	}
+
+	// The workflow only gets the servers this chat connected
+	if missing := r.missingServers(wf); len(missing) > 0 {
+		fmt.Fprintf(progress, "[WARN] Not connected in this chat: %s\n", strings.Join(missing, ", "))
+	}
+
+	logger := workflow.NewLogger("step", false)
+	logger.SetOutput(progress)
+
+	orchestrator := workflow.NewOrchestratorWithKey(wf, name, logger)
+	defer orchestrator.Close()
+
+	orchestrator.SetAppConfig(r.appConfig)
+	orchestrator.SetAppConfigForWorkflows(r.appConfig)
+	orchestrator.SetStreamOutput(progress)
+	orchestrator.SetEmbeddingService(embeddings.NewService(r.configService, ai.NewProviderFactory()))
+	if r.serverManager != nil {
+		orchestrator.SetServerManager(r.serverManager)
+	}
+
+	if err := orchestrator.Execute(ctx, input); err != nil {
+		return "", err
+	}
+	output, _ := orchestrator.FinalOutput()
+	return output, nil
+}
+
+// missingServers returns the servers a workflow uses that the chat didn't
+// connect
+func (r *workflowRunner) missingServers(wf *config.WorkflowV2) []string {
+	connected := map[string]domain.MCPServer{}
+	if r.serverManager != nil {
+		connected = r.serverManager.ListServers()
+	}
+
+	var missing []string
+	needsSkills := len(workflow.RequiredSkills(wf)) > 0
+	for _, server := range workflow.RequiredServers(wf, r.appConfig.RAG) {
+		if server == "skills" {
+			needsSkills = true
+			continue
+		}
+		if _, ok := connected[server]; !ok {
+			missing = append(missing, server)
+		}
+	}
+	if needsSkills && !r.hasSkills {
+		missing = append(missing, "skills")
+	}
+	return missing
+}
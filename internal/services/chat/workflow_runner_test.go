@@ -0,0 +1,167 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// stubServers is a server manager that lists server names and has no tools
+type stubServers struct {
+	domain.MCPServerManager
+	names []string
+}
+
+func (s *stubServers) ListServers() map[string]domain.MCPServer {
+	servers := make(map[string]domain.MCPServer)
+	for _, name := range s.names {
+		servers[name] = nil
+	}
+	return servers
+}
+
+func (s *stubServers) GetAvailableTools() ([]domain.Tool, error) { return nil, nil }
+
+// completionServer is an OpenAI-compatible endpoint that answers every chat
+// completion with the last user message, prefixed with reply
+func completionServer(t *testing.T, reply string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Messages) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      "c1",
+			"object":  "chat.completion",
+			"choices": []map[string]interface{}{{"index": 0, "finish_reason": "stop", "message": map[string]string{"role": "assistant", "content": reply + req.Messages[len(req.Messages)-1].Content}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testAppConfig returns a configuration with one OpenAI-compatible provider,
+// "local", served from endpoint
+func testAppConfig(endpoint string, workflows map[string]*config.WorkflowV2) *config.ApplicationConfig {
+	return &config.ApplicationConfig{
+		AI: &config.AIConfig{
+			Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+				config.OpenAICompatible: {Providers: map[string]config.ProviderConfig{
+					"local": {APIKey: "test", APIEndpoint: endpoint, DefaultModel: "m1", AvailableModels: []string{"m1", "m2"}},
+				}},
+			},
+		},
+		Workflows: workflows,
+	}
+}
+
+func TestWorkflowRunnerWorkflowNames(t *testing.T) {
+	r := &workflowRunner{appConfig: testAppConfig("", map[string]*config.WorkflowV2{
+		"triage": {}, "release": {}, "review": {},
+	})}
+	if got, want := r.WorkflowNames(), []string{"release", "review", "triage"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WorkflowNames() = %q, want %q", got, want)
+	}
+}
+
+func TestWorkflowRunnerRunWorkflow(t *testing.T) {
+	server := completionServer(t, "Reviewed: ")
+	r := &workflowRunner{
+		appConfig: testAppConfig(server.URL, map[string]*config.WorkflowV2{
+			"review": {
+				Name:      "review",
+				Version:   "1.0.0",
+				Execution: config.ExecutionContext{Provider: "local", Model: "m1", Servers: []string{"github"}},
+				Steps:     []config.StepV2{{Name: "review", Run: "Review {{input}}"}},
+			},
+		}),
+		serverManager: &stubServers{},
+	}
+
+	var progress bytes.Buffer
+	output, err := r.RunWorkflow(context.Background(), "review", "PR 42", &progress)
+	if err != nil {
+		t.Fatalf("RunWorkflow: %v\n%s", err, progress.String())
+	}
+	if output != "Reviewed: Review PR 42" {
+		t.Errorf("output = %q, want the step's response", output)
+	}
+	if !strings.Contains(progress.String(), "[WARN] Not connected in this chat: github\n") {
+		t.Errorf("progress doesn't warn about the missing server:\n%s", progress.String())
+	}
+
+	if _, err := r.RunWorkflow(context.Background(), "deploy", "", &progress); err == nil || !strings.Contains(err.Error(), "workflow not found: deploy (run /run to list workflows)") {
+		t.Errorf("unknown workflow error = %v", err)
+	}
+}
+
+func TestWorkflowRunnerMissingServers(t *testing.T) {
+	tests := []struct {
+		name      string
+		execution config.ExecutionContext
+		steps     []config.StepV2
+		connected []string
+		hasSkills bool
+		want      []string
+	}{
+		{
+			name:      "all connected",
+			execution: config.ExecutionContext{Servers: []string{"github"}},
+			steps:     []config.StepV2{{Name: "s1", Servers: []string{"filesystem"}}},
+			connected: []string{"github", "filesystem", "brave"},
+		},
+		{
+			name:      "missing servers",
+			execution: config.ExecutionContext{Servers: []string{"github", "jira"}},
+			steps:     []config.StepV2{{Name: "s1", Servers: []string{"filesystem"}}},
+			connected: []string{"github"},
+			want:      []string{"filesystem", "jira"},
+		},
+		{
+			name:      "skills without the skills server",
+			execution: config.ExecutionContext{Servers: []string{"github"}},
+			steps:     []config.StepV2{{Name: "s1", Skills: []string{"docx"}}},
+			connected: []string{"github"},
+			want:      []string{"skills"},
+		},
+		{
+			name:      "skills server listed",
+			execution: config.ExecutionContext{Servers: []string{"skills"}},
+			want:      []string{"skills"},
+		},
+		{
+			name:      "skills available",
+			execution: config.ExecutionContext{Servers: []string{"skills"}},
+			steps:     []config.StepV2{{Name: "s1", Skills: []string{"docx"}}},
+			hasSkills: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &workflowRunner{
+				appConfig:     &config.ApplicationConfig{},
+				serverManager: &stubServers{names: tt.connected},
+				hasSkills:     tt.hasSkills,
+			}
+			wf := &config.WorkflowV2{Execution: tt.execution, Steps: tt.steps}
+			if got := r.missingServers(wf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingServers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
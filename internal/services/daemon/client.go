@@ -0,0 +1,67 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// dialTimeout bounds how long a CLI invocation waits to reach the daemon
+// before falling back to running in-process
+const dialTimeout = 250 * time.Millisecond
+
+// ErrUnavailable is returned by Send when no daemon accepted the connection.
+// The request was not delivered, so it is safe to run it in-process instead.
+var ErrUnavailable = errors.New("daemon not reachable")
+
+// Available reports whether a daemon answers on path
+func Available(path string) bool {
+	resp, err := Send(path, &Request{Type: RequestPing}, time.Second)
+	return err == nil && resp.Error == ""
+}
+
+// Send delivers one request and waits up to timeout for the response. A
+// socket owned by another user is treated as no daemon at all.
+func Send(path string, req *Request, timeout time.Duration) (*Response, error) {
+	if err := checkSocketOwner(path); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logging.Warn("Not using daemon socket: %v", err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer conn.Close()
+
+	if timeout > 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to send request to daemon: %w", err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read daemon response: %w", err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return nil, fmt.Errorf("invalid daemon response: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,153 @@
+package daemon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/contextbundle"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/query"
+)
+
+// Request types understood by the daemon
+const (
+	RequestPing     = "ping"
+	RequestStatus   = "status"
+	RequestQuery    = "query"
+	RequestShutdown = "shutdown"
+)
+
+// SocketEnvVar overrides the socket path derived from the config file
+const SocketEnvVar = "MCP_CLI_DAEMON_SOCKET"
+
+// Request is a single command sent by a CLI invocation. Each connection
+// carries exactly one request and one response, newline-delimited JSON.
+type Request struct {
+	Type       string        `json:"type"`
+	ConfigFile string        `json:"config_file,omitempty"`
+	Query      *QueryRequest `json:"query,omitempty"`
+}
+
+// QueryRequest carries a resolved query. The client reads context files and
+// resolves the system prompt so paths are interpreted in the caller's cwd.
+type QueryRequest struct {
+	Question     string   `json:"question"`
+	Provider     string   `json:"provider,omitempty"`
+	Model        string   `json:"model,omitempty"`
+	Servers      []string `json:"servers"` // Exact server list; empty means LLM only
+	SystemPrompt string   `json:"system_prompt,omitempty"`
+	Context      string   `json:"context,omitempty"`
+	MaxTokens    int      `json:"max_tokens,omitempty"`
+
+	// Context files and pages, read by the client
+	ContextSources []query.ContextSource `json:"context_sources,omitempty"`
+	ContextOptions query.ContextOptions  `json:"context_options"`
+
+	// Exported chat or workflow run, read by the client
+	ContextBundle *contextbundle.Bundle `json:"context_bundle,omitempty"`
+}
+
+// Response is the daemon's reply. On failure Error is set and ExitCode holds
+// the process exit code the CLI would have returned; ErrorCode is the
+// command-specific code used by --error-code-only.
+type Response struct {
+	Error     string          `json:"error,omitempty"`
+	ExitCode  int             `json:"exit_code,omitempty"`
+	ErrorCode int             `json:"error_code,omitempty"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Status    *Status         `json:"status,omitempty"`
+}
+
+// ErrorResponse builds a failed response, preserving the exit code category
+// of err and the command-specific error code
+func ErrorResponse(err error, errorCode int) *Response {
+	return &Response{Error: err.Error(), ExitCode: domainErrors.ExitCode(err), ErrorCode: errorCode}
+}
+
+// RemoteError is a failure reported by the daemon
+type RemoteError struct {
+	Message   string
+	ErrorCode int
+}
+
+func (e *RemoteError) Error() string {
+	return e.Message
+}
+
+// Err returns the response's failure as an error categorized like the
+// original, so the CLI exits with the same code as an in-process run.
+// Returns nil for successful responses.
+func (r *Response) Err() error {
+	if r.Error == "" {
+		return nil
+	}
+	err := &RemoteError{Message: r.Error, ErrorCode: r.ErrorCode}
+	switch r.ExitCode {
+	case domainErrors.ExitValidation:
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	case domainErrors.ExitProvider:
+		return domainErrors.Categorize(err, domainErrors.ErrProviderFailure)
+	case domainErrors.ExitTool:
+		return domainErrors.Categorize(err, domainErrors.ErrToolFailure)
+	case domainErrors.ExitTimeout:
+		return domainErrors.Categorize(err, domainErrors.ErrTimeout)
+	case domainErrors.ExitInterrupted:
+		return domainErrors.Categorize(err, domainErrors.ErrInterrupted)
+	}
+	return err
+}
+
+// Status describes a running daemon
+type Status struct {
+	PID        int       `json:"pid"`
+	ConfigFile string    `json:"config_file"`
+	StartedAt  time.Time `json:"started_at"`
+	Servers    []string  `json:"servers"`
+	Skills     int       `json:"skills"`
+	Providers  []string  `json:"providers"`
+	Requests   int64     `json:"requests"`
+}
+
+// SocketPath returns the socket a daemon serving configFile listens on.
+// One daemon runs per config file; the path is derived from its absolute
+// path under $XDG_RUNTIME_DIR, or else a per-user directory in the temp dir,
+// since the temp dir itself is shared by every user. Windows 10+ supports
+// AF_UNIX sockets, so the same transport is used on every platform.
+func SocketPath(configFile string) string {
+	if path := os.Getenv(SocketEnvVar); path != "" {
+		return path
+	}
+
+	absConfig, err := filepath.Abs(configFile)
+	if err != nil {
+		absConfig = configFile
+	}
+	sum := sha256.Sum256([]byte(absConfig))
+
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), fmt.Sprintf("mcp-cli-%d", os.Getuid()))
+	}
+	return filepath.Join(dir, fmt.Sprintf("mcp-cli-%s.sock", hex.EncodeToString(sum[:6])))
+}
+
+// prepareSocketDir creates the socket's directory, private to the user,
+// and refuses one that another user owns, who could replace the socket
+func prepareSocketDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create socket directory %s: %w", dir, err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to check socket directory %s: %w", dir, err)
+	}
+	if uid, ok := fileOwner(info); ok && uid != os.Getuid() && uid != 0 {
+		return fmt.Errorf("socket directory %s belongs to uid %d, not the current user", dir, uid)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Handler executes query requests against the daemon's warm state
+type Handler interface {
+	Query(ctx context.Context, req *QueryRequest) *Response
+	Status() *Status
+}
+
+// Server accepts CLI connections on a unix socket
+type Server struct {
+	path     string
+	listener net.Listener
+	handler  Handler
+
+	shutdown chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
+}
+
+// Listen binds the daemon socket. It fails if another daemon is already
+// answering on path and removes a stale socket file left by a crashed one.
+func Listen(path string, handler Handler) (*Server, error) {
+	if err := prepareSocketDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	if Available(path) {
+		return nil, fmt.Errorf("a daemon is already running on %s", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+
+	listener, err := listenSocket(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// The daemon runs with the user's credentials; keep other users out
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+
+	return &Server{
+		path:     path,
+		listener: listener,
+		handler:  handler,
+		shutdown: make(chan struct{}),
+	}, nil
+}
+
+// Path returns the socket path
+func (s *Server) Path() string {
+	return s.path
+}
+
+// Serve accepts connections until ctx is cancelled, Close is called or a
+// client sends a shutdown request. In-flight requests are allowed to finish.
+func (s *Server) Serve(ctx context.Context) error {
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.shutdown:
+		}
+		s.listener.Close()
+	}()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+			case <-s.shutdown:
+			default:
+				s.Close()
+				s.wg.Wait()
+				return fmt.Errorf("accept failed: %w", err)
+			}
+			s.wg.Wait()
+			os.Remove(s.path)
+			return nil
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(ctx, conn)
+		}()
+	}
+}
+
+// Close stops accepting connections
+func (s *Server) Close() {
+	s.once.Do(func() { close(s.shutdown) })
+}
+
+func (s *Server) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		logging.Debug("Daemon: failed to read request: %v", err)
+		return
+	}
+
+	var req Request
+	var resp *Response
+	if err := json.Unmarshal(line, &req); err != nil {
+		resp = &Response{Error: fmt.Sprintf("invalid request: %v", err), ExitCode: domainErrors.ExitValidation}
+	} else {
+		resp = s.dispatch(ctx, &req)
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(&Response{Error: fmt.Sprintf("failed to encode response: %v", err), ExitCode: domainErrors.ExitFailure})
+	}
+	conn.SetWriteDeadline(time.Now().Add(30 * time.Second))
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		logging.Debug("Daemon: failed to write response: %v", err)
+	}
+}
+
+func (s *Server) dispatch(ctx context.Context, req *Request) *Response {
+	logging.Debug("Daemon: %s request", req.Type)
+
+	switch req.Type {
+	case RequestPing:
+		return &Response{}
+	case RequestStatus:
+		return &Response{Status: s.handler.Status()}
+	case RequestShutdown:
+		s.Close()
+		return &Response{}
+	case RequestQuery:
+		if req.Query == nil {
+			return &Response{Error: "query request without query", ExitCode: domainErrors.ExitValidation}
+		}
+		return s.handler.Query(ctx, req.Query)
+	default:
+		return &Response{Error: fmt.Sprintf("unknown request type %q", req.Type), ExitCode: domainErrors.ExitValidation}
+	}
+}
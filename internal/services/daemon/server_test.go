@@ -0,0 +1,155 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+)
+
+type fakeHandler struct {
+	queries int
+}
+
+func (h *fakeHandler) Query(ctx context.Context, req *QueryRequest) *Response {
+	h.queries++
+	if req.Question == "fail" {
+		return &Response{Error: "provider exploded", ExitCode: 3}
+	}
+	result, _ := json.Marshal(map[string]string{"response": "echo: " + req.Question})
+	return &Response{Result: result}
+}
+
+func (h *fakeHandler) Status() *Status {
+	return &Status{PID: os.Getpid(), Servers: []string{"filesystem"}}
+}
+
+func startServer(t *testing.T) (string, chan error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "d.sock")
+	server, err := Listen(path, &fakeHandler{})
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(context.Background()) }()
+	return path, done
+}
+
+func TestDaemonRoundTrip(t *testing.T) {
+	path, done := startServer(t)
+
+	if !Available(path) {
+		t.Fatal("daemon should be available")
+	}
+	if _, err := Listen(path, &fakeHandler{}); err == nil {
+		t.Error("second Listen on a live socket should fail")
+	}
+
+	resp, err := Send(path, &Request{Type: RequestQuery, Query: &QueryRequest{Question: "hi"}}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]string
+	if err := json.Unmarshal(resp.Result, &result); err != nil || result["response"] != "echo: hi" {
+		t.Errorf("result = %s (%v)", resp.Result, err)
+	}
+
+	resp, err = Send(path, &Request{Type: RequestQuery, Query: &QueryRequest{Question: "fail"}}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error == "" || resp.ExitCode != 3 {
+		t.Errorf("failure response = %+v", resp)
+	}
+	if code := domainErrors.ExitCode(resp.Err()); code != domainErrors.ExitProvider {
+		t.Errorf("Err() exit code = %d, want %d", code, domainErrors.ExitProvider)
+	}
+
+	resp, err = Send(path, &Request{Type: RequestStatus}, time.Second)
+	if err != nil || resp.Status == nil || resp.Status.PID != os.Getpid() {
+		t.Errorf("status = %+v, %v", resp, err)
+	}
+
+	if _, err := Send(path, &Request{Type: RequestShutdown}, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() returned %v after shutdown", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("daemon did not shut down")
+	}
+	if Available(path) {
+		t.Error("daemon should be gone after shutdown")
+	}
+}
+
+func TestListenReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stale.sock")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	server, err := Listen(path, &fakeHandler{})
+	if err != nil {
+		t.Fatalf("Listen() over stale socket: %v", err)
+	}
+	server.Close()
+}
+
+func TestSocketPathPrivateDir(t *testing.T) {
+	t.Setenv(SocketEnvVar, "")
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	path := SocketPath("config.yaml")
+	if want := filepath.Join(os.TempDir(), fmt.Sprintf("mcp-cli-%d", os.Getuid())); filepath.Dir(path) != want {
+		t.Errorf("SocketPath() = %s, want a socket in %s", path, want)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	dir := filepath.Join(t.TempDir(), "mcp-cli")
+	server, err := Listen(filepath.Join(dir, "d.sock"), &fakeHandler{})
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	defer server.Close()
+	for path, want := range map[string]os.FileMode{dir: 0700, server.Path(): 0600} {
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != want {
+			t.Errorf("%s mode = %v (%v), want %v", path, info.Mode().Perm(), err, want)
+		}
+	}
+}
+
+func TestSendRefusesForeignSocket(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Getuid() != 0 {
+		t.Skip("needs root to give the socket to another user")
+	}
+	path, _ := startServer(t)
+	if err := os.Lchown(path, 65534, -1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Send(path, &Request{Type: RequestPing}, time.Second); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Send() to another user's socket error = %v, want ErrUnavailable", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "foreign")
+	if err := os.Mkdir(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chown(dir, 65534, -1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen(filepath.Join(dir, "d.sock"), &fakeHandler{}); err == nil {
+		t.Error("Listen() in another user's directory should fail")
+	}
+}
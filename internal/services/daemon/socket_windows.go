@@ -0,0 +1,23 @@
+package daemon
+
+import (
+	"net"
+	"os"
+)
+
+// listenSocket binds path. AF_UNIX socket files on Windows take the ACL of
+// their directory, which is private under the user's temp dir.
+func listenSocket(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
+
+// checkSocketOwner does nothing on Windows, where files carry no uid; the
+// socket lives in the user's own temp dir
+func checkSocketOwner(path string) error {
+	return nil
+}
+
+// fileOwner is not available on Windows
+func fileOwner(info os.FileInfo) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,340 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
+	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+)
+
+// checkConfig loads and validates the config file and its workflows. It
+// returns nil when there is no usable config.
+func checkConfig(r *report, configFile string) *config.ApplicationConfig {
+	if _, err := os.Stat(configFile); errors.Is(err, os.ErrNotExist) {
+		r.add(AreaConfig, sandbox.Check{
+			Name:   "Config file",
+			Status: sandbox.CheckFail,
+			Detail: configFile + " not found",
+			Fix:    "Run 'mcp-cli init --quick' to create one, or pass --config",
+		})
+		return nil
+	}
+
+	service := infraConfig.NewService()
+	appConfig, err := service.LoadConfig(configFile)
+	if err != nil {
+		r.add(AreaConfig, sandbox.Check{
+			Name:   "Config file",
+			Status: sandbox.CheckFail,
+			Detail: firstLine(err),
+			Fix:    fmt.Sprintf("Fix the error in %s; 'mcp-cli config validate' shows it in full", configFile),
+		})
+		return nil
+	}
+	r.add(AreaConfig, sandbox.Check{Name: "Config file", Status: sandbox.CheckOK, Detail: configFile})
+
+	if err := service.ValidateConfig(appConfig); err != nil {
+		r.add(AreaConfig, sandbox.Check{
+			Name:   "Config values",
+			Status: sandbox.CheckFail,
+			Detail: firstLine(err),
+			Fix:    "Set ai.default_provider to a provider configured under ai.interfaces",
+		})
+	}
+
+	names := make([]string, 0, len(appConfig.Workflows))
+	for name := range appConfig.Workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	invalid := 0
+	for _, name := range names {
+		if err := workflow.ValidateWorkflow(appConfig.Workflows[name]); err != nil {
+			invalid++
+			r.add(AreaConfig, sandbox.Check{
+				Name:   "Workflow " + name,
+				Status: sandbox.CheckFail,
+				Detail: strings.Join(strings.Fields(err.Error()), " "),
+				Fix:    fmt.Sprintf("Fix workflow %s as described; it fails before any step runs", name),
+			})
+		}
+	}
+	if len(names) > 0 && invalid == 0 {
+		r.add(AreaConfig, sandbox.Check{Name: "Workflows", Status: sandbox.CheckOK, Detail: plural(len(names), "workflow") + " valid"})
+	}
+	return appConfig
+}
+
+// providerTarget is one configured provider to ping
+type providerTarget struct {
+	name          string
+	interfaceType config.InterfaceType
+	config        config.ProviderConfig
+}
+
+// checkProviders sends each configured provider a one-line completion.
+// Failures of the default provider are failures; others are warnings.
+func checkProviders(r *report, appConfig *config.ApplicationConfig, timeout time.Duration) {
+	var targets []providerTarget
+	if appConfig.AI != nil {
+		for interfaceType, iface := range appConfig.AI.Interfaces {
+			for name, providerConfig := range iface.Providers {
+				targets = append(targets, providerTarget{name, interfaceType, providerConfig})
+			}
+		}
+	}
+	if len(targets) == 0 {
+		r.add(AreaProviders, sandbox.Check{
+			Name:   "Providers",
+			Status: sandbox.CheckFail,
+			Detail: "no providers configured",
+			Fix:    "Add a provider under ai.interfaces, or run 'mcp-cli init'",
+		})
+		return
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].name < targets[j].name })
+
+	checks := make([]sandbox.Check, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target providerTarget) {
+			defer wg.Done()
+			checks[i] = pingProvider(target, timeout)
+			if checks[i].Status == sandbox.CheckFail && target.name != appConfig.AI.DefaultProvider {
+				checks[i].Status = sandbox.CheckWarn
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	for _, check := range checks {
+		r.add(AreaProviders, check)
+	}
+}
+
+// pingProvider asks a provider for a tiny completion
+func pingProvider(target providerTarget, timeout time.Duration) sandbox.Check {
+	check := sandbox.Check{Name: "Provider " + target.name}
+	model := target.config.DefaultModel
+
+	if needsAPIKey(target.interfaceType) && missingAPIKey(target.config.APIKey) {
+		check.Status = sandbox.CheckFail
+		check.Detail = "no API key"
+		check.Fix = fmt.Sprintf("Set %s_API_KEY in .env (or api_key for %s)", strings.ToUpper(target.name), target.name)
+		return check
+	}
+
+	providerConfig := target.config
+	provider, err := ai.NewProviderFactory().CreateProvider(domain.ProviderType(target.name), &providerConfig, target.interfaceType)
+	if err != nil {
+		check.Status = sandbox.CheckFail
+		check.Detail = firstLine(err)
+		check.Fix = fmt.Sprintf("Check the %s settings under ai.interfaces.%s", target.name, target.interfaceType)
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start := time.Now()
+	_, err = provider.CreateCompletion(ctx, &domain.CompletionRequest{
+		Messages:  []domain.Message{{Role: "user", Content: "Reply with OK."}},
+		MaxTokens: 16,
+	})
+	if err != nil {
+		check.Status = sandbox.CheckFail
+		check.Detail = fmt.Sprintf("%s: %s", model, firstLine(err))
+		check.Fix = providerFix(target)
+		return check
+	}
+
+	check.Status = sandbox.CheckOK
+	check.Detail = fmt.Sprintf("%s answered in %.1fs", model, time.Since(start).Seconds())
+	return check
+}
+
+// needsAPIKey reports whether a provider interface authenticates with an
+// API key (rather than a local server, cloud credentials or a program)
+func needsAPIKey(interfaceType config.InterfaceType) bool {
+	switch interfaceType {
+	case config.OpenAICompatible, config.AnthropicNative, config.GeminiNative, config.AzureOpenAI:
+		return true
+	}
+	return false
+}
+
+// missingAPIKey reports whether a key is empty or an unset ${VAR} reference
+func missingAPIKey(key string) bool {
+	key = strings.TrimSpace(key)
+	return key == "" || strings.HasPrefix(key, "${")
+}
+
+func providerFix(target providerTarget) string {
+	switch target.interfaceType {
+	case config.OllamaNative:
+		return fmt.Sprintf("Start Ollama ('ollama serve') and pull the model ('ollama pull %s')", target.config.DefaultModel)
+	case config.AWSBedrock:
+		return "Check the AWS credentials and region, and that the model is enabled in Bedrock"
+	case config.GCPVertexAI:
+		return "Check the Google Cloud credentials and project, and that the model is enabled in Vertex AI"
+	}
+	return fmt.Sprintf("Check the API key, endpoint and default_model for %s", target.name)
+}
+
+// checkServers starts each MCP server, lists its tools and stops it again
+func checkServers(r *report, appConfig *config.ApplicationConfig, timeout time.Duration) {
+	if len(appConfig.Servers) == 0 {
+		r.add(AreaServers, sandbox.Check{Name: "Servers", Status: sandbox.CheckSkip, Detail: "no MCP servers configured"})
+		return
+	}
+
+	names := make([]string, 0, len(appConfig.Servers))
+	for name := range appConfig.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checks := make([]sandbox.Check, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			checks[i] = startServer(name, appConfig.Servers[name], timeout)
+		}(i, name)
+	}
+	wg.Wait()
+
+	for _, check := range checks {
+		r.add(AreaServers, check)
+	}
+}
+
+// startServer connects to a server as chat and workflows would
+func startServer(name string, serverConfig config.ServerConfig, timeout time.Duration) sandbox.Check {
+	check := sandbox.Check{Name: "Server " + name}
+	fix := fmt.Sprintf("Run '%s' by hand to see why it fails, then fix the server %s config", strings.Join(append([]string{serverConfig.Command}, serverConfig.Args...), " "), name)
+
+	if _, err := exec.LookPath(serverConfig.Command); err != nil {
+		check.Status = sandbox.CheckFail
+		check.Detail = fmt.Sprintf("command %q not found", serverConfig.Command)
+		check.Fix = fmt.Sprintf("Install %s or use its full path in the server %s config", serverConfig.Command, name)
+		return check
+	}
+
+	done := make(chan sandbox.Check, 1)
+	start := time.Now()
+	go func() {
+		manager := host.NewServerManagerWithOptions(true)
+		defer manager.CloseConnections()
+
+		conn, err := manager.ConnectToServer(name, serverConfig, false)
+		if err != nil {
+			done <- sandbox.Check{Status: sandbox.CheckFail, Detail: firstLine(err)}
+			return
+		}
+		tools, err := conn.ListTools()
+		if err != nil {
+			done <- sandbox.Check{Status: sandbox.CheckWarn, Detail: "started, but listing tools failed: " + firstLine(err)}
+			return
+		}
+		done <- sandbox.Check{Status: sandbox.CheckOK, Detail: fmt.Sprintf("%s v%s, %s (%.1fs)",
+			conn.ServerInfo.Name, conn.ServerInfo.Version, plural(len(tools), "tool"), time.Since(start).Seconds())}
+	}()
+
+	select {
+	case result := <-done:
+		check.Status, check.Detail = result.Status, result.Detail
+	case <-time.After(timeout):
+		check.Status = sandbox.CheckFail
+		check.Detail = fmt.Sprintf("no response after %s", timeout)
+	}
+	if check.Status != sandbox.CheckOK {
+		check.Fix = fix
+	}
+	return check
+}
+
+// checkSkills discovers the installed skills and returns those with
+// scripts, which need the sandbox
+func checkSkills(r *report, configFile string, appConfig *config.ApplicationConfig) []string {
+	dir, err := infraSkills.ResolveSkillsDirectory(configFile, appConfig)
+	if err != nil {
+		r.add(AreaSkills, sandbox.Check{Name: "Skills", Status: sandbox.CheckWarn, Detail: firstLine(err)})
+		return nil
+	}
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		r.add(AreaSkills, sandbox.Check{Name: "Skills", Status: sandbox.CheckSkip, Detail: "no skills directory at " + dir})
+		return nil
+	}
+
+	service := skillsvc.NewService()
+	if appConfig != nil {
+		service.SetConfig(appConfig)
+	}
+	if err := service.Initialize(dir, skills.ExecutionModePassive); err != nil {
+		r.add(AreaSkills, sandbox.Check{
+			Name:   "Skills",
+			Status: sandbox.CheckFail,
+			Detail: firstLine(err),
+			Fix:    "Check that skills.skills_directory is readable",
+		})
+		return nil
+	}
+
+	var scripted []string
+	names := service.ListSkills()
+	for _, name := range names {
+		if skill, ok := service.GetSkill(name); ok && skill.HasScripts {
+			scripted = append(scripted, name)
+		}
+	}
+	sort.Strings(scripted)
+	r.add(AreaSkills, sandbox.Check{
+		Name:   "Skills",
+		Status: sandbox.CheckOK,
+		Detail: fmt.Sprintf("%s in %s (%d with scripts)", plural(len(names), "skill"), dir, len(scripted)),
+	})
+	return scripted
+}
+
+// addSandbox adds the sandbox doctor's findings. The outputs directory
+// check is reported on its own; engines that aren't installed are left out.
+// Without an engine, skills with scripts only run in passive mode, which
+// fails the check only when such skills are installed.
+func addSandbox(r *report, diagnosis *sandbox.Diagnosis, scripted []string) {
+	for _, check := range diagnosis.Checks {
+		switch {
+		case check.Status == sandbox.CheckSkip:
+			continue
+		case check.Name == "Outputs directory":
+			r.add(AreaOutputs, check)
+			continue
+		case check.Name == "Active mode" && check.Status == sandbox.CheckFail:
+			check.Fix = strings.Replace(check.Fix, "Fix the failures above", "Run 'mcp-cli sandbox doctor' for details", 1)
+			if len(scripted) == 0 {
+				check.Status = sandbox.CheckWarn
+				check.Detail += "; no installed skill has scripts"
+			} else {
+				check.Detail += fmt.Sprintf("; affects %s", strings.Join(scripted, ", "))
+			}
+		}
+		r.add(AreaSandbox, check)
+	}
+}
@@ -0,0 +1,148 @@
+// Package doctor checks the whole mcp-cli setup: configuration, providers,
+// MCP servers, the skills sandbox, skills and the outputs directory. It
+// reports every problem at once with a fix list ordered by what to do first.
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
+)
+
+// Area groups related checks. Fixes for earlier areas come first, since
+// later areas often depend on them (no providers work without a config).
+type Area string
+
+const (
+	AreaConfig    Area = "config"
+	AreaProviders Area = "providers"
+	AreaOutputs   Area = "outputs"
+	AreaServers   Area = "servers"
+	AreaSandbox   Area = "sandbox"
+	AreaSkills    Area = "skills"
+)
+
+// areaOrder is the order areas are reported and fixed in
+var areaOrder = []Area{AreaConfig, AreaProviders, AreaOutputs, AreaServers, AreaSandbox, AreaSkills}
+
+// Check is one finding, in the same shape as the sandbox doctor's
+type Check struct {
+	Area Area `json:"area"`
+	sandbox.Check
+}
+
+// Report is the outcome of Run
+type Report struct {
+	Checks []Check `json:"checks"`
+	Fixes  []Check `json:"fixes"` // Warnings and failures with a fix, most important first
+	OK     bool    `json:"ok"`    // No check failed
+}
+
+// Options selects what Run checks
+type Options struct {
+	ConfigFile    string
+	SkipProviders bool          // Don't send ping completions (they may cost money)
+	SkipServers   bool          // Don't start MCP servers
+	Timeout       time.Duration // Per provider ping and server start (default: 30s)
+}
+
+// DefaultTimeout bounds each provider ping and server start
+const DefaultTimeout = 30 * time.Second
+
+// report collects checks from concurrent probes
+type report struct {
+	mu     sync.Mutex
+	checks []Check
+}
+
+func (r *report) add(area Area, check sandbox.Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, Check{Area: area, Check: check})
+}
+
+// Run checks the setup described by the config file
+func Run(opts Options) *Report {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	r := &report{}
+
+	appConfig := checkConfig(r, opts.ConfigFile)
+
+	var wg sync.WaitGroup
+	if appConfig != nil {
+		if opts.SkipProviders {
+			r.add(AreaProviders, sandbox.Check{Name: "Providers", Status: sandbox.CheckSkip, Detail: "skipped (--skip-providers)"})
+		} else {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				checkProviders(r, appConfig, opts.Timeout)
+			}()
+		}
+		if opts.SkipServers {
+			r.add(AreaServers, sandbox.Check{Name: "Servers", Status: sandbox.CheckSkip, Detail: "skipped (--skip-servers)"})
+		} else {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				checkServers(r, appConfig, opts.Timeout)
+			}()
+		}
+	}
+
+	executorConfig := sandbox.DefaultConfig()
+	if appConfig != nil {
+		executorConfig.OutputsDir = appConfig.Skills.GetOutputsDir()
+	}
+	scripted := checkSkills(r, opts.ConfigFile, appConfig)
+	addSandbox(r, sandbox.Diagnose(executorConfig), scripted)
+	wg.Wait()
+
+	return newReport(r.checks)
+}
+
+// newReport orders checks by area, keeping each area's own order, and
+// builds the fix list
+func newReport(checks []Check) *Report {
+	rank := make(map[Area]int, len(areaOrder))
+	for i, area := range areaOrder {
+		rank[area] = i
+	}
+	sort.SliceStable(checks, func(i, j int) bool {
+		return rank[checks[i].Area] < rank[checks[j].Area]
+	})
+
+	report := &Report{Checks: checks, OK: true}
+	for _, check := range checks {
+		if check.Status == sandbox.CheckFail {
+			report.OK = false
+		}
+		if check.Fix != "" && (check.Status == sandbox.CheckFail || check.Status == sandbox.CheckWarn) {
+			report.Fixes = append(report.Fixes, check)
+		}
+	}
+	// Failures before warnings; area order within each
+	sort.SliceStable(report.Fixes, func(i, j int) bool {
+		return report.Fixes[i].Status == sandbox.CheckFail && report.Fixes[j].Status != sandbox.CheckFail
+	})
+	return report
+}
+
+// firstLine shortens an error to its first line for a check's detail
+func firstLine(err error) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(err.Error()), "\n")
+	return line
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
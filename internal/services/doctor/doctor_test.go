@@ -0,0 +1,96 @@
+package doctor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
+)
+
+func TestFixesPrioritized(t *testing.T) {
+	checks := []Check{
+		{Area: AreaSkills, Check: sandbox.Check{Name: "Skills", Status: sandbox.CheckWarn, Fix: "skills"}},
+		{Area: AreaServers, Check: sandbox.Check{Name: "Server git", Status: sandbox.CheckFail, Fix: "server"}},
+		{Area: AreaProviders, Check: sandbox.Check{Name: "Provider openai", Status: sandbox.CheckWarn, Fix: "provider"}},
+		{Area: AreaConfig, Check: sandbox.Check{Name: "Workflow a", Status: sandbox.CheckFail, Fix: "workflow"}},
+		{Area: AreaConfig, Check: sandbox.Check{Name: "Config file", Status: sandbox.CheckOK}},
+	}
+	report := newReport(checks)
+
+	if report.OK {
+		t.Error("report with failures is OK")
+	}
+	if report.Checks[0].Name != "Workflow a" || report.Checks[1].Name != "Config file" {
+		t.Errorf("checks not grouped by area in order: %+v", report.Checks)
+	}
+	var fixes []string
+	for _, fix := range report.Fixes {
+		fixes = append(fixes, fix.Fix)
+	}
+	want := []string{"workflow", "server", "provider", "skills"}
+	if len(fixes) != len(want) {
+		t.Fatalf("fixes = %v, want %v", fixes, want)
+	}
+	for i := range want {
+		if fixes[i] != want[i] {
+			t.Fatalf("fixes = %v, want %v", fixes, want)
+		}
+	}
+}
+
+func TestAddSandbox(t *testing.T) {
+	diagnosis := &sandbox.Diagnosis{Checks: []sandbox.Check{
+		{Name: "docker CLI", Status: sandbox.CheckSkip},
+		{Name: "Outputs directory", Status: sandbox.CheckOK},
+		{Name: "Active mode", Status: sandbox.CheckFail, Detail: "no engine", Fix: "install one"},
+	}}
+
+	r := &report{}
+	addSandbox(r, diagnosis, nil)
+	if len(r.checks) != 2 || r.checks[0].Area != AreaOutputs {
+		t.Fatalf("checks = %+v, want outputs and active mode without skipped engines", r.checks)
+	}
+	if r.checks[1].Status != sandbox.CheckWarn {
+		t.Errorf("no engine without skills that need one should only warn: %+v", r.checks[1])
+	}
+
+	r = &report{}
+	addSandbox(r, diagnosis, []string{"docx"})
+	if r.checks[1].Status != sandbox.CheckFail {
+		t.Errorf("no engine with skills that need one should fail: %+v", r.checks[1])
+	}
+}
+
+func TestMissingConfig(t *testing.T) {
+	r := &report{}
+	if checkConfig(r, filepath.Join(t.TempDir(), "config.yaml")) != nil {
+		t.Fatal("checkConfig returned a config for a missing file")
+	}
+	if len(r.checks) != 1 || r.checks[0].Status != sandbox.CheckFail || r.checks[0].Fix == "" {
+		t.Errorf("checks = %+v, want one failure with a fix", r.checks)
+	}
+}
+
+func TestMissingAPIKey(t *testing.T) {
+	appConfig := &config.ApplicationConfig{AI: &config.AIConfig{
+		DefaultProvider: "anthropic",
+		Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+			config.AnthropicNative:  {Providers: map[string]config.ProviderConfig{"anthropic": {APIKey: "${ANTHROPIC_API_KEY}"}}},
+			config.OpenAICompatible: {Providers: map[string]config.ProviderConfig{"openai": {}}},
+		},
+	}}
+
+	r := &report{}
+	checkProviders(r, appConfig, time.Second)
+	if len(r.checks) != 2 {
+		t.Fatalf("checks = %+v", r.checks)
+	}
+	if r.checks[0].Name != "Provider anthropic" || r.checks[0].Status != sandbox.CheckFail {
+		t.Errorf("default provider without a key should fail: %+v", r.checks[0])
+	}
+	if r.checks[1].Status != sandbox.CheckWarn || r.checks[1].Fix == "" {
+		t.Errorf("other provider without a key should warn with a fix: %+v", r.checks[1])
+	}
+}
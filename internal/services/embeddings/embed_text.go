@@ -0,0 +1,36 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+// EmbedText returns a single vector for text, averaging the embeddings of
+// its chunks when it is too long for one
+func EmbedText(ctx context.Context, service domain.EmbeddingService, provider, model, text string) ([]float32, error) {
+	job, err := service.GenerateEmbeddings(ctx, &domain.EmbeddingJobRequest{
+		Input:         text,
+		Provider:      provider,
+		Model:         model,
+		ChunkStrategy: domain.ChunkingFixed,
+		MaxChunkSize:  8000,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(job.Embeddings) == 0 {
+		return nil, fmt.Errorf("no embeddings generated")
+	}
+
+	mean := make([]float32, len(job.Embeddings[0].Vector))
+	for _, embedding := range job.Embeddings {
+		for i := range mean {
+			if i < len(embedding.Vector) {
+				mean[i] += embedding.Vector[i] / float32(len(job.Embeddings))
+			}
+		}
+	}
+	return mean, nil
+}
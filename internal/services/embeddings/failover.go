@@ -0,0 +1,90 @@
+package embeddings
+
+import (
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// embedAttempt generates a request's embeddings with one provider of its chain
+type embedAttempt func(target config.ProviderFallback) (*domain.EmbeddingJob, error)
+
+// failoverChain returns the providers a request tries: the primary, then the
+// request's fallbacks or, without any, the configured fallback chain.
+// Entries repeating an earlier one are dropped.
+func failoverChain(primary config.ProviderFallback, fallbacks, configured []config.ProviderFallback) []config.ProviderFallback {
+	if len(fallbacks) == 0 {
+		fallbacks = configured
+	}
+	chain := []config.ProviderFallback{primary}
+	for _, fallback := range fallbacks {
+		duplicate := false
+		for _, existing := range chain {
+			if existing == fallback {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			chain = append(chain, fallback)
+		}
+	}
+	return chain
+}
+
+// runFailover tries the chain in order until a provider succeeds. Vectors
+// from a fallback have to fit the same store as the primary's: fallbacks
+// whose configured dimensions differ from dimensions are skipped, and a
+// fallback result with vectors of another size counts as a failure.
+// dimensions of 0 (unknown) skips the checks. dimensionsOf returns a
+// target's configured dimensions, or 0 when they aren't known.
+func runFailover(chain []config.ProviderFallback, dimensions int, dimensionsOf func(config.ProviderFallback) int, attempt embedAttempt) (*domain.EmbeddingJob, error) {
+	var lastErr error
+	for i, target := range chain {
+		if i > 0 && dimensions > 0 {
+			if dims := dimensionsOf(target); dims > 0 && dims != dimensions {
+				logging.Warn("Skipping embedding fallback %s: its %d-dimension vectors don't fit the %d dimensions expected", targetLabel(target), dims, dimensions)
+				continue
+			}
+		}
+
+		job, err := attempt(target)
+		if err == nil && i > 0 && dimensions > 0 && len(job.Embeddings) > 0 && len(job.Embeddings[0].Vector) != dimensions {
+			err = fmt.Errorf("returned %d-dimension vectors, expected %d", len(job.Embeddings[0].Vector), dimensions)
+		}
+		if err != nil {
+			if len(chain) > 1 {
+				logging.Warn("Embedding provider %s failed: %v", targetLabel(target), err)
+			}
+			lastErr = err
+			continue
+		}
+
+		if i > 0 {
+			logging.Warn("Embedded with fallback %s instead of %s. Vectors from different models aren't comparable, even with the same dimensions: don't mix them in one store", targetLabel(target), targetLabel(chain[0]))
+			if job.Metadata == nil {
+				job.Metadata = make(map[string]interface{})
+			}
+			job.Metadata["failover_from"] = targetLabel(chain[0])
+		}
+		return job, nil
+	}
+
+	if len(chain) == 1 {
+		return nil, lastErr
+	}
+	if lastErr == nil {
+		return nil, fmt.Errorf("no embedding fallback produces %d-dimension vectors", dimensions)
+	}
+	return nil, fmt.Errorf("all %d embedding providers failed, last error: %w", len(chain), lastErr)
+}
+
+// targetLabel names a provider and model as provider/model
+func targetLabel(target config.ProviderFallback) string {
+	if target.Model == "" {
+		return target.Provider
+	}
+	return target.Provider + "/" + target.Model
+}
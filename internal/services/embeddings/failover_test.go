@@ -0,0 +1,94 @@
+package embeddings
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestFailoverChain(t *testing.T) {
+	primary := config.ProviderFallback{Provider: "openai", Model: "small"}
+	configured := []config.ProviderFallback{
+		{Provider: "openai", Model: "small"},
+		{Provider: "azure", Model: "small"},
+	}
+
+	chain := failoverChain(primary, nil, configured)
+	if len(chain) != 2 || chain[1].Provider != "azure" {
+		t.Errorf("configured chain: got %v, want the primary then azure", chain)
+	}
+
+	explicit := []config.ProviderFallback{{Provider: "ollama", Model: "nomic"}}
+	chain = failoverChain(primary, explicit, configured)
+	if len(chain) != 2 || chain[1].Provider != "ollama" {
+		t.Errorf("request fallbacks should replace the configured chain, got %v", chain)
+	}
+}
+
+// jobWith returns a job with one vector of the given size
+func jobWith(target config.ProviderFallback, dimensions int) *domain.EmbeddingJob {
+	return &domain.EmbeddingJob{
+		Provider:   target.Provider,
+		Model:      target.Model,
+		Embeddings: []domain.EmbeddingWithMeta{{Vector: make([]float32, dimensions)}},
+	}
+}
+
+func TestRunFailover(t *testing.T) {
+	chain := []config.ProviderFallback{
+		{Provider: "openai", Model: "small"},
+		{Provider: "local", Model: "mini"},  // 384 dimensions: skipped
+		{Provider: "azure", Model: "large"}, // dimensions unknown: checked after
+		{Provider: "azure", Model: "small"},
+	}
+	configured := map[string]int{"small": 1536, "mini": 384}
+	dimensionsOf := func(target config.ProviderFallback) int { return configured[target.Model] }
+
+	var tried []string
+	job, err := runFailover(chain, 1536, dimensionsOf, func(target config.ProviderFallback) (*domain.EmbeddingJob, error) {
+		tried = append(tried, targetLabel(target))
+		switch target.Model {
+		case "small":
+			if target.Provider == "openai" {
+				return nil, errors.New("429 rate limited")
+			}
+			return jobWith(target, 1536), nil
+		case "large":
+			return jobWith(target, 3072), nil
+		}
+		return jobWith(target, 384), nil
+	})
+	if err != nil {
+		t.Fatalf("runFailover: %v", err)
+	}
+	if got := strings.Join(tried, ","); got != "openai/small,azure/large,azure/small" {
+		t.Errorf("tried %s", got)
+	}
+	if job.Provider != "azure" || job.Model != "small" {
+		t.Errorf("got job from %s/%s, want azure/small", job.Provider, job.Model)
+	}
+	if job.Metadata["failover_from"] != "openai/small" {
+		t.Errorf("failover_from = %v, want openai/small", job.Metadata["failover_from"])
+	}
+}
+
+func TestRunFailoverAllFail(t *testing.T) {
+	chain := []config.ProviderFallback{{Provider: "a", Model: "m"}, {Provider: "b", Model: "m"}}
+	_, err := runFailover(chain, 0, func(config.ProviderFallback) int { return 0 }, func(target config.ProviderFallback) (*domain.EmbeddingJob, error) {
+		return nil, errors.New(target.Provider + " down")
+	})
+	if err == nil || !strings.Contains(err.Error(), "all 2 embedding providers failed") || !strings.Contains(err.Error(), "b down") {
+		t.Errorf("got %v, want all providers failed with the last error", err)
+	}
+
+	// A single provider's error is returned as it is
+	_, err = runFailover(chain[:1], 0, func(config.ProviderFallback) int { return 0 }, func(target config.ProviderFallback) (*domain.EmbeddingJob, error) {
+		return nil, errors.New("a down")
+	})
+	if err == nil || err.Error() != "a down" {
+		t.Errorf("got %v, want a down", err)
+	}
+}
@@ -57,6 +57,22 @@ func (s *Service) GenerateEmbeddings(ctx context.Context, req *domain.EmbeddingJ
 		providerName = defaultProviderName
 	}
 
+	// Fail over along the chain when the provider is rate-limited or down
+	primary := config.ProviderFallback{Provider: providerName, Model: req.Model}
+	chain := failoverChain(primary, req.Fallbacks, s.configService.GetEmbeddingFallbackChain())
+	dimensions := req.Dimensions
+	if dimensions == 0 && len(chain) > 1 {
+		dimensions = s.modelDimensions(primary)
+	}
+	return runFailover(chain, dimensions, s.modelDimensions, func(target config.ProviderFallback) (*domain.EmbeddingJob, error) {
+		return s.generateWith(ctx, req, target)
+	})
+}
+
+// generateWith generates a request's embeddings with one provider and model
+func (s *Service) generateWith(ctx context.Context, req *domain.EmbeddingJobRequest, target config.ProviderFallback) (*domain.EmbeddingJob, error) {
+	providerName := target.Provider
+
 	// Try to get embedding-specific configuration first
 	embeddingConfig, interfaceType, err := s.configService.GetEmbeddingProviderConfig(providerName)
 	var providerConfig *config.ProviderConfig
@@ -69,9 +85,9 @@ func (s *Service) GenerateEmbeddings(ctx context.Context, req *domain.EmbeddingJ
 			return nil, fmt.Errorf("failed to get provider config for %s: %w", providerName, err)
 		}
 	} else {
-		// For cloud providers (AWS Bedrock, GCP Vertex AI, Azure), get the full provider config instead
-		// because EmbeddingProviderConfig doesn't have cloud-specific auth fields
-		if interfaceType == config.AWSBedrock || interfaceType == config.GCPVertexAI || interfaceType == config.AzureOpenAI {
+		// For cloud providers (AWS Bedrock, GCP Vertex AI, Azure) and external programs, get the full
+		// provider config instead because EmbeddingProviderConfig doesn't have their auth or command fields
+		if interfaceType == config.AWSBedrock || interfaceType == config.GCPVertexAI || interfaceType == config.AzureOpenAI || interfaceType == config.External {
 			logging.Debug("Cloud provider %s detected, getting full provider config", providerName)
 			providerConfig, interfaceType, err = s.configService.GetProviderConfig(providerName)
 			if err != nil {
@@ -87,6 +103,7 @@ func (s *Service) GenerateEmbeddings(ctx context.Context, req *domain.EmbeddingJ
 				TimeoutSeconds:        embeddingConfig.TimeoutSeconds,
 				MaxRetries:            embeddingConfig.MaxRetries,
 				EmbeddingModels:       embeddingConfig.Models,
+				MonthlyBudget:         embeddingConfig.MonthlyBudget,
 			}
 
 			logging.Debug("Using embedding-specific configuration for %s", providerName)
@@ -105,7 +122,7 @@ func (s *Service) GenerateEmbeddings(ctx context.Context, req *domain.EmbeddingJ
 	defer provider.Close()
 
 	// Determine embedding model
-	embeddingModel := req.Model
+	embeddingModel := target.Model
 	if embeddingModel == "" {
 		if providerConfig.DefaultEmbeddingModel != "" {
 			embeddingModel = providerConfig.DefaultEmbeddingModel
@@ -145,6 +162,11 @@ func (s *Service) GenerateEmbeddings(ctx context.Context, req *domain.EmbeddingJ
 		return nil, fmt.Errorf("failed to get chunking strategy: %w", err)
 	}
 
+	// Code chunking uses the source file path for language detection and metadata
+	if codeChunker, ok := chunkingStrategy.(*chunking.CodeChunker); ok {
+		codeChunker.SetFilePath(req.FilePath)
+	}
+
 	logging.Info("Using chunking strategy: %s with overlap: %d", chunkingStrategy.GetName(), req.ChunkOverlap)
 
 	// Chunk the input text
@@ -191,6 +213,11 @@ func (s *Service) GenerateEmbeddings(ctx context.Context, req *domain.EmbeddingJ
 				},
 			}
 
+			// Add chunk-level metadata from the chunking strategy
+			for key, value := range chunks[i].Metadata {
+				embeddingMeta.Metadata[key] = value
+			}
+
 			// Add any custom metadata
 			if req.Metadata != nil {
 				for key, value := range req.Metadata {
@@ -241,6 +268,29 @@ func (s *Service) GenerateEmbeddings(ctx context.Context, req *domain.EmbeddingJ
 	return job, nil
 }
 
+// modelDimensions returns the configured dimensions of a provider's model
+// (its default embedding model when none is given), or 0 when unknown
+func (s *Service) modelDimensions(target config.ProviderFallback) int {
+	model := target.Model
+	if embeddingConfig, _, err := s.configService.GetEmbeddingProviderConfig(target.Provider); err == nil {
+		if model == "" {
+			model = embeddingConfig.DefaultModel
+		}
+		if modelConfig, ok := embeddingConfig.Models[model]; ok && modelConfig.Dimensions > 0 {
+			return modelConfig.Dimensions
+		}
+	}
+	if providerConfig, _, err := s.configService.GetProviderConfig(target.Provider); err == nil {
+		if model == "" {
+			model = providerConfig.DefaultEmbeddingModel
+		}
+		if modelConfig, ok := providerConfig.EmbeddingModels[model]; ok {
+			return modelConfig.Dimensions
+		}
+	}
+	return 0
+}
+
 // GetAvailableChunkingStrategies returns available chunking strategies
 func (s *Service) GetAvailableChunkingStrategies() []domain.ChunkingType {
 	return s.chunkingManager.GetAvailableStrategies()
@@ -0,0 +1,109 @@
+package eval
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Case is one dataset line: an input for the workflow and the output it
+// should produce
+type Case struct {
+	ID       string                 `json:"id,omitempty"`
+	Input    interface{}            `json:"input"`              // String, or any JSON value passed to the workflow as JSON
+	Expected string                 `json:"expected,omitempty"` // Expected output, regex pattern, or judge reference answer
+	Params   map[string]interface{} `json:"params,omitempty"`   // Workflow params for this case ({{params.name}})
+	Scorer   string                 `json:"scorer,omitempty"`   // Overrides the run's scorer for this case
+	Criteria string                 `json:"criteria,omitempty"` // What the judge scorer should check
+}
+
+// InputText returns the case input as workflow input data; non-string
+// inputs become JSON
+func (c *Case) InputText() string {
+	switch v := c.Input.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// ParamValues returns the case params as workflow param values
+func (c *Case) ParamValues() map[string]string {
+	values := make(map[string]string, len(c.Params))
+	for name, value := range c.Params {
+		switch v := value.(type) {
+		case string:
+			values[name] = v
+		case []interface{}, map[string]interface{}:
+			data, _ := json.Marshal(v)
+			values[name] = string(data)
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+	return values
+}
+
+// Dataset is a list of evaluation cases loaded from a JSONL file
+type Dataset struct {
+	Name  string
+	Cases []Case
+}
+
+// LoadDataset reads a JSONL dataset, one case per line. Blank lines are
+// skipped; cases without an id are named after their line number.
+func LoadDataset(path string) (*Dataset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	defer f.Close()
+
+	dataset := &Dataset{Name: path}
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var c Case
+		if err := json.Unmarshal([]byte(text), &c); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid JSON: %w", path, line, err)
+		}
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("line-%d", line)
+		}
+		if seen[c.ID] {
+			return nil, fmt.Errorf("%s:%d: duplicate case id '%s'", path, line, c.ID)
+		}
+		seen[c.ID] = true
+		if c.Scorer != "" && !IsScorer(c.Scorer) {
+			return nil, fmt.Errorf("%s:%d: unknown scorer '%s' (valid: %s)", path, line, c.Scorer, strings.Join(Scorers(), ", "))
+		}
+
+		dataset.Cases = append(dataset.Cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+
+	if len(dataset.Cases) == 0 {
+		return nil, fmt.Errorf("dataset %s has no cases", path)
+	}
+	return dataset, nil
+}
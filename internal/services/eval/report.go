@@ -0,0 +1,72 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteJSON writes the report as indented JSON
+func WriteJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteSummary writes the aggregate metrics, a per-scorer table and the
+// failing cases with expected and actual output
+func WriteSummary(w io.Writer, report *Report) error {
+	fmt.Fprintf(w, "Workflow: %s\nDataset:  %s (%d cases)\n\n", report.Workflow, report.Dataset, report.Total)
+	fmt.Fprintf(w, "Passed:   %d/%d (%.1f%%)\n", report.Passed, report.Total, report.PassRate*100)
+	fmt.Fprintf(w, "Failed:   %d\n", report.Failed)
+	fmt.Fprintf(w, "Errors:   %d\n", report.Errors)
+	fmt.Fprintf(w, "Mean score: %.3f\n", report.MeanScore)
+	fmt.Fprintf(w, "Latency p50/p95: %s / %s\n\n", formatMs(report.LatencyP50), formatMs(report.LatencyP95))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCORER\tCASES\tPASSED\tMEAN SCORE")
+	for _, stat := range report.Scorers {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.3f\n", stat.Scorer, stat.Cases, stat.Passed, stat.MeanScore)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, result := range report.Cases {
+		if result.Passed {
+			continue
+		}
+		if result.Error != "" {
+			fmt.Fprintf(w, "\nERROR %s: %s\n", result.ID, result.Error)
+			continue
+		}
+		fmt.Fprintf(w, "\nFAIL %s [%s, score %.2f]\n", result.ID, result.Scorer, result.Score)
+		if result.Reason != "" {
+			fmt.Fprintf(w, "  reason:   %s\n", result.Reason)
+		}
+		fmt.Fprintf(w, "  expected: %s\n", oneLine(result.Expected, 200))
+		fmt.Fprintf(w, "  got:      %s\n", oneLine(result.Output, 200))
+	}
+	return nil
+}
+
+func formatMs(ms float64) string {
+	if ms == 0 {
+		return "-"
+	}
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.0fms", ms)
+}
+
+// oneLine collapses whitespace and truncates text for the failure list
+func oneLine(s string, n int) string {
+	return truncate(normalizeSpace(s), n)
+}
+
+// String summarizes the outcome in one line
+func (r *Report) String() string {
+	return fmt.Sprintf("%d/%d passed (%.1f%%), %d failed, %d errors", r.Passed, r.Total, r.PassRate*100, r.Failed, r.Errors)
+}
@@ -0,0 +1,213 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RunFunc executes the workflow under test for one case and returns its output
+type RunFunc func(ctx context.Context, c *Case) (string, error)
+
+// Options controls an evaluation run
+type Options struct {
+	Workflow    string        // Name recorded in the report
+	Scorer      string        // Default scorer for cases without one (default: exact)
+	Threshold   float64       // Passing score for similarity and judge (default: DefaultThreshold)
+	Concurrency int           // Cases run in parallel (default: 1)
+	Timeout     time.Duration // Per-case workflow timeout (default: 5m)
+	Embed       EmbedFunc     // Required by the similarity scorer
+	Judge       JudgeFunc     // Required by the judge scorer
+	Progress    func(done, total int, c *Case)
+}
+
+// Report is the outcome of an evaluation run
+type Report struct {
+	Dataset    string       `json:"dataset"`
+	Workflow   string       `json:"workflow"`
+	Started    time.Time    `json:"started"`
+	Duration   float64      `json:"duration_seconds"`
+	Total      int          `json:"total"`
+	Passed     int          `json:"passed"`
+	Failed     int          `json:"failed"` // Scored below passing, errors excluded
+	Errors     int          `json:"errors"` // Workflow or scorer errors
+	PassRate   float64      `json:"pass_rate"`
+	MeanScore  float64      `json:"mean_score"`
+	LatencyP50 float64      `json:"latency_p50_ms"`
+	LatencyP95 float64      `json:"latency_p95_ms"`
+	Scorers    []ScorerStat `json:"scorers"`
+	Cases      []CaseResult `json:"cases"`
+}
+
+// ScorerStat aggregates the cases graded by one scorer
+type ScorerStat struct {
+	Scorer    string  `json:"scorer"`
+	Cases     int     `json:"cases"`
+	Passed    int     `json:"passed"`
+	MeanScore float64 `json:"mean_score"`
+}
+
+// CaseResult is the graded output of one case
+type CaseResult struct {
+	ID        string  `json:"id"`
+	Scorer    string  `json:"scorer"`
+	Score     float64 `json:"score"`
+	Passed    bool    `json:"passed"`
+	Reason    string  `json:"reason,omitempty"`
+	Expected  string  `json:"expected,omitempty"`
+	Output    string  `json:"output,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+// Run executes every case and grades the outputs. Results keep dataset order.
+func Run(ctx context.Context, dataset *Dataset, run RunFunc, opts Options) *Report {
+	if opts.Scorer == "" {
+		opts.Scorer = ScorerExact
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultThreshold
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+
+	report := &Report{Dataset: dataset.Name, Workflow: opts.Workflow, Started: time.Now()}
+	results := make([]CaseResult, len(dataset.Cases))
+
+	var (
+		mu   sync.Mutex
+		done int
+		wg   sync.WaitGroup
+	)
+	sem := make(chan struct{}, opts.Concurrency)
+	for i := range dataset.Cases {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			c := &dataset.Cases[i]
+			results[i] = runCase(ctx, c, run, &opts)
+
+			if opts.Progress != nil {
+				mu.Lock()
+				done++
+				opts.Progress(done, len(dataset.Cases), c)
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	report.Cases = results
+	summarize(report)
+	report.Duration = time.Since(report.Started).Seconds()
+	return report
+}
+
+// runCase executes and grades a single case
+func runCase(ctx context.Context, c *Case, run RunFunc, opts *Options) CaseResult {
+	scorer := c.Scorer
+	if scorer == "" {
+		scorer = opts.Scorer
+	}
+	result := CaseResult{ID: c.ID, Scorer: scorer, Expected: c.Expected}
+
+	if ctx.Err() != nil {
+		result.Error = "skipped: evaluation canceled"
+		return result
+	}
+
+	caseCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	output, err := run(caseCtx, c)
+	result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Output = output
+
+	graded, err := score(ctx, scorer, c, output, opts)
+	if err != nil {
+		result.Error = fmt.Sprintf("%s scorer: %v", scorer, err)
+		return result
+	}
+	result.Score = graded.Value
+	result.Passed = graded.Passed
+	result.Reason = graded.Reason
+	return result
+}
+
+// summarize computes the aggregate metrics from the case results
+func summarize(report *Report) {
+	report.Total = len(report.Cases)
+
+	stats := make(map[string]*ScorerStat)
+	var latencies []float64
+	var scoreSum float64
+	for _, result := range report.Cases {
+		switch {
+		case result.Error != "":
+			report.Errors++
+		case result.Passed:
+			report.Passed++
+		default:
+			report.Failed++
+		}
+		scoreSum += result.Score
+		if result.LatencyMs > 0 {
+			latencies = append(latencies, result.LatencyMs)
+		}
+
+		stat, ok := stats[result.Scorer]
+		if !ok {
+			stat = &ScorerStat{Scorer: result.Scorer}
+			stats[result.Scorer] = stat
+		}
+		stat.Cases++
+		stat.MeanScore += result.Score
+		if result.Passed {
+			stat.Passed++
+		}
+	}
+
+	if report.Total > 0 {
+		report.PassRate = float64(report.Passed) / float64(report.Total)
+		report.MeanScore = scoreSum / float64(report.Total)
+	}
+
+	sort.Float64s(latencies)
+	report.LatencyP50 = percentile(latencies, 0.50)
+	report.LatencyP95 = percentile(latencies, 0.95)
+
+	for _, stat := range stats {
+		stat.MeanScore /= float64(stat.Cases)
+		report.Scorers = append(report.Scorers, *stat)
+	}
+	sort.Slice(report.Scorers, func(i, j int) bool { return report.Scorers[i].Scorer < report.Scorers[j].Scorer })
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted))*p+0.5) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,159 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeDataset(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cases.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDataset(t *testing.T) {
+	path := writeDataset(t,
+		`{"id": "refund", "input": "I want my money back", "expected": "billing"}`,
+		``,
+		`{"input": {"ticket": "site down"}, "expected": "^outage", "scorer": "regex", "params": {"tone": "formal", "limit": 3}}`,
+	)
+
+	dataset, err := LoadDataset(path)
+	if err != nil {
+		t.Fatalf("LoadDataset() error = %v", err)
+	}
+	if len(dataset.Cases) != 2 {
+		t.Fatalf("LoadDataset() cases = %d, want 2", len(dataset.Cases))
+	}
+
+	second := dataset.Cases[1]
+	if second.ID != "line-3" {
+		t.Errorf("ID = %s, want line-3", second.ID)
+	}
+	if got := second.InputText(); got != `{"ticket":"site down"}` {
+		t.Errorf("InputText() = %s", got)
+	}
+	if params := second.ParamValues(); params["tone"] != "formal" || params["limit"] != "3" {
+		t.Errorf("ParamValues() = %v", params)
+	}
+
+	for _, bad := range []string{
+		`{"id": "a"}` + "\n" + `{"id": "a"}`,
+		`{"id": "a", "scorer": "fuzzy"}`,
+		`not json`,
+		``,
+	} {
+		if _, err := LoadDataset(writeDataset(t, bad)); err == nil {
+			t.Errorf("LoadDataset(%q) expected error", bad)
+		}
+	}
+}
+
+func TestRun_ScoresAndSummarizes(t *testing.T) {
+	dataset := &Dataset{Name: "test", Cases: []Case{
+		{ID: "exact", Input: "a", Expected: "hello  world"},
+		{ID: "contains", Input: "b", Expected: "BILLING", Scorer: ScorerContains},
+		{ID: "regex", Input: "c", Expected: `^\d+$`, Scorer: ScorerRegex},
+		{ID: "judge", Input: "d", Expected: "Paris", Scorer: ScorerJudge},
+		{ID: "similar", Input: "e", Expected: "cat", Scorer: ScorerSimilarity},
+		{ID: "broken", Input: "f", Expected: "x"},
+	}}
+
+	outputs := map[string]string{
+		"a": "hello world\n",
+		"b": "Routed to billing team",
+		"c": "12a",
+		"d": "The capital is Paris",
+		"e": "kitten",
+	}
+	run := func(ctx context.Context, c *Case) (string, error) {
+		output, ok := outputs[c.InputText()]
+		if !ok {
+			return "", fmt.Errorf("workflow failed")
+		}
+		return output, nil
+	}
+
+	report := Run(context.Background(), dataset, run, Options{
+		Workflow:    "triage",
+		Concurrency: 3,
+		Judge: func(ctx context.Context, prompt string) (string, error) {
+			if !strings.Contains(prompt, "REFERENCE ANSWER:\nParis") {
+				return "", fmt.Errorf("unexpected prompt: %s", prompt)
+			}
+			return `Sure: {"score": 9, "reason": "correct city"}`, nil
+		},
+		Embed: func(ctx context.Context, text string) ([]float32, error) {
+			if text == "cat" {
+				return []float32{1, 0}, nil
+			}
+			return []float32{1, 1}, nil
+		},
+	})
+
+	want := map[string]bool{"exact": true, "contains": true, "regex": false, "judge": true, "similar": false, "broken": false}
+	for i, result := range report.Cases {
+		if result.ID != dataset.Cases[i].ID {
+			t.Fatalf("case %d = %s, want dataset order", i, result.ID)
+		}
+		if result.Passed != want[result.ID] {
+			t.Errorf("%s passed = %v, want %v (score %.2f, error %q)", result.ID, result.Passed, want[result.ID], result.Score, result.Error)
+		}
+	}
+
+	judged := report.Cases[3]
+	if judged.Score != 0.9 || judged.Reason != "correct city" {
+		t.Errorf("judge score = %.2f %q, want 0.9 'correct city'", judged.Score, judged.Reason)
+	}
+
+	if report.Passed != 3 || report.Failed != 2 || report.Errors != 1 {
+		t.Errorf("passed/failed/errors = %d/%d/%d, want 3/2/1", report.Passed, report.Failed, report.Errors)
+	}
+	if report.PassRate != 0.5 {
+		t.Errorf("PassRate = %.2f, want 0.5", report.PassRate)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSummary(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	summary := buf.String()
+	for _, want := range []string{"Passed:   3/6", "FAIL regex [regex, score 0.00]", "got:      12a", "ERROR broken: workflow failed"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestParseJudgeScore(t *testing.T) {
+	tests := []struct {
+		answer  string
+		want    float64
+		wantErr bool
+	}{
+		{answer: `{"score": 0.75, "reason": "ok"}`, want: 0.75},
+		{answer: `{"score": "8", "reason": "ok"}`, want: 0.8},
+		{answer: `{"score": 85}`, want: 0.85},
+		{answer: `no verdict`, wantErr: true},
+		{answer: `{"score": "high"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, _, err := parseJudgeScore(tt.answer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseJudgeScore(%s) error = %v, wantErr %v", tt.answer, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseJudgeScore(%s) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
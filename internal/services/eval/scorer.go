@@ -0,0 +1,178 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/vector"
+)
+
+// Scorer names
+const (
+	ScorerExact      = "exact"      // Output equals expected (whitespace-normalized)
+	ScorerContains   = "contains"   // Output contains expected (case-insensitive)
+	ScorerRegex      = "regex"      // Output matches the expected pattern
+	ScorerSimilarity = "similarity" // Embedding cosine similarity to expected
+	ScorerJudge      = "judge"      // A judge model grades the output
+)
+
+// DefaultThreshold is the passing score for the similarity and judge scorers
+const DefaultThreshold = 0.8
+
+// Scorers lists the scorer names
+func Scorers() []string {
+	return []string{ScorerExact, ScorerContains, ScorerRegex, ScorerSimilarity, ScorerJudge}
+}
+
+// IsScorer reports whether name is a known scorer
+func IsScorer(name string) bool {
+	for _, scorer := range Scorers() {
+		if name == scorer {
+			return true
+		}
+	}
+	return false
+}
+
+// EmbedFunc returns an embedding vector for text
+type EmbedFunc func(ctx context.Context, text string) ([]float32, error)
+
+// JudgeFunc sends a grading prompt to a judge model and returns its answer
+type JudgeFunc func(ctx context.Context, prompt string) (string, error)
+
+// Score is the grade of one output
+type Score struct {
+	Value  float64 // 0.0-1.0
+	Passed bool
+	Reason string
+}
+
+// score grades an output with the named scorer
+func score(ctx context.Context, scorer string, c *Case, output string, opts *Options) (Score, error) {
+	switch scorer {
+	case ScorerExact:
+		return binaryScore(normalizeSpace(output) == normalizeSpace(c.Expected)), nil
+
+	case ScorerContains:
+		return binaryScore(strings.Contains(strings.ToLower(output), strings.ToLower(c.Expected))), nil
+
+	case ScorerRegex:
+		re, err := regexp.Compile(c.Expected)
+		if err != nil {
+			return Score{}, fmt.Errorf("invalid expected pattern: %w", err)
+		}
+		return binaryScore(re.MatchString(output)), nil
+
+	case ScorerSimilarity:
+		if opts.Embed == nil {
+			return Score{}, fmt.Errorf("similarity scorer needs an embedding provider")
+		}
+		expected, err := opts.Embed(ctx, c.Expected)
+		if err != nil {
+			return Score{}, fmt.Errorf("failed to embed expected output: %w", err)
+		}
+		actual, err := opts.Embed(ctx, output)
+		if err != nil {
+			return Score{}, fmt.Errorf("failed to embed output: %w", err)
+		}
+		similarity := vector.CosineSimilarity(expected, actual)
+		return Score{
+			Value:  similarity,
+			Passed: similarity >= opts.Threshold,
+			Reason: fmt.Sprintf("similarity %.3f (threshold %.2f)", similarity, opts.Threshold),
+		}, nil
+
+	case ScorerJudge:
+		if opts.Judge == nil {
+			return Score{}, fmt.Errorf("judge scorer needs a judge model")
+		}
+		answer, err := opts.Judge(ctx, judgePrompt(c, output))
+		if err != nil {
+			return Score{}, err
+		}
+		value, reason, err := parseJudgeScore(answer)
+		if err != nil {
+			return Score{}, err
+		}
+		return Score{Value: value, Passed: value >= opts.Threshold, Reason: reason}, nil
+	}
+	return Score{}, fmt.Errorf("unknown scorer '%s'", scorer)
+}
+
+func binaryScore(passed bool) Score {
+	if passed {
+		return Score{Value: 1, Passed: true}
+	}
+	return Score{Value: 0, Passed: false}
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// judgePrompt asks the judge to grade an output against the case
+func judgePrompt(c *Case, output string) string {
+	criteria := c.Criteria
+	if criteria == "" {
+		criteria = "whether the output is correct and complete compared to the reference answer"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("You are grading the output of an AI workflow.\n\n")
+	sb.WriteString("INPUT:\n")
+	sb.WriteString(c.InputText())
+	sb.WriteString("\n\n")
+	if c.Expected != "" {
+		sb.WriteString("REFERENCE ANSWER:\n")
+		sb.WriteString(c.Expected)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("OUTPUT:\n")
+	sb.WriteString(output)
+	sb.WriteString("\n\n")
+	fmt.Fprintf(&sb, "Grade the output on %s, from 0.0 (wrong) to 1.0 (fully correct).\n", criteria)
+	sb.WriteString(`Respond with only JSON: {"score": 0.0-1.0, "reason": "one sentence"}`)
+	return sb.String()
+}
+
+// parseJudgeScore extracts the judge's JSON verdict. Scores given out of 10
+// or 100 are scaled to 0-1.
+func parseJudgeScore(answer string) (float64, string, error) {
+	start := strings.Index(answer, "{")
+	end := strings.LastIndex(answer, "}")
+	if start < 0 || end <= start {
+		return 0, "", fmt.Errorf("judge did not return JSON: %s", truncate(answer, 200))
+	}
+
+	var verdict struct {
+		Score  json.RawMessage `json:"score"`
+		Reason string          `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(answer[start:end+1]), &verdict); err != nil {
+		return 0, "", fmt.Errorf("invalid judge JSON: %w", err)
+	}
+
+	value, err := strconv.ParseFloat(strings.Trim(string(verdict.Score), `" `), 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("judge score is not a number: %s", verdict.Score)
+	}
+	switch {
+	case value > 10:
+		value /= 100
+	case value > 1:
+		value /= 10
+	}
+	return math.Max(0, math.Min(1, value)), verdict.Reason, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
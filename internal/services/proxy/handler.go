@@ -90,10 +90,11 @@ func (h *ToolHandler) executeTemplate(vars map[string]string) (string, error) {
 	}
 
 	// Create logger
-	logger := workflowservice.NewLogger(workflow.Execution.Logging, false)
+	logger := workflowservice.NewLogger(workflow.ConsoleLogLevel(), false)
 
 	// Create orchestrator
 	orchestrator := workflowservice.NewOrchestrator(workflow, logger)
+	defer orchestrator.Close()
 	orchestrator.SetAppConfigForWorkflows(h.proxyServer.appConfig)
 
 	// Execute workflow
@@ -103,14 +104,8 @@ func (h *ToolHandler) executeTemplate(vars map[string]string) (string, error) {
 		return "", fmt.Errorf("workflow execution failed: %w", err)
 	}
 
-	// Get result from last step
-	result := ""
-	if len(workflow.Steps) > 0 {
-		lastStepName := workflow.Steps[len(workflow.Steps)-1].Name
-		if output, ok := orchestrator.GetStepResult(lastStepName); ok {
-			result = output
-		}
-	}
+	// Get result from last step (or the matrix report)
+	result, _ := orchestrator.FinalOutput()
 
 	if result == "" {
 		return fmt.Sprintf("Workflow '%s' completed but produced no output", workflow.Name), nil
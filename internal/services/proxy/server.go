@@ -16,7 +16,9 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/elicitation"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/initialize"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/roots"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/transport/stdio"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
@@ -303,6 +305,13 @@ func (s *ProxyServer) discoverToolsFromSource() error {
 		return fmt.Errorf("failed to start MCP server %s: %w", serverName, err)
 	}
 
+	// The proxy has no user to ask, so requests for input get a structured refusal
+	elicitation.Register(client.GetDispatcher(), serverName)
+
+	// Advertise the configured project roots
+	host.ConfigureRoots(s.appConfig.Roots)
+	roots.Register(client.GetDispatcher(), serverName)
+
 	// Send initialize request
 	initResult, err := initialize.SendInitialize(client, client.GetDispatcher())
 	if err != nil {
@@ -0,0 +1,145 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/contextbundle"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// DefaultContextMaxChars is the largest context source added as is
+const DefaultContextMaxChars = 20000
+
+// maxSummaryRounds bounds how often summaries of an oversized source are
+// themselves summarized
+const maxSummaryRounds = 3
+
+// ContextSource is a file or web page added to a query as context
+type ContextSource struct {
+	Name    string `json:"name"` // File path or URL, shown to the model
+	Content string `json:"content"`
+}
+
+// ContextOptions controls how context sources are added
+type ContextOptions struct {
+	Summarize bool `json:"summarize,omitempty"` // Summarize every source, not just oversized ones
+	MaxChars  int  `json:"max_chars,omitempty"` // Larger sources are summarized in chunks (default: DefaultContextMaxChars)
+}
+
+// AddContextSources adds each source as a context message. Sources over
+// MaxChars, or every source with Summarize, are first summarized with the
+// query's provider, focusing on what the question needs.
+func (h *QueryHandler) AddContextSources(ctx context.Context, question string, sources []ContextSource, opts ContextOptions) error {
+	maxChars := opts.MaxChars
+	if maxChars <= 0 {
+		maxChars = DefaultContextMaxChars
+	}
+
+	for _, source := range sources {
+		content := strings.TrimSpace(source.Content)
+		if content == "" {
+			logging.Warn("Context %s is empty; skipping it", source.Name)
+			continue
+		}
+
+		label := "Context from " + source.Name
+		if opts.Summarize || len([]rune(content)) > maxChars {
+			summary, err := h.summarizeContext(ctx, question, source.Name, content, maxChars)
+			if err != nil {
+				return fmt.Errorf("%w: failed to summarize context %s: %w", ErrLLMRequest, source.Name, err)
+			}
+			content = summary
+			label = "Summary of context from " + source.Name
+		}
+
+		h.ContextMessages = append(h.ContextMessages, domain.Message{
+			Role:    "user",
+			Content: label + " (use this to help answer my question):\n\n" + content,
+		})
+		logging.Info("Added context from %s (%d characters)", source.Name, len(content))
+	}
+	return nil
+}
+
+// AddContextBundle adds an exported chat or workflow run as context: a chat
+// is replayed as the earlier conversation, a workflow run's results as one
+// context message
+func (h *QueryHandler) AddContextBundle(bundle *contextbundle.Bundle) {
+	if bundle == nil {
+		return
+	}
+	messages := bundle.ContextMessages()
+	h.ContextMessages = append(h.ContextMessages, messages...)
+	logging.Info("Added %s context bundle (%d messages)", bundle.Kind, len(messages))
+}
+
+// summarizeContext summarizes content chunk by chunk until it fits in
+// maxChars, or gives up shortening after maxSummaryRounds
+func (h *QueryHandler) summarizeContext(ctx context.Context, question, name, content string, maxChars int) (string, error) {
+	for round := 0; round < maxSummaryRounds; round++ {
+		chunks := splitChunks(content, maxChars)
+		logging.Info("Summarizing context %s (%d characters, %d chunks)", name, len(content), len(chunks))
+
+		summaries := make([]string, 0, len(chunks))
+		for i, chunk := range chunks {
+			part := ""
+			if len(chunks) > 1 {
+				part = fmt.Sprintf(" (part %d of %d)", i+1, len(chunks))
+			}
+			summary, err := h.summarizeChunk(ctx, question, name+part, chunk)
+			if err != nil {
+				return "", err
+			}
+			summaries = append(summaries, summary)
+		}
+
+		content = strings.Join(summaries, "\n\n")
+		if len([]rune(content)) <= maxChars || len(chunks) == 1 {
+			return content, nil
+		}
+	}
+	return content, nil
+}
+
+// summarizeChunk asks the LLM for a summary of one chunk
+func (h *QueryHandler) summarizeChunk(ctx context.Context, question, name, chunk string) (string, error) {
+	prompt := fmt.Sprintf("Summarize the following content from %s. Keep the facts, figures, names and details "+
+		"needed to answer this question, and leave out the rest:\n\n%s\n\n--- CONTENT ---\n%s", name, question, chunk)
+
+	response, err := h.LLMClient.CreateCompletion(ctx, &domain.CompletionRequest{
+		Messages: []domain.Message{
+			{Role: "system", Content: "You summarize documents accurately and concisely. Reply with the summary only."},
+			{Role: "user", Content: prompt},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	h.emitUsage(response)
+	return strings.TrimSpace(response.Response), nil
+}
+
+// splitChunks splits text into pieces of at most maxChars characters,
+// breaking at paragraph or line ends where possible
+func splitChunks(text string, maxChars int) []string {
+	runes := []rune(text)
+	var chunks []string
+	for len(runes) > maxChars {
+		cut := maxChars
+		window := string(runes[:maxChars])
+		if i := strings.LastIndex(window, "\n\n"); i > len(window)/2 {
+			cut = len([]rune(window[:i]))
+		} else if i := strings.LastIndex(window, "\n"); i > len(window)/2 {
+			cut = len([]rune(window[:i]))
+		}
+		chunks = append(chunks, strings.TrimSpace(string(runes[:cut])))
+		runes = runes[cut:]
+	}
+	if rest := strings.TrimSpace(string(runes)); rest != "" {
+		chunks = append(chunks, rest)
+	}
+	return chunks
+}
@@ -0,0 +1,116 @@
+package query
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/toolloop"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+)
+
+// EnvelopeVersion is raised when a field of the query --json envelope is
+// renamed, removed or changes meaning. Added fields don't raise it.
+const EnvelopeVersion = 1
+
+// Envelope is the machine-readable output of query --json. Every field is
+// always present so scripts don't need to check for missing keys.
+type Envelope struct {
+	Version   int                `json:"version"`
+	Success   bool               `json:"success"`
+	Status    toolloop.Status    `json:"status"` // How the tool-calling loop ended; empty on error
+	Response  string             `json:"response"`
+	Provider  string             `json:"provider"`
+	Model     string             `json:"model"`
+	ToolCalls []EnvelopeToolCall `json:"tool_calls"`
+	Usage     *domain.Usage      `json:"usage"` // null when the provider didn't report usage
+	Timing    EnvelopeTiming     `json:"timing"`
+	Servers   []string           `json:"servers"`
+	Error     *EnvelopeError     `json:"error"` // null on success
+}
+
+// EnvelopeToolCall records one tool call
+type EnvelopeToolCall struct {
+	Name       string          `json:"name"`
+	Arguments  json.RawMessage `json:"arguments"`
+	Result     string          `json:"result"`
+	Success    bool            `json:"success"`
+	Error      string          `json:"error"`
+	ErrorCode  string          `json:"error_code"`
+	DurationMs int64           `json:"duration_ms"`
+}
+
+// EnvelopeTiming records when the query ran
+type EnvelopeTiming struct {
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// EnvelopeError describes why the query failed. Code is the standard process
+// exit code (domainErrors.ExitCode), not the detailed --error-code-only code.
+type EnvelopeError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewEnvelope wraps a successful query result
+func NewEnvelope(result *QueryResult) *Envelope {
+	env := &Envelope{
+		Version:   EnvelopeVersion,
+		Success:   true,
+		Status:    result.Status,
+		Response:  result.Response,
+		Provider:  result.Provider,
+		Model:     result.Model,
+		ToolCalls: make([]EnvelopeToolCall, 0, len(result.ToolCalls)),
+		Usage:     result.Usage,
+		Timing: EnvelopeTiming{
+			StartedAt:  result.StartedAt,
+			DurationMs: result.TimeTaken.Milliseconds(),
+		},
+		Servers: result.ServerConnections,
+	}
+	if env.Servers == nil {
+		env.Servers = []string{}
+	}
+	for _, call := range result.ToolCalls {
+		env.ToolCalls = append(env.ToolCalls, EnvelopeToolCall{
+			Name:       call.Name,
+			Arguments:  envelopeArguments(call.Arguments),
+			Result:     call.Result,
+			Success:    call.Success,
+			Error:      call.Error,
+			ErrorCode:  call.ErrorCode,
+			DurationMs: call.DurationMs,
+		})
+	}
+	return env
+}
+
+// NewErrorEnvelope reports a failed query
+func NewErrorEnvelope(err error, code int, provider, model string, startedAt time.Time) *Envelope {
+	return &Envelope{
+		Version:   EnvelopeVersion,
+		Provider:  provider,
+		Model:     model,
+		ToolCalls: []EnvelopeToolCall{},
+		Timing: EnvelopeTiming{
+			StartedAt:  startedAt,
+			DurationMs: time.Since(startedAt).Milliseconds(),
+		},
+		Servers: []string{},
+		Error:   &EnvelopeError{Code: code, Message: err.Error()},
+	}
+}
+
+// envelopeArguments returns tool arguments as a JSON object, keeping
+// arguments that aren't valid JSON as a string
+func envelopeArguments(arguments json.RawMessage) json.RawMessage {
+	if len(arguments) == 0 {
+		return json.RawMessage("{}")
+	}
+	if json.Valid(arguments) {
+		return arguments
+	}
+	quoted, _ := json.Marshal(string(arguments))
+	return quoted
+}
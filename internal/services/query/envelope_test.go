@@ -0,0 +1,116 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+)
+
+var envelopeKeys = []string{"version", "success", "status", "response", "provider", "model", "tool_calls", "usage", "timing", "servers", "error"}
+
+// decodeEnvelope marshals env and decodes it into a generic map, as a script would see it
+func decodeEnvelope(t *testing.T, env *Envelope) map[string]json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("unmarshal envelope: %v", err)
+	}
+	for _, key := range envelopeKeys {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("envelope missing key %q: %s", key, data)
+		}
+	}
+	return fields
+}
+
+func TestNewEnvelope(t *testing.T) {
+	t.Run("empty lists encode as arrays", func(t *testing.T) {
+		fields := decodeEnvelope(t, NewEnvelope(&QueryResult{Response: "hi", Provider: "openai", Model: "gpt-4o"}))
+
+		if got := string(fields["tool_calls"]); got != "[]" {
+			t.Errorf("tool_calls = %s, want []", got)
+		}
+		if got := string(fields["servers"]); got != "[]" {
+			t.Errorf("servers = %s, want []", got)
+		}
+		if got := string(fields["error"]); got != "null" {
+			t.Errorf("error = %s, want null", got)
+		}
+		if got := string(fields["usage"]); got != "null" {
+			t.Errorf("usage = %s, want null", got)
+		}
+		if got := string(fields["success"]); got != "true" {
+			t.Errorf("success = %s, want true", got)
+		}
+	})
+
+	t.Run("tool call arguments", func(t *testing.T) {
+		env := NewEnvelope(&QueryResult{
+			ToolCalls: []ToolCallInfo{
+				{Name: "valid", Arguments: json.RawMessage(`{"path":"a.txt"}`), Success: true},
+				{Name: "empty"},
+				{Name: "invalid", Arguments: json.RawMessage(`{"path": "a.txt"`)},
+			},
+			ServerConnections: []string{"filesystem"},
+		})
+		fields := decodeEnvelope(t, env)
+
+		var calls []struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(fields["tool_calls"], &calls); err != nil {
+			t.Fatalf("tool_calls: %v", err)
+		}
+		want := map[string]string{
+			"valid":   `{"path":"a.txt"}`,
+			"empty":   `{}`,
+			"invalid": `"{\"path\": \"a.txt\""`,
+		}
+		if len(calls) != len(want) {
+			t.Fatalf("got %d tool calls, want %d", len(calls), len(want))
+		}
+		for _, call := range calls {
+			if got := string(call.Arguments); got != want[call.Name] {
+				t.Errorf("%s arguments = %s, want %s", call.Name, got, want[call.Name])
+			}
+		}
+		if got := string(fields["servers"]); got != `["filesystem"]` {
+			t.Errorf("servers = %s", got)
+		}
+	})
+}
+
+func TestNewErrorEnvelope(t *testing.T) {
+	err := domainErrors.Categorize(errors.New("provider not found: nope"), domainErrors.ErrProviderFailure)
+	env := NewErrorEnvelope(err, domainErrors.ExitCode(err), "nope", "", time.Now())
+	fields := decodeEnvelope(t, env)
+
+	if got := string(fields["tool_calls"]); got != "[]" {
+		t.Errorf("tool_calls = %s, want []", got)
+	}
+	if got := string(fields["servers"]); got != "[]" {
+		t.Errorf("servers = %s, want []", got)
+	}
+	if got := string(fields["success"]); got != "false" {
+		t.Errorf("success = %s, want false", got)
+	}
+
+	var envErr EnvelopeError
+	if err := json.Unmarshal(fields["error"], &envErr); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if envErr.Code != domainErrors.ExitProvider {
+		t.Errorf("error code = %d, want %d", envErr.Code, domainErrors.ExitProvider)
+	}
+	if envErr.Message != "provider not found: nope" {
+		t.Errorf("error message = %q", envErr.Message)
+	}
+}
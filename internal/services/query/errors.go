@@ -3,6 +3,8 @@ package query
 import (
 	"errors"
 	"fmt"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 )
 
 // Error codes as constants
@@ -20,19 +22,21 @@ const (
 	ErrInvalidArgumentCode  = 20
 )
 
-// Error types with wrapped errors for error code mapping
+// Error types with wrapped errors for error code mapping.
+// Each carries a failure category so domainErrors.ExitCode can classify
+// query errors when --error-code-only is not used.
 var (
-	ErrConfigNotFound   = errors.New("configuration not found")
-	ErrProviderNotFound = errors.New("provider not found")
-	ErrModelNotFound    = errors.New("model not found")
-	ErrServerConnection = errors.New("server connection failed")
-	ErrToolExecution    = errors.New("tool execution failed")
-	ErrLLMRequest       = errors.New("LLM request failed")
-	ErrContextNotFound  = errors.New("context file not found")
+	ErrConfigNotFound   = domainErrors.Categorize(errors.New("configuration not found"), domainErrors.ErrValidation)
+	ErrProviderNotFound = domainErrors.Categorize(errors.New("provider not found"), domainErrors.ErrProviderFailure)
+	ErrModelNotFound    = domainErrors.Categorize(errors.New("model not found"), domainErrors.ErrProviderFailure)
+	ErrServerConnection = domainErrors.Categorize(errors.New("server connection failed"), domainErrors.ErrToolFailure)
+	ErrToolExecution    = domainErrors.Categorize(errors.New("tool execution failed"), domainErrors.ErrToolFailure)
+	ErrLLMRequest       = domainErrors.Categorize(errors.New("LLM request failed"), domainErrors.ErrProviderFailure)
+	ErrContextNotFound  = domainErrors.Categorize(errors.New("context file not found"), domainErrors.ErrValidation)
 	ErrInitialization   = errors.New("query initialization failed")
 	ErrOutputFormat     = errors.New("output formatting failed")
 	ErrOutputWrite      = errors.New("output write failed")
-	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrInvalidArgument  = domainErrors.Categorize(errors.New("invalid argument"), domainErrors.ErrValidation)
 )
 
 // Map errors to exit codes
@@ -4,20 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/toolloop"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/progress"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/mcp/messages/tools"
 )
 
 // Default maximum number of follow-up attempts to avoid infinite loops
 // Set high enough that users never hit this limit in normal usage
-const defaultMaxFollowUpAttempts = 50
+const defaultMaxFollowUpAttempts = config.DefaultQueryToolIterations
 
 // QueryHandler handles query execution
 type QueryHandler struct {
@@ -51,11 +55,29 @@ type QueryHandler struct {
 	// Tool calls made during execution
 	toolCalls []ToolCallInfo
 
+	// Token usage of the completions made during execution
+	usage *domain.Usage
+
 	// Server name - needed to check for GraphSecurityIncidents
 	ServerName string
 
 	// Maximum number of follow-up attempts (configurable)
 	MaxFollowUpAttempts int
+
+	// Identical tool calls (same name and arguments) allowed before the
+	// model is asked for a final answer
+	MaxRepeatedToolCalls int
+
+	// Times the model is asked to correct invalid tool calls before the
+	// query fails
+	MaxToolCallRepairs int
+
+	// Workflow run and step that tool calls and token usage are reported for
+	Progress progress.Scope
+
+	// Writer the model's responses are streamed to as they arrive (nil: not
+	// streamed)
+	Stream io.Writer
 }
 
 // NewQueryHandler creates a new query handler
@@ -146,16 +168,18 @@ When writing code, save output files to /outputs/ directory:
 	}
 
 	return &QueryHandler{
-		Connections:         connections,
-		LLMClient:           client,
-		SystemPrompt:        systemPrompt,
-		ContextMessages:     []domain.Message{},
-		toolsCache:          make(map[string][]tools.Tool),
-		AIOptions:           aiOptions,
-		InterfaceType:       interfaceType,
-		toolCalls:           []ToolCallInfo{},
-		ServerName:          serverName,
-		MaxFollowUpAttempts: defaultMaxFollowUpAttempts, // Use default value
+		Connections:          connections,
+		LLMClient:            client,
+		SystemPrompt:         systemPrompt,
+		ContextMessages:      []domain.Message{},
+		toolsCache:           make(map[string][]tools.Tool),
+		AIOptions:            aiOptions,
+		InterfaceType:        interfaceType,
+		toolCalls:            []ToolCallInfo{},
+		ServerName:           serverName,
+		MaxFollowUpAttempts:  defaultMaxFollowUpAttempts, // Use default value
+		MaxRepeatedToolCalls: config.DefaultMaxRepeatedToolCalls,
+		MaxToolCallRepairs:   config.DefaultToolCallRepairs,
 	}, nil
 }
 
@@ -207,16 +231,18 @@ When writing code, save output files to /outputs/ directory:
 	logging.Info("SYSTEM_PROMPT_DEBUG: Using system prompt: %s", systemPrompt)
 
 	return &QueryHandler{
-		Connections:         connections,
-		LLMClient:           llmProvider,
-		SystemPrompt:        systemPrompt,
-		ContextMessages:     []domain.Message{},
-		toolsCache:          make(map[string][]tools.Tool),
-		AIOptions:           aiOptions,
-		InterfaceType:       aiOptions.InterfaceType,
-		toolCalls:           []ToolCallInfo{},
-		ServerName:          serverName,
-		MaxFollowUpAttempts: defaultMaxFollowUpAttempts,
+		Connections:          connections,
+		LLMClient:            llmProvider,
+		SystemPrompt:         systemPrompt,
+		ContextMessages:      []domain.Message{},
+		toolsCache:           make(map[string][]tools.Tool),
+		AIOptions:            aiOptions,
+		InterfaceType:        aiOptions.InterfaceType,
+		toolCalls:            []ToolCallInfo{},
+		ServerName:           serverName,
+		MaxFollowUpAttempts:  defaultMaxFollowUpAttempts,
+		MaxRepeatedToolCalls: config.DefaultMaxRepeatedToolCalls,
+		MaxToolCallRepairs:   config.DefaultToolCallRepairs,
 	}, nil
 }
 
@@ -301,16 +327,18 @@ When writing code, save output files to /outputs/ directory:
 	}
 
 	return &QueryHandler{
-		Connections:         connections,
-		LLMClient:           client,
-		SystemPrompt:        systemPrompt,
-		ContextMessages:     []domain.Message{},
-		toolsCache:          make(map[string][]tools.Tool),
-		AIOptions:           aiOptions,
-		InterfaceType:       interfaceType,
-		toolCalls:           []ToolCallInfo{},
-		ServerName:          serverName,
-		MaxFollowUpAttempts: defaultMaxFollowUpAttempts, // Use default value
+		Connections:          connections,
+		LLMClient:            client,
+		SystemPrompt:         systemPrompt,
+		ContextMessages:      []domain.Message{},
+		toolsCache:           make(map[string][]tools.Tool),
+		AIOptions:            aiOptions,
+		InterfaceType:        interfaceType,
+		toolCalls:            []ToolCallInfo{},
+		ServerName:           serverName,
+		MaxFollowUpAttempts:  defaultMaxFollowUpAttempts, // Use default value
+		MaxRepeatedToolCalls: config.DefaultMaxRepeatedToolCalls,
+		MaxToolCallRepairs:   config.DefaultToolCallRepairs,
 	}, nil
 }
 
@@ -344,11 +372,17 @@ func (h *QueryHandler) SetMaxTokens(maxTokens int) {
 
 // Execute executes the query and returns the result
 func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
+	return h.ExecuteContext(context.Background(), question)
+}
+
+// ExecuteContext executes the query, stopping its completions and tool calls
+// when ctx is done
+func (h *QueryHandler) ExecuteContext(ctx context.Context, question string) (*QueryResult, error) {
 	startTime := time.Now()
 
-	// Get available tools for the LLM
+	// Get the tools for this question (a skill router may narrow them)
 	logging.Info("Fetching available tools for LLM")
-	llmTools, err := h.GetAvailableTools()
+	llmTools, err := h.GetToolsForRequest(question)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get available tools: %w", err)
 	}
@@ -373,6 +407,7 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 		Content: question,
 	}
 	messages = append(messages, userMessage)
+	exchangeStart := len(messages) - 1
 
 	// DEBUGGING: Log all messages being sent to LLM - THIS IS KEY!
 	logging.Info("=== CRITICAL DEBUG: Messages being sent to LLM ===")
@@ -397,15 +432,16 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 		SystemPrompt: "", // Already in messages
 	}
 
-	response, err := h.LLMClient.CreateCompletion(context.Background(), req)
+	response, err := h.completeWithValidToolCalls(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrLLMRequest, err)
+		return nil, err
 	}
 
 	logging.Debug("Initial response: %s", response.Response)
 
 	// Keep track of number of follow-up attempts to avoid infinite loops
 	followUpsUsed := 0
+	guard := toolloop.NewGuard(h.MaxFollowUpAttempts, h.MaxRepeatedToolCalls)
 
 	// Detect if we're using Ollama with alternative format
 	usingOllamaAlternativeFormat := h.AIOptions.Provider == "ollama" &&
@@ -419,9 +455,14 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 	logging.Debug("Using maximum follow-up attempts: %d", h.MaxFollowUpAttempts)
 
 	// Handle tool calls if present
-	for followUpsUsed < h.MaxFollowUpAttempts {
+	for {
 		// Check if we have tool calls in the response
 		if response != nil && len(response.ToolCalls) > 0 {
+			// Stop at the iteration limit or when the model repeats itself
+			if guard.Check(response.ToolCalls) != toolloop.Completed {
+				break
+			}
+
 			logging.Info("Query resulted in %d tool calls (follow-up #%d)", len(response.ToolCalls), followUpsUsed+1)
 
 			// DEBUGGING: Log each tool call in detail
@@ -449,8 +490,8 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 			toolCallsStartIndex := len(h.toolCalls)
 
 			// Execute tools and get results
-			if err := h.handleToolCalls(response.ToolCalls); err != nil {
-				return nil, fmt.Errorf("%w: %v", ErrToolExecution, err)
+			if err := h.handleToolCalls(ctx, response.ToolCalls); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrToolExecution, err)
 			}
 
 			// Add tool result messages to conversation history
@@ -470,6 +511,13 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 					toolCall.Function.Name, toolCall.ID, toolCallsStartIndex+i, toolInfo.Result)
 			}
 
+			// Tools found with search_tools join the selection from the next turn
+			if _, selects := h.ServerManager.(domain.ToolSelector); selects {
+				if tools, err := h.GetToolsForRequest(question); err == nil {
+					llmTools = tools
+				}
+			}
+
 			// For Ollama alternative format, add a special clarification message to help process the results
 			if usingOllamaAlternativeFormat && followUpsUsed > 0 {
 				clarificationMsg := domain.Message{
@@ -495,9 +543,9 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 				SystemPrompt: "", // Already in messages
 			}
 
-			followUpResponse, err := h.LLMClient.CreateCompletion(context.Background(), followUpReq)
+			followUpResponse, err := h.completeWithValidToolCalls(ctx, followUpReq)
 			if err != nil {
-				return nil, fmt.Errorf("%w: %v", ErrLLMRequest, err)
+				return nil, err
 			}
 
 			// Log the follow-up response
@@ -525,8 +573,39 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 		}
 	}
 
+	// The model was stopped mid-loop: ask for an answer from what it has
+	if guard.Stopped() {
+		logging.Warn("Stopping tool calls: %s", guard.Reason())
+
+		if strings.TrimSpace(response.Response) != "" {
+			messages = append(messages, domain.Message{
+				Role:    "assistant",
+				Content: response.Response,
+			})
+		}
+		messages = append(messages, domain.Message{
+			Role:    "user",
+			Content: guard.FinalAnswerPrompt(),
+		})
+
+		finalReq := &domain.CompletionRequest{
+			Messages:     messages,
+			Tools:        []domain.Tool{}, // No tools in final request
+			SystemPrompt: "",
+		}
+
+		finalResponse, err := h.complete(ctx, finalReq)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrLLMRequest, err)
+		}
+		h.emitUsage(finalResponse)
+
+		response = finalResponse
+		response.Response += fmt.Sprintf("\n\n[Note: Tool calls were stopped because %s. The result may be incomplete.]", guard.Reason())
+	}
+
 	// A special case: check if we need one final follow-up due to intent to use tools
-	if followUpsUsed < h.MaxFollowUpAttempts {
+	if !guard.Stopped() {
 		needsFinalFollowUp := false
 		responseContent := strings.ToLower(response.Response)
 
@@ -555,23 +634,17 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 				SystemPrompt: "",
 			}
 
-			finalResponse, err := h.LLMClient.CreateCompletion(context.Background(), finalReq)
+			finalResponse, err := h.complete(ctx, finalReq)
 			if err != nil {
-				return nil, fmt.Errorf("%w: %v", ErrLLMRequest, err)
+				return nil, fmt.Errorf("%w: %w", ErrLLMRequest, err)
 			}
+			h.emitUsage(finalResponse)
 
 			logging.Debug("Received final answer response: %s", finalResponse.Response)
 			response = finalResponse
 		}
 	}
 
-	// If we've reached the max follow-ups but still don't have a good response,
-	// append a note about that in the response
-	if followUpsUsed >= h.MaxFollowUpAttempts && len(response.ToolCalls) > 0 {
-		logging.Warn("Reached maximum number of follow-ups (%d) but still getting tool calls", h.MaxFollowUpAttempts)
-		response.Response += fmt.Sprintf("\n\n[Note: The maximum number of tool call iterations (%d) was reached. The result may be incomplete.]", h.MaxFollowUpAttempts)
-	}
-
 	// Calculate time taken
 	timeTaken := time.Since(startTime)
 
@@ -585,17 +658,32 @@ func (h *QueryHandler) Execute(question string) (*QueryResult, error) {
 	result := &QueryResult{
 		Response:          response.Response,
 		ToolCalls:         h.toolCalls,
+		StartedAt:         startTime,
 		TimeTaken:         timeTaken,
+		Usage:             h.usage,
+		Status:            guard.Status(),
 		Provider:          h.AIOptions.Provider,
 		Model:             h.AIOptions.Model,
 		ServerConnections: serverConnections,
+		Messages:          exchange(messages[exchangeStart:], response.Response),
 	}
 
 	return result, nil
 }
 
+// exchange copies a query's messages, ending them with the final answer
+// when the conversation doesn't already
+func exchange(messages []domain.Message, answer string) []domain.Message {
+	result := append([]domain.Message(nil), messages...)
+	last := result[len(result)-1]
+	if last.Role != "assistant" || len(last.ToolCalls) > 0 || last.Content != answer {
+		result = append(result, domain.Message{Role: "assistant", Content: answer})
+	}
+	return result
+}
+
 // handleToolCalls executes tool calls and records the results
-func (h *QueryHandler) handleToolCalls(toolCalls []domain.ToolCall) error {
+func (h *QueryHandler) handleToolCalls(ctx context.Context, toolCalls []domain.ToolCall) error {
 	for _, toolCall := range toolCalls {
 		// Log the tool call ID for debugging
 		logging.Debug("Processing tool call with ID %s: %s", toolCall.ID, toolCall.Function.Name)
@@ -606,7 +694,8 @@ func (h *QueryHandler) handleToolCalls(toolCalls []domain.ToolCall) error {
 		// Execute the tool call
 		logging.Info("Executing tool call: %s", toolName)
 
-		result, err := h.executeToolCall(toolCall)
+		started := time.Now()
+		result, err := h.executeToolCall(ctx, toolCall)
 
 		// Record tool call info
 		toolInfo := ToolCallInfo{
@@ -617,12 +706,15 @@ func (h *QueryHandler) handleToolCalls(toolCalls []domain.ToolCall) error {
 
 		if err != nil {
 			toolInfo.Error = err.Error()
-			toolInfo.Result = fmt.Sprintf("Error: %s", err.Error())
+			toolInfo.ErrorCode = string(domainErrors.ClassifyToolError(err).Code)
+			toolInfo.Result = domainErrors.FormatToolResult(err)
 		} else {
 			toolInfo.Result = result
 		}
 
+		toolInfo.DurationMs = time.Since(started).Milliseconds()
 		h.toolCalls = append(h.toolCalls, toolInfo)
+		h.emitToolCall(toolInfo)
 
 		// If there's an error, continue with other tool calls
 		if err != nil {
@@ -634,11 +726,63 @@ func (h *QueryHandler) handleToolCalls(toolCalls []domain.ToolCall) error {
 	return nil
 }
 
+// complete gets one completion, streaming it to Stream when that's set
+func (h *QueryHandler) complete(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	if h.Stream != nil {
+		req.Stream = true
+		return h.LLMClient.StreamCompletion(ctx, req, h.Stream)
+	}
+	return h.LLMClient.CreateCompletion(ctx, req)
+}
+
+// emitUsage adds the token usage of a completion to the result and reports
+// it to the progress stream
+func (h *QueryHandler) emitUsage(response *domain.CompletionResponse) {
+	if response == nil || response.Usage == nil {
+		return
+	}
+	if h.usage == nil {
+		h.usage = &domain.Usage{}
+	}
+	h.usage.PromptTokens += response.Usage.PromptTokens
+	h.usage.CompletionTokens += response.Usage.CompletionTokens
+	h.usage.TotalTokens += response.Usage.TotalTokens
+
+	model := response.Model
+	if model == "" {
+		model = h.AIOptions.Model
+	}
+	h.Progress.Emit(progress.Event{
+		Type:             progress.TokenUsage,
+		Provider:         h.AIOptions.Provider,
+		Model:            model,
+		PromptTokens:     response.Usage.PromptTokens,
+		CompletionTokens: response.Usage.CompletionTokens,
+		TotalTokens:      response.Usage.TotalTokens,
+	})
+}
+
+// emitToolCall reports a finished tool call to the progress stream
+func (h *QueryHandler) emitToolCall(info ToolCallInfo) {
+	event := progress.Event{
+		Type:       progress.ToolCall,
+		Tool:       info.Name,
+		Status:     progress.StatusSucceeded,
+		DurationMs: info.DurationMs,
+	}
+	if !info.Success {
+		event.Status = progress.StatusFailed
+		event.Error = info.Error
+		event.ErrorCode = info.ErrorCode
+	}
+	h.Progress.Emit(event)
+}
+
 // executeToolCall executes a single tool call and returns the result
-func (h *QueryHandler) executeToolCall(toolCall domain.ToolCall) (string, error) {
+func (h *QueryHandler) executeToolCall(ctx context.Context, toolCall domain.ToolCall) (string, error) {
 	// ARCHITECTURAL FIX: Use ServerManager if available (supports built-in skills)
 	if h.ServerManager != nil {
-		return h.executeToolCallWithServerManager(toolCall)
+		return h.executeToolCallWithServerManager(ctx, toolCall)
 	}
 
 	// Fall back to legacy Connections-based execution
@@ -646,7 +790,7 @@ func (h *QueryHandler) executeToolCall(toolCall domain.ToolCall) (string, error)
 }
 
 // executeToolCallWithServerManager executes a tool call using the server manager
-func (h *QueryHandler) executeToolCallWithServerManager(toolCall domain.ToolCall) (string, error) {
+func (h *QueryHandler) executeToolCallWithServerManager(ctx context.Context, toolCall domain.ToolCall) (string, error) {
 	// Parse arguments
 	var args map[string]interface{}
 	err := json.Unmarshal(toolCall.Function.Arguments, &args)
@@ -656,7 +800,7 @@ func (h *QueryHandler) executeToolCallWithServerManager(toolCall domain.ToolCall
 
 	// Execute tool using server manager
 	logging.Debug("Executing tool %s using server manager", toolCall.Function.Name)
-	result, err := h.ServerManager.ExecuteTool(context.Background(), toolCall.Function.Name, args)
+	result, err := h.ServerManager.ExecuteTool(ctx, toolCall.Function.Name, args)
 	if err != nil {
 		return "", fmt.Errorf("tool execution error: %w", err)
 	}
@@ -873,6 +1017,15 @@ func formatToolNameForOpenAI(serverName, toolName string) string {
 	return fmt.Sprintf("%s_%s", serverName, toolName)
 }
 
+// GetToolsForRequest returns the tools to offer the LLM for a request. A
+// server manager with a skill router offers only the relevant skills.
+func (h *QueryHandler) GetToolsForRequest(request string) ([]domain.Tool, error) {
+	if h.ServerManager != nil {
+		return domain.SelectTools(context.Background(), h.ServerManager, request)
+	}
+	return h.GetAvailableTools()
+}
+
 // GetAvailableTools returns the tools available for the LLM
 func (h *QueryHandler) GetAvailableTools() ([]domain.Tool, error) {
 	// ARCHITECTURAL FIX: Use ServerManager if available (supports built-in skills)
@@ -927,8 +1080,8 @@ func (h *QueryHandler) getServerTools(conn *host.ServerConnection) ([]tools.Tool
 		return cachedTools, nil
 	}
 
-	// Get the tools from the server with retry
-	var serverTools []tools.Tool
+	// Get the tools from the server with retry (the connection caches
+	// tools/list results prefetched at startup)
 	var lastErr error
 
 	for retries := 0; retries < 3; retries++ {
@@ -937,65 +1090,16 @@ func (h *QueryHandler) getServerTools(conn *host.ServerConnection) ([]tools.Tool
 			time.Sleep(time.Duration(retries) * time.Second)
 		}
 
-		logging.Info("Getting tools list from server %s", conn.Name)
-
-		// Handle both stdio and Unix socket clients
-		if stdioClient := conn.GetStdioClient(); stdioClient != nil {
-			// Stdio client
-			result, err := tools.SendToolsList(stdioClient, nil)
-			if err != nil {
-				lastErr = fmt.Errorf("failed to get tools from server %s: %w", conn.Name, err)
-				logging.Error("%v", lastErr)
-				continue
-			}
-
-			// Cache the tools
-			h.toolsCache[conn.Name] = result.Tools
-			serverTools = result.Tools
-
-			logging.Info("Successfully got %d tools from server %s", len(serverTools), conn.Name)
-			return serverTools, nil
-		} else if socketClient := conn.GetUnixSocketClient(); socketClient != nil {
-			// Unix socket client
-			result, err := socketClient.SendToolsList(nil)
-			if err != nil {
-				lastErr = fmt.Errorf("failed to get tools from server %s: %w", conn.Name, err)
-				logging.Error("%v", lastErr)
-				continue
-			}
-
-			// Parse tools from Unix socket response
-			var parsedTools []tools.Tool
-			if toolsArray, ok := result["tools"].([]interface{}); ok {
-				for _, t := range toolsArray {
-					if toolMap, ok := t.(map[string]interface{}); ok {
-						tool := tools.Tool{}
-
-						if name, ok := toolMap["name"].(string); ok {
-							tool.Name = name
-						}
-						if desc, ok := toolMap["description"].(string); ok {
-							tool.Description = desc
-						}
-						if schema, ok := toolMap["inputSchema"].(map[string]interface{}); ok {
-							tool.InputSchema = schema
-						}
-
-						parsedTools = append(parsedTools, tool)
-					}
-				}
-			}
-
-			// Cache the tools
-			h.toolsCache[conn.Name] = parsedTools
-			serverTools = parsedTools
-
-			logging.Info("Successfully got %d tools from server %s via Unix socket", len(serverTools), conn.Name)
-			return serverTools, nil
-		} else {
-			lastErr = fmt.Errorf("server %s has unknown client type", conn.Name)
-			break
+		serverTools, err := conn.ListTools()
+		if err != nil {
+			lastErr = err
+			logging.Error("%v", lastErr)
+			continue
 		}
+
+		h.toolsCache[conn.Name] = serverTools
+		logging.Info("Got %d tools from server %s", len(serverTools), conn.Name)
+		return serverTools, nil
 	}
 
 	return nil, lastErr
@@ -1033,14 +1137,16 @@ All file operations should use paths starting with /outputs/ which is your worki
 	}
 
 	return &QueryHandler{
-		ServerManager:       serverManager,
-		LLMClient:           llmProvider,
-		SystemPrompt:        systemPrompt,
-		ContextMessages:     []domain.Message{},
-		toolsCache:          make(map[string][]tools.Tool),
-		AIOptions:           aiOptions,
-		InterfaceType:       aiOptions.InterfaceType,
-		toolCalls:           []ToolCallInfo{},
-		MaxFollowUpAttempts: defaultMaxFollowUpAttempts,
+		ServerManager:        serverManager,
+		LLMClient:            llmProvider,
+		SystemPrompt:         systemPrompt,
+		ContextMessages:      []domain.Message{},
+		toolsCache:           make(map[string][]tools.Tool),
+		AIOptions:            aiOptions,
+		InterfaceType:        aiOptions.InterfaceType,
+		toolCalls:            []ToolCallInfo{},
+		MaxFollowUpAttempts:  defaultMaxFollowUpAttempts,
+		MaxRepeatedToolCalls: config.DefaultMaxRepeatedToolCalls,
+		MaxToolCallRepairs:   config.DefaultToolCallRepairs,
 	}
 }
@@ -3,6 +3,9 @@ package query
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/toolloop"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 )
 
 // QueryResult contains the response from a query execution
@@ -13,15 +16,28 @@ type QueryResult struct {
 	// Any tool calls that were made during execution
 	ToolCalls []ToolCallInfo `json:"tool_calls,omitempty"`
 
-	// Time taken to complete the query
+	// When the query started and how long it took to complete
+	StartedAt time.Time     `json:"started_at"`
 	TimeTaken time.Duration `json:"time_taken"`
 
+	// Token usage summed over every completion; nil when the provider
+	// didn't report it
+	Usage *domain.Usage `json:"usage,omitempty"`
+
+	// How the tool-calling loop ended: completed, iteration_limit_reached
+	// or repeated_tool_call
+	Status toolloop.Status `json:"status"`
+
 	// The provider and model used for the query
 	Provider string `json:"provider"`
 	Model    string `json:"model"`
 
 	// List of server names connected for this query
 	ServerConnections []string `json:"server_connections,omitempty"`
+
+	// The conversation from the question to the final answer, with tool
+	// calls and their results; the system prompt and context are left out
+	Messages []domain.Message `json:"-"`
 }
 
 // ToolCallInfo contains information about a tool call that was made
@@ -40,4 +56,10 @@ type ToolCallInfo struct {
 
 	// Error message if the tool call failed
 	Error string `json:"error,omitempty"`
+
+	// Error code if the tool call failed, e.g. TOOL_PERMISSION_DENIED
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// Time the tool took to run
+	DurationMs int64 `json:"duration_ms"`
 }
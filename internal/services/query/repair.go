@@ -0,0 +1,44 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/toolloop"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// completeWithValidToolCalls gets a completion whose tool calls all name an
+// offered tool with arguments matching its schema. Invalid calls aren't
+// run: the model is told what was wrong and asked again, up to
+// MaxToolCallRepairs times.
+func (h *QueryHandler) completeWithValidToolCalls(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	messages := req.Messages
+	for attempt := 0; ; attempt++ {
+		retry := *req
+		retry.Messages = messages
+
+		response, err := h.complete(ctx, &retry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrLLMRequest, err)
+		}
+		h.emitUsage(response)
+
+		invalid := toolloop.ValidateCalls(response.ToolCalls, req.Tools)
+		if len(invalid) == 0 {
+			return response, nil
+		}
+		if attempt >= h.MaxToolCallRepairs {
+			return nil, fmt.Errorf("%w: %w", ErrLLMRequest, &toolloop.InvalidCallsError{Calls: invalid})
+		}
+
+		logging.Warn("Model made %d invalid tool calls; asking it to correct them (attempt %d of %d)",
+			len(invalid), attempt+1, h.MaxToolCallRepairs)
+		// The invalid response is dropped; only the correction is added
+		messages = append(messages[:len(messages):len(messages)], domain.Message{
+			Role:    "user",
+			Content: toolloop.CorrectionPrompt(invalid, req.Tools),
+		})
+	}
+}
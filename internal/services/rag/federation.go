@@ -0,0 +1,134 @@
+package rag
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// Source is one RAG server of a federated search
+type Source struct {
+	Server string
+	Weight float64 // Multiplies the server's scores (0 means 1)
+}
+
+// sourceResults is what one source of a federated search returned
+type sourceResults struct {
+	source   Source
+	response *SearchResponse
+	err      error
+}
+
+// FederatedSearch runs req against every source in parallel and merges the
+// results, for example to search separate code, docs and ticket corpora at
+// once. Each result's score is multiplied by its source's weight and the
+// result is tagged with its server. Results with the same content are kept
+// once, with the best weighted score. The merged list is cut to req.TopK.
+// It fails only when every source fails.
+func (s *Service) FederatedSearch(ctx context.Context, req SearchRequest, sources []Source) (*SearchResponse, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no servers to search")
+	}
+	if req.TopK == 0 {
+		req.TopK = s.ragConfig.DefaultTopK
+	}
+
+	collected := make([]sourceResults, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source Source) {
+			defer wg.Done()
+			sourceReq := req
+			sourceReq.Server = source.Server
+			response, err := s.Search(ctx, sourceReq)
+			collected[i] = sourceResults{source: source, response: response, err: err}
+		}(i, source)
+	}
+	wg.Wait()
+
+	response, err := mergeFederated(req, collected)
+	if err != nil {
+		return nil, err
+	}
+	logging.Info("✅ Federated search completed: %d results from %d servers", response.TotalResults, len(sources)-len(response.FailedServers))
+	return response, nil
+}
+
+// mergeFederated weights, tags, deduplicates and ranks the results of a
+// federated search
+func mergeFederated(req SearchRequest, collected []sourceResults) (*SearchResponse, error) {
+	merged := &SearchResponse{Query: req.Query, Fusion: req.Fusion}
+	var errs []string
+	best := make(map[string]int) // content hash -> index in merged.Results
+
+	for _, c := range collected {
+		merged.Servers = append(merged.Servers, c.source.Server)
+		if c.err != nil {
+			logging.Warn("❌ Search failed for RAG server %s: %v", c.source.Server, c.err)
+			merged.FailedServers = append(merged.FailedServers, c.source.Server)
+			errs = append(errs, fmt.Sprintf("%s: %v", c.source.Server, c.err))
+			continue
+		}
+		if merged.ExpandedQuery == nil {
+			merged.ExpandedQuery = c.response.ExpandedQuery
+		}
+
+		weight := c.source.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		for _, result := range c.response.Results {
+			result.CombinedScore *= weight
+			result.Server = c.source.Server
+
+			key := contentHash(result.Text)
+			if i, seen := best[key]; seen {
+				if result.CombinedScore > merged.Results[i].CombinedScore {
+					merged.Results[i] = result
+				}
+				continue
+			}
+			best[key] = len(merged.Results)
+			merged.Results = append(merged.Results, result)
+		}
+	}
+
+	if len(errs) == len(collected) {
+		return nil, fmt.Errorf("all RAG servers failed: %s", strings.Join(errs, "; "))
+	}
+
+	sort.SliceStable(merged.Results, func(i, j int) bool {
+		return merged.Results[i].CombinedScore > merged.Results[j].CombinedScore
+	})
+	if req.TopK > 0 && len(merged.Results) > req.TopK {
+		merged.Results = merged.Results[:req.TopK]
+	}
+	if merged.Results == nil {
+		merged.Results = []SearchResult{}
+	}
+	merged.TotalResults = len(merged.Results)
+	return merged, nil
+}
+
+// contentHash identifies a result by its text, ignoring case and spacing, so
+// the same chunk indexed in several stores is recognised
+func contentHash(text map[string]interface{}) string {
+	keys := make([]string, 0, len(text))
+	for key := range text {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		value := strings.Join(strings.Fields(strings.ToLower(fmt.Sprint(text[key]))), " ")
+		fmt.Fprintf(h, "%s=%s\n", key, value)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package rag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func chunk(id, text string, score float64) SearchResult {
+	return SearchResult{ID: id, Text: map[string]interface{}{"content": text}, CombinedScore: score}
+}
+
+func TestMergeFederated(t *testing.T) {
+	collected := []sourceResults{
+		{source: Source{Server: "code"}, response: &SearchResponse{Results: []SearchResult{
+			chunk("c1", "func Login()", 0.9),
+			chunk("c2", "Shared   README text", 0.5),
+		}}},
+		{source: Source{Server: "docs", Weight: 2}, response: &SearchResponse{Results: []SearchResult{
+			chunk("d1", "shared readme text", 0.4),
+			chunk("d2", "Login guide", 0.3),
+		}}},
+		{source: Source{Server: "tickets"}, err: errors.New("connection refused")},
+	}
+
+	merged, err := mergeFederated(SearchRequest{Query: "login", TopK: 3}, collected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// docs scores are doubled; the duplicate README keeps its best score
+	var got []string
+	for _, r := range merged.Results {
+		got = append(got, r.Server+"/"+r.ID)
+	}
+	if strings.Join(got, ",") != "code/c1,docs/d1,docs/d2" {
+		t.Errorf("results = %v", got)
+	}
+	if merged.Results[1].CombinedScore != 0.8 || merged.TotalResults != 3 {
+		t.Errorf("merged = %+v", merged)
+	}
+	if len(merged.FailedServers) != 1 || merged.FailedServers[0] != "tickets" || len(merged.Servers) != 3 {
+		t.Errorf("servers = %v, failed = %v", merged.Servers, merged.FailedServers)
+	}
+}
+
+func TestMergeFederatedAllFail(t *testing.T) {
+	_, err := mergeFederated(SearchRequest{}, []sourceResults{
+		{source: Source{Server: "a"}, err: errors.New("down")},
+		{source: Source{Server: "b"}, err: errors.New("down")},
+	})
+	if err == nil || !strings.Contains(err.Error(), "a: down; b: down") {
+		t.Errorf("err = %v", err)
+	}
+}
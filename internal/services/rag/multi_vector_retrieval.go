@@ -30,6 +30,7 @@ type VectorSearchConfig struct {
 	SimilarityThreshold float64                `json:"similarity_threshold"`
 	MaxResults          int                    `json:"max_results"`
 	Filters             map[string]interface{} `json:"filters,omitempty"`
+	Scope               *Scope                 `json:"-"` // Namespace and metadata filter
 }
 
 // MultiVectorSearchConfig defines configuration for multi-vector search
@@ -42,6 +43,7 @@ type MultiVectorSearchConfig struct {
 	GlobalThreshold   float64              `json:"global_threshold"`
 	CombinationMethod string               `json:"combination_method,omitempty"` // "weighted", "rrf", "max", "avg"
 	RerankTopK        int                  `json:"rerank_top_k,omitempty"`
+	Scope             *Scope               `json:"-"` // Namespace and metadata filter
 }
 
 // SearchResult represents a single search result with combined score
@@ -52,6 +54,35 @@ type SearchResult struct {
 	CombinedScore   float64                `json:"combined_score"`
 	ComponentScores map[string]float64     `json:"component_scores"`
 	Source          string                 `json:"source"`
+	Server          string                 `json:"server,omitempty"` // RAG server of a federated search
+}
+
+// JoinedText joins the result's text fields in key order
+func (r SearchResult) JoinedText() string {
+	keys := make([]string, 0, len(r.Text))
+	for key := range r.Text {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprint(r.Text[key]))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// SourceName names where the result came from: its source, or the source,
+// file or path field of its metadata
+func (r SearchResult) SourceName() string {
+	if r.Source != "" {
+		return r.Source
+	}
+	for _, key := range []string{"source", "file", "path"} {
+		if value, ok := r.Metadata[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
 }
 
 // MultiVectorRetriever provides advanced multi-vector retrieval capabilities
@@ -164,6 +195,7 @@ func (mvr *MultiVectorRetriever) searchSingleVectorColumn(ctx context.Context, q
 		SimilarityThreshold: threshold,
 		MaxResults:          maxResults,
 		Filters:             vectorCol.Filters,
+		Scope:               globalConfig.Scope,
 	}
 
 	// Discover available tools
@@ -493,6 +525,12 @@ func (mvr *MultiVectorRetriever) prepareSearchParameters(queryVector []float32,
 		params["filters"] = config.Filters
 	}
 
+	if config.Scope != nil {
+		for key, value := range config.Scope.params() {
+			params[key] = value
+		}
+	}
+
 	// For SQL-based tools, prepare a full query
 	toolNameLower := strings.ToLower(tool.Function.Name)
 	if strings.Contains(toolNameLower, "query") || strings.Contains(toolNameLower, "sql") {
@@ -530,6 +568,11 @@ func (mvr *MultiVectorRetriever) buildVectorSearchSQL(queryVector []float32, con
 			whereClause = "AND " + strings.Join(conditions, " AND ")
 		}
 	}
+	if config.Scope != nil {
+		if conditions := config.Scope.sqlConditions(); len(conditions) > 0 {
+			whereClause = strings.TrimSpace(whereClause + " AND " + strings.Join(conditions, " AND "))
+		}
+	}
 
 	sql := fmt.Sprintf(`
 		SELECT %s, 
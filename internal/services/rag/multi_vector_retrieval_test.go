@@ -0,0 +1,35 @@
+package rag
+
+import "testing"
+
+func TestSearchResultJoinedText(t *testing.T) {
+	result := SearchResult{Text: map[string]interface{}{"title": "Login", "content": "Use SSO", "page": 3}}
+	if got := result.JoinedText(); got != "Use SSO\n3\nLogin" {
+		t.Errorf("JoinedText() = %q, want the fields in key order", got)
+	}
+	if got := (SearchResult{}).JoinedText(); got != "" {
+		t.Errorf("JoinedText() without text = %q", got)
+	}
+}
+
+func TestSearchResultSourceName(t *testing.T) {
+	tests := []struct {
+		name   string
+		result SearchResult
+		want   string
+	}{
+		{"source field", SearchResult{Source: "a.md", Metadata: map[string]interface{}{"file": "b.md"}}, "a.md"},
+		{"metadata source", SearchResult{Metadata: map[string]interface{}{"source": "c.md", "file": "d.md"}}, "c.md"},
+		{"metadata file", SearchResult{Metadata: map[string]interface{}{"file": "d.md", "path": "e.md"}}, "d.md"},
+		{"metadata path", SearchResult{Metadata: map[string]interface{}{"source": "", "path": "e.md"}}, "e.md"},
+		{"non-string metadata", SearchResult{Metadata: map[string]interface{}{"source": 7}}, ""},
+		{"none", SearchResult{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.SourceName(); got != tt.want {
+				t.Errorf("SourceName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
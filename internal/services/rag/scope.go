@@ -0,0 +1,193 @@
+package rag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// Scope limits a search to a namespace and to chunks whose metadata match a
+// filter, so one corpus can serve many scoped workflows. Fields names the
+// server's metadata columns.
+type Scope struct {
+	Namespace string
+	Filter    *config.RagFilter
+	Fields    config.RagMetadataFields
+}
+
+// newScope returns the scope of a request, or nil when it has none
+func newScope(req SearchRequest, fields config.RagMetadataFields) *Scope {
+	if req.Namespace == "" && req.Filter == nil {
+		return nil
+	}
+	return &Scope{Namespace: req.Namespace, Filter: req.Filter, Fields: fields}
+}
+
+// equalityFilters returns the exact-match conditions of the scope: the
+// filter's equals values and the namespace
+func (sc *Scope) equalityFilters() map[string]interface{} {
+	filters := make(map[string]interface{})
+	if sc.Filter != nil {
+		for key, value := range sc.Filter.Equals {
+			filters[key] = value
+		}
+	}
+	if sc.Namespace != "" {
+		filters[sc.Fields.NamespaceField()] = sc.Namespace
+	}
+	return filters
+}
+
+// params returns the scope as search tool parameters. Servers that support
+// them filter on their side; the results are checked again afterwards.
+func (sc *Scope) params() map[string]interface{} {
+	params := make(map[string]interface{})
+	if sc.Namespace != "" {
+		params["namespace"] = sc.Namespace
+	}
+	if f := sc.Filter; f != nil {
+		metadata := make(map[string]interface{})
+		if f.PathPrefix != "" {
+			metadata["path_prefix"] = f.PathPrefix
+		}
+		if f.After != "" {
+			metadata["after"] = f.After
+		}
+		if f.Before != "" {
+			metadata["before"] = f.Before
+		}
+		if len(f.Tags) > 0 {
+			metadata["tags"] = f.Tags
+		}
+		if len(metadata) > 0 {
+			metadata["fields"] = map[string]string{
+				"path": sc.Fields.PathField(),
+				"date": sc.Fields.DateField(),
+				"tags": sc.Fields.TagsField(),
+			}
+			params["metadata_filter"] = metadata
+		}
+	}
+	return params
+}
+
+// sqlConditions returns the range, prefix and tag conditions of the scope
+// for SQL search tools (equality conditions are added with the other
+// filters). Tags columns are expected to be text arrays.
+func (sc *Scope) sqlConditions() []string {
+	f := sc.Filter
+	if f == nil {
+		return nil
+	}
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+
+	var conditions []string
+	if f.PathPrefix != "" {
+		prefix := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(f.PathPrefix)
+		conditions = append(conditions, fmt.Sprintf("%s LIKE %s", sc.Fields.PathField(), quote(prefix+"%")))
+	}
+	if f.After != "" {
+		conditions = append(conditions, fmt.Sprintf("%s >= %s", sc.Fields.DateField(), quote(f.After)))
+	}
+	if f.Before != "" {
+		conditions = append(conditions, fmt.Sprintf("%s < %s", sc.Fields.DateField(), quote(f.Before)))
+	}
+	if len(f.Tags) > 0 {
+		tags := make([]string, len(f.Tags))
+		for i, tag := range f.Tags {
+			tags[i] = quote(tag)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s @> ARRAY[%s]::text[]", sc.Fields.TagsField(), strings.Join(tags, ", ")))
+	}
+	return conditions
+}
+
+// matches checks a result against the scope. Conditions on columns the
+// result doesn't include can't be checked here and are left to the server.
+func (sc *Scope) matches(result SearchResult) bool {
+	for key, want := range sc.equalityFilters() {
+		if value, ok := resultField(result, key); ok && fmt.Sprint(value) != fmt.Sprint(want) {
+			return false
+		}
+	}
+
+	f := sc.Filter
+	if f == nil {
+		return true
+	}
+	if f.PathPrefix != "" {
+		if value, ok := resultField(result, sc.Fields.PathField()); ok && !strings.HasPrefix(fmt.Sprint(value), f.PathPrefix) {
+			return false
+		}
+	}
+	if f.After != "" || f.Before != "" {
+		if value, ok := resultField(result, sc.Fields.DateField()); ok {
+			if date, ok := ParseFilterDate(fmt.Sprint(value)); ok {
+				if after, ok := ParseFilterDate(f.After); ok && date.Before(after) {
+					return false
+				}
+				if before, ok := ParseFilterDate(f.Before); ok && !date.Before(before) {
+					return false
+				}
+			}
+		}
+	}
+	if len(f.Tags) > 0 {
+		if value, ok := resultField(result, sc.Fields.TagsField()); ok {
+			has := make(map[string]bool)
+			for _, tag := range tagList(value) {
+				has[tag] = true
+			}
+			for _, tag := range f.Tags {
+				if !has[tag] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// ParseFilterDate parses a filter date: YYYY-MM-DD or RFC 3339
+func ParseFilterDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// resultField looks a column up in a result's metadata, then its text
+func resultField(result SearchResult, name string) (interface{}, bool) {
+	if value, ok := result.Metadata[name]; ok && value != nil {
+		return value, true
+	}
+	if value, ok := result.Text[name]; ok && value != nil {
+		return value, true
+	}
+	return nil, false
+}
+
+// tagList reads a tags column: a list, or a comma-separated string such as
+// a Postgres array literal
+func tagList(value interface{}) []string {
+	var tags []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			tags = append(tags, fmt.Sprint(item))
+		}
+	case []string:
+		tags = v
+	default:
+		for _, tag := range strings.Split(strings.Trim(fmt.Sprint(v), "{}[]"), ",") {
+			if tag = strings.Trim(strings.TrimSpace(tag), `"'`); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
@@ -0,0 +1,73 @@
+package rag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+func TestScopeMatches(t *testing.T) {
+	scope := &Scope{
+		Namespace: "team-a",
+		Filter: &config.RagFilter{
+			PathPrefix: "docs/",
+			After:      "2024-01-01",
+			Before:     "2025-01-01",
+			Tags:       []string{"security"},
+			Equals:     map[string]interface{}{"lang": "en"},
+		},
+		Fields: config.RagMetadataFields{Date: "updated_at"},
+	}
+	result := func(metadata map[string]interface{}) SearchResult {
+		return SearchResult{Metadata: metadata}
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		want     bool
+	}{
+		{"all match", map[string]interface{}{"namespace": "team-a", "path": "docs/auth.md", "updated_at": "2024-06-01T10:00:00Z", "tags": []interface{}{"security", "auth"}, "lang": "en"}, true},
+		{"columns missing are left to the server", map[string]interface{}{}, true},
+		{"other namespace", map[string]interface{}{"namespace": "team-b"}, false},
+		{"path outside prefix", map[string]interface{}{"path": "src/auth.go"}, false},
+		{"too old", map[string]interface{}{"updated_at": "2023-12-31"}, false},
+		{"before is exclusive", map[string]interface{}{"updated_at": "2025-01-01"}, false},
+		{"missing tag", map[string]interface{}{"tags": "{auth,billing}"}, false},
+		{"postgres array tags", map[string]interface{}{"tags": "{auth,security}"}, true},
+		{"other value", map[string]interface{}{"lang": "de"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scope.matches(result(tt.metadata)); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeSQLAndParams(t *testing.T) {
+	scope := &Scope{
+		Namespace: "team-a",
+		Filter:    &config.RagFilter{PathPrefix: "it's_docs/", Tags: []string{"a"}},
+	}
+
+	got := strings.Join(scope.sqlConditions(), " AND ")
+	want := `path LIKE 'it''s\_docs/%' AND tags @> ARRAY['a']::text[]`
+	if got != want {
+		t.Errorf("sqlConditions() = %s, want %s", got, want)
+	}
+
+	params := scope.params()
+	if params["namespace"] != "team-a" {
+		t.Errorf("namespace param = %v", params["namespace"])
+	}
+	metadata, _ := params["metadata_filter"].(map[string]interface{})
+	if metadata["path_prefix"] != "it's_docs/" {
+		t.Errorf("metadata_filter = %v", metadata)
+	}
+	if filters := scope.equalityFilters(); filters["namespace"] != "team-a" {
+		t.Errorf("equalityFilters() = %v", filters)
+	}
+}
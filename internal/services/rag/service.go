@@ -18,7 +18,6 @@ type Service struct {
 	configService    *infraConfig.Service
 	serverManager    domain.MCPServerManager
 	embeddingService domain.EmbeddingService
-	retriever        *MultiVectorRetriever
 	expander         *QueryExpander
 	ragConfig        *config.RagConfig
 }
@@ -33,9 +32,6 @@ func NewService(configService *infraConfig.Service, serverManager domain.MCPServ
 
 // NewServiceWithConfig creates a new RAG service with provided config
 func NewServiceWithConfig(ragConfig *config.RagConfig, serverManager domain.MCPServerManager, embeddingService domain.EmbeddingService) *Service {
-	// Initialize retriever
-	retriever := NewMultiVectorRetriever(serverManager, ragConfig.DefaultServer)
-
 	// Load expansion dictionaries
 	expansionConfig, err := loadExpansionConfig(ragConfig.QueryExpansion)
 	if err != nil {
@@ -51,7 +47,6 @@ func NewServiceWithConfig(ragConfig *config.RagConfig, serverManager domain.MCPS
 		configService:    nil, // Not needed when config is provided directly
 		serverManager:    serverManager,
 		embeddingService: embeddingService,
-		retriever:        retriever,
 		expander:         expander,
 		ragConfig:        ragConfig,
 	}
@@ -66,6 +61,8 @@ type SearchRequest struct {
 	Fusion      string                 // Fusion method (rrf, weighted, max, avg)
 	ExpandQuery bool                   // Enable query expansion
 	Filters     map[string]interface{} // Additional filters
+	Filter      *config.RagFilter      // Metadata conditions (path prefix, dates, tags, values)
+	Namespace   string                 // Only chunks in this namespace
 }
 
 // SearchResponse represents a RAG search response
@@ -77,6 +74,8 @@ type SearchResponse struct {
 	Fusion          string         `json:"fusion,omitempty"`
 	TotalResults    int            `json:"total_results"`
 	ExecutionTimeMs int64          `json:"execution_time_ms"`
+	Servers         []string       `json:"servers,omitempty"`        // Servers a federated search queried
+	FailedServers   []string       `json:"failed_servers,omitempty"` // Servers whose search failed
 }
 
 // Search performs a RAG search
@@ -130,12 +129,27 @@ func (s *Service) Search(ctx context.Context, req SearchRequest) (*SearchRespons
 		return nil, fmt.Errorf("failed to generate query embedding: %w", err)
 	}
 
-	// Execute search
-	results, err := s.retriever.Search(ctx, queryVector, searchConfig)
+	// Execute search, using the server's search_tool when it names one
+	retriever := &MultiVectorRetriever{serverManager: s.serverManager, serverName: req.Server, ragConfig: s.ragConfig}
+	results, err := retriever.Search(ctx, queryVector, searchConfig)
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
+	// Check the scope again; servers may ignore filters they don't support
+	if searchConfig.Scope != nil {
+		kept := results[:0]
+		for _, result := range results {
+			if searchConfig.Scope.matches(result) {
+				kept = append(kept, result)
+			}
+		}
+		if dropped := len(results) - len(kept); dropped > 0 {
+			logging.Debug("Dropped %d results outside the filter or namespace", dropped)
+		}
+		results = kept
+	}
+
 	logging.Info("✅ RAG Search completed: %d results", len(results))
 
 	return &SearchResponse{
@@ -297,6 +311,18 @@ func (s *Service) buildSearchConfig(req SearchRequest, serverConfig config.RagSe
 		MetadataColumns:   serverConfig.MetadataColumns,
 		GlobalMaxResults:  req.TopK,
 		CombinationMethod: req.Fusion,
+		Scope:             newScope(req, serverConfig.MetadataFields),
+	}
+
+	// Exact-match conditions go with the request's filters
+	filters := make(map[string]interface{}, len(req.Filters))
+	for k, v := range req.Filters {
+		filters[k] = v
+	}
+	if searchConfig.Scope != nil {
+		for k, v := range searchConfig.Scope.equalityFilters() {
+			filters[k] = v
+		}
 	}
 
 	// Build vector column configs
@@ -313,13 +339,15 @@ func (s *Service) buildSearchConfig(req SearchRequest, serverConfig config.RagSe
 				}
 
 				// Apply request-level filters
-				if req.Filters != nil {
-					if vectorCol.Filters == nil {
-						vectorCol.Filters = make(map[string]interface{})
+				if len(filters) > 0 {
+					merged := make(map[string]interface{}, len(vectorCol.Filters)+len(filters))
+					for k, v := range vectorCol.Filters {
+						merged[k] = v
 					}
-					for k, v := range req.Filters {
-						vectorCol.Filters[k] = v
+					for k, v := range filters {
+						merged[k] = v
 					}
+					vectorCol.Filters = merged
 				}
 
 				searchConfig.VectorColumns = append(searchConfig.VectorColumns, vectorCol)
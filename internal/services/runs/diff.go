@@ -0,0 +1,202 @@
+// Package runs compares recorded workflow runs step by step, to show which
+// step outputs changed after a prompt, model or config modification.
+package runs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/vector"
+	workflow "github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+)
+
+// Step change statuses
+const (
+	StepUnchanged = "unchanged"
+	StepChanged   = "changed"
+	StepAdded     = "added"   // Only in run B
+	StepRemoved   = "removed" // Only in run A
+)
+
+// DefaultContext is the number of unchanged lines shown around each change
+const DefaultContext = 3
+
+// EmbedFunc returns an embedding vector for text
+type EmbedFunc func(ctx context.Context, text string) ([]float32, error)
+
+// Options controls a comparison
+type Options struct {
+	Context int       // Context lines in diffs (default: DefaultContext, -1 for none)
+	Embed   EmbedFunc // Scores the similarity of changed outputs when set
+}
+
+// Report is the comparison of two recorded runs
+type Report struct {
+	A       RunInfo     `json:"a"`
+	B       RunInfo     `json:"b"`
+	Changes []Change    `json:"changes,omitempty"` // Run-level differences (input, params, provider, model)
+	Steps   []StepDiff  `json:"steps"`
+	Summary DiffSummary `json:"summary"`
+}
+
+// RunInfo identifies one side of the comparison
+type RunInfo struct {
+	Name     string `json:"name"`
+	Workflow string `json:"workflow"`
+	Version  string `json:"version,omitempty"`
+	Started  string `json:"started"`
+	Status   string `json:"status"`
+}
+
+// Change is a configuration value that differs between the runs
+type Change struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// StepDiff is the comparison of one step across the runs
+type StepDiff struct {
+	Step         string   `json:"step"`
+	Status       string   `json:"status"`
+	Changes      []Change `json:"changes,omitempty"`    // Provider, model, prompt or status differences
+	Similarity   *float64 `json:"similarity,omitempty"` // Embedding cosine similarity of the outputs
+	LinesAdded   int      `json:"lines_added"`
+	LinesRemoved int      `json:"lines_removed"`
+	Diff         string   `json:"diff,omitempty"`
+	EmbedError   string   `json:"embed_error,omitempty"`
+}
+
+// DiffSummary counts steps by status
+type DiffSummary struct {
+	Unchanged int `json:"unchanged"`
+	Changed   int `json:"changed"`
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+}
+
+// Diff compares two recorded runs. Steps follow run A's order, with steps
+// only in run B appended in their own order.
+func Diff(ctx context.Context, a, b *workflow.RunRecord, opts Options) *Report {
+	if opts.Context == 0 {
+		opts.Context = DefaultContext
+	}
+	if opts.Context < 0 {
+		opts.Context = 0
+	}
+
+	report := &Report{A: runInfo(a), B: runInfo(b)}
+	report.Changes = runChanges(a, b)
+
+	for i := range a.Steps {
+		stepA := &a.Steps[i]
+		stepB := b.Step(stepA.Name)
+		if stepB == nil {
+			report.Steps = append(report.Steps, StepDiff{Step: stepA.Name, Status: StepRemoved})
+			continue
+		}
+		report.Steps = append(report.Steps, diffStep(ctx, stepA, stepB, &opts))
+	}
+	for i := range b.Steps {
+		if a.Step(b.Steps[i].Name) == nil {
+			report.Steps = append(report.Steps, StepDiff{Step: b.Steps[i].Name, Status: StepAdded})
+		}
+	}
+
+	for _, step := range report.Steps {
+		switch step.Status {
+		case StepUnchanged:
+			report.Summary.Unchanged++
+		case StepChanged:
+			report.Summary.Changed++
+		case StepAdded:
+			report.Summary.Added++
+		case StepRemoved:
+			report.Summary.Removed++
+		}
+	}
+	return report
+}
+
+// diffStep compares one step's configuration and output
+func diffStep(ctx context.Context, a, b *workflow.StepRecord, opts *Options) StepDiff {
+	result := StepDiff{Step: a.Name, Status: StepUnchanged}
+	result.Changes = appendChange(result.Changes, "provider", a.Provider, b.Provider)
+	result.Changes = appendChange(result.Changes, "model", a.Model, b.Model)
+	result.Changes = appendChange(result.Changes, "prompt", a.Prompt, b.Prompt)
+	result.Changes = appendChange(result.Changes, "status", a.Status, b.Status)
+
+	if a.Output != b.Output {
+		result.Status = StepChanged
+		result.Diff, result.LinesAdded, result.LinesRemoved = UnifiedDiff("a/"+a.Name, "b/"+b.Name, a.Output, b.Output, opts.Context)
+
+		if opts.Embed != nil && a.Output != "" && b.Output != "" {
+			similarity, err := embeddingSimilarity(ctx, opts.Embed, a.Output, b.Output)
+			if err != nil {
+				result.EmbedError = err.Error()
+			} else {
+				result.Similarity = &similarity
+			}
+		}
+	} else if len(result.Changes) > 0 {
+		// Same output from a different configuration is still worth flagging
+		result.Status = StepChanged
+	}
+	return result
+}
+
+func embeddingSimilarity(ctx context.Context, embed EmbedFunc, a, b string) (float64, error) {
+	vecA, err := embed(ctx, a)
+	if err != nil {
+		return 0, fmt.Errorf("failed to embed run A output: %w", err)
+	}
+	vecB, err := embed(ctx, b)
+	if err != nil {
+		return 0, fmt.Errorf("failed to embed run B output: %w", err)
+	}
+	return vector.CosineSimilarity(vecA, vecB), nil
+}
+
+// runChanges lists the run-level differences
+func runChanges(a, b *workflow.RunRecord) []Change {
+	var changes []Change
+	changes = appendChange(changes, "version", a.Version, b.Version)
+	changes = appendChange(changes, "provider", a.Provider, b.Provider)
+	changes = appendChange(changes, "model", a.Model, b.Model)
+	changes = appendChange(changes, "input", a.Input, b.Input)
+
+	names := make(map[string]bool)
+	for name := range a.Params {
+		names[name] = true
+	}
+	for name := range b.Params {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		changes = appendChange(changes, "params."+name, a.Params[name], b.Params[name])
+	}
+	return changes
+}
+
+func appendChange(changes []Change, field, a, b string) []Change {
+	if a == b {
+		return changes
+	}
+	return append(changes, Change{Field: field, A: a, B: b})
+}
+
+func runInfo(r *workflow.RunRecord) RunInfo {
+	return RunInfo{
+		Name:     r.Name(),
+		Workflow: r.Workflow,
+		Version:  r.Version,
+		Started:  r.Started.Format("2006-01-02 15:04:05"),
+		Status:   r.Status,
+	}
+}
@@ -0,0 +1,99 @@
+package runs
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	workflow "github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+	b := "one\ntwo\nTHREE\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\n"
+
+	diff, added, removed := UnifiedDiff("a/x", "b/x", a, b, 1)
+	if added != 2 || removed != 1 {
+		t.Errorf("added/removed = %d/%d, want 2/1", added, removed)
+	}
+	want := "--- a/x\n+++ b/x\n" +
+		"@@ -2,3 +2,3 @@\n two\n-three\n+THREE\n four\n" +
+		"@@ -10,1 +10,2 @@\n ten\n+eleven"
+	if diff != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", diff, want)
+	}
+
+	if diff, _, _ := UnifiedDiff("a", "b", a, a, 3); diff != "" {
+		t.Errorf("identical texts should produce no diff, got %q", diff)
+	}
+	if diff, added, _ := UnifiedDiff("a", "b", "", "new\n", 3); added != 1 || !strings.Contains(diff, "@@ -0,0 +1,1 @@") {
+		t.Errorf("diff from empty = %q", diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := &workflow.RunRecord{
+		Workflow: "summarize", Dir: "/runs/summarize-1", Started: time.Now(), Status: workflow.RunSucceeded,
+		Model:  "gpt-4o",
+		Params: map[string]string{"tone": "formal"},
+		Steps: []workflow.StepRecord{
+			{Name: "fetch", Model: "gpt-4o", Output: "data"},
+			{Name: "summarize", Model: "gpt-4o", Prompt: "Summarize {{fetch}}", Output: "A short summary.\nSecond line."},
+			{Name: "legacy", Output: "old"},
+		},
+	}
+	b := &workflow.RunRecord{
+		Workflow: "summarize", Dir: "/runs/summarize-2", Started: time.Now(), Status: workflow.RunSucceeded,
+		Model:  "claude-sonnet-4",
+		Params: map[string]string{"tone": "casual"},
+		Steps: []workflow.StepRecord{
+			{Name: "fetch", Model: "gpt-4o", Output: "data"},
+			{Name: "summarize", Model: "claude-sonnet-4", Prompt: "Summarize {{fetch}}", Output: "A brief summary.\nSecond line."},
+			{Name: "publish", Output: "done"},
+		},
+	}
+
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		if strings.Contains(text, "brief") {
+			return []float32{1, 1}, nil
+		}
+		return []float32{1, 0}, nil
+	}
+
+	report := Diff(context.Background(), a, b, Options{Embed: embed})
+
+	wantStatus := []struct{ step, status string }{
+		{"fetch", StepUnchanged}, {"summarize", StepChanged}, {"legacy", StepRemoved}, {"publish", StepAdded},
+	}
+	if len(report.Steps) != len(wantStatus) {
+		t.Fatalf("steps = %d, want %d", len(report.Steps), len(wantStatus))
+	}
+	for i, want := range wantStatus {
+		if got := report.Steps[i]; got.Step != want.step || got.Status != want.status {
+			t.Errorf("step %d = %s/%s, want %s/%s", i, got.Step, got.Status, want.step, want.status)
+		}
+	}
+
+	changed := report.Steps[1]
+	if changed.Similarity == nil || *changed.Similarity < 0.70 || *changed.Similarity > 0.71 {
+		t.Errorf("similarity = %v, want ~0.707", changed.Similarity)
+	}
+	if changedFields(changed.Changes) != "model" {
+		t.Errorf("changes = %v, want model", changed.Changes)
+	}
+	if fields := changedFields(report.Changes); fields != "model, params.tone" {
+		t.Errorf("run changes = %s", fields)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSummary(&buf, report, true, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"model: gpt-4o -> claude-sonnet-4", "1 changed, 1 unchanged, 1 added, 1 removed", "=== summarize ===", "-A short summary.", "+A brief summary."} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("summary missing %q:\n%s", want, buf.String())
+		}
+	}
+}
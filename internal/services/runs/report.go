@@ -0,0 +1,106 @@
+package runs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteJSON writes the report as indented JSON
+func WriteJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteSummary writes the run-level changes, a per-step table and, when
+// showDiffs is set, the output diff of every changed step. highlight is
+// applied to each diff (pass nil for plain text).
+func WriteSummary(w io.Writer, report *Report, showDiffs bool, highlight func(string) string) error {
+	fmt.Fprintf(w, "a: %s (%s, %s)\n", report.A.Name, report.A.Started, report.A.Status)
+	fmt.Fprintf(w, "b: %s (%s, %s)\n", report.B.Name, report.B.Started, report.B.Status)
+
+	if len(report.Changes) > 0 {
+		fmt.Fprintln(w, "\nRun changes:")
+		for _, change := range report.Changes {
+			fmt.Fprintf(w, "  %s: %s -> %s\n", change.Field, oneLine(change.A, 60), oneLine(change.B, 60))
+		}
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STEP\tSTATUS\tSIMILARITY\tLINES\tCHANGED")
+	for _, step := range report.Steps {
+		similarity := "-"
+		if step.Similarity != nil {
+			similarity = fmt.Sprintf("%.3f", *step.Similarity)
+		}
+		lines := "-"
+		if step.LinesAdded > 0 || step.LinesRemoved > 0 {
+			lines = fmt.Sprintf("+%d/-%d", step.LinesAdded, step.LinesRemoved)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", step.Step, step.Status, similarity, lines, changedFields(step.Changes))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	s := report.Summary
+	fmt.Fprintf(w, "\n%d changed, %d unchanged, %d added, %d removed\n", s.Changed, s.Unchanged, s.Added, s.Removed)
+
+	if !showDiffs {
+		return nil
+	}
+	if highlight == nil {
+		highlight = func(text string) string { return text }
+	}
+	for _, step := range report.Steps {
+		if step.Status != StepChanged {
+			continue
+		}
+		fmt.Fprintf(w, "\n=== %s ===\n", step.Step)
+		for _, change := range step.Changes {
+			if change.Field == "prompt" && (strings.Contains(change.A, "\n") || strings.Contains(change.B, "\n")) {
+				diff, _, _ := UnifiedDiff("a/prompt", "b/prompt", change.A, change.B, DefaultContext)
+				fmt.Fprintf(w, "prompt:\n%s\n", highlight(diff))
+				continue
+			}
+			fmt.Fprintf(w, "%s: %s -> %s\n", change.Field, oneLine(change.A, 100), oneLine(change.B, 100))
+		}
+		if step.EmbedError != "" {
+			fmt.Fprintf(w, "similarity: %s\n", step.EmbedError)
+		}
+		if step.Diff != "" {
+			fmt.Fprintln(w, highlight(step.Diff))
+		} else {
+			fmt.Fprintln(w, "(output identical)")
+		}
+	}
+	return nil
+}
+
+// changedFields lists the names of the changed step settings
+func changedFields(changes []Change) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+	return strings.Join(fields, ", ")
+}
+
+// oneLine collapses whitespace and truncates a value for display
+func oneLine(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return `""`
+	}
+	if len(s) > n {
+		return s[:n] + "..."
+	}
+	return s
+}
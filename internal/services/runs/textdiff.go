@@ -0,0 +1,191 @@
+package runs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiffCells bounds the line-matching table; larger inputs are diffed as
+// a whole-text replacement
+const maxDiffCells = 4_000_000
+
+// lineOp is one line of an edit script
+type lineOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// UnifiedDiff returns a unified diff of two texts with the given number of
+// context lines, or "" when they are identical. It also returns the number
+// of added and removed lines.
+func UnifiedDiff(nameA, nameB, a, b string, context int) (string, int, int) {
+	if a == b {
+		return "", 0, 0
+	}
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	added, removed := 0, 0
+	for _, op := range ops {
+		switch op.kind {
+		case '+':
+			added++
+		case '-':
+			removed++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", nameA, nameB)
+	for _, h := range hunks(ops, context) {
+		sb.WriteString(h)
+	}
+	return strings.TrimRight(sb.String(), "\n"), added, removed
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a minimal edit script using the longest common
+// subsequence of lines
+func diffLines(a, b []string) []lineOp {
+	// Trim the common prefix and suffix so the table only covers the changed region
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []lineOp
+	for _, line := range a[:prefix] {
+		ops = append(ops, lineOp{' ', line})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, lineOp{' ', line})
+	}
+	return ops
+}
+
+func diffMiddle(a, b []string) []lineOp {
+	var ops []lineOp
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			ops = append(ops, lineOp{'-', line})
+		}
+		for _, line := range b {
+			ops = append(ops, lineOp{'+', line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, lineOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, lineOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, lineOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, lineOp{'+', b[j]})
+	}
+	return ops
+}
+
+// hunks groups an edit script into @@ hunks with surrounding context
+func hunks(ops []lineOp, context int) []string {
+	var result []string
+	lineA, lineB := 1, 1
+	// Positions in both texts at the start of each op
+	startA := make([]int, len(ops))
+	startB := make([]int, len(ops))
+	for k, op := range ops {
+		startA[k], startB[k] = lineA, lineB
+		if op.kind != '+' {
+			lineA++
+		}
+		if op.kind != '-' {
+			lineB++
+		}
+	}
+
+	for k := 0; k < len(ops); {
+		if ops[k].kind == ' ' {
+			k++
+			continue
+		}
+
+		// Extend the hunk while changes are within 2*context lines of each other
+		first := max(0, k-context)
+		last := k
+		for next := k; next < len(ops); next++ {
+			if ops[next].kind == ' ' {
+				if next-last > 2*context {
+					break
+				}
+				continue
+			}
+			last = next
+		}
+		end := min(len(ops), last+context+1)
+
+		countA, countB := 0, 0
+		var body strings.Builder
+		for _, op := range ops[first:end] {
+			if op.kind != '+' {
+				countA++
+			}
+			if op.kind != '-' {
+				countB++
+			}
+			body.WriteByte(op.kind)
+			body.WriteString(op.text)
+			body.WriteByte('\n')
+		}
+		result = append(result, fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s",
+			hunkStart(startA[first], countA), countA, hunkStart(startB[first], countB), countB, body.String()))
+		k = end
+	}
+	return result
+}
+
+// hunkStart follows the unified format convention of reporting the line
+// before an empty range
+func hunkStart(line, count int) int {
+	if count == 0 {
+		return line - 1
+	}
+	return line
+}
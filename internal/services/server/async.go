@@ -0,0 +1,250 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jobqueue"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/notify"
+)
+
+// GetRunResultToolName is the built-in tool that polls async runs
+const GetRunResultToolName = "get_run_result"
+
+// maxRunResultWait caps how long get_run_result blocks for a running run
+const maxRunResultWait = 2 * time.Minute
+
+// callbackAttempts is how many times a callback POST is tried
+const callbackAttempts = 3
+
+// asyncEnabled reports whether the server offers get_run_result: some tool
+// is async and no configured tool already uses the name
+func (s *Service) asyncEnabled() bool {
+	if _, taken := s.runasConfig.GetToolByName(GetRunResultToolName); taken {
+		return false
+	}
+	return s.taskManager != nil && s.runasConfig.HasAsyncTools()
+}
+
+// getRunResultTool describes the get_run_result tool for tools/list
+func getRunResultTool() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        GetRunResultToolName,
+		"description": "Fetch the status and result of an async run started by another tool, using the run_id it returned.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"run_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Run ID returned when the run started",
+				},
+				"wait_seconds": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Wait up to this many seconds (max %d) for a running run to finish", int(maxRunResultWait.Seconds())),
+					"minimum":     0,
+				},
+			},
+			"required": []string{"run_id"},
+		},
+	}
+}
+
+// asyncInputSchema adds the callback_url argument to an async tool's
+// schema when clients may choose where results are delivered
+func (s *Service) asyncInputSchema(schema map[string]interface{}) map[string]interface{} {
+	if s.runasConfig.Async == nil || len(s.runasConfig.Async.AllowedCallbackURLs) == 0 || schema == nil {
+		return schema
+	}
+
+	properties := make(map[string]interface{})
+	if existing, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, property := range existing {
+			properties[name] = property
+		}
+	}
+	properties["callback_url"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Optional URL the result is POSTed to when the run finishes. Allowed prefixes: " + strings.Join(s.runasConfig.Async.AllowedCallbackURLs, ", "),
+	}
+
+	extended := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		extended[key] = value
+	}
+	extended["properties"] = properties
+	return extended
+}
+
+// takeCallbackURL removes the callback_url argument of an async tool call
+// and returns where its result should be delivered ("" for nowhere)
+func (s *Service) takeCallbackURL(params map[string]interface{}) (string, error) {
+	defaultURL := ""
+	if s.runasConfig.Async != nil {
+		defaultURL = s.runasConfig.Async.CallbackURL
+	}
+
+	arguments, ok := params["arguments"].(map[string]interface{})
+	if !ok {
+		return defaultURL, nil
+	}
+	requested, present := arguments["callback_url"]
+	if !present {
+		return defaultURL, nil
+	}
+	delete(arguments, "callback_url")
+
+	callbackURL, _ := requested.(string)
+	if callbackURL == "" {
+		return defaultURL, nil
+	}
+	if !s.runasConfig.Async.CallbackAllowed(callbackURL) {
+		return "", fmt.Errorf("callback_url '%s' is not allowed by this server", callbackURL)
+	}
+	return callbackURL, nil
+}
+
+// handleAsyncToolCall starts a tool in the background and returns its run
+// ID at once. The run ID is a task ID, so tasks/get also works for it.
+func (s *Service) handleAsyncToolCall(toolName string, params map[string]interface{}, ticket *jobqueue.Ticket, callbackURL string) (map[string]interface{}, error) {
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		ticket.Release()
+		return nil, fmt.Errorf("failed to serialize request params: %w", err)
+	}
+
+	ttl := s.runasConfig.Async.GetResultTTL()
+	task, err := s.taskManager.CreateTask("tools/call", paramsJSON, ttl.Milliseconds(), s.owner)
+	if err != nil {
+		ticket.Release()
+		return nil, fmt.Errorf("failed to create run: %w", err)
+	}
+
+	logging.Info("Started async run %s of tool %s", task.ID, toolName)
+
+	go func() {
+		s.executeToolInBackground(task, toolName, params, ticket)
+		if callbackURL != "" {
+			s.deliverCallback(task, toolName, callbackURL)
+		}
+	}()
+
+	text := fmt.Sprintf("Started '%s' as an async run.\nrun_id: %s\n\nCall %s with this run_id to fetch the result.",
+		toolName, task.ID, GetRunResultToolName)
+	if callbackURL != "" {
+		text += " The result will also be posted to the callback URL."
+	}
+	return map[string]interface{}{
+		"content": []interface{}{
+			map[string]interface{}{
+				"type": "text",
+				"text": text,
+			},
+		},
+	}, nil
+}
+
+// handleGetRunResult reports an async run's status, or its result once
+// done. Only the client and tenant that started a run can fetch it.
+func (s *Service) handleGetRunResult(arguments map[string]interface{}) (map[string]interface{}, error) {
+	runID, _ := arguments["run_id"].(string)
+	if runID == "" {
+		return s.errorResponse("run_id is required"), nil
+	}
+
+	task, err := s.ownedTask(runID)
+	if err != nil {
+		return s.errorResponse(fmt.Sprintf("Unknown or expired run_id: %s", runID)), nil
+	}
+
+	if seconds, ok := arguments["wait_seconds"].(float64); ok && seconds > 0 {
+		wait := time.Duration(seconds * float64(time.Second))
+		if wait > maxRunResultWait {
+			wait = maxRunResultWait
+		}
+		select {
+		case <-task.Done:
+		case <-time.After(wait):
+		}
+	}
+
+	switch task.Status {
+	case domain.TaskStatusCompleted:
+		if result, ok := task.Result.(map[string]interface{}); ok {
+			return result, nil
+		}
+		return s.errorResponse(fmt.Sprintf("Run %s completed without a result", runID)), nil
+	case domain.TaskStatusFailed:
+		return s.errorResponse(fmt.Sprintf("Run %s failed: %s", runID, task.StatusMessage)), nil
+	case domain.TaskStatusCancelled:
+		return s.errorResponse(fmt.Sprintf("Run %s was canceled", runID)), nil
+	}
+
+	return map[string]interface{}{
+		"content": []interface{}{
+			map[string]interface{}{
+				"type": "text",
+				"text": fmt.Sprintf("Run %s is still running (started %s). Call %s again later.",
+					runID, task.CreatedAt.Format(time.RFC3339), GetRunResultToolName),
+			},
+		},
+	}, nil
+}
+
+// deliverCallback posts a finished run to its callback URL, retrying
+// failed deliveries with a growing delay
+func (s *Service) deliverCallback(task *domain.Task, toolName, callbackURL string) {
+	payload := map[string]interface{}{
+		"run_id":      task.ID,
+		"tool":        toolName,
+		"status":      task.Status,
+		"started_at":  task.CreatedAt.Format(time.RFC3339),
+		"finished_at": task.LastUpdatedAt.Format(time.RFC3339),
+	}
+	if task.Status == domain.TaskStatusCompleted {
+		payload["result"] = resultText(task.Result)
+	} else {
+		payload["error"] = task.StatusMessage
+	}
+
+	secret := ""
+	if s.runasConfig.Async != nil {
+		secret = s.runasConfig.Async.CallbackSecret
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := notify.PostSigned(context.Background(), callbackURL, payload, secret, 30*time.Second)
+		if err == nil {
+			logging.Info("Delivered result of run %s to callback", task.ID)
+			return
+		}
+		if attempt == callbackAttempts {
+			logging.Error("Failed to deliver result of run %s to callback after %d attempts: %v", task.ID, attempt, err)
+			return
+		}
+		logging.Warn("Callback for run %s failed (attempt %d): %v", task.ID, attempt, err)
+		time.Sleep(time.Duration(attempt*attempt) * time.Second)
+	}
+}
+
+// resultText joins the text content of a tool result
+func resultText(result interface{}) string {
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return fmt.Sprintf("%v", result)
+	}
+	content, _ := resultMap["content"].([]interface{})
+	var parts []string
+	for _, item := range content {
+		if block, ok := item.(map[string]interface{}); ok {
+			if text, ok := block["text"].(string); ok {
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(parts, "\n")
+}
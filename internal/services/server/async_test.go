@@ -0,0 +1,130 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tasks"
+)
+
+func newAsyncTestService(t *testing.T, async *runas.AsyncConfig) *Service {
+	t.Helper()
+	taskManager := tasks.NewManager(time.Minute, time.Hour, 100)
+	t.Cleanup(taskManager.Close)
+
+	s := NewService(&runas.RunAsConfig{
+		Tools: []runas.ToolExposure{{Name: "report", Template: "report", Async: true}},
+		Async: async,
+	}, nil, nil, nil)
+	s.SetTaskManager(taskManager)
+	return s
+}
+
+func toolText(result map[string]interface{}) string {
+	content, _ := result["content"].([]interface{})
+	if len(content) == 0 {
+		return ""
+	}
+	text, _ := content[0].(map[string]interface{})["text"].(string)
+	return text
+}
+
+func TestTakeCallbackURL(t *testing.T) {
+	s := newAsyncTestService(t, &runas.AsyncConfig{
+		CallbackURL:         "https://hooks.example.com/default",
+		AllowedCallbackURLs: []string{"https://hooks.example.com/"},
+	})
+
+	params := map[string]interface{}{"arguments": map[string]interface{}{"input_data": "x"}}
+	if url, err := s.takeCallbackURL(params); err != nil || url != "https://hooks.example.com/default" {
+		t.Errorf("default callback = %q, %v", url, err)
+	}
+
+	params = map[string]interface{}{"arguments": map[string]interface{}{"callback_url": "https://hooks.example.com/team"}}
+	if url, err := s.takeCallbackURL(params); err != nil || url != "https://hooks.example.com/team" {
+		t.Errorf("allowed callback = %q, %v", url, err)
+	}
+	if _, present := params["arguments"].(map[string]interface{})["callback_url"]; present {
+		t.Error("callback_url should be removed from the tool arguments")
+	}
+
+	params = map[string]interface{}{"arguments": map[string]interface{}{"callback_url": "http://169.254.169.254/"}}
+	if _, err := s.takeCallbackURL(params); err == nil {
+		t.Error("callback outside the allowed prefixes should be refused")
+	}
+}
+
+func TestCallbackAllowed(t *testing.T) {
+	async := &runas.AsyncConfig{AllowedCallbackURLs: []string{"https://hooks.example.com", "https://ci.example.com:8443/mcp/"}}
+	for callbackURL, want := range map[string]bool{
+		"https://hooks.example.com":                   true,
+		"https://hooks.example.com/runs":              true,
+		"https://HOOKS.example.com/runs":              true,
+		"https://hooks.example.com.attacker.net/runs": false,
+		"https://hooks.example.com@attacker.net/":     false,
+		"http://hooks.example.com/runs":               false,
+		"https://ci.example.com:8443/mcp/runs":        true,
+		"https://ci.example.com:8443/mcp":             true,
+		"https://ci.example.com:8443/mcp-admin":       false,
+		"https://ci.example.com:8443/mcp/../admin":    false,
+		"https://ci.example.com/mcp/runs":             false,
+		"ftp://hooks.example.com/":                    false,
+	} {
+		if got := async.CallbackAllowed(callbackURL); got != want {
+			t.Errorf("CallbackAllowed(%q) = %v, want %v", callbackURL, got, want)
+		}
+	}
+}
+
+func TestGetRunResult(t *testing.T) {
+	s := newAsyncTestService(t, nil)
+
+	listed, _ := s.HandleToolsList(nil)
+	tools := listed["tools"].([]map[string]interface{})
+	if len(tools) != 2 || tools[1]["name"] != GetRunResultToolName {
+		t.Fatalf("tools/list = %v, want report and %s", tools, GetRunResultToolName)
+	}
+
+	task, err := s.taskManager.CreateTask("tools/call", nil, 0, domain.TaskOwner{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := func(args map[string]interface{}) map[string]interface{} {
+		result, err := s.HandleToolsCall(map[string]interface{}{"name": GetRunResultToolName, "arguments": args})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if text := toolText(call(map[string]interface{}{"run_id": task.ID})); !strings.Contains(text, "still running") {
+		t.Errorf("running run = %q", text)
+	}
+
+	go task.SetResult(map[string]interface{}{"content": []interface{}{map[string]interface{}{"type": "text", "text": "done"}}})
+	if text := toolText(call(map[string]interface{}{"run_id": task.ID, "wait_seconds": 5.0})); text != "done" {
+		t.Errorf("completed run = %q, want done", text)
+	}
+
+	if result := call(map[string]interface{}{"run_id": "nope"}); result["isError"] != true {
+		t.Errorf("unknown run = %v, want isError", result)
+	}
+
+	// Another client's run is unknown to it
+	theirs, err := s.taskManager.CreateTask("tools/call", nil, 0, domain.TaskOwner{Client: "ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	theirs.SetResult(map[string]interface{}{"content": []interface{}{map[string]interface{}{"type": "text", "text": "secret"}}})
+	args := map[string]interface{}{"name": GetRunResultToolName, "arguments": map[string]interface{}{"run_id": theirs.ID}}
+	result, err := s.HandleToolsCallAs(args, "bot", "")
+	if err != nil || result["isError"] != true || strings.Contains(toolText(result), "secret") {
+		t.Errorf("bot fetching ops's run = %v, %v, want unknown run", result, err)
+	}
+	if result, _ := s.HandleToolsCallAs(args, "ops", ""); toolText(result) != "secret" {
+		t.Errorf("ops fetching its run = %v, want its result", result)
+	}
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/outputcrypt"
+)
+
+// resultsSubdir is the outputs subdirectory results of output: file tools
+// are saved in
+const resultsSubdir = "runas-results"
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+var backtickRun = regexp.MustCompile("`{3,}")
+
+// formatToolResult returns a workflow's result in the tool's output format
+func (s *Service) formatToolResult(toolExposure *runas.ToolExposure, result string) map[string]interface{} {
+	switch toolExposure.Output {
+	case runas.OutputMarkdown:
+		return textResult(fenceMarkdown(result))
+	case runas.OutputJSON:
+		return jsonResult(result)
+	case runas.OutputFile:
+		fileResult, err := s.fileResult(toolExposure.Name, result)
+		if err != nil {
+			logging.Warn("Failed to save result of tool %s, returning it as text: %v", toolExposure.Name, err)
+			return textResult(result)
+		}
+		return fileResult
+	}
+	return textResult(result)
+}
+
+func textResult(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []interface{}{
+			map[string]interface{}{
+				"type": "text",
+				"text": text,
+			},
+		},
+	}
+}
+
+// fenceMarkdown wraps the result in a code block, tagged json when it is
+// JSON. The fence is longer than any backtick run inside the result.
+func fenceMarkdown(result string) string {
+	fence := "```"
+	for _, run := range backtickRun.FindAllString(result, -1) {
+		if len(run) >= len(fence) {
+			fence = strings.Repeat("`", len(run)+1)
+		}
+	}
+
+	language := ""
+	if isJSON(result) {
+		language = "json"
+	}
+	return fmt.Sprintf("%s%s\n%s\n%s", fence, language, strings.TrimRight(result, "\n"), fence)
+}
+
+// jsonResult returns the result as structuredContent. A JSON object is
+// used as it is; any other result is wrapped as {"result": ...}. The text
+// block repeats the JSON for clients without structured content support.
+func jsonResult(result string) map[string]interface{} {
+	var structured map[string]interface{}
+	if err := json.Unmarshal([]byte(result), &structured); err != nil || structured == nil {
+		var value interface{} = result
+		if isJSON(result) {
+			_ = json.Unmarshal([]byte(result), &value)
+		}
+		structured = map[string]interface{}{"result": value}
+	}
+
+	text, err := json.MarshalIndent(structured, "", "  ")
+	if err != nil {
+		return textResult(result)
+	}
+	formatted := textResult(string(text))
+	formatted["structuredContent"] = structured
+	return formatted
+}
+
+// fileResult saves the result under the outputs directory and returns a
+// resource link to it, with a text block naming the path for clients that
+// don't show resource links
+func (s *Service) fileResult(toolName, result string) (map[string]interface{}, error) {
+	outputsDir := "/tmp/mcp-outputs"
+	if s.appConfig != nil {
+		outputsDir = s.appConfig.Skills.GetOutputsDir()
+	}
+	dir := filepath.Join(outputsDir, resultsSubdir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	ext, mimeType := ".md", "text/markdown"
+	if isJSON(result) {
+		ext, mimeType = ".json", "application/json"
+	}
+	base := strings.Trim(unsafeNameChars.ReplaceAllString(toolName, "_"), "_")
+	if base == "" {
+		base = "result"
+	}
+
+	file, err := os.CreateTemp(dir, base+"-*"+ext)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.WriteString(result); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	path, err := filepath.Abs(file.Name())
+	if err != nil {
+		path = file.Name()
+	}
+
+	// With outputs encryption the client is pointed at the sealed file
+	size := len(result)
+	if s.appConfig != nil {
+		key, err := outputcrypt.KeyFrom(s.appConfig.Skills)
+		if err == nil && key != nil {
+			path, err = outputcrypt.SealFile(key, path)
+		}
+		if err != nil {
+			os.Remove(file.Name())
+			return nil, err
+		}
+		if key != nil {
+			mimeType = "application/octet-stream"
+			if info, err := os.Stat(path); err == nil {
+				size = int(info.Size())
+			}
+		}
+	}
+	logging.Info("Saved result of tool %s to %s", toolName, path)
+
+	return map[string]interface{}{
+		"content": []interface{}{
+			map[string]interface{}{
+				"type": "text",
+				"text": fmt.Sprintf("Result saved to %s (%d bytes)", path, len(result)),
+			},
+			map[string]interface{}{
+				"type":     "resource_link",
+				"uri":      fileURI(path),
+				"name":     filepath.Base(path),
+				"mimeType": mimeType,
+				"size":     size,
+			},
+		},
+	}, nil
+}
+
+// fileURI returns the file:// URI of an absolute path
+func fileURI(path string) string {
+	slashed := filepath.ToSlash(path)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed // Windows drive paths
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
+
+// isJSON reports whether the result is a JSON object or array
+func isJSON(result string) bool {
+	trimmed := strings.TrimSpace(result)
+	return (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed))
+}
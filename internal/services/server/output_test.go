@@ -0,0 +1,66 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
+)
+
+func TestFenceMarkdown(t *testing.T) {
+	if got := fenceMarkdown(`{"ok": true}`); got != "```json\n{\"ok\": true}\n```" {
+		t.Errorf("JSON result fenced as %q", got)
+	}
+
+	// A code block inside the result must not close the fence early
+	got := fenceMarkdown("Example:\n```go\nx := 1\n```\n")
+	if !strings.HasPrefix(got, "````\n") || !strings.HasSuffix(got, "\n````") {
+		t.Errorf("result with a code block fenced as %q", got)
+	}
+}
+
+func TestJSONResult(t *testing.T) {
+	result := jsonResult(`{"total": 3}`)
+	structured, _ := result["structuredContent"].(map[string]interface{})
+	if structured["total"] != float64(3) {
+		t.Errorf("structuredContent = %v, want the object as is", result["structuredContent"])
+	}
+
+	result = jsonResult("plain text")
+	structured, _ = result["structuredContent"].(map[string]interface{})
+	if structured["result"] != "plain text" {
+		t.Errorf("structuredContent = %v, want text wrapped in result", result["structuredContent"])
+	}
+	if !strings.Contains(toolText(result), `"result": "plain text"`) {
+		t.Errorf("text = %q, want the JSON repeated", toolText(result))
+	}
+}
+
+func TestFileResult(t *testing.T) {
+	dir := t.TempDir()
+	s := NewService(&runas.RunAsConfig{}, &config.ApplicationConfig{Skills: &config.SkillsConfig{OutputsDir: dir}}, nil, nil)
+
+	result := s.formatToolResult(&runas.ToolExposure{Name: "weekly report", Output: runas.OutputFile}, "# Report\n")
+	content, _ := result["content"].([]interface{})
+	if len(content) != 2 {
+		t.Fatalf("content = %v, want text and resource_link blocks", content)
+	}
+	link, _ := content[1].(map[string]interface{})
+	if link["type"] != "resource_link" || link["mimeType"] != "text/markdown" {
+		t.Errorf("link = %v", link)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, resultsSubdir, "weekly_report-*.md"))
+	if len(matches) != 1 {
+		t.Fatalf("saved files = %v, want one", matches)
+	}
+	if data, _ := os.ReadFile(matches[0]); string(data) != "# Report\n" {
+		t.Errorf("saved %q", data)
+	}
+	if !strings.HasSuffix(link["uri"].(string), filepath.ToSlash(matches[0])) {
+		t.Errorf("uri = %v, want it to point at %s", link["uri"], matches[0])
+	}
+}
@@ -1,10 +1,12 @@
 package server
 
 import (
+	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -14,9 +16,13 @@ import (
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
 	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jobqueue"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/jsonschema"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tasks"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/usage"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
 	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
 	workflowservice "github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
 )
@@ -34,6 +40,10 @@ type Service struct {
 	skillService     skills.SkillService
 	progressNotifier ProgressNotifier
 	taskManager      *tasks.Manager
+	queue            *jobqueue.Queue  // Bounds concurrent tool calls (nil: unbounded)
+	queueMaxWait     time.Duration    // Longest a call waits for a slot (0: no limit)
+	tenant           string           // Tenant whose config appConfig is (see forTenant)
+	owner            domain.TaskOwner // Caller that owns the tasks it starts and sees (see forCaller)
 }
 
 // NewService creates a new MCP server service
@@ -56,6 +66,14 @@ func (s *Service) SetProgressNotifier(notifier ProgressNotifier) {
 	s.progressNotifier = notifier
 }
 
+// SetQueue bounds tool calls with a job queue, which may be shared with
+// the services of other transports. maxWait limits how long a call waits
+// for a slot (0 for no limit).
+func (s *Service) SetQueue(queue *jobqueue.Queue, maxWait time.Duration) {
+	s.queue = queue
+	s.queueMaxWait = maxWait
+}
+
 // HandleInitialize handles the initialize request
 func (s *Service) HandleInitialize(params map[string]interface{}) (map[string]interface{}, error) {
 	logging.Info("Initialize request from client")
@@ -111,11 +129,18 @@ func (s *Service) HandleToolsList(params map[string]interface{}) (map[string]int
 			"description": toolExposure.Description,
 			"inputSchema": toolExposure.InputSchema,
 		}
+		if toolExposure.Async {
+			tool["inputSchema"] = s.asyncInputSchema(toolExposure.InputSchema)
+		}
 
 		tools = append(tools, tool)
 		logging.Debug("Registered tool: %s (template: %s)", toolExposure.Name, toolExposure.Template)
 	}
 
+	if s.asyncEnabled() {
+		tools = append(tools, getRunResultTool())
+	}
+
 	logging.Info("Returning %d tools", len(tools))
 
 	return map[string]interface{}{
@@ -133,17 +158,78 @@ func (s *Service) HandleToolsCall(params map[string]interface{}) (map[string]int
 
 	logging.Info("Tool call request: %s", toolName)
 
+	if toolName == GetRunResultToolName && s.asyncEnabled() {
+		arguments, _ := params["arguments"].(map[string]interface{})
+		return s.handleGetRunResult(arguments)
+	}
+
+	// Async tools take an optional callback_url beside their own arguments
+	toolExposure, _ := s.runasConfig.GetToolByName(toolName)
+	isAsync := toolExposure != nil && toolExposure.Async && s.taskManager != nil
+	callbackURL := ""
+	if isAsync {
+		var err error
+		if callbackURL, err = s.takeCallbackURL(params); err != nil {
+			return s.errorResponse(err.Error()), nil
+		}
+	}
+
+	// Reject malformed arguments before a workflow or task is started
+	if problems := s.coerceArguments(toolName, params); len(problems) > 0 {
+		logging.Warn("Invalid arguments for tool %s: %v", toolName, problems)
+		return s.errorResponse(fmt.Sprintf("Invalid arguments for tool '%s':\n- %s", toolName, strings.Join(problems, "\n- "))), nil
+	}
+
+	// Admit the call to the queue; a busy server refuses it up front
+	ticket, err := s.queue.Enqueue(s.toolPriority(toolName))
+	if err != nil {
+		logging.Warn("Rejected tool call %s: %v", toolName, err)
+		return nil, err
+	}
+
 	// Check for task augmentation
 	taskRequest, isTaskAugmented := params["task"].(map[string]interface{})
 	if isTaskAugmented && s.taskManager != nil {
 		logging.Info("Detected task-augmented tool call")
-		return s.handleTaskAugmentedToolCall(toolName, params, taskRequest)
+		return s.handleTaskAugmentedToolCall(toolName, params, taskRequest, ticket)
+	}
+
+	// Async tools return a run ID and deliver the result later
+	if isAsync {
+		return s.handleAsyncToolCall(toolName, params, ticket, callbackURL)
 	}
 
 	// Standard tool call (non-task)
+	defer ticket.Release()
+	if err := s.waitForSlot(context.Background(), ticket); err != nil {
+		logging.Warn("Tool call %s did not run: %v", toolName, err)
+		return nil, err
+	}
 	return s.handleStandardToolCall(toolName, params)
 }
 
+// toolPriority returns the queue priority configured for a tool
+func (s *Service) toolPriority(toolName string) int {
+	if toolExposure, found := s.runasConfig.GetToolByName(toolName); found {
+		return toolExposure.Priority
+	}
+	return 0
+}
+
+// waitForSlot waits for a queued call's turn, up to the configured limit
+func (s *Service) waitForSlot(ctx context.Context, ticket *jobqueue.Ticket) error {
+	if s.queueMaxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.queueMaxWait)
+		defer cancel()
+	}
+	err := ticket.Wait(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w (waited %s for a slot)", jobqueue.ErrRejected, s.queueMaxWait)
+	}
+	return err
+}
+
 // handleStandardToolCall handles a standard (non-task-augmented) tool call
 func (s *Service) handleStandardToolCall(toolName string, params map[string]interface{}) (map[string]interface{}, error) {
 	// Extract arguments
@@ -179,9 +265,18 @@ func (s *Service) handleStandardToolCall(toolName string, params map[string]inte
 
 	// CHECK: Is this the execute_skill_code tool? (identified by template)
 	if toolExposure.Template == "execute_skill_code" {
+		// Skill code runs in the shared sandbox, outside tenant outputs
+		if s.tenant != "" {
+			return s.errorResponse(fmt.Sprintf("Tool '%s' is not available to tenants", toolName)), nil
+		}
 		return s.handleExecuteSkillCode(arguments)
 	}
 
+	// CHECK: Is this the read_skill_reference tool?
+	if toolExposure.Template == "read_skill_reference" {
+		return s.handleReadSkillReference(arguments)
+	}
+
 	// CHECK: Is this a skill tool (uses load_skill template)?
 	if toolExposure.Template == "load_skill" {
 		return s.handleSkillToolCall(toolExposure, arguments)
@@ -205,14 +300,7 @@ func (s *Service) handleStandardToolCall(toolName string, params map[string]inte
 	}
 
 	// Return success result in MCP format
-	return map[string]interface{}{
-		"content": []interface{}{
-			map[string]interface{}{
-				"type": "text",
-				"text": result,
-			},
-		},
-	}, nil
+	return s.formatToolResult(toolExposure, result), nil
 }
 
 // executeTemplateWithProgress executes a template and sends progress notifications
@@ -260,8 +348,19 @@ func (s *Service) executeTemplateWithProgress(toolExposure *runas.ToolExposure,
 		}()
 	}
 
+	// Steps with stream: true report their responses as progress messages
+	var stream io.Writer
+	var writer *progressWriter
+	if progressToken != "" && s.progressNotifier != nil {
+		writer = &progressWriter{notifier: s.progressNotifier, token: progressToken}
+		stream = writer
+	}
+
 	// Execute the template (this blocks)
-	result, err := s.executeTemplate(toolExposure, arguments)
+	result, err := s.executeTemplate(toolExposure, arguments, stream)
+	if writer != nil {
+		writer.Flush()
+	}
 
 	// Stop heartbeat
 	close(done)
@@ -280,8 +379,42 @@ func (s *Service) executeTemplateWithProgress(toolExposure *runas.ToolExposure,
 	return result, err
 }
 
-// executeTemplate executes a template with the given arguments
-func (s *Service) executeTemplate(toolExposure *runas.ToolExposure, arguments map[string]interface{}) (string, error) {
+// progressWriter sends streamed step responses as progress notifications,
+// a line at a time
+type progressWriter struct {
+	notifier ProgressNotifier
+	token    string
+	pending  []byte
+}
+
+// Write sends each complete line of p, holding back a trailing partial line
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.send(w.pending[:i])
+		w.pending = w.pending[i+1:]
+	}
+}
+
+// Flush sends the held-back partial line
+func (w *progressWriter) Flush() {
+	w.send(w.pending)
+	w.pending = nil
+}
+
+func (w *progressWriter) send(line []byte) {
+	if text := strings.TrimSpace(string(line)); text != "" {
+		w.notifier.SendProgressNotification(w.token, 0.5, 0, text)
+	}
+}
+
+// executeTemplate executes a template with the given arguments, writing the
+// responses of steps with stream: true to stream when it's set
+func (s *Service) executeTemplate(toolExposure *runas.ToolExposure, arguments map[string]interface{}, stream io.Writer) (string, error) {
 	logging.Info("Executing template: %s", toolExposure.Template)
 
 	// Check if template exists using contextual lookup (v2 first, then v1)
@@ -321,7 +454,7 @@ func (s *Service) executeTemplate(toolExposure *runas.ToolExposure, arguments ma
 
 	// Execute template based on version
 	if isV2 {
-		return s.executeWorkflowV2(workflowV2, inputData, actualWorkflowKey, toolExposure)
+		return s.executeWorkflowV2(workflowV2, inputData, actualWorkflowKey, toolExposure, stream)
 	}
 
 	return s.executeTemplateV1(toolExposure.Template, inputData, toolExposure)
@@ -365,7 +498,7 @@ func (s *Service) executeTemplateV1(templateName string, inputData string, toolE
 }
 
 // executeWorkflowV2 executes a v2 workflow
-func (s *Service) executeWorkflowV2(tmpl *config.WorkflowV2, inputData string, actualWorkflowKey string, toolExposure *runas.ToolExposure) (string, error) {
+func (s *Service) executeWorkflowV2(tmpl *config.WorkflowV2, inputData string, actualWorkflowKey string, toolExposure *runas.ToolExposure, stream io.Writer) (string, error) {
 	logging.Info("Executing workflow v2: %s", tmpl.Name)
 
 	// Get provider configuration
@@ -375,14 +508,28 @@ func (s *Service) executeWorkflowV2(tmpl *config.WorkflowV2, inputData string, a
 
 	if toolExposure.Overrides != nil && toolExposure.Overrides.Provider != "" {
 		providerName = toolExposure.Overrides.Provider
-		providerConfig, _, err = s.configService.GetProviderConfig(providerName)
 	} else if tmpl.Execution.Provider != "" {
 		providerName = tmpl.Execution.Provider
-		providerConfig, _, err = s.configService.GetProviderConfig(providerName)
+	} else if s.tenant != "" {
+		providerName = s.appConfig.AI.DefaultProvider
 	} else {
 		providerName, providerConfig, _, err = s.configService.GetDefaultProvider()
 	}
 
+	// Routing policies pick the workflow's base provider; steps route on their own tags
+	routedModel := ""
+	if policyName, isRouter := config.ParseRouter(providerName); isRouter && err == nil {
+		decision, routeErr := ai.Route(s.appConfig, policyName, nil)
+		if routeErr != nil {
+			return "", routeErr
+		}
+		providerName = decision.Selected().Provider
+		routedModel = decision.Selected().Model
+	}
+	if providerConfig == nil && err == nil {
+		providerConfig, err = s.providerConfig(providerName)
+	}
+
 	if err != nil {
 		return "", fmt.Errorf("failed to get provider config: %w", err)
 	}
@@ -390,6 +537,8 @@ func (s *Service) executeWorkflowV2(tmpl *config.WorkflowV2, inputData string, a
 	// Override model if specified
 	if toolExposure.Overrides != nil && toolExposure.Overrides.Model != "" {
 		providerConfig.DefaultModel = toolExposure.Overrides.Model
+	} else if routedModel != "" {
+		providerConfig.DefaultModel = routedModel
 	} else if tmpl.Execution.Model != "" {
 		providerConfig.DefaultModel = tmpl.Execution.Model
 	}
@@ -398,11 +547,25 @@ func (s *Service) executeWorkflowV2(tmpl *config.WorkflowV2, inputData string, a
 
 	// Import the provider factory and domain types to create the actual provider
 	// This implementation mirrors the CLI's executeWorkflowV2 function
-	return s.executeWorkflowV2WithProvider(tmpl, inputData, providerName, providerConfig, actualWorkflowKey, toolExposure)
+	return s.executeWorkflowV2WithProvider(tmpl, inputData, providerName, providerConfig, actualWorkflowKey, toolExposure, stream)
+}
+
+// providerConfig looks a provider up, in the tenant's providers for a
+// tenant's calls
+func (s *Service) providerConfig(name string) (*config.ProviderConfig, error) {
+	if s.tenant == "" {
+		providerConfig, _, err := s.configService.GetProviderConfig(name)
+		return providerConfig, err
+	}
+	providerConfig := s.appConfig.FindProviderConfig(name)
+	if providerConfig == nil {
+		return nil, fmt.Errorf("provider '%s' is not available to tenant '%s'", name, s.tenant)
+	}
+	return providerConfig, nil
 }
 
 // executeWorkflowV2WithProvider executes a workflow with the actual provider
-func (s *Service) executeWorkflowV2WithProvider(tmpl *config.WorkflowV2, inputData string, providerName string, providerConfig *config.ProviderConfig, actualWorkflowKey string, toolExposure *runas.ToolExposure) (string, error) {
+func (s *Service) executeWorkflowV2WithProvider(tmpl *config.WorkflowV2, inputData string, providerName string, providerConfig *config.ProviderConfig, actualWorkflowKey string, toolExposure *runas.ToolExposure, stream io.Writer) (string, error) {
 	// Convert provider name to ProviderType (configuration-driven)
 	providerType := domain.ProviderType(providerName)
 
@@ -411,7 +574,7 @@ func (s *Service) executeWorkflowV2WithProvider(tmpl *config.WorkflowV2, inputDa
 	// Get interface type from configuration
 
 	// Create logger for workflow
-	logger := workflowservice.NewLogger(tmpl.Execution.Logging, false)
+	logger := workflowservice.NewLogger(tmpl.ConsoleLogLevel(), false)
 
 	// CRITICAL: In MCP serve mode, all logging must go to stderr
 	// stdout is reserved for JSON-RPC messages only
@@ -420,10 +583,14 @@ func (s *Service) executeWorkflowV2WithProvider(tmpl *config.WorkflowV2, inputDa
 	// Create orchestrator with workflow KEY for contextual nested workflow resolution
 	// This allows loops and nested workflows to resolve relative paths correctly
 	orchestrator := workflowservice.NewOrchestratorWithKey(tmpl, actualWorkflowKey, logger)
+	defer orchestrator.Close()
 
 	// Set application config for provider creation and nested workflows
 	orchestrator.SetAppConfig(s.appConfig)
 	orchestrator.SetAppConfigForWorkflows(s.appConfig)
+	if stream != nil {
+		orchestrator.SetStreamOutput(stream)
+	}
 
 	// CRITICAL: Set skills service as server manager for built-in skill execution
 	// Use SkillsAwareServerManager to properly expose all skill tools
@@ -435,21 +602,20 @@ func (s *Service) executeWorkflowV2WithProvider(tmpl *config.WorkflowV2, inputDa
 		}
 	}
 
-	// Execute workflow
-	ctx := context.Background()
+	// Execute workflow, counting a tenant's calls against its budgets
+	ctx := usage.WithTenant(context.Background(), s.tenant)
 	err := orchestrator.Execute(ctx, inputData)
 	if err != nil {
+		// Failed parallel steps are reported with the outputs of the rest
+		var partial *workflowservice.PartialFailureError
+		if errors.As(err, &partial) {
+			return "", fmt.Errorf("workflow execution failed: %w\n\n%s", err, partial.Report.Format())
+		}
 		return "", fmt.Errorf("workflow execution failed: %w", err)
 	}
 
-	// Get result from last step
-	result := ""
-	if len(tmpl.Steps) > 0 {
-		lastStepName := tmpl.Steps[len(tmpl.Steps)-1].Name
-		if output, ok := orchestrator.GetStepResult(lastStepName); ok {
-			result = output
-		}
-	}
+	// Get result from last step (or the matrix report)
+	result, _ := orchestrator.FinalOutput()
 
 	// Return result
 	if result != "" {
@@ -532,6 +698,23 @@ func (s *Service) handleSkillToolCall(toolExposure *runas.ToolExposure, argument
 	}, nil
 }
 
+// handleReadSkillReference handles the read_skill_reference tool
+func (s *Service) handleReadSkillReference(arguments map[string]interface{}) (map[string]interface{}, error) {
+	result, err := s.skillService.ReadReference(skills.ReferenceReadRequestFromArguments(arguments))
+	if err != nil {
+		return s.errorResponse(err.Error()), nil
+	}
+
+	return map[string]interface{}{
+		"content": []interface{}{
+			map[string]interface{}{
+				"type": "text",
+				"text": result.Text(),
+			},
+		},
+	}, nil
+}
+
 // handleExecuteSkillCode handles the execute_skill_code tool
 func (s *Service) handleExecuteSkillCode(arguments map[string]interface{}) (map[string]interface{}, error) {
 	logging.Info("Handling execute_skill_code request")
@@ -597,22 +780,10 @@ func (s *Service) handleExecuteSkillCode(arguments map[string]interface{}) (map[
 
 	logging.Info("Executing code for skill: %s (language: %s, code length: %d)", skillName, language, len(code))
 
-	// Extract files (optional)
-	files := make(map[string][]byte)
-	if filesArg, ok := arguments["files"].(map[string]interface{}); ok {
-		for filename, content := range filesArg {
-			// Content should be base64 encoded
-			if contentStr, ok := content.(string); ok {
-				// Try to decode as base64
-				decoded, err := base64.StdEncoding.DecodeString(contentStr)
-				if err != nil {
-					// If not base64, treat as plain text
-					decoded = []byte(contentStr)
-				}
-				files[filename] = decoded
-				logging.Debug("Added file: %s (%d bytes)", filename, len(decoded))
-			}
-		}
+	// Extract files (optional): text, base64 or data: URIs
+	files := skills.DecodeFiles(arguments["files"])
+	for filename, content := range files {
+		logging.Debug("Added file: %s (%d bytes)", filename, len(content))
 	}
 
 	// Create execution request
@@ -634,16 +805,16 @@ func (s *Service) handleExecuteSkillCode(arguments map[string]interface{}) (map[
 	// Check if execution had an error
 	if result.Error != nil {
 		logging.Warn("Code execution completed with error: %v", result.Error)
-		return s.errorResponse(fmt.Sprintf("Code execution error: %v\n\nOutput:\n%s", result.Error, result.Output)), nil
+		return s.errorResponse(fmt.Sprintf("Code execution error: %v\n\nOutput:\n%s", result.Error, result.Text())), nil
 	}
 
 	// Success!
 	logging.Info("Code executed successfully (exit code: %d, duration: %dms)", result.ExitCode, result.Duration)
 
-	// Format response with output
-	responseText := result.Output
+	// Format response with stdout, stderr and any structured result
+	responseText := result.Text()
 	if result.Duration > 0 {
-		responseText = fmt.Sprintf("%s\n\n[Executed in %dms]", result.Output, result.Duration)
+		responseText = fmt.Sprintf("%s\n\n[Executed in %dms]", responseText, result.Duration)
 	}
 
 	return map[string]interface{}{
@@ -656,6 +827,25 @@ func (s *Service) handleExecuteSkillCode(arguments map[string]interface{}) (map[
 	}, nil
 }
 
+// coerceArguments converts the call's arguments to the types declared in the
+// tool's input schema and returns the remaining validation problems, each
+// prefixed with the argument's path ("$.field: ..."). Coerced arguments are
+// written back to params.
+func (s *Service) coerceArguments(toolName string, params map[string]interface{}) []string {
+	toolExposure, found := s.runasConfig.GetToolByName(toolName)
+	if !found || toolExposure.InputSchema == nil {
+		return nil
+	}
+
+	arguments, ok := params["arguments"].(map[string]interface{})
+	if !ok {
+		arguments = make(map[string]interface{})
+	}
+	coerced := jsonschema.Coerce(toolExposure.InputSchema, arguments)
+	params["arguments"] = coerced
+	return jsonschema.Validate(toolExposure.InputSchema, coerced)
+}
+
 // errorResponse creates an error response in MCP format
 func (s *Service) errorResponse(message string) map[string]interface{} {
 	return map[string]interface{}{
@@ -708,8 +898,9 @@ func (esm *EmptyServerManager) StopAll() error {
 // ==================== TASK-AUGMENTED HANDLERS (SEP-1686) ====================
 
 // handleTaskAugmentedToolCall handles a task-augmented tool call
-func (s *Service) handleTaskAugmentedToolCall(toolName string, params map[string]interface{}, taskRequest map[string]interface{}) (map[string]interface{}, error) {
+func (s *Service) handleTaskAugmentedToolCall(toolName string, params map[string]interface{}, taskRequest map[string]interface{}, ticket *jobqueue.Ticket) (map[string]interface{}, error) {
 	if s.taskManager == nil {
+		ticket.Release()
 		return nil, fmt.Errorf("task support not enabled on this server")
 	}
 
@@ -722,19 +913,21 @@ func (s *Service) handleTaskAugmentedToolCall(toolName string, params map[string
 	// Serialize request params for storage
 	paramsJSON, err := json.Marshal(params)
 	if err != nil {
+		ticket.Release()
 		return nil, fmt.Errorf("failed to serialize request params: %w", err)
 	}
 
 	// Create task
-	task, err := s.taskManager.CreateTask("tools/call", paramsJSON, requestedTTL)
+	task, err := s.taskManager.CreateTask("tools/call", paramsJSON, requestedTTL, s.owner)
 	if err != nil {
+		ticket.Release()
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
 	logging.Info("Created task %s for tool %s", task.ID, toolName)
 
 	// Execute tool in background
-	go s.executeToolInBackground(task, toolName, params)
+	go s.executeToolInBackground(task, toolName, params, ticket)
 
 	// Return CreateTaskResult immediately
 	return map[string]interface{}{
@@ -743,13 +936,36 @@ func (s *Service) handleTaskAugmentedToolCall(toolName string, params map[string
 }
 
 // executeToolInBackground executes a tool in the background and updates the task
-func (s *Service) executeToolInBackground(task *domain.Task, toolName string, params map[string]interface{}) {
+func (s *Service) executeToolInBackground(task *domain.Task, toolName string, params map[string]interface{}, ticket *jobqueue.Ticket) {
 	defer func() {
 		if r := recover(); r != nil {
 			logging.Error("Panic in background tool execution: %v", r)
 			task.SetError(fmt.Errorf("panic: %v", r), false)
 		}
 	}()
+	defer ticket.Release()
+
+	// Wait for a queue slot, giving up if the task is canceled meanwhile
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-task.Cancel:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	err := s.waitForSlot(ctx, ticket)
+	cancel()
+	if errors.Is(err, context.Canceled) {
+		logging.Info("Task %s was canceled while queued", task.ID)
+		task.SetCancelled()
+		return
+	}
+	if err != nil {
+		logging.Warn("Task %s did not run: %v", task.ID, err)
+		task.SetError(err, false)
+		return
+	}
 
 	logging.Info("Starting background execution of tool %s (task %s)", toolName, task.ID)
 
@@ -797,13 +1013,13 @@ func (s *Service) HandleTasksGet(params map[string]interface{}) (map[string]inte
 
 	logging.Info("Tasks/get request for task %s", taskID)
 
-	metadata, err := s.taskManager.GetTaskMetadata(taskID)
+	task, err := s.ownedTask(taskID)
 	if err != nil {
 		return nil, err
 	}
 
 	return map[string]interface{}{
-		"task": metadata,
+		"task": task.GetMetadata(s.taskManager.GetPollInterval()),
 	}, nil
 }
 
@@ -820,6 +1036,10 @@ func (s *Service) HandleTasksResult(params map[string]interface{}) (map[string]i
 
 	logging.Info("Tasks/result request for task %s", taskID)
 
+	if _, err := s.ownedTask(taskID); err != nil {
+		return nil, err
+	}
+
 	// Wait for result (blocks until task completes)
 	result, err := s.taskManager.WaitForResult(taskID, 30*time.Minute)
 	if err != nil {
@@ -856,7 +1076,7 @@ func (s *Service) HandleTasksList(params map[string]interface{}) (map[string]int
 	logging.Info("Tasks/list request (cursor: %s)", cursor)
 
 	// List tasks with pagination
-	tasks, nextCursor, err := s.taskManager.ListTasks(cursor, 20)
+	tasks, nextCursor, err := s.taskManager.ListTasks(cursor, 20, s.owner)
 	if err != nil {
 		return nil, err
 	}
@@ -885,6 +1105,10 @@ func (s *Service) HandleTasksCancel(params map[string]interface{}) (map[string]i
 
 	logging.Info("Tasks/cancel request for task %s", taskID)
 
+	if _, err := s.ownedTask(taskID); err != nil {
+		return nil, err
+	}
+
 	// Cancel the task
 	if err := s.taskManager.CancelTask(taskID); err != nil {
 		return nil, err
@@ -900,3 +1124,13 @@ func (s *Service) HandleTasksCancel(params map[string]interface{}) (map[string]i
 		"task": metadata,
 	}, nil
 }
+
+// ownedTask looks up a task the service's caller started. Other callers'
+// tasks are reported as not found, so their IDs don't leak.
+func (s *Service) ownedTask(taskID string) (*domain.Task, error) {
+	task, err := s.taskManager.GetTask(taskID)
+	if err != nil || task.Owner != s.owner {
+		return nil, fmt.Errorf("task not found: %s", taskID)
+	}
+	return task, nil
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// recordingNotifier keeps the progress notifications it's sent
+type recordingNotifier struct {
+	messages []string
+}
+
+func (n *recordingNotifier) SendProgressNotification(progressToken string, progress float64, total int, message string) {
+	n.messages = append(n.messages, fmt.Sprintf("%s:%s", progressToken, message))
+}
+
+func TestProgressWriter(t *testing.T) {
+	notifier := &recordingNotifier{}
+	w := &progressWriter{notifier: notifier, token: "tok"}
+
+	// Streamed chunks rarely end on line boundaries
+	for _, chunk := range []string{"The ans", "wer is\n\n4", "2.\nDone", "."} {
+		if n, err := w.Write([]byte(chunk)); n != len(chunk) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	if want := []string{"tok:The answer is", "tok:42."}; !reflect.DeepEqual(notifier.messages, want) {
+		t.Errorf("before Flush sent %q, want %q", notifier.messages, want)
+	}
+
+	w.Flush()
+	w.Flush()
+	if want := []string{"tok:The answer is", "tok:42.", "tok:Done."}; !reflect.DeepEqual(notifier.messages, want) {
+		t.Errorf("after Flush sent %q, want %q", notifier.messages, want)
+	}
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// HandleToolsCallAs handles a tools/call request from a client of a shared
+// HTTP server, running it with its tenant's view of the config. Tasks it
+// starts belong to the client.
+func (s *Service) HandleToolsCallAs(params map[string]interface{}, client, tenant string) (map[string]interface{}, error) {
+	scoped, err := s.forCaller(client, tenant)
+	if err != nil {
+		return nil, err
+	}
+	return scoped.HandleToolsCall(params)
+}
+
+// HandleTasksAs handles a tasks/* request from a client of a shared HTTP
+// server, which only sees and cancels its own tasks, started under the same
+// tenant
+func (s *Service) HandleTasksAs(method string, params map[string]interface{}, client, tenant string) (map[string]interface{}, error) {
+	scoped, err := s.forCaller(client, tenant)
+	if err != nil {
+		return nil, err
+	}
+	switch method {
+	case "tasks/get":
+		return scoped.HandleTasksGet(params)
+	case "tasks/result":
+		return scoped.HandleTasksResult(params)
+	case "tasks/list":
+		return scoped.HandleTasksList(params)
+	case "tasks/cancel":
+		return scoped.HandleTasksCancel(params)
+	}
+	return nil, fmt.Errorf("unknown tasks method: %s", method)
+}
+
+// forCaller returns a copy of the service acting for a client of a tenant
+// (either may be empty when the server doesn't use them)
+func (s *Service) forCaller(client, tenant string) (*Service, error) {
+	scoped := s
+	if tenant != "" {
+		var err error
+		if scoped, err = s.forTenant(tenant); err != nil {
+			return nil, err
+		}
+	} else {
+		copied := *s
+		scoped = &copied
+	}
+	scoped.owner = domain.TaskOwner{Client: client, Tenant: tenant}
+	return scoped, nil
+}
+
+// forTenant returns a copy of the service that runs tools with a tenant's
+// providers, keys, budgets and outputs directory. The queue, task manager
+// and progress notifier stay shared.
+func (s *Service) forTenant(name string) (*Service, error) {
+	if s.runasConfig.HTTP == nil {
+		return nil, fmt.Errorf("unknown tenant: %s", name)
+	}
+	tenantConfig, ok := s.runasConfig.HTTP.Tenants[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tenant: %s", name)
+	}
+	scoped := *s
+	scoped.tenant = name
+	scoped.appConfig = TenantAppConfig(s.appConfig, name, &tenantConfig)
+	return &scoped, nil
+}
+
+// TenantAppConfig returns a tenant's view of the application config: only
+// the providers the tenant lists, with its own keys, endpoints and monthly
+// budgets, and its own outputs directory. The base config is not modified.
+func TenantAppConfig(base *config.ApplicationConfig, name string, tenant *runas.TenantConfig) *config.ApplicationConfig {
+	scoped := *base
+	scoped.Tenant = name
+
+	if base.AI != nil {
+		ai := *base.AI
+		ai.Interfaces = make(map[config.InterfaceType]config.InterfaceConfig, len(base.AI.Interfaces))
+		for interfaceType, iface := range base.AI.Interfaces {
+			iface.Providers = tenantProviders(iface.Providers, tenant)
+			ai.Interfaces[interfaceType] = iface
+		}
+		ai.Providers = tenantProviders(base.AI.Providers, tenant)
+		if _, ok := tenant.Providers[ai.DefaultProvider]; !ok {
+			ai.DefaultProvider = firstTenantProvider(tenant)
+		}
+		scoped.AI = &ai
+	}
+
+	if base.Embeddings != nil {
+		embeddings := *base.Embeddings
+		embeddings.Interfaces = make(map[config.InterfaceType]config.EmbeddingInterfaceConfig, len(base.Embeddings.Interfaces))
+		for interfaceType, iface := range base.Embeddings.Interfaces {
+			iface.Providers = tenantEmbeddingProviders(iface.Providers, tenant)
+			embeddings.Interfaces[interfaceType] = iface
+		}
+		embeddings.Providers = tenantEmbeddingProviders(base.Embeddings.Providers, tenant)
+		if _, ok := tenant.Providers[embeddings.DefaultProvider]; !ok {
+			embeddings.DefaultProvider = ""
+		}
+		embeddings.FallbackChain = nil
+		for _, fallback := range base.Embeddings.FallbackChain {
+			if _, ok := tenant.Providers[fallback.Provider]; ok {
+				embeddings.FallbackChain = append(embeddings.FallbackChain, fallback)
+			}
+		}
+		scoped.Embeddings = &embeddings
+	}
+
+	skills := config.SkillsConfig{}
+	if base.Skills != nil {
+		skills = *base.Skills
+	}
+	skills.OutputsDir = tenant.OutputsDir
+	scoped.Skills = &skills
+
+	return &scoped
+}
+
+// tenantProviders keeps the providers the tenant lists, with its settings
+func tenantProviders(providers map[string]config.ProviderConfig, tenant *runas.TenantConfig) map[string]config.ProviderConfig {
+	scoped := make(map[string]config.ProviderConfig)
+	for name, overlay := range tenant.Providers {
+		providerConfig, ok := providers[name]
+		if !ok {
+			continue
+		}
+		if overlay.APIKey != "" {
+			providerConfig.APIKey = overlay.APIKey
+		}
+		if overlay.APIEndpoint != "" {
+			providerConfig.APIEndpoint = overlay.APIEndpoint
+		}
+		providerConfig.MonthlyBudget = overlay.MonthlyBudget
+		scoped[name] = providerConfig
+	}
+	return scoped
+}
+
+// tenantEmbeddingProviders keeps the embedding providers the tenant lists,
+// with its settings
+func tenantEmbeddingProviders(providers map[string]config.EmbeddingProviderConfig, tenant *runas.TenantConfig) map[string]config.EmbeddingProviderConfig {
+	scoped := make(map[string]config.EmbeddingProviderConfig)
+	for name, overlay := range tenant.Providers {
+		providerConfig, ok := providers[name]
+		if !ok {
+			continue
+		}
+		if overlay.APIKey != "" {
+			providerConfig.APIKey = overlay.APIKey
+		}
+		if overlay.APIEndpoint != "" {
+			providerConfig.APIEndpoint = overlay.APIEndpoint
+		}
+		providerConfig.MonthlyBudget = overlay.MonthlyBudget
+		scoped[name] = providerConfig
+	}
+	return scoped
+}
+
+// firstTenantProvider is the tenant's default provider when the shared
+// default isn't one of its providers: the first by name
+func firstTenantProvider(tenant *runas.TenantConfig) string {
+	names := make([]string, 0, len(tenant.Providers))
+	for name := range tenant.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// WarnUnknownTenantProviders logs tenant providers that settings.yaml
+// doesn't define, which the tenant can't use
+func WarnUnknownTenantProviders(appConfig *config.ApplicationConfig, tenants map[string]runas.TenantConfig) {
+	for name, tenant := range tenants {
+		for provider := range tenant.Providers {
+			if appConfig.FindProviderConfig(provider) == nil {
+				logging.Warn("Tenant %s: provider '%s' is not defined in the AI providers and is unavailable to it", name, provider)
+			}
+		}
+	}
+}
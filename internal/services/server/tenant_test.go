@@ -0,0 +1,130 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/runas"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tasks"
+)
+
+func TestTenantAppConfig(t *testing.T) {
+	base := &config.ApplicationConfig{
+		AI: &config.AIConfig{
+			DefaultProvider: "anthropic",
+			Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+				config.OpenAICompatible: {Providers: map[string]config.ProviderConfig{
+					"openai": {APIKey: "shared-key", DefaultModel: "gpt-4o"},
+				}},
+				config.AnthropicNative: {Providers: map[string]config.ProviderConfig{
+					"anthropic": {APIKey: "shared-key"},
+				}},
+			},
+		},
+		Skills: &config.SkillsConfig{OutputsDir: "/srv/outputs"},
+	}
+	tenant := &runas.TenantConfig{
+		Providers: map[string]runas.TenantProviderConfig{
+			"openai": {APIKey: "team-a-key", MonthlyBudget: 50},
+		},
+		OutputsDir: "/srv/team-a",
+	}
+
+	scoped := TenantAppConfig(base, "team-a", tenant)
+	if scoped.Tenant != "team-a" {
+		t.Errorf("tenant = %q, want team-a", scoped.Tenant)
+	}
+	openai := scoped.FindProviderConfig("openai")
+	if openai == nil || openai.APIKey != "team-a-key" || openai.MonthlyBudget != 50 || openai.DefaultModel != "gpt-4o" {
+		t.Errorf("openai = %+v, want the shared settings with the tenant's key and budget", openai)
+	}
+	if scoped.FindProviderConfig("anthropic") != nil {
+		t.Error("a provider the tenant doesn't list is available to it")
+	}
+	if scoped.AI.DefaultProvider != "openai" {
+		t.Errorf("default provider = %q, want the tenant's openai", scoped.AI.DefaultProvider)
+	}
+	if scoped.Skills.OutputsDir != "/srv/team-a" {
+		t.Errorf("outputs dir = %q, want the tenant's", scoped.Skills.OutputsDir)
+	}
+
+	// The shared config is untouched
+	if base.FindProviderConfig("openai").APIKey != "shared-key" || base.AI.DefaultProvider != "anthropic" || base.Skills.OutputsDir != "/srv/outputs" {
+		t.Error("TenantAppConfig modified the base config")
+	}
+}
+
+func TestHandleTasksAs(t *testing.T) {
+	taskManager := tasks.NewManager(time.Minute, time.Hour, 100)
+	t.Cleanup(taskManager.Close)
+	s := NewService(&runas.RunAsConfig{}, nil, nil, nil)
+	s.SetTaskManager(taskManager)
+
+	mine, err := taskManager.CreateTask("tools/call", nil, 0, domain.TaskOwner{Client: "ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := taskManager.CreateTask("tools/call", nil, 0, domain.TaskOwner{Client: "bot"}); err != nil {
+		t.Fatal(err)
+	}
+
+	listed, err := s.HandleTasksAs("tasks/list", nil, "ops", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := listed["tasks"].([]domain.TaskMetadata); len(got) != 1 || got[0].TaskID != mine.ID {
+		t.Errorf("ops tasks/list = %v, want only its own task", got)
+	}
+
+	// Another client's task is unknown to it, whatever the method
+	params := map[string]interface{}{"taskId": mine.ID}
+	for _, method := range []string{"tasks/get", "tasks/result", "tasks/cancel"} {
+		if _, err := s.HandleTasksAs(method, params, "bot", ""); err == nil || !strings.Contains(err.Error(), "task not found") {
+			t.Errorf("bot %s of ops's task error = %v, want not found", method, err)
+		}
+	}
+	if mine.Status != domain.TaskStatusWorking {
+		t.Errorf("ops's task status = %s after bot's cancel, want working", mine.Status)
+	}
+
+	if _, err := s.HandleTasksAs("tasks/cancel", params, "ops", ""); err != nil {
+		t.Errorf("ops tasks/cancel of its own task error = %v", err)
+	}
+}
+
+func TestHandleTasksAsTenants(t *testing.T) {
+	taskManager := tasks.NewManager(time.Minute, time.Hour, 100)
+	t.Cleanup(taskManager.Close)
+	s := NewService(&runas.RunAsConfig{HTTP: &runas.HTTPConfig{Tenants: map[string]runas.TenantConfig{
+		"team-a": {OutputsDir: "/srv/team-a"},
+		"team-b": {OutputsDir: "/srv/team-b"},
+	}}}, &config.ApplicationConfig{}, nil, nil)
+	s.SetTaskManager(taskManager)
+
+	// Without authentication, tenants named by header share no tasks
+	task, err := taskManager.CreateTask("tools/call", nil, 0, domain.TaskOwner{Tenant: "team-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed, err := s.HandleTasksAs("tasks/list", nil, "", "team-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := listed["tasks"].([]domain.TaskMetadata); len(got) != 0 {
+		t.Errorf("team-b tasks/list = %v, want none of team-a's tasks", got)
+	}
+	if _, err := s.HandleTasksAs("tasks/result", map[string]interface{}{"taskId": task.ID}, "", "team-b"); err == nil {
+		t.Error("team-b read team-a's task result")
+	}
+
+	listed, err = s.HandleTasksAs("tasks/list", nil, "", "team-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := listed["tasks"].([]domain.TaskMetadata); len(got) != 1 {
+		t.Errorf("team-a tasks/list = %v, want its task", got)
+	}
+}
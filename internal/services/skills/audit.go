@@ -0,0 +1,323 @@
+package skills
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
+)
+
+// auditManifestFile is written last, so a directory without it is an
+// incomplete snapshot
+const auditManifestFile = "manifest.json"
+
+// auditManifest describes one execute_skill_code call
+type auditManifest struct {
+	Skill       string      `json:"skill"`
+	Language    string      `json:"language"`
+	Started     time.Time   `json:"started"`
+	DurationMs  int64       `json:"duration_ms"`
+	ExitCode    int         `json:"exit_code"`
+	Error       string      `json:"error,omitempty"`
+	Executor    string      `json:"executor,omitempty"`
+	Image       string      `json:"image,omitempty"`
+	ImageDigest string      `json:"image_digest,omitempty"`
+	Code        auditFile   `json:"code"`
+	Files       []auditFile `json:"files"`     // Mounted into /workspace
+	Artifacts   []auditFile `json:"artifacts"` // Created or changed by the code
+}
+
+// auditFile is a file recorded in a snapshot. Paths are as the code saw
+// them, e.g. /workspace/data.csv or /outputs/report.docx.
+type auditFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	Copied bool   `json:"copied,omitempty"` // Artifacts only: false when over max_artifact_size_mb
+}
+
+// auditConfig returns the audit settings, or nil when auditing is off
+func (s *Service) auditConfig() *domainConfig.SkillAuditConfig {
+	if s.appConfig == nil || s.appConfig.Skills == nil || s.appConfig.Skills.Audit == nil || !s.appConfig.Skills.Audit.Enabled {
+		return nil
+	}
+	return s.appConfig.Skills.Audit
+}
+
+// recordAudit writes a snapshot of a finished execute_skill_code call: the
+// code, the mounted files, the image, the output and the artifacts it
+// produced. Failures are logged rather than returned so auditing never
+// changes the result of the call.
+func (s *Service) recordAudit(request *skills.CodeExecutionRequest, skill *skills.Skill, workspaceDir, scriptPath string, started time.Time, result *skills.ExecutionResult) {
+	audit := s.auditConfig()
+	if audit == nil {
+		return
+	}
+
+	baseDir := s.appConfig.Skills.GetAuditDir()
+	dir, err := newAuditDir(baseDir, skill.Name, started)
+	if err != nil {
+		logging.Warn("Skill audit snapshot skipped: %v", err)
+		return
+	}
+
+	manifest := auditManifest{
+		Skill:      skill.Name,
+		Language:   request.Language,
+		Started:    started,
+		DurationMs: result.Duration,
+		ExitCode:   result.ExitCode,
+		Code:       hashBytes("/workspace/"+scriptPath, []byte(request.Code)),
+		Files:      []auditFile{},
+		Artifacts:  []auditFile{},
+	}
+	if result.Error != nil {
+		manifest.Error = result.Error.Error()
+	}
+	if s.executor != nil {
+		manifest.Executor = s.executor.GetInfo()
+		if resolver, ok := s.executor.(sandbox.ImageResolver); ok {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			image, digest, err := resolver.ResolveImage(ctx, skill.DirectoryPath)
+			cancel()
+			manifest.Image, manifest.ImageDigest = image, digest
+			if err != nil {
+				logging.Debug("Skill audit: %v", err)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(request.Files))
+	for name := range request.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	inputs := make(map[string]string, len(names))
+	for _, name := range names {
+		file := hashBytes("/workspace/"+filepath.ToSlash(name), request.Files[name])
+		manifest.Files = append(manifest.Files, file)
+		inputs[file.Path] = file.SHA256
+	}
+
+	var errs []string
+	write := func(name string, data []byte) {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	write(filepath.Base(scriptPath), []byte(request.Code))
+	write("output.txt", []byte(result.Output))
+
+	// Anything in the workspace that wasn't passed in, or was changed
+	inputs[manifest.Code.Path] = manifest.Code.SHA256
+	artifacts, err := collectArtifacts(workspaceDir, "/workspace", dir, audit.GetMaxArtifactSize(), time.Time{}, inputs, "")
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	manifest.Artifacts = append(manifest.Artifacts, artifacts...)
+
+	// Anything written to the outputs directory while the code ran
+	outputsDir := filepath.Clean(s.appConfig.Skills.GetOutputsDir())
+	artifacts, err = collectArtifacts(outputsDir, "/outputs", dir, audit.GetMaxArtifactSize(), started.Truncate(time.Second), nil, baseDir)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	manifest.Artifacts = append(manifest.Artifacts, artifacts...)
+
+	data, _ := json.MarshalIndent(manifest, "", "  ")
+	write(auditManifestFile, data)
+
+	if len(errs) > 0 {
+		logging.Warn("Skill audit snapshot %s is incomplete: %s", dir, strings.Join(errs, "; "))
+	} else {
+		logging.Debug("Skill audit snapshot written to %s", dir)
+	}
+
+	pruneAuditDir(baseDir, audit.GetMaxAge(), audit.MaxRuns, time.Now())
+}
+
+// newAuditDir creates the snapshot directory <base>/<timestamp>-<skill>
+func newAuditDir(baseDir, skillName string, started time.Time) (string, error) {
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create audit directory: %w", err)
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, skillName)
+	stem := filepath.Join(baseDir, fmt.Sprintf("%s-%s", started.Format("20060102-150405.000"), name))
+	dir := stem
+	for n := 2; ; n++ {
+		err := os.Mkdir(dir, 0755)
+		if err == nil {
+			return dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", fmt.Errorf("failed to create audit directory: %w", err)
+		}
+		dir = fmt.Sprintf("%s-%d", stem, n)
+	}
+}
+
+// collectArtifacts records the files under root modified since the given
+// time whose hash differs from unchanged[path], copying those within maxSize
+// into <snapshot>/artifacts/<prefix>. The skip directory is ignored.
+func collectArtifacts(root, prefix, snapshotDir string, maxSize int64, since time.Time, unchanged map[string]string, skip string) ([]auditFile, error) {
+	var artifacts []auditFile
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if path == skip {
+			return filepath.SkipDir
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(since) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		auditPath := prefix + "/" + filepath.ToSlash(rel)
+
+		sum, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		if unchanged[auditPath] == sum {
+			return nil
+		}
+
+		artifact := auditFile{Path: auditPath, Size: info.Size(), SHA256: sum}
+		if info.Size() <= maxSize {
+			target := filepath.Join(snapshotDir, "artifacts", strings.TrimPrefix(prefix, "/"), rel)
+			if err := copyFile(path, target); err != nil {
+				return err
+			}
+			artifact.Copied = true
+		}
+		artifacts = append(artifacts, artifact)
+		return nil
+	})
+	return artifacts, err
+}
+
+// pruneAuditDir removes snapshots older than maxAge and, beyond the newest
+// maxRuns, the oldest. Zero disables either limit. Only directories holding
+// a manifest are considered.
+func pruneAuditDir(baseDir string, maxAge time.Duration, maxRuns int, now time.Time) {
+	if maxAge <= 0 && maxRuns <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		return
+	}
+
+	type snapshot struct {
+		dir     string
+		started time.Time
+	}
+	var snapshots []snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(baseDir, entry.Name())
+		info, err := os.Stat(filepath.Join(dir, auditManifestFile))
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot{dir: dir, started: info.ModTime()})
+	}
+
+	// Newest first
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].started.After(snapshots[j].started)
+	})
+
+	removed := 0
+	for i, snap := range snapshots {
+		expired := maxAge > 0 && now.Sub(snap.started) > maxAge
+		excess := maxRuns > 0 && i >= maxRuns
+		if !expired && !excess {
+			continue
+		}
+		if err := os.RemoveAll(snap.dir); err != nil {
+			logging.Warn("Failed to remove skill audit snapshot %s: %v", snap.dir, err)
+			continue
+		}
+		removed++
+	}
+	if removed > 0 {
+		logging.Debug("Removed %d skill audit snapshot(s) past retention", removed)
+	}
+}
+
+func hashBytes(path string, data []byte) auditFile {
+	sum := sha256.Sum256(data)
+	return auditFile{Path: path, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,119 @@
+package skills
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+func TestRecordAudit(t *testing.T) {
+	outputsDir := t.TempDir()
+	workspaceDir := t.TempDir()
+
+	service := NewService()
+	service.SetConfig(&domainConfig.ApplicationConfig{Skills: &domainConfig.SkillsConfig{
+		OutputsDir: outputsDir,
+		Audit:      &domainConfig.SkillAuditConfig{Enabled: true},
+	}})
+
+	request := &skills.CodeExecutionRequest{
+		SkillName: "docx",
+		Language:  "python",
+		Code:      "print('hi')",
+		Files:     map[string][]byte{"input.txt": []byte("in")},
+	}
+	started := time.Now()
+
+	// The workspace as the code left it, and an old file in outputs
+	os.WriteFile(filepath.Join(workspaceDir, "input.txt"), []byte("in"), 0644)
+	os.WriteFile(filepath.Join(workspaceDir, "script.py"), []byte(request.Code), 0644)
+	os.WriteFile(filepath.Join(workspaceDir, "scratch.txt"), []byte("new"), 0644)
+	os.WriteFile(filepath.Join(outputsDir, "old.txt"), []byte("old"), 0644)
+	past := started.Add(-time.Hour)
+	os.Chtimes(filepath.Join(outputsDir, "old.txt"), past, past)
+	os.MkdirAll(filepath.Join(outputsDir, "reports"), 0755)
+	os.WriteFile(filepath.Join(outputsDir, "reports", "report.docx"), []byte("report"), 0644)
+
+	service.recordAudit(request, &skills.Skill{Name: "docx"}, workspaceDir, "script.py", started, &skills.ExecutionResult{
+		Output:   "hi\n",
+		ExitCode: 1,
+		Error:    errors.New("exit status 1"),
+		Duration: 42,
+	})
+
+	snapshots, _ := filepath.Glob(filepath.Join(outputsDir, "runs", "skill-audit", "*-docx"))
+	if len(snapshots) != 1 {
+		t.Fatalf("expected one snapshot, got %v", snapshots)
+	}
+	dir := snapshots[0]
+
+	data, err := os.ReadFile(filepath.Join(dir, auditManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest auditManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Skill != "docx" || manifest.ExitCode != 1 || manifest.Error != "exit status 1" || manifest.DurationMs != 42 {
+		t.Errorf("unexpected manifest: %+v", manifest)
+	}
+	if len(manifest.Files) != 1 || manifest.Files[0].Path != "/workspace/input.txt" {
+		t.Errorf("unexpected files: %+v", manifest.Files)
+	}
+
+	artifacts := map[string]bool{}
+	for _, artifact := range manifest.Artifacts {
+		artifacts[artifact.Path] = artifact.Copied
+	}
+	want := map[string]bool{"/workspace/scratch.txt": true, "/outputs/reports/report.docx": true}
+	if len(artifacts) != len(want) {
+		t.Errorf("artifacts = %v, want %v", artifacts, want)
+	}
+	for path := range want {
+		if !artifacts[path] {
+			t.Errorf("missing artifact %s in %v", path, artifacts)
+		}
+	}
+
+	for _, name := range []string{"script.py", "output.txt", "artifacts/outputs/reports/report.docx", "artifacts/workspace/scratch.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("snapshot is missing %s", name)
+		}
+	}
+}
+
+func TestPruneAuditDir(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Now()
+
+	snapshot := func(name string, age time.Duration) string {
+		dir := filepath.Join(baseDir, name)
+		os.MkdirAll(dir, 0755)
+		manifest := filepath.Join(dir, auditManifestFile)
+		os.WriteFile(manifest, []byte("{}"), 0644)
+		os.Chtimes(manifest, now.Add(-age), now.Add(-age))
+		return dir
+	}
+	expired := snapshot("a", 48*time.Hour)
+	oldest := snapshot("b", 3*time.Hour)
+	middle := snapshot("c", 2*time.Hour)
+	newest := snapshot("d", time.Hour)
+	incomplete := filepath.Join(baseDir, "e")
+	os.MkdirAll(incomplete, 0755)
+
+	pruneAuditDir(baseDir, 24*time.Hour, 2, now)
+
+	for dir, kept := range map[string]bool{expired: false, oldest: false, middle: true, newest: true, incomplete: true} {
+		_, err := os.Stat(dir)
+		if kept != (err == nil) {
+			t.Errorf("%s: kept = %v, want %v", filepath.Base(dir), err == nil, kept)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package skills
+
+import (
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// executionLimiter bounds how many skill executions run at once and keeps
+// executions of exclusive skills from overlapping
+type executionLimiter struct {
+	slots chan struct{}
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex // Per-skill locks for exclusive skills
+}
+
+func newExecutionLimiter(maxConcurrent int) *executionLimiter {
+	return &executionLimiter{
+		slots: make(chan struct{}, maxConcurrent),
+		locks: make(map[string]*sync.Mutex),
+	}
+}
+
+// acquire blocks until the execution may start and returns the function
+// that ends it. The skill lock is taken before a slot, so executions queued
+// behind an exclusive skill don't hold slots other skills could use.
+func (l *executionLimiter) acquire(skillName string, exclusive bool) (release func()) {
+	var lock *sync.Mutex
+	if exclusive {
+		l.mu.Lock()
+		lock = l.locks[skillName]
+		if lock == nil {
+			lock = &sync.Mutex{}
+			l.locks[skillName] = lock
+		}
+		l.mu.Unlock()
+
+		if !lock.TryLock() {
+			logging.Info("Waiting for another execution of exclusive skill '%s' to finish", skillName)
+			lock.Lock()
+		}
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	default:
+		logging.Info("Waiting for a free execution slot (%d running)", cap(l.slots))
+		l.slots <- struct{}{}
+	}
+
+	return func() {
+		<-l.slots
+		if lock != nil {
+			lock.Unlock()
+		}
+	}
+}
+
+// beginExecution waits until a skill may run and returns the function that
+// ends the execution
+func (s *Service) beginExecution(skillName string) func() {
+	s.limiterOnce.Do(func() {
+		maxConcurrent := 4
+		if s.appConfig != nil {
+			maxConcurrent = s.appConfig.Skills.GetMaxConcurrent()
+		}
+		s.limiter = newExecutionLimiter(maxConcurrent)
+	})
+
+	exclusive := s.imageMapping != nil && s.imageMapping.IsExclusive(skillName)
+	return s.limiter.acquire(skillName, exclusive)
+}
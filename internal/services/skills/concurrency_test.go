@@ -0,0 +1,67 @@
+package skills
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// runConcurrently runs an execution of each skill through the limiter and
+// returns the most that overlapped
+func runConcurrently(l *executionLimiter, skills []string, exclusive map[string]bool) int32 {
+	var running, peak int32
+	var wg sync.WaitGroup
+	for _, skill := range skills {
+		wg.Add(1)
+		go func(skill string) {
+			defer wg.Done()
+			release := l.acquire(skill, exclusive[skill])
+			defer release()
+
+			now := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}(skill)
+	}
+	wg.Wait()
+	return peak
+}
+
+func TestExecutionLimiterBoundsConcurrency(t *testing.T) {
+	l := newExecutionLimiter(2)
+	peak := runConcurrently(l, []string{"docx", "pptx", "xlsx", "pdf", "docx", "pptx"}, nil)
+	if peak != 2 {
+		t.Errorf("Expected 2 concurrent executions, got %d", peak)
+	}
+}
+
+func TestExecutionLimiterExclusiveSkill(t *testing.T) {
+	l := newExecutionLimiter(8)
+	peak := runConcurrently(l, []string{"docx", "docx", "docx", "docx"}, map[string]bool{"docx": true})
+	if peak != 1 {
+		t.Errorf("Exclusive skill executions should not overlap, got %d", peak)
+	}
+
+	// Other skills still run alongside
+	peak = runConcurrently(l, []string{"docx", "pptx", "pptx", "pptx"}, map[string]bool{"docx": true})
+	if peak < 2 {
+		t.Errorf("Non-exclusive skills should run concurrently, got %d", peak)
+	}
+}
+
+func TestIsExclusive(t *testing.T) {
+	mapping := &SkillImageMapping{Skills: map[string]*SkillSpec{
+		"docx": {Image: "mcp-skills-docx", Exclusive: true},
+		"pptx": {Image: "mcp-skills-pptx"},
+	}}
+	if !mapping.IsExclusive("docx") || mapping.IsExclusive("pptx") || mapping.IsExclusive("unknown") {
+		t.Error("IsExclusive should follow the skill's exclusive setting")
+	}
+}
@@ -1,6 +1,8 @@
 package skills
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -16,11 +18,22 @@ type SkillDefaults struct {
 	CPU         string `yaml:"cpu"`
 	Timeout     string `yaml:"timeout"`
 	OutputsDir  string `yaml:"outputs_dir"`
+
+	// Digest pins the default image (sha256:..., see `mcp-cli skills pull --pin`)
+	Digest string `yaml:"digest,omitempty"`
+
+	// PullPolicy is "missing" (default) to pull absent images at run time,
+	// or "never" to only run images already present
+	PullPolicy string `yaml:"pull_policy,omitempty"`
+
+	// RequireDigest refuses to run images that aren't pinned by digest
+	RequireDigest bool `yaml:"require_digest,omitempty"`
 }
 
 // SkillSpec contains the complete configuration for a skill
 type SkillSpec struct {
 	Image                string   `yaml:"image"`
+	Digest               string   `yaml:"digest,omitempty"` // Pins Image; repository digest or image ID
 	Language             string   `yaml:"language,omitempty"`
 	Languages            []string `yaml:"languages,omitempty"`
 	Description          string   `yaml:"description,omitempty"`
@@ -32,6 +45,10 @@ type SkillSpec struct {
 	Mounts               []string `yaml:"mounts,omitempty"`
 	Environment          []string `yaml:"environment,omitempty"`
 	NetworkJustification string   `yaml:"network_justification,omitempty"`
+
+	// Exclusive runs one execution of the skill at a time, for skills that
+	// write shared state in /outputs
+	Exclusive bool `yaml:"exclusive,omitempty"`
 }
 
 // SkillImageMapping maps skill names to their configurations (V2 format)
@@ -96,6 +113,11 @@ func LoadSkillImageMapping(path string) (*SkillImageMapping, error) {
 	if mapping.Skills == nil {
 		mapping.Skills = make(map[string]*SkillSpec)
 	}
+	switch mapping.Defaults.PullPolicy {
+	case "", "missing", "never":
+	default:
+		return nil, fmt.Errorf("invalid pull_policy '%s': use missing or never", mapping.Defaults.PullPolicy)
+	}
 
 	return &mapping, nil
 }
@@ -135,3 +157,113 @@ func (m *SkillImageMapping) GetNetworkModeForSkill(skillName string) string {
 	}
 	return m.Defaults.NetworkMode
 }
+
+// GetDigestForSkill returns the digest a skill's image is pinned to, or ""
+// if it isn't pinned. Skills without their own image use the default's pin.
+func (m *SkillImageMapping) GetDigestForSkill(skillName string) string {
+	if spec, exists := m.Skills[skillName]; exists && spec != nil && spec.Image != "" {
+		return spec.Digest
+	}
+	return m.Defaults.Digest
+}
+
+// IsExclusive reports whether executions of a skill must not overlap
+func (m *SkillImageMapping) IsExclusive(skillName string) bool {
+	spec, exists := m.Skills[skillName]
+	return exists && spec != nil && spec.Exclusive
+}
+
+// GetPullPolicy returns when missing images may be pulled at run time
+func (m *SkillImageMapping) GetPullPolicy() string {
+	return m.Defaults.PullPolicy
+}
+
+// RequiresDigest reports whether unpinned images are refused
+func (m *SkillImageMapping) RequiresDigest() bool {
+	return m.Defaults.RequireDigest
+}
+
+// PinDigests writes digest pins into a skill-images.yaml file, keeping its
+// comments and layout. Keys are skill names; "" pins the default image.
+func PinDigests(path string, digests map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a YAML mapping", path)
+	}
+	root := doc.Content[0]
+
+	for name, digest := range digests {
+		var section *yaml.Node
+		if name == "" {
+			section = mappingValue(root, "defaults")
+		} else if skillsNode := mappingValue(root, "skills"); skillsNode != nil {
+			section = mappingValue(skillsNode, name)
+		}
+		if section == nil || section.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s has no entry to pin for %s", path, pinName(name))
+		}
+		setMappingValue(section, "digest", digest)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// pinName describes a PinDigests key in errors
+func pinName(name string) string {
+	if name == "" {
+		return "defaults"
+	}
+	return "skill " + name
+}
+
+// mappingValue returns the value node for key in a YAML mapping, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets key in a YAML mapping. A new key is placed after
+// image, so the pin sits next to the reference it pins.
+func setMappingValue(mapping *yaml.Node, key, value string) {
+	if existing := mappingValue(mapping, key); existing != nil {
+		existing.Kind, existing.Tag, existing.Value, existing.Style = yaml.ScalarNode, "!!str", value, 0
+		return
+	}
+
+	at := len(mapping.Content)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == "image" {
+			at = i + 2
+			break
+		}
+	}
+	pair := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	}
+	mapping.Content = append(mapping.Content[:at], append(pair, mapping.Content[at:]...)...)
+}
@@ -3,6 +3,7 @@ package skills
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -190,3 +191,72 @@ skills:
 		t.Errorf("Expected docx -> mcp-skills-docx, got '%s'", mapping.Skills["docx"].Image)
 	}
 }
+
+func TestPinDigests(t *testing.T) {
+	mappingFile := filepath.Join(t.TempDir(), "skill-images.yaml")
+	content := `# Skill images
+defaults:
+  image: python:3.11-alpine # shared
+  memory: 256MB
+
+skills:
+  docx:
+    image: mcp-skills-docx
+    memory: 512MB
+  bash-tools:
+    language: bash
+`
+	if err := os.WriteFile(mappingFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	pins := map[string]string{"": "sha256:111", "docx": "sha256:222"}
+	if err := PinDigests(mappingFile, pins); err != nil {
+		t.Fatalf("PinDigests failed: %v", err)
+	}
+
+	mapping, err := LoadSkillImageMapping(mappingFile)
+	if err != nil {
+		t.Fatalf("LoadSkillImageMapping failed: %v", err)
+	}
+	if got := mapping.GetDigestForSkill("docx"); got != "sha256:222" {
+		t.Errorf("docx digest = %q, want sha256:222", got)
+	}
+	if got := mapping.GetDigestForSkill("bash-tools"); got != "sha256:111" {
+		t.Errorf("bash-tools should inherit the default pin, got %q", got)
+	}
+	if mapping.Skills["docx"].Memory != "512MB" {
+		t.Errorf("Other fields should be kept, got memory %q", mapping.Skills["docx"].Memory)
+	}
+
+	data, _ := os.ReadFile(mappingFile)
+	if !strings.Contains(string(data), "# Skill images") || !strings.Contains(string(data), "# shared") {
+		t.Errorf("Comments should be kept:\n%s", data)
+	}
+	if !strings.Contains(string(data), "image: mcp-skills-docx\n    digest: sha256:222") {
+		t.Errorf("Digest should follow the image:\n%s", data)
+	}
+
+	// Re-pinning replaces the digest
+	if err := PinDigests(mappingFile, map[string]string{"docx": "sha256:333"}); err != nil {
+		t.Fatalf("PinDigests failed: %v", err)
+	}
+	data, _ = os.ReadFile(mappingFile)
+	if strings.Count(string(data), "digest:") != 2 || !strings.Contains(string(data), "sha256:333") {
+		t.Errorf("Digest should be replaced:\n%s", data)
+	}
+
+	if err := PinDigests(mappingFile, map[string]string{"missing": "sha256:444"}); err == nil {
+		t.Error("Expected error pinning a skill not in the file")
+	}
+}
+
+func TestPullPolicyValidation(t *testing.T) {
+	mappingFile := filepath.Join(t.TempDir(), "skill-images.yaml")
+	if err := os.WriteFile(mappingFile, []byte("defaults:\n  pull_policy: always\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if _, err := LoadSkillImageMapping(mappingFile); err == nil {
+		t.Error("Expected error for invalid pull_policy")
+	}
+}
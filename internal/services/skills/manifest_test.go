@@ -0,0 +1,62 @@
+package skills
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+func TestDecodeFileContent(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"plain text", "hello world", "hello world"},
+		{"short word", "true", "true"},
+		{"base64 text", base64.StdEncoding.EncodeToString([]byte("a,b\n1,2\n")), "a,b\n1,2\n"},
+		{"base64 binary", base64.StdEncoding.EncodeToString(png), string(png)},
+		{"data URI", "data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte("hi")), "hi"},
+		{"plain data URI", "data:text/plain,a%20b", "a b"},
+	}
+	for _, tt := range tests {
+		if got := string(skills.DecodeFileContent(tt.content)); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithManifest(t *testing.T) {
+	request := &skills.CodeExecutionRequest{Files: map[string][]byte{
+		"data.csv":    []byte("a,b\n1,2\n"),
+		"report.docx": append([]byte("PK\x03\x04"), make([]byte, 32)...),
+	}}
+	withFiles := withManifest(request)
+	if len(request.Files) != 2 {
+		t.Error("withManifest modified the request")
+	}
+
+	var manifest skills.WorkspaceManifest
+	if err := json.Unmarshal(withFiles.Files[skills.ManifestFileName], &manifest); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if len(manifest.Files) != 2 {
+		t.Fatalf("manifest files = %+v", manifest.Files)
+	}
+	csv, docx := manifest.Files[0], manifest.Files[1]
+	if csv.Path != "/workspace/data.csv" || csv.MIMEType != "text/csv" || !csv.Text || csv.Size != 8 {
+		t.Errorf("data.csv = %+v", csv)
+	}
+	if docx.MIMEType != "application/vnd.openxmlformats-officedocument.wordprocessingml.document" || docx.Text {
+		t.Errorf("report.docx = %+v", docx)
+	}
+
+	// No files, no manifest
+	empty := &skills.CodeExecutionRequest{}
+	if withManifest(empty) != empty {
+		t.Error("manifest added to a request without files")
+	}
+}
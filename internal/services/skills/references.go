@@ -0,0 +1,255 @@
+package skills
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+// maxIndexHeadings bounds how many headings the reference index shows per file
+const maxIndexHeadings = 12
+
+// referenceInfo describes a reference file in a skill's reference index
+type referenceInfo struct {
+	Name     string
+	Path     string
+	Size     int64
+	Headings []string // Level 1 and 2 headings
+}
+
+// listReferences returns the skill's reference files: markdown files in
+// references/, then markdown files next to SKILL.md (the layout Anthropic
+// skills use for large references such as ooxml.md)
+func (s *Service) listReferences(skill *skills.Skill) []referenceInfo {
+	var refs []referenceInfo
+	seen := make(map[string]bool)
+	add := func(name, path string) {
+		if seen[name] {
+			return
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			return
+		}
+		seen[name] = true
+		ref := referenceInfo{Name: name, Path: path, Size: info.Size()}
+		if data, err := os.ReadFile(path); err == nil {
+			for _, h := range markdownHeadings(string(data)) {
+				if h.level <= 2 {
+					ref.Headings = append(ref.Headings, h.title)
+				}
+			}
+		}
+		refs = append(refs, ref)
+	}
+
+	for _, name := range skill.ReferenceFiles {
+		add(name, filepath.Join(skill.DirectoryPath, "references", name))
+	}
+
+	entries, err := os.ReadDir(skill.DirectoryPath)
+	if err == nil {
+		var names []string
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") && entry.Name() != "SKILL.md" {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			add(name, filepath.Join(skill.DirectoryPath, name))
+		}
+	}
+	return refs
+}
+
+// referenceIndex renders the reference files of a skill for a passive load,
+// so the model can fetch what it needs with read_skill_reference
+func (s *Service) referenceIndex(skill *skills.Skill) string {
+	refs := s.listReferences(skill)
+	if len(refs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n## Reference Files\n\n")
+	fmt.Fprintf(&b, "Not loaded. Read the ones you need with read_skill_reference (skill_name='%s', reference='<file>'), "+
+		"passing section='<heading>' to read just one part.\n\n", skill.Name)
+	for _, ref := range refs {
+		fmt.Fprintf(&b, "- %s (%s)", ref.Name, formatSize(ref.Size))
+		if len(ref.Headings) > 0 {
+			headings := ref.Headings
+			more := ""
+			if len(headings) > maxIndexHeadings {
+				more = fmt.Sprintf("; +%d more", len(headings)-maxIndexHeadings)
+				headings = headings[:maxIndexHeadings]
+			}
+			fmt.Fprintf(&b, ": %s%s", strings.Join(headings, "; "), more)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ReadReference reads a reference file, or one section or byte range of
+// it, returning at most skills.ReferenceReadLimit bytes
+func (s *Service) ReadReference(request *skills.ReferenceReadRequest) (*skills.ReferenceReadResult, error) {
+	if request.SkillName == "" {
+		return nil, fmt.Errorf("skill_name is required")
+	}
+	if request.Reference == "" {
+		return nil, fmt.Errorf("reference is required")
+	}
+
+	skill, exists := s.GetSkill(request.SkillName)
+	if !exists {
+		return nil, fmt.Errorf("skill not found: %s", request.SkillName)
+	}
+
+	refs := s.listReferences(skill)
+	var ref *referenceInfo
+	for i := range refs {
+		if refs[i].Name == request.Reference || strings.TrimPrefix(request.Reference, "references/") == refs[i].Name {
+			ref = &refs[i]
+			break
+		}
+	}
+	if ref == nil {
+		names := make([]string, len(refs))
+		for i := range refs {
+			names[i] = refs[i].Name
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("skill %s has no reference files", skill.Name)
+		}
+		return nil, fmt.Errorf("reference file not found: %s (available: %s)", request.Reference, strings.Join(names, ", "))
+	}
+
+	data, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference file: %w", err)
+	}
+	content := string(data)
+
+	if request.Section != "" {
+		section, ok := markdownSection(content, request.Section)
+		if !ok {
+			var headings []string
+			for _, h := range markdownHeadings(content) {
+				headings = append(headings, h.title)
+			}
+			return nil, fmt.Errorf("section %q not found in %s (headings: %s)", request.Section, ref.Name, strings.Join(headings, "; "))
+		}
+		content = section
+	}
+
+	if request.Offset < 0 || request.Offset > len(content) {
+		return nil, fmt.Errorf("offset %d is outside %s (%d bytes)", request.Offset, ref.Name, len(content))
+	}
+	length := request.Length
+	if length <= 0 || length > skills.ReferenceReadLimit {
+		length = skills.ReferenceReadLimit
+	}
+
+	start := runeStart(content, request.Offset)
+	end := start + length
+	if end >= len(content) {
+		end = len(content)
+	} else {
+		end = runeStart(content, end)
+	}
+
+	result := &skills.ReferenceReadResult{
+		SkillName: skill.Name,
+		Reference: ref.Name,
+		Section:   request.Section,
+		Content:   content[start:end],
+		Offset:    start,
+		TotalSize: len(content),
+	}
+	if end < len(content) {
+		result.NextOffset = end
+	}
+	return result, nil
+}
+
+// markdownHeading is an ATX heading (# Title) outside code fences
+type markdownHeading struct {
+	level  int
+	title  string
+	offset int // Byte offset of the heading line
+}
+
+func markdownHeadings(content string) []markdownHeading {
+	var headings []markdownHeading
+	inFence := false
+	offset := 0
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		} else if !inFence && strings.HasPrefix(trimmed, "#") {
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			title := strings.TrimSpace(strings.Trim(trimmed[level:], " #"))
+			if level <= 6 && title != "" && (len(trimmed) == level || trimmed[level] == ' ') {
+				headings = append(headings, markdownHeading{level: level, title: title, offset: offset})
+			}
+		}
+		offset += len(line)
+	}
+	return headings
+}
+
+// markdownSection returns the section under the heading matching name,
+// up to the next heading at the same or a higher level. An exact match
+// (ignoring case) wins over a heading that merely contains name.
+func markdownSection(content, name string) (string, bool) {
+	headings := markdownHeadings(content)
+	want := strings.ToLower(strings.TrimSpace(strings.TrimLeft(name, "# ")))
+
+	match := -1
+	for i, h := range headings {
+		title := strings.ToLower(h.title)
+		if title == want {
+			match = i
+			break
+		}
+		if match < 0 && strings.Contains(title, want) {
+			match = i
+		}
+	}
+	if match < 0 {
+		return "", false
+	}
+
+	start := headings[match].offset
+	end := len(content)
+	for _, h := range headings[match+1:] {
+		if h.level <= headings[match].level {
+			end = h.offset
+			break
+		}
+	}
+	return content[start:end], true
+}
+
+// runeStart moves a byte offset back to the start of the UTF-8 character
+// it falls in
+func runeStart(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
+
+func formatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
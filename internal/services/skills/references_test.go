@@ -0,0 +1,135 @@
+package skills
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+// newReferenceSkill creates a skill with a reference in references/ and a
+// large one next to SKILL.md
+func newReferenceSkill(t *testing.T) *Service {
+	t.Helper()
+	skillsDir := t.TempDir()
+	skillDir := filepath.Join(skillsDir, "docx")
+	files := map[string]string{
+		"SKILL.md":             "---\nname: docx\ndescription: Word documents\n---\n# DOCX\n\nRead ooxml.md for the XML details.\n",
+		"references/styles.md": "# Styles\n\nUse built-in styles.\n",
+		"ooxml.md": "# OOXML\n\nIntro.\n\n## Tables\n\nTable rows.\n\n### Cells\n\nCell text ünicode.\n\n" +
+			"```bash\n# not a heading\n```\n\n## Images\n\n" + strings.Repeat("x", 100) + "\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(skillDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := NewService()
+	if err := service.Initialize(skillsDir, skills.ExecutionModePassive); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return service
+}
+
+func TestPassiveLoadListsReferences(t *testing.T) {
+	service := newReferenceSkill(t)
+	result, err := service.LoadSkillByRequest(&skills.SkillLoadRequest{SkillName: "docx", Mode: skills.SkillLoadModePassive})
+	if err != nil {
+		t.Fatalf("LoadSkillByRequest failed: %v", err)
+	}
+
+	if strings.Contains(result.Content, "Table rows") || strings.Contains(result.Content, "Use built-in styles") {
+		t.Errorf("Reference contents should not be loaded by default:\n%s", result.Content)
+	}
+	for _, want := range []string{"read_skill_reference", "- styles.md (", "- ooxml.md (", "OOXML; Tables; Images"} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("Reference index missing %q:\n%s", want, result.Content)
+		}
+	}
+	if strings.Contains(result.Content, "not a heading") {
+		t.Error("Comments in code blocks should not be listed as headings")
+	}
+}
+
+func TestReadReference(t *testing.T) {
+	service := newReferenceSkill(t)
+
+	t.Run("WholeFile", func(t *testing.T) {
+		result, err := service.ReadReference(&skills.ReferenceReadRequest{SkillName: "docx", Reference: "references/styles.md"})
+		if err != nil {
+			t.Fatalf("ReadReference failed: %v", err)
+		}
+		if result.Content != "# Styles\n\nUse built-in styles.\n" || result.NextOffset != 0 {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("Section", func(t *testing.T) {
+		result, err := service.ReadReference(&skills.ReferenceReadRequest{SkillName: "docx", Reference: "ooxml.md", Section: "tables"})
+		if err != nil {
+			t.Fatalf("ReadReference failed: %v", err)
+		}
+		if !strings.HasPrefix(result.Content, "## Tables") || !strings.Contains(result.Content, "### Cells") {
+			t.Errorf("Section should include its subsections:\n%s", result.Content)
+		}
+		if strings.Contains(result.Content, "## Images") {
+			t.Errorf("Section should stop at the next heading of the same level:\n%s", result.Content)
+		}
+	})
+
+	t.Run("ByteRange", func(t *testing.T) {
+		result, err := service.ReadReference(&skills.ReferenceReadRequest{SkillName: "docx", Reference: "ooxml.md", Length: 10})
+		if err != nil {
+			t.Fatalf("ReadReference failed: %v", err)
+		}
+		if result.Content != "# OOXML\n\nI" || result.NextOffset != 10 {
+			t.Errorf("Unexpected range: %q next=%d", result.Content, result.NextOffset)
+		}
+		if !strings.Contains(result.Text(), "offset=10") {
+			t.Errorf("Text should say how to continue: %s", result.Text())
+		}
+
+		next, err := service.ReadReference(&skills.ReferenceReadRequest{SkillName: "docx", Reference: "ooxml.md", Offset: result.NextOffset})
+		if err != nil {
+			t.Fatalf("ReadReference failed: %v", err)
+		}
+		if !strings.HasPrefix(next.Content, "ntro.") || next.NextOffset != 0 {
+			t.Errorf("Unexpected continuation: %q next=%d", next.Content[:10], next.NextOffset)
+		}
+	})
+
+	t.Run("RangeEndsOnCharacterBoundary", func(t *testing.T) {
+		data, _ := os.ReadFile(filepath.Join(service.skillsDir, "docx", "ooxml.md"))
+		cut := strings.Index(string(data), "ü") + 1 // Inside the two-byte ü
+		result, err := service.ReadReference(&skills.ReferenceReadRequest{SkillName: "docx", Reference: "ooxml.md", Length: cut})
+		if err != nil {
+			t.Fatalf("ReadReference failed: %v", err)
+		}
+		if !strings.HasSuffix(result.Content, "Cell text ") {
+			t.Errorf("Range should not split a character: %q", result.Content)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := []*skills.ReferenceReadRequest{
+			{SkillName: "docx", Reference: "missing.md"},
+			{SkillName: "docx", Reference: "../docx/SKILL.md"},
+			{SkillName: "docx", Reference: "SKILL.md"},
+			{SkillName: "docx", Reference: "ooxml.md", Section: "Charts"},
+			{SkillName: "docx", Reference: "ooxml.md", Offset: 100000},
+			{SkillName: "unknown", Reference: "ooxml.md"},
+		}
+		for _, request := range cases {
+			if _, err := service.ReadReference(request); err == nil {
+				t.Errorf("Expected error for %+v", request)
+			}
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package skills
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+func TestReadResultFile(t *testing.T) {
+	dir := t.TempDir()
+	s := NewService()
+	s.SetConfig(&domainConfig.ApplicationConfig{Skills: &domainConfig.SkillsConfig{OutputsDir: dir}})
+	path := filepath.Join(dir, skills.ResultFileName)
+	started := time.Now()
+
+	// Nothing written
+	result := &skills.ExecutionResult{Stdout: "done\n"}
+	if s.readResultFile(started, result) != "" || result.Result != nil {
+		t.Errorf("result without a result file = %s", result.Result)
+	}
+
+	// A result left by an earlier run is ignored
+	if err := os.WriteFile(path, []byte(`{"rows": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	past := started.Add(-time.Hour)
+	os.Chtimes(path, past, past)
+	if s.readResultFile(started, result) != "" {
+		t.Error("read a result file from an earlier run")
+	}
+
+	if err := os.WriteFile(path, []byte("{\"rows\": 2}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.readResultFile(started, result); got != path {
+		t.Errorf("readResultFile = %q, want %q", got, path)
+	}
+	if string(result.Result) != `{"rows": 2}` {
+		t.Errorf("Result = %s", result.Result)
+	}
+	text := result.Text()
+	for _, want := range []string{"=== stdout ===\ndone", "=== result (/outputs/result.json) ===\n{\"rows\": 2}"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() = %q, missing %q", text, want)
+		}
+	}
+
+	// Invalid JSON is reported on stderr instead
+	if err := os.WriteFile(path, []byte("rows: 3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := &skills.ExecutionResult{Stderr: "warning: slow"}
+	s.readResultFile(started, invalid)
+	if invalid.Result != nil || !strings.Contains(invalid.Stderr, "warning: slow\n/outputs/result.json is not valid JSON") {
+		t.Errorf("invalid result = %s, stderr %q", invalid.Result, invalid.Stderr)
+	}
+}
+
+func TestExecutionResultText(t *testing.T) {
+	// Executors that combine the streams report only the output
+	combined := &skills.ExecutionResult{Output: "all output"}
+	if combined.Text() != "all output" {
+		t.Errorf("Text() = %q", combined.Text())
+	}
+
+	streams := &skills.ExecutionResult{Output: "data\nwarn", Stdout: "data\n", Stderr: "warn\n"}
+	if got, want := streams.Text(), "=== stdout ===\ndata\n\n=== stderr ===\nwarn"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/vector"
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// EmbedFunc returns the embedding vector for a text
+type EmbedFunc func(ctx context.Context, text string) ([]float32, error)
+
+// Router picks the skills relevant to a request by comparing its embedding
+// with embeddings of the skill descriptions
+type Router struct {
+	embed    EmbedFunc
+	topN     int
+	minScore float64
+	always   []string
+
+	mu          sync.Mutex
+	vectors     map[string][]float32 // Skill name -> description embedding
+	lastRequest string
+	lastSkills  []string
+}
+
+// NewRouter creates a router; call Index before Route
+func NewRouter(embed EmbedFunc, cfg *domainConfig.SkillRouterConfig) *Router {
+	r := &Router{embed: embed, topN: cfg.GetTopN()}
+	if cfg != nil {
+		r.minScore = cfg.MinScore
+		r.always = cfg.Always
+	}
+	return r
+}
+
+// Index embeds the description of every skill
+func (r *Router) Index(ctx context.Context, skillList []*skills.Skill) error {
+	vectors := make(map[string][]float32, len(skillList))
+	for _, skill := range skillList {
+		vector, err := r.embed(ctx, skill.Name+": "+skill.Description)
+		if err != nil {
+			return fmt.Errorf("failed to embed skill %s: %w", skill.Name, err)
+		}
+		vectors[skill.Name] = vector
+	}
+
+	r.mu.Lock()
+	r.vectors = vectors
+	r.lastRequest, r.lastSkills = "", nil
+	r.mu.Unlock()
+
+	logging.Info("Skill router indexed %d skills (top %d per request)", len(vectors), r.topN)
+	return nil
+}
+
+// Route returns the skills to offer for a request: the always-on skills,
+// then the top-N best matches. The last result is reused for the same
+// request, so follow-up turns after tool calls don't embed it again.
+func (r *Router) Route(ctx context.Context, request string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.vectors == nil {
+		return nil, fmt.Errorf("skill router has not been indexed")
+	}
+	if request == r.lastRequest && r.lastSkills != nil {
+		return r.lastSkills, nil
+	}
+
+	type scored struct {
+		name  string
+		score float64
+	}
+	var candidates []scored
+	selected := append([]string{}, r.always...)
+
+	if len(r.vectors) <= r.topN && r.minScore <= 0 {
+		// Every skill fits; no need to embed the request
+		for name := range r.vectors {
+			candidates = append(candidates, scored{name: name})
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].name < candidates[j].name })
+	} else {
+		query, err := r.embed(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed request: %w", err)
+		}
+		for name, skillVector := range r.vectors {
+			if score := vector.CosineSimilarity(query, skillVector); score >= r.minScore {
+				candidates = append(candidates, scored{name: name, score: score})
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].score != candidates[j].score {
+				return candidates[i].score > candidates[j].score
+			}
+			return candidates[i].name < candidates[j].name
+		})
+	}
+
+	routed := 0
+	for _, c := range candidates {
+		if routed == r.topN {
+			break
+		}
+		if containsName(selected, c.name) {
+			continue
+		}
+		selected = append(selected, c.name)
+		routed++
+		logging.Debug("Skill router selected '%s' (score %.3f)", c.name, c.score)
+	}
+
+	r.lastRequest, r.lastSkills = request, selected
+	logging.Info("Skill router offering %d of %d skills: %v", len(selected), len(r.vectors), selected)
+	return selected, nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRouter makes the service select skills per request with router
+func (s *Service) SetRouter(router *Router) {
+	s.router = router
+}
+
+// GetRouter returns the skill router, or nil when every skill is offered
+func (s *Service) GetRouter() *Router {
+	return s.router
+}
+
+// AllSkills returns every loaded skill, sorted by name
+func (s *Service) AllSkills() []*skills.Skill {
+	names := s.ListSkills()
+	sort.Strings(names)
+	list := make([]*skills.Skill, 0, len(names))
+	for _, name := range names {
+		list = append(list, s.skills[name])
+	}
+	return list
+}
@@ -0,0 +1,90 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+// keywordEmbed embeds a text as counts of a few keywords, so similarity
+// follows shared topics
+func keywordEmbed(calls *int) EmbedFunc {
+	keywords := []string{"word", "slide", "spreadsheet", "pdf"}
+	return func(ctx context.Context, text string) ([]float32, error) {
+		*calls++
+		vector := make([]float32, len(keywords))
+		for i, keyword := range keywords {
+			vector[i] = float32(strings.Count(strings.ToLower(text), keyword))
+		}
+		return vector, nil
+	}
+}
+
+func routerSkills() []*skills.Skill {
+	return []*skills.Skill{
+		{Name: "docx", Description: "Create and edit Word documents"},
+		{Name: "pptx", Description: "Build slide decks"},
+		{Name: "xlsx", Description: "Work with spreadsheet files"},
+		{Name: "pdf", Description: "Fill and merge PDF forms"},
+	}
+}
+
+func TestRouterRoute(t *testing.T) {
+	calls := 0
+	router := NewRouter(keywordEmbed(&calls), &domainConfig.SkillRouterConfig{TopN: 1, MinScore: 0.1, Always: []string{"pdf"}})
+	if err := router.Index(context.Background(), routerSkills()); err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+
+	selected, err := router.Route(context.Background(), "Turn this spreadsheet into a chart")
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if fmt.Sprint(selected) != "[pdf xlsx]" {
+		t.Errorf("Expected always-on pdf then xlsx, got %v", selected)
+	}
+
+	// The same request reuses the selection
+	before := calls
+	if _, err := router.Route(context.Background(), "Turn this spreadsheet into a chart"); err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if calls != before {
+		t.Error("Repeated request should not be embedded again")
+	}
+
+	// Nothing scores above min_score: only the always-on skills
+	selected, _ = router.Route(context.Background(), "What's the weather?")
+	if fmt.Sprint(selected) != "[pdf]" {
+		t.Errorf("Expected only pdf, got %v", selected)
+	}
+}
+
+func TestRouterAllSkillsFit(t *testing.T) {
+	calls := 0
+	router := NewRouter(keywordEmbed(&calls), &domainConfig.SkillRouterConfig{TopN: 10})
+	if err := router.Index(context.Background(), routerSkills()); err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+	calls = 0
+
+	selected, err := router.Route(context.Background(), "anything")
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if len(selected) != 4 || calls != 0 {
+		t.Errorf("Expected all 4 skills without embedding the request, got %v (%d calls)", selected, calls)
+	}
+}
+
+func TestRouterNotIndexed(t *testing.T) {
+	calls := 0
+	router := NewRouter(keywordEmbed(&calls), nil)
+	if _, err := router.Route(context.Background(), "anything"); err == nil {
+		t.Error("Expected error before Index")
+	}
+}
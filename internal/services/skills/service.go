@@ -2,6 +2,7 @@ package skills
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,12 +10,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/outputcrypt"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
 	"gopkg.in/yaml.v3"
 )
@@ -29,6 +32,17 @@ type Service struct {
 	imageMapping            *SkillImageMapping
 	appConfig               *domainConfig.ApplicationConfig
 	attemptedInitialization bool // Track if we tried to initialize executor
+
+	// Bounds concurrent executions; created on first use from appConfig
+	limiterOnce sync.Once
+	limiter     *executionLimiter
+
+	// Selects the skills offered per request; nil offers every skill
+	router *Router
+
+	// Files uploaded in chat, put into every execute_skill_code workspace
+	uploadsMu sync.Mutex
+	uploads   map[string]*upload
 }
 
 // NewService creates a new skill service
@@ -169,23 +183,9 @@ func (s *Service) logExecutionStatus() {
 	}
 
 	if s.executor == nil {
-		logging.Warn("")
-		logging.Warn("╔════════════════════════════════════════════════════════════╗")
-		logging.Warn("║  ⚠️  Docker/Podman Not Available                          ║")
-		logging.Warn("╚════════════════════════════════════════════════════════════╝")
-		logging.Warn("")
-		logging.Warn("  %d skills have scripts that require Docker/Podman:", scriptsCount)
-		for _, name := range skillsWithScripts {
-			logging.Warn("    - %s", name)
-		}
-		logging.Warn("")
-		logging.Warn("  These skills will run in PASSIVE mode (documentation only)")
-		logging.Warn("")
-		logging.Warn("  To enable script execution:")
-		logging.Warn("    1. Install Docker: https://docs.docker.com/get-docker/")
-		logging.Warn("       OR Podman: https://podman.io/getting-started/installation")
-		logging.Warn("    2. Restart mcp-cli")
-		logging.Warn("")
+		logging.Warn("Docker/Podman not available: %d skills with scripts run in passive mode (documentation only): %s",
+			scriptsCount, strings.Join(skillsWithScripts, ", "))
+		logging.Warn("Run 'mcp-cli doctor' to see what to fix")
 	} else {
 		logging.Info("")
 		logging.Info("✅ Script execution enabled for %d skills", scriptsCount)
@@ -615,6 +615,14 @@ func (s *Service) LoadAsPassive(skill *skills.Skill, request *skills.SkillLoadRe
 		}
 	}
 
+	// List the reference files that weren't loaded, so the model can read
+	// the parts it needs instead of the whole skill landing in context
+	if !request.IncludeReferences {
+		if index := s.referenceIndex(skill); index != "" {
+			contentParts = append(contentParts, index)
+		}
+	}
+
 	// Combine all content
 	result.Content = strings.Join(contentParts, "\n")
 
@@ -683,6 +691,7 @@ func (s *Service) ExecuteScript(skill *skills.Skill, scriptName string, args []s
 	}
 
 	duration := time.Since(startTime)
+	s.sealOutputs(startTime)
 
 	if err != nil {
 		logging.Warn("Script execution failed after %v: %v", duration, err)
@@ -846,6 +855,63 @@ func validatePythonSyntax(code string) error {
 	return nil
 }
 
+// sealOutputs encrypts what an execution wrote to the outputs directory,
+// when outputs encryption is configured
+func (s *Service) sealOutputs(since time.Time) {
+	if s.appConfig != nil {
+		outputcrypt.SealOutputs(s.appConfig.Skills, since)
+	}
+}
+
+// readResultFile sets the result's structured data from the result.json the
+// code wrote to the outputs directory, returning the file's path. Files left
+// from earlier runs are ignored; invalid JSON is reported on stderr.
+func (s *Service) readResultFile(started time.Time, result *skills.ExecutionResult) string {
+	if s.appConfig == nil {
+		return ""
+	}
+	path := filepath.Join(s.appConfig.Skills.GetOutputsDir(), skills.ResultFileName)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.ModTime().Before(started.Truncate(time.Second)) {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		logging.Warn("Failed to read %s: %v", path, err)
+		return ""
+	}
+	if !json.Valid(data) {
+		note := fmt.Sprintf("/outputs/%s is not valid JSON and was not returned as the result", skills.ResultFileName)
+		if result.Stderr != "" && !strings.HasSuffix(result.Stderr, "\n") {
+			result.Stderr += "\n"
+		}
+		result.Stderr += note
+		return path
+	}
+	result.Result = json.RawMessage(bytes.TrimSpace(data))
+	return path
+}
+
+// withManifest returns the request with /workspace/_manifest.json added,
+// describing its files. Requests without files, or with their own file of
+// that name, are returned as they are.
+func withManifest(request *skills.CodeExecutionRequest) *skills.CodeExecutionRequest {
+	if len(request.Files) == 0 {
+		return request
+	}
+	if _, ok := request.Files[skills.ManifestFileName]; ok {
+		return request
+	}
+	files := make(map[string][]byte, len(request.Files)+1)
+	for name, data := range request.Files {
+		files[name] = data
+	}
+	files[skills.ManifestFileName] = skills.BuildManifest(request.Files).JSON()
+	withFiles := *request
+	withFiles.Files = files
+	return &withFiles
+}
+
 // ExecuteCode executes arbitrary code with access to skill's helper libraries
 // This is the correct implementation matching Anthropic's design:
 // - LLM reads skill documentation
@@ -891,6 +957,9 @@ func (s *Service) ExecuteCode(request *skills.CodeExecutionRequest) (*skills.Exe
 		return nil, fmt.Errorf("language '%s' not supported (supported: 'python', 'bash')", request.Language)
 	}
 
+	// Files uploaded in chat are in every workspace, described by a manifest
+	request = withManifest(s.withUploads(request))
+
 	// Create temporary workspace
 	workspaceDir, err := os.MkdirTemp("", "skill-workspace-*")
 	if err != nil {
@@ -931,6 +1000,10 @@ func (s *Service) ExecuteCode(request *skills.CodeExecutionRequest) (*skills.Exe
 		return nil, fmt.Errorf("failed to write code file: %w", err)
 	}
 
+	// Concurrent calls run in parallel up to skills.max_concurrent; calls
+	// for an exclusive skill wait for each other
+	defer s.beginExecution(skill.Name)()
+
 	logging.Info("Executing code for skill: %s", skill.Name)
 	logging.Debug("Code length: %d bytes", len(request.Code))
 
@@ -949,8 +1022,13 @@ func (s *Service) ExecuteCode(request *skills.CodeExecutionRequest) (*skills.Exe
 	// - /skill (read-only): skill directory for importing helper libraries
 	startTime := time.Now()
 	var output string
+	var streams *sandbox.CodeOutput
 
-	if request.Language == "python" {
+	if streamExecutor, ok := s.executor.(sandbox.StreamExecutor); ok {
+		// Keep stdout and stderr apart so warnings aren't mistaken for data
+		streams, err = streamExecutor.ExecuteCodeStreams(ctx, request.Language, workspaceDir, skill.DirectoryPath, scriptPath, nil)
+		output = streams.Combined()
+	} else if request.Language == "python" {
 		output, err = s.executor.ExecutePythonCode(
 			ctx,
 			workspaceDir,        // workspace (read-write)
@@ -978,14 +1056,28 @@ func (s *Service) ExecuteCode(request *skills.CodeExecutionRequest) (*skills.Exe
 		Error:    err,
 		Duration: duration,
 	}
+	if streams != nil {
+		result.Stdout, result.Stderr = streams.Stdout, streams.Stderr
+	}
 
 	if err != nil {
 		result.ExitCode = 1
+		if streams != nil && streams.ExitCode > 0 {
+			result.ExitCode = streams.ExitCode
+		}
 		logging.Warn("Code execution failed after %dms: %v", duration, err)
 	} else {
 		logging.Info("Code executed successfully in %dms", duration)
 	}
 
+	resultFile := s.readResultFile(startTime, result)
+	s.recordAudit(request, skill, workspaceDir, scriptPath, startTime, result)
+	if resultFile != "" {
+		// Returned to the caller, so it doesn't linger for the next run
+		os.Remove(resultFile)
+	}
+	s.sealOutputs(startTime)
+
 	return result, nil
 }
 
@@ -1145,11 +1237,11 @@ func (s *Service) GenerateRunAsTools() ([]map[string]interface{}, error) {
 				},
 				"code": map[string]interface{}{
 					"type":        "string",
-					"description": "Code to execute in the specified language. IMPORTANT: Save all files to /outputs/ directory only. Bash example: bash /skill/scripts/process.sh /outputs/input.dat /outputs/output.dat | Python example: doc.save('/outputs/file.docx')",
+					"description": "Code to execute in the specified language. IMPORTANT: Save all files to /outputs/ directory only. Bash example: bash /skill/scripts/process.sh /outputs/input.dat /outputs/output.dat | Python example: doc.save('/outputs/file.docx'). Write JSON to /outputs/result.json to return structured data, separate from stdout and stderr.",
 				},
 				"files": map[string]interface{}{
 					"type":        "object",
-					"description": "Optional files to make available in workspace (filename -> base64 content)",
+					"description": "Optional files to make available in workspace (filename -> text or base64 content). Types and sizes are listed in /workspace/_manifest.json",
 				},
 			},
 			"required": []string{"skill_name", "code"},
@@ -1159,6 +1251,15 @@ func (s *Service) GenerateRunAsTools() ([]map[string]interface{}, error) {
 
 	tools = append(tools, executeCodeTool)
 
+	// See skills.ReadReferenceToolDescription
+	tools = append(tools, map[string]interface{}{
+		"name":          "read_skill_reference",
+		"description":   skills.ReadReferenceToolDescription,
+		"template":      "read_skill_reference",
+		"input_schema":  skills.ReadReferenceInputSchema(),
+		"input_mapping": map[string]string{},
+	})
+
 	logging.Info("Generated %d MCP tool definitions from skills", len(tools))
 
 	return tools, nil
@@ -1322,8 +1423,8 @@ func (s *Service) ExecuteTool(ctx context.Context, toolName string, arguments ma
 			request.Code = code
 		}
 
-		// Note: Files argument would need base64 decoding, skip for now
-		// If needed, implement proper conversion from interface{} to []byte
+		// Files are text, base64 or data: URIs
+		request.Files = skills.DecodeFiles(arguments["files"])
 
 		// Execute the code
 		result, err := s.ExecuteCode(request)
@@ -1331,7 +1432,15 @@ func (s *Service) ExecuteTool(ctx context.Context, toolName string, arguments ma
 			return "", fmt.Errorf("code execution failed: %w", err)
 		}
 
-		return result.Output, nil
+		return result.Text(), nil
+	}
+
+	if toolName == "read_skill_reference" {
+		result, err := s.ReadReference(skills.ReferenceReadRequestFromArguments(arguments))
+		if err != nil {
+			return "", err
+		}
+		return result.Text(), nil
 	}
 
 	// For other skill tools, extract skill name from tool name (format: skillname:operation or skillname_operation)
@@ -1416,6 +1525,8 @@ func (s *Service) RunHelperScript(request *skills.HelperScriptRequest) (*skills.
 	// Build script path for container (relative to /skill/)
 	containerScriptPath := fmt.Sprintf("/skill/scripts/%s", request.ScriptName)
 
+	defer s.beginExecution(skill.Name)()
+
 	// Create execution context with timeout (default 120 seconds)
 	timeout := 120 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -1434,6 +1545,7 @@ func (s *Service) RunHelperScript(request *skills.HelperScriptRequest) (*skills.
 	}
 
 	duration := time.Since(startTime).Milliseconds()
+	s.sealOutputs(startTime)
 
 	result := &skills.ExecutionResult{
 		Output:   output,
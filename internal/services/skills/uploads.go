@@ -0,0 +1,102 @@
+package skills
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// upload is a file uploaded into the sandbox workspace, kept in memory for
+// the rest of the session
+type upload struct {
+	file skills.UploadedFile
+	data []byte
+}
+
+// UploadFile copies a host file into the sandbox workspace: every later
+// execute_skill_code call finds it at /workspace/<name>. Uploading a file
+// with the same name replaces the earlier one.
+func (s *Service) UploadFile(hostPath string) (*skills.UploadedFile, error) {
+	path, err := filepath.Abs(hostPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", hostPath)
+	}
+
+	if limit := s.skillsConfig().GetMaxUploadSize(); info.Size() > limit {
+		return nil, fmt.Errorf("%s is %d MB, over the %d MB upload limit (skills.max_upload_mb)", hostPath, info.Size()>>20, limit>>20)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(path)
+	file := skills.UploadedFile{
+		Name:          name,
+		HostPath:      path,
+		ContainerPath: "/workspace/" + name,
+		Size:          int64(len(data)),
+	}
+
+	s.uploadsMu.Lock()
+	defer s.uploadsMu.Unlock()
+	if s.uploads == nil {
+		s.uploads = make(map[string]*upload)
+	}
+	s.uploads[name] = &upload{file: file, data: data}
+	logging.Info("Uploaded %s to %s (%d bytes)", path, file.ContainerPath, file.Size)
+	return &file, nil
+}
+
+// Uploads returns the files uploaded into the workspace, by name
+func (s *Service) Uploads() []skills.UploadedFile {
+	s.uploadsMu.Lock()
+	defer s.uploadsMu.Unlock()
+	files := make([]skills.UploadedFile, 0, len(s.uploads))
+	for _, u := range s.uploads {
+		files = append(files, u.file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files
+}
+
+// withUploads returns the request with the uploaded files added to its
+// workspace files. Files passed with the request win over uploads.
+func (s *Service) withUploads(request *skills.CodeExecutionRequest) *skills.CodeExecutionRequest {
+	s.uploadsMu.Lock()
+	defer s.uploadsMu.Unlock()
+	if len(s.uploads) == 0 {
+		return request
+	}
+
+	files := make(map[string][]byte, len(request.Files)+len(s.uploads))
+	for name, u := range s.uploads {
+		files[name] = u.data
+	}
+	for name, data := range request.Files {
+		files[name] = data
+	}
+	withFiles := *request
+	withFiles.Files = files
+	return &withFiles
+}
+
+// skillsConfig returns the skills settings, which may be nil
+func (s *Service) skillsConfig() *domainConfig.SkillsConfig {
+	if s.appConfig == nil {
+		return nil
+	}
+	return s.appConfig.Skills
+}
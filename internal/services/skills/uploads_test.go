@@ -0,0 +1,52 @@
+package skills
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainConfig "github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/skills"
+)
+
+func TestUploadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.csv")
+	if err := os.WriteFile(path, []byte("a,b\n1,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService()
+	s.SetConfig(&domainConfig.ApplicationConfig{Skills: &domainConfig.SkillsConfig{MaxUploadMB: 1}})
+	file, err := s.UploadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.ContainerPath != "/workspace/report.csv" || file.Size != 8 {
+		t.Errorf("uploaded = %+v", file)
+	}
+
+	// Uploads join every request's workspace files, which win on conflicts
+	request := &skills.CodeExecutionRequest{Files: map[string][]byte{"notes.txt": []byte("hi")}}
+	withUploads := s.withUploads(request)
+	if string(withUploads.Files["report.csv"]) != "a,b\n1,2\n" || string(withUploads.Files["notes.txt"]) != "hi" {
+		t.Errorf("workspace files = %v", withUploads.Files)
+	}
+	if len(request.Files) != 1 {
+		t.Error("withUploads modified the request")
+	}
+
+	big := filepath.Join(dir, "big.bin")
+	if err := os.WriteFile(big, make([]byte, 2<<20), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UploadFile(big); err == nil {
+		t.Error("expected an error over max_upload_mb")
+	}
+	if _, err := s.UploadFile(dir); err == nil {
+		t.Error("expected an error for a directory")
+	}
+	if uploads := s.Uploads(); len(uploads) != 1 {
+		t.Errorf("uploads = %v, want only report.csv", uploads)
+	}
+}
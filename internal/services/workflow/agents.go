@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// agentServerManager limits a server manager to the tools an agent's tools:
+// list allows. Tools outside the list are neither offered to the LLM nor run.
+type agentServerManager struct {
+	domain.MCPServerManager
+	agent *config.AgentConfig
+}
+
+// agentTools wraps manager when the step's agent restricts its tools
+func agentTools(manager domain.MCPServerManager, step *config.StepV2) domain.MCPServerManager {
+	if manager == nil || step.ResolvedAgent == nil || len(step.ResolvedAgent.Tools) == 0 {
+		return manager
+	}
+	return &agentServerManager{MCPServerManager: manager, agent: step.ResolvedAgent}
+}
+
+// GetAvailableTools returns the wrapped manager's tools the agent allows
+func (m *agentServerManager) GetAvailableTools() ([]domain.Tool, error) {
+	tools, err := m.MCPServerManager.GetAvailableTools()
+	if err != nil {
+		return nil, err
+	}
+	return m.filter(tools), nil
+}
+
+// SelectTools narrows the wrapped manager's selection to the allowed tools
+func (m *agentServerManager) SelectTools(ctx context.Context, request string) ([]domain.Tool, error) {
+	tools, err := domain.SelectTools(ctx, m.MCPServerManager, request)
+	if err != nil {
+		return nil, err
+	}
+	return m.filter(tools), nil
+}
+
+// ExecuteTool runs a tool the agent allows
+func (m *agentServerManager) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	if !m.agent.AllowsTool(toolName) {
+		return "", fmt.Errorf("tool %s is not available to agent %s", toolName, m.agent.Name)
+	}
+	return m.MCPServerManager.ExecuteTool(ctx, toolName, arguments)
+}
+
+func (m *agentServerManager) filter(tools []domain.Tool) []domain.Tool {
+	allowed := make([]domain.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if m.agent.AllowsTool(tool.Function.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toolsServerManager offers a fixed set of tools and records the ones run
+type toolsServerManager struct {
+	domain.MCPServerManager
+	tools []string
+	ran   []string
+}
+
+func (m *toolsServerManager) GetAvailableTools() ([]domain.Tool, error) {
+	tools := make([]domain.Tool, len(m.tools))
+	for i, name := range m.tools {
+		tools[i] = domain.Tool{Type: "function", Function: domain.ToolFunction{Name: name}}
+	}
+	return tools, nil
+}
+
+func (m *toolsServerManager) ExecuteTool(_ context.Context, toolName string, _ map[string]interface{}) (string, error) {
+	m.ran = append(m.ran, toolName)
+	return "ok", nil
+}
+
+func TestAgentTools(t *testing.T) {
+	manager := &toolsServerManager{tools: []string{"search_web", "search_docs", "write_file"}}
+
+	// Steps without an agent, or whose agent allows every tool, get the manager as is
+	assert.Same(t, manager, agentTools(manager, &config.StepV2{Name: "plain"}))
+	assert.Same(t, manager, agentTools(manager, &config.StepV2{Name: "open", ResolvedAgent: &config.AgentConfig{Name: "any"}}))
+
+	step := &config.StepV2{Name: "research", ResolvedAgent: &config.AgentConfig{Name: "researcher", Tools: []string{"search_*"}}}
+	limited := agentTools(manager, step)
+
+	tools, err := limited.GetAvailableTools()
+	require.NoError(t, err)
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Function.Name
+	}
+	assert.Equal(t, []string{"search_web", "search_docs"}, names)
+
+	selected, err := domain.SelectTools(context.Background(), limited, "find it")
+	require.NoError(t, err)
+	assert.Len(t, selected, 2)
+
+	_, err = limited.ExecuteTool(context.Background(), "write_file", nil)
+	assert.ErrorContains(t, err, "not available to agent researcher")
+	_, err = limited.ExecuteTool(context.Background(), "search_web", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"search_web"}, manager.ran)
+}
+
+func TestValidateAgent(t *testing.T) {
+	workflow := &config.WorkflowV2{
+		Name:      "review",
+		Execution: config.ExecutionContext{Provider: "openai", Model: "gpt-4o"},
+		Agents:    []config.AgentConfig{{Name: "critic"}},
+		Steps: []config.StepV2{
+			{Name: "critique", Agent: "critic", Run: "Critique it"},
+			{Name: "rewrite", Agent: "writer", Run: "Rewrite it"},
+		},
+	}
+
+	err := ValidateWorkflow(workflow)
+	assert.ErrorContains(t, err, "unknown agent 'writer'")
+	assert.NotContains(t, err.Error(), "'critic'")
+}
@@ -0,0 +1,269 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/builtintools"
+)
+
+// ErrBlackboardConflict is returned by conditional writes when another step
+// wrote the key first
+var ErrBlackboardConflict = errors.New("blackboard version conflict")
+
+// BlackboardEntry is one version of a blackboard key
+type BlackboardEntry struct {
+	Version int       `json:"version"`
+	Value   string    `json:"value"`
+	Writer  string    `json:"writer"` // Step that wrote it, or "workflow" for initial entries
+	Written time.Time `json:"written"`
+}
+
+// Blackboard is shared memory for a run: every write adds a version of a
+// key, and readers see the latest version or all of them. It is safe for
+// parallel steps, and called workflows share their caller's blackboard.
+type Blackboard struct {
+	mu      sync.RWMutex
+	entries map[string][]BlackboardEntry
+}
+
+// NewBlackboard creates an empty blackboard
+func NewBlackboard() *Blackboard {
+	return &Blackboard{entries: make(map[string][]BlackboardEntry)}
+}
+
+// Write adds a version of key and returns its number. With ifVersion set the
+// write only happens while the key is at that version (0 when it has not
+// been written); otherwise it fails with ErrBlackboardConflict.
+func (b *Blackboard) Write(key, value, writer string, ifVersion *int) (int, error) {
+	if !config.ValidBlackboardKey(key) {
+		return 0, fmt.Errorf("invalid blackboard key %q: use letters, digits, _ and -", key)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current := len(b.entries[key])
+	if ifVersion != nil && *ifVersion != current {
+		return current, fmt.Errorf("%w: %s is at version %d, not %d", ErrBlackboardConflict, key, current, *ifVersion)
+	}
+
+	entry := BlackboardEntry{Version: current + 1, Value: value, Writer: writer, Written: time.Now()}
+	b.entries[key] = append(b.entries[key], entry)
+	return entry.Version, nil
+}
+
+// Entries returns every version of key, oldest first
+func (b *Blackboard) Entries(key string) []BlackboardEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]BlackboardEntry(nil), b.entries[key]...)
+}
+
+// Latest returns the newest version of key
+func (b *Blackboard) Latest(key string) (BlackboardEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	entries := b.entries[key]
+	if len(entries) == 0 {
+		return BlackboardEntry{}, false
+	}
+	return entries[len(entries)-1], true
+}
+
+// Keys returns the written keys, sorted
+func (b *Blackboard) Keys() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	keys := make([]string, 0, len(b.entries))
+	for key := range b.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MarshalJSON encodes every key with all its versions
+func (b *Blackboard) MarshalJSON() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return json.Marshal(b.entries)
+}
+
+// lookup resolves a variable after "blackboard.": "<key>" is the latest
+// value, "<key>.all" every value oldest first, "<key>.version" the current
+// version and "json" the whole blackboard. Keys not written yet are empty,
+// since with parallel steps they may simply not have been written so far.
+func (b *Blackboard) lookup(name string) (string, bool) {
+	if name == "json" {
+		data, err := json.Marshal(b)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+
+	key, field, _ := strings.Cut(name, ".")
+	if !config.ValidBlackboardKey(key) {
+		return "", false
+	}
+	switch field {
+	case "":
+		latest, _ := b.Latest(key)
+		return latest.Value, true
+	case "all":
+		entries := b.Entries(key)
+		values := make([]string, len(entries))
+		for i, entry := range entries {
+			values[i] = entry.Value
+		}
+		return strings.Join(values, "\n\n"), true
+	case "version":
+		latest, _ := b.Latest(key)
+		return strconv.Itoa(latest.Version), true
+	}
+	return "", false
+}
+
+// writeBlackboard adds a step's write_blackboard entries once it succeeds
+func (o *Orchestrator) writeBlackboard(step *config.StepV2) error {
+	for _, write := range step.WriteBlackboard {
+		value := ""
+		if write.Value != "" {
+			interpolated, err := o.interpolator.Interpolate(write.Value)
+			if err != nil {
+				return fmt.Errorf("write_blackboard %s: failed to interpolate value: %w", write.Key, err)
+			}
+			value = interpolated
+		} else if result, ok := o.interpolator.GetVariable(step.Name); ok {
+			value = result
+		}
+
+		version, err := o.blackboard.Write(write.Key, value, step.Name, write.IfVersion)
+		if err != nil {
+			return fmt.Errorf("write_blackboard: %w", err)
+		}
+		o.logger.Info("Blackboard: %s wrote %s version %d", step.Name, write.Key, version)
+	}
+	return nil
+}
+
+// seedBlackboard writes the workflow's initial entries. A called workflow
+// shares its caller's blackboard, so keys already written are left alone.
+func (o *Orchestrator) seedBlackboard() {
+	if o.workflow.Blackboard == nil {
+		return
+	}
+	unwritten := 0
+	for key, value := range o.workflow.Blackboard.Initial {
+		if _, err := o.blackboard.Write(key, value, "workflow", &unwritten); err != nil && !errors.Is(err, ErrBlackboardConflict) {
+			o.logger.Warn("Blackboard: initial entry skipped - %v", err)
+		}
+	}
+}
+
+// blackboardTools offers the read_blackboard and write_blackboard tools to
+// a run step when the workflow's blackboard enables them
+func (e *Executor) blackboardTools(manager domain.MCPServerManager, step *config.StepV2) domain.MCPServerManager {
+	if e.blackboard == nil || e.workflow.Blackboard == nil || !e.workflow.Blackboard.Tools {
+		return manager
+	}
+	board := e.blackboard
+
+	read := builtintools.Tool{
+		Name:        "read_blackboard",
+		Description: "Read the workflow's shared blackboard, where parallel steps and agents leave their work. Without a key, lists the keys with their current versions.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"key": map[string]interface{}{
+					"type":        "string",
+					"description": "Key to read; omit to list the keys",
+				},
+				"all_versions": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return every version of the key instead of only the latest",
+				},
+			},
+		},
+		Execute: func(ctx context.Context, arguments map[string]interface{}) (string, error) {
+			key, _ := arguments["key"].(string)
+			if key == "" {
+				versions := make(map[string]int)
+				for _, key := range board.Keys() {
+					latest, _ := board.Latest(key)
+					versions[key] = latest.Version
+				}
+				return marshalToolResult(versions)
+			}
+			if all, _ := arguments["all_versions"].(bool); all {
+				return marshalToolResult(board.Entries(key))
+			}
+			latest, ok := board.Latest(key)
+			if !ok {
+				return fmt.Sprintf("%s has not been written yet", key), nil
+			}
+			return marshalToolResult(latest)
+		},
+	}
+
+	write := builtintools.Tool{
+		Name:        "write_blackboard",
+		Description: "Add a new version of a key on the workflow's shared blackboard for other steps and agents to read. Pass if_version to write only if nobody else has written the key since you read it.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"key": map[string]interface{}{
+					"type":        "string",
+					"description": "Key to write (letters, digits, _ and -)",
+				},
+				"value": map[string]interface{}{
+					"type":        "string",
+					"description": "Full new value of the key",
+				},
+				"if_version": map[string]interface{}{
+					"type":        "integer",
+					"description": "Version the key must still be at (0 if not written yet)",
+				},
+			},
+			"required": []string{"key", "value"},
+		},
+		Execute: func(ctx context.Context, arguments map[string]interface{}) (string, error) {
+			key, _ := arguments["key"].(string)
+			value, _ := arguments["value"].(string)
+			var ifVersion *int
+			if v, ok := arguments["if_version"].(float64); ok {
+				version := int(v)
+				ifVersion = &version
+			}
+			version, err := board.Write(key, value, step.Name, ifVersion)
+			if errors.Is(err, ErrBlackboardConflict) {
+				return fmt.Sprintf("Not written: %v. Read the key again and merge your changes.", err), nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Wrote %s version %d", key, version), nil
+		},
+	}
+
+	return builtintools.NewServerManager(manager, []builtintools.Tool{read, write})
+}
+
+// marshalToolResult encodes a tool result as indented JSON
+func marshalToolResult(value interface{}) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,184 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlackboardWrite(t *testing.T) {
+	board := NewBlackboard()
+
+	version, err := board.Write("findings", "open port 22", "scan", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+	version, err = board.Write("findings", "weak ciphers", "tls", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	// Conditional writes only happen at the expected version
+	stale := 1
+	_, err = board.Write("findings", "lost update", "late", &stale)
+	assert.ErrorIs(t, err, ErrBlackboardConflict)
+	unwritten := 0
+	version, err = board.Write("summary", "first", "writer", &unwritten)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	_, err = board.Write("bad key", "x", "s", nil)
+	assert.ErrorContains(t, err, "invalid blackboard key")
+
+	latest, ok := board.Latest("findings")
+	require.True(t, ok)
+	assert.Equal(t, BlackboardEntry{Version: 2, Value: "weak ciphers", Writer: "tls", Written: latest.Written}, latest)
+	assert.Len(t, board.Entries("findings"), 2)
+	assert.Equal(t, []string{"findings", "summary"}, board.Keys())
+}
+
+func TestBlackboardConcurrentWrites(t *testing.T) {
+	board := NewBlackboard()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = board.Write("notes", "note", "agent", nil)
+			board.lookup("notes.all")
+		}()
+	}
+	wg.Wait()
+
+	entries := board.Entries("notes")
+	require.Len(t, entries, 20)
+	for i, entry := range entries {
+		assert.Equal(t, i+1, entry.Version)
+	}
+}
+
+func TestBlackboardInterpolation(t *testing.T) {
+	board := NewBlackboard()
+	interpolator := NewInterpolator()
+	interpolator.SetBlackboard(board)
+
+	_, _ = board.Write("findings", "open port 22", "scan", nil)
+	_, _ = board.Write("findings", "weak ciphers", "tls", nil)
+
+	got, err := interpolator.Interpolate("{{blackboard.findings}} | ${{ blackboard.findings.version }} | {{blackboard.missing}} | {{blackboard.missing.version}}")
+	require.NoError(t, err)
+	assert.Equal(t, "weak ciphers | 2 |  | 0", got)
+
+	got, err = interpolator.Interpolate("{{blackboard.findings.all}}")
+	require.NoError(t, err)
+	assert.Equal(t, "open port 22\n\nweak ciphers", got)
+
+	// Clones, such as loop iterations, see later writes
+	clone := interpolator.Clone()
+	_, _ = board.Write("findings", "patched", "fix", nil)
+	got, _ = clone.Interpolate("{{blackboard.findings}}")
+	assert.Equal(t, "patched", got)
+
+	got, err = interpolator.Interpolate("{{blackboard.json}}")
+	require.NoError(t, err)
+	var decoded map[string][]BlackboardEntry
+	require.NoError(t, json.Unmarshal([]byte(got), &decoded))
+	assert.Len(t, decoded["findings"], 3)
+
+	_, err = interpolator.Interpolate("{{blackboard.findings.bogus}}")
+	assert.ErrorContains(t, err, "undefined variables")
+}
+
+func TestWriteBlackboard(t *testing.T) {
+	unwritten := 0
+	o := NewOrchestrator(&config.WorkflowV2{
+		Name:       "triage",
+		Blackboard: &config.BlackboardConfig{Initial: map[string]string{"status": "open"}},
+	}, NewLogger("error", false))
+	o.seedBlackboard()
+
+	o.setStepResult("scan", "port 22 open")
+	require.NoError(t, o.writeBlackboard(&config.StepV2{Name: "scan", WriteBlackboard: []config.BlackboardWrite{
+		{Key: "findings"},
+		{Key: "status", Value: "scanned by {{scan}}"},
+		{Key: "owner", Value: "scan", IfVersion: &unwritten},
+	}}))
+
+	findings, _ := o.blackboard.Latest("findings")
+	assert.Equal(t, BlackboardEntry{Version: 1, Value: "port 22 open", Writer: "scan", Written: findings.Written}, findings)
+	status := o.blackboard.Entries("status")
+	require.Len(t, status, 2)
+	assert.Equal(t, "workflow", status[0].Writer)
+	assert.Equal(t, "scanned by port 22 open", status[1].Value)
+
+	// A called workflow sharing the blackboard doesn't reseed written keys
+	o.seedBlackboard()
+	assert.Len(t, o.blackboard.Entries("status"), 2)
+
+	err := o.writeBlackboard(&config.StepV2{Name: "late", WriteBlackboard: []config.BlackboardWrite{
+		{Key: "owner", Value: "late", IfVersion: &unwritten},
+	}})
+	assert.ErrorIs(t, err, ErrBlackboardConflict)
+}
+
+func TestBlackboardTools(t *testing.T) {
+	workflow := &config.WorkflowV2{Name: "triage", Blackboard: &config.BlackboardConfig{Tools: true}}
+	executor := NewExecutor(workflow, NewLogger("error", false))
+	executor.blackboard = NewBlackboard()
+	step := &config.StepV2{Name: "analyst"}
+
+	manager := executor.blackboardTools(&toolsServerManager{tools: []string{"search_web"}}, step)
+	tools, err := manager.GetAvailableTools()
+	require.NoError(t, err)
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Function.Name
+	}
+	assert.ElementsMatch(t, []string{"search_web", "read_blackboard", "write_blackboard"}, names)
+
+	ctx := context.Background()
+	out, err := manager.ExecuteTool(ctx, "write_blackboard", map[string]interface{}{"key": "report", "value": "draft 1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Wrote report version 1", out)
+
+	out, err = manager.ExecuteTool(ctx, "write_blackboard", map[string]interface{}{"key": "report", "value": "draft 2", "if_version": float64(0)})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Not written")
+
+	out, err = manager.ExecuteTool(ctx, "read_blackboard", map[string]interface{}{"key": "report"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `"value": "draft 1"`)
+	assert.Contains(t, out, `"writer": "analyst"`)
+
+	out, err = manager.ExecuteTool(ctx, "read_blackboard", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"report": 1}`, out)
+
+	// Without tools: true the step gets the manager unchanged
+	plain := NewExecutor(&config.WorkflowV2{Name: "plain"}, NewLogger("error", false))
+	plain.blackboard = NewBlackboard()
+	inner := &toolsServerManager{}
+	assert.Same(t, inner, plain.blackboardTools(inner, step))
+}
+
+func TestValidateWriteBlackboard(t *testing.T) {
+	negative := -1
+	wf := &config.WorkflowV2{
+		Name:       "triage",
+		Execution:  config.ExecutionContext{Provider: "openai", Model: "gpt-4o"},
+		Blackboard: &config.BlackboardConfig{Initial: map[string]string{"status": "open"}},
+		Steps: []config.StepV2{
+			{Name: "scan", Run: "Scan {{blackboard.status}}", WriteBlackboard: []config.BlackboardWrite{{Key: "findings"}}},
+		},
+	}
+	assert.NoError(t, ValidateWorkflow(wf))
+
+	wf.Steps[0].WriteBlackboard = []config.BlackboardWrite{{Key: "has space"}, {Key: "ok", IfVersion: &negative}}
+	err := ValidateWorkflow(wf)
+	assert.ErrorContains(t, err, "invalid blackboard key 'has space'")
+	assert.ErrorContains(t, err, "if_version cannot be negative")
+}
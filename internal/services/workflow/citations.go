@@ -0,0 +1,156 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
+)
+
+// citationMatchThreshold is the share of a result's distinctive words an
+// answer without citation markers must contain for the result to count as used
+const citationMatchThreshold = 0.3
+
+// Citation methods
+const (
+	CitationMarker     = "marker"     // The answer cited the result by its label
+	CitationSimilarity = "similarity" // The answer shares enough words with the result
+)
+
+// Citation is a RAG result that a step's answer used
+type Citation struct {
+	Step   string  `json:"step"`             // RAG step the result came from
+	Index  int     `json:"index"`            // Result number in that step's output, from 1
+	ID     string  `json:"id"`               // Chunk ID
+	Source string  `json:"source,omitempty"` // Source file or document
+	Server string  `json:"server,omitempty"` // RAG server, for steps that search several
+	Score  float64 `json:"score"`            // Retrieval score
+	Method string  `json:"method"`           // How the use was detected (marker or similarity)
+}
+
+var citationMarkerPattern = regexp.MustCompile(`\[([A-Za-z_][A-Za-z0-9_-]*):(\d+)\]`)
+
+// citationLabel is the label a result is cited by, e.g. docs:2
+func citationLabel(step string, index int) string {
+	return fmt.Sprintf("%s:%d", step, index)
+}
+
+// setCitable keeps a rag step's results so later answers can be checked for
+// citations of them
+func (o *Orchestrator) setCitable(stepName string, results []rag.SearchResult) {
+	o.stepResultsMu.Lock()
+	defer o.stepResultsMu.Unlock()
+	if o.citable == nil {
+		o.citable = make(map[string][]rag.SearchResult)
+	}
+	o.citable[stepName] = results
+}
+
+// recordCitations sets {{step.citations}} for a step whose prompt used the
+// output of rag steps with citations: a JSON list of the results its answer
+// cited, in the order they were retrieved
+func (o *Orchestrator) recordCitations(stepName, prompt, answer string) {
+	o.stepResultsMu.RLock()
+	sources := make(map[string][]rag.SearchResult)
+	for _, ref := range new(VariableValidator).extractVariableReferences(prompt) {
+		if results, ok := o.citable[ref]; ok {
+			sources[ref] = results
+		}
+	}
+	o.stepResultsMu.RUnlock()
+	if len(sources) == 0 {
+		return
+	}
+
+	citations := findCitations(sources, answer)
+	data, _ := json.Marshal(citations)
+	o.interpolator.Set(stepName+".citations", string(data))
+	o.logger.Info("Step %s cites %d of the retrieved results", stepName, len(citations))
+}
+
+// findCitations returns the results answer cites by label. An answer without
+// labels is matched by the words it shares with each result instead.
+func findCitations(sources map[string][]rag.SearchResult, answer string) []Citation {
+	cited := make(map[string]map[int]bool)
+	for _, match := range citationMarkerPattern.FindAllStringSubmatch(answer, -1) {
+		index, _ := strconv.Atoi(match[2])
+		if results, ok := sources[match[1]]; ok && index >= 1 && index <= len(results) {
+			if cited[match[1]] == nil {
+				cited[match[1]] = make(map[int]bool)
+			}
+			cited[match[1]][index] = true
+		}
+	}
+
+	method := CitationMarker
+	if len(cited) == 0 {
+		method = CitationSimilarity
+		answerWords := distinctiveWords(answer)
+		for step, results := range sources {
+			for i, result := range results {
+				if wordCoverage(distinctiveWords(result.JoinedText()), answerWords) >= citationMatchThreshold {
+					if cited[step] == nil {
+						cited[step] = make(map[int]bool)
+					}
+					cited[step][i+1] = true
+				}
+			}
+		}
+	}
+
+	steps := make([]string, 0, len(sources))
+	for step := range sources {
+		steps = append(steps, step)
+	}
+	sort.Strings(steps)
+
+	citations := []Citation{}
+	for _, step := range steps {
+		for i, result := range sources[step] {
+			if cited[step][i+1] {
+				citations = append(citations, Citation{
+					Step:   step,
+					Index:  i + 1,
+					ID:     result.ID,
+					Source: result.SourceName(),
+					Server: result.Server,
+					Score:  result.CombinedScore,
+					Method: method,
+				})
+			}
+		}
+	}
+	return citations
+}
+
+// distinctiveWords returns the lowercased words of text longer than three
+// letters, which leaves out most stop words
+func distinctiveWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	}) {
+		if len([]rune(word)) > 3 {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// wordCoverage is the share of words that also appear in other
+func wordCoverage(words, other map[string]bool) float64 {
+	if len(words) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range words {
+		if other[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(words))
+}
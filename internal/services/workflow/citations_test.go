@@ -0,0 +1,66 @@
+package workflow
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
+	"github.com/stretchr/testify/assert"
+)
+
+var citationResults = []rag.SearchResult{
+	{ID: "c1", Source: "handbook.md", CombinedScore: 0.9, Text: map[string]interface{}{"content": "Employees accrue twenty vacation days annually"}},
+	{ID: "c2", Metadata: map[string]interface{}{"file": "benefits.pdf"}, CombinedScore: 0.7, Text: map[string]interface{}{"content": "Dental coverage includes orthodontics"}},
+	{ID: "c3", CombinedScore: 0.5, Text: map[string]interface{}{"content": "Parking permits renew each January"}},
+}
+
+func TestFindCitationsByMarker(t *testing.T) {
+	sources := map[string][]rag.SearchResult{"docs": citationResults}
+
+	citations := findCitations(sources, "You get twenty days [docs:1] and dental [docs:2][docs:2]. See also [docs:9] and [other:1].")
+	assert.Equal(t, []Citation{
+		{Step: "docs", Index: 1, ID: "c1", Source: "handbook.md", Score: 0.9, Method: CitationMarker},
+		{Step: "docs", Index: 2, ID: "c2", Source: "benefits.pdf", Score: 0.7, Method: CitationMarker},
+	}, citations)
+}
+
+func TestFindCitationsBySimilarity(t *testing.T) {
+	sources := map[string][]rag.SearchResult{"docs": citationResults}
+
+	citations := findCitations(sources, "Staff accrue twenty vacation days every year.")
+	assert.Len(t, citations, 1)
+	assert.Equal(t, "c1", citations[0].ID)
+	assert.Equal(t, CitationSimilarity, citations[0].Method)
+
+	assert.Empty(t, findCitations(sources, "I don't know."))
+}
+
+func TestRecordCitations(t *testing.T) {
+	o := NewOrchestrator(&config.WorkflowV2{Name: "cite"}, NewLogger("error", false))
+	o.setCitable("docs", citationResults)
+
+	// Only steps whose prompt used a rag step with citations get a list
+	o.recordCitations("other", "Answer {{question}}", "[docs:1]")
+	_, ok := o.interpolator.GetVariable("other.citations")
+	assert.False(t, ok)
+
+	o.recordCitations("answer", "Answer from {{step.docs}}", "Twenty days [docs:1].")
+	raw, ok := o.interpolator.GetVariable("answer.citations")
+	assert.True(t, ok)
+	var citations []Citation
+	assert.NoError(t, json.Unmarshal([]byte(raw), &citations))
+	assert.Len(t, citations, 1)
+	assert.Equal(t, "handbook.md", citations[0].Source)
+}
+
+func TestFormatRagResultsWithCitationLabels(t *testing.T) {
+	response := &rag.SearchResponse{Query: "vacation", Results: citationResults[:1], TotalResults: 1}
+
+	labeled := formatRagResultsAsText(response, "docs")
+	assert.Contains(t, labeled, "--- [docs:1] (score: 0.9000) ---")
+	assert.True(t, strings.HasSuffix(labeled, "for example [docs:1].\n"))
+
+	assert.Contains(t, formatRagResultsAsText(response, ""), "--- Result 1 (score: 0.9000) ---")
+}
@@ -0,0 +1,294 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/tokens"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// CompareEntry is one model's answer in a comparison
+type CompareEntry struct {
+	Provider         string `json:"provider"`
+	Model            string `json:"model"`
+	Rank             int    `json:"rank,omitempty"` // 1 is best; 0 when the execution failed
+	Output           string `json:"output,omitempty"`
+	Error            string `json:"error,omitempty"`
+	LatencyMs        int64  `json:"latency_ms"`
+	PromptTokens     int    `json:"prompt_tokens"`     // Estimated with the model's tokenizer
+	CompletionTokens int    `json:"completion_tokens"` // Estimated with the model's tokenizer
+}
+
+// Label returns provider/model
+func (e *CompareEntry) Label() string {
+	if e.Model == "" {
+		return e.Provider
+	}
+	return e.Provider + "/" + e.Model
+}
+
+// CompareResult is the outcome of a compare step
+type CompareResult struct {
+	Winner  string         `json:"winner"`
+	Judge   string         `json:"judge,omitempty"`
+	Reason  string         `json:"reason,omitempty"`
+	Entries []CompareEntry `json:"entries"` // In ranked order, failures last
+}
+
+// executeCompareStep sends one prompt to several models and exposes the winner
+// as the step result, with {{step.winner}}, {{step.table}} and {{step.json}}
+// describing the comparison
+func (o *Orchestrator) executeCompareStep(ctx context.Context, step *config.StepV2) error {
+	compare := step.Compare
+	if compare == nil {
+		return fmt.Errorf("compare mode is nil")
+	}
+
+	prompt, err := o.interpolator.Interpolate(compare.Prompt)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate prompt: %w", err)
+	}
+
+	timeout := compare.Timeout
+	if timeout == 0 {
+		timeout = o.executor.resolver.ResolveTimeout(step)
+	}
+	compareCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	o.logger.Info("Comparing %d models", len(compare.Executions))
+	entries := o.runCompareExecutions(compareCtx, step, compare.Executions, prompt)
+
+	succeeded := 0
+	for _, entry := range entries {
+		if entry.Error == "" {
+			succeeded++
+		}
+	}
+	minSuccess := compare.MinSuccess
+	if minSuccess <= 0 {
+		minSuccess = 1
+	}
+	if succeeded < minSuccess {
+		return fmt.Errorf("compare: only %d/%d models answered (need %d)", succeeded, len(entries), minSuccess)
+	}
+
+	result := &CompareResult{}
+	order := successfulOrder(entries)
+	if compare.Judge != nil && succeeded > 1 {
+		judge, err := o.executor.resolveJudge(config.JudgeRoleValidation, &compare.Judge.JudgeConfig)
+		if err != nil {
+			return fmt.Errorf("compare: %w", err)
+		}
+		result.Judge = judgeLabel(judge)
+
+		ranking, reason, err := o.judgeComparison(compareCtx, judge, compare.Judge.Criteria, prompt, entries, order)
+		if err != nil {
+			// The answers are still useful; keep listed order
+			o.logger.Warn("Compare: judge %s failed, keeping listed order - %v", result.Judge, err)
+		} else {
+			order = ranking
+			result.Reason = reason
+		}
+	}
+
+	for rank, idx := range order {
+		entries[idx].Rank = rank + 1
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Rank == 0 || entries[j].Rank == 0 {
+			return entries[j].Rank == 0 && entries[i].Rank != 0
+		}
+		return entries[i].Rank < entries[j].Rank
+	})
+	result.Entries = entries
+	result.Winner = entries[0].Label()
+
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode comparison: %w", err)
+	}
+
+	o.setStepResult(step.Name, entries[0].Output)
+	o.interpolator.Set(step.Name+".winner", result.Winner)
+	o.interpolator.Set(step.Name+".table", formatCompareTable(result))
+	o.interpolator.Set(step.Name+".json", string(resultJSON))
+
+	o.logger.Output("Step %s winner: %s", step.Name, result.Winner)
+	if result.Reason != "" {
+		o.logger.Output("  Judge (%s): %s", result.Judge, result.Reason)
+	}
+	o.logger.Output("%s", formatCompareTable(result))
+
+	return nil
+}
+
+// runCompareExecutions runs every execution in parallel, keeping listed order
+func (o *Orchestrator) runCompareExecutions(ctx context.Context, step *config.StepV2, executions []config.ConsensusExec, prompt string) []CompareEntry {
+	entries := make([]CompareEntry, len(executions))
+
+	var wg sync.WaitGroup
+	for i, exec := range executions {
+		wg.Add(1)
+		go func(i int, exec config.ConsensusExec) {
+			defer wg.Done()
+
+			tempStep := &config.StepV2{
+				Name:        step.Name + "_compare",
+				Run:         prompt,
+				Provider:    exec.Provider,
+				Model:       exec.Model,
+				Temperature: exec.Temperature,
+				MaxTokens:   exec.MaxTokens,
+				Timeout:     exec.Timeout,
+				Servers:     step.Servers,
+				Logging:     step.Logging,
+				NoColor:     step.NoColor,
+
+				GenerationParams: step.GenerationParams,
+				SystemPrompt:     step.SystemPrompt,
+				SystemPromptMode: step.SystemPromptMode,
+				ResolvedAgent:    step.ResolvedAgent,
+			}
+
+			entry := CompareEntry{
+				Provider:     exec.Provider,
+				Model:        exec.Model,
+				PromptTokens: tokens.CountTokens(exec.Model, prompt),
+			}
+
+			start := time.Now()
+			result, err := o.executor.executeWithProvider(ctx, tempStep, config.ProviderFallback{Provider: exec.Provider, Model: exec.Model}, nil)
+			entry.LatencyMs = time.Since(start).Milliseconds()
+
+			if err != nil {
+				o.logger.Warn("Compare: %s failed - %v", entry.Label(), err)
+				entry.Error = err.Error()
+			} else {
+				o.logger.Info("Compare: %s answered (%.2fs)", entry.Label(), float64(entry.LatencyMs)/1000)
+				entry.Output = result.Output
+				entry.CompletionTokens = tokens.CountTokens(exec.Model, result.Output)
+			}
+			entries[i] = entry
+		}(i, exec)
+	}
+	wg.Wait()
+
+	return entries
+}
+
+// judgeComparison asks the judge model to rank the successful answers and
+// returns their indexes best first
+func (o *Orchestrator) judgeComparison(ctx context.Context, judge config.JudgeConfig, criteria, prompt string, entries []CompareEntry, order []int) ([]int, string, error) {
+	if criteria == "" {
+		criteria = "accuracy, completeness and clarity"
+	}
+
+	// Answers are anonymized as A, B, C... so the judge can't favour a vendor
+	var sb strings.Builder
+	sb.WriteString("You are judging answers that different AI models gave to the same prompt.\n\n")
+	sb.WriteString("PROMPT:\n")
+	sb.WriteString(prompt)
+	sb.WriteString("\n\n")
+	labels := make([]string, len(order))
+	for i, idx := range order {
+		labels[i] = string(rune('A' + i))
+		fmt.Fprintf(&sb, "ANSWER %s:\n%s\n\n", labels[i], entries[idx].Output)
+	}
+	fmt.Fprintf(&sb, "Rank every answer from best to worst by %s.\n", criteria)
+	sb.WriteString(`Respond with only JSON: {"ranking": ["B", "A", ...], "reason": "one sentence on why the best answer won"}`)
+
+	output, err := o.executor.runJudge(ctx, judge, sb.String())
+	if err != nil {
+		return nil, "", err
+	}
+
+	ranked, reason, err := parseJudgeRanking(output, labels)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ranking := make([]int, len(ranked))
+	for i, pos := range ranked {
+		ranking[i] = order[pos]
+	}
+	return ranking, reason, nil
+}
+
+// parseJudgeRanking extracts the judge's JSON verdict and returns label
+// positions best first. Labels the judge left out keep their order at the end.
+func parseJudgeRanking(output string, labels []string) ([]int, string, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end <= start {
+		return nil, "", fmt.Errorf("judge did not return JSON: %s", truncateString(output, 200))
+	}
+
+	var verdict struct {
+		Ranking []string `json:"ranking"`
+		Reason  string   `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(output[start:end+1]), &verdict); err != nil {
+		return nil, "", fmt.Errorf("invalid judge JSON: %w", err)
+	}
+
+	position := make(map[string]int, len(labels))
+	for i, label := range labels {
+		position[label] = i
+	}
+
+	seen := make(map[int]bool, len(labels))
+	var ranked []int
+	for _, label := range verdict.Ranking {
+		label = strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(label)), "ANSWER"))
+		if pos, ok := position[label]; ok && !seen[pos] {
+			ranked = append(ranked, pos)
+			seen[pos] = true
+		}
+	}
+	if len(ranked) == 0 {
+		return nil, "", fmt.Errorf("judge ranking names no known answers: %v", verdict.Ranking)
+	}
+	for i := range labels {
+		if !seen[i] {
+			ranked = append(ranked, i)
+		}
+	}
+	return ranked, verdict.Reason, nil
+}
+
+// successfulOrder returns the indexes of answered entries in listed order
+func successfulOrder(entries []CompareEntry) []int {
+	var order []int
+	for i, entry := range entries {
+		if entry.Error == "" {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// formatCompareTable renders the comparison as a markdown table
+func formatCompareTable(result *CompareResult) string {
+	var sb strings.Builder
+	sb.WriteString("| Rank | Model | Latency | Tokens in/out | Status |\n")
+	sb.WriteString("| ---- | ----- | ------- | ------------- | ------ |\n")
+	for _, entry := range result.Entries {
+		rank, status := "-", "ok"
+		if entry.Rank > 0 {
+			rank = fmt.Sprintf("%d", entry.Rank)
+		}
+		if entry.Error != "" {
+			status = "failed: " + strings.ReplaceAll(truncateString(entry.Error, 80), "|", "/")
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %.2fs | %d/%d | %s |\n",
+			rank, entry.Label(), float64(entry.LatencyMs)/1000, entry.PromptTokens, entry.CompletionTokens, status)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
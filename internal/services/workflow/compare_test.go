@@ -0,0 +1,74 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJudgeRanking(t *testing.T) {
+	labels := []string{"A", "B", "C"}
+
+	tests := []struct {
+		name       string
+		output     string
+		wantRanked []int
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:       "plain JSON",
+			output:     `{"ranking": ["B", "C", "A"], "reason": "B cites sources"}`,
+			wantRanked: []int{1, 2, 0},
+			wantReason: "B cites sources",
+		},
+		{
+			name:       "fenced JSON with answer prefixes",
+			output:     "Here is my verdict:\n```json\n{\"ranking\": [\"Answer C\", \"a\"], \"reason\": \"concise\"}\n```",
+			wantRanked: []int{2, 0, 1}, // B omitted, appended last
+			wantReason: "concise",
+		},
+		{
+			name:       "duplicates and unknown labels ignored",
+			output:     `{"ranking": ["A", "A", "Z", "C"]}`,
+			wantRanked: []int{0, 2, 1},
+		},
+		{
+			name:    "no JSON",
+			output:  "B is best",
+			wantErr: true,
+		},
+		{
+			name:    "no known labels",
+			output:  `{"ranking": ["X"]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked, reason, err := parseJudgeRanking(tt.output, labels)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRanked, ranked)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func TestFormatCompareTable(t *testing.T) {
+	result := &CompareResult{
+		Winner: "openai/gpt-4o",
+		Entries: []CompareEntry{
+			{Provider: "openai", Model: "gpt-4o", Rank: 1, LatencyMs: 1250, PromptTokens: 12, CompletionTokens: 40},
+			{Provider: "ollama", Model: "llama3", Error: "connection refused | retry"},
+		},
+	}
+
+	table := formatCompareTable(result)
+	assert.Contains(t, table, "| 1 | openai/gpt-4o | 1.25s | 12/40 | ok |")
+	assert.Contains(t, table, "| - | ollama/llama3 | 0.00s | 0/0 | failed: connection refused / retry |")
+}
@@ -85,7 +85,87 @@ func (ce *ConsensusExecutor) ExecuteConsensus(
 		successCount, failCount)
 
 	// Count votes from successful results only
-	return ce.countVotes(results, consensus.Require)
+	result, err := ce.countVotes(results, consensus.Require)
+	if err != nil || result.Success || !consensus.Adjudicate {
+		return result, err
+	}
+
+	return ce.adjudicate(ctx, step, results, result)
+}
+
+// adjudicate asks the consensus judge to pick between the distinct answers
+// when the vote did not meet the requirement
+func (ce *ConsensusExecutor) adjudicate(
+	ctx context.Context,
+	step *config.StepV2,
+	results []*ProviderResult,
+	result *config.ConsensusResult,
+) (*config.ConsensusResult, error) {
+	judge, err := ce.executor.resolveJudge(config.JudgeRoleConsensus, step.Consensus.Judge)
+	if err != nil {
+		return nil, fmt.Errorf("consensus adjudication: %w", err)
+	}
+
+	// One entry per distinct answer, in execution order
+	var answers []string
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		normalized := normalizeOutput(r.Output)
+		if !seen[normalized] {
+			seen[normalized] = true
+			answers = append(answers, r.Output)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Several AI models answered the same prompt but did not agree.\n\n")
+	sb.WriteString("PROMPT:\n")
+	sb.WriteString(step.Consensus.Prompt)
+	sb.WriteString("\n\n")
+	for i, answer := range answers {
+		fmt.Fprintf(&sb, "ANSWER %c:\n%s\n\n", 'A'+i, answer)
+	}
+	sb.WriteString("Which answer is correct? Respond with only the letter of the answer.")
+
+	ce.logger.Info("Consensus not reached (%.0f%% agreement), adjudicating with %s", result.Agreement*100, judgeLabel(judge))
+
+	verdict, err := ce.executor.runJudge(ctx, judge, sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("consensus adjudication: %w", err)
+	}
+
+	choice, err := parseAdjudication(verdict, len(answers))
+	if err != nil {
+		return nil, fmt.Errorf("consensus adjudication: %w", err)
+	}
+
+	ce.logger.Info("Consensus: adjudicated by %s, answer %c", judgeLabel(judge), 'A'+choice)
+
+	result.Success = true
+	result.Result = answers[choice]
+	result.Confidence = "adjudicated"
+	result.Adjudicator = judgeLabel(judge)
+	return result, nil
+}
+
+// parseAdjudication reads the chosen answer letter from the judge's reply,
+// e.g. "B", "Answer B" or "**B**"
+func parseAdjudication(verdict string, count int) (int, error) {
+	text := strings.ToUpper(strings.TrimSpace(verdict))
+	text = strings.TrimSpace(strings.TrimPrefix(strings.Trim(text, "*`\"' ."), "ANSWER"))
+	if text != "" {
+		if idx := int(text[0] - 'A'); idx >= 0 && idx < count && (len(text) == 1 || !isLetter(text[1])) {
+			return idx, nil
+		}
+	}
+	return 0, fmt.Errorf("judge did not name an answer (A-%c): %s", 'A'+count-1, truncateString(verdict, 100))
+}
+
+func isLetter(b byte) bool {
+	return b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z'
 }
 
 // executeParallel executes all consensus providers in parallel
@@ -161,6 +241,10 @@ func (ce *ConsensusExecutor) executeConsensusProvider(
 
 	// Inherit other properties from original step
 	tempStep.Servers = step.Servers
+	tempStep.GenerationParams = step.GenerationParams
+	tempStep.SystemPrompt = step.SystemPrompt
+	tempStep.SystemPromptMode = step.SystemPromptMode
+	tempStep.ResolvedAgent = step.ResolvedAgent
 	tempStep.Logging = step.Logging
 	tempStep.NoColor = step.NoColor
 
@@ -170,7 +254,7 @@ func (ce *ConsensusExecutor) executeConsensusProvider(
 		Model:    exec.Model,
 	}
 
-	result, err := ce.executor.executeWithProvider(ctx, tempStep, providerConfig)
+	result, err := ce.executor.executeWithProvider(ctx, tempStep, providerConfig, nil)
 
 	duration := time.Since(startTime)
 
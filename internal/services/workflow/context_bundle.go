@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/contextbundle"
+)
+
+// SetContextBundle makes an exported chat or workflow run available to the
+// workflow as {{context}} (the whole bundle as text) and {{context.NAME}}
+func (o *Orchestrator) SetContextBundle(bundle *contextbundle.Bundle) {
+	o.contextBundle = bundle
+}
+
+// applyContextBundle sets the bundle's variables on the interpolator
+func (o *Orchestrator) applyContextBundle() {
+	if o.contextBundle == nil {
+		return
+	}
+	o.interpolator.Set("context", o.contextBundle.Text())
+	for name, value := range o.contextBundle.Variables() {
+		o.interpolator.Set("context."+name, value)
+	}
+}
+
+// ContextBundle returns the run's input, params and step outputs as a
+// context bundle for a later query or workflow
+func (o *Orchestrator) ContextBundle() *contextbundle.Bundle {
+	bundle := &contextbundle.Bundle{
+		Version:  contextbundle.Version,
+		Kind:     contextbundle.KindWorkflow,
+		Source:   o.workflow.Name,
+		Created:  time.Now().UTC(),
+		Provider: o.workflow.Execution.Provider,
+		Model:    o.workflow.Execution.Model,
+	}
+
+	for name, value := range o.interpolator.Variables() {
+		switch {
+		case name == "input":
+			bundle.Input = value
+		case strings.HasPrefix(name, "params."):
+			if bundle.Params == nil {
+				bundle.Params = make(map[string]string)
+			}
+			bundle.Params[strings.TrimPrefix(name, "params.")] = value
+		}
+	}
+
+	for _, step := range o.workflow.Steps {
+		if output, ok := o.GetStepResult(step.Name); ok {
+			bundle.Steps = append(bundle.Steps, contextbundle.Step{Name: step.Name, Output: output})
+		}
+	}
+	for _, loop := range o.workflow.Loops {
+		if output, ok := o.interpolator.GetVariable("step." + loop.Name); ok {
+			bundle.Steps = append(bundle.Steps, contextbundle.Step{Name: loop.Name, Output: output})
+		}
+	}
+	if o.matrixResult != nil {
+		bundle.Steps = append(bundle.Steps, contextbundle.Step{Name: "matrix", Output: formatMatrixReport(o.matrixResult)})
+	}
+	return bundle
+}
@@ -0,0 +1,224 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// defaultDebateRounds is how many times each agent speaks when rounds is unset
+const defaultDebateRounds = 2
+
+// DebateTurn is one agent's argument in a debate
+type DebateTurn struct {
+	Round     int    `json:"round"`
+	Agent     string `json:"agent"`
+	Output    string `json:"output"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// DebateResult is the outcome of a debate step
+type DebateResult struct {
+	Question    string       `json:"question"`
+	Agents      []string     `json:"agents"`
+	Rounds      int          `json:"rounds"`
+	Turns       []DebateTurn `json:"turns"`       // In speaking order
+	Adjudicator string       `json:"adjudicator"` // "agent <name>" or the judge's provider/model
+	Answer      string       `json:"answer"`
+}
+
+// executeDebateStep has the debate's agents argue the question in turn for
+// the configured rounds, then asks the adjudicator for the answer. The answer
+// is the step result; {{step.transcript}} and {{step.json}} describe the
+// debate, which is also saved to the run artifacts directory.
+func (o *Orchestrator) executeDebateStep(ctx context.Context, step *config.StepV2) error {
+	debate := step.Debate
+	if debate == nil {
+		return fmt.Errorf("debate mode is nil")
+	}
+	if len(debate.ResolvedAgents) != len(debate.Agents) {
+		return fmt.Errorf("debate agents are not resolved; the workflow must be loaded with its agents")
+	}
+
+	question, err := o.interpolator.Interpolate(debate.Question)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate question: %w", err)
+	}
+
+	rounds := debate.Rounds
+	if rounds <= 0 {
+		rounds = defaultDebateRounds
+	}
+	timeout := debate.Timeout
+	if timeout == 0 {
+		timeout = o.executor.resolver.ResolveTimeout(step)
+	}
+
+	result := &DebateResult{Question: question, Agents: debate.Agents, Rounds: rounds}
+	o.logger.Info("Debate between %s, %d rounds", strings.Join(debate.Agents, ", "), rounds)
+
+	for round := 1; round <= rounds; round++ {
+		for _, agent := range debate.ResolvedAgents {
+			prompt := debateTurnPrompt(question, agent.Name, debate.Agents, round, rounds, result.Turns)
+
+			start := time.Now()
+			output, err := o.runDebateAgent(ctx, step, agent, prompt, timeout)
+			if err != nil {
+				return fmt.Errorf("debate: %s failed in round %d: %w", agent.Name, round, err)
+			}
+			latency := time.Since(start)
+			o.logger.Info("Debate: %s argued round %d (%.2fs)", agent.Name, round, latency.Seconds())
+
+			result.Turns = append(result.Turns, DebateTurn{
+				Round:     round,
+				Agent:     agent.Name,
+				Output:    output,
+				LatencyMs: latency.Milliseconds(),
+			})
+		}
+	}
+
+	prompt := debateAdjudicationPrompt(question, debate.Agents, result.Turns)
+	if adjudicator := debate.ResolvedAdjudicator; adjudicator != nil {
+		result.Adjudicator = "agent " + adjudicator.Name
+		result.Answer, err = o.runDebateAgent(ctx, step, adjudicator, prompt, timeout)
+	} else {
+		judge, judgeErr := o.executor.resolveJudge(config.JudgeRoleConsensus, debate.Judge)
+		if judgeErr != nil {
+			return fmt.Errorf("debate: %w", judgeErr)
+		}
+		result.Adjudicator = judgeLabel(judge)
+
+		judgeCtx, cancel := context.WithTimeout(ctx, timeout)
+		result.Answer, err = o.executor.runJudge(judgeCtx, judge, prompt)
+		cancel()
+	}
+	if err != nil {
+		return fmt.Errorf("debate: adjudicator %s failed: %w", result.Adjudicator, err)
+	}
+
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode debate: %w", err)
+	}
+	o.saveDebate(step.Name, resultJSON)
+
+	o.setStepResult(step.Name, result.Answer)
+	o.interpolator.Set(step.Name+".transcript", formatDebateTranscript(result.Turns))
+	o.interpolator.Set(step.Name+".json", string(resultJSON))
+
+	o.logger.Output("Step %s answer (adjudicated by %s): %s", step.Name, result.Adjudicator, result.Answer)
+	return nil
+}
+
+// runDebateAgent sends a prompt to an agent with the step's system prompt
+// layered over the agent's, and returns the agent's answer
+func (o *Orchestrator) runDebateAgent(ctx context.Context, step *config.StepV2, agent *config.AgentConfig, prompt string, timeout time.Duration) (string, error) {
+	turnStep := &config.StepV2{
+		Name:             step.Name + "_" + agent.Name,
+		Run:              prompt,
+		Timeout:          step.Timeout,
+		Logging:          step.Logging,
+		NoColor:          step.NoColor,
+		SystemPrompt:     step.SystemPrompt,
+		SystemPromptMode: step.SystemPromptMode,
+	}
+
+	// The agent's system prompt can refer to params and earlier results
+	resolved := *agent
+	if agent.SystemPrompt != "" {
+		resolved.SystemPrompt, _ = o.interpolator.Interpolate(agent.SystemPrompt)
+	}
+	resolved.Apply(turnStep)
+
+	turnCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := o.executor.ExecuteStep(turnCtx, turnStep)
+	if err != nil {
+		return "", err
+	}
+	return result.Output, nil
+}
+
+// saveDebate writes the debate as debate-<step>.json in the run artifacts
+// directory. Failures are logged, not returned.
+func (o *Orchestrator) saveDebate(stepName string, data []byte) {
+	dir, err := o.runArtifactsDir()
+	if err != nil {
+		o.logger.Warn("Debate transcript not saved: %v", err)
+		return
+	}
+	path := filepath.Join(dir, "debate-"+unsafeNameChars.ReplaceAllString(stepName, "_")+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		o.logger.Warn("Failed to write debate transcript: %v", err)
+		return
+	}
+	o.logger.Info("Debate transcript saved to %s", path)
+}
+
+// debateTurnPrompt asks an agent for its argument in a round, with the
+// debate so far
+func debateTurnPrompt(question, speaker string, agents []string, round, rounds int, turns []DebateTurn) string {
+	var opponents []string
+	for _, name := range agents {
+		if name != speaker {
+			opponents = append(opponents, name)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "You are %s in a debate with %s. This is round %d of %d.\n\n",
+		speaker, strings.Join(opponents, ", "), round, rounds)
+	sb.WriteString("QUESTION:\n")
+	sb.WriteString(question)
+	sb.WriteString("\n\n")
+
+	if len(turns) == 0 {
+		sb.WriteString("You speak first. Make your opening argument.")
+	} else {
+		sb.WriteString("DEBATE SO FAR:\n")
+		sb.WriteString(formatDebateTranscript(turns))
+		sb.WriteString("\n\n")
+		if round == rounds {
+			sb.WriteString("This is the final round. Make your closing argument: ")
+		} else {
+			sb.WriteString("Make your argument: ")
+		}
+		sb.WriteString("answer the other participants' latest points, concede what they got right, and strengthen your own position.")
+	}
+	sb.WriteString(" Reply with your argument only.")
+	return sb.String()
+}
+
+// debateAdjudicationPrompt asks the adjudicator to answer the question from
+// the debate
+func debateAdjudicationPrompt(question string, agents []string, turns []DebateTurn) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "You are adjudicating a debate between %s.\n\n", strings.Join(agents, ", "))
+	sb.WriteString("QUESTION:\n")
+	sb.WriteString(question)
+	sb.WriteString("\n\nTRANSCRIPT:\n")
+	sb.WriteString(formatDebateTranscript(turns))
+	sb.WriteString("\n\nWeigh the arguments on their merits, not on who spoke last or longest, and give the final answer to the question. ")
+	sb.WriteString("Start with the answer, then explain briefly which arguments decided it.")
+	return sb.String()
+}
+
+// formatDebateTranscript renders the turns as markdown, one heading per turn
+func formatDebateTranscript(turns []DebateTurn) string {
+	var sb strings.Builder
+	for i, turn := range turns {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		fmt.Fprintf(&sb, "### Round %d - %s\n\n%s", turn.Round, turn.Agent, strings.TrimSpace(turn.Output))
+	}
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebateTurnPrompt(t *testing.T) {
+	agents := []string{"red_team", "blue_team"}
+
+	opening := debateTurnPrompt("Is the VPN exposed?", "red_team", agents, 1, 2, nil)
+	assert.Contains(t, opening, "You are red_team in a debate with blue_team. This is round 1 of 2.")
+	assert.Contains(t, opening, "QUESTION:\nIs the VPN exposed?")
+	assert.Contains(t, opening, "You speak first.")
+	assert.NotContains(t, opening, "DEBATE SO FAR")
+
+	turns := []DebateTurn{
+		{Round: 1, Agent: "red_team", Output: "Port 443 runs an unpatched build."},
+		{Round: 1, Agent: "blue_team", Output: "The WAF blocks the exploit path.\n"},
+	}
+	closing := debateTurnPrompt("Is the VPN exposed?", "red_team", agents, 2, 2, turns)
+	assert.Contains(t, closing, "DEBATE SO FAR:\n### Round 1 - red_team\n\nPort 443 runs an unpatched build.")
+	assert.Contains(t, closing, "This is the final round.")
+	assert.NotContains(t, closing, "You speak first.")
+
+	middle := debateTurnPrompt("q", "blue_team", []string{"red_team", "blue_team", "auditor"}, 2, 3, turns)
+	assert.Contains(t, middle, "You are blue_team in a debate with red_team, auditor.")
+	assert.NotContains(t, middle, "final round")
+}
+
+func TestDebateAdjudicationPrompt(t *testing.T) {
+	turns := []DebateTurn{
+		{Round: 1, Agent: "red_team", Output: "Exploitable."},
+		{Round: 1, Agent: "blue_team", Output: "Mitigated."},
+	}
+	prompt := debateAdjudicationPrompt("Is it exploitable?", []string{"red_team", "blue_team"}, turns)
+
+	assert.Contains(t, prompt, "adjudicating a debate between red_team, blue_team")
+	assert.Contains(t, prompt, "TRANSCRIPT:\n### Round 1 - red_team\n\nExploitable.\n\n### Round 1 - blue_team\n\nMitigated.")
+	assert.Contains(t, prompt, "give the final answer")
+}
+
+func TestValidateDebateMode(t *testing.T) {
+	workflow := func(debate *config.DebateMode) *config.WorkflowV2 {
+		return &config.WorkflowV2{
+			Name:      "assess",
+			Execution: config.ExecutionContext{Provider: "openai", Model: "gpt-4o"},
+			Agents:    []config.AgentConfig{{Name: "red_team"}, {Name: "blue_team"}, {Name: "chair"}},
+			Steps:     []config.StepV2{{Name: "debate", Debate: debate}},
+		}
+	}
+
+	assert.NoError(t, ValidateWorkflow(workflow(&config.DebateMode{
+		Question: "Is it exploitable?", Agents: []string{"red_team", "blue_team"}, Rounds: 3, Adjudicator: "chair",
+	})))
+
+	tests := []struct {
+		name   string
+		debate *config.DebateMode
+		want   string
+	}{
+		{"question", &config.DebateMode{Agents: []string{"red_team", "blue_team"}}, "debate question is required"},
+		{"one agent", &config.DebateMode{Question: "q", Agents: []string{"red_team"}}, "at least 2 agents"},
+		{"duplicate", &config.DebateMode{Question: "q", Agents: []string{"red_team", "red_team"}}, "listed twice"},
+		{"unknown agent", &config.DebateMode{Question: "q", Agents: []string{"red_team", "purple_team"}}, "unknown agent 'purple_team'"},
+		{"rounds", &config.DebateMode{Question: "q", Agents: []string{"red_team", "blue_team"}, Rounds: 50}, "between 1 and 10"},
+		{"adjudicator and judge", &config.DebateMode{Question: "q", Agents: []string{"red_team", "blue_team"},
+			Adjudicator: "chair", Judge: &config.JudgeConfig{Provider: "openai"}}, "mutually exclusive"},
+		{"unknown adjudicator", &config.DebateMode{Question: "q", Agents: []string{"red_team", "blue_team"}, Adjudicator: "nobody"}, "unknown agent 'nobody'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, ValidateWorkflow(workflow(tt.debate)), tt.want)
+		})
+	}
+}
@@ -54,30 +54,6 @@ func buildFiltersClause(filters map[string]interface{}) string {
 
 // Vector similarity calculation functions
 
-// cosineSimilarity calculates cosine similarity between two vectors
-func cosineSimilarity(vec1, vec2 []float32) float64 {
-	if len(vec1) != len(vec2) {
-		return 0.0
-	}
-
-	var dotProduct, normA, normB float64
-
-	for i := 0; i < len(vec1); i++ {
-		dotProduct += float64(vec1[i]) * float64(vec2[i])
-		normA += float64(vec1[i]) * float64(vec1[i])
-		normB += float64(vec2[i]) * float64(vec2[i])
-	}
-
-	normA = math.Sqrt(normA)
-	normB = math.Sqrt(normB)
-
-	if normA == 0 || normB == 0 {
-		return 0.0
-	}
-
-	return dotProduct / (normA * normB)
-}
-
 // euclideanDistance calculates the Euclidean distance between two vectors
 func euclideanDistance(vec1, vec2 []float32) float64 {
 	if len(vec1) != len(vec2) {
@@ -0,0 +1,281 @@
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// charsPerToken approximates token counts before anything is tokenized
+const charsPerToken = 4
+
+// maxEstimateDepth bounds how deep called workflows are followed
+const maxEstimateDepth = 5
+
+// Step cost kinds
+const (
+	CostKindLLM        = "llm"
+	CostKindEmbeddings = "embeddings"
+)
+
+// CostEstimate is what a workflow run is expected to cost, worked out
+// before it runs from its steps, its input and the configured prices
+type CostEstimate struct {
+	Steps    []StepCostEstimate
+	Total    float64  // USD for the priced steps
+	Unpriced []string // provider/model pairs without prices, left out of Total
+}
+
+// StepCostEstimate is the expected cost of one step
+type StepCostEstimate struct {
+	Step         string // Step name; steps of called workflows are prefixed with the caller
+	Kind         string // llm or embeddings
+	Provider     string
+	Model        string
+	Calls        int // LLM calls, or chunks for embeddings
+	InputTokens  int
+	OutputTokens int
+	Cost         float64
+	Priced       bool
+}
+
+// EstimateWorkflowCost estimates a run of wf with inputBytes of input. LLM
+// calls are counted per step (consensus executions, loop iterations up to
+// max_iterations and called workflows included) at the prompt's tokens plus
+// the input and earlier outputs it references, and an average completion
+// length. Embedding steps are counted by chunks of their input. Tool-calling
+// rounds and retries aren't known in advance and aren't included.
+func EstimateWorkflowCost(wf *config.WorkflowV2, appConfig *config.ApplicationConfig, inputBytes int) *CostEstimate {
+	e := &estimator{appConfig: appConfig, estimate: &CostEstimate{}}
+	e.workflow(wf, "", (inputBytes+charsPerToken-1)/charsPerToken, 1, 0)
+
+	unpriced := make(map[string]bool)
+	for _, step := range e.estimate.Steps {
+		if step.Priced {
+			e.estimate.Total += step.Cost
+		} else {
+			unpriced[step.Provider+"/"+step.Model] = true
+		}
+	}
+	for label := range unpriced {
+		e.estimate.Unpriced = append(e.estimate.Unpriced, label)
+	}
+	sort.Strings(e.estimate.Unpriced)
+	return e.estimate
+}
+
+// estimator walks a workflow and the workflows it calls
+type estimator struct {
+	appConfig *config.ApplicationConfig
+	estimate  *CostEstimate
+}
+
+// workflow estimates wf's steps, run times times with inputTokens of input
+func (e *estimator) workflow(wf *config.WorkflowV2, prefix string, inputTokens, times, depth int) {
+	resolver := NewPropertyResolver(&wf.Execution)
+	outputs := make(map[string]int) // step -> estimated output tokens
+
+	for i := range wf.Steps {
+		step := &wf.Steps[i]
+		name := prefix + step.Name
+		referenced := e.referencedTokens(step.Run, inputTokens, outputs)
+
+		switch {
+		case step.Run != "" || step.PromptRef != "":
+			promptTokens := ApproxTokens(step.Run) + referenced
+			providers := resolver.ResolveProviders(step)
+			if len(providers) == 0 {
+				continue
+			}
+			output := e.outputTokens(resolver.ResolveMaxTokens(step))
+			outputs[step.Name] = output
+			e.addLLM(name, providers[0], times, promptTokens, output)
+
+		case step.Consensus != nil:
+			promptTokens := ApproxTokens(step.Consensus.Prompt) + e.referencedTokens(step.Consensus.Prompt, inputTokens, outputs)
+			for _, exec := range step.Consensus.Executions {
+				maxTokens := 0
+				if exec.MaxTokens != nil {
+					maxTokens = *exec.MaxTokens
+				}
+				output := e.outputTokens(maxTokens)
+				outputs[step.Name] = output
+				e.addLLM(name, config.ProviderFallback{Provider: exec.Provider, Model: exec.Model}, times, promptTokens, output)
+			}
+
+		case step.Embeddings != nil:
+			e.addEmbeddings(name, step, wf, inputTokens, outputs, times)
+
+		case step.Template != nil && depth < maxEstimateDepth:
+			if called, ok := e.appConfig.GetWorkflow(step.Template.Name); ok {
+				e.workflow(called, name+"/", inputTokens, times, depth+1)
+				outputs[step.Name] = e.outputTokens(0)
+			}
+
+		case step.Loop != nil && depth < maxEstimateDepth:
+			if called, ok := e.appConfig.GetWorkflow(step.Loop.Workflow); ok {
+				e.workflow(called, name+"/", inputTokens, times*loopIterations(step.Loop.MaxIterations), depth+1)
+				outputs[step.Name] = e.outputTokens(0)
+			}
+		}
+	}
+
+	if depth >= maxEstimateDepth {
+		return
+	}
+	for _, loop := range wf.Loops {
+		if called, ok := e.appConfig.GetWorkflow(loop.Workflow); ok {
+			e.workflow(called, prefix+loop.Name+"/", inputTokens, times*loopIterations(loop.MaxIterations), depth+1)
+		}
+	}
+}
+
+// referencedTokens adds up the input and earlier step outputs text refers to
+func (e *estimator) referencedTokens(text string, inputTokens int, outputs map[string]int) int {
+	total := 0
+	for _, ref := range new(VariableValidator).extractVariableReferences(text) {
+		if ref == "input" {
+			total += inputTokens
+		} else if tokens, ok := outputs[ref]; ok {
+			total += tokens
+		}
+	}
+	return total
+}
+
+// outputTokens is the completion length assumed for a call: the average,
+// or maxTokens when that is lower
+func (e *estimator) outputTokens(maxTokens int) int {
+	average := e.appConfig.CostEstimate.OutputTokens()
+	if maxTokens > 0 && maxTokens < average {
+		return maxTokens
+	}
+	return average
+}
+
+// addLLM records times calls to a provider
+func (e *estimator) addLLM(name string, pc config.ProviderFallback, times, inputTokens, outputTokens int) {
+	step := StepCostEstimate{
+		Step:         name,
+		Kind:         CostKindLLM,
+		Provider:     pc.Provider,
+		Model:        pc.Model,
+		Calls:        times,
+		InputTokens:  inputTokens * times,
+		OutputTokens: outputTokens * times,
+	}
+	if providerConfig := e.appConfig.FindProviderConfig(pc.Provider); providerConfig.HasPricing() {
+		step.Cost = providerConfig.EstimateCost(step.InputTokens, step.OutputTokens)
+		step.Priced = true
+	}
+	e.estimate.Steps = append(e.estimate.Steps, step)
+}
+
+// addEmbeddings records an embeddings step, sized from its input text or
+// input file
+func (e *estimator) addEmbeddings(name string, step *config.StepV2, wf *config.WorkflowV2, inputTokens int, outputs map[string]int, times int) {
+	emb := step.Embeddings
+	provider, model := emb.Provider, emb.Model
+	if len(emb.Providers) > 0 {
+		provider, model = emb.Providers[0].Provider, emb.Providers[0].Model
+	}
+	if provider == "" {
+		provider = firstNonEmpty(step.Provider, wf.Execution.Provider)
+	}
+	if model == "" {
+		model = firstNonEmpty(step.Model, wf.Execution.Model)
+	}
+
+	tokens := 0
+	switch v := emb.Input.(type) {
+	case string:
+		tokens = ApproxTokens(v) + e.referencedTokens(v, inputTokens, outputs)
+	case []interface{}:
+		for _, item := range v {
+			if text, ok := item.(string); ok {
+				tokens += ApproxTokens(text) + e.referencedTokens(text, inputTokens, outputs)
+			}
+		}
+	}
+	if emb.InputFile != "" && !strings.Contains(emb.InputFile, "{{") {
+		if info, err := os.Stat(emb.InputFile); err == nil {
+			tokens = int(info.Size()) / charsPerToken
+		}
+	}
+
+	estimate := EstimateEmbeddingCost(e.appConfig, provider, model, tokens, emb.MaxChunkSize)
+	estimate.Step = name
+	estimate.Calls *= times
+	estimate.InputTokens *= times
+	estimate.Cost *= float64(times)
+	e.estimate.Steps = append(e.estimate.Steps, estimate)
+}
+
+// EstimateEmbeddingCost estimates embedding tokens of input split into
+// chunks of maxChunkSize tokens (512 when 0)
+func EstimateEmbeddingCost(appConfig *config.ApplicationConfig, provider, model string, tokens, maxChunkSize int) StepCostEstimate {
+	if maxChunkSize <= 0 {
+		maxChunkSize = 512
+	}
+	estimate := StepCostEstimate{
+		Kind:        CostKindEmbeddings,
+		Provider:    provider,
+		Model:       model,
+		Calls:       (tokens + maxChunkSize - 1) / maxChunkSize,
+		InputTokens: tokens,
+	}
+	if price, ok := appConfig.EmbeddingPrice(provider, model); ok {
+		estimate.Cost = float64(tokens) / 1000 * price
+		estimate.Priced = true
+	}
+	return estimate
+}
+
+// loopIterations is the number of iterations a loop is estimated at: its
+// max_iterations, which bounds refine loops and iterate loops alike
+func loopIterations(maxIterations int) int {
+	if maxIterations < 1 {
+		return 1
+	}
+	return maxIterations
+}
+
+// ApproxTokens estimates the tokens in text from its length
+func ApproxTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// firstNonEmpty returns the first value that isn't empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Print writes the estimate as a table
+func (c *CostEstimate) Print(w io.Writer) {
+	fmt.Fprintln(w, "Cost estimate:")
+	for _, step := range c.Steps {
+		cost := "unpriced"
+		if step.Priced {
+			cost = fmt.Sprintf("$%.4f", step.Cost)
+		}
+		usage := fmt.Sprintf("%d calls, ~%d in / ~%d out tokens", step.Calls, step.InputTokens, step.OutputTokens)
+		if step.Kind == CostKindEmbeddings {
+			usage = fmt.Sprintf("%d chunks, ~%d tokens", step.Calls, step.InputTokens)
+		}
+		fmt.Fprintf(w, "  %-30s %s/%s  %s  %s\n", step.Step, step.Provider, step.Model, usage, cost)
+	}
+	fmt.Fprintf(w, "  Total: ~$%.4f", c.Total)
+	if len(c.Unpriced) > 0 {
+		fmt.Fprintf(w, " (no prices for %s)", strings.Join(c.Unpriced, ", "))
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateWorkflowCost(t *testing.T) {
+	appConfig := &config.ApplicationConfig{
+		AI: &config.AIConfig{
+			Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+				config.OpenAICompatible: {Providers: map[string]config.ProviderConfig{
+					"openai": {
+						CostPer1kInputTokens:  1,
+						CostPer1kOutputTokens: 2,
+						EmbeddingModels: map[string]config.EmbeddingModelConfig{
+							"small": {CostPer1kTokens: 0.5},
+						},
+					},
+				}},
+			},
+		},
+		CostEstimate: &config.CostEstimateConfig{AverageOutputTokens: 100},
+		Workflows: map[string]*config.WorkflowV2{
+			"child": {
+				Execution: config.ExecutionContext{Provider: "openai", Model: "gpt"},
+				Steps:     []config.StepV2{{Name: "refine", Run: "{{input}}"}},
+			},
+		},
+	}
+	maxTokens := 50
+	wf := &config.WorkflowV2{
+		Execution: config.ExecutionContext{Provider: "openai", Model: "gpt"},
+		Steps: []config.StepV2{
+			{Name: "summarize", Run: "{{input}}", MaxTokens: &maxTokens},
+			{Name: "local", Run: "{{summarize}}", Provider: "ollama", Model: "qwen"},
+			{Name: "embed", Embeddings: &config.EmbeddingsMode{Model: "small", Input: "{{input}}", MaxChunkSize: 100}},
+			{Name: "improve", Loop: &config.LoopMode{Workflow: "child", MaxIterations: 3}},
+		},
+	}
+
+	estimate := EstimateWorkflowCost(wf, appConfig, 4000) // ~1000 tokens
+	if !assert.Len(t, estimate.Steps, 4) {
+		return
+	}
+
+	summarize := estimate.Steps[0]
+	assert.Equal(t, 1, summarize.Calls)
+	assert.Equal(t, 1003, summarize.InputTokens) // prompt + input
+	assert.Equal(t, 50, summarize.OutputTokens)  // max_tokens below the average
+	assert.True(t, summarize.Priced)
+	assert.InDelta(t, 1.003+0.1, summarize.Cost, 1e-9)
+
+	local := estimate.Steps[1]
+	assert.False(t, local.Priced)
+	assert.Equal(t, 4+50, local.InputTokens) // prompt + summarize's output
+
+	embed := estimate.Steps[2]
+	assert.Equal(t, CostKindEmbeddings, embed.Kind)
+	assert.Equal(t, 11, embed.Calls) // 1003 tokens in chunks of 100
+	assert.InDelta(t, 1.003*0.5, embed.Cost, 1e-9)
+
+	loop := estimate.Steps[3]
+	assert.Equal(t, "improve/refine", loop.Step)
+	assert.Equal(t, 3, loop.Calls)
+	assert.Equal(t, 300, loop.OutputTokens)
+
+	assert.InDelta(t, summarize.Cost+embed.Cost+loop.Cost, estimate.Total, 1e-9)
+	assert.Equal(t, []string{"ollama/qwen"}, estimate.Unpriced)
+}
@@ -0,0 +1,237 @@
+package workflow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
+	"gopkg.in/yaml.v3"
+)
+
+// Few-shot defaults
+const (
+	defaultExamplesHeader    = "Here are some examples:"
+	defaultExamplesSeparator = "\n\n"
+	defaultExamplesRagTopK   = 3
+)
+
+// Example is one few-shot example record, e.g. {"input": ..., "output": ...}
+type Example map[string]interface{}
+
+// injectExamples prepends the step's few-shot examples to an already
+// interpolated prompt. Examples are added after interpolation so their text
+// is never treated as {{variables}}.
+func (o *Orchestrator) injectExamples(ctx context.Context, step *config.StepV2, prompt string) (string, error) {
+	exConfig := step.Examples
+	if exConfig == nil {
+		return prompt, nil
+	}
+
+	var examples []Example
+	var err error
+	if exConfig.Rag != nil {
+		examples, err = o.retrieveExamples(ctx, exConfig, prompt)
+	} else {
+		examples, err = o.loadExamplesFile(exConfig)
+	}
+	if err != nil {
+		return "", fmt.Errorf("examples: %w", err)
+	}
+
+	if exConfig.TopK > 0 && len(examples) > exConfig.TopK {
+		examples = examples[:exConfig.TopK]
+	}
+	if len(examples) == 0 {
+		o.logger.Warn("Step %s: no few-shot examples found", step.Name)
+		return prompt, nil
+	}
+
+	o.logger.Debug("Step %s: adding %d few-shot examples", step.Name, len(examples))
+	return formatExamples(exConfig, examples) + defaultExamplesSeparator + prompt, nil
+}
+
+// loadExamplesFile reads examples from the configured file
+func (o *Orchestrator) loadExamplesFile(exConfig *config.ExamplesConfig) ([]Example, error) {
+	path, err := o.interpolator.Interpolate(exConfig.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to interpolate file: %w", err)
+	}
+	if path, err = o.resolveOutputsPath(path); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return parseExamples(data, path)
+}
+
+// parseExamples decodes a JSONL file (one object per line) or a YAML/JSON list
+func parseExamples(data []byte, path string) ([]Example, error) {
+	var examples []Example
+
+	if strings.EqualFold(filepath.Ext(path), ".jsonl") {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			var example Example
+			if err := json.Unmarshal([]byte(text), &example); err != nil {
+				return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+			}
+			examples = append(examples, example)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return examples, nil
+	}
+
+	// YAML is a superset of JSON, so .json lists decode here too
+	if err := yaml.Unmarshal(data, &examples); err != nil {
+		return nil, fmt.Errorf("%s: expected a list of example records: %w", path, err)
+	}
+	return examples, nil
+}
+
+// retrieveExamples searches the vector store for the examples most similar to
+// the query (default: the step's prompt)
+func (o *Orchestrator) retrieveExamples(ctx context.Context, exConfig *config.ExamplesConfig, prompt string) ([]Example, error) {
+	ragExamples := exConfig.Rag
+
+	if o.appConfig == nil || o.appConfig.RAG == nil {
+		return nil, fmt.Errorf("RAG configuration not loaded")
+	}
+	if o.ragServerManager == nil {
+		return nil, fmt.Errorf("RAG server manager not initialized (no RAG servers connected)")
+	}
+	ragConfig := o.appConfig.RAG
+
+	query := prompt
+	if ragExamples.Query != "" {
+		interpolated, err := o.interpolator.Interpolate(ragExamples.Query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to interpolate query: %w", err)
+		}
+		query = interpolated
+	}
+
+	serverName := ragExamples.Server
+	if serverName == "" {
+		serverName = ragConfig.DefaultServer
+	}
+	if serverName == "" {
+		return nil, fmt.Errorf("no server specified and no default server in RAG config")
+	}
+
+	topK := exConfig.TopK
+	if topK <= 0 {
+		topK = defaultExamplesRagTopK
+	}
+
+	ragService := rag.NewServiceWithConfig(ragConfig, o.ragServerManager, o.embeddingService)
+	response, err := ragService.Search(ctx, rag.SearchRequest{
+		Query:      query,
+		Server:     serverName,
+		Strategies: ragExamples.Strategies,
+		TopK:       topK,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("RAG search failed: %w", err)
+	}
+
+	return examplesFromResults(response.Results, ragExamples), nil
+}
+
+// examplesFromResults maps search results to example records, renaming the
+// configured input/output columns to input and output
+func examplesFromResults(results []rag.SearchResult, ragExamples *config.ExamplesRag) []Example {
+	inputField := ragExamples.InputField
+	if inputField == "" {
+		inputField = "input"
+	}
+	outputField := ragExamples.OutputField
+	if outputField == "" {
+		outputField = "output"
+	}
+
+	var examples []Example
+	for _, result := range results {
+		if ragExamples.MinScore > 0 && result.CombinedScore < ragExamples.MinScore {
+			continue
+		}
+		example := make(Example, len(result.Text)+3)
+		for key, value := range result.Text {
+			example[key] = value
+		}
+		example["input"] = result.Text[inputField]
+		example["output"] = result.Text[outputField]
+		example["score"] = result.CombinedScore
+		examples = append(examples, example)
+	}
+	return examples
+}
+
+var exampleFieldPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+
+// formatExamples renders the header and each example with the template.
+// Templates reference record fields as {{field}}, plus {{index}} (1-based).
+func formatExamples(exConfig *config.ExamplesConfig, examples []Example) string {
+	template := exConfig.Template
+	if template == "" {
+		template = "Input: {{input}}\nOutput: {{output}}"
+	}
+	header := exConfig.Header
+	if header == "" {
+		header = defaultExamplesHeader
+	}
+	separator := exConfig.Separator
+	if separator == "" {
+		separator = defaultExamplesSeparator
+	}
+
+	rendered := make([]string, 0, len(examples))
+	for i, example := range examples {
+		index := i + 1
+		rendered = append(rendered, exampleFieldPattern.ReplaceAllStringFunc(template, func(match string) string {
+			field := exampleFieldPattern.FindStringSubmatch(match)[1]
+			if field == "index" {
+				return fmt.Sprintf("%d", index)
+			}
+			value, ok := example[field]
+			if !ok || value == nil {
+				return ""
+			}
+			return formatExampleValue(value)
+		}))
+	}
+
+	return header + defaultExamplesSeparator + strings.Join(rendered, separator)
+}
+
+// formatExampleValue renders strings as-is and structured values as JSON
+func formatExampleValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}, map[string]interface{}:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExamples(t *testing.T) {
+	t.Run("jsonl", func(t *testing.T) {
+		data := []byte("{\"input\": \"great product\", \"output\": \"positive\"}\n\n{\"input\": \"broke in a day\", \"output\": \"negative\"}\n")
+		examples, err := parseExamples(data, "examples.jsonl")
+		require.NoError(t, err)
+		require.Len(t, examples, 2)
+		assert.Equal(t, "broke in a day", examples[1]["input"])
+	})
+
+	t.Run("yaml list", func(t *testing.T) {
+		data := []byte("- input: great product\n  output: positive\n- input: broke in a day\n  output: negative\n")
+		examples, err := parseExamples(data, "examples.yaml")
+		require.NoError(t, err)
+		require.Len(t, examples, 2)
+		assert.Equal(t, "positive", examples[0]["output"])
+	})
+
+	t.Run("invalid jsonl line", func(t *testing.T) {
+		_, err := parseExamples([]byte("{\"input\": \"ok\"}\nnot json\n"), "examples.jsonl")
+		assert.ErrorContains(t, err, "line 2")
+	})
+}
+
+func TestFormatExamples(t *testing.T) {
+	examples := []Example{
+		{"input": "great product", "output": "positive"},
+		{"input": "broke in a day", "output": "negative", "tags": []interface{}{"quality"}},
+	}
+
+	assert.Equal(t,
+		"Here are some examples:\n\nInput: great product\nOutput: positive\n\nInput: broke in a day\nOutput: negative",
+		formatExamples(&config.ExamplesConfig{}, examples))
+
+	custom := &config.ExamplesConfig{
+		Header:    "Examples",
+		Template:  "{{index}}. {{input}} => {{output}} {{tags}}{{missing}}",
+		Separator: "\n",
+	}
+	assert.Equal(t,
+		"Examples\n\n1. great product => positive \n2. broke in a day => negative [\"quality\"]",
+		formatExamples(custom, examples))
+}
+
+func TestExamplesFromResults(t *testing.T) {
+	results := []rag.SearchResult{
+		{Text: map[string]interface{}{"question": "q1", "answer": "a1"}, CombinedScore: 0.9},
+		{Text: map[string]interface{}{"question": "q2", "answer": "a2"}, CombinedScore: 0.2},
+	}
+
+	examples := examplesFromResults(results, &config.ExamplesRag{
+		InputField:  "question",
+		OutputField: "answer",
+		MinScore:    0.5,
+	})
+	require.Len(t, examples, 1)
+	assert.Equal(t, "q1", examples[0]["input"])
+	assert.Equal(t, "a1", examples[0]["output"])
+	assert.Equal(t, "q1", examples[0]["question"])
+}
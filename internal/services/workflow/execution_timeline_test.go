@@ -1,6 +1,9 @@
 package workflow
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -151,6 +154,81 @@ func TestExecutionTimeline_ASCIITimeline(t *testing.T) {
 	}
 }
 
+func TestExecutionTimeline_ExportChromeTrace(t *testing.T) {
+	timeline := NewExecutionTimeline(2)
+	timeline.Start()
+
+	// step1 and step2 overlap, step3 reuses a free lane
+	timeline.RecordStepStart("step1")
+	timeline.RecordStepStart("step2")
+	time.Sleep(10 * time.Millisecond)
+	timeline.RecordStepEnd("step1")
+	timeline.RecordStepStart("step3")
+	time.Sleep(5 * time.Millisecond)
+	timeline.RecordStepEnd("step2")
+	timeline.RecordStepEnd("step3")
+
+	timeline.End()
+
+	data, err := timeline.ExportChromeTrace("demo", map[string]error{"step3": fmt.Errorf("boom")})
+	if err != nil {
+		t.Fatalf("ExportChromeTrace failed: %v", err)
+	}
+
+	var trace traceFile
+	if err := json.Unmarshal(data, &trace); err != nil {
+		t.Fatalf("Trace is not valid JSON: %v", err)
+	}
+
+	tids := make(map[string]int)
+	for _, event := range trace.TraceEvents {
+		if event.Ph != "X" {
+			continue
+		}
+		tids[event.Name] = event.Tid
+		if event.Dur <= 0 {
+			t.Errorf("Expected positive duration for %s", event.Name)
+		}
+		if event.Name == "step3" && event.Args["status"] != "failed" {
+			t.Errorf("Expected step3 to be marked failed, got %v", event.Args["status"])
+		}
+	}
+
+	if len(tids) != 3 {
+		t.Fatalf("Expected 3 step events, got %d", len(tids))
+	}
+	if tids["step1"] == tids["step2"] {
+		t.Error("Overlapping steps should be on different lanes")
+	}
+	if tids["step3"] != tids["step1"] {
+		t.Errorf("step3 should reuse step1's lane, got tid %d (step1: %d)", tids["step3"], tids["step1"])
+	}
+}
+
+func TestExecutionTimeline_ExportHTML(t *testing.T) {
+	timeline := NewExecutionTimeline(2)
+	timeline.Start()
+	timeline.RecordStepStart("fetch</script>")
+	time.Sleep(5 * time.Millisecond)
+	timeline.RecordStepEnd("fetch</script>")
+	timeline.End()
+
+	page, err := timeline.ExportHTML("Demo <run>", nil)
+	if err != nil {
+		t.Fatalf("ExportHTML failed: %v", err)
+	}
+
+	if !strings.Contains(page, "<title>Demo &lt;run&gt;</title>") {
+		t.Error("Title should be HTML-escaped")
+	}
+	if strings.Contains(page, "fetch</script>") {
+		t.Error("Step names must not break out of the script block")
+	}
+	if !strings.Contains(page, "fetch\\u003c/script\\u003e") {
+		t.Error("Timeline data should be embedded in the page")
+	}
+}
+
 // Helper function
 func containsString(s, substr string) bool {
 	return len(s) > 0 && len(substr) > 0 &&
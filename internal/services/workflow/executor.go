@@ -2,13 +2,17 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/progress"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/query"
 )
@@ -21,6 +25,12 @@ type Executor struct {
 	appConfig     *config.ApplicationConfig
 	configService interface{} // infraConfig.Service
 	serverManager domain.MCPServerManager
+	providers     domain.ProviderFactory // Creates step providers (nil: ai.NewProviderFactory)
+	progress      progress.Scope         // Run the executed steps belong to, for progress events
+	stream        io.Writer              // Where steps with stream: true write their responses (nil: nowhere)
+
+	workflowSystemPrompt string      // execution.system_prompt, interpolated by the orchestrator
+	blackboard           *Blackboard // Shared memory of the run, for the blackboard tools
 }
 
 // NewExecutor creates a new workflow executor
@@ -29,6 +39,8 @@ func NewExecutor(workflow *config.WorkflowV2, logger *Logger) *Executor {
 		workflow: workflow,
 		resolver: NewPropertyResolver(&workflow.Execution),
 		logger:   logger,
+
+		workflowSystemPrompt: workflow.Execution.SystemPrompt,
 	}
 }
 
@@ -52,13 +64,37 @@ func (e *ProviderError) Error() string {
 	return fmt.Sprintf("%s/%s: %v", e.Provider, e.Model, e.Err)
 }
 
+// Unwrap returns the underlying error
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
 // ExecuteStep executes a single workflow step with provider fallback
 func (e *Executor) ExecuteStep(ctx context.Context, step *config.StepV2) (*StepResult, error) {
+	return e.ExecuteStepWithHistory(ctx, step, nil)
+}
+
+// ExecuteStepWithHistory executes a step with earlier messages, such as
+// other steps' exchanges, between the system prompt and the step's prompt
+func (e *Executor) ExecuteStepWithHistory(ctx context.Context, step *config.StepV2, history []domain.Message) (*StepResult, error) {
 	// Resolve provider chain
 	providers := e.resolver.ResolveProviders(step)
 
 	if len(providers) == 0 {
-		return nil, fmt.Errorf("no providers configured for step %s", step.Name)
+		return nil, domainErrors.Categorize(fmt.Errorf("no providers configured for step %s", step.Name), domainErrors.ErrValidation)
+	}
+
+	providers, err := e.expandRouters(step, providers)
+	if err != nil {
+		return nil, domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+
+	// The step's timeout covers its whole provider chain
+	timeout := e.resolver.ResolveStepTimeout(step)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
 	e.logger.Debug("Step: %s", step.Name)
@@ -70,7 +106,7 @@ func (e *Executor) ExecuteStep(ctx context.Context, step *config.StepV2) (*StepR
 		e.logger.Debug("Attempting provider %d/%d: %s/%s", i+1, len(providers), pc.Provider, pc.Model)
 
 		startTime := time.Now()
-		result, err := e.executeWithProvider(ctx, step, pc)
+		result, err := e.executeWithProvider(ctx, step, pc, history)
 		duration := time.Since(startTime)
 
 		if err == nil {
@@ -83,11 +119,41 @@ func (e *Executor) ExecuteStep(ctx context.Context, step *config.StepV2) (*StepR
 		e.logger.Warn("Failed: %s/%s - %v", pc.Provider, pc.Model, err)
 		lastErr = err
 
-		// Continue to next provider in chain
+		// Continue to next provider in chain, unless the step is out of time
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, domainErrors.Categorize(fmt.Errorf("step %s timed out after %s: %w", step.Name, timeout, lastErr), domainErrors.ErrTimeout)
 	}
 
 	// All providers failed
-	return nil, fmt.Errorf("all %d providers failed, last error: %v", len(providers), lastErr)
+	return nil, domainErrors.Categorize(fmt.Errorf("all %d providers failed, last error: %w", len(providers), lastErr), domainErrors.ErrProviderFailure)
+}
+
+// expandRouters replaces router:<policy> entries in the chain with the
+// providers the policy selects for this step, logging each decision
+func (e *Executor) expandRouters(step *config.StepV2, providers []config.ProviderFallback) ([]config.ProviderFallback, error) {
+	var expanded []config.ProviderFallback
+	for _, pc := range providers {
+		policyName, isRouter := config.ParseRouter(pc.Provider)
+		if !isRouter {
+			expanded = append(expanded, pc)
+			continue
+		}
+		if e.appConfig == nil {
+			return nil, fmt.Errorf("step %s: %s requires application config", step.Name, pc.Provider)
+		}
+		decision, err := ai.Route(e.appConfig, policyName, step.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", step.Name, err)
+		}
+		e.logger.Info("Routing step %s: %s", step.Name, decision)
+		expanded = append(expanded, decision.Chain...)
+	}
+	return expanded, nil
 }
 
 // executeWithProvider executes a step with a specific provider using the query service
@@ -95,12 +161,13 @@ func (e *Executor) executeWithProvider(
 	ctx context.Context,
 	step *config.StepV2,
 	pc config.ProviderFallback,
+	history []domain.Message,
 ) (*StepResult, error) {
 	// ARCHITECTURAL FIX: Delegate to query service instead of reimplementing
 	// This ensures workflows behave identically to `mcp-cli query` calls
 
 	// Create provider for this specific execution
-	provider, err := e.createProvider(pc.Provider, pc.Model)
+	provider, err := e.createProvider(pc.Provider, pc.Model, step)
 	if err != nil {
 		return nil, &ProviderError{
 			Provider: pc.Provider,
@@ -110,7 +177,7 @@ func (e *Executor) executeWithProvider(
 	}
 
 	// Resolve configuration
-	maxIterations := e.resolver.ResolveMaxIterations(step)
+	maxIterations := e.resolver.ResolveMaxIterations(step, e.toolIterations())
 
 	// Build AI options (minimal - provider already configured)
 	aiOptions := &host.AIOptions{
@@ -118,54 +185,32 @@ func (e *Executor) executeWithProvider(
 		Model:    pc.Model,
 	}
 
-	// Determine system prompt based on whether skills are requested
-	systemPrompt := ""
-	if len(step.Skills) > 0 {
-		// Skills-aware system prompt
-		systemPrompt = `You are a helpful assistant that answers questions concisely and accurately. You have access to tools and should use them when necessary to answer the question.
-
-IMPORTANT - Using Skills:
-Skills provide specialized capabilities through code execution. There are three ways to use skills:
-
-1. PASSIVE MODE - Load documentation and reference materials:
-   Call the skill tool directly (e.g., 'docx', 'pdf', 'pptx', 'xlsx')
-   Use this to learn about a skill's capabilities before using it.
-
-2. RUN HELPER SCRIPT - Execute pre-written scripts (RECOMMENDED):
-   Call 'run_helper_script' with skill_name, script_name, and args parameters
-   Use this for direct execution of existing scripts in the skill's scripts/ directory
-   This is the most efficient method - no code generation needed
-
-3. EXECUTE CUSTOM CODE - Write and execute custom code:
-   Call 'execute_skill_code' with skill_name parameter
-   Use this to CREATE, MODIFY, PROCESS, or GENERATE anything with custom logic
-   Use when you need flexibility beyond what helper scripts provide
-
-CRITICAL - File Paths:
-When working with files, ALL output files MUST be saved to /outputs/ directory:
-   doc.save('/outputs/result.docx')  ✅ CORRECT - File persists to host
-   doc.save('/workspace/result.docx') ❌ WRONG - File deleted when container exits
-   doc.save('result.docx') ❌ WRONG - Defaults to /workspace/
-
-The /outputs/ directory is the ONLY location where files persist after execution.`
-	}
+	systemPrompt := e.systemPrompt(step)
 
 	// Create query handler with server manager (includes skills)
 	handler := query.NewQueryHandlerWithServerManager(
-		e.serverManager,
+		agentTools(e.blackboardTools(e.serverManager, step), step),
 		provider,
 		aiOptions,
 		systemPrompt,
 	)
 
+	handler.Progress = e.progress.WithStep(step.Name)
+	handler.ContextMessages = append(handler.ContextMessages, history...)
+
 	// Set max iterations
 	handler.SetMaxFollowUpAttempts(maxIterations)
+	handler.MaxRepeatedToolCalls = e.toolIterations().RepeatLimit()
+	handler.MaxToolCallRepairs = e.toolIterations().RepairLimit()
+	if step.Stream {
+		handler.Stream = e.stream
+	}
 
 	// Execute query
 	e.logger.Debug("Executing step via query service: %s/%s with max_iterations=%d",
 		pc.Provider, pc.Model, maxIterations)
 
-	queryResult, err := handler.Execute(step.Run)
+	queryResult, err := handler.ExecuteContext(ctx, step.Run)
 	if err != nil {
 		return nil, &ProviderError{
 			Provider: pc.Provider,
@@ -180,7 +225,7 @@ The /outputs/ directory is the ONLY location where files persist after execution
 	// Convert query result to step result
 	result := &StepResult{
 		Output:    queryResult.Response,
-		Messages:  nil, // Query service doesn't expose message history
+		Messages:  queryResult.Messages,
 		ToolsUsed: len(queryResult.ToolCalls) > 0,
 		Success:   !failed,
 	}
@@ -189,8 +234,70 @@ The /outputs/ directory is the ONLY location where files persist after execution
 	return result, nil
 }
 
-// createProvider creates a provider instance
-func (e *Executor) createProvider(providerName, modelName string) (domain.LLMProvider, error) {
+// skillsSystemPrompt explains the skill tools to steps that use skills
+const skillsSystemPrompt = `You are a helpful assistant that answers questions concisely and accurately. You have access to tools and should use them when necessary to answer the question.
+
+IMPORTANT - Using Skills:
+Skills provide specialized capabilities through code execution. There are three ways to use skills:
+
+1. PASSIVE MODE - Load documentation and reference materials:
+   Call the skill tool directly (e.g., 'docx', 'pdf', 'pptx', 'xlsx')
+   Use this to learn about a skill's capabilities before using it.
+
+2. RUN HELPER SCRIPT - Execute pre-written scripts (RECOMMENDED):
+   Call 'run_helper_script' with skill_name, script_name, and args parameters
+   Use this for direct execution of existing scripts in the skill's scripts/ directory
+   This is the most efficient method - no code generation needed
+
+3. EXECUTE CUSTOM CODE - Write and execute custom code:
+   Call 'execute_skill_code' with skill_name parameter
+   Use this to CREATE, MODIFY, PROCESS, or GENERATE anything with custom logic
+   Use when you need flexibility beyond what helper scripts provide
+
+CRITICAL - File Paths:
+When working with files, ALL output files MUST be saved to /outputs/ directory:
+   doc.save('/outputs/result.docx')  ✅ CORRECT - File persists to host
+   doc.save('/workspace/result.docx') ❌ WRONG - File deleted when container exits
+   doc.save('result.docx') ❌ WRONG - Defaults to /workspace/
+
+The /outputs/ directory is the ONLY location where files persist after execution.`
+
+// SetWorkflowSystemPrompt sets the workflow's system prompt layer, once its
+// {{params}} and {{env}} references are interpolated
+func (e *Executor) SetWorkflowSystemPrompt(prompt string) {
+	e.workflowSystemPrompt = prompt
+}
+
+// systemPrompt composes a step's system prompt from the settings default,
+// the skills instructions when the step uses skills, the workflow's
+// execution prompt, the step's agent's prompt and the step's own, and logs
+// the result
+func (e *Executor) systemPrompt(step *config.StepV2) string {
+	var layers []config.SystemPromptLayer
+	if e.appConfig != nil && e.appConfig.AI != nil {
+		layers = append(layers, config.SystemPromptLayer{Name: "settings", Text: e.appConfig.AI.DefaultSystemPrompt})
+	}
+	if len(step.Skills) > 0 {
+		layers = append(layers, config.SystemPromptLayer{Name: "skills", Text: skillsSystemPrompt})
+	}
+	layers = append(layers, config.SystemPromptLayer{Name: "workflow", Text: e.workflowSystemPrompt, Mode: e.workflow.Execution.SystemPromptMode})
+	if agent := step.ResolvedAgent; agent != nil {
+		layers = append(layers, config.SystemPromptLayer{Name: "agent " + agent.Name, Text: agent.SystemPrompt, Mode: agent.SystemPromptMode})
+	}
+	layers = append(layers, config.SystemPromptLayer{Name: "step", Text: step.SystemPrompt, Mode: step.SystemPromptMode})
+
+	prompt, sources := config.ComposeSystemPrompt(layers...)
+	if len(sources) == 0 {
+		e.logger.Debug("System prompt for %s: (none)", step.Name)
+	} else {
+		e.logger.Debug("System prompt for %s (%s):\n%s", step.Name, strings.Join(sources, " + "), prompt)
+	}
+	return prompt
+}
+
+// createProvider creates a provider instance, with the sampling settings of
+// the step it runs when there is one
+func (e *Executor) createProvider(providerName, modelName string, step *config.StepV2) (domain.LLMProvider, error) {
 	if e.appConfig == nil {
 		return nil, fmt.Errorf("no app config available")
 	}
@@ -211,6 +318,9 @@ func (e *Executor) createProvider(providerName, modelName string) (domain.LLMPro
 	}
 
 	if providerConfig == nil {
+		if e.appConfig.Tenant != "" {
+			return nil, fmt.Errorf("provider '%s' is not available to tenant '%s'", providerName, e.appConfig.Tenant)
+		}
 		return nil, fmt.Errorf("provider '%s' not found in configuration", providerName)
 	}
 
@@ -219,6 +329,9 @@ func (e *Executor) createProvider(providerName, modelName string) (domain.LLMPro
 	if modelName != "" {
 		configCopy.DefaultModel = modelName
 	}
+	if step != nil {
+		e.resolver.ApplySampling(step, &configCopy)
+	}
 
 	// For failover chains: disable retries at provider level
 	// The executor handles retries by trying the next provider
@@ -229,7 +342,10 @@ func (e *Executor) createProvider(providerName, modelName string) (domain.LLMPro
 
 	// Create provider
 	providerType := domain.ProviderType(providerName)
-	providerFactory := ai.NewProviderFactory()
+	providerFactory := e.providers
+	if providerFactory == nil {
+		providerFactory = ai.NewProviderFactory()
+	}
 	provider, err := providerFactory.CreateProvider(providerType, &configCopy, interfaceType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create provider: %w", err)
@@ -243,11 +359,26 @@ func (e *Executor) SetAppConfig(appConfig *config.ApplicationConfig) {
 	e.appConfig = appConfig
 }
 
+// toolIterations returns the tool_iterations settings, or nil (the
+// defaults) without an application config
+func (e *Executor) toolIterations() *config.ToolIterationsConfig {
+	if e.appConfig == nil {
+		return nil
+	}
+	return e.appConfig.ToolIterations
+}
+
 // SetProvider is deprecated - kept for compatibility
 func (e *Executor) SetProvider(provider domain.LLMProvider) {
 	// No-op - we create providers dynamically now
 }
 
+// SetStreamOutput sets where steps with stream: true write their responses
+// as they arrive
+func (e *Executor) SetStreamOutput(w io.Writer) {
+	e.stream = w
+}
+
 // SetServerManager sets the server manager for tool execution
 func (e *Executor) SetServerManager(serverManager domain.MCPServerManager) {
 	e.serverManager = serverManager
@@ -295,6 +426,10 @@ func (e *Executor) detectStepFailure(output string, messages []domain.Message) b
 
 // isToolErrorResponse checks if a tool response indicates an error
 func (e *Executor) isToolErrorResponse(toolOutput string) bool {
+	if domainErrors.IsToolErrorResult(toolOutput) {
+		return true
+	}
+
 	lowerOutput := strings.ToLower(toolOutput)
 
 	errorIndicators := []string{
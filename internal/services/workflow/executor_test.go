@@ -1,13 +1,97 @@
 package workflow
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// scriptedProvider calls each offered tool once, then answers. With block
+// set, it waits until the request is cancelled instead.
+type scriptedProvider struct {
+	answer   string
+	block    bool
+	requests []*domain.CompletionRequest
+}
+
+func (p *scriptedProvider) CreateCompletion(ctx context.Context, req *domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	return p.StreamCompletion(ctx, req, io.Discard)
+}
+
+func (p *scriptedProvider) StreamCompletion(ctx context.Context, req *domain.CompletionRequest, w io.Writer) (*domain.CompletionResponse, error) {
+	p.requests = append(p.requests, req)
+	if p.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	called := map[string]bool{}
+	for _, msg := range req.Messages {
+		for _, call := range msg.ToolCalls {
+			called[call.Function.Name] = true
+		}
+	}
+	for _, tool := range req.Tools {
+		if !called[tool.Function.Name] {
+			return &domain.CompletionResponse{ToolCalls: []domain.ToolCall{{
+				ID:       "call_" + tool.Function.Name,
+				Type:     "function",
+				Function: domain.Function{Name: tool.Function.Name, Arguments: json.RawMessage(`{}`)},
+			}}}, nil
+		}
+	}
+
+	io.WriteString(w, p.answer)
+	return &domain.CompletionResponse{Response: p.answer}, nil
+}
+
+func (p *scriptedProvider) CreateEmbeddings(ctx context.Context, req *domain.EmbeddingRequest) (*domain.EmbeddingResponse, error) {
+	return nil, nil
+}
+func (p *scriptedProvider) GetSupportedEmbeddingModels() []string  { return nil }
+func (p *scriptedProvider) GetMaxEmbeddingTokens(model string) int { return 0 }
+func (p *scriptedProvider) GetProviderType() domain.ProviderType   { return "scripted" }
+func (p *scriptedProvider) GetInterfaceType() config.InterfaceType { return config.OpenAICompatible }
+func (p *scriptedProvider) ValidateConfig() error                  { return nil }
+func (p *scriptedProvider) Close() error                           { return nil }
+
+// scriptedFactory hands out one provider for every step
+type scriptedFactory struct {
+	provider domain.LLMProvider
+}
+
+func (f *scriptedFactory) CreateProvider(domain.ProviderType, *config.ProviderConfig, config.InterfaceType) (domain.LLMProvider, error) {
+	return f.provider, nil
+}
+func (f *scriptedFactory) GetSupportedProviders() []domain.ProviderType { return nil }
+func (f *scriptedFactory) GetProviderInterface(domain.ProviderType) config.InterfaceType {
+	return config.OpenAICompatible
+}
+
+// newScriptedOrchestrator runs wf with provider for every step's LLM calls
+func newScriptedOrchestrator(wf *config.WorkflowV2, provider domain.LLMProvider) *Orchestrator {
+	o := NewOrchestrator(wf, NewLogger("error", false))
+	o.SetAppConfig(&config.ApplicationConfig{AI: &config.AIConfig{
+		Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+			config.OpenAICompatible: {Providers: map[string]config.ProviderConfig{"scripted": {}}},
+		},
+	}})
+	o.executor.providers = &scriptedFactory{provider: provider}
+	o.SetServerManager(&toolsServerManager{})
+	return o
+}
+
 // TestBuildMCPCliArgs is disabled - buildMCPCliArgs method doesn't exist in current implementation
 // TODO: Re-enable if this functionality is added back
 /*
@@ -313,3 +397,163 @@ func TestExecutorResolveTimeout(t *testing.T) {
 	timeout := executor.resolver.ResolveTimeout(step)
 	assert.Equal(t, 45*time.Second, timeout)
 }
+
+func TestExecutorExpandRouters(t *testing.T) {
+	localCost := 0.0
+	appConfig := &config.ApplicationConfig{
+		AI: &config.AIConfig{
+			Interfaces: map[config.InterfaceType]config.InterfaceConfig{
+				config.OpenAICompatible: {
+					Providers: map[string]config.ProviderConfig{
+						"openai":   {CostPer1kInputTokens: 0.005, CostPer1kOutputTokens: 0.015},
+						"deepseek": {CostPer1kInputTokens: 0.0003, CostPer1kOutputTokens: 0.0011},
+					},
+				},
+			},
+		},
+		Routing: &config.RoutingConfig{
+			Policies: map[string]config.RoutingPolicy{
+				"cheap": {
+					Strategy: config.RouteCheapest,
+					MaxCost:  0.005,
+					Candidates: []config.RouteCandidate{
+						{Provider: "openai", Model: "gpt-4o"},
+						{Provider: "ollama", Model: "qwen2.5-coder", Tags: []string{"code"}, Cost: &localCost},
+						{Provider: "deepseek", Model: "deepseek-chat"},
+					},
+				},
+				"fast": {
+					Strategy:   config.RouteFastest,
+					LatencySLO: 2000,
+					Candidates: []config.RouteCandidate{
+						{Provider: "openai", Model: "gpt-4o", LatencyMs: 1500},
+						{Provider: "ollama", Model: "llama3", LatencyMs: 4000},
+						{Provider: "deepseek", Model: "deepseek-chat", LatencyMs: 900},
+					},
+				},
+			},
+		},
+	}
+
+	executor := NewExecutor(&config.WorkflowV2{}, NewLogger("normal", false))
+	executor.SetAppConfig(appConfig)
+
+	// Cheapest first; openai exceeds max_cost (average of its input/output prices)
+	providers, err := executor.expandRouters(&config.StepV2{Name: "summarize"}, []config.ProviderFallback{{Provider: "router:cheap"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []config.ProviderFallback{{Provider: "ollama", Model: "qwen2.5-coder"}, {Provider: "deepseek", Model: "deepseek-chat"}}, providers)
+
+	// Tagged step: matching and untagged candidates both qualify
+	providers, err = executor.expandRouters(&config.StepV2{Name: "write", Tags: []string{"code"}}, []config.ProviderFallback{{Provider: "router:cheap"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "ollama", providers[0].Provider)
+
+	// Latency SLO drops the slow candidate; routers expand in place within a chain
+	providers, err = executor.expandRouters(&config.StepV2{Name: "s"}, []config.ProviderFallback{{Provider: "router:fast"}, {Provider: "anthropic"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"deepseek", "openai", "anthropic"}, []string{providers[0].Provider, providers[1].Provider, providers[2].Provider})
+
+	_, err = executor.expandRouters(&config.StepV2{Name: "s"}, []config.ProviderFallback{{Provider: "router:missing"}})
+	assert.Error(t, err)
+}
+
+func TestExecutorSystemPrompt(t *testing.T) {
+	workflow := &config.WorkflowV2{
+		Execution: config.ExecutionContext{SystemPrompt: "Answer in {{params.lang}}."},
+	}
+	executor := NewExecutor(workflow, NewLogger("error", false))
+	executor.SetAppConfig(&config.ApplicationConfig{AI: &config.AIConfig{DefaultSystemPrompt: "You are a helpful assistant."}})
+	executor.SetWorkflowSystemPrompt("Answer in French.")
+
+	assert.Equal(t, "You are a helpful assistant.\n\nAnswer in French.", executor.systemPrompt(&config.StepV2{Name: "plain"}))
+
+	// Skills instructions come after the settings prompt
+	withSkills := executor.systemPrompt(&config.StepV2{Name: "docs", Skills: []string{"docx"}, SystemPrompt: "Use the house style."})
+	assert.True(t, strings.HasPrefix(withSkills, "You are a helpful assistant.\n\n"+skillsSystemPrompt))
+	assert.True(t, strings.HasSuffix(withSkills, "Answer in French.\n\nUse the house style."))
+
+	replaced := executor.systemPrompt(&config.StepV2{Name: "raw", SystemPrompt: "Reply with JSON only.", SystemPromptMode: config.SystemPromptReplace})
+	assert.Equal(t, "Reply with JSON only.", replaced)
+
+	// An agent's prompt sits between the workflow's and the step's
+	critic := &config.AgentConfig{Name: "critic", SystemPrompt: "Find the weaknesses."}
+	withAgent := executor.systemPrompt(&config.StepV2{Name: "review", ResolvedAgent: critic, SystemPrompt: "Be brief."})
+	assert.Equal(t, "You are a helpful assistant.\n\nAnswer in French.\n\nFind the weaknesses.\n\nBe brief.", withAgent)
+}
+
+func TestOrchestratorRunsStepToolCalls(t *testing.T) {
+	wf := &config.WorkflowV2{
+		Name:      "tools",
+		Version:   "1.0.0",
+		Execution: config.ExecutionContext{Provider: "scripted", Model: "m"},
+		Steps:     []config.StepV2{{Name: "lookup", Run: "Look it up: {{input}}"}},
+	}
+	provider := &scriptedProvider{answer: "found it"}
+	manager := &toolsServerManager{tools: []string{"search_docs", "read_file"}}
+
+	o := newScriptedOrchestrator(wf, provider)
+	o.SetServerManager(manager)
+	require.NoError(t, o.Execute(context.Background(), "the answer"))
+
+	assert.Equal(t, []string{"search_docs", "read_file"}, manager.ran)
+	output, ok := o.GetStepResult("lookup")
+	require.True(t, ok)
+	assert.Equal(t, "found it", output)
+
+	// The answer was asked for with both tools' results
+	require.Len(t, provider.requests, 3)
+	var results []string
+	for _, msg := range provider.requests[2].Messages {
+		if msg.Role == "tool" {
+			results = append(results, msg.ToolCallID+"="+msg.Content)
+		}
+	}
+	assert.Equal(t, []string{"call_search_docs=ok", "call_read_file=ok"}, results)
+	assert.Contains(t, provider.requests[0].Messages[len(provider.requests[0].Messages)-1].Content, "Look it up: the answer")
+}
+
+func TestOrchestratorStreamsSteps(t *testing.T) {
+	wf := &config.WorkflowV2{
+		Name:      "stream",
+		Version:   "1.0.0",
+		Execution: config.ExecutionContext{Provider: "scripted", Model: "m"},
+		Steps: []config.StepV2{
+			{Name: "quiet", Run: "first"},
+			{Name: "loud", Run: "second", Stream: true, Needs: []string{"quiet"}},
+		},
+	}
+	o := newScriptedOrchestrator(wf, &scriptedProvider{answer: "streamed answer"})
+	var out bytes.Buffer
+	o.SetStreamOutput(&out)
+	require.NoError(t, o.Execute(context.Background(), ""))
+
+	// Only the step with stream: true is written as it arrives
+	assert.Equal(t, "streamed answer", out.String())
+	output, _ := o.GetStepResult("loud")
+	assert.Equal(t, "streamed answer", output)
+}
+
+func TestOrchestratorStepTimeout(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	wf := &config.WorkflowV2{
+		Name:    "slow",
+		Version: "1.0.0",
+		Execution: config.ExecutionContext{Providers: []config.ProviderFallback{
+			{Provider: "scripted", Model: "first"},
+			{Provider: "scripted", Model: "fallback"},
+		}},
+		Steps: []config.StepV2{{Name: "wait", Run: "take your time", Timeout: &timeout}},
+	}
+	provider := &scriptedProvider{block: true}
+	o := newScriptedOrchestrator(wf, provider)
+
+	started := time.Now()
+	err := o.Execute(context.Background(), "")
+	require.Error(t, err)
+	assert.Less(t, time.Since(started), 5*time.Second)
+	assert.True(t, errors.Is(err, domainErrors.ErrTimeout), "got %v", err)
+	assert.ErrorContains(t, err, "timed out after 50ms")
+
+	// The timeout covers the whole chain, so the fallback isn't tried
+	assert.Len(t, provider.requests, 1)
+}
@@ -0,0 +1,127 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+)
+
+// FailureReport is the outcome of a parallel run with on_error: continue
+// in which some steps failed
+type FailureReport struct {
+	Workflow  string          `json:"workflow"`
+	Failed    []FailedStep    `json:"failed"`
+	Skipped   []SkippedStep   `json:"skipped,omitempty"`
+	Succeeded []SucceededStep `json:"succeeded,omitempty"`
+}
+
+// FailedStep is a step or loop that failed
+type FailedStep struct {
+	Name  string `json:"name"`
+	Class string `json:"class"` // validation, provider, tool, timeout or failure
+	Error string `json:"error"`
+
+	err error
+}
+
+// SkippedStep is a step that didn't run because a step it needs failed
+type SkippedStep struct {
+	Name      string `json:"name"`
+	FailedDep string `json:"failed_dependency"` // The failed step it (indirectly) needs
+}
+
+// SucceededStep is a step or loop that completed, with its output
+type SucceededStep struct {
+	Name   string `json:"name"`
+	Output string `json:"output"`
+}
+
+// PartialFailureError is returned when a parallel run with on_error:
+// continue finished with failed steps. It wraps the steps' errors, so the
+// exit code follows their categories.
+type PartialFailureError struct {
+	Report *FailureReport
+}
+
+func (e *PartialFailureError) Error() string {
+	names := make([]string, len(e.Report.Failed))
+	for i, failed := range e.Report.Failed {
+		names[i] = fmt.Sprintf("%s (%s)", failed.Name, failed.Class)
+	}
+	total := len(e.Report.Failed) + len(e.Report.Skipped) + len(e.Report.Succeeded)
+	msg := fmt.Sprintf("%d of %d steps failed: %s", len(e.Report.Failed), total, strings.Join(names, ", "))
+	if len(e.Report.Skipped) > 0 {
+		msg += fmt.Sprintf("; %d skipped", len(e.Report.Skipped))
+	}
+	return msg
+}
+
+func (e *PartialFailureError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Report.Failed))
+	for _, failed := range e.Report.Failed {
+		if failed.err != nil {
+			errs = append(errs, failed.err)
+		}
+	}
+	return errs
+}
+
+// buildFailureReport collects the run's failed, skipped and succeeded
+// steps and loops in workflow order. skipped maps each skipped step to the
+// failed step it needs.
+func (o *Orchestrator) buildFailureReport(errs map[string]error, skipped map[string]string) *FailureReport {
+	report := &FailureReport{Workflow: o.workflow.Name}
+
+	names := make([]string, 0, len(o.workflow.Steps)+len(o.workflow.Loops))
+	for _, step := range o.workflow.Steps {
+		names = append(names, step.Name)
+	}
+	for _, loop := range o.workflow.Loops {
+		names = append(names, loop.Name)
+	}
+
+	for _, name := range names {
+		if err, failed := errs[name]; failed {
+			report.Failed = append(report.Failed, FailedStep{Name: name, Class: domainErrors.Category(err), Error: err.Error(), err: err})
+		} else if dep, ok := skipped[name]; ok {
+			report.Skipped = append(report.Skipped, SkippedStep{Name: name, FailedDep: dep})
+		} else if output, ok := o.GetStepResult(name); ok {
+			report.Succeeded = append(report.Succeeded, SucceededStep{Name: name, Output: output})
+		}
+	}
+	return report
+}
+
+// Format renders the report as markdown: a status table followed by the
+// outputs of the steps that succeeded
+func (r *FailureReport) Format() string {
+	var sb strings.Builder
+	total := len(r.Failed) + len(r.Skipped) + len(r.Succeeded)
+	fmt.Fprintf(&sb, "Workflow %s: %d of %d steps failed, %d skipped, %d succeeded\n\n",
+		r.Workflow, len(r.Failed), total, len(r.Skipped), len(r.Succeeded))
+
+	sb.WriteString("| Step | Status | Details |\n")
+	sb.WriteString("| ---- | ------ | ------- |\n")
+	for _, failed := range r.Failed {
+		details := strings.Join(strings.Fields(truncateString(failed.Error, 120)), " ")
+		fmt.Fprintf(&sb, "| %s | failed (%s) | %s |\n", failed.Name, failed.Class, strings.ReplaceAll(details, "|", "/"))
+	}
+	for _, skipped := range r.Skipped {
+		fmt.Fprintf(&sb, "| %s | skipped | needs failed step %s |\n", skipped.Name, skipped.FailedDep)
+	}
+	for _, succeeded := range r.Succeeded {
+		fmt.Fprintf(&sb, "| %s | ok | |\n", succeeded.Name)
+	}
+
+	for _, succeeded := range r.Succeeded {
+		fmt.Fprintf(&sb, "\n## %s\n\n%s\n", succeeded.Name, strings.TrimSpace(succeeded.Output))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// FailureReport returns the report of a parallel run that continued past
+// failed steps, or nil
+func (o *Orchestrator) FailureReport() *FailureReport {
+	return o.failureReport
+}
@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func shellStep(t *testing.T, name, command string, needs ...string) config.StepV2 {
+	return config.StepV2{
+		Name:   name,
+		Needs:  needs,
+		Verify: &config.VerifyMode{Command: command, Dir: t.TempDir(), FailOnError: true},
+	}
+}
+
+func TestExecuteParallel_ContinueReportsFailures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	wf := &config.WorkflowV2{
+		Name:      "partial",
+		Version:   "1.0.0",
+		Execution: config.ExecutionContext{Parallel: true, MaxWorkers: 3, OnError: "continue"},
+		Steps: []config.StepV2{
+			shellStep(t, "slow", "sleep 0.2 && echo slow done"),
+			shellStep(t, "fast", "echo fast done"),
+			shellStep(t, "after_slow", `echo "got {{step.slow}}"`, "slow"),
+			shellStep(t, "broken", "echo nope && exit 3"),
+			shellStep(t, "needs_broken", "echo unreachable", "broken"),
+			shellStep(t, "needs_skipped", "echo unreachable", "needs_broken"),
+		},
+	}
+
+	o := NewOrchestrator(wf, NewLogger("error", false))
+	err := o.Execute(context.Background(), "")
+
+	var partial *PartialFailureError
+	if !assert.True(t, errors.As(err, &partial), "error = %v", err) {
+		return
+	}
+	report := partial.Report
+	if assert.Len(t, report.Failed, 1) {
+		assert.Equal(t, "broken", report.Failed[0].Name)
+		assert.Equal(t, "failure", report.Failed[0].Class)
+	}
+	assert.Equal(t, []SkippedStep{
+		{Name: "needs_broken", FailedDep: "broken"},
+		{Name: "needs_skipped", FailedDep: "broken"},
+	}, report.Skipped)
+	assert.Len(t, report.Succeeded, 3)
+
+	// Dependents wait for their dependencies to finish, not just to start
+	output, _ := o.GetStepResult("after_slow")
+	assert.Contains(t, output, "got PASS: `sleep 0.2")
+
+	final, ok := o.FinalOutput()
+	assert.True(t, ok)
+	assert.Contains(t, final, "| broken | failed (failure) |")
+	assert.Contains(t, final, "| needs_skipped | skipped | needs failed step broken |")
+	assert.Contains(t, final, "## fast\n\nPASS: `echo fast done` succeeded")
+}
+
+func TestExecuteParallel_CancelAllStopsAtFirstFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	wf := &config.WorkflowV2{
+		Name:      "halt",
+		Version:   "1.0.0",
+		Execution: config.ExecutionContext{Parallel: true},
+		Steps: []config.StepV2{
+			shellStep(t, "broken", "exit 3"),
+			shellStep(t, "needs_broken", "echo unreachable", "broken"),
+		},
+	}
+
+	o := NewOrchestrator(wf, NewLogger("error", false))
+	err := o.Execute(context.Background(), "")
+	assert.Error(t, err)
+
+	var partial *PartialFailureError
+	assert.False(t, errors.As(err, &partial))
+	assert.Nil(t, o.FailureReport())
+}
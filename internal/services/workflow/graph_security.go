@@ -0,0 +1,108 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/azure"
+)
+
+// Defaults for graph_security paging
+const (
+	defaultGraphPageSize = 50
+	defaultGraphMaxItems = 500
+)
+
+// executeGraphSecurityStep fetches Microsoft Graph security incidents or alerts
+func (o *Orchestrator) executeGraphSecurityStep(ctx context.Context, step *config.StepV2) error {
+	gsMode := step.GraphSecurity
+	if gsMode == nil {
+		return fmt.Errorf("graph_security mode is nil")
+	}
+
+	// Get Graph configuration from already-loaded app config
+	if o.appConfig == nil || o.appConfig.Graph == nil {
+		return fmt.Errorf("graph configuration not loaded (add a graph: section to settings.yaml)")
+	}
+
+	resource := gsMode.Resource
+	if resource == "" {
+		resource = "incidents"
+	}
+
+	filter, err := o.interpolator.Interpolate(gsMode.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate filter: %w", err)
+	}
+
+	query := azure.GraphQuery{
+		Filter:   filter,
+		OrderBy:  gsMode.OrderBy,
+		Expand:   gsMode.Expand,
+		Select:   gsMode.Select,
+		PageSize: gsMode.PageSize,
+		MaxItems: gsMode.MaxItems,
+	}
+	if query.OrderBy == "" {
+		query.OrderBy = "createdDateTime desc"
+	}
+	if query.PageSize == 0 {
+		query.PageSize = defaultGraphPageSize
+	}
+	if query.MaxItems == 0 {
+		query.MaxItems = defaultGraphMaxItems
+	}
+
+	o.logger.Info("🛡️  Fetching Microsoft Graph security %s", resource)
+	if filter != "" {
+		o.logger.Debug("Graph filter: %s", filter)
+	}
+
+	client, err := azure.NewGraphClient(o.appConfig.Graph)
+	if err != nil {
+		return fmt.Errorf("failed to initialize graph client: %w", err)
+	}
+
+	items, truncated, err := client.ListSecurity(ctx, resource, query)
+	if err != nil {
+		return fmt.Errorf("graph_security step failed: %w", err)
+	}
+
+	if truncated {
+		o.logger.Warn("Graph results truncated to %d %s (raise graph_security.max_items to fetch more)", len(items), resource)
+	}
+
+	// Normalize to a stable shape unless raw output was requested
+	var records []map[string]interface{}
+	if gsMode.Raw {
+		records = items
+	} else {
+		records = make([]map[string]interface{}, 0, len(items))
+		for _, item := range items {
+			if resource == "alerts" {
+				records = append(records, azure.NormalizeAlert(item))
+			} else {
+				records = append(records, azure.NormalizeIncident(item))
+			}
+		}
+	}
+	if records == nil {
+		records = []map[string]interface{}{}
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format results: %w", err)
+	}
+	output := string(data)
+
+	o.setStepResult(step.Name, output)
+	o.interpolator.Set(fmt.Sprintf("%s.count", step.Name), fmt.Sprintf("%d", len(records)))
+	o.interpolator.Set(fmt.Sprintf("%s.truncated", step.Name), fmt.Sprintf("%t", truncated))
+
+	o.logger.Info("✓ Graph security step completed: %d %s", len(records), resource)
+
+	return nil
+}
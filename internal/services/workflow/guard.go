@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/guard"
+)
+
+// executeGuardStep checks text against policy checks. Passing text becomes
+// the step result; on violations the step fails (block), masks the offending
+// text (redact) or hands the text to a remediation workflow and re-checks its
+// answer (remediate). {{step.violations}} lists what was found.
+func (o *Orchestrator) executeGuardStep(ctx context.Context, step *config.StepV2) error {
+	guardMode := step.Guard
+	if guardMode == nil {
+		return fmt.Errorf("guard mode is nil")
+	}
+
+	text, err := o.interpolator.Interpolate(guardMode.Input)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate input: %w", err)
+	}
+
+	var moderator guard.Moderator
+	if m := guardMode.Checks.Moderation; m != nil {
+		providerConfig := o.findProviderConfig(m.Provider)
+		if providerConfig == nil {
+			return fmt.Errorf("guard: moderation provider '%s' not found in configuration", m.Provider)
+		}
+		moderator = guard.NewOpenAIModerator(providerConfig, m.Model)
+	}
+
+	checker, err := guard.NewChecker(guardMode.Checks, moderator)
+	if err != nil {
+		return fmt.Errorf("guard: %w", err)
+	}
+
+	o.logger.Info("🛡️ Guarding step output: %s", step.Name)
+
+	violations, err := checker.Check(ctx, text)
+	if err != nil {
+		return fmt.Errorf("guard: %w", err)
+	}
+	o.setGuardViolations(step, violations)
+
+	if len(violations) == 0 {
+		o.setStepResult(step.Name, text)
+		o.logger.Info("✓ Guard passed")
+		return nil
+	}
+
+	o.logger.Warn("Guard %s found %d violation(s):\n%s", step.Name, len(violations), guard.Summary(violations))
+
+	action := guardMode.Action
+	if action == "" {
+		action = config.GuardActionBlock
+	}
+
+	switch action {
+	case config.GuardActionRedact:
+		redacted, remaining := checker.Redact(text, violations)
+		if len(remaining) > 0 {
+			return fmt.Errorf("guard: %d violation(s) cannot be redacted:\n%s", len(remaining), guard.Summary(remaining))
+		}
+		o.setStepResult(step.Name, redacted)
+		o.logger.Info("✓ Guard redacted %d violation(s)", len(violations))
+		return nil
+
+	case config.GuardActionRemediate:
+		remediated, err := o.remediateGuard(ctx, step, text, violations)
+		if err != nil {
+			return fmt.Errorf("guard: remediation failed: %w", err)
+		}
+
+		recheck, err := checker.Check(ctx, remediated)
+		if err != nil {
+			return fmt.Errorf("guard: %w", err)
+		}
+		if len(recheck) > 0 {
+			return fmt.Errorf("guard: remediated output still has %d violation(s):\n%s", len(recheck), guard.Summary(recheck))
+		}
+		o.setStepResult(step.Name, remediated)
+		o.logger.Info("✓ Guard remediated output via %s", guardMode.Remediate.Workflow)
+		return nil
+
+	default:
+		return fmt.Errorf("guard: %d violation(s):\n%s", len(violations), guard.Summary(violations))
+	}
+}
+
+// remediateGuard calls the remediation workflow. Its with: values may use
+// {{step.input}} (the failing text) and {{step.violations}}; without an
+// input the workflow gets both as a revision request.
+func (o *Orchestrator) remediateGuard(ctx context.Context, step *config.StepV2, text string, violations []guard.Violation) (string, error) {
+	remediate := step.Guard.Remediate
+
+	o.interpolator.Set(step.Name+".input", text)
+
+	with := make(map[string]interface{}, len(remediate.With)+1)
+	for key, value := range remediate.With {
+		with[key] = value
+	}
+	if _, ok := with["input"]; !ok {
+		with["input"] = fmt.Sprintf("Revise the text below so it no longer violates these policy checks. Return only the revised text.\n\nViolations:\n{{%s.violations}}\n\nText:\n{{%s.input}}", step.Name, step.Name)
+	}
+
+	remediateStep := &config.StepV2{
+		Name:     step.Name + "_remediate",
+		Template: &config.TemplateMode{Name: remediate.Workflow, With: with},
+	}
+	if err := o.executeWorkflowStep(ctx, remediateStep); err != nil {
+		return "", err
+	}
+
+	result, _ := o.GetStepResult(remediateStep.Name)
+	return result, nil
+}
+
+// setGuardViolations exposes {{step.violations}} (a bulleted list),
+// {{step.violations_json}} and {{step.passed}}
+func (o *Orchestrator) setGuardViolations(step *config.StepV2, violations []guard.Violation) {
+	if violations == nil {
+		violations = []guard.Violation{}
+	}
+	data, _ := json.Marshal(violations)
+	o.interpolator.Set(step.Name+".violations", guard.Summary(violations))
+	o.interpolator.Set(step.Name+".violations_json", string(data))
+	o.interpolator.Set(step.Name+".passed", fmt.Sprintf("%t", len(violations) == 0))
+}
+
+// findProviderConfig looks up an AI provider's configuration by name
+func (o *Orchestrator) findProviderConfig(name string) *config.ProviderConfig {
+	if o.appConfig == nil || o.appConfig.AI == nil {
+		return nil
+	}
+	for _, iface := range o.appConfig.AI.Interfaces {
+		if providerConfig, exists := iface.Providers[name]; exists {
+			return &providerConfig
+		}
+	}
+	return nil
+}
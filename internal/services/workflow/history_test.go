@@ -0,0 +1,61 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepHistory(t *testing.T) {
+	o := NewOrchestrator(&config.WorkflowV2{Name: "revise"}, NewLogger("error", false))
+
+	draft := []domain.Message{
+		{Role: "user", Content: "Draft a haiku"},
+		{Role: "assistant", Content: " ", ToolCalls: []domain.ToolCall{{ID: "call_1", Type: "function"}}},
+		{Role: "tool", Content: "syllables: 5-7-5", ToolCallID: "call_1"},
+		{Role: "assistant", Content: "An old silent pond"},
+	}
+	critique := []domain.Message{
+		{Role: "user", Content: "Critique it"},
+		{Role: "assistant", Content: "Too derivative"},
+	}
+	o.setStepMessages("draft", draft)
+	o.setStepMessages("critique", critique)
+	o.setStepMessages("empty", nil)
+
+	history, err := o.stepHistory(&config.StepV2{Name: "revise", History: []string{"draft", "critique"}})
+	require.NoError(t, err)
+	assert.Equal(t, append(append([]domain.Message{}, draft...), critique...), history)
+	assert.Equal(t, "call_1", history[2].ToolCallID)
+
+	_, err = o.stepHistory(&config.StepV2{Name: "revise", History: []string{"empty"}})
+	assert.ErrorContains(t, err, "step empty has no messages")
+}
+
+func TestValidateHistory(t *testing.T) {
+	wf := &config.WorkflowV2{
+		Name:      "revise",
+		Execution: config.ExecutionContext{Provider: "openai", Model: "gpt-4o"},
+		Steps: []config.StepV2{
+			{Name: "draft", Run: "Draft it"},
+			{Name: "lookup", SQL: &config.SQLMode{Driver: "sqlite", DSN: "data.db", Query: "SELECT 1"}},
+			{Name: "revise", Run: "Revise it", Needs: []string{"draft"}, History: []string{"draft"}},
+		},
+	}
+	assert.NoError(t, ValidateWorkflow(wf))
+
+	wf.Steps[2].History = []string{"revise", "missing", "lookup"}
+	wf.Steps[2].Needs = []string{"draft", "lookup"}
+	err := ValidateWorkflow(wf)
+	require.Error(t, err)
+	for _, want := range []string{"cannot use its own history", "'missing' does not exist", "'lookup' is not a run: step"} {
+		assert.ErrorContains(t, err, want)
+	}
+
+	wf.Steps[2].History = []string{"draft"}
+	wf.Steps[2].Needs = nil
+	assert.ErrorContains(t, ValidateWorkflow(wf), "'draft' is not in needs")
+}
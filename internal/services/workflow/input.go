@@ -0,0 +1,274 @@
+package workflow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// maxInputPortion caps {{input.head}} and {{input.tail}}, so a single very
+// long line can't pull the whole input into a prompt
+const maxInputPortion = 64 << 10
+
+const defaultSummaryPrompt = "Summarize the following input. Keep names, numbers, dates and any errors or anomalies; leave out repetition."
+
+// ReadInput reads a workflow's input. Up to threshold bytes are returned as
+// text; longer input is spooled to a temp file whose path is returned
+// instead, and the caller removes it after the run. A negative threshold
+// keeps any input in memory.
+func ReadInput(r io.Reader, threshold int) (text, file string, err error) {
+	if threshold < 0 {
+		data, err := io.ReadAll(r)
+		return string(data), "", err
+	}
+
+	head, err := io.ReadAll(io.LimitReader(r, int64(threshold)+1))
+	if err != nil {
+		return "", "", err
+	}
+	if len(head) <= threshold {
+		return string(head), "", nil
+	}
+
+	f, err := os.CreateTemp("", "mcp-cli-input-*.txt")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create input spool file: %w", err)
+	}
+	if _, err := io.Copy(f, io.MultiReader(bytes.NewReader(head), r)); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", "", fmt.Errorf("failed to spool input: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", "", fmt.Errorf("failed to spool input: %w", err)
+	}
+	return "", f.Name(), nil
+}
+
+// SetInputFile makes a spooled input file the run's input. {{input}} then
+// streams from the file rather than holding it in memory.
+func (o *Orchestrator) SetInputFile(path string) {
+	o.inputFile = path
+}
+
+// setInputPortions sets the {{input.*}} variables: size, head, tail and,
+// when the workflow asks for it, summary. input may be a spill reference.
+func (o *Orchestrator) setInputPortions(ctx context.Context, input string) error {
+	src, size, err := openInput(input)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	settings := o.workflow.Input
+	if settings == nil {
+		settings = &config.WorkflowInput{}
+	}
+	headLines, tailLines := settings.HeadLines, settings.TailLines
+	if headLines <= 0 {
+		headLines = config.DefaultInputPortionLines
+	}
+	if tailLines <= 0 {
+		tailLines = config.DefaultInputPortionLines
+	}
+
+	head, err := inputHead(io.NewSectionReader(src, 0, size), headLines)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	tail, err := inputTail(src, size, tailLines)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	o.interpolator.Set("input.size", fmt.Sprintf("%d", size))
+	o.interpolator.Set("input.head", head)
+	o.interpolator.Set("input.tail", tail)
+
+	if settings.Summarize && size > 0 {
+		summary, err := o.summarizeInput(ctx, io.NewSectionReader(src, 0, size), settings)
+		if err != nil {
+			return fmt.Errorf("failed to summarize input: %w", err)
+		}
+		o.interpolator.Set("input.summary", summary)
+	}
+	return nil
+}
+
+// inputSource is the run's input for random access: the spooled file, or
+// the text in memory
+type inputSource interface {
+	io.ReaderAt
+	io.Closer
+}
+
+type stringSource struct{ *strings.Reader }
+
+func (stringSource) Close() error { return nil }
+
+// openInput opens the input for reading and returns its size
+func openInput(input string) (inputSource, int64, error) {
+	path, ok := spillRefPath(input)
+	if !ok {
+		return stringSource{strings.NewReader(input)}, int64(len(input)), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open input: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, fmt.Errorf("failed to open input: %w", err)
+	}
+	return f, info.Size(), nil
+}
+
+// inputHead returns the first n lines of r, up to maxInputPortion bytes
+func inputHead(r io.Reader, n int) (string, error) {
+	reader := bufio.NewReader(io.LimitReader(r, maxInputPortion))
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		line, err := reader.ReadString('\n')
+		sb.WriteString(line)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+// inputTail returns the last n lines of the size bytes in r, up to
+// maxInputPortion bytes. It reads backwards from the end, so a spooled
+// input is never read in full.
+func inputTail(r io.ReaderAt, size int64, n int) (string, error) {
+	start := size - maxInputPortion
+	if start < 0 {
+		start = 0
+	}
+	buf := make([]byte, size-start)
+	if _, err := r.ReadAt(buf, start); err != nil && err != io.EOF {
+		return "", err
+	}
+
+	// A newline ending the input doesn't start another line
+	end := len(buf)
+	if end > 0 && buf[end-1] == '\n' {
+		end--
+	}
+	cut := 0
+	for i, lines := end-1, 0; i >= 0; i-- {
+		if buf[i] == '\n' {
+			lines++
+			if lines == n {
+				cut = i + 1
+				break
+			}
+		}
+	}
+	return string(buf[cut:]), nil
+}
+
+// summarizeInput summarizes the input with the workflow's default provider.
+// Input longer than one chunk is summarized in parts, split at line ends
+// where possible, and the part summaries are combined.
+func (o *Orchestrator) summarizeInput(ctx context.Context, r io.Reader, settings *config.WorkflowInput) (string, error) {
+	prompt := settings.SummaryPrompt
+	if prompt == "" {
+		prompt = defaultSummaryPrompt
+	}
+	chunkSize := settings.SummaryChunkSize
+	if chunkSize <= 0 {
+		chunkSize = config.DefaultInputSummaryChunk
+	}
+
+	chunks, err := splitInput(r, chunkSize)
+	if err != nil {
+		return "", err
+	}
+	o.logger.Info("Summarizing input in %d part(s)", len(chunks))
+
+	summaries := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		request := fmt.Sprintf("%s\n\n%s", prompt, chunk)
+		if len(chunks) > 1 {
+			request = fmt.Sprintf("%s\n\nThis is part %d of %d of the input.\n\n%s", prompt, i+1, len(chunks), chunk)
+		}
+		summary, err := o.runInputSummary(ctx, request)
+		if err != nil {
+			return "", err
+		}
+		summaries = append(summaries, summary)
+	}
+	if len(summaries) == 1 {
+		return summaries[0], nil
+	}
+
+	var combined strings.Builder
+	fmt.Fprintf(&combined, "%s\n\nThe input was too long to summarize at once. Combine these summaries of its %d parts, in order, into one summary.\n", prompt, len(summaries))
+	for i, summary := range summaries {
+		fmt.Fprintf(&combined, "\n## Part %d\n%s\n", i+1, summary)
+	}
+	return o.runInputSummary(ctx, combined.String())
+}
+
+// runInputSummary sends one summary request through the workflow's
+// provider chain
+func (o *Orchestrator) runInputSummary(ctx context.Context, prompt string) (string, error) {
+	result, err := o.executor.ExecuteStep(ctx, &config.StepV2{Name: "input.summary", Run: prompt})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result.Output), nil
+}
+
+// splitInput reads r in chunks of about size bytes, ending each chunk at
+// its last line break when there is one
+func splitInput(r io.Reader, size int) ([]string, error) {
+	var chunks []string
+	var carry []byte
+	buf := make([]byte, size)
+	for {
+		n := copy(buf, carry)
+		m, err := io.ReadFull(r, buf[n:])
+		n += m
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			if n > 0 {
+				chunks = append(chunks, string(buf[:n]))
+			}
+			return chunks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cut := bytes.LastIndexByte(buf[:n], '\n') + 1
+		if cut == 0 {
+			cut = n
+		}
+		chunks = append(chunks, string(buf[:cut]))
+		carry = append(carry[:0], buf[cut:n]...)
+	}
+}
+
+// runRecordInput returns the input as recorded in run.json. A spooled input
+// is recorded by path and size rather than copied into the record.
+func runRecordInput(input string) string {
+	path, ok := spillRefPath(input)
+	if !ok {
+		return input
+	}
+	if info, err := os.Stat(path); err == nil {
+		return fmt.Sprintf("(%d bytes spooled to %s)", info.Size(), path)
+	}
+	return fmt.Sprintf("(spooled to %s)", path)
+}
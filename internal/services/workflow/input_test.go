@@ -0,0 +1,59 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadInput(t *testing.T) {
+	text, file, err := ReadInput(strings.NewReader("small"), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "small", text)
+	assert.Empty(t, file)
+
+	text, file, err = ReadInput(strings.NewReader("larger than ten bytes"), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, text)
+	defer os.Remove(file)
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "larger than ten bytes", string(data))
+}
+
+func TestInputPortions(t *testing.T) {
+	var input strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&input, "line %d\n", i)
+	}
+	spooled := filepath.Join(t.TempDir(), "input.txt")
+	assert.NoError(t, os.WriteFile(spooled, []byte(input.String()), 0600))
+
+	wf := &config.WorkflowV2{Name: "portions", Input: &config.WorkflowInput{HeadLines: 2, TailLines: 3}}
+	o := NewOrchestrator(wf, NewLogger("error", false))
+	ref := spillRefPrefix + spooled
+	o.interpolator.Set("input", ref)
+	assert.NoError(t, o.setInputPortions(context.Background(), ref))
+
+	// inputs.* is the ${{ inputs.file }} style alias of input.*
+	got, err := o.interpolator.Interpolate("{{input.head}}|{{inputs.tail}}|{{input.size}}|${{ inputs.file }}")
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("line 1\nline 2\n|line 98\nline 99\nline 100\n|%d|%s", input.Len(), spooled), got)
+
+	// {{input}} streams the spooled file
+	got, err = o.interpolator.Interpolate("{{input}}")
+	assert.NoError(t, err)
+	assert.Equal(t, input.String(), got)
+}
+
+func TestSplitInput(t *testing.T) {
+	chunks, err := splitInput(strings.NewReader("aaa\nbbb\nccc\ndddddddddd"), 9)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aaa\nbbb\n", "ccc\n", "ddddddddd", "d"}, chunks)
+}
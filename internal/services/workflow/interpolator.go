@@ -3,13 +3,20 @@ package workflow
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 )
 
+// variablePattern matches {{variable}} references. The GitHub Actions style
+// ${{ steps.name.path }} is accepted too.
+var variablePattern = regexp.MustCompile(`\$?\{\{([^}]+)\}\}`)
+
 // Interpolator handles variable interpolation in workflow prompts
 type Interpolator struct {
-	variables map[string]string
+	variables  map[string]string
+	spill      *SpillStore // Optional; large step results are kept on disk
+	blackboard *Blackboard // Optional; resolves {{blackboard.*}} as it is when read
 }
 
 // NewInterpolator creates a new interpolator with given variables
@@ -24,9 +31,20 @@ func (i *Interpolator) Set(key, value string) {
 	i.variables[key] = value
 }
 
+// SetSpillStore enables spilling of large step results to disk
+func (i *Interpolator) SetSpillStore(spill *SpillStore) {
+	i.spill = spill
+}
+
+// SetBlackboard resolves {{blackboard.*}} references from board
+func (i *Interpolator) SetBlackboard(board *Blackboard) {
+	i.blackboard = board
+}
+
 // SetStepResult sets a step's result
 // Stores both as "stepName" and "step.stepName" for compatibility
 func (i *Interpolator) SetStepResult(stepName, result string) {
+	result = i.spill.Store(stepName, result)
 	i.variables[stepName] = result
 	i.variables["step."+stepName] = result
 }
@@ -40,39 +58,97 @@ func (i *Interpolator) SetEnv(env map[string]string) {
 
 // Interpolate replaces all {{variable}} references in text
 func (i *Interpolator) Interpolate(text string) (string, error) {
-	// Regex to match {{variable}} or {{step.output}}
-	re := regexp.MustCompile(`\{\{([^}]+)\}\}`)
+	var result strings.Builder
+	err := i.InterpolateTo(&result, text)
+	return result.String(), err
+}
 
-	result := text
+// InterpolateTo writes text to w with all {{variable}} references replaced.
+// Spilled step results are streamed from disk rather than loaded into memory.
+// Undefined references are written unchanged and reported in the error.
+func (i *Interpolator) InterpolateTo(w io.Writer, text string) error {
 	missingVars := []string{}
+	last := 0
 
-	// Find all matches
-	matches := re.FindAllStringSubmatch(text, -1)
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
+	for _, loc := range variablePattern.FindAllStringSubmatchIndex(text, -1) {
+		if _, err := io.WriteString(w, text[last:loc[0]]); err != nil {
+			return err
 		}
+		last = loc[1]
 
 		// Get variable name (trim whitespace)
-		varName := strings.TrimSpace(match[1])
-		placeholder := match[0]
+		varName := strings.TrimSpace(text[loc[2]:loc[3]])
 
 		// Look up value
-		value, ok := i.variables[varName]
+		value, ok, err := i.lookup(varName)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", varName, err)
+		}
 		if !ok {
 			missingVars = append(missingVars, varName)
-			continue
+			value = text[loc[0]:loc[1]]
 		}
 
-		// Replace
-		result = strings.Replace(result, placeholder, value, -1)
+		if err := copySpilled(w, value); err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", varName, err)
+		}
+	}
+
+	if _, err := io.WriteString(w, text[last:]); err != nil {
+		return err
 	}
 
 	if len(missingVars) > 0 {
-		return result, fmt.Errorf("undefined variables: %v", missingVars)
+		return fmt.Errorf("undefined variables: %v", missingVars)
+	}
+
+	return nil
+}
+
+// lookup resolves a variable name. "steps.x" is an alias for "step.x" and
+// "inputs.x" for "input.x". "blackboard.x" reads the blackboard. "x.path" (or "step.x.path") yields a file
+// holding step x's output, and "input.file" one holding the input, spilling
+// them on demand when they are still in memory. Spilled values are returned
+// as references; callers resolve them with loadSpilled or copySpilled.
+func (i *Interpolator) lookup(name string) (string, bool, error) {
+	if strings.HasPrefix(name, "steps.") {
+		name = "step." + strings.TrimPrefix(name, "steps.")
+	}
+	if strings.HasPrefix(name, "inputs.") {
+		name = "input." + strings.TrimPrefix(name, "inputs.")
+	}
+
+	if key, ok := strings.CutPrefix(name, "blackboard."); ok && i.blackboard != nil {
+		value, ok := i.blackboard.lookup(key)
+		return value, ok, nil
 	}
 
-	return result, nil
+	if value, ok := i.variables[name]; ok {
+		return value, true, nil
+	}
+
+	if name == "input.file" {
+		if value, ok := i.variables["input"]; ok {
+			path, err := i.spill.Path("input", value)
+			if err != nil {
+				return "", false, err
+			}
+			return path, true, nil
+		}
+	}
+
+	if base, ok := strings.CutSuffix(name, ".path"); ok {
+		stepName := strings.TrimPrefix(base, "step.")
+		if value, ok := i.variables["step."+stepName]; ok {
+			path, err := i.spill.Path(stepName, value)
+			if err != nil {
+				return "", false, err
+			}
+			return path, true, nil
+		}
+	}
+
+	return "", false, nil
 }
 
 // HasVariable checks if a variable is defined
@@ -81,10 +157,30 @@ func (i *Interpolator) HasVariable(name string) bool {
 	return ok
 }
 
-// GetVariable gets a variable value
+// GetVariable gets a variable value, reading spilled step results back from disk
 func (i *Interpolator) GetVariable(name string) (string, bool) {
 	val, ok := i.variables[name]
-	return val, ok
+	if !ok {
+		return "", false
+	}
+	full, err := loadSpilled(val)
+	if err != nil {
+		return "", false
+	}
+	return full, true
+}
+
+// Variables returns a copy of all defined variables with spilled step
+// results read back from disk
+func (i *Interpolator) Variables() map[string]string {
+	vars := make(map[string]string, len(i.variables))
+	for k, v := range i.variables {
+		if full, err := loadSpilled(v); err == nil {
+			v = full
+		}
+		vars[k] = v
+	}
+	return vars
 }
 
 // Clear clears all variables
@@ -95,6 +191,8 @@ func (i *Interpolator) Clear() {
 // Clone creates a copy of the interpolator
 func (i *Interpolator) Clone() *Interpolator {
 	clone := NewInterpolator()
+	clone.spill = i.spill
+	clone.blackboard = i.blackboard
 	for k, v := range i.variables {
 		clone.variables[k] = v
 	}
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -178,3 +180,44 @@ func TestClear(t *testing.T) {
 	assert.False(t, interp.HasVariable("key1"))
 	assert.False(t, interp.HasVariable("key2"))
 }
+
+func TestInterpolateSpilledStepResult(t *testing.T) {
+	spill := NewSpillStore(16)
+	defer spill.Close()
+
+	interp := NewInterpolator()
+	interp.SetSpillStore(spill)
+
+	large := strings.Repeat("x", 64)
+	interp.SetStepResult("dump", large)
+	interp.SetStepResult("small", "tiny")
+
+	raw := interp.variables["dump"]
+	assert.True(t, isSpillRef(raw), "large result should be spilled")
+	assert.Equal(t, "tiny", interp.variables["small"])
+
+	got, err := interp.Interpolate("[{{dump}}] [{{ step.small }}]")
+	assert.NoError(t, err)
+	assert.Equal(t, "["+large+"] [tiny]", got)
+
+	path, err := interp.Interpolate("${{ steps.dump.path }}")
+	assert.NoError(t, err)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, large, string(data))
+
+	// Small results are written to a file on demand
+	path, err = interp.Interpolate("{{small.path}}")
+	assert.NoError(t, err)
+	data, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "tiny", string(data))
+
+	value, ok := interp.GetVariable("step.dump")
+	assert.True(t, ok)
+	assert.Equal(t, large, value)
+
+	assert.NoError(t, spill.Close())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "Close should remove spilled files")
+}
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// resolveJudge picks the model for a judgment in the given role. The most
+// specific setting wins: the step or loop's own judge, then the workflow's
+// judges section, then the judges section in settings.yaml, and finally
+// ai.default_provider.
+func (e *Executor) resolveJudge(role string, override *config.JudgeConfig) (config.JudgeConfig, error) {
+	candidates := []*config.JudgeConfig{override}
+	if e.workflow != nil {
+		candidates = append(candidates, e.workflow.Judges.For(role)...)
+	}
+	if e.appConfig != nil {
+		candidates = append(candidates, e.appConfig.Judges.For(role)...)
+	}
+
+	judge := config.ResolveJudge(candidates...)
+	if judge.Provider == "" && e.appConfig != nil && e.appConfig.AI != nil {
+		judge.Provider = e.appConfig.AI.DefaultProvider
+	}
+	if judge.Provider == "" {
+		return judge, fmt.Errorf("no %s judge configured: set judges.%s or judges.default (workflow or settings.yaml), or ai.default_provider", role, role)
+	}
+	return judge, nil
+}
+
+// runJudge sends a prompt to a judge model without tools and returns the
+// answer. Judges run at temperature 0 unless configured otherwise.
+func (e *Executor) runJudge(ctx context.Context, judge config.JudgeConfig, prompt string) (string, error) {
+	provider, err := e.createProvider(judge.Provider, judge.Model, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create judge provider: %w", err)
+	}
+
+	temperature := 0.0
+	if judge.Temperature != nil {
+		temperature = *judge.Temperature
+	}
+
+	response, err := provider.CreateCompletion(ctx, &domain.CompletionRequest{
+		Messages: []domain.Message{
+			{Role: "user", Content: prompt},
+		},
+		Temperature: temperature,
+	})
+	if err != nil {
+		return "", fmt.Errorf("judge %s failed: %w", judgeLabel(judge), err)
+	}
+	return response.Response, nil
+}
+
+// judgeLabel names a judge as provider/model for logs and results
+func judgeLabel(judge config.JudgeConfig) string {
+	if judge.Model == "" {
+		return judge.Provider
+	}
+	return judge.Provider + "/" + judge.Model
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveJudge(t *testing.T) {
+	zero := 0.0
+	warm := 0.3
+	workflow := &config.WorkflowV2{
+		Judges: &config.JudgesConfig{
+			Conditions: &config.JudgeConfig{Provider: "ollama", Model: "llama3"},
+		},
+	}
+	executor := NewExecutor(workflow, NewLogger("error", false))
+	executor.SetAppConfig(&config.ApplicationConfig{
+		AI: &config.AIConfig{DefaultProvider: "openai"},
+		Judges: &config.JudgesConfig{
+			Default:    &config.JudgeConfig{Provider: "anthropic", Model: "claude-haiku-4", Temperature: &warm},
+			Validation: &config.JudgeConfig{Provider: "deepseek"},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		role     string
+		override *config.JudgeConfig
+		want     config.JudgeConfig
+	}{
+		{"workflow role wins over settings", config.JudgeRoleConditions, nil,
+			config.JudgeConfig{Provider: "ollama", Model: "llama3"}},
+		{"settings role", config.JudgeRoleValidation, nil,
+			config.JudgeConfig{Provider: "deepseek"}},
+		{"settings default", config.JudgeRoleConsensus, nil,
+			config.JudgeConfig{Provider: "anthropic", Model: "claude-haiku-4", Temperature: &warm}},
+		{"step override", config.JudgeRoleConditions, &config.JudgeConfig{Provider: "gemini", Temperature: &zero},
+			config.JudgeConfig{Provider: "gemini", Temperature: &zero}},
+		{"override model keeps resolved provider", config.JudgeRoleValidation, &config.JudgeConfig{Model: "deepseek-reasoner"},
+			config.JudgeConfig{Provider: "deepseek", Model: "deepseek-reasoner"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executor.resolveJudge(tt.role, tt.override)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Falls back to the default provider
+	executor.SetAppConfig(&config.ApplicationConfig{AI: &config.AIConfig{DefaultProvider: "openai"}})
+	got, err := executor.resolveJudge(config.JudgeRoleConsensus, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "openai", got.Provider)
+}
+
+func TestParseAdjudication(t *testing.T) {
+	for verdict, want := range map[string]int{"B": 1, "**A**": 0, "Answer C.": 2, "c) is correct": 2} {
+		got, err := parseAdjudication(verdict, 3)
+		assert.NoError(t, err, verdict)
+		assert.Equal(t, want, got, verdict)
+	}
+
+	for _, verdict := range []string{"D", "Both are wrong", ""} {
+		_, err := parseAdjudication(verdict, 3)
+		assert.Error(t, err, verdict)
+	}
+}
@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tabular"
+)
+
+// executeLoadTableStep reads a CSV/TSV/XLSX file into JSON rows
+func (o *Orchestrator) executeLoadTableStep(ctx context.Context, step *config.StepV2) error {
+	ltMode := step.LoadTable
+	if ltMode == nil {
+		return fmt.Errorf("load_table mode is nil")
+	}
+
+	filePath, err := o.interpolator.Interpolate(ltMode.File)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate file: %w", err)
+	}
+
+	opts := tabular.Options{
+		Format:     ltMode.Format,
+		Sheet:      ltMode.Sheet,
+		NoHeader:   ltMode.Header != nil && !*ltMode.Header,
+		InferTypes: ltMode.InferTypes == nil || *ltMode.InferTypes,
+		Offset:     ltMode.Offset,
+		Limit:      ltMode.Limit,
+		Sample:     ltMode.Sample,
+		Seed:       ltMode.Seed,
+	}
+	if ltMode.Delimiter != "" {
+		opts.Delimiter = []rune(ltMode.Delimiter)[0]
+	}
+
+	o.logger.Info("📊 Loading table: %s", filePath)
+
+	table, err := tabular.LoadFile(filePath, opts)
+	if err != nil {
+		return fmt.Errorf("load_table step failed: %w", err)
+	}
+
+	// Format output based on configuration
+	var data []byte
+	switch ltMode.OutputFormat {
+	case "", "json":
+		data, err = json.MarshalIndent(table, "", "  ")
+	case "rows":
+		data, err = json.MarshalIndent(table.Rows, "", "  ")
+	case "schema":
+		data, err = json.MarshalIndent(table.Columns, "", "  ")
+	default:
+		return fmt.Errorf("unsupported output format: %s", ltMode.OutputFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to format table: %w", err)
+	}
+	output := string(data)
+
+	// Store results
+	o.setStepResult(step.Name, output)
+
+	// Also store structured results so loops can iterate over rows directly
+	rowsJSON, _ := json.Marshal(table.Rows)
+	columnsJSON, _ := json.Marshal(table.Columns)
+	o.interpolator.Set(fmt.Sprintf("%s.rows", step.Name), string(rowsJSON))
+	o.interpolator.Set(fmt.Sprintf("%s.columns", step.Name), string(columnsJSON))
+	o.interpolator.Set(fmt.Sprintf("%s.row_count", step.Name), fmt.Sprintf("%d", table.RowCount))
+	o.interpolator.Set(fmt.Sprintf("%s.total_rows", step.Name), fmt.Sprintf("%d", table.TotalRows))
+
+	if table.RowCount < table.TotalRows {
+		o.logger.Info("✓ Table loaded: %d of %d rows, %d columns", table.RowCount, table.TotalRows, len(table.Columns))
+	} else {
+		o.logger.Info("✓ Table loaded: %d rows, %d columns", table.RowCount, len(table.Columns))
+	}
+
+	return nil
+}
@@ -30,6 +30,12 @@ func (l *Loader) LoadFromFile(path string) (*config.WorkflowV2, error) {
 
 // LoadFromBytes loads a workflow from YAML bytes
 func (l *Loader) LoadFromBytes(data []byte) (*config.WorkflowV2, error) {
+	// Upgrade older spec versions in memory before strict parsing
+	data, _, err := config.MigrateWorkflow(data)
+	if err != nil {
+		return nil, err
+	}
+
 	var workflow config.WorkflowV2
 
 	// Parse YAML with strict mode (errors on unknown fields)
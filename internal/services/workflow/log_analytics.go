@@ -0,0 +1,107 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/azure"
+)
+
+// executeLogAnalyticsStep uploads records to Azure Log Analytics via the Logs Ingestion API
+func (o *Orchestrator) executeLogAnalyticsStep(ctx context.Context, step *config.StepV2) error {
+	laMode := step.LogAnalytics
+	if laMode == nil {
+		return fmt.Errorf("log_analytics mode is nil")
+	}
+
+	// Get log analytics configuration from already-loaded app config
+	if o.appConfig == nil || o.appConfig.LogAnalytics == nil {
+		return fmt.Errorf("log analytics configuration not loaded (add a log_analytics: section to settings.yaml)")
+	}
+
+	dest, destName, ok := o.appConfig.LogAnalytics.GetDestination(laMode.Destination)
+	if !ok {
+		if destName == "" {
+			return fmt.Errorf("no destination specified and no default_destination in log_analytics config")
+		}
+		return fmt.Errorf("log analytics destination '%s' not found in config", destName)
+	}
+
+	records, err := o.buildLogAnalyticsRecords(laMode)
+	if err != nil {
+		return err
+	}
+
+	stream := laMode.Stream
+	if stream == "" {
+		stream = dest.StreamName
+	}
+
+	o.logger.Info("📤 Sending %d records to Log Analytics (%s → %s)", len(records), destName, stream)
+
+	client, err := azure.NewLogsIngestionClient(dest)
+	if err != nil {
+		return fmt.Errorf("failed to initialize log analytics destination '%s': %w", destName, err)
+	}
+
+	if err := client.Upload(ctx, stream, records); err != nil {
+		return fmt.Errorf("log analytics ingestion failed: %w", err)
+	}
+
+	output := fmt.Sprintf("%d", len(records))
+	o.setStepResult(step.Name, output)
+	o.interpolator.Set(fmt.Sprintf("%s.record_count", step.Name), output)
+	o.interpolator.Set(fmt.Sprintf("%s.stream", step.Name), stream)
+
+	o.logger.Info("✓ Log Analytics ingestion completed: %d records", len(records))
+
+	return nil
+}
+
+// buildLogAnalyticsRecords resolves the step's data or record into ingestion records
+func (o *Orchestrator) buildLogAnalyticsRecords(laMode *config.LogAnalyticsMode) ([]map[string]interface{}, error) {
+	if len(laMode.Record) > 0 {
+		record := make(map[string]interface{}, len(laMode.Record))
+		for key, value := range laMode.Record {
+			if str, ok := value.(string); ok {
+				interpolated, err := o.interpolator.Interpolate(str)
+				if err != nil {
+					return nil, fmt.Errorf("failed to interpolate record.%s: %w", key, err)
+				}
+				record[key] = interpolated
+			} else {
+				record[key] = value
+			}
+		}
+		return []map[string]interface{}{record}, nil
+	}
+
+	data, err := o.interpolator.Interpolate(laMode.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to interpolate data: %w", err)
+	}
+
+	// LLM output often wraps JSON in a markdown code fence
+	data = strings.TrimSpace(data)
+	data = strings.TrimPrefix(data, "```json")
+	data = strings.TrimPrefix(data, "```")
+	data = strings.TrimSuffix(data, "```")
+	data = strings.TrimSpace(data)
+
+	if strings.HasPrefix(data, "[") {
+		var records []map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &records); err != nil {
+			return nil, fmt.Errorf("data is not a JSON array of objects: %w", err)
+		}
+		return records, nil
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, fmt.Errorf("data is not a JSON object or array: %w", err)
+	}
+	return []map[string]interface{}{record}, nil
+}
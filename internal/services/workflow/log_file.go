@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Log file rotation defaults
+const (
+	defaultLogMaxSizeMB  = 10
+	defaultLogMaxBackups = 3
+)
+
+// rotatingFile is an append-only log file that is renamed to <path>.1 (and
+// older files shifted to .2, .3, ...) when a write would exceed its size limit
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSizeMB, maxBackups int) (*rotatingFile, error) {
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultLogMaxSizeMB
+	}
+	if maxBackups <= 0 {
+		maxBackups = defaultLogMaxBackups
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	r := &rotatingFile{path: path, maxBytes: int64(maxSizeMB) << 20, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+// WriteLine appends a line, rotating first if needed. Write errors are
+// dropped: logging must never fail a workflow.
+func (r *rotatingFile) WriteLine(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return
+	}
+
+	n := int64(len(line) + 1)
+	if r.size > 0 && r.size+n > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: log rotation failed for %s: %v\n", r.path, err)
+			if r.file == nil {
+				return
+			}
+		}
+	}
+	if _, err := r.file.WriteString(line + "\n"); err == nil {
+		r.size += n
+	}
+}
+
+func (r *rotatingFile) rotate() error {
+	r.file.Close()
+	r.file = nil
+
+	os.Remove(r.backupPath(r.maxBackups))
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		os.Rename(r.backupPath(i), r.backupPath(i+1))
+	}
+	if err := os.Rename(r.path, r.backupPath(1)); err != nil && !os.IsNotExist(err) {
+		// Keep appending to the current file rather than losing logs
+		if openErr := r.open(); openErr != nil {
+			return openErr
+		}
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
+
+// Close closes the file
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
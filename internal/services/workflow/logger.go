@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 )
 
 // LogLevel represents logging verbosity
@@ -18,10 +20,17 @@ const (
 	LogVerbose                 // + all internal operations (noisy)
 )
 
-// Logger handles workflow logging at different verbosity levels
+// Logger handles workflow logging at different verbosity levels. Messages go
+// to the console output and, when one is attached, to a log file with its
+// own level.
 type Logger struct {
 	level  LogLevel
 	output io.Writer
+	quiet  bool // Write nothing to output
+
+	file      *rotatingFile
+	fileLevel LogLevel
+	ownsFile  bool // Close the file with the logger (sub-loggers share their parent's)
 }
 
 // NewLogger creates a new logger with the specified level
@@ -29,26 +38,8 @@ type Logger struct {
 func NewLogger(levelStr string, cliVerbose bool) *Logger {
 	var level LogLevel
 
-	// Parse level string
 	if levelStr != "" {
-		switch levelStr {
-		case "error":
-			level = LogError
-		case "warn":
-			level = LogWarn
-		case "info":
-			level = LogInfo
-		case "step", "steps": // Accept both singular and plural
-			level = LogSteps
-		case "debug":
-			level = LogDebug
-		case "verbose":
-			level = LogVerbose
-		case "noisy": // Legacy alias for verbose
-			level = LogVerbose
-		default:
-			level = LogInfo // Default to info for unknown levels
-		}
+		level = parseLogLevel(levelStr)
 	} else if cliVerbose {
 		// CLI --verbose flag enables verbose logging
 		level = LogVerbose
@@ -63,52 +54,96 @@ func NewLogger(levelStr string, cliVerbose bool) *Logger {
 	}
 }
 
+// parseLogLevel converts a level name, defaulting to info for unknown names
+func parseLogLevel(levelStr string) LogLevel {
+	switch levelStr {
+	case "error":
+		return LogError
+	case "warn":
+		return LogWarn
+	case "info":
+		return LogInfo
+	case "step", "steps": // Accept both singular and plural
+		return LogSteps
+	case "debug":
+		return LogDebug
+	case "verbose":
+		return LogVerbose
+	case "noisy": // Legacy alias for verbose
+		return LogVerbose
+	default:
+		return LogInfo // Default to info for unknown levels
+	}
+}
+
+// SubLogger creates the logger for a nested workflow run. It writes to the
+// same console output and log file as l, at its own console level.
+func (l *Logger) SubLogger(levelStr string) *Logger {
+	sub := NewLogger(levelStr, false)
+	sub.output = l.output
+	sub.quiet = l.quiet
+	sub.file = l.file
+	sub.fileLevel = l.fileLevel
+	return sub
+}
+
+// write sends a message to the console and the log file, each filtered by its level
+func (l *Logger) write(level LogLevel, tag, format string, args ...interface{}) {
+	consoleOK := l.level >= level && !l.quiet
+	fileOK := l.file != nil && l.fileLevel >= level
+	if !consoleOK && !fileOK {
+		return
+	}
+
+	message := fmt.Sprintf(format, args...)
+	if consoleOK {
+		if tag != "" && tag != "STEP" {
+			fmt.Fprintf(l.output, "[%s] %s\n", tag, message)
+		} else {
+			fmt.Fprintln(l.output, message)
+		}
+	}
+	if fileOK {
+		// Console spacing isn't wanted in the file
+		message = strings.Trim(message, "\n")
+		l.file.WriteLine(fmt.Sprintf("%s [%s] %s", time.Now().Format("2006-01-02T15:04:05.000Z07:00"), tag, message))
+	}
+}
+
 // Error logs error messages (always visible except at level < error)
 func (l *Logger) Error(format string, args ...interface{}) {
-	if l.level >= LogError {
-		fmt.Fprintf(l.output, "[ERROR] "+format+"\n", args...)
-	}
+	l.write(LogError, "ERROR", format, args...)
 }
 
 // Warn logs warning messages (visible at warn level and above)
 func (l *Logger) Warn(format string, args ...interface{}) {
-	if l.level >= LogWarn {
-		fmt.Fprintf(l.output, "[WARN] "+format+"\n", args...)
-	}
+	l.write(LogWarn, "WARN", format, args...)
 }
 
 // Info logs informational messages (visible at info level and above)
 func (l *Logger) Info(format string, args ...interface{}) {
-	if l.level >= LogInfo {
-		fmt.Fprintf(l.output, "[INFO] "+format+"\n", args...)
-	}
+	l.write(LogInfo, "INFO", format, args...)
 }
 
 // Step logs step-level workflow events (visible at steps level and above)
 // This provides clean, semantic output focused on workflow steps
 func (l *Logger) Step(format string, args ...interface{}) {
-	if l.level >= LogSteps {
-		fmt.Fprintf(l.output, format+"\n", args...)
-	}
+	l.write(LogSteps, "STEP", format, args...)
 }
 
 // Debug logs debug messages (visible at debug level and above)
 func (l *Logger) Debug(format string, args ...interface{}) {
-	if l.level >= LogDebug {
-		fmt.Fprintf(l.output, "[DEBUG] "+format+"\n", args...)
-	}
+	l.write(LogDebug, "DEBUG", format, args...)
 }
 
 // Verbose logs verbose internal operations (visible at verbose level only)
 func (l *Logger) Verbose(format string, args ...interface{}) {
-	if l.level >= LogVerbose {
-		fmt.Fprintf(l.output, "[VERBOSE] "+format+"\n", args...)
-	}
+	l.write(LogVerbose, "VERBOSE", format, args...)
 }
 
-// Output logs Q&A output (always visible at all levels)
+// Output logs Q&A output (always visible at all levels, unless quiet)
 func (l *Logger) Output(format string, args ...interface{}) {
-	fmt.Fprintf(l.output, format+"\n", args...)
+	l.write(LogError, "OUTPUT", format, args...)
 }
 
 // SetOutput sets the output writer for the logger
@@ -120,3 +155,45 @@ func (l *Logger) SetOutput(w io.Writer) {
 func (l *Logger) GetOutput() io.Writer {
 	return l.output
 }
+
+// SetQuiet keeps all logger output off the console. An attached log file
+// still receives messages at its level.
+func (l *Logger) SetQuiet(quiet bool) {
+	l.quiet = quiet
+}
+
+// OpenFile attaches a log file with its own level, rotated when it would
+// grow past maxSizeMB (0 uses the default), keeping maxBackups old files.
+// The file is closed by Close.
+func (l *Logger) OpenFile(path, levelStr string, maxSizeMB, maxBackups int) error {
+	if levelStr == "" {
+		levelStr = "debug"
+	}
+	file, err := openRotatingFile(path, maxSizeMB, maxBackups)
+	if err != nil {
+		return err
+	}
+	l.Close()
+	l.file = file
+	l.fileLevel = parseLogLevel(levelStr)
+	l.ownsFile = true
+	return nil
+}
+
+// LogFilePath returns the path of the attached log file, or ""
+func (l *Logger) LogFilePath() string {
+	if l.file == nil {
+		return ""
+	}
+	return l.file.path
+}
+
+// Close closes the log file opened by this logger
+func (l *Logger) Close() error {
+	if l.file == nil || !l.ownsFile {
+		return nil
+	}
+	// The file stays attached: late writes from other goroutines are dropped
+	l.ownsFile = false
+	return l.file.Close()
+}
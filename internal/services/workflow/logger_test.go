@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoggerFileLevelIsIndependent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "run.log")
+
+	var console bytes.Buffer
+	logger := NewLogger("error", false)
+	logger.SetOutput(&console)
+	if err := logger.OpenFile(path, "debug", 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("starting")
+	logger.Step("\n[STEP 1/1] fetch")
+	logger.Verbose("internal detail")
+	logger.Error("failed: %d", 42)
+
+	sub := logger.SubLogger("")
+	sub.Debug("from sub-workflow")
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+	logger.Warn("after sub close")
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if console.String() != "[ERROR] failed: 42\n" {
+		t.Errorf("console = %q", console.String())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	for _, want := range []string{"[INFO] starting", "[STEP] [STEP 1/1] fetch", "[ERROR] failed: 42", "[DEBUG] from sub-workflow", "[WARN] after sub close"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log file missing %q:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "internal detail") {
+		t.Errorf("verbose message written at debug level:\n%s", log)
+	}
+}
+
+func TestLoggerQuiet(t *testing.T) {
+	var console bytes.Buffer
+	logger := NewLogger("verbose", false)
+	logger.SetOutput(&console)
+	logger.SetQuiet(true)
+
+	logger.Error("error")
+	logger.Output("result")
+	logger.SubLogger("debug").Info("nested")
+
+	if console.Len() != 0 {
+		t.Errorf("quiet logger wrote %q", console.String())
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	r, err := openRotatingFile(path, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.maxBytes = 20
+
+	for _, line := range []string{"first line", "second line", "third line", "fourth line"} {
+		r.WriteLine(line)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{
+		path:        "fourth line\n",
+		path + ".1": "third line\n",
+		path + ".2": "second line\n",
+	} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(name), data, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only 2 backups, stat .3: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+const defaultCombinePrompt = "Combine these results into one answer. Keep every distinct finding, merge duplicates and resolve contradictions where the results allow it."
+
+// combineLoopOutputs sets an iterate loop's final output from its item
+// outputs, as the loop's combine setting asks. The default keeps the last
+// successful output.
+func (le *LoopExecutor) combineLoopOutputs(ctx context.Context, loop *config.LoopV2, result *config.LoopExecutionResult) error {
+	if len(result.AllOutputs) == 0 {
+		return nil
+	}
+
+	switch loop.Combine {
+	case config.LoopCombineVote:
+		winner, agree := voteOutputs(result.AllOutputs)
+		le.logger.Info("Loop %s: vote picked output %d of %d (%d identical)", loop.Name, winner+1, len(result.AllOutputs), agree)
+		result.FinalOutput = result.AllOutputs[winner]
+
+	case config.LoopCombineSummarize:
+		judge, err := le.executor.resolveJudge(config.JudgeRoleCombine, loop.CombineModel)
+		if err != nil {
+			return err
+		}
+		le.logger.Info("Loop %s: summarizing %d outputs with %s", loop.Name, len(result.AllOutputs), judgeLabel(judge))
+		summary, err := le.executor.runJudge(ctx, judge, combinePrompt(loop.CombinePrompt, result.AllOutputs))
+		if err != nil {
+			return fmt.Errorf("loop %s: combine failed: %w", loop.Name, err)
+		}
+		result.FinalOutput = strings.TrimSpace(summary)
+	}
+	return nil
+}
+
+// combinePrompt builds the reduction request for combine: summarize
+func combinePrompt(instructions string, outputs []string) string {
+	if instructions == "" {
+		instructions = defaultCombinePrompt
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n\nThere are %d results.\n", instructions, len(outputs))
+	for i, output := range outputs {
+		fmt.Fprintf(&sb, "\n## Result %d\n%s\n", i+1, strings.TrimSpace(output))
+	}
+	return sb.String()
+}
+
+// voteOutputs picks the output that agrees most with the others: each
+// identical output (ignoring case and spacing) counts as a full vote, and
+// other outputs count by how many words they share. Ties go to the earliest
+// output. It returns the winner's index and how many outputs are identical
+// to it, itself included.
+func voteOutputs(outputs []string) (winner, agree int) {
+	normalized := make([]string, len(outputs))
+	words := make([]map[string]bool, len(outputs))
+	for i, output := range outputs {
+		fields := strings.Fields(strings.ToLower(output))
+		normalized[i] = strings.Join(fields, " ")
+		words[i] = make(map[string]bool, len(fields))
+		for _, field := range fields {
+			words[i][field] = true
+		}
+	}
+
+	best := -1.0
+	for i := range outputs {
+		score, same := 0.0, 1
+		for j := range outputs {
+			if i == j {
+				continue
+			}
+			if normalized[i] == normalized[j] {
+				score++
+				same++
+			} else {
+				score += wordOverlap(words[i], words[j])
+			}
+		}
+		if score > best {
+			best, winner, agree = score, i, same
+		}
+	}
+	return winner, agree
+}
+
+// wordOverlap is the Jaccard similarity of two word sets
+func wordOverlap(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,62 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoteOutputs(t *testing.T) {
+	// The most common answer wins, ignoring case and spacing
+	winner, agree := voteOutputs([]string{"Paris", "Lyon", "paris ", "PARIS"})
+	assert.Equal(t, 0, winner)
+	assert.Equal(t, 3, agree)
+
+	// With no repeats, the output sharing most words with the others wins
+	winner, agree = voteOutputs([]string{
+		"the server crashed on start",
+		"the server crashed on start after the upgrade",
+		"disk full",
+	})
+	assert.Equal(t, 0, winner)
+	assert.Equal(t, 1, agree)
+
+	// Ties go to the earliest output
+	winner, _ = voteOutputs([]string{"a", "b"})
+	assert.Equal(t, 0, winner)
+}
+
+func TestCombinePrompt(t *testing.T) {
+	prompt := combinePrompt("", []string{"first\n", "second"})
+	assert.True(t, strings.HasPrefix(prompt, defaultCombinePrompt))
+	assert.Contains(t, prompt, "There are 2 results.")
+	assert.Contains(t, prompt, "## Result 1\nfirst\n\n## Result 2\nsecond\n")
+
+	assert.True(t, strings.HasPrefix(combinePrompt("List the bugs.", []string{"x"}), "List the bugs.\n"))
+}
+
+func TestLoopCombineValidation(t *testing.T) {
+	loop := &config.LoopV2{Workflow: "item", Mode: "iterate", Items: "{{input}}", Combine: "vote"}
+	assert.NoError(t, loop.Validate())
+
+	loop.Combine = "average"
+	assert.ErrorContains(t, loop.Validate(), "combine must be")
+
+	refine := &config.LoopV2{Workflow: "draft", Until: "output.score >= 8", Combine: "summarize"}
+	assert.ErrorContains(t, refine.Validate(), "requires iterate mode")
+}
+
+func TestCombineLoopOutputsVote(t *testing.T) {
+	le := &LoopExecutor{logger: NewLogger("error", false)}
+	result := &config.LoopExecutionResult{AllOutputs: []string{"no", "yes", "Yes"}, FinalOutput: "Yes"}
+
+	// The default keeps the last output
+	assert.NoError(t, le.combineLoopOutputs(context.Background(), &config.LoopV2{Name: "poll"}, result))
+	assert.Equal(t, "Yes", result.FinalOutput)
+
+	assert.NoError(t, le.combineLoopOutputs(context.Background(), &config.LoopV2{Name: "poll", Combine: config.LoopCombineVote}, result))
+	assert.Equal(t, "yes", result.FinalOutput)
+}
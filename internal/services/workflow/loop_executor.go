@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/expr"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
@@ -49,7 +51,7 @@ type LoopResult struct {
 	Iterations  int
 	FinalOutput string
 	AllOutputs  []string
-	ExitReason  string // "condition_met", "max_iterations", "failure"
+	ExitReason  string // "condition_met", "verified", "max_iterations", "failure"
 }
 
 // ExecuteLoop executes a loop until condition is met or max iterations reached
@@ -92,6 +94,16 @@ func (le *LoopExecutor) ExecuteLoop(ctx context.Context, loop *config.LoopV2) (*
 		return le.ExecuteLoopParallel(ctx, loop, workflow)
 	}
 
+	// Parse the exit condition once, before any iteration runs
+	var until *expr.Expr
+	if loop.Until != "" {
+		parsed, err := expr.Parse(loop.Until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until expression %q: %w", loop.Until, err)
+		}
+		until = parsed
+	}
+
 	// Sequential execution (existing refine mode logic)
 	result := &LoopResult{
 		AllOutputs: make([]string, 0),
@@ -99,6 +111,11 @@ func (le *LoopExecutor) ExecuteLoop(ctx context.Context, loop *config.LoopV2) (*
 
 	var lastOutput string
 
+	// Verify steps in the child workflow report into {{loop.verify}} for the
+	// next iteration
+	le.interpolator.Set("loop.verify", "")
+	le.interpolator.Set("loop.verified", "false")
+
 	for iteration := 1; iteration <= loop.MaxIterations; iteration++ {
 		le.logger.Info("Loop iteration %d/%d", iteration, loop.MaxIterations)
 
@@ -116,7 +133,8 @@ func (le *LoopExecutor) ExecuteLoop(ctx context.Context, loop *config.LoopV2) (*
 		}
 
 		// Execute the workflow
-		output, err := le.executeWorkflow(ctx, workflow, inputData)
+		output, verifyResults, err := le.executeWorkflowWithVerify(ctx, workflow, inputData)
+		verified := le.setVerifyFeedback(iteration, verifyResults)
 		if err != nil {
 			if loop.OnFailure == "halt" {
 				result.ExitReason = "failure"
@@ -137,19 +155,28 @@ func (le *LoopExecutor) ExecuteLoop(ctx context.Context, loop *config.LoopV2) (*
 
 		le.logger.Debug("Iteration %d output: %s", iteration, truncate(output, 100))
 
-		// Evaluate exit condition
-		if loop.Until != "" {
-			conditionMet, err := le.evaluateCondition(ctx, loop.Until, output)
-			if err != nil {
-				le.logger.Warn("Failed to evaluate condition: %v", err)
-			} else if conditionMet {
-				le.logger.Info("Loop exit condition met after %d iterations", iteration)
-				result.ExitReason = "condition_met"
-
-				// Store final result
-				le.storeLoopResult(loop, result)
-				return result, nil
-			}
+		// Passing build/test checks end the compile-fix cycle
+		if verified {
+			le.logger.Info("Loop verified after %d iterations", iteration)
+			result.ExitReason = "verified"
+			le.storeLoopResult(loop, result)
+			return result, nil
+		}
+
+		// Evaluate exit condition. A condition that cannot be evaluated would
+		// leave the loop running blind, so it ends the loop.
+		conditionMet, err := le.checkExitCondition(ctx, loop, until, iteration, output)
+		if err != nil {
+			result.ExitReason = "failure"
+			return result, fmt.Errorf("iteration %d: %w", iteration, err)
+		}
+		if conditionMet {
+			le.logger.Info("Loop exit condition met after %d iterations", iteration)
+			result.ExitReason = "condition_met"
+
+			// Store final result
+			le.storeLoopResult(loop, result)
+			return result, nil
 		}
 	}
 
@@ -200,22 +227,50 @@ func (le *LoopExecutor) prepareLoopInput(loop *config.LoopV2, iteration int, las
 
 // executeWorkflow executes a workflow and returns its final output
 func (le *LoopExecutor) executeWorkflow(ctx context.Context, workflow *config.WorkflowV2, inputData string) (string, error) {
+	output, _, err := le.executeWorkflowWithVerify(ctx, workflow, inputData)
+	return output, err
+}
+
+// setVerifyFeedback exposes an iteration's verify results as {{loop.verify}}
+// and {{loop.verified}}; it reports whether every verify step passed
+func (le *LoopExecutor) setVerifyFeedback(iteration int, results []*VerifyResult) bool {
+	if len(results) == 0 {
+		return false
+	}
+	feedback, passed := VerifyFeedback(results)
+	le.interpolator.Set("loop.verify", feedback)
+	le.interpolator.Set("loop.verified", fmt.Sprintf("%t", passed))
+	if !passed {
+		le.logger.Info("Iteration %d verification failed; feeding errors into the next iteration", iteration)
+	}
+	return passed
+}
+
+// executeWorkflowWithVerify executes a workflow and returns its final output
+// along with the results of any verify steps it ran
+func (le *LoopExecutor) executeWorkflowWithVerify(ctx context.Context, workflow *config.WorkflowV2, inputData string) (string, []*VerifyResult, error) {
 	fmt.Fprintf(os.Stderr, "[DEBUG_PRINT] executeWorkflow called for: %s\n", workflow.Name)
 	logging.Debug("[LOOP_EXEC] executeWorkflow called for workflow: %s", workflow.Name)
 	// Create sub-orchestrator
-	subLogger := NewLogger(workflow.Execution.Logging, false)
 	// CRITICAL: Inherit output from parent logger (stdout in CLI, stderr in MCP serve mode)
-	subLogger.SetOutput(le.logger.GetOutput())
+	subLogger := le.logger.SubLogger(workflow.ConsoleLogLevel())
 	subOrchestrator := NewOrchestrator(workflow, subLogger)
+	defer subOrchestrator.Close()
 
 	// Pass through dependencies
 	subOrchestrator.executor.SetAppConfig(le.appConfig)
+	if le.executor != nil && le.executor.blackboard != nil {
+		subOrchestrator.SetBlackboard(le.executor.blackboard)
+	}
+	if le.executor != nil {
+		subOrchestrator.SetStreamOutput(le.executor.stream)
+	}
 
 	// CRITICAL: Initialize subordinate workflow's server manager
 	// This follows the exact same path as standalone workflow execution
 	subordinateServerManager, err := InitializeWorkflowServerManager(workflow, le.appConfig, "config.yaml")
 	if err != nil {
-		return "", fmt.Errorf("failed to initialize subordinate workflow: %w", err)
+		return "", nil, fmt.Errorf("failed to initialize subordinate workflow: %w", err)
 	}
 
 	if subordinateServerManager != nil {
@@ -234,7 +289,7 @@ func (le *LoopExecutor) executeWorkflow(ctx context.Context, workflow *config.Wo
 
 	// Load configuration so embedding service can access provider configs
 	if _, loadErr := configService.LoadConfig("config.yaml"); loadErr != nil {
-		return "", fmt.Errorf("failed to load config for child workflow: %w", loadErr)
+		return "", nil, fmt.Errorf("failed to load config for child workflow: %w", loadErr)
 	}
 
 	providerFactory := ai.NewProviderFactory()
@@ -246,27 +301,64 @@ func (le *LoopExecutor) executeWorkflow(ctx context.Context, workflow *config.Wo
 
 	// Execute
 	err = subOrchestrator.Execute(ctx, inputData)
+	verifyResults := subOrchestrator.VerifyResults()
 	if err != nil {
-		return "", err
+		return "", verifyResults, err
 	}
 
 	// Get final result
 	if len(workflow.Steps) > 0 {
 		lastStepName := workflow.Steps[len(workflow.Steps)-1].Name
 		if output, ok := subOrchestrator.GetStepResult(lastStepName); ok {
-			return output, nil
+			return output, verifyResults, nil
 		}
 	}
 
-	return "", fmt.Errorf("no output from workflow")
+	return "", verifyResults, fmt.Errorf("no output from workflow")
 }
 
-// evaluateCondition uses LLM to evaluate exit condition
-func (le *LoopExecutor) evaluateCondition(ctx context.Context, condition string, output string) (bool, error) {
+// checkExitCondition evaluates the loop's until expression or, for
+// until_llm, asks the judge model
+func (le *LoopExecutor) checkExitCondition(ctx context.Context, loop *config.LoopV2, until *expr.Expr, iteration int, output string) (bool, error) {
+	if until != nil {
+		met, err := until.EvalBool(le.conditionLookup(iteration, output))
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate until %q: %w", loop.Until, err)
+		}
+		le.logger.Debug("Condition evaluation: '%s' -> %t", loop.Until, met)
+		return met, nil
+	}
+	if loop.UntilLLM != "" {
+		return le.evaluateLLMCondition(ctx, loop, output)
+	}
+	return false, nil
+}
+
+// conditionLookup resolves until variables: output (this iteration's
+// output), iteration, and any workflow variable such as loop.verified or
+// a step result
+func (le *LoopExecutor) conditionLookup(iteration int, output string) expr.Lookup {
+	return func(name string) (string, bool) {
+		switch name {
+		case "output":
+			return output, true
+		case "iteration":
+			return strconv.Itoa(iteration), true
+		}
+		if strings.HasPrefix(name, "steps.") {
+			name = "step." + strings.TrimPrefix(name, "steps.")
+		}
+		return le.interpolator.GetVariable(name)
+	}
+}
+
+// evaluateLLMCondition asks the conditions judge whether the until_llm
+// condition holds for the output
+func (le *LoopExecutor) evaluateLLMCondition(ctx context.Context, loop *config.LoopV2, output string) (bool, error) {
 	// Interpolate condition
-	interpolatedCondition, err := le.interpolator.Interpolate(condition)
+	interpolatedCondition, err := le.interpolator.Interpolate(loop.UntilLLM)
 	if err != nil {
-		return false, fmt.Errorf("failed to interpolate condition: %w", err)
+		return false, fmt.Errorf("failed to interpolate until_llm: %w", err)
 	}
 
 	// Build evaluation prompt
@@ -279,37 +371,33 @@ func (le *LoopExecutor) evaluateCondition(ctx context.Context, condition string,
 		truncate(output, 2000),
 	)
 
-	// Use executor's provider creation for evaluation
-	// Get default provider from app config
-	providerName := "deepseek" // Fallback
-	if le.appConfig.AI != nil && le.appConfig.AI.DefaultProvider != "" {
-		providerName = le.appConfig.AI.DefaultProvider
-	}
-
-	// Create provider
-	provider, err := le.executor.createProvider(providerName, "")
+	judge, err := le.executor.resolveJudge(config.JudgeRoleConditions, loop.Judge)
 	if err != nil {
-		return false, fmt.Errorf("failed to create provider for condition evaluation: %w", err)
+		return false, err
 	}
 
-	// Execute
-	request := &domain.CompletionRequest{
-		Messages: []domain.Message{
-			{Role: "user", Content: prompt},
-		},
-		Temperature: 0,
-	}
-
-	response, err := provider.CreateCompletion(ctx, request)
+	response, err := le.executor.runJudge(ctx, judge, prompt)
 	if err != nil {
-		return false, fmt.Errorf("failed to evaluate condition: %w", err)
+		return false, err
 	}
 
-	// Check response
-	answer := strings.ToUpper(strings.TrimSpace(response.Response))
-	le.logger.Info("Condition evaluation: '%s' -> %s", condition, answer)
+	answer := strings.ToUpper(strings.TrimSpace(response))
+	le.logger.Info("Condition evaluation: '%s' -> %s", loop.UntilLLM, truncate(answer, 50))
+
+	return parseJudgeAnswer(answer)
+}
 
-	return strings.Contains(answer, "YES"), nil
+// parseJudgeAnswer reads a YES/NO verdict, tolerating punctuation and
+// markdown emphasis around it
+func parseJudgeAnswer(answer string) (bool, error) {
+	word := strings.TrimLeft(strings.ToUpper(strings.TrimSpace(answer)), "*_`\"' ")
+	switch {
+	case strings.HasPrefix(word, "YES"):
+		return true, nil
+	case strings.HasPrefix(word, "NO"):
+		return false, nil
+	}
+	return false, fmt.Errorf("judge answered neither YES nor NO: %q", truncate(answer, 100))
 }
 
 // storeLoopResult stores loop result for later access
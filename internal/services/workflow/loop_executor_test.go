@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/expr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckExitConditionExpression(t *testing.T) {
+	interpolator := NewInterpolator()
+	interpolator.Set("loop.verified", "false")
+	interpolator.SetStepResult("review", `{"approved": true}`)
+	logger := NewLogger("error", false)
+	executor := NewExecutor(&config.WorkflowV2{}, logger)
+	executor.SetAppConfig(&config.ApplicationConfig{})
+	le := NewLoopExecutor(&config.ApplicationConfig{}, logger, interpolator, executor, nil, nil)
+
+	tests := []struct {
+		until  string
+		output string
+		want   bool
+	}{
+		{`output.score >= 8`, `{"score": 9}`, true},
+		{`output.score >= 8`, `{"score": 6}`, false},
+		{`iteration >= 2`, "", true},
+		{`loop.verified`, "", false},
+		{`steps.review.approved && contains(output, "DONE")`, "All DONE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.until, func(t *testing.T) {
+			loop := &config.LoopV2{Until: tt.until}
+			until, err := expr.Parse(tt.until)
+			assert.NoError(t, err)
+
+			met, err := le.checkExitCondition(context.Background(), loop, until, 2, tt.output)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, met)
+		})
+	}
+
+	// Errors end the loop instead of being ignored
+	loop := &config.LoopV2{Until: `missing == 1`}
+	until, _ := expr.Parse(loop.Until)
+	_, err := le.checkExitCondition(context.Background(), loop, until, 1, "")
+	assert.EqualError(t, err, "failed to evaluate until \"missing == 1\": undefined variable 'missing'")
+
+	// until_llm needs a judge provider; there is no hard-coded fallback
+	_, err = le.checkExitCondition(context.Background(), &config.LoopV2{UntilLLM: "The output says PASS"}, nil, 1, "PASS")
+	assert.EqualError(t, err, "no conditions judge configured: set judges.conditions or judges.default (workflow or settings.yaml), or ai.default_provider")
+}
+
+func TestParseJudgeAnswer(t *testing.T) {
+	for answer, want := range map[string]bool{"YES": true, "**Yes**, it does": true, "No.": false, " no": false} {
+		got, err := parseJudgeAnswer(answer)
+		assert.NoError(t, err, answer)
+		assert.Equal(t, want, got, answer)
+	}
+
+	_, err := parseJudgeAnswer("It depends")
+	assert.Error(t, err)
+}
@@ -4,12 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/outputcrypt"
 )
 
 // ExecuteIterateLoop executes a loop in iterate mode over an array of items
@@ -32,10 +31,17 @@ func (le *LoopExecutor) ExecuteIterateLoop(ctx context.Context, loop *config.Loo
 		filePath := strings.TrimPrefix(itemsSource, "file://")
 
 		// Resolve /outputs/ to actual outputs directory
-		filePath = le.resolveOutputsPath(filePath)
+		filePath, err = le.resolveOutputsPath(filePath)
+		if err != nil {
+			return nil, err
+		}
 
 		le.logger.Info("Loading items from file: %s", filePath)
-		fileContent, err := os.ReadFile(filePath)
+		var key []byte
+		if le.appConfig != nil {
+			key, _ = outputcrypt.KeyFrom(le.appConfig.Skills)
+		}
+		fileContent, err := outputcrypt.ReadFile(key, filePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read items file '%s': %w", filePath, err)
 		}
@@ -89,7 +95,11 @@ func (le *LoopExecutor) ExecuteIterateLoop(ctx context.Context, loop *config.Loo
 
 	// Check if parallel execution is enabled
 	if loop.Parallel {
-		return le.executeIterateLoopParallel(ctx, loop, workflow, items, result, startTime)
+		result, err := le.executeIterateLoopParallel(ctx, loop, workflow, items, result, startTime)
+		if err != nil {
+			return result, err
+		}
+		return result, le.combineLoopOutputs(ctx, loop, result)
 	}
 
 	// Sequential execution (existing logic)
@@ -142,6 +152,10 @@ func (le *LoopExecutor) ExecuteIterateLoop(ctx context.Context, loop *config.Loo
 		float64(result.Succeeded)/float64(result.TotalItems)*100,
 		result.Failed, result.Duration)
 
+	if err := le.combineLoopOutputs(ctx, loop, result); err != nil {
+		return result, err
+	}
+
 	// Store result for later access
 	le.storeIterateLoopResult(loop, result)
 
@@ -531,12 +545,7 @@ func (le *LoopExecutor) executeIterateLoopParallel(
 }
 
 // resolveOutputsPath resolves /outputs/ to the actual outputs directory path
-func (le *LoopExecutor) resolveOutputsPath(filePath string) string {
-	// Check if path starts with /outputs/
-	if !strings.HasPrefix(filePath, "/outputs/") {
-		return filePath
-	}
-
+func (le *LoopExecutor) resolveOutputsPath(filePath string) (string, error) {
 	// Get actual outputs directory from config
 	outputsDir := "/tmp/mcp-outputs" // default fallback
 	if le.appConfig != nil && le.appConfig.Skills != nil {
@@ -545,11 +554,12 @@ func (le *LoopExecutor) resolveOutputsPath(filePath string) string {
 		}
 	}
 
-	// Replace /outputs/ with actual directory
-	relativePath := strings.TrimPrefix(filePath, "/outputs/")
-	resolvedPath := filepath.Join(outputsDir, relativePath)
-
-	le.logger.Debug("Resolved path: %s -> %s", filePath, resolvedPath)
-
-	return resolvedPath
+	resolvedPath, err := joinOutputsPath(outputsDir, filePath)
+	if err != nil {
+		return "", err
+	}
+	if resolvedPath != filePath {
+		le.logger.Debug("Resolved path: %s -> %s", filePath, resolvedPath)
+	}
+	return resolvedPath, nil
 }
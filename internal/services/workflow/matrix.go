@@ -0,0 +1,209 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// MatrixRun is the outcome of one matrix combination
+type MatrixRun struct {
+	Params     map[string]string `json:"params"`
+	Output     string            `json:"output,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	DurationMs int64             `json:"duration_ms"`
+}
+
+// MatrixResult aggregates the runs of a matrix workflow, in expansion order
+type MatrixResult struct {
+	Workflow  string      `json:"workflow"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	Runs      []MatrixRun `json:"runs"`
+}
+
+// executeMatrix runs the workflow once per matrix combination in parallel
+// sub-runs and aggregates their final outputs. The run fails when every
+// combination failed, or on any failure with fail_fast.
+func (o *Orchestrator) executeMatrix(ctx context.Context, input string) error {
+	matrix := o.workflow.Matrix
+	combinations, err := matrix.Combinations()
+	if err != nil {
+		return fmt.Errorf("matrix: %w", err)
+	}
+
+	maxWorkers := matrix.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = 3
+	}
+	o.logger.Info("Matrix: %d runs (max_workers: %d)", len(combinations), maxWorkers)
+
+	matrixCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	runs := make([]MatrixRun, len(combinations))
+	sem := make(chan struct{}, maxWorkers)
+	var wg sync.WaitGroup
+	for i, combination := range combinations {
+		wg.Add(1)
+		go func(i int, combination map[string]string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			run := MatrixRun{Params: combination}
+			label := config.MatrixLabel(combination)
+			if matrixCtx.Err() != nil {
+				run.Error = "skipped: matrix canceled"
+				runs[i] = run
+				return
+			}
+
+			start := time.Now()
+			output, err := o.runMatrixCombination(matrixCtx, input, combination)
+			run.DurationMs = time.Since(start).Milliseconds()
+			if err != nil {
+				o.logger.Warn("Matrix: [%s] failed - %v", label, err)
+				run.Error = err.Error()
+				if matrix.FailFast {
+					cancel()
+				}
+			} else {
+				o.logger.Info("Matrix: [%s] completed (%.2fs)", label, float64(run.DurationMs)/1000)
+				run.Output = output
+			}
+			runs[i] = run
+		}(i, combination)
+	}
+	wg.Wait()
+
+	result := &MatrixResult{Workflow: o.workflow.Name, Runs: runs}
+	for _, run := range runs {
+		if run.Error == "" {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	o.matrixResult = result
+	o.writeMatrixArtifact(result)
+
+	o.logger.Output("Matrix %s: %d/%d runs succeeded", o.workflow.Name, result.Succeeded, len(runs))
+	if result.Succeeded == 0 {
+		return fmt.Errorf("matrix: all %d runs failed", len(runs))
+	}
+	if matrix.FailFast && result.Failed > 0 {
+		return fmt.Errorf("matrix: %d/%d runs failed", result.Failed, len(runs))
+	}
+	return nil
+}
+
+// runMatrixCombination executes the workflow without its matrix, with the
+// combination layered over the caller's params and applied to the
+// execution defaults, and returns its final output
+func (o *Orchestrator) runMatrixCombination(ctx context.Context, input string, combination map[string]string) (string, error) {
+	params := make(map[string]string, len(o.params)+len(combination))
+	for k, v := range o.params {
+		params[k] = v
+	}
+	for k, v := range combination {
+		params[k] = v
+	}
+
+	// Steps and loops are copied so concurrent runs never share defaults set during validation
+	wf := *o.workflow
+	wf.Matrix = nil
+	wf.Execution.RecordRun = false // Sub-runs would share the matrix run's directory
+	wf.Steps = append([]config.StepV2(nil), o.workflow.Steps...)
+	wf.Loops = append([]config.LoopV2(nil), o.workflow.Loops...)
+	if err := config.ApplyExecutionParams(&wf.Execution, params); err != nil {
+		return "", err
+	}
+
+	if wf.Logging != nil {
+		// Sub-runs write to the matrix run's log file
+		logging := *wf.Logging
+		logging.File = nil
+		wf.Logging = &logging
+	}
+
+	// CRITICAL: Inherit output from parent logger (stdout in CLI, stderr in MCP serve mode)
+	subLogger := o.logger.SubLogger(wf.ConsoleLogLevel())
+	sub := NewOrchestratorWithKey(&wf, o.workflowKey, subLogger)
+	defer sub.Close()
+
+	sub.executor.SetAppConfig(o.executor.appConfig)
+	sub.SetStreamOutput(o.executor.stream)
+	if o.executor.serverManager != nil {
+		sub.executor.SetServerManager(o.executor.serverManager)
+	}
+	sub.SetAppConfigForWorkflows(o.appConfig)
+	sub.SetEmbeddingService(o.embeddingService)
+	sub.SetStartFrom(o.startFrom)
+	sub.SetEndAt(o.endAt)
+	sub.SetParams(params)
+	sub.SetContextBundle(o.contextBundle)
+
+	if err := sub.Execute(ctx, input); err != nil {
+		return "", err
+	}
+	output, _ := sub.FinalOutput()
+	return output, nil
+}
+
+// writeMatrixArtifact saves the aggregated results as matrix.json in the
+// run artifacts directory. Failures are logged, not returned.
+func (o *Orchestrator) writeMatrixArtifact(result *MatrixResult) {
+	dir, err := o.runArtifactsDir()
+	if err != nil {
+		o.logger.Warn("Matrix results not saved: %v", err)
+		return
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		o.logger.Warn("Failed to encode matrix results: %v", err)
+		return
+	}
+	path := filepath.Join(dir, "matrix.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		o.logger.Warn("Failed to write matrix results: %v", err)
+		return
+	}
+	o.logger.Info("Matrix results saved to %s", path)
+}
+
+// MatrixResult returns the aggregated results of a matrix run, or nil
+func (o *Orchestrator) MatrixResult() *MatrixResult {
+	return o.matrixResult
+}
+
+// formatMatrixReport renders the matrix results as a markdown summary table
+// followed by each run's output
+func formatMatrixReport(result *MatrixResult) string {
+	var sb strings.Builder
+	sb.WriteString("| # | Params | Duration | Status |\n")
+	sb.WriteString("| - | ------ | -------- | ------ |\n")
+	for i, run := range result.Runs {
+		status := "ok"
+		if run.Error != "" {
+			status = "failed: " + strings.ReplaceAll(truncateString(run.Error, 80), "|", "/")
+		}
+		fmt.Fprintf(&sb, "| %d | %s | %.2fs | %s |\n",
+			i+1, strings.ReplaceAll(config.MatrixLabel(run.Params), "|", "/"), float64(run.DurationMs)/1000, status)
+	}
+
+	for i, run := range result.Runs {
+		if run.Error != "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %d. %s\n\n%s\n", i+1, config.MatrixLabel(run.Params), strings.TrimSpace(run.Output))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMatrixTestWorkflow(t *testing.T) *config.WorkflowV2 {
+	t.Helper()
+	return &config.WorkflowV2{
+		Name:    "matrix_test",
+		Version: "1.0.0",
+		Execution: config.ExecutionContext{
+			ArtifactsDir: t.TempDir(),
+		},
+		Params: map[string]config.PromptParameter{
+			"suffix": {Default: "!"},
+		},
+		Matrix: &config.MatrixConfig{
+			Params: map[string][]interface{}{"word": {"alpha", "boom", "beta"}},
+		},
+		Steps: []config.StepV2{{
+			Name: "shout",
+			Verify: &config.VerifyMode{
+				Command:     `test "{{params.word}}" != boom && echo "{{params.word}}{{params.suffix}}"`,
+				Dir:         t.TempDir(),
+				FailOnError: true,
+			},
+		}},
+	}
+}
+
+func TestExecuteMatrix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	o := NewOrchestrator(newMatrixTestWorkflow(t), NewLogger("error", false))
+	assert.NoError(t, o.Execute(context.Background(), ""))
+
+	result := o.MatrixResult()
+	if assert.NotNil(t, result) {
+		assert.Equal(t, 2, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, "boom", result.Runs[1].Params["word"])
+		assert.NotEmpty(t, result.Runs[1].Error)
+	}
+
+	report, ok := o.FinalOutput()
+	assert.True(t, ok)
+	assert.Contains(t, report, "| 1 | word=alpha |")
+	assert.Contains(t, report, "| 2 | word=boom |")
+	assert.Contains(t, report, "## 3. word=beta")
+
+	data, err := os.ReadFile(filepath.Join(o.runDir, "matrix.json"))
+	assert.NoError(t, err)
+	var saved MatrixResult
+	assert.NoError(t, json.Unmarshal(data, &saved))
+	assert.Len(t, saved.Runs, 3)
+}
+
+func TestExecuteMatrix_FailFast(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	wf := newMatrixTestWorkflow(t)
+	wf.Matrix.FailFast = true
+	wf.Matrix.MaxWorkers = 1
+
+	o := NewOrchestrator(wf, NewLogger("error", false))
+	assert.Error(t, o.Execute(context.Background(), ""))
+	assert.GreaterOrEqual(t, o.MatrixResult().Failed, 1)
+}
+
+func TestExecute_ResolvesParams(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	wf := newMatrixTestWorkflow(t)
+	wf.Matrix = nil
+	wf.Params["word"] = config.PromptParameter{Required: true}
+
+	o := NewOrchestrator(wf, NewLogger("error", false))
+	assert.Error(t, o.Execute(context.Background(), ""), "required param without a value")
+
+	o = NewOrchestrator(wf, NewLogger("error", false))
+	o.SetParams(map[string]string{"word": "gamma"})
+	assert.NoError(t, o.Execute(context.Background(), ""))
+	suffix, _ := o.interpolator.GetVariable("params.suffix")
+	assert.Equal(t, "!", suffix)
+}
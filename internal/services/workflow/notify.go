@@ -0,0 +1,67 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/notify"
+)
+
+// executeNotifyStep sends a notification through a configured channel
+func (o *Orchestrator) executeNotifyStep(ctx context.Context, step *config.StepV2) error {
+	notifyMode := step.Notify
+	if notifyMode == nil {
+		return fmt.Errorf("notify mode is nil")
+	}
+
+	// Get notification configuration from already-loaded app config
+	if o.appConfig == nil || o.appConfig.Notifications == nil {
+		return fmt.Errorf("notifications configuration not loaded (add a notifications: section to settings.yaml)")
+	}
+
+	channel, channelName, ok := o.appConfig.Notifications.GetChannel(notifyMode.Channel)
+	if !ok {
+		if channelName == "" {
+			return fmt.Errorf("no notification channel specified and no default_channel in notifications config")
+		}
+		return fmt.Errorf("notification channel '%s' not found in config", channelName)
+	}
+
+	subject, err := o.interpolator.Interpolate(notifyMode.Subject)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate subject: %w", err)
+	}
+
+	body, err := o.interpolator.Interpolate(notifyMode.Body)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate body: %w", err)
+	}
+
+	var recipients []string
+	for _, to := range notifyMode.To {
+		interpolated, err := o.interpolator.Interpolate(to)
+		if err != nil {
+			return fmt.Errorf("failed to interpolate recipient: %w", err)
+		}
+		recipients = append(recipients, interpolated)
+	}
+
+	o.logger.Info("📣 Sending %s notification via '%s'", channel.Type, channelName)
+
+	if err := notify.Send(ctx, channel, &notify.Message{
+		Subject: subject,
+		Body:    body,
+		To:      recipients,
+	}); err != nil {
+		return fmt.Errorf("failed to send notification via '%s': %w", channelName, err)
+	}
+
+	// The step result is the delivered message so later steps can reference it
+	o.setStepResult(step.Name, body)
+	o.interpolator.Set(fmt.Sprintf("%s.channel", step.Name), channelName)
+
+	o.logger.Info("✓ Notification sent")
+
+	return nil
+}
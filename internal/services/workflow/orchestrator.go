@@ -4,14 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/LaurieRhodes/mcp-cli-go/internal/core/contextbundle"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/outputcrypt"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/progress"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/usage"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/highlight"
 )
 
 // Orchestrator orchestrates workflow execution with dependency resolution
@@ -28,9 +36,26 @@ type Orchestrator struct {
 	appConfig        *config.ApplicationConfig
 	loopExecutor     *LoopExecutor
 	embeddingService domain.EmbeddingService
-	ragServerManager *host.ServerManager // Dedicated manager for RAG servers (internal, not exposed to LLM)
-	startFrom        string              // Step name to start workflow from (skips previous steps)
-	endAt            string              // Step name to end workflow at (skips steps after)
+	ragServerManager *host.ServerManager           // Dedicated manager for RAG servers (internal, not exposed to LLM)
+	startFrom        string                        // Step name to start workflow from (skips previous steps)
+	endAt            string                        // Step name to end workflow at (skips steps after)
+	runStarted       time.Time                     // Start of the current run, used to name the artifacts directory
+	runDir           string                        // Lazily created run artifacts directory
+	runDirMu         sync.Mutex                    // Protects runDir; parallel steps such as debates save artifacts
+	spill            *SpillStore                   // Temp files for step outputs above execution.spill_threshold
+	verifyResults    []*VerifyResult               // Results of verify steps, in run order (guarded by stepResultsMu)
+	params           map[string]string             // Caller-supplied param values (--param, template with:)
+	matrixResult     *MatrixResult                 // Aggregated sub-runs when the workflow has a matrix
+	failureReport    *FailureReport                // Failed, skipped and succeeded steps of a parallel run that continued past failures
+	stepRuns         map[string]stepRun            // Step outcomes for the run record (guarded by stepResultsMu)
+	runID            string                        // Identifies the run in log file names
+	progress         progress.Scope                // Identifies the run in progress events
+	contextBundle    *contextbundle.Bundle         // Exported context from an earlier chat or run (--context-bundle)
+	inputFile        string                        // Spooled stdin (SetInputFile); {{input}} streams from it
+	inherited        []string                      // Variables set by a calling workflow (template inherit:)
+	citable          map[string][]rag.SearchResult // Results of rag steps with citations, by step (guarded by stepResultsMu)
+	stepMessages     map[string][]domain.Message   // Exchanges of run: steps, for later steps' history: (guarded by stepResultsMu)
+	blackboard       *Blackboard                   // Shared memory of the run, shared with called workflows
 }
 
 // NewOrchestrator creates a new workflow orchestrator
@@ -43,6 +68,11 @@ func NewOrchestratorWithKey(workflow *config.WorkflowV2, workflowKey string, log
 	executor := NewExecutor(workflow, logger)
 	consensusExec := NewConsensusExecutor(executor)
 	interpolator := NewInterpolator()
+	spill := NewSpillStore(workflow.Execution.SpillThreshold)
+	interpolator.SetSpillStore(spill)
+	blackboard := NewBlackboard()
+	interpolator.SetBlackboard(blackboard)
+	executor.blackboard = blackboard
 
 	// Set environment variables
 	interpolator.SetEnv(workflow.Env)
@@ -56,18 +86,71 @@ func NewOrchestratorWithKey(workflow *config.WorkflowV2, workflowKey string, log
 		logger:           logger,
 		stepResults:      make(map[string]string),
 		consensusResults: make(map[string]*config.ConsensusResult),
+		spill:            spill,
+		blackboard:       blackboard,
 	}
 }
 
+// SetBlackboard makes the workflow use board, such as its caller's
+func (o *Orchestrator) SetBlackboard(board *Blackboard) {
+	o.blackboard = board
+	o.interpolator.SetBlackboard(board)
+	o.executor.blackboard = board
+}
+
 // Execute executes the entire workflow
-func (o *Orchestrator) Execute(ctx context.Context, input string) error {
+func (o *Orchestrator) Execute(ctx context.Context, input string) (err error) {
+	ctx = usage.WithWorkflow(ctx, o.workflow.Name)
+
+	// With outputs encryption, files are sealed once the run ends, so steps
+	// can read what earlier steps wrote
+	release := outputcrypt.Hold()
+	defer func(started time.Time) {
+		if o.appConfig != nil {
+			outputcrypt.SealOutputs(o.appConfig.Skills, started)
+		}
+		release()
+	}(time.Now())
+
 	// Validate workflow before execution
 	if err := ValidateWorkflow(o.workflow); err != nil {
-		return fmt.Errorf("workflow validation failed:\n%w", err)
+		return domainErrors.Categorize(fmt.Errorf("workflow validation failed:\n%w", err), domainErrors.ErrValidation)
 	}
 
 	// Set initial input
+	if o.inputFile != "" {
+		input = spillRefPrefix + o.inputFile
+	}
 	o.interpolator.Set("input", input)
+	o.applyContextBundle()
+	o.runStarted = time.Now()
+	o.applyLoggingConfig()
+	completed := o.startProgress()
+	defer func() { completed(err) }()
+
+	// A matrix fans out into sub-runs that resolve params themselves
+	if o.workflow.Matrix != nil {
+		o.logger.Info("Starting workflow matrix: %s v%s", o.workflow.Name, o.workflow.Version)
+		o.logger.Step("\n[WORKFLOW MATRIX] %s v%s", o.workflow.Name, o.workflow.Version)
+		return o.executeMatrix(ctx, input)
+	}
+
+	params, err := config.ResolveParams(o.workflow.Params, o.params)
+	if err != nil {
+		return domainErrors.Categorize(err, domainErrors.ErrValidation)
+	}
+	for name, value := range params {
+		o.interpolator.Set("params."+name, value)
+	}
+	if prompt := o.workflow.Execution.SystemPrompt; prompt != "" {
+		interpolated, _ := o.interpolator.Interpolate(prompt)
+		o.executor.SetWorkflowSystemPrompt(interpolated)
+	}
+	o.seedBlackboard()
+
+	if o.workflow.Execution.RecordRun {
+		defer func() { o.writeRunRecord(input, params, err) }()
+	}
 
 	// Log start-from if specified
 	if o.startFrom != "" {
@@ -82,6 +165,10 @@ func (o *Orchestrator) Execute(ctx context.Context, input string) error {
 	o.logger.Info("Starting workflow: %s v%s", o.workflow.Name, o.workflow.Version)
 	o.logger.Step("\n[WORKFLOW] %s v%s", o.workflow.Name, o.workflow.Version)
 
+	if err := o.setInputPortions(ctx, input); err != nil {
+		return err
+	}
+
 	// Check if parallel execution is enabled
 	if o.workflow.Execution.Parallel {
 		maxWorkers := o.workflow.Execution.MaxWorkers
@@ -227,6 +314,7 @@ func (o *Orchestrator) executeParallel(ctx context.Context) error {
 
 	// Validate variable references
 	varValidator := NewVariableValidator(o.workflow)
+	varValidator.Allow(o.inherited...)
 	if errs := varValidator.ValidateAll(); len(errs) > 0 {
 		for _, err := range errs {
 			o.logger.Error("Variable validation error: %v", err)
@@ -261,9 +349,14 @@ func (o *Orchestrator) executeParallel(ctx context.Context) error {
 
 	// Start timeline tracking
 	pool.timeline.Start()
+	if o.workflow.Execution.ExportTimeline {
+		defer o.exportTimeline(pool)
+	}
 
-	// Track completion
+	// Track completion: completed holds finished elements (and steps
+	// skipped by start-from/end-at), submitted those handed to the pool
 	completed := make(map[string]bool)
+	submitted := make(map[string]bool)
 
 	// Pre-mark steps as completed if using start-from or end-at
 	if o.startFrom != "" || o.endAt != "" {
@@ -272,93 +365,84 @@ func (o *Orchestrator) executeParallel(ctx context.Context) error {
 		}
 	}
 
-	// Get initial ready steps (no dependencies)
-	readySteps := resolver.GetReadySteps(completed)
-
-	o.logger.Debug("Initial ready steps: %d", len(readySteps))
-	for _, step := range readySteps {
-		o.logger.Debug("  - %s", step.Name)
+	// Track remaining steps and loops
+	remaining := make(map[string]bool)
+	for i := range o.workflow.Steps {
+		if !completed[o.workflow.Steps[i].Name] {
+			remaining[o.workflow.Steps[i].Name] = true
+		}
 	}
 
-	// Submit initial ready steps
-	for _, step := range readySteps {
-		if err := pool.SubmitStep(ctx, step); err != nil {
-			return fmt.Errorf("failed to submit step %s: %w", step.Name, err)
+	// submitReady submits the steps whose dependencies have all finished
+	submitReady := func() error {
+		readySteps := resolver.GetReadySteps(completed)
+		for _, step := range readySteps {
+			if submitted[step.Name] || !remaining[step.Name] {
+				continue
+			}
+			o.logger.Debug("Submitting ready step: %s", step.Name)
+			if err := pool.SubmitStep(ctx, step); err != nil {
+				return fmt.Errorf("failed to submit step %s: %w", step.Name, err)
+			}
+			submitted[step.Name] = true
 		}
-		completed[step.Name] = true // Mark as submitted
+		return nil
 	}
 
-	// Track remaining steps and loops
-	stepsRemaining := make(map[string]*config.StepV2)
-	for i := range o.workflow.Steps {
-		step := &o.workflow.Steps[i]
-		if !completed[step.Name] {
-			stepsRemaining[step.Name] = step
-		}
+	// Submit initial ready steps (no dependencies)
+	if err := submitReady(); err != nil {
+		return err
 	}
 
 	// Loops can run independently - submit them all at once
-	loopsRemaining := make(map[string]*config.LoopV2)
 	for i := range o.workflow.Loops {
 		loop := &o.workflow.Loops[i]
-		loopsRemaining[loop.Name] = loop
+		remaining[loop.Name] = true
 
 		// Submit loop immediately (loops have no dependencies)
 		o.logger.Debug("Submitting loop: %s", loop.Name)
 		if err := pool.SubmitLoop(ctx, loop); err != nil {
 			return fmt.Errorf("failed to submit loop %s: %w", loop.Name, err)
 		}
-		completed[loop.Name] = true // Mark as submitted
+		submitted[loop.Name] = true
 	}
 
-	totalRemaining := len(stepsRemaining) + len(loopsRemaining)
+	// With on_error: continue, steps needing a failed step are skipped;
+	// skipped maps each to the failed step it needs
+	continueOnError := pool.errorPolicy == "continue"
+	skipped := make(map[string]string)
 
 	// Event-driven coordination loop
-	for totalRemaining > 0 {
+	for len(remaining) > 0 {
 		select {
 		case completedName := <-pool.notifyCompletion:
 			// Step or loop completed
 			o.logger.Debug("Element completed: %s", completedName)
+			delete(remaining, completedName)
 
 			// Check for error
 			if err, hasError := pool.GetError(completedName); hasError {
 				o.logger.Error("Element %s failed: %v", completedName, err)
 
-				// Wait for in-flight elements based on error policy
-				pool.Wait()
-
-				// Copy results to orchestrator
-				o.copyPoolResults(pool)
-
-				return fmt.Errorf("element %s failed: %w", completedName, err)
-			}
-
-			// Mark as completed
-			completed[completedName] = true
-
-			// Remove from remaining (could be step or loop)
-			delete(stepsRemaining, completedName)
-			delete(loopsRemaining, completedName)
-			totalRemaining = len(stepsRemaining) + len(loopsRemaining)
+				if !continueOnError {
+					// Wait for in-flight elements based on error policy
+					pool.Wait()
 
-			// Find newly ready steps
-			newReadySteps := resolver.GetReadySteps(completed)
+					// Copy results to orchestrator
+					o.copyPoolResults(pool)
 
-			if len(newReadySteps) > 0 {
-				o.logger.Debug("New ready steps: %d", len(newReadySteps))
-				for _, step := range newReadySteps {
-					o.logger.Debug("  - %s", step.Name)
+					return fmt.Errorf("element %s failed: %w", completedName, err)
 				}
+				o.skipDependents(resolver, completedName, remaining, skipped)
+			} else {
+				completed[completedName] = true
 			}
 
 			// Submit newly ready steps
-			for _, step := range newReadySteps {
-				if err := pool.SubmitStep(ctx, step); err != nil {
-					pool.Wait()
-					o.copyPoolResults(pool)
-					return fmt.Errorf("failed to submit step %s: %w", step.Name, err)
-				}
-				completed[step.Name] = true // Mark as submitted
+			if err := submitReady(); err != nil {
+				pool.Wait()
+				o.copyPoolResults(pool)
+				return err
 			}
 
 		case <-ctx.Done():
@@ -381,6 +465,12 @@ func (o *Orchestrator) executeParallel(ctx context.Context) error {
 	// Check for any errors
 	allErrors := pool.GetAllErrors()
 	if len(allErrors) > 0 {
+		if continueOnError {
+			o.failureReport = o.buildFailureReport(allErrors, skipped)
+			partial := &PartialFailureError{Report: o.failureReport}
+			o.logger.Output("Workflow %s: %v", o.workflow.Name, partial)
+			return partial
+		}
 		// Return first error
 		for name, err := range allErrors {
 			return fmt.Errorf("element %s failed: %w", name, err)
@@ -405,6 +495,27 @@ func (o *Orchestrator) executeParallel(ctx context.Context) error {
 	return nil
 }
 
+// skipDependents removes the steps that need a failed step, directly or
+// through other skipped steps, from the remaining steps
+func (o *Orchestrator) skipDependents(resolver *DependencyResolver, failed string, remaining map[string]bool, skipped map[string]string) {
+	queue := resolver.GetDependents(failed)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if !remaining[name] {
+			continue
+		}
+		delete(remaining, name)
+		skipped[name] = failed
+
+		err := fmt.Errorf("needs failed step %s", failed)
+		o.logger.Warn("Skipping step %s: %v", name, err)
+		o.recordStepRun(name, StepSkipped, err, 0)
+		o.emitStepCompleted(name, progress.StatusSkipped, err, 0)
+		queue = append(queue, resolver.GetDependents(name)...)
+	}
+}
+
 // copyPoolResults copies results from worker pool to orchestrator
 func (o *Orchestrator) copyPoolResults(pool *WorkflowWorkerPool) {
 	results := pool.GetAllResults()
@@ -442,40 +553,93 @@ func (o *Orchestrator) executeStep(ctx context.Context, step *config.StepV2) err
 	}
 
 	o.logger.Step("\n[STEP %d/%d] %s", stepIndex, totalSteps, step.Name)
+	o.progress.Emit(progress.Event{Type: progress.StepStarted, Step: step.Name, Index: stepIndex})
 
 	// Check condition
 	if step.If != "" {
 		if !o.evaluateIfCondition(step.If) {
 			o.logger.Info("Step skipped (condition not met)")
 			o.logger.Step("  ⊘ Skipped (condition not met)")
+			o.recordStepRun(step.Name, StepSkipped, nil, 0)
+			o.emitStepCompleted(step.Name, progress.StatusSkipped, nil, 0)
 			return nil
 		}
 	}
 
+	// The step's and its agent's system prompts can refer to params and
+	// earlier results
+	agent := step.ResolvedAgent
+	if step.SystemPrompt != "" || (agent != nil && agent.SystemPrompt != "") {
+		interpolated := *step
+		if step.SystemPrompt != "" {
+			interpolated.SystemPrompt, _ = o.interpolator.Interpolate(step.SystemPrompt)
+		}
+		if agent != nil && agent.SystemPrompt != "" {
+			resolved := *agent
+			resolved.SystemPrompt, _ = o.interpolator.Interpolate(agent.SystemPrompt)
+			interpolated.ResolvedAgent = &resolved
+		}
+		step = &interpolated
+	}
+
 	// Determine step type and execute
 	var err error
 	if step.Consensus != nil {
 		err = o.executeConsensusStep(ctx, step)
 	} else if step.Loop != nil {
 		err = o.executeLoopStep(ctx, step)
-	} else if step.Run != "" {
+	} else if step.Run != "" || step.PromptRef != "" {
 		err = o.executeRegularStep(ctx, step)
 	} else if step.Embeddings != nil {
 		err = o.executeEmbeddingsStep(ctx, step)
 	} else if step.Rag != nil {
 		err = o.executeRagStep(ctx, step)
+	} else if step.SQL != nil {
+		err = o.executeSQLStep(ctx, step)
+	} else if step.Storage != nil {
+		err = o.executeStorageStep(ctx, step)
+	} else if step.Notify != nil {
+		err = o.executeNotifyStep(ctx, step)
+	} else if step.LogAnalytics != nil {
+		err = o.executeLogAnalyticsStep(ctx, step)
+	} else if step.GraphSecurity != nil {
+		err = o.executeGraphSecurityStep(ctx, step)
+	} else if step.LoadTable != nil {
+		err = o.executeLoadTableStep(ctx, step)
+	} else if step.Render != nil {
+		err = o.executeRenderStep(ctx, step)
+	} else if step.TTS != nil {
+		err = o.executeTTSStep(ctx, step)
+	} else if step.Compare != nil {
+		err = o.executeCompareStep(ctx, step)
+	} else if step.Debate != nil {
+		err = o.executeDebateStep(ctx, step)
+	} else if step.Guard != nil {
+		err = o.executeGuardStep(ctx, step)
+	} else if step.Verify != nil {
+		err = o.executeVerifyStep(ctx, step)
 	} else if step.Template != nil {
 		err = o.executeWorkflowStep(ctx, step)
 	} else {
 		err = fmt.Errorf("no execution mode specified")
 	}
+	if err == nil {
+		err = o.writeOutputFile(step)
+	}
+	if err == nil {
+		err = o.writeBlackboard(step)
+	}
 
 	// Log step completion with timing
 	duration := time.Since(stepStart)
 	if err != nil {
 		o.logger.Step("  ✗ Failed (%.1fs): %v", duration.Seconds(), err)
+		o.recordStepRun(step.Name, StepFailed, err, duration)
+		o.emitStepCompleted(step.Name, progress.StatusFailed, err, duration)
 		return err
 	}
+	o.recordStepRun(step.Name, StepSucceeded, nil, duration)
+	o.emitStepCompleted(step.Name, progress.StatusSucceeded, nil, duration)
 
 	o.logger.Step("  ✓ Completed (%.1fs)", duration.Seconds())
 	return nil
@@ -483,15 +647,35 @@ func (o *Orchestrator) executeStep(ctx context.Context, step *config.StepV2) err
 
 // executeRegularStep executes a regular (non-consensus) step
 func (o *Orchestrator) executeRegularStep(ctx context.Context, step *config.StepV2) error {
+	run := step.Run
+	if step.PromptRef != "" {
+		rendered, err := o.renderPromptRef(step)
+		if err != nil {
+			return err
+		}
+		run = rendered
+	}
+
 	// Interpolate prompt
-	prompt, _ := o.interpolator.Interpolate(step.Run)
+	prompt, _ := o.interpolator.Interpolate(run)
+
+	// Few-shot examples
+	prompt, err := o.injectExamples(ctx, step, prompt)
+	if err != nil {
+		return o.handleStepError(step, err)
+	}
+
+	history, err := o.stepHistory(step)
+	if err != nil {
+		return o.handleStepError(step, err)
+	}
 
 	// Create temp step with interpolated prompt
 	tempStep := *step
 	tempStep.Run = prompt
 
 	// Execute
-	result, err := o.executor.ExecuteStep(ctx, &tempStep)
+	result, err := o.executor.ExecuteStepWithHistory(ctx, &tempStep, history)
 
 	if err != nil {
 		// Apply error handling policy
@@ -499,20 +683,92 @@ func (o *Orchestrator) executeRegularStep(ctx context.Context, step *config.Step
 	}
 
 	// Store result
-	o.stepResults[step.Name] = result.Output
-	o.interpolator.SetStepResult(step.Name, result.Output)
+	o.setStepResult(step.Name, result.Output)
+	o.setStepMessages(step.Name, result.Messages)
+	o.recordCitations(step.Name, run, result.Output)
 
-	o.logger.Output("Step %s result: %s", step.Name, result.Output)
+	o.logger.Output("Step %s result: %s", step.Name, o.highlightOutput(step, result.Output))
 
 	return nil
 }
 
+// stepHistory returns the exchanges of the steps a step lists in history:,
+// in that order, so it continues their conversations
+func (o *Orchestrator) stepHistory(step *config.StepV2) ([]domain.Message, error) {
+	o.stepResultsMu.RLock()
+	defer o.stepResultsMu.RUnlock()
+
+	var history []domain.Message
+	for _, name := range step.History {
+		messages, ok := o.stepMessages[name]
+		if !ok {
+			return nil, fmt.Errorf("history: step %s has no messages (only run: steps that ran before %s have them)", name, step.Name)
+		}
+		history = append(history, messages...)
+	}
+	return history, nil
+}
+
+// setStepMessages keeps a step's exchange for later steps' history:
+func (o *Orchestrator) setStepMessages(stepName string, messages []domain.Message) {
+	if len(messages) == 0 {
+		return
+	}
+	o.stepResultsMu.Lock()
+	defer o.stepResultsMu.Unlock()
+	if o.stepMessages == nil {
+		o.stepMessages = make(map[string][]domain.Message)
+	}
+	o.stepMessages[stepName] = messages
+}
+
+// renderPromptRef renders a step's named prompt template. Templates are
+// resolved from the workflow's prompts: section first, then config/prompts/;
+// argument values come from prompt_args (interpolated), then parameter defaults.
+func (o *Orchestrator) renderPromptRef(step *config.StepV2) (string, error) {
+	var library *config.PromptLibrary
+	if o.appConfig != nil {
+		library = o.appConfig.Prompts
+	}
+	library, err := library.Overlay(o.workflow.Prompts, "workflow "+o.workflow.Name)
+	if err != nil {
+		return "", fmt.Errorf("invalid workflow prompts: %w", err)
+	}
+
+	prompt, err := library.Get(step.PromptRef)
+	if err != nil {
+		return "", err
+	}
+
+	values := config.FormatPromptArgs(step.PromptArgs)
+	for name, value := range values {
+		interpolated, err := o.interpolator.Interpolate(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to interpolate prompt_args.%s: %w", name, err)
+		}
+		values[name] = interpolated
+	}
+
+	o.logger.Debug("Step %s uses prompt %s (%s)", step.Name, prompt.Ref(), prompt.Source)
+	return prompt.Render(values)
+}
+
+// highlightOutput colors diffs and code blocks in step output for terminal display.
+// Stored results are never modified.
+func (o *Orchestrator) highlightOutput(step *config.StepV2, output string) string {
+	if o.executor.resolver.ResolveNoColor(step) {
+		return output
+	}
+	return highlight.Render(output)
+}
+
 // handleStepError applies error handling policy for failed steps
 func (o *Orchestrator) handleStepError(step *config.StepV2, err error) error {
 	// Determine error policy
 	onFailure := step.OnFailure
-	if onFailure == "" {
-		// Use workflow-level default
+	if onFailure == "" && !o.workflow.Execution.Parallel {
+		// Use workflow-level default; in parallel mode on_error is the
+		// worker pool's policy instead
 		onFailure = o.workflow.Execution.OnError
 	}
 	if onFailure == "" {
@@ -528,8 +784,7 @@ func (o *Orchestrator) handleStepError(step *config.StepV2, err error) error {
 		// Log warning but continue workflow
 		o.logger.Warn("Continuing workflow despite step failure (policy: continue)")
 		// Store empty result
-		o.stepResults[step.Name] = ""
-		o.interpolator.SetStepResult(step.Name, "")
+		o.setStepResult(step.Name, "")
 		return nil
 
 	case "retry":
@@ -570,12 +825,14 @@ func (o *Orchestrator) executeConsensusStep(ctx context.Context, step *config.St
 
 	// Store results
 	o.consensusResults[step.Name] = result
-	o.stepResults[step.Name] = result.Result
-	o.interpolator.SetStepResult(step.Name, result.Result)
+	o.setStepResult(step.Name, result.Result)
 
 	// Output consensus details with individual votes
 	o.logger.Output("Step %s consensus result: %s", step.Name, result.Result)
 	o.logger.Output("  Agreement: %.0f%%, Confidence: %s", result.Agreement*100, result.Confidence)
+	if result.Adjudicator != "" {
+		o.logger.Output("  Adjudicated by: %s", result.Adjudicator)
+	}
 
 	// Show individual provider votes for transparency
 	if len(result.Votes) > 0 {
@@ -621,9 +878,11 @@ func (o *Orchestrator) executeEmbeddingsStep(ctx context.Context, step *config.S
 
 	// Determine input
 	var inputText string
+	var inputPath string
 	if emb.InputFile != "" {
 		// Read from file
 		interpolatedPath, _ := o.interpolator.Interpolate(emb.InputFile)
+		inputPath = interpolatedPath
 		data, err := os.ReadFile(interpolatedPath)
 		if err != nil {
 			return fmt.Errorf("failed to read input file: %w", err)
@@ -680,6 +939,14 @@ func (o *Orchestrator) executeEmbeddingsStep(ctx context.Context, step *config.S
 		model = o.workflow.Execution.Model
 	}
 
+	// An explicit chain replaces the inherited provider; without one the
+	// embeddings.fallback_chain setting applies
+	var fallbacks []config.ProviderFallback
+	if len(emb.Providers) > 0 {
+		provider, model = emb.Providers[0].Provider, emb.Providers[0].Model
+		fallbacks = emb.Providers[1:]
+	}
+
 	if provider == "" || model == "" {
 		return fmt.Errorf("provider and model required for embeddings")
 	}
@@ -723,10 +990,12 @@ func (o *Orchestrator) executeEmbeddingsStep(ctx context.Context, step *config.S
 		ChunkOverlap:   emb.Overlap,
 		EncodingFormat: encodingFormat,
 		Dimensions:     emb.Dimensions,
+		FilePath:       inputPath,
 		Metadata: map[string]interface{}{
 			"workflow": o.workflow.Name,
 			"step":     step.Name,
 		},
+		Fallbacks: fallbacks,
 	}
 
 	// Generate embeddings
@@ -737,6 +1006,9 @@ func (o *Orchestrator) executeEmbeddingsStep(ctx context.Context, step *config.S
 
 	o.logger.Info("Generated embeddings: %d chunks, %d vectors",
 		len(job.Chunks), len(job.Embeddings))
+	if from, ok := job.Metadata["failover_from"]; ok {
+		o.logger.Warn("Step %s embedded with %s/%s after %v failed", step.Name, job.Provider, job.Model, from)
+	}
 
 	// Format output
 	var outputData []byte
@@ -783,8 +1055,7 @@ func (o *Orchestrator) executeEmbeddingsStep(ctx context.Context, step *config.S
 	}
 
 	// Store result for interpolation
-	o.stepResults[step.Name] = result
-	o.interpolator.SetStepResult(step.Name, result)
+	o.setStepResult(step.Name, result)
 
 	o.logger.Output("Step %s result: Generated %d embeddings", step.Name, len(job.Embeddings))
 
@@ -829,7 +1100,7 @@ func (o *Orchestrator) evaluateCondition(condition string) bool {
 	}
 
 	// Get step result
-	value, ok := o.stepResults[left]
+	value, ok := o.GetStepResult(left)
 	if !ok {
 		o.logger.Warn("Condition references unknown step: %s", left)
 		return false
@@ -842,10 +1113,57 @@ func (o *Orchestrator) evaluateCondition(condition string) bool {
 	return leftVal == rightVal
 }
 
-// GetStepResult gets a step's result
+// GetStepResult gets a step's result, reading it back from disk if it was spilled
 func (o *Orchestrator) GetStepResult(stepName string) (string, bool) {
+	o.stepResultsMu.RLock()
 	result, ok := o.stepResults[stepName]
-	return result, ok
+	o.stepResultsMu.RUnlock()
+	if !ok {
+		return "", false
+	}
+
+	full, err := loadSpilled(result)
+	if err != nil {
+		o.logger.Warn("Failed to load output of step %s: %v", stepName, err)
+		return "", false
+	}
+	return full, true
+}
+
+// setStepResult records a step's output for later steps and conditions.
+// Outputs above the spill threshold are stored once on disk and referenced
+// from both the results map and the interpolator.
+func (o *Orchestrator) setStepResult(stepName, output string) {
+	stored := o.spill.Store(stepName, output)
+
+	o.stepResultsMu.Lock()
+	o.stepResults[stepName] = stored
+	o.stepResultsMu.Unlock()
+
+	o.interpolator.SetStepResult(stepName, stored)
+}
+
+// Close removes temp files holding spilled step outputs. Call it once the
+// step results are no longer needed.
+func (o *Orchestrator) Close() error {
+	o.logger.Close()
+	return o.spill.Close()
+}
+
+// FinalOutput returns the workflow's result: the aggregated report for
+// matrix runs, the failure report of a parallel run that continued past
+// failed steps, otherwise the last step's output
+func (o *Orchestrator) FinalOutput() (string, bool) {
+	if o.matrixResult != nil {
+		return formatMatrixReport(o.matrixResult), true
+	}
+	if o.failureReport != nil {
+		return o.failureReport.Format(), true
+	}
+	if len(o.workflow.Steps) == 0 {
+		return "", false
+	}
+	return o.GetStepResult(o.workflow.Steps[len(o.workflow.Steps)-1].Name)
 }
 
 // GetConsensusResult gets a step's consensus result
@@ -874,11 +1192,31 @@ func (o *Orchestrator) SetEndAt(stepName string) {
 	o.endAt = stepName
 }
 
+// SetParams sets caller values for the workflow's params, overriding defaults
+func (o *Orchestrator) SetParams(params map[string]string) {
+	o.params = params
+}
+
+// Inherit makes variables of a calling workflow readable in this one under
+// the same names (template inherit:)
+func (o *Orchestrator) Inherit(vars map[string]string) {
+	for name, value := range vars {
+		o.interpolator.Set(name, value)
+		o.inherited = append(o.inherited, name)
+	}
+}
+
 // SetProvider is deprecated - kept for compatibility
 func (o *Orchestrator) SetProvider(provider domain.LLMProvider) {
 	// No-op - we create providers dynamically now
 }
 
+// SetStreamOutput sets where steps with stream: true write their responses
+// as they arrive, such as stderr or serve-mode progress notifications
+func (o *Orchestrator) SetStreamOutput(w io.Writer) {
+	o.executor.SetStreamOutput(w)
+}
+
 // SetServerManager sets the MCP server manager for the orchestrator
 func (o *Orchestrator) SetServerManager(serverManager domain.MCPServerManager) {
 	o.executor.SetServerManager(serverManager)
@@ -922,39 +1260,65 @@ func (o *Orchestrator) executeWorkflowStep(ctx context.Context, step *config.Ste
 		}
 	}
 
-	// Prepare input for sub-workflow
-	var inputData string
-	if inputValue, ok := step.Template.With["input"]; ok {
-		// Interpolate the input value
-		inputStr, ok := inputValue.(string)
-		if !ok {
-			return fmt.Errorf("workflow input must be a string")
+	// Every with: key but input is a param of the sub-workflow. Strings
+	// inside lists and maps are interpolated before they're passed as JSON.
+	values := make(map[string]interface{}, len(step.Template.With))
+	for key, value := range step.Template.With {
+		interpolated, err := o.interpolateWith(value)
+		if err != nil && key != "input" {
+			// input keeps unresolved references as text, as it always has
+			return fmt.Errorf("failed to interpolate param '%s': %w", key, err)
 		}
-		interpolated, _ := o.interpolator.Interpolate(inputStr)
-		inputData = interpolated
+		values[key] = interpolated
+	}
+	params := config.FormatPromptArgs(values)
+	inputData := params["input"]
+	delete(params, "input")
+
+	inherited, err := o.inheritedVariables(step.Template.Inherit)
+	if err != nil {
+		return err
 	}
 
 	// Create a new orchestrator for the sub-workflow with its key for directory context
-	subLogger := NewLogger(subWorkflow.Execution.Logging, false)
 	// CRITICAL: Inherit output from parent logger (stdout in CLI, stderr in MCP serve mode)
-	subLogger.SetOutput(o.logger.GetOutput())
+	subLogger := o.logger.SubLogger(subWorkflow.ConsoleLogLevel())
 	subOrchestrator := NewOrchestratorWithKey(subWorkflow, subWorkflowKey, subLogger)
+	defer subOrchestrator.Close()
 
-	// Pass through app config and server manager
+	// Pass through app config, server manager and blackboard
+	subOrchestrator.SetBlackboard(o.blackboard)
 	subOrchestrator.executor.SetAppConfig(o.executor.appConfig)
+	subOrchestrator.SetStreamOutput(o.executor.stream)
 	if o.executor.serverManager != nil {
 		subOrchestrator.executor.SetServerManager(o.executor.serverManager)
 	}
 
 	// Pass app config to sub-orchestrator for nested workflow calls
 	subOrchestrator.SetAppConfigForWorkflows(o.appConfig)
+	subOrchestrator.SetParams(params)
+	subOrchestrator.Inherit(inherited)
 
 	// Execute the sub-workflow
-	err := subOrchestrator.Execute(ctx, inputData)
-	if err != nil {
+	if err := subOrchestrator.Execute(ctx, inputData); err != nil {
 		return fmt.Errorf("execution failed: %w", err)
 	}
 
+	// Map named results back before the sub-workflow's spill files go away
+	for name, source := range step.Template.Outputs {
+		value, ok, err := subOrchestrator.interpolator.lookup(source)
+		if err == nil && ok {
+			value, err = loadSpilled(value)
+		}
+		if err != nil {
+			return fmt.Errorf("outputs: failed to read '%s' from workflow '%s': %w", source, workflowName, err)
+		}
+		if !ok {
+			return fmt.Errorf("outputs: '%s' is not defined in workflow '%s'", source, workflowName)
+		}
+		o.setStepResult(name, value)
+	}
+
 	// Get the final result from the sub-workflow
 	var result string
 	if len(subWorkflow.Steps) > 0 {
@@ -966,14 +1330,69 @@ func (o *Orchestrator) executeWorkflowStep(ctx context.Context, step *config.Ste
 	}
 
 	// Store result (same as executeRegularStep)
-	o.stepResults[step.Name] = result
-	o.interpolator.SetStepResult(step.Name, result)
+	o.setStepResult(step.Name, result)
 
 	o.logger.Info("Workflow '%s' completed, result available as {{%s}}", workflowName, step.Name)
 
 	return nil
 }
 
+// interpolateWith interpolates a template with: value, including strings
+// nested in lists and maps. On error it still returns the value with the
+// references it could resolve.
+func (o *Orchestrator) interpolateWith(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return o.interpolator.Interpolate(v)
+	case []interface{}:
+		var firstErr error
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			interpolated, err := o.interpolateWith(item)
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			out[i] = interpolated
+		}
+		return out, firstErr
+	case map[string]interface{}:
+		var firstErr error
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			interpolated, err := o.interpolateWith(item)
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			out[key] = interpolated
+		}
+		return out, firstErr
+	}
+	return value, nil
+}
+
+// inheritedVariables collects the variables a template step passes down
+// with inherit:. A step result is passed as both name and step.name.
+func (o *Orchestrator) inheritedVariables(names []string) (map[string]string, error) {
+	vars := make(map[string]string, len(names)*2)
+	for _, name := range names {
+		value, ok, err := o.interpolator.lookup(name)
+		if err == nil && ok {
+			value, err = loadSpilled(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("inherit: failed to read '%s': %w", name, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("inherit: '%s' is not defined", name)
+		}
+		vars[name] = value
+		if o.interpolator.HasVariable("step." + name) {
+			vars["step."+name] = value
+		}
+	}
+	return vars, nil
+}
+
 // parseExecutionOrder determines execution order from YAML structure
 
 // executeLoopElement executes a loop element
@@ -1002,6 +1421,26 @@ func (o *Orchestrator) executeStepElement(ctx context.Context, step *config.Step
 		return o.executeEmbeddingsStep(ctx, step)
 	} else if step.Rag != nil {
 		return o.executeRagStep(ctx, step)
+	} else if step.SQL != nil {
+		return o.executeSQLStep(ctx, step)
+	} else if step.Storage != nil {
+		return o.executeStorageStep(ctx, step)
+	} else if step.Notify != nil {
+		return o.executeNotifyStep(ctx, step)
+	} else if step.LogAnalytics != nil {
+		return o.executeLogAnalyticsStep(ctx, step)
+	} else if step.GraphSecurity != nil {
+		return o.executeGraphSecurityStep(ctx, step)
+	} else if step.LoadTable != nil {
+		return o.executeLoadTableStep(ctx, step)
+	} else if step.Render != nil {
+		return o.executeRenderStep(ctx, step)
+	} else if step.TTS != nil {
+		return o.executeTTSStep(ctx, step)
+	} else if step.Compare != nil {
+		return o.executeCompareStep(ctx, step)
+	} else if step.Debate != nil {
+		return o.executeDebateStep(ctx, step)
 	} else if step.Template != nil {
 		return o.executeWorkflowStep(ctx, step)
 	} else if step.Loop != nil {
@@ -1039,6 +1478,8 @@ func (o *Orchestrator) executeLoopStep(ctx context.Context, step *config.StepV2)
 		With:           step.Loop.With,
 		MaxIterations:  step.Loop.MaxIterations,
 		Until:          step.Loop.Until,
+		UntilLLM:       step.Loop.UntilLLM,
+		Judge:          step.Loop.Judge,
 		OnFailure:      step.Loop.OnFailure,
 		MaxRetries:     step.Loop.MaxRetries,
 		RetryDelay:     step.Loop.RetryDelay,
@@ -1048,6 +1489,9 @@ func (o *Orchestrator) executeLoopStep(ctx context.Context, step *config.StepV2)
 		Accumulate:     step.Loop.Accumulate,
 		Parallel:       step.Loop.Parallel,
 		MaxWorkers:     step.Loop.MaxWorkers,
+		Combine:        step.Loop.Combine,
+		CombinePrompt:  step.Loop.CombinePrompt,
+		CombineModel:   step.Loop.CombineModel,
 	}
 
 	// Execute the loop using LoopExecutor
@@ -1066,188 +1510,6 @@ func (o *Orchestrator) executeLoopStep(ctx context.Context, step *config.StepV2)
 	return nil
 }
 
-// loopResult stores results from loop execution
-type loopResult struct {
-	Iterations  int
-	FinalOutput string
-	AllOutputs  []string
-	ExitReason  string
-}
-
-// executeLoopInternal contains the actual loop execution logic
-func (o *Orchestrator) executeLoopInternal(ctx context.Context, name string, workflow string, with map[string]interface{}, maxIterations int, until string, onFailure string, accumulate string) (*loopResult, error) {
-	if maxIterations <= 0 {
-		return nil, fmt.Errorf("max_iterations must be > 0, got %d", maxIterations)
-	}
-
-	// Extract directory from current workflow key for directory-aware resolution
-	var contextDir string
-	if o.workflowKey != "" {
-		if idx := strings.LastIndex(o.workflowKey, "/"); idx != -1 {
-			contextDir = o.workflowKey[:idx]
-		}
-	}
-
-	// Use contextual lookup to support relative workflow references
-	wf, exists := o.appConfig.GetWorkflowWithContext(workflow, contextDir)
-	if !exists {
-		return nil, fmt.Errorf("loop workflow '%s' not found", workflow)
-	}
-
-	result := &loopResult{
-		AllOutputs: make([]string, 0),
-	}
-
-	var lastOutput string
-
-	for iteration := 1; iteration <= maxIterations; iteration++ {
-		o.logger.Info("Loop iteration %d/%d", iteration, maxIterations)
-
-		o.interpolator.SetLoopVars(iteration, lastOutput, result.AllOutputs)
-
-		inputData, err := o.prepareLoopInput(with, lastOutput)
-		if err != nil {
-			if onFailure == "halt" {
-				return nil, fmt.Errorf("iteration %d input prep failed: %w", iteration, err)
-			}
-			o.logger.Warn("Iteration %d input prep failed: %v", iteration, err)
-			continue
-		}
-
-		output, err := o.executeLoopWorkflow(ctx, wf, inputData)
-		if err != nil {
-			if onFailure == "halt" {
-				result.ExitReason = "failure"
-				return result, fmt.Errorf("iteration %d failed: %w", iteration, err)
-			}
-			o.logger.Warn("Iteration %d failed: %v", iteration, err)
-			if onFailure == "retry" {
-				iteration--
-			}
-			continue
-		}
-
-		lastOutput = output
-		result.AllOutputs = append(result.AllOutputs, output)
-		result.Iterations = iteration
-		result.FinalOutput = output
-
-		if until != "" {
-			conditionMet, err := o.evaluateLoopCondition(ctx, until, output)
-			if err != nil {
-				o.logger.Warn("Failed to evaluate condition: %v", err)
-			} else if conditionMet {
-				o.logger.Info("Loop exit condition met after %d iterations", iteration)
-				result.ExitReason = "condition_met"
-				o.storeLoopResult(name, accumulate, result)
-				return result, nil
-			}
-		}
-	}
-
-	o.logger.Info("Loop completed: max iterations (%d) reached", maxIterations)
-	result.ExitReason = "max_iterations"
-	o.storeLoopResult(name, accumulate, result)
-	return result, nil
-}
-
-func (o *Orchestrator) prepareLoopInput(with map[string]interface{}, lastOutput string) (string, error) {
-	if with == nil || len(with) == 0 {
-		return lastOutput, nil
-	}
-
-	if inputValue, ok := with["input"]; ok {
-		inputStr, ok := inputValue.(string)
-		if !ok {
-			return "", fmt.Errorf("loop input must be a string")
-		}
-		interpolated, _ := o.interpolator.Interpolate(inputStr)
-		return interpolated, nil
-	}
-
-	var parts []string
-	for key, value := range with {
-		valueStr := fmt.Sprintf("%v", value)
-		interpolated, _ := o.interpolator.Interpolate(valueStr)
-		parts = append(parts, fmt.Sprintf("%s: %s", key, interpolated))
-	}
-
-	return strings.Join(parts, "\n"), nil
-}
-
-func (o *Orchestrator) executeLoopWorkflow(ctx context.Context, workflow *config.WorkflowV2, inputData string) (string, error) {
-	subLogger := NewLogger(workflow.Execution.Logging, false)
-	// CRITICAL: Inherit output from parent logger (stdout in CLI, stderr in MCP serve mode)
-	subLogger.SetOutput(o.logger.GetOutput())
-	subOrchestrator := NewOrchestrator(workflow, subLogger)
-
-	subOrchestrator.executor.SetAppConfig(o.executor.appConfig)
-	if o.executor.serverManager != nil {
-		subOrchestrator.executor.SetServerManager(o.executor.serverManager)
-	}
-	subOrchestrator.SetAppConfigForWorkflows(o.appConfig)
-
-	err := subOrchestrator.Execute(ctx, inputData)
-	if err != nil {
-		return "", fmt.Errorf("execution failed: %w", err)
-	}
-
-	if len(workflow.Steps) > 0 {
-		lastStepName := workflow.Steps[len(workflow.Steps)-1].Name
-		if output, ok := subOrchestrator.GetStepResult(lastStepName); ok {
-			return output, nil
-		}
-	}
-
-	return "", fmt.Errorf("no output from workflow")
-}
-
-func (o *Orchestrator) evaluateLoopCondition(ctx context.Context, condition string, output string) (bool, error) {
-	interpolatedCondition, _ := o.interpolator.Interpolate(condition)
-
-	prompt := fmt.Sprintf(
-		"Evaluate if this condition is satisfied. Answer only YES or NO.\n\n"+
-			"Condition: %s\n\n"+
-			"Output to evaluate:\n%s\n\n"+
-			"Answer (YES or NO):",
-		interpolatedCondition,
-		truncateString(output, 2000),
-	)
-
-	providerName := "deepseek"
-	if o.appConfig.AI != nil && o.appConfig.AI.DefaultProvider != "" {
-		providerName = o.appConfig.AI.DefaultProvider
-	}
-
-	provider, _ := o.executor.createProvider(providerName, "")
-
-	request := &domain.CompletionRequest{
-		Messages: []domain.Message{
-			{Role: "user", Content: prompt},
-		},
-		Temperature: 0,
-	}
-
-	response, _ := provider.CreateCompletion(ctx, request)
-
-	answer := strings.ToUpper(strings.TrimSpace(response.Response))
-	o.logger.Debug("Condition evaluation: '%s' -> %s", condition, answer)
-
-	return strings.Contains(answer, "YES"), nil
-}
-
-func (o *Orchestrator) storeLoopResult(name string, accumulate string, result *loopResult) {
-	o.interpolator.SetStepResult("loop.output", result.FinalOutput)
-	o.interpolator.SetStepResult("loop.iteration", fmt.Sprintf("%d", result.Iterations))
-
-	if accumulate != "" {
-		history := strings.Join(result.AllOutputs, "\n---\n")
-		o.interpolator.SetStepResult(accumulate, history)
-	}
-
-	o.interpolator.SetStepResult(name, result.FinalOutput)
-}
-
 // dependenciesMet checks if all dependencies for a step are satisfied
 func (o *Orchestrator) dependenciesMet(step *config.StepV2) bool {
 	if len(step.Needs) == 0 {
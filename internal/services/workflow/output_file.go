@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// writeOutputFile writes a step's result to its output_file. Paths are
+// interpolated and /outputs/ maps to the outputs directory; missing
+// directories are created.
+func (o *Orchestrator) writeOutputFile(step *config.StepV2) error {
+	if step.OutputFile == "" {
+		return nil
+	}
+	output, ok := o.GetStepResult(step.Name)
+	if !ok {
+		return nil
+	}
+
+	path, err := o.interpolator.Interpolate(step.OutputFile)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate output_file: %w", err)
+	}
+	if path, err = o.resolveOutputsPath(strings.TrimSpace(path)); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output_file directory: %w", err)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if step.OutputMode == "append" {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open output_file: %w", err)
+	}
+	if _, err := file.Write(formatOutputFile(path, output)); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output_file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output_file: %w", err)
+	}
+
+	o.logger.Info("Wrote output of step %s to %s", step.Name, path)
+	return nil
+}
+
+// formatOutputFile prepares a result for writing. A JSON object or array,
+// optionally in a markdown code fence, is pretty-printed, or written on one
+// line for .jsonl and .ndjson files. Content always ends with a newline so
+// appended results stay separate.
+func formatOutputFile(path, output string) []byte {
+	if data := jsonOutput(output); data != nil {
+		var buf bytes.Buffer
+		var err error
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".jsonl", ".ndjson":
+			err = json.Compact(&buf, data)
+		default:
+			err = json.Indent(&buf, data, "", "  ")
+		}
+		if err == nil {
+			buf.WriteByte('\n')
+			return buf.Bytes()
+		}
+	}
+
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	return []byte(output)
+}
+
+// jsonOutput returns the result as JSON if it is a JSON object or array,
+// unwrapping a surrounding code fence, or nil
+func jsonOutput(output string) []byte {
+	trimmed := strings.TrimSpace(output)
+	if strings.HasPrefix(trimmed, "```") && strings.HasSuffix(trimmed, "```") {
+		trimmed = strings.TrimSuffix(trimmed, "```")
+		if newline := strings.Index(trimmed, "\n"); newline >= 0 {
+			trimmed = strings.TrimSpace(trimmed[newline+1:])
+		}
+	}
+	if (!strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[")) || !json.Valid([]byte(trimmed)) {
+		return nil
+	}
+	return []byte(trimmed)
+}
@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatOutputFile(t *testing.T) {
+	assert.Equal(t, "{\n  \"b\": 1,\n  \"a\": [\n    2\n  ]\n}\n", string(formatOutputFile("out.json", `{"b":1,"a":[2]}`)))
+	assert.Equal(t, "{\"ok\":true}\n", string(formatOutputFile("log.jsonl", "```json\n{ \"ok\": true }\n```")))
+	assert.Equal(t, "plain text\n", string(formatOutputFile("out.json", "plain text")))
+	assert.Equal(t, "{not json\n", string(formatOutputFile("out.txt", "{not json")))
+}
+
+func TestWriteOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	o := NewOrchestrator(&config.WorkflowV2{Name: "out"}, NewLogger("error", false))
+	o.interpolator.Set("params.team", "blue")
+
+	step := &config.StepV2{Name: "report", OutputFile: filepath.Join(dir, "{{params.team}}", "report.md")}
+	o.setStepResult("report", "# First")
+	assert.NoError(t, o.writeOutputFile(step))
+	o.setStepResult("report", "# Second")
+	assert.NoError(t, o.writeOutputFile(step))
+
+	data, err := os.ReadFile(filepath.Join(dir, "blue", "report.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Second\n", string(data))
+
+	log := &config.StepV2{Name: "event", OutputFile: filepath.Join(dir, "events.jsonl"), OutputMode: "append"}
+	for _, event := range []string{`{"n": 1}`, `{"n": 2}`} {
+		o.setStepResult("event", event)
+		assert.NoError(t, o.writeOutputFile(log))
+	}
+	data, err = os.ReadFile(filepath.Join(dir, "events.jsonl"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"n\":1}\n{\"n\":2}\n", string(data))
+}
+
+func TestJoinOutputsPath(t *testing.T) {
+	root := filepath.Join("srv", "outputs")
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "/outputs/report.md", want: filepath.Join(root, "report.md")},
+		{path: "/outputs/a/../b/report.md", want: filepath.Join(root, "b", "report.md")},
+		{path: "/outputs/", want: root},
+		{path: "relative/report.md", want: "relative/report.md"},
+		{path: "/outputs/../x", wantErr: true},
+		{path: "/outputs/a/../../x", wantErr: true},
+		{path: "/outputs/..", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := joinOutputsPath(root, tt.path)
+		if tt.wantErr {
+			assert.Error(t, err, tt.path)
+			continue
+		}
+		assert.NoError(t, err, tt.path)
+		assert.Equal(t, tt.want, got, tt.path)
+	}
+
+	o := NewOrchestrator(&config.WorkflowV2{Name: "out"}, NewLogger("error", false))
+	step := &config.StepV2{Name: "report", OutputFile: "/outputs/../escape.md"}
+	o.setStepResult("report", "# Report")
+	assert.Error(t, o.writeOutputFile(step))
+}
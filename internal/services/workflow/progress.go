@@ -0,0 +1,46 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/progress"
+)
+
+// startProgress identifies the run in progress events and reports its
+// start. The returned function reports its completion.
+func (o *Orchestrator) startProgress() func(err error) {
+	o.progress = progress.Scope{RunID: o.RunID(), Workflow: o.workflow.Name}
+	o.executor.progress = o.progress
+
+	o.progress.Emit(progress.Event{
+		Type:    progress.WorkflowStarted,
+		Version: o.workflow.Version,
+		Steps:   len(o.workflow.Steps),
+	})
+	return func(err error) {
+		event := progress.Event{
+			Type:       progress.WorkflowCompleted,
+			Status:     progress.StatusSucceeded,
+			DurationMs: time.Since(o.runStarted).Milliseconds(),
+		}
+		if err != nil {
+			event.Status = progress.StatusFailed
+			event.Error = err.Error()
+		}
+		o.progress.Emit(event)
+	}
+}
+
+// emitStepCompleted reports a step's outcome
+func (o *Orchestrator) emitStepCompleted(name, status string, err error, duration time.Duration) {
+	event := progress.Event{
+		Type:       progress.StepCompleted,
+		Step:       name,
+		Status:     status,
+		DurationMs: duration.Milliseconds(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	o.progress.Emit(event)
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressEvents(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	path := filepath.Join(t.TempDir(), "progress.ndjson")
+	require.NoError(t, progress.Open(path))
+	defer progress.Close()
+
+	wf := &config.WorkflowV2{
+		Name:      "progress_test",
+		Version:   "1.0.0",
+		Execution: config.ExecutionContext{Provider: "ollama", Model: "qwen2.5"},
+		Steps: []config.StepV2{
+			{Name: "echo", Verify: &config.VerifyMode{Command: "echo hi", Dir: t.TempDir(), FailOnError: true}},
+			{Name: "never", Needs: []string{"echo"}, If: "false", Verify: &config.VerifyMode{Command: "echo no", Dir: t.TempDir()}},
+			{Name: "fail", Needs: []string{"never"}, Verify: &config.VerifyMode{Command: "exit 3", Dir: t.TempDir(), FailOnError: true}},
+		},
+	}
+	o := NewOrchestrator(wf, NewLogger("error", false))
+	o.SetRunID("run-42")
+	assert.Error(t, o.Execute(context.Background(), ""))
+	require.NoError(t, progress.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var events []progress.Event
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var event progress.Event
+		require.NoError(t, json.Unmarshal([]byte(line), &event), line)
+		assert.Equal(t, "run-42", event.RunID)
+		assert.Equal(t, "progress_test", event.Workflow)
+		events = append(events, event)
+	}
+
+	var summary []string
+	for _, event := range events {
+		summary = append(summary, strings.Join(strings.Fields(event.Type+" "+event.Step+" "+event.Status), " "))
+	}
+	assert.Equal(t, []string{
+		"workflow_started",
+		"step_started echo",
+		"step_completed echo succeeded",
+		"step_started never",
+		"step_completed never skipped",
+		"step_started fail",
+		"step_completed fail failed",
+		"workflow_completed failed",
+	}, summary)
+	assert.Equal(t, 3, events[0].Steps)
+	assert.Equal(t, 3, events[5].Index)
+	assert.NotEmpty(t, events[len(events)-1].Error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
 	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
@@ -40,25 +41,47 @@ func (o *Orchestrator) executeRagStep(ctx context.Context, step *config.StepV2)
 
 	o.logger.Debug("RAG query: %s", query)
 
-	// Single server search
-	serverName := ragMode.Server
-	if serverName == "" {
-		serverName = ragConfig.DefaultServer
+	namespace, err := o.interpolator.Interpolate(ragMode.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate namespace: %w", err)
 	}
-	if serverName == "" {
-		return fmt.Errorf("no server specified and no default server in RAG config")
+	filter, err := o.interpolateRagFilter(ragMode.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate filter: %w", err)
 	}
 
 	req := rag.SearchRequest{
 		Query:       query,
-		Server:      serverName,
 		Strategies:  ragMode.Strategies,
 		TopK:        ragMode.TopK,
 		Fusion:      ragMode.Fusion,
 		ExpandQuery: ragMode.ExpandQuery,
+		Filter:      filter,
+		Namespace:   namespace,
 	}
 
-	response, err := ragService.Search(ctx, req)
+	var response *rag.SearchResponse
+	if len(ragMode.Servers) > 0 {
+		// Federated search across several servers
+		sources := make([]rag.Source, len(ragMode.Servers))
+		for i, server := range ragMode.Servers {
+			sources[i] = rag.Source{Server: server, Weight: ragMode.Weights[server]}
+		}
+		response, err = ragService.FederatedSearch(ctx, req, sources)
+		if err == nil && len(response.FailedServers) > 0 {
+			o.logger.Warn("RAG step %s: no results from %s", step.Name, strings.Join(response.FailedServers, ", "))
+		}
+	} else {
+		// Single server search
+		req.Server = ragMode.Server
+		if req.Server == "" {
+			req.Server = ragConfig.DefaultServer
+		}
+		if req.Server == "" {
+			return fmt.Errorf("no server specified and no default server in RAG config")
+		}
+		response, err = ragService.Search(ctx, req)
+	}
 	if err != nil {
 		return fmt.Errorf("RAG search failed: %w", err)
 	}
@@ -68,6 +91,9 @@ func (o *Orchestrator) executeRagStep(ctx context.Context, step *config.StepV2)
 	outputFormat := ragMode.OutputFormat
 	if outputFormat == "" {
 		outputFormat = "json"
+		if ragMode.Citations {
+			outputFormat = "text"
+		}
 	}
 
 	switch outputFormat {
@@ -86,38 +112,89 @@ func (o *Orchestrator) executeRagStep(ctx context.Context, step *config.StepV2)
 		output = string(data)
 
 	case "text":
-		output = formatRagResultsAsText(response)
+		label := ""
+		if ragMode.Citations {
+			label = step.Name
+		}
+		output = formatRagResultsAsText(response, label)
 
 	default:
 		return fmt.Errorf("unsupported output format: %s", outputFormat)
 	}
 
 	// Store results
-	o.stepResults[step.Name] = output
-	o.interpolator.SetStepResult(step.Name, output)
+	o.setStepResult(step.Name, output)
 
 	// Also store structured results for easier access
 	resultsJSON, _ := json.Marshal(response.Results)
 	o.interpolator.Set(fmt.Sprintf("%s.results", step.Name), string(resultsJSON))
 	o.interpolator.Set(fmt.Sprintf("%s.total_results", step.Name), fmt.Sprintf("%d", response.TotalResults))
 	o.interpolator.Set(fmt.Sprintf("%s.fusion_method", step.Name), response.Fusion)
+	if ragMode.Citations {
+		o.setCitable(step.Name, response.Results)
+	}
 
 	o.logger.Info("✓ RAG step completed: %d results", response.TotalResults)
 
 	return nil
 }
 
-// formatRagResultsAsText formats RAG results as human-readable text
-func formatRagResultsAsText(response *rag.SearchResponse) string {
+// interpolateRagFilter returns a copy of a rag filter with its strings
+// interpolated
+func (o *Orchestrator) interpolateRagFilter(filter *config.RagFilter) (*config.RagFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	var err error
+	interpolate := func(text string) string {
+		if err != nil {
+			return text
+		}
+		var result string
+		result, err = o.interpolator.Interpolate(text)
+		return result
+	}
+
+	out := &config.RagFilter{
+		PathPrefix: interpolate(filter.PathPrefix),
+		After:      interpolate(filter.After),
+		Before:     interpolate(filter.Before),
+	}
+	for _, tag := range filter.Tags {
+		out.Tags = append(out.Tags, interpolate(tag))
+	}
+	if len(filter.Equals) > 0 {
+		out.Equals = make(map[string]interface{}, len(filter.Equals))
+		for key, value := range filter.Equals {
+			if text, ok := value.(string); ok {
+				value = interpolate(text)
+			}
+			out.Equals[key] = value
+		}
+	}
+	return out, err
+}
+
+// formatRagResultsAsText formats RAG results as human-readable text. With a
+// citation label each result is headed [label:n] and the text ends by asking
+// answers to cite results that way.
+func formatRagResultsAsText(response *rag.SearchResponse, label string) string {
 	var output string
 
 	output += fmt.Sprintf("Query: %s\n", response.Query)
 	output += fmt.Sprintf("Results: %d\n\n", response.TotalResults)
 
 	for i, result := range response.Results {
-		output += fmt.Sprintf("--- Result %d (score: %.4f) ---\n", i+1, result.CombinedScore)
+		if label != "" {
+			output += fmt.Sprintf("--- [%s] (score: %.4f) ---\n", citationLabel(label, i+1), result.CombinedScore)
+		} else {
+			output += fmt.Sprintf("--- Result %d (score: %.4f) ---\n", i+1, result.CombinedScore)
+		}
 		output += fmt.Sprintf("ID: %s\n", result.ID)
 
+		if result.Server != "" {
+			output += fmt.Sprintf("Server: %s\n", result.Server)
+		}
 		if result.Source != "" {
 			output += fmt.Sprintf("Source: %s\n", result.Source)
 		}
@@ -137,5 +214,9 @@ func formatRagResultsAsText(response *rag.SearchResponse) string {
 		output += "\n"
 	}
 
+	if label != "" && len(response.Results) > 0 {
+		output += fmt.Sprintf("Cite the results you use by their labels in square brackets, for example [%s].\n", citationLabel(label, 1))
+	}
+
 	return output
 }
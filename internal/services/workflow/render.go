@@ -0,0 +1,154 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/render"
+)
+
+// executeRenderStep renders Markdown or a Go template to an HTML or PDF report
+func (o *Orchestrator) executeRenderStep(ctx context.Context, step *config.StepV2) error {
+	renderMode := step.Render
+	if renderMode == nil {
+		return fmt.Errorf("render mode is nil")
+	}
+
+	format := strings.ToLower(renderMode.Format)
+	if format == "" {
+		format = "html"
+	}
+
+	o.logger.Info("📝 Rendering %s report: %s", strings.ToUpper(format), step.Name)
+
+	source, err := o.renderSource(step.Name, renderMode)
+	if err != nil {
+		return err
+	}
+
+	title := renderMode.Title
+	if title != "" {
+		if title, err = o.interpolator.Interpolate(title); err != nil {
+			return fmt.Errorf("failed to interpolate title: %w", err)
+		}
+	} else {
+		title = step.Name
+	}
+
+	// Resolve output path (default: /outputs/<step>.<format>)
+	outputPath := renderMode.Output
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("/outputs/%s.%s", step.Name, format)
+	}
+	if outputPath, err = o.interpolator.Interpolate(outputPath); err != nil {
+		return fmt.Errorf("failed to interpolate output: %w", err)
+	}
+	if outputPath, err = o.resolveOutputsPath(outputPath); err != nil {
+		return err
+	}
+
+	var data []byte
+	switch format {
+	case "html":
+		body, err := render.MarkdownToHTML(source)
+		if err != nil {
+			return err
+		}
+
+		stylesheet := ""
+		if renderMode.Stylesheet != "" {
+			css, err := os.ReadFile(renderMode.Stylesheet)
+			if err != nil {
+				return fmt.Errorf("failed to read stylesheet: %w", err)
+			}
+			stylesheet = string(css)
+		}
+
+		data = []byte(render.HTMLDocument(title, body, stylesheet))
+
+	case "pdf":
+		data, err = render.MarkdownToPDF(source, title)
+		if err != nil {
+			return fmt.Errorf("failed to render PDF: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unsupported render format: %s (supported: html, pdf)", format)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	// Step result is the report path; the rendered Markdown is kept for later steps
+	o.setStepResult(step.Name, outputPath)
+	o.interpolator.Set(fmt.Sprintf("%s.path", step.Name), outputPath)
+	o.interpolator.Set(fmt.Sprintf("%s.format", step.Name), format)
+	o.interpolator.Set(fmt.Sprintf("%s.markdown", step.Name), source)
+
+	o.logger.Info("✓ Report rendered: %s (%d bytes)", outputPath, len(data))
+
+	return nil
+}
+
+// renderSource produces the Markdown for a render step from markdown, template or template_file
+func (o *Orchestrator) renderSource(stepName string, renderMode *config.RenderMode) (string, error) {
+	if renderMode.Markdown != "" {
+		source, err := o.interpolator.Interpolate(renderMode.Markdown)
+		if err != nil {
+			return "", fmt.Errorf("failed to interpolate markdown: %w", err)
+		}
+		return source, nil
+	}
+
+	tmpl := renderMode.Template
+	if renderMode.TemplateFile != "" {
+		content, err := os.ReadFile(renderMode.TemplateFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template file: %w", err)
+		}
+		tmpl = string(content)
+	}
+
+	// Templates see every workflow variable (step results, inputs, env.*) under .Vars
+	data := map[string]interface{}{
+		"Vars":     o.interpolator.Variables(),
+		"Workflow": o.workflow.Name,
+		"Step":     stepName,
+	}
+
+	return render.ExecuteTemplate(stepName, tmpl, data)
+}
+
+// resolveOutputsPath maps /outputs/ paths to the configured outputs directory
+func (o *Orchestrator) resolveOutputsPath(path string) (string, error) {
+	outputsDir := "/tmp/mcp-outputs"
+	if o.appConfig != nil && o.appConfig.Skills != nil {
+		outputsDir = o.appConfig.Skills.GetOutputsDir()
+	}
+	return joinOutputsPath(outputsDir, path)
+}
+
+// joinOutputsPath rewrites an /outputs/ path onto outputsDir. The remainder is
+// cleaned first, and a path such as /outputs/../x that would land outside
+// outputsDir is an error. Paths without the /outputs/ prefix are returned as-is.
+func joinOutputsPath(outputsDir, path string) (string, error) {
+	if !strings.HasPrefix(path, "/outputs/") {
+		return path, nil
+	}
+	rel := strings.TrimPrefix(path, "/outputs/")
+	if rel == "" {
+		return outputsDir, nil
+	}
+	if !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return "", fmt.Errorf("path %s escapes the outputs directory", path)
+	}
+	return filepath.Join(outputsDir, rel), nil
+}
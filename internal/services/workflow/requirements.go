@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"sort"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// RequiredServers returns the MCP servers a workflow uses, sorted. RAG
+// step servers are resolved to their MCP servers through ragConfig, which
+// may be nil. Skill names may be included; split them off with
+// skills.SeparateSkillsFromServers.
+func RequiredServers(wf *config.WorkflowV2, ragConfig *config.RagConfig) []string {
+	serverSet := make(map[string]bool)
+
+	// Collect from execution level
+	for _, server := range wf.Execution.Servers {
+		serverSet[server] = true
+	}
+
+	// Resolve a RAG server name to its MCP server name
+	resolveRagServer := func(ragServerName string) string {
+		if ragConfig != nil {
+			if ragServerConfig, exists := ragConfig.Servers[ragServerName]; exists {
+				logging.Debug("Resolved RAG server '%s' to MCP server '%s'", ragServerName, ragServerConfig.MCPServer)
+				return ragServerConfig.MCPServer
+			}
+		}
+		// If not found in RAG config, assume it's an MCP server name directly
+		logging.Debug("RAG server '%s' not in config, using as-is", ragServerName)
+		return ragServerName
+	}
+
+	// Collect from steps
+	for _, step := range wf.Steps {
+		for _, server := range step.Servers {
+			serverSet[server] = true
+		}
+
+		if step.Rag == nil || ragConfig == nil {
+			continue
+		}
+		if step.Rag.Server != "" {
+			if mcpServer := resolveRagServer(step.Rag.Server); mcpServer != "" {
+				serverSet[mcpServer] = true
+			}
+		}
+		for _, ragServer := range step.Rag.Servers {
+			if mcpServer := resolveRagServer(ragServer); mcpServer != "" {
+				serverSet[mcpServer] = true
+			}
+		}
+	}
+
+	return sortedKeys(serverSet)
+}
+
+// RequiredSkills returns the built-in skills a workflow uses, sorted
+func RequiredSkills(wf *config.WorkflowV2) []string {
+	skillSet := make(map[string]bool)
+	for _, skill := range wf.Execution.Skills {
+		skillSet[skill] = true
+	}
+	for _, step := range wf.Steps {
+		for _, skill := range step.Skills {
+			skillSet[skill] = true
+		}
+	}
+	return sortedKeys(skillSet)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
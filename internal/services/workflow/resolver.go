@@ -93,6 +93,39 @@ func (r *PropertyResolver) ResolveMaxTokens(step *config.StepV2) int {
 	return 4096
 }
 
+// ApplySampling sets the temperature, max tokens and generation params the
+// step or execution sets on a provider's config, over its defaults from
+// settings. Settings neither sets keep the provider's values.
+func (r *PropertyResolver) ApplySampling(step *config.StepV2, providerConfig *config.ProviderConfig) {
+	switch {
+	case step.Temperature != nil:
+		providerConfig.Temperature = *step.Temperature
+	case r.execution.Temperature != 0:
+		providerConfig.Temperature = r.execution.Temperature
+	}
+
+	switch {
+	case step.MaxTokens != nil:
+		providerConfig.MaxTokens = *step.MaxTokens
+	case r.execution.MaxTokens != 0:
+		providerConfig.MaxTokens = r.execution.MaxTokens
+	}
+
+	providerConfig.GenerationParams = providerConfig.GenerationParams.
+		Merge(r.execution.GenerationParams).
+		Merge(step.GenerationParams)
+}
+
+// ResolveStepTimeout resolves the timeout a step or its execution context
+// sets, or 0 when neither does: without one, a step's tool calls can run for
+// as long as they need
+func (r *PropertyResolver) ResolveStepTimeout(step *config.StepV2) time.Duration {
+	if step.Timeout != nil {
+		return *step.Timeout
+	}
+	return r.execution.Timeout
+}
+
 // ResolveTimeout resolves timeout duration
 func (r *PropertyResolver) ResolveTimeout(step *config.StepV2) time.Duration {
 	// Step override
@@ -209,8 +242,9 @@ func (r *PropertyResolver) ResolveConsensusTimeout(
 	return 30 * time.Second
 }
 
-// ResolveMaxIterations resolves max iterations for agentic execution
-func (r *PropertyResolver) ResolveMaxIterations(step *config.StepV2) int {
+// ResolveMaxIterations resolves max iterations for agentic execution,
+// falling back to the tool_iterations.workflow setting
+func (r *PropertyResolver) ResolveMaxIterations(step *config.StepV2, settings *config.ToolIterationsConfig) int {
 	// Step override
 	if step.MaxIterations != nil {
 		return *step.MaxIterations
@@ -221,6 +255,6 @@ func (r *PropertyResolver) ResolveMaxIterations(step *config.StepV2) int {
 		return r.execution.MaxIterations
 	}
 
-	// Default for workflows (higher than query mode's default of 2)
-	return 10
+	// Settings default
+	return settings.WorkflowLimit()
 }
@@ -265,6 +265,48 @@ func TestResolveTimeout(t *testing.T) {
 	}
 }
 
+func TestApplySampling(t *testing.T) {
+	execution := &config.ExecutionContext{
+		Temperature: 0.5,
+		GenerationParams: config.GenerationParams{
+			TopP: ptrFloat64(0.9),
+			Stop: []string{"END"},
+		},
+	}
+	resolver := NewPropertyResolver(execution)
+
+	providerConfig := config.ProviderConfig{
+		Temperature: 0.7,
+		MaxTokens:   1024,
+		GenerationParams: config.GenerationParams{
+			TopP:             ptrFloat64(1),
+			FrequencyPenalty: ptrFloat64(0.2),
+		},
+	}
+	step := &config.StepV2{
+		MaxTokens: ptrInt(256),
+		GenerationParams: config.GenerationParams{
+			TopK: ptrInt(40),
+			Stop: []string{"###"},
+		},
+	}
+	resolver.ApplySampling(step, &providerConfig)
+
+	assert.Equal(t, 0.5, providerConfig.Temperature)
+	assert.Equal(t, 256, providerConfig.MaxTokens)
+	assert.Equal(t, 0.9, *providerConfig.TopP)
+	assert.Equal(t, 40, *providerConfig.TopK)
+	assert.Equal(t, 0.2, *providerConfig.FrequencyPenalty)
+	assert.Equal(t, []string{"###"}, providerConfig.Stop)
+
+	// Without execution or step settings the provider's own are kept
+	defaults := config.ProviderConfig{Temperature: 0.3, MaxTokens: 512}
+	NewPropertyResolver(&config.ExecutionContext{}).ApplySampling(&config.StepV2{}, &defaults)
+	assert.Equal(t, 0.3, defaults.Temperature)
+	assert.Equal(t, 512, defaults.MaxTokens)
+	assert.True(t, defaults.GenerationParams.IsZero())
+}
+
 // Helper functions
 func ptrFloat64(f float64) *float64 {
 	return &f
@@ -0,0 +1,98 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// unsafeNameChars matches characters not allowed in artifact directory names
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// runArtifactsDir returns the directory for this run's artifacts, creating it
+// on first use. Runs are stored as <artifacts_dir>/<workflow>-<timestamp>.
+func (o *Orchestrator) runArtifactsDir() (string, error) {
+	o.runDirMu.Lock()
+	defer o.runDirMu.Unlock()
+
+	if o.runDir != "" {
+		return o.runDir, nil
+	}
+
+	baseDir := o.workflow.Execution.ArtifactsDir
+	if baseDir == "" {
+		baseDir = "/outputs/runs"
+	}
+	baseDir, err := o.resolveOutputsPath(baseDir)
+	if err != nil {
+		return "", err
+	}
+
+	started := o.runStarted
+	if started.IsZero() {
+		started = time.Now()
+	}
+
+	name := unsafeNameChars.ReplaceAllString(o.workflow.Name, "_")
+	if name == "" {
+		name = "workflow"
+	}
+
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create run artifacts directory: %w", err)
+	}
+
+	// Runs started in the same second get a numeric suffix
+	stem := filepath.Join(baseDir, fmt.Sprintf("%s-%s", name, started.Format("20060102-150405")))
+	dir := stem
+	for n := 2; ; n++ {
+		err := os.Mkdir(dir, 0755)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) {
+			return "", fmt.Errorf("failed to create run artifacts directory: %w", err)
+		}
+		dir = fmt.Sprintf("%s-%d", stem, n)
+	}
+
+	o.runDir = dir
+	return dir, nil
+}
+
+// exportTimeline writes the parallel execution timeline as timeline.html and
+// trace.json (chrome://tracing) into the run artifacts directory. Failures are
+// logged rather than returned so they never mask the workflow result.
+func (o *Orchestrator) exportTimeline(pool *WorkflowWorkerPool) {
+	// Early returns on failure skip the normal End() call
+	if pool.timeline.GetTotalDuration() == 0 {
+		pool.timeline.End()
+	}
+
+	dir, err := o.runArtifactsDir()
+	if err != nil {
+		o.logger.Warn("Timeline export skipped: %v", err)
+		return
+	}
+
+	stepErrors := pool.GetAllErrors()
+
+	trace, err := pool.timeline.ExportChromeTrace(o.workflow.Name, stepErrors)
+	if err != nil {
+		o.logger.Warn("Failed to export trace: %v", err)
+	} else if err := os.WriteFile(filepath.Join(dir, "trace.json"), trace, 0644); err != nil {
+		o.logger.Warn("Failed to write trace: %v", err)
+	}
+
+	title := fmt.Sprintf("%s v%s — execution timeline", o.workflow.Name, o.workflow.Version)
+	page, err := pool.timeline.ExportHTML(title, stepErrors)
+	if err != nil {
+		o.logger.Warn("Failed to export timeline: %v", err)
+	} else if err := os.WriteFile(filepath.Join(dir, "timeline.html"), []byte(page), 0644); err != nil {
+		o.logger.Warn("Failed to write timeline: %v", err)
+	}
+
+	o.logger.Info("Timeline exported to %s", dir)
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// RunID returns the identifier of the current run, used in log file names
+func (o *Orchestrator) RunID() string {
+	if o.runID == "" {
+		started := o.runStarted
+		if started.IsZero() {
+			started = time.Now()
+		}
+		o.runID = fmt.Sprintf("%s-%04x", started.Format("20060102-150405"), rand.IntN(0x10000))
+	}
+	return o.runID
+}
+
+// SetRunID sets the run identifier, for callers that already have one
+func (o *Orchestrator) SetRunID(id string) {
+	o.runID = id
+}
+
+// applyLoggingConfig applies the workflow's logging block: quiet console
+// and the log file. A file that can't be opened is reported on the
+// console and the run continues without it.
+func (o *Orchestrator) applyLoggingConfig() {
+	cfg := o.workflow.Logging
+	if cfg == nil {
+		return
+	}
+	if cfg.Quiet {
+		o.logger.SetQuiet(true)
+	}
+	if cfg.File == nil || cfg.File.Path == "" {
+		return
+	}
+
+	path, err := o.logFilePath(cfg.File.Path)
+	if err != nil {
+		o.logger.Warn("Workflow log file disabled: %v", err)
+		return
+	}
+	if err := o.logger.OpenFile(path, cfg.File.Level, cfg.File.MaxSizeMB, cfg.File.MaxBackups); err != nil {
+		o.logger.Warn("Workflow log file disabled: %v", err)
+		return
+	}
+	o.logger.Debug("Writing workflow log to %s (run %s)", path, o.RunID())
+}
+
+// logFilePath expands {{workflow}}, {{run_id}} and {{date}} in a log file
+// path and maps /outputs/ to the outputs directory
+func (o *Orchestrator) logFilePath(template string) (string, error) {
+	name := unsafeNameChars.ReplaceAllString(o.workflow.Name, "_")
+	if name == "" {
+		name = "workflow"
+	}
+	started := o.runStarted
+	if started.IsZero() {
+		started = time.Now()
+	}
+	path := strings.NewReplacer(
+		"{{workflow}}", name,
+		"{{run_id}}", o.RunID(),
+		"{{date}}", started.Format("2006-01-02"),
+	).Replace(template)
+	return o.resolveOutputsPath(path)
+}
@@ -0,0 +1,290 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// RunRecordFile is the record written to the run artifacts directory when
+// execution.record_run is set
+const RunRecordFile = "run.json"
+
+// Run and step statuses recorded in run.json
+const (
+	RunSucceeded  = "succeeded"
+	RunFailed     = "failed"
+	StepSucceeded = "ok"
+	StepFailed    = "failed"
+	StepSkipped   = "skipped"
+)
+
+// RunRecord is the recorded outcome of one workflow run
+type RunRecord struct {
+	Workflow   string            `json:"workflow"`
+	Version    string            `json:"version,omitempty"`
+	Started    time.Time         `json:"started"`
+	DurationMs int64             `json:"duration_ms"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	Input      string            `json:"input,omitempty"`
+	Params     map[string]string `json:"params,omitempty"`
+	Provider   string            `json:"provider,omitempty"`
+	Model      string            `json:"model,omitempty"`
+	Steps      []StepRecord      `json:"steps"`
+
+	Dir string `json:"-"` // Directory the record was loaded from
+}
+
+// StepRecord is one step's configuration and output within a recorded run
+type StepRecord struct {
+	Name       string `json:"name"`
+	Provider   string `json:"provider,omitempty"`
+	Model      string `json:"model,omitempty"`
+	Prompt     string `json:"prompt,omitempty"` // Uninterpolated run template or prompt_ref
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Output     string `json:"output,omitempty"`
+}
+
+// Step returns the named step, or nil
+func (r *RunRecord) Step(name string) *StepRecord {
+	for i := range r.Steps {
+		if r.Steps[i].Name == name {
+			return &r.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Name returns the run's directory name, which identifies it in runs commands
+func (r *RunRecord) Name() string {
+	return filepath.Base(r.Dir)
+}
+
+// stepRun is the outcome of executing one step, kept for the run record
+type stepRun struct {
+	status   string
+	err      string
+	duration time.Duration
+}
+
+// recordStepRun notes a step's outcome for the run record
+func (o *Orchestrator) recordStepRun(name, status string, err error, duration time.Duration) {
+	run := stepRun{status: status, duration: duration}
+	if err != nil {
+		run.err = err.Error()
+	}
+
+	o.stepResultsMu.Lock()
+	if o.stepRuns == nil {
+		o.stepRuns = make(map[string]stepRun)
+	}
+	o.stepRuns[name] = run
+	o.stepResultsMu.Unlock()
+}
+
+// buildRunRecord assembles the record of the finished run. Steps keep
+// definition order; loop results follow in name order. Steps that never
+// ran (start-from, end-at, cancellation) are left out.
+func (o *Orchestrator) buildRunRecord(input string, params map[string]string, runErr error) *RunRecord {
+	exec := o.workflow.Execution
+	record := &RunRecord{
+		Workflow:   o.workflow.Name,
+		Version:    o.workflow.Version,
+		Started:    o.runStarted,
+		DurationMs: time.Since(o.runStarted).Milliseconds(),
+		Status:     RunSucceeded,
+		Input:      runRecordInput(input),
+		Params:     params,
+	}
+	record.Provider, record.Model = primaryProvider(exec.Provider, exec.Model, exec.Providers)
+	if runErr != nil {
+		record.Status = RunFailed
+		record.Error = runErr.Error()
+	}
+
+	seen := make(map[string]bool)
+	for i := range o.workflow.Steps {
+		step := &o.workflow.Steps[i]
+		seen[step.Name] = true
+
+		o.stepResultsMu.RLock()
+		run, ran := o.stepRuns[step.Name]
+		o.stepResultsMu.RUnlock()
+		output, hasOutput := o.GetStepResult(step.Name)
+		if !ran && !hasOutput {
+			continue
+		}
+		if !ran {
+			run.status = StepSucceeded
+		}
+
+		provider, model := primaryProvider(step.Provider, step.Model, step.Providers)
+		if provider == "" {
+			provider = record.Provider
+		}
+		if model == "" {
+			model = record.Model
+		}
+		prompt := step.Run
+		if step.PromptRef != "" {
+			prompt = "prompt_ref: " + step.PromptRef
+		}
+
+		record.Steps = append(record.Steps, StepRecord{
+			Name:       step.Name,
+			Provider:   provider,
+			Model:      model,
+			Prompt:     prompt,
+			Status:     run.status,
+			Error:      run.err,
+			DurationMs: run.duration.Milliseconds(),
+			Output:     output,
+		})
+	}
+
+	o.stepResultsMu.RLock()
+	var others []string
+	for name := range o.stepResults {
+		if !seen[name] {
+			others = append(others, name)
+		}
+	}
+	o.stepResultsMu.RUnlock()
+	sort.Strings(others)
+	for _, name := range others {
+		output, _ := o.GetStepResult(name)
+		record.Steps = append(record.Steps, StepRecord{Name: name, Status: StepSucceeded, Output: output})
+	}
+
+	return record
+}
+
+// primaryProvider returns the provider and model that are tried first
+func primaryProvider(provider, model string, chain []config.ProviderFallback) (string, string) {
+	if provider == "" && len(chain) > 0 {
+		return chain[0].Provider, chain[0].Model
+	}
+	return provider, model
+}
+
+// writeRunRecord saves the run record as run.json in the run artifacts
+// directory. Failures are logged, not returned.
+func (o *Orchestrator) writeRunRecord(input string, params map[string]string, runErr error) {
+	dir, err := o.runArtifactsDir()
+	if err != nil {
+		o.logger.Warn("Run record not saved: %v", err)
+		return
+	}
+	data, err := json.MarshalIndent(o.buildRunRecord(input, params, runErr), "", "  ")
+	if err != nil {
+		o.logger.Warn("Failed to encode run record: %v", err)
+		return
+	}
+	path := filepath.Join(dir, RunRecordFile)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		o.logger.Warn("Failed to write run record: %v", err)
+		return
+	}
+	o.logger.Info("Run recorded to %s", dir)
+}
+
+// RunDir returns the run artifacts directory, or "" if nothing was written
+func (o *Orchestrator) RunDir() string {
+	return o.runDir
+}
+
+// LoadRunRecord reads run.json from a run directory
+func LoadRunRecord(dir string) (*RunRecord, error) {
+	data, err := os.ReadFile(filepath.Join(dir, RunRecordFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s has no %s (was the run recorded with record_run or --record?)", dir, RunRecordFile)
+		}
+		return nil, err
+	}
+	var record RunRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %w", RunRecordFile, dir, err)
+	}
+	record.Dir = dir
+	return &record, nil
+}
+
+// ListRunRecords loads every recorded run under baseDir, newest first.
+// Directories without a run record are skipped.
+func ListRunRecords(baseDir string) ([]*RunRecord, error) {
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var records []*RunRecord
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		record, err := LoadRunRecord(filepath.Join(baseDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Started.After(records[j].Started) })
+	return records, nil
+}
+
+// FindRunRecord resolves a run reference: a run directory path, a run
+// name under baseDir, or a workflow name optionally suffixed with ~N for
+// its Nth previous recorded run (summarize is the latest, summarize~1 the
+// one before)
+func FindRunRecord(baseDir, ref string) (*RunRecord, error) {
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		return LoadRunRecord(ref)
+	}
+	if !strings.ContainsAny(ref, `/\`) {
+		dir := filepath.Join(baseDir, ref)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return LoadRunRecord(dir)
+		}
+	}
+
+	name, back := ref, 0
+	if base, n, ok := strings.Cut(ref, "~"); ok {
+		var err error
+		if back, err = strconv.Atoi(n); err != nil || back < 0 {
+			return nil, fmt.Errorf("invalid run reference '%s': expected <workflow>~N", ref)
+		}
+		name = base
+	}
+
+	records, err := ListRunRecords(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*RunRecord
+	for _, record := range records {
+		if record.Workflow == name {
+			matches = append(matches, record)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no recorded run '%s' in %s", ref, baseDir)
+	}
+	if back >= len(matches) {
+		return nil, fmt.Errorf("workflow '%s' has only %d recorded run(s)", name, len(matches))
+	}
+	return matches[back], nil
+}
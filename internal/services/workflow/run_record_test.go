@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	artifacts := t.TempDir()
+	newWorkflow := func(word string) *config.WorkflowV2 {
+		return &config.WorkflowV2{
+			Name:    "record_test",
+			Version: "1.0.0",
+			Execution: config.ExecutionContext{
+				Provider:     "ollama",
+				Model:        "qwen2.5",
+				ArtifactsDir: artifacts,
+				RecordRun:    true,
+			},
+			Steps: []config.StepV2{
+				{
+					Name:   "echo",
+					Verify: &config.VerifyMode{Command: "echo " + word, Dir: t.TempDir(), FailOnError: true},
+				},
+				{
+					Name:   "never",
+					If:     "false",
+					Verify: &config.VerifyMode{Command: "echo unreachable", Dir: t.TempDir()},
+				},
+			},
+		}
+	}
+
+	for _, word := range []string{"first", "second"} {
+		o := NewOrchestrator(newWorkflow(word), NewLogger("error", false))
+		assert.NoError(t, o.Execute(context.Background(), "input "+word))
+		assert.NotEmpty(t, o.RunDir())
+	}
+
+	latest, err := FindRunRecord(artifacts, "record_test")
+	assert.NoError(t, err)
+	previous, err := FindRunRecord(artifacts, "record_test~1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, latest.Name(), previous.Name())
+	assert.Equal(t, "input second", latest.Input)
+	assert.Equal(t, RunSucceeded, latest.Status)
+
+	if assert.Len(t, latest.Steps, 2) {
+		echo := latest.Step("echo")
+		assert.Equal(t, StepSucceeded, echo.Status)
+		assert.Equal(t, "ollama", echo.Provider)
+		assert.Equal(t, "qwen2.5", echo.Model)
+		assert.Contains(t, echo.Output, "second")
+		assert.Equal(t, StepSkipped, latest.Step("never").Status)
+	}
+
+	_, err = FindRunRecord(artifacts, "record_test~2")
+	assert.Error(t, err)
+	_, err = FindRunRecord(artifacts, "missing")
+	assert.Error(t, err)
+
+	records, err := ListRunRecords(artifacts)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+}
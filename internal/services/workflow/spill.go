@@ -0,0 +1,162 @@
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+)
+
+// DefaultSpillThreshold is the output size above which step results are kept
+// on disk instead of in memory (execution.spill_threshold overrides it)
+const DefaultSpillThreshold = 1 << 20 // 1 MiB
+
+// spillRefPrefix marks a step result that lives in a file. The NUL byte keeps
+// it from colliding with real text output.
+const spillRefPrefix = "\x00spill:"
+
+// SpillStore keeps large step outputs in temp files so the interpolator,
+// step results and worker pool results share a short reference instead of
+// each holding the full text
+type SpillStore struct {
+	threshold int
+
+	mu    sync.Mutex
+	dir   string
+	count int
+}
+
+// NewSpillStore creates a spill store. A threshold of 0 uses
+// DefaultSpillThreshold; a negative threshold disables spilling.
+func NewSpillStore(threshold int) *SpillStore {
+	if threshold == 0 {
+		threshold = DefaultSpillThreshold
+	}
+	return &SpillStore{threshold: threshold}
+}
+
+// Store returns output unchanged when it is small, or a reference to a temp
+// file holding it. If the file can't be written the output stays in memory.
+func (s *SpillStore) Store(name, output string) string {
+	if s == nil || s.threshold < 0 || len(output) <= s.threshold || isSpillRef(output) {
+		return output
+	}
+
+	path, err := s.write(name, strings.NewReader(output))
+	if err != nil {
+		logging.Warn("Keeping %d byte output of %s in memory: %v", len(output), name, err)
+		return output
+	}
+
+	logging.Debug("Spilled %d byte output of %s to %s", len(output), name, path)
+	return spillRefPrefix + path
+}
+
+// Path returns a file containing value, writing one on demand for outputs
+// that were small enough to stay in memory
+func (s *SpillStore) Path(name, value string) (string, error) {
+	if path, ok := spillRefPath(value); ok {
+		return path, nil
+	}
+	if s == nil {
+		return "", fmt.Errorf("no spill store configured")
+	}
+	return s.write(name, strings.NewReader(value))
+}
+
+// Close removes all spilled files
+func (s *SpillStore) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dir == "" {
+		return nil
+	}
+	err := os.RemoveAll(s.dir)
+	s.dir = ""
+	return err
+}
+
+// write copies r to a new file in the store's temp directory. Every write
+// gets its own file so references held by earlier loop iterations stay valid.
+func (s *SpillStore) write(name string, r io.Reader) (string, error) {
+	s.mu.Lock()
+	if s.dir == "" {
+		dir, err := os.MkdirTemp("", "mcp-cli-outputs-")
+		if err != nil {
+			s.mu.Unlock()
+			return "", fmt.Errorf("failed to create spill directory: %w", err)
+		}
+		s.dir = dir
+	}
+	s.count++
+	safeName := unsafeNameChars.ReplaceAllString(name, "_")
+	path := filepath.Join(s.dir, fmt.Sprintf("%03d-%s.txt", s.count, safeName))
+	s.mu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create spill file: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write spill file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write spill file: %w", err)
+	}
+	return path, nil
+}
+
+// isSpillRef reports whether value is a reference to a spilled output
+func isSpillRef(value string) bool {
+	return strings.HasPrefix(value, spillRefPrefix)
+}
+
+// spillRefPath extracts the file path from a spill reference
+func spillRefPath(value string) (string, bool) {
+	if !isSpillRef(value) {
+		return "", false
+	}
+	return strings.TrimPrefix(value, spillRefPrefix), true
+}
+
+// loadSpilled returns the full text of value, reading it back from disk if
+// it is a spill reference
+func loadSpilled(value string) (string, error) {
+	path, ok := spillRefPath(value)
+	if !ok {
+		return value, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read spilled output: %w", err)
+	}
+	return string(data), nil
+}
+
+// copySpilled writes the full text of value to w, streaming spilled outputs
+// from disk without loading them into memory
+func copySpilled(w io.Writer, value string) error {
+	path, ok := spillRefPath(value)
+	if !ok {
+		_, err := io.WriteString(w, value)
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read spilled output: %w", err)
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,127 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/database"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/env"
+)
+
+// executeSQLStep executes a database query step
+func (o *Orchestrator) executeSQLStep(ctx context.Context, step *config.StepV2) error {
+	sqlMode := step.SQL
+	if sqlMode == nil {
+		return fmt.Errorf("sql mode is nil")
+	}
+
+	o.logger.Info("🗄️  Executing SQL step: %s", step.Name)
+
+	// Resolve connection string (workflow variables first, then ${ENV} references)
+	dsn, err := o.interpolator.Interpolate(sqlMode.DSN)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate dsn: %w", err)
+	}
+	dsn = env.ExpandEnv(dsn)
+
+	// Interpolate query
+	query, err := o.interpolator.Interpolate(sqlMode.Query)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate query: %w", err)
+	}
+
+	// Interpolate string parameters; other types are passed through as-is
+	params := make([]interface{}, len(sqlMode.Params))
+	for i, param := range sqlMode.Params {
+		if str, ok := param.(string); ok {
+			interpolated, err := o.interpolator.Interpolate(str)
+			if err != nil {
+				return fmt.Errorf("failed to interpolate params[%d]: %w", i, err)
+			}
+			params[i] = interpolated
+		} else {
+			params[i] = param
+		}
+	}
+
+	// Read-only by default
+	readOnly := true
+	if sqlMode.ReadOnly != nil {
+		readOnly = *sqlMode.ReadOnly
+	}
+
+	o.logger.Debug("SQL query (%s, read_only=%v): %s", sqlMode.Driver, readOnly, query)
+
+	client, err := database.Open(sqlMode.Driver, dsn)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.Query(ctx, query, params, database.QueryOptions{
+		ReadOnly: readOnly,
+		MaxRows:  sqlMode.MaxRows,
+		Timeout:  sqlMode.Timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("SQL step failed: %w", err)
+	}
+
+	if result.Truncated {
+		o.logger.Warn("SQL result truncated to %d rows (raise sql.max_rows to return more)", result.RowCount)
+	}
+
+	// Format output based on configuration
+	var output string
+	outputFormat := sqlMode.OutputFormat
+	if outputFormat == "" {
+		outputFormat = "json"
+	}
+
+	switch outputFormat {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format results: %w", err)
+		}
+		output = string(data)
+
+	case "compact":
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to format results: %w", err)
+		}
+		output = string(data)
+
+	case "rows":
+		data, err := json.MarshalIndent(result.Rows, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format results: %w", err)
+		}
+		output = string(data)
+
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	// Store results
+	o.setStepResult(step.Name, output)
+
+	// Also store structured results for easier access
+	rowsJSON, _ := json.Marshal(result.Rows)
+	columnsJSON, _ := json.Marshal(result.Columns)
+	o.interpolator.Set(fmt.Sprintf("%s.rows", step.Name), string(rowsJSON))
+	o.interpolator.Set(fmt.Sprintf("%s.columns", step.Name), string(columnsJSON))
+	o.interpolator.Set(fmt.Sprintf("%s.row_count", step.Name), fmt.Sprintf("%d", result.RowCount))
+	o.interpolator.Set(fmt.Sprintf("%s.truncated", step.Name), fmt.Sprintf("%t", result.Truncated))
+
+	if result.RowsAffected > 0 {
+		o.logger.Info("✓ SQL step completed: %d rows affected", result.RowsAffected)
+	} else {
+		o.logger.Info("✓ SQL step completed: %d rows", result.RowCount)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/storage"
+)
+
+// executeStorageStep executes an object storage get/put step
+func (o *Orchestrator) executeStorageStep(ctx context.Context, step *config.StepV2) error {
+	storageMode := step.Storage
+	if storageMode == nil {
+		return fmt.Errorf("storage mode is nil")
+	}
+
+	// Get storage configuration from already-loaded app config
+	if o.appConfig == nil || o.appConfig.Storage == nil {
+		return fmt.Errorf("storage configuration not loaded (add a storage: section to settings.yaml)")
+	}
+
+	backendConfig, backendName, ok := o.appConfig.Storage.GetBackend(storageMode.Backend)
+	if !ok {
+		if backendName == "" {
+			return fmt.Errorf("no storage backend specified and no default_backend in storage config")
+		}
+		return fmt.Errorf("storage backend '%s' not found in config", backendName)
+	}
+
+	store, err := storage.New(backendConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage backend '%s': %w", backendName, err)
+	}
+
+	key, err := o.interpolator.Interpolate(storageMode.Key)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate key: %w", err)
+	}
+
+	o.logger.Info("☁️  Executing storage %s: %s (%s)", storageMode.Action, key, backendName)
+
+	switch storageMode.Action {
+	case "get":
+		return o.executeStorageGet(ctx, step, store, key)
+	case "put":
+		return o.executeStoragePut(ctx, step, store, key)
+	default:
+		return fmt.Errorf("unsupported storage action: %s", storageMode.Action)
+	}
+}
+
+// executeStorageGet downloads an object and stores its content (or saved path) as the step result
+func (o *Orchestrator) executeStorageGet(ctx context.Context, step *config.StepV2, store storage.Store, key string) error {
+	obj, err := store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	contentType := obj.ContentType
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = storage.DetectContentType(key, obj.Data)
+	}
+
+	output := string(obj.Data)
+
+	if step.Storage.File != "" {
+		localPath, err := o.interpolator.Interpolate(step.Storage.File)
+		if err != nil {
+			return fmt.Errorf("failed to interpolate file: %w", err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(localPath, obj.Data, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+
+		o.logger.Info("Saved %d bytes to %s", len(obj.Data), localPath)
+		output = localPath
+		o.interpolator.Set(fmt.Sprintf("%s.path", step.Name), localPath)
+	} else if !utf8.Valid(obj.Data) {
+		return fmt.Errorf("object %s is binary (%s); set storage.file to save it to disk", key, contentType)
+	}
+
+	o.setStepResult(step.Name, output)
+	o.interpolator.Set(fmt.Sprintf("%s.key", step.Name), key)
+	o.interpolator.Set(fmt.Sprintf("%s.content_type", step.Name), contentType)
+	o.interpolator.Set(fmt.Sprintf("%s.size", step.Name), fmt.Sprintf("%d", len(obj.Data)))
+
+	o.logger.Info("✓ Storage get completed: %d bytes (%s)", len(obj.Data), contentType)
+
+	return nil
+}
+
+// executeStoragePut uploads content or a local file and stores the object URL as the step result
+func (o *Orchestrator) executeStoragePut(ctx context.Context, step *config.StepV2, store storage.Store, key string) error {
+	var data []byte
+	if step.Storage.File != "" {
+		localPath, err := o.interpolator.Interpolate(step.Storage.File)
+		if err != nil {
+			return fmt.Errorf("failed to interpolate file: %w", err)
+		}
+		data, err = os.ReadFile(localPath)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+	} else {
+		content, err := o.interpolator.Interpolate(step.Storage.Content)
+		if err != nil {
+			return fmt.Errorf("failed to interpolate content: %w", err)
+		}
+		data = []byte(content)
+	}
+
+	contentType := step.Storage.ContentType
+	if contentType == "" {
+		contentType = storage.DetectContentType(key, data)
+	}
+
+	objectURL, err := store.Put(ctx, key, data, contentType)
+	if err != nil {
+		return err
+	}
+
+	o.setStepResult(step.Name, objectURL)
+	o.interpolator.Set(fmt.Sprintf("%s.key", step.Name), key)
+	o.interpolator.Set(fmt.Sprintf("%s.url", step.Name), objectURL)
+	o.interpolator.Set(fmt.Sprintf("%s.content_type", step.Name), contentType)
+	o.interpolator.Set(fmt.Sprintf("%s.size", step.Name), fmt.Sprintf("%d", len(data)))
+
+	o.logger.Info("✓ Storage put completed: %d bytes → %s", len(data), objectURL)
+
+	return nil
+}
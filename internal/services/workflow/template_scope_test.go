@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateStepScoping(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	dir := t.TempDir()
+	sub := &config.WorkflowV2{
+		Name:   "sub",
+		Params: map[string]config.PromptParameter{"items": {}},
+		Steps: []config.StepV2{{
+			Name: "write",
+			Verify: &config.VerifyMode{
+				Command: "true",
+				Dir:     dir,
+				Files:   map[string]string{"out.txt": "{{summary}}|{{step.summary}}|{{params.items}}|{{input}}"},
+			},
+		}},
+	}
+	parent := &config.WorkflowV2{Name: "parent"}
+	o := NewOrchestrator(parent, NewLogger("error", false))
+	o.SetAppConfigForWorkflows(&config.ApplicationConfig{Workflows: map[string]*config.WorkflowV2{"sub": sub, "parent": parent}})
+	o.setStepResult("summary", "short")
+	o.interpolator.Set("topic", "go")
+
+	step := &config.StepV2{
+		Name: "call",
+		Template: &config.TemplateMode{
+			Name:    "sub",
+			With:    map[string]interface{}{"input": map[string]interface{}{"topic": "{{topic}}"}, "items": []interface{}{"{{topic}}", "b"}},
+			Inherit: []string{"summary"},
+			Outputs: map[string]string{"passed": "write.passed", "written": "write"},
+		},
+	}
+	assert.NoError(t, o.executeWorkflowStep(context.Background(), step))
+
+	data, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, `short|short|["go","b"]|{"topic":"go"}`, string(data))
+
+	passed, _ := o.GetStepResult("passed")
+	assert.Equal(t, "true", passed)
+	written, _ := o.interpolator.GetVariable("written")
+	assert.Contains(t, written, "PASS")
+
+	// Inheriting or mapping names that don't exist fails the step
+	step.Template.Inherit = []string{"missing"}
+	assert.ErrorContains(t, o.executeWorkflowStep(context.Background(), step), "inherit: 'missing' is not defined")
+	assert.ErrorContains(t, o.executeWorkflowStep(context.Background(), &config.StepV2{Name: "isolated", Template: &config.TemplateMode{Name: "sub"}}),
+		"undefined variables: [summary step.summary")
+	step.Template.Inherit = []string{"summary"}
+	step.Template.Outputs = map[string]string{"x": "nope"}
+	assert.ErrorContains(t, o.executeWorkflowStep(context.Background(), step), "outputs: 'nope' is not defined in workflow 'sub'")
+}
+
+func TestVariableValidatorTemplateScoping(t *testing.T) {
+	workflow := &config.WorkflowV2{
+		Steps: []config.StepV2{
+			{Name: "call", Template: &config.TemplateMode{Name: "sub", Outputs: map[string]string{"findings": "review"}}},
+			{Name: "use", Run: "{{findings}} {{topic}}", Needs: []string{"call"}},
+			{Name: "early", Run: "{{findings}}"},
+		},
+	}
+	validator := NewVariableValidator(workflow)
+	validator.Allow("topic")
+
+	errs := validator.ValidateAll()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "step 'early' references '{{findings}}' but 'call' is not in needs")
+}
@@ -0,0 +1,286 @@
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"time"
+)
+
+// traceEvent is a Chrome trace "complete" event (ph: X), loadable in
+// chrome://tracing and Perfetto
+type traceEvent struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat,omitempty"`
+	Ph   string                 `json:"ph"`
+	Ts   int64                  `json:"ts"`            // microseconds since run start
+	Dur  int64                  `json:"dur,omitempty"` // microseconds
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// traceFile is the JSON object format of the Chrome trace event spec
+type traceFile struct {
+	TraceEvents     []traceEvent      `json:"traceEvents"`
+	DisplayTimeUnit string            `json:"displayTimeUnit"`
+	OtherData       map[string]string `json:"otherData,omitempty"`
+}
+
+// timelineBar is one step as drawn by the HTML timeline
+type timelineBar struct {
+	Name    string  `json:"name"`
+	Lane    int     `json:"lane"`
+	StartMs float64 `json:"start"`
+	EndMs   float64 `json:"end"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// assignLanes places executions on worker lanes so that overlapping steps
+// never share a lane. Executions must be sorted by start time.
+func assignLanes(executions []StepExecution) []int {
+	lanes := make([]int, len(executions))
+	var laneEnds []time.Time
+
+	for i, exec := range executions {
+		lane := -1
+		for l, end := range laneEnds {
+			if !end.After(exec.startTime) {
+				lane = l
+				break
+			}
+		}
+		if lane == -1 {
+			lane = len(laneEnds)
+			laneEnds = append(laneEnds, exec.endTime)
+		} else {
+			laneEnds[lane] = exec.endTime
+		}
+		lanes[i] = lane
+	}
+
+	return lanes
+}
+
+// timelineOrigin returns the reference time for relative offsets
+func (et *ExecutionTimeline) timelineOrigin(executions []StepExecution) time.Time {
+	et.mu.RLock()
+	start := et.startTime
+	et.mu.RUnlock()
+
+	if start.IsZero() && len(executions) > 0 {
+		start = executions[0].startTime
+	}
+	return start
+}
+
+// ExportChromeTrace renders the timeline as Chrome trace event JSON.
+// Each worker lane becomes a thread; failed steps carry their error in args.
+func (et *ExecutionTimeline) ExportChromeTrace(workflowName string, stepErrors map[string]error) ([]byte, error) {
+	executions := et.GetStepExecutions()
+	lanes := assignLanes(executions)
+	origin := et.timelineOrigin(executions)
+
+	events := []traceEvent{
+		{Name: "process_name", Ph: "M", Pid: 1, Args: map[string]interface{}{"name": workflowName}},
+	}
+
+	laneCount := 0
+	for _, lane := range lanes {
+		if lane+1 > laneCount {
+			laneCount = lane + 1
+		}
+	}
+	for lane := 0; lane < laneCount; lane++ {
+		events = append(events, traceEvent{
+			Name: "thread_name",
+			Ph:   "M",
+			Pid:  1,
+			Tid:  lane + 1,
+			Args: map[string]interface{}{"name": fmt.Sprintf("worker %d", lane+1)},
+		})
+	}
+
+	for i, exec := range executions {
+		args := map[string]interface{}{"status": "success"}
+		if err, failed := stepErrors[exec.stepName]; failed && err != nil {
+			args["status"] = "failed"
+			args["error"] = err.Error()
+		}
+
+		events = append(events, traceEvent{
+			Name: exec.stepName,
+			Cat:  "step",
+			Ph:   "X",
+			Ts:   exec.startTime.Sub(origin).Microseconds(),
+			Dur:  exec.duration.Microseconds(),
+			Pid:  1,
+			Tid:  lanes[i] + 1,
+			Args: args,
+		})
+	}
+
+	return json.MarshalIndent(traceFile{
+		TraceEvents:     events,
+		DisplayTimeUnit: "ms",
+		OtherData: map[string]string{
+			"workflow":   workflowName,
+			"started_at": origin.Format(time.RFC3339),
+		},
+	}, "", "  ")
+}
+
+// ExportHTML renders the timeline as a standalone, zoomable HTML page
+func (et *ExecutionTimeline) ExportHTML(title string, stepErrors map[string]error) (string, error) {
+	executions := et.GetStepExecutions()
+	lanes := assignLanes(executions)
+	origin := et.timelineOrigin(executions)
+
+	bars := make([]timelineBar, len(executions))
+	for i, exec := range executions {
+		bars[i] = timelineBar{
+			Name:    exec.stepName,
+			Lane:    lanes[i],
+			StartMs: float64(exec.startTime.Sub(origin).Microseconds()) / 1000,
+			EndMs:   float64(exec.endTime.Sub(origin).Microseconds()) / 1000,
+		}
+		if err, failed := stepErrors[exec.stepName]; failed && err != nil {
+			bars[i].Error = err.Error()
+		}
+	}
+
+	barsJSON, err := json.Marshal(bars)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode timeline: %w", err)
+	}
+
+	total := et.GetTotalDuration()
+
+	var buf bytes.Buffer
+	err = timelineHTMLTemplate.Execute(&buf, map[string]interface{}{
+		"Title":       title,
+		"StartedAt":   origin.Format(time.RFC3339),
+		"Total":       total.Round(time.Millisecond).String(),
+		"Speedup":     fmt.Sprintf("%.2fx", et.GetSpeedup()),
+		"Parallelism": et.GetParallelismLevel(),
+		"MaxWorkers":  et.maxWorkers,
+		"Bars":        template.JS(barsJSON),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render timeline: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+var timelineHTMLTemplate = template.Must(template.New("timeline").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 24px; color: #24292f; }
+h1 { font-size: 20px; margin: 0 0 4px; }
+.meta { color: #57606a; font-size: 13px; margin-bottom: 12px; }
+.controls { margin-bottom: 8px; font-size: 13px; }
+.controls button { margin-right: 4px; }
+#viewport { overflow-x: auto; border: 1px solid #d0d7de; border-radius: 6px; position: relative; }
+#chart { position: relative; min-width: 100%; }
+.axis { position: relative; height: 22px; border-bottom: 1px solid #d0d7de; font-size: 11px; color: #57606a; }
+.tick { position: absolute; top: 0; height: 100%; border-left: 1px solid #eaeef2; padding-left: 3px; white-space: nowrap; }
+.lane { position: relative; height: 30px; border-bottom: 1px solid #f6f8fa; }
+.bar { position: absolute; top: 4px; height: 22px; background: #54aeff; border-radius: 3px; font-size: 11px; line-height: 22px; color: #fff; padding: 0 4px; box-sizing: border-box; overflow: hidden; white-space: nowrap; cursor: default; }
+.bar.failed { background: #cf222e; }
+.bar:hover { filter: brightness(0.9); }
+#tooltip { position: fixed; display: none; background: #24292f; color: #fff; font-size: 12px; padding: 6px 8px; border-radius: 4px; pointer-events: none; max-width: 420px; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="meta">Started {{.StartedAt}} &middot; Total {{.Total}} &middot; Speedup {{.Speedup}} &middot; Max parallelism {{.Parallelism}} (limit {{.MaxWorkers}})</div>
+<div class="controls">
+<button id="zoom-in">Zoom in</button><button id="zoom-out">Zoom out</button><button id="zoom-reset">Reset</button>
+<span>Ctrl + scroll to zoom</span>
+</div>
+<div id="viewport"><div id="chart"></div></div>
+<div id="tooltip"></div>
+<script>
+(function () {
+  var bars = {{.Bars}};
+  var chart = document.getElementById("chart");
+  var viewport = document.getElementById("viewport");
+  var tooltip = document.getElementById("tooltip");
+  var zoom = 1;
+  var total = 0, lanes = 0;
+  bars.forEach(function (b) { total = Math.max(total, b.end); lanes = Math.max(lanes, b.lane + 1); });
+  if (total <= 0) { total = 1; }
+
+  function fmt(ms) { return ms >= 1000 ? (ms / 1000).toFixed(2) + "s" : ms.toFixed(0) + "ms"; }
+
+  function draw() {
+    var width = viewport.clientWidth * zoom;
+    chart.style.width = width + "px";
+    chart.innerHTML = "";
+
+    var axis = document.createElement("div");
+    axis.className = "axis";
+    var ticks = Math.max(2, Math.floor(width / 100));
+    for (var i = 0; i < ticks; i++) {
+      var tick = document.createElement("div");
+      tick.className = "tick";
+      tick.style.left = (i / ticks * 100) + "%";
+      tick.textContent = fmt(total * i / ticks);
+      axis.appendChild(tick);
+    }
+    chart.appendChild(axis);
+
+    var rows = [];
+    for (var l = 0; l < lanes; l++) {
+      var row = document.createElement("div");
+      row.className = "lane";
+      chart.appendChild(row);
+      rows.push(row);
+    }
+
+    bars.forEach(function (b) {
+      var el = document.createElement("div");
+      el.className = "bar" + (b.error ? " failed" : "");
+      el.style.left = (b.start / total * 100) + "%";
+      el.style.width = Math.max((b.end - b.start) / total * 100, 0.2) + "%";
+      el.textContent = b.name;
+      el.addEventListener("mousemove", function (e) {
+        tooltip.textContent = b.name + "\n" + fmt(b.start) + " → " + fmt(b.end) +
+          " (" + fmt(b.end - b.start) + ")" + (b.error ? "\nError: " + b.error : "");
+        tooltip.style.display = "block";
+        tooltip.style.left = (e.clientX + 12) + "px";
+        tooltip.style.top = (e.clientY + 12) + "px";
+      });
+      el.addEventListener("mouseleave", function () { tooltip.style.display = "none"; });
+      rows[b.lane].appendChild(el);
+    });
+  }
+
+  function setZoom(z, anchor) {
+    var ratio = (viewport.scrollLeft + anchor) / (viewport.clientWidth * zoom);
+    zoom = Math.min(Math.max(z, 1), 200);
+    draw();
+    viewport.scrollLeft = ratio * viewport.clientWidth * zoom - anchor;
+  }
+
+  document.getElementById("zoom-in").onclick = function () { setZoom(zoom * 2, viewport.clientWidth / 2); };
+  document.getElementById("zoom-out").onclick = function () { setZoom(zoom / 2, viewport.clientWidth / 2); };
+  document.getElementById("zoom-reset").onclick = function () { setZoom(1, 0); };
+  viewport.addEventListener("wheel", function (e) {
+    if (!e.ctrlKey) { return; }
+    e.preventDefault();
+    var rect = viewport.getBoundingClientRect();
+    setZoom(e.deltaY < 0 ? zoom * 1.25 : zoom / 1.25, e.clientX - rect.left);
+  }, { passive: false });
+  window.addEventListener("resize", draw);
+  draw();
+})();
+</script>
+</body>
+</html>
+`))
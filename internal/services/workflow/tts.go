@@ -0,0 +1,90 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/tts"
+)
+
+// executeTTSStep converts text to speech and writes (and optionally plays) the audio
+func (o *Orchestrator) executeTTSStep(ctx context.Context, step *config.StepV2) error {
+	ttsMode := step.TTS
+	if ttsMode == nil {
+		return fmt.Errorf("tts mode is nil")
+	}
+
+	// Get TTS configuration from already-loaded app config
+	if o.appConfig == nil || o.appConfig.TTS == nil {
+		return fmt.Errorf("tts configuration not loaded (add a tts: section to settings.yaml)")
+	}
+
+	provider, providerName, ok := o.appConfig.TTS.GetProvider(ttsMode.Provider)
+	if !ok {
+		if providerName == "" {
+			return fmt.Errorf("no provider specified and no default_provider in tts config")
+		}
+		return fmt.Errorf("tts provider '%s' not found in config", providerName)
+	}
+
+	text, err := o.interpolator.Interpolate(ttsMode.Text)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate text: %w", err)
+	}
+
+	// Step-level voice overrides the provider default
+	if ttsMode.Voice != "" {
+		override := *provider
+		override.Voice = ttsMode.Voice
+		provider = &override
+	}
+
+	o.logger.Info("🔊 Synthesizing speech via %s (%s)", providerName, provider.Type)
+
+	audio, ext, err := tts.Synthesize(ctx, provider, text)
+	if err != nil {
+		return fmt.Errorf("tts step failed: %w", err)
+	}
+
+	// Resolve output path (default: /outputs/<step>.<ext>)
+	outputPath := ttsMode.Output
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("/outputs/%s.%s", step.Name, ext)
+	}
+	if outputPath, err = o.interpolator.Interpolate(outputPath); err != nil {
+		return fmt.Errorf("failed to interpolate output: %w", err)
+	}
+	if outputPath, err = o.resolveOutputsPath(outputPath); err != nil {
+		return err
+	}
+	if filepath.Ext(outputPath) == "" {
+		outputPath += "." + ext
+	} else if !strings.EqualFold(filepath.Ext(outputPath), "."+ext) {
+		o.logger.Warn("Output extension %s does not match %s audio", filepath.Ext(outputPath), ext)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, audio, 0644); err != nil {
+		return fmt.Errorf("failed to write audio: %w", err)
+	}
+
+	if ttsMode.Play {
+		if err := tts.Play(ctx, o.appConfig.TTS.Player, outputPath); err != nil {
+			// Playback is best-effort; the audio file is already written
+			o.logger.Warn("Playback failed: %v", err)
+		}
+	}
+
+	o.setStepResult(step.Name, outputPath)
+	o.interpolator.Set(fmt.Sprintf("%s.path", step.Name), outputPath)
+
+	o.logger.Info("✓ Speech written: %s (%d bytes)", outputPath, len(audio))
+
+	return nil
+}
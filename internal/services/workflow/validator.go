@@ -2,9 +2,13 @@ package workflow
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/database"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/expr"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/rag"
 )
 
 // ValidationError represents a workflow validation error
@@ -41,12 +45,49 @@ func NewWorkflowValidator(workflow *config.WorkflowV2) *WorkflowValidator {
 func (v *WorkflowValidator) Validate() error {
 	// Validate execution context (workflow-level settings)
 	v.validateExecutionContext()
+	v.validateLogging()
+	v.validateInput()
+
+	// Validate workflow-local prompt templates
+	if _, err := config.NewPromptLibrary().Overlay(v.workflow.Prompts, "workflow"); err != nil {
+		v.addError("workflow", "prompts", err.Error(),
+			"Each prompt needs a name and template; name@version must be unique")
+	}
+
+	// Validate params and matrix expansion
+	v.validateParams()
+
+	// Validate the blackboard's initial keys
+	if bb := v.workflow.Blackboard; bb != nil {
+		for key := range bb.Initial {
+			if !config.ValidBlackboardKey(key) {
+				v.addError("workflow", "blackboard.initial", fmt.Sprintf("invalid blackboard key '%s'", key),
+					"Keys use letters, digits, _ and -")
+			}
+		}
+	}
 
 	// Validate each step
 	for i := range v.workflow.Steps {
 		v.validateStep(&v.workflow.Steps[i])
 	}
 
+	// Validate workflow-level loop conditions
+	for _, loop := range v.workflow.Loops {
+		v.validateLoopUntil(loop.Name, "until", loop.Until, loop.UntilLLM)
+		v.validateJudge(loop.Name, "judge", loop.Judge)
+		v.validateJudge(loop.Name, "combine_model", loop.CombineModel)
+	}
+
+	// Validate judge models
+	if judges := v.workflow.Judges; judges != nil {
+		v.validateJudge("workflow", "judges.default", judges.Default)
+		v.validateJudge("workflow", "judges.conditions", judges.Conditions)
+		v.validateJudge("workflow", "judges.validation", judges.Validation)
+		v.validateJudge("workflow", "judges.consensus", judges.Consensus)
+		v.validateJudge("workflow", "judges.combine", judges.Combine)
+	}
+
 	// Check for circular dependencies (only if parallel execution is enabled)
 	if v.workflow.Execution.Parallel {
 		v.validateNoCycles()
@@ -60,6 +101,55 @@ func (v *WorkflowValidator) Validate() error {
 	return nil
 }
 
+// validateParams checks declared param defaults and that every matrix
+// combination resolves
+func (v *WorkflowValidator) validateParams() {
+	for name, param := range v.workflow.Params {
+		if param.Default == nil {
+			continue
+		}
+		if _, err := config.ResolveParams(map[string]config.PromptParameter{name: param}, nil); err != nil {
+			v.addError("workflow", "params", err.Error(),
+				"Make the default match the param's type and enum")
+		}
+	}
+
+	matrix := v.workflow.Matrix
+	if matrix == nil {
+		return
+	}
+	if matrix.MaxWorkers < 0 {
+		v.addError("workflow", "matrix.max_workers", "max_workers cannot be negative",
+			"Set max_workers to a positive integer (default: 3)")
+	}
+	combinations, err := matrix.Combinations()
+	if err != nil {
+		v.addError("workflow", "matrix", err.Error(),
+			fmt.Sprintf("Give every matrix param at least one value and keep the expansion within %d runs", config.MaxMatrixRuns))
+		return
+	}
+	for _, combination := range combinations {
+		exec := v.workflow.Execution
+		if err := config.ApplyExecutionParams(&exec, combination); err != nil {
+			v.addError("workflow", "matrix", fmt.Sprintf("[%s]: %v", config.MatrixLabel(combination), err),
+				"temperature must be a number and max_tokens an integer")
+			return
+		}
+		// Required params may be supplied by --param, so only check provided values
+		declared := make(map[string]config.PromptParameter)
+		for name := range combination {
+			if param, ok := v.workflow.Params[name]; ok {
+				declared[name] = param
+			}
+		}
+		if _, err := config.ResolveParams(declared, combination); err != nil {
+			v.addError("workflow", "matrix", fmt.Sprintf("[%s]: %v", config.MatrixLabel(combination), err),
+				"Matrix values must match the param's type and enum")
+			return
+		}
+	}
+}
+
 // validateNoCycles checks for circular dependencies in the workflow
 func (v *WorkflowValidator) validateNoCycles() {
 	// Build dependency graph
@@ -156,23 +246,103 @@ func (v *WorkflowValidator) validateExecutionContext() {
 	}
 
 	// Validate logging level (only if set)
-	if exec.Logging != "" {
-		validLevels := map[string]bool{
-			"error":   true,
-			"warn":    true,
-			"info":    true,
-			"step":    true,
-			"steps":   true,
-			"debug":   true,
-			"verbose": true,
-			"noisy":   true,
-		}
+	if exec.Logging != "" && !validLogLevels[exec.Logging] {
+		v.addError("execution", "logging",
+			fmt.Sprintf("invalid log level '%s'", exec.Logging),
+			logLevelHint)
+	}
 
-		if !validLevels[exec.Logging] {
-			v.addError("execution", "logging",
-				fmt.Sprintf("invalid log level '%s'", exec.Logging),
-				"Valid values: error, warn, info, step, steps, debug, verbose, noisy")
-		}
+	v.validateGenerationParams("execution", exec.GenerationParams)
+	v.validateSystemPromptMode("execution", exec.SystemPrompt, exec.SystemPromptMode)
+}
+
+// validateGenerationParams checks sampling settings against the ranges
+// provider APIs accept
+func (v *WorkflowValidator) validateGenerationParams(name string, params config.GenerationParams) {
+	if err := params.Validate(); err != nil {
+		v.addError(name, "", err.Error(),
+			"Ranges: top_p above 0 up to 1, top_k 1 or more, penalties -2 to 2, logit_bias -100 to 100")
+	}
+}
+
+// validateSystemPromptMode checks system_prompt_mode, which only replace
+// may use without a system_prompt (to clear the layers before it)
+func (v *WorkflowValidator) validateSystemPromptMode(name, prompt string, mode config.SystemPromptMode) {
+	if err := mode.Validate(); err != nil {
+		v.addError(name, "system_prompt_mode", err.Error(),
+			"append adds after the settings and workflow prompts, prepend adds before them, replace uses only this one")
+		return
+	}
+	if prompt == "" && mode != "" && mode != config.SystemPromptReplace {
+		v.addError(name, "system_prompt_mode", fmt.Sprintf("system_prompt_mode %s has no system_prompt", mode),
+			"Set system_prompt, or use replace with no system_prompt to clear the earlier layers")
+	}
+}
+
+// validLogLevels are the names accepted for log levels
+var validLogLevels = map[string]bool{
+	"error":   true,
+	"warn":    true,
+	"info":    true,
+	"step":    true,
+	"steps":   true,
+	"debug":   true,
+	"verbose": true,
+	"noisy":   true,
+}
+
+const logLevelHint = "Valid values: error, warn, info, step, steps, debug, verbose, noisy"
+
+// validateLogging validates the workflow's logging block
+func (v *WorkflowValidator) validateLogging() {
+	logging := v.workflow.Logging
+	if logging == nil {
+		return
+	}
+	if logging.Level != "" && !validLogLevels[logging.Level] {
+		v.addError("workflow", "logging.level",
+			fmt.Sprintf("invalid log level '%s'", logging.Level), logLevelHint)
+	}
+
+	file := logging.File
+	if file == nil {
+		return
+	}
+	if file.Path == "" {
+		v.addError("workflow", "logging.file.path", "log file path is required",
+			"Set a path such as /outputs/logs/{{workflow}}-{{run_id}}.log")
+	}
+	if file.Level != "" && !validLogLevels[file.Level] {
+		v.addError("workflow", "logging.file.level",
+			fmt.Sprintf("invalid log level '%s'", file.Level), logLevelHint)
+	}
+	if file.MaxSizeMB < 0 || file.MaxBackups < 0 {
+		v.addError("workflow", "logging.file",
+			"max_size_mb and max_backups cannot be negative",
+			"Leave them unset to use the defaults (10 MB, 3 backups)")
+	}
+}
+
+// validateInput validates the workflow's input block
+func (v *WorkflowValidator) validateInput() {
+	input := v.workflow.Input
+	if input == nil {
+		return
+	}
+	if input.SpoolThreshold < -1 {
+		v.addError("workflow", "input.spool_threshold",
+			fmt.Sprintf("invalid spool threshold %d", input.SpoolThreshold),
+			"Use a size in bytes, or -1 to keep all input in memory")
+	}
+	if input.HeadLines < 0 || input.TailLines < 0 || input.SummaryChunkSize < 0 {
+		v.addError("workflow", "input",
+			"head_lines, tail_lines and summary_chunk_size cannot be negative",
+			"Leave them unset to use the defaults (20 lines, 100000 bytes)")
+	}
+	if (input.SummaryPrompt != "" || input.SummaryChunkSize != 0) && !input.Summarize {
+		v.addError("workflow", "input.summarize",
+			"summary_prompt and summary_chunk_size only apply when summarize is true",
+			"Set summarize: true to make {{input.summary}} available")
 	}
 }
 
@@ -183,17 +353,42 @@ func (v *WorkflowValidator) validateStep(step *config.StepV2) {
 
 	if executionModes == 0 {
 		v.addError(step.Name, "", "no execution mode specified",
-			"Steps must have ONE of: run, template, rag, embeddings, consensus, or loop")
+			"Steps must have ONE of: run (or prompt_ref), template, rag, embeddings, consensus, sql, storage, notify, log_analytics, graph_security, load_table, render, tts, compare, debate, guard, verify, or loop")
 	} else if executionModes > 1 {
 		v.addError(step.Name, "", "multiple execution modes specified",
-			"Steps can only have ONE execution mode (run, template, rag, embeddings, consensus, or loop)")
+			"Steps can only have ONE execution mode (run, template, rag, embeddings, consensus, sql, storage, notify, log_analytics, graph_security, load_table, render, tts, compare, debate, guard, verify, or loop)")
+	}
+
+	// Validate prompt reference
+	if step.Run != "" && step.PromptRef != "" {
+		v.addError(step.Name, "prompt_ref", "run and prompt_ref are mutually exclusive",
+			"Use prompt_ref with prompt_args, or an inline run prompt")
+	}
+	if len(step.PromptArgs) > 0 && step.PromptRef == "" {
+		v.addError(step.Name, "prompt_args", "prompt_args requires prompt_ref",
+			"Example: prompt_ref: summarize@v2\nprompt_args:\n  text: \"{{input}}\"")
 	}
 
+	// Validate few-shot examples
+	if step.Examples != nil {
+		v.validateExamples(step)
+	}
+
+	v.validateGenerationParams(step.Name, step.GenerationParams)
+	v.validateSystemPromptMode(step.Name, step.SystemPrompt, step.SystemPromptMode)
+	v.validateHistory(step)
+	v.validateAgent(step)
+
 	// Validate template mode
 	if step.Template != nil {
 		v.validateTemplateMode(step)
 	}
 
+	// Validate embeddings mode
+	if step.Embeddings != nil {
+		v.validateEmbeddingsMode(step)
+	}
+
 	// Validate loop mode
 	if step.Loop != nil {
 		v.validateLoopMode(step)
@@ -209,6 +404,76 @@ func (v *WorkflowValidator) validateStep(step *config.StepV2) {
 		v.validateRagMode(step)
 	}
 
+	// Validate sql mode
+	if step.SQL != nil {
+		v.validateSQLMode(step)
+	}
+
+	// Validate storage mode
+	if step.Storage != nil {
+		v.validateStorageMode(step)
+	}
+
+	// Validate notify mode
+	if step.Notify != nil {
+		v.validateNotifyMode(step)
+	}
+
+	// Validate log_analytics mode
+	if step.LogAnalytics != nil {
+		v.validateLogAnalyticsMode(step)
+	}
+
+	// Validate graph_security mode
+	if step.GraphSecurity != nil {
+		v.validateGraphSecurityMode(step)
+	}
+
+	// Validate load_table mode
+	if step.LoadTable != nil {
+		v.validateLoadTableMode(step)
+	}
+
+	// Validate render mode
+	if step.Render != nil {
+		v.validateRenderMode(step)
+	}
+
+	// Validate tts mode
+	if step.TTS != nil {
+		v.validateTTSMode(step)
+	}
+
+	// Validate compare mode
+	if step.Compare != nil {
+		v.validateCompareMode(step)
+	}
+
+	// Validate debate mode
+	if step.Debate != nil {
+		v.validateDebateMode(step)
+	}
+
+	// Validate guard mode
+	if step.Guard != nil {
+		v.validateGuardMode(step)
+	}
+
+	// Validate verify mode
+	if step.Verify != nil {
+		v.validateVerifyMode(step)
+	}
+
+	// Validate output file
+	if step.OutputFile != "" || step.OutputMode != "" {
+		v.validateOutputFile(step)
+	}
+
+	// Validate blackboard writes
+	if len(step.WriteBlackboard) > 0 {
+		v.validateWriteBlackboard(step)
+	}
+
 	// Validate dependencies
 	v.validateDependencies(step)
 }
@@ -216,7 +481,7 @@ func (v *WorkflowValidator) validateStep(step *config.StepV2) {
 // countExecutionModes counts how many execution modes are set
 func (v *WorkflowValidator) countExecutionModes(step *config.StepV2) int {
 	count := 0
-	if step.Run != "" {
+	if step.Run != "" || step.PromptRef != "" {
 		count++
 	}
 	if step.Template != nil {
@@ -234,6 +499,42 @@ func (v *WorkflowValidator) countExecutionModes(step *config.StepV2) int {
 	if step.Rag != nil {
 		count++
 	}
+	if step.SQL != nil {
+		count++
+	}
+	if step.Storage != nil {
+		count++
+	}
+	if step.Notify != nil {
+		count++
+	}
+	if step.LogAnalytics != nil {
+		count++
+	}
+	if step.GraphSecurity != nil {
+		count++
+	}
+	if step.LoadTable != nil {
+		count++
+	}
+	if step.Render != nil {
+		count++
+	}
+	if step.TTS != nil {
+		count++
+	}
+	if step.Compare != nil {
+		count++
+	}
+	if step.Debate != nil {
+		count++
+	}
+	if step.Guard != nil {
+		count++
+	}
+	if step.Verify != nil {
+		count++
+	}
 	return count
 }
 
@@ -243,6 +544,37 @@ func (v *WorkflowValidator) validateTemplateMode(step *config.StepV2) {
 		v.addError(step.Name, "template.name", "template name is required",
 			"Example: template:\n  name: my_workflow\n  with:\n    param: value")
 	}
+
+	for _, name := range step.Template.Inherit {
+		if strings.TrimSpace(name) == "" {
+			v.addError(step.Name, "template.inherit", "inherit entries must be variable names",
+				"Example: inherit: [summary, topic]")
+		}
+	}
+
+	for name, source := range step.Template.Outputs {
+		switch {
+		case name == "" || strings.ContainsAny(name, ". {}"):
+			v.addError(step.Name, "template.outputs", fmt.Sprintf("'%s' is not a valid variable name", name),
+				"Use plain names like findings: review")
+		case source == "":
+			v.addError(step.Name, "template.outputs."+name, "output source is required",
+				"Name a step or variable of the called workflow, e.g. findings: review")
+		case name != step.Name && v.isStepName(name):
+			v.addError(step.Name, "template.outputs."+name, fmt.Sprintf("'%s' is already a step name", name),
+				"Pick a variable name no step uses")
+		}
+	}
+}
+
+// isStepName reports whether a step of the workflow has this name
+func (v *WorkflowValidator) isStepName(name string) bool {
+	for i := range v.workflow.Steps {
+		if v.workflow.Steps[i].Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // validateLoopMode validates loop execution mode
@@ -268,6 +600,46 @@ func (v *WorkflowValidator) validateLoopMode(step *config.StepV2) {
 		v.validateVariableSyntax(step, "loop.items", step.Loop.Items)
 		v.validateLoopVariables(step)
 	}
+
+	v.validateLoopUntil(step.Name, "loop.until", step.Loop.Until, step.Loop.UntilLLM)
+	v.validateJudge(step.Name, "loop.judge", step.Loop.Judge)
+	v.validateJudge(step.Name, "loop.combine_model", step.Loop.CombineModel)
+}
+
+// validateLoopUntil checks a refine loop's exit condition. until is
+// evaluated without a model, so it must parse as an expression.
+func (v *WorkflowValidator) validateLoopUntil(name, field, until, untilLLM string) {
+	if until != "" && untilLLM != "" {
+		v.addError(name, field, "until and until_llm are mutually exclusive",
+			"Use until for an expression or until_llm for a condition judged by a model")
+		return
+	}
+	if until == "" {
+		return
+	}
+	if _, err := expr.Parse(until); err != nil {
+		v.addError(name, field, fmt.Sprintf("invalid until expression: %v", err),
+			"Example: until: output.score >= 8 && contains(output.status, \"PASS\")\n"+
+				"For a natural-language condition use until_llm: \"All tests pass\"")
+	}
+}
+
+// validateEmbeddingsMode validates embeddings execution mode
+func (v *WorkflowValidator) validateEmbeddingsMode(step *config.StepV2) {
+	emb := step.Embeddings
+	if len(emb.Providers) == 0 {
+		return
+	}
+	if emb.Provider != "" || emb.Model != "" {
+		v.addError(step.Name, "embeddings.providers", "providers and provider/model are mutually exclusive",
+			"List the primary first: providers:\n  - provider: openai\n    model: text-embedding-3-small\n  - provider: azure_openai\n    model: text-embedding-3-small")
+	}
+	for i, pc := range emb.Providers {
+		if pc.Provider == "" || pc.Model == "" {
+			v.addError(step.Name, fmt.Sprintf("embeddings.providers[%d]", i), "provider and model are required",
+				"Each entry needs both, e.g. provider: openai, model: text-embedding-3-small")
+		}
+	}
 }
 
 // validateConsensusMode validates consensus execution mode
@@ -281,20 +653,466 @@ func (v *WorkflowValidator) validateConsensusMode(step *config.StepV2) {
 		v.addError(step.Name, "consensus.executions", "at least 2 executions required for consensus",
 			"Add multiple provider/model combinations to get consensus")
 	}
+
+	v.validateJudge(step.Name, "consensus.judge", step.Consensus.Judge)
+}
+
+// validateSQLMode validates SQL execution mode
+func (v *WorkflowValidator) validateSQLMode(step *config.StepV2) {
+	if step.SQL.Driver == "" {
+		v.addError(step.Name, "sql.driver", "database driver is required",
+			"Valid values: postgres, mysql, sqlite")
+	} else if _, err := database.ResolveDriver(step.SQL.Driver); err != nil {
+		v.addError(step.Name, "sql.driver", err.Error(),
+			"Valid values: postgres, mysql, sqlite")
+	}
+
+	if step.SQL.DSN == "" {
+		v.addError(step.Name, "sql.dsn", "connection string is required",
+			"Example: dsn: \"${DATABASE_URL}\"")
+	}
+
+	if step.SQL.Query == "" {
+		v.addError(step.Name, "sql.query", "SQL query is required",
+			"Example: query: \"SELECT * FROM orders WHERE region = $1\"")
+	}
+
+	if step.SQL.MaxRows < 0 {
+		v.addError(step.Name, "sql.max_rows", "max_rows cannot be negative",
+			"Omit max_rows to use the default limit of 1000")
+	}
+
+	v.validateVariableSyntax(step, "sql.query", step.SQL.Query)
+}
+
+// validateStorageMode validates storage execution mode
+func (v *WorkflowValidator) validateStorageMode(step *config.StepV2) {
+	switch step.Storage.Action {
+	case "get":
+	case "put":
+		if step.Storage.Content == "" && step.Storage.File == "" {
+			v.addError(step.Name, "storage.content", "put requires content or file",
+				"Example: storage:\n  action: put\n  key: reports/summary.md\n  content: \"{{summarize}}\"")
+		}
+		if step.Storage.Content != "" && step.Storage.File != "" {
+			v.addError(step.Name, "storage.file", "put accepts content or file, not both",
+				"Remove one of content or file")
+		}
+	case "":
+		v.addError(step.Name, "storage.action", "storage action is required",
+			"Valid values: get, put")
+	default:
+		v.addError(step.Name, "storage.action",
+			fmt.Sprintf("invalid storage action '%s'", step.Storage.Action),
+			"Valid values: get, put")
+	}
+
+	if step.Storage.Key == "" {
+		v.addError(step.Name, "storage.key", "object key is required",
+			"Example: key: \"reports/{{input}}.md\"")
+	}
+
+	v.validateVariableSyntax(step, "storage.key", step.Storage.Key)
+}
+
+// validateNotifyMode validates notify execution mode
+func (v *WorkflowValidator) validateNotifyMode(step *config.StepV2) {
+	if step.Notify.Body == "" {
+		v.addError(step.Name, "notify.body", "notification body is required",
+			"Example: notify:\n  channel: ops_slack\n  subject: \"Report ready\"\n  body: \"{{summarize}}\"")
+	}
+
+	v.validateVariableSyntax(step, "notify.subject", step.Notify.Subject)
+	v.validateVariableSyntax(step, "notify.body", step.Notify.Body)
+}
+
+// validateLogAnalyticsMode validates log_analytics execution mode
+func (v *WorkflowValidator) validateLogAnalyticsMode(step *config.StepV2) {
+	la := step.LogAnalytics
+	if la.Data == "" && len(la.Record) == 0 {
+		v.addError(step.Name, "log_analytics.data", "data or record is required",
+			"Example: log_analytics:\n  destination: sentinel\n  data: \"{{enrich}}\"")
+	}
+
+	if la.Data != "" && len(la.Record) > 0 {
+		v.addError(step.Name, "log_analytics.record", "data and record cannot both be set",
+			"Use data for JSON from a previous step, or record for a single templated record")
+	}
+
+	v.validateVariableSyntax(step, "log_analytics.data", la.Data)
+}
+
+// validateGraphSecurityMode validates graph_security execution mode
+func (v *WorkflowValidator) validateGraphSecurityMode(step *config.StepV2) {
+	gs := step.GraphSecurity
+	switch gs.Resource {
+	case "", "incidents", "alerts":
+	default:
+		v.addError(step.Name, "graph_security.resource", fmt.Sprintf("unsupported resource '%s'", gs.Resource),
+			"Supported resources: incidents, alerts")
+	}
+
+	if gs.PageSize < 0 {
+		v.addError(step.Name, "graph_security.page_size", "page_size cannot be negative",
+			"Example: graph_security:\n  page_size: 50")
+	} else if gs.PageSize > 50 && gs.Resource != "alerts" {
+		v.addError(step.Name, "graph_security.page_size", "page_size cannot exceed 50 for incidents",
+			"Graph limits incident pages to 50 items; use max_items to fetch more")
+	}
+
+	if gs.MaxItems < 0 {
+		v.addError(step.Name, "graph_security.max_items", "max_items cannot be negative",
+			"Example: graph_security:\n  max_items: 200")
+	}
+
+	if gs.Select != "" && !gs.Raw {
+		v.addError(step.Name, "graph_security.select", "select requires raw: true",
+			"Normalized output needs the full object; set raw: true to use $select")
+	}
+
+	v.validateVariableSyntax(step, "graph_security.filter", gs.Filter)
+}
+
+// validateLoadTableMode validates load_table execution mode
+func (v *WorkflowValidator) validateLoadTableMode(step *config.StepV2) {
+	lt := step.LoadTable
+	if lt.File == "" {
+		v.addError(step.Name, "load_table.file", "file is required",
+			"Example: load_table:\n  file: data/hosts.csv")
+	}
+
+	switch lt.Format {
+	case "", "csv", "tsv", "xlsx":
+	default:
+		v.addError(step.Name, "load_table.format", fmt.Sprintf("unsupported format '%s'", lt.Format),
+			"Supported formats: csv, tsv, xlsx")
+	}
+
+	if len([]rune(lt.Delimiter)) > 1 {
+		v.addError(step.Name, "load_table.delimiter", "delimiter must be a single character",
+			"Example: delimiter: \";\"")
+	}
+
+	if lt.Offset < 0 || lt.Limit < 0 || lt.Sample < 0 {
+		v.addError(step.Name, "load_table", "offset, limit and sample cannot be negative",
+			"Example: load_table:\n  file: data.csv\n  sample: 100")
+	}
+
+	switch lt.OutputFormat {
+	case "", "json", "rows", "schema":
+	default:
+		v.addError(step.Name, "load_table.output_format", fmt.Sprintf("unsupported output format '%s'", lt.OutputFormat),
+			"Supported output formats: json, rows, schema")
+	}
+
+	v.validateVariableSyntax(step, "load_table.file", lt.File)
+}
+
+// validateRenderMode validates render execution mode
+func (v *WorkflowValidator) validateRenderMode(step *config.StepV2) {
+	r := step.Render
+	sources := 0
+	for _, s := range []string{r.Markdown, r.Template, r.TemplateFile} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		v.addError(step.Name, "render", "exactly one of markdown, template or template_file is required",
+			"Example: render:\n  markdown: \"{{summarize}}\"\n  format: pdf")
+	}
+
+	switch strings.ToLower(r.Format) {
+	case "", "html", "pdf":
+	default:
+		v.addError(step.Name, "render.format", fmt.Sprintf("unsupported format '%s'", r.Format),
+			"Supported formats: html, pdf")
+	}
+
+	if r.Stylesheet != "" && strings.ToLower(r.Format) == "pdf" {
+		v.addError(step.Name, "render.stylesheet", "stylesheet only applies to html output",
+			"Remove stylesheet or use format: html")
+	}
+
+	v.validateVariableSyntax(step, "render.markdown", r.Markdown)
+}
+
+// validateTTSMode validates tts execution mode
+func (v *WorkflowValidator) validateTTSMode(step *config.StepV2) {
+	if strings.TrimSpace(step.TTS.Text) == "" {
+		v.addError(step.Name, "tts.text", "text is required",
+			"Example: tts:\n  provider: openai_tts\n  text: \"{{summarize}}\"")
+	}
+
+	v.validateVariableSyntax(step, "tts.text", step.TTS.Text)
+}
+
+// validateOutputFile validates output_file and output_mode
+func (v *WorkflowValidator) validateOutputFile(step *config.StepV2) {
+	if strings.TrimSpace(step.OutputFile) == "" {
+		v.addError(step.Name, "output_mode", "output_mode requires output_file",
+			"Example: output_file: /outputs/{{params.name}}.json")
+	}
+
+	switch step.OutputMode {
+	case "", "overwrite", "append":
+	default:
+		v.addError(step.Name, "output_mode", fmt.Sprintf("invalid output_mode '%s'", step.OutputMode),
+			"Valid values: overwrite (default), append")
+	}
+
+	v.validateVariableSyntax(step, "output_file", step.OutputFile)
+}
+
+// validateCompareMode validates compare execution mode
+func (v *WorkflowValidator) validateCompareMode(step *config.StepV2) {
+	c := step.Compare
+	if strings.TrimSpace(c.Prompt) == "" {
+		v.addError(step.Name, "compare.prompt", "compare prompt is required",
+			"Example: compare:\n  prompt: \"{{input}}\"\n  executions: [...]")
+	}
+
+	if len(c.Executions) < 2 {
+		v.addError(step.Name, "compare.executions", "at least 2 executions required for compare",
+			"Add the provider/model combinations to compare")
+	}
+	for i, exec := range c.Executions {
+		if exec.Provider == "" {
+			v.addError(step.Name, fmt.Sprintf("compare.executions[%d].provider", i), "provider is required",
+				"Example: - provider: openai\n  model: gpt-4o")
+		}
+	}
+
+	if c.Judge != nil {
+		v.validateJudge(step.Name, "compare.judge", &c.Judge.JudgeConfig)
+	}
+
+	if c.MinSuccess > len(c.Executions) {
+		v.addError(step.Name, "compare.min_success", "min_success exceeds the number of executions",
+			fmt.Sprintf("Use a value between 1 and %d", len(c.Executions)))
+	}
+
+	v.validateVariableSyntax(step, "compare.prompt", c.Prompt)
+}
+
+// validateWriteBlackboard validates a step's blackboard writes
+func (v *WorkflowValidator) validateWriteBlackboard(step *config.StepV2) {
+	for i, write := range step.WriteBlackboard {
+		field := fmt.Sprintf("write_blackboard[%d]", i)
+		if !config.ValidBlackboardKey(write.Key) {
+			v.addError(step.Name, field+".key", fmt.Sprintf("invalid blackboard key '%s'", write.Key),
+				"Keys use letters, digits, _ and -, e.g. key: findings")
+		}
+		if write.IfVersion != nil && *write.IfVersion < 0 {
+			v.addError(step.Name, field+".if_version", "if_version cannot be negative",
+				"Use 0 to write only if the key has not been written yet")
+		}
+		v.validateVariableSyntax(step, field+".value", write.Value)
+	}
+}
+
+// validateDebateMode validates debate execution mode
+func (v *WorkflowValidator) validateDebateMode(step *config.StepV2) {
+	d := step.Debate
+	if strings.TrimSpace(d.Question) == "" {
+		v.addError(step.Name, "debate.question", "debate question is required",
+			"Example: debate:\n  question: \"Is {{input}} exploitable?\"\n  agents: [red_team, blue_team]")
+	}
+
+	if len(d.Agents) < 2 {
+		v.addError(step.Name, "debate.agents", "at least 2 agents required for debate",
+			"Define the agents in the workflow's agents: section or in config/agents/")
+	}
+	seen := make(map[string]bool, len(d.Agents))
+	for i, name := range d.Agents {
+		if seen[name] {
+			v.addError(step.Name, fmt.Sprintf("debate.agents[%d]", i), fmt.Sprintf("agent '%s' is listed twice", name),
+				"Each agent speaks once per round; list it once")
+		}
+		seen[name] = true
+		if len(d.ResolvedAgents) != len(d.Agents) && !v.agentDefined(name) {
+			v.addError(step.Name, fmt.Sprintf("debate.agents[%d]", i), fmt.Sprintf("unknown agent '%s'", name),
+				"Define it in the workflow's agents: section or in config/agents/")
+		}
+	}
+
+	if d.Rounds < 0 || d.Rounds > 10 {
+		v.addError(step.Name, "debate.rounds", "debate rounds must be between 1 and 10",
+			"Every agent speaks once per round; the default is 2")
+	}
+	if d.Timeout < 0 {
+		v.addError(step.Name, "debate.timeout", "debate timeout cannot be negative", "Example: timeout: 2m")
+	}
+
+	if d.Adjudicator != "" && d.Judge != nil {
+		v.addError(step.Name, "debate.adjudicator", "adjudicator and judge are mutually exclusive",
+			"Use adjudicator for an agent, or judge for a model")
+	}
+	if d.Adjudicator != "" && d.ResolvedAdjudicator == nil && !v.agentDefined(d.Adjudicator) {
+		v.addError(step.Name, "debate.adjudicator", fmt.Sprintf("unknown agent '%s'", d.Adjudicator),
+			"Define it in the workflow's agents: section or in config/agents/")
+	}
+	v.validateJudge(step.Name, "debate.judge", d.Judge)
+
+	v.validateVariableSyntax(step, "debate.question", d.Question)
+}
+
+// validateJudge validates a judge model setting. The provider may be left to
+// the judges section or the default provider.
+func (v *WorkflowValidator) validateJudge(name, field string, judge *config.JudgeConfig) {
+	if judge == nil {
+		return
+	}
+	if judge.Temperature != nil && (*judge.Temperature < 0 || *judge.Temperature > 2) {
+		v.addError(name, field+".temperature", "judge temperature must be between 0 and 2",
+			"Judges are usually run at temperature: 0 for repeatable verdicts")
+	}
+}
+
+// validateExamples validates a step's few-shot examples
+func (v *WorkflowValidator) validateExamples(step *config.StepV2) {
+	ex := step.Examples
+	if step.Run == "" && step.PromptRef == "" {
+		v.addError(step.Name, "examples", "examples requires a run or prompt_ref step",
+			"Few-shot examples are added before the step's prompt")
+	}
+
+	if (ex.File == "") == (ex.Rag == nil) {
+		v.addError(step.Name, "examples", "examples needs exactly one of file or rag",
+			"Example: examples:\n  file: examples/classify.jsonl\n  top_k: 5")
+	}
+
+	if ex.TopK < 0 {
+		v.addError(step.Name, "examples.top_k", "top_k must not be negative",
+			"Omit top_k to use every example from the file (or 3 from rag)")
+	}
+
+	if ex.Rag != nil {
+		v.validateVariableSyntax(step, "examples.rag.query", ex.Rag.Query)
+	}
+}
+
+// validateGuardMode validates guard execution mode
+func (v *WorkflowValidator) validateGuardMode(step *config.StepV2) {
+	g := step.Guard
+	if strings.TrimSpace(g.Input) == "" {
+		v.addError(step.Name, "guard.input", "guard input is required",
+			"Example: guard:\n  input: \"{{draft}}\"\n  checks:\n    pii: true")
+	}
+
+	checks := g.Checks
+	if len(checks.Deny) == 0 && checks.MaxLength == 0 && checks.JSONSchema == nil &&
+		!checks.PII && !checks.Profanity && checks.Moderation == nil {
+		v.addError(step.Name, "guard.checks", "at least one check is required",
+			"Available checks: deny, max_length, json_schema, pii, profanity, moderation")
+	}
+	for i, pattern := range checks.Deny {
+		if _, err := regexp.Compile(pattern); err != nil {
+			v.addError(step.Name, fmt.Sprintf("guard.checks.deny[%d]", i), fmt.Sprintf("invalid pattern: %v", err),
+				"Deny patterns use Go regular expression syntax, e.g. \"(?i)internal use only\"")
+		}
+	}
+	if checks.MaxLength < 0 {
+		v.addError(step.Name, "guard.checks.max_length", "max_length must not be negative", "")
+	}
+	if checks.Moderation != nil && checks.Moderation.Provider == "" {
+		v.addError(step.Name, "guard.checks.moderation.provider", "moderation provider is required",
+			"Example: moderation:\n  provider: openai")
+	}
+
+	switch g.Action {
+	case "", config.GuardActionBlock, config.GuardActionRedact:
+		if g.Remediate != nil {
+			v.addError(step.Name, "guard.remediate", "remediate requires action: remediate", "")
+		}
+	case config.GuardActionRemediate:
+		if g.Remediate == nil || g.Remediate.Workflow == "" {
+			v.addError(step.Name, "guard.remediate.workflow", "remediation workflow is required",
+				"Example: remediate:\n  workflow: fix_draft")
+		}
+	default:
+		v.addError(step.Name, "guard.action", fmt.Sprintf("invalid action '%s'", g.Action),
+			"Valid values: block, redact, remediate")
+	}
+
+	v.validateVariableSyntax(step, "guard.input", g.Input)
+}
+
+// validateVerifyMode validates verify execution mode
+func (v *WorkflowValidator) validateVerifyMode(step *config.StepV2) {
+	vm := step.Verify
+	if strings.TrimSpace(vm.Command) == "" {
+		v.addError(step.Name, "verify.command", "verify command is required",
+			"Example: verify:\n  command: \"go build ./... && go test ./...\"\n  dir: /outputs/project")
+	}
+
+	switch vm.Parser {
+	case "", VerifyParserAuto, VerifyParserGo, VerifyParserPytest, VerifyParserJest, VerifyParserTSC, VerifyParserRust, VerifyParserGeneric:
+	default:
+		v.addError(step.Name, "verify.parser", fmt.Sprintf("invalid parser '%s'", vm.Parser),
+			"Valid values: auto, go, pytest, jest, tsc, rust, generic")
+	}
+
+	if vm.Sandbox != "" && vm.Dir != "" {
+		v.addError(step.Name, "verify.dir", "dir applies to local runs only",
+			"Sandbox runs use the container workspace; pass sources with files:")
+	}
+
+	if vm.MaxErrors < 0 {
+		v.addError(step.Name, "verify.max_errors", "max_errors must not be negative", "")
+	}
+
+	v.validateVariableSyntax(step, "verify.command", vm.Command)
 }
 
 // validateRagMode validates RAG execution mode
 func (v *WorkflowValidator) validateRagMode(step *config.StepV2) {
-	if step.Rag.Server == "" {
+	if step.Rag.Server == "" && len(step.Rag.Servers) == 0 {
 		v.addError(step.Name, "rag.server", "RAG server name is required",
 			"Example: rag:\n  server: pgvector\n  query: \"search terms\"")
 	}
+	if step.Rag.Server != "" && len(step.Rag.Servers) > 0 {
+		v.addError(step.Name, "rag.servers", "server and servers are mutually exclusive",
+			"Use server for one RAG server or servers to search several")
+	}
+
+	servers := make(map[string]bool, len(step.Rag.Servers))
+	for _, server := range step.Rag.Servers {
+		servers[server] = true
+	}
+	for server, weight := range step.Rag.Weights {
+		if !servers[server] {
+			v.addError(step.Name, "rag.weights."+server, fmt.Sprintf("'%s' is not in servers", server),
+				"Weights apply to the servers listed in servers")
+		} else if weight <= 0 {
+			v.addError(step.Name, "rag.weights."+server, "weight must be > 0",
+				"Use 1 for normal weight, more to favor a server's results")
+		}
+	}
 
 	if step.Rag.Query == "" {
 		v.addError(step.Name, "rag.query", "RAG query is required",
 			"Specify the search query for RAG retrieval")
 	}
 
+	if f := step.Rag.Filter; f != nil {
+		for _, date := range [][2]string{{"after", f.After}, {"before", f.Before}} {
+			field, value := date[0], date[1]
+			if value == "" || strings.Contains(value, "{{") {
+				continue
+			}
+			if _, ok := rag.ParseFilterDate(value); !ok {
+				v.addError(step.Name, "rag.filter."+field, fmt.Sprintf("'%s' is not a date", value),
+					"Use YYYY-MM-DD or RFC 3339, e.g. 2024-01-31")
+			}
+		}
+	}
+
+	if step.Rag.Citations && step.Rag.OutputFormat != "" && step.Rag.OutputFormat != "text" {
+		v.addError(step.Name, "rag.citations", "citations need text output (results are labeled for citing in text)",
+			"Remove output_format or set output_format: text")
+	}
+
 	// Validate variable syntax in query
 	v.validateVariableSyntax(step, "rag.query", step.Rag.Query)
 	v.validateRagVariables(step)
@@ -334,6 +1152,69 @@ func (v *WorkflowValidator) validateDependencies(step *config.StepV2) {
 	}
 }
 
+// validateHistory checks that history: names run: steps this step needs,
+// so their messages exist when it runs
+func (v *WorkflowValidator) validateHistory(step *config.StepV2) {
+	if len(step.History) == 0 {
+		return
+	}
+	if step.Run == "" && step.PromptRef == "" {
+		v.addError(step.Name, "history", "history is only used by run: steps",
+			"Remove history, or carry earlier results in with {{step_name}}")
+		return
+	}
+
+	needs := make(map[string]bool, len(step.Needs))
+	for _, dep := range step.Needs {
+		needs[dep] = true
+	}
+	for _, name := range step.History {
+		var source *config.StepV2
+		for i := range v.workflow.Steps {
+			if v.workflow.Steps[i].Name == name {
+				source = &v.workflow.Steps[i]
+				break
+			}
+		}
+
+		switch {
+		case name == step.Name:
+			v.addError(step.Name, "history", "step cannot use its own history",
+				"Remove '"+step.Name+"' from the history array")
+		case source == nil:
+			v.addError(step.Name, "history", fmt.Sprintf("history step '%s' does not exist", name),
+				"Available steps/loops: "+v.getAvailableNames())
+		case source.Run == "" && source.PromptRef == "":
+			v.addError(step.Name, "history", fmt.Sprintf("history step '%s' is not a run: step", name),
+				"Only run: and prompt_ref: steps have messages; use {{"+name+"}} for other results")
+		case !needs[name]:
+			v.addError(step.Name, "history", fmt.Sprintf("history step '%s' is not in needs", name),
+				"Add '"+name+"' to needs so it runs before this step")
+		}
+	}
+}
+
+// validateAgent checks a step's agent: is defined. Agents are applied when
+// the workflow loads with the rest of the config; a workflow loaded on its
+// own can only use the agents in its agents: section.
+func (v *WorkflowValidator) validateAgent(step *config.StepV2) {
+	if step.Agent == "" || step.ResolvedAgent != nil || v.agentDefined(step.Agent) {
+		return
+	}
+	v.addError(step.Name, "agent", fmt.Sprintf("unknown agent '%s'", step.Agent),
+		"Define it in the workflow's agents: section or in config/agents/")
+}
+
+// agentDefined reports whether the workflow's agents: section defines name
+func (v *WorkflowValidator) agentDefined(name string) bool {
+	for _, agent := range v.workflow.Agents {
+		if agent.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // getAvailableNames returns a comma-separated list of available step/loop names
 func (v *WorkflowValidator) getAvailableNames() string {
 	var names []string
@@ -387,6 +1268,40 @@ func (v *WorkflowValidator) formatErrors() error {
 	sb.WriteString("  • loop:\n")
 	sb.WriteString("      workflow: child_workflow\n")
 	sb.WriteString("      max_iterations: 10\n")
+	sb.WriteString("  • sql:\n")
+	sb.WriteString("      driver: postgres\n")
+	sb.WriteString("      dsn: \"${DATABASE_URL}\"\n")
+	sb.WriteString("      query: \"SELECT ...\"\n")
+	sb.WriteString("  • storage:\n")
+	sb.WriteString("      action: get | put\n")
+	sb.WriteString("      key: \"reports/{{input}}.md\"\n")
+	sb.WriteString("  • notify:\n")
+	sb.WriteString("      channel: ops_slack\n")
+	sb.WriteString("      body: \"{{summary}}\"\n")
+	sb.WriteString("  • log_analytics:\n")
+	sb.WriteString("      destination: sentinel\n")
+	sb.WriteString("      data: \"{{enrich}}\"\n")
+	sb.WriteString("  • graph_security:\n")
+	sb.WriteString("      resource: incidents | alerts\n")
+	sb.WriteString("      filter: \"status eq 'active'\"\n")
+	sb.WriteString("  • load_table:\n")
+	sb.WriteString("      file: data/hosts.csv\n")
+	sb.WriteString("  • render:\n")
+	sb.WriteString("      markdown: \"{{report}}\"\n")
+	sb.WriteString("      format: html | pdf\n")
+	sb.WriteString("  • tts:\n")
+	sb.WriteString("      text: \"{{summary}}\"\n")
+	sb.WriteString("  • compare:\n")
+	sb.WriteString("      prompt: \"{{input}}\"\n")
+	sb.WriteString("      executions: [{provider, model}, ...]\n")
+	sb.WriteString("  • debate:\n")
+	sb.WriteString("      question: \"{{input}}\"\n")
+	sb.WriteString("      agents: [red_team, blue_team]\n")
+	sb.WriteString("  • guard:\n")
+	sb.WriteString("      input: \"{{draft}}\"\n")
+	sb.WriteString("      checks: {deny, max_length, json_schema, pii, profanity, moderation}\n")
+	sb.WriteString("  • verify:\n")
+	sb.WriteString("      command: \"go test ./...\"\n")
 	sb.WriteString("  • embeddings: {...}\n")
 	sb.WriteString("  • consensus: {...}\n")
 	sb.WriteString("───────────────────────────────────────────────────────────\n")
@@ -13,6 +13,8 @@ type VariableValidator struct {
 	workflow *config.WorkflowV2
 	stepMap  map[string]bool
 	loopMap  map[string]bool
+	provided map[string]string // Template outputs: variable -> step that sets it
+	allowed  map[string]bool   // Variables set before the run (Allow)
 }
 
 // NewVariableValidator creates a new variable validator
@@ -27,10 +29,29 @@ func NewVariableValidator(workflow *config.WorkflowV2) *VariableValidator {
 		loopMap[workflow.Loops[i].Name] = true
 	}
 
+	provided := make(map[string]string)
+	for i := range workflow.Steps {
+		if t := workflow.Steps[i].Template; t != nil {
+			for name := range t.Outputs {
+				provided[name] = workflow.Steps[i].Name
+			}
+		}
+	}
+
 	return &VariableValidator{
 		workflow: workflow,
 		stepMap:  stepMap,
 		loopMap:  loopMap,
+		provided: provided,
+		allowed:  make(map[string]bool),
+	}
+}
+
+// Allow accepts references to variables that are set before the run, such
+// as those inherited from a calling workflow
+func (v *VariableValidator) Allow(names ...string) {
+	for _, name := range names {
+		v.allowed[variableBase(name)] = true
 	}
 }
 
@@ -64,8 +85,16 @@ func (v *VariableValidator) ValidateStep(step *config.StepV2) []error {
 				continue
 			}
 
-			// Check if reference exists as a step or loop
-			if !v.stepMap[ref] && !v.loopMap[ref] {
+			if v.allowed[ref] {
+				continue
+			}
+
+			// Template outputs are set by their template step
+			need := ref
+			if owner, ok := v.provided[ref]; ok {
+				need = owner
+			} else if !v.stepMap[ref] && !v.loopMap[ref] {
+				// Check if reference exists as a step or loop
 				errors = append(errors, fmt.Errorf(
 					"step '%s' references non-existent variable '{{%s}}'",
 					step.Name, ref,
@@ -74,10 +103,10 @@ func (v *VariableValidator) ValidateStep(step *config.StepV2) []error {
 			}
 
 			// Check if reference is in needs array
-			if !v.isInNeeds(step, ref) {
+			if !v.isInNeeds(step, need) {
 				errors = append(errors, fmt.Errorf(
 					"step '%s' references '{{%s}}' but '%s' is not in needs: array (add 'needs: [%s]' to ensure correct execution order)",
-					step.Name, ref, ref, ref,
+					step.Name, ref, need, need,
 				))
 			}
 		}
@@ -100,12 +129,61 @@ func (v *VariableValidator) extractTextsFromStep(step *config.StepV2) []string {
 		texts = append(texts, step.Consensus.Prompt)
 	}
 
-	// Template mode (with parameters)
-	if step.Template != nil && step.Template.With != nil {
+	// Prompt template arguments
+	for _, value := range step.PromptArgs {
+		if str, ok := value.(string); ok {
+			texts = append(texts, str)
+		}
+	}
+
+	// Few-shot examples
+	if step.Examples != nil {
+		texts = append(texts, step.Examples.File)
+		if step.Examples.Rag != nil {
+			texts = append(texts, step.Examples.Rag.Query)
+		}
+	}
+
+	// Compare mode
+	if step.Compare != nil && step.Compare.Prompt != "" {
+		texts = append(texts, step.Compare.Prompt)
+	}
+
+	// Blackboard writes
+	for _, write := range step.WriteBlackboard {
+		texts = append(texts, write.Value)
+	}
+
+	// Debate mode
+	if step.Debate != nil {
+		texts = append(texts, step.Debate.Question)
+	}
+
+	// Guard mode
+	if step.Guard != nil {
+		texts = append(texts, step.Guard.Input)
+	}
+
+	// Verify mode
+	if step.Verify != nil {
+		texts = append(texts, step.Verify.Command, step.Verify.Dir)
+		for _, content := range step.Verify.Files {
+			texts = append(texts, content)
+		}
+	}
+
+	// Output file path
+	if step.OutputFile != "" {
+		texts = append(texts, step.OutputFile)
+	}
+
+	// Template mode (with parameters, including nested lists and maps)
+	if step.Template != nil {
 		for _, value := range step.Template.With {
-			if str, ok := value.(string); ok {
-				texts = append(texts, str)
-			}
+			texts = appendNestedStrings(texts, value)
+		}
+		for _, name := range step.Template.Inherit {
+			texts = append(texts, "{{"+name+"}}")
 		}
 	}
 
@@ -123,8 +201,8 @@ func (v *VariableValidator) extractTextsFromStep(step *config.StepV2) []string {
 
 // extractVariableReferences extracts all {{variable}} references from text
 func (v *VariableValidator) extractVariableReferences(text string) []string {
-	// Match {{variable_name}} pattern
-	re := regexp.MustCompile(`\{\{([a-zA-Z_][a-zA-Z0-9_\.]*)\}\}`)
+	// Match {{variable_name}} and ${{ steps.name }} patterns
+	re := regexp.MustCompile(`\$?\{\{\s*([a-zA-Z_][a-zA-Z0-9_\.]*)\s*\}\}`)
 	matches := re.FindAllStringSubmatch(text, -1)
 
 	var refs []string
@@ -132,11 +210,7 @@ func (v *VariableValidator) extractVariableReferences(text string) []string {
 
 	for _, match := range matches {
 		if len(match) > 1 {
-			ref := match[1]
-
-			// Extract base variable name (before any dots)
-			base := strings.Split(ref, ".")[0]
-
+			base := variableBase(match[1])
 			if !seen[base] {
 				refs = append(refs, base)
 				seen[base] = true
@@ -147,16 +221,48 @@ func (v *VariableValidator) extractVariableReferences(text string) []string {
 	return refs
 }
 
+// variableBase extracts the base variable name (before any dots); step.x
+// and steps.x.path refer to step x
+func variableBase(ref string) string {
+	parts := strings.Split(ref, ".")
+	base := parts[0]
+	if (base == "step" || base == "steps") && len(parts) > 1 {
+		base = parts[1]
+	}
+	return base
+}
+
+// appendNestedStrings appends value's strings, looking inside lists and maps
+func appendNestedStrings(texts []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		texts = append(texts, v)
+	case []interface{}:
+		for _, item := range v {
+			texts = appendNestedStrings(texts, item)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			texts = appendNestedStrings(texts, item)
+		}
+	}
+	return texts
+}
+
 // isBuiltInVariable checks if a variable is a built-in variable
 func (v *VariableValidator) isBuiltInVariable(name string) bool {
 	builtIns := map[string]bool{
-		"input":     true,
-		"loop":      true,
-		"env":       true,
-		"iteration": true,
-		"item":      true,
-		"index":     true,
-		"consensus": true,
+		"input":      true,
+		"inputs":     true,
+		"loop":       true,
+		"env":        true,
+		"params":     true,
+		"iteration":  true,
+		"item":       true,
+		"index":      true,
+		"consensus":  true,
+		"context":    true,
+		"blackboard": true,
 	}
 
 	return builtIns[name]
@@ -95,6 +95,23 @@ func TestVariableValidator_DottedReferences(t *testing.T) {
 	}
 }
 
+func TestVariableValidator_StepPrefixedReferences(t *testing.T) {
+	workflow := &config.WorkflowV2{
+		Steps: []config.StepV2{
+			{Name: "dump", Run: "Export data"},
+			{Name: "analyze", Needs: []string{"dump"}, Run: "Read {{step.dump}} or ${{ steps.dump.path }}"},
+			{Name: "report", Run: "Summarize ${{ steps.analyze }}"}, // Missing needs: [analyze]
+		},
+	}
+
+	validator := NewVariableValidator(workflow)
+	errs := validator.ValidateAll()
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "'analyze' is not in needs") {
+		t.Errorf("Expected one missing-needs error for analyze, got %d: %v", len(errs), errs)
+	}
+}
+
 func TestVariableValidator_ConsensusMode(t *testing.T) {
 	workflow := &config.WorkflowV2{
 		Steps: []config.StepV2{
@@ -0,0 +1,597 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+// Verify defaults
+const (
+	defaultVerifyMaxErrors   = 20
+	verifyOutputTailLines    = 40
+	verifyOutputTailMaxBytes = 4000
+
+	// How long to wait for the output after a timed-out command is killed,
+	// since child processes it started may still hold stdout open
+	verifyWaitDelay = 2 * time.Second
+)
+
+// VerifyError is one parsed build or test failure
+type VerifyError struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Test    string `json:"test,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the error as file:line:col: message (test)
+func (e VerifyError) String() string {
+	var location string
+	if e.File != "" {
+		location = e.File
+		if e.Line > 0 {
+			location += fmt.Sprintf(":%d", e.Line)
+			if e.Column > 0 {
+				location += fmt.Sprintf(":%d", e.Column)
+			}
+		}
+	}
+	switch {
+	case e.Test != "" && location != "":
+		return fmt.Sprintf("%s (%s): %s", e.Test, location, e.Message)
+	case e.Test != "":
+		return fmt.Sprintf("%s: %s", e.Test, e.Message)
+	case location != "":
+		return fmt.Sprintf("%s: %s", location, e.Message)
+	}
+	return e.Message
+}
+
+// VerifyResult is the outcome of a verify step
+type VerifyResult struct {
+	Step       string        `json:"step"`
+	Command    string        `json:"command"`
+	Passed     bool          `json:"passed"`
+	ExitCode   int           `json:"exit_code"`
+	TimedOut   bool          `json:"timed_out,omitempty"` // The command was stopped at the timeout
+	Errors     []VerifyError `json:"errors"`
+	Truncated  int           `json:"truncated,omitempty"` // Errors left out of the list
+	Output     string        `json:"output"`              // Tail of the command output
+	DurationMs int64         `json:"duration_ms"`
+}
+
+// Feedback formats the result for an LLM: pass/fail, the parsed errors and
+// the tail of the output
+func (r *VerifyResult) Feedback() string {
+	if r.Passed {
+		return fmt.Sprintf("PASS: `%s` succeeded", r.Command)
+	}
+
+	var sb strings.Builder
+	if r.TimedOut {
+		fmt.Fprintf(&sb, "FAIL: `%s` timed out after %s", r.Command, (time.Duration(r.DurationMs) * time.Millisecond).Round(time.Second))
+	} else {
+		fmt.Fprintf(&sb, "FAIL: `%s` exited with code %d", r.Command, r.ExitCode)
+	}
+	if total := len(r.Errors) + r.Truncated; total > 0 {
+		fmt.Fprintf(&sb, " (%d errors)", total)
+	}
+	sb.WriteString("\n")
+	for _, e := range r.Errors {
+		sb.WriteString("- " + e.String() + "\n")
+	}
+	if r.Truncated > 0 {
+		fmt.Fprintf(&sb, "- ... and %d more\n", r.Truncated)
+	}
+	if r.Output != "" {
+		fmt.Fprintf(&sb, "\nOutput (last %d lines):\n%s", verifyOutputTailLines, r.Output)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// executeVerifyStep runs a build/test command and exposes parsed failures.
+// The step result is the feedback text; it only fails when fail_on_error is
+// set, so a refine loop can hand the errors to its next iteration.
+func (o *Orchestrator) executeVerifyStep(ctx context.Context, step *config.StepV2) error {
+	verifyMode := step.Verify
+	if verifyMode == nil {
+		return fmt.Errorf("verify mode is nil")
+	}
+
+	command, err := o.interpolator.Interpolate(verifyMode.Command)
+	if err != nil {
+		return fmt.Errorf("failed to interpolate command: %w", err)
+	}
+
+	files := make(map[string]string, len(verifyMode.Files))
+	for path, content := range verifyMode.Files {
+		if path, err = o.interpolator.Interpolate(path); err != nil {
+			return fmt.Errorf("failed to interpolate file path: %w", err)
+		}
+		if !filepath.IsLocal(filepath.FromSlash(path)) {
+			return fmt.Errorf("verify file %s must be a relative path inside dir", path)
+		}
+		interpolated, err := o.interpolator.Interpolate(content)
+		if err != nil {
+			return fmt.Errorf("failed to interpolate file %s: %w", path, err)
+		}
+		files[path] = extractCodeBlock(interpolated)
+	}
+
+	timeout := verifyMode.Timeout
+	if timeout == 0 {
+		timeout = o.executor.resolver.ResolveTimeout(step)
+	}
+	verifyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	o.logger.Info("🧪 Verifying: %s", command)
+
+	start := time.Now()
+	var output string
+	var exitCode int
+	if verifyMode.Sandbox != "" {
+		output, exitCode, err = o.runVerifySandbox(verifyCtx, verifyMode.Sandbox, command, files)
+	} else {
+		output, exitCode, err = o.runVerifyLocal(verifyCtx, verifyMode.Dir, command, files)
+	}
+	// A timeout is a failed run, so a refine loop can tell the model its
+	// code hangs; only errors running the command abort the step
+	timedOut := errors.Is(verifyCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	if err != nil && !timedOut {
+		return fmt.Errorf("verify: %w", err)
+	}
+	if timedOut {
+		exitCode = -1
+	}
+
+	maxErrors := verifyMode.MaxErrors
+	if maxErrors <= 0 {
+		maxErrors = defaultVerifyMaxErrors
+	}
+
+	result := &VerifyResult{
+		Step:       step.Name,
+		Command:    command,
+		Passed:     exitCode == 0 && !timedOut,
+		ExitCode:   exitCode,
+		TimedOut:   timedOut,
+		Errors:     []VerifyError{},
+		Output:     tailLines(output, verifyOutputTailLines, verifyOutputTailMaxBytes),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if !result.Passed {
+		parsed := parseVerifyOutput(verifyMode.Parser, output)
+		if len(parsed) > maxErrors {
+			result.Truncated = len(parsed) - maxErrors
+			parsed = parsed[:maxErrors]
+		}
+		result.Errors = append(result.Errors, parsed...)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode verify result: %w", err)
+	}
+	errorsJSON, _ := json.Marshal(result.Errors)
+
+	o.recordVerifyResult(result)
+	o.setStepResult(step.Name, result.Feedback())
+	o.interpolator.Set(step.Name+".passed", strconv.FormatBool(result.Passed))
+	o.interpolator.Set(step.Name+".exit_code", strconv.Itoa(result.ExitCode))
+	o.interpolator.Set(step.Name+".errors", string(errorsJSON))
+	o.interpolator.Set(step.Name+".output", output)
+	o.interpolator.Set(step.Name+".json", string(resultJSON))
+
+	if result.Passed {
+		o.logger.Info("✓ Verify passed (%.1fs)", float64(result.DurationMs)/1000)
+		return nil
+	}
+
+	if timedOut {
+		o.logger.Warn("Verify timed out after %s", timeout)
+	} else {
+		o.logger.Warn("Verify failed with exit code %d (%d errors parsed)", exitCode, len(result.Errors)+result.Truncated)
+	}
+	if verifyMode.FailOnError {
+		return fmt.Errorf("verify failed:\n%s", result.Feedback())
+	}
+	return nil
+}
+
+// runVerifyLocal writes the files and runs the command through the platform shell
+func (o *Orchestrator) runVerifyLocal(ctx context.Context, dir, command string, files map[string]string) (string, int, error) {
+	if dir != "" {
+		interpolated, err := o.interpolator.Interpolate(dir)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to interpolate dir: %w", err)
+		}
+		if dir, err = o.resolveOutputsPath(interpolated); err != nil {
+			return "", 0, err
+		}
+	}
+
+	for path, content := range files {
+		full := path
+		if !filepath.IsAbs(full) {
+			full = filepath.Join(dir, path)
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return "", 0, fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			return "", 0, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Dir = dir
+	cmd.WaitDelay = verifyWaitDelay
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return out.String(), -1, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return out.String(), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to run command: %w", err)
+	}
+	return out.String(), 0, nil
+}
+
+// runVerifySandbox runs the command as bash in a skill's container, with the
+// files written to its workspace
+func (o *Orchestrator) runVerifySandbox(ctx context.Context, skill, command string, files map[string]string) (string, int, error) {
+	if o.executor.serverManager == nil {
+		return "", 0, fmt.Errorf("sandbox '%s' requires skills (add it to the workflow's skills)", skill)
+	}
+
+	args := map[string]interface{}{
+		"skill_name": skill,
+		"language":   "bash",
+		"code":       command,
+	}
+	if len(files) > 0 {
+		fileArgs := make(map[string]interface{}, len(files))
+		for path, content := range files {
+			fileArgs[path] = content
+		}
+		args["files"] = fileArgs
+	}
+
+	raw, err := o.executor.serverManager.ExecuteTool(ctx, "skills_execute_skill_code", args)
+	if err != nil {
+		return "", 0, fmt.Errorf("sandbox execution failed: %w", err)
+	}
+
+	var result struct {
+		Output   string
+		ExitCode int
+	}
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		return "", 0, fmt.Errorf("unexpected sandbox result: %w", err)
+	}
+	return result.Output, result.ExitCode, nil
+}
+
+// recordVerifyResult keeps the result for loops that inspect this run
+func (o *Orchestrator) recordVerifyResult(result *VerifyResult) {
+	o.stepResultsMu.Lock()
+	defer o.stepResultsMu.Unlock()
+	o.verifyResults = append(o.verifyResults, result)
+}
+
+// VerifyResults returns the results of the verify steps run so far
+func (o *Orchestrator) VerifyResults() []*VerifyResult {
+	o.stepResultsMu.RLock()
+	defer o.stepResultsMu.RUnlock()
+	return append([]*VerifyResult(nil), o.verifyResults...)
+}
+
+// VerifyFeedback combines verify results into loop feedback. passed is true
+// when every verify step passed.
+func VerifyFeedback(results []*VerifyResult) (feedback string, passed bool) {
+	passed = true
+	parts := make([]string, 0, len(results))
+	for _, r := range results {
+		if !r.Passed {
+			passed = false
+		}
+		parts = append(parts, r.Feedback())
+	}
+	return strings.Join(parts, "\n\n"), passed
+}
+
+// extractCodeBlock returns the body of a single fenced code block, so LLM
+// answers can be written to files directly; other text is returned as-is
+func extractCodeBlock(text string) string {
+	matches := codeFencePattern.FindAllStringSubmatch(text, -1)
+	if len(matches) != 1 {
+		return text
+	}
+	return matches[0][1]
+}
+
+var codeFencePattern = regexp.MustCompile("(?s)```[\\w+#.-]*[ \\t]*\\n(.*?)\\n?```")
+
+// tailLines returns the last n lines of text, capped at maxBytes
+func tailLines(text string, n, maxBytes int) string {
+	text = strings.TrimRight(text, "\n")
+	lines := strings.Split(text, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	tail := strings.Join(lines, "\n")
+	if len(tail) > maxBytes {
+		tail = tail[len(tail)-maxBytes:]
+	}
+	return tail
+}
+
+// Verify output parsers
+const (
+	VerifyParserAuto    = "auto"
+	VerifyParserGo      = "go"
+	VerifyParserPytest  = "pytest"
+	VerifyParserJest    = "jest"
+	VerifyParserTSC     = "tsc"
+	VerifyParserRust    = "rust"
+	VerifyParserGeneric = "generic"
+)
+
+var verifyParsers = map[string]func([]string) []VerifyError{
+	VerifyParserGo:      parseGoOutput,
+	VerifyParserPytest:  parsePytestOutput,
+	VerifyParserJest:    parseJestOutput,
+	VerifyParserTSC:     parseTSCOutput,
+	VerifyParserRust:    parseRustOutput,
+	VerifyParserGeneric: parseGenericOutput,
+}
+
+// parseVerifyOutput extracts failures with the named parser. auto combines
+// the language parsers and falls back to generic file:line matching.
+func parseVerifyOutput(parser, output string) []VerifyError {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+
+	if parse, ok := verifyParsers[parser]; ok {
+		return parse(lines)
+	}
+
+	var all []VerifyError
+	seen := make(map[string]bool)
+	for _, name := range []string{VerifyParserGo, VerifyParserPytest, VerifyParserJest, VerifyParserTSC, VerifyParserRust} {
+		for _, e := range verifyParsers[name](lines) {
+			if key := e.String(); !seen[key] {
+				seen[key] = true
+				all = append(all, e)
+			}
+		}
+	}
+	if len(all) == 0 {
+		all = parseGenericOutput(lines)
+	}
+	return all
+}
+
+var (
+	goLocationPattern = regexp.MustCompile(`^\s*(\S+\.go):(\d+)(?::(\d+))?: (.+)$`)
+	goFailPattern     = regexp.MustCompile(`^\s*--- FAIL: (\S+)`)
+	goPanicPattern    = regexp.MustCompile(`^panic: (.+)$`)
+)
+
+func parseGoOutput(lines []string) []VerifyError {
+	var errs []VerifyError
+	var test string
+	reported := make(map[string]bool) // tests with at least one located message
+	var failed []string
+
+	for _, line := range lines {
+		if m := goFailPattern.FindStringSubmatch(line); m != nil {
+			test = m[1]
+			failed = append(failed, test)
+			continue
+		}
+		if m := goLocationPattern.FindStringSubmatch(line); m != nil {
+			e := VerifyError{File: m[1], Line: atoi(m[2]), Column: atoi(m[3]), Message: strings.TrimSpace(m[4])}
+			// Indented _test.go lines belong to the preceding failing test
+			if strings.HasSuffix(m[1], "_test.go") && strings.HasPrefix(line, " ") && test != "" {
+				e.Test = test
+				reported[test] = true
+			}
+			errs = append(errs, e)
+			continue
+		}
+		if m := goPanicPattern.FindStringSubmatch(line); m != nil {
+			errs = append(errs, VerifyError{Message: "panic: " + m[1]})
+		}
+	}
+
+	// t.Fail() without a message still needs a mention; parents of failing
+	// subtests (Test/sub) are implied
+	for _, t := range failed {
+		if reported[t] || hasSubtest(failed, t) {
+			continue
+		}
+		errs = append(errs, VerifyError{Test: t, Message: "test failed"})
+	}
+	return errs
+}
+
+func hasSubtest(tests []string, parent string) bool {
+	for _, t := range tests {
+		if strings.HasPrefix(t, parent+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	pytestFailedPattern   = regexp.MustCompile(`^(?:FAILED|ERROR) (\S+?)(?:::(\S+))?(?: - (.+))?$`)
+	pytestLocationPattern = regexp.MustCompile(`^(\S+\.py):(\d+): (.+)$`)
+)
+
+func parsePytestOutput(lines []string) []VerifyError {
+	var errs []VerifyError
+	for _, line := range lines {
+		if m := pytestFailedPattern.FindStringSubmatch(line); m != nil {
+			message := m[3]
+			if message == "" {
+				message = "failed"
+			}
+			errs = append(errs, VerifyError{File: m[1], Test: m[2], Message: message})
+			continue
+		}
+		if m := pytestLocationPattern.FindStringSubmatch(line); m != nil {
+			errs = append(errs, VerifyError{File: m[1], Line: atoi(m[2]), Message: m[3]})
+		}
+	}
+	return errs
+}
+
+var (
+	jestTestPattern     = regexp.MustCompile(`^\s*● (.+)$`)
+	jestLocationPattern = regexp.MustCompile(`\(?([^\s()]+\.[cm]?[jt]sx?):(\d+):(\d+)\)?\s*$`)
+)
+
+func parseJestOutput(lines []string) []VerifyError {
+	var errs []VerifyError
+	for i := 0; i < len(lines); i++ {
+		m := jestTestPattern.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		e := VerifyError{Test: strings.TrimSpace(m[1])}
+
+		// The message is the first non-empty line; the location is the first
+		// stack frame in the project
+		for j := i + 1; j < len(lines) && j < i+30; j++ {
+			line := strings.TrimSpace(lines[j])
+			if jestTestPattern.MatchString(lines[j]) {
+				break
+			}
+			if line == "" {
+				continue
+			}
+			if e.Message == "" {
+				e.Message = line
+				continue
+			}
+			if loc := jestLocationPattern.FindStringSubmatch(line); loc != nil && strings.HasPrefix(line, "at ") && !strings.Contains(line, "node_modules") {
+				e.File, e.Line, e.Column = loc[1], atoi(loc[2]), atoi(loc[3])
+				break
+			}
+		}
+		if e.Message == "" {
+			e.Message = "test failed"
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+var tscPattern = regexp.MustCompile(`^(\S+\.tsx?)(?:\((\d+),(\d+)\)|:(\d+):(\d+)) ?[:-] error (TS\d+: .+)$`)
+
+func parseTSCOutput(lines []string) []VerifyError {
+	var errs []VerifyError
+	for _, line := range lines {
+		m := tscPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		e := VerifyError{File: m[1], Line: atoi(m[2]), Column: atoi(m[3]), Message: m[6]}
+		if m[4] != "" {
+			e.Line, e.Column = atoi(m[4]), atoi(m[5])
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+var (
+	rustErrorPattern    = regexp.MustCompile(`^error(\[E\d+\])?: (.+)$`)
+	rustLocationPattern = regexp.MustCompile(`^\s*--> (\S+):(\d+):(\d+)$`)
+	rustTestPattern     = regexp.MustCompile(`^test (\S+) \.\.\. FAILED$`)
+)
+
+func parseRustOutput(lines []string) []VerifyError {
+	var errs []VerifyError
+	pending := -1 // Index of an error still waiting for its --> location
+	for _, line := range lines {
+		if m := rustErrorPattern.FindStringSubmatch(line); m != nil {
+			// Summary lines such as "could not compile" add nothing
+			if strings.HasPrefix(m[2], "could not compile") || strings.HasPrefix(m[2], "aborting due to") {
+				pending = -1
+				continue
+			}
+			errs = append(errs, VerifyError{Message: strings.TrimSpace(m[1] + " " + m[2])})
+			pending = len(errs) - 1
+			continue
+		}
+		if m := rustLocationPattern.FindStringSubmatch(line); m != nil && pending >= 0 {
+			errs[pending].File, errs[pending].Line, errs[pending].Column = m[1], atoi(m[2]), atoi(m[3])
+			pending = -1
+			continue
+		}
+		if m := rustTestPattern.FindStringSubmatch(line); m != nil {
+			errs = append(errs, VerifyError{Test: m[1], Message: "test failed"})
+		}
+	}
+	return errs
+}
+
+var (
+	genericLocationPattern = regexp.MustCompile(`^\s*([\w./\\-]+\.\w+):(\d+)(?::(\d+))?:?\s+(.+)$`)
+	genericErrorPattern    = regexp.MustCompile(`(?i)\b(error|failed|failure|exception)\b`)
+)
+
+// parseGenericOutput matches file:line[:col] messages, then falls back to
+// lines mentioning errors
+func parseGenericOutput(lines []string) []VerifyError {
+	var errs []VerifyError
+	for _, line := range lines {
+		if m := genericLocationPattern.FindStringSubmatch(line); m != nil {
+			errs = append(errs, VerifyError{File: m[1], Line: atoi(m[2]), Column: atoi(m[3]), Message: strings.TrimSpace(m[4])})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && genericErrorPattern.MatchString(trimmed) {
+			errs = append(errs, VerifyError{Message: trimmed})
+		}
+	}
+	return errs
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,192 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVerifyOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser string
+		output string
+		want   []string
+	}{
+		{
+			name:   "go build",
+			parser: VerifyParserAuto,
+			output: "# example.com/calc\n./calc.go:12:5: undefined: sum\n./calc.go:14:2: missing return\n",
+			want:   []string{"./calc.go:12:5: undefined: sum", "./calc.go:14:2: missing return"},
+		},
+		{
+			name:   "go test",
+			parser: VerifyParserGo,
+			output: "--- FAIL: TestAdd (0.00s)\n    calc_test.go:8: Add(1, 2) = 4, want 3\n--- FAIL: TestEmpty (0.00s)\n--- FAIL: TestTable (0.00s)\n    --- FAIL: TestTable/negative (0.00s)\n        calc_test.go:21: got -1\nFAIL\nFAIL\texample.com/calc\t0.002s\n",
+			want: []string{
+				"TestAdd (calc_test.go:8): Add(1, 2) = 4, want 3",
+				"TestTable/negative (calc_test.go:21): got -1",
+				"TestEmpty: test failed",
+			},
+		},
+		{
+			name:   "pytest",
+			parser: VerifyParserAuto,
+			output: "tests/test_calc.py:9: AssertionError\n=== short test summary info ===\nFAILED tests/test_calc.py::test_add - assert 4 == 3\nERROR tests/test_io.py\n",
+			want: []string{
+				"tests/test_calc.py:9: AssertionError",
+				"test_add (tests/test_calc.py): assert 4 == 3",
+				"tests/test_io.py: failed",
+			},
+		},
+		{
+			name:   "tsc",
+			parser: VerifyParserTSC,
+			output: "src/app.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.\nsrc/util.ts:10:1 - error TS2304: Cannot find name 'foo'.\n",
+			want: []string{
+				"src/app.ts:3:7: TS2322: Type 'string' is not assignable to type 'number'.",
+				"src/util.ts:10:1: TS2304: Cannot find name 'foo'.",
+			},
+		},
+		{
+			name:   "jest",
+			parser: VerifyParserJest,
+			output: "  ● Calculator › adds numbers\n\n    expect(received).toBe(expected)\n\n      at Object.<anonymous> (src/calc.test.js:5:21)\n",
+			want:   []string{"Calculator › adds numbers (src/calc.test.js:5:21): expect(received).toBe(expected)"},
+		},
+		{
+			name:   "rust",
+			parser: VerifyParserAuto,
+			output: "error[E0308]: mismatched types\n --> src/main.rs:4:18\n  |\nerror: could not compile `calc` due to previous error\ntest tests::adds ... FAILED\n",
+			want: []string{
+				"src/main.rs:4:18: [E0308] mismatched types",
+				"tests::adds: test failed",
+			},
+		},
+		{
+			name:   "generic fallback",
+			parser: VerifyParserAuto,
+			output: "Building...\nERROR: linker failed\nDone\n",
+			want:   []string{"ERROR: linker failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range parseVerifyOutput(tt.parser, tt.output) {
+				got = append(got, e.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtractCodeBlock(t *testing.T) {
+	assert.Equal(t, "package main\n\nfunc main() {}",
+		extractCodeBlock("Here is the fix:\n```go\npackage main\n\nfunc main() {}\n```\nDone."))
+	assert.Equal(t, "plain text", extractCodeBlock("plain text"))
+
+	// Several blocks are ambiguous, so the text is kept as-is
+	two := "```go\na\n```\n```go\nb\n```"
+	assert.Equal(t, two, extractCodeBlock(two))
+}
+
+func TestExecuteVerifyStep(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	dir := t.TempDir()
+	timeout := 30 * time.Second
+	o := NewOrchestrator(&config.WorkflowV2{Name: "verify_test"}, NewLogger("error", false))
+	o.interpolator.Set("code", "```sh\necho 'main.sh:3: syntax error near fi'\nexit 2\n```")
+
+	step := &config.StepV2{
+		Name:    "check",
+		Timeout: &timeout,
+		Verify: &config.VerifyMode{
+			Command: "sh main.sh",
+			Dir:     dir,
+			Files:   map[string]string{"main.sh": "{{code}}"},
+		},
+	}
+	assert.NoError(t, o.executeVerifyStep(context.Background(), step))
+
+	written, err := os.ReadFile(filepath.Join(dir, "main.sh"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(written), "echo"))
+
+	feedback, _ := o.GetStepResult("check")
+	assert.Contains(t, feedback, "FAIL: `sh main.sh` exited with code 2 (1 errors)")
+	assert.Contains(t, feedback, "- main.sh:3: syntax error near fi")
+	passed, _ := o.interpolator.GetVariable("check.passed")
+	assert.Equal(t, "false", passed)
+
+	results := o.VerifyResults()
+	assert.Len(t, results, 1)
+	_, allPassed := VerifyFeedback(results)
+	assert.False(t, allPassed)
+
+	step.Verify.FailOnError = true
+	assert.Error(t, o.executeVerifyStep(context.Background(), step))
+}
+
+func TestExecuteVerifyStepTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	o := NewOrchestrator(&config.WorkflowV2{Name: "verify_test"}, NewLogger("error", false))
+	step := &config.StepV2{
+		Name: "check",
+		Verify: &config.VerifyMode{
+			Command: "echo started; exec sleep 5",
+			Dir:     t.TempDir(),
+			Timeout: 200 * time.Millisecond,
+		},
+	}
+	assert.NoError(t, o.executeVerifyStep(context.Background(), step))
+
+	feedback, _ := o.GetStepResult("check")
+	assert.Contains(t, feedback, "FAIL: `echo started; exec sleep 5` timed out")
+	assert.Contains(t, feedback, "started")
+	passed, _ := o.interpolator.GetVariable("check.passed")
+	assert.Equal(t, "false", passed)
+
+	results := o.VerifyResults()
+	assert.Len(t, results, 1)
+	assert.True(t, results[0].TimedOut)
+	assert.Equal(t, -1, results[0].ExitCode)
+
+	step.Verify.FailOnError = true
+	assert.Error(t, o.executeVerifyStep(context.Background(), step))
+}
+
+func TestExecuteVerifyStepFilesStayInDir(t *testing.T) {
+	dir := t.TempDir()
+	o := NewOrchestrator(&config.WorkflowV2{Name: "verify_test"}, NewLogger("error", false))
+
+	for _, path := range []string{"../escape.go", "a/../../escape.go", filepath.Join(dir, "abs.go"), "/etc/escape.go"} {
+		step := &config.StepV2{
+			Name: "check",
+			Verify: &config.VerifyMode{
+				Command: "true",
+				Dir:     filepath.Join(dir, "build"),
+				Files:   map[string]string{path: "package main"},
+			},
+		}
+		assert.Error(t, o.executeVerifyStep(context.Background(), step), path)
+	}
+	_, err := os.Stat(filepath.Join(dir, "escape.go"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "abs.go"))
+	assert.True(t, os.IsNotExist(err))
+}
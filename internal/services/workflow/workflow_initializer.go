@@ -21,8 +21,8 @@ func InitializeWorkflowServerManager(
 	fmt.Fprintf(os.Stderr, "[DEBUG_PRINT] InitializeWorkflowServerManager called for: %s\n", workflow.Name)
 	logging.Debug("[WORKFLOW_INIT] Initializing server manager for workflow: %s", workflow.Name)
 
-	// Extract skills from workflow (same as collectSkillsFromWorkflow in cmd/workflow.go)
-	skills := collectWorkflowSkills(workflow)
+	// Extract skills from workflow
+	skills := RequiredSkills(workflow)
 	fmt.Fprintf(os.Stderr, "[DEBUG_PRINT] Extracted skills: %v\n", skills)
 	logging.Debug("[WORKFLOW_INIT] Extracted skills from workflow: %v", skills)
 
@@ -61,32 +61,6 @@ func InitializeWorkflowServerManager(
 	return serverManager, nil
 }
 
-// collectWorkflowSkills extracts all unique skill names from a workflow
-// This is identical to collectSkillsFromWorkflow in cmd/workflow.go
-func collectWorkflowSkills(wf *config.WorkflowV2) []string {
-	skillSet := make(map[string]bool)
-
-	// Collect from execution level
-	for _, skill := range wf.Execution.Skills {
-		skillSet[skill] = true
-	}
-
-	// Collect from steps
-	for _, step := range wf.Steps {
-		for _, skill := range step.Skills {
-			skillSet[skill] = true
-		}
-	}
-
-	// Convert to slice
-	skills := make([]string, 0, len(skillSet))
-	for skill := range skillSet {
-		skills = append(skills, skill)
-	}
-
-	return skills
-}
-
 // verifyServerManagerReady ensures GetAvailableTools returns expected tools
 // Retries with exponential backoff to handle async initialization (Docker startup, etc.)
 // This is critical for fast models in parallel execution where race conditions can occur
@@ -0,0 +1,158 @@
+package workflowpack
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	domainErrors "github.com/LaurieRhodes/mcp-cli-go/internal/domain/errors"
+)
+
+// InstallOptions controls where and how a pack is installed
+type InstallOptions struct {
+	ConfigDir    string // Directory containing config.yaml; pack files are copied relative to it
+	WorkflowsDir string // Destination for workflow files
+	Force        bool   // Overwrite existing workflows and files
+}
+
+// InstallResult reports what an install wrote and what the config still lacks
+type InstallResult struct {
+	Manifest  *Manifest
+	Workflows []string     // Installed workflow keys
+	Files     []string     // Installed supporting files (relative to ConfigDir)
+	Missing   Requirements // Providers, servers and skills not found in the config
+}
+
+// Install copies an opened pack (see Open) into the configuration.
+// Name collisions with existing workflows or files fail the install unless
+// Force is set. Missing providers, servers and skills are reported, not
+// fatal, since they can be added after installing.
+func Install(packDir string, appConfig *config.ApplicationConfig, opts InstallOptions) (*InstallResult, error) {
+	manifest, err := ReadManifest(filepath.Join(packDir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	type copyOp struct {
+		dst  string
+		data []byte
+	}
+	var ops []copyOp
+	var collisions []string
+
+	workflowLoader := config.NewWorkflowLoader()
+	for _, wf := range manifest.Workflows {
+		src := filepath.Join(packDir, filepath.FromSlash(wf.File))
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("pack is missing %s: %w", wf.File, err)
+		}
+		if _, err := workflowLoader.LoadFromBytes(data); err != nil {
+			return nil, fmt.Errorf("pack workflow %s is invalid: %w", wf.Name, err)
+		}
+
+		dst := filepath.Join(opts.WorkflowsDir, filepath.FromSlash(wf.Name)+".yaml")
+		if _, exists := appConfig.GetWorkflow(wf.Name); exists {
+			collisions = append(collisions, "workflow "+wf.Name)
+		} else if differs(dst, data) {
+			collisions = append(collisions, "file "+dst)
+		}
+		ops = append(ops, copyOp{dst: dst, data: data})
+	}
+
+	for _, rel := range manifest.Files {
+		data, err := os.ReadFile(filepath.Join(packDir, "files", filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("pack is missing files/%s: %w", rel, err)
+		}
+		dst := filepath.Join(opts.ConfigDir, filepath.FromSlash(rel))
+		if differs(dst, data) {
+			collisions = append(collisions, "file "+dst)
+		}
+		ops = append(ops, copyOp{dst: dst, data: data})
+	}
+
+	if len(collisions) > 0 && !opts.Force {
+		return nil, domainErrors.Categorize(fmt.Errorf("pack %s conflicts with existing configuration (use --force to overwrite):\n  %s",
+			manifest.Name, strings.Join(collisions, "\n  ")), domainErrors.ErrValidation)
+	}
+
+	for _, op := range ops {
+		if err := os.MkdirAll(filepath.Dir(op.dst), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(op.dst), err)
+		}
+		if err := os.WriteFile(op.dst, op.data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", op.dst, err)
+		}
+	}
+
+	result := &InstallResult{
+		Manifest: manifest,
+		Files:    manifest.Files,
+		Missing:  missingRequirements(manifest.Requires, appConfig, opts.ConfigDir),
+	}
+	for _, wf := range manifest.Workflows {
+		result.Workflows = append(result.Workflows, wf.Name)
+	}
+	return result, nil
+}
+
+// differs reports whether file exists with content other than data
+func differs(file string, data []byte) bool {
+	existing, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	return !bytes.Equal(existing, data)
+}
+
+// missingRequirements returns the requirements the configuration does not satisfy
+func missingRequirements(req Requirements, appConfig *config.ApplicationConfig, configDir string) Requirements {
+	var missing Requirements
+
+	for _, provider := range req.Providers {
+		if !hasProvider(appConfig, provider) {
+			missing.Providers = append(missing.Providers, provider)
+		}
+	}
+	for _, server := range req.Servers {
+		if _, ok := appConfig.Servers[server]; !ok {
+			missing.Servers = append(missing.Servers, server)
+		}
+	}
+
+	skillsDir := appConfig.Skills.GetSkillsDirectory()
+	if !filepath.IsAbs(skillsDir) {
+		skillsDir = filepath.Join(configDir, skillsDir)
+	}
+	for _, skill := range req.Skills {
+		if _, err := os.Stat(filepath.Join(skillsDir, skill, "SKILL.md")); err != nil {
+			missing.Skills = append(missing.Skills, skill)
+		}
+	}
+	return missing
+}
+
+// hasProvider checks both the flat and per-interface provider maps
+func hasProvider(appConfig *config.ApplicationConfig, name string) bool {
+	if appConfig.AI == nil {
+		return false
+	}
+	if _, ok := appConfig.AI.Providers[name]; ok {
+		return true
+	}
+	for _, iface := range appConfig.AI.Interfaces {
+		if _, ok := iface.Providers[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether nothing is required
+func (r Requirements) IsEmpty() bool {
+	return len(r.Providers) == 0 && len(r.Servers) == 0 && len(r.Skills) == 0
+}
@@ -0,0 +1,113 @@
+// Package workflowpack bundles workflows into shareable, versioned archives
+// and installs them into a configuration directory.
+//
+// A pack is a gzipped tar archive (or a directory, e.g. a git checkout) with
+// a manifest.yaml at its root:
+//
+//	manifest.yaml
+//	workflows/<key>.yaml     entry workflow and the sub-workflows it calls
+//	files/<path>             render templates and stylesheets referenced by steps
+package workflowpack
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormatV1 identifies the pack layout described in the package documentation
+const FormatV1 = "mcp-cli-pack/v1"
+
+// ManifestFile is the manifest's file name at the root of a pack
+const ManifestFile = "manifest.yaml"
+
+// Manifest describes the contents and requirements of a pack
+type Manifest struct {
+	Format      string         `yaml:"format"`
+	Name        string         `yaml:"name"`
+	Version     string         `yaml:"version"`
+	Description string         `yaml:"description,omitempty"`
+	CreatedAt   time.Time      `yaml:"created_at"`
+	Entry       string         `yaml:"entry"` // Workflow to run after install
+	Workflows   []PackWorkflow `yaml:"workflows"`
+	Files       []string       `yaml:"files,omitempty"` // Paths relative to the config directory
+	Requires    Requirements   `yaml:"requires"`
+}
+
+// PackWorkflow maps a workflow key to its file inside the pack
+type PackWorkflow struct {
+	Name string `yaml:"name"` // Workflow key as used with --workflow (may contain a directory)
+	File string `yaml:"file"` // Path inside the pack, e.g. workflows/research.yaml
+}
+
+// Requirements lists what the target configuration must provide.
+// Skills are referenced by name only; their contents are not packed.
+type Requirements struct {
+	Providers []string `yaml:"providers,omitempty"`
+	Servers   []string `yaml:"servers,omitempty"`
+	Skills    []string `yaml:"skills,omitempty"`
+}
+
+// ReadManifest loads and validates a manifest file
+func ReadManifest(file string) (*Manifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pack manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse pack manifest: %w", err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Validate checks the manifest format and that every path stays inside the pack
+func (m *Manifest) Validate() error {
+	if m.Format != FormatV1 {
+		return fmt.Errorf("unsupported pack format %q (expected %s)", m.Format, FormatV1)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("pack manifest has no name")
+	}
+	if len(m.Workflows) == 0 {
+		return fmt.Errorf("pack %s contains no workflows", m.Name)
+	}
+	for _, wf := range m.Workflows {
+		if !isSafeRelPath(wf.File) || !isSafeRelPath(wf.Name) {
+			return fmt.Errorf("pack workflow %q has an unsafe path", wf.Name)
+		}
+	}
+	for _, f := range m.Files {
+		if !isSafeRelPath(f) {
+			return fmt.Errorf("pack file %q has an unsafe path", f)
+		}
+	}
+	return nil
+}
+
+// ArchiveName returns the default archive file name, e.g. research-1.0.0.tar.gz
+func (m *Manifest) ArchiveName() string {
+	name := strings.ReplaceAll(m.Name, "/", "-")
+	if m.Version == "" {
+		return name + ".tar.gz"
+	}
+	return fmt.Sprintf("%s-%s.tar.gz", name, m.Version)
+}
+
+// isSafeRelPath reports whether p is a relative, slash-separated path that
+// does not escape its root
+func isSafeRelPath(p string) bool {
+	if p == "" || strings.ContainsAny(p, "\\:") || path.IsAbs(p) {
+		return false
+	}
+	clean := path.Clean(p)
+	return clean != ".." && !strings.HasPrefix(clean, "../")
+}
@@ -0,0 +1,439 @@
+package workflowpack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	"gopkg.in/yaml.v3"
+)
+
+// PackOptions controls how a pack is built
+type PackOptions struct {
+	ConfigDir    string // Directory containing config.yaml; relative file references resolve here
+	WorkflowsDir string // Directory holding the workflow YAML files
+	Version      string // Pack version (default: entry workflow's version)
+	Output       string // Archive path (default: <name>-<version>.tar.gz in the current directory)
+}
+
+// PackResult describes a written pack
+type PackResult struct {
+	Path     string
+	Manifest *Manifest
+	Warnings []string
+}
+
+// Pack bundles the named workflow, every sub-workflow it calls (loops and
+// templates, recursively) and the files its steps reference into an archive.
+func Pack(appConfig *config.ApplicationConfig, name string, opts PackOptions) (*PackResult, error) {
+	entry, exists := appConfig.GetWorkflow(name)
+	if !exists {
+		return nil, fmt.Errorf("workflow '%s' not found", name)
+	}
+
+	files, err := IndexWorkflowFiles(opts.WorkflowsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := collectWorkflowKeys(appConfig, name)
+	if err != nil {
+		return nil, err
+	}
+
+	version := opts.Version
+	if version == "" {
+		version = entry.Version
+	}
+
+	manifest := &Manifest{
+		Format:      FormatV1,
+		Name:        name,
+		Version:     version,
+		Description: entry.Description,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		Entry:       name,
+	}
+	result := &PackResult{Manifest: manifest}
+
+	archive := newArchive()
+	requires := newRequirementSet()
+	referenced := make(map[string]bool)
+
+	for _, key := range keys {
+		source, ok := files[key]
+		if !ok {
+			return nil, fmt.Errorf("source file for workflow '%s' not found in %s", key, opts.WorkflowsDir)
+		}
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workflow %s: %w", source, err)
+		}
+
+		packPath := "workflows/" + key + ".yaml"
+		archive.add(packPath, data)
+		manifest.Workflows = append(manifest.Workflows, PackWorkflow{Name: key, File: packPath})
+
+		wf, _ := appConfig.GetWorkflow(key)
+		requires.addWorkflow(wf)
+		for _, ref := range referencedFiles(wf) {
+			referenced[ref] = true
+		}
+	}
+
+	for _, ref := range sortedKeys(referenced) {
+		data, err := os.ReadFile(filepath.Join(opts.ConfigDir, filepath.FromSlash(ref)))
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("referenced file %s not packed: %v", ref, err))
+			continue
+		}
+		archive.add("files/"+ref, data)
+		manifest.Files = append(manifest.Files, ref)
+	}
+
+	manifest.Requires = requires.requirements()
+
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	result.Path = opts.Output
+	if result.Path == "" {
+		result.Path = manifest.ArchiveName()
+	}
+	if err := archive.write(result.Path, manifestData); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WorkflowsDir returns the directory workflows are loaded from for the given
+// config file: the non-wildcard prefix of includes.workflows, or
+// config/workflows next to the config file.
+func WorkflowsDir(configFile string) string {
+	baseDir := filepath.Dir(configFile)
+	dir := filepath.Join("config", "workflows")
+
+	if data, err := os.ReadFile(configFile); err == nil {
+		var mainConfig config.MainConfigFile
+		if yaml.Unmarshal(data, &mainConfig) == nil && mainConfig.Includes != nil && mainConfig.Includes.Workflows != "" {
+			pattern := mainConfig.Includes.Workflows
+			if idx := strings.Index(pattern, "*"); idx != -1 {
+				pattern = pattern[:idx]
+			}
+			dir = filepath.Clean(pattern)
+		}
+	}
+
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(baseDir, dir)
+}
+
+// IndexWorkflowFiles maps workflow keys (as the config loader names them) to
+// their source files under dir
+func IndexWorkflowFiles(dir string) (map[string]string, error) {
+	index := make(map[string]string)
+
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !(strings.HasSuffix(file, ".yaml") || strings.HasSuffix(file, ".yml")) {
+			return nil
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var header struct {
+			Schema string `yaml:"$schema"`
+			Name   string `yaml:"name"`
+		}
+		if yaml.Unmarshal(data, &header) != nil || header.Schema != "workflow/v2.0" || header.Name == "" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, filepath.Dir(file))
+		if err != nil {
+			return err
+		}
+		key := header.Name
+		if rel != "." {
+			key = filepath.ToSlash(filepath.Join(rel, header.Name))
+		}
+		index[key] = file
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan workflows directory %s: %w", dir, err)
+	}
+	return index, nil
+}
+
+// collectWorkflowKeys returns name and every workflow reachable from it
+func collectWorkflowKeys(appConfig *config.ApplicationConfig, name string) ([]string, error) {
+	seen := map[string]bool{name: true}
+	queue := []string{name}
+	keys := []string{}
+
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		keys = append(keys, key)
+
+		wf, _ := appConfig.GetWorkflow(key)
+		contextDir := path.Dir(key)
+		if contextDir == "." {
+			contextDir = ""
+		}
+
+		for _, ref := range subWorkflowRefs(wf) {
+			resolved, ok := resolveWorkflowKey(appConfig, ref, contextDir)
+			if !ok {
+				return nil, fmt.Errorf("workflow '%s' calls '%s', which is not configured", key, ref)
+			}
+			if !seen[resolved] {
+				seen[resolved] = true
+				queue = append(queue, resolved)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// resolveWorkflowKey applies the same lookup order as GetWorkflowWithContext
+// and returns the key that matched
+func resolveWorkflowKey(appConfig *config.ApplicationConfig, ref, contextDir string) (string, bool) {
+	if _, ok := appConfig.GetWorkflow(ref); ok {
+		return ref, true
+	}
+	if contextDir != "" && !strings.Contains(ref, "/") {
+		key := contextDir + "/" + ref
+		if _, ok := appConfig.GetWorkflow(key); ok {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// subWorkflowRefs lists the workflows called from loop and template steps
+func subWorkflowRefs(wf *config.WorkflowV2) []string {
+	var refs []string
+	for _, step := range wf.Steps {
+		if step.Loop != nil && step.Loop.Workflow != "" {
+			refs = append(refs, step.Loop.Workflow)
+		}
+		if step.Template != nil && step.Template.Name != "" {
+			refs = append(refs, step.Template.Name)
+		}
+		if step.Guard != nil && step.Guard.Remediate != nil && step.Guard.Remediate.Workflow != "" {
+			refs = append(refs, step.Guard.Remediate.Workflow)
+		}
+	}
+	for _, loop := range wf.Loops {
+		if loop.Workflow != "" {
+			refs = append(refs, loop.Workflow)
+		}
+	}
+	return refs
+}
+
+// referencedFiles lists relative, non-templated file paths used by render steps
+func referencedFiles(wf *config.WorkflowV2) []string {
+	var refs []string
+	add := func(p string) {
+		if p == "" || strings.Contains(p, "{{") || filepath.IsAbs(p) {
+			return
+		}
+		p = filepath.ToSlash(filepath.Clean(p))
+		if isSafeRelPath(p) {
+			refs = append(refs, p)
+		}
+	}
+	for _, step := range wf.Steps {
+		if step.Render != nil {
+			add(step.Render.TemplateFile)
+			add(step.Render.Stylesheet)
+		}
+	}
+	return refs
+}
+
+// requirementSet accumulates providers, servers and skills used by workflows
+type requirementSet struct {
+	providers, servers, skills map[string]bool
+}
+
+func newRequirementSet() *requirementSet {
+	return &requirementSet{
+		providers: make(map[string]bool),
+		servers:   make(map[string]bool),
+		skills:    make(map[string]bool),
+	}
+}
+
+func (r *requirementSet) addWorkflow(wf *config.WorkflowV2) {
+	addProvider := func(name string) {
+		if name != "" {
+			r.providers[name] = true
+		}
+	}
+
+	addProvider(wf.Execution.Provider)
+	for _, p := range wf.Execution.Providers {
+		addProvider(p.Provider)
+	}
+	for _, s := range wf.Execution.Servers {
+		r.servers[s] = true
+	}
+	for _, s := range wf.Execution.Skills {
+		r.skills[s] = true
+	}
+	for _, loop := range wf.Loops {
+		if loop.Judge != nil {
+			addProvider(loop.Judge.Provider)
+		}
+		if loop.CombineModel != nil {
+			addProvider(loop.CombineModel.Provider)
+		}
+	}
+	for _, judge := range wf.Judges.All() {
+		addProvider(judge.Provider)
+	}
+
+	for _, step := range wf.Steps {
+		addProvider(step.Provider)
+		for _, p := range step.Providers {
+			addProvider(p.Provider)
+		}
+		if step.Consensus != nil {
+			for _, exec := range step.Consensus.Executions {
+				addProvider(exec.Provider)
+			}
+			if step.Consensus.Judge != nil {
+				addProvider(step.Consensus.Judge.Provider)
+			}
+		}
+		if step.Compare != nil {
+			for _, exec := range step.Compare.Executions {
+				addProvider(exec.Provider)
+			}
+			if step.Compare.Judge != nil {
+				addProvider(step.Compare.Judge.Provider)
+			}
+		}
+		if step.Debate != nil && step.Debate.Judge != nil {
+			addProvider(step.Debate.Judge.Provider)
+		}
+		if step.Guard != nil && step.Guard.Checks.Moderation != nil {
+			addProvider(step.Guard.Checks.Moderation.Provider)
+		}
+		if step.Loop != nil && step.Loop.Judge != nil {
+			addProvider(step.Loop.Judge.Provider)
+		}
+		if step.Loop != nil && step.Loop.CombineModel != nil {
+			addProvider(step.Loop.CombineModel.Provider)
+		}
+		for _, s := range step.Servers {
+			r.servers[s] = true
+		}
+		for _, s := range step.Skills {
+			r.skills[s] = true
+		}
+	}
+}
+
+func (r *requirementSet) requirements() Requirements {
+	return Requirements{
+		Providers: sortedKeys(r.providers),
+		Servers:   sortedKeys(r.servers),
+		Skills:    sortedKeys(r.skills),
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// archive collects entries before writing them as a gzipped tar
+type archive struct {
+	names   []string
+	entries map[string][]byte
+}
+
+func newArchive() *archive {
+	return &archive{entries: make(map[string][]byte)}
+}
+
+func (a *archive) add(name string, data []byte) {
+	if _, exists := a.entries[name]; !exists {
+		a.names = append(a.names, name)
+	}
+	a.entries[name] = data
+}
+
+// write creates the archive with the manifest first
+func (a *archive) write(file string, manifest []byte) error {
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create pack %s: %w", file, err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	writeEntry := func(name string, data []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := writeEntry(ManifestFile, manifest); err != nil {
+		return fmt.Errorf("failed to write pack: %w", err)
+	}
+	for _, name := range a.names {
+		if err := writeEntry(name, a.entries[name]); err != nil {
+			return fmt.Errorf("failed to write pack: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write pack: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write pack: %w", err)
+	}
+	return f.Close()
+}
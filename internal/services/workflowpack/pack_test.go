@@ -0,0 +1,177 @@
+package workflowpack
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+)
+
+const entryWorkflow = `$schema: "workflow/v2.0"
+name: research
+version: "1.2.0"
+description: Research a topic
+execution:
+  provider: anthropic
+  servers: [brave-search]
+steps:
+  - name: gather
+    run: "Research {{input}}"
+  - name: refine
+    needs: [gather]
+    loop:
+      workflow: polish
+      max_iterations: 3
+      until: "done"
+  - name: report
+    needs: [refine]
+    render:
+      markdown: "{{refine}}"
+      template_file: templates/report.tmpl
+`
+
+const subWorkflow = `$schema: "workflow/v2.0"
+name: polish
+version: "1.0.0"
+execution:
+  provider: ollama
+  skills: [docx]
+steps:
+  - name: edit
+    run: "Polish {{input}}"
+`
+
+// writeConfig lays out a config directory and returns it with the loaded workflows
+func writeConfig(t *testing.T, workflows map[string]string) (string, *config.ApplicationConfig) {
+	t.Helper()
+	dir := t.TempDir()
+	appConfig := &config.ApplicationConfig{
+		Servers:   map[string]config.ServerConfig{},
+		Workflows: map[string]*config.WorkflowV2{},
+	}
+
+	loader := config.NewWorkflowLoader()
+	for key, content := range workflows {
+		file := filepath.Join(dir, "config", "workflows", key+".yaml")
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		wf, err := loader.LoadFromBytes([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		appConfig.Workflows[key] = wf
+	}
+	return dir, appConfig
+}
+
+func packResearch(t *testing.T) *PackResult {
+	t.Helper()
+	srcDir, srcConfig := writeConfig(t, map[string]string{"research": entryWorkflow, "polish": subWorkflow})
+	if err := os.MkdirAll(filepath.Join(srcDir, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "templates", "report.tmpl"), []byte("# Report"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Pack(srcConfig, "research", PackOptions{
+		ConfigDir:    srcDir,
+		WorkflowsDir: WorkflowsDir(filepath.Join(srcDir, "config.yaml")),
+		Output:       filepath.Join(t.TempDir(), "out", "research.tar.gz"),
+	})
+	if err != nil {
+		t.Fatalf("Pack() error: %v", err)
+	}
+	return result
+}
+
+func TestPackAndInstall(t *testing.T) {
+	result := packResearch(t)
+
+	m := result.Manifest
+	if m.Version != "1.2.0" {
+		t.Errorf("version = %q, want workflow version 1.2.0", m.Version)
+	}
+	if len(m.Workflows) != 2 {
+		t.Fatalf("packed %d workflows, want entry plus sub-workflow", len(m.Workflows))
+	}
+	if len(m.Files) != 1 || m.Files[0] != "templates/report.tmpl" {
+		t.Errorf("files = %v, want the render template", m.Files)
+	}
+	if strings.Join(m.Requires.Providers, ",") != "anthropic,ollama" {
+		t.Errorf("providers = %v", m.Requires.Providers)
+	}
+
+	packDir, cleanup, err := Open(context.Background(), result.Path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer cleanup()
+
+	dstDir, dstConfig := writeConfig(t, map[string]string{})
+	dstConfig.AI = &config.AIConfig{Providers: map[string]config.ProviderConfig{"ollama": {}}}
+
+	installed, err := Install(packDir, dstConfig, InstallOptions{
+		ConfigDir:    dstDir,
+		WorkflowsDir: filepath.Join(dstDir, "config", "workflows"),
+	})
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+
+	for _, rel := range []string{"config/workflows/research.yaml", "config/workflows/polish.yaml", "templates/report.tmpl"} {
+		if _, err := os.Stat(filepath.Join(dstDir, rel)); err != nil {
+			t.Errorf("%s not installed: %v", rel, err)
+		}
+	}
+
+	missing := installed.Missing
+	if strings.Join(missing.Providers, ",") != "anthropic" {
+		t.Errorf("missing providers = %v, want [anthropic]", missing.Providers)
+	}
+	if strings.Join(missing.Servers, ",") != "brave-search" || strings.Join(missing.Skills, ",") != "docx" {
+		t.Errorf("missing servers/skills = %v / %v", missing.Servers, missing.Skills)
+	}
+}
+
+func TestInstallCollision(t *testing.T) {
+	result := packResearch(t)
+
+	packDir, cleanup, err := Open(context.Background(), result.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	dstDir, dstConfig := writeConfig(t, map[string]string{"polish": strings.Replace(subWorkflow, "Polish", "Tidy", 1)})
+	opts := InstallOptions{ConfigDir: dstDir, WorkflowsDir: filepath.Join(dstDir, "config", "workflows")}
+
+	_, err = Install(packDir, dstConfig, opts)
+	if err == nil || !strings.Contains(err.Error(), "workflow polish") {
+		t.Fatalf("expected collision on polish, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dstDir, "config", "workflows", "research.yaml")); statErr == nil {
+		t.Error("nothing should be written when an install conflicts")
+	}
+
+	opts.Force = true
+	if _, err := Install(packDir, dstConfig, opts); err != nil {
+		t.Fatalf("Install(force) error: %v", err)
+	}
+}
+
+func TestManifestRejectsUnsafePaths(t *testing.T) {
+	for _, p := range []string{"../escape.yaml", "/etc/passwd", "a/../../b", `C:\x`} {
+		m := Manifest{Format: FormatV1, Name: "x", Workflows: []PackWorkflow{{Name: "x", File: p}}}
+		if err := m.Validate(); err == nil {
+			t.Errorf("Validate() accepted unsafe path %q", p)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package workflowpack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxPackSize caps downloaded and extracted pack size
+const maxPackSize = 50 << 20 // 50 MiB
+
+// Open makes a pack available as a local directory. source may be:
+//
+//	./research-1.0.0.tar.gz           local archive
+//	./packs/research                  local directory with manifest.yaml
+//	https://example.com/research.tar.gz
+//	git+https://github.com/org/packs.git#v1.2.0   (ref optional)
+//	https://github.com/org/packs.git
+//
+// A git checkout must contain manifest.yaml at its root or exactly one
+// .tar.gz archive. The returned cleanup function removes temporary files.
+func Open(ctx context.Context, source string) (dir string, cleanup func(), err error) {
+	cleanup = func() {}
+
+	switch {
+	case strings.HasPrefix(source, "git+") || (isURL(source) && strings.HasSuffix(stripRef(source), ".git")):
+		return openGit(ctx, strings.TrimPrefix(source, "git+"))
+	case isURL(source):
+		return openURL(ctx, source)
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", cleanup, fmt.Errorf("pack source %s: %w", source, err)
+	}
+	if info.IsDir() {
+		return source, cleanup, nil
+	}
+	return extractToTemp(source)
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+func stripRef(s string) string {
+	if idx := strings.LastIndex(s, "#"); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
+// openURL downloads an archive and extracts it
+func openURL(ctx context.Context, url string) (string, func(), error) {
+	noop := func() {}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", noop, fmt.Errorf("invalid pack URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to download pack: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", noop, fmt.Errorf("failed to download pack: %s", resp.Status)
+	}
+
+	tmp, err := os.CreateTemp("", "mcp-cli-pack-*.tar.gz")
+	if err != nil {
+		return "", noop, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, io.LimitReader(resp.Body, maxPackSize+1))
+	tmp.Close()
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to download pack: %w", err)
+	}
+	if n > maxPackSize {
+		return "", noop, fmt.Errorf("pack exceeds %d MiB", maxPackSize>>20)
+	}
+
+	return extractToTemp(tmp.Name())
+}
+
+// openGit clones a repository (shallow) and locates the pack inside it
+func openGit(ctx context.Context, repo string) (string, func(), error) {
+	noop := func() {}
+
+	ref := ""
+	if idx := strings.LastIndex(repo, "#"); idx >= 0 {
+		repo, ref = repo[:idx], repo[idx+1:]
+	}
+
+	tmpDir, err := os.MkdirTemp("", "mcp-cli-pack-git-*")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--", repo, tmpDir)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("git clone %s failed: %w\n%s", repo, err, strings.TrimSpace(string(out)))
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, ManifestFile)); err == nil {
+		return tmpDir, cleanup, nil
+	}
+
+	archives, _ := filepath.Glob(filepath.Join(tmpDir, "*.tar.gz"))
+	if len(archives) != 1 {
+		cleanup()
+		return "", noop, fmt.Errorf("repository %s has no %s and %d .tar.gz archives at its root (need exactly one)", repo, ManifestFile, len(archives))
+	}
+
+	dir, extractCleanup, err := extractToTemp(archives[0])
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	return dir, func() { extractCleanup(); cleanup() }, nil
+}
+
+// extractToTemp unpacks a .tar.gz archive into a new temporary directory,
+// rejecting entries that would escape it
+func extractToTemp(archivePath string) (string, func(), error) {
+	noop := func() {}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to open pack: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", noop, fmt.Errorf("pack is not a gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	dir, err := os.MkdirTemp("", "mcp-cli-pack-*")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			cleanup()
+			return "", noop, fmt.Errorf("failed to read pack: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(header.Name)
+		if !isSafeRelPath(name) {
+			cleanup()
+			return "", noop, fmt.Errorf("pack entry %q has an unsafe path", header.Name)
+		}
+
+		total += header.Size
+		if total > maxPackSize {
+			cleanup()
+			return "", noop, fmt.Errorf("pack exceeds %d MiB when extracted", maxPackSize>>20)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			cleanup()
+			return "", noop, err
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, header.Size))
+		if err != nil {
+			cleanup()
+			return "", noop, fmt.Errorf("failed to read pack entry %s: %w", name, err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			cleanup()
+			return "", noop, err
+		}
+	}
+
+	return dir, cleanup, nil
+}
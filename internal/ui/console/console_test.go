@@ -2,7 +2,9 @@ package console
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestColors(t *testing.T) {
@@ -110,3 +112,55 @@ func TestChatUIMetadata(t *testing.T) {
 	// Should still produce some output for session info
 	// but usage display would be skipped
 }
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{850 * time.Millisecond, "850ms"},
+		{4200 * time.Millisecond, "4.2s"},
+		{65 * time.Second, "1m05s"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatElapsed(tt.in); got != tt.want {
+			t.Errorf("FormatElapsed(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	SetColorsEnabled(false)
+	defer SetColorsEnabled(true)
+
+	var buf bytes.Buffer
+	spinner := NewSpinner("Thinking...")
+	spinner.SetWriter(&buf)
+	spinner.Start()
+	time.Sleep(20 * time.Millisecond)
+	spinner.Stop()
+	spinner.Stop() // Stopping twice must not block
+
+	if !strings.Contains(buf.String(), "Thinking...") {
+		t.Errorf("Expected spinner message in output, got %q", buf.String())
+	}
+	if spinner.Elapsed() <= 0 {
+		t.Error("Elapsed should be positive after start")
+	}
+}
+
+func TestSpinner_Disabled(t *testing.T) {
+	SetSpinnersEnabled(false)
+	defer SetSpinnersEnabled(true)
+
+	var buf bytes.Buffer
+	spinner := NewSpinner("Thinking...")
+	spinner.SetWriter(&buf)
+	spinner.Start()
+	spinner.Stop()
+
+	if buf.Len() != 0 {
+		t.Errorf("Disabled spinner should print nothing, got %q", buf.String())
+	}
+}
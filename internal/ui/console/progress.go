@@ -2,16 +2,31 @@ package console
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
-// Spinner represents a loading spinner
+// Spinner represents a loading spinner that shows the elapsed time
 type Spinner struct {
-	message string
-	frames  []string
-	running bool
-	done    chan bool
+	mu        sync.Mutex
+	message   string
+	frames    []string
+	writer    io.Writer
+	startTime time.Time
+	running   bool
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+var spinnersEnabled = true
+
+// SetSpinnersEnabled enables or disables spinner animation (quiet mode).
+// Disabled spinners print nothing.
+func SetSpinnersEnabled(enabled bool) {
+	spinnersEnabled = enabled
 }
 
 // NewSpinner creates a new spinner
@@ -19,35 +34,81 @@ func NewSpinner(message string) *Spinner {
 	return &Spinner{
 		message: message,
 		frames:  []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
-		done:    make(chan bool),
+		writer:  os.Stdout,
 	}
 }
 
+// SetWriter sets the output writer (default: stdout)
+func (s *Spinner) SetWriter(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writer = w
+}
+
 // Start starts the spinner
 func (s *Spinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.startTime = time.Now()
+	if !spinnersEnabled {
+		return
+	}
+
 	s.running = true
+	s.done = make(chan struct{})
+	s.stopped = make(chan struct{})
 
 	go func() {
-		i := 0
-		for s.running {
+		defer close(s.stopped)
+
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		for i := 0; ; i++ {
+			s.mu.Lock()
 			frame := s.frames[i%len(s.frames)]
-			fmt.Printf("\r%s %s", Cyan(frame), s.message)
+			fmt.Fprintf(s.writer, "\r\033[K%s %s %s", Cyan(frame), s.message, Dim(FormatElapsed(time.Since(s.startTime))))
+			s.mu.Unlock()
 
 			select {
 			case <-s.done:
 				return
-			case <-time.After(100 * time.Millisecond):
-				i++
+			case <-ticker.C:
 			}
 		}
 	}()
 }
 
-// Stop stops the spinner
+// Stop stops the spinner and clears its line
 func (s *Spinner) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
 	s.running = false
-	s.done <- true
-	fmt.Print("\r" + strings.Repeat(" ", len(s.message)+10) + "\r")
+	close(s.done)
+	s.mu.Unlock()
+
+	<-s.stopped
+
+	s.mu.Lock()
+	fmt.Fprint(s.writer, "\r\033[K")
+	s.mu.Unlock()
+}
+
+// Elapsed returns the time since the spinner was started
+func (s *Spinner) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.startTime.IsZero() {
+		return 0
+	}
+	return time.Since(s.startTime)
 }
 
 // Success stops the spinner with a success message
@@ -64,9 +125,23 @@ func (s *Spinner) Fail(message string) {
 
 // UpdateMessage updates the spinner message
 func (s *Spinner) UpdateMessage(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.message = message
 }
 
+// FormatElapsed formats a duration for progress display (e.g. "850ms", "4.2s", "1m05s")
+func FormatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	default:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+}
+
 // ProgressBar represents a progress bar
 type ProgressBar struct {
 	total   int
@@ -0,0 +1,41 @@
+package highlight
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// codeStyle matches the dracula theme used for chat markdown rendering
+const codeStyle = "dracula"
+
+// Code highlights source code for a 256-color terminal. The language is a
+// fence tag or lexer name; when empty or unknown it is guessed from the
+// content. Source that cannot be lexed is returned unchanged.
+func Code(source, language string) string {
+	var lexer chroma.Lexer
+	if language != "" {
+		lexer = lexers.Get(language)
+	}
+	if lexer == nil {
+		lexer = lexers.Analyse(source)
+	}
+	if lexer == nil {
+		return source
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, source)
+	if err != nil {
+		return source
+	}
+
+	var sb strings.Builder
+	if err := formatters.TTY256.Format(&sb, styles.Get(codeStyle), iterator); err != nil {
+		return source
+	}
+	return sb.String()
+}
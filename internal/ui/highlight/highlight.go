@@ -0,0 +1,106 @@
+// Package highlight adds terminal syntax highlighting to unified diffs and
+// fenced code blocks in tool results and workflow step output.
+package highlight
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/console"
+	"github.com/fatih/color"
+)
+
+// fencedBlockPattern matches ``` fenced code blocks, capturing the language and body
+var fencedBlockPattern = regexp.MustCompile("(?ms)^```([A-Za-z0-9_+.#-]*)[^\\n]*\\n(.*?)^```[ \\t]*$")
+
+// Enabled reports whether highlighting should be applied. It is disabled when
+// NO_COLOR is set or stdout is not a terminal.
+func Enabled() bool {
+	return !color.NoColor
+}
+
+// IsUnifiedDiff reports whether text looks like a unified diff
+func IsUnifiedDiff(text string) bool {
+	hasHeader := false
+	hasHunk := false
+
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "), strings.HasPrefix(line, "--- "):
+			hasHeader = true
+		case strings.HasPrefix(line, "@@ ") && strings.Contains(line[3:], " @@"):
+			hasHunk = true
+		}
+		if hasHeader && hasHunk {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Diff colors a unified diff: additions green, removals red, hunk headers cyan
+// and file headers bold
+func Diff(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "),
+			strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
+			lines[i] = colorize(line, console.ColorBold)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = colorize(line, console.ColorCyan)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = colorize(line, console.ColorGreen)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = colorize(line, console.ColorRed)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Render highlights unified diffs and fenced code blocks found in text.
+// Text without either is returned unchanged, as is everything when
+// highlighting is disabled.
+func Render(text string) string {
+	if !Enabled() {
+		return text
+	}
+	return render(text)
+}
+
+// render performs highlighting without checking whether it is enabled
+func render(text string) string {
+	if !strings.Contains(text, "```") {
+		if IsUnifiedDiff(text) {
+			return Diff(text)
+		}
+		return text
+	}
+
+	return fencedBlockPattern.ReplaceAllStringFunc(text, func(block string) string {
+		m := fencedBlockPattern.FindStringSubmatch(block)
+		language, body := m[1], m[2]
+
+		openFence := block[:strings.Index(block, "\n")+1]
+		closeFence := block[len(openFence)+len(body):]
+
+		var highlighted string
+		if strings.EqualFold(language, "diff") || strings.EqualFold(language, "patch") || IsUnifiedDiff(body) {
+			highlighted = Diff(body)
+		} else {
+			highlighted = Code(body, language)
+		}
+
+		return colorize(strings.TrimSuffix(openFence, "\n"), console.ColorDim) + "\n" +
+			highlighted + colorize(closeFence, console.ColorDim)
+	})
+}
+
+// colorize wraps a line in an ANSI color, leaving empty lines untouched
+func colorize(line, code string) string {
+	if line == "" {
+		return line
+	}
+	return code + line + console.ColorReset
+}
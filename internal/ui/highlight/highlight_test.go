@@ -0,0 +1,78 @@
+package highlight
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/ui/console"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-var x = 1
++var x = 2
+`
+
+func TestIsUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"git diff", sampleDiff, true},
+		{"plain diff", "--- a.txt\n+++ b.txt\n@@ -1 +1 @@\n-a\n+b\n", true},
+		{"bullet list", "- one\n- two\n+ three\n", false},
+		{"hunk without header", "@@ -1 +1 @@\n-a\n+b\n", false},
+		{"prose", "Nothing to see here", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnifiedDiff(tt.text); got != tt.want {
+				t.Errorf("IsUnifiedDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	out := Diff(sampleDiff)
+
+	if !strings.Contains(out, console.ColorGreen+"+var x = 2"+console.ColorReset) {
+		t.Error("Added line should be green")
+	}
+	if !strings.Contains(out, console.ColorRed+"-var x = 1"+console.ColorReset) {
+		t.Error("Removed line should be red")
+	}
+	if !strings.Contains(out, console.ColorBold+"--- a/main.go"+console.ColorReset) {
+		t.Error("File header should be bold, not red")
+	}
+	if !strings.Contains(out, console.ColorCyan+"@@ -1,3 +1,3 @@"+console.ColorReset) {
+		t.Error("Hunk header should be cyan")
+	}
+	if !strings.Contains(out, "\n package main\n") {
+		t.Error("Context lines should be unchanged")
+	}
+}
+
+func TestRender_FencedDiff(t *testing.T) {
+	text := "Here is the change:\n```diff\n-old\n+new\n```\nDone."
+	out := render(text)
+
+	if !strings.HasPrefix(out, "Here is the change:\n") || !strings.HasSuffix(out, "\nDone.") {
+		t.Errorf("Surrounding text should be preserved, got %q", out)
+	}
+	if !strings.Contains(out, console.ColorGreen+"+new"+console.ColorReset) {
+		t.Error("Fenced diff should be highlighted")
+	}
+}
+
+func TestRender_PlainText(t *testing.T) {
+	text := "Just a tool result\nwith two lines"
+	if out := render(text); out != text {
+		t.Errorf("Plain text should be unchanged, got %q", out)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/LaurieRhodes/mcp-cli-go/cmd"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/sandbox"
 )
 
 // Version information - set at build time
@@ -28,9 +30,24 @@ func main() {
 	// Commands are automatically set up in their respective init() functions
 	// and registered in cmd/root.go
 
+	restoreConsole := logging.EnableUTF8Console()
+
+	// Don't leave skill sandbox containers running if a command panics
+	defer func() {
+		if r := recover(); r != nil {
+			sandbox.Cleanup()
+			restoreConsole()
+			panic(r)
+		}
+	}()
+
 	// Execute the root command
-	if err := cmd.RootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	err := cmd.RootCmd.Execute()
+	sandbox.Cleanup()
+	restoreConsole()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		cmd.RecordFailure(err)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -0,0 +1,138 @@
+package mcpcli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/logging"
+	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/toolcontent"
+	skillsvc "github.com/LaurieRhodes/mcp-cli-go/internal/services/skills"
+)
+
+// DefaultConfigFile is the configuration loaded when Options.ConfigFile is empty
+const DefaultConfigFile = "config.yaml"
+
+// ErrClosed is returned by calls on a closed Client
+var ErrClosed = errors.New("mcpcli: client is closed")
+
+// Options configure a Client
+type Options struct {
+	// Configuration file, as passed to mcp-cli --config (default: config.yaml)
+	ConfigFile string
+
+	// Default provider and model for queries; empty uses the configuration's
+	// default. Workflows use their own execution settings.
+	Provider string
+	Model    string
+
+	// Where workflow and internal logs are written (default: discarded)
+	LogOutput io.Writer
+
+	// Workflow log level: error, warn, info, steps, debug or verbose
+	// (default: the workflow's own setting)
+	LogLevel string
+}
+
+// Client runs workflows, queries and tool calls against one configuration.
+// It keeps MCP server connections open between calls; Close stops them.
+// Methods may be called from multiple goroutines, but calls that use MCP
+// servers run one at a time because server connections are shared.
+type Client struct {
+	opts      Options
+	appConfig *config.ApplicationConfig
+
+	// mu serializes use of the shared server connections
+	mu      sync.Mutex
+	manager *host.ServerManager
+	skills  *skillsvc.Service
+	closed  bool
+}
+
+// New loads the configuration and returns a Client. No MCP servers are
+// started until a call needs them or Connect is called.
+func New(opts Options) (*Client, error) {
+	if opts.ConfigFile == "" {
+		opts.ConfigFile = DefaultConfigFile
+	}
+	if opts.LogOutput == nil {
+		opts.LogOutput = io.Discard
+	}
+	logging.SetOutput(opts.LogOutput)
+	if opts.LogOutput != os.Stderr {
+		logging.SetColorOutput(false)
+	}
+
+	appConfig, err := infraConfig.NewService().LoadConfig(opts.ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration %s: %w", opts.ConfigFile, err)
+	}
+	toolcontent.Configure(appConfig.Skills)
+
+	return &Client{
+		opts:      opts,
+		appConfig: appConfig,
+		manager:   host.NewServerManagerWithOptions(true),
+	}, nil
+}
+
+// Close stops the MCP servers the client started. The client can't be
+// used afterwards.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.manager.CloseConnections()
+	return nil
+}
+
+func (c *Client) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// ConfigFile returns the configuration file the client loaded
+func (c *Client) ConfigFile() string {
+	return c.opts.ConfigFile
+}
+
+// Workflows returns the names of the configured workflows, sorted
+func (c *Client) Workflows() []string {
+	names := c.appConfig.ListWorkflows()
+	sort.Strings(names)
+	return names
+}
+
+// ServerNames returns the names of the configured MCP servers, sorted
+func (c *Client) ServerNames() []string {
+	names := make([]string, 0, len(c.appConfig.Servers))
+	for name := range c.appConfig.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// skillService returns the built-in skills service, starting it on first use.
+// Callers hold c.mu.
+func (c *Client) skillService() (*skillsvc.Service, error) {
+	if c.skills == nil {
+		service, err := infraSkills.InitializeBuiltinSkills(c.opts.ConfigFile, c.appConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize built-in skills: %w", err)
+		}
+		c.skills = service
+	}
+	return c.skills, nil
+}
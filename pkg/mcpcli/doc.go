@@ -0,0 +1,23 @@
+// Package mcpcli embeds mcp-cli in other Go programs: load a configuration,
+// run workflows and queries, and call tools on MCP servers without
+// shelling out to the mcp-cli binary.
+//
+//	client, err := mcpcli.New(mcpcli.Options{ConfigFile: "config.yaml"})
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//
+//	result, err := client.RunWorkflow(ctx, mcpcli.WorkflowRequest{
+//		Name:  "summarize",
+//		Input: text,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(result.Output)
+//
+// The types in this package are its stable API. They deliberately copy,
+// rather than expose, the internal types they are built from, so that the
+// internal packages can change without breaking embedders.
+package mcpcli
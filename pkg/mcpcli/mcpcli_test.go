@@ -0,0 +1,84 @@
+package mcpcli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+ai:
+  default_provider: ollama
+  interfaces:
+    ollama_native:
+      providers:
+        ollama:
+          api_endpoint: http://localhost:11434
+          default_model: qwen2.5
+servers:
+  files:
+    command: ./files-server
+`
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testConfig), 0o644))
+
+	client, err := New(Options{ConfigFile: path})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestNewMissingConfig(t *testing.T) {
+	_, err := New(Options{ConfigFile: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
+
+func TestClientConfig(t *testing.T) {
+	client := newTestClient(t)
+	assert.Equal(t, []string{"files"}, client.ServerNames())
+	assert.Empty(t, client.Connected())
+
+	require.NoError(t, client.Close())
+	_, err := client.RunWorkflow(context.Background(), WorkflowRequest{Name: "any"})
+	assert.True(t, errors.Is(err, ErrClosed), "err = %v", err)
+}
+
+func TestRunWorkflowYAML(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	client := newTestClient(t)
+
+	result, err := client.RunWorkflow(context.Background(), WorkflowRequest{YAML: []byte(`
+$schema: "workflow/v2.0"
+name: sdk_test
+version: 1.0.0
+execution:
+  provider: ollama
+  model: qwen2.5
+steps:
+  - name: greet
+    verify:
+      command: echo hello
+      fail_on_error: true
+`)})
+	require.NoError(t, err)
+	assert.Equal(t, "sdk_test", result.Workflow)
+	assert.NotEmpty(t, result.RunID)
+	assert.Contains(t, result.Output, "hello")
+
+	_, err = client.RunWorkflow(context.Background(), WorkflowRequest{Name: "missing"})
+	assert.Error(t, err)
+	_, err = client.RunWorkflow(context.Background(), WorkflowRequest{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package mcpcli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/query"
+)
+
+// QueryRequest is a single question to a model, which may call tools on
+// MCP servers to answer it
+type QueryRequest struct {
+	Prompt string
+
+	// Provider and model; empty uses Options, then the configuration default
+	Provider string
+	Model    string
+
+	// Servers whose tools the model may call; "skills" adds the built-in
+	// skills. Built-in tools are always available unless settings turn
+	// them off.
+	Servers []string
+
+	SystemPrompt string // Empty uses the default assistant prompt
+	Context      string // Extra information added before the prompt
+	MaxTokens    int    // Response limit; 0 uses the provider default
+
+	// Maximum rounds of tool calls (default: 50)
+	MaxToolRounds int
+}
+
+// QueryResult is a model's answer
+type QueryResult struct {
+	Response  string
+	ToolCalls []ToolCall
+	Provider  string
+	Model     string
+	Duration  time.Duration
+}
+
+// ToolCall is one tool call a model made while answering
+type ToolCall struct {
+	Name      string
+	Arguments json.RawMessage
+	Result    string
+	Success   bool
+	Error     string
+	ErrorCode string // For example TOOL_PERMISSION_DENIED
+}
+
+// Message is one turn of a conversation
+type Message struct {
+	Role    string // user or assistant
+	Content string
+}
+
+// Query sends a prompt to a model and returns its answer after any tool
+// calls
+func (c *Client) Query(ctx context.Context, req QueryRequest) (*QueryResult, error) {
+	return c.query(ctx, req, nil)
+}
+
+func (c *Client) query(ctx context.Context, req QueryRequest, history []Message) (*QueryResult, error) {
+	if req.Prompt == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+
+	providerName, modelName := req.Provider, req.Model
+	if providerName == "" {
+		providerName = c.opts.Provider
+	}
+	if modelName == "" {
+		modelName = c.opts.Model
+	}
+
+	llmProvider, err := ai.NewService().InitializeProvider(c.opts.ConfigFile, providerName, modelName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AI provider: %w", err)
+	}
+	enhanced, err := host.GetEnhancedAIOptions(c.opts.ConfigFile, providerName, modelName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AI options: %w", err)
+	}
+	aiOptions := &host.AIOptions{
+		Provider:      enhanced.Provider,
+		Model:         enhanced.Model,
+		APIKey:        enhanced.APIKey,
+		APIEndpoint:   enhanced.APIEndpoint,
+		InterfaceType: enhanced.Interface,
+	}
+	if providerName != "" {
+		aiOptions.Provider = providerName
+	}
+	if modelName != "" {
+		aiOptions.Model = modelName
+	}
+
+	manager, err := c.serverManager(req.Servers)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := query.NewQueryHandlerWithServerManager(manager, llmProvider, aiOptions, req.SystemPrompt)
+	for _, msg := range history {
+		handler.ContextMessages = append(handler.ContextMessages, domain.Message{Role: msg.Role, Content: msg.Content})
+	}
+	if req.Context != "" {
+		handler.AddContext(req.Context)
+	}
+	if req.MaxTokens > 0 {
+		handler.SetMaxTokens(req.MaxTokens)
+	}
+	handler.SetMaxFollowUpAttempts(req.MaxToolRounds)
+
+	result, err := handler.Execute(req.Prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &QueryResult{
+		Response: result.Response,
+		Provider: result.Provider,
+		Model:    result.Model,
+		Duration: result.TimeTaken,
+	}
+	for _, call := range result.ToolCalls {
+		out.ToolCalls = append(out.ToolCalls, ToolCall{
+			Name:      call.Name,
+			Arguments: call.Arguments,
+			Result:    call.Result,
+			Success:   call.Success,
+			Error:     call.Error,
+			ErrorCode: call.ErrorCode,
+		})
+	}
+	return out, nil
+}
+
+// Conversation is a multi-turn chat with a model. Each Send includes the
+// earlier turns, so the model sees the whole conversation.
+type Conversation struct {
+	client   *Client
+	defaults QueryRequest
+	history  []Message
+}
+
+// NewConversation starts a conversation. defaults supplies the provider,
+// model, servers and system prompt of every turn; its Prompt is ignored.
+func (c *Client) NewConversation(defaults QueryRequest) *Conversation {
+	defaults.Prompt = ""
+	return &Conversation{client: c, defaults: defaults}
+}
+
+// Send adds a user message and returns the model's reply
+func (conv *Conversation) Send(ctx context.Context, message string) (*QueryResult, error) {
+	req := conv.defaults
+	req.Prompt = message
+	result, err := conv.client.query(ctx, req, conv.history)
+	if err != nil {
+		return nil, err
+	}
+	conv.history = append(conv.history,
+		Message{Role: "user", Content: message},
+		Message{Role: "assistant", Content: result.Response},
+	)
+	return result, nil
+}
+
+// History returns the turns so far
+func (conv *Conversation) History() []Message {
+	return append([]Message(nil), conv.history...)
+}
+
+// Reset forgets the turns so far
+func (conv *Conversation) Reset() {
+	conv.history = nil
+}
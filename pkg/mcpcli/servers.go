@@ -0,0 +1,150 @@
+package mcpcli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/builtintools"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/host"
+	infraSkills "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/skills"
+)
+
+// Tool is a tool offered to models: one from an MCP server (named
+// <server>_<tool>), a built-in skill tool or a built-in tool
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]interface{}
+}
+
+// Connect starts the named MCP servers, or all configured servers when no
+// names are given. Servers already running are kept. "skills" starts the
+// built-in skills service.
+func (c *Client) Connect(servers ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	if len(servers) == 0 {
+		servers = c.ServerNames()
+	}
+	_, err := c.serverManager(servers)
+	return err
+}
+
+// Connected returns the names of the running MCP servers, sorted, with
+// "skills" when the built-in skills service is running
+func (c *Client) Connected() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.connected()
+}
+
+func (c *Client) connected() []string {
+	var names []string
+	for _, conn := range c.manager.GetConnections() {
+		names = append(names, conn.Name)
+	}
+	if c.skills != nil {
+		names = append(names, "skills")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tools lists the tools available from the named servers (default: the
+// running ones) plus the built-in tools
+func (c *Client) Tools(ctx context.Context, servers ...string) ([]Tool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	manager, err := c.serverManager(c.serversOrConnected(servers))
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := manager.GetAvailableTools()
+	if err != nil {
+		return nil, err
+	}
+	tools := make([]Tool, 0, len(available))
+	for _, tool := range available {
+		tools = append(tools, Tool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: tool.Function.Parameters,
+		})
+	}
+	return tools, nil
+}
+
+// CallTool calls a tool by the name Tools reports and returns its text
+// result. Images and other binary content are saved to the outputs
+// directory and described in the text.
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return "", ErrClosed
+	}
+	manager, err := c.serverManager(c.connected())
+	if err != nil {
+		return "", err
+	}
+	return manager.ExecuteTool(ctx, name, arguments)
+}
+
+// serversOrConnected returns servers, or the running servers when empty
+func (c *Client) serversOrConnected(servers []string) []string {
+	if len(servers) > 0 {
+		return servers
+	}
+	return c.connected()
+}
+
+// serverManager starts any of the named servers that aren't running and
+// returns a manager for their tools, the built-in skills when "skills" is
+// named, and the built-in tools. It never returns a nil manager. Callers
+// hold c.mu.
+func (c *Client) serverManager(servers []string) (domain.MCPServerManager, error) {
+	externalServers, needsSkills := infraSkills.SeparateSkillsFromServers(servers)
+
+	conns := make([]*host.ServerConnection, 0, len(externalServers))
+	for _, name := range externalServers {
+		conn, err := c.manager.GetConnection(name)
+		if err != nil {
+			serverConfig, ok := c.appConfig.Servers[name]
+			if !ok {
+				return nil, fmt.Errorf("server '%s' is not configured", name)
+			}
+			conn, err = c.manager.ConnectToServer(name, serverConfig, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to server %s: %w", name, err)
+			}
+		}
+		conns = append(conns, conn)
+	}
+
+	var manager domain.MCPServerManager
+	if len(conns) > 0 {
+		manager = infraSkills.NewHostServerManager(conns)
+	}
+	if needsSkills {
+		skills, err := c.skillService()
+		if err != nil {
+			return nil, err
+		}
+		manager = infraSkills.NewSkillsAwareServerManager(manager, skills)
+	}
+	manager = builtintools.Wrap(manager, c.appConfig.BuiltinTools)
+	if manager == nil {
+		// No servers and built-in tools are off: a manager with no tools
+		manager = builtintools.NewServerManager(nil, nil)
+	}
+	return manager, nil
+}
@@ -0,0 +1,128 @@
+package mcpcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/LaurieRhodes/mcp-cli-go/internal/domain/config"
+	infraConfig "github.com/LaurieRhodes/mcp-cli-go/internal/infrastructure/config"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/providers/ai"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/embeddings"
+	"github.com/LaurieRhodes/mcp-cli-go/internal/services/workflow"
+)
+
+// WorkflowRequest runs a workflow, either one from the configuration by
+// name or one given as YAML
+type WorkflowRequest struct {
+	Name string // Workflow name, as in `mcp-cli --workflow`
+	YAML []byte // Workflow definition; used when Name is empty
+
+	Input  string            // Input data passed to the first step
+	Params map[string]string // Values for {{params.*}}
+
+	// Run only part of the workflow
+	StartFrom string
+	EndAt     string
+
+	// Receives the responses of steps with stream: true as they arrive
+	// (nil: not streamed)
+	Stream io.Writer
+}
+
+// WorkflowResult is the outcome of a workflow run
+type WorkflowResult struct {
+	Workflow string
+	RunID    string
+	Output   string // The aggregated matrix report, or the last step's output
+}
+
+// RunWorkflow runs a workflow to completion. The servers and skills its
+// steps use are started if they aren't running already.
+func (c *Client) RunWorkflow(ctx context.Context, req WorkflowRequest) (*WorkflowResult, error) {
+	if c.isClosed() {
+		return nil, ErrClosed
+	}
+	wf, key, err := c.resolveWorkflow(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := workflow.ValidateWorkflow(wf); err != nil {
+		return nil, fmt.Errorf("invalid workflow %s: %w", wf.Name, err)
+	}
+
+	// Embeddings steps read provider settings through the config service
+	configService := infraConfig.NewService()
+	if _, err := configService.LoadConfig(c.opts.ConfigFile); err != nil {
+		return nil, fmt.Errorf("failed to load AI provider config: %w", err)
+	}
+	embeddingService := embeddings.NewService(configService, ai.NewProviderFactory())
+
+	servers := workflow.RequiredServers(wf, c.appConfig.RAG)
+	if len(workflow.RequiredSkills(wf)) > 0 {
+		servers = append(servers, "skills")
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	manager, err := c.serverManager(servers)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	level := c.opts.LogLevel
+	if level == "" {
+		level = wf.ConsoleLogLevel()
+	}
+	logger := workflow.NewLogger(level, false)
+	logger.SetOutput(c.opts.LogOutput)
+
+	orchestrator := workflow.NewOrchestratorWithKey(wf, key, logger)
+	defer orchestrator.Close()
+	orchestrator.SetAppConfig(c.appConfig)
+	orchestrator.SetAppConfigForWorkflows(c.appConfig)
+	orchestrator.SetEmbeddingService(embeddingService)
+	orchestrator.SetServerManager(manager)
+	orchestrator.SetStartFrom(req.StartFrom)
+	orchestrator.SetEndAt(req.EndAt)
+	orchestrator.SetParams(req.Params)
+	if req.Stream != nil {
+		orchestrator.SetStreamOutput(req.Stream)
+	}
+
+	if err := orchestrator.Execute(ctx, req.Input); err != nil {
+		return nil, fmt.Errorf("workflow %s failed: %w", wf.Name, err)
+	}
+
+	output, _ := orchestrator.FinalOutput()
+	return &WorkflowResult{
+		Workflow: wf.Name,
+		RunID:    orchestrator.RunID(),
+		Output:   output,
+	}, nil
+}
+
+// resolveWorkflow returns a private copy of the requested workflow and the
+// key it's known by in the configuration
+func (c *Client) resolveWorkflow(req WorkflowRequest) (*config.WorkflowV2, string, error) {
+	if req.Name != "" {
+		wf, ok := c.appConfig.GetWorkflow(req.Name)
+		if !ok {
+			return nil, "", fmt.Errorf("workflow '%s' not found", req.Name)
+		}
+		copied := *wf
+		return &copied, req.Name, nil
+	}
+	if len(req.YAML) == 0 {
+		return nil, "", fmt.Errorf("workflow name or YAML is required")
+	}
+	wf, err := config.NewWorkflowLoader().LoadFromBytes(req.YAML)
+	if err != nil {
+		return nil, "", err
+	}
+	return wf, wf.Name, nil
+}